@@ -57,6 +57,29 @@ func (backupStatus *BackupStatus) SetAsCompleted() {
 	backupStatus.StoppedAt = ptr.To(metav1.Now())
 }
 
+// IsTerminated returns true if the verification has been completed,
+// successfully or not
+func (status *BackupVerificationStatus) IsTerminated() bool {
+	return status != nil &&
+		(status.Phase == BackupVerificationPhaseSucceeded || status.Phase == BackupVerificationPhaseFailed)
+}
+
+// SetAsSucceeded marks a certain backup verification as succeeded
+func (status *BackupVerificationStatus) SetAsSucceeded() {
+	status.Phase = BackupVerificationPhaseSucceeded
+	status.Error = ""
+	status.StoppedAt = ptr.To(metav1.Now())
+}
+
+// SetAsFailed marks a certain backup verification as failed
+func (status *BackupVerificationStatus) SetAsFailed(err error) {
+	status.Phase = BackupVerificationPhaseFailed
+	if err != nil {
+		status.Error = err.Error()
+	}
+	status.StoppedAt = ptr.To(metav1.Now())
+}
+
 // SetAsStarted marks a certain backup as started
 func (backupStatus *BackupStatus) SetAsStarted(podName, containerID string, method BackupMethod) {
 	backupStatus.Phase = BackupPhaseStarted
@@ -214,6 +237,19 @@ func (backup *Backup) GetAssignedInstance(ctx context.Context, cli client.Client
 	return &previouslyElectedPod, nil
 }
 
+// SupportsLevel checks if backups of the passed level can be taken
+// with this method. Full backups are supported by every method
+func (method BackupMethod) SupportsLevel(level BackupLevel) bool {
+	switch level {
+	case "", BackupLevelFull:
+		return true
+	case BackupLevelIncremental:
+		return method == BackupMethodPgBackRest || method == BackupMethodVolumeSnapshot
+	default:
+		return method == BackupMethodPgBackRest
+	}
+}
+
 // GetVolumeSnapshotConfiguration overrides the  configuration value with the ones specified
 // in the backup, if present.
 func (backup *Backup) GetVolumeSnapshotConfiguration(
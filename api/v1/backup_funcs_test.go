@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	"errors"
 	"time"
 
 	volumesnapshot "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
@@ -124,6 +125,37 @@ var _ = Describe("BackupStatus structure", func() {
 	})
 })
 
+var _ = Describe("BackupVerificationStatus structure", func() {
+	It("is not terminated while the verification is running", func() {
+		var status *BackupVerificationStatus
+		Expect(status.IsTerminated()).To(BeFalse())
+
+		status = &BackupVerificationStatus{Phase: BackupVerificationPhaseRunning}
+		Expect(status.IsTerminated()).To(BeFalse())
+	})
+
+	It("can be set as succeeded", func() {
+		status := &BackupVerificationStatus{
+			Phase: BackupVerificationPhaseRunning,
+			Error: "previous error",
+		}
+		status.SetAsSucceeded()
+		Expect(status.IsTerminated()).To(BeTrue())
+		Expect(status.Phase).To(Equal(BackupVerificationPhaseSucceeded))
+		Expect(status.Error).To(BeEmpty())
+		Expect(status.StoppedAt).ToNot(BeNil())
+	})
+
+	It("can be set as failed", func() {
+		status := &BackupVerificationStatus{Phase: BackupVerificationPhaseRunning}
+		status.SetAsFailed(errors.New("smoke query failed"))
+		Expect(status.IsTerminated()).To(BeTrue())
+		Expect(status.Phase).To(Equal(BackupVerificationPhaseFailed))
+		Expect(status.Error).To(Equal("smoke query failed"))
+		Expect(status.StoppedAt).ToNot(BeNil())
+	})
+})
+
 var _ = Describe("BackupList structure", func() {
 	It("can be sorted by name", func() {
 		backupList := BackupList{
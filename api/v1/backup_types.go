@@ -52,6 +52,27 @@ const (
 	BackupPhaseWalArchivingFailing = "walArchivingFailing"
 )
 
+// BackupVerificationPhase is the phase of the verification of a backup
+type BackupVerificationPhase string
+
+const (
+	// BackupVerificationPhasePending means that the verification Job
+	// has been created and is waiting to be started
+	BackupVerificationPhasePending BackupVerificationPhase = "pending"
+
+	// BackupVerificationPhaseRunning means that the backup is being
+	// restored in the throwaway instance
+	BackupVerificationPhaseRunning BackupVerificationPhase = "running"
+
+	// BackupVerificationPhaseSucceeded means that the backup has been
+	// restored and verified successfully
+	BackupVerificationPhaseSucceeded BackupVerificationPhase = "succeeded"
+
+	// BackupVerificationPhaseFailed means that the backup could not be
+	// restored or verified
+	BackupVerificationPhaseFailed BackupVerificationPhase = "failed"
+)
+
 // BarmanCredentials an object containing the potential credentials for each cloud provider
 // +kubebuilder:object:generate:=false
 type BarmanCredentials = barmanApi.BarmanCredentials
@@ -110,11 +131,33 @@ const (
 	// PostgreSQL cluster
 	BackupMethodBarmanObjectStore BackupMethod = "barmanObjectStore"
 
+	// BackupMethodPgBackRest means using pgBackRest to backup the
+	// PostgreSQL cluster
+	BackupMethodPgBackRest BackupMethod = "pgBackRest"
+
 	// BackupMethodPlugin means that this backup should be handled by
 	// a plugin
 	BackupMethodPlugin BackupMethod = "plugin"
 )
 
+// BackupLevel defines whether a base backup contains the whole data
+// directory or only the changes since a previous backup
+type BackupLevel string
+
+const (
+	// BackupLevelFull means that the backup contains the whole data
+	// directory
+	BackupLevelFull BackupLevel = "full"
+
+	// BackupLevelIncremental means that the backup only contains the
+	// changes since the previous backup, of any level
+	BackupLevelIncremental BackupLevel = "incremental"
+
+	// BackupLevelDifferential means that the backup only contains the
+	// changes since the last full backup
+	BackupLevelDifferential BackupLevel = "differential"
+)
+
 // BackupSpec defines the desired state of Backup
 type BackupSpec struct {
 	// The cluster to backup
@@ -122,18 +165,18 @@ type BackupSpec struct {
 
 	// The policy to decide which instance should perform this backup. If empty,
 	// it defaults to `cluster.spec.backup.target`.
-	// Available options are empty string, `primary` and `prefer-standby`.
-	// `primary` to have backups run always on primary instances,
+	// Available options are empty string, `primary`, `prefer-standby` and
+	// `standby`. `primary` to have backups run always on primary instances,
 	// `prefer-standby` to have backups run preferably on the most updated
-	// standby, if available.
+	// standby, if available, `standby` to have backups run only on a standby.
 	// +optional
-	// +kubebuilder:validation:Enum=primary;prefer-standby
+	// +kubebuilder:validation:Enum=primary;prefer-standby;standby
 	Target BackupTarget `json:"target,omitempty"`
 
 	// The backup method to be used, possible options are `barmanObjectStore`,
-	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
+	// `volumeSnapshot`, `pgBackRest` or `plugin`. Defaults to: `barmanObjectStore`.
 	// +optional
-	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin
+	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;pgBackRest;plugin
 	// +kubebuilder:default:=barmanObjectStore
 	Method BackupMethod `json:"method,omitempty"`
 
@@ -141,6 +184,19 @@ type BackupSpec struct {
 	// +optional
 	PluginConfiguration *BackupPluginConfiguration `json:"pluginConfiguration,omitempty"`
 
+	// The level of the backup, possible options are `full`, `incremental`
+	// and `differential`. Incremental backups are supported by the
+	// `pgBackRest` and `volumeSnapshot` methods, differential backups only
+	// by the `pgBackRest` method. pgBackRest chains them to the previous
+	// backup and to the last full backup respectively, while incremental
+	// volume snapshot backups are self-contained and only use the
+	// incremental snapshot class. If empty, it defaults to
+	// `cluster.spec.backup.pgBackRest.backupType` for the `pgBackRest`
+	// method and to `full` otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=full;incremental;differential
+	Level BackupLevel `json:"level,omitempty"`
+
 	// Whether the default type of backup with volume snapshots is
 	// online/hot (`true`, default) or offline/cold (`false`)
 	// Overrides the default setting specified in the cluster field '.spec.backup.volumeSnapshot.online'
@@ -289,9 +345,47 @@ type BackupStatus struct {
 	// +optional
 	Online *bool `json:"online,omitempty"`
 
+	// The level of the backup that has been taken
+	// +optional
+	Level BackupLevel `json:"level,omitempty"`
+
+	// The ID of the backup this one depends on. This is only set for
+	// incremental and differential backups, and the referenced backup
+	// is never expired by the retention policy while this one exists
+	// +optional
+	ParentBackupID string `json:"parentBackupId,omitempty"`
+
 	// A map containing the plugin metadata
 	// +optional
 	PluginMetadata map[string]string `json:"pluginMetadata,omitempty"`
+
+	// The result of the verification of this backup
+	// +optional
+	Verification *BackupVerificationStatus `json:"verification,omitempty"`
+}
+
+// BackupVerificationStatus is the result of the verification of a backup
+// in a throwaway instance
+type BackupVerificationStatus struct {
+	// The phase of the verification
+	// +optional
+	Phase BackupVerificationPhase `json:"phase,omitempty"`
+
+	// The name of the Job restoring the backup
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// When the verification was started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the verification was terminated
+	// +optional
+	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
+
+	// The detected error
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // InstanceID contains the information to identify an instance
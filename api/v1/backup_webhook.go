@@ -92,7 +92,8 @@ func (r *Backup) validate() field.ErrorList {
 		))
 	}
 
-	if r.Spec.Method == BackupMethodBarmanObjectStore && r.Spec.Online != nil {
+	if (r.Spec.Method == BackupMethodBarmanObjectStore || r.Spec.Method == BackupMethodPgBackRest) &&
+		r.Spec.Online != nil {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "online"),
 			r.Spec.Online,
@@ -100,7 +101,8 @@ func (r *Backup) validate() field.ErrorList {
 		))
 	}
 
-	if r.Spec.Method == BackupMethodBarmanObjectStore && r.Spec.OnlineConfiguration != nil {
+	if (r.Spec.Method == BackupMethodBarmanObjectStore || r.Spec.Method == BackupMethodPgBackRest) &&
+		r.Spec.OnlineConfiguration != nil {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "onlineConfiguration"),
 			r.Spec.OnlineConfiguration,
@@ -108,6 +110,15 @@ func (r *Backup) validate() field.ErrorList {
 		))
 	}
 
+	if !r.Spec.Method.SupportsLevel(r.Spec.Level) {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "level"),
+			r.Spec.Level,
+			"Incremental backups are supported only if the backup method is pgBackRest or volumeSnapshot, "+
+				"differential backups only if the backup method is pgBackRest",
+		))
+	}
+
 	if r.Spec.Method == BackupMethodPlugin && r.Spec.PluginConfiguration.IsEmpty() {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "pluginConfiguration"),
@@ -72,4 +72,38 @@ var _ = Describe("Backup webhook validate", func() {
 		Expect(result).To(HaveLen(1))
 		Expect(result[0].Field).To(Equal("spec.onlineConfiguration"))
 	})
+	It("complains if an incremental backup is requested with barman", func() {
+		backup := &Backup{
+			Spec: BackupSpec{
+				Method: BackupMethodBarmanObjectStore,
+				Level:  BackupLevelIncremental,
+			},
+		}
+		result := backup.validate()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.level"))
+	})
+
+	It("doesn't complain if an incremental backup is requested with volume snapshots", func() {
+		utils.SetVolumeSnapshot(true)
+		backup := &Backup{
+			Spec: BackupSpec{
+				Method: BackupMethodVolumeSnapshot,
+				Level:  BackupLevelIncremental,
+			},
+		}
+		result := backup.validate()
+		Expect(result).To(BeEmpty())
+	})
+
+	It("doesn't complain if a differential backup is requested with pgBackRest", func() {
+		backup := &Backup{
+			Spec: BackupSpec{
+				Method: BackupMethodPgBackRest,
+				Level:  BackupLevelDifferential,
+			},
+		}
+		result := backup.validate()
+		Expect(result).To(BeEmpty())
+	})
 })
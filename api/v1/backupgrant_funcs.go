@@ -0,0 +1,62 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "slices"
+
+// Allows checks if the clusters in the passed namespace are allowed
+// to be bootstrapped from the passed backup of the namespace of the grant
+func (grant *BackupGrant) Allows(namespace string, backupName string) bool {
+	if !slices.Contains(grant.Spec.Namespaces, namespace) {
+		return false
+	}
+
+	return len(grant.Spec.Backups) == 0 || slices.Contains(grant.Spec.Backups, backupName)
+}
+
+// FindGrant returns the first grant of the list allowing the clusters in
+// the passed namespace to be bootstrapped from the passed backup, or nil
+// if there is none
+func (list *BackupGrantList) FindGrant(namespace string, backupName string) *BackupGrant {
+	for idx := range list.Items {
+		if list.Items[idx].Allows(namespace, backupName) {
+			return &list.Items[idx]
+		}
+	}
+
+	return nil
+}
+
+// AllowsClone checks if the clusters in the passed namespace are allowed
+// to clone the passed cluster of the namespace of the grant
+func (grant *BackupGrant) AllowsClone(namespace string, clusterName string) bool {
+	return slices.Contains(grant.Spec.Namespaces, namespace) &&
+		slices.Contains(grant.Spec.Clusters, clusterName)
+}
+
+// FindCloneGrant returns the first grant of the list allowing the clusters
+// in the passed namespace to clone the passed cluster, or nil if there
+// is none
+func (list *BackupGrantList) FindCloneGrant(namespace string, clusterName string) *BackupGrant {
+	for idx := range list.Items {
+		if list.Items[idx].AllowsClone(namespace, clusterName) {
+			return &list.Items[idx]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup grants", func() {
+	grants := BackupGrantList{
+		Items: []BackupGrant{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+				Spec: BackupGrantSpec{
+					Namespaces: []string{"staging"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: BackupGrantSpec{
+					Namespaces: []string{"testing", "qa"},
+					Backups:    []string{"weekly"},
+				},
+			},
+		},
+	}
+
+	It("allows every backup when no backup is listed", func() {
+		Expect(grants.Items[0].Allows("staging", "weekly")).To(BeTrue())
+		Expect(grants.Items[0].Allows("staging", "daily")).To(BeTrue())
+		Expect(grants.Items[0].Allows("testing", "weekly")).To(BeFalse())
+	})
+
+	It("allows only the listed backups", func() {
+		Expect(grants.Items[1].Allows("qa", "weekly")).To(BeTrue())
+		Expect(grants.Items[1].Allows("qa", "daily")).To(BeFalse())
+	})
+
+	It("finds the grant allowing a backup", func() {
+		Expect(grants.FindGrant("testing", "weekly").Name).To(Equal("testing"))
+		Expect(grants.FindGrant("staging", "daily").Name).To(Equal("staging"))
+		Expect(grants.FindGrant("testing", "daily")).To(BeNil())
+		Expect(grants.FindGrant("production", "weekly")).To(BeNil())
+	})
+
+	It("allows cloning only the listed clusters", func() {
+		Expect(grants.Items[0].AllowsClone("staging", "cluster-example")).To(BeFalse())
+
+		grant := BackupGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "clones"},
+			Spec: BackupGrantSpec{
+				Namespaces: []string{"staging"},
+				Clusters:   []string{"cluster-example"},
+			},
+		}
+		Expect(grant.AllowsClone("staging", "cluster-example")).To(BeTrue())
+		Expect(grant.AllowsClone("staging", "cluster-other")).To(BeFalse())
+		Expect(grant.AllowsClone("testing", "cluster-example")).To(BeFalse())
+
+		list := BackupGrantList{Items: append([]BackupGrant{grant}, grants.Items...)}
+		Expect(list.FindCloneGrant("staging", "cluster-example").Name).To(Equal("clones"))
+		Expect(list.FindCloneGrant("qa", "cluster-example")).To(BeNil())
+	})
+
+	It("gets the key of the backup of a cluster", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "staging"},
+		}
+		Expect(cluster.GetRecoveryBackupKey()).To(BeNil())
+		Expect(cluster.IsRecoveringFromAnotherNamespace()).To(BeFalse())
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{
+			Recovery: &BootstrapRecovery{
+				Backup: &BackupSource{LocalObjectReference: LocalObjectReference{Name: "weekly"}},
+			},
+		}
+		Expect(cluster.GetRecoveryBackupKey()).To(Equal(&types.NamespacedName{Namespace: "staging", Name: "weekly"}))
+		Expect(cluster.IsRecoveringFromAnotherNamespace()).To(BeFalse())
+
+		cluster.Spec.Bootstrap.Recovery.Backup.Namespace = "production"
+		Expect(cluster.GetRecoveryBackupKey()).To(Equal(&types.NamespacedName{Namespace: "production", Name: "weekly"}))
+		Expect(cluster.IsRecoveringFromAnotherNamespace()).To(BeTrue())
+	})
+})
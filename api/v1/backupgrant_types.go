@@ -0,0 +1,72 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupGrantSpec defines which clusters are allowed to be bootstrapped
+// from the backups of the namespace of the BackupGrant
+type BackupGrantSpec struct {
+	// The namespaces of the clusters allowed to be bootstrapped from
+	// the backups of this namespace
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+
+	// The names of the backups that can be referenced. If empty, every
+	// backup of this namespace can be referenced
+	// +optional
+	Backups []string `json:"backups,omitempty"`
+
+	// The names of the clusters of this namespace that can be cloned.
+	// Unlike backups, clusters can be cloned only when explicitly listed
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BackupGrant allows the clusters of other namespaces to be bootstrapped
+// from the backups of the namespace where it is defined
+type BackupGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired behavior of the BackupGrant.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec BackupGrantSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupGrantList contains a list of BackupGrant
+type BackupGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of backup grants
+	Items []BackupGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupGrant{}, &BackupGrantList{})
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package v1
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"regexp"
 	"slices"
 	"strconv"
@@ -30,11 +34,13 @@ import (
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	validationutil "k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
@@ -71,14 +77,26 @@ func (o OnlineConfiguration) GetImmediateCheckpoint() bool {
 }
 
 // GetPluginConfigurationEnabledPluginNames gets the name of the plugins that are involved
-// in the reconciliation of this cluster
+// in the reconciliation of this cluster, in the order they are invoked
 func GetPluginConfigurationEnabledPluginNames(pluginList []PluginConfiguration) (result []string) {
-	pluginNames := make([]string, 0, len(pluginList))
+	enabledPlugins := make([]PluginConfiguration, 0, len(pluginList))
 	for _, pluginDeclaration := range pluginList {
 		if pluginDeclaration.IsEnabled() {
-			pluginNames = append(pluginNames, pluginDeclaration.Name)
+			enabledPlugins = append(enabledPlugins, pluginDeclaration)
 		}
 	}
+
+	slices.SortStableFunc(enabledPlugins, func(a, b PluginConfiguration) int {
+		if byPriority := cmp.Compare(a.GetPriority(), b.GetPriority()); byPriority != 0 {
+			return byPriority
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	pluginNames := make([]string, 0, len(enabledPlugins))
+	for _, pluginDeclaration := range enabledPlugins {
+		pluginNames = append(pluginNames, pluginDeclaration.Name)
+	}
 	return pluginNames
 }
 
@@ -207,6 +225,62 @@ func (r *ReplicationSlotsConfiguration) GetEnabled() bool {
 	return r.SynchronizeReplicas.GetEnabled() || r.HighAvailability.GetEnabled()
 }
 
+// GetEnabled returns true if the logical replication slots need to be synchronized
+func (r *SynchronizeLogicalSlotsConfiguration) GetEnabled() bool {
+	return r != nil && r.Enabled
+}
+
+// GetEnabled returns true if the replication slots safeguard is enabled
+func (r *ReplicationSlotsSafeguardConfiguration) GetEnabled() bool {
+	return r != nil && r.Enabled
+}
+
+// GetAction returns the action taken on the replication slots
+// exceeding the limits, defaulting to report
+func (r *ReplicationSlotsSafeguardConfiguration) GetAction() ReplicationSlotsSafeguardAction {
+	if r == nil || r.Action == "" {
+		return ReplicationSlotsSafeguardActionReport
+	}
+	return r.Action
+}
+
+// GetMaxRetainedWALBytes returns the maximum size, in bytes, of the WAL
+// files a replication slot can retain, or zero when not limited
+func (r *ReplicationSlotsSafeguardConfiguration) GetMaxRetainedWALBytes() int64 {
+	if r == nil || r.MaxRetainedWALSize == "" {
+		return 0
+	}
+
+	size, err := resource.ParseQuantity(r.MaxRetainedWALSize)
+	if err != nil {
+		return 0
+	}
+	return size.Value()
+}
+
+// GetMaxInactiveTime returns the maximum time a replication slot
+// can be inactive, or zero when not limited
+func (r *ReplicationSlotsSafeguardConfiguration) GetMaxInactiveTime() time.Duration {
+	if r == nil || r.MaxInactiveTime == nil {
+		return 0
+	}
+	return r.MaxInactiveTime.Duration
+}
+
+// IsAdditional returns true if the replication slot with the given name
+// is one of the additional replication slots managed by the operator
+func (r *ReplicationSlotsConfiguration) IsAdditional(slotName string) bool {
+	if r == nil {
+		return false
+	}
+	for _, slot := range r.Additional {
+		if slot.Name == slotName {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUpdateInterval returns the update interval, defaulting to DefaultReplicationSlotsUpdateInterval if empty
 func (r *ReplicationSlotsConfiguration) GetUpdateInterval() time.Duration {
 	if r == nil || r.UpdateInterval <= 0 {
@@ -314,6 +388,108 @@ func (s *StorageConfiguration) GetSizeOrNil() *resource.Quantity {
 	return nil
 }
 
+// IsAutoResizeEnabled checks whether the volumes should be automatically
+// expanded following the disk usage
+func (s *StorageConfiguration) IsAutoResizeEnabled() bool {
+	return s != nil && s.AutoResize != nil && s.AutoResize.Enabled
+}
+
+// WithAutoResizedSize returns a copy of the storage configuration whose size
+// is the one the volumes have been automatically expanded to, if larger
+// than the requested one
+func (s StorageConfiguration) WithAutoResizedSize(size string) StorageConfiguration {
+	if size == "" {
+		return s
+	}
+
+	autoResizedSize, err := resource.ParseQuantity(size)
+	if err != nil {
+		return s
+	}
+
+	if requestedSize := s.GetSizeOrNil(); requestedSize != nil && requestedSize.Cmp(autoResizedSize) >= 0 {
+		return s
+	}
+
+	s.Size = autoResizedSize.String()
+	return s
+}
+
+// GetThreshold gets the disk usage percentage triggering the expansion
+// of the volumes
+func (r *StorageAutoResizeConfiguration) GetThreshold() int32 {
+	if r == nil || r.Threshold == nil {
+		return 80
+	}
+	return *r.Threshold
+}
+
+// GetNextSize gets the size a volume should be expanded to, given its
+// current size. The result is capped to the maximum size, and it's equal
+// to the current size when the volume cannot be expanded anymore
+func (r *StorageAutoResizeConfiguration) GetNextSize(currentSize resource.Quantity) (resource.Quantity, error) {
+	step := "20%"
+	if r != nil && r.Step != "" {
+		step = r.Step
+	}
+
+	nextSize := currentSize.DeepCopy()
+	if percentage, found := strings.CutSuffix(step, "%"); found {
+		value, err := strconv.Atoi(percentage)
+		if err != nil || value <= 0 {
+			return currentSize, fmt.Errorf("invalid storage auto resize step: %q", step)
+		}
+		nextSize.Add(*resource.NewQuantity(currentSize.Value()*int64(value)/100, resource.BinarySI))
+	} else {
+		quantity, err := resource.ParseQuantity(step)
+		if err != nil || quantity.Sign() <= 0 {
+			return currentSize, fmt.Errorf("invalid storage auto resize step: %q", step)
+		}
+		nextSize.Add(quantity)
+	}
+
+	if r == nil || r.MaxSize == "" {
+		return nextSize, nil
+	}
+
+	maxSize, err := resource.ParseQuantity(r.MaxSize)
+	if err != nil {
+		return currentSize, fmt.Errorf("invalid storage auto resize maximum size: %q", r.MaxSize)
+	}
+	if nextSize.Cmp(maxSize) > 0 {
+		nextSize = maxSize
+	}
+	if nextSize.Cmp(currentSize) < 0 {
+		return currentSize, nil
+	}
+	return nextSize, nil
+}
+
+// GetPgDataSize gets the size the PGDATA volumes have been expanded to
+func (status *AutoResizedStorageStatus) GetPgDataSize() string {
+	if status == nil {
+		return ""
+	}
+	return status.PgData
+}
+
+// GetPgWalSize gets the size the WAL volumes have been expanded to
+func (status *AutoResizedStorageStatus) GetPgWalSize() string {
+	if status == nil {
+		return ""
+	}
+	return status.PgWal
+}
+
+// GetTablespaceSize gets the size the volumes of a tablespace have been
+// expanded to
+func (status *AutoResizedStorageStatus) GetTablespaceSize(tablespaceName string) string {
+	if status == nil {
+		return ""
+	}
+	return status.Tablespaces[tablespaceName]
+}
+
 // AreDefaultQueriesDisabled checks whether default monitoring queries should be disabled
 func (m *MonitoringConfiguration) AreDefaultQueriesDisabled() bool {
 	return m != nil && m.DisableDefaultQueries != nil && *m.DisableDefaultQueries
@@ -336,6 +512,26 @@ func (config *PluginConfiguration) IsEnabled() bool {
 	return *config.Enabled
 }
 
+// GetPriority returns the priority of this plugin, defaulting to 0
+func (config *PluginConfiguration) GetPriority() int32 {
+	if config.Priority == nil {
+		return 0
+	}
+	return *config.Priority
+}
+
+// GetPluginBackupRetention gets the retention policy of the backups
+// taken with the passed plugin, if it is enabled and has one
+func (cluster *Cluster) GetPluginBackupRetention(pluginName string) *VolumeSnapshotRetentionPolicy {
+	for idx := range cluster.Spec.Plugins {
+		plugin := &cluster.Spec.Plugins[idx]
+		if plugin.Name == pluginName && plugin.IsEnabled() {
+			return plugin.BackupRetention
+		}
+	}
+	return nil
+}
+
 // GetRoleSecretsName gets the name of the secret which is used to store the role's password
 func (roleConfiguration *RoleConfiguration) GetRoleSecretsName() string {
 	if roleConfiguration.PasswordSecret != nil {
@@ -386,6 +582,19 @@ func (cluster *Cluster) SetInContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, utils.ContextKeyCluster, cluster)
 }
 
+// IsDriftDetectionEnabled checks whether the manual changes to the
+// resources managed by the operator are detected and reported
+func (cluster *Cluster) IsDriftDetectionEnabled() bool {
+	return cluster.Spec.DriftDetection != nil
+}
+
+// ShouldRevertDrift checks whether the manual changes to the resources
+// managed by the operator should be reverted, which is the default
+func (cluster *Cluster) ShouldRevertDrift() bool {
+	return cluster.Spec.DriftDetection == nil ||
+		cluster.Spec.DriftDetection.Policy != DriftDetectionPolicyReport
+}
+
 // GetImageName get the name of the image that should be used
 // to create the pods
 func (cluster *Cluster) GetImageName() string {
@@ -422,6 +631,267 @@ func (cluster *Cluster) GetPostgresqlVersion() (version.Data, error) {
 	return version.FromTag(tag)
 }
 
+// GetMajorUpgradeMethod gets the method used to upgrade the PostgreSQL
+// major version of the cluster
+func (cluster *Cluster) GetMajorUpgradeMethod() MajorUpgradeMethod {
+	if cluster.Spec.MajorUpgrade == nil || cluster.Spec.MajorUpgrade.Method == "" {
+		return MajorUpgradeMethodPgUpgrade
+	}
+
+	return cluster.Spec.MajorUpgrade.Method
+}
+
+// GetMinorUpgradeStrategy gets the strategy used to roll out
+// the PostgreSQL minor version updates
+func (cluster *Cluster) GetMinorUpgradeStrategy() MinorUpgradeStrategy {
+	if cluster.Spec.MinorUpgrade == nil || cluster.Spec.MinorUpgrade.Strategy == "" {
+		return MinorUpgradeStrategyRolling
+	}
+
+	return cluster.Spec.MinorUpgrade.Strategy
+}
+
+// GetBackupMirrorMaxSpoolSize gets the maximum size, in bytes, of the WAL
+// files waiting to be copied to the secondary object store
+func (cluster *Cluster) GetBackupMirrorMaxSpoolSize() int64 {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Mirror == nil ||
+		cluster.Spec.Backup.Mirror.MaxSpoolSize == nil {
+		return 1024 * 1024 * 1024
+	}
+
+	return cluster.Spec.Backup.Mirror.MaxSpoolSize.Value()
+}
+
+// GetMinorUpgradeSoakPeriod gets how long the canary replica of a
+// minor version update needs to stay healthy
+func (cluster *Cluster) GetMinorUpgradeSoakPeriod() time.Duration {
+	if cluster.Spec.MinorUpgrade == nil || cluster.Spec.MinorUpgrade.SoakPeriod == nil {
+		return 5 * time.Minute
+	}
+
+	return cluster.Spec.MinorUpgrade.SoakPeriod.Duration
+}
+
+// IsPoolerDrainEnabled checks whether the PgBouncer poolers of the
+// cluster need to be paused before a switchover
+func (cluster *Cluster) IsPoolerDrainEnabled() bool {
+	return cluster.Spec.PoolerDrain != nil && cluster.Spec.PoolerDrain.Enabled
+}
+
+// GetPoolerDrainTimeout gets how long to wait for the PgBouncer poolers
+// to be paused before proceeding with a switchover
+func (cluster *Cluster) GetPoolerDrainTimeout() time.Duration {
+	if cluster.Spec.PoolerDrain == nil || cluster.Spec.PoolerDrain.Timeout == nil {
+		return 30 * time.Second
+	}
+
+	return cluster.Spec.PoolerDrain.Timeout.Duration
+}
+
+// AllowsPoolersFrom checks whether the Poolers of the given namespace
+// are allowed to reference this cluster
+func (cluster *Cluster) AllowsPoolersFrom(namespace string) bool {
+	return namespace == cluster.Namespace || slices.Contains(cluster.Spec.PoolerNamespaces, namespace)
+}
+
+// ShouldDeliverLifecycleEvent checks if the events of the given type
+// are to be delivered to the webhook or the NATS server of the cluster
+func (cluster *Cluster) ShouldDeliverLifecycleEvent(eventType LifecycleEventType) bool {
+	configuration := cluster.Spec.LifecycleEvents
+	if configuration == nil || (configuration.Webhook == nil && configuration.NATS == nil) {
+		return false
+	}
+
+	return len(configuration.Types) == 0 || slices.Contains(configuration.Types, eventType)
+}
+
+// GetLifecycleHooks gets the lifecycle hooks triggered by the given
+// event, in the order they need to be executed
+func (cluster *Cluster) GetLifecycleHooks(event LifecycleHookEvent) []LifecycleHook {
+	var hooks []LifecycleHook
+	for _, hook := range cluster.Spec.LifecycleHooks {
+		if hook.Event == event {
+			hooks = append(hooks, hook)
+		}
+	}
+
+	return hooks
+}
+
+// GetBootstrapLifecycleHookEvent gets the lifecycle hook event happening
+// when the primary instance is ready after the bootstrap of the cluster
+func (cluster *Cluster) GetBootstrapLifecycleHookEvent() LifecycleHookEvent {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.InitDB != nil {
+		return LifecycleHookEventPostInit
+	}
+
+	return LifecycleHookEventPostRecovery
+}
+
+// GetTimeout gets how long the hook can run before being considered failed
+func (hook *LifecycleHook) GetTimeout() time.Duration {
+	if hook.Timeout == nil {
+		return 30 * time.Second
+	}
+
+	return hook.Timeout.Duration
+}
+
+// GetFailurePolicy gets the behavior of the operator when the hook fails
+func (hook *LifecycleHook) GetFailurePolicy() LifecycleHookFailurePolicy {
+	if hook.FailurePolicy == "" {
+		return LifecycleHookFailurePolicyIgnore
+	}
+
+	return hook.FailurePolicy
+}
+
+// IsBootstrappedWithMigration checks if the cluster is bootstrapped
+// migrating the databases of an external cluster
+func (cluster *Cluster) IsBootstrappedWithMigration() bool {
+	return cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.Migration != nil
+}
+
+// IsBootstrappedWithClone checks if the cluster is bootstrapped
+// cloning another cluster managed by the operator
+func (cluster *Cluster) IsBootstrappedWithClone() bool {
+	return cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.Clone != nil
+}
+
+// GetCloneSecretName gets the name of the secret containing the
+// certificates used to connect to the cluster being cloned
+func (cluster *Cluster) GetCloneSecretName() string {
+	return cluster.Name + CloneSecretSuffix
+}
+
+// GetPodSpecPreviewName gets the name of the ConfigMap containing
+// the preview of the instance pod spec
+func (cluster *Cluster) GetPodSpecPreviewName() string {
+	return cluster.Name + PodSpecPreviewSuffix
+}
+
+// GetCloneSourceNamespace gets the namespace of the cluster being cloned
+func (cluster *Cluster) GetCloneSourceNamespace() string {
+	if !cluster.IsBootstrappedWithClone() || cluster.Spec.Bootstrap.Clone.Namespace == "" {
+		return cluster.Namespace
+	}
+
+	return cluster.Spec.Bootstrap.Clone.Namespace
+}
+
+// GetCloneSource gets the connection to the primary of the cluster
+// being cloned, authenticated with its streaming replication certificate
+func (cluster *Cluster) GetCloneSource() (ExternalCluster, bool) {
+	if !cluster.IsBootstrappedWithClone() {
+		return ExternalCluster{}, false
+	}
+
+	clone := cluster.Spec.Bootstrap.Clone
+	secretName := cluster.GetCloneSecretName()
+	return ExternalCluster{
+		Name: clone.Cluster,
+		ConnectionParameters: map[string]string{
+			"host": fmt.Sprintf("%v%v.%v.svc",
+				clone.Cluster, ServiceReadWriteSuffix, cluster.GetCloneSourceNamespace()),
+			"user":    StreamingReplicationUser,
+			"dbname":  "postgres",
+			"sslmode": "verify-full",
+		},
+		SSLCert: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  corev1.TLSCertKey,
+		},
+		SSLKey: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  corev1.TLSPrivateKeyKey,
+		},
+		SSLRootCert: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  "ca.crt",
+		},
+	}, true
+}
+
+// GetPgBaseBackupSource gets the server from which the cluster is
+// bootstrapped taking a physical backup
+func (cluster *Cluster) GetPgBaseBackupSource() (ExternalCluster, bool) {
+	if cluster.IsBootstrappedWithClone() {
+		return cluster.GetCloneSource()
+	}
+
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.PgBaseBackup == nil {
+		return ExternalCluster{}, false
+	}
+
+	return cluster.ExternalCluster(cluster.Spec.Bootstrap.PgBaseBackup.Source)
+}
+
+// GetDataMasking gets the data masking configuration of the bootstrap
+// method, if any
+func (cluster *Cluster) GetDataMasking() *DataMaskingConfiguration {
+	bootstrap := cluster.Spec.Bootstrap
+	switch {
+	case bootstrap == nil:
+		return nil
+	case bootstrap.Recovery != nil:
+		return bootstrap.Recovery.Masking
+	case bootstrap.PgBaseBackup != nil:
+		return bootstrap.PgBaseBackup.Masking
+	case bootstrap.Clone != nil:
+		return bootstrap.Clone.Masking
+	default:
+		return nil
+	}
+}
+
+// GetDataMaskingDatabase gets the database where the data masking SQL
+// is run, defaulting to the application database
+func (cluster *Cluster) GetDataMaskingDatabase() string {
+	if masking := cluster.GetDataMasking(); masking != nil && masking.Database != "" {
+		return masking.Database
+	}
+
+	if database := cluster.GetApplicationDatabaseName(); database != "" {
+		return database
+	}
+
+	return DefaultApplicationDatabaseName
+}
+
+// ShouldRunDataMaskingSQLRefs returns true if the bootstrap job needs to
+// run the data masking SQL files from the provided references
+func (cluster *Cluster) ShouldRunDataMaskingSQLRefs() bool {
+	masking := cluster.GetDataMasking()
+	return masking != nil && masking.SQLRefs.HasElements()
+}
+
+// GetImportBootstrap gets the initdb configuration importing the schema of
+// the databases to be migrated, which is used to bootstrap the cluster
+func (migration *BootstrapMigration) GetImportBootstrap() *BootstrapInitDB {
+	return &BootstrapInitDB{
+		Encoding:      "UTF8",
+		LocaleCollate: "C",
+		LocaleCType:   "C",
+		Import: &Import{
+			Source: ImportSource{
+				ExternalCluster: migration.Source,
+			},
+			Type:                  MonolithSnapshotType,
+			Databases:             slices.Clone(migration.Databases),
+			Roles:                 slices.Clone(migration.Roles),
+			SchemaOnly:            true,
+			PgDumpExtraOptions:    slices.Clone(migration.PgDumpExtraOptions),
+			PgRestoreExtraOptions: slices.Clone(migration.PgRestoreExtraOptions),
+		},
+	}
+}
+
+// ShouldFenceSource checks if the applications need to be disconnected
+// from the external cluster during the cutover
+func (migration *BootstrapMigration) ShouldFenceSource() bool {
+	return migration.FenceSource == nil || *migration.FenceSource
+}
+
 // GetImagePullSecret get the name of the pull secret to use
 // to download the PostgreSQL image
 func (cluster *Cluster) GetImagePullSecret() string {
@@ -516,6 +986,11 @@ func (cluster *Cluster) GetApplicationSecretName() string {
 		return pgBaseBackup.Secret.Name
 	}
 
+	clone := bootstrap.Clone
+	if clone != nil && clone.Secret != nil && clone.Secret.Name != "" {
+		return clone.Secret.Name
+	}
+
 	initDB := bootstrap.InitDB
 	if initDB != nil && initDB.Secret != nil && initDB.Secret.Name != "" {
 		return initDB.Secret.Name
@@ -539,6 +1014,10 @@ func (cluster *Cluster) GetApplicationDatabaseName() string {
 		return bootstrap.PgBaseBackup.Database
 	}
 
+	if bootstrap.Clone != nil && bootstrap.Clone.Database != "" {
+		return bootstrap.Clone.Database
+	}
+
 	if bootstrap.InitDB != nil && bootstrap.InitDB.Database != "" {
 		return bootstrap.InitDB.Database
 	}
@@ -561,6 +1040,10 @@ func (cluster *Cluster) GetApplicationDatabaseOwner() string {
 		return bootstrap.PgBaseBackup.Owner
 	}
 
+	if bootstrap.Clone != nil && bootstrap.Clone.Owner != "" {
+		return bootstrap.Clone.Owner
+	}
+
 	if bootstrap.InitDB != nil && bootstrap.InitDB.Owner != "" {
 		return bootstrap.InitDB.Owner
 	}
@@ -574,25 +1057,465 @@ func (cluster *Cluster) GetServerCASecretName() string {
 	if cluster.Spec.Certificates != nil && cluster.Spec.Certificates.ServerCASecret != "" {
 		return cluster.Spec.Certificates.ServerCASecret
 	}
-	return fmt.Sprintf("%v%v", cluster.Name, DefaultServerCaSecretSuffix)
+	return fmt.Sprintf("%v%v", cluster.Name, DefaultServerCaSecretSuffix)
+}
+
+// GetServerTLSSecretName get the name of the secret containing the
+// certificate that is used for the PostgreSQL servers
+func (cluster *Cluster) GetServerTLSSecretName() string {
+	if cluster.Spec.Certificates != nil && cluster.Spec.Certificates.ServerTLSSecret != "" {
+		return cluster.Spec.Certificates.ServerTLSSecret
+	}
+	return fmt.Sprintf("%v%v", cluster.Name, ServerSecretSuffix)
+}
+
+// GetClientCASecretName get the name of the secret containing the CA
+// of the cluster
+func (cluster *Cluster) GetClientCASecretName() string {
+	if cluster.Spec.Certificates != nil && cluster.Spec.Certificates.ClientCASecret != "" {
+		return cluster.Spec.Certificates.ClientCASecret
+	}
+	return fmt.Sprintf("%v%v", cluster.Name, ClientCaSecretSuffix)
+}
+
+// GetCertificateProvider gets the external provider issuing the
+// certificates of the cluster, or nil when the certificates are
+// issued by the operator
+func (cluster *Cluster) GetCertificateProvider() *CertificateProviderConfiguration {
+	if cluster.Spec.Certificates == nil {
+		return nil
+	}
+	return cluster.Spec.Certificates.Provider
+}
+
+// GetSecurityTLSMode gets the TLS enforcement mode of the cluster,
+// or an empty string when TLS is not enforced
+func (cluster *Cluster) GetSecurityTLSMode() SecurityTLSMode {
+	if cluster.Spec.Security == nil {
+		return ""
+	}
+	return cluster.Spec.Security.TLSMode
+}
+
+// GetLogSinks gets the external sinks where the PostgreSQL log
+// records are forwarded
+func (cluster *Cluster) GetLogSinks() []LogSink {
+	if cluster.Spec.Logging == nil {
+		return nil
+	}
+	return cluster.Spec.Logging.Sinks
+}
+
+// GetPostgresParameters gets the PostgreSQL parameters of the cluster,
+// including the ones generated from the declarative audit configuration,
+// the `pg_stat_statements` ones generated from the query insights
+// configuration, the ones of the autovacuum profile and the ones derived
+// from the resources of the pods, including the huge pages, unless they
+// are specified by the user
+func (cluster *Cluster) GetPostgresParameters() map[string]string {
+	audit := cluster.Spec.PostgresConfiguration.Audit
+	queryInsights := cluster.GetQueryInsights()
+	autovacuumProfile := cluster.Spec.PostgresConfiguration.AutovacuumProfile
+	autoTune := cluster.Spec.PostgresConfiguration.AutoTune
+	hugePagesParameters := cluster.GetHugePagesParameters()
+	if audit == nil && queryInsights == nil && autovacuumProfile == "" && !autoTune && hugePagesParameters == nil {
+		return cluster.Spec.PostgresConfiguration.Parameters
+	}
+
+	result := make(map[string]string, len(cluster.Spec.PostgresConfiguration.Parameters)+7)
+	maps.Copy(result, hugePagesParameters)
+	if autoTune {
+		for key, value := range cluster.GetAutoTuneParameters() {
+			result[key] = value
+		}
+	}
+	for key, value := range autovacuumProfile.GetParameters() {
+		result[key] = value
+	}
+	if queryInsights != nil {
+		for key, value := range queryInsights.GetParameters() {
+			result[key] = value
+		}
+	}
+	for key, value := range cluster.Spec.PostgresConfiguration.Parameters {
+		result[key] = value
+	}
+	if audit != nil {
+		for key, value := range audit.GetParameters() {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// IsWALSpaceProtectionEnabled checks whether the volume containing the
+// WAL files should be protected from running out of space
+func (cluster *Cluster) IsWALSpaceProtectionEnabled() bool {
+	return cluster.Spec.WALSpaceProtection != nil && cluster.Spec.WALSpaceProtection.Enabled
+}
+
+// GetThreshold gets the disk usage percentage of the volume containing the
+// WAL files making the primary instance enter the protective mode
+func (configuration *WALSpaceProtectionConfiguration) GetThreshold() int32 {
+	if configuration == nil || configuration.Threshold == nil {
+		return 85
+	}
+	return *configuration.Threshold
+}
+
+// GetAutoTuneParameters gets the PostgreSQL parameters derived from the
+// resources of the instances. The memory parameters are derived from the
+// memory request, which is guaranteed to the pods, falling back to the
+// limit. The parallelism parameters are derived from the CPU limit,
+// falling back to the request. Nothing is derived from the resources
+// that are not specified
+func (cluster *Cluster) GetAutoTuneParameters() map[string]string {
+	result := make(map[string]string, 8)
+
+	resources := cluster.GetResources()
+	memory := resources.Requests.Memory()
+	if memory.IsZero() {
+		memory = resources.Limits.Memory()
+	}
+	if memoryMB := memory.Value() / (1024 * 1024); memoryMB > 0 {
+		maxConnections := int64(100)
+		if value, err := strconv.ParseInt(
+			cluster.Spec.PostgresConfiguration.Parameters["max_connections"], 10, 64); err == nil && value > 0 {
+			maxConnections = value
+		}
+
+		// The shared memory must fit in the huge pages, if requested,
+		// leaving room for the structures other than the shared buffers
+		sharedBuffersMB := memoryMB / 4
+		if name, hugePages := cluster.GetHugePages(); name != "" {
+			sharedBuffersMB = min(sharedBuffersMB, hugePages.Value()/(1024*1024)*9/10)
+		}
+		result["shared_buffers"] = fmt.Sprintf("%dMB", max(sharedBuffersMB, 1))
+		result["effective_cache_size"] = fmt.Sprintf("%dMB", max(memoryMB*3/4, 1))
+		result["maintenance_work_mem"] = fmt.Sprintf("%dMB", min(max(memoryMB/16, 64), 2048))
+		result["work_mem"] = fmt.Sprintf("%dMB", max((memoryMB-sharedBuffersMB)/(maxConnections*3), 4))
+	}
+
+	cpu := resources.Limits.Cpu()
+	if cpu.IsZero() {
+		cpu = resources.Requests.Cpu()
+	}
+	if cpus := (cpu.MilliValue() + 999) / 1000; cpus > 0 {
+		parallelWorkers := min(max(cpus/2, 1), 4)
+		result["max_worker_processes"] = strconv.FormatInt(max(cpus, 8), 10)
+		result["max_parallel_workers"] = strconv.FormatInt(cpus, 10)
+		result["max_parallel_workers_per_gather"] = strconv.FormatInt(parallelWorkers, 10)
+		result["max_parallel_maintenance_workers"] = strconv.FormatInt(parallelWorkers, 10)
+	}
+
+	return result
+}
+
+// GetHugePages gets the name and the quantity of the huge pages resource
+// requested by the instances, whose name contains the size of the pages.
+// The name is empty when the instances don't request huge pages
+func (cluster *Cluster) GetHugePages() (corev1.ResourceName, resource.Quantity) {
+	limits := cluster.GetResources().Limits
+	for _, name := range slices.Sorted(maps.Keys(limits)) {
+		if strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix) {
+			return name, limits[name]
+		}
+	}
+	return "", resource.Quantity{}
+}
+
+// GetHugePagesParameters gets the PostgreSQL parameters allocating the
+// shared memory on the huge pages requested by the instances. PostgreSQL
+// is required to use the huge pages, and the size of the pages is set
+// when it's not the default one of 2MB
+func (cluster *Cluster) GetHugePagesParameters() map[string]string {
+	name, _ := cluster.GetHugePages()
+	if name == "" {
+		return nil
+	}
+
+	result := map[string]string{"huge_pages": "on"}
+	pageSize, err := resource.ParseQuantity(strings.TrimPrefix(string(name), corev1.ResourceHugePagesPrefix))
+	if err == nil && pageSize.Value() != 2*1024*1024 {
+		result["huge_page_size"] = fmt.Sprintf("%dkB", pageSize.Value()/1024)
+	}
+	return result
+}
+
+// GetParameters gets the autovacuum parameters of the profile
+func (profile AutovacuumProfile) GetParameters() map[string]string {
+	switch profile {
+	case AutovacuumProfileOLTP:
+		return map[string]string{
+			"autovacuum_naptime":              "30s",
+			"autovacuum_vacuum_scale_factor":  "0.05",
+			"autovacuum_analyze_scale_factor": "0.02",
+			"autovacuum_vacuum_cost_limit":    "1000",
+		}
+	case AutovacuumProfileAnalytics:
+		return map[string]string{
+			"autovacuum_naptime":              "1min",
+			"autovacuum_vacuum_scale_factor":  "0.2",
+			"autovacuum_analyze_scale_factor": "0.05",
+			"autovacuum_vacuum_cost_limit":    "2000",
+		}
+	case AutovacuumProfileHighChurn:
+		return map[string]string{
+			"autovacuum_naptime":              "15s",
+			"autovacuum_vacuum_scale_factor":  "0.01",
+			"autovacuum_analyze_scale_factor": "0.01",
+			"autovacuum_vacuum_cost_limit":    "2000",
+			"autovacuum_vacuum_cost_delay":    "1ms",
+		}
+	default:
+		return nil
+	}
+}
+
+// GetQueryInsights gets the query insights configuration of the
+// cluster, or nil when the query insights are not enabled
+func (cluster *Cluster) GetQueryInsights() *QueryInsightsConfiguration {
+	if cluster.Spec.Monitoring == nil || cluster.Spec.Monitoring.QueryInsights == nil ||
+		!cluster.Spec.Monitoring.QueryInsights.Enabled {
+		return nil
+	}
+	return cluster.Spec.Monitoring.QueryInsights
+}
+
+// GetParameters gets the `pg_stat_statements.*` parameters
+// corresponding to the query insights configuration
+func (queryInsights *QueryInsightsConfiguration) GetParameters() map[string]string {
+	track := queryInsights.Track
+	if track == "" {
+		track = QueryInsightsTrackTop
+	}
+
+	result := map[string]string{
+		"pg_stat_statements.track": string(track),
+	}
+	if queryInsights.MaxStatements != nil {
+		result["pg_stat_statements.max"] = strconv.Itoa(int(*queryInsights.MaxStatements))
+	}
+	return result
+}
+
+// GetTopN gets the number of statements of every database
+// included in the snapshots
+func (queryInsights *QueryInsightsConfiguration) GetTopN() int {
+	if queryInsights.TopN <= 0 {
+		return 10
+	}
+	return queryInsights.TopN
+}
+
+// GetSnapshotInterval gets the interval between two snapshots
+// of the top statements
+func (queryInsights *QueryInsightsConfiguration) GetSnapshotInterval() time.Duration {
+	if queryInsights.SnapshotInterval == nil || queryInsights.SnapshotInterval.Duration <= 0 {
+		return time.Minute
+	}
+	return queryInsights.SnapshotInterval.Duration
+}
+
+// GetDatabase gets the database containing the query history table
+func (history *QueryInsightsHistory) GetDatabase() string {
+	if history.Database == "" {
+		return "postgres"
+	}
+	return history.Database
+}
+
+// GetRetention gets how long the snapshots are kept in the
+// query history table
+func (history *QueryInsightsHistory) GetRetention() time.Duration {
+	if history.Retention == nil || history.Retention.Duration <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return history.Retention.Duration
+}
+
+// GetTableStatistics gets the configuration of the metrics about
+// the largest tables, or nil if not enabled
+func (cluster *Cluster) GetTableStatistics() *TableStatisticsConfiguration {
+	if cluster.Spec.Monitoring == nil || cluster.Spec.Monitoring.TableStatistics == nil ||
+		!cluster.Spec.Monitoring.TableStatistics.Enabled {
+		return nil
+	}
+	return cluster.Spec.Monitoring.TableStatistics
+}
+
+// GetTableStatisticsDatabases gets the databases whose tables are
+// sampled, defaulting to the application database
+func (cluster *Cluster) GetTableStatisticsDatabases() []string {
+	tableStatistics := cluster.GetTableStatistics()
+	if tableStatistics == nil {
+		return nil
+	}
+	if len(tableStatistics.Databases) > 0 {
+		return tableStatistics.Databases
+	}
+	if database := cluster.GetApplicationDatabaseName(); database != "" {
+		return []string{database}
+	}
+	return []string{"postgres"}
+}
+
+// GetTopN gets the number of largest tables of every database
+// whose metrics are exposed
+func (tableStatistics *TableStatisticsConfiguration) GetTopN() int {
+	if tableStatistics.TopN <= 0 {
+		return 20
+	}
+	return tableStatistics.TopN
+}
+
+// GetSamplingInterval gets the interval between two samples
+// of the table statistics
+func (tableStatistics *TableStatisticsConfiguration) GetSamplingInterval() time.Duration {
+	if tableStatistics.SamplingInterval == nil || tableStatistics.SamplingInterval.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return tableStatistics.SamplingInterval.Duration
+}
+
+// GetOpenTelemetry gets the configuration of the export of the
+// instance manager telemetry, or nil if not enabled
+func (cluster *Cluster) GetOpenTelemetry() *OpenTelemetryConfiguration {
+	if cluster.Spec.Monitoring == nil {
+		return nil
+	}
+	return cluster.Spec.Monitoring.OpenTelemetry
+}
+
+// GetSampleRatio gets the ratio, between 0 and 1, of the sampled traces
+func (openTelemetry *OpenTelemetryConfiguration) GetSampleRatio() float64 {
+	if openTelemetry.SamplingPercentage == nil {
+		return 1
+	}
+	return float64(*openTelemetry.SamplingPercentage) / 100
+}
+
+// GetAlerts gets the configuration of the alerts generated for the cluster
+func (cluster *Cluster) GetAlerts() *AlertsConfiguration {
+	if cluster.Spec.Monitoring == nil {
+		return nil
+	}
+	return cluster.Spec.Monitoring.Alerts
+}
+
+// IsPrometheusRuleEnabled checks if the PrometheusRule object needs to be created
+func (cluster *Cluster) IsPrometheusRuleEnabled() bool {
+	alerts := cluster.GetAlerts()
+	return alerts != nil && alerts.Enabled
+}
+
+// GetFor gets how long a condition must hold before the alert fires
+func (alerts *AlertsConfiguration) GetFor() time.Duration {
+	if alerts.For == nil {
+		return 5 * time.Minute
+	}
+	return alerts.For.Duration
+}
+
+// GetMaxReplicationLag gets the maximum replication lag of the replicas,
+// zero when the alert is disabled
+func (alerts *AlertsConfiguration) GetMaxReplicationLag() time.Duration {
+	if alerts.MaxReplicationLag == nil {
+		return 5 * time.Minute
+	}
+	return alerts.MaxReplicationLag.Duration
+}
+
+// IsWALArchiveFailureEnabled checks if the alert on the WAL archiving
+// failures is enabled
+func (alerts *AlertsConfiguration) IsWALArchiveFailureEnabled() bool {
+	return alerts.WALArchiveFailure == nil || *alerts.WALArchiveFailure
+}
+
+// GetMaxBackupAge gets the maximum age of the last available backup,
+// zero when the alert is disabled
+func (alerts *AlertsConfiguration) GetMaxBackupAge() time.Duration {
+	if alerts.MaxBackupAge == nil {
+		return 48 * time.Hour
+	}
+	return alerts.MaxBackupAge.Duration
+}
+
+// GetMaxConnectionsUsage gets the maximum percentage of `max_connections`
+// in use, zero when the alert is disabled
+func (alerts *AlertsConfiguration) GetMaxConnectionsUsage() int32 {
+	if alerts.MaxConnectionsUsage == nil {
+		return 80
+	}
+	return *alerts.MaxConnectionsUsage
+}
+
+// GetMaxDeadlocks gets the maximum number of deadlocks detected in a
+// database in the last 5 minutes, zero when the alert is disabled
+func (alerts *AlertsConfiguration) GetMaxDeadlocks() int32 {
+	if alerts.MaxDeadlocks == nil {
+		return 10
+	}
+	return *alerts.MaxDeadlocks
+}
+
+// GetParameters gets the `pgaudit.*` parameters corresponding to
+// the audit configuration
+func (audit *AuditConfiguration) GetParameters() map[string]string {
+	result := map[string]string{
+		"pgaudit.log_catalog":   formatAuditBool(audit.LogCatalog == nil || *audit.LogCatalog),
+		"pgaudit.log_parameter": formatAuditBool(audit.LogParameter),
+		"pgaudit.log_relation":  formatAuditBool(audit.LogRelation),
+	}
+	if len(audit.Classes) > 0 {
+		result["pgaudit.log"] = FormatAuditClasses(audit.Classes)
+	}
+	if audit.Role != "" {
+		result["pgaudit.role"] = audit.Role
+	}
+	return result
+}
+
+// GetShipping gets the sink where the audit log records are shipped,
+// or nil when the audit log records are not shipped
+func (audit *AuditConfiguration) GetShipping() *AuditLogShipping {
+	if audit == nil {
+		return nil
+	}
+	return audit.Shipping
 }
 
-// GetServerTLSSecretName get the name of the secret containing the
-// certificate that is used for the PostgreSQL servers
-func (cluster *Cluster) GetServerTLSSecretName() string {
-	if cluster.Spec.Certificates != nil && cluster.Spec.Certificates.ServerTLSSecret != "" {
-		return cluster.Spec.Certificates.ServerTLSSecret
+// FormatAuditClasses renders a list of audit classes as the value
+// of the `pgaudit.log` parameter
+func FormatAuditClasses(classes []string) string {
+	return strings.ToLower(strings.Join(classes, ", "))
+}
+
+// formatAuditBool renders a boolean as the value of a pgaudit parameter
+func formatAuditBool(value bool) string {
+	if value {
+		return "on"
 	}
-	return fmt.Sprintf("%v%v", cluster.Name, ServerSecretSuffix)
+	return "off"
 }
 
-// GetClientCASecretName get the name of the secret containing the CA
-// of the cluster
-func (cluster *Cluster) GetClientCASecretName() string {
-	if cluster.Spec.Certificates != nil && cluster.Spec.Certificates.ClientCASecret != "" {
-		return cluster.Spec.Certificates.ClientCASecret
+// IsDeferringCertificates checks whether the staged rotation of the
+// TLS certificates is still preventing the passed instance from
+// loading the new certificates
+func (status *CertificateRotationStatus) IsDeferringCertificates(instanceName string) bool {
+	if status == nil {
+		return false
+	}
+
+	switch status.Phase {
+	case CertificateRotationPhaseDistributingCA:
+		return true
+	case CertificateRotationPhaseReloadingInstances:
+		return status.TargetInstance != instanceName &&
+			!slices.Contains(status.ReloadedInstances, instanceName)
+	default:
+		return false
 	}
-	return fmt.Sprintf("%v%v", cluster.Name, ClientCaSecretSuffix)
 }
 
 // GetFixedInheritedAnnotations gets the annotations that should be
@@ -639,12 +1562,243 @@ func (cluster *Cluster) GetServiceReadOnlyName() string {
 	return fmt.Sprintf("%v%v", cluster.Name, ServiceReadOnlySuffix)
 }
 
+// IsOpen checks whether the maintenance window is open at the given time
+func (window MaintenanceWindow) IsOpen(now time.Time) bool {
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil || window.Duration.Duration <= 0 {
+		return false
+	}
+
+	// The window is open if it started less than its duration ago
+	now = now.UTC()
+	return !schedule.Next(now.Add(-window.Duration.Duration)).After(now)
+}
+
+// IsInMaintenanceWindow checks whether the operator is allowed to perform
+// disruptive operations at the given time
+func (cluster *Cluster) IsInMaintenanceWindow(now time.Time) bool {
+	return isInMaintenanceWindows(cluster.Spec.MaintenanceWindows, now)
+}
+
+// GetNextMaintenanceWindow gets the start of the first maintenance
+// window after the given time
+func (cluster *Cluster) GetNextMaintenanceWindow(now time.Time) (time.Time, bool) {
+	return getNextMaintenanceWindow(cluster.Spec.MaintenanceWindows, now)
+}
+
+// IsHibernationRequested checks whether the cluster should be hibernated
+// at the given time, via the hibernation annotation or the hibernation
+// configuration
+func (cluster *Cluster) IsHibernationRequested(now time.Time) bool {
+	if cluster.Annotations[utils.HibernationAnnotationName] == string(utils.HibernationAnnotationValueOn) {
+		return true
+	}
+
+	hibernation := cluster.Spec.Hibernation
+	if hibernation == nil {
+		return false
+	}
+	if hibernation.Enabled {
+		return true
+	}
+
+	for _, window := range hibernation.Windows {
+		if window.IsOpen(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetHibernationMode gets the set of instances shut down by the
+// hibernation, defaulting to every instance
+func (cluster *Cluster) GetHibernationMode() HibernationMode {
+	if cluster.Spec.Hibernation == nil || cluster.Spec.Hibernation.Mode == "" {
+		return HibernationModeFull
+	}
+
+	return cluster.Spec.Hibernation.Mode
+}
+
+// GetNextHibernationTransition gets the time when the scheduled hibernation
+// of the cluster starts or ends, whichever comes first after the given time
+func (cluster *Cluster) GetNextHibernationTransition(now time.Time) (time.Time, bool) {
+	if cluster.Spec.Hibernation == nil || len(cluster.Spec.Hibernation.Windows) == 0 {
+		return time.Time{}, false
+	}
+
+	// When a window is open, the next transition is the end of the open
+	// windows, otherwise it is the start of the next one
+	var end time.Time
+	for _, window := range cluster.Spec.Hibernation.Windows {
+		if !window.IsOpen(now) {
+			continue
+		}
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			continue
+		}
+		start := schedule.Next(now.UTC().Add(-window.Duration.Duration))
+		if windowEnd := start.Add(window.Duration.Duration); windowEnd.After(end) {
+			end = windowEnd
+		}
+	}
+	if !end.IsZero() {
+		return end, true
+	}
+
+	return getNextMaintenanceWindow(cluster.Spec.Hibernation.Windows, now)
+}
+
+// isInMaintenanceWindows checks whether any of the windows is open at
+// the given time. No window at all means no restriction
+func isInMaintenanceWindows(windows []MaintenanceWindow, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+
+	for _, window := range windows {
+		if window.IsOpen(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getNextMaintenanceWindow gets the start of the first of the windows
+// opening after the given time
+func getNextMaintenanceWindow(windows []MaintenanceWindow, now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, window := range windows {
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			continue
+		}
+		if start := schedule.Next(now.UTC()); next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// GetFailoverPolicy gets the failover policy of the cluster,
+// defaulting to the automatic one
+func (cluster *Cluster) GetFailoverPolicy() FailoverPolicy {
+	if cluster.Spec.Failover == nil || cluster.Spec.Failover.Policy == "" {
+		return FailoverPolicyAutomatic
+	}
+
+	return cluster.Spec.Failover.Policy
+}
+
+// ShouldRecloneFormerPrimary checks whether a former primary which can't
+// be realigned with pg_rewind should be cloned again from the new primary
+func (cluster *Cluster) ShouldRecloneFormerPrimary() bool {
+	return cluster.Spec.Failover != nil && cluster.Spec.Failover.RecloneFormerPrimary
+}
+
+// GetZoneLabel gets the label of the nodes identifying their zone
+func (cluster *Cluster) GetZoneLabel() string {
+	if cluster.Spec.Topology == nil || cluster.Spec.Topology.ZoneLabel == "" {
+		return corev1.LabelTopologyZone
+	}
+
+	return cluster.Spec.Topology.ZoneLabel
+}
+
+// GetPrimaryZones gets the zones allowed to host the primary instance,
+// an empty list meaning that every zone is allowed
+func (cluster *Cluster) GetPrimaryZones() []string {
+	if cluster.Spec.Topology == nil {
+		return nil
+	}
+
+	return cluster.Spec.Topology.PrimaryZones
+}
+
+// GetReplicasMaxUnavailable gets the number of replicas that can be
+// disrupted at the same time, defaults to 1
+func (cluster *Cluster) GetReplicasMaxUnavailable() int32 {
+	if cluster.Spec.PodDisruptionBudget == nil || cluster.Spec.PodDisruptionBudget.MaxUnavailableReplicas == nil {
+		return 1
+	}
+
+	return *cluster.Spec.PodDisruptionBudget.MaxUnavailableReplicas
+}
+
+// IsPrimaryPDBEnabled checks if the primary instance should be protected
+// by its own PodDisruptionBudget, defaults to true
+func (cluster *Cluster) IsPrimaryPDBEnabled() bool {
+	if cluster.Spec.PodDisruptionBudget == nil || cluster.Spec.PodDisruptionBudget.EnablePrimaryPDB == nil {
+		return true
+	}
+
+	return *cluster.Spec.PodDisruptionBudget.EnablePrimaryPDB
+}
+
+// GetEvictionPolicy gets the policy deciding how the operator reacts
+// to the drain of the node running the primary instance
+func (cluster *Cluster) GetEvictionPolicy() EvictionPolicy {
+	if cluster.Spec.PodDisruptionBudget == nil || cluster.Spec.PodDisruptionBudget.EvictionPolicy == "" {
+		return EvictionPolicySwitchover
+	}
+
+	return cluster.Spec.PodDisruptionBudget.EvictionPolicy
+}
+
+// GetEvictionDelay gets the time to wait before switching over when the
+// eviction policy is `delay`
+func (cluster *Cluster) GetEvictionDelay() time.Duration {
+	if cluster.Spec.PodDisruptionBudget == nil {
+		return 0
+	}
+
+	return time.Duration(cluster.Spec.PodDisruptionBudget.EvictionDelay) * time.Second
+}
+
+// IsFailoverApproved checks if the user approved the replacement
+// of the current primary instance
+func (cluster *Cluster) IsFailoverApproved() bool {
+	approved, ok := cluster.Annotations[utils.FailoverApprovalAnnotationName]
+	return ok && approved != "" && approved == cluster.Status.CurrentPrimary
+}
+
+// IsWitnessEnabled checks if the cluster has a witness
+func (cluster *Cluster) IsWitnessEnabled() bool {
+	return cluster.Spec.Witness != nil && cluster.Spec.Witness.Enabled
+}
+
+// GetWitnessName gets the name of the Deployment and of the Service
+// of the witness
+func (cluster *Cluster) GetWitnessName() string {
+	return fmt.Sprintf("%v%v", cluster.Name, WitnessSuffix)
+}
+
 // GetServiceReadWriteName return the default name of the service that is used for
 // read-write transactions
 func (cluster *Cluster) GetServiceReadWriteName() string {
 	return fmt.Sprintf("%v%v", cluster.Name, ServiceReadWriteSuffix)
 }
 
+// GetInstanceServiceName returns the name of the externally reachable
+// service pointing to the given instance
+func (cluster *Cluster) GetInstanceServiceName(instanceName string) string {
+	return fmt.Sprintf("%v%v", instanceName, ServiceInstanceSuffix)
+}
+
+// GetPerInstanceServices gets the configuration of the per-instance
+// services, or nil if they are not enabled
+func (cluster *Cluster) GetPerInstanceServices() *PerInstanceServicesConfiguration {
+	if cluster.Spec.Managed == nil || cluster.Spec.Managed.Services == nil {
+		return nil
+	}
+
+	return cluster.Spec.Managed.Services.PerInstance
+}
+
 // GetMaxStartDelay get the amount of time of startDelay config option
 func (cluster *Cluster) GetMaxStartDelay() int32 {
 	if cluster.Spec.MaxStartDelay > 0 {
@@ -714,6 +1868,25 @@ func (cluster *Cluster) GetEnablePDB() bool {
 	return *cluster.Spec.EnablePDB
 }
 
+// IsLogicalSlotsSynchronizationEnabled checks if the logical replication
+// slots of the primary instance are synchronized to the standby instances.
+// This requires the replication slots for high availability, and is not
+// supported in replica clusters
+func (cluster *Cluster) IsLogicalSlotsSynchronizationEnabled() bool {
+	replicationSlots := cluster.Spec.ReplicationSlots
+	return replicationSlots != nil &&
+		replicationSlots.SynchronizeLogicalSlots.GetEnabled() &&
+		replicationSlots.HighAvailability.GetEnabled() &&
+		!cluster.IsReplica()
+}
+
+// IsWALArchivedByStandby returns true if the WAL files are archived by a
+// standby instance elected by the operator instead of the primary
+func (cluster *Cluster) IsWALArchivedByStandby() bool {
+	return cluster.Spec.Backup != nil &&
+		cluster.Spec.Backup.WALArchiveTarget == WALArchiveTargetStandby
+}
+
 // IsNodeMaintenanceWindowInProgress check if the upgrade mode is active or not
 func (cluster *Cluster) IsNodeMaintenanceWindowInProgress() bool {
 	return cluster.Spec.NodeMaintenanceWindow != nil && cluster.Spec.NodeMaintenanceWindow.InProgress
@@ -766,6 +1939,7 @@ func (cluster *Cluster) ShouldResizeInUseVolumes() bool {
 func (cluster *Cluster) ShouldCreateApplicationSecret() bool {
 	return cluster.ShouldInitDBCreateApplicationSecret() ||
 		cluster.ShouldPgBaseBackupCreateApplicationSecret() ||
+		cluster.ShouldCloneCreateApplicationSecret() ||
 		cluster.ShouldRecoveryCreateApplicationSecret()
 }
 
@@ -785,6 +1959,14 @@ func (cluster *Cluster) ShouldPgBaseBackupCreateApplicationSecret() bool {
 			cluster.Spec.Bootstrap.PgBaseBackup.Secret.Name == "")
 }
 
+// ShouldCloneCreateApplicationSecret returns true if for this cluster,
+// during the bootstrap phase using clone, we need to create an application secret
+func (cluster *Cluster) ShouldCloneCreateApplicationSecret() bool {
+	return cluster.ShouldCloneCreateApplicationDatabase() &&
+		(cluster.Spec.Bootstrap.Clone.Secret == nil ||
+			cluster.Spec.Bootstrap.Clone.Secret.Name == "")
+}
+
 // ShouldRecoveryCreateApplicationSecret returns true if for this cluster,
 // during the bootstrap phase using recovery, we need to create an application secret
 func (cluster *Cluster) ShouldRecoveryCreateApplicationSecret() bool {
@@ -798,7 +1980,8 @@ func (cluster *Cluster) ShouldRecoveryCreateApplicationSecret() bool {
 func (cluster *Cluster) ShouldCreateApplicationDatabase() bool {
 	return cluster.ShouldInitDBCreateApplicationDatabase() ||
 		cluster.ShouldRecoveryCreateApplicationDatabase() ||
-		cluster.ShouldPgBaseBackupCreateApplicationDatabase()
+		cluster.ShouldPgBaseBackupCreateApplicationDatabase() ||
+		cluster.ShouldCloneCreateApplicationDatabase()
 }
 
 // ShouldInitDBRunPostInitApplicationSQLRefs returns true if for this cluster,
@@ -880,6 +2063,17 @@ func (cluster *Cluster) ShouldPgBaseBackupCreateApplicationDatabase() bool {
 	return pgBaseBackupParameters.Owner != "" && pgBaseBackupParameters.Database != ""
 }
 
+// ShouldCloneCreateApplicationDatabase returns true if the application database needs to be created during the
+// clone job
+func (cluster *Cluster) ShouldCloneCreateApplicationDatabase() bool {
+	if !cluster.IsBootstrappedWithClone() {
+		return false
+	}
+
+	cloneParameters := cluster.Spec.Bootstrap.Clone
+	return cloneParameters.Owner != "" && cloneParameters.Database != ""
+}
+
 // ShouldRecoveryCreateApplicationDatabase returns true if the application database needs to be created during the
 // recovery job
 func (cluster *Cluster) ShouldRecoveryCreateApplicationDatabase() bool {
@@ -931,6 +2125,20 @@ func (cluster *Cluster) ShouldPromoteFromReplicaCluster() bool {
 	return true
 }
 
+// ShouldWaitForPublishedPromotionToken returns true when the designated
+// primary of a replica cluster being promoted needs to wait for the former
+// primary cluster to publish its demotion token in the promotion token secret
+func (cluster *Cluster) ShouldWaitForPublishedPromotionToken(podName string) bool {
+	r := cluster.Spec.ReplicaCluster
+	if r == nil || r.PromotionTokenSecret == nil || len(r.PromotionToken) > 0 {
+		return false
+	}
+
+	// During a failover the target primary is not the current primary,
+	// and there's no need to wait for any token
+	return !cluster.IsReplica() && cluster.Status.CurrentPrimary == podName
+}
+
 // ContainsTablespaces returns true if for this cluster, we need to create tablespaces
 func (cluster *Cluster) ContainsTablespaces() bool {
 	return len(cluster.Spec.Tablespaces) != 0
@@ -986,12 +2194,121 @@ func (cluster Cluster) IsReplica() bool {
 		return *r.Enabled
 	}
 
-	clusterName := r.Self
-	if len(clusterName) == 0 {
-		clusterName = cluster.Name
+	return cluster.GetSelfName() != r.Primary
+}
+
+// GetSelfName returns the name used to identify this cluster inside
+// a distributed topology, which is the value of `.spec.replica.self`
+// when set, and the name of the cluster otherwise
+func (cluster Cluster) GetSelfName() string {
+	if cluster.Spec.ReplicaCluster != nil && len(cluster.Spec.ReplicaCluster.Self) > 0 {
+		return cluster.Spec.ReplicaCluster.Self
+	}
+
+	return cluster.Name
+}
+
+// GetSynchronousReplicaApplicationName returns the application name the
+// designated primary of a replica cluster uses when streaming from the
+// source cluster, or an empty string if the replica cluster is not
+// configured to be synchronous
+func (cluster Cluster) GetSynchronousReplicaApplicationName() string {
+	if !cluster.IsReplica() || !cluster.Spec.ReplicaCluster.IsSynchronous() {
+		return ""
+	}
+
+	return cluster.GetSelfName()
+}
+
+// GetSynchronousReplicaClusterNames returns the names of the replica
+// clusters whose designated primary should be included in the
+// `synchronous_standby_names` of this cluster
+func (cluster Cluster) GetSynchronousReplicaClusterNames() []string {
+	if cluster.IsReplica() {
+		return nil
+	}
+
+	selfName := cluster.GetSelfName()
+	var result []string
+	for _, externalCluster := range cluster.Spec.ExternalClusters {
+		if externalCluster.SynchronousReplica && externalCluster.Name != selfName {
+			result = append(result, externalCluster.Name)
+		}
+	}
+
+	return result
+}
+
+// IsSynchronous returns true if the designated primary of the replica
+// cluster should be a synchronous standby of the source cluster
+func (r *ReplicaClusterConfiguration) IsSynchronous() bool {
+	if r == nil || r.Synchronous == nil {
+		return false
+	}
+
+	return *r.Synchronous
+}
+
+// GetOnTimelineDivergence returns the action taken by the designated primary
+// of the replica cluster when its timeline diverges from the source cluster
+func (r *ReplicaClusterConfiguration) GetOnTimelineDivergence() TimelineDivergencePolicy {
+	if r == nil || r.OnTimelineDivergence == "" {
+		return TimelineDivergencePolicyManual
+	}
+
+	return r.OnTimelineDivergence
+}
+
+// GetReplicaSource returns the name of the external cluster the designated
+// primary of this replica cluster should stream from. When a topology is
+// declared, the upstream cluster is computed walking the topology from the
+// current primary, otherwise the configured source is used.
+func (cluster Cluster) GetReplicaSource() string {
+	r := cluster.Spec.ReplicaCluster
+	if r == nil {
+		return ""
+	}
+
+	if upstream, ok := r.GetTopologyUpstream(cluster.GetSelfName()); ok {
+		return upstream
+	}
+
+	return r.Source
+}
+
+// GetTopologyUpstream returns the name of the cluster that the passed
+// cluster should stream from, according to the declared topology and the
+// current primary. The second return value is false when the cluster is not
+// reachable from the primary using the topology links.
+func (r *ReplicaClusterConfiguration) GetTopologyUpstream(clusterName string) (string, bool) {
+	if len(r.Topology) == 0 || len(r.Primary) == 0 || clusterName == r.Primary {
+		return "", false
+	}
+
+	neighbours := make(map[string][]string, len(r.Topology))
+	for _, link := range r.Topology {
+		neighbours[link.Name] = append(neighbours[link.Name], link.Upstream)
+		neighbours[link.Upstream] = append(neighbours[link.Upstream], link.Name)
 	}
 
-	return clusterName != r.Primary
+	// The links are traversed starting from the primary, so that the
+	// upstream of each cluster is its neighbour on the path towards it
+	upstreams := map[string]string{r.Primary: ""}
+	queue := []string{r.Primary}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range neighbours[current] {
+			if _, visited := upstreams[next]; visited {
+				continue
+			}
+			upstreams[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	upstream, ok := upstreams[clusterName]
+	return upstream, ok
 }
 
 var slotNameNegativeRegex = regexp.MustCompile("[^a-z0-9_]+")
@@ -1013,7 +2330,7 @@ func (cluster Cluster) GetBarmanEndpointCAForReplicaCluster() *SecretKeySelector
 	if !cluster.IsReplica() {
 		return nil
 	}
-	sourceName := cluster.Spec.ReplicaCluster.Source
+	sourceName := cluster.GetReplicaSource()
 	externalCluster, found := cluster.ExternalCluster(sourceName)
 	if !found || externalCluster.BarmanObjectStore == nil {
 		return nil
@@ -1252,6 +2569,31 @@ func (cluster *Cluster) GetTablespaceConfiguration(name string) *TablespaceConfi
 	return nil
 }
 
+// GetStorageClassMigrationStrategy gets the strategy to follow when the
+// storage class of the volumes is changed
+func (cluster *Cluster) GetStorageClassMigrationStrategy() StorageClassMigrationStrategy {
+	if cluster.Spec.StorageClassMigrationStrategy == "" {
+		return StorageClassMigrationStrategyNone
+	}
+
+	return cluster.Spec.StorageClassMigrationStrategy
+}
+
+// IsEphemeral returns true if the tablespace is backed by an ephemeral volume
+func (t *TablespaceConfiguration) IsEphemeral() bool {
+	return t.Ephemeral != nil
+}
+
+// GetVolumeType returns the kind of volume backing the ephemeral
+// tablespace, defaulting to a generic ephemeral volume
+func (e *EphemeralTablespaceConfiguration) GetVolumeType() EphemeralTablespaceVolumeType {
+	if e == nil || e.VolumeType == "" {
+		return EphemeralTablespaceVolumeTypeGeneric
+	}
+
+	return e.VolumeType
+}
+
 // GetServerCASecretObjectKey returns a types.NamespacedName pointing to the secret
 func (cluster *Cluster) GetServerCASecretObjectKey() types.NamespacedName {
 	return types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.GetServerCASecretName()}
@@ -1362,6 +2704,33 @@ func (cluster *Cluster) IsReadOnlyServiceEnabled() bool {
 	return !slices.Contains(cluster.Spec.Managed.Services.DisabledDefaultServices, ServiceSelectorTypeRO)
 }
 
+// GetMaxReplicaLag gets the maximum replication lag, in bytes, of the
+// replicas being part of the endpoints of the read and read-only services,
+// or nil if the lagging replicas don't need to be excluded
+func (cluster *Cluster) GetMaxReplicaLag() *resource.Quantity {
+	if cluster.Spec.Managed == nil || cluster.Spec.Managed.Services == nil {
+		return nil
+	}
+
+	return cluster.Spec.Managed.Services.MaxReplicaLag
+}
+
+// GetServiceRouting gets the load balancing options of the default
+// service with the given selector type, or nil if not specified
+func (cluster *Cluster) GetServiceRouting(selectorType ServiceSelectorType) *ServiceRoutingConfiguration {
+	if cluster.Spec.Managed == nil || cluster.Spec.Managed.Services == nil {
+		return nil
+	}
+
+	for idx := range cluster.Spec.Managed.Services.Routing {
+		if cluster.Spec.Managed.Services.Routing[idx].SelectorType == selectorType {
+			return &cluster.Spec.Managed.Services.Routing[idx]
+		}
+	}
+
+	return nil
+}
+
 // GetRecoverySourcePlugin returns the configuration of the plugin being
 // the recovery source of the cluster. If no such plugin have been configured,
 // nil is returned
@@ -1387,6 +2756,53 @@ func (cluster *Cluster) GetRecoverySourcePlugin() *PluginConfiguration {
 	return recoveryExternalCluster.PluginConfiguration
 }
 
+// GetRecoverySourcePgBackRest returns the external cluster being the
+// recovery source of the cluster, when it is configured to recover
+// from a pgBackRest repository. Otherwise, nil is returned
+func (cluster *Cluster) GetRecoverySourcePgBackRest() *ExternalCluster {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Recovery == nil {
+		return nil
+	}
+
+	recoveryConfig := cluster.Spec.Bootstrap.Recovery
+	if len(recoveryConfig.Source) == 0 {
+		return nil
+	}
+
+	recoveryExternalCluster, found := cluster.ExternalCluster(recoveryConfig.Source)
+	if !found || recoveryExternalCluster.PgBackRest == nil {
+		return nil
+	}
+
+	return &recoveryExternalCluster
+}
+
+// GetRecoveryBackupKey returns the key of the Backup object the cluster
+// is bootstrapped from, or nil if the cluster is not recovering from a
+// Backup object
+func (cluster *Cluster) GetRecoveryBackupKey() *types.NamespacedName {
+	if cluster.Spec.Bootstrap == nil ||
+		cluster.Spec.Bootstrap.Recovery == nil ||
+		cluster.Spec.Bootstrap.Recovery.Backup == nil {
+		return nil
+	}
+
+	backupSource := cluster.Spec.Bootstrap.Recovery.Backup
+	namespace := backupSource.Namespace
+	if namespace == "" {
+		namespace = cluster.Namespace
+	}
+
+	return &types.NamespacedName{Namespace: namespace, Name: backupSource.Name}
+}
+
+// IsRecoveringFromAnotherNamespace is true when the cluster is bootstrapped
+// from a Backup object living in a different namespace
+func (cluster *Cluster) IsRecoveringFromAnotherNamespace() bool {
+	backupKey := cluster.GetRecoveryBackupKey()
+	return backupKey != nil && backupKey.Namespace != cluster.Namespace
+}
+
 // EnsureGVKIsPresent ensures that the GroupVersionKind (GVK) metadata is present in the Backup object.
 // This is necessary because informers do not automatically include metadata inside the object.
 // By setting the GVK, we ensure that components such as the plugins have enough metadata to typecheck the object.
@@ -1459,3 +2875,59 @@ func (p *Probe) ApplyInto(k8sProbe *corev1.Probe) {
 	k8sProbe.FailureThreshold = p.FailureThreshold
 	k8sProbe.TerminationGracePeriodSeconds = p.TerminationGracePeriodSeconds
 }
+
+// IsVerticalPodAutoscalerEnabled checks if the integration with the
+// Vertical Pod Autoscaler is enabled
+func (cluster *Cluster) IsVerticalPodAutoscalerEnabled() bool {
+	return cluster.Spec.VerticalPodAutoscaler != nil && cluster.Spec.VerticalPodAutoscaler.Enabled
+}
+
+// GetResources gets the resources of the instances, which are the ones
+// recommended by the Vertical Pod Autoscaler, when available, or the
+// ones specified in the cluster
+func (cluster *Cluster) GetResources() corev1.ResourceRequirements {
+	if cluster.IsVerticalPodAutoscalerEnabled() && cluster.Status.RecommendedResources != nil {
+		return *cluster.Status.RecommendedResources
+	}
+	return cluster.Spec.Resources
+}
+
+// GetControlledValues gets the resource values controlled by the
+// Vertical Pod Autoscaler, defaulting to the requests and the limits
+func (configuration *VerticalPodAutoscalerConfiguration) GetControlledValues() VerticalPodAutoscalerControlledValues {
+	if configuration == nil || configuration.ControlledValues == "" {
+		return VerticalPodAutoscalerControlledValuesRequestsAndLimits
+	}
+	return configuration.ControlledValues
+}
+
+// GetTolerance gets the minimum difference, in percent, between the
+// applied requests and the recommended ones triggering a rolling update
+func (configuration *VerticalPodAutoscalerConfiguration) GetTolerance() int32 {
+	if configuration == nil || configuration.Tolerance == nil {
+		return 10
+	}
+	return *configuration.Tolerance
+}
+
+// ValidatePgHBAAddress validates a client address of a pg_hba rule, which
+// can be a CIDR, a host name, a host name suffix starting with a dot, or
+// one of the `all`, `samehost` and `samenet` keywords
+func ValidatePgHBAAddress(address string) error {
+	switch {
+	case address == "all" || address == "samehost" || address == "samenet":
+		return nil
+	case strings.Contains(address, "/"):
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			return fmt.Errorf("invalid CIDR: %w", err)
+		}
+	case net.ParseIP(address) != nil:
+		return errors.New("IP addresses must be specified in CIDR notation")
+	default:
+		hostname := strings.TrimPrefix(address, ".")
+		if errs := validationutil.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+			return errors.New("must be a CIDR, a host name or one of all, samehost and samenet")
+		}
+	}
+	return nil
+}
@@ -1471,6 +1471,130 @@ var _ = Describe("IsReplica", func() {
 	})
 })
 
+var _ = Describe("Replica cluster topology", func() {
+	newCluster := func(self, primary string) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Self:    self,
+					Primary: primary,
+					Source:  "cluster-a",
+					Topology: []ReplicaClusterTopologyLink{
+						{Name: "cluster-b", Upstream: "cluster-a"},
+						{Name: "cluster-c", Upstream: "cluster-b"},
+					},
+				},
+			},
+		}
+	}
+
+	It("uses the source when no topology is declared", func() {
+		cluster := newCluster("cluster-c", "cluster-a")
+		cluster.Spec.ReplicaCluster.Topology = nil
+		Expect(cluster.GetReplicaSource()).To(Equal("cluster-a"))
+	})
+
+	It("cascades from the declared upstream", func() {
+		Expect(newCluster("cluster-b", "cluster-a").GetReplicaSource()).To(Equal("cluster-a"))
+		Expect(newCluster("cluster-c", "cluster-a").GetReplicaSource()).To(Equal("cluster-b"))
+	})
+
+	It("re-points the downstream clusters after a promotion", func() {
+		Expect(newCluster("cluster-a", "cluster-c").GetReplicaSource()).To(Equal("cluster-b"))
+		Expect(newCluster("cluster-b", "cluster-c").GetReplicaSource()).To(Equal("cluster-c"))
+	})
+
+	It("uses the source for clusters not reachable from the primary", func() {
+		Expect(newCluster("cluster-d", "cluster-a").GetReplicaSource()).To(Equal("cluster-a"))
+	})
+})
+
+var _ = Describe("Synchronous replica clusters", func() {
+	It("uses the self name as application name of a synchronous replica cluster", func() {
+		cluster := &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-b"},
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Self:        "cluster-eu-central",
+					Primary:     "cluster-eu-south",
+					Source:      "cluster-eu-south",
+					Synchronous: ptr.To(true),
+				},
+			},
+		}
+		Expect(cluster.GetSynchronousReplicaApplicationName()).To(Equal("cluster-eu-central"))
+
+		cluster.Spec.ReplicaCluster.Synchronous = ptr.To(false)
+		Expect(cluster.GetSynchronousReplicaApplicationName()).To(BeEmpty())
+	})
+
+	It("lists the synchronous replica clusters only in a primary cluster", func() {
+		cluster := &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-eu-south"},
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Primary: "cluster-eu-south",
+					Source:  "cluster-eu-central",
+				},
+				ExternalClusters: []ExternalCluster{
+					{Name: "cluster-eu-south", SynchronousReplica: true},
+					{Name: "cluster-eu-central", SynchronousReplica: true},
+					{Name: "cluster-us-east"},
+				},
+			},
+		}
+		Expect(cluster.GetSynchronousReplicaClusterNames()).To(Equal([]string{"cluster-eu-central"}))
+
+		cluster.Spec.ReplicaCluster.Primary = "cluster-eu-central"
+		Expect(cluster.GetSynchronousReplicaClusterNames()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ShouldWaitForPublishedPromotionToken", func() {
+	var cluster *Cluster
+
+	BeforeEach(func() {
+		cluster = &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-b"},
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Primary:              "cluster-b",
+					Source:               "cluster-a",
+					PromotionTokenSecret: &LocalObjectReference{Name: "switchover-token"},
+				},
+			},
+			Status: ClusterStatus{
+				CurrentPrimary: "cluster-b-1",
+				TargetPrimary:  "cluster-b-1",
+			},
+		}
+	})
+
+	It("waits when the designated primary is being promoted", func() {
+		Expect(cluster.ShouldWaitForPublishedPromotionToken("cluster-b-1")).To(BeTrue())
+	})
+
+	It("doesn't wait during a failover", func() {
+		cluster.Status.TargetPrimary = "cluster-b-2"
+		Expect(cluster.ShouldWaitForPublishedPromotionToken("cluster-b-2")).To(BeFalse())
+	})
+
+	It("doesn't wait when an explicit promotion token is set", func() {
+		cluster.Spec.ReplicaCluster.PromotionToken = "token"
+		Expect(cluster.ShouldWaitForPublishedPromotionToken("cluster-b-1")).To(BeFalse())
+	})
+
+	It("doesn't wait in a replica cluster", func() {
+		cluster.Spec.ReplicaCluster.Primary = "cluster-a"
+		Expect(cluster.ShouldWaitForPublishedPromotionToken("cluster-b-1")).To(BeFalse())
+	})
+
+	It("doesn't wait without a promotion token secret", func() {
+		cluster.Spec.ReplicaCluster.PromotionTokenSecret = nil
+		Expect(cluster.ShouldWaitForPublishedPromotionToken("cluster-b-1")).To(BeFalse())
+	})
+})
+
 var _ = Describe("Cluster Managed Service Enablement", func() {
 	var cluster *Cluster
 
@@ -1726,3 +1850,788 @@ var _ = Describe("Probes configuration", func() {
 		Expect(configuredProbe.TerminationGracePeriodSeconds).To(BeNil())
 	})
 })
+
+var _ = Describe("Major version upgrade method", func() {
+	It("defaults to pg_upgrade", func() {
+		cluster := &Cluster{}
+		Expect(cluster.GetMajorUpgradeMethod()).To(Equal(MajorUpgradeMethodPgUpgrade))
+
+		cluster.Spec.MajorUpgrade = &MajorUpgradeConfiguration{}
+		Expect(cluster.GetMajorUpgradeMethod()).To(Equal(MajorUpgradeMethodPgUpgrade))
+	})
+
+	It("uses the configured method", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				MajorUpgrade: &MajorUpgradeConfiguration{Method: MajorUpgradeMethodLogical},
+			},
+		}
+		Expect(cluster.GetMajorUpgradeMethod()).To(Equal(MajorUpgradeMethodLogical))
+	})
+})
+
+var _ = Describe("Bootstrap via migration", func() {
+	It("detects if the cluster is bootstrapped with a migration", func() {
+		cluster := &Cluster{}
+		Expect(cluster.IsBootstrappedWithMigration()).To(BeFalse())
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{InitDB: &BootstrapInitDB{}}
+		Expect(cluster.IsBootstrappedWithMigration()).To(BeFalse())
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{Migration: &BootstrapMigration{}}
+		Expect(cluster.IsBootstrappedWithMigration()).To(BeTrue())
+	})
+
+	It("imports the schema of the migrated databases", func() {
+		migration := &BootstrapMigration{
+			Source:             "source",
+			Databases:          []string{"app", "sales"},
+			Roles:              []string{"app"},
+			PgDumpExtraOptions: []string{"--verbose"},
+		}
+
+		initDB := migration.GetImportBootstrap()
+		Expect(initDB.Encoding).To(Equal("UTF8"))
+		Expect(initDB.Import).ToNot(BeNil())
+		Expect(initDB.Import.Type).To(Equal(MonolithSnapshotType))
+		Expect(initDB.Import.Source.ExternalCluster).To(Equal("source"))
+		Expect(initDB.Import.Databases).To(Equal([]string{"app", "sales"}))
+		Expect(initDB.Import.Roles).To(Equal([]string{"app"}))
+		Expect(initDB.Import.SchemaOnly).To(BeTrue())
+		Expect(initDB.Import.PgDumpExtraOptions).To(Equal([]string{"--verbose"}))
+
+		initDB.Import.Databases[0] = "changed"
+		Expect(migration.Databases[0]).To(Equal("app"))
+	})
+
+	It("fences the source cluster by default", func() {
+		migration := &BootstrapMigration{}
+		Expect(migration.ShouldFenceSource()).To(BeTrue())
+
+		migration.FenceSource = ptr.To(false)
+		Expect(migration.ShouldFenceSource()).To(BeFalse())
+	})
+})
+
+var _ = Describe("Failover policy", func() {
+	It("defaults to the automatic policy", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetFailoverPolicy()).To(Equal(FailoverPolicyAutomatic))
+
+		cluster.Spec.Failover = &FailoverConfiguration{}
+		Expect(cluster.GetFailoverPolicy()).To(Equal(FailoverPolicyAutomatic))
+
+		cluster.Spec.Failover.Policy = FailoverPolicyManual
+		Expect(cluster.GetFailoverPolicy()).To(Equal(FailoverPolicyManual))
+	})
+
+	It("considers the failover approved only for the current primary", func() {
+		cluster := Cluster{
+			Status: ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+		Expect(cluster.IsFailoverApproved()).To(BeFalse())
+
+		cluster.Annotations = map[string]string{
+			utils.FailoverApprovalAnnotationName: "cluster-example-2",
+		}
+		Expect(cluster.IsFailoverApproved()).To(BeFalse())
+
+		cluster.Annotations[utils.FailoverApprovalAnnotationName] = "cluster-example-1"
+		Expect(cluster.IsFailoverApproved()).To(BeTrue())
+	})
+
+	It("re-clones the former primary only when enabled", func() {
+		cluster := Cluster{}
+		Expect(cluster.ShouldRecloneFormerPrimary()).To(BeFalse())
+
+		cluster.Spec.Failover = &FailoverConfiguration{}
+		Expect(cluster.ShouldRecloneFormerPrimary()).To(BeFalse())
+
+		cluster.Spec.Failover.RecloneFormerPrimary = true
+		Expect(cluster.ShouldRecloneFormerPrimary()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Maintenance windows", func() {
+	// Every day at 02:00 UTC, for two hours
+	nightly := MaintenanceWindow{
+		Schedule: "0 0 2 * * *",
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+
+	It("allows disruptive operations when no window is defined", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsInMaintenanceWindow(time.Now())).To(BeTrue())
+	})
+
+	It("allows disruptive operations only while a window is open", func() {
+		cluster := Cluster{Spec: ClusterSpec{MaintenanceWindows: []MaintenanceWindow{nightly}}}
+		Expect(cluster.IsInMaintenanceWindow(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC))).To(BeTrue())
+		Expect(cluster.IsInMaintenanceWindow(time.Date(2024, 1, 1, 3, 30, 0, 0, time.UTC))).To(BeTrue())
+		Expect(cluster.IsInMaintenanceWindow(time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC))).To(BeFalse())
+		Expect(cluster.IsInMaintenanceWindow(time.Date(2024, 1, 1, 1, 59, 0, 0, time.UTC))).To(BeFalse())
+	})
+
+	It("finds the start of the next window", func() {
+		weekly := MaintenanceWindow{
+			Schedule: "0 0 22 * * 6",
+			Duration: metav1.Duration{Duration: time.Hour},
+		}
+		cluster := Cluster{Spec: ClusterSpec{MaintenanceWindows: []MaintenanceWindow{weekly, nightly}}}
+		next, found := cluster.GetNextMaintenanceWindow(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+		Expect(found).To(BeTrue())
+		Expect(next).To(BeTemporally("==", time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("Cluster cloning", func() {
+	It("connects to the primary of the cluster being cloned", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-staging", Namespace: "staging"},
+		}
+		_, found := cluster.GetCloneSource()
+		Expect(found).To(BeFalse())
+		Expect(cluster.GetCloneSourceNamespace()).To(Equal("staging"))
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{
+			Clone: &BootstrapClone{Cluster: "cluster-prod", Namespace: "production"},
+		}
+		server, found := cluster.GetPgBaseBackupSource()
+		Expect(found).To(BeTrue())
+		Expect(server.ConnectionParameters).To(HaveKeyWithValue("host", "cluster-prod-rw.production.svc"))
+		Expect(server.ConnectionParameters).To(HaveKeyWithValue("user", "streaming_replica"))
+		Expect(server.SSLCert.Name).To(Equal("cluster-staging-clone"))
+		Expect(server.SSLKey.Key).To(Equal("tls.key"))
+		Expect(server.SSLRootCert.Key).To(Equal("ca.crt"))
+	})
+
+	It("creates the application secret with the new credentials", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-staging"},
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					Clone: &BootstrapClone{Cluster: "cluster-prod", Database: "app", Owner: "app"},
+				},
+			},
+		}
+		Expect(cluster.ShouldCreateApplicationDatabase()).To(BeTrue())
+		Expect(cluster.ShouldCreateApplicationSecret()).To(BeTrue())
+		Expect(cluster.GetApplicationSecretName()).To(Equal("cluster-staging-app"))
+
+		cluster.Spec.Bootstrap.Clone.Secret = &LocalObjectReference{Name: "staging-app"}
+		Expect(cluster.ShouldCreateApplicationSecret()).To(BeFalse())
+		Expect(cluster.GetApplicationSecretName()).To(Equal("staging-app"))
+	})
+})
+
+var _ = Describe("Data masking", func() {
+	It("is configured by the bootstrap method copying the data", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetDataMasking()).To(BeNil())
+		Expect(cluster.ShouldRunDataMaskingSQLRefs()).To(BeFalse())
+
+		masking := &DataMaskingConfiguration{SQL: []string{"TRUNCATE audit_log"}}
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{
+			Clone: &BootstrapClone{Cluster: "cluster-prod", Database: "shop", Owner: "shop", Masking: masking},
+		}
+		Expect(cluster.GetDataMasking()).To(Equal(masking))
+		Expect(cluster.GetDataMaskingDatabase()).To(Equal("shop"))
+		Expect(cluster.ShouldRunDataMaskingSQLRefs()).To(BeFalse())
+
+		masking.Database = "crm"
+		masking.SQLRefs = &SQLRefs{
+			ConfigMapRefs: []ConfigMapKeySelector{
+				{LocalObjectReference: LocalObjectReference{Name: "masking"}, Key: "masking.sql"},
+			},
+		}
+		Expect(cluster.GetDataMaskingDatabase()).To(Equal("crm"))
+		Expect(cluster.ShouldRunDataMaskingSQLRefs()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Lifecycle hooks", func() {
+	It("selects the hooks triggered by an event, in order", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				LifecycleHooks: []LifecycleHook{
+					{Name: "migrate", Event: LifecycleHookEventPostInit},
+					{Name: "notify", Event: LifecycleHookEventPreSwitchover},
+					{Name: "seed", Event: LifecycleHookEventPostInit},
+				},
+			},
+		}
+		hooks := cluster.GetLifecycleHooks(LifecycleHookEventPostInit)
+		Expect(hooks).To(HaveLen(2))
+		Expect(hooks[0].Name).To(Equal("migrate"))
+		Expect(hooks[1].Name).To(Equal("seed"))
+		Expect(cluster.GetLifecycleHooks(LifecycleHookEventPostFailover)).To(BeEmpty())
+	})
+
+	It("gets the event happening after the bootstrap", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetBootstrapLifecycleHookEvent()).To(Equal(LifecycleHookEventPostInit))
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{InitDB: &BootstrapInitDB{}}
+		Expect(cluster.GetBootstrapLifecycleHookEvent()).To(Equal(LifecycleHookEventPostInit))
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{Recovery: &BootstrapRecovery{Source: "origin"}}
+		Expect(cluster.GetBootstrapLifecycleHookEvent()).To(Equal(LifecycleHookEventPostRecovery))
+	})
+
+	It("defaults the timeout and the failure policy", func() {
+		hook := LifecycleHook{}
+		Expect(hook.GetTimeout()).To(Equal(30 * time.Second))
+		Expect(hook.GetFailurePolicy()).To(Equal(LifecycleHookFailurePolicyIgnore))
+
+		hook.Timeout = &metav1.Duration{Duration: 5 * time.Minute}
+		hook.FailurePolicy = LifecycleHookFailurePolicyFail
+		Expect(hook.GetTimeout()).To(Equal(5 * time.Minute))
+		Expect(hook.GetFailurePolicy()).To(Equal(LifecycleHookFailurePolicyFail))
+	})
+})
+
+var _ = Describe("Scheduled hibernation", func() {
+	// Every day at 20:00 UTC, for twelve hours
+	overnight := MaintenanceWindow{
+		Schedule: "0 0 20 * * *",
+		Duration: metav1.Duration{Duration: 12 * time.Hour},
+	}
+
+	It("is requested via the annotation or the configuration", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsHibernationRequested(time.Now())).To(BeFalse())
+		Expect(cluster.GetHibernationMode()).To(Equal(HibernationModeFull))
+
+		cluster.Annotations = map[string]string{
+			utils.HibernationAnnotationName: string(utils.HibernationAnnotationValueOn),
+		}
+		Expect(cluster.IsHibernationRequested(time.Now())).To(BeTrue())
+
+		cluster.Annotations = nil
+		cluster.Spec.Hibernation = &HibernationConfiguration{Enabled: true, Mode: HibernationModeMinimal}
+		Expect(cluster.IsHibernationRequested(time.Now())).To(BeTrue())
+		Expect(cluster.GetHibernationMode()).To(Equal(HibernationModeMinimal))
+	})
+
+	It("is requested only while a window is open", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{Hibernation: &HibernationConfiguration{Windows: []MaintenanceWindow{overnight}}},
+		}
+		Expect(cluster.IsHibernationRequested(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC))).To(BeTrue())
+		Expect(cluster.IsHibernationRequested(time.Date(2024, 1, 2, 7, 0, 0, 0, time.UTC))).To(BeTrue())
+		Expect(cluster.IsHibernationRequested(time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC))).To(BeFalse())
+	})
+
+	It("finds the next transition", func() {
+		cluster := Cluster{}
+		_, found := cluster.GetNextHibernationTransition(time.Now())
+		Expect(found).To(BeFalse())
+
+		cluster.Spec.Hibernation = &HibernationConfiguration{Windows: []MaintenanceWindow{overnight}}
+		next, found := cluster.GetNextHibernationTransition(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+		Expect(found).To(BeTrue())
+		Expect(next).To(BeTemporally("==", time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)))
+
+		next, found = cluster.GetNextHibernationTransition(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+		Expect(found).To(BeTrue())
+		Expect(next).To(BeTemporally("==", time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("Certificate rotation status", func() {
+	It("defers the certificates of the instances not reloaded yet", func() {
+		rotation := &CertificateRotationStatus{
+			Phase:             CertificateRotationPhaseReloadingInstances,
+			TargetInstance:    "cluster-example-2",
+			ReloadedInstances: []string{"cluster-example-3"},
+			PendingInstances:  []string{"cluster-example-1"},
+		}
+		Expect(rotation.IsDeferringCertificates("cluster-example-1")).To(BeTrue())
+		Expect(rotation.IsDeferringCertificates("cluster-example-2")).To(BeFalse())
+		Expect(rotation.IsDeferringCertificates("cluster-example-3")).To(BeFalse())
+
+		rotation.Phase = CertificateRotationPhaseReloadingPoolers
+		Expect(rotation.IsDeferringCertificates("cluster-example-1")).To(BeFalse())
+
+		var noRotation *CertificateRotationStatus
+		Expect(noRotation.IsDeferringCertificates("cluster-example-1")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Audit parameters", func() {
+	It("doesn't add any parameter without an audit configuration", func() {
+		cluster := Cluster{}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"work_mem": "8MB"}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{"work_mem": "8MB"}))
+	})
+
+	It("adds the pgaudit parameters to the ones of the user", func() {
+		cluster := Cluster{}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"work_mem": "8MB"}
+		cluster.Spec.PostgresConfiguration.Audit = &AuditConfiguration{
+			Classes:      []string{"ALL", "-misc"},
+			LogCatalog:   ptr.To(false),
+			LogParameter: true,
+			Role:         "auditor",
+		}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{
+			"work_mem":              "8MB",
+			"pgaudit.log":           "all, -misc",
+			"pgaudit.log_catalog":   "off",
+			"pgaudit.log_parameter": "on",
+			"pgaudit.log_relation":  "off",
+			"pgaudit.role":          "auditor",
+		}))
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveLen(1))
+	})
+
+	It("enables pgaudit even when only the roles are audited", func() {
+		audit := &AuditConfiguration{
+			Roles: []AuditRoleConfiguration{{Name: "app", Classes: []string{"write"}}},
+		}
+		Expect(audit.GetParameters()).To(HaveKeyWithValue("pgaudit.log_catalog", "on"))
+		Expect(audit.GetParameters()).ToNot(HaveKey("pgaudit.log"))
+	})
+})
+
+var _ = Describe("Query insights parameters", func() {
+	It("doesn't add any parameter when the query insights are disabled", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Monitoring: &MonitoringConfiguration{
+					QueryInsights: &QueryInsightsConfiguration{MaxStatements: ptr.To(int32(1000))},
+				},
+			},
+		}
+		Expect(cluster.GetQueryInsights()).To(BeNil())
+		Expect(cluster.GetPostgresParameters()).To(BeEmpty())
+	})
+
+	It("enables pg_stat_statements, keeping the parameters of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Monitoring: &MonitoringConfiguration{
+					QueryInsights: &QueryInsightsConfiguration{
+						Enabled:       true,
+						MaxStatements: ptr.To(int32(10000)),
+					},
+				},
+			},
+		}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"pg_stat_statements.track": "all"}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{
+			"pg_stat_statements.max":   "10000",
+			"pg_stat_statements.track": "all",
+		}))
+	})
+
+	It("defaults the snapshots configuration", func() {
+		queryInsights := &QueryInsightsConfiguration{Enabled: true}
+		Expect(queryInsights.GetParameters()).To(HaveKeyWithValue("pg_stat_statements.track", "top"))
+		Expect(queryInsights.GetTopN()).To(Equal(10))
+		Expect(queryInsights.GetSnapshotInterval()).To(Equal(time.Minute))
+	})
+})
+
+var _ = Describe("OpenTelemetry configuration", func() {
+	It("is disabled by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetOpenTelemetry()).To(BeNil())
+	})
+
+	It("converts the sampling percentage to a ratio", func() {
+		openTelemetry := &OpenTelemetryConfiguration{Endpoint: "http://otel-collector:4318"}
+		Expect(openTelemetry.GetSampleRatio()).To(BeEquivalentTo(1))
+
+		openTelemetry.SamplingPercentage = ptr.To(int32(25))
+		Expect(openTelemetry.GetSampleRatio()).To(BeEquivalentTo(0.25))
+	})
+})
+
+var _ = Describe("Alerts configuration", func() {
+	It("doesn't create the PrometheusRule by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetAlerts()).To(BeNil())
+		Expect(cluster.IsPrometheusRuleEnabled()).To(BeFalse())
+
+		cluster.Spec.Monitoring = &MonitoringConfiguration{Alerts: &AlertsConfiguration{}}
+		Expect(cluster.IsPrometheusRuleEnabled()).To(BeFalse())
+
+		cluster.Spec.Monitoring.Alerts.Enabled = true
+		Expect(cluster.IsPrometheusRuleEnabled()).To(BeTrue())
+	})
+
+	It("uses the default thresholds", func() {
+		alerts := &AlertsConfiguration{Enabled: true}
+		Expect(alerts.GetFor()).To(Equal(5 * time.Minute))
+		Expect(alerts.GetMaxReplicationLag()).To(Equal(5 * time.Minute))
+		Expect(alerts.IsWALArchiveFailureEnabled()).To(BeTrue())
+		Expect(alerts.GetMaxBackupAge()).To(Equal(48 * time.Hour))
+		Expect(alerts.GetMaxConnectionsUsage()).To(BeEquivalentTo(80))
+		Expect(alerts.GetMaxDeadlocks()).To(BeEquivalentTo(10))
+	})
+
+	It("uses the configured thresholds", func() {
+		alerts := &AlertsConfiguration{
+			Enabled:             true,
+			For:                 &metav1.Duration{Duration: time.Minute},
+			MaxReplicationLag:   &metav1.Duration{},
+			WALArchiveFailure:   ptr.To(false),
+			MaxBackupAge:        &metav1.Duration{Duration: 24 * time.Hour},
+			MaxConnectionsUsage: ptr.To(int32(90)),
+			MaxDeadlocks:        ptr.To(int32(0)),
+		}
+		Expect(alerts.GetFor()).To(Equal(time.Minute))
+		Expect(alerts.GetMaxReplicationLag()).To(BeZero())
+		Expect(alerts.IsWALArchiveFailureEnabled()).To(BeFalse())
+		Expect(alerts.GetMaxBackupAge()).To(Equal(24 * time.Hour))
+		Expect(alerts.GetMaxConnectionsUsage()).To(BeEquivalentTo(90))
+		Expect(alerts.GetMaxDeadlocks()).To(BeZero())
+	})
+})
+
+var _ = Describe("Table statistics configuration", func() {
+	It("is disabled by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetTableStatistics()).To(BeNil())
+		Expect(cluster.GetTableStatisticsDatabases()).To(BeEmpty())
+
+		cluster.Spec.Monitoring = &MonitoringConfiguration{TableStatistics: &TableStatisticsConfiguration{}}
+		Expect(cluster.GetTableStatistics()).To(BeNil())
+	})
+
+	It("samples the application database by default", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Monitoring: &MonitoringConfiguration{
+					TableStatistics: &TableStatisticsConfiguration{Enabled: true},
+				},
+			},
+		}
+		Expect(cluster.GetTableStatisticsDatabases()).To(Equal([]string{"postgres"}))
+
+		cluster.Spec.Bootstrap = &BootstrapConfiguration{InitDB: &BootstrapInitDB{Database: "app"}}
+		Expect(cluster.GetTableStatisticsDatabases()).To(Equal([]string{"app"}))
+
+		cluster.Spec.Monitoring.TableStatistics.Databases = []string{"orders", "reports"}
+		Expect(cluster.GetTableStatisticsDatabases()).To(Equal([]string{"orders", "reports"}))
+	})
+
+	It("uses the default sampling options", func() {
+		tableStatistics := &TableStatisticsConfiguration{Enabled: true}
+		Expect(tableStatistics.GetTopN()).To(Equal(20))
+		Expect(tableStatistics.GetSamplingInterval()).To(Equal(5 * time.Minute))
+
+		tableStatistics.TopN = 5
+		tableStatistics.SamplingInterval = &metav1.Duration{Duration: time.Hour}
+		Expect(tableStatistics.GetTopN()).To(Equal(5))
+		Expect(tableStatistics.GetSamplingInterval()).To(Equal(time.Hour))
+	})
+})
+
+var _ = Describe("Autovacuum profile parameters", func() {
+	It("doesn't add any parameter without a profile", func() {
+		Expect(AutovacuumProfile("").GetParameters()).To(BeEmpty())
+	})
+
+	It("adds the parameters of the profile, keeping the ones of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					AutovacuumProfile: AutovacuumProfileHighChurn,
+					Parameters:        map[string]string{"autovacuum_naptime": "5s"},
+				},
+			},
+		}
+		parameters := cluster.GetPostgresParameters()
+		Expect(parameters).To(HaveKeyWithValue("autovacuum_naptime", "5s"))
+		Expect(parameters).To(HaveKeyWithValue("autovacuum_vacuum_scale_factor", "0.01"))
+		Expect(parameters).To(HaveKeyWithValue("autovacuum_vacuum_cost_delay", "1ms"))
+	})
+
+	It("has a preset for every workload", func() {
+		for _, profile := range []AutovacuumProfile{
+			AutovacuumProfileOLTP,
+			AutovacuumProfileAnalytics,
+			AutovacuumProfileHighChurn,
+		} {
+			Expect(profile.GetParameters()).To(HaveKey("autovacuum_vacuum_scale_factor"))
+		}
+	})
+})
+
+var _ = Describe("Automatic tuning parameters", func() {
+	It("doesn't derive any parameter without resources", func() {
+		cluster := Cluster{Spec: ClusterSpec{PostgresConfiguration: PostgresConfiguration{AutoTune: true}}}
+		Expect(cluster.GetAutoTuneParameters()).To(BeEmpty())
+	})
+
+	It("derives the parameters from the resources, keeping the ones of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					AutoTune:   true,
+					Parameters: map[string]string{"effective_cache_size": "1GB"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+						corev1.ResourceCPU:    resource.MustParse("2"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+						corev1.ResourceCPU:    resource.MustParse("3500m"),
+					},
+				},
+			},
+		}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{
+			"shared_buffers":                   "1024MB",
+			"effective_cache_size":             "1GB",
+			"maintenance_work_mem":             "256MB",
+			"work_mem":                         "10MB",
+			"max_worker_processes":             "8",
+			"max_parallel_workers":             "4",
+			"max_parallel_workers_per_gather":  "2",
+			"max_parallel_maintenance_workers": "2",
+		}))
+	})
+
+	It("sizes work_mem according to max_connections", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					AutoTune:   true,
+					Parameters: map[string]string{"max_connections": "20"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+			},
+		}
+		Expect(cluster.GetAutoTuneParameters()).To(HaveKeyWithValue("work_mem", "51MB"))
+	})
+})
+
+var _ = Describe("Vertical Pod Autoscaler resources", func() {
+	recommended := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	specified := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+
+	It("uses the recommended resources when the integration is enabled", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Resources:             specified,
+				VerticalPodAutoscaler: &VerticalPodAutoscalerConfiguration{Enabled: true},
+			},
+			Status: ClusterStatus{RecommendedResources: recommended},
+		}
+		Expect(cluster.GetResources()).To(Equal(*recommended))
+	})
+
+	It("uses the specified resources when the integration is disabled", func() {
+		cluster := Cluster{
+			Spec:   ClusterSpec{Resources: specified},
+			Status: ClusterStatus{RecommendedResources: recommended},
+		}
+		Expect(cluster.IsVerticalPodAutoscalerEnabled()).To(BeFalse())
+		Expect(cluster.GetResources()).To(Equal(specified))
+	})
+
+	It("defaults the configuration", func() {
+		var configuration *VerticalPodAutoscalerConfiguration
+		Expect(configuration.GetControlledValues()).To(Equal(VerticalPodAutoscalerControlledValuesRequestsAndLimits))
+		Expect(configuration.GetTolerance()).To(Equal(int32(10)))
+	})
+})
+
+var _ = Describe("Huge pages parameters", func() {
+	It("doesn't add any parameter without huge pages", func() {
+		cluster := Cluster{}
+		name, _ := cluster.GetHugePages()
+		Expect(name).To(BeEmpty())
+		Expect(cluster.GetHugePagesParameters()).To(BeNil())
+	})
+
+	It("requires the huge pages, keeping the parameters of the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Parameters: map[string]string{"huge_pages": "try"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{"hugepages-2Mi": resource.MustParse("512Mi")},
+				},
+			},
+		}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{"huge_pages": "try"}))
+	})
+
+	It("sets the size of the pages when it isn't the default one", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{"hugepages-1Gi": resource.MustParse("4Gi")},
+				},
+			},
+		}
+		Expect(cluster.GetHugePagesParameters()).To(Equal(map[string]string{
+			"huge_pages":     "on",
+			"huge_page_size": "1048576kB",
+		}))
+	})
+
+	It("keeps the automatically tuned shared buffers within the huge pages", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{AutoTune: true},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+						"hugepages-2Mi":       resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+		Expect(cluster.GetAutoTuneParameters()).To(HaveKeyWithValue("shared_buffers", "921MB"))
+	})
+})
+
+var _ = Describe("Storage auto resize", func() {
+	It("expands the volumes by a percentage of the current size by default", func() {
+		autoResize := &StorageAutoResizeConfiguration{Enabled: true}
+		nextSize, err := autoResize.GetNextSize(resource.MustParse("100Gi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextSize.String()).To(Equal("120Gi"))
+	})
+
+	It("expands the volumes by a fixed quantity", func() {
+		autoResize := &StorageAutoResizeConfiguration{Enabled: true, Step: "5Gi"}
+		nextSize, err := autoResize.GetNextSize(resource.MustParse("10Gi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextSize.String()).To(Equal("15Gi"))
+	})
+
+	It("caps the size to the maximum one", func() {
+		autoResize := &StorageAutoResizeConfiguration{Enabled: true, Step: "50%", MaxSize: "12Gi"}
+		nextSize, err := autoResize.GetNextSize(resource.MustParse("10Gi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextSize.String()).To(Equal("12Gi"))
+
+		nextSize, err = autoResize.GetNextSize(resource.MustParse("12Gi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextSize.String()).To(Equal("12Gi"))
+	})
+
+	It("rejects invalid steps", func() {
+		autoResize := &StorageAutoResizeConfiguration{Enabled: true, Step: "-10%"}
+		_, err := autoResize.GetNextSize(resource.MustParse("10Gi"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("uses the automatically expanded size when larger than the requested one", func() {
+		storage := StorageConfiguration{Size: "10Gi"}
+		Expect(storage.WithAutoResizedSize("").Size).To(Equal("10Gi"))
+		Expect(storage.WithAutoResizedSize("5Gi").Size).To(Equal("10Gi"))
+		Expect(storage.WithAutoResizedSize("12Gi").Size).To(Equal("12Gi"))
+	})
+})
+
+var _ = Describe("Ephemeral tablespaces", func() {
+	It("detects the ephemeral tablespaces", func() {
+		Expect((&TablespaceConfiguration{Name: "tbs"}).IsEphemeral()).To(BeFalse())
+		Expect((&TablespaceConfiguration{
+			Name:      "tbs",
+			Ephemeral: &EphemeralTablespaceConfiguration{},
+		}).IsEphemeral()).To(BeTrue())
+	})
+
+	It("defaults to generic ephemeral volumes", func() {
+		var ephemeral *EphemeralTablespaceConfiguration
+		Expect(ephemeral.GetVolumeType()).To(Equal(EphemeralTablespaceVolumeTypeGeneric))
+		Expect((&EphemeralTablespaceConfiguration{}).GetVolumeType()).To(Equal(EphemeralTablespaceVolumeTypeGeneric))
+		Expect((&EphemeralTablespaceConfiguration{
+			VolumeType: EphemeralTablespaceVolumeTypeEmptyDir,
+		}).GetVolumeType()).To(Equal(EphemeralTablespaceVolumeTypeEmptyDir))
+	})
+})
+
+var _ = Describe("Plugin backup retention", func() {
+	retentionPolicy := &VolumeSnapshotRetentionPolicy{KeepLast: ptr.To(7)}
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			Plugins: []PluginConfiguration{
+				{Name: "restic.example.com", BackupRetention: retentionPolicy},
+				{Name: "kopia.example.com", Enabled: ptr.To(false), BackupRetention: retentionPolicy},
+				{Name: "wal.example.com"},
+			},
+		},
+	}
+
+	It("gets the retention policy of the enabled plugins", func() {
+		Expect(cluster.GetPluginBackupRetention("restic.example.com")).To(Equal(retentionPolicy))
+		Expect(cluster.GetPluginBackupRetention("kopia.example.com")).To(BeNil())
+		Expect(cluster.GetPluginBackupRetention("wal.example.com")).To(BeNil())
+		Expect(cluster.GetPluginBackupRetention("unknown.example.com")).To(BeNil())
+	})
+})
+
+var _ = Describe("Plugin ordering", func() {
+	It("sorts the enabled plugins by priority and name", func() {
+		plugins := []PluginConfiguration{
+			{Name: "security.example.com", Priority: ptr.To(int32(10))},
+			{Name: "mesh.example.com"},
+			{Name: "disabled.example.com", Enabled: ptr.To(false)},
+			{Name: "logs.example.com"},
+			{Name: "network.example.com", Priority: ptr.To(int32(-5))},
+		}
+		Expect(GetPluginConfigurationEnabledPluginNames(plugins)).To(Equal([]string{
+			"network.example.com",
+			"logs.example.com",
+			"mesh.example.com",
+			"security.example.com",
+		}))
+	})
+})
+
+var _ = Describe("Lifecycle events", func() {
+	It("doesn't deliver the events without destinations", func() {
+		cluster := &Cluster{}
+		Expect(cluster.ShouldDeliverLifecycleEvent(LifecycleEventTypePromotion)).To(BeFalse())
+
+		cluster.Spec.LifecycleEvents = &LifecycleEventsConfiguration{}
+		Expect(cluster.ShouldDeliverLifecycleEvent(LifecycleEventTypePromotion)).To(BeFalse())
+	})
+
+	It("delivers every type of events by default", func() {
+		cluster := &Cluster{Spec: ClusterSpec{LifecycleEvents: &LifecycleEventsConfiguration{
+			NATS: &LifecycleEventsNATS{URL: "nats://nats.messaging", Subject: "cnpg.events"},
+		}}}
+		Expect(cluster.ShouldDeliverLifecycleEvent(LifecycleEventTypePromotion)).To(BeTrue())
+		Expect(cluster.ShouldDeliverLifecycleEvent(LifecycleEventTypeBackupCompleted)).To(BeTrue())
+	})
+
+	It("delivers only the selected types of events", func() {
+		cluster := &Cluster{Spec: ClusterSpec{LifecycleEvents: &LifecycleEventsConfiguration{
+			Types:   []LifecycleEventType{LifecycleEventTypeFailover},
+			Webhook: &LifecycleEventsWebhook{URL: "https://hooks.example.com/cnpg"},
+		}}}
+		Expect(cluster.ShouldDeliverLifecycleEvent(LifecycleEventTypeFailover)).To(BeTrue())
+		Expect(cluster.ShouldDeliverLifecycleEvent(LifecycleEventTypePromotion)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Pooler namespaces", func() {
+	It("allows the poolers of its own namespace and of the listed ones", func() {
+		cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "databases"}}
+		Expect(cluster.AllowsPoolersFrom("databases")).To(BeTrue())
+		Expect(cluster.AllowsPoolersFrom("apps")).To(BeFalse())
+
+		cluster.Spec.PoolerNamespaces = []string{"apps"}
+		Expect(cluster.AllowsPoolersFrom("apps")).To(BeTrue())
+		Expect(cluster.AllowsPoolersFrom("reports")).To(BeFalse())
+	})
+})
@@ -54,6 +54,15 @@ const (
 	// the generated server secret for PostgreSQL
 	ServerSecretSuffix = "-server"
 
+	// CloneSecretSuffix is the suffix appended to the secret containing
+	// the certificates used to connect to the cluster being cloned
+	CloneSecretSuffix = "-clone"
+
+	// PodSpecPreviewSuffix is the suffix appended to the cluster name to
+	// get the name of the ConfigMap containing the preview of the instance
+	// pod spec
+	PodSpecPreviewSuffix = "-pod-spec-preview"
+
 	// ServiceAnySuffix is the suffix appended to the cluster name to get the
 	// service name for every node (including non-ready ones)
 	ServiceAnySuffix = "-any"
@@ -71,10 +80,18 @@ const (
 	// data
 	ServiceReadWriteSuffix = "-rw"
 
+	// ServiceInstanceSuffix is the suffix appended to the instance name to
+	// get the name of the externally reachable service pointing to it
+	ServiceInstanceSuffix = "-external"
+
 	// ClusterSecretSuffix is the suffix appended to the cluster name to
 	// get the name of the pull secret
 	ClusterSecretSuffix = "-pull-secret"
 
+	// WitnessSuffix is the suffix appended to the cluster name to
+	// get the name of the witness Deployment and Service
+	WitnessSuffix = "-witness"
+
 	// WalArchiveVolumeSuffix is the suffix appended to the instance name to
 	// get the name of the PVC dedicated to WAL files.
 	WalArchiveVolumeSuffix = "-wal"
@@ -162,6 +179,50 @@ type VolumeSnapshotConfiguration struct {
 	// +kubebuilder:default:={waitForArchive:true,immediateCheckpoint:false}
 	// +optional
 	OnlineConfiguration OnlineConfiguration `json:"onlineConfiguration,omitempty"`
+
+	// The retention policy of the volume snapshot backups. Completed
+	// backups falling out of the policy are deleted by the operator,
+	// together with their volume snapshots
+	// +optional
+	Retention *VolumeSnapshotRetentionPolicy `json:"retention,omitempty"`
+
+	// The configuration of the incremental volume snapshot backups. When
+	// set, backups requested with the `incremental` level are taken with
+	// the incremental snapshot class. The CSI driver only stores the
+	// blocks changed since the previous snapshot, but every backup is
+	// restored on its own
+	// +optional
+	Incremental *VolumeSnapshotIncrementalConfiguration `json:"incremental,omitempty"`
+}
+
+// VolumeSnapshotIncrementalConfiguration contains the configuration
+// of the incremental volume snapshot backups
+type VolumeSnapshotIncrementalConfiguration struct {
+	// The Snapshot Class used for the incremental snapshots of every
+	// PersistentVolumeClaim. It must be configured to take incremental
+	// snapshots in the CSI driver. Defaults to the class used by the
+	// full backups
+	// +optional
+	ClassName string `json:"className,omitempty"`
+}
+
+// VolumeSnapshotRetentionPolicy defines which completed backups are kept,
+// and is used by both the volume snapshot backups and the plugin backups.
+// A backup is deleted as soon as it falls out of any of the configured
+// criteria
+type VolumeSnapshotRetentionPolicy struct {
+	// The number of most recent completed backups to keep
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepLast *int `json:"keepLast,omitempty"`
+
+	// The maximum age of a completed backup, expressed in the form
+	// of `XXu` where `XX` is a positive integer and `u` is in `[dwm]` -
+	// days, weeks, months. The most recent completed backup is always
+	// kept, regardless of its age
+	// +kubebuilder:validation:Pattern=^[1-9][0-9]*[dwm]$
+	// +optional
+	MaxAge string `json:"maxAge,omitempty"`
 }
 
 // OnlineConfiguration contains the configuration parameters for the online volume snapshot
@@ -198,6 +259,15 @@ type ImageCatalogRef struct {
 	Major int `json:"major"`
 }
 
+// ImageVerificationConfiguration defines how the cosign signatures of
+// the PostgreSQL images are verified before they are rolled out
+type ImageVerificationConfiguration struct {
+	// The ConfigMap keys containing the PEM encoded public keys trusted
+	// to sign the images
+	// +optional
+	PublicKeys []ConfigMapKeySelector `json:"publicKeys,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="!(has(self.imageCatalogRef) && has(self.imageName))",message="imageName and imageCatalogRef are mutually exclusive"
 
 // ClusterSpec defines the desired state of Cluster
@@ -220,6 +290,11 @@ type ClusterSpec struct {
 	// +optional
 	ImageCatalogRef *ImageCatalogRef `json:"imageCatalogRef,omitempty"`
 
+	// The verification of the cosign signatures of the PostgreSQL images.
+	// When defined, images without a trusted signature are not rolled out
+	// +optional
+	ImageVerification *ImageVerificationConfiguration `json:"imageVerification,omitempty"`
+
 	// Image pull policy.
 	// One of `Always`, `Never` or `IfNotPresent`.
 	// If not defined, it defaults to `IfNotPresent`.
@@ -302,6 +377,11 @@ type ClusterSpec struct {
 	// +optional
 	Certificates *CertificatesConfiguration `json:"certificates,omitempty"`
 
+	// The security profile of the cluster, hardening the connections
+	// to the instances
+	// +optional
+	Security *SecurityConfiguration `json:"security,omitempty"`
+
 	// The list of pull secrets to be used to pull the images
 	// +optional
 	ImagePullSecrets []LocalObjectReference `json:"imagePullSecrets,omitempty"`
@@ -310,6 +390,17 @@ type ClusterSpec struct {
 	// +optional
 	StorageConfiguration StorageConfiguration `json:"storage,omitempty"`
 
+	// The strategy to follow when the storage class of the volumes is changed:
+	// `none` (default) only uses the new storage class for the volumes created
+	// from now on, while `online` moves the instances to the new storage class
+	// one at a time, by creating a replacement replica, waiting for it to be
+	// in sync, and retiring the old instance, switching over the primary when
+	// needed
+	// +kubebuilder:validation:Enum:=none;online
+	// +kubebuilder:default:=none
+	// +optional
+	StorageClassMigrationStrategy StorageClassMigrationStrategy `json:"storageClassMigrationStrategy,omitempty"`
+
 	// Configure the generation of the service account
 	// +optional
 	ServiceAccountTemplate *ServiceAccountTemplate `json:"serviceAccountTemplate,omitempty"`
@@ -318,6 +409,11 @@ type ClusterSpec struct {
 	// +optional
 	WalStorage *StorageConfiguration `json:"walStorage,omitempty"`
 
+	// WALSpaceProtection configures how the instances protect themselves
+	// when the volume containing the WAL files is running out of space
+	// +optional
+	WALSpaceProtection *WALSpaceProtectionConfiguration `json:"walSpaceProtection,omitempty"`
+
 	// EphemeralVolumeSource allows the user to configure the source of ephemeral volumes.
 	// +optional
 	EphemeralVolumeSource *corev1.EphemeralVolumeSource `json:"ephemeralVolumeSource,omitempty"`
@@ -357,6 +453,17 @@ type ClusterSpec struct {
 	// +optional
 	FailoverDelay int32 `json:"failoverDelay,omitempty"`
 
+	// The policy deciding whether a failover can promote the most advanced
+	// replica without any intervention from the user
+	// +optional
+	Failover *FailoverConfiguration `json:"failover,omitempty"`
+
+	// The witness is a lightweight member of the cluster, storing no data,
+	// which is consulted before promoting a replica. It allows a cluster
+	// made of two instances to tell a failed primary from a network partition
+	// +optional
+	Witness *WitnessConfiguration `json:"witness,omitempty"`
+
 	// LivenessProbeTimeout is the time (in seconds) that is allowed for a PostgreSQL instance
 	// to successfully respond to the liveness probe (default 30).
 	// The Liveness probe failure threshold is derived from this value using the formula:
@@ -374,17 +481,37 @@ type ClusterSpec struct {
 	// +optional
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
+	// The zone constraints of the instances, from which the operator
+	// generates the scheduling constraints and which are honored when
+	// choosing the instance to be promoted
+	// +optional
+	Topology *TopologyConfiguration `json:"topology,omitempty"`
+
 	// Resources requirements of every generated Pod. Please refer to
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// for more information.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// The integration with the Vertical Pod Autoscaler. When enabled, the
+	// resources recommended by the autoscaler replace the ones specified
+	// in `resources` and are applied through a rolling update
+	// +optional
+	VerticalPodAutoscaler *VerticalPodAutoscalerConfiguration `json:"verticalPodAutoscaler,omitempty"`
+
 	// EphemeralVolumesSizeLimit allows the user to set the limits for the ephemeral
 	// volumes
 	// +optional
 	EphemeralVolumesSizeLimit *EphemeralVolumesSizeLimitConfiguration `json:"ephemeralVolumesSizeLimit,omitempty"`
 
+	// The priority of the cluster for the operator: when many clusters need
+	// to be reconciled at the same time, such as after a node outage, the
+	// ones with a higher priority are served first. Defaults to `0`
+	// +kubebuilder:validation:Minimum=-1000
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
 	// Name of the priority class which will be used in every generated Pod, if the PriorityClass
 	// specified does not exist, the pod will not be able to schedule.  Please refer to
 	// https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/#priorityclass
@@ -408,6 +535,26 @@ type ClusterSpec struct {
 	// +optional
 	PrimaryUpdateMethod PrimaryUpdateMethod `json:"primaryUpdateMethod,omitempty"`
 
+	// The coordination with the PgBouncer poolers of the cluster during
+	// the switchovers triggered by the operator
+	// +optional
+	PoolerDrain *PoolerDrainConfiguration `json:"poolerDrain,omitempty"`
+
+	// The namespaces, other than the one of the cluster, where Poolers
+	// are allowed to reference this cluster. The operator copies the
+	// certificates and the credentials needed by those Poolers into
+	// their namespaces
+	// +optional
+	PoolerNamespaces []string `json:"poolerNamespaces,omitempty"`
+
+	// The configuration of the PostgreSQL major version upgrades
+	// +optional
+	MajorUpgrade *MajorUpgradeConfiguration `json:"majorUpgrade,omitempty"`
+
+	// The configuration of the PostgreSQL minor version updates
+	// +optional
+	MinorUpgrade *MinorUpgradeConfiguration `json:"minorUpgrade,omitempty"`
+
 	// The configuration to be used for backups
 	// +optional
 	Backup *BackupConfiguration `json:"backup,omitempty"`
@@ -416,6 +563,29 @@ type ClusterSpec struct {
 	// +optional
 	NodeMaintenanceWindow *NodeMaintenanceWindow `json:"nodeMaintenanceWindow,omitempty"`
 
+	// The time windows in which the operator is allowed to perform
+	// disruptive operations, such as the rolling restarts required by
+	// configuration changes, image upgrades, and PVC attachments.
+	// Outside these windows, such operations are deferred until the next
+	// one. When no window is defined, they are performed immediately
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// The declarative hibernation of the cluster, immediate or scheduled
+	// +optional
+	Hibernation *HibernationConfiguration `json:"hibernation,omitempty"`
+
+	// The actions executed when the cluster goes through the events of
+	// its life, such as the bootstrap or a change of the primary instance
+	// +optional
+	LifecycleHooks []LifecycleHook `json:"lifecycleHooks,omitempty"`
+
+	// The delivery of the lifecycle events of the cluster, such as the
+	// promotion of a new primary or the completion of a backup, to
+	// external systems
+	// +optional
+	LifecycleEvents *LifecycleEventsConfiguration `json:"lifecycleEvents,omitempty"`
+
 	// The configuration of the monitoring infrastructure of this cluster
 	// +optional
 	Monitoring *MonitoringConfiguration `json:"monitoring,omitempty"`
@@ -430,6 +600,10 @@ type ClusterSpec struct {
 	// +optional
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// The configuration of the logs of the instances
+	// +optional
+	Logging *LoggingConfiguration `json:"logging,omitempty"`
+
 	// Template to be used to define projected volumes, projected volumes will be mounted
 	// under `/projected` base folder
 	// +optional
@@ -451,6 +625,18 @@ type ClusterSpec struct {
 	// +optional
 	Managed *ManagedConfiguration `json:"managed,omitempty"`
 
+	// The configuration of the detection of the manual changes to the
+	// resources managed by the operator
+	// +optional
+	DriftDetection *DriftDetectionConfiguration `json:"driftDetection,omitempty"`
+
+	// Paused halts every action of the operator on this cluster, that
+	// will only keep refreshing its status. The instances keep running
+	// and no failover or switchover will be executed until the cluster
+	// is resumed
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
 	// The SeccompProfile applied to every Pod and Container.
 	// Defaults to: `RuntimeDefault`
 	// +optional
@@ -472,6 +658,13 @@ type ClusterSpec struct {
 	// +optional
 	EnablePDB *bool `json:"enablePDB,omitempty"`
 
+	// The configuration of the PodDisruptionBudgets protecting the
+	// instances, used only when `enablePDB` is `true`, and of the way
+	// the operator reacts to the drain of the node running the primary
+	// instance
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
+
 	// The plugins configuration, containing
 	// any plugin to be loaded with the corresponding configuration
 	// +optional
@@ -550,10 +743,18 @@ const (
 	// PhaseUpgrade upgrade in process
 	PhaseUpgrade = "Upgrading cluster"
 
+	// PhaseStorageClassMigration is set while the instances are moved
+	// to a new storage class
+	PhaseStorageClassMigration = "Migrating the volumes to a new storage class"
+
 	// PhaseUpgradeDelayed is set when a cluster need to be upgraded
 	// but the operation is being delayed by the operator configuration
 	PhaseUpgradeDelayed = "Cluster upgrade delayed"
 
+	// PhaseMajorUpgrade is set when the PostgreSQL major version of the
+	// data directory is being upgraded
+	PhaseMajorUpgrade = "Upgrading Postgres major version"
+
 	// PhaseWaitingForUser set the status to wait for an action from the user
 	PhaseWaitingForUser = "Waiting for user action"
 
@@ -680,6 +881,32 @@ type ManagedRoles struct {
 	// PasswordStatus gives the last transaction id and password secret version for each managed role
 	// +optional
 	PasswordStatus map[string]PasswordState `json:"passwordStatus,omitempty"`
+
+	// PasswordRotation gives the time of the last password rotation
+	// done by the operator for each managed role
+	// +optional
+	PasswordRotation map[string]metav1.Time `json:"passwordRotation,omitempty"`
+
+	// LDAPRoleSync gives the status of the synchronization of the
+	// managed roles with the LDAP groups
+	// +optional
+	LDAPRoleSync *LDAPRoleSyncStatus `json:"ldapRoleSync,omitempty"`
+}
+
+// LDAPRoleSyncStatus is the status of the synchronization of the
+// managed roles with the LDAP groups
+type LDAPRoleSyncStatus struct {
+	// The managed roles added by the synchronization
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// The time of the last synchronization
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// The error raised by the last synchronization, if any
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // TablespaceState represents the state of a tablespace in a cluster
@@ -856,6 +1083,11 @@ type ClusterStatus struct {
 	// +optional
 	LastFailedBackup string `json:"lastFailedBackup,omitempty"`
 
+	// The status of the mirroring of WAL files and base backups to the
+	// secondary object store
+	// +optional
+	BackupMirror *BackupMirrorStatus `json:"backupMirror,omitempty"`
+
 	// The commit hash number of which this operator running
 	// +optional
 	CommitHash string `json:"cloudNativePGCommitHash,omitempty"`
@@ -905,10 +1137,71 @@ type ClusterStatus struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
+	// PGDataImageInfo contains the details of the latest image that
+	// has run on the current data directory
+	// +optional
+	PGDataImageInfo *ImageInfo `json:"pgDataImageInfo,omitempty"`
+
+	// LogicalMajorUpgrade contains the status of the ongoing logical
+	// major version upgrade
+	// +optional
+	LogicalMajorUpgrade *LogicalMajorUpgradeStatus `json:"logicalMajorUpgrade,omitempty"`
+
 	// PluginStatus is the status of the loaded plugins
 	// +optional
 	PluginStatus []PluginStatus `json:"pluginStatus,omitempty"`
 
+	// Maintenance reports the disruptive operations deferred
+	// until the next maintenance window
+	// +optional
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+
+	// MinorUpgrade contains the status of the ongoing canary
+	// minor version update
+	// +optional
+	MinorUpgrade *MinorUpgradeStatus `json:"minorUpgrade,omitempty"`
+
+	// PoolerDrain contains the status of the pause of the PgBouncer
+	// poolers requested before a switchover
+	// +optional
+	PoolerDrain *PoolerDrainStatus `json:"poolerDrain,omitempty"`
+
+	// LifecycleHooks contains the executions of the lifecycle hooks
+	// for the last occurrence of the events triggering them
+	// +optional
+	LifecycleHooks []LifecycleHookStatus `json:"lifecycleHooks,omitempty"`
+
+	// CertificateRotation contains the progress of the staged rotation
+	// of the TLS certificates of the instances and the poolers
+	// +optional
+	CertificateRotation *CertificateRotationStatus `json:"certificateRotation,omitempty"`
+
+	// RecommendedResources contains the resources recommended by the
+	// Vertical Pod Autoscaler and applied to the instances
+	// +optional
+	RecommendedResources *corev1.ResourceRequirements `json:"recommendedResources,omitempty"`
+
+	// Selector is the label selector matching the instances, used by
+	// the scale subresource
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// AutoResizedStorage contains the sizes the volumes have been
+	// automatically expanded to, following the disk usage
+	// +optional
+	AutoResizedStorage *AutoResizedStorageStatus `json:"autoResizedStorage,omitempty"`
+
+	// AdditionalReplicationSlots is the status of the additional physical
+	// replication slots on the primary instance
+	// +optional
+	AdditionalReplicationSlots []AdditionalReplicationSlotStatus `json:"additionalReplicationSlots,omitempty"`
+
+	// WALArchiverInstance is the standby instance elected to archive the
+	// WAL files, when the WAL archive target is `standby`. When empty, the
+	// WAL files are archived by the primary instance
+	// +optional
+	WALArchiverInstance string `json:"walArchiverInstance,omitempty"`
+
 	// SwitchReplicaClusterStatus is the status of the switch to replica cluster
 	// +optional
 	SwitchReplicaClusterStatus SwitchReplicaClusterStatus `json:"switchReplicaClusterStatus,omitempty"`
@@ -919,6 +1212,85 @@ type ClusterStatus struct {
 	// WAL file, and Time of latest checkpoint
 	// +optional
 	DemotionToken string `json:"demotionToken,omitempty"`
+
+	// PauseStatus reports since when and by whom the reconciliation
+	// of the cluster has been paused
+	// +optional
+	PauseStatus *ClusterPauseStatus `json:"pauseStatus,omitempty"`
+
+	// LastKnownPrimaryLSN is the last position of the primary instance
+	// observed by the operator, used to evaluate the lag of the replicas
+	// in case of failover when the failover policy is `lagBounded`
+	// +optional
+	LastKnownPrimaryLSN *PrimaryLSNStatus `json:"lastKnownPrimaryLSN,omitempty"`
+
+	// LastAcceptedSpec is the JSON encoded specification last accepted by
+	// the validation of the operator, when the admission webhooks are
+	// disabled. The following changes are validated against it
+	// +optional
+	LastAcceptedSpec string `json:"lastAcceptedSpec,omitempty"`
+}
+
+// ClusterPauseStatus contains the information about the pause of the
+// reconciliation of a cluster
+type ClusterPauseStatus struct {
+	// Since is the moment when the operator noticed the pause request
+	Since metav1.Time `json:"since"`
+
+	// By is the field manager which set the `paused` field, as recorded
+	// by the Kubernetes API server
+	// +optional
+	By string `json:"by,omitempty"`
+}
+
+// PrimaryLSNStatus contains a position of the primary instance
+// observed by the operator
+type PrimaryLSNStatus struct {
+	// Instance is the name of the primary instance
+	Instance string `json:"instance"`
+
+	// LSN is the position of the primary instance
+	LSN string `json:"lsn"`
+
+	// ObservedAt is the moment when the position has been observed
+	ObservedAt metav1.Time `json:"observedAt"`
+}
+
+// ImageInfo contains the information about a PostgreSQL image
+type ImageInfo struct {
+	// Image is the image name
+	Image string `json:"image"`
+
+	// MajorVersion is the major version of the image
+	MajorVersion int `json:"majorVersion"`
+}
+
+// LogicalMajorUpgradePhase is the phase of a logical major version upgrade
+type LogicalMajorUpgradePhase string
+
+const (
+	// LogicalMajorUpgradePhaseSynchronizing means that the target cluster
+	// has been created and is receiving the data from the source one
+	LogicalMajorUpgradePhaseSynchronizing LogicalMajorUpgradePhase = "Synchronizing"
+
+	// LogicalMajorUpgradePhaseCuttingOver means that the applications have
+	// been disconnected from the source cluster, and the target cluster is
+	// applying the last changes and synchronizing the sequences
+	LogicalMajorUpgradePhaseCuttingOver LogicalMajorUpgradePhase = "CuttingOver"
+
+	// LogicalMajorUpgradePhaseCompleted means that the services of the
+	// source cluster are routing the connections to the target cluster
+	LogicalMajorUpgradePhaseCompleted LogicalMajorUpgradePhase = "Completed"
+)
+
+// LogicalMajorUpgradeStatus contains the status of a logical major version upgrade
+type LogicalMajorUpgradeStatus struct {
+	// TargetCluster is the name of the cluster running the new major
+	// version, where the data is being replicated
+	TargetCluster string `json:"targetCluster"`
+
+	// Phase is the current phase of the upgrade
+	Phase LogicalMajorUpgradePhase `json:"phase"`
 }
 
 // SwitchReplicaClusterStatus contains all the statuses regarding the switch of a cluster to a replica cluster
@@ -926,6 +1298,11 @@ type SwitchReplicaClusterStatus struct {
 	// InProgress indicates if there is an ongoing procedure of switching a cluster to a replica cluster.
 	// +optional
 	InProgress bool `json:"inProgress,omitempty"`
+
+	// Upstream is the name of the external cluster the designated primary
+	// is currently streaming from, as computed from the replica cluster topology
+	// +optional
+	Upstream string `json:"upstream,omitempty"`
 }
 
 // InstanceReportedState describes the last reported state of an instance during a reconciliation loop
@@ -935,6 +1312,56 @@ type InstanceReportedState struct {
 	// indicates on which TimelineId the instance is
 	// +optional
 	TimeLineID int `json:"timeLineID,omitempty"`
+	// the state of the volumes of the instance, including the progress
+	// of their expansion
+	// +optional
+	Volumes []InstanceVolumeState `json:"volumes,omitempty"`
+}
+
+// VolumeResizeStatus is the progress of the expansion of a volume
+type VolumeResizeStatus string
+
+const (
+	// VolumeResizeStatusInProgress means that the storage provider is
+	// expanding the volume
+	VolumeResizeStatusInProgress VolumeResizeStatus = "ResizeInProgress"
+
+	// VolumeResizeStatusFileSystemPending means that the volume has been
+	// expanded, and the filesystem will be grown by the node. This may
+	// require the instance to be restarted
+	VolumeResizeStatusFileSystemPending VolumeResizeStatus = "FileSystemResizePending"
+)
+
+// InstanceVolumeState reports the size of a volume of an instance
+// and the progress of its expansion
+type InstanceVolumeState struct {
+	// The name of the PVC
+	PVCName string `json:"pvcName"`
+
+	// The role of the PVC
+	Role string `json:"role"`
+
+	// The name of the tablespace stored in the volume, if any
+	// +optional
+	TablespaceName string `json:"tablespaceName,omitempty"`
+
+	// The size requested for the PVC
+	// +optional
+	RequestedSize string `json:"requestedSize,omitempty"`
+
+	// The capacity of the volume bound to the PVC
+	// +optional
+	Capacity string `json:"capacity,omitempty"`
+
+	// The size of the filesystem, as reported by the instance
+	// +optional
+	FileSystemSize string `json:"fileSystemSize,omitempty"`
+
+	// The progress of the expansion of the volume. Empty when
+	// the volume is not being expanded
+	// +optional
+	// +kubebuilder:validation:Enum=ResizeInProgress;FileSystemResizePending
+	ResizeStatus VolumeResizeStatus `json:"resizeStatus,omitempty"`
 }
 
 // ClusterConditionType defines types of cluster conditions
@@ -947,6 +1374,46 @@ const (
 	ConditionContinuousArchiving ClusterConditionType = "ContinuousArchiving"
 	// ConditionBackup represents the last backup's status
 	ConditionBackup ClusterConditionType = "LastBackupSucceeded"
+	// ConditionBackupMirrorSynchronized represents whether the secondary object
+	// store is aligned with the primary one
+	ConditionBackupMirrorSynchronized ClusterConditionType = "BackupMirrorSynchronized"
+	// ConditionWALArchiveHealthy represents whether the WAL archive is
+	// receiving the WAL files generated by the primary instance
+	ConditionWALArchiveHealthy ClusterConditionType = "WALArchiveHealthy"
+	// ConditionWALSpaceAvailable represents whether the volume containing
+	// the WAL files of the primary instance has enough free space
+	ConditionWALSpaceAvailable ClusterConditionType = "WALSpaceAvailable"
+	// ConditionReplicationSlotsHealthy represents whether the replication
+	// slots of the primary instance are within the limits of the safeguard
+	ConditionReplicationSlotsHealthy ClusterConditionType = "ReplicationSlotsHealthy"
+	// ConditionLogicalUpgradeSynchronized represents whether the target
+	// cluster of a logical major version upgrade is aligned with the source one
+	ConditionLogicalUpgradeSynchronized ClusterConditionType = "LogicalUpgradeSynchronized"
+	// ConditionMigrationSynchronized represents whether a cluster bootstrapped
+	// with a migration is aligned with the external cluster it migrates from
+	ConditionMigrationSynchronized ClusterConditionType = "MigrationSynchronized"
+	// ConditionSplitBrainDetected represents whether both this cluster and
+	// the source cluster of its distributed topology are running as primary
+	ConditionSplitBrainDetected ClusterConditionType = "SplitBrainDetected"
+	// ConditionUpgradeBlocked represents whether a minor version update
+	// has been rolled back because the canary replica was not healthy
+	ConditionUpgradeBlocked ClusterConditionType = "UpgradeBlocked"
+	// ConditionImageVerified represents whether the signature of the
+	// requested PostgreSQL image has been verified
+	ConditionImageVerified ClusterConditionType = "ImageVerified"
+	// ConditionHugePagesAvailable represents whether the nodes where the
+	// instances can be scheduled have the requested huge pages
+	ConditionHugePagesAvailable ClusterConditionType = "HugePagesAvailable"
+	// ConditionStorageAutoResized represents whether the volumes have been
+	// automatically expanded following the disk usage
+	ConditionStorageAutoResized ClusterConditionType = "StorageAutoResized"
+	// ConditionSpecValid represents whether the specification of the
+	// cluster passed the validation performed by the operator when the
+	// admission webhooks are disabled
+	ConditionSpecValid ClusterConditionType = "SpecValid"
+	// ConditionDriftDetected represents whether the resources managed by
+	// the operator have been manually changed
+	ConditionDriftDetected ClusterConditionType = "DriftDetected"
 	// ConditionClusterReady represents whether a cluster is Ready
 	ConditionClusterReady ClusterConditionType = "Ready"
 )
@@ -989,6 +1456,155 @@ const (
 	// the WAL archiving is not working correctly
 	ConditionReasonContinuousArchivingFailing ConditionReason = "ContinuousArchivingFailing"
 
+	// ConditionReasonBackupMirrorSynchronized means that every WAL file and base backup
+	// has been copied to the secondary object store
+	ConditionReasonBackupMirrorSynchronized ConditionReason = "BackupMirrorSynchronized"
+
+	// ConditionReasonBackupMirrorLagging means that the copy of WAL files to the
+	// secondary object store is lagging behind the primary one
+	ConditionReasonBackupMirrorLagging ConditionReason = "BackupMirrorLagging"
+
+	// ConditionReasonBackupMirrorFailing means that the copy of WAL files or
+	// base backups to the secondary object store is failing
+	ConditionReasonBackupMirrorFailing ConditionReason = "BackupMirrorFailing"
+
+	// ConditionReasonBackupMirrorSpoolFull means that some WAL files have not
+	// been copied to the secondary object store, because the queue of the
+	// WAL files waiting to be copied was full
+	ConditionReasonBackupMirrorSpoolFull ConditionReason = "BackupMirrorSpoolFull"
+
+	// ConditionReasonWALArchiveHealthy means that the WAL files are being
+	// archived in time and the WAL archive is usable
+	ConditionReasonWALArchiveHealthy ConditionReason = "WALArchiveHealthy"
+
+	// ConditionReasonWALArchiveStalled means that there are WAL files which
+	// are waiting to be archived for longer than the configured delay
+	ConditionReasonWALArchiveStalled ConditionReason = "WALArchiveStalled"
+
+	// ConditionReasonWALArchiveCheckFailed means that the WAL archive
+	// can't be reached or contains WAL files from a newer timeline
+	ConditionReasonWALArchiveCheckFailed ConditionReason = "WALArchiveCheckFailed"
+
+	// ConditionReasonWALSpaceAvailable means that the usage of the volume
+	// containing the WAL files is below the threshold
+	ConditionReasonWALSpaceAvailable ConditionReason = "WALSpaceAvailable"
+
+	// ConditionReasonWALSpaceLow means that the usage of the volume containing
+	// the WAL files reached the threshold, and the primary instance entered
+	// the protective mode
+	ConditionReasonWALSpaceLow ConditionReason = "WALSpaceLow"
+
+	// ConditionReasonReplicationSlotsWithinLimits means that every replication
+	// slot of the primary instance is within the limits of the safeguard
+	ConditionReasonReplicationSlotsWithinLimits ConditionReason = "ReplicationSlotsWithinLimits"
+
+	// ConditionReasonReplicationSlotsExceedingLimits means that some replication
+	// slots of the primary instance exceed the limits of the safeguard
+	ConditionReasonReplicationSlotsExceedingLimits ConditionReason = "ReplicationSlotsExceedingLimits"
+
+	// ConditionReasonLogicalUpgradeCopying means that the initial copy of
+	// the tables of a logical major version upgrade is in progress
+	ConditionReasonLogicalUpgradeCopying ConditionReason = "LogicalUpgradeCopying"
+
+	// ConditionReasonLogicalUpgradeStreaming means that every table has been
+	// copied and the changes are being streamed from the source cluster
+	ConditionReasonLogicalUpgradeStreaming ConditionReason = "LogicalUpgradeStreaming"
+
+	// ConditionReasonLogicalUpgradeCuttingOver means that the target cluster
+	// is applying the last changes received from the source cluster
+	ConditionReasonLogicalUpgradeCuttingOver ConditionReason = "LogicalUpgradeCuttingOver"
+
+	// ConditionReasonLogicalUpgradeCompleted means that the sequences have been
+	// synchronized and the subscriptions have been removed
+	ConditionReasonLogicalUpgradeCompleted ConditionReason = "LogicalUpgradeCompleted"
+
+	// ConditionReasonLogicalUpgradeFailed means that the target cluster
+	// can't replicate the data from the source cluster
+	ConditionReasonLogicalUpgradeFailed ConditionReason = "LogicalUpgradeFailed"
+
+	// ConditionReasonMigrationCopying means that the initial copy of
+	// the tables of a migration is in progress
+	ConditionReasonMigrationCopying ConditionReason = "MigrationCopying"
+
+	// ConditionReasonMigrationStreaming means that every table has been
+	// copied and the changes are being streamed from the external cluster
+	ConditionReasonMigrationStreaming ConditionReason = "MigrationStreaming"
+
+	// ConditionReasonMigrationCuttingOver means that the cluster is applying
+	// the last changes received from the external cluster
+	ConditionReasonMigrationCuttingOver ConditionReason = "MigrationCuttingOver"
+
+	// ConditionReasonMigrationCompleted means that the sequences have been
+	// synchronized and the subscriptions have been removed
+	ConditionReasonMigrationCompleted ConditionReason = "MigrationCompleted"
+
+	// ConditionReasonMigrationFailed means that the cluster can't
+	// replicate the data from the external cluster
+	ConditionReasonMigrationFailed ConditionReason = "MigrationFailed"
+
+	// ConditionReasonSplitBrainNotDetected means that the source cluster
+	// of the distributed topology is running as a replica
+	ConditionReasonSplitBrainNotDetected ConditionReason = "SplitBrainNotDetected"
+
+	// ConditionReasonSplitBrainLessAdvanced means that the source cluster is
+	// running as primary too, and that this cluster is the less advanced one,
+	// whose primary instance is fenced by the operator
+	ConditionReasonSplitBrainLessAdvanced ConditionReason = "SplitBrainLessAdvanced"
+
+	// ConditionReasonSplitBrainMoreAdvanced means that the source cluster is
+	// running as primary too, and that this cluster is the more advanced one
+	ConditionReasonSplitBrainMoreAdvanced ConditionReason = "SplitBrainMoreAdvanced"
+
+	// ConditionReasonCanaryUnhealthy means that the canary replica of a
+	// minor version update failed the health checks
+	ConditionReasonCanaryUnhealthy ConditionReason = "CanaryUnhealthy"
+
+	// ConditionReasonSignatureVerified means that the requested image has
+	// a trusted signature
+	ConditionReasonSignatureVerified ConditionReason = "SignatureVerified"
+
+	// ConditionReasonSignatureRejected means that the requested image has
+	// no trusted signature, and has not been rolled out
+	ConditionReasonSignatureRejected ConditionReason = "SignatureRejected"
+
+	// ConditionReasonHugePagesAvailable means that at least one node can
+	// allocate the huge pages requested by the instances
+	ConditionReasonHugePagesAvailable ConditionReason = "HugePagesAvailable"
+
+	// ConditionReasonHugePagesUnavailable means that no node can allocate
+	// the huge pages requested by the instances
+	ConditionReasonHugePagesUnavailable ConditionReason = "HugePagesUnavailable"
+
+	// ConditionReasonStorageExpanded means that the volumes have been
+	// expanded because the disk usage reached the threshold
+	ConditionReasonStorageExpanded ConditionReason = "StorageExpanded"
+
+	// ConditionReasonStorageMaxSizeReached means that the disk usage reached
+	// the threshold, but the volumes cannot be expanded beyond the maximum size
+	ConditionReasonStorageMaxSizeReached ConditionReason = "MaxSizeReached"
+
+	// ConditionReasonSpecAccepted means that the specification of the
+	// cluster is valid
+	ConditionReasonSpecAccepted ConditionReason = "SpecAccepted"
+
+	// ConditionReasonSpecRejected means that the specification of the
+	// cluster is not valid, and the cluster is not reconciled until the
+	// errors are fixed
+	ConditionReasonSpecRejected ConditionReason = "SpecRejected"
+
+	// ConditionReasonNoDrift means that the resources managed by the
+	// operator match their expected state
+	ConditionReasonNoDrift ConditionReason = "NoDrift"
+
+	// ConditionReasonDriftReverted means that some resources managed by
+	// the operator have been manually changed, and that the operator is
+	// reverting the changes
+	ConditionReasonDriftReverted ConditionReason = "DriftReverted"
+
+	// ConditionReasonDriftDetected means that some resources managed by
+	// the operator have been manually changed, and are left untouched
+	ConditionReasonDriftDetected ConditionReason = "DriftDetected"
+
 	// ClusterReady means that the condition changed because the cluster is ready and working properly
 	ClusterReady ConditionReason = "ClusterIsReady"
 
@@ -999,6 +1615,74 @@ const (
 	DetachedVolume ConditionReason = "DetachedVolume"
 )
 
+// PodConditionPrimaryRejoin is the condition of the Pod of a former primary
+// instance, reporting how its data directory has been realigned with the
+// new primary after a failover
+const PodConditionPrimaryRejoin corev1.PodConditionType = "cnpg.io/primaryRejoin"
+
+const (
+	// PrimaryRejoinReasonRewound means that the former primary has been
+	// realigned with the new primary using pg_rewind
+	PrimaryRejoinReasonRewound = "Rewound"
+
+	// PrimaryRejoinReasonRecloned means that the data directory of the
+	// former primary has been cloned again from the new primary
+	PrimaryRejoinReasonRecloned = "Recloned"
+
+	// PrimaryRejoinReasonFailed means that the former primary couldn't
+	// be realigned with the new primary
+	PrimaryRejoinReasonFailed = "RejoinFailed"
+)
+
+// BackupMirrorStatus is the status of the mirroring of WAL files and
+// base backups to the secondary object store
+type BackupMirrorStatus struct {
+	// The name of the last WAL file copied to the secondary object store
+	// +optional
+	LastMirroredWAL string `json:"lastMirroredWAL,omitempty"`
+
+	// When the last WAL file has been copied to the secondary object store,
+	// stored as a date in RFC3339 format
+	// +optional
+	LastMirroredWALTime string `json:"lastMirroredWALTime,omitempty"`
+
+	// The number of WAL files archived in the primary object store and
+	// still waiting to be copied to the secondary one
+	// +optional
+	PendingWALFiles int `json:"pendingWALFiles,omitempty"`
+
+	// When the oldest WAL file waiting to be copied has been archived in
+	// the primary object store, stored as a date in RFC3339 format
+	// +optional
+	OldestPendingWALTime string `json:"oldestPendingWALTime,omitempty"`
+
+	// The last WAL file that has not been copied to the secondary object
+	// store because the queue of the WAL files waiting to be copied was
+	// full. Until a base backup starting after this WAL file is taken in
+	// the secondary object store, recovering from it is not possible
+	// +optional
+	LastSkippedWAL string `json:"lastSkippedWAL,omitempty"`
+
+	// The ID of the last base backup taken in the secondary object store
+	// +optional
+	LastMirroredBackupID string `json:"lastMirroredBackupID,omitempty"`
+
+	// The first WAL file needed by the last base backup taken in the
+	// secondary object store
+	// +optional
+	LastMirroredBackupBeginWAL string `json:"lastMirroredBackupBeginWAL,omitempty"`
+
+	// When the last base backup has been taken in the secondary object
+	// store, stored as a date in RFC3339 format
+	// +optional
+	LastMirroredBackupTime string `json:"lastMirroredBackupTime,omitempty"`
+
+	// The error raised while taking the last base backup in the secondary
+	// object store, if any
+	// +optional
+	LastBackupError string `json:"lastBackupError,omitempty"`
+}
+
 // EmbeddedObjectMetadata contains metadata to be inherited by all resources related to a Cluster
 type EmbeddedObjectMetadata struct {
 	// +optional
@@ -1056,6 +1740,78 @@ type ReplicaClusterConfiguration struct {
 	// token cannot be used.
 	// +optional
 	MinApplyDelay *metav1.Duration `json:"minApplyDelay,omitempty"`
+
+	// Topology declares the cascading links between the clusters of the
+	// distributed PostgreSQL cluster. Every link connects a cluster to
+	// the upstream cluster it streams from. The direction of each link is
+	// computed starting from `primary`, so that a promotion automatically
+	// re-points every downstream replica cluster. Clusters not appearing
+	// in the topology stream directly from `source`
+	// +optional
+	Topology []ReplicaClusterTopologyLink `json:"topology,omitempty"`
+
+	// PromotionTokenSecret is a reference to a secret, shared between the
+	// clusters of the distributed PostgreSQL cluster, used to coordinate a
+	// controlled switchover. When a primary cluster is demoted, the operator
+	// publishes the demotion token in this secret. When a replica cluster is
+	// promoted without an explicit `promotionToken`, its designated primary
+	// waits for the token to be published and for the WAL up to the token to
+	// be replayed before promoting itself
+	// +optional
+	PromotionTokenSecret *LocalObjectReference `json:"promotionTokenSecret,omitempty"`
+
+	// When enabled, the designated primary of this replica cluster streams
+	// from the source cluster using the name of this cluster (see `self`)
+	// as `application_name`, so that the source cluster can include it in
+	// its `synchronous_standby_names`. Refer to the `synchronousReplica`
+	// option of the external clusters to configure the source cluster
+	// +optional
+	Synchronous *bool `json:"synchronous,omitempty"`
+
+	// The action taken by the designated primary of this replica cluster
+	// when, while starting up, its timeline diverges from the one of the
+	// source cluster. `manual` (default) requires a manual intervention,
+	// `rewind` realigns it with `pg_rewind`, `reclone` realigns it with
+	// `pg_rewind` and re-clones it with `pg_basebackup` if that fails
+	// +kubebuilder:validation:Enum=manual;rewind;reclone
+	// +optional
+	OnTimelineDivergence TimelineDivergencePolicy `json:"onTimelineDivergence,omitempty"`
+}
+
+// TimelineDivergencePolicy is the action taken by the designated primary of
+// a replica cluster when its timeline diverges from the source cluster one
+type TimelineDivergencePolicy string
+
+const (
+	// TimelineDivergencePolicyManual means that a diverged designated primary
+	// is left as is, requiring a manual intervention
+	TimelineDivergencePolicyManual TimelineDivergencePolicy = "manual"
+
+	// TimelineDivergencePolicyRewind means that a diverged designated primary
+	// is realigned with the source cluster using pg_rewind
+	TimelineDivergencePolicyRewind TimelineDivergencePolicy = "rewind"
+
+	// TimelineDivergencePolicyReclone means that a diverged designated primary
+	// is realigned with the source cluster using pg_rewind, and re-cloned
+	// with pg_basebackup when pg_rewind fails
+	TimelineDivergencePolicyReclone TimelineDivergencePolicy = "reclone"
+)
+
+// PromotionTokenSecretKey is the key of the promotion token secret
+// containing the demotion token of the former primary cluster
+const PromotionTokenSecretKey = "token"
+
+// ReplicaClusterTopologyLink is a link between two clusters of a
+// distributed PostgreSQL cluster, referenced by their name in
+// externalClusters
+type ReplicaClusterTopologyLink struct {
+	// The name of the downstream cluster
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The name of the cluster `name` is cascading from
+	// +kubebuilder:validation:MinLength=1
+	Upstream string `json:"upstream"`
 }
 
 // DefaultReplicationSlotsUpdateInterval is the default in seconds for the replication slots update interval
@@ -1106,41 +1862,158 @@ type ReplicationSlotsConfiguration struct {
 	// Configures the synchronization of the user defined physical replication slots
 	// +optional
 	SynchronizeReplicas *SynchronizeReplicasConfiguration `json:"synchronizeReplicas,omitempty"`
-}
 
-// ReplicationSlotsHAConfiguration encapsulates the configuration
-// of the replication slots that are automatically managed by
-// the operator to control the streaming replication connections
-// with the standby instances for high availability (HA) purposes.
-// Replication slots are a PostgreSQL feature that makes sure
-// that PostgreSQL automatically keeps WAL files in the primary
-// when a streaming client (in this specific case a replica that
-// is part of the HA cluster) gets disconnected.
-type ReplicationSlotsHAConfiguration struct {
-	// If enabled (default), the operator will automatically manage replication slots
-	// on the primary instance and use them in streaming replication
-	// connections with all the standby instances that are part of the HA
-	// cluster. If disabled, the operator will not take advantage
-	// of replication slots in streaming connections with the replicas.
-	// This feature also controls replication slots in replica cluster,
-	// from the designated primary to its cascading replicas.
+	// Configures the synchronization of the logical replication slots
+	// to the standby instances, allowing the logical decoding consumers
+	// to survive a failover
 	// +optional
-	// +kubebuilder:default:=true
-	Enabled *bool `json:"enabled,omitempty"`
+	SynchronizeLogicalSlots *SynchronizeLogicalSlotsConfiguration `json:"synchronizeLogicalSlots,omitempty"`
 
-	// Prefix for replication slots managed by the operator for HA.
-	// It may only contain lower case letters, numbers, and the underscore character.
-	// This can only be set at creation time. By default set to `_cnpg_`.
-	// +kubebuilder:default:=_cnpg_
-	// +kubebuilder:validation:Pattern=^[0-9a-z_]*$
+	// Configures the monitoring of the replication slots of the primary
+	// instance, and what to do with the ones retaining too much WAL or
+	// being inactive for too long
 	// +optional
-	SlotPrefix string `json:"slotPrefix,omitempty"`
+	Safeguard *ReplicationSlotsSafeguardConfiguration `json:"safeguard,omitempty"`
+
+	// Additional physical replication slots, managed by the operator on
+	// the primary instance, for consumers outside the cluster such as an
+	// external standby. They are created on the new primary after a
+	// failover or a switchover
+	// +optional
+	Additional []AdditionalReplicationSlot `json:"additional,omitempty"`
 }
 
-// KubernetesUpgradeStrategy tells the operator if the user want to
-// allocate more space while upgrading a k8s node which is hosting
-// the PostgreSQL Pods or just wait for the node to come up
-type KubernetesUpgradeStrategy string
+// AdditionalReplicationSlot is a physical replication slot for a consumer
+// outside the cluster
+type AdditionalReplicationSlot struct {
+	// The name of the replication slot. It may only contain lower case
+	// letters, numbers, and the underscore character
+	// +kubebuilder:validation:Pattern=^[0-9a-z_]*$
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+}
+
+// AdditionalReplicationSlotStatus is the status of an additional
+// replication slot, as reported by the primary instance
+type AdditionalReplicationSlotStatus struct {
+	// The name of the replication slot
+	Name string `json:"name"`
+
+	// Whether a consumer is streaming from the replication slot
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// The oldest WAL location still required by the consumer
+	// +optional
+	RestartLSN string `json:"restartLSN,omitempty"`
+
+	// The amount of WAL, in bytes, between the current WAL location
+	// of the primary and the restart LSN of the replication slot
+	// +optional
+	LagBytes *int64 `json:"lagBytes,omitempty"`
+}
+
+// ReplicationSlotsSafeguardAction is the action taken on the replication
+// slots exceeding the limits of the safeguard
+type ReplicationSlotsSafeguardAction string
+
+const (
+	// ReplicationSlotsSafeguardActionReport means that the replication slots
+	// exceeding the limits are only reported in the cluster status
+	ReplicationSlotsSafeguardActionReport ReplicationSlotsSafeguardAction = "report"
+
+	// ReplicationSlotsSafeguardActionDrop means that the inactive replication
+	// slots exceeding the limits are dropped
+	ReplicationSlotsSafeguardActionDrop ReplicationSlotsSafeguardAction = "drop"
+
+	// ReplicationSlotsSafeguardActionInvalidate means that PostgreSQL is
+	// configured to invalidate the replication slots exceeding the limits,
+	// via `max_slot_wal_keep_size` and `idle_replication_slot_timeout`
+	ReplicationSlotsSafeguardActionInvalidate ReplicationSlotsSafeguardAction = "invalidate"
+)
+
+// ReplicationSlotsSafeguardConfiguration contains the limits on the WAL
+// retained by the replication slots of the primary instance, and on their
+// inactivity, protecting the volume containing the WAL files
+type ReplicationSlotsSafeguardConfiguration struct {
+	// Enables the monitoring of the replication slots
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled"`
+
+	// The maximum size of the WAL files a replication slot can retain,
+	// for example `10Gi`. Not limited when not set
+	// +optional
+	MaxRetainedWALSize string `json:"maxRetainedWALSize,omitempty"`
+
+	// The maximum time a replication slot can be inactive, for example
+	// `24h`. Not limited when not set
+	// +optional
+	MaxInactiveTime *metav1.Duration `json:"maxInactiveTime,omitempty"`
+
+	// The action taken on the replication slots exceeding the limits:
+	// `report` (default) only reports them in the cluster status, `drop`
+	// drops the inactive ones, and `invalidate` configures PostgreSQL to
+	// invalidate them. The replication slots for high availability are
+	// never dropped by the operator
+	// +kubebuilder:validation:Enum=report;drop;invalidate
+	// +kubebuilder:default:=report
+	// +optional
+	Action ReplicationSlotsSafeguardAction `json:"action,omitempty"`
+}
+
+// SynchronizeLogicalSlotsConfiguration contains the configuration for the
+// synchronization of the logical replication slots to the standby instances.
+// With PostgreSQL 17 and later the native slot synchronization is used, and
+// only the slots created with the `failover` option are synchronized. With
+// older versions, the `pg_failover_slots` extension is used
+type SynchronizeLogicalSlotsConfiguration struct {
+	// When set to true, the logical replication slots of the primary
+	// are synchronized to the standby instances
+	Enabled bool `json:"enabled"`
+
+	// The names of the logical replication slots to be synchronized
+	// by the `pg_failover_slots` extension. When empty, every logical
+	// replication slot is synchronized. Not supported with PostgreSQL 17
+	// and later, where the slots to be synchronized are the ones created
+	// with the `failover` option
+	// +optional
+	SlotNames []string `json:"slotNames,omitempty"`
+}
+
+// ReplicationSlotsHAConfiguration encapsulates the configuration
+// of the replication slots that are automatically managed by
+// the operator to control the streaming replication connections
+// with the standby instances for high availability (HA) purposes.
+// Replication slots are a PostgreSQL feature that makes sure
+// that PostgreSQL automatically keeps WAL files in the primary
+// when a streaming client (in this specific case a replica that
+// is part of the HA cluster) gets disconnected.
+type ReplicationSlotsHAConfiguration struct {
+	// If enabled (default), the operator will automatically manage replication slots
+	// on the primary instance and use them in streaming replication
+	// connections with all the standby instances that are part of the HA
+	// cluster. If disabled, the operator will not take advantage
+	// of replication slots in streaming connections with the replicas.
+	// This feature also controls replication slots in replica cluster,
+	// from the designated primary to its cascading replicas.
+	// +optional
+	// +kubebuilder:default:=true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Prefix for replication slots managed by the operator for HA.
+	// It may only contain lower case letters, numbers, and the underscore character.
+	// This can only be set at creation time. By default set to `_cnpg_`.
+	// +kubebuilder:default:=_cnpg_
+	// +kubebuilder:validation:Pattern=^[0-9a-z_]*$
+	// +optional
+	SlotPrefix string `json:"slotPrefix,omitempty"`
+}
+
+// KubernetesUpgradeStrategy tells the operator if the user want to
+// allocate more space while upgrading a k8s node which is hosting
+// the PostgreSQL Pods or just wait for the node to come up
+type KubernetesUpgradeStrategy string
 
 const (
 	// KubernetesUpgradeStrategyAllocateSpace means that the operator
@@ -1172,6 +2045,115 @@ type NodeMaintenanceWindow struct {
 	InProgress bool `json:"inProgress,omitempty"`
 }
 
+// MaintenanceWindow defines a recurring time window in which the
+// operator is allowed to perform disruptive operations
+type MaintenanceWindow struct {
+	// The start of the window, in Cron format with the seconds field,
+	// see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format.
+	// The schedule is evaluated in UTC
+	Schedule string `json:"schedule"`
+
+	// How long the window stays open after each start
+	Duration metav1.Duration `json:"duration"`
+}
+
+// HibernationMode is the set of instances shut down by the hibernation
+type HibernationMode string
+
+const (
+	// HibernationModeFull means that every instance is shut down
+	HibernationModeFull HibernationMode = "full"
+
+	// HibernationModeMinimal means that only the primary instance
+	// keeps running
+	HibernationModeMinimal HibernationMode = "minimal"
+)
+
+// HibernationConfiguration defines when the cluster is hibernated. The
+// instances are shut down, while their persistent volume claims are kept
+// to resume the cluster when the hibernation ends
+type HibernationConfiguration struct {
+	// When true, the cluster is hibernated until set to false. This is
+	// equivalent to the `cnpg.io/hibernation` annotation set to `on`
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The time windows in which the cluster is hibernated, such as
+	// nights and weekends. The cluster resumes when every window is
+	// closed
+	// +optional
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+
+	// The instances shut down by the hibernation: `full` (default) shuts
+	// down every instance, `minimal` keeps the primary instance running
+	// +kubebuilder:validation:Enum=full;minimal
+	// +kubebuilder:default:=full
+	// +optional
+	Mode HibernationMode `json:"mode,omitempty"`
+}
+
+// VerticalPodAutoscalerControlledValues is the set of resource
+// values controlled by the Vertical Pod Autoscaler
+type VerticalPodAutoscalerControlledValues string
+
+const (
+	// VerticalPodAutoscalerControlledValuesRequestsOnly means that only
+	// the requests are replaced by the recommended ones
+	VerticalPodAutoscalerControlledValuesRequestsOnly VerticalPodAutoscalerControlledValues = "RequestsOnly"
+
+	// VerticalPodAutoscalerControlledValuesRequestsAndLimits means that
+	// the limits are scaled together with the requests, keeping the
+	// specified ratio between them
+	VerticalPodAutoscalerControlledValuesRequestsAndLimits VerticalPodAutoscalerControlledValues = "RequestsAndLimits"
+)
+
+// VerticalPodAutoscalerConfiguration configures the integration with the
+// Vertical Pod Autoscaler. The operator creates a VerticalPodAutoscaler
+// object in recommendation-only mode, and applies the recommended
+// resources with its own rolling update, updating the replicas first
+// and the primary last
+type VerticalPodAutoscalerConfiguration struct {
+	// Enables the integration with the Vertical Pod Autoscaler
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled"`
+
+	// The minimum resources that can be recommended
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// The maximum resources that can be recommended
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+
+	// Whether the recommendation is applied to the requests only, or
+	// to the limits too. Defaults to `RequestsAndLimits`
+	// +kubebuilder:validation:Enum=RequestsOnly;RequestsAndLimits
+	// +kubebuilder:default:=RequestsAndLimits
+	// +optional
+	ControlledValues VerticalPodAutoscalerControlledValues `json:"controlledValues,omitempty"`
+
+	// The minimum difference, in percent, between the applied requests
+	// and the recommended ones triggering a rolling update of the
+	// instances. Defaults to 10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default:=10
+	// +optional
+	Tolerance *int32 `json:"tolerance,omitempty"`
+}
+
+// MaintenanceStatus reports the disruptive operations that have been
+// deferred until the next maintenance window
+type MaintenanceStatus struct {
+	// The operations waiting for the next maintenance window
+	// +optional
+	PendingOperations []string `json:"pendingOperations,omitempty"`
+
+	// The start of the next maintenance window
+	// +optional
+	NextWindow *metav1.Time `json:"nextWindow,omitempty"`
+}
+
 // PrimaryUpdateStrategy contains the strategy to follow when upgrading
 // the primary server of the cluster as part of rolling updates
 type PrimaryUpdateStrategy string
@@ -1180,6 +2162,435 @@ type PrimaryUpdateStrategy string
 // the primary server of the cluster as part of rolling updates
 type PrimaryUpdateMethod string
 
+// StorageClassMigrationStrategy contains the strategy to follow when
+// the storage class of the volumes is changed
+type StorageClassMigrationStrategy string
+
+// MajorUpgradeMethod contains the method to use when upgrading
+// the PostgreSQL major version of the cluster
+type MajorUpgradeMethod string
+
+// MajorUpgradeConfiguration contains the configuration of the
+// PostgreSQL major version upgrades
+type MajorUpgradeConfiguration struct {
+	// The method used to upgrade the PostgreSQL major version: in place via
+	// `pg_upgrade` (default), or via logical replication to a new
+	// cluster (`logical`)
+	// +kubebuilder:default:=pg_upgrade
+	// +kubebuilder:validation:Enum:=pg_upgrade;logical
+	// +optional
+	Method MajorUpgradeMethod `json:"method,omitempty"`
+}
+
+// MinorUpgradeStrategy is the strategy used to roll out
+// a PostgreSQL minor version update
+type MinorUpgradeStrategy string
+
+const (
+	// MinorUpgradeStrategyRolling means that every instance is updated,
+	// one after the other, without any further verification
+	MinorUpgradeStrategyRolling MinorUpgradeStrategy = "rolling"
+
+	// MinorUpgradeStrategyCanary means that a single replica is updated
+	// first, and the remaining instances are updated only after it
+	// stayed healthy for the whole soak period
+	MinorUpgradeStrategyCanary MinorUpgradeStrategy = "canary"
+)
+
+// MinorUpgradeConfiguration contains the configuration of the
+// PostgreSQL minor version updates
+type MinorUpgradeConfiguration struct {
+	// The strategy used to roll out minor version updates: all the
+	// instances one after the other (`rolling` - default), or a single
+	// replica first, verifying its health before proceeding (`canary`)
+	// +kubebuilder:default:=rolling
+	// +kubebuilder:validation:Enum:=rolling;canary
+	// +optional
+	Strategy MinorUpgradeStrategy `json:"strategy,omitempty"`
+
+	// How long the updated replica needs to stay healthy before the
+	// update of the other instances proceeds. Defaults to 5 minutes
+	// +optional
+	SoakPeriod *metav1.Duration `json:"soakPeriod,omitempty"`
+
+	// The maximum replication lag of the updated replica at the end of
+	// the soak period. When not set, the replica only needs to be streaming
+	// +optional
+	MaxLag *resource.Quantity `json:"maxLag,omitempty"`
+
+	// A list of SQL queries executed on the updated replica at the end
+	// of the soak period, each of them required to return `true`
+	// +optional
+	HealthChecks []string `json:"healthChecks,omitempty"`
+}
+
+// MinorUpgradePhase is the phase of a canary minor version update
+type MinorUpgradePhase string
+
+const (
+	// MinorUpgradePhaseVerifying means that the canary replica is
+	// running the new image, and its health is being verified
+	MinorUpgradePhaseVerifying MinorUpgradePhase = "Verifying"
+
+	// MinorUpgradePhaseVerified means that the canary replica stayed
+	// healthy, and the update is being rolled out to every instance
+	MinorUpgradePhaseVerified MinorUpgradePhase = "Verified"
+
+	// MinorUpgradePhaseBlocked means that the canary replica failed the
+	// health checks, and has been rolled back to the previous image
+	MinorUpgradePhaseBlocked MinorUpgradePhase = "Blocked"
+)
+
+// MinorUpgradeStatus contains the status of a canary minor version update
+type MinorUpgradeStatus struct {
+	// TargetImage is the image being rolled out
+	TargetImage string `json:"targetImage"`
+
+	// PreviousImage is the image the cluster was running before the update
+	PreviousImage string `json:"previousImage"`
+
+	// Canary is the name of the replica that has been updated first
+	Canary string `json:"canary"`
+
+	// StartedAt is the time when the canary replica has been updated
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// Phase is the current phase of the update
+	Phase MinorUpgradePhase `json:"phase"`
+}
+
+// PoolerDrainConfiguration contains the configuration of the pause
+// of the PgBouncer poolers during the switchovers
+type PoolerDrainConfiguration struct {
+	// When enabled, the operator pauses the `rw` PgBouncer poolers
+	// pointing to the cluster before promoting the new primary, and
+	// resumes them once the switchover is completed, so that the client
+	// connections are held instead of being dropped
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// How long to wait for the poolers to complete the in-flight
+	// transactions before proceeding with the switchover anyway.
+	// Defaults to 30 seconds
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PoolerDrainStatus contains the status of the pause of the PgBouncer
+// poolers requested before a switchover
+type PoolerDrainStatus struct {
+	// TargetPrimary is the instance to be promoted once the poolers are paused
+	TargetPrimary string `json:"targetPrimary"`
+
+	// Poolers is the list of the poolers that have been requested to pause
+	// +optional
+	Poolers []string `json:"poolers,omitempty"`
+
+	// StartedAt is the time when the pause has been requested
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
+// LifecycleHookEvent is an event in the life of the cluster
+// triggering the execution of the lifecycle hooks
+// +kubebuilder:validation:Enum=postInit;postRecovery;preSwitchover;postFailover
+type LifecycleHookEvent string
+
+const (
+	// LifecycleHookEventPostInit happens when the primary instance of a
+	// cluster bootstrapped with `initdb` is ready for the first time
+	LifecycleHookEventPostInit LifecycleHookEvent = "postInit"
+
+	// LifecycleHookEventPostRecovery happens when the primary instance of
+	// a cluster bootstrapped from the data of another one is ready for
+	// the first time
+	LifecycleHookEventPostRecovery LifecycleHookEvent = "postRecovery"
+
+	// LifecycleHookEventPreSwitchover happens before the operator
+	// promotes a replica in place of a working primary
+	LifecycleHookEventPreSwitchover LifecycleHookEvent = "preSwitchover"
+
+	// LifecycleHookEventPostFailover happens when the replica promoted in
+	// place of a failing primary is ready
+	LifecycleHookEventPostFailover LifecycleHookEvent = "postFailover"
+)
+
+// LifecycleHookFailurePolicy is the behavior of the operator when a
+// lifecycle hook fails
+// +kubebuilder:validation:Enum=Fail;Ignore
+type LifecycleHookFailurePolicy string
+
+const (
+	// LifecycleHookFailurePolicyFail retries the hook until it succeeds,
+	// holding back the switchover in the meantime for the `preSwitchover` hooks
+	LifecycleHookFailurePolicyFail LifecycleHookFailurePolicy = "Fail"
+
+	// LifecycleHookFailurePolicyIgnore records the failure and proceeds
+	LifecycleHookFailurePolicyIgnore LifecycleHookFailurePolicy = "Ignore"
+)
+
+// LifecycleHook is an action executed when the cluster goes through an
+// event of its life. Exactly one of `exec` and `job` needs to be defined
+type LifecycleHook struct {
+	// The name of the hook, unique in the cluster
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=30
+	Name string `json:"name"`
+
+	// The event triggering the hook
+	Event LifecycleHookEvent `json:"event"`
+
+	// A command executed in the PostgreSQL container of the primary instance
+	// +optional
+	Exec *LifecycleHookExec `json:"exec,omitempty"`
+
+	// A Job created in the namespace of the cluster
+	// +optional
+	Job *LifecycleHookJob `json:"job,omitempty"`
+
+	// How long the hook can run before being considered failed.
+	// Defaults to 30 seconds, and can't exceed 1 minute for `exec` hooks
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// What to do when the hook fails: `Fail` retries it every minute until
+	// it succeeds, while `Ignore` (default) records the failure and proceeds
+	// +kubebuilder:default:=Ignore
+	// +optional
+	FailurePolicy LifecycleHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// LifecycleHookExec is a command executed in the PostgreSQL container of
+// the primary instance
+type LifecycleHookExec struct {
+	// The command to be executed, which is not run in a shell
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+}
+
+// LifecycleHookJob is a Job running a lifecycle hook
+type LifecycleHookJob struct {
+	// The image of the container. Defaults to the PostgreSQL image of the cluster
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// The command of the container
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// The arguments of the command
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// The environment variables of the container, in addition to the
+	// ones describing the event set by the operator
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// LifecycleEventType is a transition in the life of the cluster notified
+// as a lifecycle event
+// +kubebuilder:validation:Enum=promotion;failover;backupCompleted;certificateRotated
+type LifecycleEventType string
+
+const (
+	// LifecycleEventTypePromotion happens when a new primary instance
+	// has been promoted, either by a switchover or by a failover
+	LifecycleEventTypePromotion LifecycleEventType = "promotion"
+
+	// LifecycleEventTypeFailover happens when the operator elects a new
+	// primary in place of a failing one
+	LifecycleEventTypeFailover LifecycleEventType = "failover"
+
+	// LifecycleEventTypeBackupCompleted happens when a backup of the
+	// cluster has been completed
+	LifecycleEventTypeBackupCompleted LifecycleEventType = "backupCompleted"
+
+	// LifecycleEventTypeCertificateRotated happens when the staged rotation
+	// of the TLS certificates of the instances has been completed
+	LifecycleEventTypeCertificateRotated LifecycleEventType = "certificateRotated"
+)
+
+// LifecycleEventsConfiguration configures the delivery of the lifecycle
+// events to external systems. The events are always notified to the
+// plugins through the lifecycle hook, while the webhook and the NATS
+// server are available to the clusters without a plugin handling them
+type LifecycleEventsConfiguration struct {
+	// The types of the events to be delivered, defaulting to all of them
+	// +optional
+	Types []LifecycleEventType `json:"types,omitempty"`
+
+	// A webhook receiving every event as a JSON document with an
+	// HTTP POST request
+	// +optional
+	Webhook *LifecycleEventsWebhook `json:"webhook,omitempty"`
+
+	// A NATS server receiving every event as a JSON message
+	// +optional
+	NATS *LifecycleEventsNATS `json:"nats,omitempty"`
+}
+
+// LifecycleEventsWebhook is a webhook receiving the lifecycle events
+type LifecycleEventsWebhook struct {
+	// The URL of the webhook
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// The key of a secret containing the value of the `Authorization`
+	// header of the requests
+	// +optional
+	AuthorizationSecret *SecretKeySelector `json:"authorizationSecret,omitempty"`
+}
+
+// LifecycleEventsNATS is a NATS server receiving the lifecycle events
+type LifecycleEventsNATS struct {
+	// The URL of the NATS server, i.e. `nats://nats.messaging:4222`
+	// +kubebuilder:validation:Pattern=`^nats://`
+	URL string `json:"url"`
+
+	// The subject the events are published to
+	// +kubebuilder:validation:MinLength=1
+	Subject string `json:"subject"`
+}
+
+// LifecycleHookPhase is the phase of the execution of a lifecycle hook
+type LifecycleHookPhase string
+
+const (
+	// LifecycleHookPhasePending means that the hook is waiting to be executed
+	LifecycleHookPhasePending LifecycleHookPhase = "Pending"
+
+	// LifecycleHookPhaseRunning means that the Job of the hook is running
+	LifecycleHookPhaseRunning LifecycleHookPhase = "Running"
+
+	// LifecycleHookPhaseSucceeded means that the hook has been executed
+	LifecycleHookPhaseSucceeded LifecycleHookPhase = "Succeeded"
+
+	// LifecycleHookPhaseFailed means that the hook failed
+	LifecycleHookPhaseFailed LifecycleHookPhase = "Failed"
+)
+
+// LifecycleHookStatus contains the status of the execution of a
+// lifecycle hook
+type LifecycleHookStatus struct {
+	// Name is the name of the hook
+	Name string `json:"name"`
+
+	// Event is the event which triggered the hook
+	Event LifecycleHookEvent `json:"event"`
+
+	// Instance is the primary instance the event refers to: the one
+	// being bootstrapped, promoted by the failover or about to be promoted
+	// by the switchover
+	Instance string `json:"instance"`
+
+	// Phase is the current phase of the execution
+	Phase LifecycleHookPhase `json:"phase"`
+
+	// JobName is the name of the Job running the hook, if any
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// Attempts is the number of times the hook has been executed
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Message contains the reason of the failure of the hook
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartedAt is the time when the last execution started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// CompletedAt is the time when the last execution completed
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// SecurityTLSMode is the TLS enforcement mode of the cluster
+// +kubebuilder:validation:Enum=require;verify-full
+type SecurityTLSMode string
+
+const (
+	// SecurityTLSModeRequire requires TLS on every connection that is not
+	// local, allowing only scram-sha-256 as password authentication so that
+	// the clients can use channel binding
+	SecurityTLSModeRequire SecurityTLSMode = "require"
+
+	// SecurityTLSModeVerifyFull requires, in addition, every connection
+	// that is not local to present a client certificate signed by the
+	// client CA of the cluster
+	SecurityTLSModeVerifyFull SecurityTLSMode = "verify-full"
+)
+
+// SecurityConfiguration contains the security profile of the cluster
+type SecurityConfiguration struct {
+	// TLSMode enforces TLS on every connection that is not local.
+	// With `require`, the clients must use TLS and authenticate with
+	// `scram-sha-256` or with a certificate, and can use channel binding.
+	// With `verify-full`, the clients must also present a certificate
+	// signed by the client CA, and the password authentication is
+	// disabled unless a pg_hba rule allows it together with the
+	// certificate
+	// +optional
+	TLSMode SecurityTLSMode `json:"tlsMode,omitempty"`
+}
+
+// CertificateRotationPhase is the phase of the staged rotation
+// of the TLS certificates
+type CertificateRotationPhase string
+
+const (
+	// CertificateRotationPhaseDistributingCA means that the instances are
+	// loading the CA bundle, trusting both the previous and the new CA
+	CertificateRotationPhaseDistributingCA CertificateRotationPhase = "DistributingCA"
+
+	// CertificateRotationPhaseReloadingInstances means that the instances
+	// are loading the new certificates, one at a time
+	CertificateRotationPhaseReloadingInstances CertificateRotationPhase = "ReloadingInstances"
+
+	// CertificateRotationPhaseReloadingPoolers means that the PgBouncer
+	// poolers of the cluster are reloading the new certificates
+	CertificateRotationPhaseReloadingPoolers CertificateRotationPhase = "ReloadingPoolers"
+
+	// CertificateRotationPhaseCompleted means that every instance and pooler
+	// loaded the current certificates
+	CertificateRotationPhaseCompleted CertificateRotationPhase = "Completed"
+)
+
+// CertificateRotationStatus contains the progress of the staged
+// rotation of the TLS certificates
+type CertificateRotationStatus struct {
+	// Phase is the current phase of the rotation
+	Phase CertificateRotationPhase `json:"phase"`
+
+	// Fingerprint identifies the certificates being rotated
+	Fingerprint string `json:"fingerprint"`
+
+	// TargetInstance is the instance currently loading the new certificates
+	// +optional
+	TargetInstance string `json:"targetInstance,omitempty"`
+
+	// ReloadedInstances are the instances that loaded the new certificates
+	// +optional
+	ReloadedInstances []string `json:"reloadedInstances,omitempty"`
+
+	// PendingInstances are the instances still waiting to load the new
+	// certificates, in the order they will be reloaded
+	// +optional
+	PendingInstances []string `json:"pendingInstances,omitempty"`
+
+	// StartedAt is the time when the rotation started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// LastTransitionTime is the time of the last change of phase
+	// or of target instance
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 const (
 	// PrimaryUpdateStrategySupervised means that the operator need to wait for the
 	// user to manually issue a switchover request before updating the primary
@@ -1199,6 +2610,23 @@ const (
 	// when it needs to upgrade it
 	PrimaryUpdateMethodRestart PrimaryUpdateMethod = "restart"
 
+	// StorageClassMigrationStrategyNone means that the new storage class is used
+	// only for the volumes created from now on (`none`, default)
+	StorageClassMigrationStrategyNone StorageClassMigrationStrategy = "none"
+
+	// StorageClassMigrationStrategyOnline means that the operator replaces, one at
+	// a time, the instances whose volumes don't use the requested storage class
+	// with new replicas, without downtime (`online`)
+	StorageClassMigrationStrategyOnline StorageClassMigrationStrategy = "online"
+
+	// MajorUpgradeMethodPgUpgrade means that the data directory is upgraded
+	// in place via pg_upgrade, while the cluster is shut down (`pg_upgrade` - default)
+	MajorUpgradeMethodPgUpgrade MajorUpgradeMethod = "pg_upgrade"
+
+	// MajorUpgradeMethodLogical means that the data is moved via logical
+	// replication to a new cluster running the new major version (`logical`)
+	MajorUpgradeMethodLogical MajorUpgradeMethod = "logical"
+
 	// DefaultPgCtlTimeoutForPromotion is the default for the pg_ctl timeout when a promotion is performed.
 	// It is greater than one year in seconds, big enough to simulate an infinite timeout
 	DefaultPgCtlTimeoutForPromotion = 40000000
@@ -1255,6 +2683,15 @@ type SynchronousReplicaConfiguration struct {
 	// +kubebuilder:validation:XValidation:rule="self > 0",message="The number of synchronous replicas should be greater than zero"
 	Number int `json:"number"`
 
+	// When set, the number of synchronous standby servers is computed as
+	// this percentage, rounded up, of the ready replicas of the cluster,
+	// and recomputed as they come and go. The computed value is never
+	// lower than `number`.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	QuorumPercentage *int `json:"quorumPercentage,omitempty"`
+
 	// Specifies the maximum number of local cluster pods that can be
 	// automatically included in the `synchronous_standby_names` option in
 	// PostgreSQL.
@@ -1287,6 +2724,150 @@ type SynchronousReplicaConfiguration struct {
 	DataDurability DataDurabilityLevel `json:"dataDurability,omitempty"`
 }
 
+// FailoverPolicy is the policy deciding if a failover can be
+// executed automatically by the operator
+type FailoverPolicy string
+
+const (
+	// FailoverPolicyAutomatic means that the operator promotes the most
+	// advanced replica as soon as the primary is detected to be unhealthy
+	FailoverPolicyAutomatic FailoverPolicy = "automatic"
+
+	// FailoverPolicyManual means that the operator waits for the user
+	// to approve the failover before promoting a replica
+	FailoverPolicyManual FailoverPolicy = "manual"
+
+	// FailoverPolicyLagBounded means that the operator promotes the most
+	// advanced replica only if its lag is within the configured bound,
+	// waiting for the user to approve the failover otherwise
+	FailoverPolicyLagBounded FailoverPolicy = "lagBounded"
+)
+
+// FailoverConfiguration contains the policy to be applied when
+// the primary instance is detected to be unhealthy
+// +kubebuilder:validation:XValidation:rule="!has(self.policy) || self.policy != 'lagBounded' || has(self.maxLag)",message="maxLag is required when the policy is lagBounded"
+type FailoverConfiguration struct {
+	// The failover policy: `automatic` (default) promotes the most advanced
+	// replica without any intervention, `manual` waits for the user to
+	// approve the failover, while `lagBounded` promotes the most advanced
+	// replica only if the WAL it received is within `maxLag` from the last
+	// known position of the primary, waiting for the user approval otherwise
+	// +kubebuilder:validation:Enum=automatic;manual;lagBounded
+	// +kubebuilder:default:=automatic
+	// +optional
+	Policy FailoverPolicy `json:"policy,omitempty"`
+
+	// The maximum amount of WAL, in bytes, the most advanced replica can be
+	// behind the last known position of the primary to be automatically
+	// promoted. Used only with the `lagBounded` policy
+	// +optional
+	MaxLag *resource.Quantity `json:"maxLag,omitempty"`
+
+	// When enabled, a former primary which can't be realigned with the new
+	// primary using `pg_rewind` is cloned again with `pg_basebackup`.
+	// The new copy is taken beside the existing data directory, which is
+	// replaced only when the copy succeeds, and requires enough free space
+	// for both. Disabled by default, requiring a manual intervention
+	// +optional
+	RecloneFormerPrimary bool `json:"recloneFormerPrimary,omitempty"`
+}
+
+// EvictionPolicy is the policy deciding how the operator reacts when
+// the node running the primary instance is being drained
+type EvictionPolicy string
+
+const (
+	// EvictionPolicySwitchover means that the operator switches over
+	// as soon as the node running the primary is being drained
+	EvictionPolicySwitchover EvictionPolicy = "switchover"
+
+	// EvictionPolicyDelay means that the operator waits for the
+	// configured delay before switching over, giving the time to
+	// undo a node cordon
+	EvictionPolicyDelay EvictionPolicy = "delay"
+
+	// EvictionPolicyManual means that the operator never switches over
+	// because of a drain: the primary stays protected by its
+	// PodDisruptionBudget until the user promotes another instance
+	EvictionPolicyManual EvictionPolicy = "manual"
+)
+
+// PodDisruptionBudgetConfiguration contains the configuration of the
+// PodDisruptionBudgets of the instances and the eviction policy
+type PodDisruptionBudgetConfiguration struct {
+	// The number of replicas that can be disrupted at the same time,
+	// e.g. while draining the nodes. Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxUnavailableReplicas *int32 `json:"maxUnavailableReplicas,omitempty"`
+
+	// If enabled (default), the primary instance is protected by a
+	// dedicated PodDisruptionBudget, preventing its eviction until a
+	// switchover moves it away. If disabled, the primary can be evicted,
+	// causing a failover
+	// +kubebuilder:default:=true
+	// +optional
+	EnablePrimaryPDB *bool `json:"enablePrimaryPDB,omitempty"`
+
+	// How the operator reacts when the node running the primary is being
+	// drained: `switchover` (default) switches over immediately, `delay`
+	// switches over after `evictionDelay` seconds, while `manual` waits
+	// for the user to promote another instance
+	// +kubebuilder:validation:Enum=switchover;delay;manual
+	// +kubebuilder:default:=switchover
+	// +optional
+	EvictionPolicy EvictionPolicy `json:"evictionPolicy,omitempty"`
+
+	// The number of seconds to wait before switching over when the
+	// eviction policy is `delay`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=300
+	// +optional
+	EvictionDelay int32 `json:"evictionDelay,omitempty"`
+}
+
+// TopologyConfiguration contains the zone constraints of the instances
+type TopologyConfiguration struct {
+	// The label of the nodes identifying their zone.
+	// Defaults to `topology.kubernetes.io/zone`
+	// +optional
+	ZoneLabel string `json:"zoneLabel,omitempty"`
+
+	// If enabled, no two instances are scheduled in the same zone
+	// +optional
+	DistinctZones bool `json:"distinctZones,omitempty"`
+
+	// The zones allowed to host the primary instance. The instances are
+	// preferably scheduled in these zones, and only the instances running
+	// in them are promoted by a failover or by a switchover. When empty,
+	// every zone is allowed
+	// +optional
+	PrimaryZones []string `json:"primaryZones,omitempty"`
+}
+
+// WitnessConfiguration contains the configuration of the witness, a
+// lightweight member of the cluster storing no data and taking part only
+// in the failover decisions
+type WitnessConfiguration struct {
+	// If enabled, the operator deploys the witness and promotes a replica
+	// only when the witness cannot reach the primary instance either.
+	// Only clusters made of two instances are supported
+	Enabled bool `json:"enabled"`
+
+	// Resources requirements of the witness container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// The node selector of the witness Pod, which is expected to run
+	// in a different failure domain from the instances
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the witness Pod
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
 // PostgresConfiguration defines the PostgreSQL configuration
 type PostgresConfiguration struct {
 	// PostgreSQL configuration options (postgresql.conf)
@@ -1302,36 +2883,171 @@ type PostgresConfiguration struct {
 	// +optional
 	PgHBA []string `json:"pg_hba,omitempty"`
 
+	// Structured PostgreSQL Host Based Authentication rules, appended
+	// to the pg_hba.conf file after the `pg_hba` lines
+	// +optional
+	PgHBARules []PgHBARule `json:"pg_hba_rules,omitempty"`
+
 	// PostgreSQL User Name Maps rules (lines to be appended
 	// to the pg_ident.conf file)
 	// +optional
-	PgIdent []string `json:"pg_ident,omitempty"`
+	PgIdent []string `json:"pg_ident,omitempty"`
+
+	// Requirements to be met by sync replicas. This will affect how the "synchronous_standby_names" parameter will be
+	// set up.
+	// +optional
+	SyncReplicaElectionConstraint SyncReplicaElectionConstraints `json:"syncReplicaElectionConstraint,omitempty"`
+
+	// Lists of shared preload libraries to add to the default ones
+	// +optional
+	AdditionalLibraries []string `json:"shared_preload_libraries,omitempty"`
+
+	// Options to specify LDAP configuration
+	// +optional
+	LDAP *LDAPConfig `json:"ldap,omitempty"`
+
+	// Options to specify the OAuth authentication configuration,
+	// supported from PostgreSQL 18
+	// +optional
+	OAuth *OAuthConfig `json:"oauth,omitempty"`
+
+	// Options to specify the transparent data encryption (TDE) of the
+	// data directory, supported by the operand images implementing the
+	// `--data-encryption` option of initdb. It cannot be enabled nor
+	// disabled after the cluster has been created
+	// +optional
+	TDE *TDEConfiguration `json:"tde,omitempty"`
+
+	// Options to specify the audit logging of the instances, performed
+	// with the pgaudit extension
+	// +optional
+	Audit *AuditConfiguration `json:"audit,omitempty"`
+
+	// The autovacuum preset matching the workload of the cluster. The
+	// autovacuum parameters specified in `parameters` take precedence
+	// over the ones of the profile
+	// +optional
+	AutovacuumProfile AutovacuumProfile `json:"autovacuumProfile,omitempty"`
+
+	// If true, the memory and parallelism parameters of PostgreSQL, such
+	// as `shared_buffers` and `max_worker_processes`, are derived from the
+	// resources of the pods. The parameters specified in `parameters` take
+	// precedence over the derived ones
+	// +optional
+	AutoTune bool `json:"autoTune,omitempty"`
+
+	// Specifies the maximum number of seconds to wait when promoting an instance to primary.
+	// Default value is 40000000, greater than one year in seconds,
+	// big enough to simulate an infinite timeout
+	// +optional
+	PgCtlTimeoutForPromotion int32 `json:"promotionTimeout,omitempty"`
+
+	// If this parameter is true, the user will be able to invoke `ALTER SYSTEM`
+	// on this CloudNativePG Cluster.
+	// This should only be used for debugging and troubleshooting.
+	// Defaults to false.
+	// +optional
+	EnableAlterSystem bool `json:"enableAlterSystem,omitempty"`
+}
+
+// AutovacuumProfile is a preset of autovacuum parameters
+// +kubebuilder:validation:Enum=oltp;analytics;high-churn
+type AutovacuumProfile string
+
+const (
+	// AutovacuumProfileOLTP vacuums and analyzes the tables more often than
+	// the PostgreSQL defaults, to keep the bloat of the frequently updated
+	// tables under control
+	AutovacuumProfileOLTP AutovacuumProfile = "oltp"
+
+	// AutovacuumProfileAnalytics vacuums the large and mostly appended tables
+	// less often, with a higher cost limit to complete faster
+	AutovacuumProfileAnalytics AutovacuumProfile = "analytics"
+
+	// AutovacuumProfileHighChurn vacuums aggressively the tables whose rows
+	// are continuously updated and deleted, such as queues
+	AutovacuumProfileHighChurn AutovacuumProfile = "high-churn"
+)
+
+// PgHBAConnectionType is the type of connection matched by a pg_hba rule
+// +kubebuilder:validation:Enum=local;host;hostssl;hostnossl;hostgssenc;hostnogssenc
+type PgHBAConnectionType string
+
+const (
+	// PgHBAConnectionTypeLocal matches the connections using Unix-domain sockets
+	PgHBAConnectionTypeLocal PgHBAConnectionType = "local"
+
+	// PgHBAConnectionTypeHost matches the connections using TCP/IP
+	PgHBAConnectionTypeHost PgHBAConnectionType = "host"
+
+	// PgHBAConnectionTypeHostSSL matches the connections using TCP/IP with SSL
+	PgHBAConnectionTypeHostSSL PgHBAConnectionType = "hostssl"
+
+	// PgHBAConnectionTypeHostNoSSL matches the connections using TCP/IP without SSL
+	PgHBAConnectionTypeHostNoSSL PgHBAConnectionType = "hostnossl"
+
+	// PgHBAConnectionTypeHostGSSEnc matches the connections using TCP/IP
+	// with GSSAPI encryption
+	PgHBAConnectionTypeHostGSSEnc PgHBAConnectionType = "hostgssenc"
+
+	// PgHBAConnectionTypeHostNoGSSEnc matches the connections using TCP/IP
+	// without GSSAPI encryption
+	PgHBAConnectionTypeHostNoGSSEnc PgHBAConnectionType = "hostnogssenc"
+)
+
+// PgHBAAuthMethod is the authentication method of a pg_hba rule
+// +kubebuilder:validation:Enum=trust;reject;scram-sha-256;md5;password;gss;ident;peer;ldap;radius;cert;pam;bsd;oauth
+type PgHBAAuthMethod string
+
+const (
+	// PgHBAAuthMethodLDAP authenticates the users against an LDAP server
+	PgHBAAuthMethodLDAP PgHBAAuthMethod = "ldap"
+
+	// PgHBAAuthMethodRADIUS authenticates the users against a RADIUS server
+	PgHBAAuthMethodRADIUS PgHBAAuthMethod = "radius"
+
+	// PgHBAAuthMethodGSS authenticates the users with GSSAPI
+	PgHBAAuthMethodGSS PgHBAAuthMethod = "gss"
+
+	// PgHBAAuthMethodCert authenticates the users with SSL client certificates
+	PgHBAAuthMethodCert PgHBAAuthMethod = "cert"
+
+	// PgHBAAuthMethodOAuth authenticates the users with OAuth bearer tokens
+	PgHBAAuthMethodOAuth PgHBAAuthMethod = "oauth"
+)
+
+// PgHBARule is a structured PostgreSQL Host Based Authentication rule
+type PgHBARule struct {
+	// The type of connection matched by the rule
+	// +kubebuilder:default:=host
+	// +optional
+	Type PgHBAConnectionType `json:"type,omitempty"`
 
-	// Requirements to be met by sync replicas. This will affect how the "synchronous_standby_names" parameter will be
-	// set up.
+	// The databases matched by the rule. Defaults to `all`
 	// +optional
-	SyncReplicaElectionConstraint SyncReplicaElectionConstraints `json:"syncReplicaElectionConstraint,omitempty"`
+	Databases []string `json:"databases,omitempty"`
 
-	// Lists of shared preload libraries to add to the default ones
+	// The users matched by the rule. Defaults to `all`
 	// +optional
-	AdditionalLibraries []string `json:"shared_preload_libraries,omitempty"`
+	Users []string `json:"users,omitempty"`
 
-	// Options to specify LDAP configuration
+	// The client addresses matched by the rule, in CIDR notation.
+	// Not allowed for `local` connections
 	// +optional
-	LDAP *LDAPConfig `json:"ldap,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
 
-	// Specifies the maximum number of seconds to wait when promoting an instance to primary.
-	// Default value is 40000000, greater than one year in seconds,
-	// big enough to simulate an infinite timeout
+	// The ConfigMap keys containing sets of client addresses matched
+	// by the rule, in CIDR notation, one per line
 	// +optional
-	PgCtlTimeoutForPromotion int32 `json:"promotionTimeout,omitempty"`
+	AddressesFrom []ConfigMapKeySelector `json:"addressesFrom,omitempty"`
 
-	// If this parameter is true, the user will be able to invoke `ALTER SYSTEM`
-	// on this CloudNativePG Cluster.
-	// This should only be used for debugging and troubleshooting.
-	// Defaults to false.
+	// The authentication method
+	Method PgHBAAuthMethod `json:"method"`
+
+	// The options of the authentication method, such as the
+	// LDAP or RADIUS servers
 	// +optional
-	EnableAlterSystem bool `json:"enableAlterSystem,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
 }
 
 // BootstrapConfiguration contains information about how to create the PostgreSQL
@@ -1352,6 +3068,16 @@ type BootstrapConfiguration struct {
 	// PostgreSQL instance
 	// +optional
 	PgBaseBackup *BootstrapPgBaseBackup `json:"pg_basebackup,omitempty"`
+
+	// Bootstrap the cluster as an independent copy of another cluster
+	// managed by the operator, possibly living in another namespace
+	// +optional
+	Clone *BootstrapClone `json:"clone,omitempty"`
+
+	// Bootstrap the cluster migrating the databases of an external
+	// PostgreSQL instance via logical replication
+	// +optional
+	Migration *BootstrapMigration `json:"migration,omitempty"`
 }
 
 // LDAPScheme defines the possible schemes for LDAP
@@ -1390,6 +3116,322 @@ type LDAPConfig struct {
 	TLS bool `json:"tls,omitempty"`
 }
 
+// OAuthConfig contains the configuration of the OAuth authentication,
+// used by the `pg_hba_rules` having the `oauth` method
+type OAuthConfig struct {
+	// The URL of the OAuth issuer, whose discovery document is advertised
+	// to the clients. Used as the default `issuer` of the `oauth` pg_hba rules
+	// +kubebuilder:validation:Pattern=`^https://`
+	Issuer string `json:"issuer"`
+
+	// The space-separated list of OAuth scopes the clients need to request.
+	// Used as the default `scope` of the `oauth` pg_hba rules
+	Scope string `json:"scope"`
+
+	// The audience the tokens need to be issued for, passed to the validator
+	// library as the `<library>.audience` configuration parameter
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// The validator library verifying the OAuth tokens
+	Validator OAuthValidator `json:"validator"`
+}
+
+// OAuthValidator is the library used by PostgreSQL to validate the
+// OAuth tokens
+type OAuthValidator struct {
+	// The name of the validator library, loaded through the
+	// `oauth_validator_libraries` configuration parameter
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_]+$`
+	Library string `json:"library"`
+
+	// The image containing the validator library, mounted in the
+	// PostgreSQL containers as an image volume. The library is loaded from
+	// the `lib` directory of the image. When not set, the library needs to
+	// be available in the PostgreSQL image
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// TDEConfiguration contains the configuration of the transparent data
+// encryption (TDE). The data encryption key generated by initdb is wrapped
+// with a key encryption key managed by the key provider
+type TDEConfiguration struct {
+	// The provider of the key encryption key. Changing it makes the
+	// instance managers rewrap the data encryption key
+	KeyProvider TDEKeyProvider `json:"keyProvider"`
+}
+
+// TDEKeyProvider contains the configuration of the provider of the key
+// encryption key. Exactly one provider must be specified
+// +kubebuilder:validation:XValidation:rule="[has(self.secret), has(self.vault)].filter(x, x).size() == 1",message="exactly one key provider must be specified"
+type TDEKeyProvider struct {
+	// The secret key containing the key encryption key, which must be
+	// made of 32 random bytes
+	// +optional
+	Secret *SecretKeySelector `json:"secret,omitempty"`
+
+	// A key of the transit secrets engine of HashiCorp Vault
+	// +optional
+	Vault *TDEVaultKeyProvider `json:"vault,omitempty"`
+}
+
+// TDEVaultKeyProvider contains the parameters needed to wrap the data
+// encryption key with a key of the Vault transit secrets engine
+type TDEVaultKeyProvider struct {
+	// The URL of the Vault server
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// The path where the transit secrets engine is mounted
+	// +kubebuilder:default:=transit
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// The name of the transit key
+	// +kubebuilder:validation:MinLength=1
+	KeyName string `json:"keyName"`
+
+	// The secret key containing the Vault token, which needs to be
+	// allowed to use the encrypt and decrypt endpoints of the transit key
+	Token SecretKeySelector `json:"token"`
+
+	// The secret key containing the CA certificate of the Vault server
+	// +optional
+	CA *SecretKeySelector `json:"ca,omitempty"`
+}
+
+// AuditConfiguration contains the declarative configuration of pgaudit.
+// When specified, the operator loads the extension and manages the
+// `pgaudit.*` parameters and the per-role audit settings
+type AuditConfiguration struct {
+	// The classes of statements logged for every role (`pgaudit.log`).
+	// A class can be excluded by prefixing it with `-`, i.e. `-misc`
+	// +optional
+	Classes []string `json:"classes,omitempty"`
+
+	// Whether the statements involving only the system catalog are logged
+	// (`pgaudit.log_catalog`). Defaults to true
+	// +optional
+	LogCatalog *bool `json:"logCatalog,omitempty"`
+
+	// Whether the parameters passed with the statement are logged
+	// (`pgaudit.log_parameter`)
+	// +optional
+	LogParameter bool `json:"logParameter,omitempty"`
+
+	// Whether a separate entry is logged for each relation referenced
+	// by a SELECT or DML statement (`pgaudit.log_relation`)
+	// +optional
+	LogRelation bool `json:"logRelation,omitempty"`
+
+	// The role whose privileges define the objects audited by the
+	// object audit logging (`pgaudit.role`)
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// The classes of statements logged for specific roles, set with
+	// `ALTER ROLE ... SET pgaudit.log` by the primary instance. The
+	// settings of the roles not in this list are reset
+	// +optional
+	Roles []AuditRoleConfiguration `json:"roles,omitempty"`
+
+	// Where the audit log records are shipped, separately from the
+	// other PostgreSQL log records. When not specified, the audit records
+	// are written to the standard output of the instance manager
+	// +optional
+	Shipping *AuditLogShipping `json:"shipping,omitempty"`
+}
+
+// AuditRoleConfiguration contains the audit settings of a role
+type AuditRoleConfiguration struct {
+	// The name of the role
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The classes of statements logged for the role
+	// +kubebuilder:validation:MinItems=1
+	Classes []string `json:"classes"`
+}
+
+// AuditLogShipping contains the configuration of the sink where the
+// audit log records are shipped. Exactly one sink must be specified
+// +kubebuilder:validation:XValidation:rule="[has(self.syslog), has(self.objectStore)].filter(x, x).size() == 1",message="exactly one audit log sink must be specified"
+type AuditLogShipping struct {
+	// Ship the audit log records to a syslog endpoint
+	// +optional
+	Syslog *SyslogSink `json:"syslog,omitempty"`
+
+	// Upload the audit log records to the object store
+	// +optional
+	ObjectStore *AuditObjectStoreSink `json:"objectStore,omitempty"`
+}
+
+// SyslogProtocol is the transport protocol of a syslog endpoint
+// +kubebuilder:validation:Enum=udp;tcp
+type SyslogProtocol string
+
+const (
+	// SyslogProtocolUDP sends the syslog messages over UDP
+	SyslogProtocolUDP SyslogProtocol = "udp"
+
+	// SyslogProtocolTCP sends the syslog messages over TCP
+	SyslogProtocolTCP SyslogProtocol = "tcp"
+)
+
+// SyslogSink contains the parameters needed to send the log
+// records to a syslog endpoint
+type SyslogSink struct {
+	// The address of the syslog endpoint, in the `host:port` format
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// The transport protocol of the syslog endpoint
+	// +kubebuilder:default:=udp
+	// +optional
+	Protocol SyslogProtocol `json:"protocol,omitempty"`
+
+	// The tag of the syslog messages. Defaults to `pgaudit` for the
+	// audit log records and to `postgres` for the other log records
+	// +optional
+	Tag string `json:"tag,omitempty"`
+}
+
+// AuditObjectStoreSink contains the parameters needed to upload the
+// audit log records to the object store, using the credentials of the
+// `barmanObjectStore` backup configuration of the cluster
+type AuditObjectStoreSink struct {
+	// The path where the audit log files are stored. Defaults to the
+	// `audit` folder of the backup destination path of the cluster
+	// +optional
+	DestinationPath string `json:"destinationPath,omitempty"`
+
+	// The interval between two uploads of the audit log records
+	// +kubebuilder:default:="5m"
+	// +optional
+	UploadInterval *metav1.Duration `json:"uploadInterval,omitempty"`
+}
+
+// LoggingConfiguration contains the configuration of the logs
+// of the instances
+type LoggingConfiguration struct {
+	// The external sinks where the instance managers forward the
+	// PostgreSQL log records, in addition to the standard output
+	// +optional
+	Sinks []LogSink `json:"sinks,omitempty"`
+}
+
+// LogSinkOverflowPolicy is what happens to the log records when
+// the buffer of a sink is full
+// +kubebuilder:validation:Enum=drop;block
+type LogSinkOverflowPolicy string
+
+const (
+	// LogSinkOverflowPolicyDrop discards the log records not fitting
+	// in the buffer
+	LogSinkOverflowPolicyDrop LogSinkOverflowPolicy = "drop"
+
+	// LogSinkOverflowPolicyBlock slows down the collection of the log
+	// records until the sink catches up
+	LogSinkOverflowPolicyBlock LogSinkOverflowPolicy = "block"
+)
+
+// LogSink is an external destination of the PostgreSQL log records.
+// Exactly one destination must be specified
+// +kubebuilder:validation:XValidation:rule="[has(self.loki), has(self.syslog), has(self.kafkaRestProxy)].filter(x, x).size() == 1",message="exactly one log sink destination must be specified"
+type LogSink struct {
+	// The name of the sink
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Push the log records to Grafana Loki
+	// +optional
+	Loki *LokiLogSink `json:"loki,omitempty"`
+
+	// Send the log records to a syslog endpoint
+	// +optional
+	Syslog *SyslogSink `json:"syslog,omitempty"`
+
+	// Produce the log records to a Kafka topic through a Kafka REST Proxy
+	// +optional
+	KafkaRestProxy *KafkaRestProxyLogSink `json:"kafkaRestProxy,omitempty"`
+
+	// The maximum number of log records buffered in memory while
+	// they are sent to the sink
+	// +kubebuilder:default:=10000
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BufferSize int `json:"bufferSize,omitempty"`
+
+	// What happens when the buffer is full: `drop` (default) discards
+	// the new log records, while `block` slows down the collection of
+	// the log records until the sink catches up
+	// +kubebuilder:default:=drop
+	// +optional
+	OverflowPolicy LogSinkOverflowPolicy `json:"overflowPolicy,omitempty"`
+
+	// The maximum number of log records sent to the sink at once
+	// +kubebuilder:default:=500
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// The maximum time a log record is buffered before being sent
+	// +kubebuilder:default:="1s"
+	// +optional
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+}
+
+// LokiLogSink contains the parameters needed to push the log records
+// to Grafana Loki. The log streams are labelled with the namespace,
+// the cluster, the pod and the logger of the records
+type LokiLogSink struct {
+	// The URL of the Loki push API, i.e.
+	// `http://loki.monitoring:3100/loki/api/v1/push`
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// The tenant of the log streams, sent in the `X-Scope-OrgID` header
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// Additional labels of the log streams
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// The secret key containing the bearer token used to authenticate
+	// the requests
+	// +optional
+	BearerToken *SecretKeySelector `json:"bearerToken,omitempty"`
+
+	// The secret key containing the CA certificate of the Loki server
+	// +optional
+	CA *SecretKeySelector `json:"ca,omitempty"`
+}
+
+// KafkaRestProxyLogSink contains the parameters needed to produce the log
+// records to a Kafka topic through the v2 API of the Confluent Kafka REST
+// Proxy, which needs to be deployed separately. The Kafka protocol is not
+// used directly. The records are keyed by pod name
+type KafkaRestProxyLogSink struct {
+	// The URL of the Kafka REST Proxy
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// The name of the topic
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+
+	// The name of the secret of type kubernetes.io/basic-auth containing
+	// the credentials used to authenticate to the REST Proxy
+	// +optional
+	BasicAuthSecret *LocalObjectReference `json:"basicAuthSecret,omitempty"`
+
+	// The secret key containing the CA certificate of the REST Proxy
+	// +optional
+	CA *SecretKeySelector `json:"ca,omitempty"`
+}
+
 // LDAPBindAsAuth provides the required fields to use the
 // bind authentication for LDAP
 type LDAPBindAsAuth struct {
@@ -1465,6 +3507,106 @@ type CertificatesConfiguration struct {
 	// The list of the server alternative DNS names to be added to the generated server TLS certificates, when required.
 	// +optional
 	ServerAltDNSNames []string `json:"serverAltDNSNames,omitempty"`
+
+	// The external provider issuing the server and the client certificates
+	// in place of the self-signed CA managed by the operator. When set,
+	// the CA secrets only contain the `ca.crt` returned by the provider
+	// +optional
+	Provider *CertificateProviderConfiguration `json:"provider,omitempty"`
+}
+
+// CertificateProviderConfiguration contains the configuration of the
+// external provider issuing the certificates of the cluster
+// +kubebuilder:validation:XValidation:rule="(has(self.vault) ? 1 : 0) + (has(self.awsPrivateCA) ? 1 : 0) == 1",message="exactly one certificate provider must be specified"
+type CertificateProviderConfiguration struct {
+	// Issue the certificates with the PKI secrets engine of HashiCorp Vault
+	// +optional
+	Vault *VaultPKICertificateProvider `json:"vault,omitempty"`
+
+	// Issue the certificates with AWS Private Certificate Authority
+	// +optional
+	AWSPrivateCA *AWSPrivateCACertificateProvider `json:"awsPrivateCA,omitempty"`
+}
+
+// VaultPKICertificateProvider contains the parameters needed to issue
+// the certificates with a role of the Vault PKI secrets engine
+type VaultPKICertificateProvider struct {
+	// The URL of the Vault server
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// The path where the PKI secrets engine is mounted
+	// +kubebuilder:default:=pki
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// The name of the role used to sign the certificates, which needs
+	// to allow both the server and the client usages
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+
+	// The requested validity of the certificates, using the Vault
+	// duration format. Defaults to the TTL of the role
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+
+	// The secret key containing the Vault token, which needs to be
+	// allowed to use the sign endpoint of the role
+	Token SecretKeySelector `json:"token"`
+
+	// The secret key containing the CA certificate of the Vault server
+	// +optional
+	CA *SecretKeySelector `json:"ca,omitempty"`
+}
+
+// AWSPrivateCACertificateProvider contains the parameters needed to
+// issue the certificates with AWS Private Certificate Authority
+type AWSPrivateCACertificateProvider struct {
+	// The AWS region of the certificate authority
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// The ARN of the certificate authority
+	// +kubebuilder:validation:Pattern=`^arn:`
+	CertificateAuthorityARN string `json:"certificateAuthorityARN"`
+
+	// The ARN of the certificate template. Defaults to the end entity
+	// certificate template, which allows both the server and the client usages
+	// +optional
+	TemplateARN string `json:"templateARN,omitempty"`
+
+	// The algorithm the certificate authority uses to sign the certificates,
+	// which needs to match the key algorithm of the certificate authority
+	// +kubebuilder:validation:Enum=SHA256WITHECDSA;SHA384WITHECDSA;SHA512WITHECDSA;SHA256WITHRSA;SHA384WITHRSA;SHA512WITHRSA
+	// +kubebuilder:default:=SHA256WITHECDSA
+	// +optional
+	SigningAlgorithm string `json:"signingAlgorithm,omitempty"`
+
+	// The validity of the certificates in days, which needs to be longer
+	// than the expiration check threshold of the operator
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=90
+	// +optional
+	ValidityDays int `json:"validityDays,omitempty"`
+
+	// The credentials used to access AWS Private CA. When not specified,
+	// the credentials set in the environment of the operator are used
+	// +optional
+	Credentials *AWSPrivateCACredentials `json:"credentials,omitempty"`
+}
+
+// AWSPrivateCACredentials contains the secret keys holding the static credentials
+// used to access AWS Private CA
+type AWSPrivateCACredentials struct {
+	// The secret key containing the access key ID
+	AccessKeyID SecretKeySelector `json:"accessKeyID"`
+
+	// The secret key containing the secret access key
+	SecretAccessKey SecretKeySelector `json:"secretAccessKey"`
+
+	// The secret key containing the session token of temporary credentials
+	// +optional
+	SessionToken *SecretKeySelector `json:"sessionToken,omitempty"`
 }
 
 // CertificatesStatus contains configuration certificates and related expiration dates.
@@ -1685,6 +3827,26 @@ type SQLRefs struct {
 	ConfigMapRefs []ConfigMapKeySelector `json:"configMapRefs,omitempty"`
 }
 
+// DataMaskingConfiguration contains the SQL run as a superuser on a cluster
+// bootstrapped from the data of another one, before it accepts connections
+// from the applications, to scrub the sensitive information. The helpers
+// of the `cnpg_masking` schema are available while it runs
+type DataMaskingConfiguration struct {
+	// The database where the SQL is run. Defaults to the application
+	// database
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// List of SQL queries to be executed
+	// +optional
+	SQL []string `json:"sql,omitempty"`
+
+	// List of references to ConfigMaps or Secrets containing SQL files
+	// to be executed after the `sql` queries
+	// +optional
+	SQLRefs *SQLRefs `json:"sqlRefs,omitempty"`
+}
+
 // BootstrapRecovery contains the configuration required to restore
 // from an existing cluster using 3 methodologies: external cluster,
 // volume snapshots or backup objects. Full recovery and Point-In-Time
@@ -1742,6 +3904,11 @@ type BootstrapRecovery struct {
 	// created from scratch
 	// +optional
 	Secret *LocalObjectReference `json:"secret,omitempty"`
+
+	// The SQL scrubbing the sensitive data once it has been copied,
+	// before the cluster accepts connections from the applications
+	// +optional
+	Masking *DataMaskingConfiguration `json:"masking,omitempty"`
 }
 
 // DataSource contains the configuration required to bootstrap a
@@ -1763,6 +3930,11 @@ type DataSource struct {
 // information that could be needed to correctly restore it.
 type BackupSource struct {
 	LocalObjectReference `json:",inline"`
+	// The namespace of the backup, defaulting to the namespace of the
+	// cluster. Referencing a backup in a different namespace requires
+	// a BackupGrant in that namespace allowing the namespace of the cluster
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 	// EndpointCA store the CA bundle of the barman endpoint.
 	// Useful when using self-signed certificates to avoid
 	// errors with certificate issuer and barman-cloud-wal-archive.
@@ -1791,6 +3963,85 @@ type BootstrapPgBaseBackup struct {
 	// created from scratch
 	// +optional
 	Secret *LocalObjectReference `json:"secret,omitempty"`
+
+	// The SQL scrubbing the sensitive data once it has been copied,
+	// before the cluster accepts connections from the applications
+	// +optional
+	Masking *DataMaskingConfiguration `json:"masking,omitempty"`
+}
+
+// BootstrapClone contains the configuration required to clone a running
+// cluster managed by the operator. The data is copied via a physical
+// backup streamed from the primary of the source cluster, while the
+// secrets and the certificates of the clone are generated from scratch
+type BootstrapClone struct {
+	// The name of the cluster to be cloned
+	// +kubebuilder:validation:MinLength=1
+	Cluster string `json:"cluster"`
+
+	// The namespace of the cluster to be cloned. Defaults to the
+	// namespace of this cluster
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the database used by the application. Default: `app`.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Name of the owner of the database in the instance to be used
+	// by applications. Defaults to the value of the `database` key.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Name of the secret containing the initial credentials for the
+	// owner of the user database. If empty a new secret will be
+	// created from scratch
+	// +optional
+	Secret *LocalObjectReference `json:"secret,omitempty"`
+
+	// The SQL scrubbing the sensitive data once it has been copied,
+	// before the cluster accepts connections from the applications
+	// +optional
+	Masking *DataMaskingConfiguration `json:"masking,omitempty"`
+}
+
+// BootstrapMigration contains the configuration required to migrate the
+// databases of an external PostgreSQL instance. Their schema is imported
+// while bootstrapping the cluster, and their content is kept in sync via
+// logical replication until the cutover is requested with the
+// `cnpg.io/migrationCutover` annotation
+type BootstrapMigration struct {
+	// The name of the external cluster to migrate from. Its `wal_level`
+	// needs to be `logical`
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+
+	// The databases to migrate, keeping their name. Use `*` to migrate
+	// every database of the external cluster
+	// +kubebuilder:validation:MinItems=1
+	Databases []string `json:"databases"`
+
+	// The roles to import, use `*` to import every role
+	// of the external cluster
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// When true (default), the cutover prevents the non-superuser roles
+	// from connecting to the databases of the external cluster, and
+	// terminates their connections, before receiving the last changes
+	// +kubebuilder:default:=true
+	// +optional
+	FenceSource *bool `json:"fenceSource,omitempty"`
+
+	// List of custom options to pass to the `pg_dump` command
+	// exporting the schema of the databases
+	// +optional
+	PgDumpExtraOptions []string `json:"pgDumpExtraOptions,omitempty"`
+
+	// List of custom options to pass to the `pg_restore` command
+	// importing the schema of the databases
+	// +optional
+	PgRestoreExtraOptions []string `json:"pgRestoreExtraOptions,omitempty"`
 }
 
 // RecoveryTarget allows to configure the moment where the recovery process
@@ -1858,6 +4109,56 @@ type StorageConfiguration struct {
 	// Template to be used to generate the Persistent Volume Claim
 	// +optional
 	PersistentVolumeClaimTemplate *corev1.PersistentVolumeClaimSpec `json:"pvcTemplate,omitempty"`
+
+	// AutoResize configures the automatic expansion of the volumes
+	// when their usage reaches a threshold
+	// +optional
+	AutoResize *StorageAutoResizeConfiguration `json:"autoResize,omitempty"`
+}
+
+// StorageAutoResizeConfiguration defines how the operator expands the
+// volumes before PostgreSQL runs out of disk space
+type StorageAutoResizeConfiguration struct {
+	// Enables the automatic expansion of the volumes
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled"`
+
+	// The disk usage, in percent, triggering the expansion of the
+	// volumes. Defaults to 80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default:=80
+	// +optional
+	Threshold *int32 `json:"threshold,omitempty"`
+
+	// The space added at each expansion, expressed as a percentage of
+	// the current size (e.g. `20%`) or as a quantity (e.g. `10Gi`).
+	// Defaults to `20%`
+	// +kubebuilder:default:="20%"
+	// +optional
+	Step string `json:"step,omitempty"`
+
+	// The maximum size the volumes can be expanded to. When not set,
+	// the volumes are expanded without limits
+	// +optional
+	MaxSize string `json:"maxSize,omitempty"`
+}
+
+// AutoResizedStorageStatus contains the sizes the volumes have been
+// automatically expanded to
+type AutoResizedStorageStatus struct {
+	// The size of the PGDATA volumes
+	// +optional
+	PgData string `json:"pgData,omitempty"`
+
+	// The size of the WAL volumes
+	// +optional
+	PgWal string `json:"pgWal,omitempty"`
+
+	// The size of the volumes of the tablespaces, indexed by the
+	// tablespace name
+	// +optional
+	Tablespaces map[string]string `json:"tablespaces,omitempty"`
 }
 
 // TablespaceConfiguration is the configuration of a tablespace, and includes
@@ -1878,8 +4179,42 @@ type TablespaceConfiguration struct {
 	// database objects, or other temporary files. Please refer to PostgreSQL
 	// documentation for more information on the `temp_tablespaces` GUC.
 	// +optional
-	// +kubebuilder:default:=false
-	Temporary bool `json:"temporary,omitempty"`
+	// +kubebuilder:default:=false
+	Temporary bool `json:"temporary,omitempty"`
+
+	// When set, the tablespace is backed by an ephemeral volume, for example
+	// on a local NVMe disk, rather than by a PVC. The content of the volume
+	// is lost every time the Pod is restarted, so only temporary tablespaces
+	// can be ephemeral.
+	// +optional
+	Ephemeral *EphemeralTablespaceConfiguration `json:"ephemeral,omitempty"`
+}
+
+// EphemeralTablespaceVolumeType is the kind of volume backing an
+// ephemeral tablespace
+type EphemeralTablespaceVolumeType string
+
+const (
+	// EphemeralTablespaceVolumeTypeGeneric means the tablespace is backed by
+	// a generic ephemeral volume, created using the storage configuration
+	// of the tablespace
+	EphemeralTablespaceVolumeTypeGeneric EphemeralTablespaceVolumeType = "generic"
+
+	// EphemeralTablespaceVolumeTypeEmptyDir means the tablespace is backed by
+	// an `emptyDir` volume, limited to the storage size of the tablespace
+	EphemeralTablespaceVolumeTypeEmptyDir EphemeralTablespaceVolumeType = "emptyDir"
+)
+
+// EphemeralTablespaceConfiguration is the configuration of the ephemeral
+// volume backing a temporary tablespace
+type EphemeralTablespaceConfiguration struct {
+	// The kind of volume backing the tablespace: `generic` for a generic
+	// ephemeral volume using the storage class and the size of the tablespace,
+	// `emptyDir` for an `emptyDir` volume limited to the size of the tablespace
+	// +kubebuilder:validation:Enum=generic;emptyDir
+	// +kubebuilder:default:=generic
+	// +optional
+	VolumeType EphemeralTablespaceVolumeType `json:"volumeType,omitempty"`
 }
 
 // DatabaseRoleRef is a reference an a role available inside PostgreSQL
@@ -1973,10 +4308,65 @@ const (
 	// BackupTargetStandby means backups will be performed on a standby instance if available
 	BackupTargetStandby = BackupTarget("prefer-standby")
 
+	// BackupTargetStandbyOnly means backups will be performed only on a standby
+	// instance, waiting for one to be available. In a replica cluster, the
+	// designated primary is never elected
+	BackupTargetStandbyOnly = BackupTarget("standby")
+
 	// DefaultBackupTarget is the default BackupTarget
 	DefaultBackupTarget = BackupTargetStandby
 )
 
+// BackupTargetSelectorFallback is the policy applied when no instance
+// matching the backup target selector is available
+type BackupTargetSelectorFallback string
+
+const (
+	// BackupTargetSelectorFallbackTarget means that the backup runs on the
+	// instance elected by the `target` policy among all the instances
+	BackupTargetSelectorFallbackTarget = BackupTargetSelectorFallback("target")
+
+	// BackupTargetSelectorFallbackWait means that the backup stays pending
+	// until an instance matching the selector is available
+	BackupTargetSelectorFallbackWait = BackupTargetSelectorFallback("wait")
+)
+
+// BackupTargetSelector selects the instances that can take the backups,
+// by the labels of their pods and of the nodes they are running on
+type BackupTargetSelector struct {
+	// Selects the instances by the labels of their pods. For example,
+	// `cnpg.io/instanceName` pins the backups to a given instance
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+
+	// Selects the instances by the labels of the nodes they are running
+	// on, such as `topology.kubernetes.io/zone`
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// The policy applied when no instance matching the selector is
+	// available: `target` (default) elects the instance among all the
+	// ones following the `target` policy, `wait` keeps the backup pending
+	// until an instance matching the selector is available
+	// +kubebuilder:validation:Enum=target;wait
+	// +kubebuilder:default:=target
+	// +optional
+	Fallback BackupTargetSelectorFallback `json:"fallback,omitempty"`
+}
+
+// WALArchiveTarget describes which instance archives the WAL files
+type WALArchiveTarget string
+
+const (
+	// WALArchiveTargetPrimary means the WAL files are archived by the primary
+	// instance, or by the designated primary in a replica cluster
+	WALArchiveTargetPrimary = WALArchiveTarget("primary")
+
+	// WALArchiveTargetStandby means the WAL files are archived by a standby
+	// instance elected by the operator
+	WALArchiveTargetStandby = WALArchiveTarget("standby")
+)
+
 // BackupConfiguration defines how the backup of the cluster are taken.
 // The supported backup methods are BarmanObjectStore and VolumeSnapshot.
 // For details and examples refer to the Backup and Recovery section of the
@@ -1990,11 +4380,28 @@ type BackupConfiguration struct {
 	// +optional
 	BarmanObjectStore *BarmanObjectStoreConfiguration `json:"barmanObjectStore,omitempty"`
 
+	// The configuration for pgBackRest
+	// +optional
+	PgBackRest *PgBackRestConfiguration `json:"pgBackRest,omitempty"`
+
+	// The configuration of a secondary object store where every WAL file
+	// and base backup stored in `barmanObjectStore` is asynchronously
+	// mirrored
+	// +optional
+	Mirror *BackupMirrorConfiguration `json:"mirror,omitempty"`
+
+	// The maximum amount of data uploaded per second by `barman-cloud-backup`
+	// when taking a base backup in `barmanObjectStore` or in the secondary
+	// object store, i.e. `50Mi`. When not set, the bandwidth is not limited
+	// +optional
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
+
 	// RetentionPolicy is the retention policy to be used for backups
 	// and WALs (i.e. '60d'). The retention policy is expressed in the form
 	// of `XXu` where `XX` is a positive integer and `u` is in `[dwm]` -
 	// days, weeks, months.
-	// It's currently only applicable when using the BarmanObjectStore method.
+	// It's currently only applicable when using the BarmanObjectStore
+	// and the PgBackRest methods.
 	// +kubebuilder:validation:Pattern=^[1-9][0-9]*[dwm]$
 	// +optional
 	RetentionPolicy string `json:"retentionPolicy,omitempty"`
@@ -2002,13 +4409,209 @@ type BackupConfiguration struct {
 	// The policy to decide which instance should perform backups. Available
 	// options are empty string, which will default to `prefer-standby` policy,
 	// `primary` to have backups run always on primary instances, `prefer-standby`
-	// to have backups run preferably on the most updated standby, if available.
-	// +kubebuilder:validation:Enum=primary;prefer-standby
+	// to have backups run preferably on the most updated standby, if available,
+	// `standby` to have backups run only on a standby, never on the primary
+	// or on the designated primary of a replica cluster.
+	// +kubebuilder:validation:Enum=primary;prefer-standby;standby
 	// +kubebuilder:default:=prefer-standby
 	// +optional
 	Target BackupTarget `json:"target,omitempty"`
+
+	// Restricts the instances that can take the backups to the ones matching
+	// the selector, on top of the `target` policy. For example, it allows
+	// taking the backups from the instances running in a given zone
+	// +optional
+	TargetSelector *BackupTargetSelector `json:"targetSelector,omitempty"`
+
+	// The name of the instance to be elected, when it's a ready standby,
+	// to take the backups with the `prefer-standby` and `standby` targets,
+	// and to archive the WAL files with the `standby` WAL archive target.
+	// When it's not available, or it has been promoted, another standby
+	// is elected
+	// +optional
+	PreferredInstance string `json:"preferredInstance,omitempty"`
+
+	// The policy to decide which instance archives the WAL files: `primary`
+	// (default) to have them archived by the primary, or by the designated
+	// primary in a replica cluster, `standby` to have them archived by a
+	// standby elected by the operator, falling back to the primary when no
+	// standby is available. Requires the replication slots for high
+	// availability
+	// +kubebuilder:validation:Enum=primary;standby
+	// +kubebuilder:default:=primary
+	// +optional
+	WALArchiveTarget WALArchiveTarget `json:"walArchiveTarget,omitempty"`
+
+	// The configuration of the periodic verification of the backups,
+	// where the most recent backup is restored in a throwaway instance
+	// +optional
+	Verification *BackupVerificationConfiguration `json:"verification,omitempty"`
+
+	// The configuration of the health checks of the WAL archive, executed
+	// by the primary instance
+	// +optional
+	WALArchiveHealth *WALArchiveHealthConfiguration `json:"walArchiveHealth,omitempty"`
+}
+
+// WALArchiveHealthConfiguration contains the configuration of the health
+// checks of the WAL archive
+type WALArchiveHealthConfiguration struct {
+	// The maximum time, in seconds, a WAL file can wait to be archived
+	// before the WAL archive is considered stalled. Defaults to 300
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default:=300
+	// +optional
+	MaxArchiveDelay int32 `json:"maxArchiveDelay,omitempty"`
+
+	// When enabled, the instance manager restarts the PostgreSQL archiver
+	// when archiving is stalled, and forces a WAL switch when no WAL file
+	// has been archived for longer than `maxArchiveDelay`
+	// +optional
+	SelfHealing bool `json:"selfHealing,omitempty"`
+}
+
+// WALSpaceProtectionConfiguration contains the configuration of the
+// protective mode entered by the primary instance when the volume
+// containing the WAL files is running out of space
+type WALSpaceProtectionConfiguration struct {
+	// Enables the protection of the volume containing the WAL files
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled"`
+
+	// The disk usage, in percent, of the volume containing the WAL files
+	// making the primary instance enter the protective mode. In this mode,
+	// the instance runs checkpoints to recycle the archived WAL files and
+	// reports the WAL files retained via `wal_keep_size` in the status.
+	// Defaults to 85
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default:=85
+	// +optional
+	Threshold *int32 `json:"threshold,omitempty"`
+
+	// The disk usage, in percent, of the volume containing the WAL files
+	// making the operator fence the primary instance, shutting PostgreSQL
+	// down before the volume is full. The primary is not fenced when not set
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	FencingThreshold *int32 `json:"fencingThreshold,omitempty"`
+}
+
+// BackupVerificationConfiguration contains the configuration of the
+// periodic verification of the backups
+type BackupVerificationConfiguration struct {
+	// The schedule of the verification, in the same format used by
+	// ScheduledBackups. At every run, the most recent completed backup
+	// is restored in a throwaway instance and verified
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// An SQL query executed in the throwaway instance once the recovery
+	// is completed. The verification fails if the query fails
+	// +optional
+	SmokeQuery string `json:"smokeQuery,omitempty"`
+
+	// The database where the smoke query is executed, defaults
+	// to `postgres`
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Resources requirements of the throwaway instance. If not specified,
+	// the resources of the cluster are used
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+// BackupMirrorConfiguration defines the secondary object store where
+// the WAL files and the base backups of the cluster are mirrored, i.e.
+// in a different region or with a different provider
+type BackupMirrorConfiguration struct {
+	// The configuration of the secondary object store
+	BarmanObjectStore BarmanObjectStoreConfiguration `json:"barmanObjectStore"`
+
+	// The maximum size of the WAL files waiting to be copied to the
+	// secondary object store, which are queued in the PGDATA volume.
+	// When it is reached, the WAL files are not queued anymore, leaving
+	// a gap in the secondary object store. Defaults to 1Gi
+	// +optional
+	MaxSpoolSize *resource.Quantity `json:"maxSpoolSize,omitempty"`
+}
+
+// PgBackRestConfiguration contains the configuration needed to store
+// backups and WAL files in a pgBackRest repository
+type PgBackRestConfiguration struct {
+	// The name of the pgBackRest stanza. If not defined, the name of the
+	// cluster is used
+	// +optional
+	Stanza string `json:"stanza,omitempty"`
+
+	// The repository where backups and WAL files are stored
+	Repository PgBackRestRepository `json:"repository"`
+
+	// The type of backup taken by pgBackRest
+	// +kubebuilder:validation:Enum=full;diff;incr
+	// +kubebuilder:default:=full
+	// +optional
+	BackupType PgBackRestBackupType `json:"backupType,omitempty"`
+}
+
+// PgBackRestRepository is the configuration of a pgBackRest repository
+type PgBackRestRepository struct {
+	// The type of the repository
+	// +kubebuilder:validation:Enum=s3;gcs;azure;posix
+	// +kubebuilder:default:=s3
+	// +optional
+	Type PgBackRestRepositoryType `json:"type,omitempty"`
+
+	// The path where the repository is stored
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Additional repository options, passed to pgBackRest as
+	// `--repo1-<key>=<value>` (i.e. `s3-bucket`, `s3-endpoint`,
+	// `s3-region`, `retention-full`)
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// The secret containing the repository credentials. Every key
+	// is passed to pgBackRest as an environment variable, i.e.
+	// `PGBACKREST_REPO1_S3_KEY` and `PGBACKREST_REPO1_S3_KEY_SECRET`
+	// +optional
+	CredentialsSecret *LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
+
+// PgBackRestRepositoryType is the type of a pgBackRest repository
+type PgBackRestRepositoryType string
+
+const (
+	// PgBackRestRepositoryTypeS3 is a repository stored in an S3 bucket
+	PgBackRestRepositoryTypeS3 PgBackRestRepositoryType = "s3"
+
+	// PgBackRestRepositoryTypeGCS is a repository stored in a Google Cloud Storage bucket
+	PgBackRestRepositoryTypeGCS PgBackRestRepositoryType = "gcs"
+
+	// PgBackRestRepositoryTypeAzure is a repository stored in an Azure Blob Storage container
+	PgBackRestRepositoryTypeAzure PgBackRestRepositoryType = "azure"
+
+	// PgBackRestRepositoryTypePosix is a repository stored in a mounted filesystem
+	PgBackRestRepositoryTypePosix PgBackRestRepositoryType = "posix"
+)
+
+// PgBackRestBackupType is the type of backup taken by pgBackRest
+type PgBackRestBackupType string
+
+const (
+	// PgBackRestBackupTypeFull is a full backup
+	PgBackRestBackupTypeFull PgBackRestBackupType = "full"
+
+	// PgBackRestBackupTypeDiff is a differential backup
+	PgBackRestBackupTypeDiff PgBackRestBackupType = "diff"
+
+	// PgBackRestBackupTypeIncr is an incremental backup
+	PgBackRestBackupTypeIncr PgBackRestBackupType = "incr"
+)
+
 // MonitoringConfiguration is the type containing all the monitoring
 // configuration for a certain cluster
 type MonitoringConfiguration struct {
@@ -2044,6 +4647,205 @@ type MonitoringConfiguration struct {
 	// The list of relabelings for the `PodMonitor`. Applied to samples before scraping.
 	// +optional
 	PodMonitorRelabelConfigs []monitoringv1.RelabelConfig `json:"podMonitorRelabelings,omitempty"`
+
+	// The configuration of the query-level observability, based on
+	// the `pg_stat_statements` extension
+	// +optional
+	QueryInsights *QueryInsightsConfiguration `json:"queryInsights,omitempty"`
+
+	// The metrics about the bloat and the vacuuming of the largest
+	// tables, sampled periodically by the primary instance
+	// +optional
+	TableStatistics *TableStatisticsConfiguration `json:"tableStatistics,omitempty"`
+
+	// The export of the traces and of the metrics of the instance
+	// managers to an OpenTelemetry collector.
+	// Changing this option will force a rollout of all instances.
+	// +optional
+	OpenTelemetry *OpenTelemetryConfiguration `json:"openTelemetry,omitempty"`
+
+	// The alerting rules generated for the cluster, created as a
+	// `PrometheusRule` object
+	// +optional
+	Alerts *AlertsConfiguration `json:"alerts,omitempty"`
+}
+
+// QueryInsightsTrack defines which statements are tracked
+// by `pg_stat_statements`
+// +kubebuilder:validation:Enum=top;all
+type QueryInsightsTrack string
+
+const (
+	// QueryInsightsTrackTop tracks the statements issued directly by
+	// the clients
+	QueryInsightsTrackTop QueryInsightsTrack = "top"
+
+	// QueryInsightsTrackAll tracks the nested statements too, such as
+	// the ones invoked within functions
+	QueryInsightsTrackAll QueryInsightsTrack = "all"
+)
+
+// QueryInsightsConfiguration contains the configuration of the
+// `pg_stat_statements` extension and of the periodic snapshots of the
+// top statements of every database
+type QueryInsightsConfiguration struct {
+	// Enable the `pg_stat_statements` extension and the snapshots of
+	// the top statements
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The maximum number of statements tracked by `pg_stat_statements`.
+	// Changing it requires a restart of the instances
+	// +kubebuilder:validation:Minimum=100
+	// +optional
+	MaxStatements *int32 `json:"maxStatements,omitempty"`
+
+	// Which statements are tracked: `top` (default) for the statements
+	// issued directly by the clients, `all` for the nested ones too
+	// +kubebuilder:default:=top
+	// +optional
+	Track QueryInsightsTrack `json:"track,omitempty"`
+
+	// The number of statements of every database included in the
+	// snapshots, ranked both by total and by mean execution time
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TopN int `json:"topN,omitempty"`
+
+	// The interval between two snapshots of the top statements
+	// +kubebuilder:default:="1m"
+	// +optional
+	SnapshotInterval *metav1.Duration `json:"snapshotInterval,omitempty"`
+
+	// Keep the history of the snapshots in a table of the primary
+	// +optional
+	History *QueryInsightsHistory `json:"history,omitempty"`
+}
+
+// QueryInsightsHistory contains the configuration of the table where
+// the snapshots of the top statements are stored
+type QueryInsightsHistory struct {
+	// The database containing the `cnpg_query_history` table
+	// +kubebuilder:default:=postgres
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// How long the snapshots are kept in the history table
+	// +kubebuilder:default:="168h"
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+}
+
+// TableStatisticsConfiguration contains the configuration of the metrics
+// about the estimated bloat, the dead tuples and the vacuuming of the
+// largest tables
+type TableStatisticsConfiguration struct {
+	// Enable the metrics about the largest tables
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The databases whose tables are sampled. Defaults to the
+	// application database
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// The number of largest tables of every database whose
+	// metrics are exposed
+	// +kubebuilder:default:=20
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=500
+	// +optional
+	TopN int `json:"topN,omitempty"`
+
+	// The interval between two samples of the table statistics,
+	// which are not collected at every scrape given that the
+	// bloat estimation can be expensive on large databases
+	// +kubebuilder:default:="5m"
+	// +optional
+	SamplingInterval *metav1.Duration `json:"samplingInterval,omitempty"`
+}
+
+// OpenTelemetryConfiguration contains the configuration of the OTLP
+// exporter of the instance managers
+type OpenTelemetryConfiguration struct {
+	// The base URL of the OTLP/HTTP endpoint of the collector, i.e.
+	// `http://otel-collector.monitoring:4318`. The traces and the metrics
+	// are sent to the `/v1/traces` and `/v1/metrics` paths
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// The secret containing the HTTP headers added to the export
+	// requests, i.e. the credentials, as a comma-separated list of
+	// `key=value` pairs
+	// +optional
+	HeadersSecret *SecretKeySelector `json:"headersSecret,omitempty"`
+
+	// The percentage of the traces started by the instance managers
+	// that are sampled. The requests of the operator, such as the status
+	// checks and the backups, follow the sampling decision of the operator
+	// +kubebuilder:default:=100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+
+	// Additional resource attributes describing the instances
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+}
+
+// AlertsConfiguration contains the configuration of the alerting rules
+// generated for the cluster. Setting a threshold to zero disables the
+// corresponding alert
+type AlertsConfiguration struct {
+	// Enable or disable the `PrometheusRule` containing the alerts
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// How long a condition must hold before the alert fires
+	// +kubebuilder:default:="5m"
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+
+	// Additional labels added to the alerts, i.e. to route them.
+	// The `severity` label defaults to `warning`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// The maximum replication lag of the replicas
+	// +kubebuilder:default:="5m"
+	// +optional
+	MaxReplicationLag *metav1.Duration `json:"maxReplicationLag,omitempty"`
+
+	// Alert when the archiving of the WAL files is failing
+	// +kubebuilder:default:=true
+	// +optional
+	WALArchiveFailure *bool `json:"walArchiveFailure,omitempty"`
+
+	// The maximum age of the last available backup. The alert
+	// is generated only when the backups are configured
+	// +kubebuilder:default:="48h"
+	// +optional
+	MaxBackupAge *metav1.Duration `json:"maxBackupAge,omitempty"`
+
+	// The maximum percentage of `max_connections` in use
+	// +kubebuilder:default:=80
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxConnectionsUsage *int32 `json:"maxConnectionsUsage,omitempty"`
+
+	// The maximum number of deadlocks detected in a database
+	// in the last 5 minutes
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeadlocks *int32 `json:"maxDeadlocks,omitempty"`
 }
 
 // ClusterMonitoringTLSConfiguration is the type containing the TLS configuration
@@ -2098,6 +4900,20 @@ type ExternalCluster struct {
 	// The configuration of the plugin that is taking care
 	// of WAL archiving and backups for this external cluster
 	PluginConfiguration *PluginConfiguration `json:"plugin,omitempty"`
+
+	// The configuration for pgBackRest, used to recover from the
+	// repository of this external cluster
+	// +optional
+	PgBackRest *PgBackRestConfiguration `json:"pgBackRest,omitempty"`
+
+	// When true, and the current cluster is a primary cluster, the designated
+	// primary of the replica cluster having this name is added to the
+	// `synchronous_standby_names` option, after the local instances.
+	// The replica cluster must have `.spec.replica.synchronous` enabled.
+	// Requires `.spec.postgresql.synchronous` with `dataDurability`
+	// set to `required`
+	// +optional
+	SynchronousReplica bool `json:"synchronousReplica,omitempty"`
 }
 
 // EnsureOption represents whether we should enforce the presence or absence of
@@ -2145,6 +4961,82 @@ type ManagedServices struct {
 	// Additional is a list of additional managed services specified by the user.
 	// +optional
 	Additional []ManagedService `json:"additional,omitempty"`
+	// MaxReplicaLag is the maximum replication lag, in bytes, of a replica
+	// still being part of the endpoints of the read and read-only services.
+	// Replicas exceeding it are excluded from the endpoints until they
+	// catch up with the primary. When not set, every replica is included
+	// +optional
+	MaxReplicaLag *resource.Quantity `json:"maxReplicaLag,omitempty"`
+	// Routing is a list of load balancing options for the default services
+	// +listType=map
+	// +listMapKey=selectorType
+	// +optional
+	Routing []ServiceRoutingConfiguration `json:"routing,omitempty"`
+	// PerInstance contains the configuration of the externally reachable
+	// services created for each instance of the cluster. When not set,
+	// no per-instance service is created
+	// +optional
+	PerInstance *PerInstanceServicesConfiguration `json:"perInstance,omitempty"`
+}
+
+// PerInstanceServicesConfiguration contains the configuration of the
+// services pointing to a single instance of the cluster
+type PerInstanceServicesConfiguration struct {
+	// Type is the type of the services, either `LoadBalancer` or `NodePort`
+	// +kubebuilder:validation:Enum:=LoadBalancer;NodePort
+	// +kubebuilder:default:=LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// DNSDomain is the domain used to build the hostname of each instance,
+	// as `<instance-name>.<dnsDomain>`. When set, the hostname is added
+	// to the services via the ExternalDNS hostname annotation
+	// +optional
+	DNSDomain string `json:"dnsDomain,omitempty"`
+
+	// Annotations to be added to each service
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels to be added to each service
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ExternalTrafficPolicy of the services, either `Cluster` or `Local`
+	// +kubebuilder:validation:Enum:=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the clients allowed to connect
+	// to the `LoadBalancer` services
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// ServiceRoutingConfiguration contains the load balancing options
+// of one of the default services
+type ServiceRoutingConfiguration struct {
+	// SelectorType is the default service these options are applied to.
+	// Valid values are "rw", "r", and "ro", representing read-write, read, and read-only services.
+	SelectorType ServiceSelectorType `json:"selectorType"`
+
+	// SessionAffinity can be set to `ClientIP` to route the connections
+	// coming from the same client to the same instance
+	// +kubebuilder:validation:Enum:=None;ClientIP
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// SessionAffinityTimeoutSeconds is the maximum duration of the
+	// `ClientIP` session affinity. Defaults to 3 hours
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+
+	// TopologyAwareRouting makes the service prefer the instances running
+	// in the same zone of the client, via the `PreferClose` traffic distribution
+	// +optional
+	TopologyAwareRouting bool `json:"topologyAwareRouting,omitempty"`
 }
 
 // ManagedService represents a specific service managed by the cluster.
@@ -2163,6 +5055,33 @@ type ManagedService struct {
 	ServiceTemplate ServiceTemplateSpec `json:"serviceTemplate"`
 }
 
+// DriftDetectionPolicy describes how the manual changes to the resources
+// managed by the operator should be handled
+// +kubebuilder:validation:Enum=revert;report
+type DriftDetectionPolicy string
+
+const (
+	// DriftDetectionPolicyRevert reverts the manual changes, reporting them
+	DriftDetectionPolicyRevert DriftDetectionPolicy = "revert"
+
+	// DriftDetectionPolicyReport only reports the manual changes, leaving
+	// the changed resources untouched until the drift is resolved
+	DriftDetectionPolicyReport DriftDetectionPolicy = "report"
+)
+
+// DriftDetectionConfiguration configures the detection of the manual
+// changes to the Services, the credential Secrets and the Pods managed by
+// the operator, and of the PostgreSQL parameters overridden with
+// ALTER SYSTEM. The detected changes are reported in the DriftDetected
+// condition
+type DriftDetectionConfiguration struct {
+	// How the manual changes are handled: they can be reverted
+	// (`revert` - default) or only reported (`report`)
+	// +kubebuilder:default:=revert
+	// +optional
+	Policy DriftDetectionPolicy `json:"policy,omitempty"`
+}
+
 // ManagedConfiguration represents the portions of PostgreSQL that are managed
 // by the instance manager
 type ManagedConfiguration struct {
@@ -2172,6 +5091,85 @@ type ManagedConfiguration struct {
 	// Services roles managed by the `Cluster`
 	// +optional
 	Services *ManagedServices `json:"services,omitempty"`
+
+	// The synchronization of the managed roles with the members
+	// of the groups of an LDAP server
+	// +optional
+	LDAPRoleSync *LDAPRoleSyncConfiguration `json:"ldapRoleSync,omitempty"`
+}
+
+// LDAPRoleSyncConfiguration configures the synchronization of the
+// managed roles with the members of the groups of an LDAP server.
+// A login role is added for each member of the configured groups,
+// and the roles added by the synchronization are disabled as soon
+// as their users are removed from the groups. The operator writes
+// the synchronized roles in `.spec.managed.roles`, so the tools
+// applying the cluster manifest from a Git repository must ignore
+// that field, or they will revert the synchronization
+type LDAPRoleSyncConfiguration struct {
+	// LDAP hostname or IP address
+	Server string `json:"server"`
+
+	// LDAP server port
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// LDAP schema to be used, possible options are `ldap` and `ldaps`
+	// +kubebuilder:validation:Enum=ldap;ldaps
+	// +optional
+	Scheme LDAPScheme `json:"scheme,omitempty"`
+
+	// Set to 'true' to enable LDAP over TLS. 'false' is default
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+
+	// Set to 'true' to allow connecting to the LDAP server without
+	// TLS, when the `ldap` scheme is used without StartTLS. This
+	// exposes the bind password on the network. 'false' is default
+	// +optional
+	AllowInsecureConnection bool `json:"allowInsecureConnection,omitempty"`
+
+	// Secret containing the CA certificate used to verify the
+	// LDAP server certificate. The system CAs are used by default
+	// +optional
+	CA *SecretKeySelector `json:"ca,omitempty"`
+
+	// DN of the user to bind to the directory. An anonymous bind is
+	// used by default
+	// +optional
+	BindDN string `json:"bindDN,omitempty"`
+
+	// Secret with the password for the user to bind to the directory
+	// +optional
+	BindPassword *SecretKeySelector `json:"bindPassword,omitempty"`
+
+	// Root DN to begin the search of the group members
+	BaseDN string `json:"baseDN"`
+
+	// Attribute of the group members containing the role name
+	// +kubebuilder:default:=uid
+	// +optional
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+
+	// The groups whose members are synchronized to the managed roles
+	// +kubebuilder:validation:MinItems=1
+	Groups []LDAPRoleSyncGroup `json:"groups"`
+
+	// The schedule of the synchronization, in Cron format with the
+	// seconds field, see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	Schedule string `json:"schedule"`
+}
+
+// LDAPRoleSyncGroup is an LDAP group whose members are synchronized
+// to the managed roles
+type LDAPRoleSyncGroup struct {
+	// DN of the group, matched against the `memberOf` attribute
+	// of the group members
+	DN string `json:"dn"`
+
+	// The roles the members of the group are granted
+	// +optional
+	InRoles []string `json:"inRoles,omitempty"`
 }
 
 // PluginConfiguration specifies a plugin that need to be loaded for this
@@ -2188,6 +5186,19 @@ type PluginConfiguration struct {
 	// Parameters is the configuration of the plugin
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// The order in which the plugin is invoked, for example when
+	// mutating the instance pods. Plugins with a lower priority are
+	// invoked first, and the ones with the same priority are sorted by
+	// name. Defaults to 0
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// The retention policy of the backups taken with this plugin.
+	// Completed backups falling out of the policy are deleted by the
+	// operator, after notifying the plugin through its lifecycle hooks
+	// +optional
+	BackupRetention *VolumeSnapshotRetentionPolicy `json:"backupRetention,omitempty"`
 }
 
 // PluginStatus is the status of a loaded plugin
@@ -2253,6 +5264,11 @@ type RoleConfiguration struct {
 	// +optional
 	PasswordSecret *LocalObjectReference `json:"passwordSecret,omitempty"`
 
+	// The automatic rotation of the password of the role, which is
+	// generated by the operator and stored in the password secret
+	// +optional
+	PasswordRotation *RolePasswordRotation `json:"passwordRotation,omitempty"`
+
 	// If the role can log in, this specifies how many concurrent
 	// connections the role can make. `-1` (the default) means no limit.
 	// +kubebuilder:default:=-1
@@ -2321,11 +5337,19 @@ type RoleConfiguration struct {
 	BypassRLS bool `json:"bypassrls,omitempty"` // Row-Level Security
 }
 
+// RolePasswordRotation configures the automatic rotation of the password
+// of a managed role
+type RolePasswordRotation struct {
+	// The schedule of the password rotation, in Cron format with the
+	// seconds field, see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	Schedule string `json:"schedule"`
+}
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
-// +kubebuilder:subresource:scale:specpath=.spec.instances,statuspath=.status.instances
+// +kubebuilder:subresource:scale:specpath=.spec.instances,statuspath=.status.instances,selectorpath=.status.selector
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Instances",type="integer",JSONPath=".status.instances",description="Number of instances"
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyInstances",description="Number of ready instances"
@@ -19,9 +19,13 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	barmanWebhooks "github.com/cloudnative-pg/barman-cloud/pkg/api/webhooks"
 	"github.com/cloudnative-pg/machinery/pkg/image/reference"
@@ -30,6 +34,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	"github.com/cloudnative-pg/machinery/pkg/types"
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/robfig/cron"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -108,6 +113,10 @@ func (r *Cluster) setDefaults(preserveUserSettings bool) {
 		r.defaultRecovery()
 	case r.Spec.Bootstrap.PgBaseBackup != nil:
 		r.defaultPgBaseBackup()
+	case r.Spec.Bootstrap.Clone != nil:
+		r.defaultClone()
+	case r.Spec.Bootstrap.Migration != nil:
+		// The migrated databases are created by the import
 	default:
 		r.defaultInitDB()
 	}
@@ -302,6 +311,16 @@ func (r *Cluster) defaultPgBaseBackup() {
 	}
 }
 
+// defaultClone enriches the clone with defaults if not all the required arguments were passed
+func (r *Cluster) defaultClone() {
+	if r.Spec.Bootstrap.Clone.Database == "" {
+		r.Spec.Bootstrap.Clone.Database = DefaultApplicationDatabaseName
+	}
+	if r.Spec.Bootstrap.Clone.Owner == "" {
+		r.Spec.Bootstrap.Clone.Owner = r.Spec.Bootstrap.Clone.Database
+	}
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:webhookVersions={v1},admissionReviewVersions={v1},verbs=create;update,path=/validate-postgresql-cnpg-io-v1-cluster,mutating=false,failurePolicy=fail,groups=postgresql.cnpg.io,resources=clusters,versions=v1,name=vcluster.cnpg.io,sideEffects=None
 
@@ -332,9 +351,11 @@ func (r *Cluster) Validate() (allErrs field.ErrorList) {
 		r.validateImport,
 		r.validateSuperuserSecret,
 		r.validateCerts,
+		r.validateCertificateProvider,
 		r.validateBootstrapMethod,
 		r.validateImageName,
 		r.validateImagePullPolicy,
+		r.validateImageVerification,
 		r.validateRecoveryTarget,
 		r.validatePrimaryUpdateStrategy,
 		r.validateMinSyncReplicas,
@@ -345,25 +366,47 @@ func (r *Cluster) Validate() (allErrs field.ErrorList) {
 		r.validateTablespaceStorageSize,
 		r.validateName,
 		r.validateTablespaceNames,
+		r.validateEphemeralTablespaces,
 		r.validateBootstrapPgBaseBackupSource,
+		r.validateBootstrapMigration,
+		r.validateBootstrapClone,
+		r.validateDataMasking,
 		r.validateTablespaceBackupSnapshot,
 		r.validateBootstrapRecoverySource,
 		r.validateBootstrapRecoveryDataSource,
+		r.validateBootstrapRecoveryBackup,
 		r.validateExternalClusters,
 		r.validateTolerations,
 		r.validateAntiAffinity,
+		r.validateTopology,
 		r.validateReplicaMode,
+		r.validateWitness,
 		r.validateBackupConfiguration,
+		r.validateMaintenanceWindows,
+		r.validateHibernation,
+		r.validateLifecycleHooks,
 		r.validateRetentionPolicy,
 		r.validateConfiguration,
 		r.validateSynchronousReplicaConfiguration,
 		r.validateLDAP,
+		r.validateOAuth,
+		r.validateTDE,
+		r.validateAudit,
+		r.validateLogging,
+		r.validateQueryInsights,
+		r.validatePgHBARules,
+		r.validateSecurity,
 		r.validateReplicationSlots,
+		r.validateLogicalSlotsSynchronization,
+		r.validateReplicationSlotsSafeguard,
+		r.validateAdditionalReplicationSlots,
 		r.validateEnv,
 		r.validateManagedServices,
 		r.validateManagedRoles,
 		r.validateManagedExtensions,
 		r.validateResources,
+		r.validateHugePages,
+		r.validateWALSpaceProtection,
 		r.validateHibernationAnnotation,
 		r.validatePromotionToken,
 	}
@@ -415,6 +458,7 @@ func (r *Cluster) ValidateChanges(old *Cluster) (allErrs field.ErrorList) {
 		r.validateUnixPermissionIdentifierChange,
 		r.validateReplicationSlotsChange,
 		r.validateWALLevelChange,
+		r.validateTDEChange,
 		r.validateReplicaClusterChange,
 	}
 	for _, validate := range validations {
@@ -459,6 +503,440 @@ func (r *Cluster) validateLDAP() field.ErrorList {
 	return result
 }
 
+// validateOAuth validates the OAuth configuration, which requires
+// PostgreSQL 18 or later
+func (r *Cluster) validateOAuth() field.ErrorList {
+	oauthConfig := r.Spec.PostgresConfiguration.OAuth
+	if oauthConfig == nil {
+		return nil
+	}
+
+	pgVersion, err := r.GetPostgresqlVersion()
+	if err != nil {
+		// The validation error will be already raised by the
+		// validateImageName function
+		return nil
+	}
+
+	if pgVersion.Major() < 18 {
+		return field.ErrorList{
+			field.Invalid(field.NewPath("spec", "postgresql", "oauth"), oauthConfig,
+				"OAuth authentication is supported from PostgreSQL 18"),
+		}
+	}
+
+	return nil
+}
+
+// validateTDE validates the configuration of the transparent data
+// encryption, which requires exactly one key provider
+func (r *Cluster) validateTDE() field.ErrorList {
+	tdeConfig := r.Spec.PostgresConfiguration.TDE
+	if tdeConfig == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "postgresql", "tde", "keyProvider")
+
+	keyProvider := tdeConfig.KeyProvider
+	providers := 0
+	for _, configured := range []bool{
+		keyProvider.Secret != nil,
+		keyProvider.Vault != nil,
+	} {
+		if configured {
+			providers++
+		}
+	}
+	if providers != 1 {
+		result = append(result, field.Invalid(path, keyProvider,
+			"exactly one key provider must be specified"))
+	}
+
+	if keyProvider.Vault != nil {
+		if _, err := url.ParseRequestURI(keyProvider.Vault.Address); err != nil {
+			result = append(result, field.Invalid(path.Child("vault", "address"),
+				keyProvider.Vault.Address, fmt.Sprintf("invalid Vault address: %v", err)))
+		}
+	}
+
+	return result
+}
+
+// validateTDEChange prevents enabling or disabling the transparent data
+// encryption of an existing cluster. Changing the key provider is allowed
+func (r *Cluster) validateTDEChange(old *Cluster) field.ErrorList {
+	isEnabled := r.Spec.PostgresConfiguration.TDE != nil
+	wasEnabled := old.Spec.PostgresConfiguration.TDE != nil
+	if isEnabled == wasEnabled {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Invalid(field.NewPath("spec", "postgresql", "tde"), r.Spec.PostgresConfiguration.TDE,
+			"TDE cannot be enabled or disabled after the cluster has been created"),
+	}
+}
+
+// pgHBAMethodOptions contains, for each authentication method, the
+// options that can be set in a structured pg_hba rule
+var pgHBAMethodOptions = map[PgHBAAuthMethod][]string{
+	PgHBAAuthMethodLDAP: {
+		"ldapserver", "ldapport", "ldapscheme", "ldaptls", "ldapprefix", "ldapsuffix",
+		"ldapbasedn", "ldapbinddn", "ldapbindpasswd", "ldapsearchattribute",
+		"ldapsearchfilter", "ldapurl",
+	},
+	PgHBAAuthMethodRADIUS: {"radiusservers", "radiussecrets", "radiusidentifiers", "radiusports"},
+	PgHBAAuthMethodGSS:    {"include_realm", "krb_realm", "map"},
+	PgHBAAuthMethodCert:   {"map"},
+	"ident":               {"map"},
+	"peer":                {"map"},
+	"pam":                 {"pamservice", "pam_use_hostname"},
+	PgHBAAuthMethodOAuth:  {"issuer", "scope", "validator", "map", "delegate_ident_mapping"},
+}
+
+// validatePgHBARules validates the structured pg_hba rules against
+// the PostgreSQL version used by the cluster
+func (r *Cluster) validatePgHBARules() field.ErrorList {
+	var result field.ErrorList
+
+	pgVersion, err := r.GetPostgresqlVersion()
+	if err != nil {
+		// The validation error will be already raised by the
+		// validateImageName function
+		return result
+	}
+
+	basePath := field.NewPath("spec", "postgresql", "pg_hba_rules")
+	for idx, rule := range r.Spec.PostgresConfiguration.PgHBARules {
+		rulePath := basePath.Index(idx)
+		result = append(result, validatePgHBAKeywordList(rulePath.Child("databases"), rule.Databases, pgVersion)...)
+		result = append(result, validatePgHBAKeywordList(rulePath.Child("users"), rule.Users, pgVersion)...)
+		result = append(result, validatePgHBAAddresses(rulePath, rule)...)
+		result = append(result, validatePgHBAOptions(rulePath.Child("options"), rule, pgVersion)...)
+		if rule.Method == PgHBAAuthMethodOAuth {
+			result = append(result, r.validatePgHBAOAuthRule(rulePath, rule, pgVersion)...)
+		}
+	}
+
+	return result
+}
+
+// auditClasses are the classes of statements that can be logged by pgaudit
+var auditClasses = []string{"read", "write", "function", "role", "ddl", "misc", "misc_set", "all", "none"}
+
+// validateAudit validates the declarative audit configuration
+func (r *Cluster) validateAudit() field.ErrorList {
+	audit := r.Spec.PostgresConfiguration.Audit
+	if audit == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	auditPath := field.NewPath("spec", "postgresql", "audit")
+
+	for key := range r.Spec.PostgresConfiguration.Parameters {
+		if strings.HasPrefix(key, "pgaudit.") {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", key), key,
+				"the pgaudit parameters are managed by the operator when spec.postgresql.audit is set"))
+		}
+	}
+
+	result = append(result, validateAuditClasses(auditPath.Child("classes"), audit.Classes)...)
+
+	roleNames := stringset.New()
+	for idx, role := range audit.Roles {
+		rolePath := auditPath.Child("roles").Index(idx)
+		if roleNames.Has(role.Name) {
+			result = append(result, field.Duplicate(rolePath.Child("name"), role.Name))
+		}
+		roleNames.Put(role.Name)
+		result = append(result, validateAuditClasses(rolePath.Child("classes"), role.Classes)...)
+	}
+
+	if audit.GetShipping() != nil && audit.Shipping.ObjectStore != nil &&
+		(r.Spec.Backup == nil || r.Spec.Backup.BarmanObjectStore == nil) {
+		result = append(result, field.Required(
+			field.NewPath("spec", "backup", "barmanObjectStore"),
+			"the audit log shipping to the object store requires the barmanObjectStore backup configuration"))
+	}
+
+	return result
+}
+
+// validateAuditClasses validates a list of pgaudit classes, which can
+// be excluded by prefixing them with `-`
+func validateAuditClasses(path *field.Path, classes []string) field.ErrorList {
+	var result field.ErrorList
+	for idx, class := range classes {
+		name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(class), "-"))
+		if !slices.Contains(auditClasses, name) {
+			result = append(result, field.NotSupported(path.Index(idx), class, auditClasses))
+		}
+	}
+	return result
+}
+
+// minQueryInsightsSnapshotInterval is the minimum interval between
+// two snapshots of the top statements
+const minQueryInsightsSnapshotInterval = 10 * time.Second
+
+// validateQueryInsights validates the query insights configuration
+func (r *Cluster) validateQueryInsights() field.ErrorList {
+	queryInsights := r.GetQueryInsights()
+	if queryInsights == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	queryInsightsPath := field.NewPath("spec", "monitoring", "queryInsights")
+
+	if queryInsights.SnapshotInterval != nil &&
+		queryInsights.SnapshotInterval.Duration < minQueryInsightsSnapshotInterval {
+		result = append(result, field.Invalid(
+			queryInsightsPath.Child("snapshotInterval"), queryInsights.SnapshotInterval.Duration.String(),
+			fmt.Sprintf("the snapshot interval must be at least %s", minQueryInsightsSnapshotInterval)))
+	}
+
+	if queryInsights.History != nil && queryInsights.History.Retention != nil &&
+		queryInsights.History.Retention.Duration < queryInsights.GetSnapshotInterval() {
+		result = append(result, field.Invalid(
+			queryInsightsPath.Child("history", "retention"), queryInsights.History.Retention.Duration.String(),
+			"the retention must be longer than the snapshot interval"))
+	}
+
+	return result
+}
+
+// lokiReservedLabels are the labels set by the instance manager
+// on the log streams pushed to Loki
+var lokiReservedLabels = []string{"namespace", "cluster", "pod", "logger"}
+
+// validateLogging validates the sinks where the PostgreSQL log
+// records are forwarded
+func (r *Cluster) validateLogging() field.ErrorList {
+	var result field.ErrorList
+	sinksPath := field.NewPath("spec", "logging", "sinks")
+
+	sinkNames := stringset.New()
+	for idx, sink := range r.GetLogSinks() {
+		sinkPath := sinksPath.Index(idx)
+		if sinkNames.Has(sink.Name) {
+			result = append(result, field.Duplicate(sinkPath.Child("name"), sink.Name))
+		}
+		sinkNames.Put(sink.Name)
+
+		if sink.Loki == nil {
+			continue
+		}
+		for label := range sink.Loki.Labels {
+			if slices.Contains(lokiReservedLabels, label) {
+				result = append(result, field.Invalid(
+					sinkPath.Child("loki", "labels").Key(label), label,
+					"this label is set by the operator on every log stream"))
+			}
+		}
+	}
+
+	return result
+}
+
+// weakPgHBAAuthMethods are the authentication methods that are not
+// allowed on the connections that are not local when TLS is enforced
+var weakPgHBAAuthMethods = []string{"trust", "password", "md5"}
+
+// validateSecurity validates the pg_hba rules and the password encryption
+// against the security profile of the cluster
+func (r *Cluster) validateSecurity() field.ErrorList {
+	if r.GetSecurityTLSMode() == "" {
+		return nil
+	}
+
+	var result field.ErrorList
+
+	if value, ok := r.Spec.PostgresConfiguration.Parameters["password_encryption"]; ok && value != "scram-sha-256" {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "postgresql", "parameters", "password_encryption"), value,
+			"the security profile requires scram-sha-256 password encryption"))
+	}
+
+	for idx, line := range r.Spec.PostgresConfiguration.PgHBA {
+		connectionType, method, ok := parsePgHBALine(line)
+		if !ok {
+			continue
+		}
+		if message := getSecurityPgHBAViolation(PgHBAConnectionType(connectionType), method); message != "" {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "pg_hba").Index(idx), line, message))
+		}
+	}
+
+	for idx, rule := range r.Spec.PostgresConfiguration.PgHBARules {
+		connectionType := rule.Type
+		if connectionType == "" {
+			connectionType = PgHBAConnectionTypeHostSSL
+		}
+		if message := getSecurityPgHBAViolation(connectionType, string(rule.Method)); message != "" {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "postgresql", "pg_hba_rules").Index(idx), rule, message))
+		}
+	}
+
+	return result
+}
+
+// getSecurityPgHBAViolation checks a pg_hba rule against the security
+// profile, returning the reason why it is not allowed
+func getSecurityPgHBAViolation(connectionType PgHBAConnectionType, method string) string {
+	switch {
+	case connectionType == PgHBAConnectionTypeLocal:
+		return ""
+	case connectionType != PgHBAConnectionTypeHostSSL:
+		return "the security profile only allows local and hostssl rules"
+	case slices.Contains(weakPgHBAAuthMethods, method):
+		return fmt.Sprintf("the security profile doesn't allow the %s method on connections that are not local",
+			method)
+	default:
+		return ""
+	}
+}
+
+// parsePgHBALine gets the connection type and the authentication
+// method of a pg_hba line, skipping empty lines and comments
+func parsePgHBALine(line string) (connectionType string, method string, ok bool) {
+	content, _, _ := strings.Cut(line, "#")
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+
+	// local database user method [options]
+	// host database user address [mask] method [options]
+	methodIdx := 4
+	if fields[0] == string(PgHBAConnectionTypeLocal) {
+		methodIdx = 3
+	} else if len(fields) > 5 && net.ParseIP(fields[4]) != nil {
+		methodIdx = 5
+	}
+	if len(fields) <= methodIdx {
+		return fields[0], "", true
+	}
+
+	return fields[0], fields[methodIdx], true
+}
+
+// validatePgHBAOAuthRule validates a pg_hba rule using the oauth
+// method, whose issuer and scope default to the OAuth configuration
+func (r *Cluster) validatePgHBAOAuthRule(path *field.Path, rule PgHBARule, pgVersion version.Data) field.ErrorList {
+	var result field.ErrorList
+
+	if pgVersion.Major() < 18 {
+		result = append(result, field.Invalid(path.Child("method"), rule.Method,
+			"the oauth method is supported from PostgreSQL 18"))
+	}
+
+	if r.Spec.PostgresConfiguration.OAuth == nil &&
+		(rule.Options["issuer"] == "" || rule.Options["scope"] == "") {
+		result = append(result, field.Required(path.Child("options"),
+			"the oauth method requires the issuer and scope options when spec.postgresql.oauth is not set"))
+	}
+
+	if issuer, ok := rule.Options["issuer"]; ok && !strings.HasPrefix(issuer, "https://") {
+		result = append(result, field.Invalid(path.Child("options").Key("issuer"), issuer,
+			"the issuer must be an HTTPS URL"))
+	}
+
+	return result
+}
+
+// validatePgHBAKeywordList validates the databases or the users of a
+// pg_hba rule. Regular expressions are supported since PostgreSQL 16
+func validatePgHBAKeywordList(path *field.Path, values []string, pgVersion version.Data) field.ErrorList {
+	var result field.ErrorList
+	for idx, value := range values {
+		switch {
+		case value == "":
+			result = append(result, field.Invalid(path.Index(idx), value, "cannot be empty"))
+		case strings.HasPrefix(value, "/") && pgVersion.Major() < 16:
+			result = append(result, field.Invalid(path.Index(idx), value,
+				"regular expressions are supported from PostgreSQL 16"))
+		}
+	}
+	return result
+}
+
+// validatePgHBAAddresses validates the client addresses of a pg_hba rule
+func validatePgHBAAddresses(path *field.Path, rule PgHBARule) field.ErrorList {
+	var result field.ErrorList
+
+	if rule.Type == PgHBAConnectionTypeLocal {
+		if len(rule.Addresses) > 0 || len(rule.AddressesFrom) > 0 {
+			result = append(result, field.Invalid(path.Child("addresses"), rule.Addresses,
+				"client addresses cannot be specified for local connections"))
+		}
+		return result
+	}
+
+	for idx, address := range rule.Addresses {
+		if err := ValidatePgHBAAddress(address); err != nil {
+			result = append(result, field.Invalid(path.Child("addresses").Index(idx), address, err.Error()))
+		}
+	}
+
+	for idx, ref := range rule.AddressesFrom {
+		if ref.Name == "" || ref.Key == "" {
+			result = append(result, field.Invalid(path.Child("addressesFrom").Index(idx), ref,
+				"both the ConfigMap name and key are required"))
+		}
+	}
+
+	return result
+}
+
+// validatePgHBAOptions validates the options of a pg_hba rule against
+// its authentication method
+func validatePgHBAOptions(path *field.Path, rule PgHBARule, pgVersion version.Data) field.ErrorList {
+	var result field.ErrorList
+
+	for _, name := range slices.Sorted(maps.Keys(rule.Options)) {
+		switch {
+		case name == "clientcert":
+			if rule.Type != PgHBAConnectionTypeHostSSL {
+				result = append(result, field.Invalid(path.Key(name), rule.Options[name],
+					"clientcert is only supported by hostssl rules"))
+			}
+		case name == "clientname":
+			if rule.Type != PgHBAConnectionTypeHostSSL {
+				result = append(result, field.Invalid(path.Key(name), rule.Options[name],
+					"clientname is only supported by hostssl rules"))
+			}
+			if pgVersion.Major() < 14 {
+				result = append(result, field.Invalid(path.Key(name), rule.Options[name],
+					"clientname is supported from PostgreSQL 14"))
+			}
+		case !slices.Contains(pgHBAMethodOptions[rule.Method], name):
+			result = append(result, field.NotSupported(path.Key(name), name, pgHBAMethodOptions[rule.Method]))
+		}
+	}
+
+	switch rule.Method {
+	case PgHBAAuthMethodLDAP:
+		if rule.Options["ldapserver"] == "" && rule.Options["ldapurl"] == "" {
+			result = append(result, field.Required(path,
+				"the ldap method requires either the ldapserver or the ldapurl option"))
+		}
+	case PgHBAAuthMethodRADIUS:
+		if rule.Options["radiusservers"] == "" || rule.Options["radiussecrets"] == "" {
+			result = append(result, field.Required(path,
+				"the radius method requires the radiusservers and radiussecrets options"))
+		}
+	}
+
+	return result
+}
+
 // validateEnv validate the environment variables settings proposed by the user
 func (r *Cluster) validateEnv() field.ErrorList {
 	var result field.ErrorList
@@ -777,6 +1255,48 @@ func (r *Cluster) validateCerts() field.ErrorList {
 	return result
 }
 
+// validateCertificateProvider validates the external provider issuing
+// the certificates, which replaces the user-provided secrets
+func (r *Cluster) validateCertificateProvider() field.ErrorList {
+	certificateProvider := r.GetCertificateProvider()
+	if certificateProvider == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "certificates")
+
+	userProvidedSecrets := []struct {
+		name  string
+		value string
+	}{
+		{name: "serverCASecret", value: r.Spec.Certificates.ServerCASecret},
+		{name: "serverTLSSecret", value: r.Spec.Certificates.ServerTLSSecret},
+		{name: "clientCASecret", value: r.Spec.Certificates.ClientCASecret},
+		{name: "replicationTLSSecret", value: r.Spec.Certificates.ReplicationTLSSecret},
+	}
+	for _, secret := range userProvidedSecrets {
+		if secret.value != "" {
+			result = append(result, field.Invalid(path.Child(secret.name), secret.value,
+				"user-provided certificates can't be used together with a certificate provider"))
+		}
+	}
+
+	if (certificateProvider.Vault != nil) == (certificateProvider.AWSPrivateCA != nil) {
+		result = append(result, field.Invalid(path.Child("provider"), certificateProvider,
+			"exactly one certificate provider must be specified"))
+	}
+
+	if certificateProvider.Vault != nil {
+		if _, err := url.ParseRequestURI(certificateProvider.Vault.Address); err != nil {
+			result = append(result, field.Invalid(path.Child("provider", "vault", "address"),
+				certificateProvider.Vault.Address, fmt.Sprintf("invalid Vault address: %v", err)))
+		}
+	}
+
+	return result
+}
+
 // ValidateSuperuserSecret validate super user secret value
 func (r *Cluster) validateSuperuserSecret() field.ErrorList {
 	var result field.ErrorList
@@ -819,6 +1339,12 @@ func (r *Cluster) validateBootstrapMethod() field.ErrorList {
 	if r.Spec.Bootstrap.PgBaseBackup != nil {
 		bootstrapMethods++
 	}
+	if r.Spec.Bootstrap.Clone != nil {
+		bootstrapMethods++
+	}
+	if r.Spec.Bootstrap.Migration != nil {
+		bootstrapMethods++
+	}
 
 	if bootstrapMethods > 1 {
 		result = append(
@@ -856,60 +1382,213 @@ func (r *Cluster) validateBootstrapPgBaseBackupSource() field.ErrorList {
 	return result
 }
 
-// validateBootstrapRecoverySource is used to ensure that the source
-// server is correctly defined
-func (r *Cluster) validateBootstrapRecoverySource() field.ErrorList {
-	var result field.ErrorList
-
-	// This validation is only applicable for recovery based bootstrap
-	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Recovery == nil || r.Spec.Bootstrap.Recovery.Source == "" {
-		return result
-	}
-
-	externalCluster, found := r.ExternalCluster(r.Spec.Bootstrap.Recovery.Source)
-
-	// Ensure the existence of the external cluster
-	if !found {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "bootstrap", "recovery", "source"),
-				r.Spec.Bootstrap.Recovery.Source,
-				fmt.Sprintf("External cluster %v not found", r.Spec.Bootstrap.Recovery.Source)))
+// validateBootstrapClone is used to ensure that a cluster is not
+// cloning itself, and that the application database is correctly defined
+func (r *Cluster) validateBootstrapClone() field.ErrorList {
+	if !r.IsBootstrappedWithClone() {
+		return nil
 	}
 
-	// Ensure the external cluster definition has enough information
-	// to be used to recover a data directory
-	if externalCluster.BarmanObjectStore == nil && externalCluster.PluginConfiguration == nil {
+	clone := r.Spec.Bootstrap.Clone
+	result := r.validateApplicationDatabase(clone.Database, clone.Owner, "clone")
+	if clone.Cluster == r.Name && r.GetCloneSourceNamespace() == r.Namespace {
 		result = append(
 			result,
 			field.Invalid(
-				field.NewPath("spec", "bootstrap", "recovery", "source"),
-				r.Spec.Bootstrap.Recovery.Source,
-				fmt.Sprintf("External cluster %v cannot be used for recovery: "+
-					"both Barman and CNPG-i plugin configurations are missing", r.Spec.Bootstrap.Recovery.Source)))
+				field.NewPath("spec", "bootstrap", "clone", "cluster"),
+				clone.Cluster,
+				"A cluster cannot be cloned into itself"))
 	}
 
 	return result
 }
 
-// validateBootstrapRecoveryDataSource is used to ensure that the data
-// source is correctly defined
-func (r *Cluster) validateBootstrapRecoveryDataSource() field.ErrorList {
-	// This validation is only applicable for datasource-based recovery based bootstrap
-	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Recovery == nil || r.Spec.Bootstrap.Recovery.VolumeSnapshots == nil {
+// validateDataMasking is used to ensure that the data masking SQL
+// references are complete, and that the cluster can be written
+func (r *Cluster) validateDataMasking() field.ErrorList {
+	masking := r.GetDataMasking()
+	if masking == nil {
 		return nil
 	}
 
-	recoveryPath := field.NewPath("spec", "bootstrap", "recovery")
-	recoverySection := r.Spec.Bootstrap.Recovery
-	if recoverySection.Backup != nil {
-		return field.ErrorList{
-			field.Invalid(
-				recoveryPath.Child("backup"),
-				r.Spec.Bootstrap.Recovery.Backup,
-				"Recovery from dataSource is not compatible with other types of recovery"),
-		}
+	var method string
+	switch {
+	case r.Spec.Bootstrap.Recovery != nil:
+		method = "recovery"
+	case r.Spec.Bootstrap.PgBaseBackup != nil:
+		method = "pg_basebackup"
+	default:
+		method = "clone"
+	}
+	path := field.NewPath("spec", "bootstrap", method, "masking")
+
+	var result field.ErrorList
+	if r.IsReplica() {
+		result = append(
+			result,
+			field.Invalid(
+				path,
+				"",
+				"Data masking is not compatible with replica clusters"))
+	}
+
+	if masking.SQLRefs != nil {
+		for _, item := range masking.SQLRefs.SecretRefs {
+			if item.Name == "" || item.Key == "" {
+				result = append(
+					result,
+					field.Invalid(
+						path.Child("sqlRefs", "secretRefs"),
+						item,
+						"key and name must be specified"))
+			}
+		}
+
+		for _, item := range masking.SQLRefs.ConfigMapRefs {
+			if item.Name == "" || item.Key == "" {
+				result = append(
+					result,
+					field.Invalid(
+						path.Child("sqlRefs", "configMapRefs"),
+						item,
+						"key and name must be specified"))
+			}
+		}
+	}
+
+	return result
+}
+
+// validateBootstrapMigration is used to ensure that the source server
+// of a migration is correctly defined, and that the databases and the
+// roles to migrate are valid
+func (r *Cluster) validateBootstrapMigration() field.ErrorList {
+	var result field.ErrorList
+
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Migration == nil {
+		return result
+	}
+
+	migration := r.Spec.Bootstrap.Migration
+	path := field.NewPath("spec", "bootstrap", "migration")
+	if _, found := r.ExternalCluster(migration.Source); !found {
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("source"),
+				migration.Source,
+				fmt.Sprintf("External cluster %v not found", migration.Source)))
+	}
+
+	if len(migration.Databases) > 1 && slices.Contains(migration.Databases, "*") {
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("databases"),
+				migration.Databases,
+				"Wildcard migration cannot be used along other database names"))
+	}
+
+	if len(migration.Roles) > 1 && slices.Contains(migration.Roles, "*") {
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("roles"),
+				migration.Roles,
+				"Wildcard migration cannot be used along other role names"))
+	}
+
+	if r.IsReplica() {
+		result = append(
+			result,
+			field.Invalid(
+				path,
+				migration.Source,
+				"A replica cluster cannot be bootstrapped with a migration"))
+	}
+
+	return result
+}
+
+// validateBootstrapRecoverySource is used to ensure that the source
+// server is correctly defined
+func (r *Cluster) validateBootstrapRecoverySource() field.ErrorList {
+	var result field.ErrorList
+
+	// This validation is only applicable for recovery based bootstrap
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Recovery == nil || r.Spec.Bootstrap.Recovery.Source == "" {
+		return result
+	}
+
+	externalCluster, found := r.ExternalCluster(r.Spec.Bootstrap.Recovery.Source)
+
+	// Ensure the existence of the external cluster
+	if !found {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "recovery", "source"),
+				r.Spec.Bootstrap.Recovery.Source,
+				fmt.Sprintf("External cluster %v not found", r.Spec.Bootstrap.Recovery.Source)))
+	}
+
+	// Ensure the external cluster definition has enough information
+	// to be used to recover a data directory
+	if externalCluster.BarmanObjectStore == nil && externalCluster.PluginConfiguration == nil &&
+		externalCluster.PgBackRest == nil {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "recovery", "source"),
+				r.Spec.Bootstrap.Recovery.Source,
+				fmt.Sprintf("External cluster %v cannot be used for recovery: "+
+					"Barman, pgBackRest and CNPG-i plugin configurations are missing", r.Spec.Bootstrap.Recovery.Source)))
+	}
+
+	return result
+}
+
+// validateBootstrapRecoveryBackup is used to ensure that the namespace of
+// the backup the cluster is bootstrapped from is valid
+func (r *Cluster) validateBootstrapRecoveryBackup() field.ErrorList {
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Recovery == nil || r.Spec.Bootstrap.Recovery.Backup == nil {
+		return nil
+	}
+
+	namespace := r.Spec.Bootstrap.Recovery.Backup.Namespace
+	if namespace == "" {
+		return nil
+	}
+
+	if errs := validationutil.IsDNS1123Label(namespace); len(errs) > 0 {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "recovery", "backup", "namespace"),
+				namespace,
+				strings.Join(errs, ", ")),
+		}
+	}
+
+	return nil
+}
+
+// validateBootstrapRecoveryDataSource is used to ensure that the data
+// source is correctly defined
+func (r *Cluster) validateBootstrapRecoveryDataSource() field.ErrorList {
+	// This validation is only applicable for datasource-based recovery based bootstrap
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.Recovery == nil || r.Spec.Bootstrap.Recovery.VolumeSnapshots == nil {
+		return nil
+	}
+
+	recoveryPath := field.NewPath("spec", "bootstrap", "recovery")
+	recoverySection := r.Spec.Bootstrap.Recovery
+	if recoverySection.Backup != nil {
+		return field.ErrorList{
+			field.Invalid(
+				recoveryPath.Child("backup"),
+				r.Spec.Bootstrap.Recovery.Backup,
+				"Recovery from dataSource is not compatible with other types of recovery"),
+		}
 	}
 
 	if recoverySection.RecoveryTarget != nil && recoverySection.RecoveryTarget.BackupID != "" {
@@ -945,6 +1624,43 @@ func (r *Cluster) validateBootstrapRecoveryDataSource() field.ErrorList {
 				recoveryPath.Child("dataSource", "walStorage"))...)
 	}
 
+	result = append(result, r.validateBootstrapRecoveryTablespaceSources()...)
+
+	return result
+}
+
+// validateBootstrapRecoveryTablespaceSources checks that every declared
+// tablespace has a snapshot to be restored from, and vice versa
+func (r *Cluster) validateBootstrapRecoveryTablespaceSources() field.ErrorList {
+	var result field.ErrorList
+	tablespaceStoragePath := field.NewPath("spec", "bootstrap", "recovery", "volumeSnapshots", "tablespaceStorage")
+	tablespaceSources := r.Spec.Bootstrap.Recovery.VolumeSnapshots.TablespaceStorage
+
+	for _, name := range slices.Sorted(maps.Keys(tablespaceSources)) {
+		path := tablespaceStoragePath.Key(name)
+		if !slices.ContainsFunc(r.Spec.Tablespaces, func(tbs TablespaceConfiguration) bool {
+			return tbs.Name == name
+		}) {
+			result = append(
+				result,
+				field.Invalid(
+					path,
+					name,
+					"A tablespace must be declared in the cluster to be restored from a snapshot"))
+		}
+		result = append(result, validateVolumeSnapshotSource(tablespaceSources[name], path)...)
+	}
+
+	for _, tablespace := range r.Spec.Tablespaces {
+		if _, ok := tablespaceSources[tablespace.Name]; !ok && !tablespace.IsEphemeral() {
+			result = append(
+				result,
+				field.Required(
+					tablespaceStoragePath.Key(tablespace.Name),
+					"A snapshot is required for every tablespace when recovering using a DataSource"))
+		}
+	}
+
 	return result
 }
 
@@ -1031,6 +1747,27 @@ func (r *Cluster) validateImagePullPolicy() field.ErrorList {
 	}
 }
 
+// validateImageVerification ensures the image verification trusts
+// at least a public key
+func (r *Cluster) validateImageVerification() field.ErrorList {
+	var result field.ErrorList
+
+	verification := r.Spec.ImageVerification
+	if verification == nil {
+		return result
+	}
+
+	if len(verification.PublicKeys) == 0 {
+		result = append(
+			result,
+			field.Required(
+				field.NewPath("spec", "imageVerification", "publicKeys"),
+				"at least one public key must be specified"))
+	}
+
+	return result
+}
+
 func (r *Cluster) validateResources() field.ErrorList {
 	var result field.ErrorList
 
@@ -1089,6 +1826,62 @@ func (r *Cluster) validateResources() field.ErrorList {
 	return result
 }
 
+// validateWALSpaceProtection checks that the primary instance enters the
+// protective mode before being fenced
+func (r *Cluster) validateWALSpaceProtection() field.ErrorList {
+	configuration := r.Spec.WALSpaceProtection
+	if configuration == nil || configuration.FencingThreshold == nil {
+		return nil
+	}
+
+	if *configuration.FencingThreshold <= configuration.GetThreshold() {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec", "walSpaceProtection", "fencingThreshold"),
+			*configuration.FencingThreshold,
+			"The fencing threshold must be greater than the threshold of the protective mode"),
+		}
+	}
+
+	return nil
+}
+
+// validateHugePages checks that the instances request a single size of
+// huge pages, and that the shared buffers fit in them
+func (r *Cluster) validateHugePages() field.ErrorList {
+	var result field.ErrorList
+
+	hugePagesSizes := 0
+	for name := range r.Spec.Resources.Limits {
+		if strings.HasPrefix(string(name), v1.ResourceHugePagesPrefix) {
+			hugePagesSizes++
+		}
+	}
+	if hugePagesSizes > 1 {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "resources", "limits"),
+			hugePagesSizes,
+			"Only one size of huge pages can be requested",
+		))
+	}
+
+	name, hugePages := r.GetHugePages()
+	rawSharedBuffers := r.GetPostgresParameters()[sharedBuffersParameter]
+	if name == "" || rawSharedBuffers == "" {
+		return result
+	}
+
+	sharedBuffers, err := parsePostgresQuantityValue(rawSharedBuffers)
+	if err == nil && hugePages.Cmp(sharedBuffers) <= 0 {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "resources", "limits", string(name)),
+			hugePages.String(),
+			"Huge pages limit is not enough to contain PostgreSQL `shared_buffers`",
+		))
+	}
+
+	return result
+}
+
 func (r *Cluster) validateSynchronousReplicaConfiguration() field.ErrorList {
 	if r.Spec.PostgresConfiguration.Synchronous == nil {
 		return nil
@@ -1096,7 +1889,23 @@ func (r *Cluster) validateSynchronousReplicaConfiguration() field.ErrorList {
 
 	var result field.ErrorList
 
+	synchronousReplicaClusters := 0
+	for idx, externalCluster := range r.Spec.ExternalClusters {
+		if !externalCluster.SynchronousReplica || externalCluster.Name == r.GetSelfName() {
+			continue
+		}
+		synchronousReplicaClusters++
+
+		if r.Spec.PostgresConfiguration.Synchronous.DataDurability == DataDurabilityLevelPreferred {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "externalClusters").Index(idx).Child("synchronousReplica"),
+				externalCluster.SynchronousReplica,
+				"synchronous replica clusters require dataDurability to be set to 'required'"))
+		}
+	}
+
 	if r.Spec.PostgresConfiguration.Synchronous.Number >= (r.Spec.Instances +
+		synchronousReplicaClusters +
 		len(r.Spec.PostgresConfiguration.Synchronous.StandbyNamesPost) +
 		len(r.Spec.PostgresConfiguration.Synchronous.StandbyNamesPre)) {
 		err := field.Invalid(
@@ -1405,7 +2214,8 @@ func validateSyncReplicaElectionConstraint(constraints SyncReplicaElectionConstr
 }
 
 // validateImageChange validate the change from a certain image name
-// to a new one.
+// to a new one. Major version upgrades are allowed, and will be
+// executed via pg_upgrade, while downgrades are not.
 func (r *Cluster) validateImageChange(old *Cluster) field.ErrorList {
 	var result field.ErrorList
 	var newVersion, oldVersion version.Data
@@ -1433,16 +2243,51 @@ func (r *Cluster) validateImageChange(old *Cluster) field.ErrorList {
 		return result
 	}
 
-	status := version.IsUpgradePossible(oldVersion, newVersion)
+	if version.IsUpgradePossible(oldVersion, newVersion) {
+		return result
+	}
+
+	switch {
+	case newVersion.Major() < oldVersion.Major():
+		result = append(
+			result,
+			field.Invalid(
+				newImagePath,
+				newVersion,
+				fmt.Sprintf("can't downgrade from major %v to %v",
+					oldVersion.Major(), newVersion.Major())))
+
+	case r.IsReplica():
+		result = append(
+			result,
+			field.Invalid(
+				newImagePath,
+				newVersion,
+				"can't upgrade the major version of a replica cluster"))
+
+	case r.GetMajorUpgradeMethod() == MajorUpgradeMethodPgUpgrade && len(r.Spec.Tablespaces) > 0:
+		result = append(
+			result,
+			field.Invalid(
+				newImagePath,
+				newVersion,
+				"can't upgrade the major version of a cluster using tablespaces via pg_upgrade"))
+
+	case r.GetMajorUpgradeMethod() == MajorUpgradeMethodLogical && !r.GetEnableSuperuserAccess():
+		result = append(
+			result,
+			field.Invalid(
+				newImagePath,
+				newVersion,
+				"the logical major version upgrade requires enableSuperuserAccess"))
 
-	if !status {
+	case r.GetMajorUpgradeMethod() == MajorUpgradeMethodLogical && old.Status.LogicalMajorUpgrade != nil:
 		result = append(
 			result,
 			field.Invalid(
 				newImagePath,
 				newVersion,
-				fmt.Sprintf("can't upgrade between majors %v and %v",
-					oldVersion, newVersion)))
+				"can't change the major version while a logical major version upgrade is in progress"))
 	}
 
 	return result
@@ -1688,6 +2533,56 @@ func validateStorageConfigurationSize(
 			"Size not configured. Please add it, or a storage request in the pvcTemplate."))
 	}
 
+	result = append(result, validateStorageAutoResize(structPath, storageConfiguration)...)
+
+	return result
+}
+
+// validateStorageAutoResize checks the configuration of the automatic
+// expansion of the volumes
+func validateStorageAutoResize(
+	structPath field.Path,
+	storageConfiguration StorageConfiguration,
+) field.ErrorList {
+	if !storageConfiguration.IsAutoResizeEnabled() {
+		return nil
+	}
+
+	var result field.ErrorList
+	autoResizePath := structPath.Child("autoResize")
+
+	if storageConfiguration.ResizeInUseVolumes != nil && !*storageConfiguration.ResizeInUseVolumes {
+		result = append(result, field.Invalid(
+			autoResizePath.Child("enabled"),
+			storageConfiguration.AutoResize.Enabled,
+			"The automatic expansion of the volumes requires resizeInUseVolumes to be enabled"))
+	}
+
+	size := storageConfiguration.GetSizeOrNil()
+	if size == nil {
+		return result
+	}
+
+	if _, err := storageConfiguration.AutoResize.GetNextSize(*size); err != nil {
+		result = append(result, field.Invalid(
+			autoResizePath,
+			storageConfiguration.AutoResize,
+			err.Error()))
+		return result
+	}
+
+	if storageConfiguration.AutoResize.MaxSize == "" {
+		return result
+	}
+
+	maxSize, err := resource.ParseQuantity(storageConfiguration.AutoResize.MaxSize)
+	if err == nil && maxSize.Cmp(*size) < 0 {
+		result = append(result, field.Invalid(
+			autoResizePath.Child("maxSize"),
+			storageConfiguration.AutoResize.MaxSize,
+			"The maximum size cannot be lower than the size of the storage"))
+	}
+
 	return result
 }
 
@@ -1740,18 +2635,29 @@ func (r *Cluster) validateTablespacesChange(old *Cluster) field.ErrorList {
 	var errs field.ErrorList
 	for idx, oldConf := range old.Spec.Tablespaces {
 		name := oldConf.Name
-		if newConf := r.GetTablespaceConfiguration(name); newConf != nil {
-			errs = append(errs, validateStorageConfigurationChange(
-				field.NewPath("spec", "tablespaces").Index(idx),
-				oldConf.Storage,
-				newConf.Storage,
-			)...)
-		} else {
+		newConf := r.GetTablespaceConfiguration(name)
+		switch {
+		case newConf == nil:
 			errs = append(errs,
 				field.Invalid(
 					field.NewPath("spec", "tablespaces").Index(idx),
 					r.Spec.Tablespaces,
 					"no tablespace can be deleted once created"))
+		case oldConf.IsEphemeral() != newConf.IsEphemeral():
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "tablespaces").Index(idx).Child("ephemeral"),
+					newConf.Ephemeral,
+					"a tablespace cannot be switched between ephemeral and persistent volumes"))
+		case newConf.IsEphemeral():
+			// The size of an ephemeral volume can be freely changed, as
+			// the volume is recreated together with the Pod
+		default:
+			errs = append(errs, validateStorageConfigurationChange(
+				field.NewPath("spec", "tablespaces").Index(idx),
+				oldConf.Storage,
+				newConf.Storage,
+			)...)
 		}
 	}
 	return errs
@@ -1842,6 +2748,42 @@ func (r *Cluster) validateTablespaceNames() field.ErrorList {
 	return result
 }
 
+// validateEphemeralTablespaces checks that only temporary tablespaces are
+// backed by ephemeral volumes, as their content is lost at every restart
+func (r *Cluster) validateEphemeralTablespaces() field.ErrorList {
+	var result field.ErrorList
+
+	for idx, tbsConfig := range r.Spec.Tablespaces {
+		if !tbsConfig.IsEphemeral() {
+			continue
+		}
+
+		tbsPath := field.NewPath("spec", "tablespaces").Index(idx)
+		if !tbsConfig.Temporary {
+			result = append(result, field.Invalid(
+				tbsPath.Child("ephemeral"),
+				tbsConfig.Ephemeral,
+				"only temporary tablespaces can be ephemeral"))
+		}
+
+		if tbsConfig.Ephemeral.GetVolumeType() == EphemeralTablespaceVolumeTypeGeneric &&
+			tbsConfig.Storage.GetSizeOrNil() == nil {
+			result = append(result, field.Required(
+				tbsPath.Child("storage", "size"),
+				"the size is required for tablespaces backed by a generic ephemeral volume"))
+		}
+
+		if tbsConfig.Storage.IsAutoResizeEnabled() {
+			result = append(result, field.Invalid(
+				tbsPath.Child("storage", "autoResize"),
+				tbsConfig.Storage.AutoResize,
+				"automatic volume expansion is not supported for ephemeral tablespaces"))
+		}
+	}
+
+	return result
+}
+
 func (r *Cluster) validateTablespaceBackupSnapshot() field.ErrorList {
 	if r.Spec.Backup == nil || r.Spec.Backup.VolumeSnapshot == nil ||
 		len(r.Spec.Backup.VolumeSnapshot.TablespaceClassName) == 0 {
@@ -1892,12 +2834,13 @@ func (r *Cluster) validateExternalCluster(externalCluster *ExternalCluster, path
 
 	if externalCluster.ConnectionParameters == nil &&
 		externalCluster.BarmanObjectStore == nil &&
+		externalCluster.PgBackRest == nil &&
 		externalCluster.PluginConfiguration == nil {
 		result = append(result,
 			field.Invalid(
 				path,
 				externalCluster,
-				"one of connectionParameters, plugin and barmanObjectStore is required"))
+				"one of connectionParameters, plugin, barmanObjectStore and pgBackRest is required"))
 	}
 
 	return result
@@ -2037,7 +2980,92 @@ func (r *Cluster) validateReplicaMode() field.ErrorList {
 		}
 	}
 
+	if replicaClusterConf.PromotionTokenSecret != nil && replicaClusterConf.MinApplyDelay != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "replicaCluster", "minApplyDelay"),
+			replicaClusterConf.MinApplyDelay,
+			"minApplyDelay cannot be applied with a promotion token secret"))
+	}
+
+	if replicaClusterConf.GetOnTimelineDivergence() != TimelineDivergencePolicyManual {
+		// pg_rewind and pg_basebackup need a connection to the source cluster
+		if source, ok := r.ExternalCluster(r.GetReplicaSource()); ok && len(source.ConnectionParameters) == 0 {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "replicaCluster", "onTimelineDivergence"),
+				replicaClusterConf.OnTimelineDivergence,
+				"realigning the designated primary requires a streaming connection to the source cluster"))
+		}
+	}
+
 	result = append(result, r.validateReplicaClusterExternalClusters()...)
+	result = append(result, r.validateReplicaClusterTopology()...)
+
+	return result
+}
+
+func (r *Cluster) validateReplicaClusterTopology() field.ErrorList {
+	var result field.ErrorList
+	replicaClusterConf := r.Spec.ReplicaCluster
+	if replicaClusterConf == nil || len(replicaClusterConf.Topology) == 0 {
+		return result
+	}
+
+	basePath := field.NewPath("spec", "replicaCluster", "topology")
+	if len(replicaClusterConf.Primary) == 0 {
+		result = append(result, field.Invalid(
+			basePath,
+			replicaClusterConf.Topology,
+			"a replica cluster topology requires the primary field to be set"))
+	}
+
+	// Every cluster can have only one upstream and the links must not
+	// contain loops, otherwise the topology is not a tree
+	roots := make(map[string]string)
+	var findRoot func(name string) string
+	findRoot = func(name string) string {
+		parent, ok := roots[name]
+		if !ok || parent == name {
+			return name
+		}
+		return findRoot(parent)
+	}
+
+	downstreams := stringset.New()
+	for idx, link := range replicaClusterConf.Topology {
+		linkPath := basePath.Index(idx)
+		for _, name := range []string{link.Name, link.Upstream} {
+			if _, found := r.ExternalCluster(name); !found {
+				result = append(result, field.Invalid(
+					linkPath,
+					name,
+					fmt.Sprintf("External cluster %v not found", name)))
+			}
+		}
+
+		if link.Name == link.Upstream {
+			result = append(result, field.Invalid(
+				linkPath.Child("upstream"),
+				link.Upstream,
+				"a cluster cannot cascade from itself"))
+			continue
+		}
+
+		if downstreams.Has(link.Name) {
+			result = append(result, field.Duplicate(linkPath.Child("name"), link.Name))
+			continue
+		}
+		downstreams.Put(link.Name)
+
+		nameRoot, upstreamRoot := findRoot(link.Name), findRoot(link.Upstream)
+		if nameRoot == upstreamRoot {
+			result = append(result, field.Invalid(
+				linkPath,
+				link,
+				"the replica cluster topology must not contain loops"))
+			continue
+		}
+		roots[nameRoot] = upstreamRoot
+	}
 
 	return result
 }
@@ -2086,6 +3114,48 @@ func (r *Cluster) validateReplicaClusterExternalClusters() field.ErrorList {
 	return result
 }
 
+// validateWitness ensures that the witness is only enabled
+// in clusters made of two instances
+func (r *Cluster) validateWitness() field.ErrorList {
+	if !r.IsWitnessEnabled() || r.Spec.Instances == 2 {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "witness", "enabled"),
+			r.Spec.Witness.Enabled,
+			"The witness is only supported in clusters made of two instances"),
+	}
+}
+
+// validateTopology validates the zone constraints of the instances
+func (r *Cluster) validateTopology() field.ErrorList {
+	if r.Spec.Topology == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec", "topology")
+	var result field.ErrorList
+	if r.Spec.Topology.ZoneLabel != "" {
+		result = append(result, validation.ValidateLabelName(r.Spec.Topology.ZoneLabel, path.Child("zoneLabel"))...)
+	}
+
+	zones := stringset.New()
+	for idx, zone := range r.Spec.Topology.PrimaryZones {
+		switch {
+		case zone == "":
+			result = append(result, field.Invalid(path.Child("primaryZones").Index(idx), zone,
+				"The zone name cannot be empty"))
+		case zones.Has(zone):
+			result = append(result, field.Duplicate(path.Child("primaryZones").Index(idx), zone))
+		}
+		zones.Put(zone)
+	}
+
+	return result
+}
+
 // validateTolerations check and validate the tolerations field
 // This code is almost a verbatim copy of
 // https://github.com/kubernetes/kubernetes/blob/4d38d21/pkg/apis/core/validation/validation.go#L3147
@@ -2194,10 +3264,241 @@ func (r *Cluster) validateBackupConfiguration() field.ErrorList {
 	if r.Spec.Backup == nil {
 		return nil
 	}
-	return barmanWebhooks.ValidateBackupConfiguration(
+	result := barmanWebhooks.ValidateBackupConfiguration(
 		r.Spec.Backup.BarmanObjectStore,
 		field.NewPath("spec", "backup", "barmanObjectStore"),
 	)
+
+	// Both barman-cloud and pgBackRest would archive WAL files
+	if r.Spec.Backup.BarmanObjectStore != nil && r.Spec.Backup.PgBackRest != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "backup", "pgBackRest"),
+			r.Spec.Backup.PgBackRest,
+			"pgBackRest cannot be used together with barmanObjectStore"))
+	}
+
+	result = append(result, r.validateBackupVerification()...)
+	result = append(result, r.validateBackupMirror()...)
+	result = append(result, r.validateWALArchiveHealth()...)
+	result = append(result, r.validateWALArchiveTarget()...)
+	result = append(result, r.validateBackupTargetSelector()...)
+
+	return result
+}
+
+// validateMaintenanceWindows validates the maintenance windows
+// gating the disruptive operations
+func (r *Cluster) validateMaintenanceWindows() field.ErrorList {
+	return validateWindows(field.NewPath("spec", "maintenanceWindows"), r.Spec.MaintenanceWindows)
+}
+
+// validateHibernation validates the windows of the scheduled hibernation
+func (r *Cluster) validateHibernation() field.ErrorList {
+	if r.Spec.Hibernation == nil {
+		return nil
+	}
+
+	return validateWindows(field.NewPath("spec", "hibernation", "windows"), r.Spec.Hibernation.Windows)
+}
+
+// validateLifecycleHooks validates the lifecycle hooks of the cluster
+func (r *Cluster) validateLifecycleHooks() field.ErrorList {
+	var result field.ErrorList
+
+	hooksPath := field.NewPath("spec", "lifecycleHooks")
+	names := stringset.New()
+	for idx := range r.Spec.LifecycleHooks {
+		hook := &r.Spec.LifecycleHooks[idx]
+		hookPath := hooksPath.Index(idx)
+
+		if names.Has(hook.Name) {
+			result = append(result, field.Duplicate(hookPath.Child("name"), hook.Name))
+		}
+		names.Put(hook.Name)
+
+		if (hook.Exec == nil) == (hook.Job == nil) {
+			result = append(result, field.Invalid(
+				hookPath,
+				hook.Name,
+				"exactly one of exec and job needs to be defined"))
+		}
+
+		if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+			result = append(result, field.Invalid(
+				hookPath.Child("timeout"),
+				hook.Timeout.String(),
+				"the timeout of a hook must be positive"))
+		}
+
+		// The exec hooks run while reconciling the cluster, which
+		// can't be held back for long
+		if hook.Exec != nil && hook.GetTimeout() > time.Minute {
+			result = append(result, field.Invalid(
+				hookPath.Child("timeout"),
+				hook.GetTimeout().String(),
+				"the timeout of an exec hook can't exceed 1 minute, use a job for longer tasks"))
+		}
+	}
+
+	return result
+}
+
+// validateWindows validates the schedule and the duration of a list of
+// time windows
+func validateWindows(windowsPath *field.Path, windows []MaintenanceWindow) field.ErrorList {
+	var result field.ErrorList
+	for idx, window := range windows {
+		windowPath := windowsPath.Index(idx)
+		if _, err := cron.Parse(window.Schedule); err != nil {
+			result = append(result, field.Invalid(
+				windowPath.Child("schedule"),
+				window.Schedule,
+				err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			result = append(result, field.Invalid(
+				windowPath.Child("duration"),
+				window.Duration.String(),
+				"the duration of a window must be positive"))
+		}
+	}
+
+	return result
+}
+
+// validateBackupMirror validates the configuration of the secondary
+// object store where backups are mirrored
+func (r *Cluster) validateBackupMirror() field.ErrorList {
+	mirror := r.Spec.Backup.Mirror
+	if mirror == nil {
+		return nil
+	}
+
+	mirrorPath := field.NewPath("spec", "backup", "mirror")
+	result := barmanWebhooks.ValidateBackupConfiguration(
+		&mirror.BarmanObjectStore,
+		mirrorPath.Child("barmanObjectStore"),
+	)
+
+	if r.Spec.Backup.BarmanObjectStore == nil {
+		result = append(result, field.Invalid(
+			mirrorPath,
+			mirror,
+			"backup mirroring requires barmanObjectStore to be configured"))
+		return result
+	}
+
+	if mirror.MaxSpoolSize != nil && mirror.MaxSpoolSize.Sign() <= 0 {
+		result = append(result, field.Invalid(
+			mirrorPath.Child("maxSpoolSize"),
+			mirror.MaxSpoolSize.String(),
+			"the maximum spool size must be positive"))
+	}
+
+	if mirror.BarmanObjectStore.DestinationPath == r.Spec.Backup.BarmanObjectStore.DestinationPath {
+		result = append(result, field.Invalid(
+			mirrorPath.Child("barmanObjectStore", "destinationPath"),
+			mirror.BarmanObjectStore.DestinationPath,
+			"the secondary object store must be different from the primary one"))
+	}
+
+	return result
+}
+
+// validateBackupTargetSelector validates the selectors of the instances
+// that can take the backups
+func (r *Cluster) validateBackupTargetSelector() field.ErrorList {
+	if r.Spec.Backup == nil || r.Spec.Backup.TargetSelector == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	targetSelector := r.Spec.Backup.TargetSelector
+	basePath := field.NewPath("spec", "backup", "targetSelector")
+
+	if targetSelector.InstanceSelector == nil && targetSelector.NodeSelector == nil {
+		result = append(result, field.Required(
+			basePath,
+			"At least one of instanceSelector and nodeSelector is required"))
+	}
+
+	result = append(result, validation.ValidateLabelSelector(
+		targetSelector.InstanceSelector,
+		validation.LabelSelectorValidationOptions{},
+		basePath.Child("instanceSelector"))...)
+	result = append(result, validation.ValidateLabelSelector(
+		targetSelector.NodeSelector,
+		validation.LabelSelectorValidationOptions{},
+		basePath.Child("nodeSelector"))...)
+
+	return result
+}
+
+// validateWALArchiveTarget validates the instance archiving the WAL files
+func (r *Cluster) validateWALArchiveTarget() field.ErrorList {
+	if !r.IsWALArchivedByStandby() {
+		return nil
+	}
+
+	// The primary retains the WAL files that haven't been streamed to
+	// the elected standby through its replication slot
+	if r.Spec.ReplicationSlots == nil || !r.Spec.ReplicationSlots.HighAvailability.GetEnabled() {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "backup", "walArchiveTarget"),
+				r.Spec.Backup.WALArchiveTarget,
+				"Archiving the WAL files from a standby requires the replication slots for high availability"),
+		}
+	}
+
+	return nil
+}
+
+// validateWALArchiveHealth validates the configuration of the health
+// checks of the WAL archive
+func (r *Cluster) validateWALArchiveHealth() field.ErrorList {
+	if r.Spec.Backup.WALArchiveHealth == nil {
+		return nil
+	}
+
+	if r.Spec.Backup.BarmanObjectStore == nil && r.Spec.Backup.PgBackRest == nil {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "backup", "walArchiveHealth"),
+				r.Spec.Backup.WALArchiveHealth,
+				"the health checks of the WAL archive require barmanObjectStore or pgBackRest to be configured"),
+		}
+	}
+
+	return nil
+}
+
+// validateBackupVerification validates the configuration of the
+// periodic verification of the backups
+func (r *Cluster) validateBackupVerification() field.ErrorList {
+	verification := r.Spec.Backup.Verification
+	if verification == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	verificationPath := field.NewPath("spec", "backup", "verification")
+
+	if _, err := cron.Parse(verification.Schedule); err != nil {
+		result = append(result, field.Invalid(
+			verificationPath.Child("schedule"),
+			verification.Schedule,
+			err.Error()))
+	}
+
+	if r.Spec.Backup.BarmanObjectStore == nil && r.Spec.Backup.PgBackRest == nil {
+		result = append(result, field.Invalid(
+			verificationPath,
+			verification,
+			"backup verification requires either barmanObjectStore or pgBackRest to be configured"))
+	}
+
+	return result
 }
 
 // validateRetentionPolicy validates the retention policy configuration
@@ -2276,6 +3577,146 @@ func (r *Cluster) validateReplicationSlotsChange(old *Cluster) field.ErrorList {
 	return errs
 }
 
+// validateLogicalSlotsSynchronization validates the configuration of the
+// synchronization of the logical replication slots
+func (r *Cluster) validateLogicalSlotsSynchronization() field.ErrorList {
+	if r.Spec.ReplicationSlots == nil || !r.Spec.ReplicationSlots.SynchronizeLogicalSlots.GetEnabled() {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "replicationSlots", "synchronizeLogicalSlots")
+
+	if !r.Spec.ReplicationSlots.HighAvailability.GetEnabled() {
+		result = append(result, field.Invalid(
+			basePath.Child("enabled"),
+			true,
+			"The synchronization of the logical replication slots requires "+
+				"the replication slots for high availability to be enabled"))
+	}
+
+	if walLevel, ok := r.Spec.PostgresConfiguration.Parameters[postgres.ParameterWalLevel]; ok && walLevel != "logical" {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "postgresql", "parameters", postgres.ParameterWalLevel),
+			walLevel,
+			"The synchronization of the logical replication slots requires wal_level to be set to 'logical'"))
+	}
+
+	pgVersion, err := r.GetPostgresqlVersion()
+	if err != nil {
+		// The validation error will be already raised by the
+		// validateImageName function
+		return result
+	}
+
+	if pgVersion.Major() >= 17 && len(r.Spec.ReplicationSlots.SynchronizeLogicalSlots.SlotNames) > 0 {
+		result = append(result, field.Invalid(
+			basePath.Child("slotNames"),
+			r.Spec.ReplicationSlots.SynchronizeLogicalSlots.SlotNames,
+			"Starting from PostgreSQL 17, the logical replication slots to be synchronized "+
+				"are the ones created with the 'failover' option"))
+	}
+
+	return result
+}
+
+// validateReplicationSlotsSafeguard validates the limits on the
+// replication slots of the primary instance
+func (r *Cluster) validateReplicationSlotsSafeguard() field.ErrorList {
+	if r.Spec.ReplicationSlots == nil || !r.Spec.ReplicationSlots.Safeguard.GetEnabled() {
+		return nil
+	}
+
+	var result field.ErrorList
+	safeguard := r.Spec.ReplicationSlots.Safeguard
+	basePath := field.NewPath("spec", "replicationSlots", "safeguard")
+
+	if safeguard.MaxRetainedWALSize == "" && safeguard.MaxInactiveTime == nil {
+		result = append(result, field.Required(
+			basePath,
+			"At least one of maxRetainedWALSize and maxInactiveTime is required"))
+	}
+
+	if safeguard.MaxRetainedWALSize != "" {
+		size, err := resource.ParseQuantity(safeguard.MaxRetainedWALSize)
+		if err != nil || size.Sign() <= 0 {
+			result = append(result, field.Invalid(
+				basePath.Child("maxRetainedWALSize"),
+				safeguard.MaxRetainedWALSize,
+				"must be a positive quantity"))
+		}
+	}
+
+	if safeguard.MaxInactiveTime != nil && safeguard.MaxInactiveTime.Duration <= 0 {
+		result = append(result, field.Invalid(
+			basePath.Child("maxInactiveTime"),
+			safeguard.MaxInactiveTime.String(),
+			"must be a positive duration"))
+	}
+
+	if safeguard.GetAction() != ReplicationSlotsSafeguardActionInvalidate || safeguard.MaxInactiveTime == nil {
+		return result
+	}
+
+	pgVersion, err := r.GetPostgresqlVersion()
+	if err != nil {
+		// The validation error will be already raised by the
+		// validateImageName function
+		return result
+	}
+
+	if pgVersion.Major() < 18 {
+		result = append(result, field.Invalid(
+			basePath.Child("maxInactiveTime"),
+			safeguard.MaxInactiveTime.String(),
+			"The invalidation of the inactive replication slots requires PostgreSQL 18 or later"))
+	}
+
+	return result
+}
+
+// validateAdditionalReplicationSlots validates the additional
+// replication slots managed by the operator
+func (r *Cluster) validateAdditionalReplicationSlots() field.ErrorList {
+	if r.Spec.ReplicationSlots == nil || len(r.Spec.ReplicationSlots.Additional) == 0 {
+		return nil
+	}
+
+	var result field.ErrorList
+	replicationSlots := r.Spec.ReplicationSlots
+	basePath := field.NewPath("spec", "replicationSlots", "additional")
+	haSlotPrefix := replicationSlots.HighAvailability.GetSlotPrefix()
+
+	slotNames := stringset.New()
+	for idx, slot := range replicationSlots.Additional {
+		namePath := basePath.Index(idx).Child("name")
+
+		if slotNames.Has(slot.Name) {
+			result = append(result, field.Duplicate(namePath, slot.Name))
+		}
+		slotNames.Put(slot.Name)
+
+		if strings.HasPrefix(slot.Name, haSlotPrefix) {
+			result = append(result, field.Invalid(
+				namePath,
+				slot.Name,
+				fmt.Sprintf("The prefix %q is reserved for the replication slots for high availability",
+					haSlotPrefix)))
+		}
+
+		// The slots excluded from the synchronization are not managed
+		// by the instance manager
+		if excluded, _ := replicationSlots.SynchronizeReplicas.IsExcludedByUser(slot.Name); excluded {
+			result = append(result, field.Invalid(
+				namePath,
+				slot.Name,
+				"The additional replication slots can't match the excludePatterns of synchronizeReplicas"))
+		}
+	}
+
+	return result
+}
+
 func (r *Cluster) validateWALLevelChange(old *Cluster) field.ErrorList {
 	var errs field.ErrorList
 
@@ -2360,6 +3801,62 @@ func (r *Cluster) validateManagedServices() field.ErrorList {
 		))
 	}
 
+	if managedServices.MaxReplicaLag != nil && managedServices.MaxReplicaLag.Sign() < 0 {
+		errs = append(errs, field.Invalid(
+			basePath.Child("maxReplicaLag"),
+			managedServices.MaxReplicaLag.String(),
+			"must not be negative",
+		))
+	}
+
+	if managedServices.PerInstance != nil {
+		errs = append(errs, validatePerInstanceServices(basePath.Child("perInstance"), managedServices.PerInstance)...)
+	}
+
+	for idx := range managedServices.Routing {
+		routing := &managedServices.Routing[idx]
+		if routing.SessionAffinityTimeoutSeconds != nil && routing.SessionAffinity != v1.ServiceAffinityClientIP {
+			errs = append(errs, field.Invalid(
+				basePath.Child("routing").Index(idx).Child("sessionAffinityTimeoutSeconds"),
+				*routing.SessionAffinityTimeoutSeconds,
+				"can only be set with the ClientIP session affinity",
+			))
+		}
+	}
+
+	return errs
+}
+
+// validatePerInstanceServices validates the configuration of the
+// externally reachable services created for each instance
+func validatePerInstanceServices(path *field.Path, perInstance *PerInstanceServicesConfiguration) field.ErrorList {
+	var errs field.ErrorList
+
+	if perInstance.DNSDomain != "" {
+		for _, msg := range validationutil.IsDNS1123Subdomain(perInstance.DNSDomain) {
+			errs = append(errs, field.Invalid(path.Child("dnsDomain"), perInstance.DNSDomain, msg))
+		}
+	}
+
+	if len(perInstance.LoadBalancerSourceRanges) > 0 &&
+		perInstance.Type != "" && perInstance.Type != v1.ServiceTypeLoadBalancer {
+		errs = append(errs, field.Invalid(
+			path.Child("loadBalancerSourceRanges"),
+			perInstance.LoadBalancerSourceRanges,
+			"can only be set for LoadBalancer services",
+		))
+	}
+
+	for idx, sourceRange := range perInstance.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+			errs = append(errs, field.Invalid(
+				path.Child("loadBalancerSourceRanges").Index(idx),
+				sourceRange,
+				"must be a valid CIDR",
+			))
+		}
+	}
+
 	return errs
 }
 
@@ -2429,6 +3926,99 @@ func (r *Cluster) validateManagedRoles() field.ErrorList {
 					role.Name,
 					"This role both sets and disables a password"))
 		}
+		if role.PasswordRotation != nil {
+			result = append(result, validateRolePasswordRotation(role)...)
+		}
+	}
+
+	if r.Spec.Managed.LDAPRoleSync != nil {
+		result = append(result, validateLDAPRoleSync(r.Spec.Managed.LDAPRoleSync)...)
+	}
+
+	return result
+}
+
+// validateLDAPRoleSync validates the synchronization of the managed
+// roles with the LDAP groups
+func validateLDAPRoleSync(config *LDAPRoleSyncConfiguration) field.ErrorList {
+	var result field.ErrorList
+	syncPath := field.NewPath("spec", "managed", "ldapRoleSync")
+
+	if config.Server == "" {
+		result = append(result, field.Required(syncPath.Child("server"), "the LDAP server is required"))
+	}
+
+	if config.BaseDN == "" {
+		result = append(result, field.Required(syncPath.Child("baseDN"), "the base DN is required"))
+	}
+
+	if _, err := cron.Parse(config.Schedule); err != nil {
+		result = append(result, field.Invalid(
+			syncPath.Child("schedule"),
+			config.Schedule,
+			err.Error()))
+	}
+
+	if config.BindPassword != nil && config.BindDN == "" {
+		result = append(result, field.Invalid(
+			syncPath.Child("bindPassword"),
+			config.BindPassword.Name,
+			"the bind password requires bindDN to be set"))
+	}
+
+	if config.Scheme == LDAPSchemeLDAPS && config.TLS {
+		result = append(result, field.Invalid(
+			syncPath.Child("tls"),
+			config.TLS,
+			"StartTLS can't be used together with the ldaps scheme"))
+	}
+
+	if config.Scheme != LDAPSchemeLDAPS && !config.TLS && !config.AllowInsecureConnection {
+		result = append(result, field.Invalid(
+			syncPath.Child("tls"),
+			config.TLS,
+			"the connection to the LDAP server must use the ldaps scheme or StartTLS, "+
+				"unless allowInsecureConnection is set"))
+	}
+
+	if len(config.Groups) == 0 {
+		result = append(result, field.Required(syncPath.Child("groups"), "at least a group is required"))
+	}
+	for idx, group := range config.Groups {
+		if group.DN == "" {
+			result = append(result, field.Required(syncPath.Child("groups").Index(idx).Child("dn"),
+				"the group DN is required"))
+		}
+	}
+
+	return result
+}
+
+// validateRolePasswordRotation validates the automatic rotation
+// of the password of a managed role
+func validateRolePasswordRotation(role RoleConfiguration) field.ErrorList {
+	var result field.ErrorList
+	rotationPath := field.NewPath("spec", "managed", "roles").Key(role.Name).Child("passwordRotation")
+
+	if _, err := cron.Parse(role.PasswordRotation.Schedule); err != nil {
+		result = append(result, field.Invalid(
+			rotationPath.Child("schedule"),
+			role.PasswordRotation.Schedule,
+			err.Error()))
+	}
+
+	if role.PasswordSecret == nil {
+		result = append(result, field.Invalid(
+			rotationPath,
+			role.Name,
+			"the password rotation requires passwordSecret to be set"))
+	}
+
+	if role.DisablePassword {
+		result = append(result, field.Invalid(
+			rotationPath,
+			role.Name,
+			"the password rotation can't be used together with disablePassword"))
 	}
 
 	return result
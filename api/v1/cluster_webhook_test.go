@@ -84,6 +84,91 @@ var _ = Describe("bootstrap methods validation", func() {
 		result := invalidCluster.validateBootstrapMethod()
 		Expect(result).To(HaveLen(1))
 	})
+
+	It("complains when migration is used along another bootstrap method", func() {
+		invalidCluster := &Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					Migration: &BootstrapMigration{},
+					InitDB:    &BootstrapInitDB{},
+				},
+			},
+		}
+		result := invalidCluster.validateBootstrapMethod()
+		Expect(result).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("bootstrap migration validation", func() {
+	newMigrationCluster := func(migration *BootstrapMigration) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					Migration: migration,
+				},
+				ExternalClusters: []ExternalCluster{
+					{
+						Name: "source",
+					},
+				},
+			},
+		}
+	}
+
+	It("doesn't complain if we are not bootstrapping using a migration", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{},
+			},
+		}
+		Expect(cluster.validateBootstrapMigration()).To(BeEmpty())
+	})
+
+	It("accepts a valid migration", func() {
+		cluster := newMigrationCluster(&BootstrapMigration{
+			Source:    "source",
+			Databases: []string{"app", "sales"},
+			Roles:     []string{"*"},
+		})
+		Expect(cluster.validateBootstrapMigration()).To(BeEmpty())
+	})
+
+	It("complains when the source cluster doesn't exist", func() {
+		cluster := newMigrationCluster(&BootstrapMigration{
+			Source:    "missing",
+			Databases: []string{"*"},
+		})
+		Expect(cluster.validateBootstrapMigration()).To(HaveLen(1))
+	})
+
+	It("complains when the database wildcard is mixed with other names", func() {
+		cluster := newMigrationCluster(&BootstrapMigration{
+			Source:    "source",
+			Databases: []string{"*", "app"},
+		})
+		Expect(cluster.validateBootstrapMigration()).To(HaveLen(1))
+	})
+
+	It("complains when the role wildcard is mixed with other names", func() {
+		cluster := newMigrationCluster(&BootstrapMigration{
+			Source:    "source",
+			Databases: []string{"*"},
+			Roles:     []string{"app", "*"},
+		})
+		Expect(cluster.validateBootstrapMigration()).To(HaveLen(1))
+	})
+
+	It("complains when the cluster is a replica cluster", func() {
+		cluster := newMigrationCluster(&BootstrapMigration{
+			Source:    "source",
+			Databases: []string{"*"},
+		})
+		cluster.Spec.ReplicaCluster = &ReplicaClusterConfiguration{
+			Enabled: ptr.To(true),
+			Source:  "source",
+		}
+		Expect(cluster.validateBootstrapMigration()).To(HaveLen(1))
+	})
 })
 
 var _ = Describe("certificates options validation", func() {
@@ -1331,7 +1416,7 @@ var _ = Describe("validate image name change", func() {
 			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
 
-		It("complains if it can't upgrade between mayor versions", func() {
+		It("complains on major downgrades", func() {
 			clusterOld := Cluster{
 				Spec: ClusterSpec{
 					ImageName: "postgres:17.0",
@@ -1358,9 +1443,120 @@ var _ = Describe("validate image name change", func() {
 			}
 			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
+
+		It("doesn't complain on major upgrades", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:16.4",
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:17.0",
+				},
+			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
+		})
+
+		It("complains on major upgrades of replica clusters", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:16.4",
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:17.0",
+					ReplicaCluster: &ReplicaClusterConfiguration{
+						Enabled: ptr.To(true),
+						Source:  "origin",
+					},
+				},
+			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+		})
+
+		It("complains on major upgrades of clusters using tablespaces", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:16.4",
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:17.0",
+					Tablespaces: []TablespaceConfiguration{
+						{Name: "tbs"},
+					},
+				},
+			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+		})
+
+		It("doesn't complain on logical major upgrades of clusters using tablespaces", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:16.4",
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageName:             "postgres:17.0",
+					EnableSuperuserAccess: ptr.To(true),
+					MajorUpgrade: &MajorUpgradeConfiguration{
+						Method: MajorUpgradeMethodLogical,
+					},
+					Tablespaces: []TablespaceConfiguration{
+						{Name: "tbs"},
+					},
+				},
+			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
+		})
+
+		It("complains on logical major upgrades without superuser access", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:16.4",
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:17.0",
+					MajorUpgrade: &MajorUpgradeConfiguration{
+						Method: MajorUpgradeMethodLogical,
+					},
+				},
+			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+		})
+
+		It("complains on major version changes while a logical major upgrade is in progress", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageName: "postgres:17.0",
+				},
+				Status: ClusterStatus{
+					LogicalMajorUpgrade: &LogicalMajorUpgradeStatus{
+						TargetCluster: "cluster-example-pg17",
+						Phase:         LogicalMajorUpgradePhaseSynchronizing,
+					},
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageName:             "postgres:18.0",
+					EnableSuperuserAccess: ptr.To(true),
+					MajorUpgrade: &MajorUpgradeConfiguration{
+						Method: MajorUpgradeMethodLogical,
+					},
+				},
+			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+		})
 	})
 	Context("using image catalog", func() {
-		It("complains on major upgrades", func() {
+		It("doesn't complain on major upgrades", func() {
 			clusterOld := Cluster{
 				Spec: ClusterSpec{
 					ImageCatalogRef: &ImageCatalogRef{
@@ -1383,6 +1579,32 @@ var _ = Describe("validate image name change", func() {
 					},
 				},
 			}
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
+		})
+
+		It("complains on major downgrades", func() {
+			clusterOld := Cluster{
+				Spec: ClusterSpec{
+					ImageCatalogRef: &ImageCatalogRef{
+						TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+							Name: "test",
+							Kind: "ImageCatalog",
+						},
+						Major: 16,
+					},
+				},
+			}
+			clusterNew := Cluster{
+				Spec: ClusterSpec{
+					ImageCatalogRef: &ImageCatalogRef{
+						TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+							Name: "test",
+							Kind: "ImageCatalog",
+						},
+						Major: 15,
+					},
+				},
+			}
 			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
 		})
 	})
@@ -1406,7 +1628,7 @@ var _ = Describe("validate image name change", func() {
 			}
 			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
-		It("complains on major upgrades", func() {
+		It("doesn't complain on major upgrades", func() {
 			clusterOld := Cluster{
 				Spec: ClusterSpec{
 					ImageName: "postgres:16.1",
@@ -1423,7 +1645,7 @@ var _ = Describe("validate image name change", func() {
 					},
 				},
 			}
-			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
 		It("complains going from default imageName to different major imageCatalogRef", func() {
 			clusterOld := Cluster{
@@ -1484,7 +1706,7 @@ var _ = Describe("validate image name change", func() {
 			}
 			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
-		It("complains on major upgrades", func() {
+		It("doesn't complain on major upgrades", func() {
 			clusterOld := Cluster{
 				Spec: ClusterSpec{
 					ImageCatalogRef: &ImageCatalogRef{
@@ -1501,9 +1723,9 @@ var _ = Describe("validate image name change", func() {
 					ImageName: "postgres:17.1",
 				},
 			}
-			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
-		It("complains going from imageCatalogRef to different major default imageName", func() {
+		It("doesn't complain going from imageCatalogRef to a newer major default imageName", func() {
 			clusterOld := Cluster{
 				Spec: ClusterSpec{
 					ImageCatalogRef: &ImageCatalogRef{
@@ -1518,7 +1740,7 @@ var _ = Describe("validate image name change", func() {
 			clusterNew := Cluster{
 				Spec: ClusterSpec{},
 			}
-			Expect(clusterNew.validateImageChange(&clusterOld)).To(HaveLen(1))
+			Expect(clusterNew.validateImageChange(&clusterOld)).To(BeEmpty())
 		})
 		It("doesn't complain going from imageCatalogRef to same major default imageName", func() {
 			imageNameRef := reference.New(versions.DefaultImageName)
@@ -1993,6 +2215,43 @@ var _ = Describe("validateSynchronousReplicaConfiguration", func() {
 			"must be less than the total number of instances and the provided standby names."))
 	})
 
+	It("counts the synchronous replica clusters as standbys", func() {
+		cluster := &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: ClusterSpec{
+				Instances: 2,
+				PostgresConfiguration: PostgresConfiguration{
+					Synchronous: &SynchronousReplicaConfiguration{
+						Number: 2,
+					},
+				},
+				ExternalClusters: []ExternalCluster{
+					{Name: "cluster-example", SynchronousReplica: true},
+					{Name: "cluster-dr", SynchronousReplica: true},
+				},
+			},
+		}
+		Expect(cluster.validateSynchronousReplicaConfiguration()).To(BeEmpty())
+	})
+
+	It("returns an error when synchronous replica clusters are used with preferred data durability", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Instances: 3,
+				PostgresConfiguration: PostgresConfiguration{
+					Synchronous: &SynchronousReplicaConfiguration{
+						Number:         1,
+						DataDurability: DataDurabilityLevelPreferred,
+					},
+				},
+				ExternalClusters: []ExternalCluster{
+					{Name: "cluster-dr", SynchronousReplica: true},
+				},
+			},
+		}
+		Expect(cluster.validateSynchronousReplicaConfiguration()).To(HaveLen(1))
+	})
+
 	It("returns no error when number of synchronous replicas is less than total instances and standbys", func() {
 		cluster := &Cluster{
 			Spec: ClusterSpec{
@@ -3068,6 +3327,77 @@ var _ = Describe("replica mode validation", func() {
 	})
 })
 
+var _ = Describe("promotion token secret validation", func() {
+	It("complains when used together with minApplyDelay", func() {
+		cluster := &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Primary:              "test",
+					Source:               "test",
+					PromotionTokenSecret: &LocalObjectReference{Name: "switchover-token"},
+					MinApplyDelay:        &metav1.Duration{Duration: time.Hour},
+				},
+				ExternalClusters: []ExternalCluster{{Name: "test"}},
+			},
+		}
+		Expect(cluster.validateReplicaMode()).ToNot(BeEmpty())
+	})
+
+	It("doesn't complain when used without minApplyDelay", func() {
+		cluster := &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Primary:              "test",
+					Source:               "test",
+					PromotionTokenSecret: &LocalObjectReference{Name: "switchover-token"},
+				},
+				ExternalClusters: []ExternalCluster{{Name: "test"}},
+			},
+		}
+		Expect(cluster.validateReplicaMode()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("timeline divergence policy validation", func() {
+	newReplicaCluster := func(connectionParameters map[string]string) *Cluster {
+		return &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "replica"},
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Primary:              "main",
+					Source:               "main",
+					OnTimelineDivergence: TimelineDivergencePolicyRewind,
+				},
+				Bootstrap: &BootstrapConfiguration{
+					PgBaseBackup: &BootstrapPgBaseBackup{Source: "main"},
+				},
+				ExternalClusters: []ExternalCluster{
+					{Name: "main", ConnectionParameters: connectionParameters},
+					{Name: "replica"},
+				},
+			},
+		}
+	}
+
+	It("complains when the source cluster has no connection parameters", func() {
+		cluster := newReplicaCluster(nil)
+		Expect(cluster.validateReplicaMode()).ToNot(BeEmpty())
+	})
+
+	It("doesn't complain when the source cluster can be reached", func() {
+		cluster := newReplicaCluster(map[string]string{"host": "main-rw"})
+		Expect(cluster.validateReplicaMode()).To(BeEmpty())
+	})
+
+	It("doesn't complain about the manual policy", func() {
+		cluster := newReplicaCluster(nil)
+		cluster.Spec.ReplicaCluster.OnTimelineDivergence = TimelineDivergencePolicyManual
+		Expect(cluster.validateReplicaMode()).To(BeEmpty())
+	})
+})
+
 var _ = Describe("validate the replica cluster external clusters", func() {
 	It("complains when the external cluster doesn't exist (source)", func() {
 		cluster := &Cluster{
@@ -3134,6 +3464,66 @@ var _ = Describe("validate the replica cluster external clusters", func() {
 	})
 })
 
+var _ = Describe("validate the replica cluster topology", func() {
+	newCluster := func(links ...ReplicaClusterTopologyLink) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Primary:  "cluster-a",
+					Source:   "cluster-a",
+					Topology: links,
+				},
+				ExternalClusters: []ExternalCluster{
+					{Name: "cluster-a"},
+					{Name: "cluster-b"},
+					{Name: "cluster-c"},
+				},
+			},
+		}
+	}
+
+	It("accepts a cascading topology", func() {
+		cluster := newCluster(
+			ReplicaClusterTopologyLink{Name: "cluster-b", Upstream: "cluster-a"},
+			ReplicaClusterTopologyLink{Name: "cluster-c", Upstream: "cluster-b"},
+		)
+		Expect(cluster.validateReplicaClusterTopology()).To(BeEmpty())
+	})
+
+	It("requires the primary field", func() {
+		cluster := newCluster(ReplicaClusterTopologyLink{Name: "cluster-b", Upstream: "cluster-a"})
+		cluster.Spec.ReplicaCluster.Primary = ""
+		Expect(cluster.validateReplicaClusterTopology()).To(HaveLen(1))
+	})
+
+	It("complains when the external cluster doesn't exist", func() {
+		cluster := newCluster(ReplicaClusterTopologyLink{Name: "cluster-d", Upstream: "cluster-a"})
+		Expect(cluster.validateReplicaClusterTopology()).To(HaveLen(1))
+	})
+
+	It("complains when a cluster has more than one upstream", func() {
+		cluster := newCluster(
+			ReplicaClusterTopologyLink{Name: "cluster-c", Upstream: "cluster-a"},
+			ReplicaClusterTopologyLink{Name: "cluster-c", Upstream: "cluster-b"},
+		)
+		Expect(cluster.validateReplicaClusterTopology()).To(HaveLen(1))
+	})
+
+	It("complains when the topology contains a loop", func() {
+		cluster := newCluster(
+			ReplicaClusterTopologyLink{Name: "cluster-b", Upstream: "cluster-a"},
+			ReplicaClusterTopologyLink{Name: "cluster-c", Upstream: "cluster-b"},
+			ReplicaClusterTopologyLink{Name: "cluster-a", Upstream: "cluster-c"},
+		)
+		Expect(cluster.validateReplicaClusterTopology()).To(HaveLen(1))
+	})
+
+	It("complains when a cluster cascades from itself", func() {
+		cluster := newCluster(ReplicaClusterTopologyLink{Name: "cluster-b", Upstream: "cluster-b"})
+		Expect(cluster.validateReplicaClusterTopology()).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("Validation changes", func() {
 	It("doesn't complain if given old cluster is nil", func() {
 		newCluster := &Cluster{}
@@ -3154,25 +3544,235 @@ var _ = Describe("Backup validation", func() {
 		err := cluster.validateBackupConfiguration()
 		Expect(err).To(HaveLen(1))
 	})
-})
 
-var _ = Describe("Backup retention policy validation", func() {
-	It("doesn't complain if given policy is not provided", func() {
+	It("doesn't complain about a pgBackRest repository", func() {
 		cluster := &Cluster{
 			Spec: ClusterSpec{
-				Backup: &BackupConfiguration{},
+				Backup: &BackupConfiguration{
+					PgBackRest: &PgBackRestConfiguration{
+						Repository: PgBackRestRepository{Path: "/cluster-example"},
+					},
+				},
 			},
 		}
-		err := cluster.validateRetentionPolicy()
-		Expect(err).To(BeEmpty())
+		Expect(cluster.validateBackupConfiguration()).To(BeEmpty())
 	})
 
-	It("doesn't complain if given policy is valid", func() {
+	It("complains if pgBackRest is used together with barmanObjectStore", func() {
 		cluster := &Cluster{
 			Spec: ClusterSpec{
 				Backup: &BackupConfiguration{
-					RetentionPolicy: "90d",
-				},
+					BarmanObjectStore: &BarmanObjectStoreConfiguration{},
+					PgBackRest: &PgBackRestConfiguration{
+						Repository: PgBackRestRepository{Path: "/cluster-example"},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateBackupConfiguration()).To(HaveLen(2))
+	})
+
+	It("accepts a backup verification with a valid schedule", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					PgBackRest: &PgBackRestConfiguration{
+						Repository: PgBackRestRepository{Path: "/cluster-example"},
+					},
+					Verification: &BackupVerificationConfiguration{
+						Schedule:   "0 0 6 * * *",
+						SmokeQuery: "SELECT 1",
+					},
+				},
+			},
+		}
+		Expect(cluster.validateBackupConfiguration()).To(BeEmpty())
+	})
+
+	It("complains if the backup verification schedule is not valid", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					PgBackRest: &PgBackRestConfiguration{
+						Repository: PgBackRestRepository{Path: "/cluster-example"},
+					},
+					Verification: &BackupVerificationConfiguration{
+						Schedule: "every day",
+					},
+				},
+			},
+		}
+		result := cluster.validateBackupConfiguration()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.backup.verification.schedule"))
+	})
+
+	It("complains if the backup verification has no object store to restore from", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					Verification: &BackupVerificationConfiguration{
+						Schedule: "0 0 6 * * *",
+					},
+				},
+			},
+		}
+		result := cluster.validateBackupConfiguration()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.backup.verification"))
+	})
+
+	It("accepts a mirror in a different object store", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					BarmanObjectStore: &BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://backups-eu/",
+						BarmanCredentials: BarmanCredentials{
+							AWS: &S3Credentials{InheritFromIAMRole: true},
+						},
+					},
+					Mirror: &BackupMirrorConfiguration{
+						BarmanObjectStore: BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://backups-us/",
+							BarmanCredentials: BarmanCredentials{
+								AWS: &S3Credentials{InheritFromIAMRole: true},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateBackupConfiguration()).To(BeEmpty())
+	})
+
+	It("complains if the mirror is the primary object store", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					BarmanObjectStore: &BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://backups/",
+						BarmanCredentials: BarmanCredentials{
+							AWS: &S3Credentials{InheritFromIAMRole: true},
+						},
+					},
+					Mirror: &BackupMirrorConfiguration{
+						BarmanObjectStore: BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://backups/",
+							BarmanCredentials: BarmanCredentials{
+								AWS: &S3Credentials{InheritFromIAMRole: true},
+							},
+						},
+					},
+				},
+			},
+		}
+		result := cluster.validateBackupConfiguration()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.backup.mirror.barmanObjectStore.destinationPath"))
+	})
+
+	It("complains if the maximum size of the mirror spool is not positive", func() {
+		maxSpoolSize := resource.MustParse("0")
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					BarmanObjectStore: &BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://backups-eu/",
+						BarmanCredentials: BarmanCredentials{
+							AWS: &S3Credentials{InheritFromIAMRole: true},
+						},
+					},
+					Mirror: &BackupMirrorConfiguration{
+						BarmanObjectStore: BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://backups-us/",
+							BarmanCredentials: BarmanCredentials{
+								AWS: &S3Credentials{InheritFromIAMRole: true},
+							},
+						},
+						MaxSpoolSize: &maxSpoolSize,
+					},
+				},
+			},
+		}
+		result := cluster.validateBackupConfiguration()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.backup.mirror.maxSpoolSize"))
+	})
+
+	It("complains if the mirror is used without barmanObjectStore", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					PgBackRest: &PgBackRestConfiguration{
+						Repository: PgBackRestRepository{Path: "/cluster-example"},
+					},
+					Mirror: &BackupMirrorConfiguration{
+						BarmanObjectStore: BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://backups-us/",
+							BarmanCredentials: BarmanCredentials{
+								AWS: &S3Credentials{InheritFromIAMRole: true},
+							},
+						},
+					},
+				},
+			},
+		}
+		result := cluster.validateBackupConfiguration()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.backup.mirror"))
+	})
+
+	It("accepts the health checks of the WAL archive with pgBackRest", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					PgBackRest: &PgBackRestConfiguration{
+						Repository: PgBackRestRepository{Path: "/cluster-example"},
+					},
+					WALArchiveHealth: &WALArchiveHealthConfiguration{
+						MaxArchiveDelay: 300,
+						SelfHealing:     true,
+					},
+				},
+			},
+		}
+		Expect(cluster.validateBackupConfiguration()).To(BeEmpty())
+	})
+
+	It("complains if the health checks of the WAL archive are used without a WAL archive", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					WALArchiveHealth: &WALArchiveHealthConfiguration{
+						MaxArchiveDelay: 300,
+					},
+				},
+			},
+		}
+		result := cluster.validateBackupConfiguration()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.backup.walArchiveHealth"))
+	})
+})
+
+var _ = Describe("Backup retention policy validation", func() {
+	It("doesn't complain if given policy is not provided", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{},
+			},
+		}
+		err := cluster.validateRetentionPolicy()
+		Expect(err).To(BeEmpty())
+	})
+
+	It("doesn't complain if given policy is valid", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					RetentionPolicy: "90d",
+				},
 			},
 		}
 		err := cluster.validateRetentionPolicy()
@@ -3492,6 +4092,70 @@ var _ = Describe("validation of imports", func() {
 	})
 })
 
+var _ = Describe("witness validation", func() {
+	It("doesn't complain if the witness is not enabled", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Instances: 3,
+				Witness:   &WitnessConfiguration{},
+			},
+		}
+		Expect(cluster.validateWitness()).To(BeEmpty())
+	})
+
+	It("accepts a witness in a cluster made of two instances", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Instances: 2,
+				Witness:   &WitnessConfiguration{Enabled: true},
+			},
+		}
+		Expect(cluster.validateWitness()).To(BeEmpty())
+	})
+
+	It("complains if the witness is enabled in a cluster not made of two instances", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Instances: 3,
+				Witness:   &WitnessConfiguration{Enabled: true},
+			},
+		}
+		Expect(cluster.validateWitness()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("topology validation", func() {
+	It("doesn't complain if the topology is not configured", func() {
+		cluster := &Cluster{}
+		Expect(cluster.validateTopology()).To(BeEmpty())
+	})
+
+	It("accepts a valid topology", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Topology: &TopologyConfiguration{
+					ZoneLabel:     "example.com/zone",
+					DistinctZones: true,
+					PrimaryZones:  []string{"zone-a", "zone-b"},
+				},
+			},
+		}
+		Expect(cluster.validateTopology()).To(BeEmpty())
+	})
+
+	It("complains about invalid zone labels and primary zones", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Topology: &TopologyConfiguration{
+					ZoneLabel:    "not a label",
+					PrimaryZones: []string{"zone-a", "", "zone-a"},
+				},
+			},
+		}
+		Expect(cluster.validateTopology()).To(HaveLen(3))
+	})
+})
+
 var _ = Describe("validation of replication slots configuration", func() {
 	It("can be enabled on the default PostgreSQL image", func() {
 		cluster := &Cluster{
@@ -3717,6 +4381,207 @@ var _ = Describe("Environment variables validation", func() {
 	})
 })
 
+var _ = Describe("pg_hba rules validation", func() {
+	newCluster := func(imageName string, rules ...PgHBARule) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				ImageName: imageName,
+				PostgresConfiguration: PostgresConfiguration{
+					PgHBARules: rules,
+				},
+			},
+		}
+	}
+
+	It("accepts valid rules", func() {
+		cluster := newCluster("postgres:16",
+			PgHBARule{
+				Type:      PgHBAConnectionTypeHostSSL,
+				Databases: []string{"app", "/^tenant_.*$"},
+				Users:     []string{"+readers"},
+				Addresses: []string{"10.0.0.0/8", "samenet", ".example.com"},
+				AddressesFrom: []ConfigMapKeySelector{
+					{LocalObjectReference: LocalObjectReference{Name: "office"}, Key: "cidrs"},
+				},
+				Method:  PgHBAAuthMethodLDAP,
+				Options: map[string]string{"ldapserver": "ldap.example.com", "clientname": "DN"},
+			},
+			PgHBARule{
+				Type:   PgHBAConnectionTypeLocal,
+				Method: "peer",
+			},
+		)
+		Expect(cluster.validatePgHBARules()).To(BeEmpty())
+	})
+
+	It("rejects client addresses on local rules", func() {
+		cluster := newCluster("postgres:16", PgHBARule{
+			Type:      PgHBAConnectionTypeLocal,
+			Addresses: []string{"10.0.0.0/8"},
+			Method:    "peer",
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(1))
+	})
+
+	It("rejects invalid client addresses", func() {
+		cluster := newCluster("postgres:16", PgHBARule{
+			Addresses: []string{"10.0.0.0/33", "10.0.0.1", "not_a_host!"},
+			Method:    "scram-sha-256",
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(3))
+	})
+
+	It("rejects regular expressions before PostgreSQL 16", func() {
+		cluster := newCluster("postgres:15", PgHBARule{
+			Users:  []string{"/^app_.*$"},
+			Method: "scram-sha-256",
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(1))
+	})
+
+	It("rejects clientname before PostgreSQL 14", func() {
+		cluster := newCluster("postgres:13", PgHBARule{
+			Type:    PgHBAConnectionTypeHostSSL,
+			Method:  PgHBAAuthMethodCert,
+			Options: map[string]string{"clientname": "DN"},
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(1))
+	})
+
+	It("rejects the options not supported by the authentication method", func() {
+		cluster := newCluster("postgres:16", PgHBARule{
+			Method:  "scram-sha-256",
+			Options: map[string]string{"ldapserver": "ldap.example.com"},
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(1))
+	})
+
+	It("requires the servers of the ldap and radius methods", func() {
+		cluster := newCluster("postgres:16",
+			PgHBARule{Method: PgHBAAuthMethodLDAP},
+			PgHBARule{
+				Method:  PgHBAAuthMethodRADIUS,
+				Options: map[string]string{"radiusservers": "radius.example.com"},
+			},
+		)
+		Expect(cluster.validatePgHBARules()).To(HaveLen(2))
+	})
+
+	It("requires PostgreSQL 18 for the oauth method", func() {
+		cluster := newCluster("postgres:17", PgHBARule{
+			Method: PgHBAAuthMethodOAuth,
+			Options: map[string]string{
+				"issuer": "https://idp.example.com",
+				"scope":  "openid",
+			},
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(1))
+	})
+
+	It("requires the issuer and the scope of the oauth method without an OAuth configuration", func() {
+		cluster := newCluster("postgres:18", PgHBARule{
+			Method:  PgHBAAuthMethodOAuth,
+			Options: map[string]string{"issuer": "http://idp.example.com"},
+		})
+		Expect(cluster.validatePgHBARules()).To(HaveLen(2))
+
+		cluster.Spec.PostgresConfiguration.OAuth = &OAuthConfig{
+			Issuer: "https://idp.example.com",
+			Scope:  "openid",
+		}
+		cluster.Spec.PostgresConfiguration.PgHBARules[0].Options = nil
+		Expect(cluster.validatePgHBARules()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("OAuth configuration validation", func() {
+	newCluster := func(imageName string) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				ImageName: imageName,
+				PostgresConfiguration: PostgresConfiguration{
+					OAuth: &OAuthConfig{
+						Issuer:    "https://idp.example.com",
+						Scope:     "openid",
+						Validator: OAuthValidator{Library: "validator"},
+					},
+				},
+			},
+		}
+	}
+
+	It("accepts the OAuth configuration from PostgreSQL 18", func() {
+		Expect(newCluster("postgres:18").validateOAuth()).To(BeEmpty())
+	})
+
+	It("rejects the OAuth configuration before PostgreSQL 18", func() {
+		Expect(newCluster("postgres:17").validateOAuth()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("TDE configuration validation", func() {
+	passphrase := &SecretKeySelector{
+		LocalObjectReference: LocalObjectReference{Name: "tde"},
+		Key:                  "passphrase",
+	}
+
+	newCluster := func(keyProvider *TDEKeyProvider) *Cluster {
+		cluster := &Cluster{}
+		if keyProvider != nil {
+			cluster.Spec.PostgresConfiguration.TDE = &TDEConfiguration{KeyProvider: *keyProvider}
+		}
+		return cluster
+	}
+
+	It("accepts a single key provider", func() {
+		Expect(newCluster(&TDEKeyProvider{Secret: passphrase}).validateTDE()).To(BeEmpty())
+	})
+
+	It("requires exactly one key provider", func() {
+		Expect(newCluster(&TDEKeyProvider{}).validateTDE()).To(HaveLen(1))
+
+		cluster := newCluster(&TDEKeyProvider{
+			Secret: passphrase,
+			Vault: &TDEVaultKeyProvider{
+				Address: "https://vault.example.com:8200",
+				KeyName: "postgres",
+				Token:   *passphrase,
+			},
+		})
+		Expect(cluster.validateTDE()).To(HaveLen(1))
+	})
+
+	It("rejects invalid Vault addresses", func() {
+		cluster := newCluster(&TDEKeyProvider{
+			Vault: &TDEVaultKeyProvider{
+				Address: "vault",
+				KeyName: "postgres",
+				Token:   *passphrase,
+			},
+		})
+		Expect(cluster.validateTDE()).To(HaveLen(1))
+	})
+
+	It("allows changing the key provider", func() {
+		oldCluster := newCluster(&TDEKeyProvider{Secret: passphrase})
+		cluster := newCluster(&TDEKeyProvider{
+			Vault: &TDEVaultKeyProvider{
+				Address: "https://vault.example.com:8200",
+				KeyName: "postgres",
+				Token:   *passphrase,
+			},
+		})
+		Expect(cluster.validateTDEChange(oldCluster)).To(BeEmpty())
+	})
+
+	It("prevents enabling or disabling TDE", func() {
+		enabled := newCluster(&TDEKeyProvider{Secret: passphrase})
+		disabled := newCluster(nil)
+		Expect(enabled.validateTDEChange(disabled)).To(HaveLen(1))
+		Expect(disabled.validateTDEChange(enabled)).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("Storage configuration validation", func() {
 	When("a ClusterSpec is given", func() {
 		It("produces one error if storage is not set at all", func() {
@@ -3753,8 +4618,67 @@ var _ = Describe("Storage configuration validation", func() {
 			}
 			Expect(cluster.validateStorageSize()).To(BeEmpty())
 		})
-	})
-})
+
+		It("succeeds if the automatic expansion is correctly configured", func() {
+			cluster := Cluster{
+				Spec: ClusterSpec{
+					StorageConfiguration: StorageConfiguration{
+						Size: "10Gi",
+						AutoResize: &StorageAutoResizeConfiguration{
+							Enabled: true,
+							Step:    "5Gi",
+							MaxSize: "100Gi",
+						},
+					},
+				},
+			}
+			Expect(cluster.validateStorageSize()).To(BeEmpty())
+		})
+
+		It("complains if the step of the automatic expansion is invalid", func() {
+			cluster := Cluster{
+				Spec: ClusterSpec{
+					StorageConfiguration: StorageConfiguration{
+						Size: "10Gi",
+						AutoResize: &StorageAutoResizeConfiguration{
+							Enabled: true,
+							Step:    "a lot%",
+						},
+					},
+				},
+			}
+			Expect(cluster.validateStorageSize()).To(HaveLen(1))
+		})
+
+		It("complains if the maximum size is lower than the size of the storage", func() {
+			cluster := Cluster{
+				Spec: ClusterSpec{
+					StorageConfiguration: StorageConfiguration{
+						Size: "10Gi",
+						AutoResize: &StorageAutoResizeConfiguration{
+							Enabled: true,
+							MaxSize: "5Gi",
+						},
+					},
+				},
+			}
+			Expect(cluster.validateStorageSize()).To(HaveLen(1))
+		})
+
+		It("complains if the automatic expansion is enabled without resizing the volumes in use", func() {
+			cluster := Cluster{
+				Spec: ClusterSpec{
+					StorageConfiguration: StorageConfiguration{
+						Size:               "10Gi",
+						ResizeInUseVolumes: ptr.To(false),
+						AutoResize:         &StorageAutoResizeConfiguration{Enabled: true},
+					},
+				},
+			}
+			Expect(cluster.validateStorageSize()).To(HaveLen(1))
+		})
+	})
+})
 
 var _ = Describe("Ephemeral volume configuration validation", func() {
 	It("succeeds if no ephemeral configuration is present", func() {
@@ -3924,6 +4848,140 @@ var _ = Describe("Role management validation", func() {
 		}
 		Expect(cluster.validateManagedRoles()).To(HaveLen(1))
 	})
+
+	It("should accept a password rotation with a valid schedule", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Managed: &ManagedConfiguration{
+					Roles: []RoleConfiguration{
+						{
+							Name: "my_test",
+							PasswordSecret: &LocalObjectReference{
+								Name: "my-test-password",
+							},
+							PasswordRotation: &RolePasswordRotation{
+								Schedule: "0 0 0 1 * *",
+							},
+							ConnectionLimit: -1,
+						},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateManagedRoles()).To(BeEmpty())
+	})
+
+	It("should produce an error if a password rotation has no password secret", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Managed: &ManagedConfiguration{
+					Roles: []RoleConfiguration{
+						{
+							Name: "my_test",
+							PasswordRotation: &RolePasswordRotation{
+								Schedule: "0 0 0 1 * *",
+							},
+							ConnectionLimit: -1,
+						},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateManagedRoles()).To(HaveLen(1))
+	})
+
+	It("should produce an error if a password rotation has an invalid schedule", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Managed: &ManagedConfiguration{
+					Roles: []RoleConfiguration{
+						{
+							Name: "my_test",
+							PasswordSecret: &LocalObjectReference{
+								Name: "my-test-password",
+							},
+							PasswordRotation: &RolePasswordRotation{
+								Schedule: "every month",
+							},
+							ConnectionLimit: -1,
+						},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateManagedRoles()).To(HaveLen(1))
+	})
+
+	It("should accept a valid LDAP role synchronization", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Managed: &ManagedConfiguration{
+					LDAPRoleSync: &LDAPRoleSyncConfiguration{
+						Server:   "ldap.example.com",
+						TLS:      true,
+						BindDN:   "cn=admin,dc=example,dc=com",
+						BaseDN:   "dc=example,dc=com",
+						Schedule: "0 0 * * * *",
+						BindPassword: &SecretKeySelector{
+							LocalObjectReference: LocalObjectReference{Name: "ldap-password"},
+							Key:                  "password",
+						},
+						Groups: []LDAPRoleSyncGroup{
+							{DN: "cn=dba,ou=groups,dc=example,dc=com", InRoles: []string{"pg_monitor"}},
+						},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateManagedRoles()).To(BeEmpty())
+	})
+
+	It("should produce an error on an invalid LDAP role synchronization", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Managed: &ManagedConfiguration{
+					LDAPRoleSync: &LDAPRoleSyncConfiguration{
+						Server:   "ldap.example.com",
+						Scheme:   LDAPSchemeLDAPS,
+						TLS:      true,
+						BaseDN:   "dc=example,dc=com",
+						Schedule: "every hour",
+						BindPassword: &SecretKeySelector{
+							LocalObjectReference: LocalObjectReference{Name: "ldap-password"},
+							Key:                  "password",
+						},
+						Groups: []LDAPRoleSyncGroup{{}},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateManagedRoles()).To(HaveLen(4))
+	})
+
+	It("should require TLS to connect to the LDAP server unless explicitly allowed", func() {
+		ldapRoleSync := &LDAPRoleSyncConfiguration{
+			Server:   "ldap.example.com",
+			Scheme:   LDAPSchemeLDAP,
+			BaseDN:   "dc=example,dc=com",
+			Schedule: "0 0 * * * *",
+			Groups: []LDAPRoleSyncGroup{
+				{DN: "cn=dba,ou=groups,dc=example,dc=com"},
+			},
+		}
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Managed: &ManagedConfiguration{LDAPRoleSync: ldapRoleSync},
+			},
+		}
+		Expect(cluster.validateManagedRoles()).To(HaveLen(1))
+
+		ldapRoleSync.Scheme = LDAPSchemeLDAPS
+		Expect(cluster.validateManagedRoles()).To(BeEmpty())
+
+		ldapRoleSync.Scheme = LDAPSchemeLDAP
+		ldapRoleSync.AllowInsecureConnection = true
+		Expect(cluster.validateManagedRoles()).To(BeEmpty())
+	})
 })
 
 var _ = Describe("Managed Extensions validation", func() {
@@ -4018,6 +5076,34 @@ var _ = Describe("Managed Extensions validation", func() {
 	})
 })
 
+var _ = Describe("Recovery from a backup validation", func() {
+	clusterFromBackup := func(namespace string) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					Recovery: &BootstrapRecovery{
+						Backup: &BackupSource{
+							LocalObjectReference: LocalObjectReference{Name: "backup"},
+							Namespace:            namespace,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	It("accepts a backup in another namespace", func() {
+		Expect(clusterFromBackup("").validateBootstrapRecoveryBackup()).To(BeEmpty())
+		Expect(clusterFromBackup("production").validateBootstrapRecoveryBackup()).To(BeEmpty())
+	})
+
+	It("complains if the namespace of the backup is not valid", func() {
+		result := clusterFromBackup("Production_").validateBootstrapRecoveryBackup()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.recovery.backup.namespace"))
+	})
+})
+
 var _ = Describe("Recovery from volume snapshot validation", func() {
 	clusterFromRecovery := func(recovery *BootstrapRecovery) *Cluster {
 		return &Cluster{
@@ -4206,6 +5292,79 @@ var _ = Describe("Recovery from volume snapshot validation", func() {
 		})
 		Expect(cluster.validateBootstrapRecoveryDataSource()).To(HaveLen(2))
 	})
+
+	When("the cluster has tablespaces", func() {
+		tablespaceSnapshot := func(name string) corev1.TypedLocalObjectReference {
+			return corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(storagesnapshotv1.GroupName),
+				Kind:     VolumeSnapshotKind,
+				Name:     name,
+			}
+		}
+
+		clusterWithTablespaces := func(tablespaceStorage map[string]corev1.TypedLocalObjectReference) *Cluster {
+			cluster := clusterFromRecovery(&BootstrapRecovery{
+				VolumeSnapshots: &DataSource{
+					Storage:           tablespaceSnapshot("pgdata"),
+					TablespaceStorage: tablespaceStorage,
+				},
+			})
+			cluster.Spec.Tablespaces = []TablespaceConfiguration{
+				{Name: "tbs1"},
+				{Name: "tbs2"},
+			}
+			return cluster
+		}
+
+		It("accepts a snapshot for every tablespace", func() {
+			cluster := clusterWithTablespaces(map[string]corev1.TypedLocalObjectReference{
+				"tbs1": tablespaceSnapshot("pgdata-tbs-tbs1"),
+				"tbs2": tablespaceSnapshot("pgdata-tbs-tbs2"),
+			})
+			Expect(cluster.validateBootstrapRecoveryDataSource()).To(BeEmpty())
+		})
+
+		It("doesn't require a snapshot for ephemeral tablespaces", func() {
+			cluster := clusterWithTablespaces(map[string]corev1.TypedLocalObjectReference{
+				"tbs1": tablespaceSnapshot("pgdata-tbs-tbs1"),
+			})
+			cluster.Spec.Tablespaces[1].Temporary = true
+			cluster.Spec.Tablespaces[1].Ephemeral = &EphemeralTablespaceConfiguration{}
+			Expect(cluster.validateBootstrapRecoveryDataSource()).To(BeEmpty())
+		})
+
+		It("complains when a tablespace has no snapshot", func() {
+			cluster := clusterWithTablespaces(map[string]corev1.TypedLocalObjectReference{
+				"tbs1": tablespaceSnapshot("pgdata-tbs-tbs1"),
+			})
+			result := cluster.validateBootstrapRecoveryDataSource()
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.bootstrap.recovery.volumeSnapshots.tablespaceStorage[tbs2]"))
+		})
+
+		It("complains when a snapshot refers to an undeclared tablespace", func() {
+			cluster := clusterWithTablespaces(map[string]corev1.TypedLocalObjectReference{
+				"tbs1": tablespaceSnapshot("pgdata-tbs-tbs1"),
+				"tbs2": tablespaceSnapshot("pgdata-tbs-tbs2"),
+				"tbs3": tablespaceSnapshot("pgdata-tbs-tbs3"),
+			})
+			result := cluster.validateBootstrapRecoveryDataSource()
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.bootstrap.recovery.volumeSnapshots.tablespaceStorage[tbs3]"))
+		})
+
+		It("complains when a tablespace snapshot is not a supported object", func() {
+			cluster := clusterWithTablespaces(map[string]corev1.TypedLocalObjectReference{
+				"tbs1": tablespaceSnapshot("pgdata-tbs-tbs1"),
+				"tbs2": {
+					APIGroup: ptr.To(""),
+					Kind:     "Secret",
+					Name:     "pgdata-tbs-tbs2",
+				},
+			})
+			Expect(cluster.validateBootstrapRecoveryDataSource()).To(HaveLen(1))
+		})
+	})
 })
 
 var _ = Describe("validateResources", func() {
@@ -4676,6 +5835,78 @@ var _ = Describe("Tablespaces validation", func() {
 		}
 		Expect(cluster.validateTablespaceBackupSnapshot()).To(HaveLen(1))
 	})
+
+	When("a tablespace is ephemeral", func() {
+		createFakeEphemeralTbsConf := func(name string) TablespaceConfiguration {
+			tbsConf := createFakeTemporaryTbsConf(name)
+			tbsConf.Temporary = true
+			tbsConf.Ephemeral = &EphemeralTablespaceConfiguration{}
+			return tbsConf
+		}
+
+		clusterWithTablespaces := func(tablespaces ...TablespaceConfiguration) *Cluster {
+			return &Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+				Spec: ClusterSpec{
+					Instances: 3,
+					StorageConfiguration: StorageConfiguration{
+						Size: "10Gi",
+					},
+					Tablespaces: tablespaces,
+				},
+			}
+		}
+
+		It("accepts a temporary tablespace", func() {
+			cluster := clusterWithTablespaces(createFakeEphemeralTbsConf("scratch"))
+			Expect(cluster.Validate()).To(BeEmpty())
+		})
+
+		It("complains if the tablespace is not temporary", func() {
+			tbsConf := createFakeEphemeralTbsConf("scratch")
+			tbsConf.Temporary = false
+			result := clusterWithTablespaces(tbsConf).validateEphemeralTablespaces()
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.tablespaces[0].ephemeral"))
+		})
+
+		It("requires a size for generic ephemeral volumes", func() {
+			tbsConf := createFakeEphemeralTbsConf("scratch")
+			tbsConf.Storage.Size = ""
+			result := clusterWithTablespaces(tbsConf).validateEphemeralTablespaces()
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.tablespaces[0].storage.size"))
+
+			tbsConf.Ephemeral.VolumeType = EphemeralTablespaceVolumeTypeEmptyDir
+			Expect(clusterWithTablespaces(tbsConf).validateEphemeralTablespaces()).To(BeEmpty())
+		})
+
+		It("complains if the automatic volume expansion is enabled", func() {
+			tbsConf := createFakeEphemeralTbsConf("scratch")
+			tbsConf.Storage.AutoResize = &StorageAutoResizeConfiguration{Enabled: true}
+			result := clusterWithTablespaces(tbsConf).validateEphemeralTablespaces()
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.tablespaces[0].storage.autoResize"))
+		})
+
+		It("allows the size to be reduced", func() {
+			oldCluster := clusterWithTablespaces(createFakeEphemeralTbsConf("scratch"))
+			tbsConf := createFakeEphemeralTbsConf("scratch")
+			tbsConf.Storage.Size = "1Gi"
+			Expect(clusterWithTablespaces(tbsConf).validateTablespacesChange(oldCluster)).To(BeEmpty())
+		})
+
+		It("complains if a tablespace is switched to a persistent volume", func() {
+			oldCluster := clusterWithTablespaces(createFakeEphemeralTbsConf("scratch"))
+			tbsConf := createFakeEphemeralTbsConf("scratch")
+			tbsConf.Ephemeral = nil
+			result := clusterWithTablespaces(tbsConf).validateTablespacesChange(oldCluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.tablespaces[0].ephemeral"))
+		})
+	})
 })
 
 var _ = Describe("Validate hibernation", func() {
@@ -4839,6 +6070,69 @@ var _ = Describe("validateManagedServices", func() {
 			Expect(errs[0].Field).To(Equal("spec.managed.services.disabledDefaultServices"))
 		})
 	})
+
+	Context("routing validation", func() {
+		It("should allow the session affinity timeout with the ClientIP session affinity", func() {
+			cluster.Spec.Managed.Services.Routing = []ServiceRoutingConfiguration{
+				{
+					SelectorType:                  ServiceSelectorTypeRO,
+					SessionAffinity:               corev1.ServiceAffinityClientIP,
+					SessionAffinityTimeoutSeconds: ptr.To(int32(600)),
+				},
+			}
+			Expect(cluster.validateManagedServices()).To(BeEmpty())
+		})
+
+		It("should not allow the session affinity timeout without the ClientIP session affinity", func() {
+			cluster.Spec.Managed.Services.Routing = []ServiceRoutingConfiguration{
+				{
+					SelectorType:                  ServiceSelectorTypeRO,
+					SessionAffinityTimeoutSeconds: ptr.To(int32(600)),
+				},
+			}
+			errs := cluster.validateManagedServices()
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.routing[0].sessionAffinityTimeoutSeconds"))
+		})
+
+		It("should not allow a negative maximum replica lag", func() {
+			maxReplicaLag := resource.MustParse("-1")
+			cluster.Spec.Managed.Services.MaxReplicaLag = &maxReplicaLag
+			errs := cluster.validateManagedServices()
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.maxReplicaLag"))
+		})
+	})
+
+	Context("per-instance services validation", func() {
+		It("should not allow an invalid domain for the per-instance services", func() {
+			cluster.Spec.Managed.Services.PerInstance = &PerInstanceServicesConfiguration{
+				DNSDomain: "Invalid_Domain",
+			}
+			errs := cluster.validateManagedServices()
+			Expect(errs).ToNot(BeEmpty())
+			Expect(errs[0].Field).To(Equal("spec.managed.services.perInstance.dnsDomain"))
+		})
+
+		It("should not allow source ranges on NodePort per-instance services", func() {
+			cluster.Spec.Managed.Services.PerInstance = &PerInstanceServicesConfiguration{
+				Type:                     corev1.ServiceTypeNodePort,
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			}
+			errs := cluster.validateManagedServices()
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.perInstance.loadBalancerSourceRanges"))
+		})
+
+		It("should not allow invalid source ranges on the per-instance services", func() {
+			cluster.Spec.Managed.Services.PerInstance = &PerInstanceServicesConfiguration{
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8", "not-a-cidr"},
+			}
+			errs := cluster.validateManagedServices()
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.perInstance.loadBalancerSourceRanges[1]"))
+		})
+	})
 })
 
 var _ = Describe("ServiceTemplate Validation", func() {
@@ -4980,3 +6274,766 @@ var _ = Describe("setDefaultPlugins", func() {
 			ContainElement(PluginConfiguration{Name: "predefined-plugin1", Enabled: ptr.To(true)}))
 	})
 })
+
+var _ = Describe("validateMaintenanceWindows", func() {
+	It("accepts valid maintenance windows", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				MaintenanceWindows: []MaintenanceWindow{
+					{Schedule: "0 0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				},
+			},
+		}
+		Expect(cluster.validateMaintenanceWindows()).To(BeEmpty())
+	})
+
+	It("rejects invalid schedules and durations", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				MaintenanceWindows: []MaintenanceWindow{
+					{Schedule: "not a schedule", Duration: metav1.Duration{Duration: time.Hour}},
+					{Schedule: "0 0 2 * * *"},
+				},
+			},
+		}
+		errs := cluster.validateMaintenanceWindows()
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.maintenanceWindows[0].schedule"))
+		Expect(errs[1].Field).To(Equal("spec.maintenanceWindows[1].duration"))
+	})
+})
+
+var _ = Describe("validateHibernation", func() {
+	It("accepts clusters without hibernation configuration", func() {
+		cluster := &Cluster{}
+		Expect(cluster.validateHibernation()).To(BeEmpty())
+	})
+
+	It("accepts valid hibernation windows", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Hibernation: &HibernationConfiguration{
+					Windows: []MaintenanceWindow{
+						{Schedule: "0 0 20 * * 1-5", Duration: metav1.Duration{Duration: 12 * time.Hour}},
+					},
+					Mode: HibernationModeMinimal,
+				},
+			},
+		}
+		Expect(cluster.validateHibernation()).To(BeEmpty())
+	})
+
+	It("rejects invalid schedules and durations", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Hibernation: &HibernationConfiguration{
+					Windows: []MaintenanceWindow{
+						{Schedule: "0 0 20 * * 1-5"},
+						{Schedule: "every night", Duration: metav1.Duration{Duration: time.Hour}},
+					},
+				},
+			},
+		}
+		errs := cluster.validateHibernation()
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.hibernation.windows[0].duration"))
+		Expect(errs[1].Field).To(Equal("spec.hibernation.windows[1].schedule"))
+	})
+})
+
+var _ = Describe("validateImageVerification", func() {
+	It("accepts clusters without image verification", func() {
+		cluster := &Cluster{}
+		Expect(cluster.validateImageVerification()).To(BeEmpty())
+	})
+
+	It("accepts trusted public keys", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				ImageVerification: &ImageVerificationConfiguration{
+					PublicKeys: []ConfigMapKeySelector{
+						{LocalObjectReference: LocalObjectReference{Name: "cosign"}, Key: "cosign.pub"},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateImageVerification()).To(BeEmpty())
+	})
+
+	It("requires something to trust", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				ImageVerification: &ImageVerificationConfiguration{},
+			},
+		}
+		errs := cluster.validateImageVerification()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.imageVerification.publicKeys"))
+	})
+})
+
+var _ = Describe("certificate provider validation", func() {
+	token := SecretKeySelector{
+		LocalObjectReference: LocalObjectReference{Name: "vault"},
+		Key:                  "token",
+	}
+
+	newCluster := func(certificateProvider *CertificateProviderConfiguration) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				Certificates: &CertificatesConfiguration{Provider: certificateProvider},
+			},
+		}
+	}
+
+	It("accepts a single certificate provider", func() {
+		cluster := newCluster(&CertificateProviderConfiguration{
+			AWSPrivateCA: &AWSPrivateCACertificateProvider{
+				Region:                  "eu-west-1",
+				CertificateAuthorityARN: "arn:aws:acm-pca:eu-west-1:123456789012:certificate-authority/ca",
+			},
+		})
+		Expect(cluster.validateCertificateProvider()).To(BeEmpty())
+	})
+
+	It("requires exactly one certificate provider", func() {
+		Expect(newCluster(&CertificateProviderConfiguration{}).validateCertificateProvider()).To(HaveLen(1))
+	})
+
+	It("rejects invalid Vault addresses", func() {
+		cluster := newCluster(&CertificateProviderConfiguration{
+			Vault: &VaultPKICertificateProvider{Address: "vault", Role: "postgres", Token: token},
+		})
+		Expect(cluster.validateCertificateProvider()).To(HaveLen(1))
+	})
+
+	It("rejects the user-provided certificates", func() {
+		cluster := newCluster(&CertificateProviderConfiguration{
+			Vault: &VaultPKICertificateProvider{Address: "https://vault:8200", Role: "postgres", Token: token},
+		})
+		cluster.Spec.Certificates.ServerCASecret = "server-ca"
+		cluster.Spec.Certificates.ServerTLSSecret = "server-tls"
+		Expect(cluster.validateCertificateProvider()).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("security profile validation", func() {
+	newCluster := func(tlsMode SecurityTLSMode) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				Security: &SecurityConfiguration{TLSMode: tlsMode},
+			},
+		}
+	}
+
+	It("doesn't validate the clusters without a security profile", func() {
+		cluster := newCluster("")
+		cluster.Spec.PostgresConfiguration.PgHBA = []string{"host all all all md5"}
+		Expect(cluster.validateSecurity()).To(BeEmpty())
+	})
+
+	It("accepts the local and the TLS rules with strong authentication", func() {
+		cluster := newCluster(SecurityTLSModeRequire)
+		cluster.Spec.PostgresConfiguration.PgHBA = []string{
+			"# Office network",
+			"local all all trust",
+			"hostssl app app 10.0.0.0 255.0.0.0 scram-sha-256",
+			"hostssl all all all cert # certificate users",
+		}
+		cluster.Spec.PostgresConfiguration.PgHBARules = []PgHBARule{
+			{Method: "scram-sha-256"},
+			{Type: PgHBAConnectionTypeLocal, Method: "trust"},
+		}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{
+			"password_encryption": "scram-sha-256",
+		}
+		Expect(cluster.validateSecurity()).To(BeEmpty())
+	})
+
+	It("rejects the connections without TLS and the weak authentication methods", func() {
+		cluster := newCluster(SecurityTLSModeVerifyFull)
+		cluster.Spec.PostgresConfiguration.PgHBA = []string{
+			"host all all all scram-sha-256",
+			"hostssl all all 10.0.0.0 255.0.0.0 md5",
+		}
+		cluster.Spec.PostgresConfiguration.PgHBARules = []PgHBARule{
+			{Type: PgHBAConnectionTypeHostNoSSL, Method: "scram-sha-256"},
+			{Method: "password"},
+		}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{
+			"password_encryption": "md5",
+		}
+		Expect(cluster.validateSecurity()).To(HaveLen(5))
+	})
+})
+
+var _ = Describe("audit configuration validation", func() {
+	It("doesn't validate the clusters without an audit configuration", func() {
+		cluster := &Cluster{}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"pgaudit.log": "all"}
+		Expect(cluster.validateAudit()).To(BeEmpty())
+	})
+
+	It("accepts the pgaudit classes, even when excluded", func() {
+		cluster := &Cluster{}
+		cluster.Spec.PostgresConfiguration.Audit = &AuditConfiguration{
+			Classes: []string{"all", "-misc"},
+			Roles: []AuditRoleConfiguration{
+				{Name: "app", Classes: []string{"READ", "write"}},
+			},
+		}
+		Expect(cluster.validateAudit()).To(BeEmpty())
+	})
+
+	It("rejects the unknown classes and the duplicated roles", func() {
+		cluster := &Cluster{}
+		cluster.Spec.PostgresConfiguration.Audit = &AuditConfiguration{
+			Classes: []string{"all", "select"},
+			Roles: []AuditRoleConfiguration{
+				{Name: "app", Classes: []string{"read"}},
+				{Name: "app", Classes: []string{"-everything"}},
+			},
+		}
+		Expect(cluster.validateAudit()).To(HaveLen(3))
+	})
+
+	It("rejects the pgaudit parameters", func() {
+		cluster := &Cluster{}
+		cluster.Spec.PostgresConfiguration.Audit = &AuditConfiguration{Classes: []string{"ddl"}}
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"pgaudit.log": "all"}
+		Expect(cluster.validateAudit()).To(HaveLen(1))
+	})
+
+	It("requires the object store configuration to upload the audit logs", func() {
+		cluster := &Cluster{}
+		cluster.Spec.PostgresConfiguration.Audit = &AuditConfiguration{
+			Shipping: &AuditLogShipping{ObjectStore: &AuditObjectStoreSink{}},
+		}
+		Expect(cluster.validateAudit()).To(HaveLen(1))
+
+		cluster.Spec.Backup = &BackupConfiguration{
+			BarmanObjectStore: &BarmanObjectStoreConfiguration{DestinationPath: "s3://backups/"},
+		}
+		Expect(cluster.validateAudit()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("log sinks validation", func() {
+	It("accepts the clusters without log sinks", func() {
+		cluster := &Cluster{}
+		Expect(cluster.validateLogging()).To(BeEmpty())
+	})
+
+	It("rejects the duplicated sink names", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Logging: &LoggingConfiguration{
+					Sinks: []LogSink{
+						{Name: "remote", Syslog: &SyslogSink{Address: "syslog-1:514"}},
+						{Name: "remote", Syslog: &SyslogSink{Address: "syslog-2:514"}},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateLogging()).To(HaveLen(1))
+	})
+
+	It("rejects the Loki labels set by the operator", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Logging: &LoggingConfiguration{
+					Sinks: []LogSink{
+						{
+							Name: "loki",
+							Loki: &LokiLogSink{
+								URL:    "http://loki:3100/loki/api/v1/push",
+								Labels: map[string]string{"env": "prod", "pod": "other"},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateLogging()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("query insights validation", func() {
+	var cluster *Cluster
+
+	BeforeEach(func() {
+		cluster = &Cluster{
+			Spec: ClusterSpec{
+				Monitoring: &MonitoringConfiguration{
+					QueryInsights: &QueryInsightsConfiguration{Enabled: true},
+				},
+			},
+		}
+	})
+
+	It("accepts the default configuration", func() {
+		Expect(cluster.validateQueryInsights()).To(BeEmpty())
+	})
+
+	It("rejects too short snapshot intervals", func() {
+		cluster.Spec.Monitoring.QueryInsights.SnapshotInterval = &metav1.Duration{Duration: time.Second}
+		Expect(cluster.validateQueryInsights()).To(HaveLen(1))
+	})
+
+	It("requires the retention to be longer than the snapshot interval", func() {
+		cluster.Spec.Monitoring.QueryInsights.SnapshotInterval = &metav1.Duration{Duration: time.Hour}
+		cluster.Spec.Monitoring.QueryInsights.History = &QueryInsightsHistory{
+			Retention: &metav1.Duration{Duration: time.Minute},
+		}
+		Expect(cluster.validateQueryInsights()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validateHugePages", func() {
+	var cluster *Cluster
+
+	BeforeEach(func() {
+		cluster = &Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Parameters: map[string]string{"shared_buffers": "512MB"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						"hugepages-2Mi":       resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+	})
+
+	It("accepts shared buffers fitting in the huge pages", func() {
+		Expect(cluster.validateHugePages()).To(BeEmpty())
+	})
+
+	It("complains when the shared buffers don't fit in the huge pages", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["shared_buffers"] = "1GB"
+		errors := cluster.validateHugePages()
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Field).To(Equal("spec.resources.limits.hugepages-2Mi"))
+	})
+
+	It("complains when more than one size of huge pages is requested", func() {
+		cluster.Spec.Resources.Limits["hugepages-1Gi"] = resource.MustParse("2Gi")
+		Expect(cluster.validateHugePages()).ToNot(BeEmpty())
+	})
+})
+
+var _ = Describe("validateWALSpaceProtection", func() {
+	It("accepts a fencing threshold greater than the protective mode one", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				WALSpaceProtection: &WALSpaceProtectionConfiguration{
+					Enabled:          true,
+					FencingThreshold: ptr.To(int32(95)),
+				},
+			},
+		}
+		Expect(cluster.validateWALSpaceProtection()).To(BeEmpty())
+	})
+
+	It("complains when the fencing threshold is not greater than the protective mode one", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				WALSpaceProtection: &WALSpaceProtectionConfiguration{
+					Enabled:          true,
+					Threshold:        ptr.To(int32(90)),
+					FencingThreshold: ptr.To(int32(90)),
+				},
+			},
+		}
+		Expect(cluster.validateWALSpaceProtection()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validation of the logical replication slots synchronization", func() {
+	newCluster := func(imageName string) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				ImageName: imageName,
+				ReplicationSlots: &ReplicationSlotsConfiguration{
+					SynchronizeLogicalSlots: &SynchronizeLogicalSlotsConfiguration{
+						Enabled:   true,
+						SlotNames: []string{"sub1"},
+					},
+				},
+			},
+		}
+	}
+
+	It("accepts the slot names with pg_failover_slots", func() {
+		Expect(newCluster("postgres:16").validateLogicalSlotsSynchronization()).To(BeEmpty())
+	})
+
+	It("rejects the slot names with PostgreSQL 17", func() {
+		Expect(newCluster("postgres:17").validateLogicalSlotsSynchronization()).To(HaveLen(1))
+	})
+
+	It("requires the replication slots for high availability", func() {
+		cluster := newCluster("postgres:16")
+		cluster.Spec.ReplicationSlots.HighAvailability = &ReplicationSlotsHAConfiguration{
+			Enabled: ptr.To(false),
+		}
+		Expect(cluster.validateLogicalSlotsSynchronization()).To(HaveLen(1))
+	})
+
+	It("requires the logical wal_level", func() {
+		cluster := newCluster("postgres:16")
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"wal_level": "replica"}
+		Expect(cluster.validateLogicalSlotsSynchronization()).To(HaveLen(1))
+	})
+
+	It("is not validated when disabled", func() {
+		cluster := newCluster("postgres:17")
+		cluster.Spec.ReplicationSlots.SynchronizeLogicalSlots.Enabled = false
+		Expect(cluster.validateLogicalSlotsSynchronization()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validation of the replication slots safeguard", func() {
+	newCluster := func(imageName string, safeguard ReplicationSlotsSafeguardConfiguration) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				ImageName: imageName,
+				ReplicationSlots: &ReplicationSlotsConfiguration{
+					Safeguard: &safeguard,
+				},
+			},
+		}
+	}
+
+	It("accepts valid limits", func() {
+		cluster := newCluster("postgres:17", ReplicationSlotsSafeguardConfiguration{
+			Enabled:            true,
+			MaxRetainedWALSize: "10Gi",
+			MaxInactiveTime:    &metav1.Duration{Duration: time.Hour},
+			Action:             ReplicationSlotsSafeguardActionDrop,
+		})
+		Expect(cluster.validateReplicationSlotsSafeguard()).To(BeEmpty())
+	})
+
+	It("requires at least one limit", func() {
+		cluster := newCluster("postgres:17", ReplicationSlotsSafeguardConfiguration{Enabled: true})
+		Expect(cluster.validateReplicationSlotsSafeguard()).To(HaveLen(1))
+	})
+
+	It("rejects invalid limits", func() {
+		cluster := newCluster("postgres:17", ReplicationSlotsSafeguardConfiguration{
+			Enabled:            true,
+			MaxRetainedWALSize: "ten gigabytes",
+			MaxInactiveTime:    &metav1.Duration{Duration: -time.Hour},
+		})
+		Expect(cluster.validateReplicationSlotsSafeguard()).To(HaveLen(2))
+	})
+
+	It("requires PostgreSQL 18 to invalidate the inactive slots", func() {
+		safeguard := ReplicationSlotsSafeguardConfiguration{
+			Enabled:         true,
+			MaxInactiveTime: &metav1.Duration{Duration: time.Hour},
+			Action:          ReplicationSlotsSafeguardActionInvalidate,
+		}
+		Expect(newCluster("postgres:17", safeguard).validateReplicationSlotsSafeguard()).To(HaveLen(1))
+		Expect(newCluster("postgres:18", safeguard).validateReplicationSlotsSafeguard()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validateAdditionalReplicationSlots", func() {
+	newCluster := func(slotNames ...string) *Cluster {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				ReplicationSlots: &ReplicationSlotsConfiguration{
+					HighAvailability: &ReplicationSlotsHAConfiguration{
+						Enabled: ptr.To(true),
+					},
+				},
+			},
+		}
+		for _, name := range slotNames {
+			cluster.Spec.ReplicationSlots.Additional = append(
+				cluster.Spec.ReplicationSlots.Additional,
+				AdditionalReplicationSlot{Name: name})
+		}
+		return cluster
+	}
+
+	It("accepts a cluster without additional replication slots", func() {
+		Expect(newCluster().validateAdditionalReplicationSlots()).To(BeEmpty())
+	})
+
+	It("accepts valid additional replication slots", func() {
+		Expect(newCluster("external_standby", "dms_task").validateAdditionalReplicationSlots()).To(BeEmpty())
+	})
+
+	It("rejects duplicated names", func() {
+		Expect(newCluster("external_standby", "external_standby").validateAdditionalReplicationSlots()).To(HaveLen(1))
+	})
+
+	It("rejects the names with the prefix of the HA replication slots", func() {
+		Expect(newCluster("_cnpg_external").validateAdditionalReplicationSlots()).To(HaveLen(1))
+
+		cluster := newCluster("external_standby")
+		cluster.Spec.ReplicationSlots.HighAvailability.SlotPrefix = "external_"
+		Expect(cluster.validateAdditionalReplicationSlots()).To(HaveLen(1))
+	})
+
+	It("rejects the names excluded from the synchronization", func() {
+		cluster := newCluster("external_standby")
+		cluster.Spec.ReplicationSlots.SynchronizeReplicas = &SynchronizeReplicasConfiguration{
+			Enabled:         ptr.To(true),
+			ExcludePatterns: []string{"^external_"},
+		}
+		Expect(cluster.validateAdditionalReplicationSlots()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validateWALArchiveTarget", func() {
+	newCluster := func(walArchiveTarget WALArchiveTarget, haSlots bool) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					WALArchiveTarget: walArchiveTarget,
+				},
+				ReplicationSlots: &ReplicationSlotsConfiguration{
+					HighAvailability: &ReplicationSlotsHAConfiguration{
+						Enabled: ptr.To(haSlots),
+					},
+				},
+			},
+		}
+	}
+
+	It("accepts the WAL files archived by the primary", func() {
+		Expect(newCluster("", false).validateWALArchiveTarget()).To(BeEmpty())
+		Expect(newCluster(WALArchiveTargetPrimary, false).validateWALArchiveTarget()).To(BeEmpty())
+	})
+
+	It("requires the HA replication slots to archive the WAL files from a standby", func() {
+		Expect(newCluster(WALArchiveTargetStandby, false).validateWALArchiveTarget()).To(HaveLen(1))
+		Expect(newCluster(WALArchiveTargetStandby, true).validateWALArchiveTarget()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validateBackupTargetSelector", func() {
+	newCluster := func(targetSelector *BackupTargetSelector) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				Backup: &BackupConfiguration{
+					TargetSelector: targetSelector,
+				},
+			},
+		}
+	}
+
+	It("accepts a cluster without a backup target selector", func() {
+		Expect(newCluster(nil).validateBackupTargetSelector()).To(BeEmpty())
+	})
+
+	It("accepts valid selectors", func() {
+		cluster := newCluster(&BackupTargetSelector{
+			InstanceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"cnpg.io/instanceName": "cluster-example-3"},
+			},
+			NodeSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "topology.kubernetes.io/zone",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"zone-a", "zone-b"},
+					},
+				},
+			},
+			Fallback: BackupTargetSelectorFallbackWait,
+		})
+		Expect(cluster.validateBackupTargetSelector()).To(BeEmpty())
+	})
+
+	It("requires at least one selector", func() {
+		Expect(newCluster(&BackupTargetSelector{}).validateBackupTargetSelector()).To(HaveLen(1))
+	})
+
+	It("rejects invalid selectors", func() {
+		cluster := newCluster(&BackupTargetSelector{
+			NodeSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "topology.kubernetes.io/zone",
+						Operator: metav1.LabelSelectorOpExists,
+						Values:   []string{"zone-a"},
+					},
+				},
+			},
+		})
+		Expect(cluster.validateBackupTargetSelector()).ToNot(BeEmpty())
+	})
+})
+
+var _ = Describe("bootstrap clone validation", func() {
+	newCluster := func(clone *BootstrapClone) *Cluster {
+		return &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "staging"},
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{Clone: clone},
+			},
+		}
+	}
+
+	It("defaults the application database", func() {
+		cluster := newCluster(&BootstrapClone{Cluster: "cluster-prod"})
+		cluster.Default()
+		Expect(cluster.Spec.Bootstrap.InitDB).To(BeNil())
+		Expect(cluster.Spec.Bootstrap.Clone.Database).To(Equal("app"))
+		Expect(cluster.Spec.Bootstrap.Clone.Owner).To(Equal("app"))
+		Expect(cluster.validateBootstrapClone()).To(BeEmpty())
+	})
+
+	It("accepts cloning a cluster with the same name in another namespace", func() {
+		cluster := newCluster(&BootstrapClone{Cluster: "cluster-example", Namespace: "production"})
+		Expect(cluster.validateBootstrapClone()).To(BeEmpty())
+	})
+
+	It("rejects cloning the cluster into itself", func() {
+		cluster := newCluster(&BootstrapClone{Cluster: "cluster-example"})
+		errs := cluster.validateBootstrapClone()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.bootstrap.clone.cluster"))
+	})
+
+	It("requires both the application database and its owner", func() {
+		cluster := newCluster(&BootstrapClone{Cluster: "cluster-prod", Database: "app"})
+		errs := cluster.validateBootstrapClone()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.bootstrap.clone.owner"))
+	})
+
+	It("rejects multiple bootstrap methods", func() {
+		cluster := newCluster(&BootstrapClone{Cluster: "cluster-prod"})
+		cluster.Spec.Bootstrap.InitDB = &BootstrapInitDB{}
+		Expect(cluster.validateBootstrapMethod()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("data masking validation", func() {
+	It("accepts clusters without data masking", func() {
+		cluster := &Cluster{}
+		Expect(cluster.validateDataMasking()).To(BeEmpty())
+	})
+
+	It("requires complete SQL references", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					Recovery: &BootstrapRecovery{
+						Source: "cluster-prod",
+						Masking: &DataMaskingConfiguration{
+							SQL: []string{"UPDATE customers SET email = cnpg_masking.email(email)"},
+							SQLRefs: &SQLRefs{
+								SecretRefs: []SecretKeySelector{
+									{LocalObjectReference: LocalObjectReference{Name: "masking"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		errs := cluster.validateDataMasking()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.bootstrap.recovery.masking.sqlRefs.secretRefs"))
+	})
+
+	It("rejects the data masking of replica clusters", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{Enabled: ptr.To(true), Source: "cluster-prod"},
+				Bootstrap: &BootstrapConfiguration{
+					PgBaseBackup: &BootstrapPgBaseBackup{
+						Source:  "cluster-prod",
+						Masking: &DataMaskingConfiguration{SQL: []string{"TRUNCATE audit_log"}},
+					},
+				},
+			},
+		}
+		errs := cluster.validateDataMasking()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.bootstrap.pg_basebackup.masking"))
+	})
+})
+
+var _ = Describe("lifecycle hooks validation", func() {
+	It("accepts well-formed hooks", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				LifecycleHooks: []LifecycleHook{
+					{
+						Name:  "warm-cache",
+						Event: LifecycleHookEventPostFailover,
+						Exec:  &LifecycleHookExec{Command: []string{"psql", "-c", "SELECT 1"}},
+					},
+					{
+						Name:    "migrate",
+						Event:   LifecycleHookEventPostInit,
+						Job:     &LifecycleHookJob{Command: []string{"/migrate"}},
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+			},
+		}
+		Expect(cluster.validateLifecycleHooks()).To(BeEmpty())
+	})
+
+	It("requires unique names", func() {
+		hook := LifecycleHook{
+			Name:  "notify",
+			Event: LifecycleHookEventPreSwitchover,
+			Job:   &LifecycleHookJob{Command: []string{"/notify"}},
+		}
+		cluster := &Cluster{Spec: ClusterSpec{LifecycleHooks: []LifecycleHook{hook, hook}}}
+		errs := cluster.validateLifecycleHooks()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.lifecycleHooks[1].name"))
+	})
+
+	It("requires exactly one action", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				LifecycleHooks: []LifecycleHook{
+					{Name: "none", Event: LifecycleHookEventPostInit},
+					{
+						Name:  "both",
+						Event: LifecycleHookEventPostInit,
+						Exec:  &LifecycleHookExec{Command: []string{"true"}},
+						Job:   &LifecycleHookJob{Command: []string{"true"}},
+					},
+				},
+			},
+		}
+		errs := cluster.validateLifecycleHooks()
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.lifecycleHooks[0]"))
+		Expect(errs[1].Field).To(Equal("spec.lifecycleHooks[1]"))
+	})
+
+	It("limits the timeout of the exec hooks", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				LifecycleHooks: []LifecycleHook{
+					{
+						Name:    "vacuum",
+						Event:   LifecycleHookEventPostRecovery,
+						Exec:    &LifecycleHookExec{Command: []string{"vacuumdb", "--all"}},
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+			},
+		}
+		errs := cluster.validateLifecycleHooks()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.lifecycleHooks[0].timeout"))
+	})
+})
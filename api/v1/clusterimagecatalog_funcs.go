@@ -20,3 +20,8 @@ package v1
 func (c *ClusterImageCatalog) GetSpec() *ImageCatalogSpec {
 	return &c.Spec
 }
+
+// GetStatus returns the Status of the ClusterImageCatalog
+func (c *ClusterImageCatalog) GetStatus() *ImageCatalogStatus {
+	return &c.Status
+}
@@ -22,6 +22,7 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ClusterImageCatalog is the Schema for the clusterimagecatalogs API
@@ -31,6 +32,9 @@ type ClusterImageCatalog struct {
 	// Specification of the desired behavior of the ClusterImageCatalog.
 	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
 	Spec ImageCatalogSpec `json:"spec"`
+	// Most recently observed status of the ClusterImageCatalog.
+	// +optional
+	Status ImageCatalogStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
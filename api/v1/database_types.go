@@ -162,6 +162,276 @@ type DatabaseSpec struct {
 	// +kubebuilder:default:=retain
 	// +optional
 	ReclaimPolicy DatabaseReclaimPolicy `json:"databaseReclaimPolicy,omitempty"`
+
+	// Maps to the `SET search_path` command of `ALTER DATABASE`. The
+	// default list of schemas used to resolve the names of the objects in
+	// the database. When empty, the search path of the database is not
+	// managed.
+	// +optional
+	SearchPath []string `json:"searchPath,omitempty"`
+
+	// The list of schemas to be managed in the database. The schemas
+	// are reconciled before the extensions, which can then be installed
+	// in them.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Schemas []SchemaSpec `json:"schemas,omitempty"`
+
+	// The list of extensions to be managed in the database. The extensions
+	// are created and updated following the order of the list, and dropped
+	// in the reverse order.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []ExtensionSpec `json:"extensions,omitempty"`
+
+	// The list of foreign servers to be managed in the database, together
+	// with their user mappings. The servers are reconciled after the
+	// extensions, which provide the foreign data wrappers.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Servers []ServerSpec `json:"servers,omitempty"`
+
+	// The list of privileges to be granted, or revoked, in the database.
+	// The privileges are applied at every reconciliation, correcting the
+	// changes made outside of the operator.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Grants []GrantSpec `json:"grants,omitempty"`
+
+	// The autovacuum storage parameters of the tables of the database,
+	// overriding the ones of the cluster. The parameters are applied at
+	// every reconciliation, correcting the changes made outside of the
+	// operator.
+	// +listType=map
+	// +listMapKey=schema
+	// +listMapKey=name
+	// +optional
+	TableAutovacuum []TableAutovacuumSpec `json:"tableAutovacuum,omitempty"`
+}
+
+// SchemaSpec configures a schema in a database, built around the
+// `CREATE SCHEMA`, `ALTER SCHEMA`, and `DROP SCHEMA` SQL commands of
+// PostgreSQL.
+type SchemaSpec struct {
+	// The name of the schema.
+	// +kubebuilder:validation:XValidation:rule="self != 'public'",message="the public schema cannot be managed"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('pg_')",message="the pg_ prefix is reserved"
+	Name string `json:"name"`
+
+	// Ensure the schema is `present` or `absent` - defaults to "present".
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// Maps to the `AUTHORIZATION` clause of `CREATE SCHEMA` and to the
+	// `OWNER TO` command of `ALTER SCHEMA`. When empty, the schema is
+	// owned by the owner of the database.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Maps to the `COMMENT ON SCHEMA` command.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// Maps to the `CASCADE` clause of `DROP SCHEMA`. By default, a schema
+	// is dropped only when it is empty, protecting the objects it
+	// contains.
+	// +optional
+	Cascade bool `json:"cascade,omitempty"`
+}
+
+// ExtensionSpec configures an extension in a database, built around the
+// `CREATE EXTENSION`, `ALTER EXTENSION`, and `DROP EXTENSION` SQL commands
+// of PostgreSQL.
+type ExtensionSpec struct {
+	// The name of the extension.
+	Name string `json:"name"`
+
+	// Ensure the extension is `present` or `absent` - defaults to "present".
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// Maps to the `VERSION` parameter of `CREATE EXTENSION` and to the
+	// `UPDATE TO` command of `ALTER EXTENSION`. When empty, the default
+	// version of the extension is installed, and the installed version
+	// is never updated.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Maps to the `SCHEMA` parameter of `CREATE EXTENSION` and to the
+	// `SET SCHEMA` command of `ALTER EXTENSION`. The schema where the
+	// objects of the extension are installed.
+	// +optional
+	Schema string `json:"schema,omitempty"`
+
+	// Maps to the `CASCADE` option of `CREATE EXTENSION` and `DROP
+	// EXTENSION`. When true, the extensions this extension depends on are
+	// installed if missing, and the objects depending on this extension
+	// are dropped together with it.
+	// +optional
+	Cascade bool `json:"cascade,omitempty"`
+}
+
+// ServerSpec configures a foreign server in a database, built around the
+// `CREATE SERVER`, `ALTER SERVER`, and `DROP SERVER` SQL commands of
+// PostgreSQL, together with its user mappings.
+// +kubebuilder:validation:XValidation:rule="has(self.externalClusterName) || !has(self.userMappings) || self.userMappings.all(m, !has(m.useExternalClusterCredentials) || !m.useExternalClusterCredentials)",message="useExternalClusterCredentials requires externalClusterName"
+type ServerSpec struct {
+	// The name of the foreign server.
+	Name string `json:"name"`
+
+	// Ensure the foreign server is `present` or `absent` - defaults to "present".
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// Maps to the `FOREIGN DATA WRAPPER` clause of `CREATE SERVER`. This
+	// setting cannot be changed. The extension providing the foreign data
+	// wrapper must be installed in the database.
+	// +kubebuilder:default:="postgres_fdw"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="fdw is immutable"
+	// +optional
+	FDW string `json:"fdw,omitempty"`
+
+	// The name of an external cluster, declared in the Cluster, whose
+	// connection parameters are used as options of the server, except for
+	// `user`, which is reserved to the user mappings.
+	// +optional
+	ExternalClusterName string `json:"externalClusterName,omitempty"`
+
+	// Maps to the `OPTIONS` clause of `CREATE SERVER` and `ALTER SERVER`.
+	// These options take precedence over the ones coming from the external
+	// cluster. Options that are removed from this list are not removed
+	// from the server.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// Maps to the `CASCADE` clause of `DROP SERVER`. When false, a server
+	// having user mappings or foreign tables is not dropped.
+	// +optional
+	Cascade bool `json:"cascade,omitempty"`
+
+	// The user mappings of the foreign server.
+	// +listType=map
+	// +listMapKey=role
+	// +optional
+	UserMappings []UserMappingSpec `json:"userMappings,omitempty"`
+}
+
+// UserMappingSpec configures a user mapping of a foreign server, built
+// around the `CREATE USER MAPPING`, `ALTER USER MAPPING`, and
+// `DROP USER MAPPING` SQL commands of PostgreSQL.
+type UserMappingSpec struct {
+	// The local role being mapped, or `PUBLIC` for every role.
+	Role string `json:"role"`
+
+	// Ensure the user mapping is `present` or `absent` - defaults to "present".
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// When true, the `user` and `password` options of the user mapping
+	// are taken from the external cluster of the server. The password is
+	// read from its secret, and kept in sync with it.
+	// +optional
+	UseExternalClusterCredentials bool `json:"useExternalClusterCredentials,omitempty"`
+
+	// Maps to the `OPTIONS` clause of `CREATE USER MAPPING` and
+	// `ALTER USER MAPPING`. These options take precedence over the
+	// credentials coming from the external cluster.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// GrantObjectType is the type of the objects privileges are granted on
+// +enum
+type GrantObjectType string
+
+const (
+	// GrantObjectTypeDatabase grants privileges on the database itself
+	GrantObjectTypeDatabase GrantObjectType = "database"
+
+	// GrantObjectTypeSchema grants privileges on a schema
+	GrantObjectTypeSchema GrantObjectType = "schema"
+
+	// GrantObjectTypeTable grants privileges on tables
+	GrantObjectTypeTable GrantObjectType = "table"
+
+	// GrantObjectTypeSequence grants privileges on sequences
+	GrantObjectTypeSequence GrantObjectType = "sequence"
+)
+
+// GrantSpec configures a set of privileges of a role in a database, built
+// around the `GRANT`, `REVOKE`, and `ALTER DEFAULT PRIVILEGES` SQL commands
+// of PostgreSQL.
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultPrivileges) || !self.defaultPrivileges || self.objectType == 'table' || self.objectType == 'sequence'",message="defaultPrivileges is only available for tables and sequences"
+// +kubebuilder:validation:XValidation:rule="!has(self.objects) || self.objectType == 'table' || self.objectType == 'sequence'",message="objects is only available for tables and sequences"
+type GrantSpec struct {
+	// The name identifying this set of privileges.
+	Name string `json:"name"`
+
+	// Ensure the privileges are `present` or `absent` - defaults to "present".
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// The role receiving the privileges.
+	Role string `json:"role"`
+
+	// The type of the objects the privileges are granted on.
+	// +kubebuilder:validation:Enum=database;schema;table;sequence
+	ObjectType GrantObjectType `json:"objectType"`
+
+	// The schema, when the objects are schemas, or the schema containing
+	// the tables or sequences. Defaults to `public`.
+	// +optional
+	Schema string `json:"schema,omitempty"`
+
+	// The names of the tables or sequences in the schema. When empty,
+	// the privileges are granted on all the tables or sequences of the
+	// schema.
+	// +optional
+	Objects []string `json:"objects,omitempty"`
+
+	// The privileges to be granted, for example `SELECT` or `USAGE`.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=ALL;SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;USAGE;CREATE;CONNECT;TEMPORARY
+	Privileges []string `json:"privileges"`
+
+	// When true, the privileges are also granted on the tables or
+	// sequences that will be created in the schema by the owner of the
+	// database, through `ALTER DEFAULT PRIVILEGES`.
+	// +optional
+	DefaultPrivileges bool `json:"defaultPrivileges,omitempty"`
+}
+
+// TableAutovacuumSpec configures the autovacuum storage parameters of a
+// table, built around the `ALTER TABLE ... SET` and `ALTER TABLE ... RESET`
+// SQL commands of PostgreSQL.
+type TableAutovacuumSpec struct {
+	// The schema containing the table. Defaults to `public`.
+	// +kubebuilder:default:="public"
+	Schema string `json:"schema"`
+
+	// The name of the table.
+	Name string `json:"name"`
+
+	// The autovacuum storage parameters of the table, for example
+	// `autovacuum_vacuum_scale_factor`. The parameters of the TOAST table
+	// are prefixed with `toast.`. The autovacuum parameters set on the
+	// table and not listed here are reset.
+	// +kubebuilder:validation:XValidation:rule="self.all(key, key.matches('^(toast\\.)?(autovacuum_[a-z_]+|log_autovacuum_min_duration|vacuum_index_cleanup|vacuum_truncate)$'))",message="only the autovacuum storage parameters can be specified"
+	Parameters map[string]string `json:"parameters"`
 }
 
 // DatabaseStatus defines the observed state of Database
@@ -178,6 +448,99 @@ type DatabaseStatus struct {
 	// Message is the reconciliation output message
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Schemas is the status of the managed schemas
+	// +optional
+	Schemas []SchemaStatus `json:"schemas,omitempty"`
+
+	// Extensions is the status of the managed extensions
+	// +optional
+	Extensions []ExtensionStatus `json:"extensions,omitempty"`
+
+	// Servers is the status of the managed foreign servers
+	// +optional
+	Servers []ServerStatus `json:"servers,omitempty"`
+
+	// Grants is the status of the managed privileges
+	// +optional
+	Grants []GrantStatus `json:"grants,omitempty"`
+
+	// TableAutovacuum is the status of the managed autovacuum
+	// storage parameters
+	// +optional
+	TableAutovacuum []TableAutovacuumStatus `json:"tableAutovacuum,omitempty"`
+}
+
+// ExtensionStatus is the status of a managed extension
+type ExtensionStatus struct {
+	// The name of the extension
+	Name string `json:"name"`
+
+	// True if the extension was reconciled correctly
+	Applied bool `json:"applied"`
+
+	// The installed version of the extension, empty when
+	// the extension is not installed
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SchemaStatus is the status of a managed schema
+type SchemaStatus struct {
+	// The name of the schema
+	Name string `json:"name"`
+
+	// True if the schema was reconciled correctly
+	Applied bool `json:"applied"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ServerStatus is the status of a managed foreign server
+type ServerStatus struct {
+	// The name of the foreign server
+	Name string `json:"name"`
+
+	// True if the foreign server and its user mappings were reconciled
+	// correctly
+	Applied bool `json:"applied"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GrantStatus is the status of a set of managed privileges
+type GrantStatus struct {
+	// The name identifying the set of privileges
+	Name string `json:"name"`
+
+	// True if the privileges were applied correctly
+	Applied bool `json:"applied"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TableAutovacuumStatus is the status of the autovacuum storage
+// parameters of a table
+type TableAutovacuumStatus struct {
+	// The qualified name of the table
+	Name string `json:"name"`
+
+	// True if the storage parameters were applied correctly
+	Applied bool `json:"applied"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -14,4 +14,7 @@ type GenericImageCatalog interface {
 
 	// GetSpec returns the Spec of the GenericImageCatalog
 	GetSpec() *ImageCatalogSpec
+
+	// GetStatus returns the Status of the GenericImageCatalog
+	GetStatus() *ImageCatalogStatus
 }
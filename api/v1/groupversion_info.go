@@ -48,6 +48,12 @@ const (
 
 	// DatabaseKind is the kind name of databases
 	DatabaseKind = "Database"
+
+	// ScheduledDumpKind is the kind name of scheduled dumps
+	ScheduledDumpKind = "ScheduledDump"
+
+	// BackupGrantKind is the kind name of backup grants
+	BackupGrantKind = "BackupGrant"
 )
 
 var (
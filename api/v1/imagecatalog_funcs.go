@@ -16,11 +16,22 @@ limitations under the License.
 
 package v1
 
+import (
+	"time"
+
+	"github.com/robfig/cron"
+)
+
 // GetSpec returns the Spec of the ImageCatalog
 func (c *ImageCatalog) GetSpec() *ImageCatalogSpec {
 	return &c.Spec
 }
 
+// GetStatus returns the Status of the ImageCatalog
+func (c *ImageCatalog) GetStatus() *ImageCatalogStatus {
+	return &c.Status
+}
+
 // FindImageForMajor finds the correct image for the selected major version
 func (spec *ImageCatalogSpec) FindImageForMajor(major int) (string, bool) {
 	for _, entry := range spec.Images {
@@ -31,3 +42,63 @@ func (spec *ImageCatalogSpec) FindImageForMajor(major int) (string, bool) {
 
 	return "", false
 }
+
+// FindPgBouncerImageForMajor finds the PgBouncer image for the selected major version
+func (spec *ImageCatalogSpec) FindPgBouncerImageForMajor(major int) (string, bool) {
+	for _, entry := range spec.PgBouncerImages {
+		if entry.Major == major {
+			return entry.Image, true
+		}
+	}
+
+	return "", false
+}
+
+// SetImageForMajor replaces the image for the selected major version,
+// returning the image previously in the catalog
+func (spec *ImageCatalogSpec) SetImageForMajor(major int, image string) (string, bool) {
+	for idx, entry := range spec.Images {
+		if entry.Major == major {
+			spec.Images[idx].Image = image
+			return entry.Image, true
+		}
+	}
+
+	return "", false
+}
+
+// SetImageUpdate records the automatic update of the image for
+// a major version, replacing the previous record
+func (status *ImageCatalogStatus) SetImageUpdate(update CatalogImageUpdate) {
+	for idx, entry := range status.UpdatedImages {
+		if entry.Major == update.Major {
+			status.UpdatedImages[idx] = update
+			return
+		}
+	}
+
+	status.UpdatedImages = append(status.UpdatedImages, update)
+}
+
+// GetNextCheck gets the time when the registries should be checked
+// again for new images, given the time of the last check
+func (autoUpdate *ImageCatalogAutoUpdate) GetNextCheck(lastCheck time.Time) (time.Time, error) {
+	schedule, err := cron.Parse(autoUpdate.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.Next(lastCheck.UTC()), nil
+}
+
+// IsInMaintenanceWindow checks whether the images of the catalog
+// can be updated at the given time
+func (autoUpdate *ImageCatalogAutoUpdate) IsInMaintenanceWindow(now time.Time) bool {
+	return isInMaintenanceWindows(autoUpdate.MaintenanceWindows, now)
+}
+
+// GetNextMaintenanceWindow gets the start of the first maintenance
+// window after the given time
+func (autoUpdate *ImageCatalogAutoUpdate) GetNextMaintenanceWindow(now time.Time) (time.Time, bool) {
+	return getNextMaintenanceWindow(autoUpdate.MaintenanceWindows, now)
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -46,4 +50,65 @@ var _ = Describe("image catalog", func() {
 		Expect(image).To(BeEmpty())
 		Expect(ok).To(BeFalse())
 	})
+
+	It("replaces the image for a major version", func() {
+		spec := catalogSpec.DeepCopy()
+		previous, ok := spec.SetImageForMajor(16, "test:16.4")
+		Expect(ok).To(BeTrue())
+		Expect(previous).To(Equal("test:16"))
+		Expect(spec.Images[1].Image).To(Equal("test:16.4"))
+		Expect(catalogSpec.Images[1].Image).To(Equal("test:16"))
+
+		_, ok = spec.SetImageForMajor(13, "test:13")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("keeps only the latest update of each major version", func() {
+		var status ImageCatalogStatus
+		status.SetImageUpdate(CatalogImageUpdate{Major: 16, Image: "test:16.3", PreviousImage: "test:16"})
+		status.SetImageUpdate(CatalogImageUpdate{Major: 15, Image: "test:15.7", PreviousImage: "test:15"})
+		status.SetImageUpdate(CatalogImageUpdate{Major: 16, Image: "test:16.4", PreviousImage: "test:16.3"})
+		Expect(status.UpdatedImages).To(HaveLen(2))
+		Expect(status.UpdatedImages[0].Image).To(Equal("test:16.4"))
+		Expect(status.UpdatedImages[0].PreviousImage).To(Equal("test:16.3"))
+	})
+})
+
+var _ = Describe("image catalog automatic update", func() {
+	now := time.Date(2024, 3, 6, 12, 30, 0, 0, time.UTC)
+
+	It("computes the next check from the schedule", func() {
+		autoUpdate := ImageCatalogAutoUpdate{Schedule: "0 0 * * * *"}
+		next, err := autoUpdate.GetNextCheck(now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(Equal(time.Date(2024, 3, 6, 13, 0, 0, 0, time.UTC)))
+	})
+
+	It("complains about invalid schedules", func() {
+		autoUpdate := ImageCatalogAutoUpdate{Schedule: "not a schedule"}
+		_, err := autoUpdate.GetNextCheck(now)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows updates at any time without maintenance windows", func() {
+		autoUpdate := ImageCatalogAutoUpdate{Schedule: "0 0 * * * *"}
+		Expect(autoUpdate.IsInMaintenanceWindow(now)).To(BeTrue())
+		_, ok := autoUpdate.GetNextMaintenanceWindow(now)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("allows updates only inside the maintenance windows", func() {
+		autoUpdate := ImageCatalogAutoUpdate{
+			Schedule: "0 0 * * * *",
+			MaintenanceWindows: []MaintenanceWindow{
+				{Schedule: "0 0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		Expect(autoUpdate.IsInMaintenanceWindow(now)).To(BeFalse())
+		Expect(autoUpdate.IsInMaintenanceWindow(time.Date(2024, 3, 6, 2, 30, 0, 0, time.UTC))).To(BeTrue())
+
+		next, ok := autoUpdate.GetNextMaintenanceWindow(now)
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2024, 3, 7, 2, 0, 0, 0, time.UTC)))
+	})
 })
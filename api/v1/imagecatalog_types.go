@@ -27,6 +27,34 @@ type ImageCatalogSpec struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:validation:XValidation:rule="self.all(e, self.filter(f, f.major==e.major).size() == 1)",message=Images must have unique major versions
 	Images []CatalogImage `json:"images"`
+
+	// List of PgBouncer images available in the catalog, to be used
+	// by the poolers referencing it
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(e, self.filter(f, f.major==e.major).size() == 1)",message=PgBouncer images must have unique major versions
+	// +optional
+	PgBouncerImages []PoolerCatalogImage `json:"pgbouncerImages,omitempty"`
+
+	// The configuration of the automatic update of the images of the
+	// catalog to the latest minor version available in their registry
+	// +optional
+	AutoUpdate *ImageCatalogAutoUpdate `json:"autoUpdate,omitempty"`
+}
+
+// ImageCatalogAutoUpdate configures the automatic update of the images
+// of a catalog. The registry of each image is periodically checked for
+// tags of the same major version with a higher minor version, and the
+// image is replaced with the latest one, pinned by digest
+type ImageCatalogAutoUpdate struct {
+	// How often the registries are checked for new images, in Cron format
+	// with the seconds field, see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	Schedule string `json:"schedule"`
+
+	// The time windows in which the images of the catalog can be
+	// updated. When no window is defined, the images are updated as
+	// soon as a new version is found
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 }
 
 // CatalogImage defines the image and major version
@@ -38,9 +66,46 @@ type CatalogImage struct {
 	Major int `json:"major"`
 }
 
+// PoolerCatalogImage defines the image of a pooler and its major version
+type PoolerCatalogImage struct {
+	// The image reference
+	Image string `json:"image"`
+	// +kubebuilder:validation:Minimum=1
+	// The major version of the pooler in the image. Must be unique within the catalog.
+	Major int `json:"major"`
+}
+
+// ImageCatalogStatus defines the observed state of an image catalog
+type ImageCatalogStatus struct {
+	// The last time the registries have been checked for new images
+	// +optional
+	LastCheck *metav1.Time `json:"lastCheck,omitempty"`
+
+	// The latest automatic update of each image of the catalog
+	// +optional
+	UpdatedImages []CatalogImageUpdate `json:"updatedImages,omitempty"`
+}
+
+// CatalogImageUpdate records the automatic update of an image of the catalog
+type CatalogImageUpdate struct {
+	// The PostgreSQL major version of the image
+	Major int `json:"major"`
+
+	// The image the catalog has been updated to
+	Image string `json:"image"`
+
+	// The image the catalog contained before the update, to be
+	// restored in case of rollback
+	PreviousImage string `json:"previousImage"`
+
+	// The time of the update
+	UpdatedAt metav1.Time `json:"updatedAt"`
+}
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ImageCatalog is the Schema for the imagecatalogs API
@@ -50,6 +115,9 @@ type ImageCatalog struct {
 	// Specification of the desired behavior of the ImageCatalog.
 	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
 	Spec ImageCatalogSpec `json:"spec"`
+	// Most recently observed status of the ImageCatalog.
+	// +optional
+	Status ImageCatalogStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
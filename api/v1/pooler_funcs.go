@@ -16,21 +16,88 @@ limitations under the License.
 
 package v1
 
+import (
+	"fmt"
+	"strconv"
+)
+
+// defaultPgBouncerPoolSize is the default value of the `default_pool_size`
+// PgBouncer parameter
+const defaultPgBouncerPoolSize = 20
+
 // IsPaused returns whether all database should be paused or not.
 func (in PgBouncerSpec) IsPaused() bool {
 	return in.Paused != nil && *in.Paused
 }
 
+// GetBackend returns the connection pooler implementation deployed by
+// this Pooler, defaulting to PgBouncer
+func (in *Pooler) GetBackend() PoolerBackend {
+	if in.Spec.Backend == "" {
+		return PoolerBackendPgBouncer
+	}
+
+	return in.Spec.Backend
+}
+
 // GetAuthQuerySecretName returns the specified AuthQuerySecret name for PgBouncer
-// if provided or the default name otherwise.
+// if provided or the default name otherwise. The default secret of a
+// cross-namespace Pooler is the copy of the one generated by the cluster
 func (in *Pooler) GetAuthQuerySecretName() string {
+	if in.GetBackend() == PoolerBackendPgCat && in.Spec.PgCat != nil {
+		return in.Spec.PgCat.AuthQuerySecret.Name
+	}
+
 	if in.Spec.PgBouncer != nil && in.Spec.PgBouncer.AuthQuerySecret != nil {
 		return in.Spec.PgBouncer.AuthQuerySecret.Name
 	}
 
+	if in.IsCrossNamespace() {
+		return in.GetReplicatedSecretName(in.GetClusterAuthQuerySecretName())
+	}
+
+	return in.GetClusterAuthQuerySecretName()
+}
+
+// GetClusterAuthQuerySecretName returns the name of the secret generated
+// by the cluster, in its namespace, for the automated integration
+func (in *Pooler) GetClusterAuthQuerySecretName() string {
 	return in.Spec.Cluster.Name + DefaultPgBouncerPoolerSecretSuffix
 }
 
+// GetClusterNamespace returns the namespace of the referenced cluster
+func (in *Pooler) GetClusterNamespace() string {
+	if in.Spec.ClusterNamespace != "" {
+		return in.Spec.ClusterNamespace
+	}
+
+	return in.Namespace
+}
+
+// IsCrossNamespace checks if the Pooler references a cluster living
+// in a different namespace
+func (in *Pooler) IsCrossNamespace() bool {
+	return in.GetClusterNamespace() != in.Namespace
+}
+
+// GetClusterServiceHost returns the host of the service of the referenced
+// cluster matching the type of the Pooler, qualified with the namespace
+// of the cluster for cross-namespace Poolers
+func (in *Pooler) GetClusterServiceHost() string {
+	host := fmt.Sprintf("%s-%s", in.Spec.Cluster.Name, in.Spec.Type)
+	if in.IsCrossNamespace() {
+		host = fmt.Sprintf("%s.%s", host, in.GetClusterNamespace())
+	}
+
+	return host
+}
+
+// GetReplicatedSecretName returns the name of the copy, in the namespace
+// of a cross-namespace Pooler, of a secret of the referenced cluster
+func (in *Pooler) GetReplicatedSecretName(secretName string) string {
+	return in.Name + "-" + secretName
+}
+
 // GetAuthQuery returns the specified AuthQuery name for PgBouncer
 // if provided or the default name otherwise.
 func (in *Pooler) GetAuthQuery() string {
@@ -44,6 +111,12 @@ func (in *Pooler) GetAuthQuery() string {
 // IsAutomatedIntegration returns whether the Pooler integration with the
 // Cluster is automated or not.
 func (in *Pooler) IsAutomatedIntegration() bool {
+	// PgCat can't authenticate with the TLS certificate used by the
+	// automated integration
+	if in.GetBackend() == PoolerBackendPgCat {
+		return false
+	}
+
 	if in.Spec.PgBouncer == nil {
 		return true
 	}
@@ -55,3 +128,72 @@ func (in *Pooler) IsAutomatedIntegration() bool {
 	}
 	return true
 }
+
+// GetEnablePDB returns whether the operator should manage the
+// PodDisruptionBudget of the Pooler, defaults to true
+func (in *Pooler) GetEnablePDB() bool {
+	if in.Spec.EnablePDB == nil {
+		return true
+	}
+
+	return *in.Spec.EnablePDB
+}
+
+// GetAuthQuery returns the specified AuthQuery for PgCat if provided
+// or the default one otherwise.
+func (in PgCatSpec) GetAuthQuery() string {
+	if in.AuthQuery != "" {
+		return in.AuthQuery
+	}
+
+	return DefaultPgCatPoolerAuthQuery
+}
+
+// GetPools returns the effective settings of the pools declared in the
+// PgBouncer configuration: one for each database, and one for each
+// database/user pair whose pool mode is overridden by the user settings
+func (in *PgBouncerSpec) GetPools() []PgBouncerPoolStatus {
+	if len(in.Databases) == 0 {
+		return nil
+	}
+
+	defaultPoolMode := in.PoolMode
+	if defaultPoolMode == "" {
+		defaultPoolMode = PgBouncerPoolModeSession
+	}
+
+	defaultPoolSize := int32(defaultPgBouncerPoolSize)
+	if value, ok := in.Parameters["default_pool_size"]; ok {
+		if poolSize, err := strconv.ParseInt(value, 10, 32); err == nil {
+			defaultPoolSize = int32(poolSize)
+		}
+	}
+
+	pools := make([]PgBouncerPoolStatus, 0, len(in.Databases))
+	for _, database := range in.Databases {
+		pool := PgBouncerPoolStatus{
+			Database: database.Name,
+			PoolMode: defaultPoolMode,
+			PoolSize: defaultPoolSize,
+		}
+		if database.PoolMode != "" {
+			pool.PoolMode = database.PoolMode
+		}
+		if database.PoolSize != nil {
+			pool.PoolSize = *database.PoolSize
+		}
+		pools = append(pools, pool)
+
+		for _, user := range in.Users {
+			if user.PoolMode == "" || user.PoolMode == pool.PoolMode {
+				continue
+			}
+			userPool := pool
+			userPool.User = user.Name
+			userPool.PoolMode = user.PoolMode
+			pools = append(pools, userPool)
+		}
+	}
+
+	return pools
+}
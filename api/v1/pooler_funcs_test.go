@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -34,4 +36,59 @@ var _ = Describe("Pooler type tests", func() {
 		}
 		Expect(pgbouncer.IsPaused()).To(BeTrue())
 	})
+
+	It("reports no pools when no database is declared", func() {
+		pgbouncer := PgBouncerSpec{PoolMode: PgBouncerPoolModeTransaction}
+		Expect(pgbouncer.GetPools()).To(BeEmpty())
+	})
+
+	It("reports the effective settings of the declared pools", func() {
+		poolSize := int32(5)
+		pgbouncer := PgBouncerSpec{
+			PoolMode:   PgBouncerPoolModeTransaction,
+			Parameters: map[string]string{"default_pool_size": "30"},
+			Databases: []PgBouncerDatabase{
+				{Name: "app"},
+				{Name: "reports", PoolMode: PgBouncerPoolModeSession, PoolSize: &poolSize},
+			},
+			Users: []PgBouncerUser{
+				{Name: "migrations", PoolMode: PgBouncerPoolModeSession},
+			},
+		}
+		Expect(pgbouncer.GetPools()).To(Equal([]PgBouncerPoolStatus{
+			{Database: "app", PoolMode: PgBouncerPoolModeTransaction, PoolSize: 30},
+			{Database: "app", User: "migrations", PoolMode: PgBouncerPoolModeSession, PoolSize: 30},
+			{Database: "reports", PoolMode: PgBouncerPoolModeSession, PoolSize: 5},
+		}))
+	})
+
+	It("references a cluster in its own namespace by default", func() {
+		pooler := Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw", Namespace: "apps"},
+			Spec:       PoolerSpec{Cluster: LocalObjectReference{Name: "cluster-example"}},
+		}
+		Expect(pooler.IsCrossNamespace()).To(BeFalse())
+		Expect(pooler.GetClusterNamespace()).To(Equal("apps"))
+		Expect(pooler.GetAuthQuerySecretName()).To(Equal("cluster-example-pooler"))
+
+		pooler.Spec.ClusterNamespace = "apps"
+		Expect(pooler.IsCrossNamespace()).To(BeFalse())
+	})
+
+	It("uses the copies of the cluster secrets when referencing another namespace", func() {
+		pooler := Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw", Namespace: "apps"},
+			Spec: PoolerSpec{
+				Cluster:          LocalObjectReference{Name: "cluster-example"},
+				ClusterNamespace: "databases",
+			},
+		}
+		Expect(pooler.IsCrossNamespace()).To(BeTrue())
+		Expect(pooler.GetClusterNamespace()).To(Equal("databases"))
+		Expect(pooler.GetClusterAuthQuerySecretName()).To(Equal("cluster-example-pooler"))
+		Expect(pooler.GetAuthQuerySecretName()).To(Equal("pooler-rw-cluster-example-pooler"))
+
+		pooler.Spec.PgBouncer = &PgBouncerSpec{AuthQuerySecret: &LocalObjectReference{Name: "auth"}}
+		Expect(pooler.GetAuthQuerySecretName()).To(Equal("auth"))
+	})
 })
@@ -37,6 +37,38 @@ const (
 
 	// DefaultPgBouncerPoolerAuthQuery is the default auth_query for PgBouncer
 	DefaultPgBouncerPoolerAuthQuery = "SELECT usename, passwd FROM public.user_search($1)"
+
+	// DefaultPgCatPoolerAuthQuery is the default auth_query for PgCat
+	DefaultPgCatPoolerAuthQuery = "SELECT usename, passwd FROM pg_catalog.pg_shadow WHERE usename='$1'"
+)
+
+// PoolerBackend is the connection pooler implementation deployed by a
+// Pooler. Allowed values are `pgbouncer` and `pgcat`.
+// +kubebuilder:validation:Enum=pgbouncer;pgcat
+type PoolerBackend string
+
+const (
+	// PoolerBackendPgBouncer deploys PgBouncer
+	PoolerBackendPgBouncer = PoolerBackend("pgbouncer")
+
+	// PoolerBackendPgCat deploys PgCat
+	PoolerBackendPgCat = PoolerBackend("pgcat")
+)
+
+// PgCatDefaultRole is the role of the servers where PgCat sends the
+// queries when no routing decision has been taken
+// +kubebuilder:validation:Enum=any;primary;replica
+type PgCatDefaultRole string
+
+const (
+	// PgCatDefaultRoleAny routes queries to any server
+	PgCatDefaultRoleAny = PgCatDefaultRole("any")
+
+	// PgCatDefaultRolePrimary routes queries to the primary
+	PgCatDefaultRolePrimary = PgCatDefaultRole("primary")
+
+	// PgCatDefaultRoleReplica routes queries to the replicas
+	PgCatDefaultRoleReplica = PgCatDefaultRole("replica")
 )
 
 // PgBouncerPoolMode is the mode of PgBouncer
@@ -57,6 +89,12 @@ type PoolerSpec struct {
 	// Pooler name should never match with any cluster name within the same namespace.
 	Cluster LocalObjectReference `json:"cluster"`
 
+	// The namespace of the referenced cluster, when different from the
+	// one of the Pooler. The cluster must list the namespace of the Pooler
+	// in its `.spec.poolerNamespaces`
+	// +optional
+	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+
 	// Type of service to forward traffic to. Default: `rw`.
 	// +kubebuilder:default:=rw
 	// +optional
@@ -71,13 +109,34 @@ type PoolerSpec struct {
 	// +optional
 	Template *PodTemplateSpec `json:"template,omitempty"`
 
-	// The PgBouncer configuration
-	PgBouncer *PgBouncerSpec `json:"pgbouncer"`
+	// The connection pooler implementation to be deployed.
+	// Default: `pgbouncer`.
+	// +kubebuilder:default:=pgbouncer
+	// +optional
+	Backend PoolerBackend `json:"backend,omitempty"`
+
+	// The PgBouncer configuration, required when using the
+	// `pgbouncer` backend
+	// +optional
+	PgBouncer *PgBouncerSpec `json:"pgbouncer,omitempty"`
+
+	// The PgCat configuration, required when using the
+	// `pgcat` backend
+	// +optional
+	PgCat *PgCatSpec `json:"pgcat,omitempty"`
 
 	// The deployment strategy to use for pgbouncer to replace existing pods with new ones
 	// +optional
 	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
 
+	// Manage a `PodDisruptionBudget` for the pods of the pooler, preventing
+	// voluntary disruptions, such as node drains, from evicting more than
+	// one of them at a time. The budget is created only when the pooler
+	// has more than one instance. Default: `true`.
+	// +kubebuilder:default:=true
+	// +optional
+	EnablePDB *bool `json:"enablePDB,omitempty"`
+
 	// The configuration of the monitoring infrastructure of this pooler.
 	// +optional
 	Monitoring *PoolerMonitoringConfiguration `json:"monitoring,omitempty"`
@@ -85,6 +144,31 @@ type PoolerSpec struct {
 	// Template for the Service to be created
 	// +optional
 	ServiceTemplate *ServiceTemplateSpec `json:"serviceTemplate,omitempty"`
+
+	// The additional services exposing the pooler, managed by the operator
+	// together with the default one. They can be used, for example, to
+	// reach the pooler from outside the Kubernetes cluster through a
+	// `LoadBalancer` service
+	// +optional
+	AdditionalServices []PoolerAdditionalService `json:"additionalServices,omitempty"`
+}
+
+// PoolerAdditionalService is an additional service exposing the pooler
+type PoolerAdditionalService struct {
+	// UpdateStrategy describes how the service differences should be reconciled
+	// +kubebuilder:default:="patch"
+	// +optional
+	UpdateStrategy ServiceUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// The hostname to be published by ExternalDNS for this service,
+	// set in the `external-dns.alpha.kubernetes.io/hostname` annotation
+	// +optional
+	DNSHostname string `json:"dnsHostname,omitempty"`
+
+	// ServiceTemplate is the template specification for the service.
+	// The name of the service is required, while the selector is managed
+	// by the operator
+	ServiceTemplate ServiceTemplateSpec `json:"serviceTemplate"`
 }
 
 // PoolerMonitoringConfiguration is the type containing all the monitoring
@@ -182,6 +266,165 @@ type PgBouncerSpec struct {
 	// +kubebuilder:default:=false
 	// +optional
 	Paused *bool `json:"paused,omitempty"`
+
+	// The image catalog providing the PgBouncer image, as an alternative
+	// to setting the image in the pod template
+	// +optional
+	ImageCatalogRef *PoolerImageCatalogRef `json:"imageCatalogRef,omitempty"`
+
+	// The pools of specific databases. Connections to the databases not
+	// listed here use the pool mode and the parameters of the Pooler
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []PgBouncerDatabase `json:"databases,omitempty"`
+
+	// The pool settings of specific users, taking precedence over the
+	// ones of the databases
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Users []PgBouncerUser `json:"users,omitempty"`
+}
+
+// PgBouncerDatabase defines the pool of a database in PgBouncer
+type PgBouncerDatabase struct {
+	// The name of the database requested by the clients
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9_.$-]*$`
+	Name string `json:"name"`
+
+	// The name of the database in PostgreSQL, when different from
+	// the one requested by the clients
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9_.$-]*$`
+	// +optional
+	DBName string `json:"dbname,omitempty"`
+
+	// The pool mode of the database, overriding the one of the Pooler
+	// +optional
+	PoolMode PgBouncerPoolMode `json:"poolMode,omitempty"`
+
+	// The maximum number of server connections for each user of the
+	// database, overriding the `default_pool_size` parameter
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PoolSize *int32 `json:"poolSize,omitempty"`
+
+	// The minimum number of server connections for each user of the
+	// database, overriding the `min_pool_size` parameter
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinPoolSize *int32 `json:"minPoolSize,omitempty"`
+
+	// The number of additional connections allowed for each user of the
+	// database, overriding the `reserve_pool_size` parameter
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ReservePoolSize *int32 `json:"reservePoolSize,omitempty"`
+
+	// The maximum number of server connections to the database,
+	// overriding the `max_db_connections` parameter
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDBConnections *int32 `json:"maxDBConnections,omitempty"`
+}
+
+// PgBouncerUser defines the pool settings of a user in PgBouncer
+type PgBouncerUser struct {
+	// The name of the user
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9_.$-]*$`
+	Name string `json:"name"`
+
+	// The pool mode of the user, overriding the one of the databases
+	// +optional
+	PoolMode PgBouncerPoolMode `json:"poolMode,omitempty"`
+
+	// The maximum number of server connections of the user across
+	// all the databases, overriding the `max_user_connections` parameter
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUserConnections *int32 `json:"maxUserConnections,omitempty"`
+}
+
+// PgCatSpec defines how to configure PgCat
+type PgCatSpec struct {
+	// The pool mode. Default: `transaction`.
+	// +kubebuilder:default:=transaction
+	// +optional
+	PoolMode PgBouncerPoolMode `json:"poolMode,omitempty"`
+
+	// The secret, of type `kubernetes.io/basic-auth`, containing the
+	// credentials of the user running the authentication query.
+	// PgCat cannot use the certificate-based integration of PgBouncer,
+	// so this is always required
+	AuthQuerySecret LocalObjectReference `json:"authQuerySecret"`
+
+	// The query that will be used to download the hash of the password
+	// of a certain user. PgCat replaces `$1` with the user name.
+	// Default: "SELECT usename, passwd FROM pg_catalog.pg_shadow WHERE usename='$1'".
+	// +optional
+	AuthQuery string `json:"authQuery,omitempty"`
+
+	// The databases PgCat will create a pool for. PgCat doesn't
+	// support wildcard databases
+	// +kubebuilder:validation:MinItems=1
+	Databases []string `json:"databases"`
+
+	// The users allowed to connect through PgCat, whose passwords
+	// are retrieved via the authentication query
+	// +kubebuilder:validation:MinItems=1
+	Users []string `json:"users"`
+
+	// The maximum number of server connections per database/user pair.
+	// Default: 20.
+	// +kubebuilder:default:=20
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PoolSize int32 `json:"poolSize,omitempty"`
+
+	// The query routing configuration, letting PgCat send the queries
+	// to both the primary and the replicas of the Cluster
+	// +optional
+	QueryRouting *PgCatQueryRoutingSpec `json:"queryRouting,omitempty"`
+
+	// Additional parameters to be passed to the `general` section of
+	// the PgCat configuration - please check the CNPG documentation
+	// for a list of options you can configure
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// PgCatQueryRoutingSpec configures how PgCat routes the queries between
+// the primary and the replicas of the Cluster
+type PgCatQueryRoutingSpec struct {
+	// When set to `true`, PgCat parses the queries and sends the
+	// `SELECT` statements to the replicas and everything else to the
+	// primary
+	// +kubebuilder:default:=false
+	// +optional
+	ReadWriteSplitting bool `json:"readWriteSplitting,omitempty"`
+
+	// When set to `true`, the primary is considered for load balancing
+	// read queries together with the replicas
+	// +kubebuilder:default:=false
+	// +optional
+	PrimaryReadsEnabled bool `json:"primaryReadsEnabled,omitempty"`
+
+	// The role of the server receiving the queries when no
+	// routing decision can be taken. Default: `any`.
+	// +kubebuilder:default:=any
+	// +optional
+	DefaultRole PgCatDefaultRole `json:"defaultRole,omitempty"`
+}
+
+// PoolerImageCatalogRef defines the reference to a major version of
+// PgBouncer in an ImageCatalog
+type PoolerImageCatalogRef struct {
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'ImageCatalog' || self.kind == 'ClusterImageCatalog'",message="Only image catalogs are supported"
+	// +kubebuilder:validation:XValidation:rule="self.apiGroup == 'postgresql.cnpg.io'",message="Only image catalogs are supported"
+	corev1.TypedLocalObjectReference `json:",inline"`
+	// The major version of PgBouncer we want to use from the ImageCatalog
+	// +kubebuilder:validation:Minimum=1
+	Major int `json:"major"`
 }
 
 // PoolerStatus defines the observed state of Pooler
@@ -192,6 +435,59 @@ type PoolerStatus struct {
 	// The number of pods trying to be scheduled
 	// +optional
 	Instances int32 `json:"instances,omitempty"`
+	// The PgBouncer image found in the referenced image catalog
+	// +optional
+	Image string `json:"image,omitempty"`
+	// The effective settings of the pools declared in the PgBouncer
+	// configuration, for each database and database/user pair
+	// +optional
+	Pools []PgBouncerPoolStatus `json:"pools,omitempty"`
+	// The pods of the pooler that paused their connections
+	// ahead of a switchover of the cluster
+	// +optional
+	SwitchoverPausedInstances []string `json:"switchoverPausedInstances,omitempty"`
+	// The pods of the pooler that reloaded the TLS certificates
+	// rotated by the operator
+	// +optional
+	CertificateReloadedInstances []string `json:"certificateReloadedInstances,omitempty"`
+	// The saturation indicators of the pooler, aggregated across the
+	// pools of all its instances
+	// +optional
+	Stats *PoolerStats `json:"stats,omitempty"`
+}
+
+// PoolerStats contains the saturation indicators of a pooler, collected
+// from the PgBouncer `SHOW POOLS` command of its instances
+type PoolerStats struct {
+	// The number of instances whose statistics have been collected
+	Instances int32 `json:"instances"`
+	// The client connections linked to a server connection
+	ClientsActive int32 `json:"clientsActive"`
+	// The client connections waiting for a server connection
+	ClientsWaiting int32 `json:"clientsWaiting"`
+	// The server connections linked to a client connection
+	ServersActive int32 `json:"serversActive"`
+	// The server connections ready to be used by a client connection
+	ServersIdle int32 `json:"serversIdle"`
+	// How long, in seconds, the oldest client connection in the queue of
+	// any pool has been waiting for a server connection
+	MaxWaitSeconds int32 `json:"maxWaitSeconds"`
+}
+
+// PgBouncerPoolStatus contains the effective settings of a declared pool
+type PgBouncerPoolStatus struct {
+	// The name of the database
+	Database string `json:"database"`
+
+	// The name of the user, when the pool has user specific settings
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// The effective pool mode
+	PoolMode PgBouncerPoolMode `json:"poolMode"`
+
+	// The effective maximum number of server connections of the pool
+	PoolSize int32 `json:"poolSize"`
 }
 
 // PoolerSecrets contains the versions of all the secrets used
@@ -238,6 +534,7 @@ type SecretVersion struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Backend",type="string",JSONPath=".spec.backend"
 // +kubebuilder:subresource:scale:specpath=.spec.instances,statuspath=.status.instances
 
 // Pooler is the Schema for the poolers API
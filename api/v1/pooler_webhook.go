@@ -21,15 +21,20 @@ import (
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+// pgBouncerAdminDatabase is the name of the PgBouncer admin console
+const pgBouncerAdminDatabase = "pgbouncer"
+
 var (
 	// poolerLog is for logging in this package.
 	poolerLog = log.WithName("pooler-resource").WithValues("version", "v1")
@@ -88,6 +93,25 @@ var (
 		"track_extra_parameters",
 		"verbose",
 	})
+
+	// AllowedPgCatGenericConfigurationParameters is the list of allowed parameters
+	// for the general section of the PgCat configuration
+	AllowedPgCatGenericConfigurationParameters = stringset.From([]string{
+		"ban_time",
+		"connect_timeout",
+		"healthcheck_delay",
+		"healthcheck_timeout",
+		"idle_client_in_transaction_timeout",
+		"idle_timeout",
+		"log_client_connections",
+		"log_client_disconnections",
+		"server_lifetime",
+		"shutdown_timeout",
+		"tcp_keepalives_count",
+		"tcp_keepalives_idle",
+		"tcp_keepalives_interval",
+		"worker_threads",
+	})
 )
 
 // SetupWebhookWithManager setup the webhook inside the controller manager
@@ -112,6 +136,7 @@ func (r *Pooler) ValidateCreate() (warns admission.Warnings, err error) {
 		warns = append(warns, fmt.Sprintf("The operator won't handle the Pooler %q integration with the Cluster %q (%q). "+
 			"Manually configure it as described in the docs.", r.Name, r.Spec.Cluster.Name, r.Namespace))
 	}
+	warns = append(warns, r.getDeploymentStrategyWarnings()...)
 
 	allErrs := r.Validate()
 
@@ -136,10 +161,11 @@ func (r *Pooler) ValidateUpdate(old runtime.Object) (warns admission.Warnings, e
 		warns = append(warns, fmt.Sprintf("The operator won't handle the Pooler %q integration with the Cluster %q (%q). "+
 			"Manually configure it as described in the docs.", r.Name, r.Spec.Cluster.Name, r.Namespace))
 	}
+	warns = append(warns, r.getDeploymentStrategyWarnings()...)
 
 	allErrs := r.Validate()
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warns, nil
 	}
 
 	return warns, apierrors.NewInvalid(
@@ -154,6 +180,10 @@ func (r *Pooler) ValidateDelete() (admission.Warnings, error) {
 }
 
 func (r *Pooler) validatePgBouncer() field.ErrorList {
+	if r.GetBackend() != PoolerBackendPgBouncer {
+		return nil
+	}
+
 	var result field.ErrorList
 	switch {
 	case r.Spec.PgBouncer == nil:
@@ -179,6 +209,77 @@ func (r *Pooler) validatePgBouncer() field.ErrorList {
 		result = append(result, r.validatePgbouncerGenericParameters()...)
 	}
 
+	if r.Spec.PgBouncer != nil {
+		for idx, database := range r.Spec.PgBouncer.Databases {
+			if database.Name == pgBouncerAdminDatabase {
+				result = append(result,
+					field.Invalid(
+						field.NewPath("spec", "pgbouncer", "databases").Index(idx).Child("name"),
+						database.Name, "the PgBouncer admin console database cannot be pooled"))
+			}
+		}
+	}
+
+	return result
+}
+
+func (r *Pooler) validatePgCat() field.ErrorList {
+	var result field.ErrorList
+
+	if r.GetBackend() != PoolerBackendPgCat {
+		if r.Spec.PgCat != nil {
+			result = append(result,
+				field.Invalid(
+					field.NewPath("spec", "pgcat"),
+					"", "the pgcat configuration requires the pgcat backend"))
+		}
+		return result
+	}
+
+	if r.Spec.PgBouncer != nil {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "pgbouncer"),
+				"", "the pgbouncer configuration cannot be used with the pgcat backend"))
+	}
+
+	if r.Spec.PgCat == nil {
+		return append(result,
+			field.Invalid(
+				field.NewPath("spec", "pgcat"),
+				"", "required pgcat configuration"))
+	}
+
+	if r.Spec.PgCat.AuthQuerySecret.Name == "" {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "pgcat", "authQuerySecret", "name"),
+				"", "must specify the auth query secret"))
+	}
+
+	if len(r.Spec.PgCat.Databases) == 0 {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "pgcat", "databases"),
+				"", "must specify at least one database"))
+	}
+
+	if len(r.Spec.PgCat.Users) == 0 {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "pgcat", "users"),
+				"", "must specify at least one user"))
+	}
+
+	for param := range r.Spec.PgCat.Parameters {
+		if !AllowedPgCatGenericConfigurationParameters.Has(param) {
+			result = append(result,
+				field.Invalid(
+					field.NewPath("spec", "pgcat", "parameters"),
+					param, "Invalid or reserved parameter"))
+		}
+	}
+
 	return result
 }
 
@@ -196,6 +297,12 @@ func (r *Pooler) validateCluster() field.ErrorList {
 				field.NewPath("metadata", "name"),
 				r.Name, "the pooler resource cannot have the same name of a cluster"))
 	}
+	if r.IsCrossNamespace() && r.GetBackend() != PoolerBackendPgBouncer {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "clusterNamespace"),
+				r.Spec.ClusterNamespace, "a cluster of another namespace can be referenced only by PgBouncer poolers"))
+	}
 	return result
 }
 
@@ -203,10 +310,88 @@ func (r *Pooler) validateCluster() field.ErrorList {
 // a list of errors
 func (r *Pooler) Validate() (allErrs field.ErrorList) {
 	allErrs = append(allErrs, r.validatePgBouncer()...)
+	allErrs = append(allErrs, r.validatePgCat()...)
 	allErrs = append(allErrs, r.validateCluster()...)
+	allErrs = append(allErrs, r.validateDeploymentStrategy()...)
+	allErrs = append(allErrs, r.validateAdditionalServices()...)
 	return allErrs
 }
 
+// validateAdditionalServices ensures that the additional services of the
+// pooler have a unique name, not clashing with the default service
+func (r *Pooler) validateAdditionalServices() field.ErrorList {
+	var errs field.ErrorList
+
+	basePath := field.NewPath("spec", "additionalServices")
+	names := stringset.New()
+	for idx := range r.Spec.AdditionalServices {
+		additionalService := &r.Spec.AdditionalServices[idx]
+		name := additionalService.ServiceTemplate.ObjectMeta.Name
+		path := basePath.Index(idx)
+
+		if name == r.Name {
+			errs = append(errs, field.Invalid(
+				path,
+				name,
+				fmt.Sprintf("the service name: '%s' is reserved for the default service of the pooler", name),
+			))
+		}
+
+		if name != "" && names.Has(name) {
+			errs = append(errs, field.Duplicate(path.Child("serviceTemplate", "metadata", "name"), name))
+		}
+		names.Put(name)
+
+		errs = append(errs, validateServiceTemplate(path, true, additionalService.ServiceTemplate)...)
+	}
+
+	return errs
+}
+
+// validateDeploymentStrategy ensures that a rolling update of the pooler
+// can't make all its pods unavailable at the same time
+func (r *Pooler) validateDeploymentStrategy() field.ErrorList {
+	strategy := r.Spec.DeploymentStrategy
+	if strategy == nil || strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxUnavailable == nil {
+		return nil
+	}
+
+	instances := 1
+	if r.Spec.Instances != nil {
+		instances = int(*r.Spec.Instances)
+	}
+
+	maxUnavailablePath := field.NewPath("spec", "deploymentStrategy", "rollingUpdate", "maxUnavailable")
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(strategy.RollingUpdate.MaxUnavailable, instances, false)
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(maxUnavailablePath, strategy.RollingUpdate.MaxUnavailable.String(), err.Error()),
+		}
+	}
+
+	if instances > 0 && maxUnavailable >= instances {
+		return field.ErrorList{
+			field.Invalid(maxUnavailablePath, strategy.RollingUpdate.MaxUnavailable.String(),
+				"a rolling update must keep at least one pooler instance available"),
+		}
+	}
+
+	return nil
+}
+
+// getDeploymentStrategyWarnings warns about the deployment strategies
+// stopping all the pods of the pooler during the rollouts
+func (r *Pooler) getDeploymentStrategyWarnings() admission.Warnings {
+	if r.Spec.DeploymentStrategy == nil || r.Spec.DeploymentStrategy.Type != appsv1.RecreateDeploymentStrategyType {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("The %q deployment strategy of the Pooler %q stops all its instances before "+
+			"starting the new ones, causing a downtime at every rollout", appsv1.RecreateDeploymentStrategyType, r.Name),
+	}
+}
+
 // validatePgbouncerGenericParameters validates pgbouncer parameters
 func (r *Pooler) validatePgbouncerGenericParameters() field.ErrorList {
 	var result field.ErrorList
@@ -17,7 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -107,6 +111,20 @@ var _ = Describe("Pooler validation", func() {
 		Expect(pooler.validateCluster()).To(BeEmpty())
 	})
 
+	It("allows only PgBouncer poolers to reference a cluster of another namespace", func() {
+		pooler := Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw", Namespace: "apps"},
+			Spec: PoolerSpec{
+				Cluster:          LocalObjectReference{Name: "cluster-example"},
+				ClusterNamespace: "databases",
+			},
+		}
+		Expect(pooler.validateCluster()).To(BeEmpty())
+
+		pooler.Spec.Backend = PoolerBackendPgCat
+		Expect(pooler.validateCluster()).NotTo(BeEmpty())
+	})
+
 	It("does complain when given a fixed parameter", func() {
 		pooler := Pooler{
 			Spec: PoolerSpec{
@@ -128,4 +146,165 @@ var _ = Describe("Pooler validation", func() {
 		}
 		Expect(pooler.validatePgbouncerGenericParameters()).To(BeEmpty())
 	})
+
+	Context("with the pgcat backend", func() {
+		var pooler Pooler
+
+		BeforeEach(func() {
+			pooler = Pooler{
+				Spec: PoolerSpec{
+					Backend: PoolerBackendPgCat,
+					PgCat: &PgCatSpec{
+						AuthQuerySecret: LocalObjectReference{Name: "pgcat-auth"},
+						Databases:       []string{"app"},
+						Users:           []string{"app"},
+					},
+				},
+			}
+		})
+
+		It("accepts a complete configuration", func() {
+			Expect(pooler.validatePgCat()).To(BeEmpty())
+			Expect(pooler.validatePgBouncer()).To(BeEmpty())
+		})
+
+		It("requires the pgcat section", func() {
+			pooler.Spec.PgCat = nil
+			Expect(pooler.validatePgCat()).NotTo(BeEmpty())
+		})
+
+		It("doesn't allow the pgbouncer section", func() {
+			pooler.Spec.PgBouncer = &PgBouncerSpec{}
+			Expect(pooler.validatePgCat()).NotTo(BeEmpty())
+		})
+
+		It("requires at least a database and a user", func() {
+			pooler.Spec.PgCat.Databases = nil
+			pooler.Spec.PgCat.Users = nil
+			Expect(pooler.validatePgCat()).To(HaveLen(2))
+		})
+
+		It("complains when given a reserved parameter", func() {
+			pooler.Spec.PgCat.Parameters = map[string]string{"port": "6432"}
+			Expect(pooler.validatePgCat()).NotTo(BeEmpty())
+		})
+
+		It("is never automatically integrated", func() {
+			Expect(pooler.IsAutomatedIntegration()).To(BeFalse())
+			Expect(pooler.GetAuthQuerySecretName()).To(Equal("pgcat-auth"))
+		})
+	})
+
+	It("doesn't allow the pgcat section with the pgbouncer backend", func() {
+		pooler := Pooler{
+			Spec: PoolerSpec{
+				PgBouncer: &PgBouncerSpec{},
+				PgCat:     &PgCatSpec{},
+			},
+		}
+		Expect(pooler.validatePgCat()).NotTo(BeEmpty())
+	})
+
+	It("doesn't allow pooling the PgBouncer admin console", func() {
+		pooler := Pooler{
+			Spec: PoolerSpec{
+				PgBouncer: &PgBouncerSpec{
+					Databases: []PgBouncerDatabase{{Name: "pgbouncer"}},
+				},
+			},
+		}
+		Expect(pooler.validatePgBouncer()).NotTo(BeEmpty())
+	})
+
+	Context("deployment strategy", func() {
+		rollingUpdate := func(maxUnavailable intstr.IntOrString) *appsv1.DeploymentStrategy {
+			return &appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       ptr.To(intstr.FromInt32(1)),
+				},
+			}
+		}
+
+		It("accepts a rolling update keeping some instances available", func() {
+			pooler := Pooler{Spec: PoolerSpec{Instances: ptr.To(int32(3))}}
+			pooler.Spec.DeploymentStrategy = rollingUpdate(intstr.FromInt32(2))
+			Expect(pooler.validateDeploymentStrategy()).To(BeEmpty())
+
+			pooler.Spec.DeploymentStrategy = rollingUpdate(intstr.FromString("50%"))
+			Expect(pooler.validateDeploymentStrategy()).To(BeEmpty())
+		})
+
+		It("rejects a rolling update making all the instances unavailable", func() {
+			pooler := Pooler{Spec: PoolerSpec{Instances: ptr.To(int32(2))}}
+			pooler.Spec.DeploymentStrategy = rollingUpdate(intstr.FromInt32(2))
+			Expect(pooler.validateDeploymentStrategy()).To(HaveLen(1))
+
+			pooler.Spec.DeploymentStrategy = rollingUpdate(intstr.FromString("100%"))
+			Expect(pooler.validateDeploymentStrategy()).To(HaveLen(1))
+
+			pooler.Spec.DeploymentStrategy = rollingUpdate(intstr.FromString("half"))
+			Expect(pooler.validateDeploymentStrategy()).To(HaveLen(1))
+		})
+
+		It("warns about the recreate strategy", func() {
+			pooler := Pooler{Spec: PoolerSpec{
+				DeploymentStrategy: &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			}}
+			Expect(pooler.getDeploymentStrategyWarnings()).To(HaveLen(1))
+		})
+	})
+
+	Context("additional services", func() {
+		additionalService := func(name string) PoolerAdditionalService {
+			return PoolerAdditionalService{
+				ServiceTemplate: ServiceTemplateSpec{
+					ObjectMeta: Metadata{Name: name},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				},
+			}
+		}
+
+		It("accepts services with distinct names", func() {
+			pooler := Pooler{
+				ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw"},
+				Spec: PoolerSpec{
+					AdditionalServices: []PoolerAdditionalService{
+						additionalService("pooler-rw-internal"),
+						additionalService("pooler-rw-external"),
+					},
+				},
+			}
+			Expect(pooler.validateAdditionalServices()).To(BeEmpty())
+		})
+
+		It("rejects the services without a name or with the name of the default one", func() {
+			pooler := Pooler{
+				ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw"},
+				Spec: PoolerSpec{
+					AdditionalServices: []PoolerAdditionalService{
+						additionalService(""),
+						additionalService("pooler-rw"),
+					},
+				},
+			}
+			Expect(pooler.validateAdditionalServices()).To(HaveLen(2))
+		})
+
+		It("rejects duplicate names and custom selectors", func() {
+			service := additionalService("pooler-rw-external")
+			service.ServiceTemplate.Spec.Selector = map[string]string{"app": "pgbouncer"}
+			pooler := Pooler{
+				ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw"},
+				Spec: PoolerSpec{
+					AdditionalServices: []PoolerAdditionalService{
+						additionalService("pooler-rw-external"),
+						service,
+					},
+				},
+			}
+			Expect(pooler.validateAdditionalServices()).To(HaveLen(2))
+		})
+	})
 })
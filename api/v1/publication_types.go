@@ -87,6 +87,7 @@ type PublicationTargetObject struct {
 	// Marks the publication as one that replicates changes for all tables
 	// in the specified list of schemas, including tables created in the
 	// future. Corresponding to `FOR TABLES IN SCHEMA` in PostgreSQL.
+	// Requires PostgreSQL 15 or later
 	// +optional
 	TablesInSchema string `json:"tablesInSchema,omitempty"`
 
@@ -109,9 +110,16 @@ type PublicationTargetTable struct {
 	// +optional
 	Schema string `json:"schema,omitempty"`
 
-	// The columns to publish
+	// The columns to publish. Requires PostgreSQL 15 or later
 	// +optional
 	Columns []string `json:"columns,omitempty"`
+
+	// The row filter, a boolean SQL expression selecting the rows
+	// to publish. Corresponding to the `WHERE` clause of `FOR TABLE`
+	// in PostgreSQL. Requires PostgreSQL 15 or later
+	// +kubebuilder:validation:XValidation:rule="!self.contains(';')",message="the row filter cannot contain semicolons"
+	// +optional
+	Where string `json:"where,omitempty"`
 }
 
 // PublicationStatus defines the observed state of Publication
@@ -0,0 +1,186 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// publicationLog is for logging in this package.
+var publicationLog = log.WithName("publication-resource").WithValues("version", "v1")
+
+// SetupWebhookWithManager setup the webhook inside the controller manager
+func (r *Publication) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&publicationValidator{client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:webhookVersions={v1},admissionReviewVersions={v1},verbs=create;update,path=/validate-postgresql-cnpg-io-v1-publication,mutating=false,failurePolicy=fail,groups=postgresql.cnpg.io,resources=publications,versions=v1,name=vpublication.cnpg.io,sideEffects=None
+
+// publicationValidator validates the publications, checking the
+// features they use against the PostgreSQL version of their cluster
+type publicationValidator struct {
+	client client.Reader
+}
+
+var _ webhook.CustomValidator = &publicationValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *publicationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	publication, ok := obj.(*Publication)
+	if !ok {
+		return nil, fmt.Errorf("expected a Publication but got a %T", obj)
+	}
+
+	publicationLog.Info("validate create", "name", publication.Name, "namespace", publication.Namespace)
+	return publication.toAdmissionResult(v.validate(ctx, publication))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *publicationValidator) ValidateUpdate(
+	ctx context.Context,
+	_ runtime.Object,
+	newObj runtime.Object,
+) (admission.Warnings, error) {
+	publication, ok := newObj.(*Publication)
+	if !ok {
+		return nil, fmt.Errorf("expected a Publication but got a %T", newObj)
+	}
+
+	publicationLog.Info("validate update", "name", publication.Name, "namespace", publication.Namespace)
+	return publication.toAdmissionResult(v.validate(ctx, publication))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *publicationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *Publication) toAdmissionResult(
+	warnings admission.Warnings,
+	allErrs field.ErrorList,
+) (admission.Warnings, error) {
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return nil, apierrors.NewInvalid(
+		schema.GroupKind{Group: "postgresql.cnpg.io", Kind: PublicationKind},
+		r.Name, allErrs)
+}
+
+func (v *publicationValidator) validate(
+	ctx context.Context,
+	r *Publication,
+) (admission.Warnings, field.ErrorList) {
+	if len(r.getPostgres15Features()) == 0 {
+		return nil, nil
+	}
+
+	var cluster Cluster
+	if err := v.client.Get(
+		ctx,
+		client.ObjectKey{Namespace: r.Namespace, Name: r.Spec.ClusterRef.Name},
+		&cluster,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Warnings{
+				fmt.Sprintf("Cluster %q not found, the PostgreSQL version cannot be checked", r.Spec.ClusterRef.Name),
+			}, nil
+		}
+		return nil, field.ErrorList{field.InternalError(field.NewPath("spec", "cluster"), err)}
+	}
+
+	pgVersion, err := cluster.GetPostgresqlVersion()
+	if err != nil {
+		return admission.Warnings{
+			fmt.Sprintf("Cannot detect the PostgreSQL version of cluster %q: %v", cluster.Name, err),
+		}, nil
+	}
+
+	return nil, r.validatePostgresqlMajorVersion(pgVersion.Major())
+}
+
+// ValidateWithCluster checks that the features used by the publication are
+// available in the PostgreSQL version of the passed cluster. It allows the
+// publications to be validated even when the admission webhooks are disabled
+func (r *Publication) ValidateWithCluster(cluster *Cluster) error {
+	pgVersion, err := cluster.GetPostgresqlVersion()
+	if err != nil {
+		// The version cannot be checked, as the webhook does
+		return nil
+	}
+
+	_, err = r.toAdmissionResult(nil, r.validatePostgresqlMajorVersion(pgVersion.Major()))
+	return err
+}
+
+// validatePostgresqlMajorVersion checks that the features used by the
+// publication are available in the passed PostgreSQL major version
+func (r *Publication) validatePostgresqlMajorVersion(major uint64) field.ErrorList {
+	if major >= 15 {
+		return nil
+	}
+
+	features := r.getPostgres15Features()
+	result := make(field.ErrorList, 0, len(features))
+	for _, path := range features {
+		result = append(result, field.Forbidden(
+			path,
+			fmt.Sprintf("requires PostgreSQL 15 or later, while the cluster is running PostgreSQL %d", major)))
+	}
+
+	return result
+}
+
+// getPostgres15Features returns the paths of the fields using
+// features introduced in PostgreSQL 15: row filters, column lists,
+// and schemas
+func (r *Publication) getPostgres15Features() []*field.Path {
+	var result []*field.Path
+
+	objectsPath := field.NewPath("spec", "target", "objects")
+	for idx, object := range r.Spec.Target.Objects {
+		if len(object.TablesInSchema) > 0 {
+			result = append(result, objectsPath.Index(idx).Child("tablesInSchema"))
+		}
+		if object.Table == nil {
+			continue
+		}
+		if len(object.Table.Columns) > 0 {
+			result = append(result, objectsPath.Index(idx).Child("table", "columns"))
+		}
+		if len(object.Table.Where) > 0 {
+			result = append(result, objectsPath.Index(idx).Child("table", "where"))
+		}
+	}
+
+	return result
+}
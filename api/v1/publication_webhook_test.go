@@ -0,0 +1,104 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Publication validation", func() {
+	var publication *Publication
+
+	BeforeEach(func() {
+		publication = &Publication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pub",
+				Namespace: "default",
+			},
+			Spec: PublicationSpec{
+				ClusterRef: corev1.LocalObjectReference{Name: "cluster-example"},
+				Name:       "pub",
+				DBName:     "app",
+				Target: PublicationTarget{
+					Objects: []PublicationTargetObject{
+						{TablesInSchema: "sales"},
+						{Table: &PublicationTargetTable{Name: "products"}},
+						{Table: &PublicationTargetTable{
+							Name:    "customers",
+							Columns: []string{"id", "region"},
+							Where:   "region = 'EU'",
+						}},
+					},
+				},
+			},
+		}
+	})
+
+	It("detects the features requiring PostgreSQL 15", func() {
+		paths := publication.getPostgres15Features()
+		Expect(paths).To(HaveLen(3))
+		Expect(paths[0].String()).To(Equal("spec.target.objects[0].tablesInSchema"))
+		Expect(paths[1].String()).To(Equal("spec.target.objects[2].table.columns"))
+		Expect(paths[2].String()).To(Equal("spec.target.objects[2].table.where"))
+	})
+
+	It("accepts the features requiring PostgreSQL 15 on newer versions", func() {
+		Expect(publication.validatePostgresqlMajorVersion(15)).To(BeEmpty())
+		Expect(publication.validatePostgresqlMajorVersion(17)).To(BeEmpty())
+	})
+
+	It("refuses the features requiring PostgreSQL 15 on older versions", func() {
+		Expect(publication.validatePostgresqlMajorVersion(14)).To(HaveLen(3))
+	})
+
+	It("checks the version of the cluster of the publication", func(ctx SpecContext) {
+		scheme := runtime.NewScheme()
+		Expect(AddToScheme(scheme)).To(Succeed())
+		validator := &publicationValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster-example",
+					Namespace: "default",
+				},
+				Spec: ClusterSpec{
+					ImageName: "ghcr.io/cloudnative-pg/postgresql:14.13",
+				},
+			}).Build(),
+		}
+
+		_, err := validator.ValidateCreate(ctx, publication)
+		Expect(err).To(MatchError(ContainSubstring("requires PostgreSQL 15 or later")))
+	})
+
+	It("warns when the cluster of the publication doesn't exist", func(ctx SpecContext) {
+		scheme := runtime.NewScheme()
+		Expect(AddToScheme(scheme)).To(Succeed())
+		validator := &publicationValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, publication)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+})
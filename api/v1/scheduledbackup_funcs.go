@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -75,8 +77,99 @@ func (scheduledBackup *ScheduledBackup) CreateBackup(name string) *Backup {
 			Online:              scheduledBackup.Spec.Online,
 			OnlineConfiguration: scheduledBackup.Spec.OnlineConfiguration,
 			PluginConfiguration: scheduledBackup.Spec.PluginConfiguration,
+			Level:               scheduledBackup.Spec.Level,
 		},
 	}
 	utils.InheritAnnotations(&backup.ObjectMeta, scheduledBackup.Annotations, nil, configuration.Current)
+	utils.InheritLabels(&backup.ObjectMeta, scheduledBackup.Labels, nil, configuration.Current)
 	return &backup
 }
+
+// GetPostponedStartTime gets the time when a backup due at the passed time
+// can start, which is the end of the windows postponing the start of the
+// backups active at that time, or nil if no such window is active.
+// Overlapping and adjacent windows are considered as a single one
+func (scheduledBackup *ScheduledBackup) GetPostponedStartTime(t time.Time) *time.Time {
+	var result *time.Time
+	current := t.UTC()
+
+	// Every window can postpone the start at most once per day of the week,
+	// this prevents looping forever when the windows cover the whole week
+	for range 7 * len(scheduledBackup.Spec.PostponeStartWindows) {
+		extended := false
+		for _, window := range scheduledBackup.Spec.PostponeStartWindows {
+			if end, active := window.getEnd(current); active {
+				current = end
+				result = &current
+				extended = true
+			}
+		}
+		if !extended {
+			break
+		}
+	}
+
+	return result
+}
+
+// getEnd gets the end of the occurrence of this window containing the
+// passed time. The second return value is false if the window is not
+// active at that time
+func (window BackupPostponeStartWindow) getEnd(t time.Time) (time.Time, bool) {
+	start, err := parseWindowTime(window.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := parseWindowTime(window.End)
+	if err != nil || start == end {
+		return time.Time{}, false
+	}
+
+	t = t.UTC()
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	// The window containing the passed time may have started yesterday
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+		if !window.isActiveOn(day.Weekday()) {
+			continue
+		}
+
+		windowStart := day.Add(start)
+		windowEnd := day.Add(end)
+		if end < start {
+			windowEnd = windowEnd.AddDate(0, 0, 1)
+		}
+
+		if !t.Before(windowStart) && t.Before(windowEnd) {
+			return windowEnd, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// isActiveOn checks if this window starts on the passed day of the week
+func (window BackupPostponeStartWindow) isActiveOn(weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+
+	for _, day := range window.Days {
+		if string(day) == weekday.String()[:3] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseWindowTime parses a time in the `HH:MM` format, returning
+// the time elapsed since midnight
+func parseWindowTime(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
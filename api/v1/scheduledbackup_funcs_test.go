@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	"k8s.io/utils/ptr"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -53,6 +55,19 @@ var _ = Describe("Scheduled backup", func() {
 		Expect(backup.Spec.Target).To(BeEmpty())
 	})
 
+	It("properly creates a backup with the inherited labels", func() {
+		scheduledBackup.Labels = map[string]string{
+			"team":  "payments",
+			"other": "value",
+		}
+		configuration.Current.InheritedLabels = []string{"team"}
+
+		backup := scheduledBackup.CreateBackup("test")
+		Expect(backup).ToNot(BeNil())
+		Expect(backup.Labels).To(HaveKeyWithValue("team", "payments"))
+		Expect(backup.Labels).ToNot(HaveKey("other"))
+	})
+
 	It("properly creates a backup with standby target", func() {
 		scheduledBackup.Spec.Target = BackupTargetStandby
 		backup := scheduledBackup.CreateBackup("test")
@@ -69,6 +84,14 @@ var _ = Describe("Scheduled backup", func() {
 		Expect(backup.Spec.Target).To(BeEquivalentTo(BackupTargetPrimary))
 	})
 
+	It("properly creates a backup with the requested level", func() {
+		scheduledBackup.Spec.Method = BackupMethodPgBackRest
+		scheduledBackup.Spec.Level = BackupLevelIncremental
+		backup := scheduledBackup.CreateBackup("test")
+		Expect(backup).ToNot(BeNil())
+		Expect(backup.Spec.Level).To(BeEquivalentTo(BackupLevelIncremental))
+	})
+
 	It("complains if online is set on a barman backup", func() {
 		scheduledBackup := &ScheduledBackup{
 			Spec: ScheduledBackupSpec{
@@ -97,3 +120,53 @@ var _ = Describe("Scheduled backup", func() {
 		Expect(result[0].Field).To(Equal("spec.onlineConfiguration"))
 	})
 })
+
+var _ = Describe("Scheduled backup postpone start windows", func() {
+	// 2024-01-03 is a Wednesday
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 3, hour, minute, 0, 0, time.UTC)
+	}
+
+	It("returns nil when there are no windows", func() {
+		scheduledBackup := &ScheduledBackup{}
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(10, 0))).To(BeNil())
+	})
+
+	It("gets the end of the active window", func() {
+		scheduledBackup := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				PostponeStartWindows: []BackupPostponeStartWindow{
+					{Start: "09:00", End: "18:00", Days: []BackupWeekday{"Mon", "Tue", "Wed", "Thu", "Fri"}},
+				},
+			},
+		}
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(8, 59))).To(BeNil())
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(9, 0))).To(Equal(ptr.To(wednesday(18, 0))))
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(18, 0))).To(BeNil())
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(10, 0).AddDate(0, 0, 3))).To(BeNil())
+	})
+
+	It("handles windows spanning midnight", func() {
+		scheduledBackup := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				PostponeStartWindows: []BackupPostponeStartWindow{
+					{Start: "22:00", End: "02:00", Days: []BackupWeekday{"Tue"}},
+				},
+			},
+		}
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(1, 0))).To(Equal(ptr.To(wednesday(2, 0))))
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(23, 0))).To(BeNil())
+	})
+
+	It("merges adjacent windows", func() {
+		scheduledBackup := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				PostponeStartWindows: []BackupPostponeStartWindow{
+					{Start: "12:00", End: "14:00"},
+					{Start: "09:00", End: "12:00"},
+				},
+			},
+		}
+		Expect(scheduledBackup.GetPostponedStartTime(wednesday(10, 0))).To(Equal(ptr.To(wednesday(14, 0))))
+	})
+})
@@ -49,18 +49,18 @@ type ScheduledBackupSpec struct {
 
 	// The policy to decide which instance should perform this backup. If empty,
 	// it defaults to `cluster.spec.backup.target`.
-	// Available options are empty string, `primary` and `prefer-standby`.
-	// `primary` to have backups run always on primary instances,
+	// Available options are empty string, `primary`, `prefer-standby` and
+	// `standby`. `primary` to have backups run always on primary instances,
 	// `prefer-standby` to have backups run preferably on the most updated
-	// standby, if available.
-	// +kubebuilder:validation:Enum=primary;prefer-standby
+	// standby, if available, `standby` to have backups run only on a standby.
+	// +kubebuilder:validation:Enum=primary;prefer-standby;standby
 	// +optional
 	Target BackupTarget `json:"target,omitempty"`
 
 	// The backup method to be used, possible options are `barmanObjectStore`,
-	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
+	// `volumeSnapshot`, `pgBackRest` or `plugin`. Defaults to: `barmanObjectStore`.
 	// +optional
-	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin
+	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;pgBackRest;plugin
 	// +kubebuilder:default:=barmanObjectStore
 	Method BackupMethod `json:"method,omitempty"`
 
@@ -68,6 +68,14 @@ type ScheduledBackupSpec struct {
 	// +optional
 	PluginConfiguration *BackupPluginConfiguration `json:"pluginConfiguration,omitempty"`
 
+	// The level of the backups, possible options are `full`, `incremental`
+	// and `differential`. Incremental backups are only supported by the
+	// `pgBackRest` and `volumeSnapshot` methods, differential backups only
+	// by the `pgBackRest` method.
+	// +optional
+	// +kubebuilder:validation:Enum=full;incremental;differential
+	Level BackupLevel `json:"level,omitempty"`
+
 	// Whether the default type of backup with volume snapshots is
 	// online/hot (`true`, default) or offline/cold (`false`)
 	// Overrides the default setting specified in the cluster field '.spec.backup.volumeSnapshot.online'
@@ -78,6 +86,35 @@ type ScheduledBackupSpec struct {
 	// Overrides the default settings specified in the cluster '.backup.volumeSnapshot.onlineConfiguration' stanza
 	// +optional
 	OnlineConfiguration *OnlineConfiguration `json:"onlineConfiguration,omitempty"`
+
+	// The time windows when no backup can be started, i.e. during business
+	// hours. The start of a backup due during one of these windows is
+	// postponed to the end of the window. A backup which is already
+	// running when a window starts is not paused
+	// +optional
+	PostponeStartWindows []BackupPostponeStartWindow `json:"postponeStartWindows,omitempty"`
+}
+
+// BackupWeekday is a day of the week when a window starts
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type BackupWeekday string
+
+// BackupPostponeStartWindow is a daily time window when no backup can be started.
+// Times are expressed in UTC
+type BackupPostponeStartWindow struct {
+	// The days of the week when the window starts. When empty, the window
+	// is active every day
+	// +optional
+	Days []BackupWeekday `json:"days,omitempty"`
+
+	// The time when the window starts, in the `HH:MM` format
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// The time when the window ends, in the `HH:MM` format. When it is
+	// earlier than the start time, the window ends on the following day
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
 }
 
 // ScheduledBackupStatus defines the observed state of ScheduledBackup
@@ -110,7 +110,8 @@ func (r *ScheduledBackup) validate() (admission.Warnings, field.ErrorList) {
 		))
 	}
 
-	if r.Spec.Method == BackupMethodBarmanObjectStore && r.Spec.Online != nil {
+	if (r.Spec.Method == BackupMethodBarmanObjectStore || r.Spec.Method == BackupMethodPgBackRest) &&
+		r.Spec.Online != nil {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "online"),
 			r.Spec.Online,
@@ -118,7 +119,8 @@ func (r *ScheduledBackup) validate() (admission.Warnings, field.ErrorList) {
 		))
 	}
 
-	if r.Spec.Method == BackupMethodBarmanObjectStore && r.Spec.OnlineConfiguration != nil {
+	if (r.Spec.Method == BackupMethodBarmanObjectStore || r.Spec.Method == BackupMethodPgBackRest) &&
+		r.Spec.OnlineConfiguration != nil {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "onlineConfiguration"),
 			r.Spec.OnlineConfiguration,
@@ -126,5 +128,53 @@ func (r *ScheduledBackup) validate() (admission.Warnings, field.ErrorList) {
 		))
 	}
 
+	if !r.Spec.Method.SupportsLevel(r.Spec.Level) {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "level"),
+			r.Spec.Level,
+			"Incremental backups are supported only if the method is pgBackRest or volumeSnapshot, "+
+				"differential backups only if the method is pgBackRest",
+		))
+	}
+
+	if r.Spec.Method == BackupMethodPlugin && r.Spec.PluginConfiguration.IsEmpty() {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "pluginConfiguration"),
+			r.Spec.PluginConfiguration,
+			"cannot be empty when the backup method is plugin",
+		))
+	}
+
+	result = append(result, r.validatePostponeStartWindows()...)
+
 	return warnings, result
 }
+
+// validatePostponeStartWindows validates the time windows when no backup
+// can be started
+func (r *ScheduledBackup) validatePostponeStartWindows() field.ErrorList {
+	var result field.ErrorList
+
+	for idx, window := range r.Spec.PostponeStartWindows {
+		windowPath := field.NewPath("spec", "postponeStartWindows").Index(idx)
+
+		start, startErr := parseWindowTime(window.Start)
+		if startErr != nil {
+			result = append(result, field.Invalid(windowPath.Child("start"), window.Start, startErr.Error()))
+		}
+
+		end, endErr := parseWindowTime(window.End)
+		if endErr != nil {
+			result = append(result, field.Invalid(windowPath.Child("end"), window.End, endErr.Error()))
+		}
+
+		if startErr == nil && endErr == nil && start == end {
+			result = append(result, field.Invalid(
+				windowPath.Child("end"),
+				window.End,
+				"the end of a window must be different from its start"))
+		}
+	}
+
+	return result
+}
@@ -87,4 +87,65 @@ var _ = Describe("Validate schedule", func() {
 		Expect(result).To(HaveLen(1))
 		Expect(result[0].Field).To(Equal("spec.method"))
 	})
+	It("complains if a differential backup is scheduled with volume snapshots", func() {
+		schedule := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				Schedule: "0 0 0 * * *",
+				Method:   BackupMethodVolumeSnapshot,
+				Level:    BackupLevelDifferential,
+			},
+		}
+		utils.SetVolumeSnapshot(true)
+		warnings, result := schedule.validate()
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.level"))
+	})
+
+	It("complains if a plugin backup is scheduled without the plugin configuration", func() {
+		schedule := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				Schedule: "0 0 0 * * *",
+				Method:   BackupMethodPlugin,
+			},
+		}
+		warnings, result := schedule.validate()
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.pluginConfiguration"))
+
+		schedule.Spec.PluginConfiguration = &BackupPluginConfiguration{Name: "restic.example.com"}
+		_, result = schedule.validate()
+		Expect(result).To(BeEmpty())
+	})
+
+	It("accepts windows spanning midnight", func() {
+		schedule := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				Schedule: "0 0 0 * * *",
+				PostponeStartWindows: []BackupPostponeStartWindow{
+					{Start: "22:00", End: "02:00", Days: []BackupWeekday{"Fri"}},
+				},
+			},
+		}
+		warnings, result := schedule.validate()
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("complains if a window is empty", func() {
+		schedule := &ScheduledBackup{
+			Spec: ScheduledBackupSpec{
+				Schedule: "0 0 0 * * *",
+				PostponeStartWindows: []BackupPostponeStartWindow{
+					{Start: "09:00", End: "18:00"},
+					{Start: "09:00", End: "09:00"},
+				},
+			},
+		}
+		warnings, result := schedule.validate()
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postponeStartWindows[1].end"))
+	})
 })
@@ -0,0 +1,65 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// IsSuspended check if a scheduled dump has been suspended or not
+func (scheduledDump *ScheduledDump) IsSuspended() bool {
+	if scheduledDump.Spec.Suspend == nil {
+		return false
+	}
+
+	return *scheduledDump.Spec.Suspend
+}
+
+// IsImmediate check if a dump has to be issued immediately upon creation or not
+func (scheduledDump *ScheduledDump) IsImmediate() bool {
+	if scheduledDump.Spec.Immediate == nil {
+		return false
+	}
+
+	return *scheduledDump.Spec.Immediate
+}
+
+// GetSchedule get the cron-like schedule of this scheduled dump
+func (scheduledDump *ScheduledDump) GetSchedule() string {
+	return scheduledDump.Spec.Schedule
+}
+
+// IsDumpAll is true when the whole instance has to be dumped
+// with pg_dumpall instead of dumping the selected databases
+func (scheduledDump *ScheduledDump) IsDumpAll() bool {
+	return len(scheduledDump.Spec.Databases) == 0
+}
+
+// GetFormat gets the format of the dump archives, applying the default
+// if needed
+func (scheduledDump *ScheduledDump) GetFormat() DumpFormat {
+	switch {
+	case scheduledDump.Spec.Format != "":
+		return scheduledDump.Spec.Format
+	case scheduledDump.IsDumpAll():
+		return DumpFormatPlain
+	default:
+		return DumpFormatCustom
+	}
+}
+
+// IsRunning is true when the last dump has been started
+// and not terminated yet
+func (status *ScheduledDumpStatus) IsRunning() bool {
+	return status.LastDump != nil && status.LastDump.Phase == DumpPhaseRunning
+}
@@ -0,0 +1,188 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DumpFormat is the format of the archive produced by pg_dump
+// +enum
+type DumpFormat string
+
+const (
+	// DumpFormatCustom is the custom archive format of pg_dump, to be
+	// restored with pg_restore
+	DumpFormatCustom DumpFormat = "custom"
+
+	// DumpFormatDirectory is the directory archive format of pg_dump, the
+	// only one supporting parallel dumps
+	DumpFormatDirectory DumpFormat = "directory"
+
+	// DumpFormatTar is the tar archive format of pg_dump
+	DumpFormatTar DumpFormat = "tar"
+
+	// DumpFormatPlain is a plain-text SQL script
+	DumpFormatPlain DumpFormat = "plain"
+)
+
+// DumpPhase is the phase of the execution of a logical dump
+type DumpPhase string
+
+const (
+	// DumpPhaseRunning means that the dump is being taken
+	DumpPhaseRunning DumpPhase = "running"
+
+	// DumpPhaseCompleted means that the dump has been taken and
+	// stored in the object store
+	DumpPhaseCompleted DumpPhase = "completed"
+
+	// DumpPhaseFailed means that the dump could not be taken
+	DumpPhaseFailed DumpPhase = "failed"
+)
+
+// ScheduledDumpSpec defines the desired state of ScheduledDump
+type ScheduledDumpSpec struct {
+	// If this dump is suspended or not
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// If the first dump has to be immediately start after creation or not
+	// +optional
+	Immediate *bool `json:"immediate,omitempty"`
+
+	// The schedule does not follow the same format used in Kubernetes CronJobs
+	// as it includes an additional seconds specifier,
+	// see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	Schedule string `json:"schedule"`
+
+	// The cluster to dump. The dumps are stored in the object store
+	// configured in the `.spec.backup.barmanObjectStore` section of the
+	// cluster
+	Cluster LocalObjectReference `json:"cluster"`
+
+	// The databases to be dumped with pg_dump, one archive per database.
+	// If empty, the whole instance, including roles and tablespaces, is
+	// dumped with pg_dumpall
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// The format of the archive, possible options are `custom`, `directory`,
+	// `tar` and `plain`. Defaults to `custom` when dumping databases, while
+	// pg_dumpall supports only the `plain` format
+	// +kubebuilder:validation:Enum=custom;directory;tar;plain
+	// +optional
+	Format DumpFormat `json:"format,omitempty"`
+
+	// The compression level, from 0 (no compression) to 9. If not
+	// specified, the pg_dump default for the chosen format is used
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=9
+	// +optional
+	Compression *int `json:"compression,omitempty"`
+
+	// The number of tables to be dumped in parallel. Parallel dumps
+	// are supported only by the `directory` format
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Jobs *int `json:"jobs,omitempty"`
+}
+
+// ScheduledDumpStatus defines the observed state of ScheduledDump
+type ScheduledDumpStatus struct {
+	// The latest time the schedule
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Information when was the last time that dump was successfully scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Next time we will run a dump
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// The result of the last dump
+	// +optional
+	LastDump *DumpStatus `json:"lastDump,omitempty"`
+}
+
+// DumpStatus is the result of the execution of a logical dump
+type DumpStatus struct {
+	// The phase of the dump
+	Phase DumpPhase `json:"phase"`
+
+	// The instance where the dump has been taken
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+
+	// When the dump was started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the dump was terminated
+	// +optional
+	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
+
+	// The path in the object store where the dump has been stored
+	// +optional
+	DestinationPath string `json:"destinationPath,omitempty"`
+
+	// The error that caused the dump to fail, if any
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Last Dump",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.lastDump.phase"
+
+// ScheduledDump is the Schema for the scheduleddumps API
+type ScheduledDump struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired behavior of the ScheduledDump.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec ScheduledDumpSpec `json:"spec"`
+	// Most recently observed status of the ScheduledDump. This data may not be up
+	// to date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status ScheduledDumpStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScheduledDumpList contains a list of ScheduledDump
+type ScheduledDumpList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of scheduled dumps
+	Items []ScheduledDump `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScheduledDump{}, &ScheduledDumpList{})
+}
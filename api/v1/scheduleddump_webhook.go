@@ -0,0 +1,140 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/robfig/cron"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// scheduledDumpLog is for logging in this package.
+var scheduledDumpLog = log.WithName("scheduleddump-resource").WithValues("version", "v1")
+
+// SetupWebhookWithManager setup the webhook inside the controller manager
+func (r *ScheduledDump) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:webhookVersions={v1},admissionReviewVersions={v1},verbs=create;update,path=/validate-postgresql-cnpg-io-v1-scheduleddump,mutating=false,failurePolicy=fail,groups=postgresql.cnpg.io,resources=scheduleddumps,versions=v1,name=vscheduleddump.cnpg.io,sideEffects=None
+
+var _ webhook.Validator = &ScheduledDump{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ScheduledDump) ValidateCreate() (admission.Warnings, error) {
+	scheduledDumpLog.Info("validate create", "name", r.Name, "namespace", r.Namespace)
+	return r.toAdmissionResult(r.validate())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ScheduledDump) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+	scheduledDumpLog.Info("validate update", "name", r.Name, "namespace", r.Namespace)
+	return r.toAdmissionResult(r.validate())
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ScheduledDump) ValidateDelete() (admission.Warnings, error) {
+	scheduledDumpLog.Info("validate delete", "name", r.Name, "namespace", r.Namespace)
+	return nil, nil
+}
+
+func (r *ScheduledDump) toAdmissionResult(
+	warnings admission.Warnings,
+	allErrs field.ErrorList,
+) (admission.Warnings, error) {
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return nil, apierrors.NewInvalid(
+		schema.GroupKind{Group: "postgresql.cnpg.io", Kind: ScheduledDumpKind},
+		r.Name, allErrs)
+}
+
+func (r *ScheduledDump) validate() (admission.Warnings, field.ErrorList) {
+	var result field.ErrorList
+	var warnings admission.Warnings
+
+	if _, err := cron.Parse(r.GetSchedule()); err != nil {
+		result = append(result,
+			field.Invalid(
+				field.NewPath("spec", "schedule"),
+				r.Spec.Schedule, err.Error()))
+	} else if len(strings.Fields(r.Spec.Schedule)) != 6 {
+		warnings = append(
+			warnings,
+			"Schedule parameter may not have the right number of arguments "+
+				"(usually six arguments are needed)",
+		)
+	}
+
+	seenDatabases := make(map[string]bool, len(r.Spec.Databases))
+	for idx, database := range r.Spec.Databases {
+		if seenDatabases[database] {
+			result = append(result, field.Duplicate(
+				field.NewPath("spec", "databases").Index(idx),
+				database))
+		}
+		seenDatabases[database] = true
+	}
+
+	if r.IsDumpAll() {
+		if r.GetFormat() != DumpFormatPlain {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "format"),
+				r.Spec.Format,
+				"pg_dumpall supports only the plain format, list the databases to be dumped "+
+					"to use a different one",
+			))
+		}
+		if r.Spec.Compression != nil {
+			result = append(result, field.Invalid(
+				field.NewPath("spec", "compression"),
+				*r.Spec.Compression,
+				"pg_dumpall doesn't support compression",
+			))
+		}
+	}
+
+	if r.Spec.Compression != nil && r.GetFormat() == DumpFormatTar {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "compression"),
+			*r.Spec.Compression,
+			"the tar format doesn't support compression",
+		))
+	}
+
+	if r.Spec.Jobs != nil && r.GetFormat() != DumpFormatDirectory {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "jobs"),
+			*r.Spec.Jobs,
+			"parallel dumps are supported only by the directory format",
+		))
+	}
+
+	return warnings, result
+}
@@ -0,0 +1,133 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduled dump validation", func() {
+	It("accepts a dump of the whole instance", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule: "0 0 0 * * *",
+			},
+		}
+
+		warnings, result := scheduledDump.validate()
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(BeEmpty())
+		Expect(scheduledDump.GetFormat()).To(Equal(DumpFormatPlain))
+	})
+
+	It("accepts a parallel dump of selected databases", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule:    "0 0 0 * * *",
+				Databases:   []string{"app", "reports"},
+				Format:      DumpFormatDirectory,
+				Compression: ptr.To(5),
+				Jobs:        ptr.To(4),
+			},
+		}
+
+		warnings, result := scheduledDump.validate()
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("defaults to the custom format when dumping databases", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Databases: []string{"app"},
+			},
+		}
+		Expect(scheduledDump.GetFormat()).To(Equal(DumpFormatCustom))
+	})
+
+	It("complains with a wrong schedule", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule: "0 0 0 * * * 1996",
+			},
+		}
+
+		_, result := scheduledDump.validate()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.schedule"))
+	})
+
+	It("complains about duplicated databases", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule:  "0 0 0 * * *",
+				Databases: []string{"app", "app"},
+			},
+		}
+
+		_, result := scheduledDump.validate()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.databases[1]"))
+	})
+
+	It("complains about options not supported by pg_dumpall", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule:    "0 0 0 * * *",
+				Format:      DumpFormatCustom,
+				Compression: ptr.To(5),
+			},
+		}
+
+		_, result := scheduledDump.validate()
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.format"))
+		Expect(result[1].Field).To(Equal("spec.compression"))
+	})
+
+	It("complains about compressed tar archives", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule:    "0 0 0 * * *",
+				Databases:   []string{"app"},
+				Format:      DumpFormatTar,
+				Compression: ptr.To(5),
+			},
+		}
+
+		_, result := scheduledDump.validate()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.compression"))
+	})
+
+	It("complains about parallel dumps not in the directory format", func() {
+		scheduledDump := &ScheduledDump{
+			Spec: ScheduledDumpSpec{
+				Schedule:  "0 0 0 * * *",
+				Databases: []string{"app"},
+				Jobs:      ptr.To(4),
+			},
+		}
+
+		_, result := scheduledDump.validate()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.jobs"))
+	})
+})
@@ -35,7 +35,30 @@ const (
 	SubscriptionReclaimRetain SubscriptionReclaimPolicy = "retain"
 )
 
+// SubscriptionConflictPolicy describes how the errors raised while applying
+// the changes coming from the publisher are handled
+// +enum
+type SubscriptionConflictPolicy string
+
+const (
+	// SubscriptionConflictLog means that the apply worker keeps retrying the
+	// failing transaction, logging the error every time. This is the
+	// PostgreSQL default behavior.
+	SubscriptionConflictLog SubscriptionConflictPolicy = "log"
+
+	// SubscriptionConflictStop means that the subscription is disabled when
+	// the apply worker hits an error, until it is manually re-enabled.
+	SubscriptionConflictStop SubscriptionConflictPolicy = "stop"
+
+	// SubscriptionConflictSkip means that the subscription is disabled when
+	// the apply worker hits an error and, if the error matches one of the
+	// configured error codes, the failing transaction is skipped and the
+	// subscription is enabled again.
+	SubscriptionConflictSkip SubscriptionConflictPolicy = "skip"
+)
+
 // SubscriptionSpec defines the desired state of Subscription
+// +kubebuilder:validation:XValidation:rule="!has(self.conflictPolicy) || self.conflictPolicy != 'skip' || (has(self.skipErrorCodes) && size(self.skipErrorCodes) > 0)",message="skipErrorCodes is required when conflictPolicy is skip"
 type SubscriptionSpec struct {
 	// The name of the PostgreSQL cluster that identifies the "subscriber"
 	ClusterRef corev1.LocalObjectReference `json:"cluster"`
@@ -71,6 +94,41 @@ type SubscriptionSpec struct {
 	// +kubebuilder:default:=retain
 	// +optional
 	ReclaimPolicy SubscriptionReclaimPolicy `json:"subscriptionReclaimPolicy,omitempty"`
+
+	// The policy used to handle the errors raised while applying the
+	// changes coming from the publisher. The `stop` and `skip` policies
+	// require PostgreSQL 15 or later.
+	// +kubebuilder:validation:Enum=log;stop;skip
+	// +kubebuilder:default:=log
+	// +optional
+	ConflictPolicy SubscriptionConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// The SQLSTATE error codes (i.e. `23505`) or error classes (i.e. `23`)
+	// whose failing transactions are skipped when the conflict policy
+	// is `skip`
+	// +kubebuilder:validation:items:Pattern=`^[0-9A-Z]{2}([0-9A-Z]{3})?$`
+	// +optional
+	SkipErrorCodes []string `json:"skipErrorCodes,omitempty"`
+}
+
+// SubscriptionApplyError is the latest error raised by the logical
+// replication workers of a subscription
+type SubscriptionApplyError struct {
+	// The SQLSTATE code of the error
+	// +optional
+	SQLState string `json:"sqlState,omitempty"`
+
+	// The error message
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The LSN where the failing remote transaction finished
+	// +optional
+	FinishLSN string `json:"finishLSN,omitempty"`
+
+	// The time when the error has been detected
+	// +optional
+	Time metav1.Time `json:"time,omitempty"`
 }
 
 // SubscriptionStatus defines the observed state of Subscription
@@ -87,6 +145,38 @@ type SubscriptionStatus struct {
 	// Message is the reconciliation output message
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Enabled is true if the subscription is enabled in PostgreSQL
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The number of times an error occurred while applying the changes
+	// (PostgreSQL 15 or later)
+	// +optional
+	ApplyErrorCount int64 `json:"applyErrorCount,omitempty"`
+
+	// The number of times an error occurred during the initial table
+	// synchronization (PostgreSQL 15 or later)
+	// +optional
+	SyncErrorCount int64 `json:"syncErrorCount,omitempty"`
+
+	// The time elapsed since the last message received from the publisher
+	// +optional
+	Lag *metav1.Duration `json:"lag,omitempty"`
+
+	// The latest error raised while applying the changes
+	// +optional
+	LastApplyError *SubscriptionApplyError `json:"lastApplyError,omitempty"`
+
+	// The number of remote transactions skipped by the `skip`
+	// conflict policy
+	// +optional
+	SkippedTransactions int64 `json:"skippedTransactions,omitempty"`
+
+	// The finish LSN of the latest remote transaction skipped by the
+	// `skip` conflict policy
+	// +optional
+	LastSkippedLSN string `json:"lastSkippedLSN,omitempty"`
 }
 
 // +genclient
@@ -96,6 +186,7 @@ type SubscriptionStatus struct {
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
 // +kubebuilder:printcolumn:name="PG Name",type="string",JSONPath=".spec.name"
 // +kubebuilder:printcolumn:name="Applied",type="boolean",JSONPath=".status.applied"
+// +kubebuilder:printcolumn:name="Enabled",type="boolean",JSONPath=".status.enabled"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Latest reconciliation message"
 
 // Subscription is the Schema for the subscriptions API
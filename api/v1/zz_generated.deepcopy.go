@@ -30,6 +30,83 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSPrivateCACertificateProvider) DeepCopyInto(out *AWSPrivateCACertificateProvider) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AWSPrivateCACredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateCACertificateProvider.
+func (in *AWSPrivateCACertificateProvider) DeepCopy() *AWSPrivateCACertificateProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSPrivateCACertificateProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSPrivateCACredentials) DeepCopyInto(out *AWSPrivateCACredentials) {
+	*out = *in
+	out.AccessKeyID = in.AccessKeyID
+	out.SecretAccessKey = in.SecretAccessKey
+	if in.SessionToken != nil {
+		in, out := &in.SessionToken, &out.SessionToken
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateCACredentials.
+func (in *AWSPrivateCACredentials) DeepCopy() *AWSPrivateCACredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSPrivateCACredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalReplicationSlot) DeepCopyInto(out *AdditionalReplicationSlot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalReplicationSlot.
+func (in *AdditionalReplicationSlot) DeepCopy() *AdditionalReplicationSlot {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalReplicationSlot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalReplicationSlotStatus) DeepCopyInto(out *AdditionalReplicationSlotStatus) {
+	*out = *in
+	if in.LagBytes != nil {
+		in, out := &in.LagBytes, &out.LagBytes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalReplicationSlotStatus.
+func (in *AdditionalReplicationSlotStatus) DeepCopy() *AdditionalReplicationSlotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalReplicationSlotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AffinityConfiguration) DeepCopyInto(out *AffinityConfiguration) {
 	*out = *in
@@ -79,6 +156,182 @@ func (in *AffinityConfiguration) DeepCopy() *AffinityConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertsConfiguration) DeepCopyInto(out *AlertsConfiguration) {
+	*out = *in
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WALArchiveFailure != nil {
+		in, out := &in.WALArchiveFailure, &out.WALArchiveFailure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxBackupAge != nil {
+		in, out := &in.MaxBackupAge, &out.MaxBackupAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConnectionsUsage != nil {
+		in, out := &in.MaxConnectionsUsage, &out.MaxConnectionsUsage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeadlocks != nil {
+		in, out := &in.MaxDeadlocks, &out.MaxDeadlocks
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertsConfiguration.
+func (in *AlertsConfiguration) DeepCopy() *AlertsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditConfiguration) DeepCopyInto(out *AuditConfiguration) {
+	*out = *in
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogCatalog != nil {
+		in, out := &in.LogCatalog, &out.LogCatalog
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]AuditRoleConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Shipping != nil {
+		in, out := &in.Shipping, &out.Shipping
+		*out = new(AuditLogShipping)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditConfiguration.
+func (in *AuditConfiguration) DeepCopy() *AuditConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogShipping) DeepCopyInto(out *AuditLogShipping) {
+	*out = *in
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(SyslogSink)
+		**out = **in
+	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(AuditObjectStoreSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogShipping.
+func (in *AuditLogShipping) DeepCopy() *AuditLogShipping {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogShipping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditObjectStoreSink) DeepCopyInto(out *AuditObjectStoreSink) {
+	*out = *in
+	if in.UploadInterval != nil {
+		in, out := &in.UploadInterval, &out.UploadInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditObjectStoreSink.
+func (in *AuditObjectStoreSink) DeepCopy() *AuditObjectStoreSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditObjectStoreSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRoleConfiguration) DeepCopyInto(out *AuditRoleConfiguration) {
+	*out = *in
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRoleConfiguration.
+func (in *AuditRoleConfiguration) DeepCopy() *AuditRoleConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRoleConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoResizedStorageStatus) DeepCopyInto(out *AutoResizedStorageStatus) {
+	*out = *in
+	if in.Tablespaces != nil {
+		in, out := &in.Tablespaces, &out.Tablespaces
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoResizedStorageStatus.
+func (in *AutoResizedStorageStatus) DeepCopy() *AutoResizedStorageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoResizedStorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AvailableArchitecture) DeepCopyInto(out *AvailableArchitecture) {
 	*out = *in
@@ -134,6 +387,36 @@ func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
 		*out = new(pkgapi.BarmanObjectStoreConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PgBackRest != nil {
+		in, out := &in.PgBackRest, &out.PgBackRest
+		*out = new(PgBackRestConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(BackupMirrorConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TargetSelector != nil {
+		in, out := &in.TargetSelector, &out.TargetSelector
+		*out = new(BackupTargetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WALArchiveHealth != nil {
+		in, out := &in.WALArchiveHealth, &out.WALArchiveHealth
+		*out = new(WALArchiveHealthConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
@@ -147,31 +430,25 @@ func (in *BackupConfiguration) DeepCopy() *BackupConfiguration {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupList) DeepCopyInto(out *BackupList) {
+func (in *BackupGrant) DeepCopyInto(out *BackupGrant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Backup, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupList.
-func (in *BackupList) DeepCopy() *BackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGrant.
+func (in *BackupGrant) DeepCopy() *BackupGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupList)
+	out := new(BackupGrant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BackupList) DeepCopyObject() runtime.Object {
+func (in *BackupGrant) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -179,58 +456,208 @@ func (in *BackupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupPluginConfiguration) DeepCopyInto(out *BackupPluginConfiguration) {
+func (in *BackupGrantList) DeepCopyInto(out *BackupGrantList) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPluginConfiguration.
-func (in *BackupPluginConfiguration) DeepCopy() *BackupPluginConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGrantList.
+func (in *BackupGrantList) DeepCopy() *BackupGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupPluginConfiguration)
+	out := new(BackupGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupSnapshotElementStatus) DeepCopyInto(out *BackupSnapshotElementStatus) {
+func (in *BackupGrantSpec) DeepCopyInto(out *BackupGrantSpec) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSnapshotElementStatus.
-func (in *BackupSnapshotElementStatus) DeepCopy() *BackupSnapshotElementStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGrantSpec.
+func (in *BackupGrantSpec) DeepCopy() *BackupGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupSnapshotElementStatus)
+	out := new(BackupGrantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupSnapshotStatus) DeepCopyInto(out *BackupSnapshotStatus) {
+func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
-	if in.Elements != nil {
-		in, out := &in.Elements, &out.Elements
-		*out = make([]BackupSnapshotElementStatus, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Backup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSnapshotStatus.
-func (in *BackupSnapshotStatus) DeepCopy() *BackupSnapshotStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupList.
+func (in *BackupList) DeepCopy() *BackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupSnapshotStatus)
+	out := new(BackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupMirrorConfiguration) DeepCopyInto(out *BackupMirrorConfiguration) {
+	*out = *in
+	in.BarmanObjectStore.DeepCopyInto(&out.BarmanObjectStore)
+	if in.MaxSpoolSize != nil {
+		in, out := &in.MaxSpoolSize, &out.MaxSpoolSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMirrorConfiguration.
+func (in *BackupMirrorConfiguration) DeepCopy() *BackupMirrorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupMirrorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupMirrorStatus) DeepCopyInto(out *BackupMirrorStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMirrorStatus.
+func (in *BackupMirrorStatus) DeepCopy() *BackupMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPluginConfiguration) DeepCopyInto(out *BackupPluginConfiguration) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPluginConfiguration.
+func (in *BackupPluginConfiguration) DeepCopy() *BackupPluginConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPluginConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPostponeStartWindow) DeepCopyInto(out *BackupPostponeStartWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]BackupWeekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPostponeStartWindow.
+func (in *BackupPostponeStartWindow) DeepCopy() *BackupPostponeStartWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPostponeStartWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSnapshotElementStatus) DeepCopyInto(out *BackupSnapshotElementStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSnapshotElementStatus.
+func (in *BackupSnapshotElementStatus) DeepCopy() *BackupSnapshotElementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSnapshotElementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSnapshotStatus) DeepCopyInto(out *BackupSnapshotStatus) {
+	*out = *in
+	if in.Elements != nil {
+		in, out := &in.Elements, &out.Elements
+		*out = make([]BackupSnapshotElementStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSnapshotStatus.
+func (in *BackupSnapshotStatus) DeepCopy() *BackupSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSnapshotStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -332,6 +759,11 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -344,6 +776,99 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTargetSelector) DeepCopyInto(out *BackupTargetSelector) {
+	*out = *in
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTargetSelector.
+func (in *BackupTargetSelector) DeepCopy() *BackupTargetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTargetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationConfiguration) DeepCopyInto(out *BackupVerificationConfiguration) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationConfiguration.
+func (in *BackupVerificationConfiguration) DeepCopy() *BackupVerificationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedAt != nil {
+		in, out := &in.StoppedAt, &out.StoppedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapClone) DeepCopyInto(out *BootstrapClone) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(api.LocalObjectReference)
+		**out = **in
+	}
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(DataMaskingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapClone.
+func (in *BootstrapClone) DeepCopy() *BootstrapClone {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapClone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapConfiguration) DeepCopyInto(out *BootstrapConfiguration) {
 	*out = *in
@@ -362,6 +887,16 @@ func (in *BootstrapConfiguration) DeepCopyInto(out *BootstrapConfiguration) {
 		*out = new(BootstrapPgBaseBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(BootstrapClone)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(BootstrapMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapConfiguration.
@@ -439,6 +974,46 @@ func (in *BootstrapInitDB) DeepCopy() *BootstrapInitDB {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapMigration) DeepCopyInto(out *BootstrapMigration) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FenceSource != nil {
+		in, out := &in.FenceSource, &out.FenceSource
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PgDumpExtraOptions != nil {
+		in, out := &in.PgDumpExtraOptions, &out.PgDumpExtraOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PgRestoreExtraOptions != nil {
+		in, out := &in.PgRestoreExtraOptions, &out.PgRestoreExtraOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapMigration.
+func (in *BootstrapMigration) DeepCopy() *BootstrapMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapPgBaseBackup) DeepCopyInto(out *BootstrapPgBaseBackup) {
 	*out = *in
@@ -447,6 +1022,11 @@ func (in *BootstrapPgBaseBackup) DeepCopyInto(out *BootstrapPgBaseBackup) {
 		*out = new(api.LocalObjectReference)
 		**out = **in
 	}
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(DataMaskingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapPgBaseBackup.
@@ -482,6 +1062,11 @@ func (in *BootstrapRecovery) DeepCopyInto(out *BootstrapRecovery) {
 		*out = new(api.LocalObjectReference)
 		**out = **in
 	}
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(DataMaskingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapRecovery.
@@ -510,58 +1095,137 @@ func (in *CatalogImage) DeepCopy() *CatalogImage {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatesConfiguration) DeepCopyInto(out *CertificatesConfiguration) {
+func (in *CatalogImageUpdate) DeepCopyInto(out *CatalogImageUpdate) {
 	*out = *in
-	if in.ServerAltDNSNames != nil {
-		in, out := &in.ServerAltDNSNames, &out.ServerAltDNSNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesConfiguration.
-func (in *CertificatesConfiguration) DeepCopy() *CertificatesConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogImageUpdate.
+func (in *CatalogImageUpdate) DeepCopy() *CatalogImageUpdate {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatesConfiguration)
+	out := new(CatalogImageUpdate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+func (in *CertificateProviderConfiguration) DeepCopyInto(out *CertificateProviderConfiguration) {
 	*out = *in
-	in.CertificatesConfiguration.DeepCopyInto(&out.CertificatesConfiguration)
-	if in.Expirations != nil {
-		in, out := &in.Expirations, &out.Expirations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultPKICertificateProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(AWSPrivateCACertificateProvider)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
-func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateProviderConfiguration.
+func (in *CertificateProviderConfiguration) DeepCopy() *CertificateProviderConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatesStatus)
+	out := new(CertificateProviderConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Cluster) DeepCopyInto(out *Cluster) {
+func (in *CertificateRotationStatus) DeepCopyInto(out *CertificateRotationStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.ReloadedInstances != nil {
+		in, out := &in.ReloadedInstances, &out.ReloadedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingInstances != nil {
+		in, out := &in.PendingInstances, &out.PendingInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRotationStatus.
+func (in *CertificateRotationStatus) DeepCopy() *CertificateRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesConfiguration) DeepCopyInto(out *CertificatesConfiguration) {
+	*out = *in
+	if in.ServerAltDNSNames != nil {
+		in, out := &in.ServerAltDNSNames, &out.ServerAltDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(CertificateProviderConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesConfiguration.
+func (in *CertificatesConfiguration) DeepCopy() *CertificatesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+	*out = *in
+	in.CertificatesConfiguration.DeepCopyInto(&out.CertificatesConfiguration)
+	if in.Expirations != nil {
+		in, out := &in.Expirations, &out.Expirations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
+func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cluster) DeepCopyInto(out *Cluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
 func (in *Cluster) DeepCopy() *Cluster {
 	if in == nil {
 		return nil
@@ -585,6 +1249,7 @@ func (in *ClusterImageCatalog) DeepCopyInto(out *ClusterImageCatalog) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageCatalog.
@@ -684,6 +1349,22 @@ func (in *ClusterMonitoringTLSConfiguration) DeepCopy() *ClusterMonitoringTLSCon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPauseStatus) DeepCopyInto(out *ClusterPauseStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPauseStatus.
+func (in *ClusterPauseStatus) DeepCopy() *ClusterPauseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPauseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
@@ -697,6 +1378,12 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ImageCatalogRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerificationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ImagePullPolicy = in.ImagePullPolicy
 	in.PostgresConfiguration.DeepCopyInto(&out.PostgresConfiguration)
 	if in.ReplicationSlots != nil {
 		in, out := &in.ReplicationSlots, &out.ReplicationSlots
@@ -728,6 +1415,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(CertificatesConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(SecurityConfiguration)
+		**out = **in
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]api.LocalObjectReference, len(*in))
@@ -744,6 +1436,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(StorageConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WALSpaceProtection != nil {
+		in, out := &in.WALSpaceProtection, &out.WALSpaceProtection
+		*out = new(WALSpaceProtectionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EphemeralVolumeSource != nil {
 		in, out := &in.EphemeralVolumeSource, &out.EphemeralVolumeSource
 		*out = new(corev1.EphemeralVolumeSource)
@@ -754,6 +1451,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Witness != nil {
+		in, out := &in.Witness, &out.Witness
+		*out = new(WitnessConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LivenessProbeTimeout != nil {
 		in, out := &in.LivenessProbeTimeout, &out.LivenessProbeTimeout
 		*out = new(int32)
@@ -767,12 +1474,42 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VerticalPodAutoscaler != nil {
+		in, out := &in.VerticalPodAutoscaler, &out.VerticalPodAutoscaler
+		*out = new(VerticalPodAutoscalerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EphemeralVolumesSizeLimit != nil {
 		in, out := &in.EphemeralVolumesSizeLimit, &out.EphemeralVolumesSizeLimit
 		*out = new(EphemeralVolumesSizeLimitConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PoolerDrain != nil {
+		in, out := &in.PoolerDrain, &out.PoolerDrain
+		*out = new(PoolerDrainConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PoolerNamespaces != nil {
+		in, out := &in.PoolerNamespaces, &out.PoolerNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MajorUpgrade != nil {
+		in, out := &in.MajorUpgrade, &out.MajorUpgrade
+		*out = new(MajorUpgradeConfiguration)
+		**out = **in
+	}
+	if in.MinorUpgrade != nil {
+		in, out := &in.MinorUpgrade, &out.MinorUpgrade
+		*out = new(MinorUpgradeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Backup != nil {
 		in, out := &in.Backup, &out.Backup
 		*out = new(BackupConfiguration)
@@ -783,6 +1520,28 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(NodeMaintenanceWindow)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = make([]LifecycleHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LifecycleEvents != nil {
+		in, out := &in.LifecycleEvents, &out.LifecycleEvents
+		*out = new(LifecycleEventsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Monitoring != nil {
 		in, out := &in.Monitoring, &out.Monitoring
 		*out = new(MonitoringConfiguration)
@@ -795,6 +1554,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(LoggingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProjectedVolumeTemplate != nil {
 		in, out := &in.ProjectedVolumeTemplate, &out.ProjectedVolumeTemplate
 		*out = new(corev1.ProjectedVolumeSource)
@@ -819,6 +1583,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ManagedConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetectionConfiguration)
+		**out = **in
+	}
 	if in.SeccompProfile != nil {
 		in, out := &in.SeccompProfile, &out.SeccompProfile
 		*out = new(corev1.SeccompProfile)
@@ -836,6 +1605,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make([]PluginConfiguration, len(*in))
@@ -883,7 +1657,7 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		in, out := &in.InstancesReportedState, &out.InstancesReportedState
 		*out = make(map[PodName]InstanceReportedState, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	in.ManagedRolesStatus.DeepCopyInto(&out.ManagedRolesStatus)
@@ -935,6 +1709,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.BackupMirror != nil {
+		in, out := &in.BackupMirror, &out.BackupMirror
+		*out = new(BackupMirrorStatus)
+		**out = **in
+	}
 	if in.PoolerIntegrations != nil {
 		in, out := &in.PoolerIntegrations, &out.PoolerIntegrations
 		*out = new(PoolerIntegrations)
@@ -957,6 +1736,16 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PGDataImageInfo != nil {
+		in, out := &in.PGDataImageInfo, &out.PGDataImageInfo
+		*out = new(ImageInfo)
+		**out = **in
+	}
+	if in.LogicalMajorUpgrade != nil {
+		in, out := &in.LogicalMajorUpgrade, &out.LogicalMajorUpgrade
+		*out = new(LogicalMajorUpgradeStatus)
+		**out = **in
+	}
 	if in.PluginStatus != nil {
 		in, out := &in.PluginStatus, &out.PluginStatus
 		*out = make([]PluginStatus, len(*in))
@@ -964,7 +1753,61 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinorUpgrade != nil {
+		in, out := &in.MinorUpgrade, &out.MinorUpgrade
+		*out = new(MinorUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PoolerDrain != nil {
+		in, out := &in.PoolerDrain, &out.PoolerDrain
+		*out = new(PoolerDrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = make([]LifecycleHookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateRotation != nil {
+		in, out := &in.CertificateRotation, &out.CertificateRotation
+		*out = new(CertificateRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecommendedResources != nil {
+		in, out := &in.RecommendedResources, &out.RecommendedResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoResizedStorage != nil {
+		in, out := &in.AutoResizedStorage, &out.AutoResizedStorage
+		*out = new(AutoResizedStorageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalReplicationSlots != nil {
+		in, out := &in.AdditionalReplicationSlots, &out.AdditionalReplicationSlots
+		*out = make([]AdditionalReplicationSlotStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.SwitchReplicaClusterStatus = in.SwitchReplicaClusterStatus
+	if in.PauseStatus != nil {
+		in, out := &in.PauseStatus, &out.PauseStatus
+		*out = new(ClusterPauseStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastKnownPrimaryLSN != nil {
+		in, out := &in.LastKnownPrimaryLSN, &out.LastKnownPrimaryLSN
+		*out = new(PrimaryLSNStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -999,6 +1842,31 @@ func (in *ConfigMapResourceVersion) DeepCopy() *ConfigMapResourceVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataMaskingConfiguration) DeepCopyInto(out *DataMaskingConfiguration) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SQLRefs != nil {
+		in, out := &in.SQLRefs, &out.SQLRefs
+		*out = new(SQLRefs)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataMaskingConfiguration.
+func (in *DataMaskingConfiguration) DeepCopy() *DataMaskingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DataMaskingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSource) DeepCopyInto(out *DataSource) {
 	*out = *in
@@ -1120,6 +1988,42 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.SearchPath != nil {
+		in, out := &in.SearchPath, &out.SearchPath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]SchemaSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]ServerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]GrantSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TableAutovacuum != nil {
+		in, out := &in.TableAutovacuum, &out.TableAutovacuum
+		*out = make([]TableAutovacuumSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
@@ -1140,6 +2044,31 @@ func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]SchemaStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]ServerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]GrantStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.TableAutovacuum != nil {
+		in, out := &in.TableAutovacuum, &out.TableAutovacuum
+		*out = make([]TableAutovacuumStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
@@ -1152,6 +2081,44 @@ func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetectionConfiguration) DeepCopyInto(out *DriftDetectionConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionConfiguration.
+func (in *DriftDetectionConfiguration) DeepCopy() *DriftDetectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DumpStatus) DeepCopyInto(out *DumpStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedAt != nil {
+		in, out := &in.StoppedAt, &out.StoppedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DumpStatus.
+func (in *DumpStatus) DeepCopy() *DumpStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DumpStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
 	*out = *in
@@ -1181,6 +2148,21 @@ func (in *EmbeddedObjectMetadata) DeepCopy() *EmbeddedObjectMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralTablespaceConfiguration) DeepCopyInto(out *EphemeralTablespaceConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralTablespaceConfiguration.
+func (in *EphemeralTablespaceConfiguration) DeepCopy() *EphemeralTablespaceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralTablespaceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopyInto(out *EphemeralVolumesSizeLimitConfiguration) {
 	*out = *in
@@ -1206,6 +2188,36 @@ func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopy() *EphemeralVolumesSi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionSpec) DeepCopyInto(out *ExtensionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionSpec.
+func (in *ExtensionSpec) DeepCopy() *ExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionStatus) DeepCopyInto(out *ExtensionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionStatus.
+func (in *ExtensionStatus) DeepCopy() *ExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
 	*out = *in
@@ -1246,6 +2258,11 @@ func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
 		*out = new(PluginConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PgBackRest != nil {
+		in, out := &in.PgBackRest, &out.PgBackRest
+		*out = new(PgBackRestConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCluster.
@@ -1258,12 +2275,93 @@ func (in *ExternalCluster) DeepCopy() *ExternalCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverConfiguration) DeepCopyInto(out *FailoverConfiguration) {
+	*out = *in
+	if in.MaxLag != nil {
+		in, out := &in.MaxLag, &out.MaxLag
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverConfiguration.
+func (in *FailoverConfiguration) DeepCopy() *FailoverConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantSpec) DeepCopyInto(out *GrantSpec) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantSpec.
+func (in *GrantSpec) DeepCopy() *GrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantStatus) DeepCopyInto(out *GrantStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantStatus.
+func (in *GrantStatus) DeepCopy() *GrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationConfiguration) DeepCopyInto(out *HibernationConfiguration) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationConfiguration.
+func (in *HibernationConfiguration) DeepCopy() *HibernationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageCatalog) DeepCopyInto(out *ImageCatalog) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCatalog.
@@ -1284,6 +2382,26 @@ func (in *ImageCatalog) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCatalogAutoUpdate) DeepCopyInto(out *ImageCatalogAutoUpdate) {
+	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCatalogAutoUpdate.
+func (in *ImageCatalogAutoUpdate) DeepCopy() *ImageCatalogAutoUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCatalogAutoUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageCatalogList) DeepCopyInto(out *ImageCatalogList) {
 	*out = *in
@@ -1340,6 +2458,16 @@ func (in *ImageCatalogSpec) DeepCopyInto(out *ImageCatalogSpec) {
 		*out = make([]CatalogImage, len(*in))
 		copy(*out, *in)
 	}
+	if in.PgBouncerImages != nil {
+		in, out := &in.PgBouncerImages, &out.PgBouncerImages
+		*out = make([]PoolerCatalogImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoUpdate != nil {
+		in, out := &in.AutoUpdate, &out.AutoUpdate
+		*out = new(ImageCatalogAutoUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCatalogSpec.
@@ -1352,6 +2480,67 @@ func (in *ImageCatalogSpec) DeepCopy() *ImageCatalogSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCatalogStatus) DeepCopyInto(out *ImageCatalogStatus) {
+	*out = *in
+	if in.LastCheck != nil {
+		in, out := &in.LastCheck, &out.LastCheck
+		*out = (*in).DeepCopy()
+	}
+	if in.UpdatedImages != nil {
+		in, out := &in.UpdatedImages, &out.UpdatedImages
+		*out = make([]CatalogImageUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCatalogStatus.
+func (in *ImageCatalogStatus) DeepCopy() *ImageCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageInfo) DeepCopyInto(out *ImageInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageInfo.
+func (in *ImageInfo) DeepCopy() *ImageInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationConfiguration) DeepCopyInto(out *ImageVerificationConfiguration) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]api.ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationConfiguration.
+func (in *ImageVerificationConfiguration) DeepCopy() *ImageVerificationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Import) DeepCopyInto(out *Import) {
 	*out = *in
@@ -1426,6 +2615,11 @@ func (in *InstanceID) DeepCopy() *InstanceID {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceReportedState) DeepCopyInto(out *InstanceReportedState) {
 	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]InstanceVolumeState, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceReportedState.
@@ -1438,6 +2632,46 @@ func (in *InstanceReportedState) DeepCopy() *InstanceReportedState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceVolumeState) DeepCopyInto(out *InstanceVolumeState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceVolumeState.
+func (in *InstanceVolumeState) DeepCopy() *InstanceVolumeState {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceVolumeState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaRestProxyLogSink) DeepCopyInto(out *KafkaRestProxyLogSink) {
+	*out = *in
+	if in.BasicAuthSecret != nil {
+		in, out := &in.BasicAuthSecret, &out.BasicAuthSecret
+		*out = new(api.LocalObjectReference)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaRestProxyLogSink.
+func (in *KafkaRestProxyLogSink) DeepCopy() *KafkaRestProxyLogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaRestProxyLogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LDAPBindAsAuth) DeepCopyInto(out *LDAPBindAsAuth) {
 	*out = *in
@@ -1499,738 +2733,2384 @@ func (in *LDAPConfig) DeepCopy() *LDAPConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
+func (in *LDAPRoleSyncConfiguration) DeepCopyInto(out *LDAPRoleSyncConfiguration) {
 	*out = *in
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]RoleConfiguration, len(*in))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+	if in.BindPassword != nil {
+		in, out := &in.BindPassword, &out.BindPassword
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]LDAPRoleSyncGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = new(ManagedServices)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedConfiguration.
-func (in *ManagedConfiguration) DeepCopy() *ManagedConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPRoleSyncConfiguration.
+func (in *LDAPRoleSyncConfiguration) DeepCopy() *LDAPRoleSyncConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedConfiguration)
+	out := new(LDAPRoleSyncConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedRoles) DeepCopyInto(out *ManagedRoles) {
+func (in *LDAPRoleSyncGroup) DeepCopyInto(out *LDAPRoleSyncGroup) {
 	*out = *in
-	if in.ByStatus != nil {
-		in, out := &in.ByStatus, &out.ByStatus
-		*out = make(map[RoleStatus][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.CannotReconcile != nil {
-		in, out := &in.CannotReconcile, &out.CannotReconcile
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.PasswordStatus != nil {
-		in, out := &in.PasswordStatus, &out.PasswordStatus
-		*out = make(map[string]PasswordState, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.InRoles != nil {
+		in, out := &in.InRoles, &out.InRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRoles.
-func (in *ManagedRoles) DeepCopy() *ManagedRoles {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPRoleSyncGroup.
+func (in *LDAPRoleSyncGroup) DeepCopy() *LDAPRoleSyncGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedRoles)
+	out := new(LDAPRoleSyncGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedService) DeepCopyInto(out *ManagedService) {
+func (in *LDAPRoleSyncStatus) DeepCopyInto(out *LDAPRoleSyncStatus) {
 	*out = *in
-	in.ServiceTemplate.DeepCopyInto(&out.ServiceTemplate)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedService.
-func (in *ManagedService) DeepCopy() *ManagedService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPRoleSyncStatus.
+func (in *LDAPRoleSyncStatus) DeepCopy() *LDAPRoleSyncStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedService)
+	out := new(LDAPRoleSyncStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedServices) DeepCopyInto(out *ManagedServices) {
+func (in *LifecycleEventsConfiguration) DeepCopyInto(out *LifecycleEventsConfiguration) {
 	*out = *in
-	if in.DisabledDefaultServices != nil {
-		in, out := &in.DisabledDefaultServices, &out.DisabledDefaultServices
-		*out = make([]ServiceSelectorType, len(*in))
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]LifecycleEventType, len(*in))
 		copy(*out, *in)
 	}
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]ManagedService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(LifecycleEventsWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NATS != nil {
+		in, out := &in.NATS, &out.NATS
+		*out = new(LifecycleEventsNATS)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServices.
-func (in *ManagedServices) DeepCopy() *ManagedServices {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleEventsConfiguration.
+func (in *LifecycleEventsConfiguration) DeepCopy() *LifecycleEventsConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedServices)
+	out := new(LifecycleEventsConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metadata) DeepCopyInto(out *Metadata) {
+func (in *LifecycleEventsNATS) DeepCopyInto(out *LifecycleEventsNATS) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
-func (in *Metadata) DeepCopy() *Metadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleEventsNATS.
+func (in *LifecycleEventsNATS) DeepCopy() *LifecycleEventsNATS {
 	if in == nil {
 		return nil
 	}
-	out := new(Metadata)
+	out := new(LifecycleEventsNATS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringConfiguration) DeepCopyInto(out *MonitoringConfiguration) {
+func (in *LifecycleEventsWebhook) DeepCopyInto(out *LifecycleEventsWebhook) {
 	*out = *in
-	if in.DisableDefaultQueries != nil {
-		in, out := &in.DisableDefaultQueries, &out.DisableDefaultQueries
-		*out = new(bool)
-		**out = **in
-	}
-	if in.CustomQueriesConfigMap != nil {
-		in, out := &in.CustomQueriesConfigMap, &out.CustomQueriesConfigMap
-		*out = make([]api.ConfigMapKeySelector, len(*in))
-		copy(*out, *in)
-	}
-	if in.CustomQueriesSecret != nil {
-		in, out := &in.CustomQueriesSecret, &out.CustomQueriesSecret
-		*out = make([]api.SecretKeySelector, len(*in))
-		copy(*out, *in)
-	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(ClusterMonitoringTLSConfiguration)
+	if in.AuthorizationSecret != nil {
+		in, out := &in.AuthorizationSecret, &out.AuthorizationSecret
+		*out = new(api.SecretKeySelector)
 		**out = **in
 	}
-	if in.PodMonitorMetricRelabelConfigs != nil {
-		in, out := &in.PodMonitorMetricRelabelConfigs, &out.PodMonitorMetricRelabelConfigs
-		*out = make([]monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PodMonitorRelabelConfigs != nil {
-		in, out := &in.PodMonitorRelabelConfigs, &out.PodMonitorRelabelConfigs
-		*out = make([]monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfiguration.
-func (in *MonitoringConfiguration) DeepCopy() *MonitoringConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleEventsWebhook.
+func (in *LifecycleEventsWebhook) DeepCopy() *LifecycleEventsWebhook {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringConfiguration)
+	out := new(LifecycleEventsWebhook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeMaintenanceWindow) DeepCopyInto(out *NodeMaintenanceWindow) {
+func (in *LifecycleHook) DeepCopyInto(out *LifecycleHook) {
 	*out = *in
-	if in.ReusePVC != nil {
-		in, out := &in.ReusePVC, &out.ReusePVC
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceWindow.
-func (in *NodeMaintenanceWindow) DeepCopy() *NodeMaintenanceWindow {
-	if in == nil {
-		return nil
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(LifecycleHookExec)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(NodeMaintenanceWindow)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OnlineConfiguration) DeepCopyInto(out *OnlineConfiguration) {
-	*out = *in
-	if in.WaitForArchive != nil {
-		in, out := &in.WaitForArchive, &out.WaitForArchive
-		*out = new(bool)
-		**out = **in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(LifecycleHookJob)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ImmediateCheckpoint != nil {
-		in, out := &in.ImmediateCheckpoint, &out.ImmediateCheckpoint
-		*out = new(bool)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineConfiguration.
-func (in *OnlineConfiguration) DeepCopy() *OnlineConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHook.
+func (in *LifecycleHook) DeepCopy() *LifecycleHook {
 	if in == nil {
 		return nil
 	}
-	out := new(OnlineConfiguration)
+	out := new(LifecycleHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordState) DeepCopyInto(out *PasswordState) {
+func (in *LifecycleHookExec) DeepCopyInto(out *LifecycleHookExec) {
 	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordState.
-func (in *PasswordState) DeepCopy() *PasswordState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHookExec.
+func (in *LifecycleHookExec) DeepCopy() *LifecycleHookExec {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordState)
+	out := new(LifecycleHookExec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PgBouncerIntegrationStatus) DeepCopyInto(out *PgBouncerIntegrationStatus) {
+func (in *LifecycleHookJob) DeepCopyInto(out *LifecycleHookJob) {
 	*out = *in
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerIntegrationStatus.
-func (in *PgBouncerIntegrationStatus) DeepCopy() *PgBouncerIntegrationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHookJob.
+func (in *LifecycleHookJob) DeepCopy() *LifecycleHookJob {
 	if in == nil {
 		return nil
 	}
-	out := new(PgBouncerIntegrationStatus)
+	out := new(LifecycleHookJob)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PgBouncerSecrets) DeepCopyInto(out *PgBouncerSecrets) {
+func (in *LifecycleHookStatus) DeepCopyInto(out *LifecycleHookStatus) {
 	*out = *in
-	out.AuthQuery = in.AuthQuery
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerSecrets.
-func (in *PgBouncerSecrets) DeepCopy() *PgBouncerSecrets {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHookStatus.
+func (in *LifecycleHookStatus) DeepCopy() *LifecycleHookStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PgBouncerSecrets)
+	out := new(LifecycleHookStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PgBouncerSpec) DeepCopyInto(out *PgBouncerSpec) {
+func (in *LogSink) DeepCopyInto(out *LogSink) {
 	*out = *in
-	if in.AuthQuerySecret != nil {
-		in, out := &in.AuthQuerySecret, &out.AuthQuerySecret
-		*out = new(api.LocalObjectReference)
-		**out = **in
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(LokiLogSink)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(SyslogSink)
+		**out = **in
 	}
-	if in.PgHBA != nil {
-		in, out := &in.PgHBA, &out.PgHBA
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.KafkaRestProxy != nil {
+		in, out := &in.KafkaRestProxy, &out.KafkaRestProxy
+		*out = new(KafkaRestProxyLogSink)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Paused != nil {
-		in, out := &in.Paused, &out.Paused
-		*out = new(bool)
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerSpec.
-func (in *PgBouncerSpec) DeepCopy() *PgBouncerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSink.
+func (in *LogSink) DeepCopy() *LogSink {
 	if in == nil {
 		return nil
 	}
-	out := new(PgBouncerSpec)
+	out := new(LogSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginConfiguration) DeepCopyInto(out *PluginConfiguration) {
+func (in *LoggingConfiguration) DeepCopyInto(out *LoggingConfiguration) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]LogSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfiguration.
-func (in *PluginConfiguration) DeepCopy() *PluginConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingConfiguration.
+func (in *LoggingConfiguration) DeepCopy() *LoggingConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginConfiguration)
+	out := new(LoggingConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginStatus) DeepCopyInto(out *PluginStatus) {
+func (in *LogicalMajorUpgradeStatus) DeepCopyInto(out *LogicalMajorUpgradeStatus) {
 	*out = *in
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.OperatorCapabilities != nil {
-		in, out := &in.OperatorCapabilities, &out.OperatorCapabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.WALCapabilities != nil {
-		in, out := &in.WALCapabilities, &out.WALCapabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.BackupCapabilities != nil {
-		in, out := &in.BackupCapabilities, &out.BackupCapabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.RestoreJobHookCapabilities != nil {
-		in, out := &in.RestoreJobHookCapabilities, &out.RestoreJobHookCapabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginStatus.
-func (in *PluginStatus) DeepCopy() *PluginStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalMajorUpgradeStatus.
+func (in *LogicalMajorUpgradeStatus) DeepCopy() *LogicalMajorUpgradeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginStatus)
+	out := new(LogicalMajorUpgradeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplateSpec) DeepCopyInto(out *PodTemplateSpec) {
+func (in *LokiLogSink) DeepCopyInto(out *LokiLogSink) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpec.
-func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiLogSink.
+func (in *LokiLogSink) DeepCopy() *LokiLogSink {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplateSpec)
+	out := new(LokiLogSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PodTopologyLabels) DeepCopyInto(out *PodTopologyLabels) {
-	{
-		in := &in
-		*out = make(PodTopologyLabels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	if in.PendingOperations != nil {
+		in, out := &in.PendingOperations, &out.PendingOperations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextWindow != nil {
+		in, out := &in.NextWindow, &out.NextWindow
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTopologyLabels.
-func (in PodTopologyLabels) DeepCopy() PodTopologyLabels {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTopologyLabels)
+	out := new(MaintenanceStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pooler) DeepCopyInto(out *Pooler) {
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Duration = in.Duration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pooler.
-func (in *Pooler) DeepCopy() *Pooler {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(Pooler)
+	out := new(MaintenanceWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Pooler) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolerIntegrations) DeepCopyInto(out *PoolerIntegrations) {
+func (in *MajorUpgradeConfiguration) DeepCopyInto(out *MajorUpgradeConfiguration) {
 	*out = *in
-	in.PgBouncerIntegration.DeepCopyInto(&out.PgBouncerIntegration)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerIntegrations.
-func (in *PoolerIntegrations) DeepCopy() *PoolerIntegrations {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MajorUpgradeConfiguration.
+func (in *MajorUpgradeConfiguration) DeepCopy() *MajorUpgradeConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolerIntegrations)
+	out := new(MajorUpgradeConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolerList) DeepCopyInto(out *PoolerList) {
+func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Pooler, len(*in))
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]RoleConfiguration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ManagedServices)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LDAPRoleSync != nil {
+		in, out := &in.LDAPRoleSync, &out.LDAPRoleSync
+		*out = new(LDAPRoleSyncConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerList.
-func (in *PoolerList) DeepCopy() *PoolerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedConfiguration.
+func (in *ManagedConfiguration) DeepCopy() *ManagedConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolerList)
+	out := new(ManagedConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PoolerList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolerMonitoringConfiguration) DeepCopyInto(out *PoolerMonitoringConfiguration) {
+func (in *ManagedRoles) DeepCopyInto(out *ManagedRoles) {
 	*out = *in
-	if in.PodMonitorMetricRelabelConfigs != nil {
-		in, out := &in.PodMonitorMetricRelabelConfigs, &out.PodMonitorMetricRelabelConfigs
-		*out = make([]monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.ByStatus != nil {
+		in, out := &in.ByStatus, &out.ByStatus
+		*out = make(map[RoleStatus][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
 		}
 	}
-	if in.PodMonitorRelabelConfigs != nil {
-		in, out := &in.PodMonitorRelabelConfigs, &out.PodMonitorRelabelConfigs
-		*out = make([]monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.CannotReconcile != nil {
+		in, out := &in.CannotReconcile, &out.CannotReconcile
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.PasswordStatus != nil {
+		in, out := &in.PasswordStatus, &out.PasswordStatus
+		*out = make(map[string]PasswordState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.LDAPRoleSync != nil {
+		in, out := &in.LDAPRoleSync, &out.LDAPRoleSync
+		*out = new(LDAPRoleSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerMonitoringConfiguration.
-func (in *PoolerMonitoringConfiguration) DeepCopy() *PoolerMonitoringConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRoles.
+func (in *ManagedRoles) DeepCopy() *ManagedRoles {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolerMonitoringConfiguration)
+	out := new(ManagedRoles)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolerSecrets) DeepCopyInto(out *PoolerSecrets) {
+func (in *ManagedService) DeepCopyInto(out *ManagedService) {
 	*out = *in
-	out.ServerTLS = in.ServerTLS
-	out.ServerCA = in.ServerCA
-	out.ClientCA = in.ClientCA
-	if in.PgBouncerSecrets != nil {
-		in, out := &in.PgBouncerSecrets, &out.PgBouncerSecrets
-		*out = new(PgBouncerSecrets)
-		**out = **in
-	}
+	in.ServiceTemplate.DeepCopyInto(&out.ServiceTemplate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerSecrets.
-func (in *PoolerSecrets) DeepCopy() *PoolerSecrets {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedService.
+func (in *ManagedService) DeepCopy() *ManagedService {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolerSecrets)
+	out := new(ManagedService)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolerSpec) DeepCopyInto(out *PoolerSpec) {
+func (in *ManagedServices) DeepCopyInto(out *ManagedServices) {
 	*out = *in
-	out.Cluster = in.Cluster
-	if in.Instances != nil {
-		in, out := &in.Instances, &out.Instances
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(PodTemplateSpec)
-		(*in).DeepCopyInto(*out)
+	if in.DisabledDefaultServices != nil {
+		in, out := &in.DisabledDefaultServices, &out.DisabledDefaultServices
+		*out = make([]ServiceSelectorType, len(*in))
+		copy(*out, *in)
 	}
-	if in.PgBouncer != nil {
-		in, out := &in.PgBouncer, &out.PgBouncer
-		*out = new(PgBouncerSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]ManagedService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.DeploymentStrategy != nil {
-		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
-		*out = new(appsv1.DeploymentStrategy)
-		(*in).DeepCopyInto(*out)
+	if in.MaxReplicaLag != nil {
+		in, out := &in.MaxReplicaLag, &out.MaxReplicaLag
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	if in.Monitoring != nil {
-		in, out := &in.Monitoring, &out.Monitoring
-		*out = new(PoolerMonitoringConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = make([]ServiceRoutingConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.ServiceTemplate != nil {
-		in, out := &in.ServiceTemplate, &out.ServiceTemplate
-		*out = new(ServiceTemplateSpec)
+	if in.PerInstance != nil {
+		in, out := &in.PerInstance, &out.PerInstance
+		*out = new(PerInstanceServicesConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerSpec.
-func (in *PoolerSpec) DeepCopy() *PoolerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServices.
+func (in *ManagedServices) DeepCopy() *ManagedServices {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolerSpec)
+	out := new(ManagedServices)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolerStatus) DeepCopyInto(out *PoolerStatus) {
+func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = new(PoolerSecrets)
-		(*in).DeepCopyInto(*out)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerStatus.
-func (in *PoolerStatus) DeepCopy() *PoolerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
+func (in *Metadata) DeepCopy() *Metadata {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolerStatus)
+	out := new(Metadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
+func (in *MinorUpgradeConfiguration) DeepCopyInto(out *MinorUpgradeConfiguration) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Synchronous != nil {
-		in, out := &in.Synchronous, &out.Synchronous
-		*out = new(SynchronousReplicaConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PgHBA != nil {
-		in, out := &in.PgHBA, &out.PgHBA
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.PgIdent != nil {
-		in, out := &in.PgIdent, &out.PgIdent
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxLag != nil {
+		in, out := &in.MaxLag, &out.MaxLag
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	in.SyncReplicaElectionConstraint.DeepCopyInto(&out.SyncReplicaElectionConstraint)
-	if in.AdditionalLibraries != nil {
-		in, out := &in.AdditionalLibraries, &out.AdditionalLibraries
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.LDAP != nil {
-		in, out := &in.LDAP, &out.LDAP
-		*out = new(LDAPConfig)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfiguration.
-func (in *PostgresConfiguration) DeepCopy() *PostgresConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinorUpgradeConfiguration.
+func (in *MinorUpgradeConfiguration) DeepCopy() *MinorUpgradeConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgresConfiguration)
+	out := new(MinorUpgradeConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Probe) DeepCopyInto(out *Probe) {
+func (in *MinorUpgradeStatus) DeepCopyInto(out *MinorUpgradeStatus) {
 	*out = *in
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
-		**out = **in
-	}
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
-func (in *Probe) DeepCopy() *Probe {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinorUpgradeStatus.
+func (in *MinorUpgradeStatus) DeepCopy() *MinorUpgradeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Probe)
+	out := new(MinorUpgradeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbesConfiguration) DeepCopyInto(out *ProbesConfiguration) {
+func (in *MonitoringConfiguration) DeepCopyInto(out *MonitoringConfiguration) {
 	*out = *in
-	if in.Startup != nil {
-		in, out := &in.Startup, &out.Startup
-		*out = new(Probe)
+	if in.DisableDefaultQueries != nil {
+		in, out := &in.DisableDefaultQueries, &out.DisableDefaultQueries
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CustomQueriesConfigMap != nil {
+		in, out := &in.CustomQueriesConfigMap, &out.CustomQueriesConfigMap
+		*out = make([]api.ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomQueriesSecret != nil {
+		in, out := &in.CustomQueriesSecret, &out.CustomQueriesSecret
+		*out = make([]api.SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(ClusterMonitoringTLSConfiguration)
+		**out = **in
+	}
+	if in.PodMonitorMetricRelabelConfigs != nil {
+		in, out := &in.PodMonitorMetricRelabelConfigs, &out.PodMonitorMetricRelabelConfigs
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodMonitorRelabelConfigs != nil {
+		in, out := &in.PodMonitorRelabelConfigs, &out.PodMonitorRelabelConfigs
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueryInsights != nil {
+		in, out := &in.QueryInsights, &out.QueryInsights
+		*out = new(QueryInsightsConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Liveness != nil {
-		in, out := &in.Liveness, &out.Liveness
-		*out = new(Probe)
+	if in.TableStatistics != nil {
+		in, out := &in.TableStatistics, &out.TableStatistics
+		*out = new(TableStatisticsConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Readiness != nil {
-		in, out := &in.Readiness, &out.Readiness
-		*out = new(Probe)
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetryConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(AlertsConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesConfiguration.
-func (in *ProbesConfiguration) DeepCopy() *ProbesConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfiguration.
+func (in *MonitoringConfiguration) DeepCopy() *MonitoringConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenanceWindow) DeepCopyInto(out *NodeMaintenanceWindow) {
+	*out = *in
+	if in.ReusePVC != nil {
+		in, out := &in.ReusePVC, &out.ReusePVC
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceWindow.
+func (in *NodeMaintenanceWindow) DeepCopy() *NodeMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthConfig) DeepCopyInto(out *OAuthConfig) {
+	*out = *in
+	out.Validator = in.Validator
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthConfig.
+func (in *OAuthConfig) DeepCopy() *OAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthValidator) DeepCopyInto(out *OAuthValidator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthValidator.
+func (in *OAuthValidator) DeepCopy() *OAuthValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineConfiguration) DeepCopyInto(out *OnlineConfiguration) {
+	*out = *in
+	if in.WaitForArchive != nil {
+		in, out := &in.WaitForArchive, &out.WaitForArchive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImmediateCheckpoint != nil {
+		in, out := &in.ImmediateCheckpoint, &out.ImmediateCheckpoint
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineConfiguration.
+func (in *OnlineConfiguration) DeepCopy() *OnlineConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryConfiguration) DeepCopyInto(out *OpenTelemetryConfiguration) {
+	*out = *in
+	if in.HeadersSecret != nil {
+		in, out := &in.HeadersSecret, &out.HeadersSecret
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryConfiguration.
+func (in *OpenTelemetryConfiguration) DeepCopy() *OpenTelemetryConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordState) DeepCopyInto(out *PasswordState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordState.
+func (in *PasswordState) DeepCopy() *PasswordState {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerInstanceServicesConfiguration) DeepCopyInto(out *PerInstanceServicesConfiguration) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerInstanceServicesConfiguration.
+func (in *PerInstanceServicesConfiguration) DeepCopy() *PerInstanceServicesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PerInstanceServicesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBackRestConfiguration) DeepCopyInto(out *PgBackRestConfiguration) {
+	*out = *in
+	in.Repository.DeepCopyInto(&out.Repository)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBackRestConfiguration.
+func (in *PgBackRestConfiguration) DeepCopy() *PgBackRestConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBackRestConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBackRestRepository) DeepCopyInto(out *PgBackRestRepository) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(api.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBackRestRepository.
+func (in *PgBackRestRepository) DeepCopy() *PgBackRestRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBackRestRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerDatabase) DeepCopyInto(out *PgBouncerDatabase) {
+	*out = *in
+	if in.PoolSize != nil {
+		in, out := &in.PoolSize, &out.PoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinPoolSize != nil {
+		in, out := &in.MinPoolSize, &out.MinPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReservePoolSize != nil {
+		in, out := &in.ReservePoolSize, &out.ReservePoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDBConnections != nil {
+		in, out := &in.MaxDBConnections, &out.MaxDBConnections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerDatabase.
+func (in *PgBouncerDatabase) DeepCopy() *PgBouncerDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerIntegrationStatus) DeepCopyInto(out *PgBouncerIntegrationStatus) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerIntegrationStatus.
+func (in *PgBouncerIntegrationStatus) DeepCopy() *PgBouncerIntegrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerIntegrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerPoolStatus) DeepCopyInto(out *PgBouncerPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerPoolStatus.
+func (in *PgBouncerPoolStatus) DeepCopy() *PgBouncerPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerSecrets) DeepCopyInto(out *PgBouncerSecrets) {
+	*out = *in
+	out.AuthQuery = in.AuthQuery
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerSecrets.
+func (in *PgBouncerSecrets) DeepCopy() *PgBouncerSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerSpec) DeepCopyInto(out *PgBouncerSpec) {
+	*out = *in
+	if in.AuthQuerySecret != nil {
+		in, out := &in.AuthQuerySecret, &out.AuthQuerySecret
+		*out = new(api.LocalObjectReference)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PgHBA != nil {
+		in, out := &in.PgHBA, &out.PgHBA
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImageCatalogRef != nil {
+		in, out := &in.ImageCatalogRef, &out.ImageCatalogRef
+		*out = new(PoolerImageCatalogRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]PgBouncerDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]PgBouncerUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerSpec.
+func (in *PgBouncerSpec) DeepCopy() *PgBouncerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBouncerUser) DeepCopyInto(out *PgBouncerUser) {
+	*out = *in
+	if in.MaxUserConnections != nil {
+		in, out := &in.MaxUserConnections, &out.MaxUserConnections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBouncerUser.
+func (in *PgBouncerUser) DeepCopy() *PgBouncerUser {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBouncerUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgCatQueryRoutingSpec) DeepCopyInto(out *PgCatQueryRoutingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgCatQueryRoutingSpec.
+func (in *PgCatQueryRoutingSpec) DeepCopy() *PgCatQueryRoutingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PgCatQueryRoutingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgCatSpec) DeepCopyInto(out *PgCatSpec) {
+	*out = *in
+	out.AuthQuerySecret = in.AuthQuerySecret
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryRouting != nil {
+		in, out := &in.QueryRouting, &out.QueryRouting
+		*out = new(PgCatQueryRoutingSpec)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgCatSpec.
+func (in *PgCatSpec) DeepCopy() *PgCatSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PgCatSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgHBARule) DeepCopyInto(out *PgHBARule) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddressesFrom != nil {
+		in, out := &in.AddressesFrom, &out.AddressesFrom
+		*out = make([]api.ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgHBARule.
+func (in *PgHBARule) DeepCopy() *PgHBARule {
+	if in == nil {
+		return nil
+	}
+	out := new(PgHBARule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfiguration) DeepCopyInto(out *PluginConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackupRetention != nil {
+		in, out := &in.BackupRetention, &out.BackupRetention
+		*out = new(VolumeSnapshotRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfiguration.
+func (in *PluginConfiguration) DeepCopy() *PluginConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginStatus) DeepCopyInto(out *PluginStatus) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatorCapabilities != nil {
+		in, out := &in.OperatorCapabilities, &out.OperatorCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WALCapabilities != nil {
+		in, out := &in.WALCapabilities, &out.WALCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackupCapabilities != nil {
+		in, out := &in.BackupCapabilities, &out.BackupCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestoreJobHookCapabilities != nil {
+		in, out := &in.RestoreJobHookCapabilities, &out.RestoreJobHookCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginStatus.
+func (in *PluginStatus) DeepCopy() *PluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfiguration) DeepCopyInto(out *PodDisruptionBudgetConfiguration) {
+	*out = *in
+	if in.MaxUnavailableReplicas != nil {
+		in, out := &in.MaxUnavailableReplicas, &out.MaxUnavailableReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnablePrimaryPDB != nil {
+		in, out := &in.EnablePrimaryPDB, &out.EnablePrimaryPDB
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfiguration.
+func (in *PodDisruptionBudgetConfiguration) DeepCopy() *PodDisruptionBudgetConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateSpec) DeepCopyInto(out *PodTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpec.
+func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PodTopologyLabels) DeepCopyInto(out *PodTopologyLabels) {
+	{
+		in := &in
+		*out = make(PodTopologyLabels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTopologyLabels.
+func (in PodTopologyLabels) DeepCopy() PodTopologyLabels {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTopologyLabels)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pooler) DeepCopyInto(out *Pooler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pooler.
+func (in *Pooler) DeepCopy() *Pooler {
+	if in == nil {
+		return nil
+	}
+	out := new(Pooler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pooler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerAdditionalService) DeepCopyInto(out *PoolerAdditionalService) {
+	*out = *in
+	in.ServiceTemplate.DeepCopyInto(&out.ServiceTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerAdditionalService.
+func (in *PoolerAdditionalService) DeepCopy() *PoolerAdditionalService {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerAdditionalService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerCatalogImage) DeepCopyInto(out *PoolerCatalogImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerCatalogImage.
+func (in *PoolerCatalogImage) DeepCopy() *PoolerCatalogImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerCatalogImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerDrainConfiguration) DeepCopyInto(out *PoolerDrainConfiguration) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerDrainConfiguration.
+func (in *PoolerDrainConfiguration) DeepCopy() *PoolerDrainConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerDrainConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerDrainStatus) DeepCopyInto(out *PoolerDrainStatus) {
+	*out = *in
+	if in.Poolers != nil {
+		in, out := &in.Poolers, &out.Poolers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerDrainStatus.
+func (in *PoolerDrainStatus) DeepCopy() *PoolerDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerImageCatalogRef) DeepCopyInto(out *PoolerImageCatalogRef) {
+	*out = *in
+	in.TypedLocalObjectReference.DeepCopyInto(&out.TypedLocalObjectReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerImageCatalogRef.
+func (in *PoolerImageCatalogRef) DeepCopy() *PoolerImageCatalogRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerImageCatalogRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerIntegrations) DeepCopyInto(out *PoolerIntegrations) {
+	*out = *in
+	in.PgBouncerIntegration.DeepCopyInto(&out.PgBouncerIntegration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerIntegrations.
+func (in *PoolerIntegrations) DeepCopy() *PoolerIntegrations {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerIntegrations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerList) DeepCopyInto(out *PoolerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pooler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerList.
+func (in *PoolerList) DeepCopy() *PoolerList {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PoolerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerMonitoringConfiguration) DeepCopyInto(out *PoolerMonitoringConfiguration) {
+	*out = *in
+	if in.PodMonitorMetricRelabelConfigs != nil {
+		in, out := &in.PodMonitorMetricRelabelConfigs, &out.PodMonitorMetricRelabelConfigs
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodMonitorRelabelConfigs != nil {
+		in, out := &in.PodMonitorRelabelConfigs, &out.PodMonitorRelabelConfigs
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerMonitoringConfiguration.
+func (in *PoolerMonitoringConfiguration) DeepCopy() *PoolerMonitoringConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerMonitoringConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerSecrets) DeepCopyInto(out *PoolerSecrets) {
+	*out = *in
+	out.ServerTLS = in.ServerTLS
+	out.ServerCA = in.ServerCA
+	out.ClientCA = in.ClientCA
+	if in.PgBouncerSecrets != nil {
+		in, out := &in.PgBouncerSecrets, &out.PgBouncerSecrets
+		*out = new(PgBouncerSecrets)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerSecrets.
+func (in *PoolerSecrets) DeepCopy() *PoolerSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerSpec) DeepCopyInto(out *PoolerSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PgBouncer != nil {
+		in, out := &in.PgBouncer, &out.PgBouncer
+		*out = new(PgBouncerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PgCat != nil {
+		in, out := &in.PgCat, &out.PgCat
+		*out = new(PgCatSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnablePDB != nil {
+		in, out := &in.EnablePDB, &out.EnablePDB
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(PoolerMonitoringConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceTemplate != nil {
+		in, out := &in.ServiceTemplate, &out.ServiceTemplate
+		*out = new(ServiceTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]PoolerAdditionalService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerSpec.
+func (in *PoolerSpec) DeepCopy() *PoolerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerStats) DeepCopyInto(out *PoolerStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerStats.
+func (in *PoolerStats) DeepCopy() *PoolerStats {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerStatus) DeepCopyInto(out *PoolerStatus) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = new(PoolerSecrets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]PgBouncerPoolStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SwitchoverPausedInstances != nil {
+		in, out := &in.SwitchoverPausedInstances, &out.SwitchoverPausedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateReloadedInstances != nil {
+		in, out := &in.CertificateReloadedInstances, &out.CertificateReloadedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(PoolerStats)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerStatus.
+func (in *PoolerStatus) DeepCopy() *PoolerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Synchronous != nil {
+		in, out := &in.Synchronous, &out.Synchronous
+		*out = new(SynchronousReplicaConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PgHBA != nil {
+		in, out := &in.PgHBA, &out.PgHBA
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PgHBARules != nil {
+		in, out := &in.PgHBARules, &out.PgHBARules
+		*out = make([]PgHBARule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PgIdent != nil {
+		in, out := &in.PgIdent, &out.PgIdent
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.SyncReplicaElectionConstraint.DeepCopyInto(&out.SyncReplicaElectionConstraint)
+	if in.AdditionalLibraries != nil {
+		in, out := &in.AdditionalLibraries, &out.AdditionalLibraries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(OAuthConfig)
+		**out = **in
+	}
+	if in.TDE != nil {
+		in, out := &in.TDE, &out.TDE
+		*out = new(TDEConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(AuditConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfiguration.
+func (in *PostgresConfiguration) DeepCopy() *PostgresConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryLSNStatus) DeepCopyInto(out *PrimaryLSNStatus) {
+	*out = *in
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryLSNStatus.
+func (in *PrimaryLSNStatus) DeepCopy() *PrimaryLSNStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryLSNStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probe) DeepCopyInto(out *Probe) {
+	*out = *in
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
+func (in *Probe) DeepCopy() *Probe {
+	if in == nil {
+		return nil
+	}
+	out := new(Probe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbesConfiguration) DeepCopyInto(out *ProbesConfiguration) {
+	*out = *in
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesConfiguration.
+func (in *ProbesConfiguration) DeepCopy() *ProbesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Publication) DeepCopyInto(out *Publication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Publication.
+func (in *Publication) DeepCopy() *Publication {
+	if in == nil {
+		return nil
+	}
+	out := new(Publication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Publication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicationList) DeepCopyInto(out *PublicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Publication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationList.
+func (in *PublicationList) DeepCopy() *PublicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicationSpec) DeepCopyInto(out *PublicationSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationSpec.
+func (in *PublicationSpec) DeepCopy() *PublicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicationStatus) DeepCopyInto(out *PublicationStatus) {
+	*out = *in
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationStatus.
+func (in *PublicationStatus) DeepCopy() *PublicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicationTarget) DeepCopyInto(out *PublicationTarget) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]PublicationTargetObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationTarget.
+func (in *PublicationTarget) DeepCopy() *PublicationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicationTargetObject) DeepCopyInto(out *PublicationTargetObject) {
+	*out = *in
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(PublicationTargetTable)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationTargetObject.
+func (in *PublicationTargetObject) DeepCopy() *PublicationTargetObject {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicationTargetObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicationTargetTable) DeepCopyInto(out *PublicationTargetTable) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationTargetTable.
+func (in *PublicationTargetTable) DeepCopy() *PublicationTargetTable {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicationTargetTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryInsightsConfiguration) DeepCopyInto(out *QueryInsightsConfiguration) {
+	*out = *in
+	if in.MaxStatements != nil {
+		in, out := &in.MaxStatements, &out.MaxStatements
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SnapshotInterval != nil {
+		in, out := &in.SnapshotInterval, &out.SnapshotInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(QueryInsightsHistory)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryInsightsConfiguration.
+func (in *QueryInsightsConfiguration) DeepCopy() *QueryInsightsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryInsightsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryInsightsHistory) DeepCopyInto(out *QueryInsightsHistory) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryInsightsHistory.
+func (in *QueryInsightsHistory) DeepCopy() *QueryInsightsHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryInsightsHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryTarget) DeepCopyInto(out *RecoveryTarget) {
+	*out = *in
+	if in.TargetImmediate != nil {
+		in, out := &in.TargetImmediate, &out.TargetImmediate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Exclusive != nil {
+		in, out := &in.Exclusive, &out.Exclusive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryTarget.
+func (in *RecoveryTarget) DeepCopy() *RecoveryTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaClusterConfiguration) DeepCopyInto(out *ReplicaClusterConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinApplyDelay != nil {
+		in, out := &in.MinApplyDelay, &out.MinApplyDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make([]ReplicaClusterTopologyLink, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromotionTokenSecret != nil {
+		in, out := &in.PromotionTokenSecret, &out.PromotionTokenSecret
+		*out = new(api.LocalObjectReference)
+		**out = **in
+	}
+	if in.Synchronous != nil {
+		in, out := &in.Synchronous, &out.Synchronous
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaClusterConfiguration.
+func (in *ReplicaClusterConfiguration) DeepCopy() *ReplicaClusterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaClusterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaClusterTopologyLink) DeepCopyInto(out *ReplicaClusterTopologyLink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaClusterTopologyLink.
+func (in *ReplicaClusterTopologyLink) DeepCopy() *ReplicaClusterTopologyLink {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaClusterTopologyLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlotsConfiguration) DeepCopyInto(out *ReplicationSlotsConfiguration) {
+	*out = *in
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(ReplicationSlotsHAConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SynchronizeReplicas != nil {
+		in, out := &in.SynchronizeReplicas, &out.SynchronizeReplicas
+		*out = new(SynchronizeReplicasConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SynchronizeLogicalSlots != nil {
+		in, out := &in.SynchronizeLogicalSlots, &out.SynchronizeLogicalSlots
+		*out = new(SynchronizeLogicalSlotsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Safeguard != nil {
+		in, out := &in.Safeguard, &out.Safeguard
+		*out = new(ReplicationSlotsSafeguardConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]AdditionalReplicationSlot, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotsConfiguration.
+func (in *ReplicationSlotsConfiguration) DeepCopy() *ReplicationSlotsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlotsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlotsHAConfiguration) DeepCopyInto(out *ReplicationSlotsHAConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotsHAConfiguration.
+func (in *ReplicationSlotsHAConfiguration) DeepCopy() *ReplicationSlotsHAConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlotsHAConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlotsSafeguardConfiguration) DeepCopyInto(out *ReplicationSlotsSafeguardConfiguration) {
+	*out = *in
+	if in.MaxInactiveTime != nil {
+		in, out := &in.MaxInactiveTime, &out.MaxInactiveTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotsSafeguardConfiguration.
+func (in *ReplicationSlotsSafeguardConfiguration) DeepCopy() *ReplicationSlotsSafeguardConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlotsSafeguardConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleConfiguration) DeepCopyInto(out *RoleConfiguration) {
+	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(api.LocalObjectReference)
+		**out = **in
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(RolePasswordRotation)
+		**out = **in
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.InRoles != nil {
+		in, out := &in.InRoles, &out.InRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Inherit != nil {
+		in, out := &in.Inherit, &out.Inherit
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleConfiguration.
+func (in *RoleConfiguration) DeepCopy() *RoleConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolePasswordRotation) DeepCopyInto(out *RolePasswordRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePasswordRotation.
+func (in *RolePasswordRotation) DeepCopy() *RolePasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(RolePasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateStatus) DeepCopyInto(out *RollingUpdateStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStatus.
+func (in *RollingUpdateStatus) DeepCopy() *RollingUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLRefs) DeepCopyInto(out *SQLRefs) {
+	*out = *in
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]api.SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapRefs != nil {
+		in, out := &in.ConfigMapRefs, &out.ConfigMapRefs
+		*out = make([]api.ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLRefs.
+func (in *SQLRefs) DeepCopy() *SQLRefs {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLRefs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledBackup) DeepCopyInto(out *ScheduledBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackup.
+func (in *ScheduledBackup) DeepCopy() *ScheduledBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledBackupList) DeepCopyInto(out *ScheduledBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScheduledBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupList.
+func (in *ScheduledBackupList) DeepCopy() *ScheduledBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledBackupSpec) DeepCopyInto(out *ScheduledBackupSpec) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Immediate != nil {
+		in, out := &in.Immediate, &out.Immediate
+		*out = new(bool)
+		**out = **in
+	}
+	out.Cluster = in.Cluster
+	if in.PluginConfiguration != nil {
+		in, out := &in.PluginConfiguration, &out.PluginConfiguration
+		*out = new(BackupPluginConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Online != nil {
+		in, out := &in.Online, &out.Online
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnlineConfiguration != nil {
+		in, out := &in.OnlineConfiguration, &out.OnlineConfiguration
+		*out = new(OnlineConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostponeStartWindows != nil {
+		in, out := &in.PostponeStartWindows, &out.PostponeStartWindows
+		*out = make([]BackupPostponeStartWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupSpec.
+func (in *ScheduledBackupSpec) DeepCopy() *ScheduledBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledBackupStatus) DeepCopyInto(out *ScheduledBackupStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupStatus.
+func (in *ScheduledBackupStatus) DeepCopy() *ScheduledBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbesConfiguration)
+	out := new(ScheduledBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Publication) DeepCopyInto(out *Publication) {
+func (in *ScheduledDump) DeepCopyInto(out *ScheduledDump) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -2238,18 +5118,18 @@ func (in *Publication) DeepCopyInto(out *Publication) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Publication.
-func (in *Publication) DeepCopy() *Publication {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledDump.
+func (in *ScheduledDump) DeepCopy() *ScheduledDump {
 	if in == nil {
 		return nil
 	}
-	out := new(Publication)
+	out := new(ScheduledDump)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Publication) DeepCopyObject() runtime.Object {
+func (in *ScheduledDump) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2257,31 +5137,31 @@ func (in *Publication) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicationList) DeepCopyInto(out *PublicationList) {
+func (in *ScheduledDumpList) DeepCopyInto(out *ScheduledDumpList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Publication, len(*in))
+		*out = make([]ScheduledDump, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationList.
-func (in *PublicationList) DeepCopy() *PublicationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledDumpList.
+func (in *ScheduledDumpList) DeepCopy() *ScheduledDumpList {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicationList)
+	out := new(ScheduledDumpList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PublicationList) DeepCopyObject() runtime.Object {
+func (in *ScheduledDumpList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2289,283 +5169,328 @@ func (in *PublicationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicationSpec) DeepCopyInto(out *PublicationSpec) {
+func (in *ScheduledDumpSpec) DeepCopyInto(out *ScheduledDumpSpec) {
 	*out = *in
-	out.ClusterRef = in.ClusterRef
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Immediate != nil {
+		in, out := &in.Immediate, &out.Immediate
+		*out = new(bool)
+		**out = **in
+	}
+	out.Cluster = in.Cluster
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(int)
+		**out = **in
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(int)
+		**out = **in
 	}
-	in.Target.DeepCopyInto(&out.Target)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationSpec.
-func (in *PublicationSpec) DeepCopy() *PublicationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledDumpSpec.
+func (in *ScheduledDumpSpec) DeepCopy() *ScheduledDumpSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicationSpec)
+	out := new(ScheduledDumpSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicationStatus) DeepCopyInto(out *PublicationStatus) {
+func (in *ScheduledDumpStatus) DeepCopyInto(out *ScheduledDumpStatus) {
 	*out = *in
-	if in.Applied != nil {
-		in, out := &in.Applied, &out.Applied
-		*out = new(bool)
-		**out = **in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDump != nil {
+		in, out := &in.LastDump, &out.LastDump
+		*out = new(DumpStatus)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationStatus.
-func (in *PublicationStatus) DeepCopy() *PublicationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledDumpStatus.
+func (in *ScheduledDumpStatus) DeepCopy() *ScheduledDumpStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicationStatus)
+	out := new(ScheduledDumpStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicationTarget) DeepCopyInto(out *PublicationTarget) {
+func (in *SchemaSpec) DeepCopyInto(out *SchemaSpec) {
 	*out = *in
-	if in.Objects != nil {
-		in, out := &in.Objects, &out.Objects
-		*out = make([]PublicationTargetObject, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationTarget.
-func (in *PublicationTarget) DeepCopy() *PublicationTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaSpec.
+func (in *SchemaSpec) DeepCopy() *SchemaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicationTarget)
+	out := new(SchemaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicationTargetObject) DeepCopyInto(out *PublicationTargetObject) {
+func (in *SchemaStatus) DeepCopyInto(out *SchemaStatus) {
 	*out = *in
-	if in.Table != nil {
-		in, out := &in.Table, &out.Table
-		*out = new(PublicationTargetTable)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationTargetObject.
-func (in *PublicationTargetObject) DeepCopy() *PublicationTargetObject {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaStatus.
+func (in *SchemaStatus) DeepCopy() *SchemaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicationTargetObject)
+	out := new(SchemaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicationTargetTable) DeepCopyInto(out *PublicationTargetTable) {
+func (in *SecretVersion) DeepCopyInto(out *SecretVersion) {
 	*out = *in
-	if in.Columns != nil {
-		in, out := &in.Columns, &out.Columns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationTargetTable.
-func (in *PublicationTargetTable) DeepCopy() *PublicationTargetTable {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVersion.
+func (in *SecretVersion) DeepCopy() *SecretVersion {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicationTargetTable)
+	out := new(SecretVersion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RecoveryTarget) DeepCopyInto(out *RecoveryTarget) {
+func (in *SecretsResourceVersion) DeepCopyInto(out *SecretsResourceVersion) {
 	*out = *in
-	if in.TargetImmediate != nil {
-		in, out := &in.TargetImmediate, &out.TargetImmediate
-		*out = new(bool)
-		**out = **in
+	if in.ManagedRoleSecretVersions != nil {
+		in, out := &in.ManagedRoleSecretVersions, &out.ManagedRoleSecretVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.Exclusive != nil {
-		in, out := &in.Exclusive, &out.Exclusive
-		*out = new(bool)
-		**out = **in
+	if in.ExternalClusterSecretVersions != nil {
+		in, out := &in.ExternalClusterSecretVersions, &out.ExternalClusterSecretVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryTarget.
-func (in *RecoveryTarget) DeepCopy() *RecoveryTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsResourceVersion.
+func (in *SecretsResourceVersion) DeepCopy() *SecretsResourceVersion {
 	if in == nil {
 		return nil
 	}
-	out := new(RecoveryTarget)
+	out := new(SecretsResourceVersion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicaClusterConfiguration) DeepCopyInto(out *ReplicaClusterConfiguration) {
+func (in *SecurityConfiguration) DeepCopyInto(out *SecurityConfiguration) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.MinApplyDelay != nil {
-		in, out := &in.MinApplyDelay, &out.MinApplyDelay
-		*out = new(metav1.Duration)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaClusterConfiguration.
-func (in *ReplicaClusterConfiguration) DeepCopy() *ReplicaClusterConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfiguration.
+func (in *SecurityConfiguration) DeepCopy() *SecurityConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicaClusterConfiguration)
+	out := new(SecurityConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSlotsConfiguration) DeepCopyInto(out *ReplicationSlotsConfiguration) {
+func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 	*out = *in
-	if in.HighAvailability != nil {
-		in, out := &in.HighAvailability, &out.HighAvailability
-		*out = new(ReplicationSlotsHAConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.SynchronizeReplicas != nil {
-		in, out := &in.SynchronizeReplicas, &out.SynchronizeReplicas
-		*out = new(SynchronizeReplicasConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.UserMappings != nil {
+		in, out := &in.UserMappings, &out.UserMappings
+		*out = make([]UserMappingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotsConfiguration.
-func (in *ReplicationSlotsConfiguration) DeepCopy() *ReplicationSlotsConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSpec.
+func (in *ServerSpec) DeepCopy() *ServerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSlotsConfiguration)
+	out := new(ServerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSlotsHAConfiguration) DeepCopyInto(out *ReplicationSlotsHAConfiguration) {
+func (in *ServerStatus) DeepCopyInto(out *ServerStatus) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerStatus.
+func (in *ServerStatus) DeepCopy() *ServerStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(ServerStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotsHAConfiguration.
-func (in *ReplicationSlotsHAConfiguration) DeepCopy() *ReplicationSlotsHAConfiguration {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTemplate) DeepCopyInto(out *ServiceAccountTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTemplate.
+func (in *ServiceAccountTemplate) DeepCopy() *ServiceAccountTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSlotsHAConfiguration)
+	out := new(ServiceAccountTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleConfiguration) DeepCopyInto(out *RoleConfiguration) {
+func (in *ServiceRoutingConfiguration) DeepCopyInto(out *ServiceRoutingConfiguration) {
 	*out = *in
-	if in.PasswordSecret != nil {
-		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(api.LocalObjectReference)
+	if in.SessionAffinityTimeoutSeconds != nil {
+		in, out := &in.SessionAffinityTimeoutSeconds, &out.SessionAffinityTimeoutSeconds
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ValidUntil != nil {
-		in, out := &in.ValidUntil, &out.ValidUntil
-		*out = (*in).DeepCopy()
-	}
-	if in.InRoles != nil {
-		in, out := &in.InRoles, &out.InRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Inherit != nil {
-		in, out := &in.Inherit, &out.Inherit
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRoutingConfiguration.
+func (in *ServiceRoutingConfiguration) DeepCopy() *ServiceRoutingConfiguration {
+	if in == nil {
+		return nil
 	}
+	out := new(ServiceRoutingConfiguration)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleConfiguration.
-func (in *RoleConfiguration) DeepCopy() *RoleConfiguration {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceTemplateSpec) DeepCopyInto(out *ServiceTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTemplateSpec.
+func (in *ServiceTemplateSpec) DeepCopy() *ServiceTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleConfiguration)
+	out := new(ServiceTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollingUpdateStatus) DeepCopyInto(out *RollingUpdateStatus) {
+func (in *StorageAutoResizeConfiguration) DeepCopyInto(out *StorageAutoResizeConfiguration) {
 	*out = *in
-	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStatus.
-func (in *RollingUpdateStatus) DeepCopy() *RollingUpdateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoResizeConfiguration.
+func (in *StorageAutoResizeConfiguration) DeepCopy() *StorageAutoResizeConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(RollingUpdateStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SQLRefs) DeepCopyInto(out *SQLRefs) {
-	*out = *in
-	if in.SecretRefs != nil {
-		in, out := &in.SecretRefs, &out.SecretRefs
-		*out = make([]api.SecretKeySelector, len(*in))
-		copy(*out, *in)
+	out := new(StorageAutoResizeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageConfiguration) DeepCopyInto(out *StorageConfiguration) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResizeInUseVolumes != nil {
+		in, out := &in.ResizeInUseVolumes, &out.ResizeInUseVolumes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PersistentVolumeClaimTemplate != nil {
+		in, out := &in.PersistentVolumeClaimTemplate, &out.PersistentVolumeClaimTemplate
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ConfigMapRefs != nil {
-		in, out := &in.ConfigMapRefs, &out.ConfigMapRefs
-		*out = make([]api.ConfigMapKeySelector, len(*in))
-		copy(*out, *in)
+	if in.AutoResize != nil {
+		in, out := &in.AutoResize, &out.AutoResize
+		*out = new(StorageAutoResizeConfiguration)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLRefs.
-func (in *SQLRefs) DeepCopy() *SQLRefs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfiguration.
+func (in *StorageConfiguration) DeepCopy() *StorageConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(SQLRefs)
+	out := new(StorageConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledBackup) DeepCopyInto(out *ScheduledBackup) {
+func (in *Subscription) DeepCopyInto(out *Subscription) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -2573,18 +5498,18 @@ func (in *ScheduledBackup) DeepCopyInto(out *ScheduledBackup) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackup.
-func (in *ScheduledBackup) DeepCopy() *ScheduledBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subscription.
+func (in *Subscription) DeepCopy() *Subscription {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledBackup)
+	out := new(Subscription)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ScheduledBackup) DeepCopyObject() runtime.Object {
+func (in *Subscription) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2592,31 +5517,47 @@ func (in *ScheduledBackup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledBackupList) DeepCopyInto(out *ScheduledBackupList) {
+func (in *SubscriptionApplyError) DeepCopyInto(out *SubscriptionApplyError) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionApplyError.
+func (in *SubscriptionApplyError) DeepCopy() *SubscriptionApplyError {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionApplyError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionList) DeepCopyInto(out *SubscriptionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ScheduledBackup, len(*in))
+		*out = make([]Subscription, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupList.
-func (in *ScheduledBackupList) DeepCopy() *ScheduledBackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionList.
+func (in *SubscriptionList) DeepCopy() *SubscriptionList {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledBackupList)
+	out := new(SubscriptionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ScheduledBackupList) DeepCopyObject() runtime.Object {
+func (in *SubscriptionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2624,376 +5565,319 @@ func (in *ScheduledBackupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledBackupSpec) DeepCopyInto(out *ScheduledBackupSpec) {
+func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
 	*out = *in
-	if in.Suspend != nil {
-		in, out := &in.Suspend, &out.Suspend
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Immediate != nil {
-		in, out := &in.Immediate, &out.Immediate
-		*out = new(bool)
-		**out = **in
-	}
-	out.Cluster = in.Cluster
-	if in.PluginConfiguration != nil {
-		in, out := &in.PluginConfiguration, &out.PluginConfiguration
-		*out = new(BackupPluginConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Online != nil {
-		in, out := &in.Online, &out.Online
-		*out = new(bool)
-		**out = **in
+	out.ClusterRef = in.ClusterRef
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.OnlineConfiguration != nil {
-		in, out := &in.OnlineConfiguration, &out.OnlineConfiguration
-		*out = new(OnlineConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.SkipErrorCodes != nil {
+		in, out := &in.SkipErrorCodes, &out.SkipErrorCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupSpec.
-func (in *ScheduledBackupSpec) DeepCopy() *ScheduledBackupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSpec.
+func (in *SubscriptionSpec) DeepCopy() *SubscriptionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledBackupSpec)
+	out := new(SubscriptionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledBackupStatus) DeepCopyInto(out *ScheduledBackupStatus) {
+func (in *SubscriptionStatus) DeepCopyInto(out *SubscriptionStatus) {
 	*out = *in
-	if in.LastCheckTime != nil {
-		in, out := &in.LastCheckTime, &out.LastCheckTime
-		*out = (*in).DeepCopy()
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = new(bool)
+		**out = **in
 	}
-	if in.LastScheduleTime != nil {
-		in, out := &in.LastScheduleTime, &out.LastScheduleTime
-		*out = (*in).DeepCopy()
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
 	}
-	if in.NextScheduleTime != nil {
-		in, out := &in.NextScheduleTime, &out.NextScheduleTime
-		*out = (*in).DeepCopy()
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LastApplyError != nil {
+		in, out := &in.LastApplyError, &out.LastApplyError
+		*out = new(SubscriptionApplyError)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupStatus.
-func (in *ScheduledBackupStatus) DeepCopy() *ScheduledBackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionStatus.
+func (in *SubscriptionStatus) DeepCopy() *SubscriptionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledBackupStatus)
+	out := new(SubscriptionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretVersion) DeepCopyInto(out *SecretVersion) {
+func (in *SwitchReplicaClusterStatus) DeepCopyInto(out *SwitchReplicaClusterStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVersion.
-func (in *SecretVersion) DeepCopy() *SecretVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchReplicaClusterStatus.
+func (in *SwitchReplicaClusterStatus) DeepCopy() *SwitchReplicaClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretVersion)
+	out := new(SwitchReplicaClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretsResourceVersion) DeepCopyInto(out *SecretsResourceVersion) {
+func (in *SyncReplicaElectionConstraints) DeepCopyInto(out *SyncReplicaElectionConstraints) {
 	*out = *in
-	if in.ManagedRoleSecretVersions != nil {
-		in, out := &in.ManagedRoleSecretVersions, &out.ManagedRoleSecretVersions
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ExternalClusterSecretVersions != nil {
-		in, out := &in.ExternalClusterSecretVersions, &out.ExternalClusterSecretVersions
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.NodeLabelsAntiAffinity != nil {
+		in, out := &in.NodeLabelsAntiAffinity, &out.NodeLabelsAntiAffinity
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsResourceVersion.
-func (in *SecretsResourceVersion) DeepCopy() *SecretsResourceVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncReplicaElectionConstraints.
+func (in *SyncReplicaElectionConstraints) DeepCopy() *SyncReplicaElectionConstraints {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretsResourceVersion)
+	out := new(SyncReplicaElectionConstraints)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccountTemplate) DeepCopyInto(out *ServiceAccountTemplate) {
+func (in *SynchronizeLogicalSlotsConfiguration) DeepCopyInto(out *SynchronizeLogicalSlotsConfiguration) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.SlotNames != nil {
+		in, out := &in.SlotNames, &out.SlotNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTemplate.
-func (in *ServiceAccountTemplate) DeepCopy() *ServiceAccountTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronizeLogicalSlotsConfiguration.
+func (in *SynchronizeLogicalSlotsConfiguration) DeepCopy() *SynchronizeLogicalSlotsConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccountTemplate)
+	out := new(SynchronizeLogicalSlotsConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceTemplateSpec) DeepCopyInto(out *ServiceTemplateSpec) {
+func (in *SynchronizeReplicasConfiguration) DeepCopyInto(out *SynchronizeReplicasConfiguration) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExcludePatterns != nil {
+		in, out := &in.ExcludePatterns, &out.ExcludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.synchronizeReplicasCache.DeepCopyInto(&out.synchronizeReplicasCache)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTemplateSpec.
-func (in *ServiceTemplateSpec) DeepCopy() *ServiceTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronizeReplicasConfiguration.
+func (in *SynchronizeReplicasConfiguration) DeepCopy() *SynchronizeReplicasConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceTemplateSpec)
+	out := new(SynchronizeReplicasConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageConfiguration) DeepCopyInto(out *StorageConfiguration) {
+func (in *SynchronousReplicaConfiguration) DeepCopyInto(out *SynchronousReplicaConfiguration) {
 	*out = *in
-	if in.StorageClass != nil {
-		in, out := &in.StorageClass, &out.StorageClass
-		*out = new(string)
+	if in.QuorumPercentage != nil {
+		in, out := &in.QuorumPercentage, &out.QuorumPercentage
+		*out = new(int)
 		**out = **in
 	}
-	if in.ResizeInUseVolumes != nil {
-		in, out := &in.ResizeInUseVolumes, &out.ResizeInUseVolumes
-		*out = new(bool)
+	if in.MaxStandbyNamesFromCluster != nil {
+		in, out := &in.MaxStandbyNamesFromCluster, &out.MaxStandbyNamesFromCluster
+		*out = new(int)
 		**out = **in
 	}
-	if in.PersistentVolumeClaimTemplate != nil {
-		in, out := &in.PersistentVolumeClaimTemplate, &out.PersistentVolumeClaimTemplate
-		*out = new(corev1.PersistentVolumeClaimSpec)
-		(*in).DeepCopyInto(*out)
+	if in.StandbyNamesPre != nil {
+		in, out := &in.StandbyNamesPre, &out.StandbyNamesPre
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfiguration.
-func (in *StorageConfiguration) DeepCopy() *StorageConfiguration {
-	if in == nil {
-		return nil
+	if in.StandbyNamesPost != nil {
+		in, out := &in.StandbyNamesPost, &out.StandbyNamesPost
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(StorageConfiguration)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Subscription) DeepCopyInto(out *Subscription) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subscription.
-func (in *Subscription) DeepCopy() *Subscription {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronousReplicaConfiguration.
+func (in *SynchronousReplicaConfiguration) DeepCopy() *SynchronousReplicaConfiguration {
 	if in == nil {
-		return nil
-	}
-	out := new(Subscription)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Subscription) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+		return nil
 	}
-	return nil
+	out := new(SynchronousReplicaConfiguration)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubscriptionList) DeepCopyInto(out *SubscriptionList) {
+func (in *SyslogSink) DeepCopyInto(out *SyslogSink) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Subscription, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionList.
-func (in *SubscriptionList) DeepCopy() *SubscriptionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogSink.
+func (in *SyslogSink) DeepCopy() *SyslogSink {
 	if in == nil {
 		return nil
 	}
-	out := new(SubscriptionList)
+	out := new(SyslogSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SubscriptionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
+func (in *TDEConfiguration) DeepCopyInto(out *TDEConfiguration) {
 	*out = *in
-	out.ClusterRef = in.ClusterRef
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.KeyProvider.DeepCopyInto(&out.KeyProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSpec.
-func (in *SubscriptionSpec) DeepCopy() *SubscriptionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TDEConfiguration.
+func (in *TDEConfiguration) DeepCopy() *TDEConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(SubscriptionSpec)
+	out := new(TDEConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubscriptionStatus) DeepCopyInto(out *SubscriptionStatus) {
+func (in *TDEKeyProvider) DeepCopyInto(out *TDEKeyProvider) {
 	*out = *in
-	if in.Applied != nil {
-		in, out := &in.Applied, &out.Applied
-		*out = new(bool)
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(api.SecretKeySelector)
 		**out = **in
 	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(TDEVaultKeyProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionStatus.
-func (in *SubscriptionStatus) DeepCopy() *SubscriptionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TDEKeyProvider.
+func (in *TDEKeyProvider) DeepCopy() *TDEKeyProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(SubscriptionStatus)
+	out := new(TDEKeyProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwitchReplicaClusterStatus) DeepCopyInto(out *SwitchReplicaClusterStatus) {
+func (in *TDEVaultKeyProvider) DeepCopyInto(out *TDEVaultKeyProvider) {
 	*out = *in
+	out.Token = in.Token
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchReplicaClusterStatus.
-func (in *SwitchReplicaClusterStatus) DeepCopy() *SwitchReplicaClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TDEVaultKeyProvider.
+func (in *TDEVaultKeyProvider) DeepCopy() *TDEVaultKeyProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(SwitchReplicaClusterStatus)
+	out := new(TDEVaultKeyProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SyncReplicaElectionConstraints) DeepCopyInto(out *SyncReplicaElectionConstraints) {
+func (in *TableAutovacuumSpec) DeepCopyInto(out *TableAutovacuumSpec) {
 	*out = *in
-	if in.NodeLabelsAntiAffinity != nil {
-		in, out := &in.NodeLabelsAntiAffinity, &out.NodeLabelsAntiAffinity
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncReplicaElectionConstraints.
-func (in *SyncReplicaElectionConstraints) DeepCopy() *SyncReplicaElectionConstraints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableAutovacuumSpec.
+func (in *TableAutovacuumSpec) DeepCopy() *TableAutovacuumSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SyncReplicaElectionConstraints)
+	out := new(TableAutovacuumSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SynchronizeReplicasConfiguration) DeepCopyInto(out *SynchronizeReplicasConfiguration) {
+func (in *TableAutovacuumStatus) DeepCopyInto(out *TableAutovacuumStatus) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ExcludePatterns != nil {
-		in, out := &in.ExcludePatterns, &out.ExcludePatterns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.synchronizeReplicasCache.DeepCopyInto(&out.synchronizeReplicasCache)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronizeReplicasConfiguration.
-func (in *SynchronizeReplicasConfiguration) DeepCopy() *SynchronizeReplicasConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableAutovacuumStatus.
+func (in *TableAutovacuumStatus) DeepCopy() *TableAutovacuumStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SynchronizeReplicasConfiguration)
+	out := new(TableAutovacuumStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SynchronousReplicaConfiguration) DeepCopyInto(out *SynchronousReplicaConfiguration) {
+func (in *TableStatisticsConfiguration) DeepCopyInto(out *TableStatisticsConfiguration) {
 	*out = *in
-	if in.MaxStandbyNamesFromCluster != nil {
-		in, out := &in.MaxStandbyNamesFromCluster, &out.MaxStandbyNamesFromCluster
-		*out = new(int)
-		**out = **in
-	}
-	if in.StandbyNamesPre != nil {
-		in, out := &in.StandbyNamesPre, &out.StandbyNamesPre
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.StandbyNamesPost != nil {
-		in, out := &in.StandbyNamesPost, &out.StandbyNamesPost
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.SamplingInterval != nil {
+		in, out := &in.SamplingInterval, &out.SamplingInterval
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronousReplicaConfiguration.
-func (in *SynchronousReplicaConfiguration) DeepCopy() *SynchronousReplicaConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableStatisticsConfiguration.
+func (in *TableStatisticsConfiguration) DeepCopy() *TableStatisticsConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(SynchronousReplicaConfiguration)
+	out := new(TableStatisticsConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3003,6 +5887,11 @@ func (in *TablespaceConfiguration) DeepCopyInto(out *TablespaceConfiguration) {
 	*out = *in
 	in.Storage.DeepCopyInto(&out.Storage)
 	out.Owner = in.Owner
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(EphemeralTablespaceConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TablespaceConfiguration.
@@ -3063,6 +5952,103 @@ func (in *Topology) DeepCopy() *Topology {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyConfiguration) DeepCopyInto(out *TopologyConfiguration) {
+	*out = *in
+	if in.PrimaryZones != nil {
+		in, out := &in.PrimaryZones, &out.PrimaryZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyConfiguration.
+func (in *TopologyConfiguration) DeepCopy() *TopologyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserMappingSpec) DeepCopyInto(out *UserMappingSpec) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserMappingSpec.
+func (in *UserMappingSpec) DeepCopy() *UserMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKICertificateProvider) DeepCopyInto(out *VaultPKICertificateProvider) {
+	*out = *in
+	out.Token = in.Token
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(api.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKICertificateProvider.
+func (in *VaultPKICertificateProvider) DeepCopy() *VaultPKICertificateProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKICertificateProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalPodAutoscalerConfiguration) DeepCopyInto(out *VerticalPodAutoscalerConfiguration) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Tolerance != nil {
+		in, out := &in.Tolerance, &out.Tolerance
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalPodAutoscalerConfiguration.
+func (in *VerticalPodAutoscalerConfiguration) DeepCopy() *VerticalPodAutoscalerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalPodAutoscalerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotConfiguration) DeepCopyInto(out *VolumeSnapshotConfiguration) {
 	*out = *in
@@ -3093,6 +6079,16 @@ func (in *VolumeSnapshotConfiguration) DeepCopyInto(out *VolumeSnapshotConfigura
 		**out = **in
 	}
 	in.OnlineConfiguration.DeepCopyInto(&out.OnlineConfiguration)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(VolumeSnapshotRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Incremental != nil {
+		in, out := &in.Incremental, &out.Incremental
+		*out = new(VolumeSnapshotIncrementalConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotConfiguration.
@@ -3104,3 +6100,108 @@ func (in *VolumeSnapshotConfiguration) DeepCopy() *VolumeSnapshotConfiguration {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotIncrementalConfiguration) DeepCopyInto(out *VolumeSnapshotIncrementalConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotIncrementalConfiguration.
+func (in *VolumeSnapshotIncrementalConfiguration) DeepCopy() *VolumeSnapshotIncrementalConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotIncrementalConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotRetentionPolicy) DeepCopyInto(out *VolumeSnapshotRetentionPolicy) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotRetentionPolicy.
+func (in *VolumeSnapshotRetentionPolicy) DeepCopy() *VolumeSnapshotRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WALArchiveHealthConfiguration) DeepCopyInto(out *WALArchiveHealthConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WALArchiveHealthConfiguration.
+func (in *WALArchiveHealthConfiguration) DeepCopy() *WALArchiveHealthConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WALArchiveHealthConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WALSpaceProtectionConfiguration) DeepCopyInto(out *WALSpaceProtectionConfiguration) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FencingThreshold != nil {
+		in, out := &in.FencingThreshold, &out.FencingThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WALSpaceProtectionConfiguration.
+func (in *WALSpaceProtectionConfiguration) DeepCopy() *WALSpaceProtectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WALSpaceProtectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WitnessConfiguration) DeepCopyInto(out *WitnessConfiguration) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WitnessConfiguration.
+func (in *WitnessConfiguration) DeepCopy() *WitnessConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WitnessConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -27,7 +27,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/approvefailover"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/backup"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/bench"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/certificate"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/destroy"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/fence"
@@ -40,13 +42,18 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/maintenance"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/pgadmin"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/pgbench"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/podspecpreview"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/promote"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/psql"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/reload"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/report"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/restart"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/restore"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/snapshot"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/status"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/top"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/validate"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/walcleanup"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/versions"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -67,8 +74,9 @@ func main() {
 			logFlags.ConfigureLogging()
 
 			// If we're invoking the completion command we shouldn't try to create
-			// a Kubernetes client and we just let the Cobra flow to continue
-			if cmd.Name() == "completion" || cmd.Name() == "version" ||
+			// a Kubernetes client and we just let the Cobra flow to continue.
+			// The same applies to the commands working offline
+			if cmd.Name() == "completion" || cmd.Name() == "version" || cmd.Name() == "validate" ||
 				cmd.HasParent() && cmd.Parent().Name() == "completion" {
 				return nil
 			}
@@ -110,7 +118,9 @@ func main() {
 	rootCmd.AddGroup(adminGroup, troubleshootingGroup, pgClusterGroup, pgDatabaseGroup, miscGroup)
 
 	subcommands := []*cobra.Command{
+		approvefailover.NewCmd(),
 		backup.NewCmd(),
+		bench.NewCmd(),
 		certificate.NewCmd(),
 		destroy.NewCmd(),
 		fence.NewCmd(),
@@ -121,16 +131,21 @@ func main() {
 		maintenance.NewCmd(),
 		pgadmin.NewCmd(),
 		pgbench.NewCmd(),
+		podspecpreview.NewCmd(),
 		promote.NewCmd(),
 		psql.NewCmd(),
 		publication.NewCmd(),
 		reload.NewCmd(),
 		report.NewCmd(),
 		restart.NewCmd(),
+		restore.NewCmd(),
 		snapshot.NewCmd(),
 		status.NewCmd(),
 		subscription.NewCmd(),
+		top.NewCmd(),
+		validate.NewCmd(),
 		versions.NewCmd(),
+		walcleanup.NewCmd(),
 	}
 
 	for _, cmd := range subcommands {
@@ -0,0 +1,94 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// getCacheOptions gets the options of the cache of the operator, restricting
+// the watched resources and dropping the fields the operator doesn't need
+func getCacheOptions(conf *configuration.Data) (cache.Options, error) {
+	options := cache.Options{
+		DefaultTransform: stripUnneededFields,
+		ByObject:         make(map[client.Object]cache.ByObject),
+	}
+
+	selector, err := conf.GetWatchLabelSelector()
+	if err != nil {
+		return cache.Options{}, err
+	}
+	if selector != nil {
+		for _, obj := range []client.Object{
+			&apiv1.Cluster{},
+			&apiv1.Pooler{},
+			&apiv1.Backup{},
+			&apiv1.ScheduledBackup{},
+		} {
+			options.ByObject[obj] = cache.ByObject{Label: selector}
+		}
+	}
+
+	if conf.CacheOperatorPodsOnly {
+		requirement, err := labels.NewRequirement(utils.ClusterLabelName, selection.Exists, nil)
+		if err != nil {
+			return cache.Options{}, err
+		}
+		options.ByObject[&corev1.Pod{}] = cache.ByObject{Label: labels.NewSelector().Add(*requirement)}
+	}
+
+	return options, nil
+}
+
+// stripUnneededFields drops from the cached objects the fields the operator
+// never reads: the managed fields of every object but the clusters, whose
+// ones are used to report who paused them, and the environment of the
+// containers of the pods not created by the operator
+func stripUnneededFields(obj interface{}) (interface{}, error) {
+	accessor, ok := obj.(metav1.Object)
+	if !ok {
+		return obj, nil
+	}
+	if _, isCluster := obj.(*apiv1.Cluster); !isCluster {
+		accessor.SetManagedFields(nil)
+	}
+
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Labels[utils.ClusterLabelName] != "" {
+		return obj, nil
+	}
+
+	for idx := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[idx].Env = nil
+		pod.Spec.InitContainers[idx].EnvFrom = nil
+	}
+	for idx := range pod.Spec.Containers {
+		pod.Spec.Containers[idx].Env = nil
+		pod.Spec.Containers[idx].EnvFrom = nil
+	}
+
+	return pod, nil
+}
@@ -39,7 +39,9 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/controller"
 	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/multicache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
 )
@@ -103,6 +105,23 @@ func RunController(
 		"version", versions.Version,
 		"build", versions.Info)
 
+	// The shard of the operator is needed to choose the leader election
+	// lease, before the operator configuration is loaded
+	if err := conf.ValidateSharding(); err != nil {
+		setupLog.Error(err, "invalid sharding configuration")
+		return err
+	}
+	shardCount, shardIndex := conf.ShardCount, conf.ShardIndex
+
+	// The cache filtering options are needed to create the manager,
+	// before the operator configuration is loaded
+	cacheOptions, err := getCacheOptions(conf)
+	if err != nil {
+		setupLog.Error(err, "invalid cache configuration")
+		return err
+	}
+	watchLabelSelector, cacheOperatorPodsOnly := conf.WatchLabelSelector, conf.CacheOperatorPodsOnly
+
 	managerOptions := ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
@@ -111,7 +130,8 @@ func RunController(
 		LeaderElection:   leaderConfig.enable,
 		LeaseDuration:    &leaderConfig.leaseDuration,
 		RenewDeadline:    &leaderConfig.renewDeadline,
-		LeaderElectionID: LeaderElectionID,
+		LeaderElectionID: getLeaderElectionID(conf),
+		Cache:            cacheOptions,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port:    port,
 			CertDir: defaultWebhookCertDir,
@@ -139,6 +159,9 @@ func RunController(
 	} else {
 		setupLog.Info("Listening for changes on all namespaces")
 	}
+	if watchLabelSelector != "" {
+		setupLog.Info("Listening only for labeled objects", "watchLabelSelector", watchLabelSelector)
+	}
 
 	if conf.WebhookCertDir != "" {
 		// If OLM will generate certificates for us, let's just
@@ -177,7 +200,24 @@ func RunController(
 		return err
 	}
 
+	if conf.ShardCount != shardCount || conf.ShardIndex != shardIndex {
+		err := fmt.Errorf("the sharding of the operator can only be configured with environment variables")
+		setupLog.Error(err, "invalid sharding configuration")
+		return err
+	}
+
+	if conf.WatchLabelSelector != watchLabelSelector || conf.CacheOperatorPodsOnly != cacheOperatorPodsOnly {
+		err := fmt.Errorf("the cache filtering of the operator can only be configured with environment variables")
+		setupLog.Error(err, "invalid cache configuration")
+		return err
+	}
+
 	setupLog.Info("Operator configuration loaded", "configuration", conf)
+	if conf.IsShardingEnabled() {
+		setupLog.Info("Reconciling a shard of the watched namespaces",
+			"shardIndex", conf.ShardIndex,
+			"shardCount", conf.ShardCount)
+	}
 
 	discoveryClient, err := utils.GetDiscoveryClient()
 	if err != nil {
@@ -212,6 +252,11 @@ func RunController(
 		return err
 	}
 
+	if err := setupTelemetry(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to set up the OpenTelemetry exporter")
+		return err
+	}
+
 	pluginRepository := repository.New()
 	if _, err := pluginRepository.RegisterUnixSocketPluginsInPath(
 		conf.PluginSocketDir,
@@ -257,28 +302,36 @@ func RunController(
 		DiscoveryClient: discoveryClient,
 		Scheme:          mgr.GetScheme(),
 		Recorder:        mgr.GetEventRecorderFor("cloudnative-pg-pooler"),
+		PoolerClient:    remote.NewClient().Pooler(),
 	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Pooler")
 		return err
 	}
 
-	if err = (&apiv1.Cluster{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "Cluster", "version", "v1")
+	if err = (&controller.ImageCatalogReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("cloudnative-pg-imagecatalog"),
+		NewCatalog: func() apiv1.GenericImageCatalog { return &apiv1.ImageCatalog{} },
+	}).SetupWithManager(mgr, "imagecatalog", maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ImageCatalog")
 		return err
 	}
 
-	if err = (&apiv1.Backup{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "Backup", "version", "v1")
+	if err = (&controller.ImageCatalogReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("cloudnative-pg-clusterimagecatalog"),
+		NewCatalog: func() apiv1.GenericImageCatalog { return &apiv1.ClusterImageCatalog{} },
+	}).SetupWithManager(mgr, "clusterimagecatalog", maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterImageCatalog")
 		return err
 	}
 
-	if err = (&apiv1.ScheduledBackup{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "ScheduledBackup", "version", "v1")
-		return err
-	}
-
-	if err = (&apiv1.Pooler{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "Pooler", "version", "v1")
+	if conf.DisableWebhooks {
+		setupLog.Info("Admission webhooks disabled, the resources will be defaulted " +
+			"and validated at reconciliation time")
+	} else if err = setupWebhooks(mgr); err != nil {
 		return err
 	}
 
@@ -306,6 +359,16 @@ func RunController(
 	return nil
 }
 
+// getLeaderElectionID gets the name of the lease used for the leader
+// election, which is different for every shard of the operator
+func getLeaderElectionID(conf *configuration.Data) string {
+	if !conf.IsShardingEnabled() {
+		return LeaderElectionID
+	}
+
+	return fmt.Sprintf("%s-shard-%d", LeaderElectionID, conf.ShardIndex)
+}
+
 // loadConfiguration reads the configuration from the provided configmap and secret
 func loadConfiguration(
 	ctx context.Context,
@@ -357,6 +420,41 @@ func readinessProbeHandler(w http.ResponseWriter, _ *http.Request) {
 	_, _ = fmt.Fprint(w, "OK")
 }
 
+// setupWebhooks registers the defaulting and validating admission webhooks
+func setupWebhooks(mgr ctrl.Manager) error {
+	if err := (&apiv1.Cluster{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Cluster", "version", "v1")
+		return err
+	}
+
+	if err := (&apiv1.Backup{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Backup", "version", "v1")
+		return err
+	}
+
+	if err := (&apiv1.ScheduledBackup{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ScheduledBackup", "version", "v1")
+		return err
+	}
+
+	if err := (&apiv1.ScheduledDump{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ScheduledDump", "version", "v1")
+		return err
+	}
+
+	if err := (&apiv1.Publication{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Publication", "version", "v1")
+		return err
+	}
+
+	if err := (&apiv1.Pooler{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Pooler", "version", "v1")
+		return err
+	}
+
+	return nil
+}
+
 // ensurePKI ensures that we have the required PKI infrastructure to make
 // the operator and the clusters working
 func ensurePKI(
@@ -382,6 +480,11 @@ func ensurePKI(
 		ValidatingWebhookConfigurationName: ValidatingWebhookConfigurationName,
 		OperatorDeploymentLabelSelector:    "app.kubernetes.io/name=cloudnative-pg",
 	}
+	if conf.DisableWebhooks {
+		// The webhook configurations are not expected to exist
+		pkiConfig.MutatingWebhookConfigurationName = ""
+		pkiConfig.ValidatingWebhookConfigurationName = ""
+	}
 	err := pkiConfig.Setup(ctx, kubeClient)
 	if err != nil {
 		setupLog.Error(err, "unable to setup PKI infrastructure")
@@ -463,3 +566,27 @@ func getPprofServerAddress(enabled bool) string {
 
 	return ""
 }
+
+// setupTelemetry enables the export of the operator traces and metrics
+// when the standard OpenTelemetry environment variables are set
+func setupTelemetry(ctx context.Context, mgr ctrl.Manager) error {
+	telemetryConfiguration := telemetry.NewConfigurationFromEnv(telemetry.OperatorServiceName)
+	if telemetryConfiguration == nil {
+		return nil
+	}
+
+	telemetryProvider, err := telemetry.NewProvider(ctx, telemetryConfiguration)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(telemetryProvider); err != nil {
+		return err
+	}
+	telemetry.SetProvider(telemetryProvider)
+
+	setupLog.Info("Exporting the operator telemetry",
+		"serviceName", telemetryConfiguration.ServiceName,
+		"exportTraces", telemetryConfiguration.ExportTraces,
+		"exportMetrics", telemetryConfiguration.ExportMetrics)
+	return nil
+}
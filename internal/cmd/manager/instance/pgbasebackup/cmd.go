@@ -120,7 +120,7 @@ func (env *CloneInfo) bootstrapUsingPgbasebackup(ctx context.Context) error {
 		env.info.ApplicationDatabase = cluster.GetApplicationDatabaseName()
 	}
 
-	server, ok := cluster.ExternalCluster(cluster.Spec.Bootstrap.PgBaseBackup.Source)
+	server, ok := cluster.GetPgBaseBackupSource()
 	if !ok {
 		return fmt.Errorf("missing external cluster")
 	}
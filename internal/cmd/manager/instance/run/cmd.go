@@ -40,10 +40,16 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/manager/instance/run/lifecycle"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/backupmirror"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/externalservers"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/logicalupgrade"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/roles"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/runner"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/safeguard"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/splitbrain"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/tablespaces"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/walarchivehealth"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/walspace"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/istio"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/linkerd"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/concurrency"
@@ -53,6 +59,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/metricserver"
 	pg "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
 )
 
@@ -175,6 +182,11 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 						instance.GetNamespaceName(): {},
 					},
 				},
+				&apiv1.ScheduledDump{}: {
+					Namespaces: map[string]cache.Config{
+						instance.GetNamespaceName(): {},
+					},
+				},
 			},
 		},
 		// We don't need a cache for secrets and configmap, as all reloads
@@ -187,6 +199,8 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 					// we don't have the permissions to cache backups, as the ServiceAccount
 					// doesn't have watch permission on the backup status
 					&apiv1.Backup{},
+					// we only have the permission to get the Pods of the instances
+					&corev1.Pod{},
 				},
 			},
 		},
@@ -203,11 +217,31 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 		return err
 	}
 
+	if telemetryConfiguration := telemetry.NewConfigurationFromEnv(
+		telemetry.InstanceManagerServiceName,
+	); telemetryConfiguration != nil {
+		telemetryProvider, err := telemetry.NewProvider(ctx, telemetryConfiguration)
+		if err != nil {
+			contextLogger.Error(err, "unable to set up the OpenTelemetry exporter")
+			return err
+		}
+		if err := mgr.Add(telemetryProvider); err != nil {
+			contextLogger.Error(err, "unable to add the OpenTelemetry exporter")
+			return err
+		}
+		telemetry.SetProvider(telemetryProvider)
+	}
+
 	postgresStartConditions := concurrency.MultipleExecuted{}
 	exitedConditions := concurrency.MultipleExecuted{}
 
 	metricsExporter := metricserver.NewExporter(instance)
-	reconciler := controller.NewInstanceReconciler(instance, mgr.GetClient(), metricsExporter)
+	reconciler := controller.NewInstanceReconciler(
+		instance,
+		mgr.GetClient(),
+		metricsExporter,
+		mgr.GetEventRecorderFor("instance-manager"),
+	)
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Cluster{}).
 		Named("instance-cluster").
@@ -232,15 +266,48 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 		return err
 	}
 
-	// database subscription reconciler
-	subscriptionReconciler := controller.NewSubscriptionReconciler(mgr, instance)
+	// database subscription reconciler, fed with the errors of the
+	// logical replication workers by the CSV log pipe
+	subscriptionErrorTracker := controller.NewSubscriptionErrorTracker()
+	subscriptionReconciler := controller.NewSubscriptionReconciler(mgr, instance, subscriptionErrorTracker)
 	if err := subscriptionReconciler.SetupWithManager(mgr); err != nil {
 		contextLogger.Error(err, "unable to create subscription controller")
 		return err
 	}
 
+	// scheduled logical dumps reconciler
+	scheduledDumpReconciler := controller.NewScheduledDumpReconciler(mgr, instance)
+	if err := scheduledDumpReconciler.SetupWithManager(mgr); err != nil {
+		contextLogger.Error(err, "unable to create scheduled dump controller")
+		return err
+	}
+
+	// query history writer, storing the snapshots of the top statements
+	queryInsightsHistoryWriter := controller.NewQueryInsightsHistoryWriter(mgr, instance)
+	if err := queryInsightsHistoryWriter.SetupWithManager(mgr); err != nil {
+		contextLogger.Error(err, "unable to create query insights history writer")
+		return err
+	}
+
+	// audit log shipper, fed with the PGAudit records by the CSV log pipe
+	auditLogShipper := controller.NewAuditLogShipper(mgr, instance)
+	if err := auditLogShipper.SetupWithManager(mgr); err != nil {
+		contextLogger.Error(err, "unable to create audit log shipper")
+		return err
+	}
+
+	// log forwarder, fed with the PostgreSQL log records by the CSV log pipe
+	logForwarder := controller.NewLogForwarder(mgr, instance)
+	if err := logForwarder.SetupWithManager(mgr); err != nil {
+		contextLogger.Error(err, "unable to create log forwarder")
+		return err
+	}
+
 	// postgres CSV logs handler (PGAudit too)
-	postgresLogPipe := logpipe.NewLogPipe()
+	postgresLogPipe := logpipe.NewLogPipe().
+		WithObserver(subscriptionErrorTracker).
+		WithAuditSink(auditLogShipper).
+		WithForwarder(logForwarder)
 	if err := mgr.Add(postgresLogPipe); err != nil {
 		return err
 	}
@@ -291,6 +358,42 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 		return err
 	}
 
+	backupMirrorer := backupmirror.NewMirrorer(instance, reconciler.GetClient())
+	if err = mgr.Add(backupMirrorer); err != nil {
+		contextLogger.Error(err, "unable to create backup mirrorer")
+		return err
+	}
+
+	walArchiveHealthChecker := walarchivehealth.NewHealthChecker(instance, reconciler.GetClient())
+	if err = mgr.Add(walArchiveHealthChecker); err != nil {
+		contextLogger.Error(err, "unable to create WAL archive health checker")
+		return err
+	}
+
+	walSpaceGuard := walspace.NewGuard(instance, reconciler.GetClient())
+	if err = mgr.Add(walSpaceGuard); err != nil {
+		contextLogger.Error(err, "unable to create WAL space guard")
+		return err
+	}
+
+	replicationSlotsSafeguard := safeguard.NewSafeguard(instance, reconciler.GetClient())
+	if err = mgr.Add(replicationSlotsSafeguard); err != nil {
+		contextLogger.Error(err, "unable to create replication slots safeguard")
+		return err
+	}
+
+	splitBrainDetector := splitbrain.NewDetector(instance, reconciler.GetClient())
+	if err = mgr.Add(splitBrainDetector); err != nil {
+		contextLogger.Error(err, "unable to create split-brain detector")
+		return err
+	}
+
+	logicalUpgradeSubscriber := logicalupgrade.NewSubscriber(instance, reconciler.GetClient())
+	if err = mgr.Add(logicalUpgradeSubscriber); err != nil {
+		contextLogger.Error(err, "unable to create logical major version upgrade subscriber")
+		return err
+	}
+
 	// onlineUpgradeCtx is a child context of the postgres context.
 	// onlineUpgradeCtx will be the context passed to all the manager handled Runnables via Start(ctx),
 	// its deletion will imply all Runnables to stop, but will be handled
@@ -0,0 +1,116 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tde implements the "instance tde" subcommand of the operator,
+// used by PostgreSQL to wrap and unwrap the data encryption key of the
+// instances using transparent data encryption
+package tde
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/tde"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// NewCmd creates the "instance tde" subcommand
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tde",
+		Short: "Wrap and unwrap the TDE data encryption key",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return fmt.Errorf("missing subcommand")
+		},
+	}
+
+	cmd.AddCommand(newWrapCmd())
+	cmd.AddCommand(newUnwrapCmd())
+
+	return cmd
+}
+
+// newWrapCmd creates the command wrapping the data encryption key read
+// from the standard input into the passed file. It is invoked by initdb
+func newWrapCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wrap [file]",
+		Short: "Wrap the data encryption key read from the standard input",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider, err := loadKeyProvider()
+			if err != nil {
+				return err
+			}
+
+			key, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("while reading the data encryption key: %w", err)
+			}
+
+			wrappedKey, err := provider.Wrap(cmd.Context(), key)
+			if err != nil {
+				return err
+			}
+
+			return os.WriteFile(args[0], wrappedKey, 0o600)
+		},
+	}
+}
+
+// newUnwrapCmd creates the command writing to the standard output the
+// data encryption key unwrapped from the passed file. It is invoked
+// by PostgreSQL at startup
+func newUnwrapCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unwrap [file]",
+		Short: "Unwrap the data encryption key to the standard output",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider, err := loadKeyProvider()
+			if err != nil {
+				return err
+			}
+
+			wrappedKey, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("while reading the wrapped data encryption key: %w", err)
+			}
+
+			key, err := provider.Unwrap(cmd.Context(), wrappedKey)
+			if err != nil {
+				return err
+			}
+
+			_, err = cmd.OutOrStdout().Write(key)
+			return err
+		},
+	}
+}
+
+// loadKeyProvider creates the key provider from the configuration
+// written by the instance manager
+func loadKeyProvider() (tde.KeyProvider, error) {
+	configuration, err := tde.LoadConfiguration(postgres.TDEKeyProviderFile)
+	if err != nil {
+		return nil, fmt.Errorf("while loading the TDE key provider configuration: %w", err)
+	}
+
+	return configuration.NewKeyProvider()
+}
@@ -0,0 +1,39 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the "upgrade" subcommand
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the PostgreSQL major version of the instance",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return fmt.Errorf("missing subcommand")
+		},
+	}
+
+	cmd.AddCommand(newPrepareCmd())
+	cmd.AddCommand(newExecuteCmd())
+
+	return cmd
+}
@@ -0,0 +1,356 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/istio"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/linkerd"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/configfile"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// newDirectorySuffix is the suffix of the directories where
+// the new data directory is created before being swapped
+// with the existing one
+const newDirectorySuffix = "-new"
+
+// upgradeInfo contains the information needed to upgrade
+// the data directory of an instance
+type upgradeInfo struct {
+	pgData        string
+	pgWal         string
+	oldBinaries   string
+	initDBOptions []string
+}
+
+// newExecuteCmd creates the "upgrade execute" subcommand
+func newExecuteCmd() *cobra.Command {
+	var (
+		clusterName       string
+		namespace         string
+		pgData            string
+		pgWal             string
+		oldBinaries       string
+		initDBFlagsString string
+	)
+
+	cmd := &cobra.Command{
+		Use:           "execute [flags]",
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return management.WaitForGetCluster(cmd.Context(), ctrl.ObjectKey{
+				Name:      clusterName,
+				Namespace: namespace,
+			})
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			contextLogger := log.FromContext(ctx)
+
+			initDBOptions, err := shellquote.Split(initDBFlagsString)
+			if err != nil {
+				contextLogger.Error(err, "Error while parsing initdb flags")
+				return err
+			}
+
+			info := upgradeInfo{
+				pgData:        pgData,
+				pgWal:         pgWal,
+				oldBinaries:   oldBinaries,
+				initDBOptions: initDBOptions,
+			}
+			if err := info.execute(ctx); err != nil {
+				contextLogger.Error(err, "Error while upgrading the PostgreSQL major version")
+				return err
+			}
+
+			return nil
+		},
+		PostRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := istio.TryInvokeQuitEndpoint(cmd.Context()); err != nil {
+				return err
+			}
+
+			return linkerd.TryInvokeShutdownEndpoint(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", os.Getenv("CLUSTER_NAME"), "The name of the "+
+		"cluster to be upgraded")
+	cmd.Flags().StringVar(&namespace, "namespace", os.Getenv("NAMESPACE"), "The namespace of "+
+		"the cluster")
+	cmd.Flags().StringVar(&pgData, "pg-data", os.Getenv("PGDATA"), "The PGDATA to be upgraded")
+	cmd.Flags().StringVar(&pgWal, "pg-wal", "", "The PGWAL to be upgraded")
+	cmd.Flags().StringVar(&oldBinaries, "old-binaries", "", "The directory containing the "+
+		"PostgreSQL installation of the previous major version")
+	cmd.Flags().StringVar(&initDBFlagsString, "initdb-flags", "", "The list of flags to be passed "+
+		"to initdb while creating the new data directory")
+
+	return cmd
+}
+
+// execute upgrades the data directory via pg_upgrade in link mode. The new
+// data directory is created side by side with the existing one, which is
+// kept, renamed after its major version, until the user confirms the upgrade
+func (info upgradeInfo) execute(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	oldBinDir, err := fileutils.ReadFile(filepath.Join(info.oldBinaries, oldBinDirFile))
+	if err != nil {
+		return fmt.Errorf("while reading the location of the old binaries: %w", err)
+	}
+	newBinDir, err := getPgConfig("--bindir")
+	if err != nil {
+		return err
+	}
+
+	oldMajor, err := getDataDirectoryMajorVersion(info.pgData)
+	if err != nil {
+		return err
+	}
+
+	controlData, err := getPgControldata(string(oldBinDir), info.pgData)
+	if err != nil {
+		return err
+	}
+	state := utils.PgDataState(controlData[utils.PgControlDataDatabaseClusterStateKey])
+	if !state.IsShutdown(ctx) {
+		return fmt.Errorf("the data directory has not been cleanly shut down (state: %s)", state)
+	}
+
+	newInfo := postgres.InitInfo{
+		PgData: info.pgData + newDirectorySuffix,
+	}
+	if info.pgWal != "" {
+		newInfo.PgWal = info.pgWal + newDirectorySuffix
+	}
+
+	// Remove any leftover of a previous attempt
+	for _, directory := range []string{newInfo.PgData, newInfo.PgWal} {
+		if directory == "" {
+			continue
+		}
+		if err := fileutils.RemoveDirectory(directory); err != nil {
+			return fmt.Errorf("while removing %s: %w", directory, err)
+		}
+	}
+
+	newInfo.InitDBOptions, err = info.getInitDBOptions(controlData)
+	if err != nil {
+		return err
+	}
+	if err := newInfo.CreateDataDirectory(); err != nil {
+		return err
+	}
+
+	if err := prepareConfiguration(info.pgData, newInfo.PgData); err != nil {
+		return err
+	}
+
+	pgUpgradeOptions := []string{
+		"--old-bindir", string(oldBinDir),
+		"--new-bindir", newBinDir,
+		"--old-datadir", info.pgData,
+		"--new-datadir", newInfo.PgData,
+		"--username", "postgres",
+		"--link",
+	}
+
+	// The check mode validates the extensions and the libraries
+	// used by the old instance against the new installation
+	contextLogger.Info("Checking the clusters before the upgrade")
+	if err := runPgUpgrade(append(pgUpgradeOptions, "--check")); err != nil {
+		return err
+	}
+
+	contextLogger.Info("Upgrading the data directory", "oldMajor", oldMajor)
+	if err := runPgUpgrade(pgUpgradeOptions); err != nil {
+		return err
+	}
+
+	return info.swapDirectories(ctx, newInfo, oldMajor)
+}
+
+// getInitDBOptions returns the options to be passed to initdb, which
+// must create a data directory compatible with the existing one
+func (info upgradeInfo) getInitDBOptions(controlData map[string]string) ([]string, error) {
+	options := make([]string, 0, len(info.initDBOptions)+2)
+	for i := 0; i < len(info.initDBOptions); i++ {
+		option := info.initDBOptions[i]
+		// These settings are detected from the existing data directory
+		switch {
+		case option == "-k", option == "--data-checksums", option == "--no-data-checksums":
+			continue
+		case option == "--wal-segsize":
+			// Skip the value too
+			i++
+			continue
+		case strings.HasPrefix(option, "--wal-segsize="):
+			continue
+		}
+		options = append(options, option)
+	}
+
+	if controlData["Data page checksum version"] != "0" {
+		options = append(options, "--data-checksums")
+	} else {
+		newVersion, err := getPgConfig("--version")
+		if err != nil {
+			return nil, err
+		}
+		// Checksums are enabled by default since PostgreSQL 18
+		if newMajor, err := version.FromTag(strings.TrimPrefix(newVersion, "PostgreSQL ")); err == nil &&
+			newMajor.Major() >= 18 {
+			options = append(options, "--no-data-checksums")
+		}
+	}
+
+	walSegmentSize, err := strconv.Atoi(controlData["Bytes per WAL segment"])
+	if err != nil {
+		return nil, fmt.Errorf("wrong 'Bytes per WAL segment' pg_controldata value: %w", err)
+	}
+	options = append(options, fmt.Sprintf("--wal-segsize=%d", walSegmentSize/(1024*1024)))
+
+	return options, nil
+}
+
+// swapDirectories replaces the old data and WAL directories with the
+// upgraded ones, keeping the old ones aside
+func (info upgradeInfo) swapDirectories(ctx context.Context, newInfo postgres.InitInfo, oldMajor int) error {
+	contextLogger := log.FromContext(ctx)
+
+	oldPgData := postgres.GetOldMajorDirectory(info.pgData, oldMajor)
+	contextLogger.Info("Keeping the old data directory", "directory", oldPgData)
+	if err := os.Rename(info.pgData, oldPgData); err != nil {
+		return err
+	}
+	if err := os.Rename(newInfo.PgData, info.pgData); err != nil {
+		return err
+	}
+
+	if info.pgWal == "" {
+		return nil
+	}
+
+	oldPgWal := postgres.GetOldMajorDirectory(info.pgWal, oldMajor)
+	contextLogger.Info("Keeping the old WAL directory", "directory", oldPgWal)
+	if err := os.Rename(info.pgWal, oldPgWal); err != nil {
+		return err
+	}
+	if err := os.Rename(newInfo.PgWal, info.pgWal); err != nil {
+		return err
+	}
+
+	// The pg_wal symbolic links need to follow the renamed directories
+	if err := replaceSymlink(filepath.Join(oldPgData, "pg_wal"), oldPgWal); err != nil {
+		return err
+	}
+	return replaceSymlink(filepath.Join(info.pgData, "pg_wal"), info.pgWal)
+}
+
+// prepareConfiguration replaces the configuration of the old instance with
+// a minimal one, which is enough for pg_upgrade to start it, and preloads
+// in the new instance the same libraries of the old one, as they may be
+// needed by the installed extensions
+func prepareConfiguration(oldPgData, newPgData string) error {
+	lines, err := fileutils.ReadFileLines(filepath.Join(oldPgData, constants.PostgresqlCustomConfigurationFile))
+	if err != nil {
+		return fmt.Errorf("while reading the configuration of the old instance: %w", err)
+	}
+	preloadedLibraries := configfile.ReadLinesFromConfigurationContents(lines, "shared_preload_libraries")
+
+	for _, pgData := range []string{oldPgData, newPgData} {
+		if _, err := fileutils.WriteLinesToFile(
+			filepath.Join(pgData, constants.PostgresqlCustomConfigurationFile),
+			preloadedLibraries,
+		); err != nil {
+			return err
+		}
+		if _, err := fileutils.WriteStringToFile(
+			filepath.Join(pgData, constants.PostgresqlOverrideConfigurationFile),
+			"",
+		); err != nil {
+			return err
+		}
+	}
+
+	_, err = fileutils.WriteStringToFile(
+		filepath.Join(oldPgData, constants.PostgresqlHBARulesFile),
+		"local all all trust\n",
+	)
+	return err
+}
+
+// getDataDirectoryMajorVersion returns the major version of the passed data directory
+func getDataDirectoryMajorVersion(pgData string) (int, error) {
+	content, err := fileutils.ReadFile(filepath.Join(pgData, "PG_VERSION"))
+	if err != nil {
+		return 0, fmt.Errorf("while reading the version of the data directory: %w", err)
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
+
+// getPgControldata runs the pg_controldata binary inside the passed
+// directory against the passed data directory
+func getPgControldata(binDir, pgData string) (map[string]string, error) {
+	out, err := exec.Command(filepath.Join(binDir, "pg_controldata"), pgData).Output() // #nosec
+	if err != nil {
+		return nil, fmt.Errorf("while executing pg_controldata: %w", err)
+	}
+
+	return utils.ParsePgControldataOutput(string(out)), nil
+}
+
+func runPgUpgrade(options []string) error {
+	pgUpgradeCmd := exec.Command("pg_upgrade", options...) // #nosec
+	// pg_upgrade writes its log files and sockets in the working directory
+	pgUpgradeCmd.Dir = postgresSpec.TemporaryDirectory
+	if err := execlog.RunBuffering(pgUpgradeCmd, "pg_upgrade"); err != nil {
+		return fmt.Errorf("error while running pg_upgrade: %w", err)
+	}
+
+	return nil
+}
+
+func replaceSymlink(name, target string) error {
+	if err := os.Remove(name); err != nil {
+		return err
+	}
+
+	return os.Symlink(target, name)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// oldBinDirFile is the name of the file, inside the directory where the
+// previous PostgreSQL installation is copied, containing the path of the
+// copied binaries
+const oldBinDirFile = "bindir.txt"
+
+// newPrepareCmd creates the "upgrade prepare" subcommand, which runs in the
+// image of the previous major version and copies the PostgreSQL installation
+// to the passed directory, where pg_upgrade can find it
+func newPrepareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "prepare [target directory]",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			err := prepare(ctx, args[0])
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Error while copying the PostgreSQL installation")
+			}
+			return err
+		},
+	}
+
+	return cmd
+}
+
+func prepare(ctx context.Context, target string) error {
+	contextLogger := log.FromContext(ctx)
+
+	binDir, err := getPgConfig("--bindir")
+	if err != nil {
+		return err
+	}
+
+	// The directories are copied keeping their relative position, as
+	// PostgreSQL looks for its libraries and shared files starting
+	// from the location of its binaries
+	for _, option := range []string{"--bindir", "--pkglibdir", "--sharedir"} {
+		source, err := getPgConfig(option)
+		if err != nil {
+			return err
+		}
+
+		destination := filepath.Join(target, source)
+		contextLogger.Info("Copying the PostgreSQL installation",
+			"source", source,
+			"destination", destination)
+		if err := copyDirectory(source, destination); err != nil {
+			return fmt.Errorf("while copying %s: %w", source, err)
+		}
+	}
+
+	_, err = fileutils.WriteStringToFile(filepath.Join(target, oldBinDirFile), filepath.Join(target, binDir))
+	return err
+}
+
+// getPgConfig returns the value of the passed pg_config option
+func getPgConfig(option string) (string, error) {
+	out, err := exec.Command("pg_config", option).Output() // #nosec
+	if err != nil {
+		return "", fmt.Errorf("while executing pg_config %s: %w", option, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// copyDirectory recursively copies the content of a directory, following
+// the symbolic links, as their targets may not exist in the destination
+// image
+func copyDirectory(source, destination string) error {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(destination, 0o700); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		sourcePath := filepath.Join(source, entry.Name())
+		destinationPath := filepath.Join(destination, entry.Name())
+
+		info, err := os.Stat(sourcePath)
+		if errors.Is(err, os.ErrNotExist) {
+			// Dangling symbolic link
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if err := copyDirectory(sourcePath, destinationPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fileutils.CopyFile(sourcePath, destinationPath); err != nil {
+			return err
+		}
+		if err := os.Chmod(destinationPath, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
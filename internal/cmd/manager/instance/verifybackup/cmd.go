@@ -0,0 +1,123 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifybackup
+
+import (
+	"context"
+	"os"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/istio"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/linkerd"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// NewCmd creates the "verifybackup" subcommand
+func NewCmd() *cobra.Command {
+	var clusterName string
+	var namespace string
+	var pgData string
+	var backupName string
+
+	cmd := &cobra.Command{
+		Use:           "verifybackup [flags]",
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return management.WaitForGetCluster(cmd.Context(), client.ObjectKey{
+				Name:      clusterName,
+				Namespace: namespace,
+			})
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info := postgres.InitInfo{
+				ClusterName: clusterName,
+				Namespace:   namespace,
+				PgData:      pgData,
+			}
+
+			return verifyBackupSubCommand(cmd.Context(), info, backupName)
+		},
+		PostRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := istio.TryInvokeQuitEndpoint(cmd.Context()); err != nil {
+				return err
+			}
+
+			return linkerd.TryInvokeShutdownEndpoint(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", os.Getenv("CLUSTER_NAME"), "The name of the "+
+		"current cluster in k8s")
+	cmd.Flags().StringVar(&namespace, "namespace", os.Getenv("NAMESPACE"), "The namespace of "+
+		"the cluster and the Pod in k8s")
+	cmd.Flags().StringVar(&pgData, "pg-data", os.Getenv("PGDATA"), "The PGDATA where the backup is restored")
+	cmd.Flags().StringVar(&backupName, "backup-name", "", "The name of the Backup to be verified")
+
+	return cmd
+}
+
+// verifyBackupSubCommand restores the backup in the throwaway instance and
+// reports the result of the verification in the status of the Backup
+func verifyBackupSubCommand(ctx context.Context, info postgres.InitInfo, backupName string) error {
+	contextLogger := log.FromContext(ctx).WithValues("backupName", backupName)
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	cli, err := management.NewControllerRuntimeClient()
+	if err != nil {
+		contextLogger.Error(err, "Error creating Kubernetes client")
+		return err
+	}
+
+	var backup apiv1.Backup
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: info.Namespace, Name: backupName}, &backup); err != nil {
+		contextLogger.Error(err, "Error while getting the backup to be verified")
+		return err
+	}
+
+	if err := info.EnsureTargetDirectoriesDoNotExist(ctx); err != nil {
+		return err
+	}
+
+	if backup.Status.Verification == nil {
+		backup.Status.Verification = &apiv1.BackupVerificationStatus{}
+	}
+	backup.Status.Verification.Phase = apiv1.BackupVerificationPhaseRunning
+	if err := postgres.PatchBackupStatusAndRetry(ctx, cli, &backup); err != nil {
+		contextLogger.Error(err, "Can't set the backup verification as running")
+	}
+
+	verificationErr := info.VerifyBackup(ctx, cli, &backup)
+
+	if verificationErr != nil {
+		contextLogger.Error(verificationErr, "Backup verification failed")
+		backup.Status.Verification.SetAsFailed(verificationErr)
+	} else {
+		contextLogger.Info("Backup verification succeeded")
+		backup.Status.Verification.SetAsSucceeded()
+	}
+	if err := postgres.PatchBackupStatusAndRetry(ctx, cli, &backup); err != nil {
+		contextLogger.Error(err, "Can't set the result of the backup verification")
+		return err
+	}
+
+	return verificationErr
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/repository"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbackrest"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/local"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
@@ -78,7 +79,7 @@ func NewCmd() *cobra.Command {
 			}
 
 			switch {
-			case errors.Is(err, barmanRestorer.ErrWALNotFound):
+			case errors.Is(err, barmanRestorer.ErrWALNotFound), errors.Is(err, pgbackrest.ErrWALNotFound):
 				// Nothing to log here. The failure has already been logged.
 			case errors.Is(err, ErrNoBackupConfigured):
 				contextLog.Debug("tried restoring WALs, but no backup was configured")
@@ -127,6 +128,11 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 		return nil
 	}
 
+	stanza, pgBackRestConfiguration, err := GetPgBackRestRecoverConfiguration(cluster, podName)
+	if err == nil {
+		return restoreWALViaPgBackRest(ctx, pgData, stanza, pgBackRestConfiguration, walName, destinationPath)
+	}
+
 	recoverClusterName, recoverEnv, barmanConfiguration, err := GetRecoverConfiguration(cluster, podName)
 	if errors.Is(err, ErrNoBackupConfigured) {
 		// Backup not configured, skipping WAL
@@ -241,6 +247,36 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 	return nil
 }
 
+// restoreWALViaPgBackRest restores the passed WAL file from the pgBackRest
+// repository
+func restoreWALViaPgBackRest(
+	ctx context.Context,
+	pgData string,
+	stanza string,
+	configuration *apiv1.PgBackRestConfiguration,
+	walName string,
+	destinationPath string,
+) error {
+	contextLog := log.FromContext(ctx)
+	startTime := time.Now()
+
+	env, err := local.NewClient().Cache().GetEnv(cache.WALRestoreKey)
+	if err != nil {
+		return fmt.Errorf("failed to get envs: %w", err)
+	}
+
+	options := pgbackrest.Options(configuration, stanza, pgData)
+	if err := pgbackrest.ArchiveGet(ctx, env, options, walName, destinationPath); err != nil {
+		return err
+	}
+
+	contextLog.Info("WAL restore command completed",
+		"walName", walName,
+		"startTime", startTime,
+		"totalTime", time.Since(startTime))
+	return nil
+}
+
 // restoreWALViaPlugins requests every capable plugin to restore the passed
 // WAL file, and returns an error if every plugin failed. It will not return
 // an error if there's no plugin capable of WAL archiving too
@@ -340,7 +376,7 @@ func GetRecoverConfiguration(
 	var env []string
 	// If I am the designated primary. Let's use the recovery object store for this wal
 	if cluster.IsReplica() && cluster.Status.CurrentPrimary == podName {
-		sourceName := cluster.Spec.ReplicaCluster.Source
+		sourceName := cluster.GetReplicaSource()
 		externalCluster, found := cluster.ExternalCluster(sourceName)
 		if !found {
 			return "", nil, nil, ErrExternalClusterNotFound
@@ -373,6 +409,34 @@ func GetRecoverConfiguration(
 	return "", nil, nil, ErrNoBackupConfigured
 }
 
+// GetPgBackRestRecoverConfiguration gets the pgBackRest configuration, and
+// the related stanza, to be used to restore WAL files for a given cluster
+func GetPgBackRestRecoverConfiguration(
+	cluster *apiv1.Cluster,
+	podName string,
+) (string, *apiv1.PgBackRestConfiguration, error) {
+	// If I am the designated primary. Let's use the repository of the source cluster
+	if cluster.IsReplica() && cluster.Status.CurrentPrimary == podName {
+		externalCluster, found := cluster.ExternalCluster(cluster.GetReplicaSource())
+		if !found {
+			return "", nil, ErrExternalClusterNotFound
+		}
+
+		if externalCluster.PgBackRest == nil {
+			return "", nil, ErrNoBackupConfigured
+		}
+		return pgbackrest.GetStanza(externalCluster.PgBackRest, externalCluster.Name), externalCluster.PgBackRest, nil
+	}
+
+	// Otherwise, let's use the repository which we are using to
+	// back up this cluster
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.PgBackRest != nil {
+		return pgbackrest.GetStanza(cluster.Spec.Backup.PgBackRest, cluster.Name), cluster.Spec.Backup.PgBackRest, nil
+	}
+
+	return "", nil, ErrNoBackupConfigured
+}
+
 // gatherWALFilesToRestore files a list of possible WAL files to restore, always
 // including as the first one the requested WAL file
 func gatherWALFilesToRestore(walName string, parallel int) (walList []string, err error) {
@@ -410,7 +474,7 @@ func isStreamingAvailable(cluster *apiv1.Cluster, podName string) bool {
 
 	// Designated primary in a replica cluster: return true if the external cluster has streaming connection
 	if cluster.IsReplica() {
-		externalCluster, found := cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
+		externalCluster, found := cluster.ExternalCluster(cluster.GetReplicaSource())
 
 		// This is a configuration error
 		if !found {
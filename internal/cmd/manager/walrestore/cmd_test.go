@@ -17,6 +17,7 @@ limitations under the License.
 package walrestore
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -110,3 +111,47 @@ var _ = Describe("Function isStreamingAvailable", func() {
 		Expect(isStreamingAvailable(&cluster, "primaryPod")).To(BeTrue())
 	})
 })
+
+var _ = Describe("Function GetPgBackRestRecoverConfiguration", func() {
+	pgBackRestConfiguration := &apiv1.PgBackRestConfiguration{
+		Repository: apiv1.PgBackRestRepository{Path: "/repo"},
+	}
+
+	It("returns the repository of the cluster", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{PgBackRest: pgBackRestConfiguration},
+			},
+		}
+		stanza, configuration, err := GetPgBackRestRecoverConfiguration(cluster, "cluster-example-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stanza).To(Equal("cluster-example"))
+		Expect(configuration).To(Equal(pgBackRestConfiguration))
+	})
+
+	It("returns the repository of the source cluster for a designated primary", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				ExternalClusters: []apiv1.ExternalCluster{
+					{Name: "origin", PgBackRest: pgBackRestConfiguration},
+				},
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Enabled: ptr.To(true),
+					Source:  "origin",
+				},
+			},
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+		stanza, configuration, err := GetPgBackRestRecoverConfiguration(cluster, "cluster-example-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stanza).To(Equal("origin"))
+		Expect(configuration).To(Equal(pgBackRestConfiguration))
+	})
+
+	It("returns ErrNoBackupConfigured when pgBackRest is not used", func() {
+		_, _, err := GetPgBackRestRecoverConfiguration(&apiv1.Cluster{}, "cluster-example-1")
+		Expect(err).To(MatchError(ErrNoBackupConfigured))
+	})
+})
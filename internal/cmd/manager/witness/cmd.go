@@ -0,0 +1,71 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package witness implements the "witness" subcommand of the operator
+package witness
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/witness"
+)
+
+// NewCmd creates the "witness" command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "witness",
+		Short:         "Run the witness of a PostgreSQL cluster",
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := log.IntoContext(
+				cmd.Context(),
+				log.GetLogger().WithValues("logger", "witness"),
+			)
+			return runSubCommand(ctx)
+		},
+	}
+
+	return cmd
+}
+
+func runSubCommand(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	server := witness.NewServer()
+	go func() {
+		<-ctx.Done()
+		contextLogger.Info("Shutting down the witness")
+		if err := server.Shutdown(context.Background()); err != nil {
+			contextLogger.Error(err, "while shutting down the witness")
+		}
+	}()
+
+	contextLogger.Info("Starting the witness")
+	if err := server.ListenAndServe(); err != nil {
+		contextLogger.Error(err, "Error while running the witness")
+		return err
+	}
+
+	return nil
+}
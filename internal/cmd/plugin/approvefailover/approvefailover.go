@@ -0,0 +1,66 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package approvefailover implements a command to approve the failover
+// of a cluster whose failover policy requires it
+package approvefailover
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// ApproveFailover marks the current primary of the cluster as
+// approved for replacement
+func ApproveFailover(ctx context.Context, clusterName string) error {
+	var cluster apiv1.Cluster
+
+	// Get the Cluster object
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("cluster %s not found in namespace %s: %w", clusterName, plugin.Namespace, err)
+	}
+
+	if cluster.Status.CurrentPrimary == "" {
+		return fmt.Errorf("cluster %s has no current primary", clusterName)
+	}
+
+	if cluster.GetFailoverPolicy() == apiv1.FailoverPolicyAutomatic {
+		fmt.Printf("%s has an automatic failover policy, no approval is needed\n", clusterName)
+		return nil
+	}
+
+	clusterApproved := cluster.DeepCopy()
+	if clusterApproved.Annotations == nil {
+		clusterApproved.Annotations = make(map[string]string)
+	}
+	clusterApproved.Annotations[utils.FailoverApprovalAnnotationName] = cluster.Status.CurrentPrimary
+	clusterApproved.ManagedFields = nil
+
+	err = plugin.Client.Patch(ctx, clusterApproved, client.MergeFrom(&cluster))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The failover from %s is approved\n", cluster.Status.CurrentPrimary)
+	return nil
+}
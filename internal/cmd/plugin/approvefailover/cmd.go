@@ -0,0 +1,47 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvefailover
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "approve-failover" command
+func NewCmd() *cobra.Command {
+	approveFailoverCmd := &cobra.Command{
+		Use:   "approve-failover CLUSTER",
+		Short: `Approve the failover of a cluster`,
+		Long: `Approves the replacement of the current primary instance of a cluster ` +
+			`whose failover policy requires the user approval.`,
+		GroupID: plugin.GroupIDCluster,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx := context.Background()
+			clusterName := args[0]
+			return ApproveFailover(ctx, clusterName)
+		},
+	}
+
+	return approveFailoverCmd
+}
@@ -42,6 +42,7 @@ type backupCommandOptions struct {
 	clusterName         string
 	target              apiv1.BackupTarget
 	method              apiv1.BackupMethod
+	level               apiv1.BackupLevel
 	online              *bool
 	immediateCheckpoint *bool
 	waitForArchive      *bool
@@ -62,15 +63,22 @@ func (options backupCommandOptions) getOnlineConfiguration() *apiv1.OnlineConfig
 
 // NewCmd creates the new "backup" subcommand
 func NewCmd() *cobra.Command {
-	var backupName, backupTarget, backupMethod, online, immediateCheckpoint, waitForArchive, pluginName string
+	var backupName, backupTarget, backupMethod, backupLevel, online, immediateCheckpoint, waitForArchive, pluginName string
 	var pluginParameters pluginParameters
 
 	backupMethods := []string{
 		string(apiv1.BackupMethodBarmanObjectStore),
 		string(apiv1.BackupMethodVolumeSnapshot),
+		string(apiv1.BackupMethodPgBackRest),
 		string(apiv1.BackupMethodPlugin),
 	}
 
+	backupLevels := []string{
+		string(apiv1.BackupLevelFull),
+		string(apiv1.BackupLevelIncremental),
+		string(apiv1.BackupLevelDifferential),
+	}
+
 	backupSubcommand := &cobra.Command{
 		Use:     "backup CLUSTER",
 		Short:   "Request an on-demand backup for a PostgreSQL Cluster",
@@ -95,6 +103,7 @@ func NewCmd() *cobra.Command {
 				"",
 				string(apiv1.BackupTargetPrimary),
 				string(apiv1.BackupTargetStandby),
+				string(apiv1.BackupTargetStandbyOnly),
 			}
 			if !slices.Contains(allowedBackupTargets, backupTarget) {
 				return fmt.Errorf("backup-target: %s is not supported by the backup command", backupTarget)
@@ -107,6 +116,13 @@ func NewCmd() *cobra.Command {
 				return fmt.Errorf("backup-method: %s is not supported by the backup command", backupMethod)
 			}
 
+			// Check if the backup level is correct
+			allowedBackupLevels := backupLevels
+			allowedBackupLevels = append(allowedBackupLevels, "")
+			if !slices.Contains(allowedBackupLevels, backupLevel) {
+				return fmt.Errorf("level: %s is not supported by the backup command", backupLevel)
+			}
+
 			if backupMethod != string(apiv1.BackupMethodPlugin) {
 				if len(pluginName) > 0 {
 					return fmt.Errorf("plugin-name is allowed only when backup method in %s",
@@ -153,6 +169,7 @@ func NewCmd() *cobra.Command {
 					clusterName:         clusterName,
 					target:              apiv1.BackupTarget(backupTarget),
 					method:              apiv1.BackupMethod(backupMethod),
+					level:               apiv1.BackupLevel(backupLevel),
 					online:              parsedOnline,
 					immediateCheckpoint: parsedImmediateCheckpoint,
 					waitForArchive:      parsedWaitForArchive,
@@ -175,7 +192,7 @@ func NewCmd() *cobra.Command {
 		"t",
 		"",
 		"If present, will override the backup target defined in cluster, "+
-			"valid values are primary and prefer-standby.",
+			"valid values are primary, prefer-standby and standby.",
 	)
 	backupSubcommand.Flags().StringVarP(
 		&backupMethod,
@@ -185,6 +202,14 @@ func NewCmd() *cobra.Command {
 		fmt.Sprintf("If present, will override the backup method defined in backup resource, "+
 			"valid values are: %s.", strings.Join(backupMethods, ", ")),
 	)
+	backupSubcommand.Flags().StringVar(
+		&backupLevel,
+		"level",
+		"",
+		fmt.Sprintf("The level of the backup. Incremental and differential backups "+
+			"are supported only by the pgBackRest method, valid values are: %s.",
+			strings.Join(backupLevels, ", ")),
+	)
 
 	const optionalAcceptedValues = "Optional. Accepted values: true|false|\"\"."
 	backupSubcommand.Flags().StringVar(&online, "online",
@@ -236,6 +261,7 @@ func createBackup(ctx context.Context, options backupCommandOptions) error {
 			},
 			Target:              options.target,
 			Method:              options.method,
+			Level:               options.level,
 			Online:              options.online,
 			OnlineConfiguration: options.getOnlineConfiguration(),
 		},
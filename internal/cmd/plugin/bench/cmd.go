@@ -0,0 +1,152 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "bench" command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Manage the results of the benchmarks",
+		Long: `Lists and compares the results saved by the pgbench and fio commands ` +
+			`when run with the --save-results flag.`,
+		GroupID: plugin.GroupIDMiscellaneous,
+	}
+
+	cmd.AddCommand(listCmd(), compareCmd())
+
+	return cmd
+}
+
+func listCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the saved benchmark results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			results, err := listResults(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if format := plugin.OutputFormat(output); format != plugin.OutputFormatText {
+				return plugin.Print(results, format, os.Stdout)
+			}
+
+			table := tabby.New()
+			table.AddHeader("Name", "Tool", "Completed At", "Parameters")
+			for _, result := range results {
+				parameters := make([]string, 0, len(result.Parameters))
+				for name, value := range result.Parameters {
+					parameters = append(parameters, fmt.Sprintf("%s=%s", name, value))
+				}
+				slices.Sort(parameters)
+				table.AddLine(result.Name, result.Tool, result.CompletedAt.Format("2006-01-02 15:04:05 MST"),
+					strings.Join(parameters, " "))
+			}
+			table.Print()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output", "o", "text", "Output format. One of text|json|yaml")
+
+	return cmd
+}
+
+func compareCmd() *cobra.Command {
+	var output string
+	var threshold float64
+
+	cmd := &cobra.Command{
+		Use:   "compare BASELINE CANDIDATE",
+		Short: "Compare the results of two benchmark runs",
+		Long: `Compares the metrics of two runs of the same benchmarking tool, reporting ` +
+			`whether the performance of the candidate improved or regressed.`,
+		Args: plugin.RequiresArguments(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeResults(cmd, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if threshold < 0 {
+				return fmt.Errorf("the threshold must not be negative: %v", threshold)
+			}
+
+			baseline, err := getResult(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			candidate, err := getResult(cmd.Context(), args[1])
+			if err != nil {
+				return err
+			}
+
+			comparison, err := compare(baseline, candidate, threshold)
+			if err != nil {
+				return err
+			}
+			return comparison.print(os.Stdout, plugin.OutputFormat(output))
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output", "o", "text", "Output format. One of text|json|yaml")
+	cmd.Flags().Float64Var(
+		&threshold,
+		"threshold",
+		5,
+		"The change of a metric, in percentage, below which the metric is considered unchanged",
+	)
+
+	return cmd
+}
+
+// completeResults completes the names of the saved runs
+func completeResults(cmd *cobra.Command, args []string, toComplete string) []string {
+	if len(args) >= 2 {
+		return nil
+	}
+
+	results, err := listResults(cmd.Context())
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, result := range results {
+		if strings.HasPrefix(result.Name, toComplete) && !slices.Contains(args, result.Name) {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,218 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// Verdict is the outcome of the comparison of a metric
+type Verdict string
+
+const (
+	// VerdictImproved means that the metric got better
+	VerdictImproved Verdict = "improved"
+
+	// VerdictRegressed means that the metric got worse
+	VerdictRegressed Verdict = "regressed"
+
+	// VerdictUnchanged means that the change of the metric is within the
+	// threshold
+	VerdictUnchanged Verdict = "unchanged"
+)
+
+// higherIsBetter tells, for the metrics measuring the performance, whether
+// they improve when increasing or decreasing. The other metrics describe
+// the run, and are compared without a verdict
+var higherIsBetter = map[string]bool{
+	"tps":                        true,
+	"latency_average_ms":         false,
+	"latency_stddev_ms":          false,
+	"initial_connection_time_ms": false,
+	"failed_transactions":        false,
+	"bandwidth_kib_s":            true,
+	"iops":                       true,
+}
+
+// MetricComparison is the comparison of a metric between two runs
+type MetricComparison struct {
+	// Name is the name of the metric
+	Name string `json:"name"`
+
+	// Baseline is the value of the metric in the first run
+	Baseline *float64 `json:"baseline,omitempty"`
+
+	// Candidate is the value of the metric in the second run
+	Candidate *float64 `json:"candidate,omitempty"`
+
+	// ChangePercent is the change of the metric, in percentage of the
+	// value in the first run
+	ChangePercent *float64 `json:"changePercent,omitempty"`
+
+	// Verdict is the outcome of the comparison, for the metrics
+	// measuring the performance
+	Verdict Verdict `json:"verdict,omitempty"`
+}
+
+// Comparison is the comparison between two runs
+type Comparison struct {
+	// Baseline is the first run
+	Baseline *Result `json:"baseline"`
+
+	// Candidate is the second run
+	Candidate *Result `json:"candidate"`
+
+	// Metrics are the comparisons of the metrics
+	Metrics []MetricComparison `json:"metrics"`
+}
+
+// compare compares the metrics of two runs of the same tool. A change
+// whose magnitude is within the threshold, in percentage, is considered
+// as no change
+func compare(baseline, candidate *Result, threshold float64) (*Comparison, error) {
+	if baseline.Tool != candidate.Tool {
+		return nil, fmt.Errorf("cannot compare a %s run with a %s one", baseline.Tool, candidate.Tool)
+	}
+
+	names := slices.Sorted(maps.Keys(baseline.Metrics))
+	for name := range candidate.Metrics {
+		if _, ok := baseline.Metrics[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	result := &Comparison{Baseline: baseline, Candidate: candidate}
+	for _, name := range names {
+		metric := MetricComparison{Name: name}
+		if value, ok := baseline.Metrics[name]; ok {
+			metric.Baseline = &value
+		}
+		if value, ok := candidate.Metrics[name]; ok {
+			metric.Candidate = &value
+		}
+
+		if metric.Baseline != nil && metric.Candidate != nil {
+			metric.ChangePercent, metric.Verdict = compareValues(name, *metric.Baseline, *metric.Candidate, threshold)
+		}
+		result.Metrics = append(result.Metrics, metric)
+	}
+
+	return result, nil
+}
+
+// compareValues computes the change of a metric, and its verdict when
+// the metric measures the performance
+func compareValues(name string, baseline, candidate, threshold float64) (*float64, Verdict) {
+	var change float64
+	switch {
+	case baseline != 0:
+		change = (candidate - baseline) / math.Abs(baseline) * 100
+	case candidate != 0:
+		change = math.Copysign(100, candidate)
+	}
+
+	improvesWhenHigher, ok := higherIsBetter[name]
+	switch {
+	case !ok:
+		return &change, ""
+	case math.Abs(change) <= threshold:
+		return &change, VerdictUnchanged
+	case (change > 0) == improvesWhenHigher:
+		return &change, VerdictImproved
+	default:
+		return &change, VerdictRegressed
+	}
+}
+
+// print shows the comparison in the requested format
+func (comparison *Comparison) print(writer io.Writer, format plugin.OutputFormat) error {
+	if format != plugin.OutputFormatText {
+		return plugin.Print(comparison, format, writer)
+	}
+
+	_, _ = fmt.Fprintln(writer, aurora.Green("Runs"))
+	runs := tabby.NewCustom(newTabWriter(writer))
+	runs.AddHeader("", "Baseline", "Candidate")
+	runs.AddLine("Name", comparison.Baseline.Name, comparison.Candidate.Name)
+	runs.AddLine("Completed At", comparison.Baseline.CompletedAt.Format("2006-01-02 15:04:05 MST"),
+		comparison.Candidate.CompletedAt.Format("2006-01-02 15:04:05 MST"))
+	parameters := slices.Sorted(maps.Keys(comparison.Baseline.Parameters))
+	for name := range comparison.Candidate.Parameters {
+		if _, ok := comparison.Baseline.Parameters[name]; !ok {
+			parameters = append(parameters, name)
+		}
+	}
+	slices.Sort(parameters)
+	for _, name := range parameters {
+		runs.AddLine(name, comparison.Baseline.Parameters[name], comparison.Candidate.Parameters[name])
+	}
+	runs.Print()
+	_, _ = fmt.Fprintln(writer)
+
+	_, _ = fmt.Fprintln(writer, aurora.Green("Metrics"))
+	metrics := tabby.NewCustom(newTabWriter(writer))
+	metrics.AddHeader("Metric", "Baseline", "Candidate", "Change", "Verdict")
+	for _, metric := range comparison.Metrics {
+		var verdict interface{} = metric.Verdict
+		switch metric.Verdict {
+		case VerdictImproved:
+			verdict = aurora.Green(metric.Verdict)
+		case VerdictRegressed:
+			verdict = aurora.Red(metric.Verdict)
+		}
+		metrics.AddLine(metric.Name, formatValue(metric.Baseline), formatValue(metric.Candidate),
+			formatChange(metric.ChangePercent), verdict)
+	}
+	metrics.Print()
+	return nil
+}
+
+// formatValue formats the value of a metric
+func formatValue(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	if *value == math.Trunc(*value) {
+		return strconv.FormatFloat(*value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(*value, 'f', 3, 64)
+}
+
+// formatChange formats the change of a metric
+func formatChange(change *float64) string {
+	if change == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f%%", *change)
+}
+
+// newTabWriter creates the tab writer used to print the tables
+func newTabWriter(writer io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+}
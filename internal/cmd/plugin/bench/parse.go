@@ -0,0 +1,139 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// errNoResults is raised when the output of a run contains no results
+var errNoResults = errors.New("no benchmark results found")
+
+// pgbenchMetrics are the metrics reported by pgbench, and the regular
+// expressions matching them in its output
+var pgbenchMetrics = []struct {
+	name   string
+	regexp *regexp.Regexp
+}{
+	{name: "scaling_factor", regexp: regexp.MustCompile(`(?m)^scaling factor: (\d+)`)},
+	{name: "clients", regexp: regexp.MustCompile(`(?m)^number of clients: (\d+)`)},
+	{name: "threads", regexp: regexp.MustCompile(`(?m)^number of threads: (\d+)`)},
+	{name: "transactions", regexp: regexp.MustCompile(`(?m)^number of transactions actually processed: (\d+)`)},
+	{name: "failed_transactions", regexp: regexp.MustCompile(`(?m)^number of failed transactions: (\d+)`)},
+	{name: "latency_average_ms", regexp: regexp.MustCompile(`(?m)^latency average = ([\d.]+) ms`)},
+	{name: "latency_stddev_ms", regexp: regexp.MustCompile(`(?m)^latency stddev = ([\d.]+) ms`)},
+	{name: "initial_connection_time_ms", regexp: regexp.MustCompile(`(?m)^initial connection time = ([\d.]+) ms`)},
+	{
+		name: "tps",
+		regexp: regexp.MustCompile(
+			`(?m)^tps = ([\d.]+) \((?:without initial connection time|excluding connections establishing)\)`),
+	},
+}
+
+// ParsePgbenchOutput gets the metrics from the output of pgbench
+func ParsePgbenchOutput(output string) (map[string]float64, error) {
+	metrics := make(map[string]float64)
+	for _, metric := range pgbenchMetrics {
+		match := metric.regexp.FindStringSubmatch(output)
+		if match == nil {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing %s: %w", metric.name, err)
+		}
+		metrics[metric.name] = value
+	}
+
+	if _, ok := metrics["tps"]; !ok {
+		return nil, errNoResults
+	}
+	return metrics, nil
+}
+
+// fioLogs are the logs written by the fio job, and the metrics computed
+// from them. The bandwidth is logged in KiB/s and the latency in nanoseconds
+var fioLogs = []struct {
+	// fileName is the name of the log file
+	fileName string
+
+	// metric is the name of the metric
+	metric string
+
+	// scale converts the logged values in the unit of the metric
+	scale float64
+}{
+	{fileName: "read_bw.1.log", metric: "bandwidth_kib_s", scale: 1},
+	{fileName: "read_iops.1.log", metric: "iops", scale: 1},
+	{fileName: "read_lat.1.log", metric: "latency_average_ms", scale: 1e-6},
+}
+
+// GetFioMetrics computes the metrics of a fio run from the logs written
+// by the job, read with the passed function
+func GetFioMetrics(readLog func(fileName string) (string, error)) (map[string]float64, error) {
+	metrics := make(map[string]float64, len(fioLogs))
+	for _, log := range fioLogs {
+		content, err := readLog(log.fileName)
+		if err != nil {
+			return nil, fmt.Errorf("while reading the fio log %s: %w", log.fileName, err)
+		}
+
+		average, err := parseFioLog(content)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing the fio log %s: %w", log.fileName, err)
+		}
+		metrics[log.metric] = average * log.scale
+	}
+
+	return metrics, nil
+}
+
+// parseFioLog gets the average of the values logged by fio, one sample
+// per line in the "time, value, direction, block size, offset" format
+func parseFioLog(content string) (float64, error) {
+	var sum float64
+	var samples int
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ",")
+		if len(fields) < 2 {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid line %q: %w", scanner.Text(), err)
+		}
+		sum += value
+		samples++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if samples == 0 {
+		return 0, errNoResults
+	}
+	return sum / float64(samples), nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pgbench output", func() {
+	It("gets the metrics", func() {
+		output := `pgbench (17.2 (Debian 17.2-1.pgdg110+1))
+starting vacuum...end.
+transaction type: <builtin: TPC-B (sort of)>
+scaling factor: 10
+query mode: simple
+number of clients: 4
+number of threads: 2
+maximum number of tries: 1
+duration: 30 s
+number of transactions actually processed: 12345
+number of failed transactions: 0 (0.000%)
+latency average = 9.723 ms
+latency stddev = 1.250 ms
+initial connection time = 12.345 ms
+tps = 411.500000 (without initial connection time)
+`
+		Expect(ParsePgbenchOutput(output)).To(Equal(map[string]float64{
+			"scaling_factor":             10,
+			"clients":                    4,
+			"threads":                    2,
+			"transactions":               12345,
+			"failed_transactions":        0,
+			"latency_average_ms":         9.723,
+			"latency_stddev_ms":          1.25,
+			"initial_connection_time_ms": 12.345,
+			"tps":                        411.5,
+		}))
+	})
+
+	It("supports the output of the older versions", func() {
+		output := "latency average = 2.000 ms\n" +
+			"tps = 498.000000 (including connections establishing)\n" +
+			"tps = 500.000000 (excluding connections establishing)\n"
+		Expect(ParsePgbenchOutput(output)).To(Equal(map[string]float64{
+			"latency_average_ms": 2,
+			"tps":                500,
+		}))
+	})
+
+	It("fails without results, as when initializing the database", func() {
+		_, err := ParsePgbenchOutput("creating tables...\ndone in 1.23 s\n")
+		Expect(err).To(MatchError(errNoResults))
+	})
+})
+
+var _ = Describe("fio logs", func() {
+	It("computes the metrics from the averages of the logs", func() {
+		logs := map[string]string{
+			"read_bw.1.log":   "1000, 102400, 0, 8192, 0\n2000, 104448, 0, 8192, 0\n",
+			"read_iops.1.log": "1000, 12800, 0, 8192, 0\n2000, 13056, 0, 8192, 0\n",
+			"read_lat.1.log":  "1000, 2000000, 0, 8192, 0\n2000, 3000000, 0, 8192, 0\n",
+		}
+		metrics, err := GetFioMetrics(func(fileName string) (string, error) {
+			return logs[fileName], nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics).To(Equal(map[string]float64{
+			"bandwidth_kib_s":    103424,
+			"iops":               12928,
+			"latency_average_ms": 2.5,
+		}))
+	})
+
+	It("fails when a log is missing or invalid", func() {
+		_, err := GetFioMetrics(func(fileName string) (string, error) {
+			return "", errors.New("not found")
+		})
+		Expect(err).To(HaveOccurred())
+
+		_, err = GetFioMetrics(func(fileName string) (string, error) {
+			return "", nil
+		})
+		Expect(err).To(MatchError(errNoResults))
+
+		_, err = GetFioMetrics(func(fileName string) (string, error) {
+			return fmt.Sprintf("1000, %s, 0, 8192, 0\n", "fast"), nil
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// ToolPgbench is the name of the pgbench benchmarking tool
+	ToolPgbench = "pgbench"
+
+	// ToolFio is the name of the fio benchmarking tool
+	ToolFio = "fio"
+
+	// resultKey is the key of the ConfigMap containing the result
+	resultKey = "result.json"
+
+	// resultSuffix is the suffix of the name of the ConfigMaps containing
+	// the results
+	resultSuffix = "-results"
+)
+
+// Result is the outcome of a benchmark run
+type Result struct {
+	// Name is the name of the run, such as the name of the pgbench job
+	Name string `json:"name"`
+
+	// Tool is the benchmarking tool
+	Tool string `json:"tool"`
+
+	// CompletedAt is when the results were collected
+	CompletedAt metav1.Time `json:"completedAt"`
+
+	// Parameters are the conditions of the run, such as the benchmarked
+	// cluster or the storage class
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Metrics are the measured values, by name
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// getConfigMapName gets the name of the ConfigMap containing the results
+// of a run
+func getConfigMapName(name string) string {
+	return name + resultSuffix
+}
+
+// toConfigMap stores the result in a ConfigMap
+func (result *Result) toConfigMap(namespace string) (*corev1.ConfigMap, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getConfigMapName(result.Name),
+			Namespace: namespace,
+			Labels: map[string]string{
+				utils.BenchmarkLabelName: result.Tool,
+			},
+		},
+		Data: map[string]string{
+			resultKey: string(data),
+		},
+	}, nil
+}
+
+// fromConfigMap reads the result stored in a ConfigMap
+func fromConfigMap(configMap *corev1.ConfigMap) (*Result, error) {
+	data, ok := configMap.Data[resultKey]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s contains no benchmark result", configMap.Name)
+	}
+
+	var result Result
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, fmt.Errorf("while decoding the benchmark result of configmap %s: %w", configMap.Name, err)
+	}
+	return &result, nil
+}
+
+// SaveResult stores the result of a run in a ConfigMap, replacing the
+// one of a previous run with the same name
+func SaveResult(ctx context.Context, result *Result) error {
+	configMap, err := result.toConfigMap(plugin.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var existing corev1.ConfigMap
+	err = plugin.Client.Get(ctx, client.ObjectKeyFromObject(configMap), &existing)
+	switch {
+	case err == nil:
+		existing.Labels = configMap.Labels
+		existing.Data = configMap.Data
+		err = plugin.Client.Update(ctx, &existing)
+	case client.IgnoreNotFound(err) == nil:
+		err = plugin.Client.Create(ctx, configMap)
+	}
+	if err != nil {
+		return fmt.Errorf("while saving the benchmark result: %w", err)
+	}
+
+	fmt.Printf("benchmark results saved in configmap/%v\n", configMap.Name)
+	return nil
+}
+
+// getResult gets the result of a run
+func getResult(ctx context.Context, name string) (*Result, error) {
+	var configMap corev1.ConfigMap
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: getConfigMapName(name)},
+		&configMap,
+	); err != nil {
+		return nil, fmt.Errorf("while getting the benchmark result of %s: %w", name, err)
+	}
+
+	return fromConfigMap(&configMap)
+}
+
+// listResults gets the results of all the runs, in the order they
+// were completed
+func listResults(ctx context.Context) ([]Result, error) {
+	var configMaps corev1.ConfigMapList
+	if err := plugin.Client.List(
+		ctx,
+		&configMaps,
+		client.InNamespace(plugin.Namespace),
+		client.HasLabels{utils.BenchmarkLabelName},
+	); err != nil {
+		return nil, fmt.Errorf("while listing the benchmark results: %w", err)
+	}
+
+	results := make([]Result, 0, len(configMaps.Items))
+	for idx := range configMaps.Items {
+		result, err := fromConfigMap(&configMaps.Items[idx])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CompletedAt.Before(&results[j].CompletedAt)
+	})
+	return results, nil
+}
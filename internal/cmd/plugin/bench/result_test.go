@@ -0,0 +1,118 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"bytes"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("benchmark results", func() {
+	completedAt := metav1.NewTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	baseline := &Result{
+		Name:        "cluster-example-pgbench-1",
+		Tool:        ToolPgbench,
+		CompletedAt: completedAt,
+		Parameters:  map[string]string{"cluster": "cluster-example", "storageClass": "standard"},
+		Metrics: map[string]float64{
+			"clients":            4,
+			"tps":                400,
+			"latency_average_ms": 10,
+			"latency_stddev_ms":  1,
+		},
+	}
+	candidate := &Result{
+		Name:        "cluster-example-pgbench-2",
+		Tool:        ToolPgbench,
+		CompletedAt: completedAt,
+		Parameters:  map[string]string{"cluster": "cluster-example", "storageClass": "premium"},
+		Metrics: map[string]float64{
+			"clients":             4,
+			"tps":                 500,
+			"latency_average_ms":  8,
+			"latency_stddev_ms":   1.02,
+			"failed_transactions": 1,
+		},
+	}
+
+	It("stores the results in a ConfigMap", func() {
+		configMap, err := baseline.toConfigMap("default")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Name).To(Equal("cluster-example-pgbench-1-results"))
+		Expect(configMap.Labels).To(HaveKeyWithValue(utils.BenchmarkLabelName, ToolPgbench))
+
+		result, err := fromConfigMap(configMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Name).To(Equal(baseline.Name))
+		Expect(result.Tool).To(Equal(baseline.Tool))
+		Expect(result.CompletedAt.Equal(&baseline.CompletedAt)).To(BeTrue())
+		Expect(result.Parameters).To(Equal(baseline.Parameters))
+		Expect(result.Metrics).To(Equal(baseline.Metrics))
+
+		configMap.Data = nil
+		_, err = fromConfigMap(configMap)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("compares two runs", func() {
+		comparison, err := compare(baseline, candidate, 5)
+		Expect(err).ToNot(HaveOccurred())
+
+		verdicts := make(map[string]Verdict)
+		for _, metric := range comparison.Metrics {
+			verdicts[metric.Name] = metric.Verdict
+		}
+		Expect(verdicts).To(Equal(map[string]Verdict{
+			"clients":             "",
+			"failed_transactions": "",
+			"latency_average_ms":  VerdictImproved,
+			"latency_stddev_ms":   VerdictUnchanged,
+			"tps":                 VerdictImproved,
+		}))
+
+		Expect(comparison.Metrics[4].Name).To(Equal("tps"))
+		Expect(*comparison.Metrics[4].ChangePercent).To(BeNumerically("~", 25))
+		Expect(comparison.Metrics[1].Name).To(Equal("failed_transactions"))
+		Expect(comparison.Metrics[1].Baseline).To(BeNil())
+
+		var buffer bytes.Buffer
+		Expect(comparison.print(&buffer, plugin.OutputFormatText)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("premium"))
+		Expect(buffer.String()).To(ContainSubstring("+25.00%"))
+	})
+
+	It("reports the regressions", func() {
+		comparison, err := compare(candidate, baseline, 5)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comparison.Metrics[4].Verdict).To(Equal(VerdictRegressed))
+	})
+
+	It("refuses to compare runs of different tools", func() {
+		fio := &Result{Name: "fio", Tool: ToolFio}
+		_, err := compare(baseline, fio, 5)
+		Expect(err).To(HaveOccurred())
+	})
+})
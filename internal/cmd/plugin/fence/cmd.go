@@ -37,7 +37,12 @@ var (
 				node = fmt.Sprintf("%s-%s", clusterName, node)
 			}
 
-			return fencingOn(cmd.Context(), clusterName, node)
+			duration, _ := cmd.Flags().GetDuration("duration")
+			if duration < 0 {
+				return fmt.Errorf("the fencing duration cannot be negative: %v", duration)
+			}
+
+			return fencingOn(cmd.Context(), clusterName, node, duration)
 		},
 	}
 
@@ -63,6 +68,12 @@ func NewCmd() *cobra.Command {
 		Short:   `Fencing related commands`,
 		GroupID: plugin.GroupIDCluster,
 	}
+	fenceOnCmd.Flags().Duration(
+		"duration",
+		0,
+		"Automatically lift the fencing after the given amount of time (e.g. 30m, 2h). "+
+			"By default, the fencing is kept until it is removed",
+	)
 	cmd.AddCommand(fenceOnCmd)
 	cmd.AddCommand(fenceOffCmd)
 
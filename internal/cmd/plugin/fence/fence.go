@@ -20,6 +20,7 @@ package fence
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 
@@ -28,19 +29,31 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-// fencingOn marks an instance in a cluster as fenced
-func fencingOn(ctx context.Context, clusterName string, serverName string) error {
-	err := utils.NewFencingMetadataExecutor(plugin.Client).
+// fencingOn marks an instance in a cluster as fenced, for the
+// given amount of time if the duration is not zero
+func fencingOn(ctx context.Context, clusterName string, serverName string, duration time.Duration) error {
+	executor := utils.NewFencingMetadataExecutor(plugin.Client).
 		AddFencing().
-		ForInstance(serverName).
-		Execute(ctx,
-			types.NamespacedName{Name: clusterName, Namespace: plugin.Namespace},
-			&apiv1.Cluster{},
-		)
+		ForInstance(serverName)
+
+	var expiration time.Time
+	if duration > 0 {
+		expiration = time.Now().Add(duration)
+		executor = executor.WithExpiration(expiration)
+	}
+
+	err := executor.Execute(ctx,
+		types.NamespacedName{Name: clusterName, Namespace: plugin.Namespace},
+		&apiv1.Cluster{},
+	)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%s fenced\n", serverName)
+	if expiration.IsZero() {
+		fmt.Printf("%s fenced\n", serverName)
+		return nil
+	}
+	fmt.Printf("%s fenced until %s\n", serverName, expiration.UTC().Format(time.RFC3339))
 	return nil
 }
 
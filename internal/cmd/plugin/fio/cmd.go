@@ -29,7 +29,7 @@ import (
 // NewCmd initializes the fio command
 func NewCmd() *cobra.Command {
 	var storageClassName, deploymentName, pvcSize string
-	var dryRun bool
+	var dryRun, saveResults bool
 
 	fioCmd := &cobra.Command{
 		Use:     "fio [name]",
@@ -42,7 +42,7 @@ func NewCmd() *cobra.Command {
 			ctx := context.Background()
 			fioArgs := args[1:]
 			deploymentName = args[0]
-			fioCommand := newFioCommand(deploymentName, storageClassName, pvcSize, dryRun, fioArgs)
+			fioCommand := newFioCommand(deploymentName, storageClassName, pvcSize, dryRun, saveResults, fioArgs)
 			return fioCommand.execute(ctx)
 		},
 		PreRun: func(_ *cobra.Command, _ []string) {
@@ -86,6 +86,12 @@ func NewCmd() *cobra.Command {
 		false,
 		"When true prints the deployment manifest instead of creating it",
 	)
+	fioCmd.Flags().BoolVar(
+		&saveResults,
+		"save-results",
+		false,
+		"When true waits for the fio job to complete and saves its results in a ConfigMap",
+	)
 
 	return fioCmd
 }
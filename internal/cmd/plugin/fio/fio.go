@@ -38,6 +38,7 @@ type fioCommand struct {
 	pvcSize          string
 	fioCommandArgs   []string
 	dryRun           bool
+	saveResults      bool
 }
 
 const (
@@ -57,6 +58,9 @@ var jobExample = `
 
   # Create a job with given values and clusterName "cluster-example"
   kubectl-cnpg fio <fio-name> -n <namespace> --storageClass <name> --pvcSize <size>
+
+  # Create a job and save its results, to be compared with "kubectl-cnpg bench compare"
+  kubectl-cnpg fio <fio-name> -n <namespace> --storageClass <name> --save-results
 `
 
 // newFioCommand initialize fio deployment options
@@ -65,12 +69,14 @@ func newFioCommand(
 	storageClassName string,
 	pvcSize string,
 	dryRun bool,
+	saveResults bool,
 	fioCommandArgs []string,
 ) *fioCommand {
 	fioArgs := &fioCommand{
 		name:             name,
 		storageClassName: storageClassName,
 		dryRun:           dryRun,
+		saveResults:      saveResults,
 		fioCommandArgs:   fioCommandArgs,
 		pvcSize:          pvcSize,
 	}
@@ -86,7 +92,14 @@ func (cmd *fioCommand) execute(ctx context.Context) error {
 	deployment := cmd.generateFioDeployment(cmd.name)
 	objectList := []client.Object{pvc, configMap, deployment}
 
-	return plugin.CreateAndGenerateObjects(ctx, objectList, cmd.dryRun)
+	if err := plugin.CreateAndGenerateObjects(ctx, objectList, cmd.dryRun); err != nil {
+		return err
+	}
+
+	if cmd.saveResults && !cmd.dryRun {
+		return cmd.collectResults(ctx)
+	}
+	return nil
 }
 
 // CreatePVC creates spec of a PVC, given its name and the storage configuration
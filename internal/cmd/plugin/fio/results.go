@@ -0,0 +1,108 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/bench"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// resultsPollInterval is the interval between two attempts of
+	// collecting the results
+	resultsPollInterval = 10 * time.Second
+
+	// resultsPort is the port where the fio pod serves its logs
+	resultsPort = "8000"
+)
+
+// collectResults waits for the fio job to complete, and saves the metrics
+// computed from the logs served by the fio pod. The logs are only written
+// when the job completes
+func (cmd *fioCommand) collectResults(ctx context.Context) error {
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+
+	fmt.Printf("waiting for the fio job of deployment/%v to complete\n", cmd.name)
+	var metrics map[string]float64
+	err := wait.PollUntilContextCancel(ctx, resultsPollInterval, true, func(ctx context.Context) (bool, error) {
+		pod, err := cmd.getReadyPod(ctx)
+		if err != nil || pod == nil {
+			return false, err
+		}
+
+		var metricsErr error
+		metrics, metricsErr = bench.GetFioMetrics(func(fileName string) (string, error) {
+			data, err := clientInterface.CoreV1().Pods(pod.Namespace).
+				ProxyGet("http", pod.Name, resultsPort, fileName, nil).
+				DoRaw(ctx)
+			return string(data), err
+		})
+		return metricsErr == nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	storageClass := cmd.storageClassName
+	if storageClass == "" {
+		storageClass = "default"
+	}
+	return bench.SaveResult(ctx, &bench.Result{
+		Name:        cmd.name,
+		Tool:        bench.ToolFio,
+		CompletedAt: metav1.Now(),
+		Parameters: map[string]string{
+			"storageClass": storageClass,
+			"pvcSize":      cmd.pvcSize,
+		},
+		Metrics: metrics,
+	})
+}
+
+// getReadyPod gets the ready pod of the fio deployment, if any
+func (cmd *fioCommand) getReadyPod(ctx context.Context) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := plugin.Client.List(
+		ctx,
+		&pods,
+		client.InNamespace(plugin.Namespace),
+		client.MatchingLabels{
+			"app.kubernetes.io/name":     fioKeyWord,
+			"app.kubernetes.io/instance": cmd.name,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	for idx := range pods.Items {
+		if utils.IsPodReady(pods.Items[idx]) {
+			return &pods.Items[idx], nil
+		}
+	}
+	return nil, nil
+}
@@ -71,6 +71,13 @@ func NewCmd() *cobra.Command {
 		"When true prints the job manifest instead of creating it",
 	)
 
+	pgBenchCmd.Flags().BoolVar(
+		&run.saveResults,
+		"save-results",
+		false,
+		"When true waits for the job to complete and saves its results in a ConfigMap",
+	)
+
 	pgBenchCmd.Flags().StringSliceVar(
 		&run.nodeSelector,
 		"node-selector",
@@ -40,6 +40,7 @@ type pgBenchRun struct {
 	nodeSelector       []string
 	pgBenchCommandArgs []string
 	dryRun             bool
+	saveResults        bool
 }
 
 const (
@@ -59,6 +60,10 @@ var jobExample = `
 
   # Create a job with given values and [cluster] "cluster-example"
   kubectl-cnpg pgbench cluster-example --db-name pgbenchDBName --job-name job-name -- \
+    --time 30 --client 1 --jobs 1
+
+  # Create a job and save its results, to be compared with "kubectl-cnpg bench compare"
+  kubectl-cnpg pgbench cluster-example --job-name job-name --save-results -- \
     --time 30 --client 1 --jobs 1`
 
 func (cmd *pgBenchRun) execute(ctx context.Context) error {
@@ -78,6 +83,10 @@ func (cmd *pgBenchRun) execute(ctx context.Context) error {
 	}
 
 	fmt.Printf("job/%v created\n", job.Name)
+
+	if cmd.saveResults {
+		return cmd.saveJobResults(ctx, cluster, job)
+	}
 	return nil
 }
 
@@ -0,0 +1,139 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbench
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/bench"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/logs"
+)
+
+// jobPollInterval is the interval between two checks of the job status
+const jobPollInterval = 5 * time.Second
+
+// saveJobResults waits for the pgbench job to complete, and saves the
+// metrics found in its output
+func (cmd *pgBenchRun) saveJobResults(ctx context.Context, cluster *apiv1.Cluster, job *batchv1.Job) error {
+	fmt.Printf("waiting for job/%v to complete\n", job.Name)
+	if err := waitForJob(ctx, job); err != nil {
+		return err
+	}
+
+	output, err := getJobOutput(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	metrics, err := bench.ParsePgbenchOutput(output)
+	if err != nil {
+		return fmt.Errorf("while parsing the output of job %s: %w", job.Name, err)
+	}
+
+	return bench.SaveResult(ctx, &bench.Result{
+		Name:        job.Name,
+		Tool:        bench.ToolPgbench,
+		CompletedAt: metav1.Now(),
+		Parameters:  cmd.getResultParameters(cluster),
+		Metrics:     metrics,
+	})
+}
+
+// getResultParameters gets the conditions of the run, describing the
+// benchmarked cluster
+func (cmd *pgBenchRun) getResultParameters(cluster *apiv1.Cluster) map[string]string {
+	parameters := map[string]string{
+		"cluster":   cluster.Name,
+		"database":  cmd.dbName,
+		"arguments": strings.Join(cmd.pgBenchCommandArgs, " "),
+		"instances": strconv.Itoa(cluster.Spec.Instances),
+		"imageName": cluster.Status.Image,
+	}
+	if storageClass := cluster.Spec.StorageConfiguration.StorageClass; storageClass != nil {
+		parameters["storageClass"] = *storageClass
+	}
+	return parameters
+}
+
+// waitForJob waits for the job to complete, failing if the job fails
+func waitForJob(ctx context.Context, job *batchv1.Job) error {
+	return wait.PollUntilContextCancel(ctx, jobPollInterval, true, func(ctx context.Context) (bool, error) {
+		if err := plugin.Client.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+			return false, err
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobComplete:
+				return true, nil
+			case batchv1.JobFailed:
+				return false, fmt.Errorf("job %s failed: %s", job.Name, condition.Message)
+			}
+		}
+		return false, nil
+	})
+}
+
+// getJobOutput gets the output of the pod that completed the job
+func getJobOutput(ctx context.Context, job *batchv1.Job) (string, error) {
+	var pods corev1.PodList
+	if err := plugin.Client.List(
+		ctx,
+		&pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return "", fmt.Errorf("while listing the pods of job %s: %w", job.Name, err)
+	}
+
+	for idx := range pods.Items {
+		pod := pods.Items[idx]
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+
+		var output bytes.Buffer
+		streamPodLogs := &logs.StreamingRequest{
+			Pod:     &pod,
+			Options: &corev1.PodLogOptions{Container: pgBenchKeyWord},
+			Client:  kubernetes.NewForConfigOrDie(plugin.Config),
+		}
+		if err := streamPodLogs.Stream(ctx, &output); err != nil {
+			return "", fmt.Errorf("while getting the logs of pod %s: %w", pod.Name, err)
+		}
+		return output.String(), nil
+	}
+
+	return "", fmt.Errorf("no completed pod found for job %s", job.Name)
+}
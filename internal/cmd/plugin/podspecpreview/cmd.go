@@ -0,0 +1,58 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspecpreview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "pod-spec-preview" command
+func NewCmd() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "pod-spec-preview CLUSTER",
+		Short: `Preview the instance pods of a cluster`,
+		Long: `Prints the instance pod of the cluster as it would be created by the operator, ` +
+			`including the mutations of the plugins. No pod is created.`,
+		GroupID: plugin.GroupIDCluster,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if timeout <= 0 {
+				return fmt.Errorf("the timeout must be positive: %v", timeout)
+			}
+			return Preview(cmd.Context(), args[0], timeout)
+		},
+	}
+
+	cmd.Flags().DurationVar(
+		&timeout,
+		"timeout",
+		30*time.Second,
+		"The time to wait for the operator to render the preview",
+	)
+
+	return cmd
+}
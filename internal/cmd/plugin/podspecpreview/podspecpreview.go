@@ -0,0 +1,81 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package podspecpreview implements a command to preview the instance
+// pods of a cluster, including the mutations of the plugins
+package podspecpreview
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// Preview requests the operator to render the instance pod of the
+// cluster, and prints it as soon as it is available
+func Preview(ctx context.Context, clusterName string, timeout time.Duration) error {
+	var cluster apiv1.Cluster
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return err
+	}
+
+	requestedAt := pgTime.GetCurrentTimestamp()
+	origCluster := cluster.DeepCopy()
+	if cluster.Annotations == nil {
+		cluster.Annotations = make(map[string]string)
+	}
+	cluster.Annotations[utils.PodSpecPreviewAnnotationName] = requestedAt
+	cluster.ManagedFields = nil
+	if err := plugin.Client.Patch(ctx, &cluster, client.MergeFrom(origCluster)); err != nil {
+		return err
+	}
+
+	var configMap corev1.ConfigMap
+	err = wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		err := plugin.Client.Get(ctx, client.ObjectKey{
+			Namespace: plugin.Namespace,
+			Name:      cluster.GetPodSpecPreviewName(),
+		}, &configMap)
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return configMap.Annotations[utils.PodSpecPreviewAnnotationName] == requestedAt, nil
+	})
+	if err != nil {
+		return fmt.Errorf("while waiting for the operator to render the preview: %w", err)
+	}
+
+	if message, ok := configMap.Data[specs.PodSpecPreviewErrorKey]; ok {
+		return fmt.Errorf("cannot render the instance pod: %s", message)
+	}
+
+	fmt.Print(configMap.Data[specs.PodSpecPreviewKey])
+	return nil
+}
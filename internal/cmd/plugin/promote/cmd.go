@@ -28,14 +28,40 @@ import (
 
 // NewCmd create the new "promote" subcommand
 func NewCmd() *cobra.Command {
+	var replicaOptions ReplicaOptions
+
 	promoteCmd := &cobra.Command{
-		Use:     "promote CLUSTER INSTANCE",
-		Short:   "Promote the instance named CLUSTER-INSTANCE to primary",
+		Use:   "promote CLUSTER [INSTANCE]",
+		Short: "Promote the instance named CLUSTER-INSTANCE to primary, or the replica cluster CLUSTER",
+		Long: `Promote the instance named CLUSTER-INSTANCE to primary.
+
+When only the name of a replica cluster is given, the replica cluster is
+promoted once its designated primary received the WAL up to the target
+set with --to-timestamp or --to-lsn, optionally waiting for it to catch up.`,
+		Example: `  # Promote the instance cluster-example-2
+  kubectl cnpg promote cluster-example 2
+
+  # Promote a replica cluster, waiting up to 5 minutes for it to replay
+  # the transactions committed before the given time
+  kubectl cnpg promote cluster-dr --to-timestamp "2024-03-01 10:00:00+00" --wait 5m`,
 		GroupID: plugin.GroupIDCluster,
-		Args:    plugin.RequiresArguments(2),
-		RunE: func(_ *cobra.Command, args []string) error {
+		Args:    plugin.RequiresArguments(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			clusterName := args[0]
+			if len(args) == 1 {
+				if replicaOptions.TargetTime == "" && replicaOptions.TargetLSN == "" {
+					return fmt.Errorf("either an instance or one of --to-timestamp and --to-lsn is required")
+				}
+				return PromoteReplicaCluster(ctx, clusterName, replicaOptions)
+			}
+
+			for _, flag := range []string{"to-timestamp", "to-lsn", "wait", "dry-run"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s can only be used to promote a replica cluster", flag)
+				}
+			}
+
 			node := args[1]
 			if _, err := strconv.Atoi(args[1]); err == nil {
 				node = fmt.Sprintf("%s-%s", clusterName, node)
@@ -44,5 +70,31 @@ func NewCmd() *cobra.Command {
 		},
 	}
 
+	promoteCmd.Flags().StringVar(
+		&replicaOptions.TargetTime,
+		"to-timestamp",
+		"",
+		"The time up to which the designated primary of the replica cluster must have replayed the transactions",
+	)
+	promoteCmd.Flags().StringVar(
+		&replicaOptions.TargetLSN,
+		"to-lsn",
+		"",
+		"The LSN up to which the designated primary of the replica cluster must have received the WAL",
+	)
+	promoteCmd.Flags().DurationVar(
+		&replicaOptions.WaitTimeout,
+		"wait",
+		0,
+		"How long to wait for the replica cluster to catch up with the target. "+
+			"By default, the promotion fails if the target is not already reached",
+	)
+	promoteCmd.Flags().BoolVar(
+		&replicaOptions.DryRun,
+		"dry-run",
+		false,
+		"Verify the target without promoting the replica cluster",
+	)
+
 	return promoteCmd
 }
@@ -0,0 +1,276 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// replicaStatusQuery gets the position of the designated primary of a
+// replica cluster. The WAL files restored from the archive are not
+// counted as received, hence the greatest of the received and the
+// replayed LSN is used
+const replicaStatusQuery = `SELECT pg_catalog.pg_is_in_recovery(),
+  GREATEST(pg_catalog.pg_last_wal_receive_lsn(), pg_catalog.pg_last_wal_replay_lsn()),
+  COALESCE(EXTRACT(EPOCH FROM pg_catalog.pg_last_xact_replay_timestamp())::text, '')`
+
+// catchUpPollInterval is the interval between two checks of the
+// position of the designated primary, while waiting for it to catch up
+const catchUpPollInterval = 5 * time.Second
+
+// ReplicaOptions are the options of the promotion of a replica cluster
+type ReplicaOptions struct {
+	// TargetTime is the time up to which the transactions must have been
+	// replayed by the designated primary
+	TargetTime string
+
+	// TargetLSN is the LSN up to which the WAL must have been received
+	// by the designated primary
+	TargetLSN string
+
+	// WaitTimeout is how long to wait for the designated primary to reach
+	// the target. When zero, the position is checked only once
+	WaitTimeout time.Duration
+
+	// DryRun only verifies the position, without promoting the cluster
+	DryRun bool
+}
+
+// promotionCheck is the position of the designated primary, verified
+// against the target of the promotion
+type promotionCheck struct {
+	Instance       string     `json:"instance"`
+	TargetTime     *time.Time `json:"targetTime,omitempty"`
+	TargetLSN      types.LSN  `json:"targetLSN,omitempty"`
+	ReceivedLSN    types.LSN  `json:"receivedLSN"`
+	LastReplayTime *time.Time `json:"lastReplayTime,omitempty"`
+	CheckedAt      time.Time  `json:"checkedAt"`
+}
+
+// isReached checks if the designated primary reached the target
+func (check *promotionCheck) isReached() bool {
+	if check.TargetLSN != "" && check.ReceivedLSN.Less(check.TargetLSN) {
+		return false
+	}
+
+	if check.TargetTime != nil &&
+		(check.LastReplayTime == nil || check.LastReplayTime.Before(*check.TargetTime)) {
+		return false
+	}
+
+	return true
+}
+
+// describe describes the position of the designated primary
+func (check *promotionCheck) describe() string {
+	lastReplayTime := "never"
+	if check.LastReplayTime != nil {
+		lastReplayTime = check.LastReplayTime.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("received LSN %s, last replayed transaction at %s", check.ReceivedLSN, lastReplayTime)
+}
+
+// update gets the current position of the designated primary
+func (check *promotionCheck) update(ctx context.Context, clientInterface kubernetes.Interface, pod corev1.Pod) error {
+	timeout := 10 * time.Second
+	stdout, stderr, err := utils.ExecCommand(ctx, clientInterface, plugin.Config, pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"psql", "-XAt", "-F", "\t", "-c", replicaStatusQuery)
+	if err != nil {
+		return fmt.Errorf("while getting the position of %s: %w: %s", pod.Name, err, strings.TrimSpace(stderr))
+	}
+
+	inRecovery, receivedLSN, lastReplayTime, err := parseReplicaStatus(stdout)
+	if err != nil {
+		return err
+	}
+	if !inRecovery {
+		return fmt.Errorf("the designated primary %s is not in recovery", pod.Name)
+	}
+
+	check.ReceivedLSN = receivedLSN
+	check.LastReplayTime = lastReplayTime
+	check.CheckedAt = time.Now().UTC()
+	return nil
+}
+
+// parseReplicaStatus parses the output of replicaStatusQuery
+func parseReplicaStatus(output string) (bool, types.LSN, *time.Time, error) {
+	fields := strings.Split(strings.TrimRight(output, "\n"), "\t")
+	if len(fields) != 3 {
+		return false, "", nil, fmt.Errorf("unexpected output of the status query: %q", output)
+	}
+
+	inRecovery := fields[0] == "t"
+	receivedLSN := types.LSN(fields[1])
+	if fields[2] == "" {
+		return inRecovery, receivedLSN, nil, nil
+	}
+
+	epoch, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return false, "", nil, fmt.Errorf("invalid last replay time %q: %w", fields[2], err)
+	}
+	lastReplayTime := time.UnixMicro(int64(epoch * 1e6)).UTC()
+	return inRecovery, receivedLSN, &lastReplayTime, nil
+}
+
+// newPromotionCheck validates the target of the promotion
+func newPromotionCheck(instance string, options ReplicaOptions) (*promotionCheck, error) {
+	check := &promotionCheck{Instance: instance}
+
+	if options.TargetTime != "" {
+		targetTime, err := types.ParseTargetTime(nil, options.TargetTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target time %q: %w", options.TargetTime, err)
+		}
+		targetTime = targetTime.UTC()
+		check.TargetTime = &targetTime
+	}
+
+	if options.TargetLSN != "" {
+		check.TargetLSN = types.LSN(options.TargetLSN)
+		if _, err := check.TargetLSN.Parse(); err != nil {
+			return nil, fmt.Errorf("invalid target LSN: %w", err)
+		}
+	}
+
+	return check, nil
+}
+
+// PromoteReplicaCluster promotes a replica cluster, once its designated
+// primary received the WAL up to the given target
+func PromoteReplicaCluster(ctx context.Context, clusterName string, options ReplicaOptions) error {
+	var cluster apiv1.Cluster
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("cluster %s not found in namespace %s: %w", clusterName, plugin.Namespace, err)
+	}
+
+	if !cluster.IsReplica() {
+		return fmt.Errorf("cluster %s is not a replica cluster", clusterName)
+	}
+	if cluster.Status.CurrentPrimary == "" {
+		return fmt.Errorf("cluster %s has no designated primary", clusterName)
+	}
+
+	check, err := newPromotionCheck(cluster.Status.CurrentPrimary, options)
+	if err != nil {
+		return err
+	}
+
+	var pod corev1.Pod
+	err = plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: check.Instance}, &pod)
+	if err != nil {
+		return fmt.Errorf("designated primary %s not found in namespace %s: %w", check.Instance, plugin.Namespace, err)
+	}
+
+	if err := waitForPromotionTarget(ctx, pod, check, options.WaitTimeout); err != nil {
+		return err
+	}
+	fmt.Printf("Designated primary %s reached the promotion target: %s\n", check.Instance, check.describe())
+
+	if options.DryRun {
+		fmt.Printf("Replica cluster %s not promoted (dry run)\n", clusterName)
+		return nil
+	}
+
+	return promoteReplicaCluster(ctx, &cluster, check)
+}
+
+// waitForPromotionTarget waits for the designated primary to reach the
+// target of the promotion, failing when it doesn't reach it in time
+func waitForPromotionTarget(
+	ctx context.Context,
+	pod corev1.Pod,
+	check *promotionCheck,
+	timeout time.Duration,
+) error {
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+	if err := check.update(ctx, clientInterface, pod); err != nil {
+		return err
+	}
+
+	if !check.isReached() && timeout > 0 {
+		fmt.Printf("Waiting up to %v for %s to catch up: %s\n", timeout, check.Instance, check.describe())
+		err := wait.PollUntilContextTimeout(ctx, catchUpPollInterval, timeout, false,
+			func(ctx context.Context) (bool, error) {
+				if err := check.update(ctx, clientInterface, pod); err != nil {
+					return false, err
+				}
+				return check.isReached(), nil
+			})
+		if err != nil && !wait.Interrupted(err) {
+			return err
+		}
+	}
+
+	if !check.isReached() {
+		return fmt.Errorf("the designated primary %s did not reach the promotion target: %s",
+			check.Instance, check.describe())
+	}
+
+	return nil
+}
+
+// promoteReplicaCluster makes the replica cluster a primary one, recording
+// the verified position of the designated primary in an annotation
+func promoteReplicaCluster(ctx context.Context, cluster *apiv1.Cluster, check *promotionCheck) error {
+	checkJSON, err := json.Marshal(check)
+	if err != nil {
+		return err
+	}
+
+	origCluster := cluster.DeepCopy()
+	if cluster.Annotations == nil {
+		cluster.Annotations = make(map[string]string)
+	}
+	cluster.Annotations[utils.PromotionCheckAnnotationName] = string(checkJSON)
+
+	// Standalone replica clusters are detached from their source, while
+	// the ones in a distributed topology become the primary cluster
+	if cluster.Spec.ReplicaCluster.Enabled != nil {
+		cluster.Spec.ReplicaCluster.Enabled = ptr.To(false)
+	} else {
+		cluster.Spec.ReplicaCluster.Primary = cluster.GetSelfName()
+	}
+
+	if err := plugin.Client.Patch(ctx, cluster, client.MergeFrom(origCluster)); err != nil {
+		return fmt.Errorf("while promoting the replica cluster %s: %w", cluster.Name, err)
+	}
+
+	fmt.Printf("Replica cluster %s will be promoted\n", cluster.Name)
+	return nil
+}
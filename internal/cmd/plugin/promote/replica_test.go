@@ -0,0 +1,85 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promote
+
+import (
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parseReplicaStatus", func() {
+	It("parses the position of the designated primary", func() {
+		inRecovery, receivedLSN, lastReplayTime, err := parseReplicaStatus("t\t0/3000060\t1709287200.5\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inRecovery).To(BeTrue())
+		Expect(receivedLSN).To(Equal(types.LSN("0/3000060")))
+		Expect(lastReplayTime).ToNot(BeNil())
+		Expect(*lastReplayTime).To(Equal(time.Date(2024, 3, 1, 10, 0, 0, 500000000, time.UTC)))
+	})
+
+	It("accepts a designated primary that never replayed a transaction", func() {
+		_, _, lastReplayTime, err := parseReplicaStatus("t\t0/3000060\t")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lastReplayTime).To(BeNil())
+	})
+
+	It("fails on an unexpected output", func() {
+		_, _, _, err := parseReplicaStatus("t\t0/3000060")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("promotionCheck", func() {
+	lastReplayTime := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	It("rejects an invalid target", func() {
+		_, err := newPromotionCheck("cluster-dr-1", ReplicaOptions{TargetLSN: "3000060"})
+		Expect(err).To(HaveOccurred())
+
+		_, err = newPromotionCheck("cluster-dr-1", ReplicaOptions{TargetTime: "yesterday"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("checks the received LSN", func() {
+		check, err := newPromotionCheck("cluster-dr-1", ReplicaOptions{TargetLSN: "0/3000060"})
+		Expect(err).ToNot(HaveOccurred())
+
+		check.ReceivedLSN = "0/2FFFFFF"
+		Expect(check.isReached()).To(BeFalse())
+
+		check.ReceivedLSN = "0/3000060"
+		Expect(check.isReached()).To(BeTrue())
+	})
+
+	It("checks the time of the last replayed transaction", func() {
+		check, err := newPromotionCheck("cluster-dr-1", ReplicaOptions{TargetTime: "2024-03-01 10:00:00+00"})
+		Expect(err).ToNot(HaveOccurred())
+		check.ReceivedLSN = "0/3000060"
+		Expect(check.isReached()).To(BeFalse())
+
+		beforeTarget := lastReplayTime.Add(-time.Second)
+		check.LastReplayTime = &beforeTarget
+		Expect(check.isReached()).To(BeFalse())
+
+		check.LastReplayTime = &lastReplayTime
+		Expect(check.isReached()).To(BeTrue())
+	})
+})
@@ -29,6 +29,11 @@ func NewCmd() *cobra.Command {
 	var replica bool
 	var allocateTTY bool
 	var passStdin bool
+	var role string
+	var service string
+	var portForward bool
+	var localPort int
+	var database string
 
 	cmd := &cobra.Command{
 		Use:   "psql CLUSTER [-- PSQL_ARGS...]",
@@ -42,6 +47,10 @@ func NewCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clusterName := args[0]
 			psqlArgs := args[1:]
+			if err := validateConnectionOptions(replica, service, portForward, psqlArgs); err != nil {
+				return err
+			}
+
 			psqlOptions := CommandOptions{
 				Replica:     replica,
 				Namespace:   plugin.Namespace,
@@ -50,6 +59,11 @@ func NewCmd() *cobra.Command {
 				PassStdin:   passStdin,
 				Args:        psqlArgs,
 				Name:        clusterName,
+				Role:        role,
+				Service:     service,
+				PortForward: portForward,
+				LocalPort:   localPort,
+				Database:    database,
 			}
 
 			psqlCommand, err := NewCommand(cmd.Context(), psqlOptions)
@@ -84,6 +98,42 @@ func NewCmd() *cobra.Command {
 		"Whether to pass stdin to the container",
 	)
 
+	cmd.Flags().StringVar(
+		&role,
+		"role",
+		"",
+		"The managed role to connect as, using the password stored in its secret "+
+			"(by default connects as the superuser, or as the application owner in port-forward mode)",
+	)
+
+	cmd.Flags().StringVar(
+		&service,
+		"service",
+		"",
+		"The service whose instances are targeted, one of rw|ro|r (defaults to rw)",
+	)
+
+	cmd.Flags().BoolVar(
+		&portForward,
+		"port-forward",
+		false,
+		"Forward a local port to the service and print the connection string, instead of starting psql",
+	)
+
+	cmd.Flags().IntVar(
+		&localPort,
+		"local-port",
+		0,
+		"The local port to be forwarded in port-forward mode (by default a free port is chosen)",
+	)
+
+	cmd.Flags().StringVar(
+		&database,
+		"dbname",
+		"",
+		"The database of the connection string in port-forward mode (defaults to the application database)",
+	)
+
 	return cmd
 }
 
@@ -98,3 +148,21 @@ func validatePsqlArgs(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func validateConnectionOptions(replica bool, service string, portForward bool, psqlArgs []string) error {
+	switch service {
+	case "", ServiceReadWrite, ServiceReadOnly, ServiceRead:
+	default:
+		return fmt.Errorf("unknown service %q, must be one of rw|ro|r", service)
+	}
+
+	if replica && service != "" {
+		return fmt.Errorf("--replica and --service cannot be used together")
+	}
+
+	if portForward && len(psqlArgs) > 0 {
+		return fmt.Errorf("psqlArgs cannot be used in port-forward mode")
+	}
+
+	return nil
+}
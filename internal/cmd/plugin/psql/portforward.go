@@ -0,0 +1,189 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psql
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"syscall"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// ServiceReadWrite targets the primary instance
+	ServiceReadWrite = "rw"
+
+	// ServiceReadOnly targets the replicas
+	ServiceReadOnly = "ro"
+
+	// ServiceRead targets any instance
+	ServiceRead = "r"
+)
+
+// superuserName is the name of the PostgreSQL superuser
+const superuserName = "postgres"
+
+// roleCredentials are the username and the password of a role
+type roleCredentials struct {
+	username string
+	password string
+}
+
+// getRoleCredentials gets the credentials of a role from its secret. The
+// application owner is used when no role is passed
+func getRoleCredentials(ctx context.Context, cluster *apiv1.Cluster, role string) (*roleCredentials, error) {
+	secretName, err := getRoleSecretName(cluster, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret corev1.Secret
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: secretName},
+		&secret,
+	); err != nil {
+		return nil, fmt.Errorf("while getting the secret of the role: %w", err)
+	}
+
+	credentials := &roleCredentials{
+		username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+		password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+	}
+	if credentials.username == "" {
+		credentials.username = role
+	}
+	if credentials.password == "" {
+		return nil, fmt.Errorf("no password found in secret %s", secretName)
+	}
+	return credentials, nil
+}
+
+// getRoleSecretName gets the name of the secret containing the password
+// of a role
+func getRoleSecretName(cluster *apiv1.Cluster, role string) (string, error) {
+	switch role {
+	case "", cluster.GetApplicationDatabaseOwner():
+		if cluster.GetApplicationDatabaseOwner() == "" {
+			return "", fmt.Errorf("cluster %s has no application database owner", cluster.Name)
+		}
+		return cluster.GetApplicationSecretName(), nil
+
+	case superuserName:
+		if !cluster.GetEnableSuperuserAccess() {
+			return "", fmt.Errorf("the superuser access is disabled in cluster %s", cluster.Name)
+		}
+		return cluster.GetSuperuserSecretName(), nil
+	}
+
+	if cluster.Spec.Managed != nil {
+		for idx := range cluster.Spec.Managed.Roles {
+			roleConfiguration := &cluster.Spec.Managed.Roles[idx]
+			if roleConfiguration.Name != role {
+				continue
+			}
+			if secretName := roleConfiguration.GetRoleSecretsName(); secretName != "" {
+				return secretName, nil
+			}
+			return "", fmt.Errorf("role %s has no password secret", role)
+		}
+	}
+
+	return "", fmt.Errorf("role %s is not managed by cluster %s", role, cluster.Name)
+}
+
+// getServiceName gets the name of the service to be forwarded
+func (psql *Command) getServiceName() string {
+	switch {
+	case psql.Service == ServiceRead:
+		return psql.Name + apiv1.ServiceReadSuffix
+	case psql.Service == ServiceReadOnly || psql.Replica:
+		return psql.Name + apiv1.ServiceReadOnlySuffix
+	default:
+		return psql.Name + apiv1.ServiceReadWriteSuffix
+	}
+}
+
+// getPortForwardInvocation gets the kubectl command forwarding the local
+// port to the PostgreSQL port of the service
+func (psql *Command) getPortForwardInvocation(localPort int) []string {
+	result := []string{"kubectl", "port-forward"}
+	if psql.Context != "" {
+		result = append(result, "--context", psql.Context)
+	}
+	if len(psql.Namespace) > 0 {
+		result = append(result, "-n", psql.Namespace)
+	}
+
+	return append(result,
+		"svc/"+psql.getServiceName(),
+		fmt.Sprintf("%d:%d", localPort, postgres.ServerPort))
+}
+
+// getConnectionString gets the URI to connect to the forwarded port
+func (psql *Command) getConnectionString(localPort int) string {
+	connectionURL := url.URL{
+		Scheme:   "postgresql",
+		User:     url.UserPassword(psql.credentials.username, psql.credentials.password),
+		Host:     net.JoinHostPort("localhost", strconv.Itoa(localPort)),
+		Path:     "/" + psql.Database,
+		RawQuery: "sslmode=require",
+	}
+	return connectionURL.String()
+}
+
+// getLocalPort gets the local port to be forwarded, choosing a free one
+// when not specified
+func (psql *Command) getLocalPort() (int, error) {
+	if psql.LocalPort != 0 {
+		return psql.LocalPort, nil
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("while looking for a free local port: %w", err)
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// execPortForward prints the connection string and replaces the current
+// process with a `kubectl port-forward` invocation
+func (psql *Command) execPortForward() error {
+	localPort, err := psql.getLocalPort()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Connect to %s with:\n\n  %s\n\nPress Ctrl+C to stop forwarding\n\n",
+		psql.getServiceName(), psql.getConnectionString(localPort))
+
+	return syscall.Exec(psql.kubectlPath, psql.getPortForwardInvocation(localPort), os.Environ()) // #nosec
+}
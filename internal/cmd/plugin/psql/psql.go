@@ -27,6 +27,7 @@ import (
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -46,6 +47,10 @@ type Command struct {
 
 	// The path of kubectl
 	kubectlPath string
+
+	// The credentials of the role, when connecting as a role
+	// different from the superuser
+	credentials *roleCredentials
 }
 
 // CommandOptions are the options required to start psql
@@ -70,6 +75,25 @@ type CommandOptions struct {
 
 	// Arguments to pass to psql
 	Args []string
+
+	// The managed role to connect as. When empty, psql connects as
+	// the superuser via the local socket
+	Role string
+
+	// The service whose instances are targeted: rw, ro or r. When
+	// empty, the Replica option is used
+	Service string
+
+	// Whether to forward a local port to the service, instead of
+	// starting psql
+	PortForward bool
+
+	// The local port to be forwarded. A free port is chosen when zero
+	LocalPort int
+
+	// The database to connect to in port-forward mode. When empty,
+	// the application database is used
+	Database string
 }
 
 // NewCommand creates a new psql command
@@ -97,11 +121,33 @@ func NewCommand(
 		return nil, fmt.Errorf("while getting kubectl path: %w", err)
 	}
 
-	return &Command{
+	command := &Command{
 		CommandOptions: options,
 		podList:        pods.Items,
 		kubectlPath:    kubectlPath,
-	}, nil
+	}
+
+	// The superuser connects via the local socket, while the other
+	// roles need their password
+	if (options.Role != "" && options.Role != superuserName) || options.PortForward {
+		var cluster apiv1.Cluster
+		if err := plugin.Client.Get(
+			ctx,
+			client.ObjectKey{Namespace: plugin.Namespace, Name: options.Name},
+			&cluster,
+		); err != nil {
+			return nil, err
+		}
+
+		if command.credentials, err = getRoleCredentials(ctx, &cluster, options.Role); err != nil {
+			return nil, err
+		}
+		if command.Database == "" {
+			command.Database = cluster.GetApplicationDatabaseName()
+		}
+	}
+
+	return command, nil
 }
 
 // getKubectlInvocation gets the kubectl command to be executed
@@ -129,13 +175,23 @@ func (psql *Command) getKubectlInvocation() ([]string, error) {
 		return nil, err
 	}
 
+	result = append(result, podName, "--")
+
+	// Roles other than the superuser authenticate with their
+	// password over a TCP connection
+	if psql.credentials != nil {
+		result = append(result, "env", "PGPASSWORD="+psql.credentials.password, "psql",
+			"-h", "localhost", "-U", psql.credentials.username)
+		result = append(result, psql.Args...)
+		return result, nil
+	}
+
 	// Default to `postgres` if no-user has been specified
 	if !slices.Contains(psql.Args, "-U") {
-		psql.Args = append([]string{"-U", "postgres"}, psql.Args...)
+		psql.Args = append([]string{"-U", superuserName}, psql.Args...)
 	}
 
-	result = append(result, podName)
-	result = append(result, "--", "psql")
+	result = append(result, "psql")
 	result = append(result, psql.Args...)
 	return result, nil
 }
@@ -143,7 +199,10 @@ func (psql *Command) getKubectlInvocation() ([]string, error) {
 // getPodName get the first Pod name with the required role
 func (psql *Command) getPodName() (string, error) {
 	targetPodRole := specs.ClusterRoleLabelPrimary
-	if psql.Replica {
+	switch {
+	case psql.Service == ServiceRead && len(psql.podList) > 0:
+		return psql.podList[0].Name, nil
+	case psql.Replica || psql.Service == ServiceReadOnly:
 		targetPodRole = specs.ClusterRoleLabelReplica
 	}
 
@@ -157,9 +216,14 @@ func (psql *Command) getPodName() (string, error) {
 	return "", &ErrMissingPod{role: targetPodRole}
 }
 
-// Exec replaces the current process with a `kubectl Exec` invocation.
+// Exec replaces the current process with a `kubectl Exec` invocation,
+// or with a `kubectl port-forward` one in port-forward mode.
 // This function won't return
 func (psql *Command) Exec() error {
+	if psql.PortForward {
+		return psql.execPortForward()
+	}
+
 	kubectlExec, err := psql.getKubectlInvocation()
 	if err != nil {
 		return err
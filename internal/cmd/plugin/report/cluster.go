@@ -26,14 +26,14 @@ import (
 
 func clusterCmd() *cobra.Command {
 	var (
-		file, output              string
-		includeLogs, logTimeStamp bool
+		file, output                          string
+		includeLogs, logTimeStamp, includeSQL bool
 	)
 
 	const filePlaceholder = "report_cluster_<name>_<timestamp>.zip"
 	cmd := &cobra.Command{
 		Use:   "cluster CLUSTER",
-		Short: "Report cluster resources, pods, events, logs (opt-in)",
+		Short: "Report cluster resources, pods, events, logs and SQL diagnostics (opt-in)",
 		Long:  "Collects combined information on the cluster in a Zip file",
 		Args:  plugin.RequiresArguments(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -46,7 +46,7 @@ func clusterCmd() *cobra.Command {
 				file = reportName("cluster", now, clusterName) + ".zip"
 			}
 			return cluster(cmd.Context(), clusterName, plugin.Namespace,
-				plugin.OutputFormat(output), file, includeLogs, logTimeStamp, now)
+				plugin.OutputFormat(output), file, includeLogs, logTimeStamp, includeSQL, now)
 		},
 	}
 
@@ -57,6 +57,8 @@ func clusterCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&includeLogs, "logs", "l", false, "include logs")
 	cmd.Flags().BoolVarP(&logTimeStamp, "timestamps", "t", false,
 		"Prepend human-readable timestamp to each log line")
+	cmd.Flags().BoolVarP(&includeSQL, "sql", "s", false,
+		"include SQL diagnostics, such as statistics, settings, replication and locks")
 
 	return cmd
 }
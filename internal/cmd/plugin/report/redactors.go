@@ -17,6 +17,8 @@ limitations under the License.
 package report
 
 import (
+	"regexp"
+
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -66,3 +68,33 @@ func redactWebhookClientConfig(config admissionv1.WebhookClientConfig) admission
 	}
 	return config
 }
+
+// sqlSecretsRedactors match the passwords that may be included in the
+// output of the diagnostic queries, such as the ones in connection strings
+// and in the text of the queries changing a role
+var sqlSecretsRedactors = []struct {
+	regexp      *regexp.Regexp
+	replacement string
+}{
+	{
+		regexp:      regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|[^\s,"']+)`),
+		replacement: "${1}********",
+	},
+	{
+		regexp:      regexp.MustCompile(`(?i)(password\s+)('(?:[^']|'')*')`),
+		replacement: "${1}'********'",
+	},
+	{
+		regexp:      regexp.MustCompile(`(?i)(postgres(?:ql)?://[^:/@\s]+:)[^@\s/]+@`),
+		replacement: "${1}********@",
+	},
+}
+
+// redactSQLDiagnostic obfuscates the passwords in the output of a
+// diagnostic query
+func redactSQLDiagnostic(content string) string {
+	for _, redactor := range sqlSecretsRedactors {
+		content = redactor.regexp.ReplaceAllString(content, redactor.replacement)
+	}
+	return content
+}
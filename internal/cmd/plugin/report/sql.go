@@ -0,0 +1,251 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// sqlDiagnosticTimeout is the maximum duration of a diagnostic query
+const sqlDiagnosticTimeout = 30 * time.Second
+
+// sqlDiagnostic is a query whose result is added to the report
+type sqlDiagnostic struct {
+	// name is the name of the file containing the result, without extension
+	name string
+
+	// query is the query to be executed
+	query string
+
+	// primaryOnly is true when the query is meaningful only on the primary
+	primaryOnly bool
+}
+
+// databasesQuery lists the databases accepting connections
+const databasesQuery = "SELECT datname FROM pg_catalog.pg_database WHERE datallowconn AND NOT datistemplate ORDER BY 1"
+
+// lockGraphQuery lists the sessions waiting for a lock, together with
+// the ones blocking them
+const lockGraphQuery = `
+SELECT waiting.pid, waiting.usename, waiting.datname, waiting.wait_event_type, waiting.wait_event,
+	now() - waiting.query_start AS waiting_for, waiting.query,
+	blocking.pid AS blocking_pid, blocking.usename AS blocking_usename, blocking.state AS blocking_state,
+	now() - blocking.xact_start AS blocking_xact_duration, blocking.query AS blocking_query
+FROM pg_catalog.pg_stat_activity AS waiting
+	CROSS JOIN LATERAL unnest(pg_catalog.pg_blocking_pids(waiting.pid)) AS blocked_by(pid)
+	JOIN pg_catalog.pg_stat_activity AS blocking ON blocking.pid = blocked_by.pid
+ORDER BY waiting.pid`
+
+// tableBloatQuery estimates the bloat of the tables of a database from
+// the statistics gathered by ANALYZE
+const tableBloatQuery = `
+SELECT schemaname, tblname, bs * tblpages AS real_size,
+	CASE WHEN tblpages > est_tblpages_ff THEN (tblpages - est_tblpages_ff) * bs ELSE 0 END AS bloat_size,
+	CASE WHEN tblpages > 0 AND tblpages > est_tblpages_ff
+		THEN round((100 * (tblpages - est_tblpages_ff) / tblpages)::numeric, 1) ELSE 0 END AS bloat_pct,
+	fillfactor
+FROM (
+	SELECT ceil(reltuples / ((bs - page_hdr) * fillfactor / (tpl_size * 100))) AS est_tblpages_ff,
+		tblpages, fillfactor, bs, schemaname, tblname
+	FROM (
+		SELECT (4 + tpl_hdr_size + tpl_data_size + (2 * ma)
+				- CASE WHEN tpl_hdr_size % ma = 0 THEN ma ELSE tpl_hdr_size % ma END
+				- CASE WHEN ceil(tpl_data_size)::int % ma = 0 THEN ma ELSE ceil(tpl_data_size)::int % ma END
+			) AS tpl_size,
+			heappages AS tblpages, reltuples, bs, page_hdr, schemaname, tblname, fillfactor
+		FROM (
+			SELECT ns.nspname AS schemaname, tbl.relname AS tblname, tbl.reltuples, tbl.relpages AS heappages,
+				coalesce(substring(array_to_string(tbl.reloptions, ' ') FROM 'fillfactor=([0-9]+)')::smallint,
+					100) AS fillfactor,
+				current_setting('block_size')::numeric AS bs,
+				8 AS ma,
+				24 AS page_hdr,
+				23 + CASE WHEN max(coalesce(s.null_frac, 0)) > 0 THEN (7 + count(s.attname)) / 8 ELSE 0 END
+					AS tpl_hdr_size,
+				sum((1 - coalesce(s.null_frac, 0)) * coalesce(s.avg_width, 0)) AS tpl_data_size
+			FROM pg_catalog.pg_attribute AS att
+				JOIN pg_catalog.pg_class AS tbl ON att.attrelid = tbl.oid
+				JOIN pg_catalog.pg_namespace AS ns ON ns.oid = tbl.relnamespace
+				LEFT JOIN pg_catalog.pg_stats AS s ON s.schemaname = ns.nspname AND s.tablename = tbl.relname
+					AND NOT s.inherited AND s.attname = att.attname
+			WHERE att.attnum > 0 AND NOT att.attisdropped AND tbl.relkind IN ('r', 'm')
+				AND ns.nspname NOT IN ('pg_catalog', 'information_schema') AND tbl.reltuples >= 0
+			GROUP BY 1, 2, 3, 4, 5, 6, 7, 8
+		) AS table_stats
+	) AS table_sizes
+) AS table_estimates
+ORDER BY bloat_size DESC`
+
+// instanceDiagnostics are the queries executed once per instance
+var instanceDiagnostics = []sqlDiagnostic{
+	{
+		name: "settings",
+		query: "SELECT name, setting, unit, source, sourcefile, sourceline, pending_restart " +
+			"FROM pg_catalog.pg_settings ORDER BY name",
+	},
+	{name: "pg_stat_activity", query: "SELECT * FROM pg_catalog.pg_stat_activity"},
+	{name: "pg_stat_database", query: "SELECT * FROM pg_catalog.pg_stat_database"},
+	{name: "pg_stat_bgwriter", query: "SELECT * FROM pg_catalog.pg_stat_bgwriter"},
+	{name: "pg_stat_archiver", query: "SELECT * FROM pg_catalog.pg_stat_archiver"},
+	{name: "pg_stat_wal_receiver", query: "SELECT * FROM pg_catalog.pg_stat_wal_receiver"},
+	{name: "pg_replication_slots", query: "SELECT * FROM pg_catalog.pg_replication_slots"},
+	{name: "pg_locks", query: "SELECT * FROM pg_catalog.pg_locks"},
+	{name: "lock_graph", query: lockGraphQuery},
+	{name: "pg_stat_replication", query: "SELECT * FROM pg_catalog.pg_stat_replication", primaryOnly: true},
+}
+
+// databaseDiagnostics are the queries executed in every database of
+// the primary instance
+var databaseDiagnostics = []sqlDiagnostic{
+	{name: "pg_stat_user_tables", query: "SELECT * FROM pg_catalog.pg_stat_user_tables"},
+	{name: "pg_stat_user_indexes", query: "SELECT * FROM pg_catalog.pg_stat_user_indexes"},
+	{name: "table_bloat", query: tableBloatQuery},
+}
+
+// streamSQLDiagnosticsToZip adds to the ZIP the result of the diagnostic
+// queries, in CSV format, executed in every instance of the cluster. The
+// failure of a query is recorded in place of its result, without stopping
+// the report
+func streamSQLDiagnosticsToZip(
+	ctx context.Context,
+	clusterName string,
+	dirname string,
+	zipper *zip.Writer,
+) error {
+	sqlDir := filepath.Join(dirname, "sql")
+	if _, err := zipper.Create(sqlDir + "/"); err != nil {
+		return fmt.Errorf("could not add '%s' to zip: %w", sqlDir, err)
+	}
+
+	pods, _, err := resources.GetInstancePods(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("could not get cluster pods: %w", err)
+	}
+
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+	for idx := range pods {
+		pod := pods[idx]
+		podDir := filepath.Join(sqlDir, pod.Name)
+		isPrimary := specs.IsPodPrimary(pod)
+
+		for _, diagnostic := range instanceDiagnostics {
+			if diagnostic.primaryOnly && !isPrimary {
+				continue
+			}
+			output, err := runSQLDiagnostic(ctx, clientInterface, pod, "postgres", diagnostic.query)
+			if err := addSQLDiagnosticToZip(zipper, podDir, diagnostic.name, output, err); err != nil {
+				return err
+			}
+		}
+
+		if !isPrimary {
+			continue
+		}
+
+		databases, err := runSQLDiagnostic(ctx, clientInterface, pod, "postgres", databasesQuery)
+		if err != nil {
+			if err := addSQLDiagnosticToZip(zipper, podDir, "databases", "", err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, database := range parseDatabases(databases) {
+			databaseDir := filepath.Join(podDir, "databases", database)
+			for _, diagnostic := range databaseDiagnostics {
+				output, err := runSQLDiagnostic(ctx, clientInterface, pod, database, diagnostic.query)
+				if err := addSQLDiagnosticToZip(zipper, databaseDir, diagnostic.name, output, err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseDatabases gets the database names from the CSV output of
+// the databases query, skipping its header
+func parseDatabases(output string) []string {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		result = append(result, record[0])
+	}
+	return result
+}
+
+// runSQLDiagnostic executes a query in a database of the instance, getting
+// its result in CSV format
+func runSQLDiagnostic(
+	ctx context.Context,
+	clientInterface kubernetes.Interface,
+	pod corev1.Pod,
+	database string,
+	query string,
+) (string, error) {
+	timeout := sqlDiagnosticTimeout
+	stdout, stderr, err := utils.ExecCommand(ctx, clientInterface, plugin.Config, pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"psql", "-X", "-q", "-d", database,
+		"-c", fmt.Sprintf("COPY (%s) TO STDOUT WITH (FORMAT csv, HEADER)", query))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, stderr)
+	}
+
+	return stdout, nil
+}
+
+// addSQLDiagnosticToZip adds the redacted result of a diagnostic query to
+// the ZIP, or the error that prevented getting it
+func addSQLDiagnosticToZip(zipper *zip.Writer, folder, name, output string, queryErr error) error {
+	fileName := filepath.Join(folder, name) + ".csv"
+	content := output
+	if queryErr != nil {
+		fileName = filepath.Join(folder, name) + ".error"
+		content = queryErr.Error()
+	}
+
+	writer, err := zipper.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("could not add '%s' to zip: %w", fileName, err)
+	}
+
+	if _, err := fmt.Fprint(writer, redactSQLDiagnostic(content)); err != nil {
+		return fmt.Errorf("could not write '%s': %w", fileName, err)
+	}
+	return nil
+}
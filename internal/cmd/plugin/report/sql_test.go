@@ -0,0 +1,30 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SQL diagnostics", func() {
+	It("parses the databases of the instance", func() {
+		Expect(parseDatabases("datname\napp\npostgres\n\"my,db\"\n")).To(Equal([]string{"app", "postgres", "my,db"}))
+		Expect(parseDatabases("datname\n")).To(BeEmpty())
+		Expect(parseDatabases("")).To(BeEmpty())
+	})
+})
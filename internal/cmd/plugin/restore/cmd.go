@@ -0,0 +1,72 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "restore" command
+func NewCmd() *cobra.Command {
+	var options Options
+
+	cmd := &cobra.Command{
+		Use:   "restore CLUSTER",
+		Short: "Create a new cluster from the backups of an existing one",
+		Long: `Lists the completed backups of a cluster and creates a new cluster recovering ` +
+			`one of them, optionally up to a target time. The WAL archive is checked to ` +
+			`cover the requested target before creating anything.`,
+		GroupID: plugin.GroupIDDatabase,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = args[0]
+			return Restore(cmd.Context(), options)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&options.NewClusterName,
+		"name",
+		"",
+		"The name of the cluster to be created, defaults to the name of the cluster with the \"-restore\" suffix",
+	)
+	cmd.Flags().StringVar(
+		&options.BackupName,
+		"backup",
+		"",
+		"The backup to be recovered, defaults to the most recent one completed before the target time",
+	)
+	cmd.Flags().StringVar(
+		&options.TargetTime,
+		"target-time",
+		"",
+		"The time to recover the cluster to, defaults to the most recent archived WAL file",
+	)
+	cmd.Flags().BoolVar(
+		&options.DryRun,
+		"dry-run",
+		false,
+		"When true prints the manifest of the new cluster instead of creating it",
+	)
+
+	return cmd
+}
@@ -0,0 +1,275 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+)
+
+// walArchiveStatus is the status of the WAL archiver of the primary
+// instance of a cluster
+type walArchiveStatus struct {
+	// lastArchivedWAL is the name of the last archived WAL file
+	lastArchivedWAL string
+
+	// lastArchivedTime is when the last WAL file was archived. It is
+	// zero when no WAL file was archived
+	lastArchivedTime time.Time
+}
+
+// newWALArchiveStatus gets the status of the WAL archiver from the
+// status of the primary instance
+func newWALArchiveStatus(status postgres.PostgresqlStatus) *walArchiveStatus {
+	result := &walArchiveStatus{lastArchivedWAL: status.LastArchivedWAL}
+	// pg_stat_archiver reports "-infinity" when no WAL file was archived
+	if lastArchivedTime, err := types.ParseTargetTime(nil, status.LastArchivedWALTime); err == nil {
+		result.lastArchivedTime = lastArchivedTime
+	}
+	return result
+}
+
+// parseTargetTime parses a recovery target time, accepting the same
+// formats of the "targetTime" field of the recovery target
+func parseTargetTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	targetTime, err := types.ParseTargetTime(nil, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target time %q: %w", value, err)
+	}
+	return &targetTime, nil
+}
+
+// getCompletedBackups gets the completed backups of a cluster from a list,
+// ordered by the time they ended
+func getCompletedBackups(backupList *apiv1.BackupList, clusterName string) []apiv1.Backup {
+	var result []apiv1.Backup
+	for _, backup := range backupList.Items {
+		if backup.Spec.Cluster.Name == clusterName &&
+			backup.Status.Phase == apiv1.BackupPhaseCompleted &&
+			backup.Status.StoppedAt != nil {
+			result = append(result, backup)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Status.StoppedAt.Before(result[j].Status.StoppedAt)
+	})
+	return result
+}
+
+// selectBackup chooses the backup to be recovered among the completed ones:
+// the requested one, or the most recent one ended before the target time
+func selectBackup(backups []apiv1.Backup, backupName string, targetTime *time.Time) (*apiv1.Backup, error) {
+	if backupName != "" {
+		idx := slices.IndexFunc(backups, func(backup apiv1.Backup) bool {
+			return backup.Name == backupName
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("backup %s is not among the completed backups of the cluster", backupName)
+		}
+		return &backups[idx], nil
+	}
+
+	for idx := len(backups) - 1; idx >= 0; idx-- {
+		if targetTime == nil || !backups[idx].Status.StoppedAt.Time.After(*targetTime) {
+			return &backups[idx], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no backup was completed before %s", targetTime.Format(time.RFC3339))
+}
+
+// checkRecoveryTarget verifies that the target time can be reached by
+// recovering the backup, which requires the backup to end before the
+// target and the WAL archive to cover it
+func checkRecoveryTarget(backup *apiv1.Backup, targetTime *time.Time, archive *walArchiveStatus) error {
+	if targetTime == nil {
+		return nil
+	}
+
+	if targetTime.After(time.Now()) {
+		return fmt.Errorf("the target time %s is in the future", targetTime.Format(time.RFC3339))
+	}
+
+	if backup.Status.StoppedAt.Time.After(*targetTime) {
+		return fmt.Errorf("backup %s ended at %s, after the target time %s",
+			backup.Name, backup.Status.StoppedAt.Format(time.RFC3339), targetTime.Format(time.RFC3339))
+	}
+
+	if archive == nil {
+		return errors.New("cannot check whether the WAL archive covers the target time: the primary is not reachable")
+	}
+
+	if archive.lastArchivedTime.Before(*targetTime) {
+		return fmt.Errorf("the WAL files are archived up to %s, before the target time %s",
+			formatArchivedTime(archive), targetTime.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// formatArchivedTime formats the time of the last archived WAL file
+func formatArchivedTime(archive *walArchiveStatus) string {
+	if archive == nil {
+		return "unknown"
+	}
+	if archive.lastArchivedTime.IsZero() {
+		return "never"
+	}
+	return archive.lastArchivedTime.Format(time.RFC3339)
+}
+
+// getRecoveryCluster creates the definition of a cluster recovering a
+// backup of the source cluster, up to the target time when set
+func getRecoveryCluster(
+	source *apiv1.Cluster,
+	name string,
+	backup *apiv1.Backup,
+	targetTime *time.Time,
+) (*apiv1.Cluster, error) {
+	result := &apiv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       apiv1.ClusterKind,
+			APIVersion: apiv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: source.Namespace,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	// The new cluster must not archive its WAL files and its backups
+	// together with the ones of the source cluster
+	result.Spec.Backup = nil
+	result.Spec.ReplicaCluster = nil
+	result.Spec.ExternalClusters = nil
+
+	recovery := &apiv1.BootstrapRecovery{
+		Database: source.GetApplicationDatabaseName(),
+		Owner:    source.GetApplicationDatabaseOwner(),
+	}
+	if targetTime != nil {
+		recovery.RecoveryTarget = &apiv1.RecoveryTarget{
+			TargetTime: targetTime.UTC().Format(time.RFC3339),
+		}
+	}
+	result.Spec.Bootstrap = &apiv1.BootstrapConfiguration{Recovery: recovery}
+
+	switch backup.Status.Method {
+	case apiv1.BackupMethodVolumeSnapshot:
+		storageSource := persistentvolumeclaim.GetCandidateSourceFromBackup(backup)
+		recovery.VolumeSnapshots = &apiv1.DataSource{
+			Storage:           storageSource.DataSource,
+			WalStorage:        storageSource.WALSource,
+			TablespaceStorage: storageSource.TablespaceSource,
+		}
+
+		// The WAL files after the snapshots are only needed to reach
+		// the target time
+		if targetTime != nil {
+			walArchive := getWALArchiveSource(source)
+			if walArchive == nil {
+				return nil, fmt.Errorf(
+					"cluster %s has no WAL archive to recover the volume snapshots up to the target time",
+					source.Name)
+			}
+			recovery.Source = walArchive.Name
+			result.Spec.ExternalClusters = []apiv1.ExternalCluster{*walArchive}
+		}
+
+	case apiv1.BackupMethodPgBackRest:
+		walArchive := getWALArchiveSource(source)
+		if walArchive == nil || walArchive.PgBackRest == nil {
+			return nil, fmt.Errorf("cluster %s has no pgBackRest configuration", source.Name)
+		}
+		recovery.Source = walArchive.Name
+		result.Spec.ExternalClusters = []apiv1.ExternalCluster{*walArchive}
+		if recovery.RecoveryTarget == nil {
+			recovery.RecoveryTarget = &apiv1.RecoveryTarget{}
+		}
+		recovery.RecoveryTarget.BackupID = backup.Status.BackupID
+
+	case apiv1.BackupMethodPlugin:
+		if backup.Spec.PluginConfiguration == nil {
+			return nil, fmt.Errorf("backup %s has no plugin configuration", backup.Name)
+		}
+		pluginName := backup.Spec.PluginConfiguration.Name
+
+		pluginConfiguration := &apiv1.PluginConfiguration{Name: pluginName}
+		if idx := slices.IndexFunc(source.Spec.Plugins, func(plugin apiv1.PluginConfiguration) bool {
+			return plugin.Name == pluginName
+		}); idx >= 0 {
+			pluginConfiguration.Parameters = source.Spec.Plugins[idx].Parameters
+		}
+
+		recovery.Source = source.Name
+		result.Spec.ExternalClusters = []apiv1.ExternalCluster{
+			{Name: source.Name, PluginConfiguration: pluginConfiguration},
+		}
+		result.Spec.Plugins = slices.DeleteFunc(result.Spec.Plugins, func(plugin apiv1.PluginConfiguration) bool {
+			return plugin.Name == pluginName
+		})
+
+	default:
+		recovery.Backup = &apiv1.BackupSource{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: backup.Name},
+		}
+	}
+
+	return result, nil
+}
+
+// getWALArchiveSource gets the external cluster giving access to the WAL
+// archive of the source cluster, or nil if the source cluster has none
+func getWALArchiveSource(source *apiv1.Cluster) *apiv1.ExternalCluster {
+	if source.Spec.Backup == nil {
+		return nil
+	}
+
+	switch {
+	case source.Spec.Backup.BarmanObjectStore != nil:
+		objectStore := source.Spec.Backup.BarmanObjectStore.DeepCopy()
+		if objectStore.ServerName == "" {
+			objectStore.ServerName = source.Name
+		}
+		return &apiv1.ExternalCluster{Name: source.Name, BarmanObjectStore: objectStore}
+
+	case source.Spec.Backup.PgBackRest != nil:
+		pgBackRest := source.Spec.Backup.PgBackRest.DeepCopy()
+		if pgBackRest.Stanza == "" {
+			pgBackRest.Stanza = source.Name
+		}
+		return &apiv1.ExternalCluster{Name: source.Name, PgBackRest: pgBackRest}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("restore", func() {
+	now := time.Now().Truncate(time.Second)
+
+	newBackup := func(name string, method apiv1.BackupMethod, stoppedAt time.Time) apiv1.Backup {
+		return apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       apiv1.BackupSpec{Cluster: apiv1.LocalObjectReference{Name: "cluster-example"}},
+			Status: apiv1.BackupStatus{
+				Phase:     apiv1.BackupPhaseCompleted,
+				Method:    method,
+				BackupID:  name + "-id",
+				StoppedAt: &metav1.Time{Time: stoppedAt},
+			},
+		}
+	}
+
+	source := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+		Spec: apiv1.ClusterSpec{
+			Instances: 3,
+			Bootstrap: &apiv1.BootstrapConfiguration{
+				InitDB: &apiv1.BootstrapInitDB{Database: "app", Owner: "app"},
+			},
+			Backup: &apiv1.BackupConfiguration{
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+					DestinationPath: "s3://backups/",
+				},
+			},
+			ExternalClusters: []apiv1.ExternalCluster{{Name: "replica-source"}},
+		},
+	}
+
+	Context("choosing the backup", func() {
+		backupList := &apiv1.BackupList{
+			Items: []apiv1.Backup{
+				newBackup("nightly-2", apiv1.BackupMethodBarmanObjectStore, now.Add(-time.Hour)),
+				newBackup("nightly-1", apiv1.BackupMethodBarmanObjectStore, now.Add(-25*time.Hour)),
+				newBackup("other-cluster", apiv1.BackupMethodBarmanObjectStore, now),
+			},
+		}
+		backupList.Items[2].Spec.Cluster.Name = "cluster-other"
+		running := newBackup("running", apiv1.BackupMethodBarmanObjectStore, now)
+		running.Status.Phase = apiv1.BackupPhaseRunning
+		backupList.Items = append(backupList.Items, running)
+
+		backups := getCompletedBackups(backupList, "cluster-example")
+
+		It("lists the completed backups of the cluster in order", func() {
+			Expect(backups).To(HaveLen(2))
+			Expect(backups[0].Name).To(Equal("nightly-1"))
+			Expect(backups[1].Name).To(Equal("nightly-2"))
+		})
+
+		It("chooses the most recent backup ended before the target time", func() {
+			backup, err := selectBackup(backups, "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backup.Name).To(Equal("nightly-2"))
+
+			targetTime := now.Add(-2 * time.Hour)
+			backup, err = selectBackup(backups, "", &targetTime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backup.Name).To(Equal("nightly-1"))
+
+			targetTime = now.Add(-48 * time.Hour)
+			_, err = selectBackup(backups, "", &targetTime)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("chooses the requested backup", func() {
+			backup, err := selectBackup(backups, "nightly-1", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backup.Name).To(Equal("nightly-1"))
+
+			_, err = selectBackup(backups, "running", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("checking the recovery target", func() {
+		backup := newBackup("nightly", apiv1.BackupMethodBarmanObjectStore, now.Add(-time.Hour))
+		archive := newWALArchiveStatus(postgres.PostgresqlStatus{
+			LastArchivedWAL:     "000000010000000000000010",
+			LastArchivedWALTime: now.Add(-time.Minute).UTC().Format(time.RFC3339),
+		})
+
+		It("accepts a target covered by the WAL archive", func() {
+			Expect(checkRecoveryTarget(&backup, nil, nil)).To(Succeed())
+
+			targetTime := now.Add(-30 * time.Minute)
+			Expect(checkRecoveryTarget(&backup, &targetTime, archive)).To(Succeed())
+		})
+
+		It("rejects a target before the end of the backup", func() {
+			targetTime := now.Add(-2 * time.Hour)
+			Expect(checkRecoveryTarget(&backup, &targetTime, archive)).To(MatchError(ContainSubstring("after the target")))
+		})
+
+		It("rejects a target after the last archived WAL file", func() {
+			targetTime := now.Add(-time.Second)
+			Expect(checkRecoveryTarget(&backup, &targetTime, archive)).To(MatchError(ContainSubstring("archived up to")))
+
+			neverArchived := newWALArchiveStatus(postgres.PostgresqlStatus{LastArchivedWALTime: "-infinity"})
+			Expect(checkRecoveryTarget(&backup, &targetTime, neverArchived)).To(HaveOccurred())
+			Expect(checkRecoveryTarget(&backup, &targetTime, nil)).To(HaveOccurred())
+		})
+
+		It("rejects a target in the future", func() {
+			targetTime := now.Add(time.Hour)
+			Expect(checkRecoveryTarget(&backup, &targetTime, archive)).To(MatchError(ContainSubstring("future")))
+		})
+	})
+
+	Context("generating the recovery cluster", func() {
+		targetTime := now.Add(-30 * time.Minute)
+
+		It("recovers an object store backup", func() {
+			backup := newBackup("nightly", apiv1.BackupMethodBarmanObjectStore, now.Add(-time.Hour))
+			cluster, err := getRecoveryCluster(source, "cluster-restore", &backup, &targetTime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cluster.Name).To(Equal("cluster-restore"))
+			Expect(cluster.Spec.Instances).To(Equal(3))
+			Expect(cluster.Spec.Backup).To(BeNil())
+			Expect(cluster.Spec.ExternalClusters).To(BeEmpty())
+
+			recovery := cluster.Spec.Bootstrap.Recovery
+			Expect(cluster.Spec.Bootstrap.InitDB).To(BeNil())
+			Expect(recovery.Backup.Name).To(Equal("nightly"))
+			Expect(recovery.Database).To(Equal("app"))
+			Expect(recovery.RecoveryTarget.TargetTime).To(Equal(targetTime.UTC().Format(time.RFC3339)))
+			Expect(source.Spec.Backup).ToNot(BeNil())
+		})
+
+		It("recovers volume snapshots using the WAL archive to reach the target", func() {
+			backup := newBackup("snapshot", apiv1.BackupMethodVolumeSnapshot, now.Add(-time.Hour))
+			backup.Status.BackupSnapshotStatus.Elements = []apiv1.BackupSnapshotElementStatus{
+				{Name: "snapshot-data", Type: string(utils.PVCRolePgData)},
+			}
+
+			cluster, err := getRecoveryCluster(source, "cluster-restore", &backup, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cluster.Spec.Bootstrap.Recovery.VolumeSnapshots.Storage.Name).To(Equal("snapshot-data"))
+			Expect(cluster.Spec.Bootstrap.Recovery.Source).To(BeEmpty())
+
+			cluster, err = getRecoveryCluster(source, "cluster-restore", &backup, &targetTime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cluster.Spec.Bootstrap.Recovery.Source).To(Equal("cluster-example"))
+			Expect(cluster.Spec.ExternalClusters).To(ConsistOf(apiv1.ExternalCluster{
+				Name: "cluster-example",
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+					DestinationPath: "s3://backups/",
+					ServerName:      "cluster-example",
+				},
+			}))
+
+			withoutArchive := source.DeepCopy()
+			withoutArchive.Spec.Backup = nil
+			_, err = getRecoveryCluster(withoutArchive, "cluster-restore", &backup, &targetTime)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("recovers a plugin backup through the plugin", func() {
+			backup := newBackup("plugin", apiv1.BackupMethodPlugin, now.Add(-time.Hour))
+			backup.Spec.PluginConfiguration = &apiv1.BackupPluginConfiguration{Name: "restic.example.com"}
+			withPlugin := source.DeepCopy()
+			withPlugin.Spec.Plugins = []apiv1.PluginConfiguration{
+				{Name: "restic.example.com", Parameters: map[string]string{"repository": "restic"}},
+			}
+
+			cluster, err := getRecoveryCluster(withPlugin, "cluster-restore", &backup, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cluster.Spec.Plugins).To(BeEmpty())
+			Expect(cluster.Spec.Bootstrap.Recovery.Source).To(Equal("cluster-example"))
+			Expect(cluster.Spec.ExternalClusters[0].PluginConfiguration.Parameters).To(
+				HaveKeyWithValue("repository", "restic"))
+		})
+	})
+})
@@ -0,0 +1,191 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+)
+
+// Options are the options of the restore command
+type Options struct {
+	// ClusterName is the name of the cluster whose backups are recovered
+	ClusterName string
+
+	// NewClusterName is the name of the cluster to be created
+	NewClusterName string
+
+	// BackupName is the name of the backup to be recovered
+	BackupName string
+
+	// TargetTime is the time to recover the cluster to
+	TargetTime string
+
+	// DryRun enables printing the manifest of the new cluster instead
+	// of creating it
+	DryRun bool
+}
+
+// Restore creates a new cluster recovering a backup of an existing one.
+// When neither the backup nor the target time are requested and the
+// command is run in a terminal, it asks for the target time after
+// listing the available backups
+func Restore(ctx context.Context, options Options) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: options.ClusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", options.ClusterName, err)
+	}
+
+	newClusterName := options.NewClusterName
+	if newClusterName == "" {
+		newClusterName = cluster.Name + "-restore"
+	}
+	if newClusterName == cluster.Name {
+		return fmt.Errorf("the new cluster must have a name different from %s", cluster.Name)
+	}
+
+	var backupList apiv1.BackupList
+	if err := plugin.Client.List(ctx, &backupList, client.InNamespace(plugin.Namespace)); err != nil {
+		return fmt.Errorf("while listing the backups: %w", err)
+	}
+	backups := getCompletedBackups(&backupList, cluster.Name)
+	if len(backups) == 0 {
+		return fmt.Errorf("cluster %s has no completed backups", cluster.Name)
+	}
+
+	archive := getWALArchiveStatus(ctx, cluster.Name)
+
+	interactive := options.BackupName == "" && options.TargetTime == "" &&
+		term.IsTerminal(int(os.Stdin.Fd())) // #nosec
+	reader := bufio.NewReader(os.Stdin)
+	targetTimeValue := options.TargetTime
+	if interactive {
+		printBackups(backups, archive)
+
+		fmt.Print("Target time (empty to replay all the archived WAL files): ")
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		targetTimeValue = strings.TrimSpace(answer)
+	}
+
+	targetTime, err := parseTargetTime(targetTimeValue)
+	if err != nil {
+		return err
+	}
+
+	backup, err := selectBackup(backups, options.BackupName, targetTime)
+	if err != nil {
+		return err
+	}
+
+	if err := checkRecoveryTarget(backup, targetTime, archive); err != nil {
+		return err
+	}
+
+	newCluster, err := getRecoveryCluster(&cluster, newClusterName, backup, targetTime)
+	if err != nil {
+		return err
+	}
+
+	if interactive && !options.DryRun {
+		target := "the last archived WAL file"
+		if targetTime != nil {
+			target = targetTime.Format(time.RFC3339)
+		}
+		fmt.Printf("Cluster %s will be created recovering backup %s up to %s\n",
+			newClusterName, backup.Name, target)
+		if !askToProceed(reader) {
+			return nil
+		}
+	}
+
+	return plugin.CreateAndGenerateObjects(ctx, []client.Object{newCluster}, options.DryRun)
+}
+
+// getWALArchiveStatus gets the status of the WAL archiver of the primary
+// instance, or nil if it can't be reached
+func getWALArchiveStatus(ctx context.Context, clusterName string) *walArchiveStatus {
+	_, primary, err := resources.GetInstancePods(ctx, clusterName)
+	if err != nil || primary.Name == "" {
+		return nil
+	}
+
+	statusList, _ := resources.ExtractInstancesStatus(ctx, plugin.Config, []corev1.Pod{primary})
+	if len(statusList.Items) == 0 || statusList.Items[0].Error != nil {
+		return nil
+	}
+
+	return newWALArchiveStatus(statusList.Items[0])
+}
+
+// printBackups shows the completed backups and the time the cluster can
+// be recovered to
+func printBackups(backups []apiv1.Backup, archive *walArchiveStatus) {
+	fmt.Println(aurora.Green("Completed backups"))
+	table := tabby.New()
+	table.AddHeader("Name", "Method", "Started At", "Stopped At", "Begin WAL", "End WAL")
+	for _, backup := range backups {
+		startedAt := "-"
+		if backup.Status.StartedAt != nil {
+			startedAt = backup.Status.StartedAt.Format(time.RFC3339)
+		}
+		table.AddLine(backup.Name, backup.Status.Method, startedAt,
+			backup.Status.StoppedAt.Format(time.RFC3339), backup.Status.BeginWal, backup.Status.EndWal)
+	}
+	table.Print()
+	fmt.Println()
+
+	fmt.Println(aurora.Green("Recovery window"))
+	window := tabby.New()
+	window.AddLine("Earliest target time:", backups[0].Status.StoppedAt.Format(time.RFC3339))
+	window.AddLine("Latest target time:", formatArchivedTime(archive))
+	if archive != nil && archive.lastArchivedWAL != "" {
+		window.AddLine("Last archived WAL:", archive.lastArchivedWAL)
+	}
+	window.Print()
+	fmt.Println()
+}
+
+func askToProceed(reader *bufio.Reader) bool {
+	fmt.Printf("Do you want to proceed? [y/n]: ")
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -44,7 +44,11 @@ func NewCmd() *cobra.Command {
 
 			verbose, _ := cmd.Flags().GetCount("verbose")
 			output, _ := cmd.Flags().GetString("output")
+			pendingChanges, _ := cmd.Flags().GetBool("pending-changes")
 
+			if pendingChanges {
+				return PendingChangesStatus(ctx, clusterName, plugin.OutputFormat(output))
+			}
 			return Status(ctx, clusterName, verbose, plugin.OutputFormat(output))
 		},
 	}
@@ -53,6 +57,9 @@ func NewCmd() *cobra.Command {
 		"verbose", "v", "Increase verbosity to display more information")
 	statusCmd.Flags().StringP(
 		"output", "o", "text", "Output format. One of text|json")
+	statusCmd.Flags().Bool(
+		"pending-changes", false,
+		"Show the changes not yet applied to the instances, and the order in which they will be rolled out")
 
 	return statusCmd
 }
@@ -0,0 +1,398 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// pendingParametersQuery gets the parameters waiting for a restart of
+// PostgreSQL, with the value they will take from the configuration files
+const pendingParametersQuery = `
+SELECT s.name, current_setting(s.name), coalesce(f.setting, s.boot_val), s.context
+FROM pg_settings s
+LEFT JOIN (
+	SELECT name, setting, rank() OVER (PARTITION BY name ORDER BY seqno DESC) AS rank
+	FROM pg_file_settings
+) f ON f.name = s.name AND f.rank = 1
+WHERE s.pending_restart
+ORDER BY s.name`
+
+// InstanceAction is how an instance is updated to apply its pending changes
+type InstanceAction string
+
+const (
+	// InstanceActionNone means that the instance is up to date
+	InstanceActionNone InstanceAction = "none"
+
+	// InstanceActionRestart means that PostgreSQL is restarted in place
+	InstanceActionRestart InstanceAction = "restart"
+
+	// InstanceActionRecreate means that the pod is deleted and created again
+	InstanceActionRecreate InstanceAction = "recreate"
+
+	// InstanceActionSwitchover means that the primary is moved to another
+	// instance, and then its pod is deleted and created again
+	InstanceActionSwitchover InstanceAction = "switchover"
+
+	// InstanceActionSupervised means that the rollout of the primary waits
+	// for the user to request a switchover
+	InstanceActionSupervised InstanceAction = "supervised"
+)
+
+// ParameterChange is a PostgreSQL parameter whose new value will be
+// applied with the next restart
+type ParameterChange struct {
+	Name         string `json:"name"`
+	CurrentValue string `json:"currentValue"`
+	NewValue     string `json:"newValue"`
+	Context      string `json:"context"`
+}
+
+// InstancePendingChanges contains the changes still to be applied to
+// an instance
+type InstancePendingChanges struct {
+	Name       string            `json:"name"`
+	IsPrimary  bool              `json:"isPrimary"`
+	IsFenced   bool              `json:"isFenced,omitempty"`
+	Action     InstanceAction    `json:"action"`
+	Reasons    []string          `json:"reasons,omitempty"`
+	Parameters []ParameterChange `json:"parameters,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// PendingChanges contains the changes of the cluster specification not
+// yet applied to the instances. The instances are listed in the order in
+// which the operator rolls them out
+type PendingChanges struct {
+	Cluster               string                   `json:"cluster"`
+	Instances             []InstancePendingChanges `json:"instances"`
+	DeferredOperations    []string                 `json:"deferredOperations,omitempty"`
+	NextMaintenanceWindow string                   `json:"nextMaintenanceWindow,omitempty"`
+}
+
+// PendingChangesStatus implements the "status --pending-changes" subcommand
+func PendingChangesStatus(ctx context.Context, clusterName string, format plugin.OutputFormat) error {
+	var cluster apiv1.Cluster
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("while trying to get cluster %s in namespace %s: %w",
+			clusterName, plugin.Namespace, err)
+	}
+
+	managedPods, _, err := resources.GetInstancePods(ctx, cluster.Name)
+	if err != nil {
+		return err
+	}
+	instancesStatus, _ := resources.ExtractInstancesStatus(ctx, plugin.Config, managedPods)
+
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+	parameters := make(map[string][]ParameterChange)
+	for _, instance := range instancesStatus.Items {
+		if instance.Error != nil || !instance.PendingRestart {
+			continue
+		}
+		changes, err := getPendingParameters(ctx, clientInterface, *instance.Pod)
+		if err != nil {
+			return fmt.Errorf("while getting the pending parameters of %s: %w", instance.Pod.Name, err)
+		}
+		parameters[instance.Pod.Name] = changes
+	}
+
+	pendingChanges := getPendingChanges(&cluster, instancesStatus, parameters)
+	if format != plugin.OutputFormatText {
+		return plugin.Print(pendingChanges, format, os.Stdout)
+	}
+
+	pendingChanges.print()
+	return nil
+}
+
+// getPendingParameters runs psql inside the instance to get the
+// parameters waiting for a restart
+func getPendingParameters(
+	ctx context.Context,
+	clientInterface kubernetes.Interface,
+	pod corev1.Pod,
+) ([]ParameterChange, error) {
+	timeout := time.Second * 10
+	stdout, _, err := utils.ExecCommand(ctx, clientInterface, plugin.Config, pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"psql", "-XAt", "-F", "\t", "-c", pendingParametersQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePendingParameters(stdout), nil
+}
+
+// parsePendingParameters parses the output of the pending parameters query
+func parsePendingParameters(output string) []ParameterChange {
+	var changes []ParameterChange
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		changes = append(changes, ParameterChange{
+			Name:         fields[0],
+			CurrentValue: fields[1],
+			NewValue:     fields[2],
+			Context:      fields[3],
+		})
+	}
+	return changes
+}
+
+// getPendingChanges computes the changes still to be applied to the
+// instances, sorting them in the rollout order of the operator: the
+// replicas, starting from the most lagging one, and the primary last
+func getPendingChanges(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	parameters map[string][]ParameterChange,
+) PendingChanges {
+	result := PendingChanges{Cluster: cluster.Name}
+	if maintenance := cluster.Status.Maintenance; maintenance != nil {
+		result.DeferredOperations = maintenance.PendingOperations
+		if maintenance.NextWindow != nil {
+			result.NextMaintenanceWindow = maintenance.NextWindow.Format(time.RFC3339)
+		}
+	}
+
+	sort.Sort(&instancesStatus)
+
+	var primary *InstancePendingChanges
+	for i := len(instancesStatus.Items) - 1; i >= 0; i-- {
+		instance := instancesStatus.Items[i]
+		changes := InstancePendingChanges{
+			Name:       instance.Pod.Name,
+			IsPrimary:  instance.Pod.Name == cluster.Status.CurrentPrimary,
+			IsFenced:   cluster.IsInstanceFenced(instance.Pod.Name),
+			Action:     InstanceActionNone,
+			Parameters: parameters[instance.Pod.Name],
+		}
+		if instance.Error != nil {
+			changes.Error = instance.Error.Error()
+			result.Instances = append(result.Instances, changes)
+			continue
+		}
+
+		reasons, inPlace, forceRecreate := getInstanceRolloutReasons(cluster, instance)
+		changes.Reasons = reasons
+		if len(reasons) > 0 && !changes.IsFenced {
+			changes.Action = InstanceActionRecreate
+			if changes.IsPrimary {
+				changes.Action = getPrimaryAction(cluster, len(instancesStatus.Items), inPlace, forceRecreate)
+			}
+		}
+
+		if changes.IsPrimary {
+			primary = &changes
+			continue
+		}
+		result.Instances = append(result.Instances, changes)
+	}
+
+	if primary != nil {
+		result.Instances = append(result.Instances, *primary)
+	}
+
+	return result
+}
+
+// getPrimaryAction gets how the operator updates the primary instance
+func getPrimaryAction(cluster *apiv1.Cluster, instances int, inPlace, forceRecreate bool) InstanceAction {
+	switch {
+	case cluster.GetPrimaryUpdateStrategy() == apiv1.PrimaryUpdateStrategySupervised:
+		return InstanceActionSupervised
+	case cluster.GetPrimaryUpdateMethod() == apiv1.PrimaryUpdateMethodRestart || forceRecreate:
+		if inPlace {
+			return InstanceActionRestart
+		}
+		return InstanceActionRecreate
+	case instances > 1:
+		return InstanceActionSwitchover
+	default:
+		return InstanceActionRecreate
+	}
+}
+
+// getInstanceRolloutReasons checks the instance against the cluster
+// specification, in the same way the operator does, returning the reasons
+// why it needs to be updated, whether PostgreSQL can just be restarted, and
+// whether the pod must be recreated even when the primary is updated by a
+// switchover
+func getInstanceRolloutReasons(
+	cluster *apiv1.Cluster,
+	instance postgres.PostgresqlStatus,
+) (reasons []string, inPlace bool, forceRecreate bool) {
+	pod := instance.Pod
+	inPlace = true
+
+	if instance.ExecutableHash == "" {
+		reasons = append(reasons, "the instance manager is not reporting its executable hash")
+		inPlace = false
+	}
+
+	if persistentvolumeclaim.InstanceHasMissingMounts(cluster, pod) {
+		reasons = append(reasons, "a new PVC needs to be attached")
+		inPlace = false
+		forceRecreate = true
+	}
+
+	if currentImage, err := specs.GetPostgresImageName(*pod); err == nil && currentImage != cluster.GetImageName() {
+		reasons = append(reasons, fmt.Sprintf("image change: %s -> %s", currentImage, cluster.GetImageName()))
+		inPlace = false
+	}
+
+	if clusterRestart, ok := cluster.Annotations[utils.ClusterRestartAnnotationName]; ok &&
+		clusterRestart != pod.Annotations[utils.ClusterRestartAnnotationName] {
+		reasons = append(reasons, "restart requested via annotation")
+	}
+
+	if diff := getPodSpecDiff(cluster, pod); diff != "" {
+		reasons = append(reasons, "pod spec change in "+diff)
+		inPlace = false
+	}
+
+	if instance.PendingRestart {
+		reasons = append(reasons, "configuration changes requiring a restart")
+	}
+
+	return reasons, inPlace && len(reasons) > 0, forceRecreate
+}
+
+// getPodSpecDiff compares the spec the pod has been created with and the
+// one it would be created with now, ignoring the init containers that
+// depend on the operator version
+func getPodSpecDiff(cluster *apiv1.Cluster, pod *corev1.Pod) string {
+	if utils.IsPodSpecReconciliationDisabled(&cluster.ObjectMeta) {
+		return ""
+	}
+
+	podSpecAnnotation, ok := pod.Annotations[utils.PodSpecAnnotationName]
+	if !ok {
+		return ""
+	}
+
+	var storedPodSpec corev1.PodSpec
+	if err := json.Unmarshal([]byte(podSpecAnnotation), &storedPodSpec); err != nil {
+		return ""
+	}
+
+	envConfig := specs.CreatePodEnvConfig(*cluster, pod.Name)
+	gracePeriod := int64(cluster.GetMaxStopDelay())
+	tlsEnabled := remote.GetStatusSchemeFromPod(pod).IsHTTPS()
+	targetPodSpec := specs.CreateClusterPodSpec(pod.Name, *cluster, envConfig, gracePeriod, tlsEnabled)
+
+	storedPodSpec.InitContainers = nil
+	targetPodSpec.InitContainers = nil
+
+	if match, diff := specs.ComparePodSpecs(storedPodSpec, targetPodSpec); !match {
+		return diff
+	}
+	return ""
+}
+
+func (pendingChanges PendingChanges) print() {
+	fmt.Println(aurora.Green("Pending changes"))
+	instances := tabby.New()
+	instances.AddHeader("Step", "Instance", "Role", "Action", "Reasons")
+	step := 0
+	for _, instance := range pendingChanges.Instances {
+		role := "Standby"
+		if instance.IsPrimary {
+			role = "Primary"
+		}
+
+		action := string(instance.Action)
+		reasons := strings.Join(instance.Reasons, "; ")
+		switch {
+		case instance.Error != "":
+			action = "unknown"
+			reasons = instance.Error
+		case instance.IsFenced && len(instance.Reasons) > 0:
+			action = "none (fenced)"
+		}
+
+		stepColumn := "-"
+		if instance.Action != InstanceActionNone {
+			step++
+			stepColumn = strconv.Itoa(step)
+		}
+		instances.AddLine(stepColumn, instance.Name, role, action, reasons)
+	}
+	instances.Print()
+	fmt.Println()
+
+	if step == 0 {
+		fmt.Println("No instance needs to be updated")
+		fmt.Println()
+	}
+
+	parameters := tabby.New()
+	hasParameters := false
+	for _, instance := range pendingChanges.Instances {
+		for _, parameter := range instance.Parameters {
+			if !hasParameters {
+				fmt.Println(aurora.Green("PostgreSQL parameters waiting for a restart"))
+				parameters.AddHeader("Instance", "Parameter", "Current value", "New value")
+				hasParameters = true
+			}
+			parameters.AddLine(instance.Name, parameter.Name, parameter.CurrentValue, parameter.NewValue)
+		}
+	}
+	if hasParameters {
+		parameters.Print()
+		fmt.Println()
+	}
+
+	if len(pendingChanges.DeferredOperations) > 0 {
+		fmt.Println(aurora.Yellow("Operations deferred until the next maintenance window"))
+		if pendingChanges.NextMaintenanceWindow != "" {
+			fmt.Printf("Next maintenance window: %s\n", pendingChanges.NextMaintenanceWindow)
+		}
+		for _, operation := range pendingChanges.DeferredOperations {
+			fmt.Printf("- %s\n", operation)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pending changes", func() {
+	var (
+		cluster  *apiv1.Cluster
+		statuses postgres.PostgresqlStatusList
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				ImageName: "ghcr.io/cloudnative-pg/postgresql:16.4",
+			},
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+
+		statuses = postgres.PostgresqlStatusList{}
+		for serial := 1; serial <= 3; serial++ {
+			statuses.Items = append(statuses.Items, postgres.PostgresqlStatus{
+				Pod:            specs.PodWithExistingStorage(*cluster, serial),
+				IsPrimary:      serial == 1,
+				IsPodReady:     true,
+				ExecutableHash: "test_hash",
+				ReceivedLsn:    "0/3000000",
+			})
+		}
+		statuses.Items[1].ReceivedLsn = "0/2000000"
+	})
+
+	getActions := func(changes PendingChanges) map[string]InstanceAction {
+		result := make(map[string]InstanceAction)
+		for _, instance := range changes.Instances {
+			result[instance.Name] = instance.Action
+		}
+		return result
+	}
+
+	It("reports no change for instances that are up to date", func() {
+		changes := getPendingChanges(cluster, statuses, nil)
+		Expect(changes.Instances).To(HaveLen(3))
+		for _, instance := range changes.Instances {
+			Expect(instance.Action).To(Equal(InstanceActionNone))
+			Expect(instance.Reasons).To(BeEmpty())
+		}
+	})
+
+	It("rolls out the replicas from the most lagging one, and then switches over the primary", func() {
+		cluster.Spec.ImageName = "ghcr.io/cloudnative-pg/postgresql:16.5"
+		cluster.Spec.PrimaryUpdateMethod = apiv1.PrimaryUpdateMethodSwitchover
+
+		changes := getPendingChanges(cluster, statuses, nil)
+		Expect(changes.Instances).To(HaveLen(3))
+		Expect(changes.Instances[0].Name).To(Equal("cluster-example-2"))
+		Expect(changes.Instances[1].Name).To(Equal("cluster-example-3"))
+		Expect(changes.Instances[2].Name).To(Equal("cluster-example-1"))
+		Expect(changes.Instances[2].IsPrimary).To(BeTrue())
+		Expect(changes.Instances[2].Reasons).To(ContainElement(
+			"image change: ghcr.io/cloudnative-pg/postgresql:16.4 -> ghcr.io/cloudnative-pg/postgresql:16.5"))
+		Expect(getActions(changes)).To(Equal(map[string]InstanceAction{
+			"cluster-example-1": InstanceActionSwitchover,
+			"cluster-example-2": InstanceActionRecreate,
+			"cluster-example-3": InstanceActionRecreate,
+		}))
+	})
+
+	It("restarts the primary in place for the parameters requiring a restart", func() {
+		cluster.Spec.PrimaryUpdateMethod = apiv1.PrimaryUpdateMethodRestart
+		statuses.Items[0].PendingRestart = true
+		parameters := map[string][]ParameterChange{
+			"cluster-example-1": parsePendingParameters("max_connections\t100\t200\tpostmaster\n"),
+		}
+
+		changes := getPendingChanges(cluster, statuses, parameters)
+		primary := changes.Instances[2]
+		Expect(primary.Action).To(Equal(InstanceActionRestart))
+		Expect(primary.Reasons).To(ConsistOf("configuration changes requiring a restart"))
+		Expect(primary.Parameters).To(ConsistOf(ParameterChange{
+			Name:         "max_connections",
+			CurrentValue: "100",
+			NewValue:     "200",
+			Context:      "postmaster",
+		}))
+	})
+
+	It("waits for the user with the supervised strategy", func() {
+		cluster.Spec.PrimaryUpdateStrategy = apiv1.PrimaryUpdateStrategySupervised
+		cluster.Spec.ImageName = "ghcr.io/cloudnative-pg/postgresql:16.5"
+
+		changes := getPendingChanges(cluster, statuses, nil)
+		Expect(changes.Instances[2].Action).To(Equal(InstanceActionSupervised))
+	})
+})
@@ -35,6 +35,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -139,6 +140,7 @@ func Status(
 	status.printReplicaStatus(verbosity)
 	if verbosity > 0 {
 		status.printUnmanagedReplicationSlotStatus()
+		status.printAdditionalReplicationSlotsStatus()
 		status.printRoleManagerStatus()
 		status.printTablespacesStatus()
 		status.printPodDisruptionBudgetStatus()
@@ -261,7 +263,7 @@ func (fullStatus *PostgresqlStatus) printBasicInfo(ctx context.Context, k8sClien
 	summary.AddLine("PostgreSQL Image:", cluster.GetImageName())
 	if cluster.IsReplica() {
 		summary.AddLine("Designated primary:", primaryInstance)
-		summary.AddLine("Source cluster: ", cluster.Spec.ReplicaCluster.Source)
+		summary.AddLine("Source cluster: ", cluster.GetReplicaSource())
 	} else {
 		summary.AddLine("Primary instance:", primaryInstance)
 	}
@@ -272,6 +274,10 @@ func (fullStatus *PostgresqlStatus) printBasicInfo(ctx context.Context, k8sClien
 	}
 
 	summary.AddLine("Status:", fullStatus.getStatus(isPrimaryFenced, cluster))
+	if pause := cluster.Status.PauseStatus; pause != nil {
+		summary.AddLine("Reconciliation:", aurora.Yellow(fmt.Sprintf("paused since %s by %s",
+			pause.Since.UTC().Format(time.RFC3339), pause.By)))
+	}
 	if cluster.Spec.Instances == cluster.Status.Instances {
 		summary.AddLine("Instances:", aurora.Green(cluster.Spec.Instances))
 	} else {
@@ -896,6 +902,9 @@ func (fullStatus *PostgresqlStatus) printUnmanagedReplicationSlotStatus() {
 			strings.HasPrefix(slot.SlotName, replicationSlots.HighAvailability.GetSlotPrefix()) {
 			continue
 		}
+		if replicationSlots.IsAdditional(slot.SlotName) {
+			continue
+		}
 		unmanagedReplicationSlots = append(unmanagedReplicationSlots, slot)
 	}
 
@@ -952,6 +961,48 @@ func (fullStatus *PostgresqlStatus) printUnmanagedReplicationSlotStatus() {
 	fmt.Println()
 }
 
+func (fullStatus *PostgresqlStatus) printAdditionalReplicationSlotsStatus() {
+	additionalReplicationSlots := fullStatus.Cluster.Status.AdditionalReplicationSlots
+	if len(additionalReplicationSlots) == 0 {
+		return
+	}
+
+	status := tabby.New()
+	status.AddHeader(
+		"Slot Name",
+		"Active",
+		"Restart LSN",
+		"Lag",
+	)
+
+	var containsFailure bool
+	for _, slot := range additionalReplicationSlots {
+		if !slot.Active {
+			containsFailure = true
+		}
+
+		lag := "NULL"
+		if slot.LagBytes != nil {
+			lag = resource.NewQuantity(*slot.LagBytes, resource.BinarySI).String()
+		}
+		status.AddLine(
+			slot.Name,
+			slot.Active,
+			slot.RestartLSN,
+			lag,
+		)
+	}
+
+	color := aurora.Green
+	if containsFailure {
+		color = aurora.Red
+	}
+
+	fmt.Println(color("Additional Replication Slots Status"))
+	status.Print()
+	fmt.Println()
+}
+
 func (fullStatus *PostgresqlStatus) printPodDisruptionBudgetStatus() {
 	const header = "Pod Disruption Budgets status"
 
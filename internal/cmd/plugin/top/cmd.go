@@ -0,0 +1,72 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "top" command
+func NewCmd() *cobra.Command {
+	var options Options
+
+	cmd := &cobra.Command{
+		Use:   "top CLUSTER",
+		Short: "Show the live activity of the instances of a cluster",
+		Long: `Periodically shows the sessions, the wait events and the replication status ` +
+			`of all the instances of a cluster, until interrupted.`,
+		GroupID: plugin.GroupIDTroubleshooting,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Interval <= 0 {
+				return fmt.Errorf("the refresh interval must be positive: %v", options.Interval)
+			}
+			return Top(cmd.Context(), args[0], options)
+		},
+	}
+
+	cmd.Flags().DurationVarP(
+		&options.Interval,
+		"interval",
+		"d",
+		2*time.Second,
+		"The time between two refreshes",
+	)
+	cmd.Flags().IntVarP(
+		&options.Iterations,
+		"iterations",
+		"n",
+		0,
+		"The number of refreshes before exiting (by default, runs until interrupted)",
+	)
+	cmd.Flags().BoolVar(
+		&options.ShowIdle,
+		"idle",
+		false,
+		"Show the idle sessions too",
+	)
+
+	return cmd
+}
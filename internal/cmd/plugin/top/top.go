@@ -0,0 +1,328 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// activityQuery gets the sessions of the client backends, one per line,
+// with the fields separated by tabs
+const activityQuery = `
+SELECT pid,
+	coalesce(usename, ''),
+	coalesce(datname, ''),
+	coalesce(application_name, ''),
+	coalesce(client_addr::text, 'local'),
+	coalesce(state, ''),
+	coalesce(wait_event_type || ':' || wait_event, ''),
+	coalesce(extract(epoch FROM clock_timestamp() - query_start)::bigint, 0),
+	left(regexp_replace(query, '\s+', ' ', 'g'), 200)
+FROM pg_stat_activity
+WHERE backend_type = 'client backend' AND pid <> pg_backend_pid()
+ORDER BY query_start`
+
+// maxQueryLength is the number of characters of the queries that are shown
+const maxQueryLength = 60
+
+// clearScreen moves the cursor to the top left corner of the terminal
+// and clears it
+const clearScreen = "\033[H\033[2J"
+
+// Options are the options of the top command
+type Options struct {
+	// Interval is the time between two refreshes
+	Interval time.Duration
+
+	// Iterations is the number of refreshes before exiting. Zero
+	// means until interrupted
+	Iterations int
+
+	// ShowIdle enables showing the idle sessions
+	ShowIdle bool
+}
+
+// session is a client session of an instance
+type session struct {
+	PID         string
+	User        string
+	Database    string
+	Application string
+	Client      string
+	State       string
+	WaitEvent   string
+	Duration    time.Duration
+	Query       string
+}
+
+// instanceActivity is the activity of an instance
+type instanceActivity struct {
+	Name      string
+	IsPrimary bool
+	Sessions  []session
+	Err       error
+}
+
+// snapshot is the activity of the cluster at a point in time
+type snapshot struct {
+	Cluster     string
+	Time        time.Time
+	Instances   []instanceActivity
+	Replication postgres.PgStatReplicationList
+}
+
+// Top shows the activity of the cluster until interrupted, or until the
+// requested number of refreshes is reached
+func Top(ctx context.Context, clusterName string, options Options) error {
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+	isTerminal := term.IsTerminal(int(os.Stdout.Fd())) // #nosec
+
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+
+	for iteration := 1; ; iteration++ {
+		current, err := takeSnapshot(ctx, clientInterface, clusterName)
+		if err != nil {
+			return err
+		}
+
+		if isTerminal {
+			fmt.Print(clearScreen)
+		}
+		current.print(os.Stdout, options)
+
+		if options.Iterations > 0 && iteration >= options.Iterations {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// takeSnapshot collects the activity of every instance of the cluster
+func takeSnapshot(ctx context.Context, clientInterface kubernetes.Interface, clusterName string) (*snapshot, error) {
+	pods, _, err := resources.GetInstancePods(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("cluster %s does not exist or has no instances", clusterName)
+	}
+
+	result := &snapshot{Cluster: clusterName, Time: time.Now()}
+	for idx := range pods {
+		pod := pods[idx]
+		activity := instanceActivity{Name: pod.Name, IsPrimary: specs.IsPodPrimary(pod)}
+		activity.Sessions, activity.Err = getSessions(ctx, clientInterface, pod)
+		result.Instances = append(result.Instances, activity)
+	}
+	sort.Slice(result.Instances, func(i, j int) bool {
+		return result.Instances[i].Name < result.Instances[j].Name
+	})
+
+	statusList, _ := resources.ExtractInstancesStatus(ctx, plugin.Config, pods)
+	for _, status := range statusList.Items {
+		if status.Error == nil && status.IsPrimary {
+			result.Replication = status.ReplicationInfo
+		}
+	}
+
+	return result, nil
+}
+
+// getSessions runs psql inside the instance to get its client sessions
+func getSessions(ctx context.Context, clientInterface kubernetes.Interface, pod corev1.Pod) ([]session, error) {
+	timeout := time.Second * 10
+	stdout, _, err := utils.ExecCommand(ctx, clientInterface, plugin.Config, pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"psql", "-XAt", "-F", "\t", "-c", activityQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSessions(stdout), nil
+}
+
+// parseSessions parses the output of the activity query
+func parseSessions(output string) []session {
+	var sessions []session
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 9 {
+			continue
+		}
+
+		seconds, _ := strconv.ParseInt(fields[7], 10, 64)
+		sessions = append(sessions, session{
+			PID:         fields[0],
+			User:        fields[1],
+			Database:    fields[2],
+			Application: fields[3],
+			Client:      fields[4],
+			State:       fields[5],
+			WaitEvent:   fields[6],
+			Duration:    time.Duration(seconds) * time.Second,
+			Query:       fields[8],
+		})
+	}
+	return sessions
+}
+
+// waitEventCount is the number of sessions waiting on an event
+type waitEventCount struct {
+	instance  string
+	waitEvent string
+	sessions  int
+}
+
+// getWaitEvents counts the sessions waiting on each event, per instance,
+// starting from the most common ones
+func (snap *snapshot) getWaitEvents() []waitEventCount {
+	var result []waitEventCount
+	for _, instance := range snap.Instances {
+		counts := make(map[string]int)
+		for _, item := range instance.Sessions {
+			if item.WaitEvent != "" && item.State != "idle" {
+				counts[item.WaitEvent]++
+			}
+		}
+		for waitEvent, sessions := range counts {
+			result = append(result, waitEventCount{instance: instance.Name, waitEvent: waitEvent, sessions: sessions})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].sessions != result[j].sessions {
+			return result[i].sessions > result[j].sessions
+		}
+		if result[i].instance != result[j].instance {
+			return result[i].instance < result[j].instance
+		}
+		return result[i].waitEvent < result[j].waitEvent
+	})
+	return result
+}
+
+func (snap *snapshot) print(writer io.Writer, options Options) {
+	_, _ = fmt.Fprintf(writer, "%s - %s (refreshing every %s, press Ctrl+C to exit)\n\n",
+		aurora.Bold(snap.Cluster), snap.Time.Format(time.RFC3339), options.Interval)
+
+	snap.printReplication(writer)
+	snap.printWaitEvents(writer)
+	snap.printSessions(writer, options.ShowIdle)
+}
+
+func (snap *snapshot) printReplication(writer io.Writer) {
+	_, _ = fmt.Fprintln(writer, aurora.Green("Replication"))
+	if len(snap.Replication) == 0 {
+		_, _ = fmt.Fprintf(writer, "No streaming replicas\n\n")
+		return
+	}
+
+	table := tabby.NewCustom(newTabWriter(writer))
+	table.AddHeader("Replica", "State", "Sent LSN", "Replay LSN", "Replay Lag", "Sync State")
+	for _, replica := range snap.Replication {
+		table.AddLine(replica.ApplicationName, replica.State, replica.SentLsn, replica.ReplayLsn,
+			replica.ReplayLag, replica.SyncState)
+	}
+	table.Print()
+	_, _ = fmt.Fprintln(writer)
+}
+
+func (snap *snapshot) printWaitEvents(writer io.Writer) {
+	_, _ = fmt.Fprintln(writer, aurora.Green("Wait events"))
+	waitEvents := snap.getWaitEvents()
+	if len(waitEvents) == 0 {
+		_, _ = fmt.Fprintf(writer, "No sessions waiting\n\n")
+		return
+	}
+
+	table := tabby.NewCustom(newTabWriter(writer))
+	table.AddHeader("Instance", "Wait Event", "Sessions")
+	for _, waitEvent := range waitEvents {
+		table.AddLine(waitEvent.instance, waitEvent.waitEvent, waitEvent.sessions)
+	}
+	table.Print()
+	_, _ = fmt.Fprintln(writer)
+}
+
+func (snap *snapshot) printSessions(writer io.Writer, showIdle bool) {
+	_, _ = fmt.Fprintln(writer, aurora.Green("Sessions"))
+	table := tabby.NewCustom(newTabWriter(writer))
+	table.AddHeader("Instance", "Role", "PID", "User", "Database", "Application", "Client",
+		"State", "Wait Event", "Duration", "Query")
+	for _, instance := range snap.Instances {
+		role := "Standby"
+		if instance.IsPrimary {
+			role = "Primary"
+		}
+
+		if instance.Err != nil {
+			table.AddLine(instance.Name, role, "-", "-", "-", "-", "-", "-", "-", "-", instance.Err.Error())
+			continue
+		}
+
+		for _, item := range instance.Sessions {
+			if item.State == "idle" && !showIdle {
+				continue
+			}
+			table.AddLine(instance.Name, role, item.PID, item.User, item.Database,
+				item.Application, item.Client, item.State, item.WaitEvent,
+				item.Duration, truncate(item.Query, maxQueryLength))
+		}
+	}
+	table.Print()
+}
+
+// newTabWriter creates the tab writer used to print the tables
+func newTabWriter(writer io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+}
+
+// truncate shortens the text to the given number of characters
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
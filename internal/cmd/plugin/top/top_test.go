@@ -0,0 +1,87 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("top", func() {
+	sessions := parseSessions(
+		"1234\tapp\tapp\tpsql\t10.0.0.5\tactive\tLock:transactionid\t42\tUPDATE orders SET state = 'paid'\n" +
+			"1235\tapp\tapp\tpsql\t10.0.0.6\tactive\tLock:transactionid\t3\tUPDATE orders SET state = 'sent'\n" +
+			"1236\tapp\tapp\tworker\tlocal\tidle\tClient:ClientRead\t300\tCOMMIT\n" +
+			"malformed line\n")
+
+	current := &snapshot{
+		Cluster: "cluster-example",
+		Time:    time.Now(),
+		Instances: []instanceActivity{
+			{Name: "cluster-example-1", IsPrimary: true, Sessions: sessions},
+			{Name: "cluster-example-2", Err: errors.New("container not found")},
+		},
+		Replication: postgres.PgStatReplicationList{
+			{ApplicationName: "cluster-example-2", State: "streaming", SyncState: "async"},
+		},
+	}
+
+	It("parses the sessions of an instance", func() {
+		Expect(sessions).To(HaveLen(3))
+		Expect(sessions[0]).To(Equal(session{
+			PID:         "1234",
+			User:        "app",
+			Database:    "app",
+			Application: "psql",
+			Client:      "10.0.0.5",
+			State:       "active",
+			WaitEvent:   "Lock:transactionid",
+			Duration:    42 * time.Second,
+			Query:       "UPDATE orders SET state = 'paid'",
+		}))
+	})
+
+	It("counts the wait events of the sessions that are not idle", func() {
+		Expect(current.getWaitEvents()).To(ConsistOf(
+			waitEventCount{instance: "cluster-example-1", waitEvent: "Lock:transactionid", sessions: 2},
+		))
+	})
+
+	It("hides the idle sessions unless requested", func() {
+		var buffer bytes.Buffer
+		current.print(&buffer, Options{Interval: time.Second})
+		Expect(buffer.String()).To(ContainSubstring("streaming"))
+		Expect(buffer.String()).To(ContainSubstring("1234"))
+		Expect(buffer.String()).To(ContainSubstring("container not found"))
+		Expect(buffer.String()).ToNot(ContainSubstring("1236"))
+
+		buffer.Reset()
+		current.print(&buffer, Options{Interval: time.Second, ShowIdle: true})
+		Expect(buffer.String()).To(ContainSubstring("1236"))
+	})
+
+	It("truncates the long queries", func() {
+		Expect(truncate("SELECT 1", 10)).To(Equal("SELECT 1"))
+		Expect(truncate("SELECT * FROM orders", 10)).To(Equal("SELECT ..."))
+	})
+})
@@ -0,0 +1,48 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "validate" command
+func NewCmd() *cobra.Command {
+	var fileNames []string
+
+	cmd := &cobra.Command{
+		Use:   "validate -f FILENAME",
+		Short: "Validate the resources of the operator",
+		Long: `Applies the defaults and the validation rules of the operator admission webhooks ` +
+			`to the resources in the given files, without contacting the Kubernetes cluster. ` +
+			`Use "-" to read from the standard input.`,
+		Example: "  kubectl cnpg validate -f cluster.yaml",
+		GroupID: plugin.GroupIDMiscellaneous,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return Validate(cmd.InOrStdin(), cmd.OutOrStdout(), fileNames)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&fileNames, "filename", "f", nil,
+		"The files containing the resources to be validated")
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
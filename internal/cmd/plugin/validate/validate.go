@@ -0,0 +1,183 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+)
+
+// errInvalidResources is returned when at least one resource is not valid
+var errInvalidResources = errors.New("invalid resources found")
+
+// defaulter is implemented by the resources having a defaulting webhook
+type defaulter interface {
+	Default()
+}
+
+// validator is implemented by the resources having a validating webhook
+type validator interface {
+	ValidateCreate() (admission.Warnings, error)
+}
+
+// result is the outcome of the validation of a resource
+type result struct {
+	kind     string
+	name     string
+	warnings []string
+	err      error
+}
+
+// Validate validates the resources contained in the given files, writing
+// the outcome to out. A file named "-" is read from in
+func Validate(in io.Reader, out io.Writer, fileNames []string) error {
+	var results []result
+	for _, fileName := range fileNames {
+		fileResults, err := validateFile(in, fileName)
+		if err != nil {
+			return err
+		}
+		results = append(results, fileResults...)
+	}
+
+	invalid := false
+	for _, res := range results {
+		if res.err != nil {
+			invalid = true
+			_, _ = fmt.Fprintf(out, "%s/%s is not valid:\n", res.kind, res.name)
+			for _, cause := range errorCauses(res.err) {
+				_, _ = fmt.Fprintf(out, "  - %s\n", cause)
+			}
+			continue
+		}
+
+		_, _ = fmt.Fprintf(out, "%s/%s is valid\n", res.kind, res.name)
+		for _, warning := range res.warnings {
+			_, _ = fmt.Fprintf(out, "  warning: %s\n", warning)
+		}
+	}
+
+	if invalid {
+		return errInvalidResources
+	}
+	return nil
+}
+
+func validateFile(in io.Reader, fileName string) ([]result, error) {
+	if fileName == "-" {
+		return validateStream(in)
+	}
+
+	file, err := os.Open(fileName) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	results, err := validateStream(file)
+	if err != nil {
+		return nil, fmt.Errorf("while reading %s: %w", fileName, err)
+	}
+	return results, nil
+}
+
+// validateStream validates every resource of the operator found in a
+// stream of YAML or JSON documents, ignoring the other resources
+func validateStream(reader io.Reader) ([]result, error) {
+	var results []result
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var document runtime.RawExtension
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, err
+		}
+		if len(bytes.TrimSpace(document.Raw)) == 0 || bytes.Equal(document.Raw, []byte("null")) {
+			continue
+		}
+
+		var object unstructured.Unstructured
+		if err := object.UnmarshalJSON(document.Raw); err != nil {
+			return nil, err
+		}
+		if object.GroupVersionKind().Group != apiv1.GroupVersion.Group {
+			continue
+		}
+
+		results = append(results, validateObject(&object))
+	}
+}
+
+// validateObject decodes the resource rejecting the unknown fields, then
+// applies the same defaulting and validation of the admission webhooks
+func validateObject(object *unstructured.Unstructured) result {
+	res := result{kind: object.GetKind(), name: object.GetName()}
+
+	typed, err := scheme.BuildWithAllKnownScheme().New(object.GroupVersionKind())
+	if err != nil {
+		res.err = err
+		return res
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(
+		object.Object, typed, true); err != nil {
+		res.err = err
+		return res
+	}
+
+	if resource, ok := typed.(defaulter); ok {
+		resource.Default()
+	}
+
+	if resource, ok := typed.(validator); ok {
+		res.warnings, res.err = resource.ValidateCreate()
+	}
+
+	return res
+}
+
+// errorCauses returns the list of the problems reported by an error
+func errorCauses(err error) []string {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil ||
+		len(statusErr.ErrStatus.Details.Causes) == 0 {
+		return []string{err.Error()}
+	}
+
+	causes := make([]string, 0, len(statusErr.ErrStatus.Details.Causes))
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		causes = append(causes, strings.TrimSpace(fmt.Sprintf("%s: %s", cause.Field, cause.Message)))
+	}
+	return causes
+}
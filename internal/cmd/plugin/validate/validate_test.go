@@ -0,0 +1,85 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const validCluster = `
+apiVersion: postgresql.cnpg.io/v1
+kind: Cluster
+metadata:
+  name: cluster-example
+spec:
+  instances: 3
+  storage:
+    size: 1Gi
+`
+
+var _ = Describe("validate", func() {
+	It("accepts valid resources and ignores the ones of other groups", func() {
+		input := validCluster + `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  key: value
+`
+		var out bytes.Buffer
+		Expect(Validate(strings.NewReader(input), &out, []string{"-"})).To(Succeed())
+		Expect(out.String()).To(Equal("Cluster/cluster-example is valid\n"))
+	})
+
+	It("reports the validation errors of the webhooks", func() {
+		input := strings.Replace(validCluster, "instances: 3", "instances: 3\n  imagePullPolicy: Sometimes", 1)
+
+		var out bytes.Buffer
+		err := Validate(strings.NewReader(input), &out, []string{"-"})
+		Expect(err).To(MatchError(errInvalidResources))
+		Expect(out.String()).To(ContainSubstring("Cluster/cluster-example is not valid"))
+		Expect(out.String()).To(ContainSubstring("spec.imagePullPolicy: "))
+	})
+
+	It("rejects the unknown fields", func() {
+		input := strings.Replace(validCluster, "instances: 3", "instance: 3", 1)
+
+		var out bytes.Buffer
+		err := Validate(strings.NewReader(input), &out, []string{"-"})
+		Expect(err).To(MatchError(errInvalidResources))
+		Expect(out.String()).To(ContainSubstring(`unknown field "spec.instance"`))
+	})
+
+	It("reads the resources from files", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "cluster.yaml")
+		Expect(os.WriteFile(fileName, []byte(validCluster), 0o600)).To(Succeed())
+
+		var out bytes.Buffer
+		Expect(Validate(nil, &out, []string{fileName})).To(Succeed())
+		Expect(out.String()).To(Equal("Cluster/cluster-example is valid\n"))
+
+		err := Validate(nil, &out, []string{filepath.Join(GinkgoT().TempDir(), "missing.yaml")})
+		Expect(err).To(HaveOccurred())
+	})
+})
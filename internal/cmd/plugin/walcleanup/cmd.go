@@ -0,0 +1,67 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walcleanup
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "wal-cleanup" command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wal-cleanup CLUSTER",
+		Short: "Remove the WAL files that have been archived and are not needed anymore",
+		Long: `Free some space in the WAL volume of an instance, by default the primary one, by removing
+the WAL files that have been archived and that are older than the latest checkpoint, than the
+WAL files retained by the replication slots and than the ones kept by wal_keep_size. PostgreSQL
+needs to be running. This is meant to be used in emergencies, such as when the WAL volume of
+the instance is about to be full.`,
+		GroupID: plugin.GroupIDTroubleshooting,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName := args[0]
+			instanceName, _ := cmd.Flags().GetString("instance")
+			if _, err := strconv.Atoi(instanceName); err == nil {
+				instanceName = fmt.Sprintf("%s-%s", clusterName, instanceName)
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			return Cleanup(cmd.Context(), clusterName, instanceName, dryRun)
+		},
+	}
+
+	cmd.Flags().String(
+		"instance",
+		"",
+		"The instance whose WAL files are removed. Defaults to the current primary",
+	)
+	cmd.Flags().Bool(
+		"dry-run",
+		false,
+		"List the WAL files that would be removed, without removing them",
+	)
+
+	return cmd
+}
@@ -0,0 +1,228 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package walcleanup implements the kubectl-cnpg wal-cleanup command
+package walcleanup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// walSegmentRegex matches the name of the WAL segments, excluding
+// the history, backup and partial files
+var walSegmentRegex = regexp.MustCompile(`^[0-9A-F]{24}$`)
+
+// retentionQuery gets the oldest restart_lsn of the replication slots,
+// empty when no slot is retaining WAL files, and wal_keep_size in bytes
+const retentionQuery = "SELECT coalesce(min(restart_lsn)::text, ''), " +
+	"pg_catalog.pg_size_bytes(pg_catalog.current_setting('wal_keep_size')) " +
+	"FROM pg_catalog.pg_replication_slots WHERE restart_lsn IS NOT NULL"
+
+// removeBatchSize is the number of WAL files removed by every command
+// executed in the pod, keeping its arguments within the system limits
+const removeBatchSize = 500
+
+// Cleanup removes the WAL files of an instance that have been archived
+// and are not needed anymore
+func Cleanup(ctx context.Context, clusterName, instanceName string, dryRun bool) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("cluster %s not found in namespace %s: %w", clusterName, plugin.Namespace, err)
+	}
+
+	if instanceName == "" {
+		instanceName = cluster.Status.CurrentPrimary
+	}
+
+	var pod corev1.Pod
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: instanceName},
+		&pod,
+	); err != nil {
+		return fmt.Errorf("instance %s not found in namespace %s: %w", instanceName, plugin.Namespace, err)
+	}
+
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+
+	controlData, err := plugin.GetPGControlData(ctx, pod)
+	if err != nil {
+		return fmt.Errorf("while reading the control data: %w", err)
+	}
+	controlDataMap := utils.ParsePgControldataOutput(controlData)
+	redoLocation, err := types.LSN(controlDataMap[utils.PgControlDataKeyLatestCheckpointREDOLocation]).Parse()
+	if err != nil {
+		return fmt.Errorf("cannot find the REDO location of the latest checkpoint: %w", err)
+	}
+	walSegmentSize, err := strconv.ParseInt(controlDataMap[utils.PgControlDataKeyBytesPerWALSegment], 10, 64)
+	if err != nil || walSegmentSize <= 0 {
+		return fmt.Errorf("cannot find the WAL segment size: %q",
+			controlDataMap[utils.PgControlDataKeyBytesPerWALSegment])
+	}
+
+	// The replication slots and wal_keep_size can be reliably read only
+	// from a running PostgreSQL server
+	retention, err := execInPod(ctx, clientInterface, pod, "psql", "-XAtq", "-d", "postgres", "-c", retentionQuery)
+	if err != nil {
+		return fmt.Errorf("cannot read the replication slots and wal_keep_size, "+
+			"PostgreSQL must be running to remove the WAL files: %w", err)
+	}
+	oldestRequiredWAL, err := getOldestRequiredWALFile(retention, redoLocation, walSegmentSize)
+	if err != nil {
+		return err
+	}
+
+	archiveStatus, err := execInPod(ctx, clientInterface, pod, "ls", "-1", specs.PgWalArchiveStatusPath)
+	if err != nil {
+		return fmt.Errorf("while listing the archived WAL files: %w", err)
+	}
+
+	removableWALs := getRemovableWALFiles(strings.Split(archiveStatus, "\n"), oldestRequiredWAL)
+	if len(removableWALs) == 0 {
+		fmt.Printf("No WAL file to be removed from %s, older than %s\n", instanceName, oldestRequiredWAL)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("%d WAL files would be removed from %s, older than %s:\n",
+			len(removableWALs), instanceName, oldestRequiredWAL)
+		for _, walName := range removableWALs {
+			fmt.Println(walName)
+		}
+		return nil
+	}
+
+	removedWALs := 0
+	for batch := range slices.Chunk(removableWALs, removeBatchSize) {
+		removeCommand := make([]string, 0, 2*len(batch)+2)
+		removeCommand = append(removeCommand, "rm", "-f")
+		for _, walName := range batch {
+			removeCommand = append(removeCommand,
+				path.Join(specs.PgWalPath, walName),
+				path.Join(specs.PgWalArchiveStatusPath, walName+".done"))
+		}
+		if _, err := execInPod(ctx, clientInterface, pod, removeCommand...); err != nil {
+			return fmt.Errorf("while removing the WAL files (%d of %d removed): %w",
+				removedWALs, len(removableWALs), err)
+		}
+		removedWALs += len(batch)
+	}
+
+	fmt.Printf("%d WAL files removed from %s, from %s to %s\n",
+		len(removableWALs), instanceName, removableWALs[0], removableWALs[len(removableWALs)-1])
+	return nil
+}
+
+// getRemovableWALFiles gets the WAL segments that have been archived,
+// as reported by the content of the archive status directory, and that
+// are older than the oldest required one, sorted by name
+func getRemovableWALFiles(archiveStatus []string, oldestRequiredWAL string) []string {
+	var result []string
+	for _, fileName := range archiveStatus {
+		walName, found := strings.CutSuffix(strings.TrimSpace(fileName), ".done")
+		if !found || !walSegmentRegex.MatchString(walName) {
+			continue
+		}
+		if isOlderWALSegment(walName, oldestRequiredWAL) {
+			result = append(result, walName)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// getOldestRequiredWALFile gets the name of the oldest WAL segment to be
+// kept, given the output of retentionQuery, the REDO location of the latest
+// checkpoint and the WAL segment size. PostgreSQL keeps the WAL segments
+// needed by the latest checkpoint, by the replication slots and, counting
+// back from the checkpoint, by wal_keep_size.
+// The timeline of the returned name is not significant
+func getOldestRequiredWALFile(retention string, redoLocation, walSegmentSize int64) (string, error) {
+	slotsRestartLSN, walKeepSize, found := strings.Cut(strings.TrimSpace(retention), "|")
+	if !found {
+		return "", fmt.Errorf("unexpected replication slots and wal_keep_size: %q", retention)
+	}
+
+	oldestLSN := redoLocation
+	if slotsRestartLSN != "" {
+		lsn, err := types.LSN(slotsRestartLSN).Parse()
+		if err != nil {
+			return "", fmt.Errorf("while parsing the restart_lsn of the replication slots: %w", err)
+		}
+		oldestLSN = min(oldestLSN, lsn)
+	}
+
+	keepBytes, err := strconv.ParseInt(walKeepSize, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("while parsing wal_keep_size: %w", err)
+	}
+	oldestLSN = min(oldestLSN, max(redoLocation-keepBytes, 0))
+
+	segmentNumber := oldestLSN / walSegmentSize
+	segmentsPerLogID := 0x100000000 / walSegmentSize
+	return fmt.Sprintf("%08X%08X%08X", 1, segmentNumber/segmentsPerLogID, segmentNumber%segmentsPerLogID), nil
+}
+
+// isOlderWALSegment checks whether a WAL segment precedes another one,
+// regardless of their timelines, as done by pg_archivecleanup
+func isOlderWALSegment(walName, otherWALName string) bool {
+	return walName[8:] < otherWALName[8:]
+}
+
+// execInPod executes a command in the PostgreSQL container of the
+// passed pod, returning its trimmed output
+func execInPod(
+	ctx context.Context,
+	clientInterface kubernetes.Interface,
+	pod corev1.Pod,
+	command ...string,
+) (string, error) {
+	timeout := 30 * time.Second
+	stdout, stderr, err := utils.ExecCommand(
+		ctx,
+		clientInterface,
+		plugin.Config,
+		pod,
+		specs.PostgresContainerName,
+		&timeout,
+		command...)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
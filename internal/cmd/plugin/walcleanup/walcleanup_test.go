@@ -0,0 +1,88 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walcleanup
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getRemovableWALFiles", func() {
+	It("selects the archived WAL segments older than the oldest required one", func() {
+		archiveStatus := []string{
+			"000000010000000000000003.done",
+			"000000010000000000000001.done",
+			"000000010000000000000004.ready",
+			"000000020000000000000005.done",
+			"00000002.history.done",
+			"000000010000000000000002.00000028.backup.done",
+			"",
+		}
+		Expect(getRemovableWALFiles(archiveStatus, "000000020000000000000005")).To(Equal([]string{
+			"000000010000000000000001",
+			"000000010000000000000003",
+		}))
+	})
+
+	It("doesn't select anything when every WAL segment is required", func() {
+		archiveStatus := []string{"000000010000000000000003.done"}
+		Expect(getRemovableWALFiles(archiveStatus, "000000010000000000000003")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("getOldestRequiredWALFile", func() {
+	const (
+		walSegmentSize = 16 * 1024 * 1024
+		// 1/A0000060
+		redoLocation = 0x1A0000060
+	)
+
+	It("keeps the WAL segment of the latest checkpoint", func() {
+		Expect(getOldestRequiredWALFile("|0", redoLocation, walSegmentSize)).
+			To(Equal("0000000100000001000000A0"))
+	})
+
+	It("takes the WAL segment size into account", func() {
+		Expect(getOldestRequiredWALFile("|0", redoLocation, 4*walSegmentSize)).
+			To(Equal("000000010000000100000028"))
+	})
+
+	It("keeps the WAL segments retained by the replication slots", func() {
+		Expect(getOldestRequiredWALFile("0/3000028|0", redoLocation, walSegmentSize)).
+			To(Equal("000000010000000000000003"))
+	})
+
+	It("keeps the WAL segments retained by wal_keep_size", func() {
+		// 1GB, or 64 WAL segments, before the latest checkpoint
+		Expect(getOldestRequiredWALFile("1/A0000000|1073741824", redoLocation, walSegmentSize)).
+			To(Equal("000000010000000100000060"))
+	})
+
+	It("keeps every WAL segment when wal_keep_size exceeds the written WAL", func() {
+		Expect(getOldestRequiredWALFile("|10737418240", redoLocation, walSegmentSize)).
+			To(Equal("000000010000000000000000"))
+	})
+
+	It("fails when the output of the query cannot be parsed", func() {
+		_, err := getOldestRequiredWALFile("not-a-number", redoLocation, walSegmentSize)
+		Expect(err).To(HaveOccurred())
+		_, err = getOldestRequiredWALFile("not-an-lsn|0", redoLocation, walSegmentSize)
+		Expect(err).To(HaveOccurred())
+		_, err = getOldestRequiredWALFile("|not-a-number", redoLocation, walSegmentSize)
+		Expect(err).To(HaveOccurred())
+	})
+})
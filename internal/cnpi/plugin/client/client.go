@@ -18,9 +18,9 @@ package client
 
 import (
 	"context"
+	"slices"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
-	"github.com/cloudnative-pg/machinery/pkg/stringset"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/connection"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/repository"
@@ -87,11 +87,15 @@ func WithPlugins(ctx context.Context, repository repository.Interface, names ...
 
 	// The following ensures that each plugin is loaded just one
 	// time, even when the same plugin has been requested multiple
-	// times.
-	loadingPlugins := stringset.From(names)
-	uniqueSortedPluginName := loadingPlugins.ToSortedList()
+	// times. The plugins are invoked in the requested order.
+	uniquePluginNames := make([]string, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(uniquePluginNames, name) {
+			uniquePluginNames = append(uniquePluginNames, name)
+		}
+	}
 
-	if err := load(uniqueSortedPluginName...); err != nil {
+	if err := load(uniquePluginNames...); err != nil {
 		result.Close(ctx)
 		return nil, err
 	}
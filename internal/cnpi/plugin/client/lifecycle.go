@@ -18,9 +18,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"slices"
+	"strings"
 
 	"github.com/cloudnative-pg/cnpg-i/pkg/lifecycle"
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -37,6 +39,16 @@ import (
 
 var runtimeScheme = runtime.NewScheme()
 
+// ErrConflictingMutations is raised when more than one plugin
+// changes the same part of an object created by the operator
+var ErrConflictingMutations = errors.New("conflicting plugin mutations")
+
+// pluginChange is a path of an object changed by a plugin
+type pluginChange struct {
+	pluginName string
+	path       string
+}
+
 func init() {
 	_ = scheme.AddToScheme(runtimeScheme)
 }
@@ -107,6 +119,7 @@ func (data *data) LifecycleHook(
 
 	serializedObjectOrig := make([]byte, len(serializedObject))
 	copy(serializedObjectOrig, serializedObject)
+	var changes []pluginChange
 	for _, plg := range invokablePlugin {
 		req := &lifecycle.OperatorLifecycleRequest{
 			OperationType: &lifecycle.OperatorOperationType{
@@ -132,6 +145,15 @@ func (data *data) LifecycleHook(
 			return nil, err
 		}
 
+		if changes, err = recordPluginChanges(changes, plg.Name(), patch); err != nil {
+			contextLogger.Error(err, "Error while checking the JSON patch from plugin", "patch", result.JsonPatch)
+			return nil, fmt.Errorf("while mutating %s %s/%s: %w",
+				object.GetObjectKind().GroupVersionKind().Kind,
+				object.GetNamespace(), object.GetName(),
+				err,
+			)
+		}
+
 		responseObj, err := patch.Apply(serializedObject)
 		if err != nil {
 			contextLogger.Error(err, "Error while applying JSON patch from plugin", "patch", result.JsonPatch)
@@ -157,3 +179,54 @@ func (data *data) LifecycleHook(
 
 	return mutatedObject.(client.Object), nil
 }
+
+// recordPluginChanges appends to the passed changes the paths changed by
+// the JSON patch of a plugin, failing if any of them overlaps with a path
+// changed by another plugin
+func recordPluginChanges(changes []pluginChange, pluginName string, patch jsonpatch.Patch) ([]pluginChange, error) {
+	var paths []string
+	for _, operation := range patch {
+		if operation.Kind() == "test" {
+			continue
+		}
+
+		path, err := operation.Path()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+
+		// Moving an element also removes it from its original path
+		if operation.Kind() == "move" {
+			from, err := operation.From()
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, from)
+		}
+	}
+
+	for _, path := range paths {
+		for _, change := range changes {
+			if change.pluginName != pluginName && pathsOverlap(path, change.path) {
+				return nil, fmt.Errorf("%w: plugins %q and %q both change %q",
+					ErrConflictingMutations, change.pluginName, pluginName, path)
+			}
+		}
+	}
+
+	for _, path := range paths {
+		changes = append(changes, pluginChange{pluginName: pluginName, path: path})
+	}
+	return changes, nil
+}
+
+// pathsOverlap checks if two JSON pointers refer to the same part of an
+// object, or one of them is contained in the other. Appending elements
+// to the same array doesn't make two paths overlap
+func pathsOverlap(path, other string) bool {
+	if path == other {
+		return !strings.HasSuffix(path, "/-")
+	}
+	return strings.HasPrefix(path, other+"/") || strings.HasPrefix(other, path+"/")
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/cloudnative-pg/cnpg-i/pkg/lifecycle"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"google.golang.org/grpc"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -217,6 +218,67 @@ var _ = Describe("LifecycleHook", func() {
 		Expect(ok).To(BeTrue())
 		Expect(podModified.Labels).To(Equal(map[string]string{"other": "stuff"}))
 	})
+
+	It("rejects the mutations of different plugins changing the same path", func(ctx SpecContext) {
+		d.plugins = append(d.plugins, &fakeConnection{name: "other"})
+		newFakeLifecycleClient(capabilities, map[string]string{"test": "test"}, nil, nil).
+			set(d.plugins[0].(*fakeConnection))
+		newFakeLifecycleClient(capabilities, map[string]string{"other": "other"}, nil, nil).
+			set(d.plugins[1].(*fakeConnection))
+
+		pod := &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Pod",
+			},
+		}
+		_, err := d.LifecycleHook(ctx, plugin.OperationVerbCreate, clusterObj, pod)
+		Expect(err).To(MatchError(ErrConflictingMutations))
+		Expect(err.Error()).To(ContainSubstring(`plugins "test" and "other" both change "/metadata/labels"`))
+	})
+})
+
+var _ = Describe("plugin changes", func() {
+	decodePatch := func(patch string) jsonpatch.Patch {
+		result, err := jsonpatch.DecodePatch([]byte(patch))
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	It("detects the overlapping paths", func() {
+		Expect(pathsOverlap("/spec/containers/0", "/spec/containers/0")).To(BeTrue())
+		Expect(pathsOverlap("/spec/containers", "/spec/containers/0/env/-")).To(BeTrue())
+		Expect(pathsOverlap("/spec/containers/0/env/-", "/spec/containers")).To(BeTrue())
+		Expect(pathsOverlap("/spec/containers/-", "/spec/containers/-")).To(BeFalse())
+		Expect(pathsOverlap("/spec/volumes/-", "/spec/containers/-")).To(BeFalse())
+		Expect(pathsOverlap("/metadata/labels/app", "/metadata/labels/application")).To(BeFalse())
+	})
+
+	It("allows different plugins to append to the same array", func() {
+		sidecar := `[{"op": "add", "path": "/spec/containers/-", "value": {"name": "sidecar"}}]`
+		changes, err := recordPluginChanges(nil, "mesh", decodePatch(sidecar))
+		Expect(err).ToNot(HaveOccurred())
+		changes, err = recordPluginChanges(changes, "logs", decodePatch(sidecar))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+	})
+
+	It("allows a plugin to change its own paths more than once", func() {
+		changes, err := recordPluginChanges(nil, "mesh", decodePatch(
+			`[{"op": "add", "path": "/metadata/annotations", "value": {}},
+			  {"op": "add", "path": "/metadata/annotations/mesh", "value": "true"}]`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+	})
+
+	It("considers the source of the moved elements as changed", func() {
+		changes, err := recordPluginChanges(nil, "mesh", decodePatch(
+			`[{"op": "move", "from": "/spec/containers/0/env/0", "path": "/spec/containers/0/env/1"}]`))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = recordPluginChanges(changes, "agent", decodePatch(
+			`[{"op": "replace", "path": "/spec/containers/0/env/0/value", "value": "agent"}]`))
+		Expect(err).To(MatchError(ErrConflictingMutations))
+	})
 })
 
 func createJSONPatchForLabels(originalInstance, instance *corev1.Pod) ([]byte, error) {
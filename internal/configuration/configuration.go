@@ -19,11 +19,15 @@ limitations under the License.
 package configuration
 
 import (
+	"fmt"
+	"hash/fnv"
 	"path"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/configparser"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
@@ -40,11 +44,36 @@ const (
 
 	// ExpiringCheckThreshold is the default threshold to consider a certificate as expiring
 	ExpiringCheckThreshold = 7
+
+	// DefaultClusterReconcileMinBackoff is the default delay before retrying
+	// the first failed reconciliation of a cluster
+	DefaultClusterReconcileMinBackoff = 5 * time.Millisecond
+
+	// DefaultClusterReconcileMaxBackoff is the default maximum delay before
+	// retrying the failed reconciliations of a cluster
+	DefaultClusterReconcileMaxBackoff = 1000 * time.Second
+
+	// DefaultClusterReconcileQPS is the default number of retries of failed
+	// reconciliations per second, shared by all the clusters
+	DefaultClusterReconcileQPS = 10
+
+	// DefaultClusterReconcileBurst is the default number of retries of failed
+	// reconciliations that can exceed the DefaultClusterReconcileQPS limit
+	DefaultClusterReconcileBurst = 100
 )
 
 // DefaultPluginSocketDir is the default directory where the plugin sockets are located.
 const DefaultPluginSocketDir = "/plugins"
 
+// DefaultDrainTaints is the default list of taints marking a node
+// that is about to be drained, as set by the cluster-autoscaler and
+// by Karpenter before deprovisioning a node
+var DefaultDrainTaints = []string{
+	"ToBeDeletedByClusterAutoscaler",
+	"karpenter.sh/disrupted",
+	"karpenter.sh/disruption",
+}
+
 // Data is the struct containing the configuration of the operator.
 // Usually the operator code will use the "Current" configuration.
 type Data struct {
@@ -61,6 +90,16 @@ type Data struct {
 	// Multiple namespaces can be specified separated by comma
 	WatchNamespace string `json:"watchNamespace" env:"WATCH_NAMESPACE"`
 
+	// WatchLabelSelector restricts the Clusters, Poolers, Backups and
+	// ScheduledBackups watched by the operator to the ones matching
+	// this label selector
+	WatchLabelSelector string `json:"watchLabelSelector" env:"WATCH_LABEL_SELECTOR"`
+
+	// CacheOperatorPodsOnly restricts the pods kept in the cache of the
+	// operator to the ones it created, which are labeled with the name of
+	// their cluster
+	CacheOperatorPodsOnly bool `json:"cacheOperatorPodsOnly" env:"CACHE_OPERATOR_PODS_ONLY"`
+
 	// OperatorNamespace is the namespace where the operator is installed
 	OperatorNamespace string `json:"operatorNamespace" env:"OPERATOR_NAMESPACE"`
 
@@ -125,6 +164,48 @@ type Data struct {
 	// IncludePlugins is a comma-separated list of plugins to always be
 	// included in the Cluster reconciliation
 	IncludePlugins string `json:"includePlugins" env:"INCLUDE_PLUGINS"`
+
+	// DrainTaints is the list of taint keys marking a node as being
+	// drained. The operator will switch over a primary running on
+	// such a node, exactly as it does for a cordoned one
+	DrainTaints []string `json:"drainTaints" env:"DRAIN_TAINTS"`
+
+	// DisableWebhooks runs the operator without the admission webhooks,
+	// for the Kubernetes clusters whose API server can't reach them. The
+	// clusters are defaulted and validated by the operator when reconciled
+	DisableWebhooks bool `json:"disableWebhooks" env:"DISABLE_WEBHOOKS"`
+
+	// EnableServerSideApply makes the operator create and update the
+	// PodDisruptionBudgets and the Services of the clusters with
+	// server-side apply, sharing their fields with other field managers
+	EnableServerSideApply bool `json:"enableServerSideApply" env:"ENABLE_SERVER_SIDE_APPLY"`
+
+	// ShardCount is the number of shards the watched namespaces are split
+	// into, each one reconciled by a different operator deployment.
+	// Sharding is disabled when lower than 2
+	ShardCount int `json:"shardCount" env:"SHARD_COUNT"`
+
+	// ShardIndex is the shard reconciled by this operator, between 0
+	// and ShardCount-1
+	ShardIndex int `json:"shardIndex" env:"SHARD_INDEX"`
+
+	// ClusterReconcileMinBackoff is the delay (in seconds) before retrying
+	// the first failed reconciliation of a cluster, doubled at every
+	// consecutive failure. It can be overridden per cluster
+	ClusterReconcileMinBackoff int `json:"clusterReconcileMinBackoff" env:"CLUSTER_RECONCILE_MIN_BACKOFF"`
+
+	// ClusterReconcileMaxBackoff is the maximum delay (in seconds) before
+	// retrying the failed reconciliations of a cluster. It can be
+	// overridden per cluster
+	ClusterReconcileMaxBackoff int `json:"clusterReconcileMaxBackoff" env:"CLUSTER_RECONCILE_MAX_BACKOFF"`
+
+	// ClusterReconcileQPS is the number of retries of failed
+	// reconciliations per second, shared by all the clusters
+	ClusterReconcileQPS int `json:"clusterReconcileQPS" env:"CLUSTER_RECONCILE_QPS"`
+
+	// ClusterReconcileBurst is the number of retries of failed
+	// reconciliations that can exceed the ClusterReconcileQPS limit
+	ClusterReconcileBurst int `json:"clusterReconcileBurst" env:"CLUSTER_RECONCILE_BURST"`
 }
 
 // Current is the configuration used by the operator
@@ -140,6 +221,7 @@ func newDefaultConfig() *Data {
 		CreateAnyService:       false,
 		CertificateDuration:    CertificateDuration,
 		ExpiringCheckThreshold: ExpiringCheckThreshold,
+		DrainTaints:            DefaultDrainTaints,
 	}
 }
 
@@ -168,6 +250,12 @@ func (config *Data) IsLabelInherited(name string) bool {
 	return evaluateGlobPatterns(config.InheritedLabels, name)
 }
 
+// IsDrainTaint checks if a taint with a certain key marks
+// the node as being drained
+func (config *Data) IsDrainTaint(key string) bool {
+	return slices.Contains(config.DrainTaints, key)
+}
+
 // GetClustersRolloutDelay gets the delay between roll-outs of different clusters
 func (config *Data) GetClustersRolloutDelay() time.Duration {
 	return time.Duration(config.ClustersRolloutDelay) * time.Second
@@ -179,6 +267,42 @@ func (config *Data) GetInstancesRolloutDelay() time.Duration {
 	return time.Duration(config.InstancesRolloutDelay) * time.Second
 }
 
+// GetClusterReconcileMinBackoff gets the delay before retrying the first
+// failed reconciliation of a cluster
+func (config *Data) GetClusterReconcileMinBackoff() time.Duration {
+	if config.ClusterReconcileMinBackoff <= 0 {
+		return DefaultClusterReconcileMinBackoff
+	}
+	return time.Duration(config.ClusterReconcileMinBackoff) * time.Second
+}
+
+// GetClusterReconcileMaxBackoff gets the maximum delay before retrying the
+// failed reconciliations of a cluster
+func (config *Data) GetClusterReconcileMaxBackoff() time.Duration {
+	if config.ClusterReconcileMaxBackoff <= 0 {
+		return DefaultClusterReconcileMaxBackoff
+	}
+	return time.Duration(config.ClusterReconcileMaxBackoff) * time.Second
+}
+
+// GetClusterReconcileQPS gets the number of retries of failed
+// reconciliations per second, shared by all the clusters
+func (config *Data) GetClusterReconcileQPS() int {
+	if config.ClusterReconcileQPS <= 0 {
+		return DefaultClusterReconcileQPS
+	}
+	return config.ClusterReconcileQPS
+}
+
+// GetClusterReconcileBurst gets the number of retries of failed
+// reconciliations that can exceed the QPS limit
+func (config *Data) GetClusterReconcileBurst() int {
+	if config.ClusterReconcileBurst <= 0 {
+		return DefaultClusterReconcileBurst
+	}
+	return config.ClusterReconcileBurst
+}
+
 // WatchedNamespaces get the list of additional watched namespaces.
 // The result is a list of namespaces specified in the WATCHED_NAMESPACE where
 // each namespace is separated by comma
@@ -186,6 +310,65 @@ func (config *Data) WatchedNamespaces() []string {
 	return cleanNamespaceList(config.WatchNamespace)
 }
 
+// IsShardingEnabled checks if the reconciliation of the watched namespaces
+// is split between multiple operator deployments
+func (config *Data) IsShardingEnabled() bool {
+	return config.ShardCount > 1
+}
+
+// ValidateSharding checks that the shard of this operator is one
+// of the configured ones
+func (config *Data) ValidateSharding() error {
+	if !config.IsShardingEnabled() {
+		return nil
+	}
+
+	if config.ShardIndex < 0 || config.ShardIndex >= config.ShardCount {
+		return fmt.Errorf("invalid shard index %d, expected a value between 0 and %d",
+			config.ShardIndex, config.ShardCount-1)
+	}
+
+	return nil
+}
+
+// IsNamespaceInShard checks if the objects of a namespace are reconciled
+// by this operator. The cluster-wide objects are reconciled by the
+// first shard
+func (config *Data) IsNamespaceInShard(namespace string) bool {
+	if !config.IsShardingEnabled() {
+		return true
+	}
+
+	if namespace == "" {
+		return config.ShardIndex == 0
+	}
+
+	return GetNamespaceShard(namespace, config.ShardCount) == config.ShardIndex
+}
+
+// GetNamespaceShard gets the shard reconciling the objects of a namespace,
+// given the number of shards
+func GetNamespaceShard(namespace string, shardCount int) int {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(namespace))
+	return int(hasher.Sum32() % uint32(shardCount)) //nolint:gosec
+}
+
+// GetWatchLabelSelector gets the label selector restricting the resources
+// watched by the operator, or nil when every resource is watched
+func (config *Data) GetWatchLabelSelector() (labels.Selector, error) {
+	if strings.TrimSpace(config.WatchLabelSelector) == "" {
+		return nil, nil
+	}
+
+	selector, err := labels.Parse(config.WatchLabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid watch label selector %q: %w", config.WatchLabelSelector, err)
+	}
+
+	return selector, nil
+}
+
 // GetIncludePlugins gets the list of plugins to be always
 // included in the operator reconciliation
 func (config *Data) GetIncludePlugins() []string {
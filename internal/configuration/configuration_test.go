@@ -19,6 +19,8 @@ package configuration
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -167,4 +169,99 @@ var _ = Describe("Annotation and label inheritance", func() {
 		config := Data{}
 		Expect(config.GetInstancesRolloutDelay()).To(BeZero())
 	})
+
+	It("uses the default cluster reconciliation backoff when not set", func() {
+		config := Data{}
+		Expect(config.GetClusterReconcileMinBackoff()).To(Equal(DefaultClusterReconcileMinBackoff))
+		Expect(config.GetClusterReconcileMaxBackoff()).To(Equal(DefaultClusterReconcileMaxBackoff))
+		Expect(config.GetClusterReconcileQPS()).To(Equal(DefaultClusterReconcileQPS))
+		Expect(config.GetClusterReconcileBurst()).To(Equal(DefaultClusterReconcileBurst))
+	})
+
+	It("returns the configured cluster reconciliation backoff", func() {
+		config := Data{
+			ClusterReconcileMinBackoff: 2,
+			ClusterReconcileMaxBackoff: 300,
+			ClusterReconcileQPS:        5,
+			ClusterReconcileBurst:      20,
+		}
+		Expect(config.GetClusterReconcileMinBackoff()).To(Equal(2 * time.Second))
+		Expect(config.GetClusterReconcileMaxBackoff()).To(Equal(300 * time.Second))
+		Expect(config.GetClusterReconcileQPS()).To(Equal(5))
+		Expect(config.GetClusterReconcileBurst()).To(Equal(20))
+	})
+
+	It("recognizes the cluster-autoscaler and Karpenter taints by default", func() {
+		config := newDefaultConfig()
+		Expect(config.IsDrainTaint("ToBeDeletedByClusterAutoscaler")).To(BeTrue())
+		Expect(config.IsDrainTaint("karpenter.sh/disrupted")).To(BeTrue())
+		Expect(config.IsDrainTaint("node.kubernetes.io/unreachable")).To(BeFalse())
+	})
+
+	It("uses the configured list of drain taints", func() {
+		config := Data{DrainTaints: []string{"example.com/draining"}}
+		Expect(config.IsDrainTaint("example.com/draining")).To(BeTrue())
+		Expect(config.IsDrainTaint("ToBeDeletedByClusterAutoscaler")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Sharding", func() {
+	It("reconciles every namespace when sharding is disabled", func() {
+		config := Data{}
+		Expect(config.IsShardingEnabled()).To(BeFalse())
+		Expect(config.ValidateSharding()).To(Succeed())
+		Expect(config.IsNamespaceInShard("default")).To(BeTrue())
+		Expect(config.IsNamespaceInShard("")).To(BeTrue())
+	})
+
+	It("rejects a shard index out of range", func() {
+		Expect((&Data{ShardCount: 3, ShardIndex: 3}).ValidateSharding()).ToNot(Succeed())
+		Expect((&Data{ShardCount: 3, ShardIndex: -1}).ValidateSharding()).ToNot(Succeed())
+		Expect((&Data{ShardCount: 3, ShardIndex: 2}).ValidateSharding()).To(Succeed())
+	})
+
+	It("assigns every namespace to exactly one shard", func() {
+		shards := []Data{
+			{ShardCount: 3, ShardIndex: 0},
+			{ShardCount: 3, ShardIndex: 1},
+			{ShardCount: 3, ShardIndex: 2},
+		}
+
+		for _, namespace := range []string{"default", "team-a", "team-b", "production", "staging"} {
+			owners := 0
+			for idx := range shards {
+				if shards[idx].IsNamespaceInShard(namespace) {
+					owners++
+					Expect(GetNamespaceShard(namespace, 3)).To(Equal(shards[idx].ShardIndex))
+				}
+			}
+			Expect(owners).To(Equal(1), namespace)
+		}
+	})
+
+	It("reconciles the cluster-wide objects in the first shard", func() {
+		Expect((&Data{ShardCount: 3, ShardIndex: 0}).IsNamespaceInShard("")).To(BeTrue())
+		Expect((&Data{ShardCount: 3, ShardIndex: 1}).IsNamespaceInShard("")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Watch label selector", func() {
+	It("watches every resource by default", func() {
+		selector, err := (&Data{}).GetWatchLabelSelector()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selector).To(BeNil())
+	})
+
+	It("parses the label selector", func() {
+		selector, err := (&Data{WatchLabelSelector: "team in (payments, billing),cnpg.io/managed"}).
+			GetWatchLabelSelector()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selector.Matches(labels.Set{"team": "billing", "cnpg.io/managed": "true"})).To(BeTrue())
+		Expect(selector.Matches(labels.Set{"team": "billing"})).To(BeFalse())
+	})
+
+	It("rejects an invalid label selector", func() {
+		_, err := (&Data{WatchLabelSelector: "team in payments"}).GetWatchLabelSelector()
+		Expect(err).To(HaveOccurred())
+	})
 })
@@ -21,13 +21,18 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"go.opentelemetry.io/otel/attribute"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
@@ -47,10 +52,12 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/backup/volumesnapshot"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
 	resourcestatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -72,6 +79,10 @@ type BackupReconciler struct {
 	Plugins  repository.Interface
 
 	instanceStatusClient remote.InstanceClient
+
+	// runningBackups contains the backups observed while running,
+	// whose completion is to be notified as a lifecycle event
+	runningBackups sync.Map
 }
 
 // NewBackupReconciler properly initializes the BackupReconciler
@@ -93,30 +104,68 @@ func NewBackupReconciler(
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get
-// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;watch;list;patch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;watch;list;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=get;list;delete;patch;create;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 // Reconcile is the main reconciliation loop
 // nolint: gocognit
-func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := telemetry.Start(ctx, "backup.reconcile",
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("cnpg.backup.name", req.Name))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	contextLogger, ctx := log.SetupLogger(ctx)
 	contextLogger.Debug(fmt.Sprintf("reconciling object %#q", req.NamespacedName))
 
 	var backup apiv1.Backup
 	if err := r.Get(ctx, req.NamespacedName, &backup); err != nil {
 		if apierrs.IsNotFound(err) {
+			r.runningBackups.Delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	span.SetAttributes(
+		attribute.String("cnpg.cluster.name", backup.Spec.Cluster.Name),
+		attribute.String("cnpg.backup.method", string(backup.Spec.Method)),
+		attribute.String("cnpg.backup.phase", string(backup.Status.Phase)))
+
 	switch backup.Status.Phase {
-	case apiv1.BackupPhaseFailed, apiv1.BackupPhaseCompleted:
+	case apiv1.BackupPhaseFailed:
+		r.runningBackups.Delete(req.NamespacedName)
 		return ctrl.Result{}, nil
+	case apiv1.BackupPhaseCompleted:
+		if _, running := r.runningBackups.LoadAndDelete(req.NamespacedName); running {
+			r.notifyBackupCompleted(ctx, &backup)
+		}
+		switch backup.Spec.Method {
+		case apiv1.BackupMethodVolumeSnapshot:
+			return r.reconcileVolumeSnapshotRetention(ctx, &backup)
+		case apiv1.BackupMethodPlugin:
+			return r.reconcilePluginBackupRetention(ctx, &backup)
+		}
+		return r.reconcileBackupVerification(ctx, &backup)
 	}
 
+	// Without the admission webhooks, the backup is validated here
+	if backup.Status.Phase == "" {
+		if err := validateWithoutWebhooks(&backup); err != nil {
+			tryFlagBackupAsFailed(ctx, r.Client, &backup, err)
+			r.Recorder.Event(&backup, "Warning", "InvalidSpec", err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+
+	r.runningBackups.Store(req.NamespacedName, struct{}{})
+
 	clusterName := backup.Spec.Cluster.Name
 	var cluster apiv1.Cluster
 	if err := r.Get(ctx, client.ObjectKey{
@@ -135,6 +184,14 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 
+	// The backups already in progress are completed, but no new backup
+	// is started while the cluster is paused
+	if cluster.Spec.Paused && (backup.Status.Phase == "" || backup.Status.Phase == apiv1.BackupPhasePending) {
+		contextLogger.Info("The cluster is paused, waiting for it to be resumed before starting the backup",
+			"cluster", clusterName)
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
 	// Load the required plugins
 	pluginClient, err := cnpgiClient.WithPlugins(
 		ctx,
@@ -198,7 +255,30 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			"Starting backup for cluster %v", cluster.Name)
 	}
 
+	if backup.Spec.Method == apiv1.BackupMethodPgBackRest {
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.PgBackRest == nil {
+			tryFlagBackupAsFailed(ctx, r.Client, &backup,
+				errors.New("no pgBackRest section defined on the target cluster"))
+			return ctrl.Result{}, nil
+		}
+
+		if isRunning {
+			return ctrl.Result{}, nil
+		}
+
+		r.Recorder.Eventf(&backup, "Normal", "Starting",
+			"Starting backup for cluster %v", cluster.Name)
+	}
+
 	if backup.Spec.Method == apiv1.BackupMethodPlugin {
+		if backup.Spec.PluginConfiguration.IsEmpty() ||
+			!slices.Contains(apiv1.GetPluginConfigurationEnabledPluginNames(cluster.Spec.Plugins),
+				backup.Spec.PluginConfiguration.Name) {
+			tryFlagBackupAsFailed(ctx, r.Client, &backup,
+				errors.New("the backup plugin is not enabled on the target cluster"))
+			return ctrl.Result{}, nil
+		}
+
 		if isRunning {
 			return ctrl.Result{}, nil
 		}
@@ -209,11 +289,11 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	origBackup := backup.DeepCopy()
 
-	// From now on, we differentiate backups managed by the instance manager (barman and plugins)
-	// from the ones managed directly by the operator (VolumeSnapshot)
+	// From now on, we differentiate backups managed by the instance manager (barman,
+	// pgBackRest and plugins) from the ones managed directly by the operator (VolumeSnapshot)
 
 	switch backup.Spec.Method {
-	case apiv1.BackupMethodBarmanObjectStore, apiv1.BackupMethodPlugin:
+	case apiv1.BackupMethodBarmanObjectStore, apiv1.BackupMethodPgBackRest, apiv1.BackupMethodPlugin:
 		// If no good running backups are found we elect a pod for the backup
 		pod, err := r.getBackupTargetPod(ctx, &cluster, &backup)
 		if apierrs.IsNotFound(err) {
@@ -318,6 +398,9 @@ func (r *BackupReconciler) isValidBackupRunning(
 	case apiv1.BackupTargetStandby, "":
 		// we don't really care for this type
 		isCorrectPodElected = true
+	case apiv1.BackupTargetStandbyOnly:
+		isCorrectPodElected = backup.Status.InstanceID.PodName != cluster.Status.TargetPrimary &&
+			backup.Status.InstanceID.PodName != cluster.Status.CurrentPrimary
 	default:
 		return false, fmt.Errorf("unknown.spec.target received: %s", backup.Spec.Target)
 	}
@@ -416,6 +499,11 @@ func (r *BackupReconciler) reconcileSnapshotBackup(
 		backup.Status.BackupID = backup.Name
 		backup.Status.BackupName = backup.Name
 		backup.Status.StartedAt = ptr.To(metav1.Now())
+		backup.Status.Level = apiv1.BackupLevelFull
+		if backup.Spec.Level == apiv1.BackupLevelIncremental &&
+			cluster.Spec.Backup.VolumeSnapshot.Incremental != nil {
+			backup.Status.Level = apiv1.BackupLevelIncremental
+		}
 		if err := postgres.PatchBackupStatusAndRetry(ctx, r.Client, backup); err != nil {
 			return nil, err
 		}
@@ -576,27 +664,38 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context,
 	if backup.Spec.Target != "" {
 		backupTarget = backup.Spec.Target
 	}
+	if backup.Spec.Method == apiv1.BackupMethodPgBackRest {
+		// pgBackRest is configured to take backups from the primary instance
+		backupTarget = apiv1.BackupTargetPrimary
+	}
 	postgresqlStatusList := r.instanceStatusClient.GetStatusFromInstances(ctx, pods)
-	for _, item := range postgresqlStatusList.Items {
-		if !item.IsPodReady {
-			contextLogger.Debug("Instance not ready, discarded as target for backup",
-				"pod", item.Pod.Name)
-			continue
+
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.TargetSelector != nil &&
+		backup.Spec.Method != apiv1.BackupMethodPgBackRest {
+		selector := cluster.Spec.Backup.TargetSelector
+		selectedStatusList, err := r.selectBackupTargetCandidates(ctx, selector, postgresqlStatusList)
+		if err != nil {
+			return nil, err
 		}
-		switch backupTarget {
-		case apiv1.BackupTargetPrimary:
-			if item.IsPrimary {
-				contextLogger.Debug("Primary Instance is elected as backup target",
-					"instance", item.Pod.Name)
-				return item.Pod, nil
-			}
-		case apiv1.BackupTargetStandby, "":
-			if !item.IsPrimary {
-				contextLogger.Debug("Standby Instance is elected as backup target",
-					"instance", item.Pod.Name)
-				return item.Pod, nil
-			}
+		if pod := electBackupTargetPod(ctx, cluster, backupTarget, selectedStatusList); pod != nil {
+			return pod, nil
 		}
+
+		if selector.Fallback == apiv1.BackupTargetSelectorFallbackWait {
+			contextLogger.Debug("No ready instances matching the backup target selector")
+			return nil, apierrs.NewNotFound(corev1.Resource("pod"), "")
+		}
+		contextLogger.Debug("No ready instances matching the backup target selector, " +
+			"falling back to the backup target policy")
+	}
+
+	if pod := electBackupTargetPod(ctx, cluster, backupTarget, postgresqlStatusList); pod != nil {
+		return pod, nil
+	}
+
+	if backupTarget == apiv1.BackupTargetStandbyOnly {
+		contextLogger.Debug("No ready standby instances found as target for backup")
+		return nil, apierrs.NewNotFound(corev1.Resource("pod"), "")
 	}
 
 	contextLogger.Debug("No ready instances found as target for backup, defaulting to primary")
@@ -610,6 +709,134 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context,
 	return &pod, err
 }
 
+// selectBackupTargetCandidates returns the instances matching the backup
+// target selector, by the labels of their pods and of their nodes
+func (r *BackupReconciler) selectBackupTargetCandidates(
+	ctx context.Context,
+	selector *apiv1.BackupTargetSelector,
+	postgresqlStatusList postgresSpec.PostgresqlStatusList,
+) (postgresSpec.PostgresqlStatusList, error) {
+	var result postgresSpec.PostgresqlStatusList
+	for _, item := range postgresqlStatusList.Items {
+		if item.Pod == nil {
+			continue
+		}
+
+		var node *corev1.Node
+		if selector.NodeSelector != nil && item.Pod.Spec.NodeName != "" {
+			node = &corev1.Node{}
+			if err := r.Get(ctx, client.ObjectKey{Name: item.Pod.Spec.NodeName}, node); err != nil {
+				if !apierrs.IsNotFound(err) {
+					return result, err
+				}
+				node = nil
+			}
+		}
+
+		matches, err := matchesBackupTargetSelector(selector, item.Pod, node)
+		if err != nil {
+			return result, err
+		}
+		if matches {
+			result.Items = append(result.Items, item)
+		}
+	}
+
+	return result, nil
+}
+
+// matchesBackupTargetSelector checks if an instance, given its pod and
+// the node it's running on, matches the backup target selector
+func matchesBackupTargetSelector(
+	selector *apiv1.BackupTargetSelector,
+	pod *corev1.Pod,
+	node *corev1.Node,
+) (bool, error) {
+	if selector.InstanceSelector != nil {
+		instanceSelector, err := metav1.LabelSelectorAsSelector(selector.InstanceSelector)
+		if err != nil {
+			return false, fmt.Errorf("while parsing the instance selector: %w", err)
+		}
+		if !instanceSelector.Matches(labels.Set(pod.Labels)) {
+			return false, nil
+		}
+	}
+
+	if selector.NodeSelector != nil {
+		if node == nil {
+			return false, nil
+		}
+		nodeSelector, err := metav1.LabelSelectorAsSelector(selector.NodeSelector)
+		if err != nil {
+			return false, fmt.Errorf("while parsing the node selector: %w", err)
+		}
+		if !nodeSelector.Matches(labels.Set(node.Labels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// electBackupTargetPod elects the ready instance that should run the backup
+// according to the backup target, giving precedence to the preferred instance
+// when it's eligible. It returns nil when no instance is eligible
+func electBackupTargetPod(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backupTarget apiv1.BackupTarget,
+	postgresqlStatusList postgresSpec.PostgresqlStatusList,
+) *corev1.Pod {
+	contextLogger := log.FromContext(ctx)
+
+	isEligible := func(item postgresSpec.PostgresqlStatus) bool {
+		switch backupTarget {
+		case apiv1.BackupTargetPrimary:
+			return item.IsPrimary
+		case apiv1.BackupTargetStandby, "":
+			return !item.IsPrimary
+		case apiv1.BackupTargetStandbyOnly:
+			// In a replica cluster, the designated primary is
+			// not considered a standby
+			return !item.IsPrimary && item.Pod.Name != cluster.Status.CurrentPrimary &&
+				item.Pod.Name != cluster.Status.TargetPrimary
+		}
+		return false
+	}
+
+	var preferredInstance string
+	if cluster.Spec.Backup != nil && backupTarget != apiv1.BackupTargetPrimary {
+		preferredInstance = cluster.Spec.Backup.PreferredInstance
+	}
+
+	var electedPod *corev1.Pod
+	for _, item := range postgresqlStatusList.Items {
+		if !item.IsPodReady {
+			contextLogger.Debug("Instance not ready, discarded as target for backup",
+				"pod", item.Pod.Name)
+			continue
+		}
+		if !isEligible(item) {
+			continue
+		}
+		if item.Pod.Name == preferredInstance {
+			contextLogger.Debug("Preferred Instance is elected as backup target",
+				"instance", item.Pod.Name)
+			return item.Pod
+		}
+		if electedPod == nil {
+			electedPod = item.Pod
+		}
+	}
+
+	if electedPod != nil {
+		contextLogger.Debug("Instance is elected as backup target",
+			"instance", electedPod.Name,
+			"target", backupTarget)
+	}
+	return electedPod
+}
+
 // startInstanceManagerBackup request a backup in a Pod and marks the backup started
 // or failed if needed
 func startInstanceManagerBackup(
@@ -689,6 +916,7 @@ func (r *BackupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manage
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Backup{}).
 		Named("backup").
+		Owns(&batchv1.Job{}).
 		Watches(&apiv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.mapClustersToBackup()),
 			builder.WithPredicates(clustersWithBackupPredicate),
@@ -703,7 +931,7 @@ func (r *BackupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manage
 	// TODO: allow concurrent reconciliations when the hot snapshot backup reconciler
 	// will allow that
 	controllerBuilder = controllerBuilder.WithOptions(controller.Options{MaxConcurrentReconciles: 1})
-	return controllerBuilder.Complete(r)
+	return controllerBuilder.Complete(newShardedReconciler(r))
 }
 
 func tryFlagBackupAsFailed(
@@ -28,6 +28,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -435,3 +436,166 @@ var _ = Describe("update snapshot backup metadata", func() {
 			To(Equal(oneHourAgo))
 	})
 })
+
+var _ = Describe("electBackupTargetPod", func() {
+	newStatus := func(name string, isPrimary, isPodReady bool) postgresSpec.PostgresqlStatus {
+		return postgresSpec.PostgresqlStatus{
+			Pod:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			IsPrimary:  isPrimary,
+			IsPodReady: isPodReady,
+		}
+	}
+
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+	})
+
+	statuses := postgresSpec.PostgresqlStatusList{
+		Items: []postgresSpec.PostgresqlStatus{
+			newStatus("cluster-example-1", true, true),
+			newStatus("cluster-example-2", false, false),
+			newStatus("cluster-example-3", false, true),
+			newStatus("cluster-example-4", false, true),
+		},
+	}
+
+	It("elects the primary with the primary target", func(ctx SpecContext) {
+		cluster.Spec.Backup.PreferredInstance = "cluster-example-3"
+		pod := electBackupTargetPod(ctx, cluster, apiv1.BackupTargetPrimary, statuses)
+		Expect(pod.Name).To(Equal("cluster-example-1"))
+	})
+
+	It("elects the first ready standby", func(ctx SpecContext) {
+		pod := electBackupTargetPod(ctx, cluster, apiv1.BackupTargetStandby, statuses)
+		Expect(pod.Name).To(Equal("cluster-example-3"))
+	})
+
+	It("elects the preferred instance when it's a ready standby", func(ctx SpecContext) {
+		cluster.Spec.Backup.PreferredInstance = "cluster-example-4"
+		pod := electBackupTargetPod(ctx, cluster, apiv1.BackupTargetStandbyOnly, statuses)
+		Expect(pod.Name).To(Equal("cluster-example-4"))
+
+		cluster.Spec.Backup.PreferredInstance = "cluster-example-1"
+		pod = electBackupTargetPod(ctx, cluster, apiv1.BackupTargetStandby, statuses)
+		Expect(pod.Name).To(Equal("cluster-example-3"))
+	})
+
+	It("never elects the designated primary with the standby target", func(ctx SpecContext) {
+		replicaStatuses := postgresSpec.PostgresqlStatusList{
+			Items: []postgresSpec.PostgresqlStatus{
+				newStatus("cluster-example-1", false, true),
+				newStatus("cluster-example-2", false, false),
+			},
+		}
+		Expect(electBackupTargetPod(ctx, cluster, apiv1.BackupTargetStandbyOnly, replicaStatuses)).To(BeNil())
+		Expect(electBackupTargetPod(ctx, cluster, apiv1.BackupTargetStandby, replicaStatuses).Name).
+			To(Equal("cluster-example-1"))
+	})
+})
+
+var _ = Describe("matchesBackupTargetSelector", func() {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cluster-example-2",
+			Labels: map[string]string{utils.InstanceNameLabelName: "cluster-example-2"},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+		},
+	}
+
+	It("matches the instances by the labels of their pods", func() {
+		selector := &apiv1.BackupTargetSelector{
+			InstanceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{utils.InstanceNameLabelName: "cluster-example-2"},
+			},
+		}
+		Expect(matchesBackupTargetSelector(selector, pod, nil)).To(BeTrue())
+
+		selector.InstanceSelector.MatchLabels[utils.InstanceNameLabelName] = "cluster-example-3"
+		Expect(matchesBackupTargetSelector(selector, pod, nil)).To(BeFalse())
+	})
+
+	It("matches the instances by the labels of their nodes", func() {
+		selector := &apiv1.BackupTargetSelector{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+			},
+		}
+		Expect(matchesBackupTargetSelector(selector, pod, node)).To(BeTrue())
+		Expect(matchesBackupTargetSelector(selector, pod, nil)).To(BeFalse())
+
+		selector.NodeSelector.MatchLabels["topology.kubernetes.io/zone"] = "zone-b"
+		Expect(matchesBackupTargetSelector(selector, pod, node)).To(BeFalse())
+	})
+
+	It("returns an error with an invalid selector", func() {
+		selector := &apiv1.BackupTargetSelector{
+			InstanceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: utils.InstanceNameLabelName, Operator: "Invalid"},
+				},
+			},
+		}
+		_, err := matchesBackupTargetSelector(selector, pod, node)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("selectBackupTargetCandidates", func() {
+	newStatus := func(name, nodeName string) postgresSpec.PostgresqlStatus {
+		return postgresSpec.PostgresqlStatus{
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       corev1.PodSpec{NodeName: nodeName},
+			},
+			IsPodReady: true,
+		}
+	}
+
+	It("selects the instances running on the matching nodes", func(ctx SpecContext) {
+		nodes := []client.Object{
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-a",
+				Labels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+			}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-b",
+				Labels: map[string]string{"topology.kubernetes.io/zone": "zone-b"},
+			}},
+		}
+		r := &BackupReconciler{
+			Client: fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(nodes...).Build(),
+		}
+		selector := &apiv1.BackupTargetSelector{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"topology.kubernetes.io/zone": "zone-b"},
+			},
+		}
+		statuses := postgresSpec.PostgresqlStatusList{
+			Items: []postgresSpec.PostgresqlStatus{
+				newStatus("cluster-example-1", "node-a"),
+				newStatus("cluster-example-2", "node-b"),
+				newStatus("cluster-example-3", "node-missing"),
+			},
+		}
+
+		result, err := r.selectBackupTargetCandidates(ctx, selector, statuses)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Items).To(HaveLen(1))
+		Expect(result.Items[0].Pod.Name).To(Equal("cluster-example-2"))
+	})
+})
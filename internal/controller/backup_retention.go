@@ -0,0 +1,195 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin"
+	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/backup/retention"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/backup/volumesnapshot"
+)
+
+// reconcileVolumeSnapshotRetention deletes the volume snapshot backups of
+// the cluster which fell out of the configured retention policy
+func (r *BackupReconciler) reconcileVolumeSnapshotRetention(
+	ctx context.Context,
+	backup *apiv1.Backup,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.Cluster.Name,
+	}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.VolumeSnapshot == nil ||
+		cluster.Spec.Backup.VolumeSnapshot.Retention == nil {
+		return ctrl.Result{}, nil
+	}
+
+	var backupList apiv1.BackupList
+	if err := r.List(ctx, &backupList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingFields{clusterName: cluster.Name},
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	expiredBackups, nextExpiration, err := volumesnapshot.ExpiredBackups(
+		backupList.Items,
+		cluster.Spec.Backup.VolumeSnapshot.Retention,
+		now,
+	)
+	if err != nil {
+		contextLogger.Error(err, "while applying the volume snapshot retention policy")
+		return ctrl.Result{}, nil
+	}
+
+	for idx := range expiredBackups {
+		expiredBackup := &expiredBackups[idx]
+		contextLogger.Info("Deleting volume snapshot backup out of the retention policy",
+			"backup", expiredBackup.Name)
+		if err := volumesnapshot.DeleteBackup(ctx, r.Client, expiredBackup); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&cluster, "Normal", "BackupExpired",
+			"Deleted backup %v as it is out of the retention policy", expiredBackup.Name)
+	}
+
+	if nextExpiration != nil {
+		return ctrl.Result{RequeueAfter: nextExpiration.Sub(now)}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcilePluginBackupRetention deletes the backups taken with the plugin
+// of the passed backup which fell out of the retention policy configured
+// for that plugin in the cluster
+func (r *BackupReconciler) reconcilePluginBackupRetention(
+	ctx context.Context,
+	backup *apiv1.Backup,
+) (ctrl.Result, error) {
+	if backup.Spec.PluginConfiguration.IsEmpty() {
+		return ctrl.Result{}, nil
+	}
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.Cluster.Name,
+	}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	pluginName := backup.Spec.PluginConfiguration.Name
+	if cluster.GetPluginBackupRetention(pluginName) == nil {
+		return ctrl.Result{}, nil
+	}
+
+	pluginClient, err := cnpgiClient.WithPlugins(ctx, r.Plugins, pluginName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("while loading the backup plugin: %w", err)
+	}
+	defer func() {
+		pluginClient.Close(ctx)
+	}()
+
+	return r.deleteExpiredPluginBackups(ctx, &cluster, pluginClient, pluginName)
+}
+
+// deleteExpiredPluginBackups deletes the backups taken with the passed
+// plugin which fell out of its retention policy. The plugin is notified
+// of every deletion through the lifecycle hook of the Backup objects,
+// giving it the chance to remove the backup data, and a failure to do
+// so prevents the Backup object from being deleted
+func (r *BackupReconciler) deleteExpiredPluginBackups(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pluginClient cnpgiClient.Client,
+	pluginName string,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).WithValues("plugin", pluginName)
+
+	var backupList apiv1.BackupList
+	if err := r.List(ctx, &backupList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingFields{clusterName: cluster.Name},
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	expiredBackups, nextExpiration, err := retention.ExpiredBackups(
+		backupList.Items,
+		cluster.GetPluginBackupRetention(pluginName),
+		now,
+		func(backup *apiv1.Backup) bool {
+			return backup.Spec.Method == apiv1.BackupMethodPlugin &&
+				!backup.Spec.PluginConfiguration.IsEmpty() &&
+				backup.Spec.PluginConfiguration.Name == pluginName
+		},
+	)
+	if err != nil {
+		contextLogger.Error(err, "while applying the plugin backup retention policy")
+		return ctrl.Result{}, nil
+	}
+
+	for idx := range expiredBackups {
+		expiredBackup := &expiredBackups[idx]
+		contextLogger.Info("Deleting plugin backup out of the retention policy",
+			"backup", expiredBackup.Name)
+		expiredBackup.EnsureGVKIsPresent()
+		if _, err := pluginClient.LifecycleHook(ctx, plugin.OperationVerbDelete, cluster, expiredBackup); err != nil {
+			r.Recorder.Eventf(cluster, "Warning", "BackupExpirationFailed",
+				"Plugin %v could not delete the data of backup %v: %v", pluginName, expiredBackup.Name, err)
+			return ctrl.Result{}, fmt.Errorf("while notifying the plugin of the deletion of backup %s: %w",
+				expiredBackup.Name, err)
+		}
+		if err := r.Delete(ctx, expiredBackup); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(cluster, "Normal", "BackupExpired",
+			"Deleted backup %v as it is out of the retention policy", expiredBackup.Name)
+	}
+
+	if nextExpiration != nil {
+		return ctrl.Result{RequeueAfter: nextExpiration.Sub(now)}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
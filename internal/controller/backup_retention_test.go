@@ -0,0 +1,153 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin"
+	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeBackupPluginClient records the backups whose deletion
+// has been notified to the plugin
+type fakeBackupPluginClient struct {
+	cnpgiClient.Client
+	deletedBackups []string
+	err            error
+}
+
+func (f *fakeBackupPluginClient) LifecycleHook(
+	_ context.Context,
+	operationVerb plugin.OperationVerb,
+	_ client.Object,
+	object client.Object,
+) (client.Object, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if operationVerb == plugin.OperationVerbDelete &&
+		object.GetObjectKind().GroupVersionKind().Kind == apiv1.BackupKind {
+		f.deletedBackups = append(f.deletedBackups, object.GetName())
+	}
+	return object, nil
+}
+
+var _ = Describe("plugin backup retention", func() {
+	const pluginName = "restic.example.com"
+
+	var (
+		cluster      *apiv1.Cluster
+		backups      []client.Object
+		pluginClient *fakeBackupPluginClient
+	)
+
+	newBackup := func(name string, backupPlugin string, age time.Duration) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: apiv1.BackupSpec{
+				Cluster:             apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:              apiv1.BackupMethodPlugin,
+				PluginConfiguration: &apiv1.BackupPluginConfiguration{Name: backupPlugin},
+			},
+			Status: apiv1.BackupStatus{
+				Phase:     apiv1.BackupPhaseCompleted,
+				StoppedAt: ptr.To(metav1.NewTime(time.Now().Add(-age))),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Plugins: []apiv1.PluginConfiguration{
+					{
+						Name:            pluginName,
+						BackupRetention: &apiv1.VolumeSnapshotRetentionPolicy{KeepLast: ptr.To(1), MaxAge: "7d"},
+					},
+				},
+			},
+		}
+		backups = []client.Object{
+			newBackup("latest", pluginName, time.Hour),
+			newBackup("previous", pluginName, 48*time.Hour),
+			newBackup("other-plugin", "kopia.example.com", 48*time.Hour),
+		}
+		pluginClient = &fakeBackupPluginClient{}
+	})
+
+	newReconciler := func() *BackupReconciler {
+		return &BackupReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(append(backups, cluster)...).
+				WithIndex(&apiv1.Backup{}, clusterName, func(obj client.Object) []string {
+					return []string{obj.(*apiv1.Backup).Spec.Cluster.Name}
+				}).
+				Build(),
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	It("deletes the expired backups of the plugin after notifying it", func(ctx SpecContext) {
+		r := newReconciler()
+
+		res, err := r.deleteExpiredPluginBackups(ctx, cluster, pluginClient, pluginName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+		Expect(pluginClient.deletedBackups).To(ConsistOf("previous"))
+
+		err = r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "previous"}, &apiv1.Backup{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "latest"}, &apiv1.Backup{})).To(Succeed())
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "other-plugin"}, &apiv1.Backup{})).To(Succeed())
+	})
+
+	It("requeues when the oldest retained backup is going to expire", func(ctx SpecContext) {
+		cluster.Spec.Plugins[0].BackupRetention.KeepLast = nil
+		r := newReconciler()
+
+		res, err := r.deleteExpiredPluginBackups(ctx, cluster, pluginClient, pluginName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeNumerically("~", 5*24*time.Hour, time.Minute))
+		Expect(pluginClient.deletedBackups).To(BeEmpty())
+	})
+
+	It("keeps the backups whose data the plugin couldn't delete", func(ctx SpecContext) {
+		pluginClient.err = errors.New("repository is locked")
+		r := newReconciler()
+
+		_, err := r.deleteExpiredPluginBackups(ctx, cluster, pluginClient, pluginName)
+		Expect(err).To(MatchError(ContainSubstring("repository is locked")))
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "previous"}, &apiv1.Backup{})).To(Succeed())
+	})
+})
@@ -0,0 +1,282 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+var (
+	backupVerificationLastResult = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cnpg",
+		Subsystem: "backup_verification",
+		Name:      "last_result",
+		Help:      "1 if the last backup verification of the cluster succeeded, 0 if it failed",
+	}, []string{"namespace", "cluster"})
+
+	backupVerificationLastTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cnpg",
+		Subsystem: "backup_verification",
+		Name:      "last_timestamp_seconds",
+		Help:      "The time when the last backup verification of the cluster terminated",
+	}, []string{"namespace", "cluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		backupVerificationLastResult,
+		backupVerificationLastTimestamp,
+	)
+}
+
+// reconcileBackupVerification periodically restores the latest completed
+// backup of a cluster in a throwaway instance, to prove it can be used for
+// a recovery
+func (r *BackupReconciler) reconcileBackupVerification(
+	ctx context.Context,
+	backup *apiv1.Backup,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.Cluster.Name,
+	}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Verification == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if backup.Status.Verification != nil {
+		return ctrl.Result{}, r.reconcileExistingBackupVerification(ctx, &cluster, backup)
+	}
+
+	var backupList apiv1.BackupList
+	if err := r.List(ctx, &backupList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingFields{clusterName: cluster.Name},
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	latestBackup := getLatestVerifiableBackup(backupList)
+	if latestBackup == nil || latestBackup.Name != backup.Name {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	nextVerification, err := getNextBackupVerificationTime(
+		cluster.Spec.Backup.Verification.Schedule,
+		getLastBackupVerificationTime(backupList),
+	)
+	if err != nil {
+		contextLogger.Error(err, "while parsing the backup verification schedule")
+		return ctrl.Result{}, nil
+	}
+	if nextVerification.After(now) {
+		contextLogger.Debug("Backup verification not due yet", "nextVerification", nextVerification)
+		return ctrl.Result{RequeueAfter: nextVerification.Sub(now)}, nil
+	}
+
+	job := specs.CreateBackupVerificationJob(cluster, backup)
+	if err := ctrl.SetControllerReference(backup, job, r.Scheme); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, job); err != nil && !apierrs.IsAlreadyExists(err) {
+		r.Recorder.Eventf(backup, "Warning", "VerificationFailed",
+			"Error creating backup verification job: %v", err)
+		return ctrl.Result{}, fmt.Errorf("while creating backup verification job: %w", err)
+	}
+
+	origBackup := backup.DeepCopy()
+	backup.Status.Verification = &apiv1.BackupVerificationStatus{
+		Phase:     apiv1.BackupVerificationPhasePending,
+		JobName:   job.Name,
+		StartedAt: ptr.To(metav1.NewTime(now)),
+	}
+	if err := r.Status().Patch(ctx, backup, client.MergeFrom(origBackup)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	contextLogger.Info("Started backup verification", "job", job.Name)
+	r.Recorder.Eventf(backup, "Normal", "VerificationStarted",
+		"Started the verification of backup %v", backup.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileExistingBackupVerification tracks the progress of a verification
+// job, and cleans it up once the verification is terminated
+func (r *BackupReconciler) reconcileExistingBackupVerification(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) error {
+	verification := backup.Status.Verification
+
+	var job batchv1.Job
+	err := r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: verification.JobName}, &job)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	jobFound := err == nil
+
+	if verification.IsTerminated() {
+		if err := r.updateBackupVerificationMetrics(ctx, cluster, backup); err != nil {
+			return err
+		}
+
+		// The throwaway instance is not needed anymore, and its storage
+		// can be released
+		if jobFound {
+			return client.IgnoreNotFound(
+				r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+		}
+		return nil
+	}
+
+	origBackup := backup.DeepCopy()
+	switch {
+	case !jobFound:
+		verification.SetAsFailed(errors.New("the backup verification job disappeared"))
+	case job.Status.Failed > 0:
+		verification.SetAsFailed(errors.New("the backup verification job failed"))
+	case utils.JobHasOneCompletion(job):
+		verification.SetAsSucceeded()
+	default:
+		return nil
+	}
+
+	if verification.Phase == apiv1.BackupVerificationPhaseFailed {
+		r.Recorder.Eventf(backup, "Warning", "VerificationFailed",
+			"Backup verification failed: %s", verification.Error)
+	} else {
+		r.Recorder.Event(backup, "Normal", "VerificationSucceeded", "Backup verification succeeded")
+	}
+
+	return r.Status().Patch(ctx, backup, client.MergeFrom(origBackup))
+}
+
+// updateBackupVerificationMetrics exposes the result of the verification
+// if it is the latest one of the cluster
+func (r *BackupReconciler) updateBackupVerificationMetrics(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) error {
+	var backupList apiv1.BackupList
+	if err := r.List(ctx, &backupList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingFields{clusterName: cluster.Name},
+	); err != nil {
+		return err
+	}
+
+	lastVerification := getLastBackupVerificationTime(backupList)
+	verification := backup.Status.Verification
+	if lastVerification == nil || verification.StartedAt == nil ||
+		!verification.StartedAt.Time.Equal(lastVerification.Time) {
+		return nil
+	}
+
+	result := 0.0
+	if verification.Phase == apiv1.BackupVerificationPhaseSucceeded {
+		result = 1.0
+	}
+	backupVerificationLastResult.WithLabelValues(cluster.Namespace, cluster.Name).Set(result)
+	if verification.StoppedAt != nil {
+		backupVerificationLastTimestamp.WithLabelValues(cluster.Namespace, cluster.Name).
+			Set(float64(verification.StoppedAt.Unix()))
+	}
+
+	return nil
+}
+
+// getLatestVerifiableBackup gets the latest completed backup which can be
+// restored in a throwaway instance, or nil if there is none
+func getLatestVerifiableBackup(backupList apiv1.BackupList) *apiv1.Backup {
+	backupList.SortByReverseCreationTime()
+	for idx := range backupList.Items {
+		backup := &backupList.Items[idx]
+		if backup.Status.Phase != apiv1.BackupPhaseCompleted {
+			continue
+		}
+		switch backup.Spec.Method {
+		case apiv1.BackupMethodBarmanObjectStore, apiv1.BackupMethodPgBackRest:
+			return backup
+		}
+	}
+
+	return nil
+}
+
+// getLastBackupVerificationTime gets the time when the most recent
+// verification was started, or nil if no backup has ever been verified
+func getLastBackupVerificationTime(backupList apiv1.BackupList) *metav1.Time {
+	var result *metav1.Time
+	for idx := range backupList.Items {
+		verification := backupList.Items[idx].Status.Verification
+		if verification == nil || verification.StartedAt == nil {
+			continue
+		}
+		if result == nil || verification.StartedAt.After(result.Time) {
+			result = verification.StartedAt
+		}
+	}
+
+	return result
+}
+
+// getNextBackupVerificationTime computes when the next verification should
+// be started. If no verification has ever been executed, the verification
+// is due immediately
+func getNextBackupVerificationTime(schedule string, lastVerification *metav1.Time) (time.Time, error) {
+	if lastVerification == nil {
+		return time.Time{}, nil
+	}
+
+	parsedSchedule, err := cron.Parse(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parsedSchedule.Next(lastVerification.Time), nil
+}
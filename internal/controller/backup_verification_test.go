@@ -0,0 +1,127 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup verification", func() {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	newBackup := func(
+		name string,
+		creation time.Time,
+		method apiv1.BackupMethod,
+		phase apiv1.BackupPhase,
+	) apiv1.Backup {
+		return apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(creation),
+			},
+			Spec: apiv1.BackupSpec{
+				Method: method,
+			},
+			Status: apiv1.BackupStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	Context("getLatestVerifiableBackup", func() {
+		It("returns nil when there are no backups", func() {
+			Expect(getLatestVerifiableBackup(apiv1.BackupList{})).To(BeNil())
+		})
+
+		It("skips backups which are not completed or cannot be restored", func() {
+			backupList := apiv1.BackupList{
+				Items: []apiv1.Backup{
+					newBackup("old", now.Add(-3*time.Hour),
+						apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseCompleted),
+					newBackup("pgbackrest", now.Add(-2*time.Hour),
+						apiv1.BackupMethodPgBackRest, apiv1.BackupPhaseCompleted),
+					newBackup("snapshot", now.Add(-time.Hour),
+						apiv1.BackupMethodVolumeSnapshot, apiv1.BackupPhaseCompleted),
+					newBackup("running", now,
+						apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseRunning),
+				},
+			}
+
+			backup := getLatestVerifiableBackup(backupList)
+			Expect(backup).ToNot(BeNil())
+			Expect(backup.Name).To(Equal("pgbackrest"))
+		})
+	})
+
+	Context("getLastBackupVerificationTime", func() {
+		It("returns nil when no backup has been verified", func() {
+			backupList := apiv1.BackupList{
+				Items: []apiv1.Backup{
+					newBackup("backup", now, apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseCompleted),
+				},
+			}
+			Expect(getLastBackupVerificationTime(backupList)).To(BeNil())
+		})
+
+		It("returns the most recent verification start time", func() {
+			first := newBackup("first", now.Add(-2*time.Hour),
+				apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseCompleted)
+			first.Status.Verification = &apiv1.BackupVerificationStatus{
+				StartedAt: ptr.To(metav1.NewTime(now.Add(-90 * time.Minute))),
+			}
+			second := newBackup("second", now.Add(-time.Hour),
+				apiv1.BackupMethodBarmanObjectStore, apiv1.BackupPhaseCompleted)
+			second.Status.Verification = &apiv1.BackupVerificationStatus{
+				StartedAt: ptr.To(metav1.NewTime(now.Add(-30 * time.Minute))),
+			}
+
+			result := getLastBackupVerificationTime(apiv1.BackupList{
+				Items: []apiv1.Backup{second, first},
+			})
+			Expect(result).ToNot(BeNil())
+			Expect(result.Time).To(Equal(now.Add(-30 * time.Minute)))
+		})
+	})
+
+	Context("getNextBackupVerificationTime", func() {
+		It("is due immediately when no verification has ever been done", func() {
+			next, err := getNextBackupVerificationTime("0 0 0 * * *", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next.After(now)).To(BeFalse())
+		})
+
+		It("follows the schedule after the last verification", func() {
+			next, err := getNextBackupVerificationTime("0 0 0 * * *", ptr.To(metav1.NewTime(now)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(Equal(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("fails with an invalid schedule", func() {
+			_, err := getNextBackupVerificationTime("not a schedule", ptr.To(metav1.NewTime(now)))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,147 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// getRecoveryBackupGrant gets the BackupGrant allowing the cluster to be
+// bootstrapped from a backup living in another namespace, or nil if the
+// access to the backup has not been granted
+func (r *ClusterReconciler) getRecoveryBackupGrant(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (*apiv1.BackupGrant, error) {
+	backupKey := cluster.GetRecoveryBackupKey()
+	if backupKey == nil {
+		return nil, nil
+	}
+
+	var grants apiv1.BackupGrantList
+	if err := r.List(ctx, &grants, client.InNamespace(backupKey.Namespace)); err != nil {
+		return nil, fmt.Errorf("while listing the backup grants: %w", err)
+	}
+
+	return grants.FindGrant(cluster.Namespace, backupKey.Name), nil
+}
+
+// reconcileRecoveryBackupAccess allows the instance manager to read the backup
+// the cluster is bootstrapped from, and its credentials, when the backup lives
+// in another namespace. The role is owned by the BackupGrant allowing the access,
+// and is removed as soon as the cluster has been bootstrapped
+func (r *ClusterReconciler) reconcileRecoveryBackupAccess(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !cluster.IsRecoveringFromAnotherNamespace() {
+		return nil
+	}
+
+	if cluster.Status.ReadyInstances > 0 {
+		return r.deleteRecoveryBackupAccess(ctx, cluster)
+	}
+
+	grant, err := r.getRecoveryBackupGrant(ctx, cluster)
+	if err != nil || grant == nil {
+		return err
+	}
+
+	var backup apiv1.Backup
+	if err := r.Get(ctx, *cluster.GetRecoveryBackupKey(), &backup); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	grantTypeMeta := metav1.TypeMeta{
+		APIVersion: apiv1.GroupVersion.String(),
+		Kind:       apiv1.BackupGrantKind,
+	}
+
+	role := specs.CreateRecoveryBackupRole(*cluster, &backup)
+	utils.SetAsOwnedBy(&role.ObjectMeta, grant.ObjectMeta, grantTypeMeta)
+	if err := r.createOrPatchRecoveryBackupRole(ctx, &role); err != nil {
+		return err
+	}
+
+	roleBinding := specs.CreateRecoveryBackupRoleBinding(*cluster, backup.Namespace)
+	utils.SetAsOwnedBy(&roleBinding.ObjectMeta, grant.ObjectMeta, grantTypeMeta)
+	if err := r.Create(ctx, &roleBinding); err != nil && !apierrs.IsAlreadyExists(err) {
+		return fmt.Errorf("while creating the recovery role binding: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ClusterReconciler) createOrPatchRecoveryBackupRole(ctx context.Context, generatedRole *rbacv1.Role) error {
+	var role rbacv1.Role
+	err := r.Get(ctx, client.ObjectKeyFromObject(generatedRole), &role)
+	if apierrs.IsNotFound(err) {
+		log.FromContext(ctx).Info("Creating the recovery role",
+			"namespace", generatedRole.Namespace, "name", generatedRole.Name)
+		return r.Create(ctx, generatedRole)
+	}
+	if err != nil {
+		return fmt.Errorf("while getting the recovery role: %w", err)
+	}
+
+	if equality.Semantic.DeepEqual(generatedRole.Rules, role.Rules) {
+		return nil
+	}
+
+	patchedRole := role.DeepCopy()
+	patchedRole.Rules = generatedRole.Rules
+	if err := r.Patch(ctx, patchedRole, client.MergeFrom(&role)); err != nil {
+		return fmt.Errorf("while patching the recovery role: %w", err)
+	}
+
+	return nil
+}
+
+// deleteRecoveryBackupAccess removes the role allowing the instance manager
+// to read the backup the cluster has been bootstrapped from
+func (r *ClusterReconciler) deleteRecoveryBackupAccess(ctx context.Context, cluster *apiv1.Cluster) error {
+	objectKey := client.ObjectKey{
+		Namespace: cluster.GetRecoveryBackupKey().Namespace,
+		Name:      specs.GetRecoveryBackupRoleName(*cluster),
+	}
+
+	for _, object := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+		if err := r.Get(ctx, objectKey, object); err != nil {
+			if apierrs.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		log.FromContext(ctx).Info("Removing the access to the recovery backup",
+			"namespace", objectKey.Namespace, "name", objectKey.Name)
+		if err := r.Delete(ctx, object); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,503 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/lifecycleevents"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// certificateRotationStepTimeout is the time the operator waits for the
+	// instances and the poolers to complete a step of the certificate
+	// rotation before proceeding with the next one
+	certificateRotationStepTimeout = 5 * time.Minute
+
+	// certificateRotationRequeueInterval is the interval used to check the
+	// progress of the certificate rotation
+	certificateRotationRequeueInterval = 5 * time.Second
+)
+
+// certificateRotationSecrets contains the secrets holding the TLS
+// certificates being rotated
+type certificateRotationSecrets struct {
+	serverTLS      *v1.Secret
+	replicationTLS *v1.Secret
+	serverCA       *v1.Secret
+	clientCA       *v1.Secret
+}
+
+// getRotationFingerprint gets the fingerprint identifying a rotation. The
+// previous CA certificates are excluded, as removing them at the end of
+// the rotation must not start a new one
+func (secrets *certificateRotationSecrets) getRotationFingerprint() string {
+	return certs.Fingerprint(
+		secrets.serverTLS.Data[v1.TLSCertKey],
+		secrets.replicationTLS.Data[v1.TLSCertKey],
+		secrets.serverCA.Data[certs.CACertKey],
+		secrets.clientCA.Data[certs.CACertKey])
+}
+
+// getCertificatesFingerprint gets the fingerprint of the certificates
+// as reported by the instances that loaded them
+func (secrets *certificateRotationSecrets) getCertificatesFingerprint() string {
+	return certs.Fingerprint(
+		secrets.serverTLS.Data[v1.TLSCertKey],
+		secrets.replicationTLS.Data[v1.TLSCertKey])
+}
+
+// getCAFingerprint gets the fingerprint of the CA bundles as reported
+// by the instances that loaded them
+func (secrets *certificateRotationSecrets) getCAFingerprint() string {
+	return certs.Fingerprint(
+		certs.GetCABundle(secrets.clientCA),
+		certs.GetCABundle(secrets.serverCA))
+}
+
+// reconcileCertificateRotation coordinates the staged rotation of the TLS
+// certificates of the cluster. When the certificates change, the instances
+// first load the CA bundles trusting both the previous and the new CA, then
+// they load the new certificates one at a time, with the primary as last,
+// and finally the PgBouncer poolers are reloaded. This way the existing
+// replication and pooler connections keep working during the rotation
+func (r *ClusterReconciler) reconcileCertificateRotation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	secrets, err := r.getCertificateRotationSecrets(ctx, cluster)
+	if apierrs.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rotation := cluster.Status.CertificateRotation
+	fingerprint := secrets.getRotationFingerprint()
+	switch {
+	case rotation == nil:
+		// There's nothing to rotate: the instances loaded the
+		// certificates when they were created
+		return ctrl.Result{}, r.patchCertificateRotation(ctx, cluster, &apiv1.CertificateRotationStatus{
+			Phase:       apiv1.CertificateRotationPhaseCompleted,
+			Fingerprint: fingerprint,
+		})
+
+	case rotation.Fingerprint != fingerprint:
+		return r.startCertificateRotation(ctx, cluster, instancesStatus, fingerprint)
+	}
+
+	switch rotation.Phase {
+	case apiv1.CertificateRotationPhaseDistributingCA:
+		return r.reconcileCADistribution(ctx, cluster, instancesStatus, secrets)
+	case apiv1.CertificateRotationPhaseReloadingInstances:
+		return r.reconcileInstancesCertificateReload(ctx, cluster, instancesStatus, secrets)
+	case apiv1.CertificateRotationPhaseReloadingPoolers:
+		return r.reconcilePoolersCertificateReload(ctx, cluster)
+	default:
+		return ctrl.Result{}, nil
+	}
+}
+
+// getCertificateRotationSecrets gets the secrets holding the TLS
+// certificates of the cluster
+func (r *ClusterReconciler) getCertificateRotationSecrets(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (*certificateRotationSecrets, error) {
+	getSecret := func(name string) (*v1.Secret, error) {
+		var secret v1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, &secret); err != nil {
+			return nil, err
+		}
+		return &secret, nil
+	}
+
+	var (
+		result certificateRotationSecrets
+		err    error
+	)
+	if result.serverTLS, err = getSecret(cluster.GetServerTLSSecretName()); err != nil {
+		return nil, err
+	}
+	if result.replicationTLS, err = getSecret(cluster.GetReplicationSecretName()); err != nil {
+		return nil, err
+	}
+	if result.serverCA, err = getSecret(cluster.GetServerCASecretName()); err != nil {
+		return nil, err
+	}
+	if result.clientCA, err = getSecret(cluster.GetClientCASecretName()); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// startCertificateRotation starts a new rotation of the certificates,
+// distributing the CA bundles to the instances
+func (r *ClusterReconciler) startCertificateRotation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	fingerprint string,
+) (ctrl.Result, error) {
+	pendingInstances := getCertificateRotationOrder(cluster, instancesStatus)
+	now := metav1.Now()
+	if err := r.patchCertificateRotation(ctx, cluster, &apiv1.CertificateRotationStatus{
+		Phase:            apiv1.CertificateRotationPhaseDistributingCA,
+		Fingerprint:      fingerprint,
+		PendingInstances: pendingInstances,
+		StartedAt:        &now,
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.FromContext(ctx).Info("Starting the rotation of the TLS certificates",
+		"instances", pendingInstances)
+	r.Recorder.Eventf(cluster, "Normal", "CertificateRotation",
+		"Starting the rotation of the TLS certificates of the instances %v", pendingInstances)
+	return ctrl.Result{RequeueAfter: certificateRotationRequeueInterval}, nil
+}
+
+// reconcileCADistribution waits for every instance to load the CA bundles,
+// then starts reloading the certificates of the instances
+func (r *ClusterReconciler) reconcileCADistribution(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	secrets *certificateRotationSecrets,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+	rotation := cluster.Status.CertificateRotation
+
+	caFingerprint := secrets.getCAFingerprint()
+	for _, item := range instancesStatus.Items {
+		if item.Pod == nil || item.Error != nil || item.TLSCAFingerprint == caFingerprint {
+			continue
+		}
+
+		if !isCertificateRotationStepExpired(rotation) {
+			contextLogger.Debug("Waiting for the instance to load the CA bundle",
+				"instance", item.Pod.Name)
+			return ctrl.Result{RequeueAfter: certificateRotationRequeueInterval}, nil
+		}
+
+		contextLogger.Warning("Timeout expired while waiting for the instance to load the CA bundle",
+			"instance", item.Pod.Name)
+		r.Recorder.Eventf(cluster, "Warning", "CertificateRotationTimeout",
+			"Timeout expired while distributing the CA bundle to instance %s", item.Pod.Name)
+		break
+	}
+
+	return r.reloadNextInstanceCertificates(ctx, cluster)
+}
+
+// reconcileInstancesCertificateReload waits for the target instance to
+// load the new certificates, then proceeds with the next one
+func (r *ClusterReconciler) reconcileInstancesCertificateReload(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	secrets *certificateRotationSecrets,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+	rotation := cluster.Status.CertificateRotation
+
+	target := getInstanceStatus(instancesStatus, rotation.TargetInstance)
+	switch {
+	case target == nil || target.Error != nil:
+		contextLogger.Info("Skipping the certificate reload of an unavailable instance",
+			"instance", rotation.TargetInstance)
+
+	case target.TLSCertificatesFingerprint == secrets.getCertificatesFingerprint():
+		contextLogger.Info("The instance loaded the new certificates",
+			"instance", rotation.TargetInstance)
+
+	case isCertificateRotationStepExpired(rotation):
+		contextLogger.Warning("Timeout expired while waiting for the instance to load the new certificates",
+			"instance", rotation.TargetInstance)
+		r.Recorder.Eventf(cluster, "Warning", "CertificateRotationTimeout",
+			"Timeout expired while reloading the certificates of instance %s", rotation.TargetInstance)
+
+	default:
+		contextLogger.Debug("Waiting for the instance to load the new certificates",
+			"instance", rotation.TargetInstance)
+		return ctrl.Result{RequeueAfter: certificateRotationRequeueInterval}, nil
+	}
+
+	return r.reloadNextInstanceCertificates(ctx, cluster)
+}
+
+// reloadNextInstanceCertificates allows the next pending instance to load
+// the new certificates, or starts reloading the poolers when every
+// instance has been reloaded
+func (r *ClusterReconciler) reloadNextInstanceCertificates(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	rotation := cluster.Status.CertificateRotation.DeepCopy()
+	if rotation.TargetInstance != "" {
+		rotation.ReloadedInstances = append(rotation.ReloadedInstances, rotation.TargetInstance)
+		rotation.TargetInstance = ""
+	}
+
+	if len(rotation.PendingInstances) > 0 {
+		rotation.Phase = apiv1.CertificateRotationPhaseReloadingInstances
+		rotation.TargetInstance = rotation.PendingInstances[0]
+		rotation.PendingInstances = rotation.PendingInstances[1:]
+		log.FromContext(ctx).Info("Reloading the certificates of the instance",
+			"instance", rotation.TargetInstance)
+	} else {
+		rotation.Phase = apiv1.CertificateRotationPhaseReloadingPoolers
+		rotation.PendingInstances = nil
+	}
+
+	if err := r.patchCertificateRotation(ctx, cluster, rotation); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: certificateRotationRequeueInterval}, nil
+}
+
+// reconcilePoolersCertificateReload requests the PgBouncer poolers of the
+// cluster to reload the new certificates and waits for every pooler
+// instance to complete it. When done, the previous CA certificates are
+// removed and the rotation is completed
+func (r *ClusterReconciler) reconcilePoolersCertificateReload(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+	rotation := cluster.Status.CertificateRotation
+
+	poolers, err := r.getCertificateReloadPoolers(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var waitingPoolers []string
+	for i := range poolers {
+		pooler := &poolers[i]
+		if pooler.Annotations[utils.PoolerCertificateReloadAnnotationName] != rotation.Fingerprint {
+			if err := r.setPoolerCertificateReload(ctx, pooler, rotation.Fingerprint); err != nil {
+				return ctrl.Result{}, err
+			}
+			waitingPoolers = append(waitingPoolers, pooler.Name)
+			continue
+		}
+
+		if int32(len(pooler.Status.CertificateReloadedInstances)) < pooler.Status.Instances {
+			waitingPoolers = append(waitingPoolers, pooler.Name)
+		}
+	}
+
+	if len(waitingPoolers) > 0 {
+		if !isCertificateRotationStepExpired(rotation) {
+			contextLogger.Debug("Waiting for the poolers to reload the new certificates",
+				"poolers", waitingPoolers)
+			return ctrl.Result{RequeueAfter: certificateRotationRequeueInterval}, nil
+		}
+
+		contextLogger.Warning("Timeout expired while waiting for the poolers to reload the new certificates",
+			"poolers", waitingPoolers)
+		r.Recorder.Eventf(cluster, "Warning", "CertificateRotationTimeout",
+			"Timeout expired while reloading the certificates of the poolers %v", waitingPoolers)
+	}
+
+	for i := range poolers {
+		if err := r.setPoolerCertificateReload(ctx, &poolers[i], ""); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.removePreviousCACertificates(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	completedRotation := rotation.DeepCopy()
+	completedRotation.Phase = apiv1.CertificateRotationPhaseCompleted
+	if err := r.patchCertificateRotation(ctx, cluster, completedRotation); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	contextLogger.Info("Completed the rotation of the TLS certificates")
+	r.Recorder.Event(cluster, "Normal", "CertificateRotation",
+		"Completed the rotation of the TLS certificates")
+	notifyLifecycleEvent(ctx, r.Client, r.Recorder, cluster, lifecycleevents.NewEvent(
+		cluster, apiv1.LifecycleEventTypeCertificateRotated, "Completed the rotation of the TLS certificates"))
+	return ctrl.Result{}, nil
+}
+
+// getCertificateReloadPoolers gets the PgBouncer poolers of the cluster
+func (r *ClusterReconciler) getCertificateReloadPoolers(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) ([]apiv1.Pooler, error) {
+	poolers, err := r.getClusterPoolers(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(poolers.Items, func(pooler apiv1.Pooler) bool {
+		return pooler.GetBackend() != apiv1.PoolerBackendPgBouncer
+	}), nil
+}
+
+// setPoolerCertificateReload requests the pooler to reload the
+// certificates identified by the given fingerprint, or removes the
+// request when the fingerprint is empty. The instances that already
+// reloaded the certificates are reset before a new request
+func (r *ClusterReconciler) setPoolerCertificateReload(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	fingerprint string,
+) error {
+	currentFingerprint, requested := pooler.Annotations[utils.PoolerCertificateReloadAnnotationName]
+	if (fingerprint == "" && !requested) || (requested && currentFingerprint == fingerprint) {
+		return nil
+	}
+
+	if fingerprint != "" && len(pooler.Status.CertificateReloadedInstances) > 0 {
+		origPooler := pooler.DeepCopy()
+		pooler.Status.CertificateReloadedInstances = nil
+		if err := r.Status().Patch(ctx, pooler, client.MergeFrom(origPooler)); err != nil {
+			return fmt.Errorf("while resetting the certificate reload status of pooler %s: %w", pooler.Name, err)
+		}
+	}
+
+	origPooler := pooler.DeepCopy()
+	if fingerprint == "" {
+		delete(pooler.Annotations, utils.PoolerCertificateReloadAnnotationName)
+	} else {
+		if pooler.Annotations == nil {
+			pooler.Annotations = make(map[string]string)
+		}
+		pooler.Annotations[utils.PoolerCertificateReloadAnnotationName] = fingerprint
+	}
+
+	if err := r.Patch(ctx, pooler, client.MergeFrom(origPooler)); err != nil {
+		return fmt.Errorf("while setting the certificate reload of pooler %s: %w", pooler.Name, err)
+	}
+
+	return nil
+}
+
+// removePreviousCACertificates removes the previous CA certificates from
+// the CA secrets managed by the operator, as every instance and pooler
+// is now using the certificates signed by the new CA
+func (r *ClusterReconciler) removePreviousCACertificates(ctx context.Context, cluster *apiv1.Cluster) error {
+	secretNames := []string{cluster.GetServerCASecretName(), cluster.GetClientCASecretName()}
+	for _, secretName := range slices.Compact(secretNames) {
+		var secret v1.Secret
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: secretName}, &secret)
+		if apierrs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if owner, ok := IsOwnedByCluster(&secret); !ok || owner != cluster.Name {
+			continue
+		}
+		if _, ok := secret.Data[certs.CAPreviousCertKey]; !ok {
+			continue
+		}
+
+		origSecret := secret.DeepCopy()
+		delete(secret.Data, certs.CAPreviousCertKey)
+		if err := r.Patch(ctx, &secret, client.MergeFrom(origSecret)); err != nil {
+			return fmt.Errorf("while removing the previous CA certificate from secret %s: %w", secretName, err)
+		}
+	}
+
+	return nil
+}
+
+// patchCertificateRotation stores the progress of the certificate rotation
+// in the cluster status, tracking the time of the last transition
+func (r *ClusterReconciler) patchCertificateRotation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	rotation *apiv1.CertificateRotationStatus,
+) error {
+	now := metav1.Now()
+	rotation.LastTransitionTime = &now
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.CertificateRotation = rotation
+	})
+}
+
+// getCertificateRotationOrder gets the order in which the instances load
+// the new certificates: the replicas first, sorted by name, and the
+// primary as last
+func getCertificateRotationOrder(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) []string {
+	result := make([]string, 0, len(instancesStatus.Items))
+	hasPrimary := false
+	for _, item := range instancesStatus.Items {
+		if item.Pod == nil {
+			continue
+		}
+		if item.Pod.Name == cluster.Status.CurrentPrimary {
+			hasPrimary = true
+			continue
+		}
+		result = append(result, item.Pod.Name)
+	}
+
+	slices.Sort(result)
+	if hasPrimary {
+		result = append(result, cluster.Status.CurrentPrimary)
+	}
+	return result
+}
+
+// getInstanceStatus gets the status of the instance with the given name
+func getInstanceStatus(instancesStatus postgres.PostgresqlStatusList, name string) *postgres.PostgresqlStatus {
+	for idx := range instancesStatus.Items {
+		item := &instancesStatus.Items[idx]
+		if item.Pod != nil && item.Pod.Name == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// isCertificateRotationStepExpired checks whether the current step of
+// the certificate rotation is taking longer than expected
+func isCertificateRotationStepExpired(rotation *apiv1.CertificateRotationStatus) bool {
+	return rotation.LastTransitionTime != nil &&
+		time.Since(rotation.LastTransitionTime.Time) > certificateRotationStepTimeout
+}
@@ -0,0 +1,201 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("certificate rotation", func() {
+	var (
+		env             *testingEnvironment
+		crReconciler    *ClusterReconciler
+		cluster         *apiv1.Cluster
+		pooler          *apiv1.Pooler
+		instancesStatus postgres.PostgresqlStatusList
+	)
+
+	newSecret := func(ctx context.Context, name string, data map[string][]byte) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster.Namespace},
+			Data:       data,
+		}
+		utils.SetAsOwnedBy(&secret.ObjectMeta, cluster.ObjectMeta, cluster.TypeMeta)
+		Expect(env.client.Create(ctx, secret)).To(Succeed())
+	}
+
+	getSecret := func(ctx context.Context, name string) *corev1.Secret {
+		var secret corev1.Secret
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, &secret)).
+			To(Succeed())
+		return &secret
+	}
+
+	getPooler := func(ctx context.Context) *apiv1.Pooler {
+		var result apiv1.Pooler
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(pooler), &result)).To(Succeed())
+		return &result
+	}
+
+	// renewCertificates replaces the CA and the server certificate,
+	// returning the fingerprints the instances report when loaded
+	renewCertificates := func(ctx context.Context) (string, string) {
+		caSecret := getSecret(ctx, cluster.GetServerCASecretName())
+		certs.ReplaceCACertificate(caSecret, []byte("server-ca-2"))
+		Expect(env.client.Update(ctx, caSecret)).To(Succeed())
+
+		serverSecret := getSecret(ctx, cluster.GetServerTLSSecretName())
+		serverSecret.Data[corev1.TLSCertKey] = []byte("server-2")
+		Expect(env.client.Update(ctx, serverSecret)).To(Succeed())
+
+		return certs.Fingerprint([]byte("server-2"), []byte("replication-1")),
+			certs.Fingerprint([]byte("client-ca-1"), []byte("server-ca-2\nserver-ca-1"))
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		env = buildTestEnvironment()
+		crReconciler = &ClusterReconciler{
+			Client: fakeClientWithIndexAdapter{
+				Client: env.clusterReconciler.Client,
+			},
+			Scheme:   env.clusterReconciler.Scheme,
+			Recorder: env.clusterReconciler.Recorder,
+		}
+
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+		})
+		pooler = newFakePooler(env.client, cluster)
+		pooler.Status.Instances = 1
+		Expect(env.client.Status().Update(ctx, pooler)).To(Succeed())
+
+		newSecret(ctx, cluster.GetServerTLSSecretName(), map[string][]byte{corev1.TLSCertKey: []byte("server-1")})
+		newSecret(ctx, cluster.GetReplicationSecretName(),
+			map[string][]byte{corev1.TLSCertKey: []byte("replication-1")})
+		newSecret(ctx, cluster.GetServerCASecretName(), map[string][]byte{certs.CACertKey: []byte("server-ca-1")})
+		newSecret(ctx, cluster.GetClientCASecretName(), map[string][]byte{certs.CACertKey: []byte("client-ca-1")})
+
+		instancesStatus = postgres.PostgresqlStatusList{}
+		for _, name := range []string{cluster.Name + "-1", cluster.Name + "-3", cluster.Name + "-2"} {
+			instancesStatus.Items = append(instancesStatus.Items, postgres.PostgresqlStatus{
+				Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			})
+		}
+	})
+
+	It("records the current certificates without rotating them", func(ctx context.Context) {
+		result, err := crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(cluster.Status.CertificateRotation).ToNot(BeNil())
+		Expect(cluster.Status.CertificateRotation.Phase).To(Equal(apiv1.CertificateRotationPhaseCompleted))
+	})
+
+	It("reloads the replicas, the primary and the poolers in order", func(ctx context.Context) {
+		_, err := crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+
+		certificatesFingerprint, caFingerprint := renewCertificates(ctx)
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		rotation := cluster.Status.CertificateRotation
+		Expect(rotation.Phase).To(Equal(apiv1.CertificateRotationPhaseDistributingCA))
+		Expect(rotation.PendingInstances).To(Equal([]string{
+			cluster.Name + "-2", cluster.Name + "-3", cluster.Name + "-1",
+		}))
+
+		By("waiting for the instances to load the CA bundle")
+		result, err := crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(certificateRotationRequeueInterval))
+		Expect(cluster.Status.CertificateRotation.Phase).To(Equal(apiv1.CertificateRotationPhaseDistributingCA))
+
+		for idx := range instancesStatus.Items {
+			instancesStatus.Items[idx].TLSCAFingerprint = caFingerprint
+		}
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.CertificateRotation.Phase).To(Equal(apiv1.CertificateRotationPhaseReloadingInstances))
+		Expect(cluster.Status.CertificateRotation.TargetInstance).To(Equal(cluster.Name + "-2"))
+
+		By("reloading the instances one at a time")
+		for _, name := range []string{cluster.Name + "-2", cluster.Name + "-3", cluster.Name + "-1"} {
+			Expect(cluster.Status.CertificateRotation.TargetInstance).To(Equal(name))
+			getInstanceStatus(instancesStatus, name).TLSCertificatesFingerprint = certificatesFingerprint
+			_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(cluster.Status.CertificateRotation.Phase).To(Equal(apiv1.CertificateRotationPhaseReloadingPoolers))
+		Expect(cluster.Status.CertificateRotation.ReloadedInstances).To(HaveLen(3))
+
+		By("reloading the poolers")
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		reloadingPooler := getPooler(ctx)
+		Expect(reloadingPooler.Annotations).To(
+			HaveKeyWithValue(utils.PoolerCertificateReloadAnnotationName, rotation.Fingerprint))
+
+		reloadingPooler.Status.CertificateReloadedInstances = []string{"pooler-pod-1"}
+		Expect(env.client.Status().Update(ctx, reloadingPooler)).To(Succeed())
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.CertificateRotation.Phase).To(Equal(apiv1.CertificateRotationPhaseCompleted))
+		Expect(getPooler(ctx).Annotations).ToNot(HaveKey(utils.PoolerCertificateReloadAnnotationName))
+		Expect(getSecret(ctx, cluster.GetServerCASecretName()).Data).ToNot(HaveKey(certs.CAPreviousCertKey))
+
+		By("not starting a new rotation when the previous CA is removed")
+		result, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(cluster.Status.CertificateRotation.Phase).To(Equal(apiv1.CertificateRotationPhaseCompleted))
+	})
+
+	It("skips the unavailable instances", func(ctx context.Context) {
+		_, err := crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		_, caFingerprint := renewCertificates(ctx)
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+
+		for idx := range instancesStatus.Items {
+			instancesStatus.Items[idx].TLSCAFingerprint = caFingerprint
+		}
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.CertificateRotation.TargetInstance).To(Equal(cluster.Name + "-2"))
+
+		getInstanceStatus(instancesStatus, cluster.Name+"-2").Error = errors.New("unreachable")
+		_, err = crReconciler.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.CertificateRotation.TargetInstance).To(Equal(cluster.Name + "-3"))
+		Expect(cluster.Status.CertificateRotation.ReloadedInstances).To(ConsistOf(cluster.Name + "-2"))
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+)
+
+// reconcileCloneSecret copies the certificates needed to connect to the
+// primary of the cluster being cloned into the namespace of the clone.
+// When the source cluster lives in another namespace, a BackupGrant of that
+// namespace needs to allow it to be cloned
+func (r *ClusterReconciler) reconcileCloneSecret(ctx context.Context, cluster *apiv1.Cluster) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	sourceKey := client.ObjectKey{
+		Namespace: cluster.GetCloneSourceNamespace(),
+		Name:      cluster.Spec.Bootstrap.Clone.Cluster,
+	}
+
+	if sourceKey.Namespace != cluster.Namespace {
+		var grants apiv1.BackupGrantList
+		if err := r.List(ctx, &grants, client.InNamespace(sourceKey.Namespace)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("while listing the backup grants: %w", err)
+		}
+		if grants.FindCloneGrant(cluster.Namespace, sourceKey.Name) == nil {
+			r.Recorder.Eventf(cluster, "Warning", "ErrorCloneNotGranted",
+				"No BackupGrant allows namespace \"%v\" to clone the cluster \"%v/%v\"",
+				cluster.Namespace, sourceKey.Namespace, sourceKey.Name)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+
+	var source apiv1.Cluster
+	if err := r.Get(ctx, sourceKey, &source); err != nil {
+		if apierrs.IsNotFound(err) {
+			r.Recorder.Eventf(cluster, "Warning", "ErrorNoCloneSource",
+				"Cluster \"%v/%v\" is missing", sourceKey.Namespace, sourceKey.Name)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("while getting the cluster to be cloned: %w", err)
+	}
+
+	if source.Status.Phase != apiv1.PhaseHealthy {
+		contextLogger.Info("The cluster to be cloned is not healthy, retrying",
+			"source", sourceKey, "phase", source.Status.Phase)
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	var serverCA, replication corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: sourceKey.Namespace,
+		Name:      source.GetServerCASecretName(),
+	}, &serverCA); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while getting the server CA of the cluster to be cloned: %w", err)
+	}
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: sourceKey.Namespace,
+		Name:      source.GetReplicationSecretName(),
+	}, &replication); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while getting the replication certificate of the cluster to be cloned: %w",
+			err)
+	}
+
+	// Only the public certificate of the CA is copied, while the
+	// client certificate allows connecting as the streaming replication user
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.GetCloneSecretName(),
+			Namespace: cluster.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			certs.CACertKey:         serverCA.Data[certs.CACertKey],
+			corev1.TLSCertKey:       replication.Data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey: replication.Data[corev1.TLSPrivateKeyKey],
+		},
+	}
+	cluster.SetInheritedDataAndOwnership(&secret.ObjectMeta)
+
+	if err := r.Create(ctx, secret); err != nil && !apierrs.IsAlreadyExists(err) {
+		return ctrl.Result{}, fmt.Errorf("while creating the clone secret: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleteCloneSecret removes the certificates used to connect to the
+// cluster being cloned as soon as the clone has been bootstrapped,
+// since they grant the replication access to the source cluster
+func (r *ClusterReconciler) deleteCloneSecret(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !cluster.IsBootstrappedWithClone() || cluster.Status.ReadyInstances == 0 {
+		return nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.GetCloneSecretName(),
+	}, &secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if _, owned := IsOwnedByCluster(&secret); !owned {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Removing the certificates used to clone the cluster",
+		"secretName", secret.Name)
+	return client.IgnoreNotFound(r.Delete(ctx, &secret))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cluster cloning", func() {
+	var (
+		source  *apiv1.Cluster
+		clone   *apiv1.Cluster
+		secrets []client.Object
+	)
+
+	BeforeEach(func() {
+		source = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-prod", Namespace: "production"},
+			Status:     apiv1.ClusterStatus{Phase: apiv1.PhaseHealthy},
+		}
+		clone = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       apiv1.ClusterKind,
+				APIVersion: apiv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-staging", Namespace: "staging"},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Clone: &apiv1.BootstrapClone{Cluster: "cluster-prod", Namespace: "production"},
+				},
+			},
+		}
+		secrets = []client.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-prod-ca", Namespace: "production"},
+				Data: map[string][]byte{
+					"ca.crt": []byte("ca-certificate"),
+					"ca.key": []byte("ca-private-key"),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-prod-replication", Namespace: "production"},
+				Data: map[string][]byte{
+					"tls.crt": []byte("client-certificate"),
+					"tls.key": []byte("client-private-key"),
+				},
+			},
+		}
+	})
+
+	newReconciler := func(objects ...client.Object) *ClusterReconciler {
+		return &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(objects...).
+				Build(),
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	It("waits for a grant when cloning a cluster of another namespace", func(ctx SpecContext) {
+		r := newReconciler(append(secrets, source)...)
+
+		res, err := r.reconcileCloneSecret(ctx, clone)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).ToNot(BeZero())
+
+		err = r.Get(ctx, client.ObjectKey{Namespace: "staging", Name: clone.GetCloneSecretName()}, &corev1.Secret{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("copies the certificates needed to connect to the source cluster", func(ctx SpecContext) {
+		grant := &apiv1.BackupGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging-clones", Namespace: "production"},
+			Spec: apiv1.BackupGrantSpec{
+				Namespaces: []string{"staging"},
+				Clusters:   []string{"cluster-prod"},
+			},
+		}
+		r := newReconciler(append(secrets, source, grant)...)
+
+		res, err := r.reconcileCloneSecret(ctx, clone)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+
+		var secret corev1.Secret
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "staging", Name: "cluster-staging-clone"}, &secret)).To(Succeed())
+		Expect(secret.Data).To(HaveLen(3))
+		Expect(secret.Data).To(HaveKeyWithValue("ca.crt", []byte("ca-certificate")))
+		Expect(secret.Data).To(HaveKeyWithValue("tls.crt", []byte("client-certificate")))
+		Expect(secret.Data).To(HaveKeyWithValue("tls.key", []byte("client-private-key")))
+
+		By("removing the certificates once the clone is bootstrapped", func() {
+			Expect(r.deleteCloneSecret(ctx, clone)).To(Succeed())
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(&secret), &corev1.Secret{})).To(Succeed())
+
+			clone.Status.ReadyInstances = 1
+			Expect(r.deleteCloneSecret(ctx, clone)).To(Succeed())
+			err := r.Get(ctx, client.ObjectKeyFromObject(&secret), &corev1.Secret{})
+			Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	It("waits for the source cluster to be healthy", func(ctx SpecContext) {
+		clone.Spec.Bootstrap.Clone.Namespace = ""
+		source.Namespace = "staging"
+		source.Status.Phase = apiv1.PhaseFirstPrimary
+		r := newReconciler(source)
+
+		res, err := r.reconcileCloneSecret(ctx, clone)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).ToNot(BeZero())
+	})
+})
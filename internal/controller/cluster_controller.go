@@ -22,9 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -34,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -54,9 +57,13 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/hibernation"
 	instanceReconciler "github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/instance"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/majorupgrade"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/replicaclusterswitch"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/registry"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -84,9 +91,41 @@ type ClusterReconciler struct {
 	Scheme          *runtime.Scheme
 	Recorder        record.EventRecorder
 	InstanceClient  remote.InstanceClient
+	WitnessClient   remote.WitnessClient
 	Plugins         repository.Interface
 
 	rolloutManager *rolloutManager.Manager
+
+	// imageRegistry gets the signatures of the PostgreSQL images
+	imageRegistry imageSignatureClient
+
+	// ldapConnector connects to the LDAP servers synchronized
+	// to the managed roles
+	ldapConnector ldapDirectoryConnector
+
+	// certificateProviderBuilder builds the external providers
+	// issuing the certificates of the clusters
+	certificateProviderBuilder certificateProviderBuilder
+
+	// primaryLSNs contains the last known position of the primary
+	// instance of each cluster, indexed by the cluster name
+	primaryLSNs sync.Map
+
+	// knownPrimaries contains the last known primary instance of
+	// each cluster, indexed by the cluster name
+	knownPrimaries sync.Map
+
+	// primaryDrains contains since when the node running the primary
+	// instance of each cluster is being drained, indexed by the cluster name
+	primaryDrains sync.Map
+
+	// rateLimiter limits the retries of the failed reconciliations,
+	// with a backoff specific to each cluster
+	rateLimiter *clusterRateLimiter
+
+	// priorities contains the priority of each cluster, used to
+	// choose which cluster to reconcile first
+	priorities clusterPriorities
 }
 
 // NewClusterReconciler creates a new ClusterReconciler initializing it
@@ -95,8 +134,10 @@ func NewClusterReconciler(
 	discoveryClient *discovery.DiscoveryClient,
 	plugins repository.Interface,
 ) *ClusterReconciler {
+	remoteClient := remote.NewClient()
 	return &ClusterReconciler{
-		InstanceClient:  remote.NewClient().Instance(),
+		InstanceClient:  remoteClient.Instance(),
+		WitnessClient:   remoteClient.Witness(),
 		DiscoveryClient: discoveryClient,
 		Client:          operatorclient.NewExtendedClient(mgr.GetClient()),
 		Scheme:          mgr.GetScheme(),
@@ -106,6 +147,10 @@ func NewClusterReconciler(
 			configuration.Current.GetClustersRolloutDelay(),
 			configuration.Current.GetInstancesRolloutDelay(),
 		),
+		imageRegistry:              registry.NewClient(nil),
+		ldapConnector:              connectLDAPDirectory,
+		certificateProviderBuilder: newCertificateProvider,
+		rateLimiter:                newClusterRateLimiter(configuration.Current),
 	}
 }
 
@@ -115,9 +160,11 @@ var ErrNextLoop = utils.ErrNextLoop
 // Alphabetical order to not repeat or miss permissions
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;patch
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;create;list;watch;delete;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;delete;patch;create;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;list;watch;delete;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;create;list;watch;delete;patch
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;delete;get;list;watch;update;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters/finalizers,verbs=update
@@ -137,9 +184,18 @@ var ErrNextLoop = utils.ErrNextLoop
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;watch;list;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=imagecatalogs,verbs=get;watch;list
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterimagecatalogs,verbs=get;watch;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backupgrants,verbs=get;watch;list
 
 // Reconcile is the operator reconcile loop
-func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := telemetry.Start(ctx, "cluster.reconcile",
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("cnpg.cluster.name", req.Name))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	contextLogger, ctx := log.SetupLogger(ctx)
 
 	contextLogger.Debug("Reconciliation loop start")
@@ -153,6 +209,13 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	if cluster == nil {
+		r.primaryLSNs.Delete(req.NamespacedName)
+		r.knownPrimaries.Delete(req.NamespacedName)
+		r.primaryDrains.Delete(req.NamespacedName)
+		r.priorities.remove(req)
+		if r.rateLimiter != nil {
+			r.rateLimiter.removeCluster(req)
+		}
 		if err := r.deleteDanglingMonitoringQueries(ctx, req.Namespace); err != nil {
 			contextLogger.Error(
 				err,
@@ -173,6 +236,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	ctx = cluster.SetInContext(ctx)
+	r.updateClusterBackoff(ctx, req, cluster)
+	r.priorities.set(req, cluster.Spec.Priority)
 
 	// Load the plugins required to bootstrap and reconcile this cluster
 	enabledPluginNames := apiv1.GetPluginConfigurationEnabledPluginNames(cluster.Spec.Plugins)
@@ -212,7 +277,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Run the inner reconcile loop. Translate any ErrNextLoop to an errorless return
 	result, err := r.reconcile(ctx, cluster)
 	if errors.Is(err, ErrNextLoop) {
-		return result, nil
+		return requeueOnFencingExpiration(cluster, result), nil
 	}
 	if errors.Is(err, utils.ErrTerminateLoop) {
 		return ctrl.Result{}, nil
@@ -220,7 +285,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return result, nil
+	return requeueOnFencingExpiration(cluster, result), nil
 }
 
 // Inner reconcile loop. Anything inside can require the reconciliation loop to stop by returning ErrNextLoop
@@ -233,6 +298,18 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.reconcilePauseStatus(ctx, cluster); err != nil {
+		if apierrs.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot update the pause status: %w", err)
+	}
+
+	if cluster.Spec.Paused {
+		contextLogger.Debug("Cluster paused, only refreshing its status")
+		return r.reconcilePausedCluster(ctx, cluster)
+	}
+
 	// IMPORTANT: the following call will delete conditions using
 	// invalid condition reasons.
 	//
@@ -253,6 +330,14 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, err
 	}
 
+	// Without the admission webhooks, the specification is validated here
+	if res, err := r.reconcileSpecValidation(ctx, cluster); res != nil || err != nil {
+		if res != nil {
+			return *res, err
+		}
+		return ctrl.Result{}, err
+	}
+
 	// Discover the image to be used and set it into the status
 	if result, err := r.reconcileImage(ctx, cluster); result != nil || err != nil {
 		if result != nil {
@@ -262,11 +347,23 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, fmt.Errorf("cannot set image name: %w", err)
 	}
 
+	if err := r.reconcilePGDataImageInfo(ctx, cluster); err != nil {
+		if apierrs.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot set the image of the data directory: %w", err)
+	}
+
 	// Ensure we load all the plugins that are required to reconcile this cluster
 	if err := r.updatePluginsStatus(ctx, cluster); err != nil {
 		return ctrl.Result{}, fmt.Errorf("cannot reconcile required plugins: %w", err)
 	}
 
+	// Preview the instance pod spec if requested
+	if err := r.reconcilePodSpecPreview(ctx, cluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot preview the instance pod spec: %w", err)
+	}
+
 	// Ensure we reconcile the orphan resources if present when we reconcile for the first time a cluster
 	if res, err := r.reconcileRestoredCluster(ctx, cluster); res != nil || err != nil {
 		if res != nil {
@@ -275,6 +372,14 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, fmt.Errorf("cannot reconcile restored Cluster: %w", err)
 	}
 
+	// Lift the fencing of the instances whose fencing expired
+	if err := r.reconcileFencingExpiration(ctx, cluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot lift expired fencing: %w", err)
+	}
+
+	// Collect the manual changes to the managed resources, if requested
+	ctx, driftReport := newDriftContext(ctx, cluster)
+
 	// Ensure we have the required global objects
 	if err := r.createPostgresClusterObjects(ctx, cluster); err != nil {
 		if errors.Is(err, ErrNextLoop) {
@@ -323,6 +428,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 	}
 
+	// Notifies the promotion of a new primary instance, if any
+	r.reconcilePromotionEvent(ctx, cluster)
+
 	if cluster.ShouldPromoteFromReplicaCluster() {
 		if !(cluster.Status.Phase == apiv1.PhaseReplicaClusterPromotion ||
 			cluster.Status.Phase == apiv1.PhaseUnrecoverable) {
@@ -348,7 +456,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 	instancesStatus := r.InstanceClient.GetStatusFromInstances(ctx, resources.instances)
 
 	// we update all the cluster status fields that require the instances status
-	if err := r.updateClusterStatusThatRequiresInstancesState(ctx, cluster, instancesStatus); err != nil {
+	if err := r.updateClusterStatusThatRequiresInstancesState(
+		ctx, cluster, instancesStatus, resources.pvcs.Items,
+	); err != nil {
 		if apierrs.IsConflict(err) {
 			contextLogger.Debug("Conflict error while reconciling cluster status and instance state",
 				"error", err)
@@ -357,6 +467,19 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, fmt.Errorf("cannot update the instances status on the cluster: %w", err)
 	}
 
+	if err := r.reconcileDriftDetection(ctx, cluster, driftReport, resources, instancesStatus); err != nil {
+		if apierrs.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot report the manual changes to the managed resources: %w", err)
+	}
+
+	// Coordinates the staged rotation of the TLS certificates
+	certificateRotationResult, err := r.reconcileCertificateRotation(ctx, cluster, instancesStatus)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot rotate the TLS certificates: %w", err)
+	}
+
 	// If a Pod loses connectivity, the operator will fail over but the faulty
 	// Pod would not receive a change of its role from primary to replica.
 	//
@@ -396,6 +519,15 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, err
 	}
 
+	if err := instanceReconciler.ReconcileReplicaLagLabels(
+		ctx,
+		r.Client,
+		cluster,
+		instancesStatus,
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := persistentvolumeclaim.ReconcileSerialAnnotation(
 		ctx,
 		r.Client,
@@ -421,10 +553,22 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, registerPhaseErr
 	}
 
+	if err := r.reconcileStorageAutoResize(ctx, cluster, resources.pvcs.Items, instancesStatus); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot automatically resize the volumes: %w", err)
+	}
+
 	if res, err := r.ensureNoFailoverOnFullDisk(ctx, cluster, instancesStatus); err != nil || !res.IsZero() {
 		return res, err
 	}
 
+	if err := r.reconcileSplitBrain(ctx, cluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot reconcile the split-brain status: %w", err)
+	}
+
+	if err := r.reconcileWALSpaceFencing(ctx, cluster, instancesStatus); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot protect the WAL volume of the primary instance: %w", err)
+	}
+
 	if res, err := replicaclusterswitch.Reconcile(
 		ctx, r.Client, cluster, r.InstanceClient, instancesStatus); res != nil || err != nil {
 		if res != nil {
@@ -479,6 +623,22 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return *result, err
 	}
 
+	// Major version upgrades need all the instances to be shut down
+	// and are handled before any rolling update could take place
+	if result, err := majorupgrade.Reconcile(
+		ctx,
+		r.Client,
+		cluster,
+		resources.instances.Items,
+		resources.pvcs.Items,
+		resources.jobs.Items,
+	); result != nil || err != nil {
+		if result != nil {
+			return *result, err
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot reconcile the major version upgrade: %w", err)
+	}
+
 	// We have already updated the status in updateResourceStatus call,
 	// so we need to issue an extra update when the OnlineUpdateEnabled changes.
 	// It's okay because it should not change often.
@@ -505,12 +665,46 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return *result, nil
 	}
 
+	if err := r.resumeDrainedPoolers(ctx, cluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot resume the poolers after the switchover: %w", err)
+	}
+
 	// Updates all the objects managed by the controller
 	res, err := r.reconcileResources(ctx, cluster, resources, instancesStatus)
 	if err != nil || !res.IsZero() {
 		return res, err
 	}
 
+	// Rotates the passwords of the managed roles, once the cluster is healthy
+	rotationResult, err := r.reconcileRolePasswordRotation(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot rotate the passwords of the managed roles: %w", err)
+	}
+
+	// Synchronizes the managed roles with the LDAP groups
+	ldapSyncResult, err := r.reconcileLDAPRoleSync(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot synchronize the managed roles with LDAP: %w", err)
+	}
+	if ldapSyncResult.RequeueAfter > 0 &&
+		(rotationResult.RequeueAfter == 0 || ldapSyncResult.RequeueAfter < rotationResult.RequeueAfter) {
+		rotationResult.RequeueAfter = ldapSyncResult.RequeueAfter
+	}
+	if certificateRotationResult.RequeueAfter > 0 &&
+		(rotationResult.RequeueAfter == 0 || certificateRotationResult.RequeueAfter < rotationResult.RequeueAfter) {
+		rotationResult.RequeueAfter = certificateRotationResult.RequeueAfter
+	}
+
+	// Executes the lifecycle hooks queued after the bootstrap or a failover
+	hooksResult, err := r.reconcileLifecycleHooks(ctx, cluster, instancesStatus)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot execute the lifecycle hooks: %w", err)
+	}
+	if hooksResult.RequeueAfter > 0 &&
+		(rotationResult.RequeueAfter == 0 || hooksResult.RequeueAfter < rotationResult.RequeueAfter) {
+		rotationResult.RequeueAfter = hooksResult.RequeueAfter
+	}
+
 	// Calls post-reconcile hooks
 	if hookResult := postReconcilePluginHooks(ctx, cluster, cluster); hookResult.Err != nil ||
 		!hookResult.Result.IsZero() {
@@ -519,7 +713,12 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return hookResult.Result, hookResult.Err
 	}
 
-	return setStatusPluginHook(ctx, r.Client, getPluginClientFromContext(ctx), cluster)
+	res, err = setStatusPluginHook(ctx, r.Client, getPluginClientFromContext(ctx), cluster)
+	if err != nil || !res.IsZero() {
+		return res, err
+	}
+
+	return rotationResult, nil
 }
 
 func (r *ClusterReconciler) ensureNoFailoverOnFullDisk(
@@ -594,6 +793,31 @@ func (r *ClusterReconciler) handleSwitchover(
 			contextLogger.Info("Waiting for all WAL receivers to be down to elect a new primary")
 			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		}
+		if errors.Is(err, ErrPrimaryReachableFromWitness) || errors.Is(err, ErrWitnessUnavailable) {
+			contextLogger.Info("The witness doesn't confirm that the current primary is unreachable, "+
+				"suspending the failover", "reason", err.Error())
+			return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if errors.Is(err, ErrWaitingForFailoverApproval) {
+			contextLogger.Info("Waiting for the user to approve the failover")
+			return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if errors.Is(err, ErrNoPrimaryZoneCandidate) {
+			contextLogger.Info("Waiting for an instance to run in the zones allowed to host the primary")
+			return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if errors.Is(err, ErrWaitingForEvictionDelay) {
+			contextLogger.Info("Waiting for the eviction delay to expire before switching over")
+			return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if errors.Is(err, ErrWaitingForManualSwitchover) {
+			contextLogger.Info("Waiting for the user to switch over the primary running on a drained node")
+			return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		if errors.Is(err, errWaitingForLifecycleHooks) {
+			contextLogger.Info("Waiting for the lifecycle hooks to be completed before the switchover")
+			return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
 		contextLogger.Info("Cannot update target primary: operation cannot be fulfilled. "+
 			"An immediate retry will be scheduled",
 			"error", err)
@@ -609,8 +833,9 @@ func (r *ClusterReconciler) handleSwitchover(
 	// Primary is healthy, No switchover in progress.
 	// If we have a currentPrimaryFailingSince timestamp, let's unset it.
 	if cluster.Status.CurrentPrimaryFailingSinceTimestamp != "" {
+		origCluster := cluster.DeepCopy()
 		cluster.Status.CurrentPrimaryFailingSinceTimestamp = ""
-		if err := r.Status().Update(ctx, cluster); err != nil {
+		if err := status.PatchIfChanged(ctx, r.Client, cluster, origCluster); err != nil {
 			return nil, err
 		}
 	}
@@ -645,8 +870,10 @@ func (r *ClusterReconciler) setDefaults(ctx context.Context, cluster *apiv1.Clus
 	originCluster := cluster.DeepCopy()
 	cluster.SetDefaults()
 	if !reflect.DeepEqual(originCluster.Spec, cluster.Spec) {
-		contextLogger.Info("Admission controllers (webhooks) appear to have been disabled. " +
-			"Please enable them for this object/namespace")
+		if !configuration.Current.DisableWebhooks {
+			contextLogger.Info("Admission controllers (webhooks) appear to have been disabled. " +
+				"Please enable them for this object/namespace")
+		}
 		err := r.Patch(ctx, cluster, client.MergeFrom(originCluster))
 		if err != nil {
 			return err
@@ -737,7 +964,7 @@ func (r *ClusterReconciler) reconcileResources(
 	}
 
 	// If we still need more instances, we need to wait before setting healthy status
-	if instancesStatus.InstancesReportingStatus() != cluster.Spec.Instances {
+	if instancesStatus.InstancesReportingStatus() != getDesiredInstances(cluster, resources.pvcs.Items) {
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
 	}
 
@@ -753,6 +980,11 @@ func (r *ClusterReconciler) reconcileResources(
 
 	r.cleanupCompletedJobs(ctx, resources.jobs)
 
+	// The scheduled hibernation needs to be started when its window opens
+	if next, found := cluster.GetNextHibernationTransition(time.Now()); found {
+		return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -901,7 +1133,8 @@ func (r *ClusterReconciler) reconcilePods(
 	}
 
 	// Are there missing nodes? Let's create one
-	if cluster.Status.Instances < cluster.Spec.Instances &&
+	desiredInstances := getDesiredInstances(cluster, resources.pvcs.Items)
+	if cluster.Status.Instances < desiredInstances &&
 		instancesStatus.InstancesReportingStatus() == cluster.Status.Instances {
 		newNodeSerial, err := r.generateNodeSerial(ctx, cluster)
 		if err != nil {
@@ -911,7 +1144,7 @@ func (r *ClusterReconciler) reconcilePods(
 	}
 
 	// Are there nodes to be removed? Remove one of them
-	if cluster.Status.Instances > cluster.Spec.Instances {
+	if cluster.Status.Instances > desiredInstances {
 		if err := r.scaleDownCluster(ctx, cluster, resources); err != nil {
 			return ctrl.Result{}, fmt.Errorf("cannot scale down cluster: %w", err)
 		}
@@ -928,7 +1161,11 @@ func (r *ClusterReconciler) reconcilePods(
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
 	}
 
-	return r.handleRollingUpdate(ctx, cluster, instancesStatus)
+	if res, err := r.handleRollingUpdate(ctx, cluster, instancesStatus); !res.IsZero() || err != nil {
+		return res, err
+	}
+
+	return r.reconcileStorageClassMigration(ctx, cluster, resources, instancesStatus)
 }
 
 func (r *ClusterReconciler) ensureHealthyPVCsAnnotation(
@@ -972,6 +1209,12 @@ func (r *ClusterReconciler) handleRollingUpdate(
 
 	// If we need to roll out a restart of any instance, this is the right moment
 	done, err := r.rolloutRequiredInstances(ctx, cluster, &instancesStatus)
+	if !errors.Is(err, errOutsideMaintenanceWindow) {
+		if err := r.clearPendingMaintenance(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	switch {
 	case errors.Is(err, errLogShippingReplicaElected):
 		contextLogger.Warning(
@@ -994,6 +1237,21 @@ func (r *ClusterReconciler) handleRollingUpdate(
 		}
 
 		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	case errors.Is(err, errWaitingForPoolerDrain):
+		contextLogger.Info("Waiting for the poolers to be paused before the switchover")
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	case errors.Is(err, errWaitingForLifecycleHooks):
+		contextLogger.Info("Waiting for the lifecycle hooks to be completed before the switchover")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	case errors.Is(err, errCanaryVerificationInProgress):
+		contextLogger.Info("Waiting for the canary replica to be verified before updating the other instances")
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	case errors.Is(err, errOutsideMaintenanceWindow):
+		requeueAfter, err := r.reportPendingMaintenance(ctx, cluster, instancesStatus)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	case err != nil:
 		return ctrl.Result{}, err
 	case done:
@@ -1035,11 +1293,22 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return err
 	}
 
+	options := controller.Options{
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		NewQueue: func(
+			controllerName string,
+			rateLimiter workqueue.TypedRateLimiter[ctrl.Request],
+		) workqueue.TypedRateLimitingInterface[ctrl.Request] {
+			return newClusterWorkQueue(controllerName, rateLimiter, &r.priorities)
+		},
+	}
+	if r.rateLimiter != nil {
+		options.RateLimiter = r.rateLimiter
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: maxConcurrentReconciles,
-		}).
-		For(&apiv1.Cluster{}).
+		WithOptions(options).
+		For(&apiv1.Cluster{}, builder.WithPredicates(r.recordClusterPriority())).
 		Named("cluster").
 		Owns(&corev1.Pod{}).
 		Owns(&batchv1.Job{}).
@@ -1075,7 +1344,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			handler.EnqueueRequestsFromMapFunc(r.mapClusterImageCatalogsToClusters()),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
-		Complete(r)
+		Complete(newShardedReconciler(r))
 }
 
 // createFieldIndexes creates the indexes needed by this controller
@@ -1158,7 +1427,7 @@ func (r *ClusterReconciler) createFieldIndexes(ctx context.Context, mgr ctrl.Man
 				return nil
 			}
 
-			return []string{pooler.Spec.Cluster.Name}
+			return []string{getPoolerClusterIndexValue(pooler)}
 		}); err != nil {
 		return err
 	}
@@ -1274,7 +1543,10 @@ func (r *ClusterReconciler) mapPoolersToClusters() handler.MapFunc {
 			return nil
 		}
 		var cluster apiv1.Cluster
-		clusterNamespacedName := types.NamespacedName{Namespace: pooler.Namespace, Name: pooler.Spec.Cluster.Name}
+		clusterNamespacedName := types.NamespacedName{
+			Namespace: pooler.GetClusterNamespace(),
+			Name:      pooler.Spec.Cluster.Name,
+		}
 		// get all the clusters handled by the operator in the secret namespaces
 		err := r.Get(ctx, clusterNamespacedName, &cluster)
 		if err != nil {
@@ -1351,9 +1623,10 @@ func filterClustersUsingConfigMap(
 func (r *ClusterReconciler) mapNodeToClusters() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		node := obj.(*corev1.Node)
-		// exit if the node is schedulable (e.g. not cordoned)
+		// exit if the node is schedulable (e.g. not cordoned
+		// nor tainted for deprovisioning)
 		// could be expanded here with other conditions (e.g. pressure or issues)
-		if !node.Spec.Unschedulable {
+		if !isNodeBeingDrained(node) {
 			return nil
 		}
 		var childPods corev1.PodList
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"slices"
@@ -39,10 +40,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
@@ -71,6 +75,11 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = r.reconcileWitness(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	err = r.createOrPatchServiceAccount(ctx, cluster)
 	if err != nil {
 		return err
@@ -86,6 +95,16 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = r.reconcileRecoveryBackupAccess(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.deleteCloneSecret(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	if !cluster.Spec.Monitoring.AreDefaultQueriesDisabled() {
 		err = r.createOrPatchDefaultMetrics(ctx, cluster)
 		if err != nil {
@@ -98,6 +117,17 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = createOrPatchPrometheusRule(
+		ctx, r.Client, r.DiscoveryClient, specs.NewClusterPrometheusRuleManager(cluster))
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileVerticalPodAutoscaler(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -248,10 +278,12 @@ func createOrPatchClusterCredentialSecret(
 
 	patchedSecret := currentSecret.DeepCopy()
 	utils.MergeObjectsMetadata(patchedSecret, proposed)
+	reconcileCredentialSecretDrift(ctx, patchedSecret, proposed)
 
-	// we cannot compare the data due to the password being randomly generated everytime
+	// we cannot compare the whole data due to the password being randomly generated everytime
 	if reflect.DeepEqual(patchedSecret.Labels, currentSecret.Labels) &&
-		reflect.DeepEqual(patchedSecret.Annotations, currentSecret.Annotations) {
+		reflect.DeepEqual(patchedSecret.Annotations, currentSecret.Annotations) &&
+		reflect.DeepEqual(patchedSecret.Data, currentSecret.Data) {
 		return nil
 	}
 
@@ -332,6 +364,7 @@ func (r *ClusterReconciler) reconcileManagedServices(ctx context.Context, cluste
 	if err != nil {
 		return err
 	}
+	managedServices = append(managedServices, specs.BuildInstanceServices(*cluster)...)
 	for idx := range managedServices {
 		if err := r.serviceReconciler(ctx, cluster, &managedServices[idx], true); err != nil {
 			return err
@@ -385,13 +418,21 @@ func (r *ClusterReconciler) serviceReconciler(
 		"updateStrategy", strategy,
 	)
 
+	desiredHash, err := annotateServiceDesiredHash(ctx, proposed)
+	if err != nil {
+		return err
+	}
+
 	var livingService corev1.Service
-	err := r.Client.Get(ctx, types.NamespacedName{Name: proposed.Name, Namespace: proposed.Namespace}, &livingService)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: proposed.Name, Namespace: proposed.Namespace}, &livingService)
 	if apierrs.IsNotFound(err) {
 		if !enabled {
 			return nil
 		}
 		contextLogger.Info("creating service")
+		if configuration.Current.EnableServerSideApply {
+			return resources.Apply(ctx, r.Client, proposed)
+		}
 		return r.Client.Create(ctx, proposed)
 	}
 	if err != nil {
@@ -411,12 +452,39 @@ func (r *ClusterReconciler) serviceReconciler(
 		contextLogger.Info("deleting service, due to not being managed anymore")
 		return r.Client.Delete(ctx, &livingService)
 	}
-	var shouldUpdate bool
+	// the hash of the desired state the living service was last aligned to
+	livingHash := livingService.Annotations[utils.CNPGHashAnnotationName]
+
+	var changedFields []string
 
 	// we ensure that the selector perfectly match
 	if !reflect.DeepEqual(proposed.Spec.Selector, livingService.Spec.Selector) {
 		livingService.Spec.Selector = proposed.Spec.Selector
-		shouldUpdate = true
+		changedFields = append(changedFields, "spec.selector")
+	}
+
+	// we ensure that the type of the externally reachable services matches,
+	// as switching between NodePort and LoadBalancer can be done in place
+	if isExternalServiceType(proposed.Spec.Type) && isExternalServiceType(livingService.Spec.Type) &&
+		proposed.Spec.Type != livingService.Spec.Type {
+		livingService.Spec.Type = proposed.Spec.Type
+		changedFields = append(changedFields, "spec.type")
+	}
+	if isExternalServiceType(proposed.Spec.Type) {
+		if proposed.Spec.ExternalTrafficPolicy != "" &&
+			proposed.Spec.ExternalTrafficPolicy != livingService.Spec.ExternalTrafficPolicy {
+			livingService.Spec.ExternalTrafficPolicy = proposed.Spec.ExternalTrafficPolicy
+			changedFields = append(changedFields, "spec.externalTrafficPolicy")
+		}
+		if !slices.Equal(proposed.Spec.LoadBalancerSourceRanges, livingService.Spec.LoadBalancerSourceRanges) {
+			livingService.Spec.LoadBalancerSourceRanges = proposed.Spec.LoadBalancerSourceRanges
+			changedFields = append(changedFields, "spec.loadBalancerSourceRanges")
+		}
+	}
+
+	// we ensure that the load balancing options match
+	if updateServiceRouting(&livingService, proposed) {
+		changedFields = append(changedFields, "load balancing options")
 	}
 
 	// we ensure we've some space to store the labels and the annotations
@@ -430,20 +498,34 @@ func (r *ClusterReconciler) serviceReconciler(
 	// we preserve existing labels/annotation that could be added by third parties
 	if !utils.IsMapSubset(livingService.Labels, proposed.Labels) {
 		utils.MergeMap(livingService.Labels, proposed.Labels)
-		shouldUpdate = true
+		changedFields = append(changedFields, "metadata.labels")
 	}
 
 	if !utils.IsMapSubset(livingService.Annotations, proposed.Annotations) {
 		utils.MergeMap(livingService.Annotations, proposed.Annotations)
-		shouldUpdate = true
+		changedFields = append(changedFields, "metadata.annotations")
 	}
 
-	if !shouldUpdate {
+	if len(changedFields) == 0 {
 		return nil
 	}
 
+	// the living service was aligned to the current desired state,
+	// so the differences are due to a manual change
+	if report := getDriftReport(ctx); report != nil && livingHash == desiredHash {
+		report.record(ctx, "Service", livingService.Name, changedFields...)
+		if !report.revert {
+			return nil
+		}
+	}
+
 	if strategy == apiv1.ServiceUpdateStrategyPatch {
 		contextLogger.Info("reconciling service")
+		if configuration.Current.EnableServerSideApply {
+			// the labels and annotations set by third parties are
+			// preserved, as they're owned by other field managers
+			return resources.Apply(ctx, r.Client, proposed)
+		}
 		// we update to ensure that we substitute the selectors
 		return r.Client.Update(ctx, &livingService)
 	}
@@ -456,6 +538,48 @@ func (r *ClusterReconciler) serviceReconciler(
 	return ErrNextLoop
 }
 
+// isExternalServiceType checks whether the service type exposes
+// the service outside the Kubernetes cluster
+func isExternalServiceType(serviceType corev1.ServiceType) bool {
+	return serviceType == corev1.ServiceTypeNodePort || serviceType == corev1.ServiceTypeLoadBalancer
+}
+
+// updateServiceRouting aligns the load balancing options of the living
+// service with the proposed ones, returning true if they changed
+func updateServiceRouting(livingService, proposed *corev1.Service) bool {
+	var changed bool
+
+	normalizeAffinity := func(affinity corev1.ServiceAffinity) corev1.ServiceAffinity {
+		if affinity == "" {
+			return corev1.ServiceAffinityNone
+		}
+		return affinity
+	}
+
+	sessionAffinity := normalizeAffinity(proposed.Spec.SessionAffinity)
+	if normalizeAffinity(livingService.Spec.SessionAffinity) != sessionAffinity {
+		livingService.Spec.SessionAffinity = sessionAffinity
+		changed = true
+	}
+
+	switch {
+	case sessionAffinity == corev1.ServiceAffinityNone && livingService.Spec.SessionAffinityConfig != nil:
+		livingService.Spec.SessionAffinityConfig = nil
+		changed = true
+	case proposed.Spec.SessionAffinityConfig != nil &&
+		!reflect.DeepEqual(proposed.Spec.SessionAffinityConfig, livingService.Spec.SessionAffinityConfig):
+		livingService.Spec.SessionAffinityConfig = proposed.Spec.SessionAffinityConfig
+		changed = true
+	}
+
+	if !reflect.DeepEqual(proposed.Spec.TrafficDistribution, livingService.Spec.TrafficDistribution) {
+		livingService.Spec.TrafficDistribution = proposed.Spec.TrafficDistribution
+		changed = true
+	}
+
+	return changed
+}
+
 // createOrPatchOwnedPodDisruptionBudget ensures that we have a PDB requiring to remove one node at a time
 func (r *ClusterReconciler) createOrPatchOwnedPodDisruptionBudget(
 	ctx context.Context,
@@ -475,6 +599,12 @@ func (r *ClusterReconciler) createOrPatchOwnedPodDisruptionBudget(
 
 		r.Recorder.Event(cluster, "Normal", "CreatingPodDisruptionBudget",
 			fmt.Sprintf("Creating PodDisruptionBudget %s", pdb.Name))
+		if configuration.Current.EnableServerSideApply {
+			if err := resources.Apply(ctx, r.Client, pdb); err != nil {
+				return fmt.Errorf("while applying PodDisruptionBudget: %w", err)
+			}
+			return nil
+		}
 		if err = r.Create(ctx, pdb); err != nil {
 			return fmt.Errorf("while creating PodDisruptionBudget: %w", err)
 		}
@@ -493,6 +623,13 @@ func (r *ClusterReconciler) createOrPatchOwnedPodDisruptionBudget(
 	r.Recorder.Event(cluster, "Normal", "UpdatingPodDisruptionBudget",
 		fmt.Sprintf("Updating PodDisruptionBudget %s", pdb.Name))
 
+	if configuration.Current.EnableServerSideApply {
+		if err := resources.Apply(ctx, r.Client, pdb); err != nil {
+			return fmt.Errorf("while applying PodDisruptionBudget: %w", err)
+		}
+		return nil
+	}
+
 	if err := r.Patch(ctx, patchedPdb, client.MergeFrom(&oldPdb)); err != nil {
 		return fmt.Errorf("while patching PodDisruptionBudget: %w", err)
 	}
@@ -1002,6 +1139,76 @@ func createOrPatchPodMonitor(
 	}
 }
 
+type prometheusRuleManager interface {
+	// IsPrometheusRuleEnabled returns a boolean indicating if the PrometheusRule should exists or not
+	IsPrometheusRuleEnabled() bool
+	// BuildPrometheusRule builds a new PrometheusRule object
+	BuildPrometheusRule() *monitoringv1.PrometheusRule
+}
+
+// createOrPatchPrometheusRule creates, updates or deletes the PrometheusRule
+// containing the alerts of the cluster
+func createOrPatchPrometheusRule(
+	ctx context.Context,
+	cli client.Client,
+	discoveryClient discovery.DiscoveryInterface,
+	manager prometheusRuleManager,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	havePrometheusRuleCRD, err := utils.PrometheusRuleExist(discoveryClient)
+	if err != nil {
+		return err
+	}
+
+	if !havePrometheusRuleCRD {
+		if manager.IsPrometheusRuleEnabled() {
+			contextLogger.Warning("PrometheusRule CRD not present. Cannot create the PrometheusRule object")
+		}
+		return nil
+	}
+
+	expectedPrometheusRule := manager.BuildPrometheusRule()
+	prometheusRule := &monitoringv1.PrometheusRule{}
+	if err := cli.Get(
+		ctx,
+		client.ObjectKeyFromObject(expectedPrometheusRule),
+		prometheusRule,
+	); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while getting the prometheusrule: %w", err)
+		}
+		prometheusRule = nil
+	}
+
+	switch {
+	case !manager.IsPrometheusRuleEnabled() && prometheusRule == nil:
+		return nil
+	case !manager.IsPrometheusRuleEnabled() && prometheusRule != nil:
+		contextLogger.Info("Deleting PrometheusRule")
+		if err := cli.Delete(ctx, prometheusRule); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	case manager.IsPrometheusRuleEnabled() && prometheusRule == nil:
+		contextLogger.Debug("Creating PrometheusRule")
+		return cli.Create(ctx, expectedPrometheusRule)
+	default:
+		origPrometheusRule := prometheusRule.DeepCopy()
+		prometheusRule.Spec = expectedPrometheusRule.Spec
+		utils.MergeObjectsMetadata(prometheusRule, expectedPrometheusRule)
+
+		if reflect.DeepEqual(origPrometheusRule, prometheusRule) {
+			return nil
+		}
+
+		contextLogger.Debug("Patching PrometheusRule")
+		return cli.Patch(ctx, prometheusRule, client.MergeFrom(origPrometheusRule))
+	}
+}
+
 // createRole creates the role
 func (r *ClusterReconciler) createRole(ctx context.Context, cluster *apiv1.Cluster, backupOrigin *apiv1.Backup) error {
 	role := specs.CreateRole(*cluster, backupOrigin)
@@ -1032,8 +1239,9 @@ func (r *ClusterReconciler) createRoleBinding(ctx context.Context, cluster *apiv
 
 // generateNodeSerial extracts the first free node serial in this pods
 func (r *ClusterReconciler) generateNodeSerial(ctx context.Context, cluster *apiv1.Cluster) (int, error) {
+	origCluster := cluster.DeepCopy()
 	cluster.Status.LatestGeneratedNode++
-	if err := r.Status().Update(ctx, cluster); err != nil {
+	if err := status.PatchIfChanged(ctx, r.Client, cluster, origCluster); err != nil {
 		return 0, err
 	}
 
@@ -1098,6 +1306,14 @@ func (r *ClusterReconciler) createPrimaryInstance(
 		recoverySnapshot = persistentvolumeclaim.GetCandidateStorageSourceForPrimary(cluster, backup)
 	}
 
+	// When cloning another cluster, the certificates needed to
+	// connect to it are required by the bootstrap job
+	if cluster.IsBootstrappedWithClone() {
+		if res, err := r.reconcileCloneSecret(ctx, cluster); !res.IsZero() || err != nil {
+			return res, err
+		}
+	}
+
 	// Generate a new node serial
 	nodeSerial, err := r.generateNodeSerial(ctx, cluster)
 	if err != nil {
@@ -1121,6 +1337,8 @@ func (r *ClusterReconciler) createPrimaryInstance(
 
 	isBootstrappingFromRecovery := cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.Recovery != nil
 	isBootstrappingFromBaseBackup := cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.PgBaseBackup != nil
+	isBootstrappingFromClone := cluster.IsBootstrappedWithClone()
+	isBootstrappingFromMigration := cluster.IsBootstrappedWithMigration()
 	switch {
 	case isBootstrappingFromRecovery && recoverySnapshot != nil:
 		metadata, err := persistentvolumeclaim.GetSourceMetadataOrNil(
@@ -1143,6 +1361,15 @@ func (r *ClusterReconciler) createPrimaryInstance(
 		r.Recorder.Event(cluster, "Normal", "CreatingInstance", "Primary instance (from physical backup)")
 		job = specs.CreatePrimaryJobViaPgBaseBackup(*cluster, nodeSerial)
 
+	case isBootstrappingFromClone:
+		r.Recorder.Eventf(cluster, "Normal", "CreatingInstance",
+			"Primary instance (clone of %v/%v)", cluster.GetCloneSourceNamespace(), cluster.Spec.Bootstrap.Clone.Cluster)
+		job = specs.CreatePrimaryJobViaPgBaseBackup(*cluster, nodeSerial)
+
+	case isBootstrappingFromMigration:
+		r.Recorder.Event(cluster, "Normal", "CreatingInstance", "Primary instance (migration)")
+		job = specs.CreatePrimaryJobViaMigration(*cluster, nodeSerial)
+
 	default:
 		r.Recorder.Event(cluster, "Normal", "CreatingInstance", "Primary instance (initdb)")
 		job = specs.CreatePrimaryJobViaInitdb(*cluster, nodeSerial)
@@ -1159,6 +1386,10 @@ func (r *ClusterReconciler) createPrimaryInstance(
 		return ctrl.Result{}, err
 	}
 
+	if err = r.queueLifecycleHooks(ctx, cluster, cluster.GetBootstrapLifecycleHookEvent(), podName); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	err = r.RegisterPhase(ctx, cluster, apiv1.PhaseFirstPrimary,
 		fmt.Sprintf("Creating primary instance %v", podName))
 	if err != nil {
@@ -1194,18 +1425,27 @@ func (r *ClusterReconciler) createPrimaryInstance(
 
 // getOriginBackup gets the backup that is used to bootstrap a new PostgreSQL cluster
 func (r *ClusterReconciler) getOriginBackup(ctx context.Context, cluster *apiv1.Cluster) (*apiv1.Backup, error) {
-	if cluster.Spec.Bootstrap == nil ||
-		cluster.Spec.Bootstrap.Recovery == nil ||
-		cluster.Spec.Bootstrap.Recovery.Backup == nil {
+	backupObjectKey := cluster.GetRecoveryBackupKey()
+	if backupObjectKey == nil {
 		return nil, nil
 	}
 
-	var backup apiv1.Backup
-	backupObjectKey := client.ObjectKey{
-		Namespace: cluster.Namespace,
-		Name:      cluster.Spec.Bootstrap.Recovery.Backup.Name,
+	if cluster.IsRecoveringFromAnotherNamespace() {
+		grant, err := r.getRecoveryBackupGrant(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if grant == nil {
+			r.Recorder.Eventf(cluster, "Warning", "ErrorBackupNotGranted",
+				"No BackupGrant allows namespace \"%v\" to use the backup object \"%v/%v\"",
+				cluster.Namespace, backupObjectKey.Namespace, backupObjectKey.Name)
+
+			return nil, nil
+		}
 	}
-	err := r.Get(ctx, backupObjectKey, &backup)
+
+	var backup apiv1.Backup
+	err := r.Get(ctx, *backupObjectKey, &backup)
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			r.Recorder.Eventf(cluster, "Warning", "ErrorNoBackup",
@@ -1218,6 +1458,16 @@ func (r *ClusterReconciler) getOriginBackup(ctx context.Context, cluster *apiv1.
 		return nil, fmt.Errorf("cannot get the backup object: %w", err)
 	}
 
+	// Kubernetes doesn't allow creating a PVC from a snapshot
+	// living in another namespace
+	if cluster.IsRecoveringFromAnotherNamespace() && backup.Spec.Method == apiv1.BackupMethodVolumeSnapshot {
+		r.Recorder.Eventf(cluster, "Warning", "ErrorInvalidBackup",
+			"Backup object \"%v/%v\" is made of volume snapshots, which can't be restored in another namespace",
+			backupObjectKey.Namespace, backupObjectKey.Name)
+
+		return nil, nil
+	}
+
 	return &backup, nil
 }
 
@@ -1388,6 +1638,10 @@ func (r *ClusterReconciler) ensureInstancesAreCreated(
 			contextLogger.Info("Instance already exist, maybe the cache is stale", "instance", instanceToCreate.Name)
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
 		}
+		if errors.Is(err, cnpgiClient.ErrConflictingMutations) {
+			r.Recorder.Eventf(cluster, "Warning", "PluginConflict",
+				"Cannot create instance %v: %v", instanceToCreate.Name, err)
+		}
 
 		return ctrl.Result{}, fmt.Errorf("unable to create Pod: %w", err)
 	}
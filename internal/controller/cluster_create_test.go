@@ -816,6 +816,106 @@ var _ = Describe("CreateOrPatchPodMonitor", func() {
 	})
 })
 
+type mockPrometheusRuleManager struct {
+	isEnabled      bool
+	prometheusRule *v1.PrometheusRule
+}
+
+func (m *mockPrometheusRuleManager) IsPrometheusRuleEnabled() bool {
+	return m.isEnabled
+}
+
+func (m *mockPrometheusRuleManager) BuildPrometheusRule() *v1.PrometheusRule {
+	return m.prometheusRule
+}
+
+var _ = Describe("CreateOrPatchPrometheusRule", func() {
+	var (
+		fakeCli             k8client.Client
+		fakeDiscoveryClient discovery.DiscoveryInterface
+		manager             *mockPrometheusRuleManager
+	)
+
+	BeforeEach(func() {
+		manager = &mockPrometheusRuleManager{
+			isEnabled: true,
+			prometheusRule: &v1.PrometheusRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: v1.PrometheusRuleSpec{
+					Groups: []v1.RuleGroup{{Name: "cnpg-test"}},
+				},
+			},
+		}
+
+		fakeCli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).Build()
+
+		fakeDiscoveryClient = &fakediscovery.FakeDiscovery{
+			Fake: &testing.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "monitoring.coreos.com/v1",
+						APIResources: []metav1.APIResource{
+							{
+								Name:       "prometheusrules",
+								Kind:       "PrometheusRule",
+								Namespaced: true,
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	getPrometheusRule := func(ctx context.Context) (*v1.PrometheusRule, error) {
+		prometheusRule := &v1.PrometheusRule{}
+		err := fakeCli.Get(ctx, k8client.ObjectKeyFromObject(manager.prometheusRule), prometheusRule)
+		return prometheusRule, err
+	}
+
+	It("should create the PrometheusRule when it is enabled", func(ctx SpecContext) {
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+
+		prometheusRule, err := getPrometheusRule(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prometheusRule.Spec).To(Equal(manager.prometheusRule.Spec))
+	})
+
+	It("should not create the PrometheusRule when the CRD is not installed", func(ctx SpecContext) {
+		fakeDiscoveryClient = &fakediscovery.FakeDiscovery{Fake: &testing.Fake{}}
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+
+		_, err := getPrometheusRule(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should remove the PrometheusRule when it is disabled", func(ctx SpecContext) {
+		Expect(fakeCli.Create(ctx, manager.prometheusRule)).To(Succeed())
+
+		manager.isEnabled = false
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+
+		_, err := getPrometheusRule(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should patch the PrometheusRule with the updated alerts", func(ctx SpecContext) {
+		Expect(fakeCli.Create(ctx, manager.prometheusRule.DeepCopy())).To(Succeed())
+
+		manager.prometheusRule.Spec.Groups[0].Rules = []v1.Rule{
+			{Alert: "CNPGClusterDeadlocks", Expr: intstr.FromString("vector(1)")},
+		}
+		Expect(createOrPatchPrometheusRule(ctx, fakeCli, fakeDiscoveryClient, manager)).To(Succeed())
+
+		prometheusRule, err := getPrometheusRule(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prometheusRule.Spec.Groups[0].Rules).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("createOrPatchClusterCredentialSecret", func() {
 	const (
 		secretName = "test-secret"
@@ -1285,6 +1385,24 @@ var _ = Describe("Service Reconciling", func() {
 				Expect(updatedService.Labels).To(HaveKeyWithValue("custom-label", "value"))
 				Expect(updatedService.Annotations).To(HaveKeyWithValue("custom-annotation", "value"))
 			})
+
+			It("should update the load balancing options of the service", func() {
+				proposedService.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+				proposedService.Spec.TrafficDistribution = ptr.To(corev1.ServiceTrafficDistributionPreferClose)
+
+				err := reconciler.serviceReconciler(ctx, &cluster, proposedService, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				var updatedService corev1.Service
+				err = serviceClient.Get(ctx, types.NamespacedName{
+					Name:      proposedService.Name,
+					Namespace: proposedService.Namespace,
+				}, &updatedService)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedService.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+				Expect(updatedService.Spec.TrafficDistribution).To(
+					HaveValue(Equal(corev1.ServiceTrafficDistributionPreferClose)))
+			})
 		})
 	})
 
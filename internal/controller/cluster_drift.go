@@ -0,0 +1,262 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
+)
+
+// credentialSecretKeys are the keys of the generated credential secrets
+// that must match the cluster definition. The other ones, such as the
+// password, can be freely changed by the users
+var credentialSecretKeys = []string{"username", "user", "dbname"}
+
+// driftReport collects the manual changes to the resources managed by
+// the operator that are detected during a reconciliation loop
+type driftReport struct {
+	// revert is true when the manual changes should be reverted
+	revert bool
+
+	lock   sync.Mutex
+	drifts []string
+}
+
+// newDriftContext adds to the context a report of the manual changes
+// to the resources of the cluster, when the drift detection is enabled
+func newDriftContext(ctx context.Context, cluster *apiv1.Cluster) (context.Context, *driftReport) {
+	if !cluster.IsDriftDetectionEnabled() {
+		return ctx, nil
+	}
+
+	report := &driftReport{revert: cluster.ShouldRevertDrift()}
+	return context.WithValue(ctx, utils.DriftReportKey, report), report
+}
+
+// getDriftReport gets the report of the manual changes from the context,
+// or nil if the drift detection is not enabled
+func getDriftReport(ctx context.Context) *driftReport {
+	report, _ := ctx.Value(utils.DriftReportKey).(*driftReport)
+	return report
+}
+
+// record adds a manual change to the report
+func (report *driftReport) record(ctx context.Context, kind, name string, fields ...string) {
+	log.FromContext(ctx).Info("Detected a manual change to a managed resource",
+		"kind", kind,
+		"name", name,
+		"fields", fields,
+		"revert", report.revert)
+
+	report.lock.Lock()
+	defer report.lock.Unlock()
+	report.drifts = append(report.drifts, fmt.Sprintf("%s %s: %s", kind, name, strings.Join(fields, ", ")))
+}
+
+// getDrifts gets the manual changes collected in the report
+func (report *driftReport) getDrifts() []string {
+	report.lock.Lock()
+	defer report.lock.Unlock()
+
+	return slices.Clone(report.drifts)
+}
+
+// annotateServiceDesiredHash stores in the proposed service the hash of
+// its desired state, which is used to tell a manual change to the living
+// service from a change to the cluster definition. The hash is returned,
+// and is empty when the drift detection is not enabled
+func annotateServiceDesiredHash(ctx context.Context, proposed *corev1.Service) (string, error) {
+	if getDriftReport(ctx) == nil {
+		return "", nil
+	}
+
+	desiredHash, err := hash.ComputeHash(struct {
+		Labels      map[string]string
+		Annotations map[string]string
+		Spec        corev1.ServiceSpec
+	}{
+		Labels:      proposed.Labels,
+		Annotations: proposed.Annotations,
+		Spec:        proposed.Spec,
+	})
+	if err != nil {
+		return "", fmt.Errorf("while computing the hash of service %s: %w", proposed.Name, err)
+	}
+
+	if proposed.Annotations == nil {
+		proposed.Annotations = make(map[string]string)
+	}
+	proposed.Annotations[utils.CNPGHashAnnotationName] = desiredHash
+
+	return desiredHash, nil
+}
+
+// reconcileCredentialSecretDrift detects the manual changes to the keys of
+// a generated credential secret that must match the cluster definition,
+// reverting them in the passed secret when requested
+func reconcileCredentialSecretDrift(ctx context.Context, secret *corev1.Secret, proposed *corev1.Secret) {
+	report := getDriftReport(ctx)
+	if report == nil {
+		return
+	}
+
+	var changedFields []string
+	for _, key := range credentialSecretKeys {
+		expected, ok := proposed.StringData[key]
+		if !ok || string(secret.Data[key]) == expected {
+			continue
+		}
+
+		changedFields = append(changedFields, "data."+key)
+		if report.revert {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			secret.Data[key] = []byte(expected)
+		}
+	}
+
+	if len(changedFields) > 0 {
+		report.record(ctx, "Secret", secret.Name, changedFields...)
+	}
+}
+
+// getPodDrift gets the fields of an instance pod that have been manually
+// changed after its creation, comparing them with the stored PodSpec
+func getPodDrift(pod *corev1.Pod) []string {
+	podSpecAnnotation, ok := pod.Annotations[utils.PodSpecAnnotationName]
+	if !ok {
+		return nil
+	}
+
+	var storedPodSpec corev1.PodSpec
+	if err := json.Unmarshal([]byte(podSpecAnnotation), &storedPodSpec); err != nil {
+		return nil
+	}
+
+	var changedFields []string
+	compareImages := func(field string, storedContainers, livingContainers []corev1.Container) {
+		for _, living := range livingContainers {
+			idx := slices.IndexFunc(storedContainers, func(stored corev1.Container) bool {
+				return stored.Name == living.Name
+			})
+			if idx >= 0 && storedContainers[idx].Image != living.Image {
+				changedFields = append(changedFields, fmt.Sprintf("%s[%s].image", field, living.Name))
+			}
+		}
+	}
+	compareImages("spec.initContainers", storedPodSpec.InitContainers, pod.Spec.InitContainers)
+	compareImages("spec.containers", storedPodSpec.Containers, pod.Spec.Containers)
+
+	if !ptr.Equal(storedPodSpec.ActiveDeadlineSeconds, pod.Spec.ActiveDeadlineSeconds) {
+		changedFields = append(changedFields, "spec.activeDeadlineSeconds")
+	}
+
+	return changedFields
+}
+
+// getAlterSystemDrift gets the PostgreSQL parameters of the cluster
+// definition that have been overridden in an instance with ALTER SYSTEM
+func getAlterSystemDrift(cluster *apiv1.Cluster, alterSystemParameters []string) []string {
+	var overridden []string
+	for _, name := range alterSystemParameters {
+		if _, declared := cluster.Spec.PostgresConfiguration.Parameters[name]; declared {
+			overridden = append(overridden, "ALTER SYSTEM "+name)
+		}
+	}
+	return overridden
+}
+
+// reconcileDriftDetection completes the report of the manual changes with
+// the ones made to the instances, and reports them in the DriftDetected
+// condition of the cluster
+func (r *ClusterReconciler) reconcileDriftDetection(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	report *driftReport,
+	resources *managedResources,
+	instancesStatus postgres.PostgresqlStatusList,
+) error {
+	if report == nil {
+		if meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionDriftDetected)) == nil {
+			return nil
+		}
+		return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, string(apiv1.ConditionDriftDetected))
+		})
+	}
+
+	for idx := range resources.instances.Items {
+		pod := &resources.instances.Items[idx]
+		if changedFields := getPodDrift(pod); len(changedFields) > 0 {
+			report.record(ctx, "Pod", pod.Name, changedFields...)
+		}
+	}
+	for _, item := range instancesStatus.Items {
+		if item.Pod == nil {
+			continue
+		}
+		if overridden := getAlterSystemDrift(cluster, item.AlterSystemParameters); len(overridden) > 0 {
+			report.record(ctx, "Pod", item.Pod.Name, overridden...)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionDriftDetected),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonNoDrift),
+		Message: "The managed resources match their expected state",
+	}
+	if drifts := report.getDrifts(); len(drifts) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(apiv1.ConditionReasonDriftDetected)
+		if report.revert {
+			condition.Reason = string(apiv1.ConditionReasonDriftReverted)
+		}
+		condition.Message = strings.Join(drifts, "; ")
+	}
+
+	current := meta.FindStatusCondition(cluster.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status &&
+		current.Reason == condition.Reason && current.Message == condition.Message {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		r.Recorder.Event(cluster, "Warning", condition.Reason, condition.Message)
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	})
+}
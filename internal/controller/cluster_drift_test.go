@@ -0,0 +1,178 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drift detection", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{"work_mem": "8MB"},
+				},
+				DriftDetection: &apiv1.DriftDetectionConfiguration{
+					Policy: apiv1.DriftDetectionPolicyRevert,
+				},
+			},
+		}
+	})
+
+	newPod := func(storedImage, livingImage string) *corev1.Pod {
+		storedSpec, err := json.Marshal(corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "postgres", Image: storedImage}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster-example-1",
+				Annotations: map[string]string{utils.PodSpecAnnotationName: string(storedSpec)},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "postgres", Image: livingImage}},
+			},
+		}
+	}
+
+	It("doesn't collect a report when the drift detection is disabled", func(ctx SpecContext) {
+		cluster.Spec.DriftDetection = nil
+		driftCtx, report := newDriftContext(ctx, cluster)
+		Expect(report).To(BeNil())
+		Expect(getDriftReport(driftCtx)).To(BeNil())
+	})
+
+	It("detects the manual changes to the images of the pods", func() {
+		Expect(getPodDrift(newPod("postgres:17.2", "postgres:17.2"))).To(BeEmpty())
+		Expect(getPodDrift(newPod("postgres:17.2", "postgres:17.0"))).
+			To(Equal([]string{"spec.containers[postgres].image"}))
+	})
+
+	It("requires the rollout of the pods manually changed only when reverting", func() {
+		pod := newPod("postgres:17.2", "postgres:17.0")
+
+		podRollout, err := checkPodSpecHasDrifted(pod, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podRollout.required).To(BeTrue())
+
+		cluster.Spec.DriftDetection.Policy = apiv1.DriftDetectionPolicyReport
+		podRollout, err = checkPodSpecHasDrifted(pod, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podRollout.required).To(BeFalse())
+	})
+
+	It("detects the declared parameters overridden with ALTER SYSTEM", func() {
+		Expect(getAlterSystemDrift(cluster, []string{"random_page_cost", "work_mem"})).
+			To(Equal([]string{"ALTER SYSTEM work_mem"}))
+	})
+
+	It("reverts the manual changes to the credential secrets", func(ctx SpecContext) {
+		driftCtx, report := newDriftContext(ctx, cluster)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-app"},
+			Data: map[string][]byte{
+				"username": []byte("someone"),
+				"password": []byte("changed"),
+			},
+		}
+		proposed := &corev1.Secret{
+			StringData: map[string]string{"username": "app", "password": "generated"},
+		}
+
+		reconcileCredentialSecretDrift(driftCtx, secret, proposed)
+		Expect(secret.Data).To(HaveKeyWithValue("username", []byte("app")))
+		Expect(secret.Data).To(HaveKeyWithValue("password", []byte("changed")))
+		Expect(report.getDrifts()).To(Equal([]string{"Secret cluster-example-app: data.username"}))
+	})
+
+	It("only reports the manual changes to the credential secrets when requested", func(ctx SpecContext) {
+		cluster.Spec.DriftDetection.Policy = apiv1.DriftDetectionPolicyReport
+		driftCtx, report := newDriftContext(ctx, cluster)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-app"},
+			Data:       map[string][]byte{"username": []byte("someone")},
+		}
+		proposed := &corev1.Secret{StringData: map[string]string{"username": "app"}}
+
+		reconcileCredentialSecretDrift(driftCtx, secret, proposed)
+		Expect(secret.Data).To(HaveKeyWithValue("username", []byte("someone")))
+		Expect(report.getDrifts()).To(HaveLen(1))
+	})
+
+	It("reports the manual changes in the cluster conditions", func(ctx SpecContext) {
+		cluster.Spec.DriftDetection.Policy = apiv1.DriftDetectionPolicyReport
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: recorder,
+		}
+
+		driftCtx, report := newDriftContext(ctx, cluster)
+		resources := &managedResources{
+			instances: corev1.PodList{Items: []corev1.Pod{*newPod("postgres:17.2", "postgres:17.0")}},
+		}
+		instancesStatus := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:                   &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-2"}},
+					AlterSystemParameters: []string{"work_mem"},
+				},
+			},
+		}
+
+		Expect(reconciler.reconcileDriftDetection(driftCtx, cluster, report, resources, instancesStatus)).
+			To(Succeed())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionDriftDetected))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonDriftDetected)))
+		Expect(condition.Message).To(Equal(
+			"Pod cluster-example-1: spec.containers[postgres].image; Pod cluster-example-2: ALTER SYSTEM work_mem"))
+		Expect(recorder.Events).To(HaveLen(1))
+
+		By("clearing the condition when the drift detection is disabled", func() {
+			Expect(reconciler.reconcileDriftDetection(ctx, cluster, nil, resources, instancesStatus)).
+				To(Succeed())
+			Expect(meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionDriftDetected))).
+				To(BeNil())
+		})
+	})
+})
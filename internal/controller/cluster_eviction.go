@@ -0,0 +1,87 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// ErrWaitingForEvictionDelay is raised when the primary running on a node
+// being drained can't be switched over because the eviction delay hasn't
+// elapsed yet
+var ErrWaitingForEvictionDelay = fmt.Errorf("the primary is running on a drained node, " +
+	"waiting for the eviction delay before switching over")
+
+// ErrWaitingForManualSwitchover is raised when the primary running on a
+// node being drained can't be switched over because the eviction policy
+// requires the user to do it
+var ErrWaitingForManualSwitchover = fmt.Errorf("the primary is running on a drained node, " +
+	"waiting for the user to switch over")
+
+// primaryDrain is the node running the primary of a cluster that is
+// being drained, and since when the operator noticed it
+type primaryDrain struct {
+	node  string
+	since time.Time
+}
+
+// enforceEvictionPolicy checks if the eviction policy of the cluster allows
+// switching over the primary instance running on a node being drained
+func (r *ClusterReconciler) enforceEvictionPolicy(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	nodeName string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	switch cluster.GetEvictionPolicy() {
+	case apiv1.EvictionPolicyManual:
+		reason := fmt.Sprintf("The primary %v is running on the drained node %v and requires a manual switchover",
+			cluster.Status.CurrentPrimary, nodeName)
+		if cluster.Status.Phase != apiv1.PhaseWaitingForUser || cluster.Status.PhaseReason != reason {
+			r.Recorder.Event(cluster, "Warning", "SwitchoverRequired", reason)
+			if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForUser, reason); err != nil {
+				return err
+			}
+		}
+		return ErrWaitingForManualSwitchover
+
+	case apiv1.EvictionPolicyDelay:
+		key := client.ObjectKeyFromObject(cluster)
+		value, _ := r.primaryDrains.LoadOrStore(key, primaryDrain{node: nodeName, since: time.Now()})
+		drain := value.(primaryDrain)
+		if drain.node != nodeName {
+			drain = primaryDrain{node: nodeName, since: time.Now()}
+			r.primaryDrains.Store(key, drain)
+		}
+
+		if elapsed := time.Since(drain.since); elapsed < cluster.GetEvictionDelay() {
+			contextLogger.Debug("Waiting for the eviction delay to expire",
+				"node", nodeName, "elapsed", elapsed, "evictionDelay", cluster.GetEvictionDelay())
+			return ErrWaitingForEvictionDelay
+		}
+	}
+
+	return nil
+}
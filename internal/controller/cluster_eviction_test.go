@@ -0,0 +1,93 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Eviction policy", func() {
+	var (
+		cluster    *apiv1.Cluster
+		reconciler *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec:       apiv1.ClusterSpec{Instances: 3},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+	})
+
+	It("switches over immediately by default", func(ctx SpecContext) {
+		Expect(reconciler.enforceEvictionPolicy(ctx, cluster, "node-1")).To(Succeed())
+	})
+
+	It("waits for the eviction delay before switching over", func(ctx SpecContext) {
+		cluster.Spec.PodDisruptionBudget = &apiv1.PodDisruptionBudgetConfiguration{
+			EvictionPolicy: apiv1.EvictionPolicyDelay,
+			EvictionDelay:  60,
+		}
+		Expect(reconciler.enforceEvictionPolicy(ctx, cluster, "node-1")).
+			To(MatchError(ErrWaitingForEvictionDelay))
+
+		By("switching over when the delay is expired", func() {
+			reconciler.primaryDrains.Store(client.ObjectKeyFromObject(cluster), primaryDrain{
+				node:  "node-1",
+				since: time.Now().Add(-2 * time.Minute),
+			})
+			Expect(reconciler.enforceEvictionPolicy(ctx, cluster, "node-1")).To(Succeed())
+		})
+
+		By("restarting the delay when the primary is on a different node", func() {
+			Expect(reconciler.enforceEvictionPolicy(ctx, cluster, "node-2")).
+				To(MatchError(ErrWaitingForEvictionDelay))
+		})
+	})
+
+	It("waits for the user to switch over with the manual policy", func(ctx SpecContext) {
+		cluster.Spec.PodDisruptionBudget = &apiv1.PodDisruptionBudgetConfiguration{
+			EvictionPolicy: apiv1.EvictionPolicyManual,
+		}
+		Expect(reconciler.enforceEvictionPolicy(ctx, cluster, "node-1")).
+			To(MatchError(ErrWaitingForManualSwitchover))
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForUser))
+	})
+})
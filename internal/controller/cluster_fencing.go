@@ -0,0 +1,69 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileFencingExpiration lifts the fencing of the instances
+// that were fenced for a limited amount of time
+func (r *ClusterReconciler) reconcileFencingExpiration(ctx context.Context, cluster *apiv1.Cluster) error {
+	if _, ok := cluster.Annotations[utils.FencingExpirationAnnotation]; !ok {
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	expired, err := utils.RemoveExpiredFencing(cluster, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Fencing expired, lifting it", "instances", expired)
+	r.Recorder.Eventf(cluster, "Normal", "FencingExpired",
+		"Fencing expired, lifting it for: %v", strings.Join(expired, ", "))
+
+	return r.Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// requeueOnFencingExpiration makes sure the cluster is reconciled again
+// as soon as the fencing of one of its instances expires
+func requeueOnFencingExpiration(cluster *apiv1.Cluster, result ctrl.Result) ctrl.Result {
+	expiration, found := utils.GetNextFencingExpiration(cluster.Annotations)
+	if !found || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+
+	requeueAfter := time.Until(expiration) + time.Second
+	if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+		result.RequeueAfter = requeueAfter
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fencing expiration", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+				Annotations: map[string]string{
+					utils.FencedInstanceAnnotation: `["cluster-example-1","cluster-example-2"]`,
+				},
+			},
+		}
+	})
+
+	It("lifts the fencing once it expired", func(ctx SpecContext) {
+		cluster.Annotations[utils.FencingExpirationAnnotation] = `{"cluster-example-1":"` +
+			time.Now().Add(-time.Minute).UTC().Format(time.RFC3339) + `"}`
+		reconciler := &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		Expect(reconciler.reconcileFencingExpiration(ctx, cluster)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.IsInstanceFenced("cluster-example-1")).To(BeFalse())
+		Expect(updatedCluster.IsInstanceFenced("cluster-example-2")).To(BeTrue())
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.FencingExpirationAnnotation))
+	})
+
+	It("keeps the fencing until it expires", func(ctx SpecContext) {
+		cluster.Annotations[utils.FencingExpirationAnnotation] = `{"cluster-example-1":"` +
+			time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`
+		reconciler := &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		Expect(reconciler.reconcileFencingExpiration(ctx, cluster)).To(Succeed())
+		Expect(cluster.IsInstanceFenced("cluster-example-1")).To(BeTrue())
+
+		result := requeueOnFencingExpiration(cluster, ctrl.Result{})
+		Expect(result.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour+time.Second))
+
+		result = requeueOnFencingExpiration(cluster, ctrl.Result{RequeueAfter: 10 * time.Second})
+		Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+	})
+})
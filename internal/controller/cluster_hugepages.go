@@ -0,0 +1,87 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// setHugePagesCondition reports whether the nodes matching the node
+// selector of the cluster can allocate the huge pages requested by the
+// instances. Without this check, the instances would be stuck in the
+// pending state without a clear indication of the cause
+func setHugePagesCondition(cluster *apiv1.Cluster, nodes map[string]corev1.Node) {
+	name, hugePages := cluster.GetHugePages()
+	if name == "" {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, string(apiv1.ConditionHugePagesAvailable))
+		return
+	}
+
+	availableNodes := countNodesWithHugePages(cluster, nodes, name, hugePages)
+	if availableNodes == 0 {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:   string(apiv1.ConditionHugePagesAvailable),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonHugePagesUnavailable),
+			Message: fmt.Sprintf(
+				"No schedulable node can allocate %s of %s: configure vm.nr_hugepages on the nodes",
+				hugePages.String(), name),
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:    string(apiv1.ConditionHugePagesAvailable),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonHugePagesAvailable),
+		Message: fmt.Sprintf("%d nodes can allocate %s of %s", availableNodes, hugePages.String(), name),
+	})
+}
+
+// countNodesWithHugePages counts the schedulable nodes, matching the node
+// selector of the cluster, whose allocatable huge pages are enough for an
+// instance
+func countNodesWithHugePages(
+	cluster *apiv1.Cluster,
+	nodes map[string]corev1.Node,
+	name corev1.ResourceName,
+	hugePages resource.Quantity,
+) int {
+	selector := labels.SelectorFromSet(cluster.Spec.Affinity.NodeSelector)
+
+	result := 0
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+
+		allocatable, ok := node.Status.Allocatable[name]
+		if ok && allocatable.Cmp(hugePages) >= 0 {
+			result++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("huge pages condition", func() {
+	var (
+		cluster *apiv1.Cluster
+		nodes   map[string]corev1.Node
+	)
+
+	newNode := func(name string, hugePages string, nodeLabels map[string]string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{"hugepages-2Mi": resource.MustParse(hugePages)},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{"hugepages-2Mi": resource.MustParse("1Gi")},
+				},
+			},
+		}
+		nodes = map[string]corev1.Node{
+			"small": newNode("small", "512Mi", nil),
+			"large": newNode("large", "2Gi", map[string]string{"workload": "postgres"}),
+		}
+	})
+
+	getCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionHugePagesAvailable))
+	}
+
+	It("reports the nodes that can allocate the huge pages", func() {
+		setHugePagesCondition(cluster, nodes)
+		Expect(getCondition().Status).To(Equal(metav1.ConditionTrue))
+		Expect(getCondition().Message).To(HavePrefix("1 nodes"))
+	})
+
+	It("reports when no node matching the node selector has enough huge pages", func() {
+		cluster.Spec.Affinity.NodeSelector = map[string]string{"workload": "analytics"}
+		setHugePagesCondition(cluster, nodes)
+		Expect(getCondition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(getCondition().Reason).To(Equal(string(apiv1.ConditionReasonHugePagesUnavailable)))
+	})
+
+	It("removes the condition when the huge pages are not requested", func() {
+		setHugePagesCondition(cluster, nodes)
+		cluster.Spec.Resources.Limits = nil
+		setHugePagesCondition(cluster, nodes)
+		Expect(getCondition()).To(BeNil())
+	})
+})
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 )
 
 // reconcileImage sets the image inside the status, to be used by the following
@@ -41,8 +42,13 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 	oldCluster := cluster.DeepCopy()
 
 	// If ImageName is defined and different from the current image in the status, we update the status
-	if cluster.Spec.ImageName != "" && cluster.Status.Image != cluster.Spec.ImageName {
-		cluster.Status.Image = cluster.Spec.ImageName
+	if cluster.Spec.ImageName != "" && !isRequestedImage(cluster.Status.Image, cluster.Spec.ImageName) &&
+		!isMinorUpgradeBlocked(cluster, cluster.Spec.ImageName) {
+		verifiedImage, res, err := r.reconcileImageSignature(ctx, cluster, cluster.Spec.ImageName)
+		if verifiedImage == "" {
+			return res, err
+		}
+		cluster.Status.Image = verifiedImage
 		if err := r.Status().Patch(ctx, cluster, client.MergeFrom(oldCluster)); err != nil {
 			contextLogger.Error(
 				err,
@@ -112,9 +118,14 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 			"Selected major version is not available in the catalog")
 	}
 
-	// If the image is different, we set it into the cluster status
-	if cluster.Status.Image != catalogImage {
-		cluster.Status.Image = catalogImage
+	// If the image is different, we set it into the cluster status,
+	// unless its minor version update has been rolled back
+	if !isRequestedImage(cluster.Status.Image, catalogImage) && !isMinorUpgradeBlocked(cluster, catalogImage) {
+		verifiedImage, res, err := r.reconcileImageSignature(ctx, cluster, catalogImage)
+		if verifiedImage == "" {
+			return res, err
+		}
+		cluster.Status.Image = verifiedImage
 		patch := client.MergeFrom(oldCluster)
 		if err := r.Status().Patch(ctx, cluster, patch); err != nil {
 			patchBytes, _ := patch.Data(cluster)
@@ -129,6 +140,39 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 	return nil, nil
 }
 
+// reconcilePGDataImageInfo initializes the information about the image
+// that was used to create the data directory, and keeps it up to date
+// when a minor version update happens. Major version changes are
+// handled by the major upgrade reconciler instead
+func (r *ClusterReconciler) reconcilePGDataImageInfo(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Status.Image == "" {
+		return nil
+	}
+
+	requestedVersion, err := cluster.GetPostgresqlVersion()
+	if err != nil {
+		// The image tag doesn't carry a version, i.e. it has been
+		// referenced by digest: there's nothing we can track
+		log.FromContext(ctx).Debug("Cannot detect the requested PostgreSQL version", "error", err)
+		return nil
+	}
+
+	currentInfo := cluster.Status.PGDataImageInfo
+	switch {
+	case currentInfo == nil:
+	case currentInfo.MajorVersion == int(requestedVersion.Major()) && currentInfo.Image != cluster.Status.Image:
+	default:
+		return nil
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.PGDataImageInfo = &apiv1.ImageInfo{
+			Image:        cluster.Status.Image,
+			MajorVersion: int(requestedVersion.Major()),
+		}
+	})
+}
+
 func (r *ClusterReconciler) getClustersForImageCatalogsToClustersMapper(
 	ctx context.Context,
 	object metav1.Object,
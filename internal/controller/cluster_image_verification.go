@@ -0,0 +1,193 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/image/reference"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/registry"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// imageVerificationRetryInterval is how often the signature of the image
+// of a cluster that has not been created yet is verified again
+const imageVerificationRetryInterval = time.Minute
+
+// imageSignatureClient is the interface used to get the cosign
+// signatures of the PostgreSQL images from their registry
+type imageSignatureClient interface {
+	GetDigest(ctx context.Context, name, tag string) (string, error)
+	GetCosignSignatures(ctx context.Context, name, digest string) ([]registry.Signature, error)
+}
+
+// reconcileImageSignature verifies the signature of the image that is
+// going to be rolled out, returning the image to be rolled out pinned to
+// its verified digest, or an empty string when the rollout can't proceed.
+// A rejected image is reported in the ImageVerified condition, and the
+// cluster keeps running its current image
+func (r *ClusterReconciler) reconcileImageSignature(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	image string,
+) (string, *ctrl.Result, error) {
+	if cluster.Spec.ImageVerification == nil {
+		return image, nil, nil
+	}
+
+	verifier, err := r.getImageVerifier(ctx, cluster)
+	if err != nil {
+		return "", nil, fmt.Errorf("while loading the image verification policy: %w", err)
+	}
+
+	verifiedImage, err := r.verifyImageSignature(ctx, verifier, image)
+	if err == nil {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionImageVerified),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonSignatureVerified),
+			Message: fmt.Sprintf("The signature of %s has been verified", verifiedImage),
+		})
+		return verifiedImage, nil, nil
+	}
+	if !errors.Is(err, registry.ErrNoValidSignature) {
+		return "", nil, fmt.Errorf("while verifying the signature of %s: %w", image, err)
+	}
+
+	message := fmt.Sprintf("Image %s rejected: %v", image, err)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionImageVerified))
+	if condition == nil || condition.Message != message {
+		log.FromContext(ctx).Warning("Refusing to roll out an image without a trusted signature",
+			"image", image, "reason", err.Error())
+		r.Recorder.Event(cluster, "Warning", "ImageRejected", message)
+
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:    string(apiv1.ConditionImageVerified),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(apiv1.ConditionReasonSignatureRejected),
+				Message: message,
+			})
+		}); err != nil {
+			return "", nil, err
+		}
+	}
+
+	// Without a verified image there's nothing we can create
+	if cluster.Status.Image == "" {
+		return "", &ctrl.Result{RequeueAfter: imageVerificationRetryInterval}, nil
+	}
+
+	return "", nil, nil
+}
+
+// verifyImageSignature checks the signatures of the image against the
+// verification policy, returning the image pinned to the verified digest.
+// Images referenced by tag are verified using the digest the tag currently
+// points to, so that the instances run exactly the verified image even if
+// the tag is moved afterwards
+func (r *ClusterReconciler) verifyImageSignature(
+	ctx context.Context,
+	verifier *registry.Verifier,
+	image string,
+) (string, error) {
+	ref := reference.New(image)
+	if ref.Digest != "" {
+		digest := "sha256:" + ref.Digest
+		signatures, err := r.imageRegistry.GetCosignSignatures(ctx, ref.Name, digest)
+		if err != nil {
+			return "", err
+		}
+		return image, verifier.Verify(signatures, digest)
+	}
+
+	digest, err := r.imageRegistry.GetDigest(ctx, ref.Name, ref.Tag)
+	if err != nil {
+		return "", err
+	}
+	signatures, err := r.imageRegistry.GetCosignSignatures(ctx, ref.Name, digest)
+	if err != nil {
+		return "", err
+	}
+	if err := verifier.Verify(signatures, digest); err != nil {
+		return "", err
+	}
+
+	return image + "@" + digest, nil
+}
+
+// isRequestedImage checks whether the image in the status of the cluster
+// is the requested one, including when it has been pinned to the digest
+// verified by the image verification
+func isRequestedImage(statusImage, requestedImage string) bool {
+	if statusImage == requestedImage {
+		return true
+	}
+
+	return reference.New(requestedImage).Digest == "" && strings.HasPrefix(statusImage, requestedImage+"@sha256:")
+}
+
+// getImageVerifier builds the verifier of the image signatures from the
+// keys referenced in the cluster specification
+func (r *ClusterReconciler) getImageVerifier(ctx context.Context, cluster *apiv1.Cluster) (*registry.Verifier, error) {
+	configuration := cluster.Spec.ImageVerification
+	verifier := &registry.Verifier{}
+
+	for _, selector := range configuration.PublicKeys {
+		data, err := r.getConfigMapKey(ctx, cluster.Namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		key, err := registry.ParsePublicKey([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key in %s/%s: %w", selector.Name, selector.Key, err)
+		}
+		verifier.PublicKeys = append(verifier.PublicKeys, key)
+	}
+
+	return verifier, nil
+}
+
+func (r *ClusterReconciler) getConfigMapKey(
+	ctx context.Context,
+	namespace string,
+	selector apiv1.ConfigMapKeySelector,
+) (string, error) {
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, &configMap); err != nil {
+		return "", fmt.Errorf("while getting ConfigMap %s: %w", selector.Name, err)
+	}
+
+	data, ok := configMap.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("missing key %s in ConfigMap %s", selector.Key, selector.Name)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/registry"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeImageSignatureClient struct {
+	digest     string
+	signatures []registry.Signature
+}
+
+func (f *fakeImageSignatureClient) GetDigest(_ context.Context, _, _ string) (string, error) {
+	return f.digest, nil
+}
+
+func (f *fakeImageSignatureClient) GetCosignSignatures(
+	_ context.Context,
+	_, digest string,
+) ([]registry.Signature, error) {
+	if digest != f.digest {
+		return nil, nil
+	}
+	return f.signatures, nil
+}
+
+var _ = Describe("Image signature verification", func() {
+	const (
+		image  = "ghcr.io/cloudnative-pg/postgresql:16.4"
+		digest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	)
+
+	var (
+		cluster    *apiv1.Cluster
+		key        *ecdsa.PrivateKey
+		signatures *fakeImageSignatureClient
+		reconciler *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cosign", Namespace: "default"},
+			Data: map[string]string{
+				"cosign.pub": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			},
+		}
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				ImageName: image,
+				ImageVerification: &apiv1.ImageVerificationConfiguration{
+					PublicKeys: []apiv1.ConfigMapKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "cosign"}, Key: "cosign.pub"},
+					},
+				},
+			},
+		}
+
+		signatures = &fakeImageSignatureClient{digest: digest}
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster, configMap).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder:      record.NewFakeRecorder(10),
+			imageRegistry: signatures,
+		}
+	})
+
+	sign := func(signedDigest string) registry.Signature {
+		payload := []byte(fmt.Sprintf(
+			`{"critical":{"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"}}`,
+			signedDigest))
+		hash := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		Expect(err).ToNot(HaveOccurred())
+		return registry.Signature{Payload: payload, Signature: signature}
+	}
+
+	It("rolls out images signed with a trusted key", func(ctx SpecContext) {
+		signatures.signatures = []registry.Signature{sign(digest)}
+
+		verifiedImage, res, err := reconciler.reconcileImageSignature(ctx, cluster, image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(verifiedImage).To(Equal(image + "@" + digest))
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionImageVerified))).
+			To(BeTrue())
+	})
+
+	It("refuses to create a cluster with an unsigned image", func(ctx SpecContext) {
+		verifiedImage, res, err := reconciler.reconcileImageSignature(ctx, cluster, image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verifiedImage).To(BeEmpty())
+		Expect(res).ToNot(BeNil())
+		Expect(res.RequeueAfter).To(Equal(imageVerificationRetryInterval))
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		condition := meta.FindStatusCondition(updatedCluster.Status.Conditions, string(apiv1.ConditionImageVerified))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSignatureRejected)))
+		Expect(condition.Message).To(ContainSubstring("not signed"))
+	})
+
+	It("keeps running the current image when the new one is tampered", func(ctx SpecContext) {
+		signatures.signatures = []registry.Signature{sign("sha256:0000")}
+		cluster.Status.Image = "ghcr.io/cloudnative-pg/postgresql:16.3"
+		Expect(reconciler.Status().Update(ctx, cluster)).To(Succeed())
+
+		verifiedImage, res, err := reconciler.reconcileImageSignature(ctx, cluster, image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verifiedImage).To(BeEmpty())
+		Expect(res).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("ghcr.io/cloudnative-pg/postgresql:16.3"))
+	})
+
+	It("verifies images referenced by digest", func(ctx SpecContext) {
+		signatures.signatures = []registry.Signature{sign(digest)}
+		verifier, err := reconciler.getImageVerifier(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		verifiedImage, err := reconciler.verifyImageSignature(ctx, verifier, image+"@"+digest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verifiedImage).To(Equal(image + "@" + digest))
+		_, err = reconciler.verifyImageSignature(ctx, verifier, image+"@sha256:0000")
+		Expect(err).To(MatchError(registry.ErrNoValidSignature))
+	})
+
+	It("doesn't verify again the image pinned to its verified digest", func() {
+		Expect(isRequestedImage(image+"@"+digest, image)).To(BeTrue())
+		Expect(isRequestedImage(image+"@"+digest, image+"@"+digest)).To(BeTrue())
+		Expect(isRequestedImage(image+"@"+digest, "ghcr.io/cloudnative-pg/postgresql:16.5")).To(BeFalse())
+		Expect(isRequestedImage(image+"@"+digest, "ghcr.io/cloudnative-pg/postgresql:16")).To(BeFalse())
+	})
+})
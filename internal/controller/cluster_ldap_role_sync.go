@@ -0,0 +1,377 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// ldapTimeout is the time allowed to connect to the LDAP server and
+// to run each LDAP operation
+const ldapTimeout = 30 * time.Second
+
+// errLDAPPlaintext is returned when the connection to the LDAP server
+// is not encrypted and the user didn't allow it
+var errLDAPPlaintext = errors.New(
+	"refusing to connect to the LDAP server without TLS, use the ldaps scheme, " +
+		"enable StartTLS, or set allowInsecureConnection")
+
+// ldapGroupDirectory is the interface used to read the members of
+// the LDAP groups synchronized to the managed roles
+type ldapGroupDirectory interface {
+	SearchGroupMembers(baseDN, groupDN, attribute string) ([]string, error)
+	Close() error
+}
+
+// ldapConnectionConfig contains the parameters of the connection
+// to an LDAP server
+type ldapConnectionConfig struct {
+	// URL is the URL of the server, i.e. `ldaps://ldap.example.com:636`
+	URL string
+
+	// StartTLS upgrades the connection to TLS before binding
+	StartTLS bool
+
+	// TLSConfig is the configuration used to establish the TLS session
+	TLSConfig *tls.Config
+}
+
+// ldapDirectoryConnector connects and binds to an LDAP server
+type ldapDirectoryConnector func(
+	ctx context.Context,
+	config ldapConnectionConfig,
+	bindDN, bindPassword string,
+) (ldapGroupDirectory, error)
+
+// connectLDAPDirectory connects and binds to an LDAP server. An empty
+// bind DN makes an anonymous bind
+func connectLDAPDirectory(
+	_ context.Context,
+	config ldapConnectionConfig,
+	bindDN, bindPassword string,
+) (ldapGroupDirectory, error) {
+	conn, err := ldap.DialURL(config.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}),
+		ldap.DialWithTLSConfig(config.TLSConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(ldapTimeout)
+
+	if config.StartTLS {
+		if err := conn.StartTLS(config.TLSConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("while starting TLS: %w", err)
+		}
+	}
+
+	if bindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(bindDN, bindPassword)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("while binding to the LDAP server: %w", err)
+	}
+
+	return &ldapGroupDirectoryConn{Conn: conn}, nil
+}
+
+// ldapGroupDirectoryConn reads the members of the LDAP groups
+// through a connection to the LDAP server
+type ldapGroupDirectoryConn struct {
+	*ldap.Conn
+}
+
+// SearchGroupMembers gets the value of the passed attribute from the
+// entries below the base DN that are members of the passed group,
+// according to their `memberOf` attribute. Referrals to other servers
+// are not followed
+func (c *ldapGroupDirectoryConn) SearchGroupMembers(baseDN, groupDN, attribute string) ([]string, error) {
+	result, err := c.Search(ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(&(memberOf=%s)(%s=*))", ldap.EscapeFilter(groupDN), attribute),
+		[]string{attribute},
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		if value := entry.GetEqualFoldAttributeValue(attribute); value != "" {
+			members = append(members, value)
+		}
+	}
+	return members, nil
+}
+
+// reconcileLDAPRoleSync synchronizes the managed roles with the members
+// of the configured LDAP groups when the synchronization is due, and
+// returns when the reconciliation loop should run again for the next one.
+// Failures are reported in the cluster status and retried at the next
+// scheduled synchronization
+func (r *ClusterReconciler) reconcileLDAPRoleSync(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	if cluster.Spec.Managed == nil || cluster.Spec.Managed.LDAPRoleSync == nil || cluster.IsReplica() {
+		return ctrl.Result{}, nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	config := cluster.Spec.Managed.LDAPRoleSync
+
+	schedule, err := cron.Parse(config.Schedule)
+	if err != nil {
+		contextLogger.Error(err, "while parsing the LDAP role synchronization schedule")
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	var syncedRoles []string
+	if syncStatus := cluster.Status.ManagedRolesStatus.LDAPRoleSync; syncStatus != nil {
+		if syncStatus.LastSyncTime != nil {
+			if due := schedule.Next(syncStatus.LastSyncTime.Time); due.After(now) {
+				return ctrl.Result{RequeueAfter: due.Sub(now)}, nil
+			}
+		}
+		syncedRoles = syncStatus.Roles
+	}
+
+	var syncError string
+	members, err := r.getLDAPGroupMembers(ctx, cluster)
+	if err != nil {
+		contextLogger.Error(err, "while synchronizing the managed roles with the LDAP groups")
+		r.Recorder.Eventf(cluster, "Warning", "LDAPRoleSyncFailed",
+			"Cannot synchronize the managed roles with the LDAP groups: %v", err)
+		syncError = err.Error()
+	} else {
+		var roles []apiv1.RoleConfiguration
+		roles, syncedRoles = buildLDAPSyncedRoles(cluster.Spec.Managed.Roles, syncedRoles, members)
+		if !equality.Semantic.DeepEqual(roles, cluster.Spec.Managed.Roles) {
+			contextLogger.Info("Updating the managed roles from the LDAP groups", "roles", syncedRoles)
+			origCluster := cluster.DeepCopy()
+			cluster.Spec.Managed.Roles = roles
+			if err := r.Patch(ctx, cluster,
+				client.MergeFromWithOptions(origCluster, client.MergeFromWithOptimisticLock{})); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Event(cluster, "Normal", "LDAPRoleSync",
+				"Managed roles synchronized with the LDAP groups")
+		}
+	}
+
+	if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.ManagedRolesStatus.LDAPRoleSync = &apiv1.LDAPRoleSyncStatus{
+			Roles:        syncedRoles,
+			LastSyncTime: ptr.To(metav1.NewTime(now)),
+			Error:        syncError,
+		}
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: schedule.Next(now).Sub(now)}, nil
+}
+
+// getLDAPGroupMembers reads the members of the configured LDAP groups,
+// returning the roles each of them should be granted
+func (r *ClusterReconciler) getLDAPGroupMembers(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (map[string][]string, error) {
+	config := cluster.Spec.Managed.LDAPRoleSync
+
+	var bindPassword string
+	if config.BindPassword != nil {
+		password, err := r.getSecretKey(ctx, cluster.Namespace, config.BindPassword)
+		if err != nil {
+			return nil, err
+		}
+		bindPassword = string(password)
+	}
+
+	useLDAPS := config.Scheme == apiv1.LDAPSchemeLDAPS
+	if !useLDAPS && !config.TLS && !config.AllowInsecureConnection {
+		return nil, errLDAPPlaintext
+	}
+
+	scheme, port := apiv1.LDAPSchemeLDAP, config.Port
+	if useLDAPS {
+		scheme = apiv1.LDAPSchemeLDAPS
+	}
+	if port == 0 {
+		port = 389
+		if useLDAPS {
+			port = 636
+		}
+	}
+
+	connectionConfig := ldapConnectionConfig{
+		URL:       fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(config.Server, strconv.Itoa(port))),
+		StartTLS:  config.TLS,
+		TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12, ServerName: config.Server},
+	}
+	if config.CA != nil {
+		caCertificate, err := r.getSecretKey(ctx, cluster.Namespace, config.CA)
+		if err != nil {
+			return nil, err
+		}
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caCertificate) {
+			return nil, fmt.Errorf("no valid CA certificate found in secret %s", config.CA.Name)
+		}
+		connectionConfig.TLSConfig.RootCAs = caPool
+	}
+
+	directory, err := r.ldapConnector(ctx, connectionConfig, config.BindDN, bindPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = directory.Close()
+	}()
+
+	usernameAttribute := config.UsernameAttribute
+	if usernameAttribute == "" {
+		usernameAttribute = "uid"
+	}
+
+	members := make(map[string][]string)
+	for _, group := range config.Groups {
+		groupMembers, err := directory.SearchGroupMembers(config.BaseDN, group.DN, usernameAttribute)
+		if err != nil {
+			return nil, fmt.Errorf("while getting the members of group %s: %w", group.DN, err)
+		}
+		for _, member := range groupMembers {
+			members[member] = append(members[member], group.InRoles...)
+		}
+	}
+
+	for member, inRoles := range members {
+		members[member] = stringset.From(inRoles).ToSortedList()
+	}
+	return members, nil
+}
+
+// getSecretKey reads the content of a key of a secret
+func (r *ClusterReconciler) getSecretKey(
+	ctx context.Context,
+	namespace string,
+	selector *apiv1.SecretKeySelector,
+) ([]byte, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: selector.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("while getting secret %s: %w", selector.Name, err)
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("missing key %s in secret %s", selector.Key, selector.Name)
+	}
+	return value, nil
+}
+
+// buildLDAPSyncedRoles computes the managed roles given the members of
+// the LDAP groups. Group members without a managed role get a new login
+// role, and the roles previously added by the synchronization follow the
+// group membership, being disabled when their user leaves every group.
+// Roles defined by the user are never changed. The managed roles and the
+// names of the ones owned by the synchronization are returned
+func buildLDAPSyncedRoles(
+	roles []apiv1.RoleConfiguration,
+	syncedRoles []string,
+	members map[string][]string,
+) ([]apiv1.RoleConfiguration, []string) {
+	result := make([]apiv1.RoleConfiguration, len(roles))
+	existingRoles := stringset.New()
+	owned := stringset.From(syncedRoles)
+	newSyncedRoles := stringset.New()
+
+	for idx := range roles {
+		role := roles[idx].DeepCopy()
+		existingRoles.Put(role.Name)
+
+		if owned.Has(role.Name) {
+			newSyncedRoles.Put(role.Name)
+			if inRoles, isMember := members[role.Name]; isMember {
+				role.Login = true
+				role.InRoles = inRoles
+			} else {
+				role.Login = false
+			}
+		}
+
+		result[idx] = *role
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if existingRoles.Has(name) || postgres.IsRoleReserved(name) {
+			continue
+		}
+
+		result = append(result, apiv1.RoleConfiguration{
+			Name:            name,
+			Comment:         "Synchronized from LDAP",
+			Ensure:          apiv1.EnsurePresent,
+			ConnectionLimit: -1,
+			InRoles:         members[name],
+			Inherit:         ptr.To(true),
+			Login:           true,
+		})
+		newSyncedRoles.Put(name)
+	}
+
+	return result, newSyncedRoles.ToSortedList()
+}
@@ -0,0 +1,222 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeLDAPGroupDirectory struct {
+	members map[string][]string
+}
+
+func (f *fakeLDAPGroupDirectory) SearchGroupMembers(_, groupDN, _ string) ([]string, error) {
+	return f.members[groupDN], nil
+}
+
+func (f *fakeLDAPGroupDirectory) Close() error {
+	return nil
+}
+
+var _ = Describe("LDAP role synchronization", func() {
+	const (
+		dbaGroup       = "cn=dba,ou=groups,dc=example,dc=com"
+		analystsGroup  = "cn=analysts,ou=groups,dc=example,dc=com"
+		bindPassword   = "secret"
+		bindSecretName = "ldap-bind"
+	)
+
+	var (
+		cluster    *apiv1.Cluster
+		directory  *fakeLDAPGroupDirectory
+		reconciler *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: bindSecretName, Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte(bindPassword)},
+		}
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{Name: "app", Login: true},
+					},
+					LDAPRoleSync: &apiv1.LDAPRoleSyncConfiguration{
+						Server: "ldap.example.com",
+						TLS:    true,
+						BindDN: "cn=admin,dc=example,dc=com",
+						BindPassword: &apiv1.SecretKeySelector{
+							LocalObjectReference: apiv1.LocalObjectReference{Name: bindSecretName},
+							Key:                  "password",
+						},
+						BaseDN:   "dc=example,dc=com",
+						Schedule: "0 0 * * * *",
+						Groups: []apiv1.LDAPRoleSyncGroup{
+							{DN: dbaGroup, InRoles: []string{"pg_monitor", "dba"}},
+							{DN: analystsGroup, InRoles: []string{"analysts"}},
+						},
+					},
+				},
+			},
+		}
+
+		directory = &fakeLDAPGroupDirectory{
+			members: map[string][]string{
+				dbaGroup:      {"alice", "app"},
+				analystsGroup: {"alice", "bob", "cnpg_pooler_pgbouncer"},
+			},
+		}
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster, secret).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+			ldapConnector: func(
+				_ context.Context,
+				config ldapConnectionConfig,
+				_, password string,
+			) (ldapGroupDirectory, error) {
+				Expect(config.URL).To(Equal("ldap://ldap.example.com:389"))
+				Expect(config.StartTLS).To(BeTrue())
+				Expect(config.TLSConfig.ServerName).To(Equal("ldap.example.com"))
+				if password != bindPassword {
+					return nil, errors.New("invalid credentials")
+				}
+				return directory, nil
+			},
+		}
+	})
+
+	getCluster := func(ctx context.Context) *apiv1.Cluster {
+		var result apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &result)).To(Succeed())
+		return &result
+	}
+
+	It("adds a login role for each group member", func(ctx SpecContext) {
+		result, err := reconciler.reconcileLDAPRoleSync(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+		updatedCluster := getCluster(ctx)
+		roles := updatedCluster.Spec.Managed.Roles
+		Expect(roles).To(HaveLen(3))
+		Expect(roles[0]).To(Equal(apiv1.RoleConfiguration{Name: "app", Login: true}))
+		Expect(roles[1].Name).To(Equal("alice"))
+		Expect(roles[1].Login).To(BeTrue())
+		Expect(roles[1].InRoles).To(Equal([]string{"analysts", "dba", "pg_monitor"}))
+		Expect(roles[2].Name).To(Equal("bob"))
+		Expect(roles[2].InRoles).To(Equal([]string{"analysts"}))
+
+		syncStatus := updatedCluster.Status.ManagedRolesStatus.LDAPRoleSync
+		Expect(syncStatus).ToNot(BeNil())
+		Expect(syncStatus.Roles).To(Equal([]string{"alice", "bob"}))
+		Expect(syncStatus.LastSyncTime).ToNot(BeNil())
+		Expect(syncStatus.Error).To(BeEmpty())
+	})
+
+	It("waits for the next scheduled synchronization", func(ctx SpecContext) {
+		cluster.Status.ManagedRolesStatus.LDAPRoleSync = &apiv1.LDAPRoleSyncStatus{
+			LastSyncTime: ptr.To(metav1.Now()),
+		}
+
+		result, err := reconciler.reconcileLDAPRoleSync(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(getCluster(ctx).Spec.Managed.Roles).To(HaveLen(1))
+	})
+
+	It("reports the synchronization failures in the status", func(ctx SpecContext) {
+		cluster.Spec.Managed.LDAPRoleSync.BindPassword = nil
+
+		result, err := reconciler.reconcileLDAPRoleSync(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		updatedCluster := getCluster(ctx)
+		Expect(updatedCluster.Spec.Managed.Roles).To(HaveLen(1))
+		Expect(updatedCluster.Status.ManagedRolesStatus.LDAPRoleSync.Error).To(ContainSubstring("invalid credentials"))
+	})
+
+	It("refuses to connect without TLS unless explicitly allowed", func(ctx SpecContext) {
+		cluster.Spec.Managed.LDAPRoleSync.TLS = false
+
+		_, err := reconciler.reconcileLDAPRoleSync(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		updatedCluster := getCluster(ctx)
+		Expect(updatedCluster.Spec.Managed.Roles).To(HaveLen(1))
+		Expect(updatedCluster.Status.ManagedRolesStatus.LDAPRoleSync.Error).To(Equal(errLDAPPlaintext.Error()))
+	})
+})
+
+var _ = Describe("buildLDAPSyncedRoles", func() {
+	It("disables the synchronized roles whose users left the groups", func() {
+		roles := []apiv1.RoleConfiguration{
+			{Name: "alice", Login: true, InRoles: []string{"dba"}},
+			{Name: "bob", Login: true, InRoles: []string{"dba"}},
+		}
+
+		result, synced := buildLDAPSyncedRoles(roles, []string{"alice", "bob"}, map[string][]string{
+			"alice": {"analysts"},
+		})
+		Expect(synced).To(Equal([]string{"alice", "bob"}))
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Login).To(BeTrue())
+		Expect(result[0].InRoles).To(Equal([]string{"analysts"}))
+		Expect(result[1].Login).To(BeFalse())
+		Expect(result[1].InRoles).To(Equal([]string{"dba"}))
+	})
+
+	It("forgets the synchronized roles removed by the user", func() {
+		_, synced := buildLDAPSyncedRoles(nil, []string{"alice"}, nil)
+		Expect(synced).To(BeEmpty())
+	})
+
+	It("doesn't change the roles defined by the user", func() {
+		roles := []apiv1.RoleConfiguration{
+			{Name: "alice", Login: false, Superuser: true},
+		}
+
+		result, synced := buildLDAPSyncedRoles(roles, nil, map[string][]string{
+			"alice": {"analysts"},
+		})
+		Expect(synced).To(BeEmpty())
+		Expect(result).To(Equal(roles))
+	})
+})
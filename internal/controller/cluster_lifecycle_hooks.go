@@ -0,0 +1,351 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errWaitingForLifecycleHooks is raised when a switchover is waiting for
+// the preSwitchover lifecycle hooks to be completed
+var errWaitingForLifecycleHooks = errors.New("waiting for the lifecycle hooks to be completed before the switchover")
+
+// lifecycleHookRetryDelay is how long to wait before executing again
+// a failed lifecycle hook whose failure policy is `Fail`
+const lifecycleHookRetryDelay = time.Minute
+
+// execLifecycleHookCommand executes the command of an exec lifecycle
+// hook in the PostgreSQL container of the given Pod
+var execLifecycleHookCommand = func(
+	ctx context.Context,
+	pod *corev1.Pod,
+	timeout time.Duration,
+	command ...string,
+) error {
+	config := ctrl.GetConfigOrDie()
+	clientInterface := kubernetes.NewForConfigOrDie(config)
+
+	_, stderr, err := utils.ExecCommand(
+		ctx,
+		clientInterface,
+		config,
+		*pod,
+		specs.PostgresContainerName,
+		&timeout,
+		command...,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+
+	return nil
+}
+
+// queueLifecycleHooks records the lifecycle hooks triggered by the given
+// event, referring to the given instance, as waiting to be executed.
+// Only the last occurrence of the event is tracked for each hook
+func (r *ClusterReconciler) queueLifecycleHooks(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	event apiv1.LifecycleHookEvent,
+	instance string,
+) error {
+	hooks := cluster.GetLifecycleHooks(event)
+	if len(hooks) == 0 || cluster.IsReplica() {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Queueing the lifecycle hooks",
+		"event", event, "instance", instance, "hooks", len(hooks))
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		for _, hook := range hooks {
+			setLifecycleHookStatus(cluster, apiv1.LifecycleHookStatus{
+				Name:     hook.Name,
+				Event:    event,
+				Instance: instance,
+				Phase:    apiv1.LifecycleHookPhasePending,
+			})
+		}
+	})
+}
+
+// reconcileLifecycleHooks executes the lifecycle hooks queued after the
+// bootstrap of the cluster or after a failover, once the primary
+// instance is ready
+func (r *ClusterReconciler) reconcileLifecycleHooks(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	if len(cluster.Status.LifecycleHooks) == 0 ||
+		cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
+		return ctrl.Result{}, nil
+	}
+
+	var primaryPod *corev1.Pod
+	for _, item := range instancesStatus.Items {
+		if item.Pod != nil && item.Pod.Name == cluster.Status.CurrentPrimary && item.IsPodReady {
+			primaryPod = item.Pod
+		}
+	}
+	if primaryPod == nil {
+		return ctrl.Result{}, nil
+	}
+
+	for _, event := range []apiv1.LifecycleHookEvent{
+		cluster.GetBootstrapLifecycleHookEvent(),
+		apiv1.LifecycleHookEventPostFailover,
+	} {
+		completed, err := r.runLifecycleHooks(ctx, cluster, event, "", primaryPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !completed {
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// runPreSwitchoverHooks executes the preSwitchover lifecycle hooks before
+// promoting the target primary, returning errWaitingForLifecycleHooks
+// until every one of them has been completed
+func (r *ClusterReconciler) runPreSwitchoverHooks(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	primaryPod *corev1.Pod,
+	targetPrimary string,
+) error {
+	hooks := cluster.GetLifecycleHooks(apiv1.LifecycleHookEventPreSwitchover)
+	if len(hooks) == 0 || cluster.IsReplica() {
+		return nil
+	}
+
+	for _, hook := range hooks {
+		hookStatus := findLifecycleHookStatus(cluster, hook.Name)
+		if hookStatus == nil || hookStatus.Event != hook.Event || hookStatus.Instance != targetPrimary {
+			if err := r.queueLifecycleHooks(ctx, cluster, hook.Event, targetPrimary); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	completed, err := r.runLifecycleHooks(ctx, cluster, apiv1.LifecycleHookEventPreSwitchover, targetPrimary,
+		primaryPod)
+	if err != nil {
+		return err
+	}
+	if !completed {
+		return errWaitingForLifecycleHooks
+	}
+
+	return nil
+}
+
+// runLifecycleHooks executes, one at a time and in the order they are
+// defined, the queued hooks triggered by the given event, returning true
+// when all of them are completed. When the instance is not empty, only
+// the hooks queued for it are considered
+func (r *ClusterReconciler) runLifecycleHooks(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	event apiv1.LifecycleHookEvent,
+	instance string,
+	primaryPod *corev1.Pod,
+) (bool, error) {
+	for _, hook := range cluster.GetLifecycleHooks(event) {
+		hookStatus := findLifecycleHookStatus(cluster, hook.Name)
+		if hookStatus == nil || hookStatus.Event != event ||
+			(instance != "" && hookStatus.Instance != instance) {
+			continue
+		}
+
+		completed, err := r.runLifecycleHook(ctx, cluster, hook, *hookStatus, primaryPod)
+		if err != nil || !completed {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// runLifecycleHook moves the execution of a lifecycle hook forward,
+// returning true when it is completed
+func (r *ClusterReconciler) runLifecycleHook(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	hook apiv1.LifecycleHook,
+	hookStatus apiv1.LifecycleHookStatus,
+	primaryPod *corev1.Pod,
+) (bool, error) {
+	switch hookStatus.Phase {
+	case apiv1.LifecycleHookPhaseSucceeded:
+		return true, nil
+
+	case apiv1.LifecycleHookPhaseFailed:
+		if hook.GetFailurePolicy() == apiv1.LifecycleHookFailurePolicyIgnore {
+			return true, nil
+		}
+		if hookStatus.CompletedAt != nil && time.Since(hookStatus.CompletedAt.Time) < lifecycleHookRetryDelay {
+			return false, nil
+		}
+
+	case apiv1.LifecycleHookPhaseRunning:
+		return false, r.checkLifecycleHookJob(ctx, cluster, hook, hookStatus)
+	}
+
+	contextLogger := log.FromContext(ctx).WithValues("hook", hook.Name, "event", hook.Event)
+
+	hookStatus.Attempts++
+	hookStatus.StartedAt = ptr.To(metav1.Now())
+	hookStatus.CompletedAt = nil
+	hookStatus.Message = ""
+
+	if hook.Exec != nil {
+		contextLogger.Info("Executing the lifecycle hook", "pod", primaryPod.Name)
+		command := []string{"env"}
+		for _, env := range specs.CreateLifecycleHookEnv(hook, hookStatus.Instance) {
+			command = append(command, fmt.Sprintf("%s=%s", env.Name, env.Value))
+		}
+		command = append(command, hook.Exec.Command...)
+
+		err := execLifecycleHookCommand(ctx, primaryPod, hook.GetTimeout(), command...)
+		return false, r.completeLifecycleHook(ctx, cluster, hook, hookStatus, err)
+	}
+
+	job := specs.CreateLifecycleHookJob(*cluster, hook, hookStatus.Instance)
+	if err := r.Create(ctx, job); err != nil {
+		return false, fmt.Errorf("while creating the job of the lifecycle hook %s: %w", hook.Name, err)
+	}
+
+	contextLogger.Info("Running the lifecycle hook", "jobName", job.Name)
+	r.Recorder.Eventf(cluster, "Normal", "LifecycleHookStarted",
+		"Running the %s hook %s in job %s", hook.Event, hook.Name, job.Name)
+
+	hookStatus.Phase = apiv1.LifecycleHookPhaseRunning
+	hookStatus.JobName = job.Name
+	return false, status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		setLifecycleHookStatus(cluster, hookStatus)
+	})
+}
+
+// checkLifecycleHookJob records the result of the Job running a
+// lifecycle hook, once it is completed
+func (r *ClusterReconciler) checkLifecycleHookJob(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	hook apiv1.LifecycleHook,
+	hookStatus apiv1.LifecycleHookStatus,
+) error {
+	var job batchv1.Job
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: hookStatus.JobName}, &job)
+	switch {
+	case apierrs.IsNotFound(err):
+		return r.completeLifecycleHook(ctx, cluster, hook, hookStatus,
+			fmt.Errorf("the job %s has been deleted", hookStatus.JobName))
+	case err != nil:
+		return fmt.Errorf("while getting the job of the lifecycle hook %s: %w", hook.Name, err)
+	}
+
+	if utils.JobHasOneCompletion(job) {
+		return r.completeLifecycleHook(ctx, cluster, hook, hookStatus, nil)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return r.completeLifecycleHook(ctx, cluster, hook, hookStatus,
+				fmt.Errorf("the job %s failed: %s", job.Name, condition.Message))
+		}
+	}
+
+	return nil
+}
+
+// completeLifecycleHook records the result of the execution of a
+// lifecycle hook
+func (r *ClusterReconciler) completeLifecycleHook(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	hook apiv1.LifecycleHook,
+	hookStatus apiv1.LifecycleHookStatus,
+	hookErr error,
+) error {
+	contextLogger := log.FromContext(ctx).WithValues("hook", hook.Name, "event", hook.Event)
+
+	hookStatus.CompletedAt = ptr.To(metav1.Now())
+	if hookErr != nil {
+		hookStatus.Phase = apiv1.LifecycleHookPhaseFailed
+		hookStatus.Message = hookErr.Error()
+		contextLogger.Warning("The lifecycle hook failed",
+			"error", hookErr.Error(), "failurePolicy", hook.GetFailurePolicy())
+		r.Recorder.Eventf(cluster, "Warning", "LifecycleHookFailed",
+			"The %s hook %s failed: %v", hook.Event, hook.Name, hookErr)
+	} else {
+		hookStatus.Phase = apiv1.LifecycleHookPhaseSucceeded
+		contextLogger.Info("The lifecycle hook succeeded")
+		r.Recorder.Eventf(cluster, "Normal", "LifecycleHookSucceeded",
+			"The %s hook %s succeeded", hook.Event, hook.Name)
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		setLifecycleHookStatus(cluster, hookStatus)
+	})
+}
+
+// findLifecycleHookStatus gets the status of the lifecycle hook
+// with the given name, or nil if it has never been queued
+func findLifecycleHookStatus(cluster *apiv1.Cluster, name string) *apiv1.LifecycleHookStatus {
+	for idx := range cluster.Status.LifecycleHooks {
+		if cluster.Status.LifecycleHooks[idx].Name == name {
+			return &cluster.Status.LifecycleHooks[idx]
+		}
+	}
+
+	return nil
+}
+
+// setLifecycleHookStatus replaces the status of a lifecycle hook
+func setLifecycleHookStatus(cluster *apiv1.Cluster, hookStatus apiv1.LifecycleHookStatus) {
+	if existing := findLifecycleHookStatus(cluster, hookStatus.Name); existing != nil {
+		*existing = hookStatus
+		return
+	}
+
+	cluster.Status.LifecycleHooks = append(cluster.Status.LifecycleHooks, hookStatus)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lifecycle hooks", func() {
+	var (
+		env          *testingEnvironment
+		crReconciler *ClusterReconciler
+		cluster      *apiv1.Cluster
+		primaryPod   *corev1.Pod
+		executed     [][]string
+		execErr      error
+	)
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		crReconciler = &ClusterReconciler{
+			Client: fakeClientWithIndexAdapter{
+				Client: env.clusterReconciler.Client,
+			},
+			Scheme:   env.clusterReconciler.Scheme,
+			Recorder: env.clusterReconciler.Recorder,
+		}
+
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.LifecycleHooks = []apiv1.LifecycleHook{
+				{
+					Name:  "warm-cache",
+					Event: apiv1.LifecycleHookEventPostFailover,
+					Exec:  &apiv1.LifecycleHookExec{Command: []string{"psql", "-c", "SELECT pg_prewarm('orders')"}},
+				},
+				{
+					Name:  "notify",
+					Event: apiv1.LifecycleHookEventPreSwitchover,
+					Job: &apiv1.LifecycleHookJob{
+						Image:   "curlimages/curl",
+						Command: []string{"curl", "-X", "POST", "https://hooks.example.com/switchover"},
+					},
+					FailurePolicy: apiv1.LifecycleHookFailurePolicyFail,
+				},
+			}
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			cluster.Status.TargetPrimary = cluster.Name + "-1"
+		})
+		primaryPod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: cluster.Name + "-1", Namespace: namespace}}
+
+		executed = nil
+		execErr = nil
+		originalExec := execLifecycleHookCommand
+		execLifecycleHookCommand = func(_ context.Context, _ *corev1.Pod, _ time.Duration, command ...string) error {
+			executed = append(executed, command)
+			return execErr
+		}
+		DeferCleanup(func() {
+			execLifecycleHookCommand = originalExec
+		})
+	})
+
+	readyPrimary := func() postgres.PostgresqlStatusList {
+		return postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{{Pod: primaryPod, IsPrimary: true, IsPodReady: true}},
+		}
+	}
+
+	getHookJob := func(ctx context.Context, name string) *batchv1.Job {
+		var job batchv1.Job
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, &job)).To(Succeed())
+		return &job
+	}
+
+	It("ignores the events without hooks", func(ctx context.Context) {
+		Expect(crReconciler.queueLifecycleHooks(ctx, cluster, apiv1.LifecycleHookEventPostInit,
+			primaryPod.Name)).To(Succeed())
+		Expect(cluster.Status.LifecycleHooks).To(BeEmpty())
+
+		res, err := crReconciler.reconcileLifecycleHooks(ctx, cluster, readyPrimary())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+		Expect(executed).To(BeEmpty())
+	})
+
+	It("executes the post failover hooks once the promoted instance is the ready primary", func(ctx context.Context) {
+		Expect(crReconciler.queueLifecycleHooks(ctx, cluster, apiv1.LifecycleHookEventPostFailover,
+			primaryPod.Name)).To(Succeed())
+		Expect(cluster.Status.LifecycleHooks).To(HaveLen(1))
+		Expect(cluster.Status.LifecycleHooks[0].Phase).To(Equal(apiv1.LifecycleHookPhasePending))
+
+		By("waiting for the primary to be ready", func() {
+			res, err := crReconciler.reconcileLifecycleHooks(ctx, cluster, postgres.PostgresqlStatusList{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+			Expect(executed).To(BeEmpty())
+		})
+
+		res, err := crReconciler.reconcileLifecycleHooks(ctx, cluster, readyPrimary())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		Expect(executed).To(ConsistOf(ConsistOf(
+			"env",
+			"HOOK_NAME=warm-cache",
+			"HOOK_EVENT=postFailover",
+			"HOOK_INSTANCE="+primaryPod.Name,
+			"psql", "-c", "SELECT pg_prewarm('orders')",
+		)))
+		Expect(cluster.Status.LifecycleHooks[0].Phase).To(Equal(apiv1.LifecycleHookPhaseSucceeded))
+		Expect(cluster.Status.LifecycleHooks[0].Attempts).To(BeEquivalentTo(1))
+
+		res, err = crReconciler.reconcileLifecycleHooks(ctx, cluster, readyPrimary())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+		Expect(executed).To(HaveLen(1))
+	})
+
+	It("proceeds after the failure of a hook whose failures are ignored", func(ctx context.Context) {
+		execErr = errors.New("relation \"orders\" does not exist")
+		Expect(crReconciler.queueLifecycleHooks(ctx, cluster, apiv1.LifecycleHookEventPostFailover,
+			primaryPod.Name)).To(Succeed())
+
+		_, err := crReconciler.reconcileLifecycleHooks(ctx, cluster, readyPrimary())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.LifecycleHooks[0].Phase).To(Equal(apiv1.LifecycleHookPhaseFailed))
+		Expect(cluster.Status.LifecycleHooks[0].Message).To(ContainSubstring("does not exist"))
+
+		res, err := crReconciler.reconcileLifecycleHooks(ctx, cluster, readyPrimary())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+		Expect(executed).To(HaveLen(1))
+	})
+
+	It("holds back the switchover until the hooks are completed", func(ctx context.Context) {
+		targetPrimary := cluster.Name + "-2"
+
+		err := crReconciler.runPreSwitchoverHooks(ctx, cluster, primaryPod, targetPrimary)
+		Expect(err).To(MatchError(errWaitingForLifecycleHooks))
+
+		hookStatus := findLifecycleHookStatus(cluster, "notify")
+		Expect(hookStatus).ToNot(BeNil())
+		Expect(hookStatus.Phase).To(Equal(apiv1.LifecycleHookPhaseRunning))
+		Expect(hookStatus.Instance).To(Equal(targetPrimary))
+
+		job := getHookJob(ctx, hookStatus.JobName)
+		Expect(job.Labels).To(HaveKeyWithValue(utils.LifecycleHookLabelName, "notify"))
+		Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("curlimages/curl"))
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: "HOOK_INSTANCE", Value: targetPrimary}))
+
+		By("failing the job, the switchover is still held back", func() {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Message: "Job has reached the specified backoff limit",
+				},
+			}
+			Expect(env.client.Status().Update(ctx, job)).To(Succeed())
+
+			err := crReconciler.runPreSwitchoverHooks(ctx, cluster, primaryPod, targetPrimary)
+			Expect(err).To(MatchError(errWaitingForLifecycleHooks))
+			Expect(findLifecycleHookStatus(cluster, "notify").Phase).To(Equal(apiv1.LifecycleHookPhaseFailed))
+
+			err = crReconciler.runPreSwitchoverHooks(ctx, cluster, primaryPod, targetPrimary)
+			Expect(err).To(MatchError(errWaitingForLifecycleHooks))
+			Expect(findLifecycleHookStatus(cluster, "notify").Attempts).To(BeEquivalentTo(1))
+		})
+
+		By("retrying the hook after the retry delay", func() {
+			hookStatus := *findLifecycleHookStatus(cluster, "notify")
+			hookStatus.CompletedAt = &metav1.Time{Time: time.Now().Add(-2 * lifecycleHookRetryDelay)}
+			setLifecycleHookStatus(cluster, hookStatus)
+
+			err := crReconciler.runPreSwitchoverHooks(ctx, cluster, primaryPod, targetPrimary)
+			Expect(err).To(MatchError(errWaitingForLifecycleHooks))
+			hookStatus = *findLifecycleHookStatus(cluster, "notify")
+			Expect(hookStatus.Phase).To(Equal(apiv1.LifecycleHookPhaseRunning))
+			Expect(hookStatus.Attempts).To(BeEquivalentTo(2))
+
+			job := getHookJob(ctx, hookStatus.JobName)
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}
+			job.Status.Succeeded = 1
+			Expect(env.client.Status().Update(ctx, job)).To(Succeed())
+		})
+
+		err = crReconciler.runPreSwitchoverHooks(ctx, cluster, primaryPod, targetPrimary)
+		Expect(err).To(MatchError(errWaitingForLifecycleHooks))
+		Expect(findLifecycleHookStatus(cluster, "notify").Phase).To(Equal(apiv1.LifecycleHookPhaseSucceeded))
+
+		Expect(crReconciler.runPreSwitchoverHooks(ctx, cluster, primaryPod, targetPrimary)).To(Succeed())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// isRolloutAllowedNow checks whether a pod rollout can be performed
+// now, given the maintenance windows of the cluster
+func isRolloutAllowedNow(cluster *apiv1.Cluster, podRollout rollout) bool {
+	return podRollout.explicitlyRequested || cluster.IsInMaintenanceWindow(time.Now())
+}
+
+// getPendingMaintenanceOperations gets the list of the pod rollouts
+// that are waiting for the next maintenance window
+func getPendingMaintenanceOperations(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) []string {
+	var operations []string
+	for _, instance := range instancesStatus.Items {
+		if instance.Pod == nil || cluster.IsInstanceFenced(instance.Pod.Name) {
+			continue
+		}
+
+		podRollout := isInstanceNeedingRollout(ctx, instance, cluster)
+		if !podRollout.required || podRollout.explicitlyRequested {
+			continue
+		}
+
+		operations = append(operations, fmt.Sprintf("%s: %s", instance.Pod.Name, podRollout.reason))
+	}
+	slices.Sort(operations)
+
+	return operations
+}
+
+// reportPendingMaintenance stores in the cluster status the disruptive
+// operations deferred until the next maintenance window, returning the
+// time to wait before the window opens
+func (r *ClusterReconciler) reportPendingMaintenance(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (time.Duration, error) {
+	now := time.Now()
+	maintenance := &apiv1.MaintenanceStatus{
+		PendingOperations: getPendingMaintenanceOperations(ctx, cluster, instancesStatus),
+	}
+
+	var requeueAfter time.Duration
+	if nextWindow, found := cluster.GetNextMaintenanceWindow(now); found {
+		maintenance.NextWindow = &metav1.Time{Time: nextWindow}
+		requeueAfter = nextWindow.Sub(now)
+	}
+
+	if cluster.Status.Maintenance != nil &&
+		slices.Equal(cluster.Status.Maintenance.PendingOperations, maintenance.PendingOperations) &&
+		cluster.Status.Maintenance.NextWindow.Equal(maintenance.NextWindow) {
+		return requeueAfter, nil
+	}
+
+	log.FromContext(ctx).Info("Deferring disruptive operations until the next maintenance window",
+		"pendingOperations", maintenance.PendingOperations,
+		"nextWindow", maintenance.NextWindow)
+	r.Recorder.Eventf(cluster, "Normal", "MaintenanceDeferred",
+		"Deferring %d disruptive operations until the next maintenance window",
+		len(maintenance.PendingOperations))
+
+	return requeueAfter, status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.Maintenance = maintenance
+	})
+}
+
+// clearPendingMaintenance removes from the cluster status the
+// disruptive operations deferred until the next maintenance window
+func (r *ClusterReconciler) clearPendingMaintenance(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Status.Maintenance == nil {
+		return nil
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.Maintenance = nil
+	})
+}
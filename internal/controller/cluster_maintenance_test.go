@@ -0,0 +1,60 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Maintenance windows gating", func() {
+	// A window that opened an hour ago, and will close in an hour
+	openWindow := apiv1.MaintenanceWindow{
+		Schedule: time.Now().UTC().Add(-time.Hour).Format("0 4 15 * * *"),
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+	// A window that opens in an hour
+	closedWindow := apiv1.MaintenanceWindow{
+		Schedule: time.Now().UTC().Add(time.Hour).Format("0 4 15 * * *"),
+		Duration: metav1.Duration{Duration: time.Minute},
+	}
+
+	It("allows rollouts when no window is defined", func() {
+		Expect(isRolloutAllowedNow(&apiv1.Cluster{}, rollout{required: true})).To(BeTrue())
+	})
+
+	It("allows rollouts while a window is open", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{MaintenanceWindows: []apiv1.MaintenanceWindow{openWindow}},
+		}
+		Expect(isRolloutAllowedNow(cluster, rollout{required: true})).To(BeTrue())
+	})
+
+	It("defers rollouts outside the windows, unless explicitly requested", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{MaintenanceWindows: []apiv1.MaintenanceWindow{closedWindow}},
+		}
+		Expect(isRolloutAllowedNow(cluster, rollout{required: true})).To(BeFalse())
+		Expect(isRolloutAllowedNow(cluster, rollout{required: true, explicitlyRequested: true})).To(BeTrue())
+	})
+})
@@ -0,0 +1,281 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errCanaryVerificationInProgress is raised when the update of the instances
+// is waiting for the canary replica of a minor version update to be verified
+var errCanaryVerificationInProgress = errors.New("waiting for the canary replica to be verified")
+
+// canaryHealthCheckTimeout is the maximum time a health check query
+// can run on the canary replica
+const canaryHealthCheckTimeout = 30 * time.Second
+
+// isMinorUpgradeBlocked checks whether the given image has been rolled
+// back because the canary replica of its minor version update was not healthy
+func isMinorUpgradeBlocked(cluster *apiv1.Cluster, image string) bool {
+	upgrade := cluster.Status.MinorUpgrade
+	return cluster.GetMinorUpgradeStrategy() == apiv1.MinorUpgradeStrategyCanary &&
+		upgrade != nil &&
+		upgrade.Phase == apiv1.MinorUpgradePhaseBlocked &&
+		isRequestedImage(upgrade.TargetImage, image)
+}
+
+// reconcileCanaryUpgrade gates the update of a replica to a new image behind
+// the verification of the canary replica, returning true when the update of
+// the given replica can proceed
+func (r *ClusterReconciler) reconcileCanaryUpgrade(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+	instance postgres.PostgresqlStatus,
+) (bool, error) {
+	if cluster.GetMinorUpgradeStrategy() != apiv1.MinorUpgradeStrategyCanary {
+		return true, nil
+	}
+
+	upgrade := cluster.Status.MinorUpgrade
+
+	// The canary replica is being rolled back to the previous image
+	if upgrade != nil && upgrade.Phase == apiv1.MinorUpgradePhaseBlocked &&
+		cluster.Status.Image == upgrade.PreviousImage {
+		return true, nil
+	}
+
+	if upgrade == nil || upgrade.TargetImage != cluster.Status.Image {
+		// Without replicas there's no canary to be verified
+		if instance.IsPrimary {
+			return true, nil
+		}
+		return true, r.startCanaryUpgrade(ctx, cluster, instance)
+	}
+
+	switch {
+	case upgrade.Phase == apiv1.MinorUpgradePhaseVerified:
+		return true, nil
+	case upgrade.Phase == apiv1.MinorUpgradePhaseBlocked:
+		return false, nil
+	case instance.Pod.Name == upgrade.Canary:
+		// The canary replica still needs to be updated
+		return true, nil
+	}
+
+	healthy, reason := r.verifyCanary(ctx, cluster, podList)
+	switch {
+	case healthy:
+		log.FromContext(ctx).Info("Canary replica verified, proceeding with the update",
+			"canary", upgrade.Canary, "image", upgrade.TargetImage)
+		r.Recorder.Eventf(cluster, "Normal", "CanaryVerified",
+			"Canary replica %v verified, updating the other instances to %v",
+			upgrade.Canary, upgrade.TargetImage)
+		return true, status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.MinorUpgrade.Phase = apiv1.MinorUpgradePhaseVerified
+		})
+	case reason == "":
+		return false, errCanaryVerificationInProgress
+	default:
+		return false, r.blockMinorUpgrade(ctx, cluster, reason)
+	}
+}
+
+// startCanaryUpgrade elects the given replica as the canary of the
+// update to the image of the cluster
+func (r *ClusterReconciler) startCanaryUpgrade(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instance postgres.PostgresqlStatus,
+) error {
+	previousImage, err := specs.GetPostgresImageName(*instance.Pod)
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Updating the canary replica",
+		"canary", instance.Pod.Name, "previousImage", previousImage, "targetImage", cluster.Status.Image)
+	r.Recorder.Eventf(cluster, "Normal", "CanaryUpgrade",
+		"Updating the canary replica %v to %v", instance.Pod.Name, cluster.Status.Image)
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.MinorUpgrade = &apiv1.MinorUpgradeStatus{
+			TargetImage:   cluster.Status.Image,
+			PreviousImage: previousImage,
+			Canary:        instance.Pod.Name,
+			StartedAt:     metav1.Now(),
+			Phase:         apiv1.MinorUpgradePhaseVerifying,
+		}
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, string(apiv1.ConditionUpgradeBlocked))
+	})
+}
+
+// verifyCanary checks the health of the canary replica, returning true
+// if it is healthy at the end of the soak period. When the canary replica
+// is not healthy, the reason is returned, while an empty reason means that
+// the soak period is not over yet
+func (r *ClusterReconciler) verifyCanary(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+) (bool, string) {
+	healthy, reason := evaluateCanary(cluster, podList, time.Now())
+	if !healthy || cluster.Spec.MinorUpgrade == nil {
+		return healthy, reason
+	}
+
+	canary := podList.Items[indexOfInstance(podList, cluster.Status.MinorUpgrade.Canary)]
+	for _, query := range cluster.Spec.MinorUpgrade.HealthChecks {
+		if err := runCanaryHealthCheck(ctx, canary.Pod, query); err != nil {
+			return false, fmt.Sprintf("health check %q failed: %v", query, err)
+		}
+	}
+
+	return true, ""
+}
+
+// evaluateCanary checks the status of the canary replica reported by
+// the instance manager, as described by verifyCanary
+func evaluateCanary(
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+	now time.Time,
+) (bool, string) {
+	upgrade := cluster.Status.MinorUpgrade
+	soakOver := now.Sub(upgrade.StartedAt.Time) >= cluster.GetMinorUpgradeSoakPeriod()
+
+	idx := indexOfInstance(podList, upgrade.Canary)
+	if idx < 0 {
+		if soakOver {
+			return false, "the canary replica is not running"
+		}
+		return false, ""
+	}
+	canary := podList.Items[idx]
+
+	for _, containerStatus := range canary.Pod.Status.ContainerStatuses {
+		if containerStatus.Name == specs.PostgresContainerName && containerStatus.RestartCount > 0 {
+			return false, fmt.Sprintf("the canary replica restarted %d times", containerStatus.RestartCount)
+		}
+	}
+
+	if !soakOver {
+		return false, ""
+	}
+
+	if image, err := specs.GetPostgresImageName(*canary.Pod); err != nil || image != upgrade.TargetImage {
+		return false, "the canary replica is not running the new image"
+	}
+	if !utils.IsPodReady(*canary.Pod) || !canary.IsPodReady {
+		return false, "the canary replica is not ready"
+	}
+	if !canary.IsWalReceiverActive {
+		return false, "the canary replica is not streaming from the primary"
+	}
+
+	if cluster.Spec.MinorUpgrade != nil && cluster.Spec.MinorUpgrade.MaxLag != nil &&
+		len(podList.Items) > 0 && podList.Items[0].IsPrimary {
+		primaryLSN, primaryErr := podList.Items[0].CurrentLsn.Parse()
+		canaryLSN, canaryErr := canary.ReplayLsn.Parse()
+		if primaryErr != nil || canaryErr != nil {
+			return false, "cannot evaluate the replication lag of the canary replica"
+		}
+		if lag := primaryLSN - canaryLSN; lag > cluster.Spec.MinorUpgrade.MaxLag.Value() {
+			return false, fmt.Sprintf("the canary replica is lagging %d bytes behind the primary", lag)
+		}
+	}
+
+	return true, ""
+}
+
+// indexOfInstance gets the position of the instance running in
+// the Pod with the given name, or -1 if not found
+func indexOfInstance(podList *postgres.PostgresqlStatusList, podName string) int {
+	for idx, item := range podList.Items {
+		if item.Pod != nil && item.Pod.Name == podName {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// runCanaryHealthCheck executes a health check query on the canary
+// replica, expecting it to return `true`
+func runCanaryHealthCheck(ctx context.Context, pod *corev1.Pod, query string) error {
+	config := ctrl.GetConfigOrDie()
+	clientInterface := kubernetes.NewForConfigOrDie(config)
+
+	timeout := canaryHealthCheckTimeout
+	stdout, stderr, err := utils.ExecCommand(
+		ctx,
+		clientInterface,
+		config,
+		*pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"psql", "-XAtq", "-d", "postgres", "-c", query,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+	if result := strings.TrimSpace(stdout); result != "t" {
+		return fmt.Errorf("returned %q instead of true", result)
+	}
+
+	return nil
+}
+
+// blockMinorUpgrade rolls back the update of the canary replica,
+// reporting that the update to the new image is blocked
+func (r *ClusterReconciler) blockMinorUpgrade(ctx context.Context, cluster *apiv1.Cluster, reason string) error {
+	upgrade := cluster.Status.MinorUpgrade
+	message := fmt.Sprintf("Update to %v rolled back: %v", upgrade.TargetImage, reason)
+
+	log.FromContext(ctx).Warning("Canary replica unhealthy, rolling back the update",
+		"canary", upgrade.Canary, "targetImage", upgrade.TargetImage,
+		"previousImage", upgrade.PreviousImage, "reason", reason)
+	r.Recorder.Event(cluster, "Warning", "UpgradeBlocked", message)
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.MinorUpgrade.Phase = apiv1.MinorUpgradePhaseBlocked
+		cluster.Status.Image = cluster.Status.MinorUpgrade.PreviousImage
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionUpgradeBlocked),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonCanaryUnhealthy),
+			Message: message,
+		})
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Canary minor version update", func() {
+	const (
+		previousImage = "ghcr.io/cloudnative-pg/postgresql:16.3"
+		targetImage   = "ghcr.io/cloudnative-pg/postgresql:16.4"
+	)
+
+	var (
+		cluster *apiv1.Cluster
+		podList *postgres.PostgresqlStatusList
+		now     time.Time
+	)
+
+	newInstance := func(name, image string, isPrimary bool) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: specs.PostgresContainerName, Image: image}},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+						{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: specs.PostgresContainerName},
+					},
+				},
+			},
+			IsPrimary:           isPrimary,
+			IsPodReady:          true,
+			IsWalReceiverActive: !isPrimary,
+			CurrentLsn:          types.LSN("0/3000000"),
+			ReplayLsn:           types.LSN("0/2000000"),
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MinorUpgrade: &apiv1.MinorUpgradeConfiguration{
+					Strategy:   apiv1.MinorUpgradeStrategyCanary,
+					SoakPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Image: targetImage,
+				MinorUpgrade: &apiv1.MinorUpgradeStatus{
+					TargetImage:   targetImage,
+					PreviousImage: previousImage,
+					Canary:        "cluster-example-3",
+					StartedAt:     metav1.NewTime(now.Add(-10 * time.Minute)),
+					Phase:         apiv1.MinorUpgradePhaseVerifying,
+				},
+			},
+		}
+		podList = &postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newInstance("cluster-example-1", previousImage, true),
+				newInstance("cluster-example-2", previousImage, false),
+				newInstance("cluster-example-3", targetImage, false),
+			},
+		}
+	})
+
+	It("waits for the end of the soak period", func() {
+		cluster.Status.MinorUpgrade.StartedAt = metav1.NewTime(now.Add(-time.Minute))
+		healthy, reason := evaluateCanary(cluster, podList, now)
+		Expect(healthy).To(BeFalse())
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("verifies a healthy canary replica", func() {
+		healthy, reason := evaluateCanary(cluster, podList, now)
+		Expect(healthy).To(BeTrue())
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("rejects a canary replica that restarted during the soak period", func() {
+		cluster.Status.MinorUpgrade.StartedAt = metav1.NewTime(now.Add(-time.Minute))
+		podList.Items[2].Pod.Status.ContainerStatuses[0].RestartCount = 2
+		healthy, reason := evaluateCanary(cluster, podList, now)
+		Expect(healthy).To(BeFalse())
+		Expect(reason).To(ContainSubstring("restarted"))
+	})
+
+	It("rejects a canary replica which is not streaming", func() {
+		podList.Items[2].IsWalReceiverActive = false
+		healthy, reason := evaluateCanary(cluster, podList, now)
+		Expect(healthy).To(BeFalse())
+		Expect(reason).To(ContainSubstring("streaming"))
+	})
+
+	It("rejects a canary replica lagging too much", func() {
+		maxLag := resource.MustParse("1Mi")
+		cluster.Spec.MinorUpgrade.MaxLag = &maxLag
+		healthy, reason := evaluateCanary(cluster, podList, now)
+		Expect(healthy).To(BeFalse())
+		Expect(reason).To(ContainSubstring("lagging"))
+	})
+
+	It("blocks only the rolled back image", func() {
+		Expect(isMinorUpgradeBlocked(cluster, targetImage)).To(BeFalse())
+
+		cluster.Status.MinorUpgrade.Phase = apiv1.MinorUpgradePhaseBlocked
+		Expect(isMinorUpgradeBlocked(cluster, targetImage)).To(BeTrue())
+		Expect(isMinorUpgradeBlocked(cluster, "ghcr.io/cloudnative-pg/postgresql:16.5")).To(BeFalse())
+	})
+})
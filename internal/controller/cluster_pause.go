@@ -0,0 +1,139 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// unknownFieldManager is reported when the field manager that paused
+// the cluster cannot be found between the managed fields
+const unknownFieldManager = "unknown"
+
+// reconcilePauseStatus records in the status since when and by whom the
+// cluster has been paused, and clears that information when it is resumed
+func (r *ClusterReconciler) reconcilePauseStatus(ctx context.Context, cluster *apiv1.Cluster) error {
+	switch {
+	case cluster.Spec.Paused && cluster.Status.PauseStatus == nil:
+		pausedBy := getPausingFieldManager(cluster)
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.PauseStatus = &apiv1.ClusterPauseStatus{
+				Since: metav1.Now(),
+				By:    pausedBy,
+			}
+		}); err != nil {
+			return err
+		}
+
+		log.FromContext(ctx).Info("Cluster reconciliation paused", "pausedBy", pausedBy)
+		r.Recorder.Eventf(cluster, "Normal", "Paused", "Cluster reconciliation paused by %s", pausedBy)
+
+	case !cluster.Spec.Paused && cluster.Status.PauseStatus != nil:
+		pausedSince := cluster.Status.PauseStatus.Since
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.PauseStatus = nil
+		}); err != nil {
+			return err
+		}
+
+		log.FromContext(ctx).Info("Cluster reconciliation resumed", "pausedSince", pausedSince)
+		r.Recorder.Eventf(cluster, "Normal", "Resumed",
+			"Cluster reconciliation resumed, it was paused since %s", pausedSince.UTC().Format(metav1.RFC3339Micro))
+	}
+
+	return nil
+}
+
+// reconcilePausedCluster refreshes the status of a paused cluster
+// without changing any of the resources managed by the operator
+func (r *ClusterReconciler) reconcilePausedCluster(ctx context.Context, cluster *apiv1.Cluster) (ctrl.Result, error) {
+	resources, err := r.getManagedResources(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateResourceStatus(ctx, cluster, resources); err != nil {
+		if apierrs.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot update the resource status: %w", err)
+	}
+
+	ctx, err = certs.NewTLSConfigForContext(
+		ctx,
+		r.Client,
+		cluster.GetServerCASecretObjectKey(),
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	instancesStatus := r.InstanceClient.GetStatusFromInstances(ctx, resources.instances)
+	if err := r.updateClusterStatusThatRequiresInstancesState(
+		ctx, cluster, instancesStatus, resources.pvcs.Items,
+	); err != nil {
+		if apierrs.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot update the instances status on the cluster: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getPausingFieldManager gets the name of the field manager that most
+// recently set the `paused` field of the cluster specification
+func getPausingFieldManager(cluster *apiv1.Cluster) string {
+	var latest *metav1.ManagedFieldsEntry
+	for idx := range cluster.ManagedFields {
+		entry := &cluster.ManagedFields[idx]
+		if entry.FieldsV1 == nil || !hasPausedField(entry.FieldsV1.Raw) {
+			continue
+		}
+		if latest == nil || latest.Time == nil || (entry.Time != nil && latest.Time.Before(entry.Time)) {
+			latest = entry
+		}
+	}
+
+	if latest == nil || latest.Manager == "" {
+		return unknownFieldManager
+	}
+	return latest.Manager
+}
+
+// hasPausedField checks if a set of managed fields includes the
+// `paused` field of the cluster specification
+func hasPausedField(rawFields []byte) bool {
+	var fields map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(rawFields, &fields); err != nil {
+		return false
+	}
+
+	_, found := fields["f:spec"]["f:paused"]
+	return found
+}
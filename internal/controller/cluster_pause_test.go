@@ -0,0 +1,97 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster pause", func() {
+	managedFieldsEntry := func(manager string, fields string, setAt time.Time) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			Time:       &metav1.Time{Time: setAt},
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	It("reports the field manager which most recently set the paused field", func() {
+		now := time.Now()
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					managedFieldsEntry("argocd-controller", `{"f:spec":{"f:paused":{}}}`, now.Add(-time.Hour)),
+					managedFieldsEntry("kubectl-edit", `{"f:spec":{"f:paused":{}}}`, now),
+					managedFieldsEntry("helm", `{"f:spec":{"f:instances":{}}}`, now.Add(time.Hour)),
+				},
+			},
+		}
+		Expect(getPausingFieldManager(cluster)).To(Equal("kubectl-edit"))
+	})
+
+	It("reports an unknown field manager when the managed fields are not available", func() {
+		Expect(getPausingFieldManager(&apiv1.Cluster{})).To(Equal(unknownFieldManager))
+	})
+
+	It("records since when the cluster is paused and clears it when resumed", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec:       apiv1.ClusterSpec{Paused: true},
+		}
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: recorder,
+		}
+
+		Expect(reconciler.reconcilePauseStatus(ctx, cluster)).To(Succeed())
+		Expect(cluster.Status.PauseStatus).ToNot(BeNil())
+		Expect(cluster.Status.PauseStatus.Since.IsZero()).To(BeFalse())
+		Expect(cluster.Status.PauseStatus.By).ToNot(BeEmpty())
+		Expect(recorder.Events).To(HaveLen(1))
+
+		By("not changing the status while the cluster stays paused", func() {
+			since := cluster.Status.PauseStatus.Since
+			Expect(reconciler.reconcilePauseStatus(ctx, cluster)).To(Succeed())
+			Expect(cluster.Status.PauseStatus.Since).To(Equal(since))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		By("clearing the status when the cluster is resumed", func() {
+			cluster.Spec.Paused = false
+			Expect(reconciler.reconcilePauseStatus(ctx, cluster)).To(Succeed())
+			Expect(cluster.Status.PauseStatus).To(BeNil())
+			Expect(recorder.Events).To(HaveLen(2))
+		})
+	})
+})
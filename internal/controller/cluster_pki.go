@@ -34,6 +34,10 @@ import (
 // setupPostgresPKI create all the PKI infrastructure that PostgreSQL need to work
 // if using ssl=on
 func (r *ClusterReconciler) setupPostgresPKI(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.GetCertificateProvider() != nil {
+		return r.setupProviderPostgresPKI(ctx, cluster)
+	}
+
 	// This is the CA of cluster
 	serverCaSecret, err := r.ensureServerCASecret(ctx, cluster)
 	if err != nil {
@@ -170,6 +174,12 @@ func (r *ClusterReconciler) ensureCASecret(ctx context.Context, cluster *apiv1.C
 	var secret v1.Secret
 	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: secretName}, &secret)
 	if err == nil {
+		// The secret only contains the CA certificate of a certificate
+		// provider that has been removed, replace it with a new CA
+		if _, hasKey := secret.Data[certs.CAPrivateKeyKey]; !hasKey {
+			return &secret, r.replaceProviderCASecret(ctx, cluster, &secret)
+		}
+
 		// Verify the validity of this CA and renew it if needed
 		err = r.renewCASecret(ctx, &secret)
 		if err != nil {
@@ -193,6 +203,26 @@ func (r *ClusterReconciler) ensureCASecret(ctx context.Context, cluster *apiv1.C
 	return derivedCaSecret, err
 }
 
+// replaceProviderCASecret replaces the CA certificate stored in a secret by
+// a certificate provider with a new self-signed CA managed by the operator,
+// keeping the previous CA certificate until the certificate rotation ends
+func (r *ClusterReconciler) replaceProviderCASecret(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	secret *v1.Secret,
+) error {
+	caPair, err := certs.CreateRootCA(cluster.Name, cluster.Namespace)
+	if err != nil {
+		return fmt.Errorf("while creating the CA of the cluster: %w", err)
+	}
+
+	origSecret := secret.DeepCopy()
+	caData := caPair.GenerateCASecret(cluster.Namespace, secret.Name).Data
+	certs.ReplaceCACertificate(secret, caData[certs.CACertKey])
+	secret.Data[certs.CAPrivateKeyKey] = caData[certs.CAPrivateKeyKey]
+	return r.Patch(ctx, secret, client.MergeFrom(origSecret))
+}
+
 // renewCASecret check if this CA secret is valid and renew it if needed
 func (r *ClusterReconciler) renewCASecret(ctx context.Context, secret *v1.Secret) error {
 	pair, err := certs.ParseCASecret(secret)
@@ -218,7 +248,7 @@ func (r *ClusterReconciler) renewCASecret(ctx context.Context, secret *v1.Secret
 		return err
 	}
 
-	secret.Data[certs.CACertKey] = pair.Certificate
+	certs.ReplaceCACertificate(secret, pair.Certificate)
 	return r.Update(ctx, secret)
 }
 
@@ -323,9 +353,27 @@ func (r *ClusterReconciler) ensureLeafCertificate(
 	altDNSNames []string,
 	additionalLabels map[string]string,
 ) error {
+	if cluster.GetCertificateProvider() != nil {
+		_, err := r.ensureProviderLeafCertificate(
+			ctx, cluster, secretName, commonName, usage, altDNSNames, additionalLabels)
+		return err
+	}
+
 	var secret v1.Secret
 	err := r.Get(ctx, secretName, &secret)
 	switch {
+	case err == nil && secret.Annotations[utils.CertificateProviderHashAnnotationName] != "":
+		// The certificate has been issued by a certificate provider
+		// that has been removed, sign a new one with our CA
+		newSecret, err := generateCertificateFromCA(caSecret, commonName, usage, altDNSNames, secretName)
+		if err != nil {
+			return err
+		}
+
+		origSecret := secret.DeepCopy()
+		delete(secret.Annotations, utils.CertificateProviderHashAnnotationName)
+		secret.Data = newSecret.Data
+		return r.Patch(ctx, &secret, client.MergeFrom(origSecret))
 	case err == nil:
 		return r.renewAndUpdateCertificate(ctx, caSecret, &secret, altDNSNames)
 	case apierrors.IsNotFound(err):
@@ -0,0 +1,348 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	certprovider "github.com/cloudnative-pg/cloudnative-pg/pkg/certs/provider"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
+)
+
+// errMissingAWSCredentials is returned when the AWS Private CA provider
+// has no credentials, neither in its configuration nor in the environment
+var errMissingAWSCredentials = errors.New(
+	"missing AWS credentials: set them in the provider or in the environment of the operator")
+
+// certificateProviderBuilder builds an external certificate provider,
+// reading its credentials with the passed function
+type certificateProviderBuilder func(
+	configuration *apiv1.CertificateProviderConfiguration,
+	getSecretKey func(selector *apiv1.SecretKeySelector) ([]byte, error),
+) (certprovider.Provider, error)
+
+// newCertificateProvider builds the external certificate provider
+// configured in a cluster
+func newCertificateProvider(
+	configuration *apiv1.CertificateProviderConfiguration,
+	getSecretKey func(selector *apiv1.SecretKeySelector) ([]byte, error),
+) (certprovider.Provider, error) {
+	switch {
+	case configuration.Vault != nil:
+		token, err := getSecretKey(&configuration.Vault.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		vaultConfiguration := &certprovider.VaultPKIConfiguration{
+			Address:   configuration.Vault.Address,
+			MountPath: configuration.Vault.MountPath,
+			Role:      configuration.Vault.Role,
+			TTL:       configuration.Vault.TTL,
+			Token:     string(token),
+		}
+		if vaultConfiguration.MountPath == "" {
+			vaultConfiguration.MountPath = "pki"
+		}
+		if configuration.Vault.CA != nil {
+			if vaultConfiguration.CA, err = getSecretKey(configuration.Vault.CA); err != nil {
+				return nil, err
+			}
+		}
+		return certprovider.NewVaultPKIProvider(vaultConfiguration)
+
+	case configuration.AWSPrivateCA != nil:
+		awsConfiguration := &certprovider.AWSPrivateCAConfiguration{
+			Region:                  configuration.AWSPrivateCA.Region,
+			CertificateAuthorityARN: configuration.AWSPrivateCA.CertificateAuthorityARN,
+			TemplateARN:             configuration.AWSPrivateCA.TemplateARN,
+			SigningAlgorithm:        configuration.AWSPrivateCA.SigningAlgorithm,
+			ValidityDays:            configuration.AWSPrivateCA.ValidityDays,
+		}
+		if awsConfiguration.SigningAlgorithm == "" {
+			awsConfiguration.SigningAlgorithm = "SHA256WITHECDSA"
+		}
+		if awsConfiguration.ValidityDays == 0 {
+			awsConfiguration.ValidityDays = 90
+		}
+
+		credentials, err := getAWSPrivateCACredentials(configuration.AWSPrivateCA.Credentials, getSecretKey)
+		if err != nil {
+			return nil, err
+		}
+		awsConfiguration.Credentials = credentials
+		return certprovider.NewAWSPrivateCAProvider(awsConfiguration), nil
+
+	default:
+		return nil, errors.New("no certificate provider configured")
+	}
+}
+
+// getAWSPrivateCACredentials gets the credentials used to access AWS
+// Private CA, falling back to the ones in the environment of the operator
+func getAWSPrivateCACredentials(
+	configuration *apiv1.AWSPrivateCACredentials,
+	getSecretKey func(selector *apiv1.SecretKeySelector) ([]byte, error),
+) (certprovider.AWSCredentials, error) {
+	if configuration == nil {
+		credentials := certprovider.AWSCredentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+			return certprovider.AWSCredentials{}, errMissingAWSCredentials
+		}
+		return credentials, nil
+	}
+
+	var credentials certprovider.AWSCredentials
+	accessKeyID, err := getSecretKey(&configuration.AccessKeyID)
+	if err != nil {
+		return credentials, err
+	}
+	secretAccessKey, err := getSecretKey(&configuration.SecretAccessKey)
+	if err != nil {
+		return credentials, err
+	}
+	credentials.AccessKeyID = string(accessKeyID)
+	credentials.SecretAccessKey = string(secretAccessKey)
+
+	if configuration.SessionToken != nil {
+		sessionToken, err := getSecretKey(configuration.SessionToken)
+		if err != nil {
+			return credentials, err
+		}
+		credentials.SessionToken = string(sessionToken)
+	}
+
+	return credentials, nil
+}
+
+// setupProviderPostgresPKI issues the server and the streaming replication
+// client certificates with the external certificate provider, storing the
+// CA certificates it returns in the CA secrets of the cluster
+func (r *ClusterReconciler) setupProviderPostgresPKI(ctx context.Context, cluster *apiv1.Cluster) error {
+	serverSecret, err := r.ensureProviderLeafCertificate(
+		ctx,
+		cluster,
+		client.ObjectKey{Namespace: cluster.GetNamespace(), Name: cluster.GetServerTLSSecretName()},
+		cluster.GetServiceReadWriteName(),
+		certs.CertTypeServer,
+		cluster.GetClusterAltDNSNames(),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("issuing server TLS certificate: %w", err)
+	}
+	if err := r.ensureProviderCASecret(ctx, cluster, cluster.GetServerCASecretName(), serverSecret); err != nil {
+		return fmt.Errorf("updating server CA certificate: %w", err)
+	}
+
+	replicationSecret, err := r.ensureProviderLeafCertificate(
+		ctx,
+		cluster,
+		client.ObjectKey{Namespace: cluster.GetNamespace(), Name: cluster.GetReplicationSecretName()},
+		apiv1.StreamingReplicationUser,
+		certs.CertTypeClient,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("issuing streaming replication client certificate: %w", err)
+	}
+	if err := r.ensureProviderCASecret(ctx, cluster, cluster.GetClientCASecretName(), replicationSecret); err != nil {
+		return fmt.Errorf("updating client CA certificate: %w", err)
+	}
+
+	return nil
+}
+
+// ensureProviderCASecret stores the CA certificate returned by the
+// certificate provider, contained in a leaf certificate secret, in a
+// CA secret of the cluster. The CA private key is not known and is
+// removed, if the secret was previously managed by the operator, while
+// the previous CA certificate is kept until the certificate rotation ends
+func (r *ClusterReconciler) ensureProviderCASecret(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	secretName string,
+	leafSecret *v1.Secret,
+) error {
+	caCertificate := leafSecret.Data[certs.CACertKey]
+	if len(caCertificate) == 0 {
+		return fmt.Errorf("missing %s in secret %s", certs.CACertKey, leafSecret.Name)
+	}
+
+	var secret v1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: secretName}, &secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		caSecret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: cluster.GetNamespace()},
+			Data:       map[string][]byte{certs.CACertKey: caCertificate},
+			Type:       v1.SecretTypeOpaque,
+		}
+		utils.SetAsOwnedBy(&caSecret.ObjectMeta, cluster.ObjectMeta, cluster.TypeMeta)
+		return r.Create(ctx, caSecret)
+
+	case err != nil:
+		return err
+	}
+
+	if _, hasKey := secret.Data[certs.CAPrivateKeyKey]; !hasKey &&
+		bytes.Equal(secret.Data[certs.CACertKey], caCertificate) {
+		return nil
+	}
+
+	origSecret := secret.DeepCopy()
+	delete(secret.Data, certs.CAPrivateKeyKey)
+	certs.ReplaceCACertificate(&secret, caCertificate)
+	return r.Patch(ctx, &secret, client.MergeFrom(origSecret))
+}
+
+// ensureProviderLeafCertificate checks if we have a valid certificate issued
+// by the external certificate provider, and issues a new one when it is
+// missing, expiring, lacking some of the alternative names or when the
+// provider configuration changed. The certificate secret is returned
+func (r *ClusterReconciler) ensureProviderLeafCertificate(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	secretName client.ObjectKey,
+	commonName string,
+	usage certs.CertType,
+	altDNSNames []string,
+	additionalLabels map[string]string,
+) (*v1.Secret, error) {
+	contextLogger := log.FromContext(ctx).WithValues("secret", secretName.Name)
+
+	providerConfiguration := cluster.GetCertificateProvider()
+	providerHash, err := hash.ComputeHash(providerConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret v1.Secret
+	err = r.Get(ctx, secretName, &secret)
+	isNotFound := apierrors.IsNotFound(err)
+	if err != nil && !isNotFound {
+		return nil, err
+	}
+
+	if !isNotFound {
+		reason := getProviderCertificateReissueReason(&secret, providerHash, altDNSNames)
+		if reason == "" {
+			return &secret, nil
+		}
+		contextLogger.Info("Issuing a new certificate with the certificate provider", "reason", reason)
+	}
+
+	provider, err := r.certificateProviderBuilder(
+		providerConfiguration,
+		func(selector *apiv1.SecretKeySelector) ([]byte, error) {
+			return r.getSecretKey(ctx, cluster.Namespace, selector)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request, privateKey, err := certprovider.NewRequest(commonName, certprovider.Usage(usage), altDNSNames)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := provider.Issue(ctx, request)
+	if err != nil {
+		r.Recorder.Eventf(cluster, "Warning", "CertificateIssueFailed",
+			"Issuing certificate %s: %v", secretName.Name, err)
+		return nil, err
+	}
+
+	data := map[string][]byte{
+		certs.TLSPrivateKeyKey: privateKey,
+		certs.TLSCertKey:       certificate.Certificate,
+		certs.CACertKey:        certificate.CA,
+	}
+
+	if isNotFound {
+		secret = v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName.Name,
+				Namespace:   secretName.Namespace,
+				Annotations: map[string]string{utils.CertificateProviderHashAnnotationName: providerHash},
+				Labels:      additionalLabels,
+			},
+			Data: data,
+			Type: v1.SecretTypeTLS,
+		}
+		utils.SetAsOwnedBy(&secret.ObjectMeta, cluster.ObjectMeta, cluster.TypeMeta)
+		if err := r.Create(ctx, &secret); err != nil {
+			return nil, err
+		}
+	} else {
+		origSecret := secret.DeepCopy()
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[utils.CertificateProviderHashAnnotationName] = providerHash
+		secret.Data = data
+		if err := r.Patch(ctx, &secret, client.MergeFrom(origSecret)); err != nil {
+			return nil, err
+		}
+	}
+
+	r.Recorder.Eventf(cluster, "Normal", "CertificateIssued",
+		"Issued certificate %s with the certificate provider", secretName.Name)
+	return &secret, nil
+}
+
+// getProviderCertificateReissueReason checks whether a certificate secret
+// needs a new certificate from the certificate provider, returning the
+// reason or an empty string when the certificate can be kept
+func getProviderCertificateReissueReason(secret *v1.Secret, providerHash string, altDNSNames []string) string {
+	if secret.Annotations[utils.CertificateProviderHashAnnotationName] != providerHash {
+		return "the certificate provider changed"
+	}
+
+	pair, err := certs.ParseServerSecret(secret)
+	if err != nil {
+		return fmt.Sprintf("invalid certificate: %v", err)
+	}
+
+	if expiring, _, err := pair.IsExpiring(); err != nil || expiring {
+		return "the certificate is expiring"
+	}
+
+	if hasNames, err := certprovider.HasNames(pair.Certificate, altDNSNames); err != nil || !hasNames {
+		return "the alternative DNS names changed"
+	}
+
+	return ""
+}
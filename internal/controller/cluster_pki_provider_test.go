@@ -0,0 +1,203 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	certprovider "github.com/cloudnative-pg/cloudnative-pg/pkg/certs/provider"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCertificateProvider signs the certificate requests
+// with a CA generated by the operator
+type fakeCertificateProvider struct {
+	ca     *certs.KeyPair
+	issued []string
+}
+
+func (f *fakeCertificateProvider) Issue(_ context.Context, request *certprovider.Request) (
+	*certprovider.Certificate, error,
+) {
+	block, _ := pem.Decode(request.CSR)
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	caCertificate, err := f.ca.ParseCertificate()
+	if err != nil {
+		return nil, err
+	}
+	caKey, err := f.ca.ParseECPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(len(f.issued) + 1)),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, caCertificate, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	f.issued = append(f.issued, request.CommonName)
+	return &certprovider.Certificate{
+		Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}),
+		CA:          f.ca.Certificate,
+	}, nil
+}
+
+var _ = Describe("certificate provider", func() {
+	const vaultToken = "s.token"
+
+	var (
+		cluster    *apiv1.Cluster
+		provider   *fakeCertificateProvider
+		reconciler *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Certificates: &apiv1.CertificatesConfiguration{
+					Provider: &apiv1.CertificateProviderConfiguration{
+						Vault: &apiv1.VaultPKICertificateProvider{
+							Address: "https://vault.example.com:8200",
+							Role:    "postgres",
+							Token: apiv1.SecretKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{Name: "vault"},
+								Key:                  "token",
+							},
+						},
+					},
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte(vaultToken)},
+		}
+
+		ca, err := certs.CreateRootCA("external-ca", "test")
+		Expect(err).ToNot(HaveOccurred())
+		provider = &fakeCertificateProvider{ca: ca}
+
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster, secret).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+			certificateProviderBuilder: func(
+				configuration *apiv1.CertificateProviderConfiguration,
+				getSecretKey func(selector *apiv1.SecretKeySelector) ([]byte, error),
+			) (certprovider.Provider, error) {
+				token, err := getSecretKey(&configuration.Vault.Token)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(token)).To(Equal(vaultToken))
+				return provider, nil
+			},
+		}
+	})
+
+	getSecret := func(ctx context.Context, name string) *corev1.Secret {
+		var secret corev1.Secret
+		Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &secret)).To(Succeed())
+		return &secret
+	}
+
+	It("issues the certificates and stores the CA returned by the provider", func(ctx SpecContext) {
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+		Expect(provider.issued).To(Equal([]string{"cluster-example-rw", apiv1.StreamingReplicationUser}))
+
+		serverSecret := getSecret(ctx, cluster.GetServerTLSSecretName())
+		Expect(serverSecret.Type).To(Equal(corev1.SecretTypeTLS))
+		Expect(serverSecret.Annotations).To(HaveKey(utils.CertificateProviderHashAnnotationName))
+		pair, err := certs.ParseServerSecret(serverSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certprovider.HasNames(pair.Certificate, cluster.GetClusterAltDNSNames())).To(BeTrue())
+
+		for _, name := range []string{cluster.GetServerCASecretName(), cluster.GetClientCASecretName()} {
+			caSecret := getSecret(ctx, name)
+			Expect(caSecret.Data).To(Equal(map[string][]byte{certs.CACertKey: provider.ca.Certificate}))
+		}
+	})
+
+	It("keeps the valid certificates", func(ctx SpecContext) {
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+		Expect(provider.issued).To(HaveLen(2))
+	})
+
+	It("reissues the certificates when the provider changes", func(ctx SpecContext) {
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+
+		cluster.Spec.Certificates.Provider.Vault.Role = "postgres-v2"
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+		Expect(provider.issued).To(HaveLen(4))
+	})
+
+	It("replaces the CA managed by the operator", func(ctx SpecContext) {
+		operatorCA, err := certs.CreateRootCA(cluster.Name, cluster.Namespace)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciler.Create(ctx,
+			operatorCA.GenerateCASecret(cluster.Namespace, cluster.GetServerCASecretName()))).To(Succeed())
+
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+		caSecret := getSecret(ctx, cluster.GetServerCASecretName())
+		Expect(caSecret.Data).ToNot(HaveKey(certs.CAPrivateKeyKey))
+		Expect(caSecret.Data[certs.CACertKey]).To(Equal(provider.ca.Certificate))
+	})
+
+	It("goes back to the CA of the operator when the provider is removed", func(ctx SpecContext) {
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+
+		cluster.Spec.Certificates = nil
+		Expect(reconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+
+		caSecret := getSecret(ctx, cluster.GetServerCASecretName())
+		Expect(caSecret.Data).To(HaveKey(certs.CAPrivateKeyKey))
+		serverSecret := getSecret(ctx, cluster.GetServerTLSSecretName())
+		Expect(serverSecret.Annotations).ToNot(HaveKey(utils.CertificateProviderHashAnnotationName))
+		Expect(validateLeafCertificate(caSecret, serverSecret,
+			&x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})).To(Succeed())
+	})
+})
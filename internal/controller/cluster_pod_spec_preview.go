@@ -0,0 +1,97 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
+)
+
+// reconcilePodSpecPreview renders the instance pod as it would be created
+// by the operator, including the mutations of the plugins, when requested
+// through the pod spec preview annotation. The result is stored in a
+// ConfigMap and no pod is created
+func (r *ClusterReconciler) reconcilePodSpecPreview(ctx context.Context, cluster *apiv1.Cluster) error {
+	requestedAt, ok := cluster.Annotations[utils.PodSpecPreviewAnnotationName]
+	if !ok {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Info("Previewing the instance pod spec", "requestedAt", requestedAt)
+
+	pod, renderErr := r.renderInstancePod(ctx, cluster)
+	configMap, err := specs.CreatePodSpecPreview(cluster, requestedAt, pod, renderErr)
+	if err != nil {
+		return err
+	}
+
+	var existingConfigMap corev1.ConfigMap
+	err = r.Get(ctx, client.ObjectKeyFromObject(configMap), &existingConfigMap)
+	switch {
+	case apierrs.IsNotFound(err):
+		err = r.Create(ctx, configMap)
+	case err == nil:
+		updatedConfigMap := existingConfigMap.DeepCopy()
+		updatedConfigMap.Annotations = configMap.Annotations
+		updatedConfigMap.Data = configMap.Data
+		err = r.Patch(ctx, updatedConfigMap, client.MergeFrom(&existingConfigMap))
+	}
+	if err != nil {
+		return fmt.Errorf("while storing the pod spec preview: %w", err)
+	}
+
+	origCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, utils.PodSpecPreviewAnnotationName)
+	return r.Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// renderInstancePod generates the pod of the latest instance of the cluster
+// and applies the mutations of the plugins, without creating it
+func (r *ClusterReconciler) renderInstancePod(ctx context.Context, cluster *apiv1.Cluster) (*corev1.Pod, error) {
+	serial := max(cluster.Status.LatestGeneratedNode, 1)
+	pod := specs.PodWithExistingStorage(*cluster, serial)
+	utils.SetOperatorVersion(&pod.ObjectMeta, versions.Version)
+	utils.InheritAnnotations(&pod.ObjectMeta, cluster.Annotations,
+		cluster.GetFixedInheritedAnnotations(), configuration.Current)
+	utils.InheritLabels(&pod.ObjectMeta, cluster.Labels,
+		cluster.GetFixedInheritedLabels(), configuration.Current)
+
+	pluginClient := getPluginClientFromContext(ctx)
+	mutatedPod, err := pluginClient.LifecycleHook(ctx, plugin.OperationVerbCreate, cluster, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := mutatedPod.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object returned by the plugins: %T", mutatedPod)
+	}
+	return result, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin"
+	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSidecarPluginClient injects a sidecar into the pods
+type fakeSidecarPluginClient struct {
+	cnpgiClient.Client
+	err error
+}
+
+func (f *fakeSidecarPluginClient) LifecycleHook(
+	_ context.Context,
+	operationVerb plugin.OperationVerb,
+	_ client.Object,
+	object client.Object,
+) (client.Object, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	pod, ok := object.(*corev1.Pod)
+	if !ok || operationVerb != plugin.OperationVerbCreate {
+		return object, nil
+	}
+	pod = pod.DeepCopy()
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "log-shipper", Image: "fluent-bit"})
+	return pod, nil
+}
+
+var _ = Describe("pod spec preview", func() {
+	var (
+		env          *testingEnvironment
+		cluster      *apiv1.Cluster
+		pluginClient *fakeSidecarPluginClient
+	)
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace)
+		pluginClient = &fakeSidecarPluginClient{}
+	})
+
+	getPreview := func(ctx context.Context) *corev1.ConfigMap {
+		var configMap corev1.ConfigMap
+		Expect(env.client.Get(ctx, client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      cluster.GetPodSpecPreviewName(),
+		}, &configMap)).To(Succeed())
+		return &configMap
+	}
+
+	requestPreview := func(ctx context.Context, requestedAt string) {
+		origCluster := cluster.DeepCopy()
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		cluster.Annotations[utils.PodSpecPreviewAnnotationName] = requestedAt
+		Expect(env.client.Patch(ctx, cluster, client.MergeFrom(origCluster))).To(Succeed())
+	}
+
+	It("does nothing when no preview has been requested", func(ctx SpecContext) {
+		Expect(env.clusterReconciler.reconcilePodSpecPreview(setPluginClientInContext(ctx, pluginClient),
+			cluster)).To(Succeed())
+
+		err := env.client.Get(ctx, client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      cluster.GetPodSpecPreviewName(),
+		}, &corev1.ConfigMap{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("renders the instance pod including the mutations of the plugins", func(ctx SpecContext) {
+		requestPreview(ctx, "2024-03-01 10:00:00.000000+00")
+		Expect(env.clusterReconciler.reconcilePodSpecPreview(setPluginClientInContext(ctx, pluginClient),
+			cluster)).To(Succeed())
+
+		configMap := getPreview(ctx)
+		Expect(configMap.Annotations).To(HaveKeyWithValue(utils.PodSpecPreviewAnnotationName,
+			"2024-03-01 10:00:00.000000+00"))
+		Expect(configMap.Data).ToNot(HaveKey(specs.PodSpecPreviewErrorKey))
+
+		var pod corev1.Pod
+		Expect(yaml.Unmarshal([]byte(configMap.Data[specs.PodSpecPreviewKey]), &pod)).To(Succeed())
+		Expect(pod.Name).To(Equal(cluster.Name + "-1"))
+		Expect(pod.Spec.Containers).To(ContainElement(HaveField("Name", "log-shipper")))
+
+		var updatedCluster apiv1.Cluster
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.PodSpecPreviewAnnotationName))
+
+		var podList corev1.PodList
+		Expect(env.client.List(ctx, &podList, client.InNamespace(cluster.Namespace))).To(Succeed())
+		Expect(podList.Items).To(BeEmpty())
+	})
+
+	It("reports the conflicts between the plugins", func(ctx SpecContext) {
+		requestPreview(ctx, "2024-03-01 10:00:00.000000+00")
+		Expect(env.clusterReconciler.reconcilePodSpecPreview(setPluginClientInContext(ctx, pluginClient),
+			cluster)).To(Succeed())
+
+		By("requesting a new preview while the plugins conflict", func() {
+			pluginClient.err = cnpgiClient.ErrConflictingMutations
+			requestPreview(ctx, "2024-03-01 11:00:00.000000+00")
+			Expect(env.clusterReconciler.reconcilePodSpecPreview(setPluginClientInContext(ctx, pluginClient),
+				cluster)).To(Succeed())
+		})
+
+		configMap := getPreview(ctx)
+		Expect(configMap.Annotations).To(HaveKeyWithValue(utils.PodSpecPreviewAnnotationName,
+			"2024-03-01 11:00:00.000000+00"))
+		Expect(configMap.Data).ToNot(HaveKey(specs.PodSpecPreviewKey))
+		Expect(configMap.Data[specs.PodSpecPreviewErrorKey]).To(ContainSubstring("conflicting plugin mutations"))
+	})
+})
@@ -0,0 +1,213 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errWaitingForPoolerDrain is raised when a switchover is waiting for
+// the PgBouncer poolers of the cluster to be paused
+var errWaitingForPoolerDrain = errors.New("waiting for the poolers to be paused before the switchover")
+
+// drainPoolersBeforeSwitchover pauses the `rw` PgBouncer poolers of the
+// cluster before promoting the target primary, returning
+// errWaitingForPoolerDrain until every pooler instance has been paused
+// or the drain timeout has expired
+func (r *ClusterReconciler) drainPoolersBeforeSwitchover(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	targetPrimary string,
+) error {
+	if !cluster.IsPoolerDrainEnabled() {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	drain := cluster.Status.PoolerDrain
+	if drain == nil || drain.TargetPrimary != targetPrimary {
+		poolers, err := r.getDrainablePoolers(ctx, cluster)
+		if err != nil {
+			return err
+		}
+		if len(poolers) == 0 {
+			return nil
+		}
+
+		poolerNames := make([]string, 0, len(poolers))
+		for i := range poolers {
+			if err := r.setPoolerSwitchoverPause(ctx, &poolers[i], targetPrimary); err != nil {
+				return err
+			}
+			poolerNames = append(poolerNames, poolers[i].Name)
+		}
+
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.PoolerDrain = &apiv1.PoolerDrainStatus{
+				TargetPrimary: targetPrimary,
+				Poolers:       poolerNames,
+				StartedAt:     metav1.Now(),
+			}
+		}); err != nil {
+			return err
+		}
+
+		contextLogger.Info("Pausing the poolers before the switchover",
+			"targetPrimary", targetPrimary, "poolers", poolerNames)
+		r.Recorder.Eventf(cluster, "Normal", "PoolerDrain",
+			"Pausing the poolers %v before switching over to %s", poolerNames, targetPrimary)
+		return errWaitingForPoolerDrain
+	}
+
+	if time.Since(drain.StartedAt.Time) > cluster.GetPoolerDrainTimeout() {
+		contextLogger.Warning("Timeout expired while waiting for the poolers to be paused, "+
+			"proceeding with the switchover", "targetPrimary", targetPrimary, "poolers", drain.Poolers)
+		r.Recorder.Eventf(cluster, "Warning", "PoolerDrainTimeout",
+			"Timeout expired while pausing the poolers, switching over to %s", targetPrimary)
+		return nil
+	}
+
+	for _, poolerName := range drain.Poolers {
+		var pooler apiv1.Pooler
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: poolerName}, &pooler)
+		if apierrs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("while getting pooler %s: %w", poolerName, err)
+		}
+
+		if int32(len(pooler.Status.SwitchoverPausedInstances)) < pooler.Status.Instances {
+			contextLogger.Debug("Waiting for the pooler to be paused",
+				"pooler", poolerName,
+				"pausedInstances", pooler.Status.SwitchoverPausedInstances,
+				"instances", pooler.Status.Instances)
+			return errWaitingForPoolerDrain
+		}
+	}
+
+	return nil
+}
+
+// resumeDrainedPoolers resumes the poolers that have been paused before
+// a switchover, once the new primary has been promoted. Poolers paused
+// for a switchover that never happened are resumed too, after twice the
+// drain timeout
+func (r *ClusterReconciler) resumeDrainedPoolers(ctx context.Context, cluster *apiv1.Cluster) error {
+	drain := cluster.Status.PoolerDrain
+	if drain == nil {
+		return nil
+	}
+
+	// The switchover is still in progress
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
+		return nil
+	}
+
+	switchoverCompleted := cluster.Status.CurrentPrimary == drain.TargetPrimary
+	drainExpired := time.Since(drain.StartedAt.Time) > 2*cluster.GetPoolerDrainTimeout()
+	if !switchoverCompleted && !drainExpired {
+		return nil
+	}
+
+	for _, poolerName := range drain.Poolers {
+		var pooler apiv1.Pooler
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: poolerName}, &pooler)
+		if apierrs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("while getting pooler %s: %w", poolerName, err)
+		}
+
+		if err := r.setPoolerSwitchoverPause(ctx, &pooler, ""); err != nil {
+			return err
+		}
+	}
+
+	if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.PoolerDrain = nil
+	}); err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Resuming the poolers paused before the switchover",
+		"targetPrimary", drain.TargetPrimary, "poolers", drain.Poolers)
+	r.Recorder.Eventf(cluster, "Normal", "PoolerResume",
+		"Resuming the poolers %v", drain.Poolers)
+	return nil
+}
+
+// getDrainablePoolers gets the PgBouncer poolers pointing to the
+// primary instance of the cluster
+func (r *ClusterReconciler) getDrainablePoolers(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) ([]apiv1.Pooler, error) {
+	poolers, err := r.getClusterPoolers(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(poolers.Items, func(pooler apiv1.Pooler) bool {
+		return pooler.Spec.Type != apiv1.PoolerTypeRW || pooler.GetBackend() != apiv1.PoolerBackendPgBouncer
+	}), nil
+}
+
+// setPoolerSwitchoverPause requests the pooler to be paused before
+// the promotion of the given instance, or to be resumed when the
+// target primary is empty
+func (r *ClusterReconciler) setPoolerSwitchoverPause(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	targetPrimary string,
+) error {
+	currentTargetPrimary, paused := pooler.Annotations[utils.PoolerSwitchoverPauseAnnotationName]
+	if (targetPrimary == "" && !paused) || (paused && currentTargetPrimary == targetPrimary) {
+		return nil
+	}
+
+	origPooler := pooler.DeepCopy()
+	if targetPrimary == "" {
+		delete(pooler.Annotations, utils.PoolerSwitchoverPauseAnnotationName)
+	} else {
+		if pooler.Annotations == nil {
+			pooler.Annotations = make(map[string]string)
+		}
+		pooler.Annotations[utils.PoolerSwitchoverPauseAnnotationName] = targetPrimary
+	}
+
+	if err := r.Patch(ctx, pooler, client.MergeFrom(origPooler)); err != nil {
+		return fmt.Errorf("while setting the switchover pause of pooler %s: %w", pooler.Name, err)
+	}
+
+	return nil
+}
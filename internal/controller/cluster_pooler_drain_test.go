@@ -0,0 +1,124 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pooler drain during switchover", func() {
+	var (
+		env          *testingEnvironment
+		crReconciler *ClusterReconciler
+		cluster      *apiv1.Cluster
+		pooler       *apiv1.Pooler
+	)
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		crReconciler = &ClusterReconciler{
+			Client: fakeClientWithIndexAdapter{
+				Client: env.clusterReconciler.Client,
+			},
+			Scheme:   env.clusterReconciler.Scheme,
+			Recorder: env.clusterReconciler.Recorder,
+		}
+
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.PoolerDrain = &apiv1.PoolerDrainConfiguration{Enabled: true}
+		})
+		pooler = newFakePooler(env.client, cluster)
+		pooler.Status.Instances = 1
+		Expect(env.client.Status().Update(context.Background(), pooler)).To(Succeed())
+	})
+
+	getPooler := func(ctx context.Context) *apiv1.Pooler {
+		var result apiv1.Pooler
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(pooler), &result)).To(Succeed())
+		return &result
+	}
+
+	It("doesn't wait for the poolers when the drain is disabled", func(ctx context.Context) {
+		cluster.Spec.PoolerDrain = nil
+		Expect(crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")).To(Succeed())
+		Expect(getPooler(ctx).Annotations).ToNot(HaveKey(utils.PoolerSwitchoverPauseAnnotationName))
+	})
+
+	It("pauses the poolers and waits for them before the switchover", func(ctx context.Context) {
+		err := crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")
+		Expect(err).To(MatchError(errWaitingForPoolerDrain))
+		Expect(getPooler(ctx).Annotations).To(
+			HaveKeyWithValue(utils.PoolerSwitchoverPauseAnnotationName, "cluster-2"))
+		Expect(cluster.Status.PoolerDrain).ToNot(BeNil())
+		Expect(cluster.Status.PoolerDrain.TargetPrimary).To(Equal("cluster-2"))
+		Expect(cluster.Status.PoolerDrain.Poolers).To(ConsistOf(pooler.Name))
+
+		err = crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")
+		Expect(err).To(MatchError(errWaitingForPoolerDrain))
+
+		pausedPooler := getPooler(ctx)
+		pausedPooler.Status.SwitchoverPausedInstances = []string{"pooler-pod-1"}
+		Expect(env.client.Status().Update(ctx, pausedPooler)).To(Succeed())
+
+		Expect(crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")).To(Succeed())
+	})
+
+	It("proceeds with the switchover when the drain timeout expires", func(ctx context.Context) {
+		cluster.Status.PoolerDrain = &apiv1.PoolerDrainStatus{
+			TargetPrimary: "cluster-2",
+			Poolers:       []string{pooler.Name},
+			StartedAt:     metav1.NewTime(time.Now().Add(-time.Minute)),
+		}
+		Expect(crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")).To(Succeed())
+	})
+
+	It("resumes the poolers once the new primary is promoted", func(ctx context.Context) {
+		Expect(crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")).To(
+			MatchError(errWaitingForPoolerDrain))
+
+		cluster.Status.TargetPrimary = "cluster-2"
+		cluster.Status.CurrentPrimary = "cluster-1"
+		Expect(crReconciler.resumeDrainedPoolers(ctx, cluster)).To(Succeed())
+		Expect(getPooler(ctx).Annotations).To(HaveKey(utils.PoolerSwitchoverPauseAnnotationName))
+		Expect(cluster.Status.PoolerDrain).ToNot(BeNil())
+
+		cluster.Status.CurrentPrimary = "cluster-2"
+		Expect(crReconciler.resumeDrainedPoolers(ctx, cluster)).To(Succeed())
+		Expect(getPooler(ctx).Annotations).ToNot(HaveKey(utils.PoolerSwitchoverPauseAnnotationName))
+		Expect(cluster.Status.PoolerDrain).To(BeNil())
+	})
+
+	It("ignores the poolers pointing to the replicas", func(ctx context.Context) {
+		roPooler := getPooler(ctx)
+		roPooler.Spec.Type = apiv1.PoolerTypeRO
+		Expect(env.client.Update(ctx, roPooler)).To(Succeed())
+
+		Expect(crReconciler.drainPoolersBeforeSwitchover(ctx, cluster, "cluster-2")).To(Succeed())
+		Expect(cluster.Status.PoolerDrain).To(BeNil())
+	})
+})
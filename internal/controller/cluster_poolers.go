@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// getPoolerClusterIndexValue gets the value of the poolerClusterKey index
+// of a Pooler, which includes the namespace of the referenced cluster
+// for cross-namespace Poolers
+func getPoolerClusterIndexValue(pooler *apiv1.Pooler) string {
+	if pooler.IsCrossNamespace() {
+		return getCrossNamespacePoolerIndexValue(pooler.GetClusterNamespace(), pooler.Spec.Cluster.Name)
+	}
+
+	return pooler.Spec.Cluster.Name
+}
+
+// getCrossNamespacePoolerIndexValue gets the value of the poolerClusterKey
+// index of the cross-namespace Poolers referencing the given cluster
+func getCrossNamespacePoolerIndexValue(clusterNamespace, clusterName string) string {
+	return clusterNamespace + "/" + clusterName
+}
+
+// getClusterPoolers gets the Poolers referencing the cluster, including the
+// ones living in the namespaces allowed by `.spec.poolerNamespaces`
+func (r *ClusterReconciler) getClusterPoolers(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (apiv1.PoolerList, error) {
+	var poolers apiv1.PoolerList
+	if err := r.List(ctx, &poolers,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingFields{poolerClusterKey: cluster.Name},
+	); err != nil {
+		return poolers, fmt.Errorf("while getting poolers for cluster %s: %w", cluster.Name, err)
+	}
+
+	if len(cluster.Spec.PoolerNamespaces) == 0 {
+		return poolers, nil
+	}
+
+	var crossNamespacePoolers apiv1.PoolerList
+	if err := r.List(ctx, &crossNamespacePoolers,
+		client.MatchingFields{
+			poolerClusterKey: getCrossNamespacePoolerIndexValue(cluster.Namespace, cluster.Name),
+		},
+	); err != nil {
+		return poolers, fmt.Errorf("while getting cross-namespace poolers for cluster %s: %w", cluster.Name, err)
+	}
+
+	for _, pooler := range crossNamespacePoolers.Items {
+		if cluster.AllowsPoolersFrom(pooler.Namespace) {
+			poolers.Items = append(poolers.Items, pooler)
+		}
+	}
+
+	return poolers, nil
+}
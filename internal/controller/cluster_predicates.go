@@ -74,7 +74,7 @@ var (
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldNode, oldOk := e.ObjectOld.(*corev1.Node)
 			newNode, newOk := e.ObjectNew.(*corev1.Node)
-			return oldOk && newOk && oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable
+			return oldOk && newOk && isNodeBeingDrained(oldNode) != isNodeBeingDrained(newNode)
 		},
 		CreateFunc: func(_ event.CreateEvent) bool {
 			return false
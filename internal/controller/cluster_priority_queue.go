@@ -0,0 +1,172 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"container/heap"
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// clusterPriorities contains the priority of every known cluster,
+// as defined in its specification
+type clusterPriorities struct {
+	priorities sync.Map
+}
+
+// get gets the priority of a cluster, defaulting to zero
+// for the ones not reconciled yet
+func (p *clusterPriorities) get(item ctrl.Request) int32 {
+	priority, ok := p.priorities.Load(item.NamespacedName)
+	if !ok {
+		return 0
+	}
+	return priority.(int32)
+}
+
+// set stores the priority of a cluster
+func (p *clusterPriorities) set(item ctrl.Request, priority int32) {
+	p.priorities.Store(item.NamespacedName, priority)
+}
+
+// remove forgets the priority of a deleted cluster
+func (p *clusterPriorities) remove(item ctrl.Request) {
+	p.priorities.Delete(item.NamespacedName)
+}
+
+// recordClusterPriority is a predicate storing the priority of the
+// clusters before they are queued, so that it is known even before
+// their first reconciliation
+func (r *ClusterReconciler) recordClusterPriority() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		if cluster, ok := object.(*apiv1.Cluster); ok {
+			r.priorities.set(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster)}, cluster.Spec.Priority)
+		}
+		return true
+	})
+}
+
+// clusterQueueItem is a cluster waiting in the queue
+type clusterQueueItem struct {
+	request  ctrl.Request
+	priority int32
+	sequence uint64
+}
+
+// clusterQueueHeap keeps the waiting clusters sorted by priority,
+// and then by the order they were added to the queue
+type clusterQueueHeap []clusterQueueItem
+
+func (h clusterQueueHeap) Len() int { return len(h) }
+
+func (h clusterQueueHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].sequence < h[j].sequence
+}
+
+func (h clusterQueueHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *clusterQueueHeap) Push(x any) { *h = append(*h, x.(clusterQueueItem)) }
+
+func (h *clusterQueueHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// clusterPriorityQueue is the storage of the work queue of the cluster
+// controller, making the workers get the waiting clusters with the highest
+// priority first. The work queue calls it while holding its own lock, so
+// it doesn't need any synchronization
+type clusterPriorityQueue struct {
+	items      clusterQueueHeap
+	sequence   uint64
+	priorities *clusterPriorities
+}
+
+var _ workqueue.Queue[ctrl.Request] = &clusterPriorityQueue{}
+
+// newClusterWorkQueue creates the work queue of the cluster controller.
+// It is the default rate limited work queue of controller-runtime, with its
+// de-duplication of the delayed clusters, its shutdown semantics and its
+// metrics, storing the waiting clusters sorted by priority
+func newClusterWorkQueue(
+	controllerName string,
+	rateLimiter workqueue.TypedRateLimiter[ctrl.Request],
+	priorities *clusterPriorities,
+) workqueue.TypedRateLimitingInterface[ctrl.Request] {
+	queue := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[ctrl.Request]{
+		Name:  controllerName,
+		Queue: &clusterPriorityQueue{priorities: priorities},
+	})
+
+	return workqueue.NewTypedRateLimitingQueueWithConfig(
+		rateLimiter,
+		workqueue.TypedRateLimitingQueueConfig[ctrl.Request]{
+			Name: controllerName,
+			DelayingQueue: workqueue.NewTypedDelayingQueueWithConfig(
+				workqueue.TypedDelayingQueueConfig[ctrl.Request]{
+					Name:  controllerName,
+					Queue: queue,
+				}),
+		})
+}
+
+// Touch refreshes the priority of a waiting cluster added again
+func (q *clusterPriorityQueue) Touch(item ctrl.Request) {
+	priority := q.priorities.get(item)
+	for i := range q.items {
+		if q.items[i].request != item {
+			continue
+		}
+		if q.items[i].priority != priority {
+			q.items[i].priority = priority
+			heap.Fix(&q.items, i)
+		}
+		return
+	}
+}
+
+// Push adds a cluster to the waiting ones
+func (q *clusterPriorityQueue) Push(item ctrl.Request) {
+	q.sequence++
+	heap.Push(&q.items, clusterQueueItem{
+		request:  item,
+		priority: q.priorities.get(item),
+		sequence: q.sequence,
+	})
+}
+
+// Len gets the number of waiting clusters
+func (q *clusterPriorityQueue) Len() int {
+	return q.items.Len()
+}
+
+// Pop gets the waiting cluster with the highest priority
+func (q *clusterPriorityQueue) Pop() ctrl.Request {
+	return heap.Pop(&q.items).(clusterQueueItem).request
+}
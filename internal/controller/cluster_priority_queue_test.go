@@ -0,0 +1,150 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cluster priority queue", func() {
+	var (
+		priorities *clusterPriorities
+		queue      workqueue.TypedRateLimitingInterface[ctrl.Request]
+	)
+
+	request := func(name string) ctrl.Request {
+		return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+	}
+
+	get := func() ctrl.Request {
+		item, shutdown := queue.Get()
+		Expect(shutdown).To(BeFalse())
+		return item
+	}
+
+	BeforeEach(func() {
+		priorities = &clusterPriorities{}
+		queue = newClusterWorkQueue("", workqueue.DefaultTypedControllerRateLimiter[ctrl.Request](), priorities)
+		DeferCleanup(queue.ShutDown)
+	})
+
+	It("serves the clusters with the highest priority first", func() {
+		priorities.set(request("production"), 100)
+		priorities.set(request("development"), -10)
+
+		queue.Add(request("development"))
+		queue.Add(request("first"))
+		queue.Add(request("production"))
+		queue.Add(request("second"))
+		Expect(queue.Len()).To(Equal(4))
+
+		Expect(get()).To(Equal(request("production")))
+		Expect(get()).To(Equal(request("first")))
+		Expect(get()).To(Equal(request("second")))
+		Expect(get()).To(Equal(request("development")))
+	})
+
+	It("refreshes the priority of a cluster added again", func() {
+		queue.Add(request("first"))
+		queue.Add(request("second"))
+
+		priorities.set(request("second"), 10)
+		queue.Add(request("second"))
+		Expect(queue.Len()).To(Equal(2))
+		Expect(get()).To(Equal(request("second")))
+	})
+
+	It("doesn't queue the same cluster twice", func() {
+		queue.Add(request("first"))
+		queue.Add(request("first"))
+		Expect(queue.Len()).To(Equal(1))
+	})
+
+	It("queues again a cluster added while being reconciled", func() {
+		queue.Add(request("first"))
+		item := get()
+
+		queue.Add(item)
+		Expect(queue.Len()).To(BeZero())
+
+		queue.Done(item)
+		Expect(queue.Len()).To(Equal(1))
+		Expect(get()).To(Equal(item))
+	})
+
+	It("adds the clusters after the requested delay", func() {
+		queue.AddAfter(request("first"), 10*time.Millisecond)
+		Expect(queue.Len()).To(BeZero())
+		Eventually(queue.Len).Should(Equal(1))
+	})
+
+	It("adds the delayed clusters only once", func() {
+		queue.AddAfter(request("first"), 10*time.Millisecond)
+		queue.AddAfter(request("first"), 20*time.Millisecond)
+		Eventually(queue.Len).Should(Equal(1))
+
+		item := get()
+		queue.Done(item)
+		Consistently(queue.Len, 100*time.Millisecond).Should(BeZero())
+	})
+
+	It("drops the delayed clusters when shut down", func() {
+		queue.AddAfter(request("first"), 10*time.Millisecond)
+		queue.ShutDown()
+		Consistently(queue.Len, 100*time.Millisecond).Should(BeZero())
+	})
+
+	It("releases the workers when shut down", func() {
+		queue.ShutDown()
+		_, shutdown := queue.Get()
+		Expect(shutdown).To(BeTrue())
+		Expect(queue.ShuttingDown()).To(BeTrue())
+
+		queue.Add(request("first"))
+		Expect(queue.Len()).To(BeZero())
+	})
+
+	It("forgets the priority of the deleted clusters", func() {
+		priorities.set(request("first"), 10)
+		Expect(priorities.get(request("first"))).To(BeEquivalentTo(10))
+
+		priorities.remove(request("first"))
+		Expect(priorities.get(request("first"))).To(BeZero())
+	})
+
+	It("records the priority of the clusters before they are queued", func() {
+		r := &ClusterReconciler{}
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "production"},
+			Spec:       apiv1.ClusterSpec{Priority: 100},
+		}
+
+		Expect(r.recordClusterPriority().Create(event.CreateEvent{Object: cluster})).To(BeTrue())
+		Expect(r.priorities.get(request("production"))).To(BeEquivalentTo(100))
+	})
+})
@@ -0,0 +1,180 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// clusterBackoff is the exponential backoff applied to the retries of the
+// failed reconciliations of a cluster
+type clusterBackoff struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+// clusterRateLimiter is the rate limiter of the cluster controller. Every
+// cluster has its own failure backoff, which can be customized with
+// annotations, so that a cluster stuck in an error loop can be slowed
+// down without delaying the retries of the other ones. The retries of all
+// the clusters are additionally limited by a shared token bucket
+type clusterRateLimiter struct {
+	lock           sync.Mutex
+	failures       map[ctrl.Request]int
+	backoffs       map[ctrl.Request]clusterBackoff
+	defaultBackoff clusterBackoff
+	bucket         *rate.Limiter
+}
+
+var _ workqueue.TypedRateLimiter[ctrl.Request] = &clusterRateLimiter{}
+
+// newClusterRateLimiter creates the rate limiter of the cluster controller
+// from the operator configuration
+func newClusterRateLimiter(conf *configuration.Data) *clusterRateLimiter {
+	return &clusterRateLimiter{
+		failures: make(map[ctrl.Request]int),
+		backoffs: make(map[ctrl.Request]clusterBackoff),
+		defaultBackoff: clusterBackoff{
+			minDelay: conf.GetClusterReconcileMinBackoff(),
+			maxDelay: conf.GetClusterReconcileMaxBackoff(),
+		},
+		bucket: rate.NewLimiter(
+			rate.Limit(conf.GetClusterReconcileQPS()),
+			conf.GetClusterReconcileBurst(),
+		),
+	}
+}
+
+// When gets how long to wait before retrying the reconciliation of a cluster
+func (l *clusterRateLimiter) When(item ctrl.Request) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	backoff, ok := l.backoffs[item]
+	if !ok {
+		backoff = l.defaultBackoff
+	}
+
+	failures := l.failures[item]
+	l.failures[item] = failures + 1
+
+	delay := float64(backoff.minDelay) * math.Pow(2, float64(failures))
+	if delay > float64(backoff.maxDelay) {
+		delay = float64(backoff.maxDelay)
+	}
+
+	return max(time.Duration(delay), l.bucket.Reserve().Delay())
+}
+
+// Forget resets the failures of a cluster after a successful reconciliation
+func (l *clusterRateLimiter) Forget(item ctrl.Request) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.failures, item)
+}
+
+// NumRequeues gets the number of consecutive failed reconciliations of a cluster
+func (l *clusterRateLimiter) NumRequeues(item ctrl.Request) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.failures[item]
+}
+
+// setBackoff sets the failure backoff of a cluster, falling back to
+// the default one when nil is passed
+func (l *clusterRateLimiter) setBackoff(item ctrl.Request, backoff *clusterBackoff) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if backoff == nil {
+		delete(l.backoffs, item)
+		return
+	}
+	l.backoffs[item] = *backoff
+}
+
+// removeCluster forgets everything about a deleted cluster
+func (l *clusterRateLimiter) removeCluster(item ctrl.Request) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.failures, item)
+	delete(l.backoffs, item)
+}
+
+// getClusterBackoff gets the failure backoff requested with the annotations
+// of a cluster, or nil when the cluster uses the default one
+func (l *clusterRateLimiter) getClusterBackoff(cluster *apiv1.Cluster) (*clusterBackoff, error) {
+	minValue, hasMin := cluster.Annotations[utils.ReconcileMinBackoffAnnotationName]
+	maxValue, hasMax := cluster.Annotations[utils.ReconcileMaxBackoffAnnotationName]
+	if !hasMin && !hasMax {
+		return nil, nil
+	}
+
+	backoff := l.defaultBackoff
+	if hasMin {
+		delay, err := time.ParseDuration(minValue)
+		if err != nil || delay <= 0 {
+			return nil, fmt.Errorf("invalid value for annotation %s: %q",
+				utils.ReconcileMinBackoffAnnotationName, minValue)
+		}
+		backoff.minDelay = delay
+	}
+	if hasMax {
+		delay, err := time.ParseDuration(maxValue)
+		if err != nil || delay <= 0 {
+			return nil, fmt.Errorf("invalid value for annotation %s: %q",
+				utils.ReconcileMaxBackoffAnnotationName, maxValue)
+		}
+		backoff.maxDelay = delay
+	}
+	if backoff.maxDelay < backoff.minDelay {
+		return nil, fmt.Errorf("the maximum reconciliation backoff (%s) is lower than the minimum one (%s)",
+			backoff.maxDelay, backoff.minDelay)
+	}
+
+	return &backoff, nil
+}
+
+// updateClusterBackoff applies to the rate limiter the failure backoff
+// requested with the annotations of the cluster
+func (r *ClusterReconciler) updateClusterBackoff(ctx context.Context, req ctrl.Request, cluster *apiv1.Cluster) {
+	if r.rateLimiter == nil {
+		return
+	}
+
+	backoff, err := r.rateLimiter.getClusterBackoff(cluster)
+	if err != nil {
+		log.FromContext(ctx).Warning("Ignoring the reconciliation backoff annotations", "error", err.Error())
+	}
+	r.rateLimiter.setBackoff(req, backoff)
+}
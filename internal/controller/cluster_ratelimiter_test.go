@@ -0,0 +1,114 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cluster rate limiter", func() {
+	var limiter *clusterRateLimiter
+
+	first := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "first"}}
+	second := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "second"}}
+
+	BeforeEach(func() {
+		limiter = newClusterRateLimiter(&configuration.Data{
+			ClusterReconcileMinBackoff: 1,
+			ClusterReconcileMaxBackoff: 10,
+			ClusterReconcileQPS:        1000,
+			ClusterReconcileBurst:      1000,
+		})
+	})
+
+	It("applies an exponential backoff to the failures of every cluster", func() {
+		Expect(limiter.When(first)).To(Equal(1 * time.Second))
+		Expect(limiter.When(first)).To(Equal(2 * time.Second))
+		Expect(limiter.When(first)).To(Equal(4 * time.Second))
+		Expect(limiter.When(first)).To(Equal(8 * time.Second))
+		Expect(limiter.When(first)).To(Equal(10 * time.Second))
+		Expect(limiter.NumRequeues(first)).To(Equal(5))
+
+		Expect(limiter.When(second)).To(Equal(1 * time.Second))
+
+		limiter.Forget(first)
+		Expect(limiter.NumRequeues(first)).To(BeZero())
+		Expect(limiter.When(first)).To(Equal(1 * time.Second))
+	})
+
+	It("uses the backoff set for a cluster", func() {
+		limiter.setBackoff(first, &clusterBackoff{minDelay: time.Minute, maxDelay: 3 * time.Minute})
+
+		Expect(limiter.When(first)).To(Equal(time.Minute))
+		Expect(limiter.When(first)).To(Equal(2 * time.Minute))
+		Expect(limiter.When(first)).To(Equal(3 * time.Minute))
+		Expect(limiter.When(second)).To(Equal(1 * time.Second))
+
+		limiter.removeCluster(first)
+		Expect(limiter.When(first)).To(Equal(1 * time.Second))
+	})
+
+	It("reads the backoff from the annotations of the cluster", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.ReconcileMaxBackoffAnnotationName: "5m",
+				},
+			},
+		}
+
+		backoff, err := limiter.getClusterBackoff(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backoff).To(Equal(&clusterBackoff{minDelay: time.Second, maxDelay: 5 * time.Minute}))
+	})
+
+	It("uses the default backoff for clusters without annotations", func() {
+		backoff, err := limiter.getClusterBackoff(&apiv1.Cluster{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backoff).To(BeNil())
+	})
+
+	It("rejects invalid backoff annotations", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.ReconcileMinBackoffAnnotationName: "forever",
+				},
+			},
+		}
+		_, err := limiter.getClusterBackoff(cluster)
+		Expect(err).To(HaveOccurred())
+
+		cluster.Annotations = map[string]string{
+			utils.ReconcileMinBackoffAnnotationName: "1m",
+			utils.ReconcileMaxBackoffAnnotationName: "30s",
+		}
+		_, err = limiter.getClusterBackoff(cluster)
+		Expect(err).To(HaveOccurred())
+	})
+})
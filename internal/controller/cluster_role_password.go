@@ -0,0 +1,185 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/robfig/cron"
+	"github.com/sethvargo/go-password/password"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileRolePasswordRotation generates a new password for the managed
+// roles whose rotation is due, and returns when the reconciliation loop
+// should run again for the next rotation. The instance manager applies
+// the new password to the role, and the Poolers using the secret for
+// their authentication reload it.
+func (r *ClusterReconciler) reconcileRolePasswordRotation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	if cluster.Spec.Managed == nil || cluster.IsReplica() {
+		return ctrl.Result{}, nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	now := time.Now()
+	rotatedRoles := make(map[string]metav1.Time)
+	var nextRotation time.Time
+
+	for _, role := range cluster.Spec.Managed.Roles {
+		if role.PasswordRotation == nil || role.PasswordSecret == nil ||
+			role.DisablePassword || role.Ensure == apiv1.EnsureAbsent {
+			continue
+		}
+
+		schedule, err := cron.Parse(role.PasswordRotation.Schedule)
+		if err != nil {
+			contextLogger.Error(err, "while parsing the password rotation schedule", "role", role.Name)
+			continue
+		}
+
+		var secret corev1.Secret
+		err = r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: role.PasswordSecret.Name}, &secret)
+		switch {
+		case apierrs.IsNotFound(err):
+			// The secret will be created with the first password
+		case err != nil:
+			return ctrl.Result{}, err
+		case !isSecretRotatableByCluster(&secret, cluster):
+			contextLogger.Debug("Password secret managed by another controller, skipping the rotation",
+				"role", role.Name, "secret", secret.Name)
+			continue
+		}
+
+		lastRotation := getLastRolePasswordRotationTime(cluster, role.Name, &secret)
+		if due := schedule.Next(lastRotation); !lastRotation.IsZero() && due.After(now) {
+			if nextRotation.IsZero() || due.Before(nextRotation) {
+				nextRotation = due
+			}
+			continue
+		}
+
+		contextLogger.Info("Rotating the password of the managed role", "role", role.Name)
+		if err := r.rotateRolePassword(ctx, cluster, role, &secret); err != nil {
+			return ctrl.Result{}, err
+		}
+		rotatedRoles[role.Name] = metav1.NewTime(now)
+		if due := schedule.Next(now); nextRotation.IsZero() || due.Before(nextRotation) {
+			nextRotation = due
+		}
+	}
+
+	if len(rotatedRoles) > 0 {
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			if cluster.Status.ManagedRolesStatus.PasswordRotation == nil {
+				cluster.Status.ManagedRolesStatus.PasswordRotation = make(map[string]metav1.Time)
+			}
+			for name, rotation := range rotatedRoles {
+				cluster.Status.ManagedRolesStatus.PasswordRotation[name] = rotation
+			}
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if nextRotation.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: nextRotation.Sub(now)}, nil
+}
+
+// isSecretRotatableByCluster checks if the operator can write the password
+// in the passed secret. Secrets owned by another controller, i.e. by an
+// ExternalSecret, get their password rotated by the external secret manager.
+func isSecretRotatableByCluster(secret *corev1.Secret, cluster *apiv1.Cluster) bool {
+	owner := metav1.GetControllerOf(secret)
+	if owner == nil {
+		return true
+	}
+
+	clusterName, owned := IsOwnedByCluster(secret)
+	return owned && clusterName == cluster.Name
+}
+
+// getLastRolePasswordRotationTime gets the time when the password of the
+// passed role was last changed. The creation of the password secret counts
+// as a rotation, and a zero time is returned when the secret doesn't exist.
+func getLastRolePasswordRotationTime(cluster *apiv1.Cluster, roleName string, secret *corev1.Secret) time.Time {
+	lastRotation := secret.CreationTimestamp.Time
+	if lastRotation.IsZero() {
+		return lastRotation
+	}
+
+	if rotation, ok := cluster.Status.ManagedRolesStatus.PasswordRotation[roleName]; ok &&
+		rotation.After(lastRotation) {
+		return rotation.Time
+	}
+
+	return lastRotation
+}
+
+// rotateRolePassword stores a new password in the password secret of the
+// role, together with the connection information built from it, with a
+// single update of the secret
+func (r *ClusterReconciler) rotateRolePassword(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	role apiv1.RoleConfiguration,
+	secret *corev1.Secret,
+) error {
+	newPassword, err := password.Generate(64, 10, 0, false, true)
+	if err != nil {
+		return err
+	}
+
+	proposed := specs.CreateSecret(
+		role.PasswordSecret.Name,
+		cluster.Namespace,
+		cluster.GetServiceReadWriteName(),
+		"*",
+		role.Name,
+		newPassword,
+		utils.UserTypeApp)
+
+	if secret.ResourceVersion == "" {
+		cluster.SetInheritedDataAndOwnership(&proposed.ObjectMeta)
+		return r.Create(ctx, proposed)
+	}
+
+	// The resource version of the secret makes the update
+	// fail if the secret has been changed in the meantime
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(proposed.StringData))
+	}
+	for key, value := range proposed.StringData {
+		secret.Data[key] = []byte(value)
+	}
+	return r.Update(ctx, secret)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("managed roles password rotation", func() {
+	const monthly = "0 0 0 1 * *"
+
+	var (
+		cluster    *apiv1.Cluster
+		fakeClient k8client.Client
+		reconciler *ClusterReconciler
+	)
+
+	newSecret := func(creation time.Time, owner *metav1.OwnerReference) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "app-reader-password",
+				Namespace:         cluster.Namespace,
+				CreationTimestamp: metav1.NewTime(creation),
+			},
+			Data: map[string][]byte{
+				"username": []byte("app_reader"),
+				"password": []byte("old-password"),
+			},
+		}
+		if owner != nil {
+			secret.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return secret
+	}
+
+	buildReconciler := func(objects ...k8client.Object) {
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(append(objects, cluster)...).
+			WithStatusSubresource(cluster).
+			Build()
+		reconciler = &ClusterReconciler{
+			Client:   fakeClient,
+			Recorder: record.NewFakeRecorder(10000),
+			Scheme:   schemeBuilder.BuildWithAllKnownScheme(),
+		}
+	}
+
+	getSecret := func(ctx SpecContext) *corev1.Secret {
+		var secret corev1.Secret
+		Expect(fakeClient.Get(ctx, k8client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      "app-reader-password",
+		}, &secret)).To(Succeed())
+		return &secret
+	}
+
+	// The fake client may not convert the string data of the secrets
+	secretValue := func(secret *corev1.Secret, key string) string {
+		if value, ok := secret.Data[key]; ok {
+			return string(value)
+		}
+		return secret.StringData[key]
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+				UID:       "cluster-uid",
+			},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:  "app_reader",
+							Login: true,
+							PasswordSecret: &apiv1.LocalObjectReference{
+								Name: "app-reader-password",
+							},
+							PasswordRotation: &apiv1.RolePasswordRotation{
+								Schedule: monthly,
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("creates the password secret when it doesn't exist", func(ctx SpecContext) {
+		buildReconciler()
+
+		result, err := reconciler.reconcileRolePasswordRotation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		secret := getSecret(ctx)
+		Expect(secretValue(secret, "username")).To(Equal("app_reader"))
+		Expect(secretValue(secret, "password")).ToNot(BeEmpty())
+		Expect(secret.OwnerReferences).To(ConsistOf(HaveField("UID", cluster.UID)))
+		Expect(cluster.Status.ManagedRolesStatus.PasswordRotation).To(HaveKey("app_reader"))
+	})
+
+	It("waits for the next scheduled rotation", func(ctx SpecContext) {
+		buildReconciler(newSecret(time.Now(), nil))
+
+		result, err := reconciler.reconcileRolePasswordRotation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(getSecret(ctx).Data).To(HaveKeyWithValue("password", []byte("old-password")))
+		Expect(cluster.Status.ManagedRolesStatus.PasswordRotation).To(BeEmpty())
+	})
+
+	It("rotates the password and the connection information when due", func(ctx SpecContext) {
+		buildReconciler(newSecret(time.Now().AddDate(0, -2, 0), nil))
+
+		result, err := reconciler.reconcileRolePasswordRotation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		secret := getSecret(ctx)
+		Expect(secret.Data["password"]).ToNot(Equal([]byte("old-password")))
+		Expect(secret.Data).To(HaveKey("uri"))
+		Expect(string(secret.Data["pgpass"])).To(ContainSubstring(string(secret.Data["password"])))
+		Expect(cluster.Status.ManagedRolesStatus.PasswordRotation).To(HaveKey("app_reader"))
+	})
+
+	It("leaves the rotation of externally managed secrets to their controller", func(ctx SpecContext) {
+		buildReconciler(newSecret(time.Now().AddDate(0, -2, 0), &metav1.OwnerReference{
+			APIVersion: "external-secrets.io/v1beta1",
+			Kind:       "ExternalSecret",
+			Name:       "app-reader-password",
+			UID:        "external-secret-uid",
+			Controller: ptr.To(true),
+		}))
+
+		result, err := reconciler.reconcileRolePasswordRotation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(getSecret(ctx).Data).To(HaveKeyWithValue("password", []byte("old-password")))
+	})
+})
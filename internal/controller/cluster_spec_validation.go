@@ -0,0 +1,137 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// reconcileSpecValidation validates the specification of the cluster when
+// the operator runs without the admission webhooks. The changes are validated
+// against the last accepted specification, as the webhook would do against
+// the previous version of the cluster. An invalid cluster is reported in the
+// SpecValid condition and is not reconciled until fixed
+func (r *ClusterReconciler) reconcileSpecValidation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (*ctrl.Result, error) {
+	if !configuration.Current.DisableWebhooks {
+		return nil, nil
+	}
+
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionSpecValid),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonSpecAccepted),
+		Message: "The cluster specification is valid",
+	}
+
+	// The validation functions can alter the status of the
+	// clusters they are invoked on, hence the copies
+	validatedCluster := cluster.DeepCopy()
+	allErrs := validatedCluster.Validate()
+	lastAcceptedCluster, err := getLastAcceptedCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if lastAcceptedCluster != nil {
+		allErrs = append(allErrs, validatedCluster.ValidateChanges(lastAcceptedCluster)...)
+	}
+
+	lastAcceptedSpec := cluster.Status.LastAcceptedSpec
+	if len(allErrs) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonSpecRejected)
+		condition.Message = allErrs.ToAggregate().Error()
+	} else {
+		encodedSpec, err := json.Marshal(cluster.Spec)
+		if err != nil {
+			return nil, err
+		}
+		lastAcceptedSpec = string(encodedSpec)
+	}
+
+	current := meta.FindStatusCondition(cluster.Status.Conditions, condition.Type)
+	if current == nil || current.Status != condition.Status || current.Message != condition.Message ||
+		cluster.Status.LastAcceptedSpec != lastAcceptedSpec {
+		if condition.Status == metav1.ConditionFalse {
+			log.FromContext(ctx).Warning("Invalid cluster specification", "errors", condition.Message)
+			r.Recorder.Event(cluster, "Warning", "InvalidSpec", condition.Message)
+		}
+
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+			cluster.Status.LastAcceptedSpec = lastAcceptedSpec
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		// The cluster will be reconciled again once its specification changes
+		return &ctrl.Result{}, nil
+	}
+
+	return nil, nil
+}
+
+// getLastAcceptedCluster rebuilds the cluster as it was when its specification
+// has been accepted for the last time, or nil if it has never been accepted
+func getLastAcceptedCluster(cluster *apiv1.Cluster) (*apiv1.Cluster, error) {
+	if cluster.Status.LastAcceptedSpec == "" {
+		return nil, nil
+	}
+
+	lastAcceptedCluster := cluster.DeepCopy()
+	lastAcceptedCluster.Spec = apiv1.ClusterSpec{}
+	if err := json.Unmarshal([]byte(cluster.Status.LastAcceptedSpec), &lastAcceptedCluster.Spec); err != nil {
+		return nil, fmt.Errorf("while decoding the last accepted specification: %w", err)
+	}
+
+	// New defaults are applied before validating the changes, as the
+	// admission webhook does with the previous version of the cluster
+	lastAcceptedCluster.SetDefaults()
+	return lastAcceptedCluster, nil
+}
+
+// validateWithoutWebhooks defaults and validates a resource when the operator
+// runs without the admission webhooks, as the API server accepted it without
+// asking the operator. The returned error reports the validation errors
+func validateWithoutWebhooks(object webhook.Validator) error {
+	if !configuration.Current.DisableWebhooks {
+		return nil
+	}
+
+	if defaulter, ok := object.(webhook.Defaulter); ok {
+		defaulter.Default()
+	}
+
+	_, err := object.ValidateCreate()
+	return err
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster specification validation", func() {
+	var (
+		cluster    *apiv1.Cluster
+		recorder   *record.FakeRecorder
+		reconciler *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Instances:            3,
+				StorageConfiguration: apiv1.StorageConfiguration{Size: "10Gi"},
+			},
+		}
+
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: recorder,
+		}
+
+		configuration.Current = configuration.NewConfiguration()
+		configuration.Current.DisableWebhooks = true
+		DeferCleanup(func() {
+			configuration.Current = configuration.NewConfiguration()
+		})
+	})
+
+	It("doesn't validate the clusters when the webhooks are enabled", func(ctx SpecContext) {
+		configuration.Current.DisableWebhooks = false
+		cluster.Spec.ImagePullPolicy = "Sometimes"
+
+		res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(cluster.Status.Conditions).To(BeEmpty())
+	})
+
+	It("accepts a valid cluster", func(ctx SpecContext) {
+		res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionSpecValid))).
+			To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("stops the reconciliation of an invalid cluster", func(ctx SpecContext) {
+		cluster.Spec.ImagePullPolicy = "Sometimes"
+
+		res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(res.IsZero()).To(BeTrue())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionSpecValid))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSpecRejected)))
+		Expect(condition.Message).To(ContainSubstring("spec.imagePullPolicy"))
+		Expect(recorder.Events).To(HaveLen(1))
+
+		By("not repeating the event while the errors are unchanged", func() {
+			_, err := reconciler.reconcileSpecValidation(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		By("resuming the reconciliation once the cluster is fixed", func() {
+			cluster.Spec.ImagePullPolicy = corev1.PullIfNotPresent
+			res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionSpecValid))).
+				To(BeTrue())
+		})
+	})
+
+	It("validates the changes against the last accepted specification", func(ctx SpecContext) {
+		// The clusters are defaulted before being validated
+		cluster.SetDefaults()
+		res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(cluster.Status.LastAcceptedSpec).To(ContainSubstring(`"size":"10Gi"`))
+
+		By("rejecting a reduction of the storage size", func() {
+			cluster.Spec.StorageConfiguration.Size = "5Gi"
+			res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).ToNot(BeNil())
+
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionSpecValid))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("can't shrink existing storage"))
+			Expect(cluster.Status.LastAcceptedSpec).To(ContainSubstring(`"size":"10Gi"`))
+		})
+
+		By("accepting an expansion of the storage", func() {
+			cluster.Spec.StorageConfiguration.Size = "20Gi"
+			res, err := reconciler.reconcileSpecValidation(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(BeNil())
+			Expect(cluster.Status.LastAcceptedSpec).To(ContainSubstring(`"size":"20Gi"`))
+		})
+	})
+
+	It("validates the other resources only without the webhooks", func() {
+		online := true
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example", Namespace: "default"},
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodBarmanObjectStore,
+				Online: &online,
+			},
+		}
+		Expect(validateWithoutWebhooks(backup)).To(MatchError(ContainSubstring("spec.online")))
+
+		configuration.Current.DisableWebhooks = false
+		Expect(validateWithoutWebhooks(backup)).To(Succeed())
+	})
+})
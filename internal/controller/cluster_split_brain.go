@@ -0,0 +1,90 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileSplitBrain acts on the split-brain detected by the instance
+// manager between this cluster and the source cluster of its distributed
+// topology, fencing the primary instance when this cluster is the less
+// advanced one. The fencing stops the writes while keeping the data
+// available to be realigned once the user demotes the cluster
+func (r *ClusterReconciler) reconcileSplitBrain(ctx context.Context, cluster *apiv1.Cluster) error {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionSplitBrainDetected))
+	if condition == nil {
+		return nil
+	}
+
+	// A replica cluster cannot be part of a split-brain anymore
+	if cluster.IsReplica() {
+		// The primary instance needs to be running for the demotion to
+		// proceed, and will be fenced again by the demotion itself
+		fencedInstances, err := utils.GetFencedInstances(cluster.Annotations)
+		if err != nil {
+			return err
+		}
+		if condition.Reason == string(apiv1.ConditionReasonSplitBrainLessAdvanced) &&
+			fencedInstances.Has(cluster.Status.CurrentPrimary) {
+			log.FromContext(ctx).Info("Cluster demoted after a split-brain, unfencing the primary instance",
+				"primary", cluster.Status.CurrentPrimary)
+			if err := utils.NewFencingMetadataExecutor(r.Client).
+				RemoveFencing().
+				ForInstance(cluster.Status.CurrentPrimary).
+				Execute(ctx, client.ObjectKeyFromObject(cluster), cluster); err != nil {
+				return err
+			}
+		}
+
+		return status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			func(cluster *apiv1.Cluster) {
+				meta.RemoveStatusCondition(&cluster.Status.Conditions, string(apiv1.ConditionSplitBrainDetected))
+			},
+		)
+	}
+
+	if condition.Status != metav1.ConditionTrue ||
+		condition.Reason != string(apiv1.ConditionReasonSplitBrainLessAdvanced) ||
+		cluster.Status.CurrentPrimary == "" ||
+		cluster.IsInstanceFenced(cluster.Status.CurrentPrimary) {
+		return nil
+	}
+
+	log.FromContext(ctx).Warning("Split-brain detected, fencing the primary instance",
+		"primary", cluster.Status.CurrentPrimary,
+		"message", condition.Message)
+	r.Recorder.Eventf(cluster, "Warning", "SplitBrainDetected",
+		"Fencing the primary instance %v: %v", cluster.Status.CurrentPrimary, condition.Message)
+
+	return utils.NewFencingMetadataExecutor(r.Client).
+		AddFencing().
+		ForInstance(cluster.Status.CurrentPrimary).
+		Execute(ctx, client.ObjectKeyFromObject(cluster), cluster)
+}
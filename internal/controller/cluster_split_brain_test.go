@@ -0,0 +1,133 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Split-brain", func() {
+	var (
+		cluster    *apiv1.Cluster
+		reconciler *ClusterReconciler
+	)
+
+	newReconciler := func() *ClusterReconciler {
+		primaryPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-a-1",
+				Namespace: "default",
+			},
+		}
+		return &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster, primaryPod).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       apiv1.ClusterKind,
+				APIVersion: apiv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-a",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Primary: "cluster-a",
+					Source:  "cluster-b",
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-a-1",
+				TargetPrimary:  "cluster-a-1",
+				Conditions: []metav1.Condition{
+					{
+						Type:   string(apiv1.ConditionSplitBrainDetected),
+						Status: metav1.ConditionTrue,
+						Reason: string(apiv1.ConditionReasonSplitBrainLessAdvanced),
+					},
+				},
+			},
+		}
+	})
+
+	It("fences the primary instance of the less advanced cluster", func(ctx SpecContext) {
+		reconciler = newReconciler()
+		Expect(reconciler.reconcileSplitBrain(ctx, cluster)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.IsInstanceFenced("cluster-a-1")).To(BeTrue())
+	})
+
+	It("doesn't fence the primary instance of the more advanced cluster", func(ctx SpecContext) {
+		cluster.Status.Conditions[0].Reason = string(apiv1.ConditionReasonSplitBrainMoreAdvanced)
+		reconciler = newReconciler()
+		Expect(reconciler.reconcileSplitBrain(ctx, cluster)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.IsInstanceFenced("cluster-a-1")).To(BeFalse())
+	})
+
+	It("removes the condition once the cluster has been demoted", func(ctx SpecContext) {
+		cluster.Spec.ReplicaCluster.Primary = "cluster-b"
+		reconciler = newReconciler()
+		Expect(reconciler.reconcileSplitBrain(ctx, cluster)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(meta.FindStatusCondition(updatedCluster.Status.Conditions,
+			string(apiv1.ConditionSplitBrainDetected))).To(BeNil())
+	})
+
+	It("unfences the primary instance once the cluster has been demoted", func(ctx SpecContext) {
+		cluster.Annotations = map[string]string{
+			utils.FencedInstanceAnnotation: `["cluster-a-1"]`,
+		}
+		cluster.Spec.ReplicaCluster.Primary = "cluster-b"
+		reconciler = newReconciler()
+		Expect(reconciler.reconcileSplitBrain(ctx, cluster)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.IsInstanceFenced("cluster-a-1")).To(BeFalse())
+		Expect(meta.FindStatusCondition(updatedCluster.Status.Conditions,
+			string(apiv1.ConditionSplitBrainDetected))).To(BeNil())
+	})
+})
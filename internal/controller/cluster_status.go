@@ -25,9 +25,11 @@ import (
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -246,7 +248,7 @@ func (r *ClusterReconciler) updateResourceStatus(
 	contextLogger := log.FromContext(ctx)
 	// Retrieve the cluster key
 
-	existingClusterStatus := cluster.Status
+	origCluster := cluster.DeepCopy()
 
 	persistentvolumeclaim.EnrichStatus(
 		ctx,
@@ -272,10 +274,17 @@ func (r *ClusterReconciler) updateResourceStatus(
 		cluster.Spec.PostgresConfiguration.SyncReplicaElectionConstraint,
 	)
 
+	setHugePagesCondition(cluster, resources.nodes)
+
 	// Services
 	cluster.Status.WriteService = cluster.GetServiceReadWriteName()
 	cluster.Status.ReadService = cluster.GetServiceReadName()
 
+	// Selector of the instances, used by the scale subresource
+	cluster.Status.Selector = fmt.Sprintf("%s=%s,%s=%s",
+		utils.ClusterLabelName, cluster.Name,
+		utils.PodRoleLabelName, utils.PodRoleInstance)
+
 	// If we are switching, check if the target primary is still active
 	// Ignore this check if current primary is empty (it happens during the bootstrap)
 	if cluster.Status.TargetPrimary != cluster.Status.CurrentPrimary &&
@@ -363,10 +372,7 @@ func (r *ClusterReconciler) updateResourceStatus(
 		cluster.Status.DemotionToken = ""
 	}
 
-	if !reflect.DeepEqual(existingClusterStatus, cluster.Status) {
-		return r.Status().Update(ctx, cluster)
-	}
-	return nil
+	return status.PatchIfChanged(ctx, r.Client, cluster, origCluster)
 }
 
 // removeConditionsWithInvalidReason will remove every condition which has a not valid
@@ -387,8 +393,9 @@ func (r *ClusterReconciler) removeConditionsWithInvalidReason(ctx context.Contex
 
 	if !reflect.DeepEqual(cluster.Status.Conditions, conditions) {
 		contextLogger.Info("Updating Cluster to remove conditions with invalid reason")
+		origCluster := cluster.DeepCopy()
 		cluster.Status.Conditions = conditions
-		if err := r.Status().Update(ctx, cluster); err != nil {
+		if err := status.PatchIfChanged(ctx, r.Client, cluster, origCluster); err != nil {
 			return err
 		}
 
@@ -408,21 +415,18 @@ func (r *ClusterReconciler) updateOnlineUpdateEnabled(
 		return nil
 	}
 
+	origCluster := cluster.DeepCopy()
 	cluster.Status.OnlineUpdateEnabled = onlineUpdateEnabled
-	return r.Status().Update(ctx, cluster)
+	return status.PatchIfChanged(ctx, r.Client, cluster, origCluster)
 }
 
 // getPoolerIntegrationsNeeded returns a struct with all the pooler integrations needed
 func (r *ClusterReconciler) getPoolerIntegrationsNeeded(ctx context.Context,
 	cluster *apiv1.Cluster,
 ) (*apiv1.PoolerIntegrations, error) {
-	var poolers apiv1.PoolerList
-
-	err := r.List(ctx, &poolers,
-		client.InNamespace(cluster.Namespace),
-		client.MatchingFields{poolerClusterKey: cluster.Name})
+	poolers, err := r.getClusterPoolers(ctx, cluster)
 	if err != nil {
-		return nil, fmt.Errorf("while getting poolers for cluster %s: %w", cluster.Name, err)
+		return nil, err
 	}
 
 	pgbouncerPoolerIntegrations, err := r.getPgbouncerIntegrationStatus(ctx, cluster, poolers)
@@ -469,7 +473,7 @@ func (r *ClusterReconciler) getPgbouncerIntegrationStatus(
 			continue
 		}
 
-		secretName := pooler.GetAuthQuerySecretName()
+		secretName := pooler.GetClusterAuthQuerySecretName()
 		// there is no need to examine further, the potential secret we may add is already present.
 		// This saves us:
 		// - further API calls to the kube-api server,
@@ -482,7 +486,7 @@ func (r *ClusterReconciler) getPgbouncerIntegrationStatus(
 		authQuerySecret := corev1.Secret{}
 		err := r.Get(
 			ctx,
-			client.ObjectKey{Namespace: cluster.Namespace, Name: pooler.GetAuthQuerySecretName()},
+			client.ObjectKey{Namespace: cluster.Namespace, Name: secretName},
 			&authQuerySecret,
 		)
 		if apierrs.IsNotFound(err) {
@@ -740,8 +744,9 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
 	statuses postgres.PostgresqlStatusList,
+	pvcs []corev1.PersistentVolumeClaim,
 ) error {
-	existingClusterStatus := cluster.Status
+	origCluster := cluster.DeepCopy()
 	cluster.Status.InstancesReportedState = make(map[apiv1.PodName]apiv1.InstanceReportedState, len(statuses.Items))
 
 	// we extract the instances reported state
@@ -749,6 +754,7 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 		cluster.Status.InstancesReportedState[apiv1.PodName(item.Pod.Name)] = apiv1.InstanceReportedState{
 			IsPrimary:  item.IsPrimary,
 			TimeLineID: item.TimeLineID,
+			Volumes:    getInstanceVolumesState(item.Pod.Name, pvcs, item.VolumesUsage),
 		}
 	}
 
@@ -759,12 +765,130 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 		if item.IsPrimary && item.TimeLineID != 0 {
 			cluster.Status.TimelineID = item.TimeLineID
 		}
+
+		if item.IsPrimary {
+			cluster.Status.AdditionalReplicationSlots = getAdditionalReplicationSlotsStatus(
+				cluster.Spec.ReplicationSlots,
+				item.ReplicationSlotsInfo,
+			)
+		}
 	}
 
-	if !reflect.DeepEqual(existingClusterStatus, cluster.Status) {
-		return r.Status().Update(ctx, cluster)
+	// The instance archiving the WAL files is not changed while the
+	// cluster is paused, as this would be acted upon by the instances
+	if !cluster.Spec.Paused {
+		cluster.Status.WALArchiverInstance = electWALArchiverInstance(cluster, statuses)
 	}
-	return nil
+
+	return status.PatchIfChanged(ctx, r.Client, cluster, origCluster)
+}
+
+// getInstanceVolumesState gets the size of the volumes of an instance
+// and the progress of their expansion, given the PVCs of the cluster
+// and the disk usage reported by the instance
+func getInstanceVolumesState(
+	instanceName string,
+	pvcs []corev1.PersistentVolumeClaim,
+	volumesUsage []postgres.VolumeUsage,
+) []apiv1.InstanceVolumeState {
+	var result []apiv1.InstanceVolumeState
+	for _, pvc := range pvcs {
+		if pvc.Labels[utils.InstanceNameLabelName] != instanceName {
+			continue
+		}
+
+		volumeState := apiv1.InstanceVolumeState{
+			PVCName:        pvc.Name,
+			Role:           pvc.Labels[utils.PvcRoleLabelName],
+			TablespaceName: pvc.Labels[utils.TablespaceNameLabelName],
+			ResizeStatus:   persistentvolumeclaim.GetResizeStatus(pvc),
+		}
+		if requestedSize, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			volumeState.RequestedSize = requestedSize.String()
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			volumeState.Capacity = capacity.String()
+		}
+		for _, usage := range volumesUsage {
+			if string(usage.Role) == volumeState.Role && usage.TablespaceName == volumeState.TablespaceName {
+				volumeState.FileSystemSize = resource.NewQuantity(
+					int64(usage.TotalBytes), //nolint:gosec
+					resource.BinarySI).String()
+			}
+		}
+
+		result = append(result, volumeState)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PVCName < result[j].PVCName
+	})
+	return result
+}
+
+// electWALArchiverInstance elects the standby instance that archives the WAL
+// files when the WAL archive target is `standby`. To avoid leaving WAL files
+// behind, the currently elected instance is kept as long as it's a ready
+// standby, otherwise the preferred instance is elected when eligible.
+// An empty string means that the WAL files are archived by the primary
+func electWALArchiverInstance(cluster *apiv1.Cluster, statuses postgres.PostgresqlStatusList) string {
+	if !cluster.IsWALArchivedByStandby() ||
+		cluster.Spec.ReplicationSlots == nil ||
+		!cluster.Spec.ReplicationSlots.HighAvailability.GetEnabled() {
+		return ""
+	}
+
+	eligibleInstances := stringset.New()
+	for _, item := range statuses.Items {
+		if !item.IsPodReady || item.IsPrimary ||
+			item.Pod.Name == cluster.Status.CurrentPrimary ||
+			item.Pod.Name == cluster.Status.TargetPrimary {
+			continue
+		}
+		eligibleInstances.Put(item.Pod.Name)
+	}
+
+	if eligibleInstances.Has(cluster.Status.WALArchiverInstance) {
+		return cluster.Status.WALArchiverInstance
+	}
+	if eligibleInstances.Has(cluster.Spec.Backup.PreferredInstance) {
+		return cluster.Spec.Backup.PreferredInstance
+	}
+	if sortedInstances := eligibleInstances.ToSortedList(); len(sortedInstances) > 0 {
+		return sortedInstances[0]
+	}
+
+	return ""
+}
+
+// getAdditionalReplicationSlotsStatus gets the status of the additional
+// replication slots, given the replication slots reported by the primary
+// instance. The slots that haven't been created yet are reported too
+func getAdditionalReplicationSlotsStatus(
+	config *apiv1.ReplicationSlotsConfiguration,
+	slots postgres.PgReplicationSlotList,
+) []apiv1.AdditionalReplicationSlotStatus {
+	if config == nil || len(config.Additional) == 0 {
+		return nil
+	}
+
+	result := make([]apiv1.AdditionalReplicationSlotStatus, 0, len(config.Additional))
+	for _, additionalSlot := range config.Additional {
+		slotStatus := apiv1.AdditionalReplicationSlotStatus{
+			Name: additionalSlot.Name,
+		}
+		for _, slot := range slots {
+			if slot.SlotName != additionalSlot.Name {
+				continue
+			}
+			slotStatus.Active = slot.Active
+			slotStatus.RestartLSN = slot.RestartLsn
+			slotStatus.LagBytes = slot.LagBytes
+		}
+		result = append(result, slotStatus)
+	}
+
+	return result
 }
 
 // getPodsTopology returns a map with all the information about the pods topology
@@ -21,12 +21,17 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
 
 	v1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -171,3 +176,184 @@ var _ = Describe("cluster_status unit tests", func() {
 		})
 	})
 })
+
+var _ = Describe("getInstanceVolumesState", func() {
+	newPVC := func(
+		name, instanceName string,
+		role utils.PVCRole,
+		requestedSize, capacity string,
+	) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+					utils.PvcRoleLabelName:      string(role),
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(requestedSize),
+					},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimBound,
+				Capacity: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(capacity),
+				},
+			},
+		}
+	}
+
+	pvcs := []corev1.PersistentVolumeClaim{
+		newPVC("cluster-example-1-wal", "cluster-example-1", utils.PVCRolePgWal, "1Gi", "1Gi"),
+		newPVC("cluster-example-1", "cluster-example-1", utils.PVCRolePgData, "2Gi", "1Gi"),
+		newPVC("cluster-example-2", "cluster-example-2", utils.PVCRolePgData, "2Gi", "2Gi"),
+	}
+
+	It("reports the volumes of the instance and their expansion", func() {
+		volumesUsage := []postgres.VolumeUsage{
+			{Role: utils.PVCRolePgData, TotalBytes: 1024 * 1024 * 1024},
+		}
+		Expect(getInstanceVolumesState("cluster-example-1", pvcs, volumesUsage)).To(Equal(
+			[]v1.InstanceVolumeState{
+				{
+					PVCName:        "cluster-example-1",
+					Role:           string(utils.PVCRolePgData),
+					RequestedSize:  "2Gi",
+					Capacity:       "1Gi",
+					FileSystemSize: "1Gi",
+					ResizeStatus:   v1.VolumeResizeStatusInProgress,
+				},
+				{
+					PVCName:       "cluster-example-1-wal",
+					Role:          string(utils.PVCRolePgWal),
+					RequestedSize: "1Gi",
+					Capacity:      "1Gi",
+				},
+			}))
+	})
+
+	It("ignores the volumes of the other instances", func() {
+		Expect(getInstanceVolumesState("cluster-example-3", pvcs, nil)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("getAdditionalReplicationSlotsStatus", func() {
+	It("returns nothing when no additional replication slot is declared", func() {
+		Expect(getAdditionalReplicationSlotsStatus(nil, nil)).To(BeNil())
+		Expect(getAdditionalReplicationSlotsStatus(&v1.ReplicationSlotsConfiguration{}, nil)).To(BeNil())
+	})
+
+	It("reports the additional replication slots found on the primary", func() {
+		config := &v1.ReplicationSlotsConfiguration{
+			Additional: []v1.AdditionalReplicationSlot{
+				{Name: "external_standby"},
+				{Name: "not_created_yet"},
+			},
+		}
+		slots := postgres.PgReplicationSlotList{
+			{SlotName: "_cnpg_cluster_example_2", Active: true, RestartLsn: "0/4000000", LagBytes: ptr.To(int64(0))},
+			{SlotName: "external_standby", Active: true, RestartLsn: "0/3000000", LagBytes: ptr.To(int64(16777216))},
+		}
+
+		Expect(getAdditionalReplicationSlotsStatus(config, slots)).To(Equal(
+			[]v1.AdditionalReplicationSlotStatus{
+				{
+					Name:       "external_standby",
+					Active:     true,
+					RestartLSN: "0/3000000",
+					LagBytes:   ptr.To(int64(16777216)),
+				},
+				{
+					Name: "not_created_yet",
+				},
+			}))
+	})
+})
+
+var _ = Describe("electWALArchiverInstance", func() {
+	newStatus := func(name string, isPrimary, isPodReady bool) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			IsPrimary:  isPrimary,
+			IsPodReady: isPodReady,
+		}
+	}
+
+	statuses := postgres.PostgresqlStatusList{
+		Items: []postgres.PostgresqlStatus{
+			newStatus("cluster-example-1", true, true),
+			newStatus("cluster-example-2", false, false),
+			newStatus("cluster-example-3", false, true),
+			newStatus("cluster-example-4", false, true),
+		},
+	}
+
+	var cluster *v1.Cluster
+	BeforeEach(func() {
+		cluster = &v1.Cluster{
+			Spec: v1.ClusterSpec{
+				Backup: &v1.BackupConfiguration{
+					WALArchiveTarget: v1.WALArchiveTargetStandby,
+				},
+				ReplicationSlots: &v1.ReplicationSlotsConfiguration{
+					HighAvailability: &v1.ReplicationSlotsHAConfiguration{
+						Enabled: ptr.To(true),
+					},
+				},
+			},
+			Status: v1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+	})
+
+	It("elects nobody when the WAL files are archived by the primary", func() {
+		cluster.Spec.Backup.WALArchiveTarget = v1.WALArchiveTargetPrimary
+		Expect(electWALArchiverInstance(cluster, statuses)).To(BeEmpty())
+	})
+
+	It("elects the first ready standby", func() {
+		Expect(electWALArchiverInstance(cluster, statuses)).To(Equal("cluster-example-3"))
+	})
+
+	It("elects the preferred instance when it's a ready standby", func() {
+		cluster.Spec.Backup.PreferredInstance = "cluster-example-4"
+		Expect(electWALArchiverInstance(cluster, statuses)).To(Equal("cluster-example-4"))
+
+		cluster.Spec.Backup.PreferredInstance = "cluster-example-2"
+		Expect(electWALArchiverInstance(cluster, statuses)).To(Equal("cluster-example-3"))
+	})
+
+	It("keeps the elected instance while it's a ready standby", func() {
+		cluster.Spec.Backup.PreferredInstance = "cluster-example-3"
+		cluster.Status.WALArchiverInstance = "cluster-example-4"
+		Expect(electWALArchiverInstance(cluster, statuses)).To(Equal("cluster-example-4"))
+	})
+
+	It("elects another standby when the elected instance has been promoted", func() {
+		cluster.Status.WALArchiverInstance = "cluster-example-3"
+		cluster.Status.CurrentPrimary = "cluster-example-3"
+		cluster.Status.TargetPrimary = "cluster-example-3"
+		promotedStatuses := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-3", true, true),
+				newStatus("cluster-example-4", false, true),
+			},
+		}
+		Expect(electWALArchiverInstance(cluster, promotedStatuses)).To(Equal("cluster-example-4"))
+	})
+
+	It("falls back to the primary when no standby is ready", func() {
+		Expect(electWALArchiverInstance(cluster, postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true, true),
+				newStatus("cluster-example-2", false, false),
+			},
+		})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,220 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// storageAutoResizeTarget is a group of volumes, one per instance,
+// sharing the same storage configuration
+type storageAutoResizeTarget struct {
+	description    string
+	role           utils.PVCRole
+	tablespaceName string
+	configuration  *apiv1.StorageConfiguration
+	autoResized    string
+}
+
+// getStorageAutoResizeTargets gets the groups of volumes that should be
+// automatically expanded following the disk usage
+func getStorageAutoResizeTargets(cluster *apiv1.Cluster) []storageAutoResizeTarget {
+	var result []storageAutoResizeTarget
+
+	if cluster.Spec.StorageConfiguration.IsAutoResizeEnabled() {
+		result = append(result, storageAutoResizeTarget{
+			description:   "PGDATA",
+			role:          utils.PVCRolePgData,
+			configuration: &cluster.Spec.StorageConfiguration,
+			autoResized:   cluster.Status.AutoResizedStorage.GetPgDataSize(),
+		})
+	}
+
+	if cluster.Spec.WalStorage.IsAutoResizeEnabled() {
+		result = append(result, storageAutoResizeTarget{
+			description:   "WAL",
+			role:          utils.PVCRolePgWal,
+			configuration: cluster.Spec.WalStorage,
+			autoResized:   cluster.Status.AutoResizedStorage.GetPgWalSize(),
+		})
+	}
+
+	for idx := range cluster.Spec.Tablespaces {
+		tablespace := &cluster.Spec.Tablespaces[idx]
+		if tablespace.IsEphemeral() || !tablespace.Storage.IsAutoResizeEnabled() {
+			continue
+		}
+		result = append(result, storageAutoResizeTarget{
+			description:    fmt.Sprintf("tablespace %s", tablespace.Name),
+			role:           utils.PVCRolePgTablespace,
+			tablespaceName: tablespace.Name,
+			configuration:  &tablespace.Storage,
+			autoResized:    cluster.Status.AutoResizedStorage.GetTablespaceSize(tablespace.Name),
+		})
+	}
+
+	return result
+}
+
+// getMaxUsedPercentage gets the highest disk usage of the volumes, as
+// reported by the instances
+func (target storageAutoResizeTarget) getMaxUsedPercentage(instancesStatus postgres.PostgresqlStatusList) int {
+	result := 0
+	for _, instanceStatus := range instancesStatus.Items {
+		for _, usage := range instanceStatus.VolumesUsage {
+			if usage.Role != target.role || usage.TablespaceName != target.tablespaceName {
+				continue
+			}
+			result = max(result, usage.GetUsedPercentage())
+		}
+	}
+	return result
+}
+
+// isExpansionInProgress checks whether at least one of the volumes has
+// not reached the requested size yet. In that case the usage reported by
+// the instances doesn't reflect the last expansion
+func (target storageAutoResizeTarget) isExpansionInProgress(
+	pvcs []corev1.PersistentVolumeClaim,
+	size resource.Quantity,
+) bool {
+	for _, pvc := range pvcs {
+		if utils.PVCRole(pvc.Labels[utils.PvcRoleLabelName]) != target.role ||
+			pvc.Labels[utils.TablespaceNameLabelName] != target.tablespaceName {
+			continue
+		}
+
+		capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok || capacity.Cmp(size) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// setAutoResizedSize stores the size the volumes have been expanded to
+// inside the status of the cluster
+func (target storageAutoResizeTarget) setAutoResizedSize(cluster *apiv1.Cluster, size string) {
+	if cluster.Status.AutoResizedStorage == nil {
+		cluster.Status.AutoResizedStorage = &apiv1.AutoResizedStorageStatus{}
+	}
+
+	switch target.role {
+	case utils.PVCRolePgData:
+		cluster.Status.AutoResizedStorage.PgData = size
+	case utils.PVCRolePgWal:
+		cluster.Status.AutoResizedStorage.PgWal = size
+	case utils.PVCRolePgTablespace:
+		if cluster.Status.AutoResizedStorage.Tablespaces == nil {
+			cluster.Status.AutoResizedStorage.Tablespaces = make(map[string]string)
+		}
+		cluster.Status.AutoResizedStorage.Tablespaces[target.tablespaceName] = size
+	}
+}
+
+// reconcileStorageAutoResize expands the volumes whose disk usage, as
+// reported by the instances, reached the configured threshold. The new
+// size is stored inside the status, and applied to the PVCs by the usual
+// reconciliation of the storage requests, before PostgreSQL runs out of
+// disk space
+func (r *ClusterReconciler) reconcileStorageAutoResize(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pvcs []corev1.PersistentVolumeClaim,
+	instancesStatus postgres.PostgresqlStatusList,
+) error {
+	if !cluster.ShouldResizeInUseVolumes() {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx).WithName("storage_autoresize")
+
+	for _, target := range getStorageAutoResizeTargets(cluster) {
+		usedPercentage := target.getMaxUsedPercentage(instancesStatus)
+		if usedPercentage < int(target.configuration.AutoResize.GetThreshold()) {
+			continue
+		}
+
+		configuration := target.configuration.WithAutoResizedSize(target.autoResized)
+		currentSize := configuration.GetSizeOrNil()
+		if currentSize == nil || target.isExpansionInProgress(pvcs, *currentSize) {
+			continue
+		}
+
+		nextSize, err := target.configuration.AutoResize.GetNextSize(*currentSize)
+		if err != nil {
+			return err
+		}
+
+		if nextSize.Cmp(*currentSize) <= 0 {
+			message := fmt.Sprintf("The %s volumes are %d%% full and cannot be expanded beyond %s",
+				target.description, usedPercentage, target.configuration.AutoResize.MaxSize)
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionStorageAutoResized))
+			if condition != nil && condition.Message == message {
+				continue
+			}
+
+			contextLogger.Warning("Cannot expand the volumes beyond the maximum size",
+				"volumes", target.description, "usedPercentage", usedPercentage,
+				"maxSize", target.configuration.AutoResize.MaxSize)
+			r.Recorder.Event(cluster, "Warning", "StorageMaxSizeReached", message)
+			if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+				meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+					Type:    string(apiv1.ConditionStorageAutoResized),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(apiv1.ConditionReasonStorageMaxSizeReached),
+					Message: message,
+				})
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		message := fmt.Sprintf("Expanding the %s volumes from %s to %s, as they are %d%% full",
+			target.description, currentSize.String(), nextSize.String(), usedPercentage)
+		contextLogger.Info("Expanding the volumes",
+			"volumes", target.description, "usedPercentage", usedPercentage,
+			"from", currentSize.String(), "to", nextSize.String())
+		r.Recorder.Event(cluster, "Normal", "StorageAutoResized", message)
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			target.setAutoResizedSize(cluster, nextSize.String())
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:    string(apiv1.ConditionStorageAutoResized),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(apiv1.ConditionReasonStorageExpanded),
+				Message: message,
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
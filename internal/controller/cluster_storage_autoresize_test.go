@@ -0,0 +1,122 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("storage auto resize", func() {
+	var cluster *apiv1.Cluster
+
+	newPVC := func(role utils.PVCRole, capacity string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{utils.PvcRoleLabelName: string(role)},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size:       "10Gi",
+					AutoResize: &apiv1.StorageAutoResizeConfiguration{Enabled: true},
+				},
+				WalStorage: &apiv1.StorageConfiguration{
+					Size: "1Gi",
+				},
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name: "tbs1",
+						Storage: apiv1.StorageConfiguration{
+							Size:       "1Gi",
+							AutoResize: &apiv1.StorageAutoResizeConfiguration{Enabled: true},
+						},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				AutoResizedStorage: &apiv1.AutoResizedStorageStatus{PgData: "12Gi"},
+			},
+		}
+	})
+
+	It("selects the volumes where the automatic expansion is enabled", func() {
+		targets := getStorageAutoResizeTargets(cluster)
+		Expect(targets).To(HaveLen(2))
+		Expect(targets[0].role).To(Equal(utils.PVCRolePgData))
+		Expect(targets[0].autoResized).To(Equal("12Gi"))
+		Expect(targets[1].role).To(Equal(utils.PVCRolePgTablespace))
+		Expect(targets[1].tablespaceName).To(Equal("tbs1"))
+	})
+
+	It("gets the highest disk usage reported by the instances", func() {
+		instancesStatus := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					VolumesUsage: []postgres.VolumeUsage{
+						{Role: utils.PVCRolePgData, TotalBytes: 100, AvailableBytes: 30},
+						{Role: utils.PVCRolePgTablespace, TablespaceName: "tbs1", TotalBytes: 100, AvailableBytes: 90},
+					},
+				},
+				{
+					VolumesUsage: []postgres.VolumeUsage{
+						{Role: utils.PVCRolePgData, TotalBytes: 100, AvailableBytes: 15},
+					},
+				},
+			},
+		}
+
+		targets := getStorageAutoResizeTargets(cluster)
+		Expect(targets[0].getMaxUsedPercentage(instancesStatus)).To(Equal(85))
+		Expect(targets[1].getMaxUsedPercentage(instancesStatus)).To(Equal(10))
+	})
+
+	It("detects when the previous expansion is still in progress", func() {
+		target := getStorageAutoResizeTargets(cluster)[0]
+		pvcs := []corev1.PersistentVolumeClaim{
+			newPVC(utils.PVCRolePgData, "12Gi"),
+			newPVC(utils.PVCRolePgWal, "1Gi"),
+		}
+		Expect(target.isExpansionInProgress(pvcs, resource.MustParse("12Gi"))).To(BeFalse())
+
+		pvcs = append(pvcs, newPVC(utils.PVCRolePgData, "10Gi"))
+		Expect(target.isExpansionInProgress(pvcs, resource.MustParse("12Gi"))).To(BeTrue())
+	})
+
+	It("stores the size the volumes have been expanded to", func() {
+		targets := getStorageAutoResizeTargets(cluster)
+		targets[0].setAutoResizedSize(cluster, "15Gi")
+		targets[1].setAutoResizedSize(cluster, "2Gi")
+		Expect(cluster.Status.AutoResizedStorage.PgData).To(Equal("15Gi"))
+		Expect(cluster.Status.AutoResizedStorage.GetTablespaceSize("tbs1")).To(Equal("2Gi"))
+	})
+})
@@ -0,0 +1,176 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+)
+
+// getDesiredInstances gets the number of instances the cluster should
+// be made of. While migrating the volumes to a new storage class, an
+// additional instance is created to replace the outdated ones
+func getDesiredInstances(cluster *apiv1.Cluster, pvcs []corev1.PersistentVolumeClaim) int {
+	if cluster.GetStorageClassMigrationStrategy() != apiv1.StorageClassMigrationStrategyOnline {
+		return cluster.Spec.Instances
+	}
+
+	if len(getInstancesToMigrate(cluster, pvcs)) == 0 {
+		return cluster.Spec.Instances
+	}
+
+	return cluster.Spec.Instances + 1
+}
+
+// getInstancesToMigrate gets the names of the instances whose volumes
+// are using a storage class different from the requested one
+func getInstancesToMigrate(cluster *apiv1.Cluster, pvcs []corev1.PersistentVolumeClaim) []string {
+	outdatedInstances := persistentvolumeclaim.GetInstancesWithOutdatedStorageClass(cluster, pvcs)
+	return slices.DeleteFunc(outdatedInstances, func(instanceName string) bool {
+		return !slices.Contains(cluster.Status.InstanceNames, instanceName)
+	})
+}
+
+// reconcileStorageClassMigration retires, one at a time, the instances whose
+// volumes are using an outdated storage class, once their replacements are
+// ready. The primary instance is retired last, after a switchover
+func (r *ClusterReconciler) reconcileStorageClassMigration(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if cluster.GetStorageClassMigrationStrategy() != apiv1.StorageClassMigrationStrategyOnline {
+		return ctrl.Result{}, nil
+	}
+
+	instancesToMigrate := getInstancesToMigrate(cluster, resources.pvcs.Items)
+	if len(instancesToMigrate) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the replacement instance to be created
+	if cluster.Status.Instances < getDesiredInstances(cluster, resources.pvcs.Items) {
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+	}
+
+	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseStorageClassMigration,
+		fmt.Sprintf("Instances to be migrated: %v", instancesToMigrate)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, instanceName := range instancesToMigrate {
+		if instanceName == cluster.Status.CurrentPrimary || instanceName == cluster.Status.TargetPrimary {
+			continue
+		}
+
+		contextLogger.Info("Retiring instance using an outdated storage class",
+			"instance", instanceName)
+		r.Recorder.Eventf(cluster, "Normal", "StorageClassMigration",
+			"Retiring instance %s using an outdated storage class", instanceName)
+		if err := r.ensureInstanceIsDeleted(ctx, cluster, instanceName); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// Give time to the informer cache to notice the deletion
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+	}
+
+	// Only the primary instance is still using the outdated storage class,
+	// we need to promote one of the migrated replicas
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+	}
+
+	return r.switchoverForStorageClassMigration(ctx, cluster, instancesToMigrate, instancesStatus)
+}
+
+// switchoverForStorageClassMigration promotes the first replica which
+// has already been migrated to the new storage class
+func (r *ClusterReconciler) switchoverForStorageClassMigration(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesToMigrate []string,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).WithValues("currentPrimary", cluster.Status.CurrentPrimary)
+
+	if cluster.GetPrimaryUpdateStrategy() == apiv1.PrimaryUpdateStrategySupervised {
+		contextLogger.Info("Waiting for the user to request a switchover to complete the storage class migration")
+		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForUser,
+			"User must issue a supervised switchover"); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+	}
+
+	for _, targetInstance := range instancesStatus.Items {
+		if targetInstance.Pod == nil || slices.Contains(instancesToMigrate, targetInstance.Pod.Name) {
+			continue
+		}
+
+		// Refuse to promote a replica whose streaming connection is not
+		// active, as we would risk to lose the data written on the primary
+		if !targetInstance.IsWalReceiverActive {
+			contextLogger.Info(
+				"chosen new primary is still not connected via streaming replication, "+
+					"interrupting the storage class migration",
+				"targetPrimary", targetInstance.Pod.Name)
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
+		contextLogger.Info("The primary is using an outdated storage class, we'll trigger a switchover",
+			"targetPrimary", targetInstance.Pod.Name)
+		if idx := indexOfInstance(&instancesStatus, cluster.Status.CurrentPrimary); idx >= 0 {
+			err := r.runPreSwitchoverHooks(ctx, cluster, instancesStatus.Items[idx].Pod, targetInstance.Pod.Name)
+			if errors.Is(err, errWaitingForLifecycleHooks) {
+				contextLogger.Info("Waiting for the lifecycle hooks to be completed before the switchover")
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, ErrNextLoop
+			}
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if err := r.drainPoolersBeforeSwitchover(ctx, cluster, targetInstance.Pod.Name); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.Recorder.Eventf(cluster, "Normal", "Switchover",
+			"Initiating switchover to %s to migrate %s to the new storage class",
+			targetInstance.Pod.Name, cluster.Status.CurrentPrimary)
+		if err := r.setPrimaryInstance(ctx, cluster, targetInstance.Pod.Name); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+	}
+
+	contextLogger.Info("No migrated replica available to be promoted, waiting")
+	return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Storage class migration", func() {
+	newPVC := func(instanceName, storageClass string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: instanceName,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+					utils.PvcRoleLabelName:      string(utils.PVCRolePgData),
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(storageClass),
+			},
+		}
+	}
+
+	newCluster := func(strategy apiv1.StorageClassMigrationStrategy) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances:                     3,
+				StorageClassMigrationStrategy: strategy,
+				StorageConfiguration: apiv1.StorageConfiguration{
+					StorageClass: ptr.To("fast"),
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				InstanceNames: []string{"cluster-example-1", "cluster-example-2", "cluster-example-3"},
+			},
+		}
+	}
+
+	pvcs := []corev1.PersistentVolumeClaim{
+		newPVC("cluster-example-1", "standard"),
+		newPVC("cluster-example-2", "standard"),
+		newPVC("cluster-example-3", "fast"),
+		newPVC("cluster-example-4", "standard"),
+	}
+
+	It("requires an additional instance while migrating online", func() {
+		cluster := newCluster(apiv1.StorageClassMigrationStrategyOnline)
+		Expect(getInstancesToMigrate(cluster, pvcs)).To(
+			Equal([]string{"cluster-example-1", "cluster-example-2"}))
+		Expect(getDesiredInstances(cluster, pvcs)).To(Equal(4))
+	})
+
+	It("doesn't migrate the instances when the strategy is none", func() {
+		cluster := newCluster(apiv1.StorageClassMigrationStrategyNone)
+		Expect(getDesiredInstances(cluster, pvcs)).To(Equal(3))
+
+		cluster.Spec.StorageClassMigrationStrategy = ""
+		Expect(getDesiredInstances(cluster, pvcs)).To(Equal(3))
+	})
+
+	It("requires the requested instances when every volume has been migrated", func() {
+		cluster := newCluster(apiv1.StorageClassMigrationStrategyOnline)
+		cluster.Spec.StorageConfiguration.StorageClass = ptr.To("standard")
+		cluster.Status.InstanceNames = []string{"cluster-example-1", "cluster-example-2"}
+		Expect(getInstancesToMigrate(cluster, pvcs)).To(BeEmpty())
+		Expect(getDesiredInstances(cluster, pvcs)).To(Equal(3))
+	})
+})
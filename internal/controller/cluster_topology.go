@@ -0,0 +1,92 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// ErrNoPrimaryZoneCandidate is raised when a new primary can't be elected
+// because no instance is running in the zones allowed to host the primary
+var ErrNoPrimaryZoneCandidate = fmt.Errorf("no instance is running in the zones allowed to host the primary")
+
+// isNodeInPrimaryZones checks if a node is in one of the zones allowed
+// to host the primary instance of the cluster
+func isNodeInPrimaryZones(cluster *apiv1.Cluster, node *corev1.Node) bool {
+	zones := cluster.GetPrimaryZones()
+	if len(zones) == 0 {
+		return true
+	}
+	if node == nil {
+		return false
+	}
+
+	return slices.Contains(zones, node.Labels[cluster.GetZoneLabel()])
+}
+
+// getPrimaryCandidate gets the most advanced instance that can be promoted,
+// skipping the ones running outside the zones allowed to host the primary.
+// The current primary is always a valid candidate
+func getPrimaryCandidate(
+	cluster *apiv1.Cluster,
+	status postgres.PostgresqlStatusList,
+	resources *managedResources,
+) (postgres.PostgresqlStatus, error) {
+	if len(cluster.GetPrimaryZones()) == 0 {
+		return status.Items[0], nil
+	}
+
+	for _, item := range status.Items {
+		if item.IsPrimary && item.Pod.Name == cluster.Status.CurrentPrimary {
+			return item, nil
+		}
+
+		if resources == nil {
+			continue
+		}
+		if node, found := resources.nodes[item.Node]; found && isNodeInPrimaryZones(cluster, &node) {
+			return item, nil
+		}
+	}
+
+	return postgres.PostgresqlStatus{}, ErrNoPrimaryZoneCandidate
+}
+
+// canNodeHostPrimary checks if the node with the given name is in one of
+// the zones allowed to host the primary instance of the cluster
+func (r *ClusterReconciler) canNodeHostPrimary(ctx context.Context, cluster *apiv1.Cluster, nodeName string) bool {
+	if len(cluster.GetPrimaryZones()) == 0 {
+		return true
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+		log.FromContext(ctx).Error(err, "while getting the zone of a node", "node", nodeName)
+		return false
+	}
+
+	return isNodeInPrimaryZones(cluster, &node)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Primary zones", func() {
+	var (
+		cluster   *apiv1.Cluster
+		status    postgres.PostgresqlStatusList
+		resources *managedResources
+	)
+
+	newStatus := func(podName, nodeName string, isPrimary bool) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod:       &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName}},
+			Node:      nodeName,
+			IsPrimary: isPrimary,
+		}
+	}
+
+	newNode := func(name, zone string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{corev1.LabelTopologyZone: zone},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				Topology: &apiv1.TopologyConfiguration{
+					PrimaryZones: []string{"zone-a", "zone-b"},
+				},
+			},
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+		status = postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-3", "node-3", false),
+				newStatus("cluster-example-2", "node-2", false),
+			},
+		}
+		resources = &managedResources{
+			nodes: map[string]corev1.Node{
+				"node-1": newNode("node-1", "zone-a"),
+				"node-2": newNode("node-2", "zone-b"),
+				"node-3": newNode("node-3", "zone-c"),
+			},
+		}
+	})
+
+	It("promotes the most advanced instance when every zone is allowed", func() {
+		cluster.Spec.Topology = nil
+		candidate, err := getPrimaryCandidate(cluster, status, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Pod.Name).To(Equal("cluster-example-3"))
+	})
+
+	It("skips the instances outside the primary zones", func() {
+		candidate, err := getPrimaryCandidate(cluster, status, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Pod.Name).To(Equal("cluster-example-2"))
+	})
+
+	It("keeps the current primary even if outside the primary zones", func() {
+		status.Items = append([]postgres.PostgresqlStatus{newStatus("cluster-example-1", "node-3", true)},
+			status.Items...)
+		candidate, err := getPrimaryCandidate(cluster, status, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Pod.Name).To(Equal("cluster-example-1"))
+	})
+
+	It("fails when no instance is running in the primary zones", func() {
+		status.Items = status.Items[:1]
+		_, err := getPrimaryCandidate(cluster, status, resources)
+		Expect(err).To(MatchError(ErrNoPrimaryZoneCandidate))
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	corev1 "k8s.io/api/core/v1"
@@ -46,6 +47,10 @@ var errLogShippingReplicaElected = errors.New("log shipping replica elected as a
 // of the operator configuration
 var errRolloutDelayed = errors.New("pod rollout delayed")
 
+// errOutsideMaintenanceWindow is raised when a pod rollout have been
+// deferred until the next maintenance window of the cluster
+var errOutsideMaintenanceWindow = errors.New("pod rollout deferred until the next maintenance window")
+
 type rolloutReason = string
 
 func (r *ClusterReconciler) rolloutRequiredInstances(
@@ -76,6 +81,10 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 			continue
 		}
 
+		if !isRolloutAllowedNow(cluster, podRollout) {
+			return false, errOutsideMaintenanceWindow
+		}
+
 		managerResult := r.rolloutManager.CoordinateRollout(client.ObjectKeyFromObject(cluster), postgresqlStatus.Pod.Name)
 		if !managerResult.RolloutAllowed {
 			r.Recorder.Eventf(
@@ -89,6 +98,12 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 			return false, errRolloutDelayed
 		}
 
+		if podRollout.needsChangeOperandImage {
+			if proceed, err := r.reconcileCanaryUpgrade(ctx, cluster, podList, postgresqlStatus); err != nil || !proceed {
+				return false, err
+			}
+		}
+
 		restartMessage := fmt.Sprintf("Restarting instance %s, because: %s",
 			postgresqlStatus.Pod.Name, podRollout.reason)
 		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseUpgrade, restartMessage); err != nil {
@@ -122,6 +137,10 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 		return false, nil
 	}
 
+	if !isRolloutAllowedNow(cluster, podRollout) {
+		return false, errOutsideMaintenanceWindow
+	}
+
 	managerResult := r.rolloutManager.CoordinateRollout(
 		client.ObjectKeyFromObject(cluster),
 		primaryPostgresqlStatus.Pod.Name)
@@ -137,6 +156,12 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 		return false, errRolloutDelayed
 	}
 
+	if podRollout.needsChangeOperandImage {
+		if proceed, err := r.reconcileCanaryUpgrade(ctx, cluster, podList, *primaryPostgresqlStatus); err != nil || !proceed {
+			return false, err
+		}
+	}
+
 	return r.updatePrimaryPod(ctx, cluster, podList, *primaryPostgresqlStatus.Pod,
 		podRollout.canBeInPlace, podRollout.primaryForceRecreate, podRollout.reason)
 }
@@ -222,6 +247,13 @@ func (r *ClusterReconciler) updatePrimaryPod(
 			"reason", reason,
 			"currentPrimary", primaryPod.Name,
 			"targetPrimary", targetInstance.Pod.Name)
+		if err := r.runPreSwitchoverHooks(ctx, cluster, &primaryPod, targetInstance.Pod.Name); err != nil {
+			return false, err
+		}
+		if err := r.drainPoolersBeforeSwitchover(ctx, cluster, targetInstance.Pod.Name); err != nil {
+			return false, err
+		}
+
 		podList.LogStatus(ctx)
 		r.Recorder.Eventf(cluster, "Normal", "Switchover",
 			"Initiating switchover to %s to upgrade %s", targetInstance.Pod.Name, primaryPod.Name)
@@ -272,6 +304,10 @@ type rollout struct {
 	needsChangeOperatorImage bool
 	needsChangeOperandImage  bool
 
+	// explicitlyRequested is true when the rollout has been requested
+	// by the user, and is not subject to the maintenance windows
+	explicitlyRequested bool
+
 	reason string
 }
 
@@ -351,6 +387,13 @@ func isPodNeedingRollout(
 		return rollout{}
 	}
 
+	// The pods manually changed are left untouched when the drift
+	// is only reported
+	if cluster.IsDriftDetectionEnabled() && !cluster.ShouldRevertDrift() && len(getPodDrift(pod)) > 0 {
+		contextLogger.Info("Skipping the rollout of a manually changed pod", "podName", pod.Name)
+		return rollout{}
+	}
+
 	checkers := map[string]rolloutChecker{
 		"pod has missing PVCs":                 checkHasMissingPVCs,
 		"pod has PVC requiring resizing":       checkHasResizingPVC,
@@ -374,7 +417,8 @@ func isPodNeedingRollout(
 	// If not, we should perform additional legacy checks
 	if hasValidPodSpec(pod) {
 		return applyCheckers(map[string]rolloutChecker{
-			"PodSpec is outdated": checkPodSpecIsOutdated,
+			"PodSpec is outdated":               checkPodSpecIsOutdated,
+			"PodSpec has been manually changed": checkPodSpecHasDrifted,
 		})
 	}
 
@@ -554,9 +598,10 @@ func checkClusterHasNewerRestartAnnotation(pod *corev1.Pod, cluster *apiv1.Clust
 		podRestart := pod.Annotations[utils.ClusterRestartAnnotationName]
 		if clusterRestart != podRestart {
 			return rollout{
-				required:     true,
-				reason:       "cluster has been explicitly restarted via annotation",
-				canBeInPlace: true,
+				required:            true,
+				reason:              "cluster has been explicitly restarted via annotation",
+				canBeInPlace:        true,
+				explicitlyRequested: true,
 			}, nil
 		}
 	}
@@ -609,6 +654,24 @@ func checkPodEnvironmentIsOutdated(pod *corev1.Pod, cluster *apiv1.Cluster) (rol
 	return rollout{}, nil
 }
 
+// checkPodSpecHasDrifted requires the rollout of the pods whose
+// spec has been manually changed, when the drift should be reverted
+func checkPodSpecHasDrifted(pod *corev1.Pod, cluster *apiv1.Cluster) (rollout, error) {
+	if !cluster.IsDriftDetectionEnabled() || !cluster.ShouldRevertDrift() {
+		return rollout{}, nil
+	}
+
+	changedFields := getPodDrift(pod)
+	if len(changedFields) == 0 {
+		return rollout{}, nil
+	}
+
+	return rollout{
+		required: true,
+		reason:   "the PodSpec has been manually changed in " + strings.Join(changedFields, ", "),
+	}, nil
+}
+
 func checkPodSpecIsOutdated(pod *corev1.Pod, cluster *apiv1.Cluster) (rollout, error) {
 	podSpecAnnotation, ok := pod.ObjectMeta.Annotations[utils.PodSpecAnnotationName]
 	if !ok {
@@ -0,0 +1,157 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+type verticalPodAutoscalerManager interface {
+	// IsVerticalPodAutoscalerEnabled returns a boolean indicating if the VerticalPodAutoscaler should exist or not
+	IsVerticalPodAutoscalerEnabled() bool
+	// BuildVerticalPodAutoscaler builds a new VerticalPodAutoscaler object
+	BuildVerticalPodAutoscaler() *unstructured.Unstructured
+}
+
+// reconcileVerticalPodAutoscaler manages the VerticalPodAutoscaler of the
+// cluster and stores the resources it recommends inside the status. The
+// instances are then updated by the usual rolling update, replicas first
+// and primary last, as their resources differ from the recommended ones
+func (r *ClusterReconciler) reconcileVerticalPodAutoscaler(ctx context.Context, cluster *apiv1.Cluster) error {
+	vpa, err := createOrPatchVerticalPodAutoscaler(
+		ctx, r.Client, r.DiscoveryClient, specs.NewClusterVerticalPodAutoscalerManager(cluster))
+	if err != nil {
+		return err
+	}
+
+	return r.updateRecommendedResources(ctx, cluster, vpa)
+}
+
+// updateRecommendedResources stores the resources recommended by the
+// VerticalPodAutoscaler inside the status of the cluster, when they
+// differ from the applied ones by more than the tolerance
+func (r *ClusterReconciler) updateRecommendedResources(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	vpa *unstructured.Unstructured,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if !cluster.IsVerticalPodAutoscalerEnabled() || vpa == nil {
+		if cluster.Status.RecommendedResources == nil {
+			return nil
+		}
+
+		contextLogger.Info("Restoring the resources specified in the cluster")
+		return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.RecommendedResources = nil
+		})
+	}
+
+	recommended, err := specs.GetRecommendedResources(cluster, vpa)
+	if err != nil {
+		return fmt.Errorf("while reading the resources recommended by the VerticalPodAutoscaler: %w", err)
+	}
+	if recommended == nil ||
+		!specs.IsResourcesChangeSignificant(
+			cluster.GetResources(), *recommended, cluster.Spec.VerticalPodAutoscaler.GetTolerance()) {
+		return nil
+	}
+
+	contextLogger.Info("Applying the resources recommended by the VerticalPodAutoscaler",
+		"current", cluster.GetResources(), "recommended", recommended)
+	r.Recorder.Event(cluster, "Normal", "RecommendedResources",
+		"Applying the resources recommended by the VerticalPodAutoscaler")
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.RecommendedResources = recommended
+	})
+}
+
+// createOrPatchVerticalPodAutoscaler creates, updates or deletes the
+// VerticalPodAutoscaler of the cluster, returning it when it exists
+func createOrPatchVerticalPodAutoscaler(
+	ctx context.Context,
+	cli client.Client,
+	discoveryClient discovery.DiscoveryInterface,
+	manager verticalPodAutoscalerManager,
+) (*unstructured.Unstructured, error) {
+	contextLogger := log.FromContext(ctx)
+
+	haveVerticalPodAutoscalerCRD, err := utils.VerticalPodAutoscalerExist(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if !haveVerticalPodAutoscalerCRD {
+		if manager.IsVerticalPodAutoscalerEnabled() {
+			contextLogger.Warning(
+				"VerticalPodAutoscaler CRD not present. Cannot create the VerticalPodAutoscaler object")
+		}
+		return nil, nil
+	}
+
+	expectedVPA := manager.BuildVerticalPodAutoscaler()
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(specs.VerticalPodAutoscalerGVK)
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(expectedVPA), vpa); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("while getting the verticalpodautoscaler: %w", err)
+		}
+		vpa = nil
+	}
+
+	switch {
+	case !manager.IsVerticalPodAutoscalerEnabled() && vpa == nil:
+		return nil, nil
+	case !manager.IsVerticalPodAutoscalerEnabled() && vpa != nil:
+		contextLogger.Info("Deleting VerticalPodAutoscaler")
+		if err := cli.Delete(ctx, vpa); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case manager.IsVerticalPodAutoscalerEnabled() && vpa == nil:
+		contextLogger.Debug("Creating VerticalPodAutoscaler")
+		return expectedVPA, cli.Create(ctx, expectedVPA)
+	default:
+		origVPA := vpa.DeepCopy()
+		vpa.Object["spec"] = expectedVPA.Object["spec"]
+		utils.MergeObjectsMetadata(vpa, expectedVPA)
+
+		if reflect.DeepEqual(origVPA, vpa) {
+			return vpa, nil
+		}
+
+		contextLogger.Debug("Patching VerticalPodAutoscaler")
+		return vpa, cli.Patch(ctx, vpa, client.MergeFrom(origVPA))
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VerticalPodAutoscaler integration", func() {
+	var (
+		cluster         *apiv1.Cluster
+		reconciler      *ClusterReconciler
+		discoveryClient *fakediscovery.FakeDiscovery
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				VerticalPodAutoscaler: &apiv1.VerticalPodAutoscalerConfiguration{Enabled: true},
+			},
+		}
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		discoveryClient = &fakediscovery.FakeDiscovery{
+			Fake: &testing.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "autoscaling.k8s.io/v1",
+						APIResources: []metav1.APIResource{
+							{Name: "verticalpodautoscalers", Kind: "VerticalPodAutoscaler", Namespaced: true},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	getVPA := func(ctx SpecContext) (*unstructured.Unstructured, error) {
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(specs.VerticalPodAutoscalerGVK)
+		err := reconciler.Client.Get(ctx, client.ObjectKeyFromObject(cluster), vpa)
+		return vpa, err
+	}
+
+	withRecommendation := func(memory string) *unstructured.Unstructured {
+		vpa := specs.NewClusterVerticalPodAutoscalerManager(cluster).BuildVerticalPodAutoscaler()
+		vpa.Object["status"] = map[string]interface{}{
+			"recommendation": map[string]interface{}{
+				"containerRecommendations": []interface{}{
+					map[string]interface{}{
+						"containerName": specs.PostgresContainerName,
+						"target":        map[string]interface{}{"memory": memory},
+					},
+				},
+			},
+		}
+		return vpa
+	}
+
+	It("creates the VerticalPodAutoscaler when enabled", func(ctx SpecContext) {
+		_, err := createOrPatchVerticalPodAutoscaler(
+			ctx, reconciler.Client, discoveryClient, specs.NewClusterVerticalPodAutoscalerManager(cluster))
+		Expect(err).ToNot(HaveOccurred())
+
+		vpa, err := getVPA(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		Expect(updateMode).To(Equal("Off"))
+	})
+
+	It("doesn't create the VerticalPodAutoscaler when the CRD is not installed", func(ctx SpecContext) {
+		discoveryClient = &fakediscovery.FakeDiscovery{Fake: &testing.Fake{}}
+		vpa, err := createOrPatchVerticalPodAutoscaler(
+			ctx, reconciler.Client, discoveryClient, specs.NewClusterVerticalPodAutoscalerManager(cluster))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vpa).To(BeNil())
+
+		_, err = getVPA(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("removes the VerticalPodAutoscaler when disabled", func(ctx SpecContext) {
+		manager := specs.NewClusterVerticalPodAutoscalerManager(cluster)
+		Expect(reconciler.Client.Create(ctx, manager.BuildVerticalPodAutoscaler())).To(Succeed())
+
+		cluster.Spec.VerticalPodAutoscaler.Enabled = false
+		_, err := createOrPatchVerticalPodAutoscaler(ctx, reconciler.Client, discoveryClient, manager)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = getVPA(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("applies the recommended resources exceeding the tolerance", func(ctx SpecContext) {
+		Expect(reconciler.updateRecommendedResources(ctx, cluster, withRecommendation("1100Mi"))).To(Succeed())
+		Expect(cluster.Status.RecommendedResources).To(BeNil())
+
+		Expect(reconciler.updateRecommendedResources(ctx, cluster, withRecommendation("2Gi"))).To(Succeed())
+		Expect(cluster.Status.RecommendedResources).ToNot(BeNil())
+		memory := cluster.GetResources().Requests[corev1.ResourceMemory]
+		Expect(memory.String()).To(Equal("2Gi"))
+	})
+
+	It("restores the specified resources when disabled", func(ctx SpecContext) {
+		Expect(reconciler.updateRecommendedResources(ctx, cluster, withRecommendation("2Gi"))).To(Succeed())
+
+		cluster.Spec.VerticalPodAutoscaler.Enabled = false
+		Expect(reconciler.updateRecommendedResources(ctx, cluster, nil)).To(Succeed())
+		Expect(cluster.Status.RecommendedResources).To(BeNil())
+		memory := cluster.GetResources().Requests[corev1.ResourceMemory]
+		Expect(memory.String()).To(Equal("1Gi"))
+	})
+})
@@ -0,0 +1,86 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileWALSpaceFencing fences the primary instance when the volume
+// containing its WAL files is about to be full, as reported by the
+// instance manager. PostgreSQL is shut down cleanly instead of crashing
+// when no space is left, and the fencing is kept until the user frees
+// some space, e.g. with the `wal-cleanup` plugin command, or expands the
+// volume
+func (r *ClusterReconciler) reconcileWALSpaceFencing(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) error {
+	if !cluster.IsWALSpaceProtectionEnabled() ||
+		cluster.Spec.WALSpaceProtection.FencingThreshold == nil ||
+		cluster.Status.CurrentPrimary == "" ||
+		cluster.IsInstanceFenced(cluster.Status.CurrentPrimary) {
+		return nil
+	}
+
+	usedPercentage, ok := getPrimaryWALVolumeUsedPercentage(cluster, instancesStatus)
+	if !ok || usedPercentage < int(*cluster.Spec.WALSpaceProtection.FencingThreshold) {
+		return nil
+	}
+
+	log.FromContext(ctx).Warning("The WAL volume of the primary instance is almost full, fencing it",
+		"primary", cluster.Status.CurrentPrimary,
+		"usedPercentage", usedPercentage)
+	r.Recorder.Eventf(cluster, "Warning", "WALSpaceFencing",
+		"Fencing the primary instance %v as its WAL volume is %d%% full",
+		cluster.Status.CurrentPrimary, usedPercentage)
+
+	return utils.NewFencingMetadataExecutor(r.Client).
+		AddFencing().
+		ForInstance(cluster.Status.CurrentPrimary).
+		Execute(ctx, client.ObjectKeyFromObject(cluster), cluster)
+}
+
+// getPrimaryWALVolumeUsedPercentage gets the usage of the volume containing
+// the WAL files of the current primary instance. The second return value
+// is false when the primary instance didn't report it
+func getPrimaryWALVolumeUsedPercentage(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (int, bool) {
+	for _, instanceStatus := range instancesStatus.Items {
+		if instanceStatus.Pod == nil || instanceStatus.Pod.Name != cluster.Status.CurrentPrimary {
+			continue
+		}
+
+		usage, ok := instanceStatus.GetWALVolumeUsage()
+		if !ok {
+			return 0, false
+		}
+		return usage.GetUsedPercentage(), true
+	}
+
+	return 0, false
+}
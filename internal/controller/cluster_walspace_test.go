@@ -0,0 +1,77 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAL volume usage of the primary instance", func() {
+	cluster := &apiv1.Cluster{
+		Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+	}
+
+	newInstanceStatus := func(name string, usage ...postgres.VolumeUsage) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod:          &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			VolumesUsage: usage,
+		}
+	}
+
+	It("uses the WAL volume when present", func() {
+		instancesStatus := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newInstanceStatus("cluster-example-2",
+					postgres.VolumeUsage{Role: utils.PVCRolePgWal, TotalBytes: 100, AvailableBytes: 1}),
+				newInstanceStatus("cluster-example-1",
+					postgres.VolumeUsage{Role: utils.PVCRolePgData, TotalBytes: 100, AvailableBytes: 90},
+					postgres.VolumeUsage{Role: utils.PVCRolePgWal, TotalBytes: 100, AvailableBytes: 40}),
+			},
+		}
+		usedPercentage, ok := getPrimaryWALVolumeUsedPercentage(cluster, instancesStatus)
+		Expect(ok).To(BeTrue())
+		Expect(usedPercentage).To(Equal(60))
+	})
+
+	It("uses the PGDATA volume when there is no WAL volume", func() {
+		instancesStatus := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newInstanceStatus("cluster-example-1",
+					postgres.VolumeUsage{Role: utils.PVCRolePgData, TotalBytes: 100, AvailableBytes: 5}),
+			},
+		}
+		usedPercentage, ok := getPrimaryWALVolumeUsedPercentage(cluster, instancesStatus)
+		Expect(ok).To(BeTrue())
+		Expect(usedPercentage).To(Equal(95))
+	})
+
+	It("reports when the primary instance didn't report the usage", func() {
+		instancesStatus := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{newInstanceStatus("cluster-example-1")},
+		}
+		_, ok := getPrimaryWALVolumeUsedPercentage(cluster, instancesStatus)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,113 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileWitness ensures that the Deployment and the Service of the
+// witness exist when the witness is enabled, and removes them otherwise
+func (r *ClusterReconciler) reconcileWitness(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !cluster.IsWitnessEnabled() {
+		if err := r.deleteWitnessDeploymentIfExists(ctx, cluster); err != nil {
+			return err
+		}
+
+		return r.serviceReconciler(ctx, cluster, specs.BuildWitnessService(cluster), false)
+	}
+
+	if err := r.createOrPatchWitnessDeployment(ctx, cluster); err != nil {
+		return err
+	}
+
+	return r.serviceReconciler(ctx, cluster, specs.BuildWitnessService(cluster), true)
+}
+
+// createOrPatchWitnessDeployment ensures that the Deployment running
+// the witness matches the cluster specification
+func (r *ClusterReconciler) createOrPatchWitnessDeployment(ctx context.Context, cluster *apiv1.Cluster) error {
+	contextLogger := log.FromContext(ctx)
+
+	deployment, err := specs.BuildWitnessDeployment(cluster)
+	if err != nil {
+		return err
+	}
+
+	var livingDeployment appsv1.Deployment
+	err = r.Get(ctx, client.ObjectKeyFromObject(deployment), &livingDeployment)
+	if apierrs.IsNotFound(err) {
+		contextLogger.Info("Creating the witness deployment", "name", deployment.Name)
+		r.Recorder.Event(cluster, "Normal", "CreatingWitness",
+			fmt.Sprintf("Creating witness Deployment %s", deployment.Name))
+		return r.Create(ctx, deployment)
+	}
+	if err != nil {
+		return fmt.Errorf("while getting the witness deployment: %w", err)
+	}
+
+	if owner, _ := IsOwnedByCluster(&livingDeployment); owner != cluster.Name {
+		return fmt.Errorf("refusing to reconcile deployment: %s, not owned by the cluster", livingDeployment.Name)
+	}
+
+	if livingDeployment.Annotations[utils.CNPGHashAnnotationName] ==
+		deployment.Annotations[utils.CNPGHashAnnotationName] {
+		return nil
+	}
+
+	patchedDeployment := livingDeployment.DeepCopy()
+	patchedDeployment.Spec = deployment.Spec
+	utils.MergeObjectsMetadata(patchedDeployment, deployment)
+
+	contextLogger.Info("Updating the witness deployment", "name", deployment.Name)
+	return r.Patch(ctx, patchedDeployment, client.MergeFrom(&livingDeployment))
+}
+
+// deleteWitnessDeploymentIfExists removes the Deployment
+// running the witness, if present
+func (r *ClusterReconciler) deleteWitnessDeploymentIfExists(ctx context.Context, cluster *apiv1.Cluster) error {
+	var deployment appsv1.Deployment
+	err := r.Get(ctx, client.ObjectKey{Name: cluster.GetWitnessName(), Namespace: cluster.Namespace}, &deployment)
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("while getting the witness deployment: %w", err)
+	}
+
+	if owner, _ := IsOwnedByCluster(&deployment); owner != cluster.Name {
+		return nil
+	}
+
+	r.Recorder.Event(cluster, "Normal", "DeletingWitness",
+		fmt.Sprintf("Deleting witness Deployment %s", deployment.Name))
+	if err := r.Delete(ctx, &deployment); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("while deleting the witness deployment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeWitnessClient struct {
+	reachable bool
+	err       error
+	queried   bool
+}
+
+func (f *fakeWitnessClient) IsInstanceReachable(
+	_ context.Context,
+	_ *apiv1.Cluster,
+	_ *corev1.Pod,
+) (bool, error) {
+	f.queried = true
+	return f.reachable, f.err
+}
+
+var _ = Describe("Witness", func() {
+	var (
+		cluster       *apiv1.Cluster
+		primaryPod    corev1.Pod
+		resources     *managedResources
+		witnessClient *fakeWitnessClient
+		reconciler    *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       apiv1.ClusterKind,
+				APIVersion: apiv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances: 2,
+				Witness:   &apiv1.WitnessConfiguration{Enabled: true},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		primaryPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example-1",
+				Namespace: "default",
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: "10.0.0.1",
+			},
+		}
+		resources = &managedResources{
+			instances: corev1.PodList{Items: []corev1.Pod{primaryPod}},
+		}
+		witnessClient = &fakeWitnessClient{}
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				Build(),
+			Recorder:      record.NewFakeRecorder(10),
+			WitnessClient: witnessClient,
+		}
+	})
+
+	Context("failover consensus", func() {
+		It("doesn't query the witness when it is not enabled", func(ctx SpecContext) {
+			cluster.Spec.Witness = nil
+			Expect(reconciler.enforceWitnessConsensus(ctx, cluster, resources)).To(Succeed())
+			Expect(witnessClient.queried).To(BeFalse())
+		})
+
+		It("allows the failover when the primary Pod is gone", func(ctx SpecContext) {
+			resources.instances.Items = nil
+			Expect(reconciler.enforceWitnessConsensus(ctx, cluster, resources)).To(Succeed())
+			Expect(witnessClient.queried).To(BeFalse())
+		})
+
+		It("allows the failover when the witness cannot reach the primary", func(ctx SpecContext) {
+			Expect(reconciler.enforceWitnessConsensus(ctx, cluster, resources)).To(Succeed())
+			Expect(witnessClient.queried).To(BeTrue())
+		})
+
+		It("suspends the failover when the witness can reach the primary", func(ctx SpecContext) {
+			witnessClient.reachable = true
+			err := reconciler.enforceWitnessConsensus(ctx, cluster, resources)
+			Expect(err).To(MatchError(ErrPrimaryReachableFromWitness))
+		})
+
+		It("suspends the failover when the witness is not available", func(ctx SpecContext) {
+			witnessClient.err = errors.New("connection refused")
+			err := reconciler.enforceWitnessConsensus(ctx, cluster, resources)
+			Expect(err).To(MatchError(ErrWitnessUnavailable))
+		})
+	})
+
+	Context("resources", func() {
+		It("creates and removes the Deployment and the Service of the witness", func(ctx SpecContext) {
+			key := client.ObjectKey{Name: cluster.GetWitnessName(), Namespace: cluster.Namespace}
+
+			Expect(reconciler.reconcileWitness(ctx, cluster)).To(Succeed())
+			Expect(reconciler.Get(ctx, key, &appsv1.Deployment{})).To(Succeed())
+			Expect(reconciler.Get(ctx, key, &corev1.Service{})).To(Succeed())
+
+			cluster.Spec.Witness.Enabled = false
+			Expect(reconciler.reconcileWitness(ctx, cluster)).To(Succeed())
+			err := reconciler.Get(ctx, key, &appsv1.Deployment{})
+			Expect(apierrs.IsNotFound(err)).To(BeTrue())
+			err = reconciler.Get(ctx, key, &corev1.Service{})
+			Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
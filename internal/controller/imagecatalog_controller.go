@@ -0,0 +1,262 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/image/reference"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/registry"
+)
+
+// minorVersionTagRegex matches the tags of PostgreSQL images in
+// the "<major>.<minor><suffix>" format, i.e. "16.4-bookworm"
+var minorVersionTagRegex = regexp.MustCompile(`^(\d+)\.(\d+)(.*)$`)
+
+// registryClient is the interface used to discover the images
+// available in a container registry
+type registryClient interface {
+	ListTags(ctx context.Context, name string) ([]string, error)
+	GetDigest(ctx context.Context, name, tag string) (string, error)
+}
+
+// ImageCatalogReconciler automatically updates the images of the
+// catalogs of a kind, following the new minor versions published
+// in the registries
+type ImageCatalogReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// NewCatalog creates an empty catalog of the reconciled kind
+	NewCatalog func() apiv1.GenericImageCatalog
+
+	registry registryClient
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=imagecatalogs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=imagecatalogs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterimagecatalogs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterimagecatalogs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile checks the registries for new images when the schedule
+// of the catalog is due, and updates the catalog inside its maintenance
+// windows
+func (r *ImageCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	catalog := r.NewCatalog()
+	if err := r.Get(ctx, req.NamespacedName, catalog); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("cannot get the image catalog: %w", err)
+	}
+
+	autoUpdate := catalog.GetSpec().AutoUpdate
+	if autoUpdate == nil {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	lastCheck := catalog.GetCreationTimestamp().Time
+	if catalog.GetStatus().LastCheck != nil {
+		lastCheck = catalog.GetStatus().LastCheck.Time
+	}
+
+	nextCheck, err := autoUpdate.GetNextCheck(lastCheck)
+	if err != nil {
+		r.Recorder.Eventf(catalog, "Warning", "InvalidSchedule",
+			"Cannot parse the automatic update schedule %q: %v", autoUpdate.Schedule, err)
+		return ctrl.Result{}, nil
+	}
+	if nextCheck.After(now) {
+		return ctrl.Result{RequeueAfter: nextCheck.Sub(now)}, nil
+	}
+
+	if !autoUpdate.IsInMaintenanceWindow(now) {
+		nextWindow, ok := autoUpdate.GetNextMaintenanceWindow(now)
+		if !ok {
+			r.Recorder.Event(catalog, "Warning", "InvalidSchedule",
+				"Cannot parse the schedule of any maintenance window")
+			return ctrl.Result{}, nil
+		}
+		contextLogger.Debug("Waiting for the next maintenance window", "nextWindow", nextWindow)
+		return ctrl.Result{RequeueAfter: nextWindow.Sub(now)}, nil
+	}
+
+	updates := r.checkForUpdates(ctx, catalog, now)
+
+	if len(updates) > 0 {
+		origCatalog := catalog.DeepCopyObject().(client.Object)
+		for _, update := range updates {
+			catalog.GetSpec().SetImageForMajor(update.Major, update.Image)
+		}
+		if err := r.Patch(ctx, catalog, client.MergeFrom(origCatalog)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("while updating the images of the catalog: %w", err)
+		}
+		for _, update := range updates {
+			contextLogger.Info("Updated catalog image",
+				"major", update.Major, "image", update.Image, "previousImage", update.PreviousImage)
+			r.Recorder.Eventf(catalog, "Normal", "ImageUpdated",
+				"Updated the image for PostgreSQL %d from %s to %s",
+				update.Major, update.PreviousImage, update.Image)
+		}
+	}
+
+	origCatalog := catalog.DeepCopyObject().(client.Object)
+	for _, update := range updates {
+		catalog.GetStatus().SetImageUpdate(update)
+	}
+	catalog.GetStatus().LastCheck = &metav1.Time{Time: now}
+	if err := r.Status().Patch(ctx, catalog, client.MergeFrom(origCatalog)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while updating the status of the catalog: %w", err)
+	}
+
+	nextCheck, _ = autoUpdate.GetNextCheck(now)
+	return ctrl.Result{RequeueAfter: nextCheck.Sub(now)}, nil
+}
+
+// checkForUpdates gets the images of the catalog that have a newer
+// version in their registry. Registry errors are reported as events
+// and don't prevent the other images from being updated
+func (r *ImageCatalogReconciler) checkForUpdates(
+	ctx context.Context,
+	catalog apiv1.GenericImageCatalog,
+	now time.Time,
+) []apiv1.CatalogImageUpdate {
+	var updates []apiv1.CatalogImageUpdate
+	for _, entry := range catalog.GetSpec().Images {
+		image, err := r.getLatestImage(ctx, entry.Image)
+		if err != nil {
+			r.Recorder.Eventf(catalog, "Warning", "RegistryError",
+				"Cannot check for new versions of %s: %v", entry.Image, err)
+			continue
+		}
+		if image == "" {
+			continue
+		}
+
+		updates = append(updates, apiv1.CatalogImageUpdate{
+			Major:         entry.Major,
+			Image:         image,
+			PreviousImage: entry.Image,
+			UpdatedAt:     metav1.Time{Time: now},
+		})
+	}
+
+	return updates
+}
+
+// getLatestImage gets the latest minor version of the passed image,
+// pinned by digest, or an empty string if the image is up to date
+func (r *ImageCatalogReconciler) getLatestImage(ctx context.Context, image string) (string, error) {
+	ref := reference.New(image)
+	if ref.Tag == "" {
+		// Images referenced only by digest have no version to follow
+		return "", nil
+	}
+
+	tags, err := r.registry.ListTags(ctx, ref.Name)
+	if err != nil {
+		return "", err
+	}
+
+	tag, ok := findLatestMinorTag(ref.Tag, tags)
+	if !ok || (tag == ref.Tag && ref.Digest == "") {
+		return "", nil
+	}
+
+	digest, err := r.registry.GetDigest(ctx, ref.Name, tag)
+	if err != nil {
+		return "", err
+	}
+	if tag == ref.Tag && digest == "sha256:"+ref.Digest {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s:%s@%s", ref.Name, tag, digest), nil
+}
+
+// findLatestMinorTag gets, among the passed tags, the one with the
+// highest minor version having the same major version and suffix
+// of the current tag
+func findLatestMinorTag(current string, tags []string) (string, bool) {
+	major, minor, suffix, ok := parseMinorVersionTag(current)
+	if !ok {
+		return "", false
+	}
+
+	latest, latestMinor := current, minor
+	for _, tag := range tags {
+		tagMajor, tagMinor, tagSuffix, ok := parseMinorVersionTag(tag)
+		if !ok || tagMajor != major || tagSuffix != suffix {
+			continue
+		}
+		if tagMinor > latestMinor {
+			latest, latestMinor = tag, tagMinor
+		}
+	}
+
+	return latest, true
+}
+
+func parseMinorVersionTag(tag string) (int, int, string, bool) {
+	match := minorVersionTagRegex.FindStringSubmatch(tag)
+	if match == nil {
+		return 0, 0, "", false
+	}
+
+	major, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, 0, "", false
+	}
+	minor, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, 0, "", false
+	}
+
+	return major, minor, match[3], true
+}
+
+// SetupWithManager setup this controller inside the controller manager
+func (r *ImageCatalogReconciler) SetupWithManager(mgr ctrl.Manager, name string, maxConcurrentReconciles int) error {
+	if r.registry == nil {
+		r.registry = registry.NewClient(nil)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(r.NewCatalog()).
+		Named(name).
+		Complete(newShardedReconciler(r))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeRegistryClient struct {
+	tags    map[string][]string
+	digests map[string]string
+}
+
+func (f *fakeRegistryClient) ListTags(_ context.Context, name string) ([]string, error) {
+	tags, ok := f.tags[name]
+	if !ok {
+		return nil, errors.New("repository not found")
+	}
+	return tags, nil
+}
+
+func (f *fakeRegistryClient) GetDigest(_ context.Context, name, tag string) (string, error) {
+	digest, ok := f.digests[name+":"+tag]
+	if !ok {
+		return "", errors.New("manifest not found")
+	}
+	return digest, nil
+}
+
+var _ = Describe("Image catalog automatic update", func() {
+	const repository = "ghcr.io/cloudnative-pg/postgresql"
+
+	DescribeTable("finds the latest minor version tag",
+		func(current string, expected string, expectedOK bool) {
+			tags := []string{
+				"15.8", "16.2", "16.3", "16.4", "16.10-bookworm", "16.4-bookworm",
+				"17.0", "16.5rc1-", "latest", "16",
+			}
+			tag, ok := findLatestMinorTag(current, tags)
+			Expect(ok).To(Equal(expectedOK))
+			Expect(tag).To(Equal(expected))
+		},
+		Entry("newer minor with the same major", "16.2", "16.4", true),
+		Entry("already the latest", "16.4", "16.4", true),
+		Entry("same suffix only", "16.3-bookworm", "16.10-bookworm", true),
+		Entry("never changes the major version", "15.7", "15.8", true),
+		Entry("tags without a minor version", "16", "", false),
+		Entry("non-version tags", "latest", "", false),
+	)
+
+	Context("resolving the latest image", func() {
+		var reconciler *ImageCatalogReconciler
+
+		BeforeEach(func() {
+			reconciler = &ImageCatalogReconciler{
+				registry: &fakeRegistryClient{
+					tags: map[string][]string{repository: {"16.3", "16.4"}},
+					digests: map[string]string{
+						repository + ":16.3": "sha256:aaaa",
+						repository + ":16.4": "sha256:bbbb",
+					},
+				},
+			}
+		})
+
+		It("pins the latest minor version by digest", func(ctx context.Context) {
+			image, err := reconciler.getLatestImage(ctx, repository+":16.3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image).To(Equal(repository + ":16.4@sha256:bbbb"))
+		})
+
+		It("doesn't update images already pinned to the latest digest", func(ctx context.Context) {
+			image, err := reconciler.getLatestImage(ctx, repository+":16.4@sha256:bbbb")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image).To(BeEmpty())
+		})
+
+		It("follows the digest of a tag that has been pushed again", func(ctx context.Context) {
+			image, err := reconciler.getLatestImage(ctx, repository+":16.4@sha256:0000")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image).To(Equal(repository + ":16.4@sha256:bbbb"))
+		})
+
+		It("doesn't pin an up to date image", func(ctx context.Context) {
+			image, err := reconciler.getLatestImage(ctx, repository+":16.4")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image).To(BeEmpty())
+		})
+
+		It("skips images referenced only by digest", func(ctx context.Context) {
+			image, err := reconciler.getLatestImage(ctx, repository+"@sha256:aaaa")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image).To(BeEmpty())
+		})
+
+		It("reports registry errors", func(ctx context.Context) {
+			_, err := reconciler.getLatestImage(ctx, "ghcr.io/unknown/postgresql:16.3")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,172 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin"
+	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/lifecycleevents"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// lifecycleEventDeliveryTimeout is the time allowed to deliver a
+// lifecycle event to the destinations configured in the cluster
+const lifecycleEventDeliveryTimeout = 10 * time.Second
+
+// notifyLifecycleEvent notifies a lifecycle event of the cluster to the
+// plugins in the context, through the lifecycle hook of the Kubernetes
+// events, and to the destinations configured in the cluster. The delivery
+// is best-effort: the failures are reported as events of the cluster and
+// never stop the reconciliation
+func notifyLifecycleEvent(
+	ctx context.Context,
+	cli client.Client,
+	recorder record.EventRecorder,
+	cluster *apiv1.Cluster,
+	event lifecycleevents.Event,
+) {
+	contextLogger := log.FromContext(ctx).WithValues("lifecycleEvent", event.Type)
+
+	if pluginClient, ok := ctx.Value(utils.PluginClientKey).(cnpgiClient.Client); ok {
+		kubernetesEvent, err := event.ToKubernetesEvent(cluster)
+		if err == nil {
+			_, err = pluginClient.LifecycleHook(ctx, plugin.OperationVerbCreate, cluster, kubernetesEvent)
+		}
+		if err != nil {
+			contextLogger.Error(err, "while notifying the lifecycle event to the plugins")
+			recorder.Eventf(cluster, "Warning", "LifecycleEventFailed",
+				"Cannot notify the %s event to the plugins: %v", event.Type, err)
+		}
+	}
+
+	if !cluster.ShouldDeliverLifecycleEvent(event.Type) {
+		return
+	}
+
+	configuration, err := getLifecycleEventsConfiguration(ctx, cli, cluster)
+	if err == nil {
+		deliveryCtx, cancel := context.WithTimeout(ctx, lifecycleEventDeliveryTimeout)
+		defer cancel()
+		err = lifecycleevents.Deliver(deliveryCtx, configuration, event)
+	}
+	if err != nil {
+		contextLogger.Error(err, "while delivering the lifecycle event")
+		recorder.Eventf(cluster, "Warning", "LifecycleEventFailed",
+			"Cannot deliver the %s event: %v", event.Type, err)
+		return
+	}
+
+	contextLogger.Debug("Lifecycle event delivered")
+}
+
+// getLifecycleEventsConfiguration gets the destinations of the lifecycle
+// events of the cluster, including the credentials stored in the secrets
+func getLifecycleEventsConfiguration(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) (*lifecycleevents.Configuration, error) {
+	configuration := &lifecycleevents.Configuration{}
+
+	if webhook := cluster.Spec.LifecycleEvents.Webhook; webhook != nil {
+		configuration.Webhook = &lifecycleevents.WebhookConfiguration{URL: webhook.URL}
+		if selector := webhook.AuthorizationSecret; selector != nil {
+			var secret corev1.Secret
+			if err := cli.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: selector.Name},
+				&secret); err != nil {
+				return nil, fmt.Errorf("while getting the webhook authorization secret: %w", err)
+			}
+			value, ok := secret.Data[selector.Key]
+			if !ok {
+				return nil, fmt.Errorf("missing key %q in the webhook authorization secret %q",
+					selector.Key, selector.Name)
+			}
+			configuration.Webhook.Authorization = strings.TrimSpace(string(value))
+		}
+	}
+
+	if nats := cluster.Spec.LifecycleEvents.NATS; nats != nil {
+		configuration.NATS = &lifecycleevents.NATSConfiguration{
+			URL:     nats.URL,
+			Subject: nats.Subject,
+		}
+	}
+
+	return configuration, nil
+}
+
+// reconcilePromotionEvent notifies the promotion of a new primary instance.
+// The primary instances are tracked in memory, so the promotions happening
+// while the operator is not running are not notified
+func (r *ClusterReconciler) reconcilePromotionEvent(ctx context.Context, cluster *apiv1.Cluster) {
+	currentPrimary := cluster.Status.CurrentPrimary
+	if currentPrimary == "" {
+		return
+	}
+
+	previousPrimary, loaded := r.knownPrimaries.Swap(client.ObjectKeyFromObject(cluster), currentPrimary)
+	if !loaded || previousPrimary == currentPrimary {
+		return
+	}
+
+	event := lifecycleevents.NewEvent(cluster, apiv1.LifecycleEventTypePromotion,
+		fmt.Sprintf("Promoted %v in place of %v", currentPrimary, previousPrimary))
+	event.Instance = currentPrimary
+	event.PreviousInstance = previousPrimary.(string)
+	notifyLifecycleEvent(ctx, r.Client, r.Recorder, cluster, event)
+}
+
+// notifyBackupCompleted notifies the completion of a backup. Only the
+// backups observed while running by this operator are notified
+func (r *BackupReconciler) notifyBackupCompleted(ctx context.Context, backup *apiv1.Backup) {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.Cluster.Name,
+	}, &cluster); err != nil {
+		contextLogger.Error(err, "while getting the cluster of the completed backup")
+		return
+	}
+
+	pluginClient, err := cnpgiClient.WithPlugins(ctx, r.Plugins,
+		apiv1.GetPluginConfigurationEnabledPluginNames(cluster.Spec.Plugins)...)
+	if err != nil {
+		contextLogger.Error(err, "while loading the plugins to notify the completed backup")
+		return
+	}
+	defer func() {
+		pluginClient.Close(ctx)
+	}()
+
+	event := lifecycleevents.NewEvent(&cluster, apiv1.LifecycleEventTypeBackupCompleted,
+		fmt.Sprintf("Backup %v completed", backup.Name))
+	event.Backup = backup.Name
+	notifyLifecycleEvent(setPluginClientInContext(ctx, pluginClient), r.Client, r.Recorder, &cluster, event)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin"
+	cnpgiClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/lifecycleevents"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeEventsPluginClient records the Kubernetes events notified
+// to the plugin
+type fakeEventsPluginClient struct {
+	cnpgiClient.Client
+	events []*corev1.Event
+}
+
+func (f *fakeEventsPluginClient) LifecycleHook(
+	_ context.Context,
+	operationVerb plugin.OperationVerb,
+	_ client.Object,
+	object client.Object,
+) (client.Object, error) {
+	if event, ok := object.(*corev1.Event); ok && operationVerb == plugin.OperationVerbCreate {
+		f.events = append(f.events, event)
+	}
+	return object, nil
+}
+
+var _ = Describe("lifecycle events", func() {
+	var (
+		cluster        *apiv1.Cluster
+		pluginClient   *fakeEventsPluginClient
+		recorder       *record.FakeRecorder
+		receivedEvents []lifecycleevents.Event
+		authorization  string
+		statusCode     int
+	)
+
+	BeforeEach(func() {
+		receivedEvents = nil
+		statusCode = http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event lifecycleevents.Event
+			Expect(json.NewDecoder(r.Body).Decode(&event)).To(Succeed())
+			receivedEvents = append(receivedEvents, event)
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(statusCode)
+		}))
+		DeferCleanup(server.Close)
+
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				LifecycleEvents: &apiv1.LifecycleEventsConfiguration{
+					Webhook: &apiv1.LifecycleEventsWebhook{
+						URL: server.URL,
+						AuthorizationSecret: &apiv1.SecretKeySelector{
+							LocalObjectReference: apiv1.LocalObjectReference{Name: "events-webhook"},
+							Key:                  "authorization",
+						},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		pluginClient = &fakeEventsPluginClient{}
+		recorder = record.NewFakeRecorder(120)
+	})
+
+	newFakeClient := func() client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(
+				cluster,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "events-webhook", Namespace: "default"},
+					Data:       map[string][]byte{"authorization": []byte("Bearer secret-token\n")},
+				},
+			).
+			Build()
+	}
+
+	It("notifies the promotion of a new primary", func(ctx SpecContext) {
+		r := &ClusterReconciler{Client: newFakeClient(), Recorder: recorder}
+		pluginCtx := setPluginClientInContext(ctx, pluginClient)
+
+		By("ignoring the first primary observed by the operator", func() {
+			r.reconcilePromotionEvent(pluginCtx, cluster)
+			Expect(pluginClient.events).To(BeEmpty())
+			Expect(receivedEvents).To(BeEmpty())
+		})
+
+		cluster.Status.CurrentPrimary = "cluster-example-2"
+		cluster.Status.TargetPrimary = "cluster-example-2"
+		r.reconcilePromotionEvent(pluginCtx, cluster)
+		r.reconcilePromotionEvent(pluginCtx, cluster)
+
+		Expect(pluginClient.events).To(HaveLen(1))
+		Expect(pluginClient.events[0].Reason).To(Equal("Promotion"))
+		Expect(pluginClient.events[0].Related.Name).To(Equal("cluster-example-2"))
+
+		Expect(receivedEvents).To(HaveLen(1))
+		Expect(receivedEvents[0].Type).To(Equal(apiv1.LifecycleEventTypePromotion))
+		Expect(receivedEvents[0].Instance).To(Equal("cluster-example-2"))
+		Expect(receivedEvents[0].PreviousInstance).To(Equal("cluster-example-1"))
+		Expect(authorization).To(Equal("Bearer secret-token"))
+	})
+
+	It("delivers only the selected types of events", func(ctx SpecContext) {
+		cluster.Spec.LifecycleEvents.Types = []apiv1.LifecycleEventType{apiv1.LifecycleEventTypeBackupCompleted}
+		event := lifecycleevents.NewEvent(cluster, apiv1.LifecycleEventTypeCertificateRotated,
+			"Completed the rotation of the TLS certificates")
+
+		notifyLifecycleEvent(setPluginClientInContext(ctx, pluginClient), newFakeClient(), recorder, cluster, event)
+		Expect(pluginClient.events).To(HaveLen(1))
+		Expect(receivedEvents).To(BeEmpty())
+	})
+
+	It("reports the failed deliveries as events of the cluster", func(ctx SpecContext) {
+		statusCode = http.StatusServiceUnavailable
+		event := lifecycleevents.NewEvent(cluster, apiv1.LifecycleEventTypeCertificateRotated,
+			"Completed the rotation of the TLS certificates")
+
+		notifyLifecycleEvent(ctx, newFakeClient(), recorder, cluster, event)
+		Expect(receivedEvents).To(HaveLen(1))
+		Expect(recorder.Events).To(Receive(ContainSubstring("LifecycleEventFailed")))
+	})
+
+	It("notifies the completion of the backups", func(ctx SpecContext) {
+		r := &BackupReconciler{Client: newFakeClient(), Recorder: recorder}
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example", Namespace: "default"},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+			},
+		}
+
+		r.notifyBackupCompleted(ctx, backup)
+		Expect(receivedEvents).To(HaveLen(1))
+		Expect(receivedEvents[0].Type).To(Equal(apiv1.LifecycleEventTypeBackupCompleted))
+		Expect(receivedEvents[0].Backup).To(Equal("backup-example"))
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,9 +38,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
 )
 
 // PoolerReconciler reconciles a Pooler object
@@ -48,6 +51,7 @@ type PoolerReconciler struct {
 	DiscoveryClient discovery.DiscoveryInterface
 	Scheme          *runtime.Scheme
 	Recorder        record.EventRecorder
+	PoolerClient    remote.PoolerClient
 }
 
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=poolers,verbs=get;list;watch;create;update;patch;delete
@@ -57,6 +61,9 @@ type PoolerReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;create;delete;update;patch;list;watch
 // +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;create;delete;update;patch;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;delete;get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=imagecatalogs,verbs=get;watch;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterimagecatalogs,verbs=get;watch;list
 
 // Reconcile implements the main reconciliation loop for pooler objects
 func (r *PoolerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -76,6 +83,13 @@ func (r *PoolerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, fmt.Errorf("cannot get the pooler resource: %w", err)
 	}
 
+	// Without the admission webhooks, the pooler is validated here
+	if err := validateWithoutWebhooks(&pooler); err != nil {
+		contextLogger.Warning("Invalid pooler, skipping its reconciliation", "errors", err.Error())
+		r.Recorder.Event(&pooler, "Warning", "InvalidSpec", err.Error())
+		return ctrl.Result{}, nil
+	}
+
 	// We make sure that there isn't a cluster with the same name as the pooler
 	conflictingCluster, err := getClusterOrNil(ctx, r.Client, req.NamespacedName)
 	if err != nil {
@@ -98,10 +112,22 @@ func (r *PoolerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	if resources.Cluster == nil {
-		contextLogger.Info("Cluster not found, will retry in 30 seconds", "cluster", pooler.Spec.Cluster.Name)
+		contextLogger.Info("Cluster not found, will retry in 30 seconds",
+			"cluster", pooler.Spec.Cluster.Name, "clusterNamespace", pooler.GetClusterNamespace())
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// Copy the secrets of a cluster living in another namespace
+	if pooler.IsCrossNamespace() {
+		if res, err := r.reconcileCrossNamespaceSecrets(ctx, &pooler, resources); res != nil || err != nil {
+			if res != nil {
+				return *res, err
+			}
+
+			return ctrl.Result{}, fmt.Errorf("cannot copy the secrets of the cluster: %w", err)
+		}
+	}
+
 	if resources.AuthUserSecret == nil {
 		contextLogger.Info("AuthUserSecret not found, waiting 30 seconds", "secret", pooler.GetAuthQuerySecretName())
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -111,6 +137,15 @@ func (r *PoolerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return res, nil
 	}
 
+	// Get the PgBouncer image from the image catalog, if any
+	if res, err := r.reconcilePgBouncerImage(ctx, &pooler); res != nil || err != nil {
+		if res != nil {
+			return *res, err
+		}
+
+		return ctrl.Result{}, fmt.Errorf("cannot set the PgBouncer image: %w", err)
+	}
+
 	// Update the status of the Pooler resource given what we read
 	// from the controlled resources
 	if err := r.updatePoolerStatus(ctx, &pooler, resources); err != nil {
@@ -123,7 +158,16 @@ func (r *PoolerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Take the required actions to align the spec with the collected status
-	return ctrl.Result{}, r.updateOwnedObjects(ctx, &pooler, resources)
+	if err := r.updateOwnedObjects(ctx, &pooler, resources); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Keep the saturation indicators in the status up to date
+	if pooler.GetBackend() == apiv1.PoolerBackendPgBouncer {
+		return ctrl.Result{RequeueAfter: poolerStatsInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
 }
 
 // SetupWithManager setup this controller inside the controller manager
@@ -134,6 +178,7 @@ func (r *PoolerReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRecon
 		Named("pooler").
 		Owns(&v1.Deployment{}).
 		Owns(&corev1.Service{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -142,7 +187,20 @@ func (r *PoolerReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRecon
 			handler.EnqueueRequestsFromMapFunc(r.mapSecretToPooler()),
 			builder.WithPredicates(secretsPoolerPredicate),
 		).
-		Complete(r)
+		Watches(
+			&apiv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.mapClusterToPoolers()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&apiv1.ImageCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.mapImageCatalogToPoolers()),
+		).
+		Watches(
+			&apiv1.ClusterImageCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.mapImageCatalogToPoolers()),
+		).
+		Complete(newShardedReconciler(r))
 }
 
 // isOwnedByPoolerKind checks that an object is owned by a pooler and returns
@@ -185,6 +243,10 @@ func (r *PoolerReconciler) ensureManagedResourcesAreOwned(
 		invalidData = append(invalidData, "notOwnedServiceName", resources.Service.Name)
 	}
 
+	if resources.PodDisruptionBudget != nil && !isOwnedByPooler(pooler.Name, resources.PodDisruptionBudget) {
+		invalidData = append(invalidData, "notOwnedPodDisruptionBudgetName", resources.PodDisruptionBudget.Name)
+	}
+
 	if resources.Role != nil && !isOwnedByPooler(pooler.Name, resources.Role) {
 		invalidData = append(invalidData, "notOwnedRoleName", resources.Role.Name)
 	}
@@ -237,6 +299,12 @@ func (r *PoolerReconciler) mapSecretToPooler() handler.MapFunc {
 			result[idx] = reconcile.Request{NamespacedName: value}
 		}
 
+		// the secrets of a cluster are copied into the namespaces
+		// of its cross-namespace poolers
+		if clusterName, isOwned := IsOwnedByCluster(secret); isOwned {
+			result = append(result, r.getCrossNamespacePoolerRequests(ctx, secret.Namespace, clusterName)...)
+		}
+
 		return
 	}
 }
@@ -0,0 +1,241 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// replicatedSecretSource is a secret of the cluster that is copied into
+// the namespace of a cross-namespace Pooler
+type replicatedSecretSource struct {
+	// The name of the secret of the cluster
+	name string
+
+	// The keys to be copied, or nil to copy every key
+	keys []string
+}
+
+// getReplicatedSecretSources gets the secrets of the cluster needed by a
+// cross-namespace Pooler. Only the certificates of the certification
+// authorities are copied, leaving their private keys in the namespace
+// of the cluster
+func getReplicatedSecretSources(pooler *apiv1.Pooler, cluster *apiv1.Cluster) []replicatedSecretSource {
+	caKeys := []string{certs.CACertKey, certs.CAPreviousCertKey}
+	sources := []replicatedSecretSource{
+		{name: cluster.GetServerCASecretName(), keys: caKeys},
+		{name: cluster.GetServerTLSSecretName(), keys: []string{certs.TLSCertKey, certs.TLSPrivateKeyKey}},
+	}
+
+	// The client CA may be the same secret of the server CA
+	if cluster.GetClientCASecretName() != cluster.GetServerCASecretName() {
+		sources = append(sources, replicatedSecretSource{name: cluster.GetClientCASecretName(), keys: caKeys})
+	}
+
+	if pooler.IsAutomatedIntegration() {
+		sources = append(sources, replicatedSecretSource{name: pooler.GetClusterAuthQuerySecretName()})
+	}
+
+	return sources
+}
+
+// reconcileCrossNamespaceSecrets copies the secrets of the cluster needed
+// by a cross-namespace Pooler into the namespace of the Pooler, once the
+// cluster allows it. The copies are removed when the cluster doesn't
+// allow the Pooler anymore
+func (r *PoolerReconciler) reconcileCrossNamespaceSecrets(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	resources *poolerManagedResources,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+	cluster := resources.Cluster
+
+	if !cluster.AllowsPoolersFrom(pooler.Namespace) {
+		contextLogger.Info("The cluster doesn't allow poolers from this namespace, will retry in 30 seconds",
+			"cluster", cluster.Name, "clusterNamespace", cluster.Namespace)
+		r.Recorder.Eventf(pooler, "Warning", "PoolerNotAllowed",
+			"The cluster %s/%s doesn't allow poolers from the namespace %s",
+			cluster.Namespace, cluster.Name, pooler.Namespace)
+		return &ctrl.Result{RequeueAfter: 30 * time.Second}, r.deleteReplicatedSecrets(ctx, pooler, cluster)
+	}
+
+	resources.ReplicatedSecrets = make(map[string]*corev1.Secret)
+	for _, source := range getReplicatedSecretSources(pooler, cluster) {
+		sourceSecret, err := getSecretOrNil(ctx, r.Client,
+			client.ObjectKey{Namespace: cluster.Namespace, Name: source.name})
+		if err != nil {
+			return nil, err
+		}
+		if sourceSecret == nil {
+			contextLogger.Info("Secret of the cluster not found, will retry in 30 seconds",
+				"secret", source.name, "clusterNamespace", cluster.Namespace)
+			return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+
+		replicatedSecret, err := r.replicateSecret(ctx, pooler, sourceSecret, source.keys)
+		if err != nil {
+			return nil, fmt.Errorf("while copying the secret %s: %w", source.name, err)
+		}
+		resources.ReplicatedSecrets[source.name] = replicatedSecret
+	}
+
+	if pooler.IsAutomatedIntegration() {
+		resources.AuthUserSecret = resources.ReplicatedSecrets[pooler.GetClusterAuthQuerySecretName()]
+	}
+
+	return nil, nil
+}
+
+// replicateSecret creates or updates the copy of a secret of the cluster
+// in the namespace of the Pooler, owned by the Pooler
+func (r *PoolerReconciler) replicateSecret(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	source *corev1.Secret,
+	keys []string,
+) (*corev1.Secret, error) {
+	expectedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pooler.GetReplicatedSecretName(source.Name),
+			Namespace: pooler.Namespace,
+			Labels: map[string]string{
+				utils.PgbouncerNameLabel: pooler.Name,
+			},
+		},
+		Type: source.Type,
+		Data: make(map[string][]byte, len(source.Data)),
+	}
+	for key, value := range source.Data {
+		if keys == nil || slices.Contains(keys, key) {
+			expectedSecret.Data[key] = value
+		}
+	}
+
+	currentSecret, err := getSecretOrNil(ctx, r.Client, client.ObjectKeyFromObject(expectedSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	if currentSecret == nil {
+		if err := ctrl.SetControllerReference(pooler, expectedSecret, r.Scheme); err != nil {
+			return nil, err
+		}
+
+		log.FromContext(ctx).Info("Creating the copy of a secret of the cluster", "secret", expectedSecret.Name)
+		if err := r.Create(ctx, expectedSecret); err != nil {
+			return nil, err
+		}
+		return expectedSecret, nil
+	}
+
+	if !isOwnedByPooler(pooler.Name, currentSecret) {
+		return nil, fmt.Errorf("secret %s is not owned by the pooler", currentSecret.Name)
+	}
+
+	if reflect.DeepEqual(currentSecret.Data, expectedSecret.Data) {
+		return currentSecret, nil
+	}
+
+	patchedSecret := currentSecret.DeepCopy()
+	patchedSecret.Data = expectedSecret.Data
+	log.FromContext(ctx).Info("Updating the copy of a secret of the cluster", "secret", patchedSecret.Name)
+	if err := r.Patch(ctx, patchedSecret, client.MergeFrom(currentSecret)); err != nil {
+		return nil, err
+	}
+
+	return patchedSecret, nil
+}
+
+// deleteReplicatedSecrets removes the copies of the secrets of the cluster
+// from the namespace of the Pooler
+func (r *PoolerReconciler) deleteReplicatedSecrets(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	cluster *apiv1.Cluster,
+) error {
+	for _, source := range getReplicatedSecretSources(pooler, cluster) {
+		secret, err := getSecretOrNil(ctx, r.Client,
+			client.ObjectKey{Namespace: pooler.Namespace, Name: pooler.GetReplicatedSecretName(source.name)})
+		if err != nil {
+			return err
+		}
+		if secret == nil || !isOwnedByPooler(pooler.Name, secret) {
+			continue
+		}
+
+		log.FromContext(ctx).Info("Deleting the copy of a secret of the cluster", "secret", secret.Name)
+		if err := r.Delete(ctx, secret); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getCrossNamespacePoolerRequests gets the reconciliation requests of the
+// cross-namespace Poolers referencing the given cluster
+func (r *PoolerReconciler) getCrossNamespacePoolerRequests(
+	ctx context.Context,
+	clusterNamespace string,
+	clusterName string,
+) []reconcile.Request {
+	var poolers apiv1.PoolerList
+	if err := r.List(ctx, &poolers,
+		client.MatchingFields{poolerClusterKey: getCrossNamespacePoolerIndexValue(clusterNamespace, clusterName)},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "while getting the cross-namespace poolers of a cluster",
+			"cluster", clusterName, "clusterNamespace", clusterNamespace)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(poolers.Items))
+	for idx := range poolers.Items {
+		requests[idx] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&poolers.Items[idx])}
+	}
+	return requests
+}
+
+// mapClusterToPoolers returns a function mapping the changes of a cluster
+// to the cross-namespace Poolers referencing it
+func (r *PoolerReconciler) mapClusterToPoolers() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		cluster, ok := obj.(*apiv1.Cluster)
+		if !ok {
+			return nil
+		}
+
+		return r.getCrossNamespacePoolerRequests(ctx, cluster.Namespace, cluster.Name)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cross-namespace poolers", func() {
+	var (
+		env       *testingEnvironment
+		cluster   *apiv1.Cluster
+		pooler    *apiv1.Pooler
+		resources *poolerManagedResources
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		env = buildTestEnvironment()
+		cluster = newFakeCNPGCluster(env.client, newFakeNamespace(env.client))
+
+		caData := map[string][]byte{
+			certs.CACertKey:       []byte("ca-certificate"),
+			certs.CAPrivateKeyKey: []byte("ca-private-key"),
+		}
+		tlsData := map[string][]byte{
+			certs.TLSCertKey:       []byte("certificate"),
+			certs.TLSPrivateKeyKey: []byte("private-key"),
+		}
+		for name, data := range map[string]map[string][]byte{
+			cluster.GetServerCASecretName():                         caData,
+			cluster.GetClientCASecretName():                         caData,
+			cluster.GetServerTLSSecretName():                        tlsData,
+			cluster.Name + apiv1.DefaultPgBouncerPoolerSecretSuffix: tlsData,
+		} {
+			Expect(env.client.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster.Namespace},
+				Data:       data,
+			})).To(Succeed())
+		}
+
+		pooler = &apiv1.Pooler{
+			TypeMeta: metav1.TypeMeta{Kind: apiv1.PoolerKind, APIVersion: apiv1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pooler-rw",
+				Namespace: newFakeNamespace(env.client),
+			},
+			Spec: apiv1.PoolerSpec{
+				Cluster:          apiv1.LocalObjectReference{Name: cluster.Name},
+				ClusterNamespace: cluster.Namespace,
+				Type:             apiv1.PoolerTypeRW,
+				Instances:        ptr.To(int32(1)),
+				PgBouncer:        &apiv1.PgBouncerSpec{PoolMode: apiv1.PgBouncerPoolModeSession},
+			},
+		}
+		Expect(env.client.Create(ctx, pooler)).To(Succeed())
+
+		resources = &poolerManagedResources{Cluster: cluster}
+	})
+
+	getReplicatedSecret := func(ctx context.Context, name string) (*corev1.Secret, error) {
+		var secret corev1.Secret
+		err := env.client.Get(ctx,
+			client.ObjectKey{Namespace: pooler.Namespace, Name: pooler.GetReplicatedSecretName(name)},
+			&secret)
+		return &secret, err
+	}
+
+	It("waits for the cluster to allow the namespace of the pooler", func(ctx context.Context) {
+		res, err := env.poolerReconciler.reconcileCrossNamespaceSecrets(ctx, pooler, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(res.RequeueAfter).ToNot(BeZero())
+
+		_, err = getReplicatedSecret(ctx, cluster.GetServerCASecretName())
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("copies the secrets of the cluster into the namespace of the pooler", func(ctx context.Context) {
+		cluster.Spec.PoolerNamespaces = []string{pooler.Namespace}
+
+		res, err := env.poolerReconciler.reconcileCrossNamespaceSecrets(ctx, pooler, resources)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+
+		serverCA, err := getReplicatedSecret(ctx, cluster.GetServerCASecretName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serverCA.Data).To(HaveKeyWithValue(certs.CACertKey, []byte("ca-certificate")))
+		Expect(serverCA.Data).ToNot(HaveKey(certs.CAPrivateKeyKey))
+		Expect(isOwnedByPooler(pooler.Name, serverCA)).To(BeTrue())
+
+		serverTLS, err := getReplicatedSecret(ctx, cluster.GetServerTLSSecretName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serverTLS.Data).To(HaveKeyWithValue(certs.TLSPrivateKeyKey, []byte("private-key")))
+
+		Expect(resources.AuthUserSecret).ToNot(BeNil())
+		Expect(resources.AuthUserSecret.Name).To(Equal(pooler.GetAuthQuerySecretName()))
+		Expect(resources.getClusterSecretVersion(cluster.GetClientCASecretName(), "").Name).
+			To(Equal(pooler.GetReplicatedSecretName(cluster.GetClientCASecretName())))
+
+		By("removing the copies when the cluster doesn't allow the pooler anymore", func() {
+			cluster.Spec.PoolerNamespaces = nil
+
+			res, err := env.poolerReconciler.reconcileCrossNamespaceSecrets(ctx, pooler, resources)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).ToNot(BeNil())
+
+			_, err = getReplicatedSecret(ctx, cluster.GetServerCASecretName())
+			Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
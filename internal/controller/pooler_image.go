@@ -0,0 +1,130 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// reconcilePgBouncerImage sets the PgBouncer image found in the image
+// catalog referenced by the pooler into its status, from where it is
+// used to generate the deployment
+func (r *PoolerReconciler) reconcilePgBouncerImage(ctx context.Context, pooler *apiv1.Pooler) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var image string
+	if pooler.Spec.PgBouncer != nil && pooler.Spec.PgBouncer.ImageCatalogRef != nil {
+		catalogRef := pooler.Spec.PgBouncer.ImageCatalogRef
+		contextLogger = contextLogger.WithValues("catalogRef", catalogRef)
+
+		var catalog apiv1.GenericImageCatalog
+		switch catalogRef.Kind {
+		case apiv1.ClusterImageCatalogKind:
+			catalog = &apiv1.ClusterImageCatalog{}
+		case apiv1.ImageCatalogKind:
+			catalog = &apiv1.ImageCatalog{}
+		default:
+			contextLogger.Info("Unknown catalog kind")
+			r.Recorder.Eventf(pooler, "Warning", "DiscoverImage", "Invalid image catalog kind %v", catalogRef.Kind)
+			return &ctrl.Result{}, nil
+		}
+
+		err := r.Get(ctx, types.NamespacedName{Namespace: pooler.Namespace, Name: catalogRef.Name}, catalog)
+		if apierrs.IsNotFound(err) {
+			r.Recorder.Eventf(pooler, "Warning", "DiscoverImage", "Cannot get %v/%v",
+				catalogRef.Kind, catalogRef.Name)
+			return &ctrl.Result{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var ok bool
+		image, ok = catalog.GetSpec().FindPgBouncerImageForMajor(catalogRef.Major)
+		if !ok {
+			contextLogger.Info("cannot find requested PgBouncer major version",
+				"requestedMajorVersion", catalogRef.Major)
+			r.Recorder.Eventf(pooler, "Warning", "DiscoverImage", "Cannot find PgBouncer major %v in %v/%v",
+				catalogRef.Major, catalogRef.Kind, catalogRef.Name)
+			return &ctrl.Result{}, nil
+		}
+	}
+
+	if pooler.Status.Image == image {
+		return nil, nil
+	}
+
+	contextLogger.Info("Updating the PgBouncer image", "image", image, "previousImage", pooler.Status.Image)
+	oldPooler := pooler.DeepCopy()
+	pooler.Status.Image = image
+	if err := r.Status().Patch(ctx, pooler, client.MergeFrom(oldPooler)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// mapImageCatalogToPoolers enqueues the poolers getting the
+// PgBouncer image from the changed image catalog
+func (r *PoolerReconciler) mapImageCatalogToPoolers() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var listOptions []client.ListOption
+		var kind string
+		switch obj.(type) {
+		case *apiv1.ImageCatalog:
+			kind = apiv1.ImageCatalogKind
+			listOptions = append(listOptions, client.InNamespace(obj.GetNamespace()))
+		case *apiv1.ClusterImageCatalog:
+			kind = apiv1.ClusterImageCatalogKind
+		default:
+			return nil
+		}
+
+		var poolers apiv1.PoolerList
+		if err := r.List(ctx, &poolers, listOptions...); err != nil {
+			log.FromContext(ctx).Error(err, "while getting pooler list for image catalog",
+				"kind", kind, "name", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, pooler := range poolers.Items {
+			if pooler.Spec.PgBouncer == nil || pooler.Spec.PgBouncer.ImageCatalogRef == nil {
+				continue
+			}
+			catalogRef := pooler.Spec.PgBouncer.ImageCatalogRef
+			if catalogRef.Kind != kind || catalogRef.Name != obj.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: pooler.Name, Namespace: pooler.Namespace},
+			})
+		}
+
+		return requests
+	}
+}
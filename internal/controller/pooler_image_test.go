@@ -0,0 +1,103 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PgBouncer image catalogs", func() {
+	const pgbouncerImage = "ghcr.io/cloudnative-pg/pgbouncer:1.24.0"
+
+	var (
+		env     *testingEnvironment
+		pooler  *apiv1.Pooler
+		catalog *apiv1.ImageCatalog
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace)
+
+		catalog = &apiv1.ImageCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "catalog", Namespace: namespace},
+			Spec: apiv1.ImageCatalogSpec{
+				Images:          []apiv1.CatalogImage{{Major: 16, Image: "ghcr.io/cloudnative-pg/postgresql:16.4"}},
+				PgBouncerImages: []apiv1.PoolerCatalogImage{{Major: 1, Image: pgbouncerImage}},
+			},
+		}
+		Expect(env.client.Create(ctx, catalog)).To(Succeed())
+
+		pooler = newFakePooler(env.client, cluster)
+		pooler.Spec.PgBouncer.ImageCatalogRef = &apiv1.PoolerImageCatalogRef{
+			TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(apiv1.GroupVersion.Group),
+				Kind:     apiv1.ImageCatalogKind,
+				Name:     catalog.Name,
+			},
+			Major: 1,
+		}
+		Expect(env.client.Update(ctx, pooler)).To(Succeed())
+	})
+
+	It("sets the image found in the catalog", func(ctx SpecContext) {
+		res, err := env.poolerReconciler.reconcilePgBouncerImage(ctx, pooler)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+
+		var updatedPooler apiv1.Pooler
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(pooler), &updatedPooler)).To(Succeed())
+		Expect(updatedPooler.Status.Image).To(Equal(pgbouncerImage))
+	})
+
+	It("stops the reconciliation when the major version is not in the catalog", func(ctx SpecContext) {
+		pooler.Spec.PgBouncer.ImageCatalogRef.Major = 2
+		res, err := env.poolerReconciler.reconcilePgBouncerImage(ctx, pooler)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(pooler.Status.Image).To(BeEmpty())
+	})
+
+	It("goes back to the default image when the catalog is not used anymore", func(ctx SpecContext) {
+		pooler.Status.Image = pgbouncerImage
+		Expect(env.client.Status().Update(ctx, pooler)).To(Succeed())
+
+		pooler.Spec.PgBouncer.ImageCatalogRef = nil
+		res, err := env.poolerReconciler.reconcilePgBouncerImage(ctx, pooler)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(pooler.Status.Image).To(BeEmpty())
+	})
+
+	It("enqueues the poolers using a changed catalog", func(ctx SpecContext) {
+		requests := env.poolerReconciler.mapImageCatalogToPoolers()(ctx, catalog)
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Name).To(Equal(pooler.Name))
+
+		otherCatalog := &apiv1.ClusterImageCatalog{ObjectMeta: metav1.ObjectMeta{Name: catalog.Name}}
+		Expect(env.poolerReconciler.mapImageCatalogToPoolers()(ctx, otherCatalog)).To(BeEmpty())
+	})
+})
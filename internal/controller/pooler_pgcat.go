@@ -0,0 +1,117 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/sethvargo/go-password/password"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs/pgcat"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
+)
+
+// generatePgCatDeployment reconciles the secret containing the PgCat
+// configuration and generates the deployment running it
+func (r *PoolerReconciler) generatePgCatDeployment(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	resources *poolerManagedResources,
+) (*appsv1.Deployment, error) {
+	configSecret, err := r.reconcilePgCatConfigSecret(ctx, pooler, resources)
+	if err != nil {
+		return nil, fmt.Errorf("while reconciling the pgcat configuration: %w", err)
+	}
+
+	configHash, err := hash.ComputeHash(configSecret.Data[pgcat.ConfigFileName])
+	if err != nil {
+		return nil, err
+	}
+
+	return pgcat.Deployment(pooler, resources.Cluster, configHash)
+}
+
+// reconcilePgCatConfigSecret creates or updates the secret containing the
+// PgCat configuration, preserving the generated admin password
+func (r *PoolerReconciler) reconcilePgCatConfigSecret(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	resources *poolerManagedResources,
+) (*corev1.Secret, error) {
+	contextLog := log.FromContext(ctx)
+
+	currentSecret, err := getSecretOrNil(
+		ctx, r.Client, client.ObjectKey{Name: pgcat.GetConfigSecretName(pooler), Namespace: pooler.Namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	var adminPassword string
+	if currentSecret != nil {
+		if _, isOwned := isOwnedByPoolerKind(currentSecret); !isOwned {
+			return nil, fmt.Errorf("secret %s is not owned by the pooler", currentSecret.Name)
+		}
+		adminPassword = string(currentSecret.Data[pgcat.AdminPasswordKey])
+	}
+	if adminPassword == "" {
+		if adminPassword, err = password.Generate(64, 10, 0, false, true); err != nil {
+			return nil, err
+		}
+	}
+
+	expectedSecret, err := pgcat.ConfigSecret(pooler, resources.Cluster, resources.AuthUserSecret, adminPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentSecret == nil {
+		if err := ctrl.SetControllerReference(pooler, expectedSecret, r.Scheme); err != nil {
+			return nil, err
+		}
+
+		contextLog.Info("Creating the pgcat configuration secret")
+		if err := r.Create(ctx, expectedSecret); err != nil && !apierrs.IsAlreadyExists(err) {
+			return nil, err
+		}
+		return expectedSecret, nil
+	}
+
+	if reflect.DeepEqual(currentSecret.Data, expectedSecret.Data) {
+		return currentSecret, nil
+	}
+
+	patchedSecret := currentSecret.DeepCopy()
+	patchedSecret.Data = expectedSecret.Data
+	utils.MergeObjectsMetadata(patchedSecret, expectedSecret)
+
+	contextLog.Info("Updating the pgcat configuration secret")
+	if err := r.Patch(ctx, patchedSecret, client.MergeFrom(currentSecret)); err != nil {
+		return nil, err
+	}
+
+	return patchedSecret, nil
+}
@@ -21,11 +21,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/api/rbac/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // poolerManagedResources contains all the resources that are going to be
@@ -41,9 +43,20 @@ type poolerManagedResources struct {
 	// This is the service where pgbouncer is accessible
 	Service *corev1.Service
 
+	// These are the additional services exposing pgbouncer
+	AdditionalServices []corev1.Service
+
+	// This is the pod disruption budget of the pooler pods
+	PodDisruptionBudget *policyv1.PodDisruptionBudget
+
 	// The referenced Cluster
 	Cluster *apiv1.Cluster
 
+	// The copies of the secrets of the referenced Cluster, indexed by
+	// the name of the original secret, when the Cluster lives in
+	// another namespace
+	ReplicatedSecrets map[string]*corev1.Secret
+
 	// The RBAC resources needed for the pooler instance manager
 	// to watch over the relative Pooler resource
 	ServiceAccount *corev1.ServiceAccount
@@ -79,9 +92,22 @@ func (r *PoolerReconciler) getManagedResources(ctx context.Context,
 		return nil, err
 	}
 
+	// Get the additional services
+	result.AdditionalServices, err = getAdditionalServices(ctx, r.Client, pooler)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pod disruption budget
+	result.PodDisruptionBudget, err = getPodDisruptionBudgetOrNil(
+		ctx, r.Client, client.ObjectKey{Name: pooler.Name, Namespace: pooler.Namespace})
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the referenced cluster
 	result.Cluster, err = getClusterOrNil(
-		ctx, r.Client, client.ObjectKey{Name: pooler.Spec.Cluster.Name, Namespace: pooler.Namespace})
+		ctx, r.Client, client.ObjectKey{Name: pooler.Spec.Cluster.Name, Namespace: pooler.GetClusterNamespace()})
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +133,16 @@ func (r *PoolerReconciler) getManagedResources(ctx context.Context,
 	return result, nil
 }
 
+// getClusterSecretVersion gets the version of a secret of the referenced
+// cluster, or of its copy when the cluster lives in another namespace
+func (resources *poolerManagedResources) getClusterSecretVersion(name string, version string) apiv1.SecretVersion {
+	if replicatedSecret, ok := resources.ReplicatedSecrets[name]; ok {
+		return apiv1.SecretVersion{Name: replicatedSecret.Name, Version: replicatedSecret.ResourceVersion}
+	}
+
+	return apiv1.SecretVersion{Name: name, Version: version}
+}
+
 // getDeploymentOrNil gets a deployment with a certain name, returning nil when it doesn't exist
 func getDeploymentOrNil(
 	ctx context.Context, r client.Client, objectKey client.ObjectKey,
@@ -139,6 +175,48 @@ func getServiceOrNil(ctx context.Context, r client.Client, objectKey client.Obje
 	return &service, nil
 }
 
+// getAdditionalServices gets the services created by the pooler, other
+// than its default one
+func getAdditionalServices(ctx context.Context, r client.Client, pooler *apiv1.Pooler) ([]corev1.Service, error) {
+	var services corev1.ServiceList
+	if err := r.List(ctx, &services, client.InNamespace(pooler.Namespace), client.MatchingLabels{
+		utils.PgbouncerNameLabel: pooler.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	result := make([]corev1.Service, 0, len(services.Items))
+	for idx := range services.Items {
+		service := &services.Items[idx]
+		if service.Name == pooler.Name || !isOwnedByPooler(pooler.Name, service) {
+			continue
+		}
+		result = append(result, *service)
+	}
+
+	return result, nil
+}
+
+// getPodDisruptionBudgetOrNil gets a pod disruption budget with a certain name,
+// returning nil when it doesn't exist
+func getPodDisruptionBudgetOrNil(
+	ctx context.Context,
+	r client.Client,
+	objectKey client.ObjectKey,
+) (*policyv1.PodDisruptionBudget, error) {
+	var pdb policyv1.PodDisruptionBudget
+	err := r.Get(ctx, objectKey, &pdb)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &pdb, nil
+}
+
 // getServiceAccountOrNil gets a service account with a certain name, returning nil when it doesn't exist
 func getServiceAccountOrNil(
 	ctx context.Context,
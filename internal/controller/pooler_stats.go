@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// poolerStatsInterval is how often the saturation indicators of a
+// PgBouncer pooler are refreshed in its status
+const poolerStatsInterval = 30 * time.Second
+
+// getPoolerStats collects the saturation indicators of the ready
+// instances of a PgBouncer pooler and aggregates them, returning nil
+// when no instance could be queried
+func (r *PoolerReconciler) getPoolerStats(ctx context.Context, pooler *apiv1.Pooler) *apiv1.PoolerStats {
+	contextLogger := log.FromContext(ctx)
+
+	if r.PoolerClient == nil || pooler.GetBackend() != apiv1.PoolerBackendPgBouncer {
+		return nil
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods,
+		client.InNamespace(pooler.Namespace),
+		client.MatchingLabels{utils.PgbouncerNameLabel: pooler.Name},
+	); err != nil {
+		contextLogger.Error(err, "while listing the pods of the pooler")
+		return nil
+	}
+
+	var result *apiv1.PoolerStats
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+		if pod.Status.PodIP == "" || !utils.IsPodReady(*pod) {
+			continue
+		}
+
+		stats, err := r.PoolerClient.GetStats(ctx, pod)
+		if err != nil {
+			contextLogger.Info("Cannot get the PgBouncer stats", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+
+		if result == nil {
+			result = &apiv1.PoolerStats{}
+		}
+		result.Instances++
+		result.ClientsActive += stats.ClientsActive
+		result.ClientsWaiting += stats.ClientsWaiting
+		result.ServersActive += stats.ServersActive
+		result.ServersIdle += stats.ServersIdle
+		result.MaxWaitSeconds = max(result.MaxWaitSeconds, stats.MaxWaitSeconds)
+	}
+
+	return result
+}
@@ -0,0 +1,118 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakePoolerClient struct {
+	stats map[string]*apiv1.PoolerStats
+}
+
+func (f fakePoolerClient) GetStats(_ context.Context, pod *corev1.Pod) (*apiv1.PoolerStats, error) {
+	stats, ok := f.stats[pod.Name]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return stats, nil
+}
+
+var _ = Describe("pooler stats", func() {
+	var pooler *apiv1.Pooler
+
+	newPoolerPod := func(name string, ready bool) *corev1.Pod {
+		readyCondition := corev1.ConditionFalse
+		if ready {
+			readyCondition = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: pooler.Namespace,
+				Labels:    map[string]string{utils.PgbouncerNameLabel: pooler.Name},
+			},
+			Status: corev1.PodStatus{
+				PodIP:      "10.0.0.1",
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: readyCondition}},
+			},
+		}
+	}
+
+	newReconciler := func(poolerClient fakePoolerClient, objects ...client.Object) *PoolerReconciler {
+		return &PoolerReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(objects...).
+				Build(),
+			PoolerClient: poolerClient,
+		}
+	}
+
+	BeforeEach(func() {
+		pooler = &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler-rw", Namespace: "default"},
+			Spec:       apiv1.PoolerSpec{Cluster: apiv1.LocalObjectReference{Name: "cluster-example"}},
+		}
+	})
+
+	It("aggregates the stats of the ready instances", func(ctx SpecContext) {
+		r := newReconciler(
+			fakePoolerClient{stats: map[string]*apiv1.PoolerStats{
+				"pooler-rw-1": {Instances: 1, ClientsActive: 10, ClientsWaiting: 2, ServersActive: 10, MaxWaitSeconds: 3},
+				"pooler-rw-2": {Instances: 1, ClientsActive: 5, ServersActive: 5, ServersIdle: 5, MaxWaitSeconds: 1},
+				"pooler-rw-3": {Instances: 1, ClientsWaiting: 100},
+			}},
+			newPoolerPod("pooler-rw-1", true),
+			newPoolerPod("pooler-rw-2", true),
+			newPoolerPod("pooler-rw-3", false),
+			newPoolerPod("pooler-rw-4", true),
+		)
+
+		Expect(r.getPoolerStats(ctx, pooler)).To(Equal(&apiv1.PoolerStats{
+			Instances:      2,
+			ClientsActive:  15,
+			ClientsWaiting: 2,
+			ServersActive:  15,
+			ServersIdle:    5,
+			MaxWaitSeconds: 3,
+		}))
+	})
+
+	It("doesn't collect the stats of the PgCat poolers", func(ctx SpecContext) {
+		pooler.Spec.Backend = apiv1.PoolerBackendPgCat
+		r := newReconciler(
+			fakePoolerClient{stats: map[string]*apiv1.PoolerStats{"pooler-rw-1": {Instances: 1}}},
+			newPoolerPod("pooler-rw-1", true),
+		)
+
+		Expect(r.getPoolerStats(ctx, pooler)).To(BeNil())
+	})
+})
@@ -47,24 +47,31 @@ func (r *PoolerReconciler) updatePoolerStatus(
 	}
 
 	if cluster := resources.Cluster; cluster != nil {
-		updatedStatus.Secrets.ServerTLS = apiv1.SecretVersion{
-			Name:    cluster.GetServerTLSSecretName(),
-			Version: cluster.Status.SecretsResourceVersion.ServerSecretVersion,
-		}
-		updatedStatus.Secrets.ServerCA = apiv1.SecretVersion{
-			Name:    cluster.GetServerCASecretName(),
-			Version: cluster.Status.SecretsResourceVersion.ServerCASecretVersion,
-		}
-		updatedStatus.Secrets.ClientCA = apiv1.SecretVersion{
-			Name:    cluster.GetClientCASecretName(),
-			Version: cluster.Status.SecretsResourceVersion.ClientCASecretVersion,
-		}
+		updatedStatus.Secrets.ServerTLS = resources.getClusterSecretVersion(
+			cluster.GetServerTLSSecretName(),
+			cluster.Status.SecretsResourceVersion.ServerSecretVersion,
+		)
+		updatedStatus.Secrets.ServerCA = resources.getClusterSecretVersion(
+			cluster.GetServerCASecretName(),
+			cluster.Status.SecretsResourceVersion.ServerCASecretVersion,
+		)
+		updatedStatus.Secrets.ClientCA = resources.getClusterSecretVersion(
+			cluster.GetClientCASecretName(),
+			cluster.Status.SecretsResourceVersion.ClientCASecretVersion,
+		)
 	}
 
 	if resources.Deployment != nil {
 		updatedStatus.Instances = resources.Deployment.Status.Replicas
 	}
 
+	updatedStatus.Pools = nil
+	if pooler.Spec.PgBouncer != nil {
+		updatedStatus.Pools = pooler.Spec.PgBouncer.GetPools()
+	}
+
+	updatedStatus.Stats = r.getPoolerStats(ctx, pooler)
+
 	// then update the status if anything changed
 	if !reflect.DeepEqual(pooler.Status, updatedStatus) {
 		pooler.Status = *updatedStatus
@@ -22,6 +22,7 @@ import (
 	"reflect"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,6 +57,14 @@ func (r *PoolerReconciler) updateOwnedObjects(
 		return err
 	}
 
+	if err := r.reconcileAdditionalServices(ctx, pooler, resources); err != nil {
+		return err
+	}
+
+	if err := r.reconcilePodDisruptionBudget(ctx, pooler, resources); err != nil {
+		return err
+	}
+
 	return createOrPatchPodMonitor(ctx, r.Client, r.DiscoveryClient, pgbouncer.NewPoolerPodMonitorManager(pooler))
 }
 
@@ -69,7 +78,14 @@ func (r *PoolerReconciler) updateDeployment(
 ) error {
 	contextLog := log.FromContext(ctx)
 
-	generatedDeployment, err := pgbouncer.Deployment(pooler, resources.Cluster)
+	var generatedDeployment *appsv1.Deployment
+	var err error
+	switch pooler.GetBackend() {
+	case apiv1.PoolerBackendPgCat:
+		generatedDeployment, err = r.generatePgCatDeployment(ctx, pooler, resources)
+	default:
+		generatedDeployment, err = pgbouncer.Deployment(pooler, resources.Cluster)
+	}
 	if err != nil {
 		return err
 	}
@@ -160,6 +176,150 @@ func (r *PoolerReconciler) reconcileService(
 	return r.Patch(ctx, patchedService, client.MergeFrom(resources.Service))
 }
 
+// reconcileAdditionalServices creates, updates or deletes the additional
+// services of pgbouncer as needed
+func (r *PoolerReconciler) reconcileAdditionalServices(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	resources *poolerManagedResources,
+) error {
+	expectedServices, err := pgbouncer.AdditionalServices(pooler, resources.Cluster)
+	if err != nil {
+		return err
+	}
+
+	livingServices := make(map[string]*corev1.Service, len(resources.AdditionalServices))
+	for idx := range resources.AdditionalServices {
+		livingServices[resources.AdditionalServices[idx].Name] = &resources.AdditionalServices[idx]
+	}
+
+	for idx := range expectedServices {
+		expectedService := &expectedServices[idx]
+		if err := ctrl.SetControllerReference(pooler, expectedService, r.Scheme); err != nil {
+			return err
+		}
+
+		livingService := livingServices[expectedService.Name]
+		delete(livingServices, expectedService.Name)
+		if err := r.reconcileAdditionalService(ctx, expectedService, livingService); err != nil {
+			return err
+		}
+	}
+
+	// we delete the services not appearing anymore in the spec
+	for _, livingService := range livingServices {
+		log.FromContext(ctx).Info("Deleting the service, due to not being managed anymore",
+			"serviceName", livingService.Name)
+		if err := r.Delete(ctx, livingService); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileAdditionalService aligns an additional service of pgbouncer
+// with its expected definition, following its update strategy
+func (r *PoolerReconciler) reconcileAdditionalService(
+	ctx context.Context,
+	expectedService *corev1.Service,
+	livingService *corev1.Service,
+) error {
+	strategy := apiv1.ServiceUpdateStrategy(expectedService.Annotations[utils.UpdateStrategyAnnotation])
+	contextLog := log.FromContext(ctx).WithValues(
+		"serviceName", expectedService.Name,
+		"updateStrategy", strategy,
+	)
+
+	if livingService == nil {
+		contextLog.Info("Creating the service")
+		return r.Create(ctx, expectedService)
+	}
+
+	if !livingService.DeletionTimestamp.IsZero() {
+		contextLog.Info("Waiting for the service to be deleted")
+		return nil
+	}
+
+	currentVersion := livingService.Annotations[utils.PoolerSpecHashAnnotationName]
+	updatedVersion := expectedService.Annotations[utils.PoolerSpecHashAnnotationName]
+	if currentVersion == updatedVersion {
+		return nil
+	}
+
+	if strategy == apiv1.ServiceUpdateStrategyReplace {
+		// the service will be recreated in the next reconciliation loop,
+		// triggered by its deletion
+		contextLog.Info("Deleting the service, to recreate it")
+		if err := r.Delete(ctx, livingService); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	patchedService := livingService.DeepCopy()
+	patchedService.Spec = expectedService.Spec
+	utils.MergeObjectsMetadata(patchedService, expectedService)
+
+	contextLog.Info("Updating the service")
+	return r.Patch(ctx, patchedService, client.MergeFrom(livingService))
+}
+
+// reconcilePodDisruptionBudget creates, updates or deletes the pod
+// disruption budget of the pooler pods as needed
+func (r *PoolerReconciler) reconcilePodDisruptionBudget(
+	ctx context.Context,
+	pooler *apiv1.Pooler,
+	resources *poolerManagedResources,
+) error {
+	contextLog := log.FromContext(ctx)
+	expectedPDB := pgbouncer.PodDisruptionBudget(pooler)
+
+	if expectedPDB == nil {
+		if resources.PodDisruptionBudget == nil {
+			return nil
+		}
+
+		contextLog.Info("Deleting the pod disruption budget")
+		if err := r.Delete(ctx, resources.PodDisruptionBudget); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+		resources.PodDisruptionBudget = nil
+		return nil
+	}
+
+	if err := ctrl.SetControllerReference(pooler, expectedPDB, r.Scheme); err != nil {
+		return err
+	}
+
+	if resources.PodDisruptionBudget == nil {
+		contextLog.Info("Creating the pod disruption budget")
+		err := r.Create(ctx, expectedPDB)
+		if err != nil && !apierrs.IsAlreadyExists(err) {
+			return err
+		}
+		resources.PodDisruptionBudget = expectedPDB
+		return nil
+	}
+
+	patchedPDB := resources.PodDisruptionBudget.DeepCopy()
+	patchedPDB.Spec = expectedPDB.Spec
+	utils.MergeObjectsMetadata(patchedPDB, expectedPDB)
+
+	if reflect.DeepEqual(patchedPDB.ObjectMeta, resources.PodDisruptionBudget.ObjectMeta) &&
+		reflect.DeepEqual(patchedPDB.Spec, resources.PodDisruptionBudget.Spec) {
+		return nil
+	}
+
+	contextLog.Info("Updating the pod disruption budget")
+	if err := r.Patch(ctx, patchedPDB, client.MergeFrom(resources.PodDisruptionBudget)); err != nil {
+		return err
+	}
+
+	resources.PodDisruptionBudget = patchedPDB
+	return nil
+}
+
 // updateRBAC update or create the pgbouncer RBAC
 func (r *PoolerReconciler) updateRBAC(
 	ctx context.Context,
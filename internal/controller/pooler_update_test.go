@@ -22,6 +22,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -290,6 +291,104 @@ var _ = Describe("unit test of pooler_update reconciliation logic", func() {
 		})
 	})
 
+	It("should reconcile the pod disruption budget", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace)
+		pooler := newFakePooler(env.client, cluster)
+		res := &poolerManagedResources{Cluster: cluster}
+
+		getPDB := func() (*policyv1.PodDisruptionBudget, error) {
+			var pdb policyv1.PodDisruptionBudget
+			err := env.client.Get(ctx, types.NamespacedName{Name: pooler.Name, Namespace: pooler.Namespace}, &pdb)
+			return &pdb, err
+		}
+
+		By("not creating it for a single instance", func() {
+			Expect(env.poolerReconciler.reconcilePodDisruptionBudget(ctx, pooler, res)).To(Succeed())
+			Expect(res.PodDisruptionBudget).To(BeNil())
+			_, err := getPDB()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		By("creating it when the pooler is scaled up", func() {
+			pooler.Spec.Instances = ptr.To(int32(3))
+			Expect(env.poolerReconciler.reconcilePodDisruptionBudget(ctx, pooler, res)).To(Succeed())
+
+			pdb, err := getPDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue(utils.PgbouncerNameLabel, pooler.Name))
+			Expect(isOwnedByPooler(pooler.Name, pdb)).To(BeTrue())
+		})
+
+		By("deleting it when disabled", func() {
+			pooler.Spec.EnablePDB = ptr.To(false)
+			Expect(env.poolerReconciler.reconcilePodDisruptionBudget(ctx, pooler, res)).To(Succeed())
+			Expect(res.PodDisruptionBudget).To(BeNil())
+			_, err := getPDB()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	It("should reconcile the additional services", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace)
+		pooler := newFakePooler(env.client, cluster)
+		res := &poolerManagedResources{Cluster: cluster}
+
+		listServices := func() []corev1.Service {
+			services, err := getAdditionalServices(ctx, env.client, pooler)
+			Expect(err).ToNot(HaveOccurred())
+			res.AdditionalServices = services
+			return services
+		}
+
+		By("creating the services", func() {
+			pooler.Spec.AdditionalServices = []apiv1.PoolerAdditionalService{
+				{
+					ServiceTemplate: apiv1.ServiceTemplateSpec{
+						ObjectMeta: apiv1.Metadata{Name: pooler.Name + "-internal"},
+					},
+				},
+				{
+					DNSHostname: "pooler.example.com",
+					ServiceTemplate: apiv1.ServiceTemplateSpec{
+						ObjectMeta: apiv1.Metadata{Name: pooler.Name + "-external"},
+						Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+					},
+				},
+			}
+			Expect(env.poolerReconciler.reconcileAdditionalServices(ctx, pooler, res)).To(Succeed())
+
+			services := listServices()
+			Expect(services).To(HaveLen(2))
+			for idx := range services {
+				Expect(isOwnedByPooler(pooler.Name, &services[idx])).To(BeTrue())
+			}
+		})
+
+		By("patching the services whose definition changed", func() {
+			pooler.Spec.AdditionalServices[1].DNSHostname = "pgbouncer.example.com"
+			Expect(env.poolerReconciler.reconcileAdditionalServices(ctx, pooler, res)).To(Succeed())
+
+			var service corev1.Service
+			Expect(env.client.Get(ctx, types.NamespacedName{Name: pooler.Name + "-external", Namespace: namespace},
+				&service)).To(Succeed())
+			Expect(service.Annotations).To(HaveKeyWithValue(utils.ExternalDNSHostnameAnnotationName,
+				"pgbouncer.example.com"))
+		})
+
+		By("deleting the services removed from the spec", func() {
+			listServices()
+			pooler.Spec.AdditionalServices = pooler.Spec.AdditionalServices[:1]
+			Expect(env.poolerReconciler.reconcileAdditionalServices(ctx, pooler, res)).To(Succeed())
+
+			services := listServices()
+			Expect(services).To(HaveLen(1))
+			Expect(services[0].Name).To(Equal(pooler.Name + "-internal"))
+		})
+	})
+
 	It("should not reconcile if pooler has podSpec reconciliation disabled", func() {
 		ctx := context.Background()
 		namespace := newFakeNamespace(env.client)
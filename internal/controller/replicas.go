@@ -18,18 +18,27 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/lifecycleevents"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -41,6 +50,22 @@ var ErrWalReceiversRunning = fmt.Errorf("wal receivers are still running")
 // elapsed yet
 var ErrWaitingOnFailOverDelay = fmt.Errorf("current primary isn't healthy, waiting for the delay before triggering a failover") //nolint: lll
 
+// ErrPrimaryReachableFromWitness is raised when the primary server can't be replaced
+// because the witness can still reach it, which hints at a network partition
+var ErrPrimaryReachableFromWitness = fmt.Errorf("the witness can still reach the current primary")
+
+// ErrWitnessUnavailable is raised when the primary server can't be replaced
+// because the witness cannot confirm that the primary is unreachable
+var ErrWitnessUnavailable = fmt.Errorf("the witness is not available")
+
+// ErrWaitingForFailoverApproval is raised when the primary server can't be replaced
+// because the failover policy requires the user to approve the failover
+var ErrWaitingForFailoverApproval = fmt.Errorf("the failover is waiting for the user approval")
+
+// primaryLSNPersistenceInterval is how often the last known position of
+// the primary is stored in the cluster status
+const primaryLSNPersistenceInterval = 30 * time.Second
+
 // reconcileTargetPrimaryFromPods sets the name of the target primary from the Pods status if needed
 // this function will return the name of the new primary selected for promotion.
 // Returns the name of the primary if any changes was made and any error encountered.
@@ -59,6 +84,16 @@ func (r *ClusterReconciler) reconcileTargetPrimaryFromPods(
 		return "", nil
 	}
 
+	// Keep track of the position of a working primary, to be able
+	// to evaluate the lag of the replicas in case of failover
+	if primary := status.Items[0]; primary.IsPrimary && primary.HasHTTPStatus() &&
+		primary.Pod.Name == cluster.Status.CurrentPrimary {
+		r.primaryLSNs.Store(client.ObjectKeyFromObject(cluster), primary.CurrentLsn)
+		if err := r.persistPrimaryLSN(ctx, cluster, primary); err != nil {
+			return "", err
+		}
+	}
+
 	// First step: check if the current primary is running in an unschedulable node
 	// and issue a switchover if that's the case
 	if primary := status.Items[0]; (primary.IsPrimary || (cluster.IsReplica() && primary.IsPodReady)) &&
@@ -69,9 +104,14 @@ func (r *ClusterReconciler) reconcileTargetPrimaryFromPods(
 			contextLogger.Error(err, "while checking if current primary is on an unschedulable node")
 			// in case of error it's better to proceed with the normal target primary reconciliation
 		} else if isPrimaryOnUnschedulableNode {
+			if err := r.enforceEvictionPolicy(ctx, cluster, primary.Node); err != nil {
+				return "", err
+			}
 			contextLogger.Info("Primary is running on an unschedulable node, will try switching over",
 				"node", primary.Node, "primary", primary.Pod.Name)
 			return r.setPrimaryOnSchedulableNode(ctx, cluster, status, &primary)
+		} else {
+			r.primaryDrains.Delete(client.ObjectKeyFromObject(cluster))
 		}
 	}
 
@@ -90,7 +130,7 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 	cluster *apiv1.Cluster,
 	status postgres.PostgresqlStatusList,
 	resources *managedResources,
-) (string, error) {
+) (_ string, err error) {
 	contextLogger := log.FromContext(ctx)
 
 	mostAdvancedInstance := status.Items[0]
@@ -98,15 +138,43 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 		return "", nil
 	}
 
+	// Only the instances running in the zones allowed to host the
+	// primary can be promoted
+	mostAdvancedInstance, err = getPrimaryCandidate(cluster, status, resources)
+	if err != nil {
+		return "", err
+	}
+	if cluster.Status.TargetPrimary == mostAdvancedInstance.Pod.Name {
+		return "", nil
+	}
+
 	// If the first pod of the list has no reported status we can't evaluate the failover logic.
 	if !mostAdvancedInstance.HasHTTPStatus() {
 		return "", nil
 	}
 
+	ctx, span := telemetry.Start(ctx, "cluster.failover",
+		attribute.String("cnpg.current_primary", cluster.Status.CurrentPrimary),
+		attribute.String("cnpg.target_primary", cluster.Status.TargetPrimary),
+		attribute.String("cnpg.candidate_primary", mostAdvancedInstance.Pod.Name))
+	defer func() {
+		endFailoverSpan(span, err)
+	}()
+
 	if err := r.enforceFailoverDelay(ctx, cluster); err != nil {
 		return "", err
 	}
 
+	if cluster.Status.TargetPrimary == cluster.Status.CurrentPrimary {
+		if err := r.enforceWitnessConsensus(ctx, cluster, resources); err != nil {
+			return "", err
+		}
+
+		if err := r.enforceFailoverPolicy(ctx, cluster, mostAdvancedInstance); err != nil {
+			return "", err
+		}
+	}
+
 	// The current primary is not correctly working, and we need to elect a new one
 	// but before doing that we need to wait for all the WAL receivers to be
 	// terminated. To make sure they eventually terminate we signal the old primary
@@ -126,6 +194,9 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 		if err != nil {
 			return "", err
 		}
+		if err := r.consumeFailoverApproval(ctx, cluster); err != nil {
+			return "", err
+		}
 	}
 
 	// Wait until all the WAL receivers are down. This is needed to avoid losing the WAL
@@ -148,6 +219,16 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 		); err != nil {
 			return "", err
 		}
+		if err := r.queueLifecycleHooks(ctx, cluster, apiv1.LifecycleHookEventPostFailover,
+			mostAdvancedInstance.Pod.Name); err != nil {
+			return "", err
+		}
+
+		event := lifecycleevents.NewEvent(cluster, apiv1.LifecycleEventTypeFailover,
+			fmt.Sprintf("Failing over from %v to %v", cluster.Status.CurrentPrimary, mostAdvancedInstance.Pod.Name))
+		event.Instance = mostAdvancedInstance.Pod.Name
+		event.PreviousInstance = cluster.Status.CurrentPrimary
+		notifyLifecycleEvent(ctx, r.Client, r.Recorder, cluster, event)
 	} else {
 		contextLogger.Info("Target primary isn't healthy, switching target",
 			"newPrimary", mostAdvancedInstance.Pod.Name)
@@ -166,14 +247,49 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 	return mostAdvancedInstance.Pod.Name, r.setPrimaryInstance(ctx, cluster, mostAdvancedInstance.Pod.Name)
 }
 
+// endFailoverSpan ends the span tracing the election of a new primary.
+// The errors returned while waiting for the election to be possible are
+// recorded as events, as they are not failures
+func endFailoverSpan(span *telemetry.Span, err error) {
+	switch {
+	case errors.Is(err, ErrWalReceiversRunning),
+		errors.Is(err, ErrWaitingOnFailOverDelay),
+		errors.Is(err, ErrPrimaryReachableFromWitness),
+		errors.Is(err, ErrWitnessUnavailable),
+		errors.Is(err, ErrWaitingForFailoverApproval):
+		span.AddEvent("waiting", trace.WithAttributes(attribute.String("reason", err.Error())))
+	default:
+		span.RecordError(err)
+	}
+	span.End()
+}
+
 // isNodeUnschedulable checks whether a node is set to unschedulable
+// or is about to be drained
 func (r *ClusterReconciler) isNodeUnschedulable(ctx context.Context, nodeName string) (bool, error) {
 	var node corev1.Node
 	err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node)
 	if err != nil {
 		return false, err
 	}
-	return node.Spec.Unschedulable, nil
+	return isNodeBeingDrained(&node), nil
+}
+
+// isNodeBeingDrained checks whether a node is cordoned or carries one of
+// the taints used by the cluster-autoscaler or by Karpenter before
+// deprovisioning it
+func isNodeBeingDrained(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if configuration.Current.IsDrainTaint(taint.Key) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Pick the next primary on a schedulable node, if the current is running on an unschedulable one,
@@ -225,11 +341,20 @@ func (r *ClusterReconciler) setPrimaryOnSchedulableNode(
 			continue
 		}
 
+		// If the candidate is outside the zones allowed to host the primary, skip it
+		if !r.canNodeHostPrimary(ctx, cluster, candidate.Node) {
+			continue
+		}
+
 		// If the candidate has not established a connection to the current primary, skip it
 		if !candidate.IsWalReceiverActive {
 			continue
 		}
 
+		if err := r.runPreSwitchoverHooks(ctx, cluster, primaryPod.Pod, candidate.Pod.Name); err != nil {
+			return "", err
+		}
+
 		// Set the current candidate as targetPrimary
 		contextLogger.Info("Current primary is running on unschedulable node, triggering a switchover",
 			"currentPrimary", primaryPod.Pod.Name, "currentPrimaryNode", primaryPod.Node,
@@ -290,19 +415,24 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForReplicaCluster(
 		return "", ErrWalReceiversRunning
 	}
 
+	candidate, err := getPrimaryCandidate(cluster, status, resources)
+	if err != nil {
+		return "", err
+	}
+
 	contextLogger.Info("Current target primary isn't healthy, failing over",
-		"newPrimary", status.Items[0].Pod.Name)
+		"newPrimary", candidate.Pod.Name)
 	status.LogStatus(ctx)
 	contextLogger.Debug("Cluster status before failover", "instances", resources.instances)
 	r.Recorder.Eventf(cluster, "Normal", "FailingOver",
 		"Current target primary isn't healthy, failing over from %v to %v",
-		cluster.Status.TargetPrimary, status.Items[0].Pod.Name)
+		cluster.Status.TargetPrimary, candidate.Pod.Name)
 	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseFailOver,
-		fmt.Sprintf("Failing over to %v", status.Items[0].Pod.Name)); err != nil {
+		fmt.Sprintf("Failing over to %v", candidate.Pod.Name)); err != nil {
 		return "", err
 	}
 
-	return status.Items[0].Pod.Name, r.setPrimaryInstance(ctx, cluster, status.Items[0].Pod.Name)
+	return candidate.Pod.Name, r.setPrimaryInstance(ctx, cluster, candidate.Pod.Name)
 }
 
 // GetPodsNotOnPrimaryNode filters out only pods that are not on the same node as the primary one
@@ -351,8 +481,9 @@ func (r *ClusterReconciler) evaluateFailoverDelay(
 	}
 
 	if cluster.Status.CurrentPrimaryFailingSinceTimestamp == "" {
+		origCluster := cluster.DeepCopy()
 		cluster.Status.CurrentPrimaryFailingSinceTimestamp = pgTime.GetCurrentTimestamp()
-		if err := r.Status().Update(ctx, cluster); err != nil {
+		if err := status.PatchIfChanged(ctx, r.Client, cluster, origCluster); err != nil {
 			return err
 		}
 	}
@@ -371,6 +502,180 @@ func (r *ClusterReconciler) evaluateFailoverDelay(
 	return nil
 }
 
+// enforceWitnessConsensus ensures, when the cluster has a witness, that the
+// witness cannot reach the current primary either before initiating a failover.
+// A primary reachable from the witness is likely to be still serving the
+// applications, with the operator being isolated from it by a network partition
+func (r *ClusterReconciler) enforceWitnessConsensus(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+) error {
+	if !cluster.IsWitnessEnabled() {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	var primaryPod *corev1.Pod
+	for idx := range resources.instances.Items {
+		if resources.instances.Items[idx].Name == cluster.Status.CurrentPrimary {
+			primaryPod = &resources.instances.Items[idx]
+			break
+		}
+	}
+
+	// If the Pod of the primary is gone, there's no risk of having two
+	// primaries serving the applications. A Pod being deleted is still
+	// checked, as it could be running in a node isolated from the operator
+	if primaryPod == nil || primaryPod.Status.PodIP == "" {
+		return nil
+	}
+
+	reachable, err := r.WitnessClient.IsInstanceReachable(ctx, cluster, primaryPod)
+	if err != nil {
+		contextLogger.Warning("Cannot query the witness, the failover is suspended", "error", err.Error())
+		return ErrWitnessUnavailable
+	}
+
+	if reachable {
+		return ErrPrimaryReachableFromWitness
+	}
+
+	contextLogger.Info("The witness confirmed that the current primary is not reachable",
+		"primary", cluster.Status.CurrentPrimary)
+	return nil
+}
+
+// enforceFailoverPolicy ensures that the failover policy of the cluster allows
+// the promotion of the passed candidate. When it doesn't, the cluster waits
+// for the user to approve the replacement of the current primary
+func (r *ClusterReconciler) enforceFailoverPolicy(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	candidate postgres.PostgresqlStatus,
+) error {
+	policy := cluster.GetFailoverPolicy()
+	if policy == apiv1.FailoverPolicyAutomatic {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	if cluster.IsFailoverApproved() {
+		contextLogger.Info("The user approved the failover", "primary", cluster.Status.CurrentPrimary)
+		return nil
+	}
+
+	reason := fmt.Sprintf("The failover from %v requires the user approval", cluster.Status.CurrentPrimary)
+	if policy == apiv1.FailoverPolicyLagBounded {
+		lag, known := r.getReplicaLag(cluster, candidate)
+		switch {
+		case !known:
+			reason = fmt.Sprintf("The lag of %v is unknown, the failover from %v requires the user approval",
+				candidate.Pod.Name, cluster.Status.CurrentPrimary)
+		case lag > cluster.Spec.Failover.MaxLag.Value():
+			reason = fmt.Sprintf("The lag of %v is %v bytes, the failover from %v requires the user approval",
+				candidate.Pod.Name, lag, cluster.Status.CurrentPrimary)
+		default:
+			contextLogger.Info("The lag of the most advanced replica is within the bound",
+				"candidate", candidate.Pod.Name, "lag", lag)
+			return nil
+		}
+	}
+
+	if cluster.Status.Phase != apiv1.PhaseWaitingForUser || cluster.Status.PhaseReason != reason {
+		r.Recorder.Event(cluster, "Warning", "FailoverApprovalRequired", reason)
+		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForUser, reason); err != nil {
+			return err
+		}
+	}
+
+	return ErrWaitingForFailoverApproval
+}
+
+// consumeFailoverApproval removes the approval of the user once the
+// failover has been initiated, so that it can't be reused for a later one
+func (r *ClusterReconciler) consumeFailoverApproval(ctx context.Context, cluster *apiv1.Cluster) error {
+	if _, ok := cluster.Annotations[utils.FailoverApprovalAnnotationName]; !ok {
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, utils.FailoverApprovalAnnotationName)
+	return r.Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// persistPrimaryLSN stores the position of the working primary in the status
+// of the cluster, so that the lag of the replicas can still be evaluated after
+// a restart of the operator. As the position changes continuously, the status
+// is updated at most once every primaryLSNPersistenceInterval
+func (r *ClusterReconciler) persistPrimaryLSN(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	primary postgres.PostgresqlStatus,
+) error {
+	if cluster.GetFailoverPolicy() != apiv1.FailoverPolicyLagBounded || primary.CurrentLsn == "" {
+		return nil
+	}
+
+	lastKnown := cluster.Status.LastKnownPrimaryLSN
+	if lastKnown != nil && lastKnown.Instance == primary.Pod.Name &&
+		time.Since(lastKnown.ObservedAt.Time) < primaryLSNPersistenceInterval {
+		return nil
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.LastKnownPrimaryLSN = &apiv1.PrimaryLSNStatus{
+			Instance:   primary.Pod.Name,
+			LSN:        string(primary.CurrentLsn),
+			ObservedAt: metav1.Now(),
+		}
+	})
+}
+
+// getLastKnownPrimaryLSN gets the last position of the current primary
+// observed by the operator, preferring the one kept in memory, which is
+// more recent than the one stored in the status of the cluster
+func (r *ClusterReconciler) getLastKnownPrimaryLSN(cluster *apiv1.Cluster) (types.LSN, bool) {
+	if value, ok := r.primaryLSNs.Load(client.ObjectKeyFromObject(cluster)); ok {
+		return value.(types.LSN), true
+	}
+
+	lastKnown := cluster.Status.LastKnownPrimaryLSN
+	if lastKnown == nil || lastKnown.Instance != cluster.Status.CurrentPrimary {
+		return "", false
+	}
+
+	return types.LSN(lastKnown.LSN), true
+}
+
+// getReplicaLag gets the amount of WAL, in bytes, the passed replica
+// received is behind the last known position of the primary. The second
+// returned value is false when the lag cannot be computed, i.e. when
+// the current primary has never been seen working
+func (r *ClusterReconciler) getReplicaLag(
+	cluster *apiv1.Cluster,
+	replica postgres.PostgresqlStatus,
+) (int64, bool) {
+	lsn, ok := r.getLastKnownPrimaryLSN(cluster)
+	if !ok {
+		return 0, false
+	}
+
+	primaryLSN, err := lsn.Parse()
+	if err != nil {
+		return 0, false
+	}
+
+	replicaLSN, err := replica.ReceivedLsn.Parse()
+	if err != nil {
+		return 0, false
+	}
+
+	return max(primaryLSN-replicaLSN, 0), true
+}
+
 // findDeletableInstance get the Pod who is supposed to be deleted when the cluster is scaled down
 func findDeletableInstance(cluster *apiv1.Cluster, instances []corev1.Pod) string {
 	resultIdx := -1
@@ -0,0 +1,175 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Failover policy", func() {
+	var (
+		cluster    *apiv1.Cluster
+		candidate  postgres.PostgresqlStatus
+		reconciler *ClusterReconciler
+	)
+
+	BeforeEach(func() {
+		maxLag := resource.MustParse("16Mi")
+		cluster = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       apiv1.ClusterKind,
+				APIVersion: apiv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				Failover: &apiv1.FailoverConfiguration{
+					Policy: apiv1.FailoverPolicyLagBounded,
+					MaxLag: &maxLag,
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		candidate = postgres.PostgresqlStatus{
+			Pod:         &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-2"}},
+			ReceivedLsn: types.LSN("0/3000000"),
+		}
+		reconciler = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				WithStatusSubresource(cluster).
+				Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+	})
+
+	It("allows the failover with the automatic policy", func(ctx SpecContext) {
+		cluster.Spec.Failover = nil
+		Expect(reconciler.enforceFailoverPolicy(ctx, cluster, candidate)).To(Succeed())
+	})
+
+	It("waits for the user approval with the manual policy", func(ctx SpecContext) {
+		cluster.Spec.Failover = &apiv1.FailoverConfiguration{Policy: apiv1.FailoverPolicyManual}
+		err := reconciler.enforceFailoverPolicy(ctx, cluster, candidate)
+		Expect(err).To(MatchError(ErrWaitingForFailoverApproval))
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForUser))
+	})
+
+	It("allows the failover when the user approved it", func(ctx SpecContext) {
+		cluster.Spec.Failover = &apiv1.FailoverConfiguration{Policy: apiv1.FailoverPolicyManual}
+		cluster.Annotations = map[string]string{
+			utils.FailoverApprovalAnnotationName: "cluster-example-1",
+		}
+		Expect(reconciler.enforceFailoverPolicy(ctx, cluster, candidate)).To(Succeed())
+	})
+
+	It("ignores an approval given for a different primary", func(ctx SpecContext) {
+		cluster.Spec.Failover = &apiv1.FailoverConfiguration{Policy: apiv1.FailoverPolicyManual}
+		cluster.Annotations = map[string]string{
+			utils.FailoverApprovalAnnotationName: "cluster-example-3",
+		}
+		err := reconciler.enforceFailoverPolicy(ctx, cluster, candidate)
+		Expect(err).To(MatchError(ErrWaitingForFailoverApproval))
+	})
+
+	It("waits for the user approval when the lag is unknown", func(ctx SpecContext) {
+		err := reconciler.enforceFailoverPolicy(ctx, cluster, candidate)
+		Expect(err).To(MatchError(ErrWaitingForFailoverApproval))
+	})
+
+	It("allows the failover when the lag is within the bound", func(ctx SpecContext) {
+		reconciler.primaryLSNs.Store(client.ObjectKeyFromObject(cluster), types.LSN("0/3800000"))
+		Expect(reconciler.enforceFailoverPolicy(ctx, cluster, candidate)).To(Succeed())
+	})
+
+	It("waits for the user approval when the lag exceeds the bound", func(ctx SpecContext) {
+		reconciler.primaryLSNs.Store(client.ObjectKeyFromObject(cluster), types.LSN("0/5000000"))
+		err := reconciler.enforceFailoverPolicy(ctx, cluster, candidate)
+		Expect(err).To(MatchError(ErrWaitingForFailoverApproval))
+	})
+
+	It("evaluates the lag against the position stored in the status", func(ctx SpecContext) {
+		cluster.Status.LastKnownPrimaryLSN = &apiv1.PrimaryLSNStatus{
+			Instance:   "cluster-example-1",
+			LSN:        "0/3800000",
+			ObservedAt: metav1.Now(),
+		}
+		Expect(reconciler.enforceFailoverPolicy(ctx, cluster, candidate)).To(Succeed())
+
+		By("ignoring the position of a different primary", func() {
+			cluster.Status.LastKnownPrimaryLSN.Instance = "cluster-example-3"
+			err := reconciler.enforceFailoverPolicy(ctx, cluster, candidate)
+			Expect(err).To(MatchError(ErrWaitingForFailoverApproval))
+		})
+	})
+
+	It("stores the position of the primary in the status", func(ctx SpecContext) {
+		primary := postgres.PostgresqlStatus{
+			Pod:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1"}},
+			CurrentLsn: types.LSN("0/4000000"),
+		}
+		Expect(reconciler.persistPrimaryLSN(ctx, cluster, primary)).To(Succeed())
+		Expect(cluster.Status.LastKnownPrimaryLSN).ToNot(BeNil())
+		Expect(cluster.Status.LastKnownPrimaryLSN.LSN).To(Equal("0/4000000"))
+
+		By("not updating it again before the persistence interval", func() {
+			primary.CurrentLsn = types.LSN("0/5000000")
+			Expect(reconciler.persistPrimaryLSN(ctx, cluster, primary)).To(Succeed())
+
+			var updatedCluster apiv1.Cluster
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+			Expect(updatedCluster.Status.LastKnownPrimaryLSN.LSN).To(Equal("0/4000000"))
+		})
+	})
+
+	It("removes the approval once the failover is initiated", func(ctx SpecContext) {
+		cluster.Annotations = map[string]string{
+			utils.FailoverApprovalAnnotationName: "cluster-example-1",
+		}
+		Expect(reconciler.Update(ctx, cluster)).To(Succeed())
+
+		Expect(reconciler.consumeFailoverApproval(ctx, cluster)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.FailoverApprovalAnnotationName))
+	})
+})
@@ -151,3 +151,31 @@ var _ = Describe("Check pods not on primary node", func() {
 		Expect(GetPodsNotOnPrimaryNode(statusList2, &statusList2.Items[0]).Items).ToNot(BeEmpty())
 	})
 })
+
+var _ = Describe("Node drain detection", func() {
+	It("detects a cordoned node", func() {
+		node := &corev1.Node{Spec: corev1.NodeSpec{Unschedulable: true}}
+		Expect(isNodeBeingDrained(node)).To(BeTrue())
+	})
+
+	It("detects a node tainted by the cluster-autoscaler", func() {
+		node := &corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "ToBeDeletedByClusterAutoscaler", Effect: corev1.TaintEffectNoSchedule},
+		}}}
+		Expect(isNodeBeingDrained(node)).To(BeTrue())
+	})
+
+	It("detects a node disrupted by Karpenter", func() {
+		node := &corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectNoSchedule},
+		}}}
+		Expect(isNodeBeingDrained(node)).To(BeTrue())
+	})
+
+	It("ignores schedulable nodes with unrelated taints", func() {
+		node := &corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "dedicated", Value: "postgres", Effect: corev1.TaintEffectNoSchedule},
+		}}}
+		Expect(isNodeBeingDrained(node)).To(BeFalse())
+	})
+})
@@ -83,6 +83,13 @@ func (r *ScheduledBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	// Without the admission webhooks, the scheduled backup is validated here
+	if err := validateWithoutWebhooks(&scheduledBackup); err != nil {
+		contextLogger.Warning("Invalid scheduled backup, skipping it", "errors", err.Error())
+		r.Recorder.Event(&scheduledBackup, "Warning", "InvalidSpec", err.Error())
+		return ctrl.Result{}, nil
+	}
+
 	// This check is still needed for when the scheduled backup resource creation is forced through the webhook
 	if scheduledBackup.Spec.Method == apiv1.BackupMethodVolumeSnapshot && !utils.HaveVolumeSnapshot() {
 		contextLogger.Error(
@@ -186,6 +193,10 @@ func ReconcileScheduledBackup(
 	}
 
 	if scheduledBackup.Status.LastCheckTime == nil && scheduledBackup.IsImmediate() {
+		if result, postponed := postponeStart(ctx, event, scheduledBackup, now); postponed {
+			return result, nil
+		}
+
 		// we populate the status (lastCheckTime...) by following the same rules of the scheduled backup
 		event.Eventf(scheduledBackup, "Normal", "BackupSchedule", "Scheduled immediate backup now: %v", now)
 		return createBackup(ctx, event, cli, scheduledBackup, now, now, schedule, true)
@@ -219,9 +230,35 @@ func ReconcileScheduledBackup(
 		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
 	}
 
+	if result, postponed := postponeStart(ctx, event, scheduledBackup, now); postponed {
+		return result, nil
+	}
+
 	return createBackup(ctx, event, cli, scheduledBackup, nextTime, now, schedule, false)
 }
 
+// postponeStart checks if a backup can be started now. When a window
+// postponing the start of the backups is active, the backup is postponed
+// to its end
+func postponeStart(
+	ctx context.Context,
+	event record.EventRecorder,
+	scheduledBackup *apiv1.ScheduledBackup,
+	now time.Time,
+) (ctrl.Result, bool) {
+	startTime := scheduledBackup.GetPostponedStartTime(now)
+	if startTime == nil {
+		return ctrl.Result{}, false
+	}
+
+	log.FromContext(ctx).Info("Postponing the start of the backup until the end of the window",
+		"startTime", startTime)
+	event.Eventf(scheduledBackup, "Normal", "BackupPostponed",
+		"Backup start postponed until the end of the window: %v", *startTime)
+
+	return ctrl.Result{RequeueAfter: startTime.Sub(now)}, true
+}
+
 // createBackup creates a scheduled backup for a backuptime, updating the ScheduledBackup accordingly
 func createBackup(
 	ctx context.Context,
@@ -361,5 +398,5 @@ func (r *ScheduledBackupReconciler) SetupWithManager(
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
 		For(&apiv1.ScheduledBackup{}).
 		Named("scheduled-backup").
-		Complete(r)
+		Complete(newShardedReconciler(r))
 }
@@ -0,0 +1,47 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+)
+
+// shardedReconciler skips the reconciliation requests of the objects
+// living in the namespaces assigned to the other shards of the operator
+type shardedReconciler struct {
+	reconcile.Reconciler
+}
+
+// newShardedReconciler wraps a reconciler, restricting it to the
+// namespaces of the current operator shard
+func newShardedReconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return shardedReconciler{Reconciler: r}
+}
+
+// Reconcile implements the reconcile.Reconciler interface
+func (r shardedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if !configuration.Current.IsNamespaceInShard(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+
+	return r.Reconciler.Reconcile(ctx, req)
+}
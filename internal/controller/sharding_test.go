@@ -0,0 +1,77 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sharded reconciler", func() {
+	var reconciled []string
+
+	BeforeEach(func() {
+		reconciled = nil
+		configuration.Current = configuration.NewConfiguration()
+		DeferCleanup(func() {
+			configuration.Current = configuration.NewConfiguration()
+		})
+	})
+
+	newReconciler := func() reconcile.Reconciler {
+		return newShardedReconciler(reconcile.Func(func(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+			reconciled = append(reconciled, req.Namespace)
+			return ctrl.Result{}, nil
+		}))
+	}
+
+	request := func(namespace string) ctrl.Request {
+		return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "cluster-example"}}
+	}
+
+	It("reconciles every namespace when sharding is disabled", func(ctx SpecContext) {
+		r := newReconciler()
+		for _, namespace := range []string{"team-a", "team-b", ""} {
+			_, err := r.Reconcile(ctx, request(namespace))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(reconciled).To(HaveLen(3))
+	})
+
+	It("reconciles only the namespaces of its shard", func(ctx SpecContext) {
+		configuration.Current.ShardCount = 2
+		configuration.Current.ShardIndex = configuration.GetNamespaceShard("team-a", 2)
+		r := newReconciler()
+
+		_, err := r.Reconcile(ctx, request("team-a"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciled).To(ConsistOf("team-a"))
+
+		configuration.Current.ShardIndex = 1 - configuration.Current.ShardIndex
+		_, err = r.Reconcile(ctx, request("team-a"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciled).To(ConsistOf("team-a"))
+	})
+})
@@ -0,0 +1,354 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// auditSyslogRetryInterval is the time the audit records are written
+	// to the instance manager logger after the syslog endpoint failed
+	auditSyslogRetryInterval = 10 * time.Second
+
+	// defaultAuditUploadInterval is the time between two uploads of the
+	// audit log records to the object store
+	defaultAuditUploadInterval = 5 * time.Minute
+
+	// auditPendingDirectoryName is the name of the directory where the
+	// audit log files are moved while being uploaded
+	auditPendingDirectoryName = "pending"
+)
+
+// auditLogLine is a pgaudit record shipped to the audit sink, tagged
+// with the instance it comes from, since it is not shipped together
+// with the logs of the Pod
+type auditLogLine struct {
+	Logger     string                           `json:"logger"`
+	Namespace  string                           `json:"namespace"`
+	Cluster    string                           `json:"cluster"`
+	LoggingPod string                           `json:"logging_pod"`
+	Record     *logpipe.PgAuditLoggingDecorator `json:"record"`
+}
+
+// AuditLogShipper ships the pgaudit records read by the CSV log pipe
+// to the sink configured in the cluster, instead of writing them to
+// the instance manager logger
+type AuditLogShipper struct {
+	client.Client
+
+	instance       *postgres.Instance
+	localDirectory string
+	upload         func(
+		ctx context.Context,
+		cluster *apiv1.Cluster,
+		localDirectory string,
+		destinationPath string,
+	) error
+
+	mu              sync.Mutex
+	shipping        *apiv1.AuditLogShipping
+	syslogWriter    io.WriteCloser
+	syslogRetryTime time.Time
+	bufferFile      *os.File
+}
+
+// ShipAuditRecord implements the logpipe.AuditSink interface. When the
+// sink fails, the record is written to the instance manager logger
+func (r *AuditLogShipper) ShipAuditRecord(record *logpipe.PgAuditLoggingDecorator) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.shipping == nil {
+		return false
+	}
+
+	line, err := json.Marshal(auditLogLine{
+		Logger:     logpipe.PgAuditRecordName,
+		Namespace:  r.instance.GetNamespaceName(),
+		Cluster:    r.instance.GetClusterName(),
+		LoggingPod: r.instance.GetPodName(),
+		Record:     record,
+	})
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case r.shipping.Syslog != nil:
+		return r.writeToSyslog(line)
+	case r.shipping.ObjectStore != nil:
+		return r.writeToBuffer(line)
+	default:
+		return false
+	}
+}
+
+// writeToSyslog sends an audit log line to the syslog endpoint,
+// connecting to it if needed
+func (r *AuditLogShipper) writeToSyslog(line []byte) bool {
+	if r.syslogWriter == nil {
+		if time.Now().Before(r.syslogRetryTime) {
+			return false
+		}
+
+		protocol := r.shipping.Syslog.Protocol
+		if protocol == "" {
+			protocol = apiv1.SyslogProtocolUDP
+		}
+		tag := r.shipping.Syslog.Tag
+		if tag == "" {
+			tag = logpipe.PgAuditRecordName
+		}
+		writer, err := syslog.Dial(
+			string(protocol),
+			r.shipping.Syslog.Address,
+			syslog.LOG_INFO|syslog.LOG_LOCAL0,
+			tag)
+		if err != nil {
+			log.Warning("Cannot connect to the audit syslog endpoint, writing the audit records to the log",
+				"address", r.shipping.Syslog.Address, "err", err)
+			r.syslogRetryTime = time.Now().Add(auditSyslogRetryInterval)
+			return false
+		}
+		r.syslogWriter = writer
+	}
+
+	if _, err := r.syslogWriter.Write(line); err != nil {
+		log.Warning("Cannot send the audit records to the syslog endpoint, writing them to the log",
+			"address", r.shipping.Syslog.Address, "err", err)
+		r.closeSyslogWriter()
+		r.syslogRetryTime = time.Now().Add(auditSyslogRetryInterval)
+		return false
+	}
+
+	return true
+}
+
+// writeToBuffer appends an audit log line to the local file
+// that will be uploaded to the object store
+func (r *AuditLogShipper) writeToBuffer(line []byte) bool {
+	if r.bufferFile == nil {
+		if err := fileutils.EnsureDirectoryExists(r.localDirectory); err != nil {
+			return false
+		}
+
+		fileName := path.Join(r.localDirectory, fmt.Sprintf("%s-%s.json",
+			r.instance.GetPodName(), pgTime.ToCompactISO8601(time.Now())))
+		file, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304
+		if err != nil {
+			log.Warning("Cannot create the audit log file, writing the audit records to the log",
+				"fileName", fileName, "err", err)
+			return false
+		}
+		r.bufferFile = file
+	}
+
+	_, err := r.bufferFile.Write(append(line, '\n'))
+	return err == nil
+}
+
+// closeSyslogWriter closes the connection to the syslog endpoint, if any
+func (r *AuditLogShipper) closeSyslogWriter() {
+	if r.syslogWriter == nil {
+		return
+	}
+	_ = r.syslogWriter.Close()
+	r.syslogWriter = nil
+}
+
+// rotateBufferFile closes the audit log file being written, moving
+// it to the directory of the files to be uploaded
+func (r *AuditLogShipper) rotateBufferFile() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.bufferFile == nil {
+		return nil
+	}
+
+	fileName := r.bufferFile.Name()
+	if err := r.bufferFile.Close(); err != nil {
+		return err
+	}
+	r.bufferFile = nil
+
+	pendingDirectory := path.Join(r.localDirectory, auditPendingDirectoryName)
+	if err := fileutils.EnsureDirectoryExists(pendingDirectory); err != nil {
+		return err
+	}
+	return os.Rename(fileName, path.Join(pendingDirectory, path.Base(fileName)))
+}
+
+// configure applies the shipping configuration of the cluster,
+// closing the connection to the previous syslog endpoint if it changed
+func (r *AuditLogShipper) configure(shipping *apiv1.AuditLogShipping) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if reflect.DeepEqual(r.shipping, shipping) {
+		return
+	}
+
+	r.closeSyslogWriter()
+	r.syslogRetryTime = time.Time{}
+	r.shipping = shipping.DeepCopy()
+}
+
+// Reconcile applies the audit log shipping configuration of the cluster,
+// periodically uploading the buffered audit log files to the object store
+func (r *AuditLogShipper) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).WithName("audit_log_shipper")
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	cluster, err := getClusterFromInstance(ctx, r.Client, r.instance)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	shipping := cluster.Spec.PostgresConfiguration.Audit.GetShipping()
+	r.configure(shipping)
+	if shipping == nil || shipping.ObjectStore == nil {
+		return ctrl.Result{}, nil
+	}
+
+	uploadInterval := defaultAuditUploadInterval
+	if shipping.ObjectStore.UploadInterval != nil {
+		uploadInterval = shipping.ObjectStore.UploadInterval.Duration
+	}
+
+	if err := r.uploadAuditLogs(ctx, cluster); err != nil {
+		contextLogger.Error(err, "while uploading the audit log files to the object store")
+	}
+
+	return ctrl.Result{RequeueAfter: uploadInterval}, nil
+}
+
+// uploadAuditLogs uploads the audit log files to the object store,
+// removing them once copied
+func (r *AuditLogShipper) uploadAuditLogs(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
+		return errObjectStoreNotConfigured
+	}
+
+	if err := r.rotateBufferFile(); err != nil {
+		return fmt.Errorf("while rotating the audit log file: %w", err)
+	}
+
+	pendingDirectory := path.Join(r.localDirectory, auditPendingDirectoryName)
+	entries, err := os.ReadDir(pendingDirectory)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	destinationPath := getAuditDestinationPath(cluster)
+	log.FromContext(ctx).Info("Copying the audit log files to the object store",
+		"destinationPath", destinationPath, "files", len(entries))
+	if err := r.upload(ctx, cluster, pendingDirectory, destinationPath); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(pendingDirectory)
+}
+
+// uploadToObjectStore copies the audit log files to the object store,
+// using the credentials of the backup configuration of the cluster
+func (r *AuditLogShipper) uploadToObjectStore(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	localDirectory string,
+	destinationPath string,
+) error {
+	return uploadDirectoryToObjectStore(
+		ctx,
+		r.Client,
+		cluster.Namespace,
+		cluster.Spec.Backup.BarmanObjectStore,
+		localDirectory,
+		destinationPath,
+		"audit-upload",
+	)
+}
+
+// getAuditDestinationPath gets the path in the object store where the
+// audit log files are stored. Unless specified, they are kept next to
+// the base backups of the cluster
+func getAuditDestinationPath(cluster *apiv1.Cluster) string {
+	sink := cluster.Spec.PostgresConfiguration.Audit.Shipping.ObjectStore
+	if sink.DestinationPath != "" {
+		return sink.DestinationPath
+	}
+
+	configuration := cluster.Spec.Backup.BarmanObjectStore
+	serverName := configuration.ServerName
+	if serverName == "" {
+		serverName = cluster.Name
+	}
+
+	return strings.Join([]string{
+		strings.TrimSuffix(configuration.DestinationPath, "/"),
+		serverName,
+		"audit",
+	}, "/")
+}
+
+// NewAuditLogShipper creates a new audit log shipper
+func NewAuditLogShipper(
+	mgr manager.Manager,
+	instance *postgres.Instance,
+) *AuditLogShipper {
+	r := &AuditLogShipper{
+		Client:         mgr.GetClient(),
+		instance:       instance,
+		localDirectory: postgresSpec.AuditLogDirectory,
+	}
+	r.upload = r.uploadToObjectStore
+	return r
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AuditLogShipper) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Cluster{}).
+		Named("instance-audit-log-shipper").
+		Complete(r)
+}
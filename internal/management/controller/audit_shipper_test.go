@@ -0,0 +1,121 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("audit log shipper", func() {
+	var (
+		shipper       *AuditLogShipper
+		cluster       *apiv1.Cluster
+		uploadedLines []string
+		uploadedPath  string
+	)
+
+	BeforeEach(func() {
+		uploadedLines = nil
+		uploadedPath = ""
+		shipper = &AuditLogShipper{
+			instance: postgres.NewInstance().
+				WithNamespace("default").
+				WithClusterName("cluster-example").
+				WithPodName("cluster-example-1"),
+			localDirectory: GinkgoT().TempDir(),
+		}
+		shipper.upload = func(_ context.Context, _ *apiv1.Cluster, localDirectory, destinationPath string) error {
+			entries, err := os.ReadDir(localDirectory)
+			Expect(err).ToNot(HaveOccurred())
+			for _, entry := range entries {
+				content, err := os.ReadFile(path.Join(localDirectory, entry.Name()))
+				Expect(err).ToNot(HaveOccurred())
+				uploadedLines = append(uploadedLines, strings.Split(strings.TrimSpace(string(content)), "\n")...)
+			}
+			uploadedPath = destinationPath
+			return nil
+		}
+
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{DestinationPath: "s3://backups/"},
+				},
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Audit: &apiv1.AuditConfiguration{
+						Shipping: &apiv1.AuditLogShipping{ObjectStore: &apiv1.AuditObjectStoreSink{}},
+					},
+				},
+			},
+		}
+	})
+
+	newAuditRecord := func(statement string) *logpipe.PgAuditLoggingDecorator {
+		record := logpipe.NewPgAuditLoggingDecorator()
+		record.Audit.Statement = statement
+		return record
+	}
+
+	It("doesn't ship the audit records without a sink", func() {
+		Expect(shipper.ShipAuditRecord(newAuditRecord("SELECT 1"))).To(BeFalse())
+	})
+
+	It("uploads the tagged audit records to the object store", func(ctx SpecContext) {
+		shipper.configure(cluster.Spec.PostgresConfiguration.Audit.Shipping)
+		Expect(shipper.ShipAuditRecord(newAuditRecord("SELECT 1"))).To(BeTrue())
+		Expect(shipper.ShipAuditRecord(newAuditRecord("SELECT 2"))).To(BeTrue())
+
+		Expect(shipper.uploadAuditLogs(ctx, cluster)).To(Succeed())
+		Expect(uploadedPath).To(Equal("s3://backups/cluster-example/audit"))
+		Expect(uploadedLines).To(HaveLen(2))
+
+		var line map[string]any
+		Expect(json.Unmarshal([]byte(uploadedLines[1]), &line)).To(Succeed())
+		Expect(line).To(HaveKeyWithValue("logger", "pgaudit"))
+		Expect(line).To(HaveKeyWithValue("cluster", "cluster-example"))
+		Expect(line).To(HaveKeyWithValue("logging_pod", "cluster-example-1"))
+		Expect(line["record"]).To(HaveKeyWithValue("audit",
+			HaveKeyWithValue("statement", "SELECT 2")))
+
+		Expect(path.Join(shipper.localDirectory, auditPendingDirectoryName)).ToNot(BeADirectory())
+	})
+
+	It("doesn't upload anything when there are no audit records", func(ctx SpecContext) {
+		shipper.configure(cluster.Spec.PostgresConfiguration.Audit.Shipping)
+		Expect(shipper.uploadAuditLogs(ctx, cluster)).To(Succeed())
+		Expect(uploadedPath).To(BeEmpty())
+	})
+
+	It("uses the destination path of the sink when specified", func() {
+		cluster.Spec.PostgresConfiguration.Audit.Shipping.ObjectStore.DestinationPath = "s3://audit/cluster"
+		Expect(getAuditDestinationPath(cluster)).To(Equal("s3://audit/cluster"))
+	})
+})
@@ -0,0 +1,385 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupmirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	barmanArchiver "github.com/cloudnative-pg/barman-cloud/pkg/archiver"
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// walSegmentRegex matches the name of the WAL segments
+var walSegmentRegex = regexp.MustCompile(`^[0-9A-F]{24}$`)
+
+const (
+	// mirrorInterval is the interval between two copies of the spooled
+	// WAL files to the secondary object store
+	mirrorInterval = 30 * time.Second
+
+	// mirrorLagThreshold is the age of the oldest WAL file waiting to be
+	// copied after which the secondary object store is considered lagging
+	mirrorLagThreshold = 5 * time.Minute
+)
+
+// A Mirrorer is a runner that copies the WAL files archived by this instance
+// to the secondary object store of the cluster
+type Mirrorer struct {
+	instance         *postgres.Instance
+	client           client.Client
+	spoolDirectory   string
+	skippedDirectory string
+}
+
+// NewMirrorer creates a new backup Mirrorer
+func NewMirrorer(instance *postgres.Instance, client client.Client) *Mirrorer {
+	runner := &Mirrorer{
+		instance:         instance,
+		client:           client,
+		spoolDirectory:   postgresSpec.MirrorSpoolDirectory,
+		skippedDirectory: postgresSpec.MirrorSkippedDirectory,
+	}
+	return runner
+}
+
+// Start starts running the backup Mirrorer
+func (m *Mirrorer) Start(ctx context.Context) error {
+	contextLog := log.FromContext(ctx).WithName("backup_mirrorer")
+	go func() {
+		ticker := time.NewTicker(mirrorInterval)
+
+		defer func() {
+			ticker.Stop()
+			contextLog.Info("Terminated backup Mirrorer loop")
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := m.reconcile(ctx); err != nil {
+				contextLog.Error(err, "synchronizing the secondary object store")
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile copies the spooled WAL files to the secondary object store and,
+// when running in the primary instance, reports the result in the
+// cluster status
+func (m *Mirrorer) reconcile(ctx context.Context) error {
+	var cluster apiv1.Cluster
+	if err := m.client.Get(ctx, client.ObjectKey{
+		Namespace: m.instance.GetNamespaceName(),
+		Name:      m.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return err
+	}
+
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Mirror == nil {
+		return nil
+	}
+
+	pendingWALs, err := getPendingWALFiles(m.spoolDirectory)
+	if err != nil {
+		return err
+	}
+
+	mirrored, mirrorErr := m.mirrorWALFiles(ctx, &cluster, pendingWALs)
+	if mirrorErr != nil {
+		log.FromContext(ctx).Error(mirrorErr, "while copying WAL files to the secondary object store")
+	}
+
+	isPrimary, err := m.instance.IsPrimary()
+	if err != nil || !isPrimary {
+		return err
+	}
+
+	var backupBeginWAL string
+	if cluster.Status.BackupMirror != nil {
+		backupBeginWAL = cluster.Status.BackupMirror.LastMirroredBackupBeginWAL
+	}
+	lastSkippedWAL, err := getLastSkippedWALFile(m.skippedDirectory, backupBeginWAL)
+	if err != nil {
+		return err
+	}
+
+	return m.updateStatus(ctx, &cluster, pendingWALs[mirrored:], lastSkippedWAL, mirrorErr)
+}
+
+// mirrorWALFiles copies the passed WAL files, in order, to the secondary
+// object store, removing them from the spool directory. It returns how
+// many of them have been copied
+func (m *Mirrorer) mirrorWALFiles(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	walNames []string,
+) (int, error) {
+	if len(walNames) == 0 {
+		return 0, nil
+	}
+
+	configuration := &cluster.Spec.Backup.Mirror.BarmanObjectStore
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(
+		ctx,
+		m.client,
+		cluster.Namespace,
+		configuration,
+		os.Environ())
+	if err != nil {
+		return 0, fmt.Errorf("while getting the secondary object store credentials: %w", err)
+	}
+
+	walArchiver, err := barmanArchiver.New(ctx, env, postgresSpec.SpoolDirectory, m.instance.PgData, "")
+	if err != nil {
+		return 0, err
+	}
+
+	options, err := walArchiver.BarmanCloudWalArchiveOptions(ctx, configuration, cluster.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	for idx, walName := range walNames {
+		walPath := path.Join(m.spoolDirectory, walName)
+		// The WAL files are copied one at a time, to never leave a hole
+		// in the WAL stream of the secondary object store
+		result := walArchiver.ArchiveList(ctx, []string{walPath}, options)
+		if result[0].Err != nil {
+			return idx, result[0].Err
+		}
+
+		if err := fileutils.RemoveFile(walPath); err != nil {
+			return idx, err
+		}
+
+		cluster.Status.BackupMirror = ensureMirrorStatus(cluster.Status.BackupMirror)
+		cluster.Status.BackupMirror.LastMirroredWAL = walName
+		cluster.Status.BackupMirror.LastMirroredWALTime = result[0].EndTime.Format(time.RFC3339)
+	}
+
+	return len(walNames), nil
+}
+
+// updateStatus stores the progress of the copy in the cluster status
+// and updates the BackupMirrorSynchronized condition
+func (m *Mirrorer) updateStatus(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pendingWALs []string,
+	lastSkippedWAL string,
+	mirrorErr error,
+) error {
+	oldestPendingWALTime, err := getOldestPendingWALTime(m.spoolDirectory, pendingWALs)
+	if err != nil {
+		return err
+	}
+
+	mirrorStatus := ensureMirrorStatus(cluster.Status.BackupMirror.DeepCopy())
+	mirrorStatus.PendingWALFiles = len(pendingWALs)
+	mirrorStatus.LastSkippedWAL = lastSkippedWAL
+	mirrorStatus.OldestPendingWALTime = ""
+	if oldestPendingWALTime != nil {
+		mirrorStatus.OldestPendingWALTime = oldestPendingWALTime.Format(time.RFC3339)
+	}
+
+	if err := status.PatchWithOptimisticLock(ctx, m.client, cluster, func(cluster *apiv1.Cluster) {
+		// The result of the last base backup is managed by the backup
+		// process and must not be overwritten
+		if cluster.Status.BackupMirror != nil {
+			mirrorStatus.LastMirroredBackupID = cluster.Status.BackupMirror.LastMirroredBackupID
+			mirrorStatus.LastMirroredBackupBeginWAL = cluster.Status.BackupMirror.LastMirroredBackupBeginWAL
+			mirrorStatus.LastMirroredBackupTime = cluster.Status.BackupMirror.LastMirroredBackupTime
+			mirrorStatus.LastBackupError = cluster.Status.BackupMirror.LastBackupError
+		}
+		cluster.Status.BackupMirror = mirrorStatus
+	}); err != nil {
+		return err
+	}
+
+	return status.PatchConditionsWithOptimisticLock(
+		ctx,
+		m.client,
+		cluster,
+		buildMirrorCondition(mirrorStatus, oldestPendingWALTime, mirrorErr, time.Now()),
+	)
+}
+
+// getPendingWALFiles gets the sorted list of the WAL files waiting
+// to be copied to the secondary object store
+func getPendingWALFiles(spoolDirectory string) ([]string, error) {
+	files, err := fileutils.GetDirectoryContent(spoolDirectory)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		// Temporary files are still being written by the WAL archiver
+		if strings.HasSuffix(file, ".tmp") {
+			continue
+		}
+		result = append(result, file)
+	}
+	slices.Sort(result)
+
+	return result, nil
+}
+
+// getLastSkippedWALFile gets the newest of the WAL files that have not been
+// spooled because the spool was full, if any. The ones preceding the first
+// WAL file of the last base backup taken in the secondary object store
+// are not needed anymore, and are forgotten
+func getLastSkippedWALFile(skippedDirectory, backupBeginWAL string) (string, error) {
+	files, err := fileutils.GetDirectoryContent(skippedDirectory)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	for _, file := range files {
+		if !walSegmentRegex.MatchString(file) {
+			continue
+		}
+
+		if backupBeginWAL != "" && isOlderWALSegment(file, backupBeginWAL) {
+			if err := fileutils.RemoveFile(path.Join(skippedDirectory, file)); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		if result == "" || isOlderWALSegment(result, file) {
+			result = file
+		}
+	}
+
+	return result, nil
+}
+
+// isOlderWALSegment checks whether a WAL segment precedes another one,
+// regardless of their timelines
+func isOlderWALSegment(walName, otherWALName string) bool {
+	return walName[8:] < otherWALName[8:]
+}
+
+// getOldestPendingWALTime gets the time when the oldest pending WAL file
+// has been spooled, or nil if there are no pending WAL files
+func getOldestPendingWALTime(spoolDirectory string, pendingWALs []string) (*time.Time, error) {
+	if len(pendingWALs) == 0 {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path.Join(spoolDirectory, pendingWALs[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	result := info.ModTime()
+	return &result, nil
+}
+
+// buildMirrorCondition computes the BackupMirrorSynchronized condition
+// given the status of the secondary object store
+func buildMirrorCondition(
+	mirrorStatus *apiv1.BackupMirrorStatus,
+	oldestPendingWALTime *time.Time,
+	mirrorErr error,
+	now time.Time,
+) metav1.Condition {
+	switch {
+	case mirrorErr != nil:
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionBackupMirrorSynchronized),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonBackupMirrorFailing),
+			Message: mirrorErr.Error(),
+		}
+
+	case mirrorStatus.LastSkippedWAL != "":
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionBackupMirrorSynchronized),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonBackupMirrorSpoolFull),
+			Message: fmt.Sprintf("The WAL files waiting to be copied exceeded the maximum spool size "+
+				"and up to %s have been skipped: recovering from the secondary object store requires "+
+				"a base backup started after it", mirrorStatus.LastSkippedWAL),
+		}
+
+	case mirrorStatus.LastBackupError != "":
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionBackupMirrorSynchronized),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonBackupMirrorFailing),
+			Message: mirrorStatus.LastBackupError,
+		}
+
+	case oldestPendingWALTime != nil && now.Sub(*oldestPendingWALTime) > mirrorLagThreshold:
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionBackupMirrorSynchronized),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonBackupMirrorLagging),
+			Message: fmt.Sprintf("%d WAL files are waiting to be copied, the oldest one since %s",
+				mirrorStatus.PendingWALFiles, oldestPendingWALTime.Format(time.RFC3339)),
+		}
+
+	default:
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionBackupMirrorSynchronized),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonBackupMirrorSynchronized),
+			Message: "The secondary object store is synchronized",
+		}
+	}
+}
+
+// ensureMirrorStatus returns the passed mirror status, or an empty one
+// if it is nil
+func ensureMirrorStatus(mirrorStatus *apiv1.BackupMirrorStatus) *apiv1.BackupMirrorStatus {
+	if mirrorStatus == nil {
+		return &apiv1.BackupMirrorStatus{}
+	}
+	return mirrorStatus
+}
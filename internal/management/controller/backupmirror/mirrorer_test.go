@@ -0,0 +1,161 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupmirror
+
+import (
+	"errors"
+	"os"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pending WAL files", func() {
+	var spoolDirectory string
+
+	BeforeEach(func() {
+		spoolDirectory = GinkgoT().TempDir()
+	})
+
+	It("returns no WAL files when the spool directory does not exist", func() {
+		walFiles, err := getPendingWALFiles(path.Join(spoolDirectory, "missing"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walFiles).To(BeEmpty())
+	})
+
+	It("returns the sorted WAL files, skipping the ones being written", func() {
+		for _, name := range []string{
+			"000000010000000000000003",
+			"000000010000000000000001",
+			"000000010000000000000002.tmp",
+		} {
+			Expect(os.WriteFile(path.Join(spoolDirectory, name), nil, 0o600)).To(Succeed())
+		}
+
+		walFiles, err := getPendingWALFiles(spoolDirectory)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walFiles).To(Equal([]string{
+			"000000010000000000000001",
+			"000000010000000000000003",
+		}))
+	})
+
+	It("gets the time of the oldest pending WAL file", func() {
+		spoolTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		walPath := path.Join(spoolDirectory, "000000010000000000000001")
+		Expect(os.WriteFile(walPath, nil, 0o600)).To(Succeed())
+		Expect(os.Chtimes(walPath, spoolTime, spoolTime)).To(Succeed())
+
+		oldestTime, err := getOldestPendingWALTime(spoolDirectory, []string{"000000010000000000000001"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(oldestTime).ToNot(BeNil())
+		Expect(oldestTime.Equal(spoolTime)).To(BeTrue())
+
+		oldestTime, err = getOldestPendingWALTime(spoolDirectory, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(oldestTime).To(BeNil())
+	})
+})
+
+var _ = Describe("skipped WAL files", func() {
+	var skippedDirectory string
+
+	BeforeEach(func() {
+		skippedDirectory = GinkgoT().TempDir()
+		for _, name := range []string{
+			"000000010000000000000003",
+			"000000020000000000000005",
+			"000000010000000000000001",
+		} {
+			Expect(os.WriteFile(path.Join(skippedDirectory, name), nil, 0o600)).To(Succeed())
+		}
+	})
+
+	It("returns nothing when the directory does not exist", func() {
+		lastSkippedWAL, err := getLastSkippedWALFile(path.Join(skippedDirectory, "missing"), "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lastSkippedWAL).To(BeEmpty())
+	})
+
+	It("gets the newest skipped WAL file", func() {
+		lastSkippedWAL, err := getLastSkippedWALFile(skippedDirectory, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lastSkippedWAL).To(Equal("000000020000000000000005"))
+	})
+
+	It("forgets the WAL files preceding the last base backup", func() {
+		lastSkippedWAL, err := getLastSkippedWALFile(skippedDirectory, "000000020000000000000004")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lastSkippedWAL).To(Equal("000000020000000000000005"))
+		Expect(path.Join(skippedDirectory, "000000010000000000000003")).ToNot(BeAnExistingFile())
+
+		lastSkippedWAL, err = getLastSkippedWALFile(skippedDirectory, "000000020000000000000006")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lastSkippedWAL).To(BeEmpty())
+	})
+})
+
+var _ = Describe("buildMirrorCondition", func() {
+	now := time.Now()
+
+	It("reports a synchronized secondary object store", func() {
+		condition := buildMirrorCondition(&apiv1.BackupMirrorStatus{}, nil, nil, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonBackupMirrorSynchronized)))
+	})
+
+	It("tolerates WAL files waiting for less than the lag threshold", func() {
+		oldestTime := now.Add(-time.Minute)
+		condition := buildMirrorCondition(&apiv1.BackupMirrorStatus{PendingWALFiles: 2}, &oldestTime, nil, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("reports a lagging secondary object store", func() {
+		oldestTime := now.Add(-time.Hour)
+		condition := buildMirrorCondition(&apiv1.BackupMirrorStatus{PendingWALFiles: 2}, &oldestTime, nil, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonBackupMirrorLagging)))
+	})
+
+	It("reports a failure copying WAL files", func() {
+		condition := buildMirrorCondition(&apiv1.BackupMirrorStatus{}, nil, errors.New("access denied"), now)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonBackupMirrorFailing)))
+		Expect(condition.Message).To(Equal("access denied"))
+	})
+
+	It("reports the WAL files skipped because the spool was full", func() {
+		condition := buildMirrorCondition(
+			&apiv1.BackupMirrorStatus{LastSkippedWAL: "000000010000000000000003"}, nil, nil, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonBackupMirrorSpoolFull)))
+		Expect(condition.Message).To(ContainSubstring("000000010000000000000003"))
+	})
+
+	It("reports a failure taking the last base backup", func() {
+		condition := buildMirrorCondition(&apiv1.BackupMirrorStatus{LastBackupError: "bucket not found"}, nil, nil, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonBackupMirrorFailing)))
+		Expect(condition.Message).To(Equal("bucket not found"))
+	})
+})
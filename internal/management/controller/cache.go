@@ -28,6 +28,7 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/manager/walrestore"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbackrest"
 )
 
 // updateCacheFromCluster will update the internal cache with the cluster
@@ -51,7 +52,7 @@ func (r *InstanceReconciler) updateWALRestoreSettingsCache(ctx context.Context,
 
 	_, env, barmanConfiguration, err := walrestore.GetRecoverConfiguration(cluster, r.instance.GetPodName())
 	if errors.Is(err, walrestore.ErrNoBackupConfigured) {
-		cache.Delete(cache.WALRestoreKey)
+		r.updatePgBackRestWALRestoreSettingsCache(ctx, cluster)
 		return
 	}
 	if err != nil {
@@ -73,6 +74,31 @@ func (r *InstanceReconciler) updateWALRestoreSettingsCache(ctx context.Context,
 	cache.Store(cache.WALRestoreKey, envRestore)
 }
 
+// updatePgBackRestWALRestoreSettingsCache updates the cache with the
+// credentials of the pgBackRest repository used to restore WAL files
+func (r *InstanceReconciler) updatePgBackRestWALRestoreSettingsCache(ctx context.Context, cluster *apiv1.Cluster) {
+	contextLogger := log.FromContext(ctx)
+
+	_, pgBackRestConfiguration, err := walrestore.GetPgBackRestRecoverConfiguration(cluster, r.instance.GetPodName())
+	if err != nil {
+		cache.Delete(cache.WALRestoreKey)
+		return
+	}
+
+	envRestore, err := pgbackrest.EnvSetCredentials(
+		ctx,
+		r.GetClient(),
+		cluster.Namespace,
+		pgBackRestConfiguration,
+		os.Environ(),
+	)
+	if err != nil {
+		contextLogger.Error(err, "while getting recover credentials")
+		return
+	}
+	cache.Store(cache.WALRestoreKey, envRestore)
+}
+
 // shouldUpdateWALArchiveSettingsCache updates the cache with the backup credentials
 //
 // returns true if and only if the update should run again, because:
@@ -83,18 +109,31 @@ func (r *InstanceReconciler) shouldUpdateWALArchiveSettingsCache(
 ) (shouldRetry bool) {
 	contextLogger := log.FromContext(ctx)
 
-	if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
+	var envArchive []string
+	var err error
+
+	// Populate the cache with the backup configuration
+	switch {
+	case cluster.Spec.Backup != nil && cluster.Spec.Backup.PgBackRest != nil:
+		envArchive, err = pgbackrest.EnvSetCredentials(
+			ctx,
+			r.GetClient(),
+			cluster.Namespace,
+			cluster.Spec.Backup.PgBackRest,
+			os.Environ())
+
+	case cluster.Spec.Backup != nil && cluster.Spec.Backup.BarmanObjectStore != nil:
+		envArchive, err = barmanCredentials.EnvSetBackupCloudCredentials(
+			ctx,
+			r.GetClient(),
+			cluster.Namespace,
+			cluster.Spec.Backup.BarmanObjectStore,
+			os.Environ())
+
+	default:
 		cache.Delete(cache.WALArchiveKey)
 		return false
 	}
-
-	// Populate the cache with the backup configuration
-	envArchive, err := barmanCredentials.EnvSetBackupCloudCredentials(
-		ctx,
-		r.GetClient(),
-		cluster.Namespace,
-		cluster.Spec.Backup.BarmanObjectStore,
-		os.Environ())
 	if apierrors.IsForbidden(err) {
 		contextLogger.Info("backup credentials don't yet have access permissions. Will retry reconciliation loop")
 		return true
@@ -19,7 +19,9 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -29,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -41,6 +44,7 @@ type DatabaseReconciler struct {
 	instance            instanceInterface
 	finalizerReconciler *finalizerReconciler[*apiv1.Database]
 	getSuperUserDB      func() (*sql.DB, error)
+	getTargetDB         func(dbname string) (*sql.DB, error)
 }
 
 // databaseReconciliationInterval is the time between the
@@ -75,8 +79,14 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
-	// If everything is reconciled, we're done here
-	if database.Generation == database.Status.ObservedGeneration {
+	// If everything is reconciled, we're done here. The privileges, the
+	// foreign servers and the autovacuum settings of the tables are the
+	// exception, as they are periodically applied to correct the changes
+	// made outside of the operator and to follow the credentials stored
+	// in the secrets
+	alreadyApplied := database.Generation == database.Status.ObservedGeneration
+	if alreadyApplied && len(database.Spec.Grants) == 0 && len(database.Spec.Servers) == 0 &&
+		len(database.Spec.TableAutovacuum) == 0 {
 		return ctrl.Result{}, nil
 	}
 
@@ -121,7 +131,7 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return res, err
 	}
 
-	if err := r.reconcileDatabase(ctx, &database); err != nil {
+	if err := r.reconcileDatabaseObjects(ctx, cluster, &database, alreadyApplied); err != nil {
 		if markErr := markAsFailed(ctx, r.Client, &database, err); markErr != nil {
 			contextLogger.Error(err, "while marking as failed the database resource",
 				"error", err,
@@ -164,6 +174,9 @@ func NewDatabaseReconciler(
 		getSuperUserDB: func() (*sql.DB, error) {
 			return instance.GetSuperUserDB()
 		},
+		getTargetDB: func(dbname string) (*sql.DB, error) {
+			return instance.ConnectionPool().Connection(dbname)
+		},
 	}
 
 	dr.finalizerReconciler = newFinalizerReconciler(
@@ -188,6 +201,41 @@ func (r *DatabaseReconciler) GetCluster(ctx context.Context) (*apiv1.Cluster, er
 	return getClusterFromInstance(ctx, r.Client, r.instance)
 }
 
+// reconcileDatabaseObjects reconciles the database together with the
+// objects it contains. When the current generation has already been
+// applied, only the foreign servers, the privileges and the autovacuum
+// settings of the tables are reconciled again
+func (r *DatabaseReconciler) reconcileDatabaseObjects(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	obj *apiv1.Database,
+	alreadyApplied bool,
+) error {
+	if !alreadyApplied {
+		if err := r.reconcileDatabase(ctx, obj); err != nil {
+			return err
+		}
+
+		if err := r.reconcileSchemas(ctx, obj); err != nil {
+			return err
+		}
+
+		if err := r.reconcileExtensions(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcileServers(ctx, cluster, obj); err != nil {
+		return err
+	}
+
+	if err := r.reconcileGrants(ctx, obj); err != nil {
+		return err
+	}
+
+	return r.reconcileTableAutovacuum(ctx, obj)
+}
+
 func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, obj *apiv1.Database) error {
 	db, err := r.getSuperUserDB()
 	if err != nil {
@@ -204,8 +252,396 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, obj *apiv1.D
 	}
 
 	if dbExists {
-		return updateDatabase(ctx, db, obj)
+		err = updateDatabase(ctx, db, obj)
+	} else {
+		err = createDatabase(ctx, db, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	return setDatabaseSearchPath(ctx, db, obj)
+}
+
+// reconcileSchemas reconciles the schemas of the database and
+// stores their status inside the Database object
+func (r *DatabaseReconciler) reconcileSchemas(ctx context.Context, obj *apiv1.Database) error {
+	if obj.Spec.Ensure == apiv1.EnsureAbsent ||
+		(len(obj.Spec.Schemas) == 0 && len(obj.Status.Schemas) == 0) {
+		return nil
+	}
+
+	schemasStatus, reconcileErr := r.applySchemas(ctx, obj)
+
+	oldDatabase := obj.DeepCopy()
+	obj.Status.Schemas = schemasStatus
+	if err := r.Client.Status().Patch(ctx, obj, client.MergeFrom(oldDatabase)); err != nil {
+		return fmt.Errorf("while updating the status of the schemas: %w", err)
+	}
+
+	return reconcileErr
+}
+
+// applySchemas creates, updates, or drops the schemas in the order of
+// declaration. A failure doesn't prevent the remaining schemas from
+// being reconciled.
+func (r *DatabaseReconciler) applySchemas(
+	ctx context.Context,
+	obj *apiv1.Database,
+) ([]apiv1.SchemaStatus, error) {
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	existing, err := getDatabaseSchemaInfo(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := obj.Spec.Schemas
+	errs := make([]error, len(schemas))
+	schemasStatus := make([]apiv1.SchemaStatus, len(schemas))
+	for idx := range schemas {
+		schema := schemas[idx]
+		if len(schema.Owner) == 0 {
+			schema.Owner = obj.Spec.Owner
+		}
+
+		info, exists := existing[schema.Name]
+		switch {
+		case schema.Ensure == apiv1.EnsureAbsent:
+			if exists {
+				errs[idx] = dropDatabaseSchema(ctx, db, schema)
+			}
+		case exists:
+			errs[idx] = updateDatabaseSchema(ctx, db, schema, info)
+		default:
+			errs[idx] = createDatabaseSchema(ctx, db, schema)
+		}
+
+		schemasStatus[idx] = apiv1.SchemaStatus{
+			Name:    schema.Name,
+			Applied: errs[idx] == nil,
+		}
+		if errs[idx] != nil {
+			schemasStatus[idx].Message = errs[idx].Error()
+		}
+	}
+
+	return schemasStatus, errors.Join(errs...)
+}
+
+// reconcileExtensions reconciles the extensions of the database and
+// stores their status inside the Database object
+func (r *DatabaseReconciler) reconcileExtensions(ctx context.Context, obj *apiv1.Database) error {
+	if obj.Spec.Ensure == apiv1.EnsureAbsent ||
+		(len(obj.Spec.Extensions) == 0 && len(obj.Status.Extensions) == 0) {
+		return nil
+	}
+
+	extensionsStatus, reconcileErr := r.applyExtensions(ctx, obj)
+
+	oldDatabase := obj.DeepCopy()
+	obj.Status.Extensions = extensionsStatus
+	if err := r.Client.Status().Patch(ctx, obj, client.MergeFrom(oldDatabase)); err != nil {
+		return fmt.Errorf("while updating the status of the extensions: %w", err)
+	}
+
+	return reconcileErr
+}
+
+// applyExtensions drops the extensions that should be absent, in the reverse
+// order of declaration, and then creates or updates the ones that should be
+// present, in the order of declaration. A failure doesn't prevent the
+// remaining extensions from being reconciled.
+func (r *DatabaseReconciler) applyExtensions(
+	ctx context.Context,
+	obj *apiv1.Database,
+) ([]apiv1.ExtensionStatus, error) {
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	installed, err := getDatabaseExtensionInfo(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := obj.Spec.Extensions
+	errs := make([]error, len(extensions))
+	for idx := len(extensions) - 1; idx >= 0; idx-- {
+		if extensions[idx].Ensure != apiv1.EnsureAbsent {
+			continue
+		}
+		if _, ok := installed[extensions[idx].Name]; ok {
+			errs[idx] = dropDatabaseExtension(ctx, db, extensions[idx])
+		}
+	}
+	for idx := range extensions {
+		if extensions[idx].Ensure == apiv1.EnsureAbsent {
+			continue
+		}
+		if info, ok := installed[extensions[idx].Name]; ok {
+			errs[idx] = updateDatabaseExtension(ctx, db, extensions[idx], info)
+		} else {
+			errs[idx] = createDatabaseExtension(ctx, db, extensions[idx])
+		}
+	}
+
+	// Creating an extension with CASCADE, or dropping it, can
+	// change other extensions too
+	if installed, err = getDatabaseExtensionInfo(ctx, db); err != nil {
+		return nil, err
+	}
+
+	extensionsStatus := make([]apiv1.ExtensionStatus, len(extensions))
+	for idx := range extensions {
+		extensionsStatus[idx] = apiv1.ExtensionStatus{
+			Name:    extensions[idx].Name,
+			Applied: errs[idx] == nil,
+			Version: installed[extensions[idx].Name].Version,
+		}
+		if errs[idx] != nil {
+			extensionsStatus[idx].Message = errs[idx].Error()
+		}
+	}
+
+	return extensionsStatus, errors.Join(errs...)
+}
+
+// reconcileServers reconciles the foreign servers of the database and
+// stores their status inside the Database object
+func (r *DatabaseReconciler) reconcileServers(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	obj *apiv1.Database,
+) error {
+	if obj.Spec.Ensure == apiv1.EnsureAbsent ||
+		(len(obj.Spec.Servers) == 0 && len(obj.Status.Servers) == 0) {
+		return nil
+	}
+
+	serversStatus, reconcileErr := r.applyServers(ctx, cluster, obj)
+
+	oldDatabase := obj.DeepCopy()
+	obj.Status.Servers = serversStatus
+	if err := r.Client.Status().Patch(ctx, obj, client.MergeFrom(oldDatabase)); err != nil {
+		return fmt.Errorf("while updating the status of the foreign servers: %w", err)
+	}
+
+	return reconcileErr
+}
+
+// applyServers creates, updates, or drops the foreign servers, together
+// with their user mappings, in the order of declaration. A failure
+// doesn't prevent the remaining servers from being reconciled.
+func (r *DatabaseReconciler) applyServers(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	obj *apiv1.Database,
+) ([]apiv1.ServerStatus, error) {
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	existing, err := getDatabaseForeignServers(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := obj.Spec.Servers
+	errs := make([]error, len(servers))
+	serversStatus := make([]apiv1.ServerStatus, len(servers))
+	for idx := range servers {
+		fdw, exists := existing[servers[idx].Name]
+		if servers[idx].Ensure == apiv1.EnsureAbsent {
+			if exists {
+				errs[idx] = dropForeignServer(ctx, db, servers[idx])
+			}
+		} else {
+			errs[idx] = r.applyServer(ctx, db, cluster, servers[idx], fdw, exists)
+		}
+
+		serversStatus[idx] = apiv1.ServerStatus{
+			Name:    servers[idx].Name,
+			Applied: errs[idx] == nil,
+		}
+		if errs[idx] != nil {
+			serversStatus[idx].Message = errs[idx].Error()
+		}
+	}
+
+	return serversStatus, errors.Join(errs...)
+}
+
+// applyServer creates or updates a foreign server and reconciles
+// its user mappings
+func (r *DatabaseReconciler) applyServer(
+	ctx context.Context,
+	db *sql.DB,
+	cluster *apiv1.Cluster,
+	server apiv1.ServerSpec,
+	currentFDW string,
+	exists bool,
+) error {
+	if len(server.FDW) == 0 {
+		server.FDW = "postgres_fdw"
+	}
+
+	var externalCluster *apiv1.ExternalCluster
+	serverOptions := make(map[string]string)
+	if len(server.ExternalClusterName) > 0 {
+		found, ok := cluster.ExternalCluster(server.ExternalClusterName)
+		if !ok {
+			return fmt.Errorf("externalCluster '%s' not declared in cluster %s",
+				server.ExternalClusterName, cluster.Name)
+		}
+		externalCluster = &found
+
+		maps.Copy(serverOptions, externalCluster.ConnectionParameters)
+		delete(serverOptions, "user")
+	}
+	maps.Copy(serverOptions, server.Options)
+
+	switch {
+	case !exists:
+		if err := createForeignServer(ctx, db, server, serverOptions); err != nil {
+			return err
+		}
+	case currentFDW != server.FDW:
+		return fmt.Errorf("foreign server %q uses the foreign data wrapper %q instead of %q",
+			server.Name, currentFDW, server.FDW)
+	default:
+		if err := updateForeignServer(ctx, db, server, serverOptions); err != nil {
+			return err
+		}
+	}
+
+	errs := make([]error, len(server.UserMappings))
+	for idx, mapping := range server.UserMappings {
+		mappingOptions := make(map[string]string)
+		if mapping.UseExternalClusterCredentials && externalCluster != nil {
+			if user, ok := externalCluster.ConnectionParameters["user"]; ok {
+				mappingOptions["user"] = user
+			}
+
+			password, err := external.GetServerPassword(ctx, r.Client, r.instance.GetNamespaceName(), externalCluster)
+			if err != nil {
+				errs[idx] = fmt.Errorf("while reading the password of externalCluster '%s': %w",
+					externalCluster.Name, err)
+				continue
+			}
+			if len(password) > 0 {
+				mappingOptions["password"] = password
+			}
+		}
+		maps.Copy(mappingOptions, mapping.Options)
+
+		errs[idx] = applyUserMapping(ctx, db, server.Name, mapping, mappingOptions)
+	}
+
+	return errors.Join(errs...)
+}
+
+// reconcileGrants applies the privileges of the database and
+// stores their status inside the Database object
+func (r *DatabaseReconciler) reconcileGrants(ctx context.Context, obj *apiv1.Database) error {
+	if obj.Spec.Ensure == apiv1.EnsureAbsent ||
+		(len(obj.Spec.Grants) == 0 && len(obj.Status.Grants) == 0) {
+		return nil
+	}
+
+	grantsStatus, reconcileErr := r.applyGrants(ctx, obj)
+
+	oldDatabase := obj.DeepCopy()
+	obj.Status.Grants = grantsStatus
+	if err := r.Client.Status().Patch(ctx, obj, client.MergeFrom(oldDatabase)); err != nil {
+		return fmt.Errorf("while updating the status of the privileges: %w", err)
+	}
+
+	return reconcileErr
+}
+
+// applyGrants grants, or revokes, the privileges in the order of
+// declaration. A failure doesn't prevent the remaining privileges
+// from being reconciled.
+func (r *DatabaseReconciler) applyGrants(
+	ctx context.Context,
+	obj *apiv1.Database,
+) ([]apiv1.GrantStatus, error) {
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	grants := obj.Spec.Grants
+	errs := make([]error, len(grants))
+	grantsStatus := make([]apiv1.GrantStatus, len(grants))
+	for idx := range grants {
+		errs[idx] = applyDatabaseGrant(ctx, db, obj, grants[idx])
+		grantsStatus[idx] = apiv1.GrantStatus{
+			Name:    grants[idx].Name,
+			Applied: errs[idx] == nil,
+		}
+		if errs[idx] != nil {
+			grantsStatus[idx].Message = errs[idx].Error()
+		}
+	}
+
+	return grantsStatus, errors.Join(errs...)
+}
+
+// reconcileTableAutovacuum applies the autovacuum storage parameters of
+// the tables and stores their status inside the Database object
+func (r *DatabaseReconciler) reconcileTableAutovacuum(ctx context.Context, obj *apiv1.Database) error {
+	if obj.Spec.Ensure == apiv1.EnsureAbsent ||
+		(len(obj.Spec.TableAutovacuum) == 0 && len(obj.Status.TableAutovacuum) == 0) {
+		return nil
+	}
+
+	tablesStatus, reconcileErr := r.applyTableAutovacuum(ctx, obj)
+
+	oldDatabase := obj.DeepCopy()
+	obj.Status.TableAutovacuum = tablesStatus
+	if err := r.Client.Status().Patch(ctx, obj, client.MergeFrom(oldDatabase)); err != nil {
+		return fmt.Errorf("while updating the status of the autovacuum settings: %w", err)
+	}
+
+	return reconcileErr
+}
+
+// applyTableAutovacuum applies the autovacuum storage parameters of the
+// tables. A failure, such as a missing table, doesn't prevent the
+// remaining tables from being reconciled.
+func (r *DatabaseReconciler) applyTableAutovacuum(
+	ctx context.Context,
+	obj *apiv1.Database,
+) ([]apiv1.TableAutovacuumStatus, error) {
+	if len(obj.Spec.TableAutovacuum) == 0 {
+		return nil, nil
+	}
+
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	tables := obj.Spec.TableAutovacuum
+	errs := make([]error, len(tables))
+	tablesStatus := make([]apiv1.TableAutovacuumStatus, len(tables))
+	for idx := range tables {
+		errs[idx] = applyTableAutovacuum(ctx, db, tables[idx])
+		tablesStatus[idx] = apiv1.TableAutovacuumStatus{
+			Name:    tables[idx].Schema + "." + tables[idx].Name,
+			Applied: errs[idx] == nil,
+		}
+		if errs[idx] != nil {
+			tablesStatus[idx].Message = errs[idx].Error()
+		}
 	}
 
-	return createDatabase(ctx, db, obj)
+	return tablesStatus, errors.Join(errs...)
 }
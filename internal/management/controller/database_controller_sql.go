@@ -20,10 +20,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
@@ -213,3 +217,787 @@ func dropDatabase(
 
 	return nil
 }
+
+func setDatabaseSearchPath(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Database,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if len(obj.Spec.SearchPath) == 0 {
+		return nil
+	}
+
+	schemas := make([]string, len(obj.Spec.SearchPath))
+	for idx, schema := range obj.Spec.SearchPath {
+		schemas[idx] = pgx.Identifier{schema}.Sanitize()
+	}
+
+	changeSearchPathSQL := fmt.Sprintf(
+		"ALTER DATABASE %s SET search_path TO %s",
+		pgx.Identifier{obj.Spec.Name}.Sanitize(),
+		strings.Join(schemas, ", "))
+
+	if _, err := db.ExecContext(ctx, changeSearchPathSQL); err != nil {
+		contextLogger.Error(err, "while altering database", "query", changeSearchPathSQL)
+		return fmt.Errorf("while altering database %q search_path: %w", obj.Spec.Name, err)
+	}
+
+	return nil
+}
+
+// schemaInfo is the information about a schema of a database
+type schemaInfo struct {
+	Name    string
+	Owner   string
+	Comment string
+}
+
+func getDatabaseSchemaInfo(ctx context.Context, db *sql.DB) (map[string]schemaInfo, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT n.nspname, pg_catalog.pg_get_userbyid(n.nspowner),
+			COALESCE(pg_catalog.obj_description(n.oid, 'pg_namespace'), '')
+		FROM pg_catalog.pg_namespace n
+		`)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the schemas: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]schemaInfo)
+	for rows.Next() {
+		var schema schemaInfo
+		if err := rows.Scan(&schema.Name, &schema.Owner, &schema.Comment); err != nil {
+			return nil, fmt.Errorf("while scanning the schemas: %w", err)
+		}
+		result[schema.Name] = schema
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("while getting the schemas: %w", err)
+	}
+
+	return result, nil
+}
+
+func createDatabaseSchema(ctx context.Context, db *sql.DB, schema apiv1.SchemaSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	sqlCreateSchema := fmt.Sprintf("CREATE SCHEMA %s", pgx.Identifier{schema.Name}.Sanitize())
+	if len(schema.Owner) > 0 {
+		sqlCreateSchema += fmt.Sprintf(" AUTHORIZATION %s", pgx.Identifier{schema.Owner}.Sanitize())
+	}
+
+	if _, err := db.ExecContext(ctx, sqlCreateSchema); err != nil {
+		contextLogger.Error(err, "while creating schema", "query", sqlCreateSchema)
+		return fmt.Errorf("while creating schema %q: %w", schema.Name, err)
+	}
+
+	if len(schema.Comment) > 0 {
+		return setDatabaseSchemaComment(ctx, db, schema)
+	}
+
+	return nil
+}
+
+func updateDatabaseSchema(
+	ctx context.Context,
+	db *sql.DB,
+	schema apiv1.SchemaSpec,
+	info schemaInfo,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if len(schema.Owner) > 0 && schema.Owner != info.Owner {
+		changeOwnerSQL := fmt.Sprintf(
+			"ALTER SCHEMA %s OWNER TO %s",
+			pgx.Identifier{schema.Name}.Sanitize(),
+			pgx.Identifier{schema.Owner}.Sanitize())
+
+		if _, err := db.ExecContext(ctx, changeOwnerSQL); err != nil {
+			contextLogger.Error(err, "while altering schema", "query", changeOwnerSQL)
+			return fmt.Errorf("while altering schema %q owner to %s: %w",
+				schema.Name, schema.Owner, err)
+		}
+	}
+
+	if schema.Comment != info.Comment {
+		return setDatabaseSchemaComment(ctx, db, schema)
+	}
+
+	return nil
+}
+
+func setDatabaseSchemaComment(ctx context.Context, db *sql.DB, schema apiv1.SchemaSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	comment := "NULL"
+	if len(schema.Comment) > 0 {
+		comment = pq.QuoteLiteral(schema.Comment)
+	}
+
+	query := fmt.Sprintf("COMMENT ON SCHEMA %s IS %s", pgx.Identifier{schema.Name}.Sanitize(), comment)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while setting schema comment", "query", query)
+		return fmt.Errorf("while setting the comment of schema %q: %w", schema.Name, err)
+	}
+
+	return nil
+}
+
+func dropDatabaseSchema(ctx context.Context, db *sql.DB, schema apiv1.SchemaSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	query := fmt.Sprintf("DROP SCHEMA IF EXISTS %s", pgx.Identifier{schema.Name}.Sanitize())
+	if schema.Cascade {
+		query += " CASCADE"
+	}
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while dropping schema", "query", query)
+		return fmt.Errorf("while dropping schema %q: %w", schema.Name, err)
+	}
+
+	return nil
+}
+
+// extInfo is the information about an extension installed in a database
+type extInfo struct {
+	Name    string
+	Version string
+	Schema  string
+}
+
+func getDatabaseExtensionInfo(ctx context.Context, db *sql.DB) (map[string]extInfo, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT e.extname, e.extversion, n.nspname
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON e.extnamespace = n.oid
+		`)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the installed extensions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]extInfo)
+	for rows.Next() {
+		var extension extInfo
+		if err := rows.Scan(&extension.Name, &extension.Version, &extension.Schema); err != nil {
+			return nil, fmt.Errorf("while scanning the installed extensions: %w", err)
+		}
+		result[extension.Name] = extension
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("while getting the installed extensions: %w", err)
+	}
+
+	return result, nil
+}
+
+func createDatabaseExtension(ctx context.Context, db *sql.DB, extension apiv1.ExtensionSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	var sqlCreateExtension strings.Builder
+	sqlCreateExtension.WriteString(fmt.Sprintf("CREATE EXTENSION %s", pgx.Identifier{extension.Name}.Sanitize()))
+	if len(extension.Schema) > 0 {
+		sqlCreateExtension.WriteString(fmt.Sprintf(" SCHEMA %s", pgx.Identifier{extension.Schema}.Sanitize()))
+	}
+	if len(extension.Version) > 0 {
+		sqlCreateExtension.WriteString(fmt.Sprintf(" VERSION %s", pgx.Identifier{extension.Version}.Sanitize()))
+	}
+	if extension.Cascade {
+		sqlCreateExtension.WriteString(" CASCADE")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlCreateExtension.String()); err != nil {
+		contextLogger.Error(err, "while creating extension", "query", sqlCreateExtension.String())
+		return fmt.Errorf("while creating extension %q: %w", extension.Name, err)
+	}
+
+	return nil
+}
+
+func updateDatabaseExtension(
+	ctx context.Context,
+	db *sql.DB,
+	extension apiv1.ExtensionSpec,
+	info extInfo,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if len(extension.Schema) > 0 && extension.Schema != info.Schema {
+		changeSchemaSQL := fmt.Sprintf(
+			"ALTER EXTENSION %s SET SCHEMA %s",
+			pgx.Identifier{extension.Name}.Sanitize(),
+			pgx.Identifier{extension.Schema}.Sanitize())
+
+		if _, err := db.ExecContext(ctx, changeSchemaSQL); err != nil {
+			contextLogger.Error(err, "while altering extension", "query", changeSchemaSQL)
+			return fmt.Errorf("while altering extension %q schema to %s: %w",
+				extension.Name, extension.Schema, err)
+		}
+	}
+
+	if len(extension.Version) > 0 && extension.Version != info.Version {
+		updateVersionSQL := fmt.Sprintf(
+			"ALTER EXTENSION %s UPDATE TO %s",
+			pgx.Identifier{extension.Name}.Sanitize(),
+			pgx.Identifier{extension.Version}.Sanitize())
+
+		if _, err := db.ExecContext(ctx, updateVersionSQL); err != nil {
+			contextLogger.Error(err, "while altering extension", "query", updateVersionSQL)
+			return fmt.Errorf("while updating extension %q to version %s: %w",
+				extension.Name, extension.Version, err)
+		}
+	}
+
+	return nil
+}
+
+func dropDatabaseExtension(ctx context.Context, db *sql.DB, extension apiv1.ExtensionSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	query := fmt.Sprintf("DROP EXTENSION IF EXISTS %s", pgx.Identifier{extension.Name}.Sanitize())
+	if extension.Cascade {
+		query += " CASCADE"
+	}
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while dropping extension", "query", query)
+		return fmt.Errorf("while dropping extension %q: %w", extension.Name, err)
+	}
+
+	return nil
+}
+
+// grantablePrivileges is the list of privileges that can be
+// embedded in a GRANT or REVOKE statement
+var grantablePrivileges = []string{
+	"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES",
+	"TRIGGER", "USAGE", "CREATE", "CONNECT", "TEMPORARY",
+}
+
+// getGrantPrivileges returns the comma-separated list of the
+// privileges of a grant, refusing the unknown ones
+func getGrantPrivileges(grant apiv1.GrantSpec) (string, error) {
+	privileges := make([]string, len(grant.Privileges))
+	for idx, privilege := range grant.Privileges {
+		privilege = strings.ToUpper(privilege)
+		if !slices.Contains(grantablePrivileges, privilege) {
+			return "", fmt.Errorf("unknown privilege %q", grant.Privileges[idx])
+		}
+		privileges[idx] = privilege
+	}
+
+	return strings.Join(privileges, ", "), nil
+}
+
+// getGrantSchema returns the schema the grant refers to
+func getGrantSchema(grant apiv1.GrantSpec) string {
+	if len(grant.Schema) > 0 {
+		return grant.Schema
+	}
+	return "public"
+}
+
+// getGrantTarget returns the objects part of a GRANT or REVOKE statement
+func getGrantTarget(dbname string, grant apiv1.GrantSpec) (string, error) {
+	schema := getGrantSchema(grant)
+
+	switch grant.ObjectType {
+	case apiv1.GrantObjectTypeDatabase:
+		return fmt.Sprintf("DATABASE %s", pgx.Identifier{dbname}.Sanitize()), nil
+
+	case apiv1.GrantObjectTypeSchema:
+		return fmt.Sprintf("SCHEMA %s", pgx.Identifier{schema}.Sanitize()), nil
+
+	case apiv1.GrantObjectTypeTable, apiv1.GrantObjectTypeSequence:
+		keyword := strings.ToUpper(string(grant.ObjectType))
+		if len(grant.Objects) == 0 {
+			return fmt.Sprintf("ALL %sS IN SCHEMA %s", keyword, pgx.Identifier{schema}.Sanitize()), nil
+		}
+
+		objects := make([]string, len(grant.Objects))
+		for idx, object := range grant.Objects {
+			objects[idx] = pgx.Identifier{schema, object}.Sanitize()
+		}
+		return fmt.Sprintf("%s %s", keyword, strings.Join(objects, ", ")), nil
+
+	default:
+		return "", fmt.Errorf("unknown object type %q", grant.ObjectType)
+	}
+}
+
+// applyDatabaseGrant grants, or revokes, the privileges described
+// by the grant. The statements are idempotent, so they can be
+// executed at every reconciliation loop
+func applyDatabaseGrant(ctx context.Context, db *sql.DB, obj *apiv1.Database, grant apiv1.GrantSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	privileges, err := getGrantPrivileges(grant)
+	if err != nil {
+		return err
+	}
+
+	target, err := getGrantTarget(obj.Spec.Name, grant)
+	if err != nil {
+		return err
+	}
+
+	role := pgx.Identifier{grant.Role}.Sanitize()
+	queries := make([]string, 0, 2)
+	if grant.Ensure == apiv1.EnsureAbsent {
+		queries = append(queries, fmt.Sprintf("REVOKE %s ON %s FROM %s", privileges, target, role))
+	} else {
+		queries = append(queries, fmt.Sprintf("GRANT %s ON %s TO %s", privileges, target, role))
+	}
+
+	if grant.DefaultPrivileges {
+		var query strings.Builder
+		query.WriteString("ALTER DEFAULT PRIVILEGES")
+		if len(obj.Spec.Owner) > 0 {
+			query.WriteString(fmt.Sprintf(" FOR ROLE %s", pgx.Identifier{obj.Spec.Owner}.Sanitize()))
+		}
+		query.WriteString(fmt.Sprintf(" IN SCHEMA %s", pgx.Identifier{getGrantSchema(grant)}.Sanitize()))
+
+		objects := strings.ToUpper(string(grant.ObjectType)) + "S"
+		if grant.Ensure == apiv1.EnsureAbsent {
+			query.WriteString(fmt.Sprintf(" REVOKE %s ON %s FROM %s", privileges, objects, role))
+		} else {
+			query.WriteString(fmt.Sprintf(" GRANT %s ON %s TO %s", privileges, objects, role))
+		}
+		queries = append(queries, query.String())
+	}
+
+	for _, query := range queries {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			contextLogger.Error(err, "while applying privileges", "query", query)
+			return fmt.Errorf("while applying privileges %q: %w", grant.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getDatabaseForeignServers returns the foreign data wrapper of
+// every foreign server of the database
+func getDatabaseForeignServers(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT s.srvname, w.fdwname
+		FROM pg_catalog.pg_foreign_server s
+		JOIN pg_catalog.pg_foreign_data_wrapper w ON s.srvfdw = w.oid
+		`)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the foreign servers: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var name, fdw string
+		if err := rows.Scan(&name, &fdw); err != nil {
+			return nil, fmt.Errorf("while scanning the foreign servers: %w", err)
+		}
+		result[name] = fdw
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("while getting the foreign servers: %w", err)
+	}
+
+	return result, nil
+}
+
+// getForeignServerOptions returns the options of a foreign server
+func getForeignServerOptions(ctx context.Context, db *sql.DB, serverName string) (map[string]string, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT o.option_name, o.option_value
+		FROM pg_catalog.pg_foreign_server s,
+			pg_catalog.pg_options_to_table(s.srvoptions) o
+		WHERE s.srvname = $1
+		`,
+		serverName)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the options of foreign server %q: %w", serverName, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, fmt.Errorf("while scanning the options of foreign server %q: %w", serverName, err)
+		}
+		result[name] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("while getting the options of foreign server %q: %w", serverName, err)
+	}
+
+	return result, nil
+}
+
+// getUserMappingOptions returns the options of the user mapping of a
+// role for a foreign server, and whether the user mapping exists
+func getUserMappingOptions(
+	ctx context.Context,
+	db *sql.DB,
+	serverName string,
+	role string,
+) (map[string]string, bool, error) {
+	if strings.EqualFold(role, "public") {
+		role = "public"
+	}
+
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT o.option_name, o.option_value
+		FROM pg_catalog.pg_user_mappings um
+		LEFT JOIN LATERAL pg_catalog.pg_options_to_table(um.umoptions) o ON true
+		WHERE um.srvname = $1 AND um.usename = $2
+		`,
+		serverName, role)
+	if err != nil {
+		return nil, false, fmt.Errorf("while getting the user mapping of %q for foreign server %q: %w",
+			role, serverName, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	exists := false
+	result := make(map[string]string)
+	for rows.Next() {
+		var name, value sql.NullString
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, false, fmt.Errorf("while scanning the user mapping of %q for foreign server %q: %w",
+				role, serverName, err)
+		}
+		exists = true
+		if name.Valid {
+			result[name.String] = value.String
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("while getting the user mapping of %q for foreign server %q: %w",
+			role, serverName, err)
+	}
+
+	return result, exists, nil
+}
+
+// getCreateOptionsClause returns the OPTIONS clause used to
+// create an object with the passed options
+func getCreateOptionsClause(options map[string]string) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	items := make([]string, 0, len(options))
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		items = append(items, fmt.Sprintf("%s %s", pgx.Identifier{key}.Sanitize(), pq.QuoteLiteral(options[key])))
+	}
+
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(items, ", "))
+}
+
+// getOptionsClause returns the OPTIONS clause bringing the current
+// options to the desired ones. The current options that are not
+// desired are left untouched
+func getOptionsClause(desired, current map[string]string) string {
+	actions := make([]string, 0, len(desired))
+	for _, key := range slices.Sorted(maps.Keys(desired)) {
+		currentValue, ok := current[key]
+		switch {
+		case !ok:
+			actions = append(actions, fmt.Sprintf("ADD %s %s",
+				pgx.Identifier{key}.Sanitize(), pq.QuoteLiteral(desired[key])))
+		case currentValue != desired[key]:
+			actions = append(actions, fmt.Sprintf("SET %s %s",
+				pgx.Identifier{key}.Sanitize(), pq.QuoteLiteral(desired[key])))
+		}
+	}
+
+	if len(actions) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(actions, ", "))
+}
+
+func createForeignServer(
+	ctx context.Context,
+	db *sql.DB,
+	server apiv1.ServerSpec,
+	options map[string]string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	query := fmt.Sprintf(
+		"CREATE SERVER %s FOREIGN DATA WRAPPER %s",
+		pgx.Identifier{server.Name}.Sanitize(),
+		pgx.Identifier{server.FDW}.Sanitize())
+	if optionsClause := getCreateOptionsClause(options); len(optionsClause) > 0 {
+		query += " " + optionsClause
+	}
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while creating foreign server", "query", query)
+		return fmt.Errorf("while creating foreign server %q: %w", server.Name, err)
+	}
+
+	return nil
+}
+
+func updateForeignServer(
+	ctx context.Context,
+	db *sql.DB,
+	server apiv1.ServerSpec,
+	options map[string]string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	currentOptions, err := getForeignServerOptions(ctx, db, server.Name)
+	if err != nil {
+		return err
+	}
+
+	optionsClause := getOptionsClause(options, currentOptions)
+	if len(optionsClause) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER SERVER %s %s", pgx.Identifier{server.Name}.Sanitize(), optionsClause)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while altering foreign server", "query", query)
+		return fmt.Errorf("while altering foreign server %q: %w", server.Name, err)
+	}
+
+	return nil
+}
+
+func dropForeignServer(ctx context.Context, db *sql.DB, server apiv1.ServerSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	query := fmt.Sprintf("DROP SERVER IF EXISTS %s", pgx.Identifier{server.Name}.Sanitize())
+	if server.Cascade {
+		query += " CASCADE"
+	}
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while dropping foreign server", "query", query)
+		return fmt.Errorf("while dropping foreign server %q: %w", server.Name, err)
+	}
+
+	return nil
+}
+
+// getUserMappingRole returns the role of a user mapping as
+// used in the SQL commands
+func getUserMappingRole(role string) string {
+	if strings.EqualFold(role, "public") {
+		return "PUBLIC"
+	}
+	return pgx.Identifier{role}.Sanitize()
+}
+
+// applyUserMapping creates, updates, or drops the user mapping
+// of a role for a foreign server
+func applyUserMapping(
+	ctx context.Context,
+	db *sql.DB,
+	serverName string,
+	mapping apiv1.UserMappingSpec,
+	options map[string]string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	currentOptions, exists, err := getUserMappingOptions(ctx, db, serverName, mapping.Role)
+	if err != nil {
+		return err
+	}
+
+	var query string
+	switch {
+	case mapping.Ensure == apiv1.EnsureAbsent && !exists:
+		return nil
+
+	case mapping.Ensure == apiv1.EnsureAbsent:
+		query = fmt.Sprintf(
+			"DROP USER MAPPING IF EXISTS FOR %s SERVER %s",
+			getUserMappingRole(mapping.Role),
+			pgx.Identifier{serverName}.Sanitize())
+
+	case !exists:
+		query = fmt.Sprintf(
+			"CREATE USER MAPPING FOR %s SERVER %s",
+			getUserMappingRole(mapping.Role),
+			pgx.Identifier{serverName}.Sanitize())
+		if optionsClause := getCreateOptionsClause(options); len(optionsClause) > 0 {
+			query += " " + optionsClause
+		}
+
+	default:
+		optionsClause := getOptionsClause(options, currentOptions)
+		if len(optionsClause) == 0 {
+			return nil
+		}
+		query = fmt.Sprintf(
+			"ALTER USER MAPPING FOR %s SERVER %s %s",
+			getUserMappingRole(mapping.Role),
+			pgx.Identifier{serverName}.Sanitize(),
+			optionsClause)
+	}
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		// The query may contain a password, so it is not logged
+		contextLogger.Error(err, "while reconciling user mapping",
+			"serverName", serverName, "role", mapping.Role)
+		return fmt.Errorf("while reconciling the user mapping of %q for foreign server %q: %w",
+			mapping.Role, serverName, err)
+	}
+
+	return nil
+}
+
+// tableAutovacuumParameterRegex matches the storage parameters managed
+// through the autovacuum settings of the tables
+var tableAutovacuumParameterRegex = regexp.MustCompile(
+	`^(toast\.)?(autovacuum_[a-z_]+|log_autovacuum_min_duration|vacuum_index_cleanup|vacuum_truncate)$`)
+
+// getTableStorageParameters returns the storage parameters of a table,
+// including the ones of its TOAST table prefixed with `toast.`, and
+// whether the table exists
+func getTableStorageParameters(
+	ctx context.Context,
+	db *sql.DB,
+	table apiv1.TableAutovacuumSpec,
+) (map[string]string, bool, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT o.option_name, o.option_value
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_catalog.pg_class t ON t.oid = c.reltoastrelid
+		LEFT JOIN LATERAL (
+			SELECT option_name, option_value
+			FROM pg_catalog.pg_options_to_table(c.reloptions)
+			UNION ALL
+			SELECT 'toast.' || option_name, option_value
+			FROM pg_catalog.pg_options_to_table(t.reloptions)
+		) o ON true
+		WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'm')
+		`,
+		table.Schema, table.Name)
+	if err != nil {
+		return nil, false, fmt.Errorf("while getting the storage parameters of table %q.%q: %w",
+			table.Schema, table.Name, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	exists := false
+	result := make(map[string]string)
+	for rows.Next() {
+		var name, value sql.NullString
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, false, fmt.Errorf("while scanning the storage parameters of table %q.%q: %w",
+				table.Schema, table.Name, err)
+		}
+		exists = true
+		if name.Valid {
+			result[name.String] = value.String
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("while getting the storage parameters of table %q.%q: %w",
+			table.Schema, table.Name, err)
+	}
+
+	return result, exists, nil
+}
+
+// getTableAutovacuumActions returns the SET and RESET lists bringing the
+// current autovacuum storage parameters of a table to the desired ones.
+// The current autovacuum parameters that are not desired are reset,
+// while the other storage parameters are left untouched
+func getTableAutovacuumActions(desired, current map[string]string) (set []string, reset []string) {
+	for _, key := range slices.Sorted(maps.Keys(desired)) {
+		if currentValue, ok := current[key]; !ok || currentValue != desired[key] {
+			set = append(set, fmt.Sprintf("%s = %s", key, pq.QuoteLiteral(desired[key])))
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := desired[key]; !ok && tableAutovacuumParameterRegex.MatchString(key) {
+			reset = append(reset, key)
+		}
+	}
+
+	return set, reset
+}
+
+func applyTableAutovacuum(ctx context.Context, db *sql.DB, table apiv1.TableAutovacuumSpec) error {
+	contextLogger := log.FromContext(ctx)
+
+	for key := range table.Parameters {
+		if !tableAutovacuumParameterRegex.MatchString(key) {
+			return fmt.Errorf("%q is not an autovacuum storage parameter", key)
+		}
+	}
+
+	currentParameters, exists, err := getTableStorageParameters(ctx, db, table)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("table %q.%q does not exist", table.Schema, table.Name)
+	}
+
+	set, reset := getTableAutovacuumActions(table.Parameters, currentParameters)
+	actions := make([]string, 0, 2)
+	if len(set) > 0 {
+		actions = append(actions, fmt.Sprintf("SET (%s)", strings.Join(set, ", ")))
+	}
+	if len(reset) > 0 {
+		actions = append(actions, fmt.Sprintf("RESET (%s)", strings.Join(reset, ", ")))
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s %s",
+		pgx.Identifier{table.Schema, table.Name}.Sanitize(),
+		strings.Join(actions, ", "))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while altering the storage parameters of the table", "query", query)
+		return fmt.Errorf("while altering the storage parameters of table %q.%q: %w",
+			table.Schema, table.Name, err)
+	}
+
+	return nil
+}
@@ -222,4 +222,291 @@ var _ = Describe("Managed Database SQL", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("schemas", func() {
+		It("should not alter a schema that is already up to date", func(ctx SpecContext) {
+			schema := apiv1.SchemaSpec{
+				Name:    "app",
+				Owner:   "app",
+				Comment: "application data",
+			}
+			Expect(updateDatabaseSchema(ctx, db, schema, schemaInfo{
+				Name:    "app",
+				Owner:   "app",
+				Comment: "application data",
+			})).To(Succeed())
+		})
+
+		It("should remove the comment of a schema", func(ctx SpecContext) {
+			schema := apiv1.SchemaSpec{Name: "app"}
+			dbMock.ExpectExec(`COMMENT ON SCHEMA "app" IS NULL`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(updateDatabaseSchema(ctx, db, schema, schemaInfo{
+				Name:    "app",
+				Owner:   "app",
+				Comment: "application data",
+			})).To(Succeed())
+		})
+
+		It("should drop a schema with cascade", func(ctx SpecContext) {
+			schema := apiv1.SchemaSpec{
+				Name:    "app",
+				Cascade: true,
+			}
+			dbMock.ExpectExec(`DROP SCHEMA IF EXISTS "app" CASCADE`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(dropDatabaseSchema(ctx, db, schema)).To(Succeed())
+		})
+
+		It("should not change the search path when it is not set", func(ctx SpecContext) {
+			Expect(setDatabaseSearchPath(ctx, db, database)).To(Succeed())
+		})
+	})
+
+	Context("extensions", func() {
+		It("should get the installed extensions", func(ctx SpecContext) {
+			expectedValue := sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+				AddRow("plpgsql", "1.0", "pg_catalog").
+				AddRow("postgis", "3.4.2", "public")
+			dbMock.ExpectQuery(detectDatabaseExtensionsQuery).WillReturnRows(expectedValue)
+
+			extensions, err := getDatabaseExtensionInfo(ctx, db)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extensions).To(Equal(map[string]extInfo{
+				"plpgsql": {Name: "plpgsql", Version: "1.0", Schema: "pg_catalog"},
+				"postgis": {Name: "postgis", Version: "3.4.2", Schema: "public"},
+			}))
+		})
+
+		It("should create an extension with all the options", func(ctx SpecContext) {
+			extension := apiv1.ExtensionSpec{
+				Name:    "postgis_topology",
+				Schema:  "topology",
+				Version: "3.4.2",
+				Cascade: true,
+			}
+			expectedQuery := fmt.Sprintf(
+				"CREATE EXTENSION %s SCHEMA %s VERSION %s CASCADE",
+				pgx.Identifier{extension.Name}.Sanitize(),
+				pgx.Identifier{extension.Schema}.Sanitize(),
+				pgx.Identifier{extension.Version}.Sanitize(),
+			)
+			dbMock.ExpectExec(expectedQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(createDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+
+		It("should update the version and the schema of an extension", func(ctx SpecContext) {
+			extension := apiv1.ExtensionSpec{
+				Name:    "postgis",
+				Schema:  "gis",
+				Version: "3.5.0",
+			}
+			dbMock.ExpectExec(fmt.Sprintf(
+				"ALTER EXTENSION %s SET SCHEMA %s",
+				pgx.Identifier{extension.Name}.Sanitize(),
+				pgx.Identifier{extension.Schema}.Sanitize(),
+			)).WillReturnResult(sqlmock.NewResult(0, 1))
+			dbMock.ExpectExec(fmt.Sprintf(
+				"ALTER EXTENSION %s UPDATE TO %s",
+				pgx.Identifier{extension.Name}.Sanitize(),
+				pgx.Identifier{extension.Version}.Sanitize(),
+			)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(updateDatabaseExtension(ctx, db, extension, extInfo{
+				Name:    "postgis",
+				Version: "3.4.2",
+				Schema:  "public",
+			})).To(Succeed())
+		})
+
+		It("should not alter an extension that is already up to date", func(ctx SpecContext) {
+			extension := apiv1.ExtensionSpec{
+				Name:    "postgis",
+				Version: "3.4.2",
+			}
+			Expect(updateDatabaseExtension(ctx, db, extension, extInfo{
+				Name:    "postgis",
+				Version: "3.4.2",
+				Schema:  "public",
+			})).To(Succeed())
+		})
+
+		It("should drop an extension with cascade", func(ctx SpecContext) {
+			extension := apiv1.ExtensionSpec{
+				Name:    "postgis",
+				Cascade: true,
+			}
+			dbMock.ExpectExec(fmt.Sprintf(
+				"DROP EXTENSION IF EXISTS %s CASCADE",
+				pgx.Identifier{extension.Name}.Sanitize(),
+			)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(dropDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+	})
+
+	Context("foreign servers", func() {
+		It("should only add or set the options that changed", func() {
+			Expect(getOptionsClause(
+				map[string]string{"host": "remote", "port": "5432", "dbname": "app"},
+				map[string]string{"host": "old", "port": "5432", "sslmode": "require"},
+			)).To(Equal(`OPTIONS (ADD "dbname" 'app', SET "host" 'remote')`))
+			Expect(getOptionsClause(
+				map[string]string{"port": "5432"},
+				map[string]string{"port": "5432"},
+			)).To(BeEmpty())
+		})
+
+		It("should update the password of an existing user mapping", func(ctx SpecContext) {
+			dbMock.ExpectQuery(`SELECT o.option_name, o.option_value
+				FROM pg_catalog.pg_user_mappings um
+				LEFT JOIN LATERAL pg_catalog.pg_options_to_table(um.umoptions) o ON true
+				WHERE um.srvname = $1 AND um.usename = $2`).
+				WithArgs("remote", "public").
+				WillReturnRows(sqlmock.NewRows([]string{"option_name", "option_value"}).
+					AddRow("user", "reader").
+					AddRow("password", "old"))
+			dbMock.ExpectExec(`ALTER USER MAPPING FOR PUBLIC SERVER "remote" OPTIONS (SET "password" 'new')`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(applyUserMapping(ctx, db, "remote", apiv1.UserMappingSpec{Role: "PUBLIC"},
+				map[string]string{"user": "reader", "password": "new"})).To(Succeed())
+		})
+
+		It("should drop a foreign server with cascade", func(ctx SpecContext) {
+			dbMock.ExpectExec(`DROP SERVER IF EXISTS "remote" CASCADE`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(dropForeignServer(ctx, db, apiv1.ServerSpec{Name: "remote", Cascade: true})).To(Succeed())
+		})
+	})
+
+	Context("grants", func() {
+		It("should grant privileges on the database", func(ctx SpecContext) {
+			grant := apiv1.GrantSpec{
+				Name:       "connect",
+				Role:       "reader",
+				ObjectType: apiv1.GrantObjectTypeDatabase,
+				Privileges: []string{"CONNECT", "TEMPORARY"},
+			}
+			dbMock.ExpectExec(fmt.Sprintf(
+				"GRANT CONNECT, TEMPORARY ON DATABASE %s TO %s",
+				pgx.Identifier{database.Spec.Name}.Sanitize(),
+				pgx.Identifier{grant.Role}.Sanitize(),
+			)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(applyDatabaseGrant(ctx, db, database, grant)).To(Succeed())
+		})
+
+		It("should grant privileges on all the tables of the schema, including the future ones",
+			func(ctx SpecContext) {
+				grant := apiv1.GrantSpec{
+					Name:              "read",
+					Role:              "reader",
+					ObjectType:        apiv1.GrantObjectTypeTable,
+					Privileges:        []string{"SELECT"},
+					DefaultPrivileges: true,
+				}
+				dbMock.ExpectExec(`GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "reader"`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectExec(fmt.Sprintf(
+					`ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA "public" GRANT SELECT ON TABLES TO "reader"`,
+					pgx.Identifier{database.Spec.Owner}.Sanitize(),
+				)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				Expect(applyDatabaseGrant(ctx, db, database, grant)).To(Succeed())
+			})
+
+		It("should revoke privileges on a list of sequences", func(ctx SpecContext) {
+			grant := apiv1.GrantSpec{
+				Name:       "sequences",
+				Ensure:     apiv1.EnsureAbsent,
+				Role:       "writer",
+				ObjectType: apiv1.GrantObjectTypeSequence,
+				Schema:     "app",
+				Objects:    []string{"seq_one", "seq_two"},
+				Privileges: []string{"usage"},
+			}
+			dbMock.ExpectExec(`REVOKE USAGE ON SEQUENCE "app"."seq_one", "app"."seq_two" FROM "writer"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(applyDatabaseGrant(ctx, db, database, grant)).To(Succeed())
+		})
+
+		It("should refuse unknown privileges", func(ctx SpecContext) {
+			grant := apiv1.GrantSpec{
+				Name:       "invalid",
+				Role:       "reader",
+				ObjectType: apiv1.GrantObjectTypeSchema,
+				Privileges: []string{"USAGE; DROP TABLE users"},
+			}
+
+			Expect(applyDatabaseGrant(ctx, db, database, grant)).To(MatchError(ContainSubstring("unknown privilege")))
+		})
+	})
+
+	Context("table autovacuum", func() {
+		const storageParametersQuery = `SELECT o.option_name, o.option_value
+			FROM pg_catalog.pg_class c
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			LEFT JOIN pg_catalog.pg_class t ON t.oid = c.reltoastrelid
+			LEFT JOIN LATERAL (
+				SELECT option_name, option_value
+				FROM pg_catalog.pg_options_to_table(c.reloptions)
+				UNION ALL
+				SELECT 'toast.' || option_name, option_value
+				FROM pg_catalog.pg_options_to_table(t.reloptions)
+			) o ON true
+			WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'm')`
+
+		table := apiv1.TableAutovacuumSpec{
+			Schema: "public",
+			Name:   "orders",
+			Parameters: map[string]string{
+				"autovacuum_vacuum_scale_factor": "0.01",
+				"toast.autovacuum_enabled":       "true",
+			},
+		}
+
+		It("should set the changed parameters and reset the undeclared ones", func(ctx SpecContext) {
+			dbMock.ExpectQuery(storageParametersQuery).
+				WithArgs("public", "orders").
+				WillReturnRows(sqlmock.NewRows([]string{"option_name", "option_value"}).
+					AddRow("autovacuum_vacuum_scale_factor", "0.2").
+					AddRow("autovacuum_analyze_threshold", "100").
+					AddRow("fillfactor", "90").
+					AddRow("toast.autovacuum_enabled", "true"))
+			dbMock.ExpectExec(`ALTER TABLE "public"."orders" SET (autovacuum_vacuum_scale_factor = '0.01'), ` +
+				`RESET (autovacuum_analyze_threshold)`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(applyTableAutovacuum(ctx, db, table)).To(Succeed())
+		})
+
+		It("shouldn't alter a table which is already configured", func(ctx SpecContext) {
+			dbMock.ExpectQuery(storageParametersQuery).
+				WithArgs("public", "orders").
+				WillReturnRows(sqlmock.NewRows([]string{"option_name", "option_value"}).
+					AddRow("autovacuum_vacuum_scale_factor", "0.01").
+					AddRow("toast.autovacuum_enabled", "true"))
+
+			Expect(applyTableAutovacuum(ctx, db, table)).To(Succeed())
+		})
+
+		It("should fail when the table doesn't exist", func(ctx SpecContext) {
+			dbMock.ExpectQuery(storageParametersQuery).
+				WithArgs("public", "orders").
+				WillReturnRows(sqlmock.NewRows([]string{"option_name", "option_value"}))
+
+			Expect(applyTableAutovacuum(ctx, db, table)).To(MatchError(ContainSubstring("does not exist")))
+		})
+
+		It("should refuse the parameters not related to autovacuum", func(ctx SpecContext) {
+			Expect(applyTableAutovacuum(ctx, db, apiv1.TableAutovacuumSpec{
+				Schema:     "public",
+				Name:       "orders",
+				Parameters: map[string]string{"fillfactor": "50"},
+			})).To(MatchError(ContainSubstring("not an autovacuum storage parameter")))
+		})
+	})
 })
@@ -45,6 +45,23 @@ const databaseDetectionQuery = `SELECT count(*)
 			FROM pg_database
 			WHERE datname = $1`
 
+const detectDatabaseExtensionsQuery = `SELECT e.extname, e.extversion, n.nspname
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON e.extnamespace = n.oid`
+
+const detectDatabaseSchemasQuery = `SELECT n.nspname, pg_catalog.pg_get_userbyid(n.nspowner),
+			COALESCE(pg_catalog.obj_description(n.oid, 'pg_namespace'), '')
+		FROM pg_catalog.pg_namespace n`
+
+const detectDatabaseServersQuery = `SELECT s.srvname, w.fdwname
+		FROM pg_catalog.pg_foreign_server s
+		JOIN pg_catalog.pg_foreign_data_wrapper w ON s.srvfdw = w.oid`
+
+const detectUserMappingQuery = `SELECT o.option_name, o.option_value
+		FROM pg_catalog.pg_user_mappings um
+		LEFT JOIN LATERAL pg_catalog.pg_options_to_table(um.umoptions) o ON true
+		WHERE um.srvname = $1 AND um.usename = $2`
+
 var _ = Describe("Managed Database status", func() {
 	var (
 		dbMock     sqlmock.Sqlmock
@@ -103,6 +120,9 @@ var _ = Describe("Managed Database status", func() {
 			getSuperUserDB: func() (*sql.DB, error) {
 				return db, nil
 			},
+			getTargetDB: func(string) (*sql.DB, error) {
+				return db, nil
+			},
 		}
 		r.finalizerReconciler = newFinalizerReconciler(
 			fakeClient,
@@ -258,6 +278,9 @@ var _ = Describe("Managed Database status", func() {
 			getSuperUserDB: func() (*sql.DB, error) {
 				return db, nil
 			},
+			getTargetDB: func(string) (*sql.DB, error) {
+				return db, nil
+			},
 		}
 
 		// Updating the Database object to reference the newly created Cluster
@@ -320,6 +343,191 @@ var _ = Describe("Managed Database status", func() {
 		Expect(database.Status.ObservedGeneration).To(BeEquivalentTo(1))
 	})
 
+	It("reconciles the extensions following their order", func(ctx SpecContext) {
+		database.Spec.Extensions = []apiv1.ExtensionSpec{
+			{Name: "postgis", Ensure: apiv1.EnsurePresent},
+			{Name: "postgis_topology", Ensure: apiv1.EnsurePresent},
+			{Name: "pg_trgm", Ensure: apiv1.EnsureAbsent},
+			{Name: "hstore", Ensure: apiv1.EnsureAbsent},
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectDatabaseExtensionsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+				AddRow("hstore", "1.8", "public").
+				AddRow("pg_trgm", "1.6", "public"))
+		dbMock.ExpectExec(`DROP EXTENSION IF EXISTS "hstore"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`DROP EXTENSION IF EXISTS "pg_trgm"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`CREATE EXTENSION "postgis"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`CREATE EXTENSION "postgis_topology"`).
+			WillReturnError(fmt.Errorf("extension not available"))
+		dbMock.ExpectQuery(detectDatabaseExtensionsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+				AddRow("postgis", "3.4.2", "public"))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(database.Status.Message).To(ContainSubstring("extension not available"))
+		Expect(database.Status.Extensions).To(HaveLen(4))
+		Expect(database.Status.Extensions[0]).To(Equal(apiv1.ExtensionStatus{
+			Name:    "postgis",
+			Applied: true,
+			Version: "3.4.2",
+		}))
+		Expect(database.Status.Extensions[1].Applied).To(BeFalse())
+		Expect(database.Status.Extensions[1].Message).To(ContainSubstring("extension not available"))
+		Expect(database.Status.Extensions[2]).To(Equal(apiv1.ExtensionStatus{Name: "pg_trgm", Applied: true}))
+		Expect(database.Status.Extensions[3]).To(Equal(apiv1.ExtensionStatus{Name: "hstore", Applied: true}))
+	})
+
+	It("reconciles the search path and the schemas", func(ctx SpecContext) {
+		database.Spec.SearchPath = []string{"$user", "app"}
+		database.Spec.Schemas = []apiv1.SchemaSpec{
+			{Name: "app", Comment: "application data"},
+			{Name: "audit", Owner: "auditor"},
+			{Name: "legacy", Ensure: apiv1.EnsureAbsent},
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(fmt.Sprintf(`ALTER DATABASE %s SET search_path TO "$user", "app"`,
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectDatabaseSchemasQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"nspname", "owner", "comment"}).
+				AddRow("public", "pg_database_owner", "standard public schema").
+				AddRow("audit", "app", "").
+				AddRow("legacy", "app", ""))
+		dbMock.ExpectExec(`CREATE SCHEMA "app" AUTHORIZATION "app"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`COMMENT ON SCHEMA "app" IS 'application data'`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`ALTER SCHEMA "audit" OWNER TO "auditor"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`DROP SCHEMA IF EXISTS "legacy"`).
+			WillReturnError(fmt.Errorf("cannot drop schema legacy because other objects depend on it"))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(database.Status.Message).To(ContainSubstring("other objects depend on it"))
+		Expect(database.Status.Schemas).To(HaveLen(3))
+		Expect(database.Status.Schemas[0]).To(Equal(apiv1.SchemaStatus{Name: "app", Applied: true}))
+		Expect(database.Status.Schemas[1]).To(Equal(apiv1.SchemaStatus{Name: "audit", Applied: true}))
+		Expect(database.Status.Schemas[2].Applied).To(BeFalse())
+	})
+
+	It("creates the foreign servers using the credentials of the external clusters", func(ctx SpecContext) {
+		cluster.Spec.ExternalClusters = []apiv1.ExternalCluster{
+			{
+				Name: "cluster-remote",
+				ConnectionParameters: map[string]string{
+					"host":   "cluster-remote-rw",
+					"dbname": "app",
+					"user":   "reader",
+				},
+				Password: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "cluster-remote-reader"},
+					Key:                  "password",
+				},
+			},
+		}
+		Expect(fakeClient.Update(ctx, cluster)).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-remote-reader",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"password": []byte("s3cr3t"),
+			},
+		})).To(Succeed())
+
+		database.Spec.Servers = []apiv1.ServerSpec{
+			{
+				Name:                "remote",
+				ExternalClusterName: "cluster-remote",
+				Options:             map[string]string{"fetch_size": "1000"},
+				UserMappings: []apiv1.UserMappingSpec{
+					{Role: "app", UseExternalClusterCredentials: true},
+				},
+			},
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectDatabaseServersQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"srvname", "fdwname"}))
+		dbMock.ExpectExec(`CREATE SERVER "remote" FOREIGN DATA WRAPPER "postgres_fdw" ` +
+			`OPTIONS ("dbname" 'app', "fetch_size" '1000', "host" 'cluster-remote-rw')`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectQuery(detectUserMappingQuery).WithArgs("remote", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"option_name", "option_value"}))
+		dbMock.ExpectExec(`CREATE USER MAPPING FOR "app" SERVER "remote" ` +
+			`OPTIONS ("password" 's3cr3t', "user" 'reader')`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).To(HaveValue(BeTrue()))
+		Expect(database.Status.Servers).To(Equal([]apiv1.ServerStatus{{Name: "remote", Applied: true}}))
+	})
+
+	It("applies the privileges again when the generation is already reconciled", func(ctx SpecContext) {
+		database.Spec.Grants = []apiv1.GrantSpec{
+			{
+				Name:       "usage",
+				Role:       "reader",
+				ObjectType: apiv1.GrantObjectTypeSchema,
+				Privileges: []string{"USAGE"},
+			},
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+		database.Status.ObservedGeneration = database.Generation
+		Expect(fakeClient.Status().Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectExec(`GRANT USAGE ON SCHEMA "public" TO "reader"`).
+			WillReturnError(fmt.Errorf("role does not exist"))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(database.Status.Message).To(ContainSubstring("role does not exist"))
+		Expect(database.Status.Grants).To(HaveLen(1))
+		Expect(database.Status.Grants[0].Applied).To(BeFalse())
+
+		dbMock.ExpectExec(`GRANT USAGE ON SCHEMA "public" TO "reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).To(HaveValue(BeTrue()))
+		Expect(database.Status.Grants).To(Equal([]apiv1.GrantStatus{{Name: "usage", Applied: true}}))
+	})
+
 	It("marks as failed if the target Database is already being managed", func(ctx SpecContext) {
 		// Let's force the database to have a past reconciliation
 		database.Status.ObservedGeneration = 2
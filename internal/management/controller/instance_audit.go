@@ -0,0 +1,123 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// auditRoleSettingPrefix is the prefix of the role settings
+// defining the classes of statements logged by pgaudit
+const auditRoleSettingPrefix = "pgaudit.log="
+
+// reconcileAuditRoles applies the per-role audit settings of the
+// declarative audit configuration, resetting the settings of the
+// roles not listed there. The roles not existing yet are skipped
+func reconcileAuditRoles(ctx context.Context, db *sql.DB, audit *apiv1.AuditConfiguration) error {
+	if audit == nil {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	currentSettings, err := getAuditRoleSettings(ctx, db)
+	if err != nil {
+		return fmt.Errorf("while reading the audit settings of the roles: %w", err)
+	}
+
+	desiredRoles := make(map[string]bool, len(audit.Roles))
+	for _, role := range audit.Roles {
+		desiredRoles[role.Name] = true
+		classes := apiv1.FormatAuditClasses(role.Classes)
+		if currentClasses, ok := currentSettings[role.Name]; ok && currentClasses == classes {
+			continue
+		}
+
+		var exists bool
+		if err := db.QueryRowContext(
+			ctx,
+			"SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)",
+			role.Name,
+		).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			contextLogger.Debug("Skipping the audit settings of a missing role", "role", role.Name)
+			continue
+		}
+
+		contextLogger.Info("Updating the audit settings of a role", "role", role.Name, "classes", classes)
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(
+			"ALTER ROLE %s SET pgaudit.log = '%s'",
+			pgx.Identifier{role.Name}.Sanitize(),
+			strings.ReplaceAll(classes, "'", "''"),
+		)); err != nil {
+			return fmt.Errorf("while updating the audit settings of role %s: %w", role.Name, err)
+		}
+	}
+
+	for roleName := range currentSettings {
+		if desiredRoles[roleName] {
+			continue
+		}
+
+		contextLogger.Info("Resetting the audit settings of a role", "role", roleName)
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(
+			"ALTER ROLE %s RESET pgaudit.log",
+			pgx.Identifier{roleName}.Sanitize(),
+		)); err != nil {
+			return fmt.Errorf("while resetting the audit settings of role %s: %w", roleName, err)
+		}
+	}
+
+	return nil
+}
+
+// getAuditRoleSettings gets the classes of statements logged by
+// pgaudit for each role having a specific setting
+func getAuditRoleSettings(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT r.rolname, c.setting
+		FROM pg_catalog.pg_db_role_setting s
+		JOIN pg_catalog.pg_roles r ON r.oid = s.setrole
+		CROSS JOIN LATERAL unnest(s.setconfig) AS c(setting)
+		WHERE s.setdatabase = 0 AND c.setting LIKE 'pgaudit.log=%'`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var roleName, setting string
+		if err := rows.Scan(&roleName, &setting); err != nil {
+			return nil, err
+		}
+		result[roleName] = strings.TrimPrefix(setting, auditRoleSettingPrefix)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("per-role audit settings", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	expectRoleSettings := func(settings ...string) {
+		rows := sqlmock.NewRows([]string{"rolname", "setting"})
+		for idx := 0; idx < len(settings); idx += 2 {
+			rows.AddRow(settings[idx], settings[idx+1])
+		}
+		dbMock.ExpectQuery("FROM pg_catalog.pg_db_role_setting").WillReturnRows(rows)
+	}
+
+	It("doesn't touch the roles without an audit configuration", func(ctx SpecContext) {
+		Expect(reconcileAuditRoles(ctx, db, nil)).To(Succeed())
+	})
+
+	It("sets the audit classes of the roles and resets the other ones", func(ctx SpecContext) {
+		audit := &apiv1.AuditConfiguration{
+			Roles: []apiv1.AuditRoleConfiguration{
+				{Name: "app", Classes: []string{"read", "write"}},
+				{Name: "reports", Classes: []string{"read"}},
+				{Name: "missing", Classes: []string{"ddl"}},
+			},
+		}
+
+		expectRoleSettings("app", "pgaudit.log=read, write", "legacy", "pgaudit.log=all")
+		dbMock.ExpectQuery("SELECT EXISTS").WithArgs("reports").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		dbMock.ExpectExec(`ALTER ROLE "reports" SET pgaudit.log = 'read'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectQuery("SELECT EXISTS").WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		dbMock.ExpectExec(`ALTER ROLE "legacy" RESET pgaudit.log`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(reconcileAuditRoles(ctx, db, audit)).To(Succeed())
+	})
+})
@@ -59,6 +59,8 @@ import (
 	externalcluster "github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/replicaclusterswitch"
 	clusterstatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
+	pkgUtils "github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 const (
@@ -86,7 +88,13 @@ type shoudRequeue bool
 func (r *InstanceReconciler) Reconcile(
 	ctx context.Context,
 	_ reconcile.Request,
-) (reconcile.Result, error) {
+) (_ reconcile.Result, err error) {
+	ctx, span := telemetry.Start(ctx, "instance.reconcile")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	// set up a convenient contextLog object so we don't have to type request over and over again
 	contextLogger := log.FromContext(ctx)
 
@@ -153,6 +161,12 @@ func (r *InstanceReconciler) Reconcile(
 	// This doesn't need the PG connection, but it needs to reload it in case of changes
 	reloadNeeded := r.RefreshSecrets(ctx, cluster)
 
+	// Write the configuration of the TDE key provider, which PostgreSQL needs
+	// to start, rewrapping the data encryption key when it changed
+	if err := postgresManagement.ReconcileTDEKeyProvider(ctx, r.client, cluster, r.instance.PgData); err != nil {
+		return reconcile.Result{}, fmt.Errorf("while reconciling the TDE key provider: %w", err)
+	}
+
 	reloadConfigNeeded, err := r.refreshConfigurationFiles(ctx, cluster)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -221,6 +235,16 @@ func (r *InstanceReconciler) Reconcile(
 	}
 	restarted = restarted || restartedInplace
 
+	superUserDB, err := r.instance.GetSuperUserDB()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("while getting the superuser connection: %w", err)
+	}
+	revertedAlterSystem, err := revertAlterSystemDrift(ctx, superUserDB, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	reloadNeeded = reloadNeeded || revertedAlterSystem
+
 	if reloadNeeded && !restarted {
 		contextLogger.Info("reloading the instance")
 		if err = r.instance.Reload(ctx); err != nil {
@@ -255,6 +279,10 @@ func (r *InstanceReconciler) Reconcile(
 		if err != nil || !result.IsZero() {
 			return result, err
 		}
+
+		if err := reconcileAuditRoles(ctx, postgresDB, cluster.Spec.PostgresConfiguration.Audit); err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot reconcile the audit settings of the roles: %w", err)
+		}
 	}
 
 	if err = r.refreshCredentialsFromSecret(ctx, cluster); err != nil {
@@ -277,6 +305,10 @@ func (r *InstanceReconciler) Reconcile(
 	// the PostgreSQL major version
 	r.reconcilePostgreSQLAutoConfFilePermissions(ctx, cluster)
 
+	// Remove the data directories kept after a major version upgrade
+	// once the user confirmed it
+	r.reconcileOldMajorDataDirectories(ctx, cluster)
+
 	// EXTREMELY IMPORTANT
 	//
 	// The reconciliation loop may not have applied all the changes needed. In this case
@@ -347,7 +379,7 @@ func (r *InstanceReconciler) refreshConfigurationFiles(
 		return false, err
 	}
 
-	reloadIdent, err := r.instance.RefreshPGIdent(ctx, cluster.Spec.PostgresConfiguration.PgIdent)
+	reloadIdent, err := r.instance.RefreshPGIdent(ctx, cluster)
 	if err != nil {
 		return false, err
 	}
@@ -409,6 +441,10 @@ func (r *InstanceReconciler) initialize(ctx context.Context, cluster *apiv1.Clus
 		return err
 	}
 
+	if err := r.realignDesignatedPrimary(ctx, cluster); err != nil {
+		return err
+	}
+
 	if err := system.SetCoredumpFilter(cluster.GetCoredumpFilter()); err != nil {
 		return err
 	}
@@ -564,7 +600,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 
 	extensionStatusChanged := false
 	for _, extension := range postgres.ManagedExtensions {
-		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
+		extensionIsUsed := extension.IsUsed(cluster.GetPostgresParameters())
 		if lastStatus, ok := r.extensionStatus[extension.Name]; !ok || lastStatus != extensionIsUsed {
 			extensionStatusChanged = true
 			break
@@ -580,7 +616,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 			continue
 		}
 		if extensionStatusChanged {
-			if err = r.reconcileExtensions(ctx, db, cluster.Spec.PostgresConfiguration.Parameters); err != nil {
+			if err = r.reconcileExtensions(ctx, db, cluster.GetPostgresParameters()); err != nil {
 				errors = append(errors,
 					fmt.Errorf("could not reconcile extensions for database %s: %w", databaseName, err))
 			}
@@ -595,7 +631,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 	}
 
 	for _, extension := range postgres.ManagedExtensions {
-		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
+		extensionIsUsed := extension.IsUsed(cluster.GetPostgresParameters())
 		r.extensionStatus[extension.Name] = extensionIsUsed
 	}
 
@@ -1002,6 +1038,19 @@ func (r *InstanceReconciler) reconcilePostgreSQLAutoConfFilePermissions(ctx cont
 	}
 }
 
+// reconcileOldMajorDataDirectories removes the data directories of the
+// previous major versions, which are kept after an upgrade to allow a
+// manual rollback, when the user confirmed the upgrade via annotation
+func (r *InstanceReconciler) reconcileOldMajorDataDirectories(ctx context.Context, cluster *apiv1.Cluster) {
+	if cluster.Annotations[pkgUtils.ConfirmMajorUpgradeAnnotationName] != "true" {
+		return
+	}
+
+	if err := postgresManagement.RemoveOldMajorDirectories(ctx, r.instance.PgData); err != nil {
+		log.FromContext(ctx).Error(err, "Error while removing the data directories of previous major versions")
+	}
+}
+
 // reconcileCheckWalArchiveFile takes care of the `.check-empty-wal-archive`
 // file inside the PGDATA.
 // If `.check-empty-wal-archive` is present, the WAL archiver verifies
@@ -1138,18 +1187,18 @@ func (r *InstanceReconciler) refreshCertificateFilesFromSecret(
 	return certificateIsChanged || privateKeyIsChanged, nil
 }
 
-// refreshCAFromSecret receive a secret and rewrite the ca.crt file to the provided location
+// refreshCAFromSecret receive a secret and rewrite the ca.crt file to the provided location,
+// including the previous CA certificate when it is being rotated
 func (r *InstanceReconciler) refreshCAFromSecret(
 	ctx context.Context,
 	secret *corev1.Secret,
 	destLocation string,
 ) (bool, error) {
-	caCertificate, ok := secret.Data[certs.CACertKey]
-	if !ok {
+	if _, ok := secret.Data[certs.CACertKey]; !ok {
 		return false, fmt.Errorf("missing %s entry in Secret", certs.CACertKey)
 	}
 
-	changed, err := fileutils.WriteFileAtomic(destLocation, caCertificate, 0o600)
+	changed, err := fileutils.WriteFileAtomic(destLocation, certs.GetCABundle(secret), 0o600)
 	if err != nil {
 		return false, fmt.Errorf("while writing server certificate: %w", err)
 	}
@@ -1207,7 +1256,11 @@ func (r *InstanceReconciler) reconcilePrimary(ctx context.Context, cluster *apiv
 	// If I'm not the primary, let's promote myself
 	if !isPrimary {
 		// Verify that the promotion token is met before promoting
-		if err := r.verifyPromotionToken(cluster); err != nil {
+		err := r.verifyPublishedPromotionToken(ctx, cluster)
+		if err == nil {
+			err = r.verifyPromotionToken(cluster)
+		}
+		if err != nil {
 			// Report that a promotion is still ongoing on the cluster
 			cluster.Status.Phase = apiv1.PhaseReplicaClusterPromotion
 			if err := r.client.Status().Patch(ctx, cluster, client.MergeFrom(oldCluster)); err != nil {
@@ -1436,8 +1489,54 @@ func (r *InstanceReconciler) refreshPGHBA(ctx context.Context, cluster *apiv1.Cl
 		}
 		ldapBindPassword = string(ldapBindPasswordByte)
 	}
+
+	addressSets, err := r.getHBAAddressSets(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
 	// Generate pg_hba.conf file
-	return r.instance.RefreshPGHBA(ctx, cluster, ldapBindPassword)
+	return r.instance.RefreshPGHBA(ctx, cluster, ldapBindPassword, addressSets)
+}
+
+// getHBAAddressSets reads the sets of client addresses referenced
+// by the structured pg_hba rules from their ConfigMaps
+func (r *InstanceReconciler) getHBAAddressSets(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (postgresManagement.HBAAddressSets, error) {
+	addressSets := make(postgresManagement.HBAAddressSets)
+	for _, rule := range cluster.Spec.PostgresConfiguration.PgHBARules {
+		for _, ref := range rule.AddressesFrom {
+			if _, ok := addressSets[ref.Name][ref.Key]; ok {
+				continue
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.GetClient().Get(ctx,
+				types.NamespacedName{
+					Name:      ref.Name,
+					Namespace: r.instance.GetNamespaceName(),
+				}, &configMap); err != nil {
+				return nil, fmt.Errorf("while getting the pg_hba address set %s: %w", ref.Name, err)
+			}
+
+			content, ok := configMap.Data[ref.Key]
+			if !ok {
+				continue
+			}
+			set, err := postgresManagement.ParseHBAAddressSet(content)
+			if err != nil {
+				return nil, fmt.Errorf("while parsing the pg_hba address set %s/%s: %w", ref.Name, ref.Key, err)
+			}
+			if addressSets[ref.Name] == nil {
+				addressSets[ref.Name] = make(map[string][]string)
+			}
+			addressSets[ref.Name][ref.Key] = set
+		}
+	}
+
+	return addressSets, nil
 }
 
 func (r *InstanceReconciler) shouldRequeueForMissingTopology(
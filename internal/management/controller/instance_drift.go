@@ -0,0 +1,68 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// revertAlterSystemDrift resets the PostgreSQL parameters of the cluster
+// definition that have been overridden with ALTER SYSTEM, when the drift
+// detection requires the manual changes to be reverted. It returns true
+// when the configuration needs to be reloaded
+func revertAlterSystemDrift(ctx context.Context, db *sql.DB, cluster *apiv1.Cluster) (bool, error) {
+	if !cluster.IsDriftDetectionEnabled() || !cluster.ShouldRevertDrift() {
+		return false, nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	parameters, err := postgresManagement.GetAlterSystemParameters(db)
+	if err != nil {
+		return false, fmt.Errorf("while getting the parameters set with ALTER SYSTEM: %w", err)
+	}
+
+	var reverted bool
+	for _, name := range parameters {
+		if _, declared := cluster.Spec.PostgresConfiguration.Parameters[name]; !declared {
+			continue
+		}
+
+		contextLogger.Info("Reverting a parameter overridden with ALTER SYSTEM", "parameter", name)
+		if _, err := db.ExecContext(
+			ctx,
+			fmt.Sprintf("ALTER SYSTEM RESET %s", pgx.Identifier{name}.Sanitize()),
+		); err != nil {
+			// the postgresql.auto.conf file is read-only when ALTER SYSTEM
+			// is disabled: we report the drift without failing the loop
+			contextLogger.Warning("Cannot revert a parameter overridden with ALTER SYSTEM",
+				"parameter", name, "error", err.Error())
+			continue
+		}
+		reverted = true
+	}
+
+	return reverted, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ALTER SYSTEM drift", func() {
+	var (
+		dbMock  sqlmock.Sqlmock
+		db      *sql.DB
+		cluster *apiv1.Cluster
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"work_mem":        "8MB",
+						"max_connections": "200",
+					},
+				},
+				DriftDetection: &apiv1.DriftDetectionConfiguration{
+					Policy: apiv1.DriftDetectionPolicyRevert,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	expectAlterSystemParameters := func(names ...string) {
+		rows := sqlmock.NewRows([]string{"name"})
+		for _, name := range names {
+			rows.AddRow(name)
+		}
+		dbMock.ExpectQuery("FROM pg_catalog.pg_file_settings").WillReturnRows(rows)
+	}
+
+	It("doesn't touch the instance without drift detection", func(ctx SpecContext) {
+		cluster.Spec.DriftDetection = nil
+		reverted, err := revertAlterSystemDrift(ctx, db, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reverted).To(BeFalse())
+	})
+
+	It("doesn't touch the instance when the drift is only reported", func(ctx SpecContext) {
+		cluster.Spec.DriftDetection.Policy = apiv1.DriftDetectionPolicyReport
+		reverted, err := revertAlterSystemDrift(ctx, db, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reverted).To(BeFalse())
+	})
+
+	It("resets the declared parameters overridden with ALTER SYSTEM", func(ctx SpecContext) {
+		expectAlterSystemParameters("random_page_cost", "work_mem")
+		dbMock.ExpectExec(`ALTER SYSTEM RESET "work_mem"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		reverted, err := revertAlterSystemDrift(ctx, db, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reverted).To(BeTrue())
+	})
+
+	It("doesn't fail when the parameters can't be reset", func(ctx SpecContext) {
+		expectAlterSystemParameters("max_connections")
+		dbMock.ExpectExec(`ALTER SYSTEM RESET "max_connections"`).
+			WillReturnError(errors.New("could not open file postgresql.auto.conf"))
+
+		reverted, err := revertAlterSystemDrift(ctx, db, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reverted).To(BeFalse())
+	})
+})
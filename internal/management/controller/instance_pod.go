@@ -0,0 +1,78 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getPod gets the Pod this instance manager is running into
+func (r *InstanceReconciler) getPod(ctx context.Context) (*corev1.Pod, error) {
+	var pod corev1.Pod
+	err := r.client.Get(ctx, client.ObjectKey{
+		Namespace: r.instance.GetNamespaceName(),
+		Name:      r.instance.GetPodName(),
+	}, &pod)
+	return &pod, err
+}
+
+// recordPodEvent records an event on the Pod this instance manager is
+// running into. Failures are logged, as events are informative only
+func (r *InstanceReconciler) recordPodEvent(ctx context.Context, eventType, reason, message string) {
+	if r.recorder == nil {
+		return
+	}
+
+	pod, err := r.getPod(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warning("Cannot get the instance Pod to record an event",
+			"reason", reason, "err", err.Error())
+		return
+	}
+
+	r.recorder.Event(pod, eventType, reason, message)
+}
+
+// setPodCondition adds or replaces the passed condition in the status of
+// the Pod this instance manager is running into. The other conditions,
+// which are owned by the kubelet, are preserved by the strategic merge patch
+func (r *InstanceReconciler) setPodCondition(ctx context.Context, condition corev1.PodCondition) error {
+	condition.LastTransitionTime = metav1.Now()
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"conditions": []corev1.PodCondition{condition},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.instance.GetNamespaceName(),
+			Name:      r.instance.GetPodName(),
+		},
+	}
+	return r.client.Status().Patch(ctx, pod, client.RawPatch(types.StrategicMergePatchType, patch))
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -26,12 +27,14 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/controller"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/archiver"
 	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -43,6 +46,12 @@ import (
 func (r *InstanceReconciler) refreshServerCertificateFiles(ctx context.Context, cluster *apiv1.Cluster) (bool, error) {
 	contextLogger := log.FromContext(ctx)
 
+	if r.instance.ServerCertificate != nil &&
+		r.isCertificateRefreshDeferred(cluster, postgresSpec.ServerCertificateLocation) {
+		contextLogger.Debug("Waiting for the certificate rotation to reach this instance")
+		return false, nil
+	}
+
 	var secret corev1.Secret
 
 	err := retry.OnError(retry.DefaultBackoff, func(error) bool { return true },
@@ -84,6 +93,10 @@ func (r *InstanceReconciler) refreshReplicationUserCertificate(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
 ) (bool, error) {
+	if r.isCertificateRefreshDeferred(cluster, postgresSpec.StreamingReplicaCertificateLocation) {
+		return false, nil
+	}
+
 	var secret corev1.Secret
 	err := r.GetClient().Get(
 		ctx,
@@ -100,6 +113,22 @@ func (r *InstanceReconciler) refreshReplicationUserCertificate(
 		postgresSpec.StreamingReplicaKeyLocation)
 }
 
+// isCertificateRefreshDeferred checks whether the certificate file should be
+// kept untouched because the staged rotation of the certificates hasn't
+// reached this instance yet. The instances without certificates always
+// get the current ones
+func (r *InstanceReconciler) isCertificateRefreshDeferred(
+	cluster *apiv1.Cluster,
+	certificateLocation string,
+) bool {
+	if !cluster.Status.CertificateRotation.IsDeferringCertificates(r.instance.GetPodName()) {
+		return false
+	}
+
+	exists, err := fileutils.FileExists(certificateLocation)
+	return err == nil && exists
+}
+
 // refreshClientCA gets the latest client CA certificates from the secrets.
 // It returns true if configuration has been changed
 func (r *InstanceReconciler) refreshClientCA(ctx context.Context, cluster *apiv1.Cluster) (bool, error) {
@@ -262,20 +291,137 @@ func (r *InstanceReconciler) verifyPgDataCoherenceForPrimary(ctx context.Context
 			return fmt.Errorf("while ensuring all WAL files are archived: %w", err)
 		}
 
+		if err := r.rejoinFormerPrimary(ctx, cluster, pgVersion); err != nil {
+			r.reportPrimaryRejoin(ctx, corev1.ConditionFalse, apiv1.PrimaryRejoinReasonFailed, err.Error())
+			return err
+		}
+
+		// Now I can demote myself
+		return r.instance.Demote(ctx, cluster)
+	}
+}
+
+// reportPrimaryRejoin reports how the former primary rejoined the cluster,
+// in the Pod conditions and events
+func (r *InstanceReconciler) reportPrimaryRejoin(
+	ctx context.Context,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	eventType := corev1.EventTypeNormal
+	if status != corev1.ConditionTrue {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recordPodEvent(ctx, eventType, reason, message)
+
+	if err := r.setPodCondition(ctx, corev1.PodCondition{
+		Type:    apiv1.PodConditionPrimaryRejoin,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}); err != nil {
+		log.FromContext(ctx).Warning("Cannot set the primary rejoin condition on the instance Pod",
+			"reason", reason, "err", err.Error())
+	}
+}
+
+// rejoinFormerPrimary realigns the data directory of a former primary with
+// the new one using pg_rewind, when the data directory allows it. When
+// pg_rewind can't be used, and the cluster allows it, the former primary
+// is cloned again from the new one with pg_basebackup
+func (r *InstanceReconciler) rejoinFormerPrimary(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pgVersion version.Data,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	canRewind, err := r.instance.CanRewind()
+	if err != nil {
+		return err
+	}
+
+	var rewindErr error
+	if canRewind {
 		// pg_rewind could require a clean shutdown of the old primary to
 		// work. Unfortunately, if the old primary is already clean starting
 		// it up may make it advance in respect to the new one.
 		// The only way to check if we really need to start it up before
 		// invoking pg_rewind is to try using pg_rewind and, on failures,
 		// retrying after having started up the instance.
-		err = r.instance.Rewind(ctx, pgVersion)
-		if err != nil {
-			return fmt.Errorf("while exucuting pg_rewind: %w", err)
+		r.recordPodEvent(ctx, corev1.EventTypeNormal, "PrimaryRejoin",
+			"Realigning the former primary with the new one using pg_rewind")
+		rewindErr = r.instance.Rewind(ctx, pgVersion)
+		if rewindErr == nil {
+			r.reportPrimaryRejoin(ctx, corev1.ConditionTrue, apiv1.PrimaryRejoinReasonRewound,
+				"The former primary has been realigned with the new one using pg_rewind")
+			return nil
+		}
+		if !errors.Is(rewindErr, postgres.ErrRewindNotPossible) {
+			// This may be a transient failure, such as the new primary
+			// not being reachable yet, and we'll try again later
+			return fmt.Errorf("while executing pg_rewind: %w", rewindErr)
 		}
+	} else {
+		rewindErr = fmt.Errorf("%w: either the data checksums or wal_log_hints need to be enabled",
+			postgres.ErrRewindNotPossible)
+	}
 
-		// Now I can demote myself
-		return r.instance.Demote(ctx, cluster)
+	if !cluster.ShouldRecloneFormerPrimary() {
+		return fmt.Errorf("%w, and cloning the former primary again is not enabled", rewindErr)
+	}
+
+	contextLogger.Warning("Cloning the former primary from the new one", "reason", rewindErr)
+	r.recordPodEvent(ctx, corev1.EventTypeWarning, "PrimaryRejoin",
+		fmt.Sprintf("Cloning the former primary from the new one using pg_basebackup, %v", rewindErr))
+
+	var walDir string
+	if cluster.ShouldCreateWalArchiveVolume() {
+		walDir = specs.PgWalVolumePgWalPath
+	}
+	if err := r.instance.RecloneFromServer(ctx, r.instance.GetPrimaryConnInfo()+" dbname=postgres", walDir); err != nil {
+		return fmt.Errorf("while cloning the former primary: %w", err)
+	}
+
+	r.reportPrimaryRejoin(ctx, corev1.ConditionTrue, apiv1.PrimaryRejoinReasonRecloned,
+		fmt.Sprintf("The former primary has been cloned from the new one using pg_basebackup, %v", rewindErr))
+	return nil
+}
+
+// realignDesignatedPrimary runs the replica cluster timeline divergence
+// policy on the designated primary, before PostgreSQL is started
+func (r *InstanceReconciler) realignDesignatedPrimary(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !cluster.IsReplica() || cluster.Status.TargetPrimary != r.instance.GetPodName() {
+		return nil
+	}
+	if cluster.Spec.ReplicaCluster.GetOnTimelineDivergence() == apiv1.TimelineDivergencePolicyManual {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Info("Realigning the designated primary with the source cluster",
+		"policy", cluster.Spec.ReplicaCluster.GetOnTimelineDivergence())
+
+	// Clean up any stale pid file before executing pg_rewind
+	if err := r.instance.CleanUpStalePid(); err != nil {
+		return err
 	}
+
+	// Set permission of postgres.auto.conf to 0600 to allow pg_rewind to write to it
+	// the mode will be later reset by the reconciliation again, skip the error as
+	// rewind may be not needed
+	if err := r.instance.SetPostgreSQLAutoConfWritable(true); err != nil {
+		contextLogger.Error(
+			err, "Error while changing mode of the postgresql.auto.conf file before pg_rewind, skipped")
+	}
+
+	var walDir string
+	if cluster.ShouldCreateWalArchiveVolume() {
+		walDir = specs.PgWalVolumePgWalPath
+	}
+
+	return r.instance.RealignDesignatedPrimary(ctx, r.client, cluster, walDir)
 }
 
 // ReconcileWalStorage moves the files from PGDATA/pg_wal to the volume attached, if exists, and
@@ -322,7 +468,8 @@ func (r *InstanceReconciler) ReconcileWalStorage(ctx context.Context) error {
 // ReconcileTablespaces ensures the mount points created for the tablespaces
 // are there, and creates a subdirectory in each of them, which will therefore
 // be owned by the `postgres` user (rather than `root` as the mount point),
-// as required in order to hold PostgreSQL Tablespaces
+// as required in order to hold PostgreSQL Tablespaces.
+// The content of the ephemeral tablespaces is recreated too.
 func (r *InstanceReconciler) ReconcileTablespaces(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -362,6 +509,17 @@ func (r *InstanceReconciler) ReconcileTablespaces(
 				"instance", r.instance.GetPodName(), "tablespace", tbsName)
 			return fmt.Errorf("while creating data dir in tablespace %s: %w", mountPoint, err)
 		}
+
+		// The volume of an ephemeral tablespace is empty after every restart,
+		// so we need to recreate the content PostgreSQL expects to find
+		if tbsConfig.IsEphemeral() {
+			if err := r.instance.EnsureTablespaceVersionDirectory(specs.LocationForTablespace(tbsName)); err != nil {
+				contextLogger.Error(err,
+					"could not recreate the content of the ephemeral tablespace",
+					"instance", r.instance.GetPodName(), "tablespace", tbsName)
+				return fmt.Errorf("while recreating ephemeral tablespace %s: %w", tbsName, err)
+			}
+		}
 	}
 	return nil
 }
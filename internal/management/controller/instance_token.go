@@ -17,8 +17,14 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/promotiontoken"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -53,6 +59,63 @@ func (r *InstanceReconciler) verifyPromotionToken(cluster *apiv1.Cluster) error
 		return fmt.Errorf("while validating the promotion token: %w", err)
 	}
 
+	return r.validatePromotionTokenAgainstInstance(promotionToken)
+}
+
+// errPromotionTokenNotPublished is raised when the designated primary is
+// waiting for the former primary cluster to publish its demotion token
+var errPromotionTokenNotPublished = errors.New("waiting for the promotion token to be published")
+
+// verifyPublishedPromotionToken checks, during a controlled switchover, that
+// the former primary cluster published its demotion token in the promotion
+// token secret, and that this instance replayed the WAL up to the token
+func (r *InstanceReconciler) verifyPublishedPromotionToken(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !cluster.ShouldWaitForPublishedPromotionToken(r.instance.GetPodName()) {
+		return nil
+	}
+
+	var secret corev1.Secret
+	err := r.client.Get(ctx, client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.ReplicaCluster.PromotionTokenSecret.Name,
+	}, &secret)
+	if apierrors.IsNotFound(err) {
+		return errPromotionTokenNotPublished
+	}
+	if err != nil {
+		return fmt.Errorf("while reading the promotion token secret: %w", err)
+	}
+
+	rawToken := string(secret.Data[apiv1.PromotionTokenSecretKey])
+	if len(rawToken) == 0 {
+		return errPromotionTokenNotPublished
+	}
+
+	promotionToken, err := utils.ParsePgControldataToken(rawToken)
+	if err != nil {
+		return fmt.Errorf("while decoding the published promotion token: %w", err)
+	}
+
+	if err := promotionToken.IsValid(); err != nil {
+		return fmt.Errorf("while validating the published promotion token: %w", err)
+	}
+
+	err = r.validatePromotionTokenAgainstInstance(promotionToken)
+	var tokenError *promotiontoken.TokenVerificationError
+	if errors.As(err, &tokenError) && !tokenError.IsRetryable() {
+		// The secret contains a token generated during a previous
+		// switchover: the former primary has not been demoted yet
+		return fmt.Errorf("%w: %s", errPromotionTokenNotPublished, tokenError.Error())
+	}
+
+	return err
+}
+
+// validatePromotionTokenAgainstInstance checks if the promotion token
+// conditions are met by the current instance
+func (r *InstanceReconciler) validatePromotionTokenAgainstInstance(
+	promotionToken *utils.PgControldataTokenContent,
+) error {
 	// Request a checkpoint on the replica instance, to
 	// ensure update the control file
 	db, err := r.instance.GetSuperUserDB()
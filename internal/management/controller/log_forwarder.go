@@ -0,0 +1,298 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/logsink"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+)
+
+// forwardedLogLine is a PostgreSQL log record forwarded to the log
+// sinks, tagged with the instance it comes from like the records
+// written by the instance manager logger
+type forwardedLogLine struct {
+	Timestamp  time.Time           `json:"ts"`
+	Logger     string              `json:"logger"`
+	Namespace  string              `json:"namespace"`
+	Cluster    string              `json:"cluster"`
+	LoggingPod string              `json:"logging_pod"`
+	Record     logpipe.NamedRecord `json:"record"`
+}
+
+// runningLogSink is a log sink being fed by the log forwarder
+type runningLogSink struct {
+	name          string
+	configuration *logsink.Configuration
+	forwarder     *logsink.Forwarder
+}
+
+// LogForwarder forwards the PostgreSQL log records read by the CSV
+// log pipe to the log sinks configured in the cluster, in addition to
+// writing them to the instance manager logger
+type LogForwarder struct {
+	client.Client
+
+	instance *postgres.Instance
+	sinks    atomic.Pointer[[]*runningLogSink]
+}
+
+// Forward implements the logpipe.RecordForwarder interface
+func (r *LogForwarder) Forward(record logpipe.NamedRecord) {
+	sinks := r.sinks.Load()
+	if sinks == nil || len(*sinks) == 0 {
+		return
+	}
+
+	now := time.Now()
+	line, err := json.Marshal(forwardedLogLine{
+		Timestamp:  now,
+		Logger:     record.GetName(),
+		Namespace:  r.instance.GetNamespaceName(),
+		Cluster:    r.instance.GetClusterName(),
+		LoggingPod: r.instance.GetPodName(),
+		Record:     record,
+	})
+	if err != nil {
+		return
+	}
+
+	entry := logsink.LogEntry{Time: now, Logger: record.GetName(), Line: line}
+	for _, sink := range *sinks {
+		sink.forwarder.Forward(entry)
+	}
+}
+
+// Reconcile applies the log sinks configuration of the cluster,
+// restarting the forwarders whose configuration changed
+func (r *LogForwarder) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).WithName("log_forwarder")
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	cluster, err := getClusterFromInstance(ctx, r.Client, r.instance)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var current []*runningLogSink
+	if sinks := r.sinks.Load(); sinks != nil {
+		current = *sinks
+	}
+	running := make(map[string]*runningLogSink, len(current))
+	for _, sink := range current {
+		running[sink.name] = sink
+	}
+
+	source := logsink.Source{
+		Namespace: r.instance.GetNamespaceName(),
+		Cluster:   r.instance.GetClusterName(),
+		Pod:       r.instance.GetPodName(),
+	}
+
+	// The forwarders outlive this reconciliation loop, and are
+	// stopped when they are reconfigured or the manager exits
+	forwarderContext := context.WithoutCancel(ctx)
+	desired := make([]*runningLogSink, 0, len(cluster.GetLogSinks()))
+	for _, sink := range cluster.GetLogSinks() {
+		configuration, err := getLogSinkConfiguration(ctx, r.Client, cluster.Namespace, sink)
+		if err != nil {
+			contextLogger.Error(err, "while reading the log sink configuration", "sink", sink.Name)
+			if previous, ok := running[sink.Name]; ok {
+				desired = append(desired, previous)
+				delete(running, sink.Name)
+			}
+			continue
+		}
+
+		if previous, ok := running[sink.Name]; ok && reflect.DeepEqual(previous.configuration, configuration) {
+			desired = append(desired, previous)
+			delete(running, sink.Name)
+			continue
+		}
+
+		forwarder, err := logsink.NewForwarder(sink.Name, configuration, source)
+		if err != nil {
+			contextLogger.Error(err, "while creating the log sink forwarder", "sink", sink.Name)
+			continue
+		}
+		contextLogger.Info("Starting to forward the PostgreSQL logs", "sink", sink.Name)
+		forwarder.Start(forwarderContext)
+		desired = append(desired, &runningLogSink{
+			name:          sink.Name,
+			configuration: configuration,
+			forwarder:     forwarder,
+		})
+	}
+
+	r.sinks.Store(&desired)
+	for _, sink := range running {
+		contextLogger.Info("Stopping to forward the PostgreSQL logs", "sink", sink.name)
+		sink.forwarder.Stop()
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// Start implements the manager.Runnable interface, stopping the
+// forwarders when the instance manager exits
+func (r *LogForwarder) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	sinks := r.sinks.Swap(&[]*runningLogSink{})
+	if sinks == nil {
+		return nil
+	}
+	for _, sink := range *sinks {
+		sink.forwarder.Stop()
+	}
+	return nil
+}
+
+// getLogSinkConfiguration gets the configuration of a log sink,
+// including the credentials read from the secrets
+func getLogSinkConfiguration(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	sink apiv1.LogSink,
+) (*logsink.Configuration, error) {
+	getSecretKey := func(selector *apiv1.SecretKeySelector) ([]byte, error) {
+		return getLogSinkSecretKey(ctx, cli, namespace, selector.Name, selector.Key)
+	}
+
+	result := &logsink.Configuration{
+		BufferSize:      sink.BufferSize,
+		BlockOnOverflow: sink.OverflowPolicy == apiv1.LogSinkOverflowPolicyBlock,
+		BatchSize:       sink.BatchSize,
+	}
+	if sink.FlushInterval != nil {
+		result.FlushInterval = sink.FlushInterval.Duration
+	}
+
+	var err error
+	switch {
+	case sink.Loki != nil:
+		result.Loki = &logsink.LokiConfiguration{
+			URL:      sink.Loki.URL,
+			TenantID: sink.Loki.TenantID,
+			Labels:   sink.Loki.Labels,
+		}
+		if sink.Loki.BearerToken != nil {
+			token, err := getSecretKey(sink.Loki.BearerToken)
+			if err != nil {
+				return nil, err
+			}
+			result.Loki.BearerToken = string(token)
+		}
+		if sink.Loki.CA != nil {
+			if result.Loki.CA, err = getSecretKey(sink.Loki.CA); err != nil {
+				return nil, err
+			}
+		}
+
+	case sink.Syslog != nil:
+		result.Syslog = &logsink.SyslogConfiguration{
+			Network: string(sink.Syslog.Protocol),
+			Address: sink.Syslog.Address,
+			Tag:     sink.Syslog.Tag,
+		}
+		if result.Syslog.Network == "" {
+			result.Syslog.Network = string(apiv1.SyslogProtocolUDP)
+		}
+
+	case sink.KafkaRestProxy != nil:
+		result.KafkaRestProxy = &logsink.KafkaRestProxyConfiguration{
+			URL:   sink.KafkaRestProxy.URL,
+			Topic: sink.KafkaRestProxy.Topic,
+		}
+		if sink.KafkaRestProxy.BasicAuthSecret != nil {
+			secretName := sink.KafkaRestProxy.BasicAuthSecret.Name
+			username, err := getLogSinkSecretKey(ctx, cli, namespace, secretName, corev1.BasicAuthUsernameKey)
+			if err != nil {
+				return nil, err
+			}
+			password, err := getLogSinkSecretKey(ctx, cli, namespace, secretName, corev1.BasicAuthPasswordKey)
+			if err != nil {
+				return nil, err
+			}
+			result.KafkaRestProxy.Username = string(username)
+			result.KafkaRestProxy.Password = string(password)
+		}
+		if sink.KafkaRestProxy.CA != nil {
+			if result.KafkaRestProxy.CA, err = getSecretKey(sink.KafkaRestProxy.CA); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getLogSinkSecretKey reads a key of a secret used by a log sink
+func getLogSinkSecretKey(
+	ctx context.Context,
+	cli client.Client,
+	namespace, name, key string,
+) ([]byte, error) {
+	var secret corev1.Secret
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("while getting secret %s: %w", name, err)
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("missing key %s in secret %s", key, name)
+	}
+	return value, nil
+}
+
+// NewLogForwarder creates a new log forwarder
+func NewLogForwarder(
+	mgr manager.Manager,
+	instance *postgres.Instance,
+) *LogForwarder {
+	return &LogForwarder{
+		Client:   mgr.GetClient(),
+		instance: instance,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LogForwarder) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(r); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Cluster{}).
+		Named("instance-log-forwarder").
+		Complete(r)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("log forwarder", func() {
+	var (
+		forwarder *LogForwarder
+		cluster   *apiv1.Cluster
+		secret    *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-credentials", Namespace: "default"},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("user"),
+				corev1.BasicAuthPasswordKey: []byte("secret"),
+			},
+		}
+	})
+
+	newForwarder := func() *LogForwarder {
+		return &LogForwarder{
+			Client: fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster, secret).
+				Build(),
+			instance: postgres.NewInstance().
+				WithNamespace("default").
+				WithClusterName("cluster-example").
+				WithPodName("cluster-example-1"),
+		}
+	}
+
+	It("reads the credentials of the sinks from the secrets", func(ctx SpecContext) {
+		forwarder = newForwarder()
+		configuration, err := getLogSinkConfiguration(ctx, forwarder.Client, "default", apiv1.LogSink{
+			Name: "kafka",
+			KafkaRestProxy: &apiv1.KafkaRestProxyLogSink{
+				URL:             "https://kafka-rest.example.com",
+				Topic:           "postgres-logs",
+				BasicAuthSecret: &apiv1.LocalObjectReference{Name: "kafka-credentials"},
+			},
+			BufferSize:     100,
+			OverflowPolicy: apiv1.LogSinkOverflowPolicyBlock,
+			BatchSize:      10,
+			FlushInterval:  &metav1.Duration{Duration: time.Minute},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configuration.KafkaRestProxy.Username).To(Equal("user"))
+		Expect(configuration.KafkaRestProxy.Password).To(Equal("secret"))
+		Expect(configuration.BlockOnOverflow).To(BeTrue())
+		Expect(configuration.FlushInterval).To(Equal(time.Minute))
+	})
+
+	It("fails when a secret key is missing", func(ctx SpecContext) {
+		forwarder = newForwarder()
+		_, err := getLogSinkConfiguration(ctx, forwarder.Client, "default", apiv1.LogSink{
+			Name: "loki",
+			Loki: &apiv1.LokiLogSink{
+				URL: "https://loki.example.com/loki/api/v1/push",
+				BearerToken: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "kafka-credentials"},
+					Key:                  "token",
+				},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("forwards the log records to the configured sinks", func(ctx SpecContext) {
+		var (
+			mu      sync.Mutex
+			streams []map[string]any
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Streams []map[string]any `json:"streams"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			mu.Lock()
+			streams = append(streams, request.Streams...)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		cluster.Spec.Logging = &apiv1.LoggingConfiguration{
+			Sinks: []apiv1.LogSink{
+				{
+					Name:          "loki",
+					Loki:          &apiv1.LokiLogSink{URL: server.URL},
+					BufferSize:    10,
+					BatchSize:     1,
+					FlushInterval: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}
+		forwarder = newForwarder()
+
+		// Nothing is forwarded before the sinks are configured
+		forwarder.Forward(&logpipe.LoggingRecord{})
+
+		_, err := forwarder.Reconcile(ctx, ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		forwarder.Forward(&logpipe.LoggingRecord{})
+
+		getStreams := func() []map[string]any {
+			mu.Lock()
+			defer mu.Unlock()
+			return streams
+		}
+		Eventually(getStreams).Should(HaveLen(1))
+		Expect(getStreams()[0]["stream"]).To(HaveKeyWithValue("logger", "postgres"))
+
+		managerContext, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(forwarder.Start(managerContext)).To(Succeed())
+		Expect(*forwarder.sinks.Load()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,21 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logicalupgrade contains the runner that, on the primary instance
+// of the target cluster of a logical major version upgrade or of a cluster
+// bootstrapped with a migration, replicates the data from the source
+// cluster and performs the cutover
+package logicalupgrade
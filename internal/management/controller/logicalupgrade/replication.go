@@ -0,0 +1,126 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalupgrade
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// replication is the logical replication of the databases of an
+// external cluster into the cluster, followed by the cutover
+type replication struct {
+	// The external cluster the databases are replicated from
+	source apiv1.ExternalCluster
+
+	// The databases to be replicated, every database of
+	// the source when empty
+	databases []string
+
+	// The name of the publication created in the source
+	// databases, which prefixes the subscription names too
+	publicationName string
+
+	// Whether the applications need to be disconnected from
+	// the source databases during the cutover
+	fenceSource bool
+
+	// Whether the cutover has been requested
+	cutoverRequested bool
+
+	// The condition reporting the progress of the replication
+	conditionType apiv1.ClusterConditionType
+	copying       apiv1.ConditionReason
+	streaming     apiv1.ConditionReason
+	cuttingOver   apiv1.ConditionReason
+	completed     apiv1.ConditionReason
+	failed        apiv1.ConditionReason
+}
+
+// getReplication gets the logical replication the cluster needs to
+// receive, being the target of a logical major version upgrade or
+// being bootstrapped with a migration. Nil is returned otherwise
+func getReplication(cluster *apiv1.Cluster) (*replication, error) {
+	switch {
+	case cluster.Labels[utils.LogicalUpgradeSourceLabelName] != "":
+		source, ok := cluster.ExternalCluster(specs.LogicalUpgradeSourceExternalClusterName)
+		if !ok {
+			return nil, fmt.Errorf("missing external cluster %s", specs.LogicalUpgradeSourceExternalClusterName)
+		}
+
+		return &replication{
+			source:           source,
+			publicationName:  logicalUpgradePublicationName,
+			fenceSource:      true,
+			cutoverRequested: cluster.Annotations[utils.LogicalUpgradeCutoverAnnotationName] == "true",
+			conditionType:    apiv1.ConditionLogicalUpgradeSynchronized,
+			copying:          apiv1.ConditionReasonLogicalUpgradeCopying,
+			streaming:        apiv1.ConditionReasonLogicalUpgradeStreaming,
+			cuttingOver:      apiv1.ConditionReasonLogicalUpgradeCuttingOver,
+			completed:        apiv1.ConditionReasonLogicalUpgradeCompleted,
+			failed:           apiv1.ConditionReasonLogicalUpgradeFailed,
+		}, nil
+
+	case cluster.IsBootstrappedWithMigration():
+		migration := cluster.Spec.Bootstrap.Migration
+		source, ok := cluster.ExternalCluster(migration.Source)
+		if !ok {
+			return nil, fmt.Errorf("missing external cluster %s", migration.Source)
+		}
+
+		var databases []string
+		if !slices.Contains(migration.Databases, "*") {
+			databases = migration.Databases
+		}
+
+		return &replication{
+			source:           source,
+			databases:        databases,
+			publicationName:  migrationPublicationName,
+			fenceSource:      migration.ShouldFenceSource(),
+			cutoverRequested: cluster.Annotations[utils.MigrationCutoverAnnotationName] == "true",
+			conditionType:    apiv1.ConditionMigrationSynchronized,
+			copying:          apiv1.ConditionReasonMigrationCopying,
+			streaming:        apiv1.ConditionReasonMigrationStreaming,
+			cuttingOver:      apiv1.ConditionReasonMigrationCuttingOver,
+			completed:        apiv1.ConditionReasonMigrationCompleted,
+			failed:           apiv1.ConditionReasonMigrationFailed,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// newCondition creates the condition reporting the progress of the replication
+func (r *replication) newCondition(
+	status metav1.ConditionStatus,
+	reason apiv1.ConditionReason,
+	message string,
+) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(r.conditionType),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	}
+}
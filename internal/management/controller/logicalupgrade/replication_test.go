@@ -0,0 +1,123 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalupgrade
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getReplication", func() {
+	It("returns nothing for a regular cluster", func() {
+		repl, err := getReplication(&apiv1.Cluster{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repl).To(BeNil())
+	})
+
+	It("replicates every database for the target of a logical major version upgrade", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{utils.LogicalUpgradeSourceLabelName: "cluster-example"},
+				Annotations: map[string]string{utils.LogicalUpgradeCutoverAnnotationName: "true"},
+			},
+			Spec: apiv1.ClusterSpec{
+				ExternalClusters: []apiv1.ExternalCluster{
+					{Name: specs.LogicalUpgradeSourceExternalClusterName},
+				},
+			},
+		}
+
+		repl, err := getReplication(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repl.databases).To(BeEmpty())
+		Expect(repl.publicationName).To(Equal(logicalUpgradePublicationName))
+		Expect(repl.fenceSource).To(BeTrue())
+		Expect(repl.cutoverRequested).To(BeTrue())
+		Expect(repl.conditionType).To(Equal(apiv1.ConditionLogicalUpgradeSynchronized))
+	})
+
+	It("replicates the migrated databases from the external cluster", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Migration: &apiv1.BootstrapMigration{
+						Source:      "legacy",
+						Databases:   []string{"app", "billing"},
+						FenceSource: ptr.To(false),
+					},
+				},
+				ExternalClusters: []apiv1.ExternalCluster{{Name: "legacy"}},
+			},
+		}
+
+		repl, err := getReplication(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repl.source.Name).To(Equal("legacy"))
+		Expect(repl.databases).To(Equal([]string{"app", "billing"}))
+		Expect(repl.publicationName).To(Equal(migrationPublicationName))
+		Expect(repl.fenceSource).To(BeFalse())
+		Expect(repl.cutoverRequested).To(BeFalse())
+		Expect(repl.conditionType).To(Equal(apiv1.ConditionMigrationSynchronized))
+
+		cluster.Annotations = map[string]string{utils.MigrationCutoverAnnotationName: "true"}
+		repl, err = getReplication(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repl.cutoverRequested).To(BeTrue())
+	})
+
+	It("replicates every database when migrating with a wildcard", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Migration: &apiv1.BootstrapMigration{
+						Source:    "legacy",
+						Databases: []string{"*"},
+					},
+				},
+				ExternalClusters: []apiv1.ExternalCluster{{Name: "legacy"}},
+			},
+		}
+
+		repl, err := getReplication(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repl.databases).To(BeEmpty())
+		Expect(repl.fenceSource).To(BeTrue())
+	})
+
+	It("fails when the external cluster is missing", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Migration: &apiv1.BootstrapMigration{
+						Source:    "legacy",
+						Databases: []string{"app"},
+					},
+				},
+			},
+		}
+
+		_, err := getReplication(cluster)
+		Expect(err).To(MatchError(ContainSubstring("missing external cluster legacy")))
+	})
+})
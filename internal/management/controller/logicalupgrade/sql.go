@@ -0,0 +1,303 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalupgrade
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+const (
+	// logicalUpgradePublicationName is the name of the publication created
+	// in every database of the source cluster of a logical major version
+	// upgrade
+	logicalUpgradePublicationName = "cnpg_major_upgrade"
+
+	// migrationPublicationName is the name of the publication created in
+	// every migrated database of the external cluster
+	migrationPublicationName = "cnpg_migration"
+)
+
+// getSubscriptionName gets the name of the subscription, and of the
+// replication slot in the source cluster, used to replicate the database
+// having the passed OID in the target cluster. The name needs to be unique
+// as replication slots are shared between all the databases.
+func getSubscriptionName(publicationName string, databaseOID int64) string {
+	return fmt.Sprintf("%s_%d", publicationName, databaseOID)
+}
+
+// getSourceDatabases gets the databases of the source cluster that have
+// been imported in the target cluster
+func getSourceDatabases(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT datname FROM pg_catalog.pg_database
+		WHERE datallowconn AND NOT datistemplate AND datname != 'postgres'
+		ORDER BY datname`)
+	if err != nil {
+		return nil, fmt.Errorf("while listing the databases: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var databases []string
+	for rows.Next() {
+		var datname string
+		if err := rows.Scan(&datname); err != nil {
+			return nil, fmt.Errorf("while listing the databases (scan): %w", err)
+		}
+		databases = append(databases, datname)
+	}
+
+	return databases, rows.Err()
+}
+
+// ensurePublication creates the publication of every table
+// of the passed source database, if not existing
+func ensurePublication(ctx context.Context, db *sql.DB, publicationName string) error {
+	var exists bool
+	row := db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_publication WHERE pubname = $1)",
+		publicationName)
+	if err := row.Scan(&exists); err != nil {
+		return fmt.Errorf("while checking the publication: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf("CREATE PUBLICATION %s FOR ALL TABLES", pgx.Identifier{publicationName}.Sanitize()),
+	); err != nil {
+		return fmt.Errorf("while creating the publication: %w", err)
+	}
+
+	return nil
+}
+
+// dropPublication removes the publication from the passed source database
+func dropPublication(ctx context.Context, db *sql.DB, publicationName string) error {
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf("DROP PUBLICATION IF EXISTS %s", pgx.Identifier{publicationName}.Sanitize()),
+	); err != nil {
+		return fmt.Errorf("while dropping the publication: %w", err)
+	}
+
+	return nil
+}
+
+// getSubscriptionNameForDatabase gets the name of the subscription
+// to be used in the passed target database
+func getSubscriptionNameForDatabase(ctx context.Context, db *sql.DB, publicationName string) (string, error) {
+	var databaseOID int64
+	row := db.QueryRowContext(
+		ctx,
+		"SELECT oid FROM pg_catalog.pg_database WHERE datname = pg_catalog.current_database()")
+	if err := row.Scan(&databaseOID); err != nil {
+		return "", fmt.Errorf("while getting the database OID: %w", err)
+	}
+
+	return getSubscriptionName(publicationName, databaseOID), nil
+}
+
+// subscriptionExists checks if the passed subscription exists
+func subscriptionExists(ctx context.Context, db *sql.DB, subscriptionName string) (bool, error) {
+	var exists bool
+	row := db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_subscription WHERE subname = $1)",
+		subscriptionName)
+	if err := row.Scan(&exists); err != nil {
+		return false, fmt.Errorf("while checking the subscription: %w", err)
+	}
+
+	return exists, nil
+}
+
+// createSubscription creates the subscription to the publication of
+// the source database, which will copy the content of every table
+func createSubscription(
+	ctx context.Context,
+	db *sql.DB,
+	subscriptionName string,
+	connString string,
+	publicationName string,
+) error {
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf(
+			"CREATE SUBSCRIPTION %s CONNECTION %s PUBLICATION %s",
+			pgx.Identifier{subscriptionName}.Sanitize(),
+			pq.QuoteLiteral(connString),
+			pgx.Identifier{publicationName}.Sanitize(),
+		),
+	); err != nil {
+		return fmt.Errorf("while creating the subscription: %w", err)
+	}
+
+	return nil
+}
+
+// dropSubscription removes the subscription, together with
+// its replication slot in the source cluster
+func dropSubscription(ctx context.Context, db *sql.DB, subscriptionName string) error {
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf("DROP SUBSCRIPTION IF EXISTS %s", pgx.Identifier{subscriptionName}.Sanitize()),
+	); err != nil {
+		return fmt.Errorf("while dropping the subscription: %w", err)
+	}
+
+	return nil
+}
+
+// isSubscriptionSynchronized checks if the initial copy of
+// every table of the passed subscription has been completed
+func isSubscriptionSynchronized(ctx context.Context, db *sql.DB, subscriptionName string) (bool, error) {
+	var synchronized bool
+	row := db.QueryRowContext(
+		ctx,
+		`SELECT NOT EXISTS(
+			SELECT 1
+			FROM pg_catalog.pg_subscription_rel r
+			JOIN pg_catalog.pg_subscription s ON s.oid = r.srsubid
+			WHERE s.subname = $1 AND r.srsubstate <> 'r'
+		)`,
+		subscriptionName)
+	if err := row.Scan(&synchronized); err != nil {
+		return false, fmt.Errorf("while checking the subscription status: %w", err)
+	}
+
+	return synchronized, nil
+}
+
+// disconnectApplications prevents the non-superuser roles from connecting
+// to the passed source database, and terminates their existing connections
+func disconnectApplications(ctx context.Context, db *sql.DB, databaseName string) error {
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf("ALTER DATABASE %s CONNECTION LIMIT 0", pgx.Identifier{databaseName}.Sanitize()),
+	); err != nil {
+		return fmt.Errorf("while limiting the connections: %w", err)
+	}
+
+	if _, err := db.ExecContext(
+		ctx,
+		`SELECT pg_catalog.pg_terminate_backend(a.pid)
+		FROM pg_catalog.pg_stat_activity a
+		JOIN pg_catalog.pg_roles r ON r.oid = a.usesysid
+		WHERE a.datname = $1 AND a.backend_type = 'client backend' AND NOT r.rolsuper`,
+		databaseName,
+	); err != nil {
+		return fmt.Errorf("while terminating the connections: %w", err)
+	}
+
+	return nil
+}
+
+// getCurrentWALLSN gets the current WAL write location of the source cluster
+func getCurrentWALLSN(ctx context.Context, db *sql.DB) (string, error) {
+	var lsn string
+	row := db.QueryRowContext(ctx, "SELECT pg_catalog.pg_current_wal_lsn()::text")
+	if err := row.Scan(&lsn); err != nil {
+		return "", fmt.Errorf("while getting the current WAL location: %w", err)
+	}
+
+	return lsn, nil
+}
+
+// isSubscriptionCaughtUp checks if the passed subscription received
+// every change up to the passed location of the source cluster
+func isSubscriptionCaughtUp(ctx context.Context, db *sql.DB, subscriptionName string, lsn string) (bool, error) {
+	var caughtUp bool
+	row := db.QueryRowContext(
+		ctx,
+		`SELECT COALESCE(bool_and(latest_end_lsn >= $2::pg_lsn), false)
+		FROM pg_catalog.pg_stat_subscription
+		WHERE subname = $1 AND relid IS NULL`,
+		subscriptionName,
+		lsn)
+	if err := row.Scan(&caughtUp); err != nil {
+		return false, fmt.Errorf("while checking the subscription progress: %w", err)
+	}
+
+	return caughtUp, nil
+}
+
+// sequenceValue is the last value emitted by a sequence
+type sequenceValue struct {
+	schema string
+	name   string
+	value  int64
+}
+
+// getSequenceValues gets the last value of the sequences
+// of the source database that have been used
+func getSequenceValues(ctx context.Context, db *sql.DB) ([]sequenceValue, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT schemaname, sequencename, last_value
+		FROM pg_catalog.pg_sequences
+		WHERE last_value IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the sequences: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var sequences []sequenceValue
+	for rows.Next() {
+		var sequence sequenceValue
+		if err := rows.Scan(&sequence.schema, &sequence.name, &sequence.value); err != nil {
+			return nil, fmt.Errorf("while getting the sequences (scan): %w", err)
+		}
+		sequences = append(sequences, sequence)
+	}
+
+	return sequences, rows.Err()
+}
+
+// setSequenceValues aligns the sequences of the target database
+// with the passed values, as logical replication doesn't
+// replicate them
+func setSequenceValues(ctx context.Context, db *sql.DB, sequences []sequenceValue) error {
+	for _, sequence := range sequences {
+		if _, err := db.ExecContext(
+			ctx,
+			`SELECT pg_catalog.setval(seq, $3)
+			FROM pg_catalog.to_regclass(pg_catalog.format('%I.%I', $1::text, $2::text)) AS seq
+			WHERE seq IS NOT NULL`,
+			sequence.schema,
+			sequence.name,
+			sequence.value,
+		); err != nil {
+			return fmt.Errorf("while setting the value of the sequence %s.%s: %w",
+				sequence.schema, sequence.name, err)
+		}
+	}
+
+	return nil
+}
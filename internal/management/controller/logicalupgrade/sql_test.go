@@ -0,0 +1,133 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalupgrade
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("logical upgrade sql", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("uses a different subscription name for each database", func() {
+		Expect(getSubscriptionName(logicalUpgradePublicationName, 16384)).To(Equal("cnpg_major_upgrade_16384"))
+		Expect(getSubscriptionName(logicalUpgradePublicationName, 16385)).
+			ToNot(Equal(getSubscriptionName(logicalUpgradePublicationName, 16384)))
+		Expect(getSubscriptionName(migrationPublicationName, 16384)).To(Equal("cnpg_migration_16384"))
+	})
+
+	It("creates the publication when it doesn't exist", func(ctx SpecContext) {
+		dbMock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_publication WHERE pubname = $1)").
+			WithArgs(logicalUpgradePublicationName).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		dbMock.ExpectExec(fmt.Sprintf("CREATE PUBLICATION %s FOR ALL TABLES",
+			pgx.Identifier{logicalUpgradePublicationName}.Sanitize())).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(ensurePublication(ctx, db, logicalUpgradePublicationName)).To(Succeed())
+	})
+
+	It("doesn't create the publication twice", func(ctx SpecContext) {
+		dbMock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_publication WHERE pubname = $1)").
+			WithArgs(logicalUpgradePublicationName).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		Expect(ensurePublication(ctx, db, logicalUpgradePublicationName)).To(Succeed())
+	})
+
+	It("creates the subscription quoting the connection string", func(ctx SpecContext) {
+		dbMock.ExpectExec(fmt.Sprintf(
+			"CREATE SUBSCRIPTION %s CONNECTION 'host=source-rw password=''secret''' PUBLICATION %s",
+			pgx.Identifier{"cnpg_major_upgrade_1"}.Sanitize(),
+			pgx.Identifier{logicalUpgradePublicationName}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(createSubscription(ctx, db, "cnpg_major_upgrade_1", "host=source-rw password='secret'",
+			logicalUpgradePublicationName)).To(Succeed())
+	})
+
+	It("reports the errors while dropping the subscription", func(ctx SpecContext) {
+		dbMock.ExpectExec(fmt.Sprintf("DROP SUBSCRIPTION IF EXISTS %s",
+			pgx.Identifier{"cnpg_major_upgrade_1"}.Sanitize())).
+			WillReturnError(fmt.Errorf("boom"))
+
+		err := dropSubscription(ctx, db, "cnpg_major_upgrade_1")
+		Expect(err).To(MatchError(ContainSubstring("while dropping the subscription: boom")))
+	})
+
+	It("disconnects the applications from the source database", func(ctx SpecContext) {
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s CONNECTION LIMIT 0", pgx.Identifier{"app"}.Sanitize())).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`SELECT pg_catalog.pg_terminate_backend(a.pid)
+		FROM pg_catalog.pg_stat_activity a
+		JOIN pg_catalog.pg_roles r ON r.oid = a.usesysid
+		WHERE a.datname = $1 AND a.backend_type = 'client backend' AND NOT r.rolsuper`).
+			WithArgs("app").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		Expect(disconnectApplications(ctx, db, "app")).To(Succeed())
+	})
+
+	It("checks if the subscription received the changes up to a certain location", func(ctx SpecContext) {
+		dbMock.ExpectQuery(`SELECT COALESCE(bool_and(latest_end_lsn >= $2::pg_lsn), false)
+		FROM pg_catalog.pg_stat_subscription
+		WHERE subname = $1 AND relid IS NULL`).
+			WithArgs("cnpg_major_upgrade_1", "0/3000060").
+			WillReturnRows(sqlmock.NewRows([]string{"caught_up"}).AddRow(true))
+
+		caughtUp, err := isSubscriptionCaughtUp(ctx, db, "cnpg_major_upgrade_1", "0/3000060")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(caughtUp).To(BeTrue())
+	})
+
+	It("aligns the sequences of the target database", func(ctx SpecContext) {
+		const query = `SELECT pg_catalog.setval(seq, $3)
+			FROM pg_catalog.to_regclass(pg_catalog.format('%I.%I', $1::text, $2::text)) AS seq
+			WHERE seq IS NOT NULL`
+		dbMock.ExpectExec(query).
+			WithArgs("public", "orders_id_seq", int64(42)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(query).
+			WithArgs("sales", "invoices_id_seq", int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(setSequenceValues(ctx, db, []sequenceValue{
+			{schema: "public", name: "orders_id_seq", value: 42},
+			{schema: "sales", name: "invoices_id_seq", value: 7},
+		})).To(Succeed())
+	})
+})
@@ -0,0 +1,341 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// reconcileInterval is the interval between two checks of
+// the logical replication from the source cluster
+const reconcileInterval = 10 * time.Second
+
+// A Subscriber is a runner that, when the cluster is the target of a logical
+// major version upgrade or is bootstrapped with a migration, subscribes to
+// the databases of the source cluster and, when requested, performs the cutover
+type Subscriber struct {
+	instance *postgres.Instance
+	client   client.Client
+
+	// The WAL location of the source cluster, taken for each database after
+	// the applications have been disconnected, that the subscriptions need
+	// to reach before completing the cutover
+	cutoverLSN map[string]string
+}
+
+// NewSubscriber creates a new logical major version upgrade Subscriber
+func NewSubscriber(instance *postgres.Instance, client client.Client) *Subscriber {
+	runner := &Subscriber{
+		instance:   instance,
+		client:     client,
+		cutoverLSN: make(map[string]string),
+	}
+	return runner
+}
+
+// Start starts running the logical major version upgrade Subscriber
+func (s *Subscriber) Start(ctx context.Context) error {
+	contextLog := log.FromContext(ctx).WithName("logical_upgrade_subscriber")
+	go func() {
+		ticker := time.NewTicker(reconcileInterval)
+
+		defer func() {
+			ticker.Stop()
+			contextLog.Info("Terminated logical major version upgrade Subscriber loop")
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := s.reconcile(ctx); err != nil {
+				contextLog.Error(err, "replicating the data of the logical major version upgrade")
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile replicates the data from the source cluster and
+// reports the progress in the cluster status
+func (s *Subscriber) reconcile(ctx context.Context) error {
+	var cluster apiv1.Cluster
+	if err := s.client.Get(ctx, client.ObjectKey{
+		Namespace: s.instance.GetNamespaceName(),
+		Name:      s.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return err
+	}
+
+	repl, err := getReplication(&cluster)
+	if err != nil || repl == nil {
+		return err
+	}
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(repl.conditionType))
+	if condition != nil && condition.Reason == string(repl.completed) {
+		return nil
+	}
+
+	// The subscriptions live in the primary instance
+	isPrimary, err := s.instance.IsPrimary()
+	if err != nil || !isPrimary {
+		return err
+	}
+
+	newCondition, err := s.synchronize(ctx, repl)
+	if err != nil {
+		newCondition = repl.newCondition(metav1.ConditionFalse, repl.failed, err.Error())
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, s.client, &cluster, newCondition)
+}
+
+// synchronize subscribes to the databases of the source cluster or,
+// if requested, performs the cutover
+func (s *Subscriber) synchronize(
+	ctx context.Context,
+	repl *replication,
+) (metav1.Condition, error) {
+	sourcePool := pool.NewPostgresqlConnectionPool(external.GetServerConnectionString(&repl.source, ""))
+	defer sourcePool.ShutdownConnections()
+
+	databases := repl.databases
+	if len(databases) == 0 {
+		sourceDB, err := sourcePool.Connection("postgres")
+		if err != nil {
+			return metav1.Condition{}, err
+		}
+		if databases, err = getSourceDatabases(ctx, sourceDB); err != nil {
+			return metav1.Condition{}, err
+		}
+	}
+
+	if repl.cutoverRequested {
+		return s.cutover(ctx, sourcePool, repl, databases)
+	}
+
+	synchronizedDatabases := 0
+	for _, databaseName := range databases {
+		synchronized, err := s.subscribe(ctx, sourcePool, repl, databaseName)
+		if err != nil {
+			return metav1.Condition{}, fmt.Errorf("database %s: %w", databaseName, err)
+		}
+		if synchronized {
+			synchronizedDatabases++
+		}
+	}
+
+	if synchronizedDatabases < len(databases) {
+		return repl.newCondition(metav1.ConditionFalse, repl.copying,
+			fmt.Sprintf("The initial copy of %d databases out of %d has been completed",
+				synchronizedDatabases, len(databases))), nil
+	}
+
+	return repl.newCondition(metav1.ConditionTrue, repl.streaming,
+		"The changes are being streamed from the source cluster"), nil
+}
+
+// subscribe ensures the passed database is replicated from the source
+// cluster, and checks if the initial copy has been completed
+func (s *Subscriber) subscribe(
+	ctx context.Context,
+	sourcePool *pool.ConnectionPool,
+	repl *replication,
+	databaseName string,
+) (bool, error) {
+	sourceDB, err := sourcePool.Connection(databaseName)
+	if err != nil {
+		return false, err
+	}
+	if err := ensurePublication(ctx, sourceDB, repl.publicationName); err != nil {
+		return false, err
+	}
+
+	db, err := s.instance.ConnectionPool().Connection(databaseName)
+	if err != nil {
+		return false, err
+	}
+	subscriptionName, err := getSubscriptionNameForDatabase(ctx, db, repl.publicationName)
+	if err != nil {
+		return false, err
+	}
+
+	exists, err := subscriptionExists(ctx, db, subscriptionName)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		log.FromContext(ctx).Info("Subscribing to the source database",
+			"databaseName", databaseName,
+			"subscriptionName", subscriptionName)
+		connString := external.GetServerConnectionString(&repl.source, databaseName)
+		if err := createSubscription(ctx, db, subscriptionName, connString, repl.publicationName); err != nil {
+			return false, err
+		}
+	}
+
+	return isSubscriptionSynchronized(ctx, db, subscriptionName)
+}
+
+// cutover disconnects the applications from the source cluster and, once
+// every change has been received, synchronizes the sequences and removes
+// the subscriptions
+func (s *Subscriber) cutover(
+	ctx context.Context,
+	sourcePool *pool.ConnectionPool,
+	repl *replication,
+	databases []string,
+) (metav1.Condition, error) {
+	contextLogger := log.FromContext(ctx)
+
+	// The subscriptions that have already been removed belong
+	// to databases whose cutover has been completed
+	subscriptions := make(map[string]string, len(databases))
+	for _, databaseName := range databases {
+		db, err := s.instance.ConnectionPool().Connection(databaseName)
+		if err != nil {
+			return metav1.Condition{}, err
+		}
+		subscriptionName, err := getSubscriptionNameForDatabase(ctx, db, repl.publicationName)
+		if err != nil {
+			return metav1.Condition{}, err
+		}
+		exists, err := subscriptionExists(ctx, db, subscriptionName)
+		if err != nil {
+			return metav1.Condition{}, err
+		}
+		if exists {
+			subscriptions[databaseName] = subscriptionName
+		}
+	}
+
+	caughtUpDatabases := 0
+	for databaseName, subscriptionName := range subscriptions {
+		caughtUp, err := s.isCaughtUp(ctx, sourcePool, repl, databaseName, subscriptionName)
+		if err != nil {
+			return metav1.Condition{}, fmt.Errorf("database %s: %w", databaseName, err)
+		}
+		if caughtUp {
+			caughtUpDatabases++
+		}
+	}
+
+	if caughtUpDatabases < len(subscriptions) {
+		return repl.newCondition(metav1.ConditionFalse, repl.cuttingOver,
+			fmt.Sprintf("%d databases out of %d received the last changes",
+				caughtUpDatabases, len(subscriptions))), nil
+	}
+
+	for databaseName, subscriptionName := range subscriptions {
+		contextLogger.Info("Completing the cutover of the database",
+			"databaseName", databaseName,
+			"subscriptionName", subscriptionName)
+		if err := s.completeCutover(ctx, sourcePool, repl, databaseName, subscriptionName); err != nil {
+			return metav1.Condition{}, fmt.Errorf("database %s: %w", databaseName, err)
+		}
+	}
+
+	return repl.newCondition(metav1.ConditionTrue, repl.completed,
+		"The sequences have been synchronized and the subscriptions removed"), nil
+}
+
+// isCaughtUp disconnects, if required, the applications from the passed
+// source database and checks if the subscription received every change
+func (s *Subscriber) isCaughtUp(
+	ctx context.Context,
+	sourcePool *pool.ConnectionPool,
+	repl *replication,
+	databaseName string,
+	subscriptionName string,
+) (bool, error) {
+	sourceDB, err := sourcePool.Connection(databaseName)
+	if err != nil {
+		return false, err
+	}
+	if repl.fenceSource {
+		if err := disconnectApplications(ctx, sourceDB, databaseName); err != nil {
+			return false, err
+		}
+	}
+
+	// The changes made after this location don't come from the applications,
+	// unless they are still connected to an unfenced source
+	lsn, ok := s.cutoverLSN[databaseName]
+	if !ok {
+		if lsn, err = getCurrentWALLSN(ctx, sourceDB); err != nil {
+			return false, err
+		}
+		s.cutoverLSN[databaseName] = lsn
+	}
+
+	db, err := s.instance.ConnectionPool().Connection(databaseName)
+	if err != nil {
+		return false, err
+	}
+	return isSubscriptionCaughtUp(ctx, db, subscriptionName, lsn)
+}
+
+// completeCutover synchronizes the sequences of the passed database
+// and removes the subscription and the publication
+func (s *Subscriber) completeCutover(
+	ctx context.Context,
+	sourcePool *pool.ConnectionPool,
+	repl *replication,
+	databaseName string,
+	subscriptionName string,
+) error {
+	sourceDB, err := sourcePool.Connection(databaseName)
+	if err != nil {
+		return err
+	}
+	db, err := s.instance.ConnectionPool().Connection(databaseName)
+	if err != nil {
+		return err
+	}
+
+	sequences, err := getSequenceValues(ctx, sourceDB)
+	if err != nil {
+		return err
+	}
+	if err := setSequenceValues(ctx, db, sequences); err != nil {
+		return err
+	}
+
+	if err := dropSubscription(ctx, db, subscriptionName); err != nil {
+		return err
+	}
+
+	return dropPublication(ctx, sourceDB, repl.publicationName)
+}
@@ -117,6 +117,12 @@ func (r *PublicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{RequeueAfter: publicationReconciliationInterval}, nil
 	}
 
+	// The admission webhook may be disabled, so the publication
+	// is validated against the cluster here too
+	if err := publication.ValidateWithCluster(cluster); err != nil {
+		return ctrl.Result{}, markAsFailed(ctx, r.Client, &publication, err)
+	}
+
 	if res, err := detectConflictingManagers(ctx, r.Client, &publication, &apiv1.PublicationList{}); err != nil ||
 		!res.IsZero() {
 		return res, err
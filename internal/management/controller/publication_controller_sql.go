@@ -189,5 +189,9 @@ func toPublicationObjectSQL(obj *apiv1.PublicationTargetObject) string {
 		result.WriteString(fmt.Sprintf(" (%s)", strings.Join(sanitizedColumns, ", ")))
 	}
 
+	if len(obj.Table.Where) > 0 {
+		result.WriteString(fmt.Sprintf(" WHERE (%s)", obj.Table.Where))
+	}
+
 	return result.String()
 }
@@ -191,6 +191,19 @@ var _ = Describe("toPublicationObjectSQL", func() {
 		Expect(result).To(Equal(`TABLE "test"."table" ("a", "b")`))
 	})
 
+	It("returns correct SQL for table with columns and row filter", func() {
+		obj := &apiv1.PublicationTargetObject{
+			Table: &apiv1.PublicationTargetTable{
+				Name:    "customers",
+				Schema:  "sales",
+				Columns: []string{"id", "region"},
+				Where:   "region = 'EU'",
+			},
+		}
+		result := toPublicationObjectSQL(obj)
+		Expect(result).To(Equal(`TABLE "sales"."customers" ("id", "region") WHERE (region = 'EU')`))
+	})
+
 	It("returns correct SQL for table with only clause", func() {
 		obj := &apiv1.PublicationTargetObject{
 			Table: &apiv1.PublicationTargetTable{
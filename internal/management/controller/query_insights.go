@@ -0,0 +1,128 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/querystats"
+)
+
+// QueryInsightsHistoryWriter periodically stores the snapshots of the top
+// statements of the primary instance in the query history table
+type QueryInsightsHistoryWriter struct {
+	client.Client
+
+	instance     *postgres.Instance
+	getDB        func(database string) (*sql.DB, error)
+	lastSnapshot time.Time
+}
+
+// Reconcile takes a snapshot of the top statements when the snapshot
+// interval has elapsed, storing it in the query history table
+func (r *QueryInsightsHistoryWriter) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).WithName("query_insights_history")
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	cluster, err := getClusterFromInstance(ctx, r.Client, r.instance)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	queryInsights := cluster.GetQueryInsights()
+	if queryInsights == nil || queryInsights.History == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// The history is written by the primary instance only
+	snapshotInterval := queryInsights.GetSnapshotInterval()
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary ||
+		cluster.Status.CurrentPrimary != r.instance.GetPodName() {
+		return ctrl.Result{RequeueAfter: snapshotInterval}, nil
+	}
+
+	if elapsed := time.Since(r.lastSnapshot); elapsed < snapshotInterval {
+		return ctrl.Result{RequeueAfter: snapshotInterval - elapsed}, nil
+	}
+
+	r.lastSnapshot = time.Now()
+	if err := r.writeSnapshot(ctx, queryInsights, r.lastSnapshot); err != nil {
+		contextLogger.Error(err, "while storing the snapshot of the top statements")
+	}
+
+	return ctrl.Result{RequeueAfter: snapshotInterval}, nil
+}
+
+// writeSnapshot takes a snapshot of the top statements, storing it
+// in the history table
+func (r *QueryInsightsHistoryWriter) writeSnapshot(
+	ctx context.Context,
+	queryInsights *apiv1.QueryInsightsConfiguration,
+	snapshotTime time.Time,
+) error {
+	database := queryInsights.History.GetDatabase()
+	db, err := r.getDB(database)
+	if err != nil {
+		return fmt.Errorf("while connecting to database %s: %w", database, err)
+	}
+
+	statements, err := querystats.GetTopStatements(ctx, db, queryInsights.GetTopN())
+	if err != nil {
+		return fmt.Errorf("while getting the top statements: %w", err)
+	}
+
+	return querystats.WriteHistory(
+		ctx,
+		db,
+		r.instance.GetPodName(),
+		snapshotTime,
+		statements,
+		queryInsights.History.GetRetention(),
+	)
+}
+
+// NewQueryInsightsHistoryWriter creates a new query insights history writer
+func NewQueryInsightsHistoryWriter(
+	mgr manager.Manager,
+	instance *postgres.Instance,
+) *QueryInsightsHistoryWriter {
+	return &QueryInsightsHistoryWriter{
+		Client:   mgr.GetClient(),
+		instance: instance,
+		getDB: func(database string) (*sql.DB, error) {
+			return instance.ConnectionPool().Connection(database)
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *QueryInsightsHistoryWriter) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Cluster{}).
+		Named("instance-query-insights-history").
+		Complete(r)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("query insights history writer", func() {
+	var (
+		db        *sql.DB
+		mock      sqlmock.Sqlmock
+		cluster   *apiv1.Cluster
+		databases []string
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+
+		databases = nil
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					QueryInsights: &apiv1.QueryInsightsConfiguration{
+						Enabled: true,
+						History: &apiv1.QueryInsightsHistory{Database: "app"},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+	})
+
+	newWriter := func(podName string) *QueryInsightsHistoryWriter {
+		return &QueryInsightsHistoryWriter{
+			Client: fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				Build(),
+			instance: postgres.NewInstance().
+				WithNamespace("default").
+				WithClusterName("cluster-example").
+				WithPodName(podName),
+			getDB: func(database string) (*sql.DB, error) {
+				databases = append(databases, database)
+				return db, nil
+			},
+		}
+	}
+
+	It("stores the snapshots on the primary at every interval", func(ctx SpecContext) {
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_statements").WithArgs(10).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"datname", "rolname", "queryid", "query", "calls", "total_exec_time", "mean_exec_time", "rows",
+			}).AddRow("app", "app", 42, "SELECT 1", 1000, 2500.0, 2.5, 1000))
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.cnpg_query_history").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO public.cnpg_query_history").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM public.cnpg_query_history").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		writer := newWriter("cluster-example-1")
+		result, err := writer.Reconcile(ctx, ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(databases).To(Equal([]string{"app"}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		// The next snapshot is taken when the interval elapses
+		result, err = writer.Reconcile(ctx, ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		Expect(databases).To(HaveLen(1))
+	})
+
+	It("doesn't store anything on the replicas", func(ctx SpecContext) {
+		writer := newWriter("cluster-example-2")
+		result, err := writer.Reconcile(ctx, ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(databases).To(BeEmpty())
+	})
+
+	It("doesn't store anything without the history configuration", func(ctx SpecContext) {
+		cluster.Spec.Monitoring.QueryInsights.History = nil
+		writer := newWriter("cluster-example-1")
+		result, err := writer.Reconcile(ctx, ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(databases).To(BeEmpty())
+	})
+})
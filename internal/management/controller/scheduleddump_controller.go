@@ -0,0 +1,235 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// scheduledDumpReconciliationInterval is the time between two checks of
+// a scheduled dump when this instance cannot take it
+const scheduledDumpReconciliationInterval = 30 * time.Second
+
+// errDumpInterrupted is raised when a dump was still marked as running
+// after the instance manager has been restarted
+var errDumpInterrupted = errors.New("the dump was interrupted before being completed")
+
+// ScheduledDumpReconciler takes the logical dumps of the cluster
+// according to the schedule of the ScheduledDump objects
+type ScheduledDumpReconciler struct {
+	client.Client
+
+	instance *postgres.Instance
+	takeDump func(
+		ctx context.Context,
+		cluster *apiv1.Cluster,
+		scheduledDump *apiv1.ScheduledDump,
+		startTime time.Time,
+	) (string, error)
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=scheduleddumps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=scheduleddumps/status,verbs=get;update;patch
+
+// Reconcile takes a logical dump when the schedule is due. The dumps are
+// taken by the primary instance, one at a time
+func (r *ScheduledDumpReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).
+		WithName("scheduled_dump_reconciler").
+		WithValues("scheduledDumpName", req.Name)
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	var scheduledDump apiv1.ScheduledDump
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+	}, &scheduledDump); err != nil {
+		contextLogger.Trace("Could not fetch ScheduledDump", "error", err)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// This is not for me!
+	if scheduledDump.Spec.Cluster.Name != r.instance.GetClusterName() {
+		return ctrl.Result{}, nil
+	}
+
+	if scheduledDump.IsSuspended() {
+		contextLogger.Debug("Skipping as the scheduled dump is suspended")
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := getClusterFromInstance(ctx, r.Client, r.instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// This is not for me, at least now
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary ||
+		cluster.Status.CurrentPrimary != r.instance.GetPodName() {
+		return ctrl.Result{RequeueAfter: scheduledDumpReconciliationInterval}, nil
+	}
+
+	// Dumps are taken synchronously, so a dump still marked as running
+	// has been interrupted by a restart or a switchover
+	if scheduledDump.Status.IsRunning() {
+		if err := r.markDumpAsTerminated(ctx, &scheduledDump, "", errDumpInterrupted); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The admission webhook may be disabled, so the
+	// scheduled dump is validated here too
+	if _, err := scheduledDump.ValidateCreate(); err != nil {
+		contextLogger.Info("Detected an invalid scheduled dump", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	schedule, err := cron.Parse(scheduledDump.GetSchedule())
+	if err != nil {
+		contextLogger.Info("Detected an invalid cron schedule",
+			"schedule", scheduledDump.GetSchedule())
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	if schedule.Next(now).IsZero() {
+		contextLogger.Info("No time satisfying the schedule has been found",
+			"schedule", scheduledDump.GetSchedule())
+		return ctrl.Result{}, nil
+	}
+
+	if scheduledDump.Status.LastCheckTime == nil && !scheduledDump.IsImmediate() {
+		// This is the first time we check this schedule,
+		// let's wait until the first dump will be actually
+		// scheduled
+		origScheduledDump := scheduledDump.DeepCopy()
+		nextTime := schedule.Next(now)
+		scheduledDump.Status.LastCheckTime = &metav1.Time{Time: now}
+		scheduledDump.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+		if err := r.Client.Status().Patch(ctx, &scheduledDump, client.MergeFrom(origScheduledDump)); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		contextLogger.Info("Next dump schedule", "next", nextTime)
+		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+	}
+
+	if scheduledDump.Status.LastCheckTime != nil {
+		nextTime := schedule.Next(scheduledDump.Status.LastCheckTime.Time)
+		if now.Before(nextTime) {
+			// No need to take a new dump, let's wait a bit
+			return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+		}
+	}
+
+	return r.runDump(ctx, cluster, &scheduledDump, schedule, now)
+}
+
+// runDump takes a dump, recording its progress in the status
+// of the scheduled dump
+func (r *ScheduledDumpReconciler) runDump(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	scheduledDump *apiv1.ScheduledDump,
+	schedule cron.Schedule,
+	now time.Time,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	origScheduledDump := scheduledDump.DeepCopy()
+	nextTime := schedule.Next(now)
+	scheduledDump.Status.LastCheckTime = &metav1.Time{Time: now}
+	scheduledDump.Status.LastScheduleTime = &metav1.Time{Time: now}
+	scheduledDump.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+	scheduledDump.Status.LastDump = &apiv1.DumpStatus{
+		Phase:        apiv1.DumpPhaseRunning,
+		InstanceName: r.instance.GetPodName(),
+		StartedAt:    &metav1.Time{Time: now},
+	}
+	if err := r.Client.Status().Patch(ctx, scheduledDump, client.MergeFrom(origScheduledDump)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	contextLogger.Info("Starting logical dump")
+	destinationPath, dumpErr := r.takeDump(ctx, cluster, scheduledDump, now)
+	if dumpErr != nil {
+		contextLogger.Error(dumpErr, "while taking logical dump")
+	} else {
+		contextLogger.Info("Logical dump completed", "destinationPath", destinationPath)
+	}
+
+	if err := r.markDumpAsTerminated(ctx, scheduledDump, destinationPath, dumpErr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	contextLogger.Info("Next dump schedule", "next", nextTime)
+	return ctrl.Result{RequeueAfter: time.Until(nextTime)}, nil
+}
+
+// markDumpAsTerminated records the result of the last dump
+func (r *ScheduledDumpReconciler) markDumpAsTerminated(
+	ctx context.Context,
+	scheduledDump *apiv1.ScheduledDump,
+	destinationPath string,
+	dumpErr error,
+) error {
+	origScheduledDump := scheduledDump.DeepCopy()
+	lastDump := scheduledDump.Status.LastDump
+	lastDump.StoppedAt = &metav1.Time{Time: time.Now()}
+	if dumpErr != nil {
+		lastDump.Phase = apiv1.DumpPhaseFailed
+		lastDump.Error = dumpErr.Error()
+	} else {
+		lastDump.Phase = apiv1.DumpPhaseCompleted
+		lastDump.DestinationPath = destinationPath
+	}
+
+	return r.Client.Status().Patch(ctx, scheduledDump, client.MergeFrom(origScheduledDump))
+}
+
+// NewScheduledDumpReconciler creates a new scheduled dump reconciler
+func NewScheduledDumpReconciler(
+	mgr manager.Manager,
+	instance *postgres.Instance,
+) *ScheduledDumpReconciler {
+	r := &ScheduledDumpReconciler{
+		Client:   mgr.GetClient(),
+		instance: instance,
+	}
+	r.takeDump = r.takeObjectStoreDump
+	return r
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScheduledDumpReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.ScheduledDump{}).
+		Named("instance-scheduled-dump").
+		Complete(r)
+}
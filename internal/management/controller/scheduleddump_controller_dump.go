@@ -0,0 +1,254 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	barmanCommand "github.com/cloudnative-pg/barman-cloud/pkg/command"
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	pgDump    = "pg_dump"
+	pgDumpAll = "pg_dumpall"
+	python    = "python3"
+
+	// dumpAllFileName is the name of the archive produced by pg_dumpall
+	dumpAllFileName = "all.sql"
+)
+
+// dumpUploadScript copies the content of a local directory to the object
+// store, using the cloud interfaces of Barman Cloud, which is installed in
+// every operand image
+const dumpUploadScript = `
+import argparse
+import os
+
+from barman.cloud_providers import get_cloud_interface
+
+parser = argparse.ArgumentParser()
+parser.add_argument("--cloud-provider", default="aws-s3")
+parser.add_argument("--endpoint-url")
+parser.add_argument("--credential", dest="azure_credential")
+parser.add_argument("source")
+parser.add_argument("destination_url")
+config = parser.parse_args()
+config.jobs = 1
+config.tags = None
+
+cloud_interface = get_cloud_interface(config)
+for root, _, files in os.walk(config.source):
+    for name in files:
+        file_path = os.path.join(root, name)
+        key = os.path.join(cloud_interface.path, os.path.relpath(file_path, config.source))
+        with open(file_path, "rb") as file_object:
+            cloud_interface.upload_fileobj(file_object, key)
+`
+
+// errObjectStoreNotConfigured is raised when the cluster has no object
+// store where the dumps can be stored
+var errObjectStoreNotConfigured = errors.New("the cluster has no barmanObjectStore backup configuration")
+
+// takeObjectStoreDump dumps the requested databases in a local directory,
+// and then copies it in the object store of the cluster. It returns the
+// path where the dump has been stored
+func (r *ScheduledDumpReconciler) takeObjectStoreDump(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	scheduledDump *apiv1.ScheduledDump,
+	startTime time.Time,
+) (string, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
+		return "", errObjectStoreNotConfigured
+	}
+	configuration := cluster.Spec.Backup.BarmanObjectStore
+
+	// Remove whatever has been left by an interrupted dump
+	localDirectory := path.Join(postgresSpec.ScheduledDumpDirectory, scheduledDump.Name)
+	if err := os.RemoveAll(localDirectory); err != nil {
+		return "", err
+	}
+	if err := fileutils.EnsureDirectoryExists(localDirectory); err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := os.RemoveAll(localDirectory); err != nil {
+			contextLogger.Error(err, "while removing the local dump directory",
+				"directory", localDirectory)
+		}
+	}()
+
+	dumpCommands := buildDumpCommands(scheduledDump, localDirectory, r.instance.ConnectionPool().GetDsn)
+	for _, dumpCommand := range dumpCommands {
+		contextLogger.Info("Running dump command", "cmd", dumpCommand[0], "options", dumpCommand[1:])
+		cmd := exec.Command(dumpCommand[0], dumpCommand[1:]...) // #nosec G204
+		if err := execlog.RunStreaming(cmd, dumpCommand[0]); err != nil {
+			return "", fmt.Errorf("error in %s: %w", dumpCommand[0], err)
+		}
+	}
+
+	destinationPath := getDumpDestinationPath(configuration, cluster.Name, scheduledDump.Name, startTime)
+	contextLogger.Info("Copying dump to the object store", "destinationPath", destinationPath)
+	if err := uploadDirectoryToObjectStore(
+		ctx,
+		r.Client,
+		cluster.Namespace,
+		configuration,
+		localDirectory,
+		destinationPath,
+		"dump-upload",
+	); err != nil {
+		return "", fmt.Errorf("while copying the dump to the object store: %w", err)
+	}
+
+	return destinationPath, nil
+}
+
+// uploadDirectoryToObjectStore copies the content of a local directory
+// to the passed path of the object store, using the credentials of the
+// passed Barman object store configuration
+func uploadDirectoryToObjectStore(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	configuration *apiv1.BarmanObjectStoreConfiguration,
+	localDirectory string,
+	destinationPath string,
+	commandName string,
+) error {
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(
+		ctx,
+		cli,
+		namespace,
+		configuration,
+		os.Environ())
+	if err != nil {
+		return fmt.Errorf("cannot recover backup credentials: %w", err)
+	}
+
+	options, err := barmanCommand.AppendCloudProviderOptionsFromConfiguration(
+		ctx,
+		[]string{"-c", dumpUploadScript},
+		configuration)
+	if err != nil {
+		return err
+	}
+	if configuration.EndpointURL != "" {
+		options = append(options, "--endpoint-url", configuration.EndpointURL)
+	}
+	options = append(options, localDirectory, destinationPath)
+
+	uploadCmd := exec.Command(python, options...) // #nosec G204
+	uploadCmd.Env = env
+	return execlog.RunStreaming(uploadCmd, commandName)
+}
+
+// buildDumpCommands gets the pg_dump or pg_dumpall invocations needed to
+// dump the requested databases in the passed directory
+func buildDumpCommands(
+	scheduledDump *apiv1.ScheduledDump,
+	directory string,
+	getDsn func(dbname string) string,
+) [][]string {
+	if scheduledDump.IsDumpAll() {
+		return [][]string{{
+			pgDumpAll,
+			"-d", getDsn("postgres"),
+			"-f", path.Join(directory, dumpAllFileName),
+		}}
+	}
+
+	format := scheduledDump.GetFormat()
+	result := make([][]string, 0, len(scheduledDump.Spec.Databases))
+	for _, database := range scheduledDump.Spec.Databases {
+		options := []string{
+			pgDump,
+			"-d", getDsn(database),
+			"-F", string(format),
+			"-f", path.Join(directory, getDumpFileName(database, format, scheduledDump.Spec.Compression)),
+		}
+		if scheduledDump.Spec.Compression != nil {
+			options = append(options, "-Z", strconv.Itoa(*scheduledDump.Spec.Compression))
+		}
+		if scheduledDump.Spec.Jobs != nil {
+			options = append(options, "-j", strconv.Itoa(*scheduledDump.Spec.Jobs))
+		}
+		result = append(result, options)
+	}
+
+	return result
+}
+
+// getDumpFileName gets the name of the archive where a database is dumped
+func getDumpFileName(database string, format apiv1.DumpFormat, compression *int) string {
+	switch format {
+	case apiv1.DumpFormatDirectory:
+		return database
+	case apiv1.DumpFormatTar:
+		return database + ".tar"
+	case apiv1.DumpFormatPlain:
+		// pg_dump compresses plain-text scripts with gzip
+		if compression != nil && *compression > 0 {
+			return database + ".sql.gz"
+		}
+		return database + ".sql"
+	default:
+		return database + ".dump"
+	}
+}
+
+// getDumpDestinationPath gets the path in the object store where a dump
+// is stored. Dumps are kept next to the base backups of the cluster, in
+// a different folder for every execution
+func getDumpDestinationPath(
+	configuration *apiv1.BarmanObjectStoreConfiguration,
+	clusterName string,
+	scheduledDumpName string,
+	startTime time.Time,
+) string {
+	serverName := configuration.ServerName
+	if serverName == "" {
+		serverName = clusterName
+	}
+
+	return strings.Join([]string{
+		strings.TrimSuffix(configuration.DestinationPath, "/"),
+		serverName,
+		"dumps",
+		scheduledDumpName,
+		pgTime.ToCompactISO8601(startTime),
+	}, "/")
+}
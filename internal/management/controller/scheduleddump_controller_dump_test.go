@@ -0,0 +1,87 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduled dump commands", func() {
+	getDsn := func(dbname string) string {
+		return "dbname=" + dbname
+	}
+
+	It("dumps the whole instance with pg_dumpall", func() {
+		scheduledDump := &apiv1.ScheduledDump{}
+		Expect(buildDumpCommands(scheduledDump, "/dumps", getDsn)).To(Equal([][]string{
+			{"pg_dumpall", "-d", "dbname=postgres", "-f", "/dumps/all.sql"},
+		}))
+	})
+
+	It("dumps every database with pg_dump", func() {
+		scheduledDump := &apiv1.ScheduledDump{
+			Spec: apiv1.ScheduledDumpSpec{
+				Databases:   []string{"app", "reports"},
+				Compression: ptr.To(6),
+			},
+		}
+		Expect(buildDumpCommands(scheduledDump, "/dumps", getDsn)).To(Equal([][]string{
+			{"pg_dump", "-d", "dbname=app", "-F", "custom", "-f", "/dumps/app.dump", "-Z", "6"},
+			{"pg_dump", "-d", "dbname=reports", "-F", "custom", "-f", "/dumps/reports.dump", "-Z", "6"},
+		}))
+	})
+
+	It("dumps in parallel with the directory format", func() {
+		scheduledDump := &apiv1.ScheduledDump{
+			Spec: apiv1.ScheduledDumpSpec{
+				Databases: []string{"app"},
+				Format:    apiv1.DumpFormatDirectory,
+				Jobs:      ptr.To(4),
+			},
+		}
+		Expect(buildDumpCommands(scheduledDump, "/dumps", getDsn)).To(Equal([][]string{
+			{"pg_dump", "-d", "dbname=app", "-F", "directory", "-f", "/dumps/app", "-j", "4"},
+		}))
+	})
+
+	It("names the archives after the format", func() {
+		Expect(getDumpFileName("app", apiv1.DumpFormatTar, nil)).To(Equal("app.tar"))
+		Expect(getDumpFileName("app", apiv1.DumpFormatPlain, nil)).To(Equal("app.sql"))
+		Expect(getDumpFileName("app", apiv1.DumpFormatPlain, ptr.To(0))).To(Equal("app.sql"))
+		Expect(getDumpFileName("app", apiv1.DumpFormatPlain, ptr.To(5))).To(Equal("app.sql.gz"))
+	})
+
+	It("stores every dump in a different folder of the object store", func() {
+		startTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		configuration := &apiv1.BarmanObjectStoreConfiguration{
+			DestinationPath: "s3://backups/",
+		}
+		Expect(getDumpDestinationPath(configuration, "cluster-example", "nightly", startTime)).
+			To(Equal("s3://backups/cluster-example/dumps/nightly/20240102030405"))
+
+		configuration.ServerName = "old-cluster"
+		Expect(getDumpDestinationPath(configuration, "cluster-example", "nightly", startTime)).
+			To(Equal("s3://backups/old-cluster/dumps/nightly/20240102030405"))
+	})
+})
@@ -0,0 +1,212 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduled dump controller tests", func() {
+	var (
+		scheduledDump *apiv1.ScheduledDump
+		cluster       *apiv1.Cluster
+		r             *ScheduledDumpReconciler
+		fakeClient    client.Client
+		dumpsTaken    int
+		dumpErr       error
+	)
+
+	request := func() ctrl.Request {
+		return ctrl.Request{NamespacedName: types.NamespacedName{
+			Namespace: scheduledDump.Namespace,
+			Name:      scheduledDump.Name,
+		}}
+	}
+
+	getScheduledDump := func(ctx context.Context) *apiv1.ScheduledDump {
+		var result apiv1.ScheduledDump
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(scheduledDump), &result)).To(Succeed())
+		return &result
+	}
+
+	BeforeEach(func() {
+		dumpsTaken = 0
+		dumpErr = nil
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		scheduledDump = &apiv1.ScheduledDump{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly",
+				Namespace: "default",
+			},
+			Spec: apiv1.ScheduledDumpSpec{
+				Schedule:  "0 0 0 * * *",
+				Cluster:   apiv1.LocalObjectReference{Name: cluster.Name},
+				Databases: []string{"app"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		pgInstance := postgres.NewInstance().
+			WithNamespace("default").
+			WithPodName("cluster-example-1").
+			WithClusterName("cluster-example")
+
+		fakeClient = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster, scheduledDump).
+			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.ScheduledDump{}).
+			Build()
+
+		r = &ScheduledDumpReconciler{
+			Client:   fakeClient,
+			instance: pgInstance,
+			takeDump: func(
+				_ context.Context,
+				_ *apiv1.Cluster,
+				_ *apiv1.ScheduledDump,
+				_ time.Time,
+			) (string, error) {
+				dumpsTaken++
+				if dumpErr != nil {
+					return "", dumpErr
+				}
+				return "s3://backups/cluster-example/dumps/nightly/20240101000000", nil
+			},
+		}
+	})
+
+	It("waits for the first scheduled time", func(ctx SpecContext) {
+		result, err := r.Reconcile(ctx, request())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(dumpsTaken).To(BeZero())
+
+		updatedDump := getScheduledDump(ctx)
+		Expect(updatedDump.Status.LastCheckTime).ToNot(BeNil())
+		Expect(updatedDump.Status.NextScheduleTime).ToNot(BeNil())
+		Expect(updatedDump.Status.LastDump).To(BeNil())
+	})
+
+	When("the dump is immediate", func() {
+		BeforeEach(func() {
+			scheduledDump.Spec.Immediate = ptr.To(true)
+		})
+
+		It("takes the dump and records its destination", func(ctx SpecContext) {
+			result, err := r.Reconcile(ctx, request())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(dumpsTaken).To(Equal(1))
+
+			lastDump := getScheduledDump(ctx).Status.LastDump
+			Expect(lastDump).ToNot(BeNil())
+			Expect(lastDump.Phase).To(Equal(apiv1.DumpPhaseCompleted))
+			Expect(lastDump.InstanceName).To(Equal("cluster-example-1"))
+			Expect(lastDump.DestinationPath).To(Equal("s3://backups/cluster-example/dumps/nightly/20240101000000"))
+			Expect(lastDump.StoppedAt).ToNot(BeNil())
+		})
+
+		It("records the failure of the dump", func(ctx SpecContext) {
+			dumpErr = errors.New("pg_dump failed")
+
+			_, err := r.Reconcile(ctx, request())
+			Expect(err).ToNot(HaveOccurred())
+
+			lastDump := getScheduledDump(ctx).Status.LastDump
+			Expect(lastDump.Phase).To(Equal(apiv1.DumpPhaseFailed))
+			Expect(lastDump.Error).To(Equal("pg_dump failed"))
+		})
+
+	})
+
+	When("the scheduled dump is suspended", func() {
+		BeforeEach(func() {
+			scheduledDump.Spec.Immediate = ptr.To(true)
+			scheduledDump.Spec.Suspend = ptr.To(true)
+		})
+
+		It("doesn't take the dump", func(ctx SpecContext) {
+			_, err := r.Reconcile(ctx, request())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dumpsTaken).To(BeZero())
+		})
+	})
+
+	When("this instance is not the primary", func() {
+		BeforeEach(func() {
+			scheduledDump.Spec.Immediate = ptr.To(true)
+			cluster.Status.CurrentPrimary = "cluster-example-2"
+			cluster.Status.TargetPrimary = "cluster-example-2"
+		})
+
+		It("doesn't take the dump", func(ctx SpecContext) {
+			result, err := r.Reconcile(ctx, request())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(scheduledDumpReconciliationInterval))
+			Expect(dumpsTaken).To(BeZero())
+		})
+	})
+
+	When("a dump was running when the instance manager stopped", func() {
+		BeforeEach(func() {
+			now := metav1.Now()
+			scheduledDump.Status = apiv1.ScheduledDumpStatus{
+				LastCheckTime: &now,
+				LastDump: &apiv1.DumpStatus{
+					Phase:        apiv1.DumpPhaseRunning,
+					InstanceName: "cluster-example-1",
+					StartedAt:    &now,
+				},
+			}
+		})
+
+		It("marks it as failed", func(ctx SpecContext) {
+			_, err := r.Reconcile(ctx, request())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dumpsTaken).To(BeZero())
+
+			lastDump := getScheduledDump(ctx).Status.LastDump
+			Expect(lastDump.Phase).To(Equal(apiv1.DumpPhaseFailed))
+			Expect(lastDump.Error).To(Equal(errDumpInterrupted.Error()))
+		})
+	})
+})
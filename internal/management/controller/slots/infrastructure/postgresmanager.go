@@ -93,7 +93,7 @@ func Create(ctx context.Context, db *sql.DB, slot ReplicationSlot) error {
 	contextLog.Trace("Invoked", "slot", slot)
 
 	_, err := db.ExecContext(ctx, "SELECT pg_create_physical_replication_slot($1, $2)",
-		slot.SlotName, slot.RestartLSN != "")
+		slot.SlotName, slot.RestartLSN != "" || slot.ReserveWAL)
 	return err
 }
 
@@ -30,6 +30,7 @@ type ReplicationSlot struct {
 	RestartLSN string   `json:"restartLSN,omitempty"`
 	IsHA       bool     `json:"isHA,omitempty"`
 	HoldsXmin  bool     `json:"holdsXmin,omitempty"`
+	ReserveWAL bool     `json:"reserveWAL,omitempty"`
 }
 
 // ReplicationSlotList contains a list of replication slots
@@ -43,6 +43,15 @@ func ReconcileReplicationSlots(
 
 	isPrimary := cluster.Status.CurrentPrimary == instanceName || cluster.Status.TargetPrimary == instanceName
 
+	// The additional replication slots are created on the primary instance
+	// independently of the HA replication slots feature. This also recreates
+	// them on a new primary after a failover or a switchover
+	if isPrimary {
+		if err := reconcilePrimaryAdditionalReplicationSlots(ctx, db, cluster); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	// If the HA replication slots feature is turned off, we will remove all the HA
 	// replication slots on both the primary and standby servers.
 	// NOTE: If both the HA replication slots and the user defined replication slots features are disabled,
@@ -129,6 +138,44 @@ func reconcilePrimaryHAReplicationSlots(
 	return reconcile.Result{}, nil
 }
 
+// reconcilePrimaryAdditionalReplicationSlots creates the missing additional
+// replication slots on the primary instance. The slots that are not declared
+// anymore are not dropped, as they can't be told apart from the ones created
+// by the user
+func reconcilePrimaryAdditionalReplicationSlots(
+	ctx context.Context,
+	db *sql.DB,
+	cluster *apiv1.Cluster,
+) error {
+	if len(cluster.Spec.ReplicationSlots.Additional) == 0 {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Debug("Updating primary additional replication slots")
+
+	currentSlots, err := infrastructure.List(ctx, db, cluster.Spec.ReplicationSlots)
+	if err != nil {
+		return fmt.Errorf("reconciling primary additional replication slots: %w", err)
+	}
+
+	for _, slot := range cluster.Spec.ReplicationSlots.Additional {
+		if currentSlots.Has(slot.Name) {
+			continue
+		}
+
+		contextLogger.Info("Creating additional replication slot", "slotName", slot.Name)
+		if err := infrastructure.Create(ctx, db, infrastructure.ReplicationSlot{
+			SlotName:   slot.Name,
+			ReserveWAL: true,
+		}); err != nil {
+			return fmt.Errorf("creating additional replication slot %q: %w", slot.Name, err)
+		}
+	}
+
+	return nil
+}
+
 // dropReplicationSlots cleans up the HA replication slots when the feature is disabled.
 // If both the HA replication slots and the user defined replication slots features are disabled,
 // we also clean up the slots that fall under the user defined replication slots feature here.
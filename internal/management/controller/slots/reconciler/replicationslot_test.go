@@ -207,3 +207,73 @@ var _ = Describe("dropReplicationSlots", func() {
 		Expect(res.RequeueAfter).To(Equal(time.Duration(0)))
 	})
 })
+
+var _ = Describe("Additional replication slots reconciliation in Primary", func() {
+	const selectPgRepSlot = "^SELECT (.+) FROM pg_replication_slots"
+
+	var (
+		db      *sql.DB
+		mock    sqlmock.Sqlmock
+		cluster apiv1.Cluster
+	)
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster = makeClusterWithInstanceNames([]string{"instance1"}, "instance1")
+		cluster.Spec.ReplicationSlots.Additional = []apiv1.AdditionalReplicationSlot{
+			{Name: "external_standby"},
+			{Name: "dms_task"},
+		}
+	})
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("creates the missing additional replication slots reserving the WAL", func(ctx SpecContext) {
+		mock.ExpectQuery(selectPgRepSlot).
+			WillReturnRows(sqlmock.NewRows(repSlotColumns).
+				AddRow("external_standby", string(infrastructure.SlotTypePhysical), true, "0/3000000", false))
+		mock.ExpectExec("SELECT pg_create_physical_replication_slot").
+			WithArgs("dms_task", true).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(selectPgRepSlot).
+			WillReturnRows(sqlmock.NewRows(repSlotColumns))
+
+		_, err := ReconcileReplicationSlots(ctx, "instance1", db, &cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("creates the additional replication slots when the HA ones are disabled", func(ctx SpecContext) {
+		cluster.Spec.ReplicationSlots.HighAvailability.Enabled = ptr.To(false)
+		cluster.Spec.ReplicationSlots.Additional = cluster.Spec.ReplicationSlots.Additional[:1]
+
+		mock.ExpectQuery(selectPgRepSlot).
+			WillReturnRows(sqlmock.NewRows(repSlotColumns))
+		mock.ExpectExec("SELECT pg_create_physical_replication_slot").
+			WithArgs("external_standby", true).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(selectPgRepSlot).
+			WillReturnRows(sqlmock.NewRows(repSlotColumns))
+
+		_, err := ReconcileReplicationSlots(ctx, "instance1", db, &cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("returns an error when the creation fails", func(ctx SpecContext) {
+		mock.ExpectQuery(selectPgRepSlot).
+			WillReturnRows(sqlmock.NewRows(repSlotColumns))
+		mock.ExpectExec("SELECT pg_create_physical_replication_slot").
+			WithArgs("external_standby", true).
+			WillReturnError(errors.New("creation error"))
+
+		_, err := ReconcileReplicationSlots(ctx, "instance1", db, &cluster)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("does nothing on the standby instances", func(ctx SpecContext) {
+		_, err := ReconcileReplicationSlots(ctx, "instance2", db, &cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,277 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package safeguard
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// safeguardInterval is the interval between two checks
+// of the replication slots
+const safeguardInterval = 30 * time.Second
+
+// replicationSlot is the state of a replication slot of the primary instance
+type replicationSlot struct {
+	name             string
+	slotType         string
+	active           bool
+	retainedWALBytes int64
+
+	// The time when the slot has been first seen inactive
+	inactiveSince time.Time
+}
+
+// A Safeguard is a runner that periodically checks the replication slots
+// of the primary instance, reporting the ones exceeding the limits set in
+// the cluster and, when requested, dropping them
+type Safeguard struct {
+	instance *postgres.Instance
+	client   client.Client
+
+	// The time when each replication slot has been first seen inactive
+	inactiveSince map[string]time.Time
+}
+
+// NewSafeguard creates a new replication slots Safeguard
+func NewSafeguard(instance *postgres.Instance, client client.Client) *Safeguard {
+	return &Safeguard{
+		instance:      instance,
+		client:        client,
+		inactiveSince: make(map[string]time.Time),
+	}
+}
+
+// Start starts running the replication slots Safeguard
+func (s *Safeguard) Start(ctx context.Context) error {
+	contextLog := log.FromContext(ctx).WithName("replication_slots_safeguard")
+	go func() {
+		ticker := time.NewTicker(safeguardInterval)
+
+		defer func() {
+			ticker.Stop()
+			contextLog.Info("Terminated replication slots Safeguard loop")
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := s.reconcile(ctx); err != nil {
+				contextLog.Error(err, "checking the replication slots")
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile checks the replication slots of the primary instance against
+// the limits set in the cluster, drops the exceeding ones when requested,
+// and reports the result in the cluster status
+func (s *Safeguard) reconcile(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := s.client.Get(ctx, client.ObjectKey{
+		Namespace: s.instance.GetNamespaceName(),
+		Name:      s.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return err
+	}
+
+	if cluster.Spec.ReplicationSlots == nil || !cluster.Spec.ReplicationSlots.Safeguard.GetEnabled() {
+		return nil
+	}
+	safeguard := cluster.Spec.ReplicationSlots.Safeguard
+
+	isPrimary, err := s.instance.IsPrimary()
+	if err != nil || !isPrimary {
+		return err
+	}
+
+	db, err := s.instance.GetSuperUserDB()
+	if err != nil {
+		return err
+	}
+
+	slots, err := listReplicationSlots(ctx, db)
+	if err != nil {
+		return fmt.Errorf("while listing the replication slots: %w", err)
+	}
+	s.trackInactivity(slots, time.Now())
+
+	exceedingSlots := getExceedingSlots(s.withInactivity(slots), safeguard, time.Now())
+	if safeguard.GetAction() != apiv1.ReplicationSlotsSafeguardActionDrop {
+		return status.PatchConditionsWithOptimisticLock(
+			ctx,
+			s.client,
+			&cluster,
+			buildReplicationSlotsCondition(exceedingSlots),
+		)
+	}
+
+	var remainingSlots []replicationSlot
+	haSlotPrefix := cluster.Spec.ReplicationSlots.HighAvailability.GetSlotPrefix()
+	for _, slot := range exceedingSlots {
+		// The replication slots for high availability and the additional
+		// ones are managed by the operator, and the active ones can't be dropped
+		if slot.active || strings.HasPrefix(slot.name, haSlotPrefix) ||
+			cluster.Spec.ReplicationSlots.IsAdditional(slot.name) {
+			remainingSlots = append(remainingSlots, slot)
+			continue
+		}
+
+		contextLogger.Warning("Dropping the replication slot exceeding the limits",
+			"slotName", slot.name,
+			"slotType", slot.slotType,
+			"retainedWALBytes", slot.retainedWALBytes,
+			"inactiveSince", slot.inactiveSince)
+		if _, err := db.ExecContext(ctx, "SELECT pg_catalog.pg_drop_replication_slot($1)", slot.name); err != nil {
+			contextLogger.Error(err, "while dropping the replication slot", "slotName", slot.name)
+			remainingSlots = append(remainingSlots, slot)
+		}
+	}
+
+	return status.PatchConditionsWithOptimisticLock(
+		ctx,
+		s.client,
+		&cluster,
+		buildReplicationSlotsCondition(remainingSlots),
+	)
+}
+
+// trackInactivity records when each replication slot has been
+// first seen inactive, forgetting the ones that don't exist anymore
+func (s *Safeguard) trackInactivity(slots []replicationSlot, now time.Time) {
+	inactiveSince := make(map[string]time.Time, len(slots))
+	for _, slot := range slots {
+		if slot.active {
+			continue
+		}
+
+		if since, ok := s.inactiveSince[slot.name]; ok {
+			inactiveSince[slot.name] = since
+		} else {
+			inactiveSince[slot.name] = now
+		}
+	}
+	s.inactiveSince = inactiveSince
+}
+
+// withInactivity fills the time each replication slot has been first seen inactive
+func (s *Safeguard) withInactivity(slots []replicationSlot) []replicationSlot {
+	for idx := range slots {
+		slots[idx].inactiveSince = s.inactiveSince[slots[idx].name]
+	}
+	return slots
+}
+
+// listReplicationSlots lists the replication slots of the instance,
+// including the size of the WAL files they retain
+func listReplicationSlots(ctx context.Context, db *sql.DB) ([]replicationSlot, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT slot_name, slot_type, active,
+            COALESCE(pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_current_wal_lsn(), restart_lsn), 0)::bigint
+            FROM pg_catalog.pg_replication_slots
+            WHERE NOT temporary`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []replicationSlot
+	for rows.Next() {
+		var slot replicationSlot
+		if err := rows.Scan(&slot.name, &slot.slotType, &slot.active, &slot.retainedWALBytes); err != nil {
+			return nil, err
+		}
+		result = append(result, slot)
+	}
+
+	return result, rows.Err()
+}
+
+// getExceedingSlots gets the replication slots retaining more WAL than
+// allowed, or being inactive for longer than allowed
+func getExceedingSlots(
+	slots []replicationSlot,
+	safeguard *apiv1.ReplicationSlotsSafeguardConfiguration,
+	now time.Time,
+) []replicationSlot {
+	maxRetainedWALBytes := safeguard.GetMaxRetainedWALBytes()
+	maxInactiveTime := safeguard.GetMaxInactiveTime()
+
+	var result []replicationSlot
+	for _, slot := range slots {
+		exceedsRetainedWAL := maxRetainedWALBytes > 0 && slot.retainedWALBytes > maxRetainedWALBytes
+		exceedsInactiveTime := maxInactiveTime > 0 && !slot.active && !slot.inactiveSince.IsZero() &&
+			now.Sub(slot.inactiveSince) > maxInactiveTime
+		if exceedsRetainedWAL || exceedsInactiveTime {
+			result = append(result, slot)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+// buildReplicationSlotsCondition computes the ReplicationSlotsHealthy
+// condition given the replication slots exceeding the limits
+func buildReplicationSlotsCondition(exceedingSlots []replicationSlot) metav1.Condition {
+	if len(exceedingSlots) == 0 {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionReplicationSlotsHealthy),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonReplicationSlotsWithinLimits),
+			Message: "Every replication slot is within the limits",
+		}
+	}
+
+	slotNames := make([]string, len(exceedingSlots))
+	for idx, slot := range exceedingSlots {
+		slotNames[idx] = slot.name
+	}
+	return metav1.Condition{
+		Type:   string(apiv1.ConditionReplicationSlotsHealthy),
+		Status: metav1.ConditionFalse,
+		Reason: string(apiv1.ConditionReasonReplicationSlotsExceedingLimits),
+		Message: fmt.Sprintf("Replication slots exceeding the limits: %s",
+			strings.Join(slotNames, ", ")),
+	}
+}
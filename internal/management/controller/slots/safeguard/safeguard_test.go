@@ -0,0 +1,78 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package safeguard
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Replication slots safeguard", func() {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	safeguard := &apiv1.ReplicationSlotsSafeguardConfiguration{
+		Enabled:            true,
+		MaxRetainedWALSize: "1Gi",
+		MaxInactiveTime:    &metav1.Duration{Duration: time.Hour},
+	}
+
+	It("finds the slots exceeding the limits", func() {
+		slots := []replicationSlot{
+			{name: "within_limits", active: true, retainedWALBytes: 1024},
+			{name: "retaining_wal", active: true, retainedWALBytes: 2 * 1024 * 1024 * 1024},
+			{name: "inactive", inactiveSince: now.Add(-2 * time.Hour)},
+			{name: "recently_inactive", inactiveSince: now.Add(-time.Minute)},
+		}
+
+		exceedingSlots := getExceedingSlots(slots, safeguard, now)
+		Expect(exceedingSlots).To(HaveLen(2))
+		Expect(exceedingSlots[0].name).To(Equal("inactive"))
+		Expect(exceedingSlots[1].name).To(Equal("retaining_wal"))
+	})
+
+	It("tracks when the slots have been first seen inactive", func() {
+		s := NewSafeguard(nil, nil)
+		s.trackInactivity([]replicationSlot{{name: "first"}, {name: "second", active: true}}, now)
+		Expect(s.inactiveSince).To(Equal(map[string]time.Time{"first": now}))
+
+		s.trackInactivity([]replicationSlot{{name: "first"}, {name: "second"}}, now.Add(time.Minute))
+		Expect(s.inactiveSince).To(Equal(map[string]time.Time{
+			"first":  now,
+			"second": now.Add(time.Minute),
+		}))
+
+		s.trackInactivity([]replicationSlot{{name: "second", active: true}}, now.Add(2*time.Minute))
+		Expect(s.inactiveSince).To(BeEmpty())
+	})
+
+	It("reports the slots exceeding the limits in the condition", func() {
+		condition := buildReplicationSlotsCondition(nil)
+		Expect(condition.Type).To(Equal(string(apiv1.ConditionReplicationSlotsHealthy)))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+		condition = buildReplicationSlotsCondition([]replicationSlot{{name: "first"}, {name: "second"}})
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonReplicationSlotsExceedingLimits)))
+		Expect(condition.Message).To(ContainSubstring("first, second"))
+	})
+})
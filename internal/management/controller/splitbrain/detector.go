@@ -0,0 +1,267 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splitbrain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// detectionInterval is the interval between two checks of the
+// source cluster
+const detectionInterval = 30 * time.Second
+
+// walPosition is the position in the WAL stream of a primary server
+type walPosition struct {
+	// The current WAL location
+	lsn types.LSN
+
+	// The WAL file containing the current WAL location, whose
+	// first eight characters are the current timeline
+	walFile string
+}
+
+// timeline gets the timeline of the WAL position
+func (position walPosition) timeline() string {
+	if len(position.walFile) < 8 {
+		return ""
+	}
+
+	return position.walFile[:8]
+}
+
+// A Detector is a runner that periodically checks, from the primary
+// instance, whether the source cluster of the distributed topology
+// is running as primary too
+type Detector struct {
+	instance *postgres.Instance
+	client   client.Client
+}
+
+// NewDetector creates a new split-brain Detector
+func NewDetector(instance *postgres.Instance, client client.Client) *Detector {
+	runner := &Detector{
+		instance: instance,
+		client:   client,
+	}
+	return runner
+}
+
+// Start starts running the split-brain Detector
+func (d *Detector) Start(ctx context.Context) error {
+	contextLog := log.FromContext(ctx).WithName("split_brain_detector")
+	go func() {
+		ticker := time.NewTicker(detectionInterval)
+
+		defer func() {
+			ticker.Stop()
+			contextLog.Info("Terminated split-brain Detector loop")
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := d.reconcile(ctx); err != nil {
+				contextLog.Error(err, "checking for a split-brain with the source cluster")
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile compares the position of this cluster with the one of the
+// source cluster, and reports the result in the cluster status
+func (d *Detector) reconcile(ctx context.Context) error {
+	var cluster apiv1.Cluster
+	if err := d.client.Get(ctx, client.ObjectKey{
+		Namespace: d.instance.GetNamespaceName(),
+		Name:      d.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return err
+	}
+
+	peer, ok := getPeer(&cluster)
+	if !ok {
+		return nil
+	}
+
+	// A fenced instance is not running PostgreSQL, and the
+	// last reported result is kept until the fencing is removed
+	if d.instance.IsFenced() {
+		return nil
+	}
+
+	// Only the primary instance can be part of a split-brain
+	isPrimary, err := d.instance.IsPrimary()
+	if err != nil || !isPrimary {
+		return err
+	}
+
+	local, err := d.getLocalPosition()
+	if err != nil {
+		return err
+	}
+
+	peerPosition, err := getPeerPosition(ctx, &peer)
+	if err != nil {
+		return fmt.Errorf("while querying the source cluster %s: %w", peer.Name, err)
+	}
+
+	condition, err := buildSplitBrainCondition(&cluster, peer.Name, local, peerPosition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		log.FromContext(ctx).Warning("Split-brain detected",
+			"sourceCluster", peer.Name,
+			"lsn", local.lsn,
+			"sourceClusterLSN", peerPosition.lsn,
+			"reason", condition.Reason)
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, d.client, &cluster, condition)
+}
+
+// getPeer gets the external cluster which is expected to be a replica when
+// this cluster is the primary one of the distributed topology
+func getPeer(cluster *apiv1.Cluster) (apiv1.ExternalCluster, bool) {
+	if cluster.Spec.ReplicaCluster == nil || cluster.IsReplica() {
+		return apiv1.ExternalCluster{}, false
+	}
+
+	return cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
+}
+
+// getLocalPosition gets the position of the local primary instance
+func (d *Detector) getLocalPosition() (*walPosition, error) {
+	db, err := d.instance.GetSuperUserDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var position walPosition
+	row := db.QueryRow("SELECT lsn, pg_catalog.pg_walfile_name(lsn) FROM pg_catalog.pg_current_wal_lsn() lsn")
+	if err := row.Scan(&position.lsn, &position.walFile); err != nil {
+		return nil, fmt.Errorf("while reading the current WAL location: %w", err)
+	}
+
+	return &position, nil
+}
+
+// getPeerPosition gets the position of the passed external cluster, or
+// nil if it is running as a replica
+func getPeerPosition(ctx context.Context, peer *apiv1.ExternalCluster) (*walPosition, error) {
+	peerPool := pool.NewPostgresqlConnectionPool(external.GetServerConnectionString(peer, ""))
+	defer peerPool.ShutdownConnections()
+
+	db, err := peerPool.Connection("postgres")
+	if err != nil {
+		return nil, err
+	}
+
+	var isInRecovery bool
+	var lsn, walFile sql.NullString
+	row := db.QueryRowContext(ctx,
+		`SELECT
+			pg_catalog.pg_is_in_recovery(),
+			CASE WHEN pg_catalog.pg_is_in_recovery() THEN NULL ELSE pg_catalog.pg_current_wal_lsn() END,
+			CASE WHEN pg_catalog.pg_is_in_recovery() THEN NULL
+				ELSE pg_catalog.pg_walfile_name(pg_catalog.pg_current_wal_lsn()) END`)
+	if err := row.Scan(&isInRecovery, &lsn, &walFile); err != nil {
+		return nil, err
+	}
+
+	if isInRecovery {
+		return nil, nil
+	}
+
+	return &walPosition{lsn: types.LSN(lsn.String), walFile: walFile.String}, nil
+}
+
+// buildSplitBrainCondition builds the condition reporting whether this
+// cluster and the source cluster are both running as primary. When that
+// happens, the cluster which is less advanced is the one to be fenced,
+// and ties are broken using the names of the clusters
+func buildSplitBrainCondition(
+	cluster *apiv1.Cluster,
+	peerName string,
+	local *walPosition,
+	peer *walPosition,
+) (metav1.Condition, error) {
+	if peer == nil {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionSplitBrainDetected),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonSplitBrainNotDetected),
+			Message: fmt.Sprintf("The source cluster %s is running as a replica", peerName),
+		}, nil
+	}
+
+	localLSN, err := local.lsn.Parse()
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+	peerLSN, err := peer.lsn.Parse()
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+
+	description := fmt.Sprintf(
+		"Both this cluster (timeline %s, LSN %s) and the source cluster %s (timeline %s, LSN %s) "+
+			"are running as primary",
+		local.timeline(), local.lsn, peerName, peer.timeline(), peer.lsn)
+
+	lessAdvanced := localLSN < peerLSN || (localLSN == peerLSN && cluster.GetSelfName() > peerName)
+	if lessAdvanced {
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionSplitBrainDetected),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonSplitBrainLessAdvanced),
+			Message: description + fmt.Sprintf(
+				". The primary instance of this cluster has been fenced. Demote this cluster setting "+
+					"`.spec.replica.primary` to %s, and set `.spec.replica.onTimelineDivergence` to `rewind` "+
+					"to realign it with pg_rewind", peerName),
+		}, nil
+	}
+
+	return metav1.Condition{
+		Type:   string(apiv1.ConditionSplitBrainDetected),
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonSplitBrainMoreAdvanced),
+		Message: description + fmt.Sprintf(
+			". The source cluster %s is less advanced and is expected to be fenced and demoted", peerName),
+	}, nil
+}
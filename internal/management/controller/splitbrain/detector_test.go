@@ -0,0 +1,100 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splitbrain
+
+import (
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getPeer", func() {
+	It("returns the source cluster of a primary cluster", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"},
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Primary: "cluster-a",
+					Source:  "cluster-b",
+				},
+				ExternalClusters: []apiv1.ExternalCluster{{Name: "cluster-b"}},
+			},
+		}
+		peer, ok := getPeer(cluster)
+		Expect(ok).To(BeTrue())
+		Expect(peer.Name).To(Equal("cluster-b"))
+	})
+
+	It("ignores replica clusters and clusters outside a distributed topology", func() {
+		cluster := &apiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"}}
+		_, ok := getPeer(cluster)
+		Expect(ok).To(BeFalse())
+
+		cluster.Spec.ReplicaCluster = &apiv1.ReplicaClusterConfiguration{
+			Primary: "cluster-b",
+			Source:  "cluster-b",
+		}
+		cluster.Spec.ExternalClusters = []apiv1.ExternalCluster{{Name: "cluster-b"}}
+		_, ok = getPeer(cluster)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("buildSplitBrainCondition", func() {
+	cluster := &apiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"}}
+	local := &walPosition{lsn: types.LSN("0/5000000"), walFile: "000000020000000000000005"}
+
+	It("reports no split-brain when the source cluster is a replica", func() {
+		condition, err := buildSplitBrainCondition(cluster, "cluster-b", local, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSplitBrainNotDetected)))
+	})
+
+	It("detects when this cluster is the less advanced one", func() {
+		peer := &walPosition{lsn: types.LSN("0/6000000"), walFile: "000000030000000000000006"}
+		condition, err := buildSplitBrainCondition(cluster, "cluster-b", local, peer)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSplitBrainLessAdvanced)))
+		Expect(condition.Message).To(ContainSubstring("timeline 00000002"))
+		Expect(condition.Message).To(ContainSubstring("timeline 00000003"))
+	})
+
+	It("detects when this cluster is the more advanced one", func() {
+		peer := &walPosition{lsn: types.LSN("0/4000000"), walFile: "000000030000000000000004"}
+		condition, err := buildSplitBrainCondition(cluster, "cluster-b", local, peer)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSplitBrainMoreAdvanced)))
+	})
+
+	It("breaks the ties using the names of the clusters", func() {
+		peer := &walPosition{lsn: local.lsn, walFile: local.walFile}
+		condition, err := buildSplitBrainCondition(cluster, "cluster-b", local, peer)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSplitBrainMoreAdvanced)))
+
+		condition, err = buildSplitBrainCondition(cluster, "cluster-0", local, peer)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonSplitBrainLessAdvanced)))
+	})
+})
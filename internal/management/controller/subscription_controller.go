@@ -42,6 +42,7 @@ type SubscriptionReconciler struct {
 	instance            *postgres.Instance
 	finalizerReconciler *finalizerReconciler[*apiv1.Subscription]
 	getDB               func(name string) (*sql.DB, error)
+	errorTracker        *SubscriptionErrorTracker
 }
 
 // subscriptionReconciliationInterval is the time between the
@@ -76,11 +77,6 @@ func (r *SubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
-	// If everything is reconciled, we're done here
-	if subscription.Generation == subscription.Status.ObservedGeneration {
-		return ctrl.Result{}, nil
-	}
-
 	// Fetch the Cluster from the cache
 	cluster, err := r.GetCluster(ctx)
 	if err != nil {
@@ -97,6 +93,17 @@ func (r *SubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{RequeueAfter: subscriptionReconciliationInterval}, nil
 	}
 
+	// If the specification is reconciled, we only need to keep
+	// monitoring the subscription
+	if subscription.Generation == subscription.Status.ObservedGeneration {
+		if !cluster.IsReplica() && subscription.GetDeletionTimestamp().IsZero() {
+			if err := r.reconcileSubscriptionStatus(ctx, &subscription); err != nil {
+				contextLogger.Error(err, "while monitoring subscription")
+			}
+		}
+		return ctrl.Result{RequeueAfter: subscriptionReconciliationInterval}, nil
+	}
+
 	contextLogger.Info("Reconciling subscription")
 	defer func() {
 		contextLogger.Info("Reconciliation loop of subscription exited")
@@ -180,6 +187,7 @@ func (r *SubscriptionReconciler) evaluateDropSubscription(ctx context.Context, s
 func NewSubscriptionReconciler(
 	mgr manager.Manager,
 	instance *postgres.Instance,
+	errorTracker *SubscriptionErrorTracker,
 ) *SubscriptionReconciler {
 	sr := &SubscriptionReconciler{
 		Client:   mgr.GetClient(),
@@ -187,6 +195,7 @@ func NewSubscriptionReconciler(
 		getDB: func(name string) (*sql.DB, error) {
 			return instance.ConnectionPool().Connection(name)
 		},
+		errorTracker: errorTracker,
 	}
 	sr.finalizerReconciler = newFinalizerReconciler(
 		mgr.GetClient(),
@@ -19,12 +19,20 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/lib/pq"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
 )
 
 func (r *SubscriptionReconciler) alignSubscription(
@@ -54,6 +62,13 @@ func (r *SubscriptionReconciler) alignSubscription(
 		return fmt.Errorf("while getting subscription status (scan): %w", err)
 	}
 
+	parameters, err := getSubscriptionParameters(db, obj)
+	if err != nil {
+		return err
+	}
+	obj = obj.DeepCopy()
+	obj.Spec.Parameters = parameters
+
 	if count > 0 {
 		if err := r.patchSubscription(ctx, db, obj, connString); err != nil {
 			return fmt.Errorf("while patching subscription: %w", err)
@@ -68,6 +83,30 @@ func (r *SubscriptionReconciler) alignSubscription(
 	return nil
 }
 
+// getSubscriptionParameters returns the parameters of the subscription,
+// adding the ones implementing its conflict policy
+func getSubscriptionParameters(db *sql.DB, obj *apiv1.Subscription) (map[string]string, error) {
+	if obj.Spec.ConflictPolicy != apiv1.SubscriptionConflictStop &&
+		obj.Spec.ConflictPolicy != apiv1.SubscriptionConflictSkip {
+		return obj.Spec.Parameters, nil
+	}
+
+	pgVersion, err := postgresutils.GetPgVersion(db)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the PostgreSQL version: %w", err)
+	}
+	if pgVersion.Major < 15 {
+		return nil, fmt.Errorf("conflict policy %q requires PostgreSQL 15 or later", obj.Spec.ConflictPolicy)
+	}
+
+	parameters := maps.Clone(obj.Spec.Parameters)
+	if parameters == nil {
+		parameters = make(map[string]string, 1)
+	}
+	parameters["disable_on_error"] = "true"
+	return parameters, nil
+}
+
 func (r *SubscriptionReconciler) patchSubscription(
 	ctx context.Context,
 	db *sql.DB,
@@ -148,3 +187,165 @@ func executeDropSubscription(ctx context.Context, db *sql.DB, name string) error
 
 	return nil
 }
+
+// subscriptionInfo is the status of a subscription as seen
+// by PostgreSQL
+type subscriptionInfo struct {
+	oid             uint32
+	enabled         bool
+	applyErrorCount int64
+	syncErrorCount  int64
+	lag             sql.NullFloat64
+}
+
+func getSubscriptionInfo(
+	ctx context.Context,
+	db *sql.DB,
+	name string,
+	withStats bool,
+) (*subscriptionInfo, error) {
+	errorCounters := "0, 0"
+	statsJoin := ""
+	if withStats {
+		errorCounters = "COALESCE(st.apply_error_count, 0), COALESCE(st.sync_error_count, 0)"
+		statsJoin = "LEFT JOIN pg_catalog.pg_stat_subscription_stats st ON st.subid = s.oid"
+	}
+
+	row := db.QueryRowContext(
+		ctx,
+		fmt.Sprintf(`
+		SELECT s.oid, s.subenabled, %s,
+			(SELECT EXTRACT(EPOCH FROM now() - max(latest_end_time))
+			FROM pg_catalog.pg_stat_subscription
+			WHERE subid = s.oid AND relid IS NULL)
+		FROM pg_catalog.pg_subscription s %s
+		WHERE s.subname = $1
+		AND s.subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = current_database())
+		`, errorCounters, statsJoin),
+		name)
+
+	var info subscriptionInfo
+	if err := row.Scan(
+		&info.oid,
+		&info.enabled,
+		&info.applyErrorCount,
+		&info.syncErrorCount,
+		&info.lag,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while getting subscription info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// reconcileSubscriptionStatus reports the status of the subscription
+// and, depending on its conflict policy, skips the failing transaction
+func (r *SubscriptionReconciler) reconcileSubscriptionStatus(
+	ctx context.Context,
+	obj *apiv1.Subscription,
+) error {
+	db, err := r.getDB(obj.Spec.DBName)
+	if err != nil {
+		return fmt.Errorf("while getting DB connection: %w", err)
+	}
+
+	pgVersion, err := postgresutils.GetPgVersion(db)
+	if err != nil {
+		return fmt.Errorf("while getting the PostgreSQL version: %w", err)
+	}
+
+	info, err := getSubscriptionInfo(ctx, db, obj.Spec.Name, pgVersion.Major >= 15)
+	if err != nil || info == nil {
+		return err
+	}
+
+	oldObj := obj.DeepCopy()
+	obj.Status.Enabled = ptr.To(info.enabled)
+	obj.Status.ApplyErrorCount = info.applyErrorCount
+	obj.Status.SyncErrorCount = info.syncErrorCount
+	obj.Status.Lag = nil
+	if info.lag.Valid {
+		obj.Status.Lag = &metav1.Duration{
+			Duration: time.Duration(info.lag.Float64 * float64(time.Second)).Round(time.Second),
+		}
+	}
+
+	var skipErr error
+	if applyError, ok := r.errorTracker.get(info.oid); ok {
+		obj.Status.LastApplyError = &apiv1.SubscriptionApplyError{
+			SQLState:  applyError.sqlState,
+			Message:   applyError.message,
+			FinishLSN: applyError.finishLSN,
+			Time:      metav1.NewTime(applyError.time),
+		}
+
+		if shouldSkipTransaction(obj, info, applyError) {
+			skipErr = skipSubscriptionTransaction(ctx, db, obj.Spec.Name, applyError.finishLSN)
+			if skipErr == nil {
+				obj.Status.Enabled = ptr.To(true)
+				obj.Status.SkippedTransactions++
+				obj.Status.LastSkippedLSN = applyError.finishLSN
+			}
+		}
+	}
+
+	return errors.Join(skipErr, r.Client.Status().Patch(ctx, obj, client.MergeFrom(oldObj)))
+}
+
+// shouldSkipTransaction checks if the transaction failing with the passed
+// error, which disabled the subscription, needs to be skipped
+func shouldSkipTransaction(
+	obj *apiv1.Subscription,
+	info *subscriptionInfo,
+	applyError subscriptionApplyError,
+) bool {
+	if obj.Spec.ConflictPolicy != apiv1.SubscriptionConflictSkip || info.enabled {
+		return false
+	}
+
+	// Never skip the same transaction twice
+	if applyError.finishLSN == obj.Status.LastSkippedLSN {
+		return false
+	}
+
+	return matchesErrorCode(obj.Spec.SkipErrorCodes, applyError.sqlState)
+}
+
+// matchesErrorCode checks if the SQLSTATE code matches one of the passed
+// error codes or error classes
+func matchesErrorCode(errorCodes []string, sqlState string) bool {
+	for _, errorCode := range errorCodes {
+		if errorCode == sqlState || (len(errorCode) == 2 && strings.HasPrefix(sqlState, errorCode)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skipSubscriptionTransaction skips the remote transaction finishing at
+// the passed LSN and enables the subscription again
+func skipSubscriptionTransaction(ctx context.Context, db *sql.DB, name string, finishLSN string) error {
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf(
+			"ALTER SUBSCRIPTION %s SKIP (lsn = %s)",
+			pgx.Identifier{name}.Sanitize(),
+			pq.QuoteLiteral(finishLSN),
+		),
+	); err != nil {
+		return fmt.Errorf("while skipping transaction: %w", err)
+	}
+
+	if _, err := db.ExecContext(
+		ctx,
+		fmt.Sprintf("ALTER SUBSCRIPTION %s ENABLE", pgx.Identifier{name}.Sanitize()),
+	); err != nil {
+		return fmt.Errorf("while enabling subscription: %w", err)
+	}
+
+	return nil
+}
@@ -166,4 +166,107 @@ var _ = Describe("subscription sql", func() {
 		Expect(sqls).To(ContainElement(`ALTER SUBSCRIPTION "test_sub" SET PUBLICATION "test_pub"`))
 		Expect(sqls).To(ContainElement(`ALTER SUBSCRIPTION "test_sub" CONNECTION 'host=localhost user=test dbname=test'`))
 	})
+
+	It("adds disable_on_error when the conflict policy stops the subscription", func() {
+		obj := &apiv1.Subscription{
+			Spec: apiv1.SubscriptionSpec{
+				Name:           "test_sub",
+				ConflictPolicy: apiv1.SubscriptionConflictSkip,
+				Parameters: map[string]string{
+					"param1": "value1",
+				},
+			},
+		}
+		dbMock.ExpectQuery("SHOW server_version_num").
+			WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow("160004"))
+
+		parameters, err := getSubscriptionParameters(db, obj)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(Equal(map[string]string{
+			"param1":           "value1",
+			"disable_on_error": "true",
+		}))
+		Expect(obj.Spec.Parameters).ToNot(HaveKey("disable_on_error"))
+	})
+
+	It("rejects the stop conflict policy before PostgreSQL 15", func() {
+		obj := &apiv1.Subscription{
+			Spec: apiv1.SubscriptionSpec{
+				Name:           "test_sub",
+				ConflictPolicy: apiv1.SubscriptionConflictStop,
+			},
+		}
+		dbMock.ExpectQuery("SHOW server_version_num").
+			WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow("140011"))
+
+		_, err := getSubscriptionParameters(db, obj)
+		Expect(err).To(MatchError(ContainSubstring("requires PostgreSQL 15 or later")))
+	})
+
+	It("leaves the parameters untouched with the log conflict policy", func() {
+		obj := &apiv1.Subscription{
+			Spec: apiv1.SubscriptionSpec{
+				Name:           "test_sub",
+				ConflictPolicy: apiv1.SubscriptionConflictLog,
+			},
+		}
+
+		parameters, err := getSubscriptionParameters(db, obj)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(BeEmpty())
+	})
+
+	It("matches error codes and error classes", func() {
+		Expect(matchesErrorCode([]string{"23505"}, "23505")).To(BeTrue())
+		Expect(matchesErrorCode([]string{"23"}, "23503")).To(BeTrue())
+		Expect(matchesErrorCode([]string{"23505"}, "23503")).To(BeFalse())
+		Expect(matchesErrorCode([]string{"42"}, "23505")).To(BeFalse())
+		Expect(matchesErrorCode(nil, "23505")).To(BeFalse())
+	})
+
+	It("skips a failing transaction only once and only with the skip policy", func() {
+		obj := &apiv1.Subscription{
+			Spec: apiv1.SubscriptionSpec{
+				Name:           "test_sub",
+				ConflictPolicy: apiv1.SubscriptionConflictSkip,
+				SkipErrorCodes: []string{"23505"},
+			},
+		}
+		applyError := subscriptionApplyError{sqlState: "23505", finishLSN: "0/14C0378"}
+
+		Expect(shouldSkipTransaction(obj, &subscriptionInfo{enabled: false}, applyError)).To(BeTrue())
+		Expect(shouldSkipTransaction(obj, &subscriptionInfo{enabled: true}, applyError)).To(BeFalse())
+
+		obj.Status.LastSkippedLSN = "0/14C0378"
+		Expect(shouldSkipTransaction(obj, &subscriptionInfo{enabled: false}, applyError)).To(BeFalse())
+
+		obj.Status.LastSkippedLSN = ""
+		obj.Spec.ConflictPolicy = apiv1.SubscriptionConflictStop
+		Expect(shouldSkipTransaction(obj, &subscriptionInfo{enabled: false}, applyError)).To(BeFalse())
+	})
+
+	It("skips the failing transaction and enables the subscription", func(ctx SpecContext) {
+		dbMock.ExpectExec(`ALTER SUBSCRIPTION "test_sub" SKIP (lsn = '0/14C0378')`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`ALTER SUBSCRIPTION "test_sub" ENABLE`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		Expect(skipSubscriptionTransaction(ctx, db, "test_sub", "0/14C0378")).To(Succeed())
+	})
+
+	It("returns nothing when the subscription does not exist", func(ctx SpecContext) {
+		dbMock.ExpectQuery(`
+		SELECT s.oid, s.subenabled, 0, 0,
+			(SELECT EXTRACT(EPOCH FROM now() - max(latest_end_time))
+			FROM pg_catalog.pg_stat_subscription
+			WHERE subid = s.oid AND relid IS NULL)
+		FROM pg_catalog.pg_subscription s
+		WHERE s.subname = $1
+		AND s.subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = current_database())
+		`).WithArgs("test_sub").WillReturnError(sql.ErrNoRows)
+
+		info, err := getSubscriptionInfo(ctx, db, "test_sub", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(BeNil())
+	})
 })
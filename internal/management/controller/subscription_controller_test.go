@@ -162,6 +162,52 @@ var _ = Describe("Managed subscription controller tests", func() {
 		Expect(subscription.GetFinalizers()).NotTo(BeEmpty())
 	})
 
+	It("skips the failing transaction once the specification is applied", func(ctx SpecContext) {
+		subscription.Spec.ConflictPolicy = apiv1.SubscriptionConflictSkip
+		subscription.Spec.SkipErrorCodes = []string{"23"}
+		Expect(fakeClient.Update(ctx, subscription)).To(Succeed())
+		subscription.Status.ObservedGeneration = subscription.Generation
+		Expect(fakeClient.Status().Update(ctx, subscription)).To(Succeed())
+
+		r.errorTracker = NewSubscriptionErrorTracker()
+		r.errorTracker.errors[16395] = subscriptionApplyError{
+			sqlState:  "23505",
+			message:   "duplicate key value violates unique constraint",
+			finishLSN: "0/14C0378",
+		}
+
+		dbMock.ExpectQuery("SHOW server_version_num").
+			WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow("160004"))
+		dbMock.ExpectQuery(`
+		SELECT s.oid, s.subenabled,
+			COALESCE(st.apply_error_count, 0), COALESCE(st.sync_error_count, 0),
+			(SELECT EXTRACT(EPOCH FROM now() - max(latest_end_time))
+			FROM pg_catalog.pg_stat_subscription
+			WHERE subid = s.oid AND relid IS NULL)
+		FROM pg_catalog.pg_subscription s
+		LEFT JOIN pg_catalog.pg_stat_subscription_stats st ON st.subid = s.oid
+		WHERE s.subname = $1
+		AND s.subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = current_database())
+		`).WithArgs(subscription.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"oid", "subenabled", "apply", "sync", "lag"}).
+				AddRow(16395, false, 3, 0, nil))
+		dbMock.ExpectExec(`ALTER SUBSCRIPTION "sub-one" SKIP (lsn = '0/14C0378')`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`ALTER SUBSCRIPTION "sub-one" ENABLE`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = reconcileSubscription(ctx, fakeClient, r, subscription)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(subscription.Status.Enabled).Should(HaveValue(BeTrue()))
+		Expect(subscription.Status.ApplyErrorCount).To(BeEquivalentTo(3))
+		Expect(subscription.Status.Lag).To(BeNil())
+		Expect(subscription.Status.LastApplyError).ToNot(BeNil())
+		Expect(subscription.Status.LastApplyError.SQLState).To(Equal("23505"))
+		Expect(subscription.Status.SkippedTransactions).To(BeEquivalentTo(1))
+		Expect(subscription.Status.LastSkippedLSN).To(Equal("0/14C0378"))
+	})
+
 	It("subscription object inherits error after patching", func(ctx SpecContext) {
 		expectedError := fmt.Errorf("no permission")
 		oneHit := sqlmock.NewRows([]string{""}).AddRow("1")
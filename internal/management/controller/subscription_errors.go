@@ -0,0 +1,99 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+)
+
+// applyErrorContextRegex matches the context of the errors raised by the
+// logical replication workers while applying a remote transaction, i.e.:
+//
+//	processing remote data for replication origin "pg_16395" during message
+//	type "INSERT" for replication target relation "public.test" in
+//	transaction 725 finished at 0/14C0378
+var applyErrorContextRegex = regexp.MustCompile(
+	`replication origin "pg_(\d+)".* finished at ([0-9A-F]+/[0-9A-F]+)`)
+
+// subscriptionApplyError is the latest error raised while applying
+// a remote transaction for a subscription
+type subscriptionApplyError struct {
+	sqlState  string
+	message   string
+	finishLSN string
+	time      time.Time
+}
+
+// SubscriptionErrorTracker collects, from the PostgreSQL logs, the
+// latest error raised by the logical replication workers of each
+// subscription
+type SubscriptionErrorTracker struct {
+	mu     sync.Mutex
+	errors map[uint32]subscriptionApplyError
+}
+
+// NewSubscriptionErrorTracker creates a new subscription error tracker
+func NewSubscriptionErrorTracker() *SubscriptionErrorTracker {
+	return &SubscriptionErrorTracker{
+		errors: make(map[uint32]subscriptionApplyError),
+	}
+}
+
+// Observe implements the logpipe.RecordObserver interface
+func (t *SubscriptionErrorTracker) Observe(record *logpipe.LoggingRecord) {
+	if record.ErrorSeverity != "ERROR" || !strings.HasPrefix(record.BackendType, "logical replication") {
+		return
+	}
+
+	matches := applyErrorContextRegex.FindStringSubmatch(record.Context)
+	if matches == nil {
+		return
+	}
+
+	oid, err := strconv.ParseUint(matches[1], 10, 32)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errors[uint32(oid)] = subscriptionApplyError{
+		sqlState:  record.SQLStateCode,
+		message:   record.Message,
+		finishLSN: matches[2],
+		time:      time.Now(),
+	}
+}
+
+// get returns the latest apply error of the subscription having
+// the passed OID, if any
+func (t *SubscriptionErrorTracker) get(oid uint32) (subscriptionApplyError, bool) {
+	if t == nil {
+		return subscriptionApplyError{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	applyError, ok := t.errors[oid]
+	return applyError, ok
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("subscription error tracker", func() {
+	var tracker *SubscriptionErrorTracker
+
+	BeforeEach(func() {
+		tracker = NewSubscriptionErrorTracker()
+	})
+
+	It("tracks the errors of the logical replication apply workers", func() {
+		tracker.Observe(&logpipe.LoggingRecord{
+			ErrorSeverity: "ERROR",
+			BackendType:   "logical replication apply worker",
+			SQLStateCode:  "23505",
+			Message:       `duplicate key value violates unique constraint "test_pkey"`,
+			Context: `processing remote data for replication origin "pg_16395" during message type "INSERT" ` +
+				`for replication target relation "public.test" in transaction 725 finished at 0/14C0378`,
+		})
+
+		applyError, ok := tracker.get(16395)
+		Expect(ok).To(BeTrue())
+		Expect(applyError.sqlState).To(Equal("23505"))
+		Expect(applyError.finishLSN).To(Equal("0/14C0378"))
+		Expect(applyError.message).To(ContainSubstring("duplicate key"))
+	})
+
+	It("ignores the records of other backends", func() {
+		tracker.Observe(&logpipe.LoggingRecord{
+			ErrorSeverity: "ERROR",
+			BackendType:   "client backend",
+			SQLStateCode:  "23505",
+			Context:       `replication origin "pg_16395" in transaction 725 finished at 0/14C0378`,
+		})
+
+		_, ok := tracker.get(16395)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("ignores the records without a failing transaction", func() {
+		tracker.Observe(&logpipe.LoggingRecord{
+			ErrorSeverity: "ERROR",
+			BackendType:   "logical replication apply worker",
+			SQLStateCode:  "08006",
+			Message:       "could not connect to the publisher",
+		})
+
+		Expect(tracker.errors).To(BeEmpty())
+	})
+
+	It("handles a nil tracker", func() {
+		var nilTracker *SubscriptionErrorTracker
+		_, ok := nilTracker.get(16395)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,412 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walarchivehealth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	barmanArchiver "github.com/cloudnative-pg/barman-cloud/pkg/archiver"
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+const (
+	// healthCheckInterval is the interval between two checks of the
+	// WAL archive
+	healthCheckInterval = 30 * time.Second
+
+	// walArchiveCheckInterval is the interval between two executions of
+	// barman-cloud-check-wal-archive, which needs to list the whole
+	// content of the WAL archive
+	walArchiveCheckInterval = time.Hour
+
+	// defaultMaxArchiveDelay is the delay used when the cluster doesn't
+	// specify one
+	defaultMaxArchiveDelay = 300 * time.Second
+)
+
+// healingAction is the action taken to recover a stalled WAL archive
+type healingAction string
+
+const (
+	// healingActionNone means that no action is needed
+	healingActionNone healingAction = ""
+
+	// healingActionRestartArchiver means that the PostgreSQL archiver
+	// needs to be restarted
+	healingActionRestartArchiver healingAction = "RestartArchiver"
+
+	// healingActionSwitchWAL means that a WAL switch needs to be forced
+	healingActionSwitchWAL healingAction = "SwitchWAL"
+)
+
+// walArchiveStats contains the status of the WAL archiving process
+// of the primary instance
+type walArchiveStats struct {
+	// The number of WAL files waiting to be archived
+	readyWALFiles int
+
+	// When the oldest WAL file waiting to be archived has been marked as
+	// ready, or nil if there are no WAL files to be archived
+	oldestReadyWALTime *time.Time
+
+	// When the last WAL file has been archived, or nil if no WAL file
+	// has ever been archived
+	lastArchivedTime *time.Time
+
+	// The last WAL file that PostgreSQL failed to archive
+	lastFailedWAL string
+
+	// When the last failure happened, or nil if archiving never failed
+	lastFailedTime *time.Time
+
+	// The current timeline of the instance
+	timeline int
+}
+
+// isStalled is true when a WAL file is waiting to be archived for
+// longer than the maximum delay
+func (stats *walArchiveStats) isStalled(maxDelay time.Duration, now time.Time) bool {
+	return stats.oldestReadyWALTime != nil && now.Sub(*stats.oldestReadyWALTime) > maxDelay
+}
+
+// isIdle is true when there are no WAL files waiting to be archived,
+// and no WAL file has been archived in the last maximum delay
+func (stats *walArchiveStats) isIdle(maxDelay time.Duration, now time.Time) bool {
+	return stats.readyWALFiles == 0 &&
+		(stats.lastArchivedTime == nil || now.Sub(*stats.lastArchivedTime) > maxDelay)
+}
+
+// A HealthChecker is a runner that periodically checks the WAL archive
+// of the cluster from the primary instance
+type HealthChecker struct {
+	instance *postgres.Instance
+	client   client.Client
+
+	// The time when barman-cloud-check-wal-archive was last executed
+	lastWALArchiveCheck time.Time
+
+	// The result of the last execution of barman-cloud-check-wal-archive
+	walArchiveCheckErr error
+
+	// The time when the last self-healing action was taken
+	lastHealingTime time.Time
+}
+
+// NewHealthChecker creates a new WAL archive HealthChecker
+func NewHealthChecker(instance *postgres.Instance, client client.Client) *HealthChecker {
+	runner := &HealthChecker{
+		instance: instance,
+		client:   client,
+	}
+	return runner
+}
+
+// Start starts running the WAL archive HealthChecker
+func (h *HealthChecker) Start(ctx context.Context) error {
+	contextLog := log.FromContext(ctx).WithName("wal_archive_health_checker")
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+
+		defer func() {
+			ticker.Stop()
+			contextLog.Info("Terminated WAL archive HealthChecker loop")
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := h.reconcile(ctx); err != nil {
+				contextLog.Error(err, "checking the health of the WAL archive")
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile checks the WAL archive, takes the self-healing actions
+// if requested, and reports the result in the cluster status
+func (h *HealthChecker) reconcile(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := h.client.Get(ctx, client.ObjectKey{
+		Namespace: h.instance.GetNamespaceName(),
+		Name:      h.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return err
+	}
+
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.WALArchiveHealth == nil {
+		return nil
+	}
+
+	// Only the primary instance generates the WAL files
+	isPrimary, err := h.instance.IsPrimary()
+	if err != nil || !isPrimary {
+		return err
+	}
+
+	stats, err := h.getWALArchiveStats()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	maxDelay := getMaxArchiveDelay(cluster.Spec.Backup.WALArchiveHealth)
+
+	if cluster.Spec.Backup.BarmanObjectStore != nil &&
+		(stats.isStalled(maxDelay, now) || now.Sub(h.lastWALArchiveCheck) > walArchiveCheckInterval) {
+		h.walArchiveCheckErr = h.checkWALArchive(ctx, &cluster, stats.timeline)
+		h.lastWALArchiveCheck = now
+	}
+
+	if cluster.Spec.Backup.WALArchiveHealth.SelfHealing && now.Sub(h.lastHealingTime) > maxDelay {
+		action := getHealingAction(stats, maxDelay, now)
+		if action != healingActionNone {
+			contextLogger.Info("Taking a self-healing action on the WAL archive",
+				"action", action,
+				"readyWALFiles", stats.readyWALFiles,
+				"lastArchivedTime", stats.lastArchivedTime)
+			if err := h.heal(action); err != nil {
+				contextLogger.Error(err, "while healing the WAL archive", "action", action)
+			}
+			h.lastHealingTime = now
+		}
+	}
+
+	return status.PatchConditionsWithOptimisticLock(
+		ctx,
+		h.client,
+		&cluster,
+		buildWALArchiveHealthCondition(stats, h.walArchiveCheckErr, maxDelay, now),
+	)
+}
+
+// getWALArchiveStats gets the status of the WAL archiving process from
+// PostgreSQL and from the archive status directory
+func (h *HealthChecker) getWALArchiveStats() (*walArchiveStats, error) {
+	db, err := h.instance.GetSuperUserDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastArchivedTime, lastFailedTime sql.NullTime
+	var lastFailedWAL sql.NullString
+	var stats walArchiveStats
+	row := db.QueryRow(
+		`SELECT
+			last_archived_time,
+			last_failed_wal,
+			last_failed_time,
+			(SELECT timeline_id FROM pg_catalog.pg_control_checkpoint())
+		FROM pg_catalog.pg_stat_archiver`)
+	if err := row.Scan(&lastArchivedTime, &lastFailedWAL, &lastFailedTime, &stats.timeline); err != nil {
+		return nil, fmt.Errorf("while reading the archiver status: %w", err)
+	}
+
+	if lastArchivedTime.Valid {
+		stats.lastArchivedTime = &lastArchivedTime.Time
+	}
+	if lastFailedTime.Valid {
+		stats.lastFailedTime = &lastFailedTime.Time
+	}
+	stats.lastFailedWAL = lastFailedWAL.String
+
+	readyWALs, err := postgres.GetReadyWALFiles()
+	if err != nil {
+		return nil, err
+	}
+	stats.readyWALFiles = len(readyWALs)
+	stats.oldestReadyWALTime, err = getOldestReadyWALTime(specs.PgWalArchiveStatusPath, readyWALs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// checkWALArchive runs barman-cloud-check-wal-archive, verifying that the
+// WAL archive can be reached and doesn't contain WAL files coming from
+// a timeline newer than the current one
+func (h *HealthChecker) checkWALArchive(ctx context.Context, cluster *apiv1.Cluster, timeline int) error {
+	configuration := cluster.Spec.Backup.BarmanObjectStore
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(
+		ctx,
+		h.client,
+		cluster.Namespace,
+		configuration,
+		os.Environ())
+	if err != nil {
+		return fmt.Errorf("while getting the object store credentials: %w", err)
+	}
+
+	walArchiver, err := barmanArchiver.New(ctx, env, postgresSpec.SpoolDirectory, h.instance.PgData, "")
+	if err != nil {
+		return err
+	}
+
+	options, err := walArchiver.BarmanCloudCheckWalArchiveOptions(ctx, configuration, cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	// Every WAL file in the archive is expected to belong to the current
+	// timeline or to one of its ancestors
+	options = append([]string{"--timeline", strconv.Itoa(timeline + 1)}, options...)
+
+	return walArchiver.CheckWalArchiveDestination(ctx, options)
+}
+
+// heal executes the passed self-healing action
+func (h *HealthChecker) heal(action healingAction) error {
+	db, err := h.instance.GetSuperUserDB()
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case healingActionSwitchWAL:
+		// This is a no-op when nothing has been written since
+		// the last WAL switch
+		if _, err := db.Exec("SELECT pg_catalog.pg_switch_wal()"); err != nil {
+			return fmt.Errorf("while switching to a new WAL: %w", err)
+		}
+
+	case healingActionRestartArchiver:
+		var pid int
+		row := db.QueryRow("SELECT pid FROM pg_catalog.pg_stat_activity WHERE backend_type = 'archiver'")
+		if err := row.Scan(&pid); err != nil {
+			return fmt.Errorf("while looking for the archiver process: %w", err)
+		}
+
+		// SIGUSR2 asks the archiver to exit cleanly. The postmaster
+		// will start a new one right away
+		if err := syscall.Kill(pid, syscall.SIGUSR2); err != nil {
+			return fmt.Errorf("while restarting the archiver process: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getMaxArchiveDelay gets the maximum delay after which a WAL file waiting
+// to be archived makes the WAL archive stalled
+func getMaxArchiveDelay(configuration *apiv1.WALArchiveHealthConfiguration) time.Duration {
+	if configuration.MaxArchiveDelay <= 0 {
+		return defaultMaxArchiveDelay
+	}
+	return time.Duration(configuration.MaxArchiveDelay) * time.Second
+}
+
+// getOldestReadyWALTime gets the time when the oldest WAL file waiting to
+// be archived has been marked as ready, or nil if there are no WAL files
+// waiting to be archived
+func getOldestReadyWALTime(archiveStatusDirectory string, readyWALs []string) (*time.Time, error) {
+	if len(readyWALs) == 0 {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path.Join(archiveStatusDirectory, slices.Min(readyWALs)+".ready"))
+	if errors.Is(err, os.ErrNotExist) {
+		// The WAL file has been archived in the meantime
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := info.ModTime()
+	return &result, nil
+}
+
+// getHealingAction computes the self-healing action needed to recover
+// the WAL archive given its status
+func getHealingAction(stats *walArchiveStats, maxDelay time.Duration, now time.Time) healingAction {
+	switch {
+	case stats.isStalled(maxDelay, now):
+		return healingActionRestartArchiver
+	case stats.isIdle(maxDelay, now):
+		return healingActionSwitchWAL
+	default:
+		return healingActionNone
+	}
+}
+
+// buildWALArchiveHealthCondition computes the WALArchiveHealthy condition
+// given the status of the WAL archive
+func buildWALArchiveHealthCondition(
+	stats *walArchiveStats,
+	walArchiveCheckErr error,
+	maxDelay time.Duration,
+	now time.Time,
+) metav1.Condition {
+	switch {
+	case walArchiveCheckErr != nil:
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionWALArchiveHealthy),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonWALArchiveCheckFailed),
+			Message: walArchiveCheckErr.Error(),
+		}
+
+	case stats.isStalled(maxDelay, now):
+		message := fmt.Sprintf("%d WAL files are waiting to be archived, the oldest one since %s",
+			stats.readyWALFiles, stats.oldestReadyWALTime.Format(time.RFC3339))
+		if stats.lastFailedTime != nil &&
+			(stats.lastArchivedTime == nil || stats.lastFailedTime.After(*stats.lastArchivedTime)) {
+			message += fmt.Sprintf(", last failure archiving %s at %s",
+				stats.lastFailedWAL, stats.lastFailedTime.Format(time.RFC3339))
+		}
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionWALArchiveHealthy),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonWALArchiveStalled),
+			Message: message,
+		}
+
+	default:
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionWALArchiveHealthy),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonWALArchiveHealthy),
+			Message: "WAL files are being archived in time",
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walarchivehealth
+
+import (
+	"errors"
+	"os"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getOldestReadyWALTime", func() {
+	var archiveStatusDirectory string
+
+	BeforeEach(func() {
+		archiveStatusDirectory = GinkgoT().TempDir()
+	})
+
+	It("returns nil when there are no WAL files to be archived", func() {
+		oldestTime, err := getOldestReadyWALTime(archiveStatusDirectory, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(oldestTime).To(BeNil())
+	})
+
+	It("gets the time of the oldest WAL file to be archived", func() {
+		readyTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		for _, name := range []string{"000000010000000000000002", "000000010000000000000001"} {
+			Expect(os.WriteFile(path.Join(archiveStatusDirectory, name+".ready"), nil, 0o600)).To(Succeed())
+		}
+		oldestPath := path.Join(archiveStatusDirectory, "000000010000000000000001.ready")
+		Expect(os.Chtimes(oldestPath, readyTime, readyTime)).To(Succeed())
+
+		oldestTime, err := getOldestReadyWALTime(archiveStatusDirectory, []string{
+			"000000010000000000000002",
+			"000000010000000000000001",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(oldestTime).ToNot(BeNil())
+		Expect(oldestTime.Equal(readyTime)).To(BeTrue())
+	})
+
+	It("returns nil when the WAL file has been archived in the meantime", func() {
+		oldestTime, err := getOldestReadyWALTime(archiveStatusDirectory, []string{"000000010000000000000001"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(oldestTime).To(BeNil())
+	})
+})
+
+var _ = Describe("getMaxArchiveDelay", func() {
+	It("uses the configured delay", func() {
+		Expect(getMaxArchiveDelay(&apiv1.WALArchiveHealthConfiguration{MaxArchiveDelay: 60})).
+			To(Equal(time.Minute))
+	})
+
+	It("defaults to five minutes", func() {
+		Expect(getMaxArchiveDelay(&apiv1.WALArchiveHealthConfiguration{})).To(Equal(5 * time.Minute))
+	})
+})
+
+var _ = Describe("WAL archive health", func() {
+	const maxDelay = 5 * time.Minute
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	It("is healthy when WAL files are archived in time", func() {
+		stats := &walArchiveStats{
+			readyWALFiles:      1,
+			oldestReadyWALTime: ptr.To(now.Add(-time.Minute)),
+			lastArchivedTime:   ptr.To(now.Add(-time.Minute)),
+		}
+		condition := buildWALArchiveHealthCondition(stats, nil, maxDelay, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALArchiveHealthy)))
+		Expect(getHealingAction(stats, maxDelay, now)).To(Equal(healingActionNone))
+	})
+
+	It("is stalled when a WAL file is waiting for longer than the maximum delay", func() {
+		stats := &walArchiveStats{
+			readyWALFiles:      3,
+			oldestReadyWALTime: ptr.To(now.Add(-10 * time.Minute)),
+			lastArchivedTime:   ptr.To(now.Add(-20 * time.Minute)),
+			lastFailedWAL:      "000000010000000000000004",
+			lastFailedTime:     ptr.To(now.Add(-time.Minute)),
+		}
+		condition := buildWALArchiveHealthCondition(stats, nil, maxDelay, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALArchiveStalled)))
+		Expect(condition.Message).To(ContainSubstring("3 WAL files"))
+		Expect(condition.Message).To(ContainSubstring("000000010000000000000004"))
+		Expect(getHealingAction(stats, maxDelay, now)).To(Equal(healingActionRestartArchiver))
+	})
+
+	It("reports the failures of barman-cloud-check-wal-archive", func() {
+		stats := &walArchiveStats{}
+		condition := buildWALArchiveHealthCondition(stats, errors.New("newer timeline found"), maxDelay, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALArchiveCheckFailed)))
+		Expect(condition.Message).To(Equal("newer timeline found"))
+	})
+
+	It("forces a WAL switch when nothing has been archived for longer than the maximum delay", func() {
+		stats := &walArchiveStats{
+			lastArchivedTime: ptr.To(now.Add(-time.Hour)),
+		}
+		condition := buildWALArchiveHealthCondition(stats, nil, maxDelay, now)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(getHealingAction(stats, maxDelay, now)).To(Equal(healingActionSwitchWAL))
+	})
+
+	It("forces a WAL switch when nothing has ever been archived", func() {
+		Expect(getHealingAction(&walArchiveStats{}, maxDelay, now)).To(Equal(healingActionSwitchWAL))
+	})
+})
@@ -0,0 +1,211 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+const (
+	// guardInterval is the interval between two checks of the volume
+	// containing the WAL files
+	guardInterval = 30 * time.Second
+
+	// checkpointInterval is the minimum interval between two checkpoints
+	// requested while in protective mode
+	checkpointInterval = time.Minute
+)
+
+// A Guard is a runner that periodically checks the usage of the volume
+// containing the WAL files of the primary instance, entering the
+// protective mode when the volume is running out of space
+type Guard struct {
+	instance *postgres.Instance
+	client   client.Client
+
+	// The time when the last checkpoint has been requested
+	lastCheckpointTime time.Time
+}
+
+// NewGuard creates a new WAL space Guard
+func NewGuard(instance *postgres.Instance, client client.Client) *Guard {
+	return &Guard{
+		instance: instance,
+		client:   client,
+	}
+}
+
+// Start starts running the WAL space Guard
+func (g *Guard) Start(ctx context.Context) error {
+	contextLog := log.FromContext(ctx).WithName("wal_space_guard")
+	go func() {
+		ticker := time.NewTicker(guardInterval)
+
+		defer func() {
+			ticker.Stop()
+			contextLog.Info("Terminated WAL space Guard loop")
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := g.reconcile(ctx); err != nil {
+				contextLog.Error(err, "checking the space of the WAL volume")
+			}
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+// reconcile checks the usage of the volume containing the WAL files and,
+// while it's above the threshold, requests checkpoints to recycle the WAL
+// files that are not needed anymore. The result is reported in the cluster
+// status, together with the WAL files retained for the standbys via
+// `wal_keep_size`, which is not changed as it is set by the user
+func (g *Guard) reconcile(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := g.client.Get(ctx, client.ObjectKey{
+		Namespace: g.instance.GetNamespaceName(),
+		Name:      g.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return err
+	}
+
+	if !cluster.IsWALSpaceProtectionEnabled() {
+		return nil
+	}
+
+	// Only the primary instance is protected, as the WAL files of the
+	// standbys are recycled following the primary
+	isPrimary, err := g.instance.IsPrimary()
+	if err != nil || !isPrimary {
+		return err
+	}
+
+	usage, err := g.instance.GetWALVolumeUsage()
+	if err != nil {
+		return fmt.Errorf("while getting the usage of the WAL volume: %w", err)
+	}
+
+	readyWALs, err := postgres.GetReadyWALFiles()
+	if err != nil {
+		return err
+	}
+
+	usedPercentage := usage.GetUsedPercentage()
+	threshold := int(cluster.Spec.WALSpaceProtection.GetThreshold())
+	walKeepSize := ""
+	if usedPercentage >= threshold {
+		if time.Since(g.lastCheckpointTime) > checkpointInterval {
+			contextLogger.Warning("The WAL volume is running out of space, requesting a checkpoint",
+				"usedPercentage", usedPercentage,
+				"threshold", threshold,
+				"readyWALFiles", len(readyWALs))
+			if err := g.checkpoint(); err != nil {
+				contextLogger.Error(err, "while requesting a checkpoint to recycle the WAL files")
+			}
+			g.lastCheckpointTime = time.Now()
+		}
+
+		if walKeepSize, err = g.getWALKeepSize(); err != nil {
+			contextLogger.Error(err, "while reading the wal_keep_size setting")
+		}
+	}
+
+	// The condition only changes when the protective mode is entered or
+	// left, or when its causes change, and not with the usage of the volume
+	return status.PatchConditionsWithOptimisticLock(
+		ctx,
+		g.client,
+		&cluster,
+		buildWALSpaceCondition(usedPercentage, threshold, len(readyWALs) > 0, walKeepSize),
+	)
+}
+
+// checkpoint requests a checkpoint, which removes or recycles the WAL
+// files that are not needed anymore
+func (g *Guard) checkpoint() error {
+	db, err := g.instance.GetSuperUserDB()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("CHECKPOINT")
+	return err
+}
+
+// getWALKeepSize gets the current value of the wal_keep_size setting
+func (g *Guard) getWALKeepSize() (string, error) {
+	db, err := g.instance.GetSuperUserDB()
+	if err != nil {
+		return "", err
+	}
+
+	var walKeepSize string
+	err = db.QueryRow("SELECT pg_catalog.current_setting('wal_keep_size')").Scan(&walKeepSize)
+	return walKeepSize, err
+}
+
+// buildWALSpaceCondition computes the WALSpaceAvailable condition given
+// the usage of the volume containing the WAL files, whether some WAL files
+// are waiting to be archived and the value of the wal_keep_size setting
+func buildWALSpaceCondition(
+	usedPercentage, threshold int,
+	waitingForArchive bool,
+	walKeepSize string,
+) metav1.Condition {
+	if usedPercentage < threshold {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionWALSpaceAvailable),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonWALSpaceAvailable),
+			Message: fmt.Sprintf("The WAL volume usage is below %d%%", threshold),
+		}
+	}
+
+	message := fmt.Sprintf("The WAL volume usage reached %d%%, the primary instance entered the protective mode",
+		threshold)
+	if waitingForArchive {
+		message += ", some WAL files are waiting to be archived"
+	}
+	if walKeepSize != "" && walKeepSize != "0" {
+		message += fmt.Sprintf(", wal_keep_size is retaining up to %s of WAL files for the standbys", walKeepSize)
+	}
+	return metav1.Condition{
+		Type:    string(apiv1.ConditionWALSpaceAvailable),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonWALSpaceLow),
+		Message: message,
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walspace
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WALSpaceAvailable condition", func() {
+	It("reports the space as available below the threshold", func() {
+		condition := buildWALSpaceCondition(50, 85, false, "")
+		Expect(condition.Type).To(Equal(string(apiv1.ConditionWALSpaceAvailable)))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALSpaceAvailable)))
+	})
+
+	It("reports the protective mode when the threshold is reached", func() {
+		condition := buildWALSpaceCondition(85, 85, false, "0")
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALSpaceLow)))
+		Expect(condition.Message).ToNot(ContainSubstring("archived"))
+		Expect(condition.Message).ToNot(ContainSubstring("wal_keep_size"))
+	})
+
+	It("doesn't change the condition with the usage of the volume", func() {
+		Expect(buildWALSpaceCondition(86, 85, true, "512MB")).
+			To(Equal(buildWALSpaceCondition(93, 85, true, "512MB")))
+	})
+
+	It("reports the WAL files waiting to be archived and retained for the standbys", func() {
+		condition := buildWALSpaceCondition(92, 85, true, "512MB")
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("some WAL files are waiting to be archived"))
+		Expect(condition.Message).To(ContainSubstring("wal_keep_size is retaining up to 512MB"))
+	})
+})
@@ -21,6 +21,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
@@ -36,6 +38,7 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbouncer/config"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // PgBouncerReconciler reconciles the status of the Pooler resource with
@@ -45,6 +48,10 @@ type PgBouncerReconciler struct {
 	poolerWatch          watch.Interface
 	instance             PgBouncerInstanceInterface
 	poolerNamespacedName types.NamespacedName
+
+	// reloadedCertificates is the fingerprint of the certificates
+	// whose reload was last requested by the operator and completed
+	reloadedCertificates string
 }
 
 // NewPgBouncerReconciler creates a new pgbouncer reconciler
@@ -138,14 +145,23 @@ func (r *PgBouncerReconciler) Reconcile(ctx context.Context, event *watch.Event)
 		return fmt.Errorf("while reconciling configuration: %w", err)
 	}
 
-	return r.synchronizePause(pooler)
+	if err := r.synchronizePause(pooler); err != nil {
+		return err
+	}
+
+	if err := r.synchronizeSwitchoverPause(ctx, pooler); err != nil {
+		return err
+	}
+
+	return r.synchronizeCertificateReload(ctx, pooler)
 }
 
 // synchronizePause ensure that the pause flag inside the Pooler
-// specification matches the PgBouncer status
+// specification, or the pause requested by the operator ahead of
+// a switchover, matches the PgBouncer status
 func (r *PgBouncerReconciler) synchronizePause(pooler *apiv1.Pooler) error {
 	isPaused := r.instance.Paused()
-	shouldBePaused := pooler.Spec.PgBouncer.IsPaused()
+	shouldBePaused := pooler.Spec.PgBouncer.IsPaused() || isSwitchoverPauseRequested(pooler)
 	if shouldBePaused && !isPaused {
 		if err := r.instance.Pause(); err != nil {
 			return fmt.Errorf("while pausing instance: %w", err)
@@ -159,6 +175,97 @@ func (r *PgBouncerReconciler) synchronizePause(pooler *apiv1.Pooler) error {
 	return nil
 }
 
+// synchronizeSwitchoverPause reports in the Pooler status whether this
+// instance completed the pause requested by the operator ahead of a
+// switchover, so that the operator can proceed with the promotion
+func (r *PgBouncerReconciler) synchronizeSwitchoverPause(ctx context.Context, pooler *apiv1.Pooler) error {
+	podName, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("while getting the pod name: %w", err)
+	}
+
+	shouldBeAcknowledged := isSwitchoverPauseRequested(pooler) && r.instance.Paused()
+	changed, err := r.updateAcknowledgement(ctx, podName, shouldBeAcknowledged,
+		func(status *apiv1.PoolerStatus) *[]string { return &status.SwitchoverPausedInstances })
+	if changed {
+		log.FromContext(ctx).Info("Updated the switchover pause status",
+			"podName", podName, "paused", shouldBeAcknowledged)
+	}
+	return err
+}
+
+// synchronizeCertificateReload reloads PgBouncer when the operator
+// requests it during a rotation of the TLS certificates, and reports
+// in the Pooler status that this instance loaded the new certificates
+func (r *PgBouncerReconciler) synchronizeCertificateReload(ctx context.Context, pooler *apiv1.Pooler) error {
+	podName, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("while getting the pod name: %w", err)
+	}
+
+	fingerprint, requested := pooler.Annotations[utils.PoolerCertificateReloadAnnotationName]
+	if requested && fingerprint != r.reloadedCertificates {
+		if err := r.instance.Reload(); err != nil {
+			return fmt.Errorf("while reloading the certificates: %w", err)
+		}
+		r.reloadedCertificates = fingerprint
+	}
+
+	changed, err := r.updateAcknowledgement(ctx, podName, requested,
+		func(status *apiv1.PoolerStatus) *[]string { return &status.CertificateReloadedInstances })
+	if changed {
+		log.FromContext(ctx).Info("Updated the certificate reload status",
+			"podName", podName, "reloaded", requested)
+	}
+	return err
+}
+
+// updateAcknowledgement adds or removes this instance from the list of
+// instances in the Pooler status that acknowledged a request of the
+// operator, returning true if the status was changed
+func (r *PgBouncerReconciler) updateAcknowledgement(
+	ctx context.Context,
+	podName string,
+	shouldBeAcknowledged bool,
+	instances func(status *apiv1.PoolerStatus) *[]string,
+) (bool, error) {
+	var currentPooler apiv1.Pooler
+	if err := r.client.Get(ctx, r.poolerNamespacedName, &currentPooler); err != nil {
+		return false, fmt.Errorf("while getting the pooler: %w", err)
+	}
+
+	acknowledged := slices.Contains(*instances(&currentPooler.Status), podName)
+	if acknowledged == shouldBeAcknowledged {
+		return false, nil
+	}
+
+	updatedPooler := currentPooler.DeepCopy()
+	updatedInstances := instances(&updatedPooler.Status)
+	if shouldBeAcknowledged {
+		*updatedInstances = append(*updatedInstances, podName)
+	} else {
+		*updatedInstances = slices.DeleteFunc(*updatedInstances,
+			func(name string) bool { return name == podName })
+	}
+
+	if err := r.client.Status().Patch(
+		ctx,
+		updatedPooler,
+		ctrl.MergeFromWithOptions(&currentPooler, ctrl.MergeFromWithOptimisticLock{}),
+	); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isSwitchoverPauseRequested checks whether the operator requested
+// the pooler to be paused ahead of a switchover
+func isSwitchoverPauseRequested(pooler *apiv1.Pooler) bool {
+	_, ok := pooler.Annotations[utils.PoolerSwitchoverPauseAnnotationName]
+	return ok
+}
+
 // synchronizeConfig ensure that the configuration derived from
 // the pooler specification matches the one loaded in PgBouncer
 func (r *PgBouncerReconciler) synchronizeConfig(ctx context.Context, pooler *apiv1.Pooler) error {
@@ -0,0 +1,194 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakePgBouncerInstance struct {
+	paused  bool
+	reloads int
+}
+
+func (f *fakePgBouncerInstance) Paused() bool { return f.paused }
+
+func (f *fakePgBouncerInstance) Pause() error {
+	f.paused = true
+	return nil
+}
+
+func (f *fakePgBouncerInstance) Resume() error {
+	f.paused = false
+	return nil
+}
+
+func (f *fakePgBouncerInstance) Reload() error {
+	f.reloads++
+	return nil
+}
+
+var _ = Describe("switchover pause", func() {
+	var (
+		pooler     *apiv1.Pooler
+		instance   *fakePgBouncerInstance
+		reconciler *PgBouncerReconciler
+		podName    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		podName, err = os.Hostname()
+		Expect(err).ToNot(HaveOccurred())
+
+		pooler = &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pooler",
+				Namespace: "default",
+				Annotations: map[string]string{
+					utils.PoolerSwitchoverPauseAnnotationName: "cluster-2",
+				},
+			},
+			Spec: apiv1.PoolerSpec{
+				PgBouncer: &apiv1.PgBouncerSpec{},
+			},
+		}
+		instance = &fakePgBouncerInstance{}
+		reconciler = &PgBouncerReconciler{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(pooler).
+				WithStatusSubresource(&apiv1.Pooler{}).
+				Build(),
+			instance:             instance,
+			poolerNamespacedName: types.NamespacedName{Name: pooler.Name, Namespace: pooler.Namespace},
+		}
+	})
+
+	getPausedInstances := func(ctx context.Context) []string {
+		var updatedPooler apiv1.Pooler
+		Expect(reconciler.client.Get(ctx, client.ObjectKeyFromObject(pooler), &updatedPooler)).To(Succeed())
+		return updatedPooler.Status.SwitchoverPausedInstances
+	}
+
+	It("pauses the instance and reports it in the status", func(ctx context.Context) {
+		Expect(reconciler.synchronizePause(pooler)).To(Succeed())
+		Expect(instance.Paused()).To(BeTrue())
+
+		Expect(reconciler.synchronizeSwitchoverPause(ctx, pooler)).To(Succeed())
+		Expect(getPausedInstances(ctx)).To(ConsistOf(podName))
+	})
+
+	It("resumes the instance and removes it from the status", func(ctx context.Context) {
+		Expect(reconciler.synchronizePause(pooler)).To(Succeed())
+		Expect(reconciler.synchronizeSwitchoverPause(ctx, pooler)).To(Succeed())
+
+		delete(pooler.Annotations, utils.PoolerSwitchoverPauseAnnotationName)
+		Expect(reconciler.synchronizePause(pooler)).To(Succeed())
+		Expect(instance.Paused()).To(BeFalse())
+
+		Expect(reconciler.synchronizeSwitchoverPause(ctx, pooler)).To(Succeed())
+		Expect(getPausedInstances(ctx)).To(BeEmpty())
+	})
+
+	It("keeps the instance paused when requested by the user", func(ctx context.Context) {
+		delete(pooler.Annotations, utils.PoolerSwitchoverPauseAnnotationName)
+		pooler.Spec.PgBouncer.Paused = ptr.To(true)
+
+		Expect(reconciler.synchronizePause(pooler)).To(Succeed())
+		Expect(instance.Paused()).To(BeTrue())
+
+		Expect(reconciler.synchronizeSwitchoverPause(ctx, pooler)).To(Succeed())
+		Expect(getPausedInstances(ctx)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("certificate reload", func() {
+	var (
+		pooler     *apiv1.Pooler
+		instance   *fakePgBouncerInstance
+		reconciler *PgBouncerReconciler
+		podName    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		podName, err = os.Hostname()
+		Expect(err).ToNot(HaveOccurred())
+
+		pooler = &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pooler",
+				Namespace: "default",
+				Annotations: map[string]string{
+					utils.PoolerCertificateReloadAnnotationName: "fingerprint-1",
+				},
+			},
+			Spec: apiv1.PoolerSpec{
+				PgBouncer: &apiv1.PgBouncerSpec{},
+			},
+		}
+		instance = &fakePgBouncerInstance{}
+		reconciler = &PgBouncerReconciler{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(pooler).
+				WithStatusSubresource(&apiv1.Pooler{}).
+				Build(),
+			instance:             instance,
+			poolerNamespacedName: types.NamespacedName{Name: pooler.Name, Namespace: pooler.Namespace},
+		}
+	})
+
+	getReloadedInstances := func(ctx context.Context) []string {
+		var updatedPooler apiv1.Pooler
+		Expect(reconciler.client.Get(ctx, client.ObjectKeyFromObject(pooler), &updatedPooler)).To(Succeed())
+		return updatedPooler.Status.CertificateReloadedInstances
+	}
+
+	It("reloads the instance once and reports it in the status", func(ctx context.Context) {
+		Expect(reconciler.synchronizeCertificateReload(ctx, pooler)).To(Succeed())
+		Expect(reconciler.synchronizeCertificateReload(ctx, pooler)).To(Succeed())
+		Expect(instance.reloads).To(Equal(1))
+		Expect(getReloadedInstances(ctx)).To(ConsistOf(podName))
+
+		pooler.Annotations[utils.PoolerCertificateReloadAnnotationName] = "fingerprint-2"
+		Expect(reconciler.synchronizeCertificateReload(ctx, pooler)).To(Succeed())
+		Expect(instance.reloads).To(Equal(2))
+	})
+
+	It("removes the instance from the status when the reload is over", func(ctx context.Context) {
+		Expect(reconciler.synchronizeCertificateReload(ctx, pooler)).To(Succeed())
+
+		delete(pooler.Annotations, utils.PoolerCertificateReloadAnnotationName)
+		Expect(reconciler.synchronizeCertificateReload(ctx, pooler)).To(Succeed())
+		Expect(getReloadedInstances(ctx)).To(BeEmpty())
+		Expect(instance.reloads).To(Equal(1))
+	})
+})
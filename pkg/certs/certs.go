@@ -18,12 +18,15 @@ limitations under the License.
 package certs
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"math/big"
@@ -53,6 +56,10 @@ const (
 	// CAPrivateKeyKey is the key for the private key field in a CA secret
 	CAPrivateKeyKey = "ca.key"
 
+	// CAPreviousCertKey is the key for the CA certificate replaced by the
+	// last rotation, which is still trusted until the rotation completes
+	CAPreviousCertKey = "ca-previous.crt"
+
 	// TLSCertKey is the key for certificates in a CA secret
 	TLSCertKey = "tls.crt"
 
@@ -506,3 +513,42 @@ func getCheckThreshold() time.Duration {
 	}
 	return time.Duration(threshold) * 24 * time.Hour
 }
+
+// GetCABundle gets the CA certificates that should be trusted according
+// to a CA secret: the current one and, while a rotation is in progress,
+// the previous one
+func GetCABundle(secret *v1.Secret) []byte {
+	bundle := slices.Clone(secret.Data[CACertKey])
+	if previous := secret.Data[CAPreviousCertKey]; len(previous) > 0 {
+		if len(bundle) > 0 && bundle[len(bundle)-1] != '\n' {
+			bundle = append(bundle, '\n')
+		}
+		bundle = append(bundle, previous...)
+	}
+	return bundle
+}
+
+// ReplaceCACertificate sets the CA certificate of a CA secret, keeping
+// the replaced one trusted until the rotation of the certificates
+// signed by it completes
+func ReplaceCACertificate(secret *v1.Secret, caCertificate []byte) {
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	current := secret.Data[CACertKey]
+	if len(current) > 0 && !bytes.Equal(current, caCertificate) {
+		secret.Data[CAPreviousCertKey] = current
+	}
+	secret.Data[CACertKey] = caCertificate
+}
+
+// Fingerprint computes the SHA-256 fingerprint of a set of PEM
+// encoded certificates
+func Fingerprint(certificates ...[]byte) string {
+	hash := sha256.New()
+	for _, certificate := range certificates {
+		_, _ = hash.Write(certificate)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
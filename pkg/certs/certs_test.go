@@ -22,6 +22,8 @@ import (
 	"encoding/pem"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -381,3 +383,26 @@ var _ = Describe("Certicate duration and expiration threshold", func() {
 		Expect(threshold).To(BeEquivalentTo(tenDays))
 	})
 })
+
+var _ = Describe("CA bundle", func() {
+	It("trusts the previous CA certificate after a replacement", func() {
+		secret := &v1.Secret{Data: map[string][]byte{CACertKey: []byte("old")}}
+		Expect(GetCABundle(secret)).To(Equal([]byte("old")))
+
+		ReplaceCACertificate(secret, []byte("new\n"))
+		Expect(secret.Data[CACertKey]).To(Equal([]byte("new\n")))
+		Expect(secret.Data[CAPreviousCertKey]).To(Equal([]byte("old")))
+		Expect(GetCABundle(secret)).To(Equal([]byte("new\nold")))
+	})
+
+	It("doesn't keep the previous CA certificate when it didn't change", func() {
+		secret := &v1.Secret{Data: map[string][]byte{CACertKey: []byte("ca")}}
+		ReplaceCACertificate(secret, []byte("ca"))
+		Expect(secret.Data).ToNot(HaveKey(CAPreviousCertKey))
+	})
+
+	It("computes the fingerprint of a set of certificates", func() {
+		Expect(Fingerprint([]byte("a"), []byte("b"))).To(Equal(Fingerprint([]byte("ab"))))
+		Expect(Fingerprint([]byte("a"))).ToNot(Equal(Fingerprint([]byte("b"))))
+	})
+})
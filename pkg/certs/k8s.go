@@ -70,11 +70,11 @@ type PublicKeyInfrastructure struct {
 	OperatorNamespace string
 
 	// The name of the mutating webhook configuration in k8s, used to
-	// inject the caBundle
+	// inject the caBundle. When empty, no caBundle is injected
 	MutatingWebhookConfigurationName string
 
 	// The name of the validating webhook configuration in k8s, used
-	// to inject the caBundle
+	// to inject the caBundle. When empty, no caBundle is injected
 	ValidatingWebhookConfigurationName string
 
 	// The labelSelector to be used to get the operators deployment,
@@ -406,6 +406,10 @@ func ensureMountedSecretsAreInSync(secret *v1.Secret, certDir string) error {
 func (pki PublicKeyInfrastructure) injectPublicKeyIntoMutatingWebhook(
 	ctx context.Context, kubeClient client.Client, tlsSecret *v1.Secret,
 ) error {
+	if pki.MutatingWebhookConfigurationName == "" {
+		return nil
+	}
+
 	config := &admissionregistrationv1.MutatingWebhookConfiguration{}
 	if err := kubeClient.Get(ctx, types.NamespacedName{Name: pki.MutatingWebhookConfigurationName}, config); err != nil {
 		return err
@@ -432,6 +436,10 @@ func (pki PublicKeyInfrastructure) injectPublicKeyIntoMutatingWebhook(
 func (pki PublicKeyInfrastructure) injectPublicKeyIntoValidatingWebhook(
 	ctx context.Context, kubeClient client.Client, tlsSecret *v1.Secret,
 ) error {
+	if pki.ValidatingWebhookConfigurationName == "" {
+		return nil
+	}
+
 	config := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 	if err := kubeClient.Get(ctx, types.NamespacedName{Name: pki.ValidatingWebhookConfigurationName}, config); err != nil {
 		return err
@@ -0,0 +1,252 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	// awsPCATimeout is the timeout of the requests to AWS Private CA
+	awsPCATimeout = 30 * time.Second
+
+	// awsPCAService is the name of the AWS Private CA service
+	// used to sign the requests
+	awsPCAService = "acm-pca"
+
+	// awsPCARequestInProgress is the error returned by AWS Private CA
+	// while the certificate is not issued yet
+	awsPCARequestInProgress = "RequestInProgressException"
+
+	// awsPCADefaultPollInterval is the default interval between the
+	// requests checking whether the certificate has been issued
+	awsPCADefaultPollInterval = 2 * time.Second
+
+	// awsPCAMaxPollAttempts is the maximum number of requests checking
+	// whether the certificate has been issued
+	awsPCAMaxPollAttempts = 15
+)
+
+// AWSCredentials are the credentials used to sign the requests
+// to the AWS APIs
+type AWSCredentials struct {
+	// AccessKeyID is the AWS access key ID
+	AccessKeyID string
+
+	// SecretAccessKey is the AWS secret access key
+	SecretAccessKey string
+
+	// SessionToken is the session token of temporary credentials
+	SessionToken string
+}
+
+// AWSPrivateCAConfiguration contains the parameters needed to issue
+// certificates with AWS Private Certificate Authority
+type AWSPrivateCAConfiguration struct {
+	// Region is the AWS region of the certificate authority
+	Region string
+
+	// CertificateAuthorityARN is the ARN of the certificate authority
+	CertificateAuthorityARN string
+
+	// TemplateARN is the ARN of the certificate template. Empty
+	// means the default end entity certificate template
+	TemplateARN string
+
+	// SigningAlgorithm is the algorithm used to sign the certificates
+	SigningAlgorithm string
+
+	// ValidityDays is the validity of the certificates in days
+	ValidityDays int
+
+	// Credentials are the credentials used to sign the requests
+	Credentials AWSCredentials
+
+	// Endpoint overrides the endpoint of the AWS Private CA API
+	Endpoint string
+
+	// PollInterval is the interval between the requests checking
+	// whether the certificate has been issued
+	PollInterval time.Duration
+}
+
+// awsPrivateCAProvider issues the certificates with the IssueCertificate
+// and GetCertificate actions of the AWS Private CA API
+type awsPrivateCAProvider struct {
+	configuration *AWSPrivateCAConfiguration
+	httpClient    *http.Client
+	signer        *v4.Signer
+}
+
+// NewAWSPrivateCAProvider creates a provider issuing the certificates
+// with AWS Private Certificate Authority
+func NewAWSPrivateCAProvider(configuration *AWSPrivateCAConfiguration) Provider {
+	return &awsPrivateCAProvider{
+		configuration: configuration,
+		httpClient:    &http.Client{Timeout: awsPCATimeout},
+		signer:        v4.NewSigner(),
+	}
+}
+
+// awsPCAError is an error returned by the AWS Private CA API
+type awsPCAError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (e *awsPCAError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// Issue implements the Provider interface
+func (p *awsPrivateCAProvider) Issue(ctx context.Context, request *Request) (*Certificate, error) {
+	idempotencyToken := make([]byte, 16)
+	if _, err := rand.Read(idempotencyToken); err != nil {
+		return nil, err
+	}
+
+	issueRequest := map[string]any{
+		"CertificateAuthorityArn": p.configuration.CertificateAuthorityARN,
+		// The CSR is a blob, which is base64 encoded by json.Marshal
+		"Csr":              request.CSR,
+		"SigningAlgorithm": p.configuration.SigningAlgorithm,
+		"Validity": map[string]any{
+			"Type":  "DAYS",
+			"Value": p.configuration.ValidityDays,
+		},
+		"IdempotencyToken": hex.EncodeToString(idempotencyToken),
+	}
+	if p.configuration.TemplateARN != "" {
+		issueRequest["TemplateArn"] = p.configuration.TemplateARN
+	}
+
+	var issueResponse struct {
+		CertificateArn string `json:"CertificateArn"`
+	}
+	if err := p.invoke(ctx, "IssueCertificate", issueRequest, &issueResponse); err != nil {
+		return nil, err
+	}
+
+	pollInterval := p.configuration.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = awsPCADefaultPollInterval
+	}
+
+	getRequest := map[string]string{
+		"CertificateAuthorityArn": p.configuration.CertificateAuthorityARN,
+		"CertificateArn":          issueResponse.CertificateArn,
+	}
+	for attempt := 0; ; attempt++ {
+		var getResponse struct {
+			Certificate      string `json:"Certificate"`
+			CertificateChain string `json:"CertificateChain"`
+		}
+		err := p.invoke(ctx, "GetCertificate", getRequest, &getResponse)
+		if err == nil {
+			if getResponse.Certificate == "" {
+				return nil, ErrEmptyCertificate
+			}
+			return &Certificate{
+				Certificate: []byte(joinPEM(getResponse.Certificate)),
+				CA:          []byte(joinPEM(getResponse.CertificateChain)),
+			}, nil
+		}
+
+		var pcaError *awsPCAError
+		if !errors.As(err, &pcaError) || pcaError.Type != awsPCARequestInProgress ||
+			attempt+1 >= awsPCAMaxPollAttempts {
+			return nil, fmt.Errorf("while getting certificate %s: %w", issueResponse.CertificateArn, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// invoke calls an action of the AWS Private CA API, decoding its response
+func (p *awsPrivateCAProvider) invoke(ctx context.Context, action string, request, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	endpoint := p.configuration.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com/", awsPCAService, p.configuration.Region)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	httpRequest.Header.Set("X-Amz-Target", "ACMPrivateCA."+action)
+	payloadHash := sha256.Sum256(body)
+	credentials := aws.Credentials{
+		AccessKeyID:     p.configuration.Credentials.AccessKeyID,
+		SecretAccessKey: p.configuration.Credentials.SecretAccessKey,
+		SessionToken:    p.configuration.Credentials.SessionToken,
+	}
+	if err := p.signer.SignHTTP(ctx, credentials, httpRequest, hex.EncodeToString(payloadHash[:]),
+		awsPCAService, p.configuration.Region, time.Now()); err != nil {
+		return fmt.Errorf("while signing the AWS Private CA %s request: %w", action, err)
+	}
+
+	httpResponse, err := p.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = httpResponse.Body.Close()
+	}()
+
+	content, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		var pcaError awsPCAError
+		if err := json.Unmarshal(content, &pcaError); err != nil || pcaError.Type == "" {
+			return fmt.Errorf("AWS Private CA %s failed with status %d", action, httpResponse.StatusCode)
+		}
+		// The error type can be qualified with its namespace
+		if idx := strings.LastIndexByte(pcaError.Type, '#'); idx >= 0 {
+			pcaError.Type = pcaError.Type[idx+1:]
+		}
+		return &pcaError
+	}
+
+	return json.Unmarshal(content, response)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWS Private CA provider", func() {
+	const (
+		caARN          = "arn:aws:acm-pca:eu-west-1:123456789012:certificate-authority/ca"
+		certificateARN = caARN + "/certificate/1"
+	)
+
+	var (
+		ca            *testCA
+		server        *httptest.Server
+		request       *Request
+		getAttempts   int
+		issuedRequest map[string]any
+		signed        string
+	)
+
+	BeforeEach(func() {
+		ca = newTestCA()
+		getAttempts = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Header.Get("Authorization")).To(HavePrefix(
+				"AWS4-HMAC-SHA256 Credential=AKID/"))
+			Expect(r.Header.Get("Authorization")).To(ContainSubstring("/eu-west-1/acm-pca/aws4_request"))
+			Expect(r.Header.Get("X-Amz-Security-Token")).To(Equal("session"))
+
+			var body map[string]any
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			Expect(body["CertificateAuthorityArn"]).To(Equal(caARN))
+
+			switch r.Header.Get("X-Amz-Target") {
+			case "ACMPrivateCA.IssueCertificate":
+				issuedRequest = body
+				csr, err := base64.StdEncoding.DecodeString(body["Csr"].(string))
+				Expect(err).ToNot(HaveOccurred())
+				signed = ca.sign(csr)
+				_, _ = w.Write([]byte(`{"CertificateArn":"` + certificateARN + `"}`))
+
+			case "ACMPrivateCA.GetCertificate":
+				Expect(body["CertificateArn"]).To(Equal(certificateARN))
+				getAttempts++
+				if getAttempts == 1 {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"__type":"RequestInProgressException","message":"in progress"}`))
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{
+					"Certificate":      signed,
+					"CertificateChain": ca.pem,
+				})
+
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazon.coral.service#UnknownOperationException"}`))
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		var err error
+		request, _, err = NewRequest("streaming_replica", UsageClient, nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	newProvider := func() Provider {
+		return NewAWSPrivateCAProvider(&AWSPrivateCAConfiguration{
+			Region:                  "eu-west-1",
+			CertificateAuthorityARN: caARN,
+			SigningAlgorithm:        "SHA256WITHECDSA",
+			ValidityDays:            90,
+			Credentials: AWSCredentials{
+				AccessKeyID:     "AKID",
+				SecretAccessKey: "secret",
+				SessionToken:    "session",
+			},
+			Endpoint:     server.URL,
+			PollInterval: time.Millisecond,
+		})
+	}
+
+	It("issues the certificate waiting for it to be ready", func(ctx context.Context) {
+		certificate, err := newProvider().Issue(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getAttempts).To(Equal(2))
+		Expect(string(certificate.Certificate)).To(Equal(signed))
+		Expect(string(certificate.CA)).To(Equal(ca.pem))
+
+		Expect(issuedRequest["SigningAlgorithm"]).To(Equal("SHA256WITHECDSA"))
+		Expect(issuedRequest["Validity"]).To(Equal(map[string]any{"Type": "DAYS", "Value": 90.0}))
+		Expect(issuedRequest).ToNot(HaveKey("TemplateArn"))
+	})
+
+	It("strips the namespace from the error types", func(ctx context.Context) {
+		provider := newProvider().(*awsPrivateCAProvider)
+		err := provider.invoke(ctx, "Unknown", map[string]string{"CertificateAuthorityArn": caARN}, nil)
+		var pcaError *awsPCAError
+		Expect(err).To(BeAssignableToTypeOf(pcaError))
+		Expect(strings.HasPrefix(err.Error(), "UnknownOperationException")).To(BeTrue())
+	})
+})
@@ -0,0 +1,156 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+)
+
+// Usage is the intended usage of an issued certificate
+type Usage string
+
+const (
+	// UsageServer is the usage of the certificates of the PostgreSQL server
+	UsageServer Usage = "server"
+
+	// UsageClient is the usage of the certificates authenticating a user
+	UsageClient Usage = "client"
+)
+
+// Request is the request of a new certificate. The private key is
+// generated by the operator and never leaves the cluster: only the
+// certificate signing request is sent to the provider
+type Request struct {
+	// CommonName is the common name of the certificate
+	CommonName string
+
+	// DNSNames are the DNS alternative names of the certificate
+	DNSNames []string
+
+	// IPAddresses are the IP alternative names of the certificate
+	IPAddresses []net.IP
+
+	// Usage is the intended usage of the certificate
+	Usage Usage
+
+	// CSR is the PEM encoded certificate signing request
+	CSR []byte
+}
+
+// Certificate is a certificate issued by a provider
+type Certificate struct {
+	// Certificate is the PEM encoded certificate, followed by the
+	// intermediate CA certificates, if any
+	Certificate []byte
+
+	// CA is the PEM encoded certificate chain of the issuing CA
+	CA []byte
+}
+
+// Provider issues the certificates of a cluster
+type Provider interface {
+	// Issue signs the certificate signing request contained
+	// in the request
+	Issue(ctx context.Context, request *Request) (*Certificate, error)
+}
+
+// ErrEmptyCertificate is returned when a provider doesn't
+// include any certificate in its response
+var ErrEmptyCertificate = errors.New("the provider returned an empty certificate")
+
+const (
+	// ecPrivateKeyPEMBlockType is the PEM block type of the
+	// elliptic curve private keys
+	ecPrivateKeyPEMBlockType = "EC PRIVATE KEY"
+
+	// csrPEMBlockType is the PEM block type of the certificate
+	// signing requests
+	csrPEMBlockType = "CERTIFICATE REQUEST"
+)
+
+// NewRequest generates a new private key and the certificate signing
+// request for it, returning the request and the PEM encoded private key.
+// The common name of the server certificates is added to their
+// alternative names, as the self-signed CA of the operator does
+func NewRequest(commonName string, usage Usage, altDNSNames []string) (*Request, []byte, error) {
+	request := &Request{
+		CommonName: commonName,
+		DNSNames:   slices.Clone(altDNSNames),
+		Usage:      usage,
+	}
+	if usage == UsageServer {
+		if ip := net.ParseIP(commonName); ip != nil {
+			request.IPAddresses = append(request.IPAddresses, ip)
+		} else if !slices.Contains(request.DNSNames, commonName) {
+			request.DNSNames = append(request.DNSNames, commonName)
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: commonName},
+		DNSNames:    request.DNSNames,
+		IPAddresses: request.IPAddresses,
+	}, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating the certificate signing request: %w", err)
+	}
+	request.CSR = pem.EncodeToMemory(&pem.Block{Type: csrPEMBlockType, Bytes: csr})
+
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return request, pem.EncodeToMemory(&pem.Block{Type: ecPrivateKeyPEMBlockType, Bytes: privateKey}), nil
+}
+
+// HasNames checks whether a PEM encoded certificate contains all the
+// passed DNS alternative names. Providers are free to add more names,
+// like the common name, so the names are not required to be equal
+func HasNames(certificate []byte, dnsNames []string) (bool, error) {
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return false, errors.New("invalid PEM encoded certificate")
+	}
+
+	parsedCertificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range dnsNames {
+		if !slices.Contains(parsedCertificate.DNSNames, name) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
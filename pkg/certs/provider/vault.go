@@ -0,0 +1,127 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/vault"
+)
+
+// VaultPKIConfiguration contains the parameters needed to issue
+// certificates with the PKI secrets engine of HashiCorp Vault
+type VaultPKIConfiguration struct {
+	// Address is the URL of the Vault server
+	Address string
+
+	// MountPath is the path where the PKI secrets engine is mounted
+	MountPath string
+
+	// Role is the name of the role used to sign the certificates
+	Role string
+
+	// TTL is the requested validity of the certificates, using
+	// the Vault duration format. Empty means the role default
+	TTL string
+
+	// Token is the Vault token used to authenticate the requests
+	Token string
+
+	// CA is the PEM encoded CA certificate of the Vault server
+	CA []byte
+}
+
+// vaultPKIProvider issues the certificates using the sign endpoint
+// of the Vault PKI secrets engine
+type vaultPKIProvider struct {
+	configuration *VaultPKIConfiguration
+	client        *vault.Client
+}
+
+// NewVaultPKIProvider creates a provider issuing the certificates
+// with the PKI secrets engine of HashiCorp Vault
+func NewVaultPKIProvider(configuration *VaultPKIConfiguration) (Provider, error) {
+	client, err := vault.NewClient(configuration.Address, configuration.Token, configuration.CA)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vaultPKIProvider{
+		configuration: configuration,
+		client:        client,
+	}, nil
+}
+
+// Issue implements the Provider interface
+func (p *vaultPKIProvider) Issue(ctx context.Context, request *Request) (*Certificate, error) {
+	ipSANs := make([]string, len(request.IPAddresses))
+	for idx, ip := range request.IPAddresses {
+		ipSANs[idx] = ip.String()
+	}
+
+	signRequest := map[string]any{
+		"csr":                  string(request.CSR),
+		"common_name":          request.CommonName,
+		"alt_names":            strings.Join(request.DNSNames, ","),
+		"ip_sans":              strings.Join(ipSANs, ","),
+		"format":               "pem",
+		"exclude_cn_from_sans": "true",
+	}
+	if p.configuration.TTL != "" {
+		signRequest["ttl"] = p.configuration.TTL
+	}
+
+	var response struct {
+		Certificate string   `json:"certificate"`
+		IssuingCA   string   `json:"issuing_ca"`
+		CAChain     []string `json:"ca_chain"`
+	}
+	signPath := path.Join(strings.Trim(p.configuration.MountPath, "/"), "sign", p.configuration.Role)
+	if err := p.client.Write(ctx, signPath, signRequest, &response); err != nil {
+		return nil, err
+	}
+	if response.Certificate == "" {
+		return nil, ErrEmptyCertificate
+	}
+
+	caChain := response.CAChain
+	if len(caChain) == 0 {
+		caChain = []string{response.IssuingCA}
+	}
+
+	return &Certificate{
+		Certificate: []byte(joinPEM(response.Certificate)),
+		CA:          []byte(joinPEM(caChain...)),
+	}, nil
+}
+
+// joinPEM concatenates a list of PEM blocks, making sure
+// each one of them ends with a new line
+func joinPEM(blocks ...string) string {
+	var result strings.Builder
+	for _, block := range blocks {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		result.WriteString(block)
+		result.WriteString("\n")
+	}
+	return result.String()
+}
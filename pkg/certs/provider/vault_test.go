@@ -0,0 +1,95 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Vault PKI provider", func() {
+	var (
+		ca      *testCA
+		server  *httptest.Server
+		request *Request
+	)
+
+	BeforeEach(func() {
+		ca = newTestCA()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "s.token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			Expect(r.URL.Path).To(Equal("/v1/pki_int/sign/postgres"))
+
+			var body map[string]string
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			Expect(body["common_name"]).To(Equal("cluster-example-rw"))
+			Expect(body["alt_names"]).To(Equal("cluster-example-rw.default,cluster-example-rw"))
+			Expect(body["ttl"]).To(Equal("720h"))
+
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"certificate": ca.sign([]byte(body["csr"])),
+					"issuing_ca":  ca.pem,
+					"ca_chain":    []string{ca.pem},
+				},
+			})
+		}))
+		DeferCleanup(server.Close)
+
+		var err error
+		request, _, err = NewRequest("cluster-example-rw", UsageServer, []string{"cluster-example-rw.default"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	newProvider := func(token string) Provider {
+		provider, err := NewVaultPKIProvider(&VaultPKIConfiguration{
+			Address:   server.URL,
+			MountPath: "/pki_int/",
+			Role:      "postgres",
+			TTL:       "720h",
+			Token:     token,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return provider
+	}
+
+	It("signs the certificate requests", func(ctx context.Context) {
+		certificate, err := newProvider("s.token").Issue(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(certificate.CA)).To(Equal(ca.pem))
+		Expect(HasNames(certificate.Certificate, request.DNSNames)).To(BeTrue())
+	})
+
+	It("reports the errors returned by Vault", func(ctx context.Context) {
+		_, err := newProvider("wrong").Issue(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("permission denied")))
+	})
+
+	It("rejects an invalid CA certificate", func() {
+		_, err := NewVaultPKIProvider(&VaultPKIConfiguration{CA: []byte("invalid")})
+		Expect(err).To(HaveOccurred())
+	})
+})
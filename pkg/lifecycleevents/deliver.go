@@ -0,0 +1,72 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Configuration contains the destinations of the lifecycle events,
+// including the credentials retrieved from the Kubernetes secrets
+type Configuration struct {
+	// Webhook is the configuration of a webhook destination
+	Webhook *WebhookConfiguration
+
+	// NATS is the configuration of a NATS server destination
+	NATS *NATSConfiguration
+}
+
+// sender sends a serialized event to a destination
+type sender interface {
+	send(ctx context.Context, payload []byte) error
+}
+
+// destination is a named sender
+type destination struct {
+	name   string
+	sender sender
+}
+
+// Deliver sends the event to every configured destination, returning
+// the errors of the ones which failed
+func Deliver(ctx context.Context, configuration *Configuration, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var destinations []destination
+	if configuration.Webhook != nil {
+		destinations = append(destinations, destination{name: "webhook", sender: newWebhookSender(configuration.Webhook)})
+	}
+	if configuration.NATS != nil {
+		destinations = append(destinations, destination{name: "NATS", sender: newNATSSender(configuration.NATS)})
+	}
+
+	var errs []error
+	for _, destination := range destinations {
+		if err := destination.sender.send(ctx, payload); err != nil {
+			errs = append(errs, fmt.Errorf("while sending the event to the %s destination: %w",
+				destination.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// eventSource is the component reported as the source of the
+// Kubernetes events
+const eventSource = "cloudnative-pg"
+
+// Event is a transition in the life of a cluster
+type Event struct {
+	// Type is the kind of transition
+	Type apiv1.LifecycleEventType `json:"type"`
+
+	// Time is when the operator observed the transition
+	Time metav1.Time `json:"time"`
+
+	// Namespace is the namespace of the cluster
+	Namespace string `json:"namespace"`
+
+	// Cluster is the name of the cluster
+	Cluster string `json:"cluster"`
+
+	// Instance is the instance involved in the transition: the promoted
+	// primary or the one elected by the failover
+	Instance string `json:"instance,omitempty"`
+
+	// PreviousInstance is the primary instance before the transition
+	PreviousInstance string `json:"previousInstance,omitempty"`
+
+	// Backup is the name of the completed backup
+	Backup string `json:"backup,omitempty"`
+
+	// Message is a human-readable description of the transition
+	Message string `json:"message"`
+}
+
+// NewEvent creates an event of the given cluster happening now
+func NewEvent(cluster *apiv1.Cluster, eventType apiv1.LifecycleEventType, message string) Event {
+	return Event{
+		Type:      eventType,
+		Time:      metav1.Now(),
+		Namespace: cluster.Namespace,
+		Cluster:   cluster.Name,
+		Message:   message,
+	}
+}
+
+// Reason is the reason of the Kubernetes event, i.e. `BackupCompleted`
+func (e Event) Reason() string {
+	if e.Type == "" {
+		return ""
+	}
+	return strings.ToUpper(string(e.Type[:1])) + string(e.Type[1:])
+}
+
+// ToKubernetesEvent creates the Kubernetes event notified to the plugins
+// through the lifecycle hook. The event refers to the cluster, while the
+// instance or the backup involved in the transition is the related object
+func (e Event) ToKubernetesEvent(cluster *apiv1.Cluster) (*corev1.Event, error) {
+	serializedEvent, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &corev1.Event{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Event",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", cluster.Name, e.Time.UnixNano()),
+			Namespace: cluster.Namespace,
+			Annotations: map[string]string{
+				utils.LifecycleEventAnnotationName: string(serializedEvent),
+			},
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: apiv1.GroupVersion.String(),
+			Kind:       apiv1.ClusterKind,
+			Namespace:  cluster.Namespace,
+			Name:       cluster.Name,
+			UID:        cluster.UID,
+		},
+		Reason:              e.Reason(),
+		Message:             e.Message,
+		Type:                corev1.EventTypeNormal,
+		Source:              corev1.EventSource{Component: eventSource},
+		ReportingController: eventSource,
+		FirstTimestamp:      e.Time,
+		LastTimestamp:       e.Time,
+		Count:               1,
+	}
+
+	switch {
+	case e.Backup != "":
+		event.Related = &corev1.ObjectReference{
+			APIVersion: apiv1.GroupVersion.String(),
+			Kind:       apiv1.BackupKind,
+			Namespace:  cluster.Namespace,
+			Name:       e.Backup,
+		}
+	case e.Instance != "":
+		event.Related = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  cluster.Namespace,
+			Name:       e.Instance,
+		}
+	}
+
+	if e.Type == apiv1.LifecycleEventTypeFailover {
+		event.Type = corev1.EventTypeWarning
+	}
+
+	return event, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lifecycle events", func() {
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+	}
+
+	It("uses the event type as the reason of the Kubernetes event", func() {
+		Expect(Event{Type: apiv1.LifecycleEventTypeBackupCompleted}.Reason()).To(Equal("BackupCompleted"))
+		Expect(Event{Type: apiv1.LifecycleEventTypePromotion}.Reason()).To(Equal("Promotion"))
+		Expect(Event{}.Reason()).To(BeEmpty())
+	})
+
+	It("refers the Kubernetes event to the promoted instance", func() {
+		event := NewEvent(cluster, apiv1.LifecycleEventTypePromotion, "Promoted cluster-example-2")
+		event.Instance = "cluster-example-2"
+		event.PreviousInstance = "cluster-example-1"
+
+		kubernetesEvent, err := event.ToKubernetesEvent(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kubernetesEvent.Namespace).To(Equal("default"))
+		Expect(kubernetesEvent.InvolvedObject.Kind).To(Equal(apiv1.ClusterKind))
+		Expect(kubernetesEvent.InvolvedObject.Name).To(Equal("cluster-example"))
+		Expect(kubernetesEvent.Reason).To(Equal("Promotion"))
+		Expect(kubernetesEvent.Type).To(Equal(corev1.EventTypeNormal))
+		Expect(kubernetesEvent.Related).ToNot(BeNil())
+		Expect(kubernetesEvent.Related.Kind).To(Equal("Pod"))
+		Expect(kubernetesEvent.Related.Name).To(Equal("cluster-example-2"))
+
+		var annotatedEvent Event
+		Expect(json.Unmarshal(
+			[]byte(kubernetesEvent.Annotations[utils.LifecycleEventAnnotationName]),
+			&annotatedEvent,
+		)).To(Succeed())
+		Expect(annotatedEvent.PreviousInstance).To(Equal("cluster-example-1"))
+	})
+
+	It("refers the Kubernetes event to the completed backup", func() {
+		event := NewEvent(cluster, apiv1.LifecycleEventTypeBackupCompleted, "Backup completed")
+		event.Backup = "backup-example"
+
+		kubernetesEvent, err := event.ToKubernetesEvent(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kubernetesEvent.Related.Kind).To(Equal(apiv1.BackupKind))
+		Expect(kubernetesEvent.Related.Name).To(Equal("backup-example"))
+	})
+
+	It("notifies the failovers as warnings", func() {
+		event := NewEvent(cluster, apiv1.LifecycleEventTypeFailover, "Failing over")
+		kubernetesEvent, err := event.ToKubernetesEvent(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kubernetesEvent.Type).To(Equal(corev1.EventTypeWarning))
+	})
+})
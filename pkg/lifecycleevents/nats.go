@@ -0,0 +1,88 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsTimeout is the time allowed to publish an event when the
+// context has no deadline
+const natsTimeout = 10 * time.Second
+
+// NATSConfiguration contains the parameters needed to publish the
+// events to a NATS server
+type NATSConfiguration struct {
+	// URL is the URL of the server, i.e. `nats://nats.messaging:4222`
+	URL string
+
+	// Subject is the subject the events are published to
+	Subject string
+}
+
+// natsSender publishes the events to a NATS server with the core NATS
+// protocol, opening a new connection for every event
+type natsSender struct {
+	configuration *NATSConfiguration
+}
+
+func newNATSSender(configuration *NATSConfiguration) *natsSender {
+	return &natsSender{configuration: configuration}
+}
+
+// send implements the sender interface. The connection is flushed before
+// being closed, so the server processed the event when no error is returned
+func (s *natsSender) send(ctx context.Context, payload []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, natsTimeout)
+		defer cancel()
+	}
+
+	timeout := natsTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	conn, err := nats.Connect(s.configuration.URL,
+		nats.Name("cloudnative-pg"),
+		nats.Timeout(timeout),
+		nats.NoReconnect(),
+	)
+	if err != nil {
+		return fmt.Errorf("while connecting to the NATS server: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Publish(s.configuration.Subject, payload); err != nil {
+		return fmt.Errorf("while publishing the event: %w", err)
+	}
+
+	if err := conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("while publishing the event: %w", err)
+	}
+
+	if err := conn.LastError(); err != nil {
+		return fmt.Errorf("the NATS server rejected the event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// natsServerInfo is the greeting of the fake NATS server
+const natsServerInfo = `{"server_id":"test","version":"2.10.0","proto":1,"max_payload":1048576}`
+
+// natsPublication is a message received by the fake NATS server
+type natsPublication struct {
+	subject string
+	payload []byte
+}
+
+// startFakeNATSServer starts a NATS server accepting a single connection.
+// The server answers to the first PING of the client, sent while connecting,
+// with connectReply, and to every publication with publishReply, if not empty
+func startFakeNATSServer(connectReply, publishReply string) (string, <-chan natsPublication) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+	DeferCleanup(listener.Close)
+
+	publications := make(chan natsPublication, 1)
+	go func() {
+		defer GinkgoRecover()
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		_, _ = fmt.Fprintf(conn, "INFO %s\r\n", natsServerInfo)
+		reader := bufio.NewReader(conn)
+		connected := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch fields := strings.Fields(line); fields[0] {
+			case "PUB":
+				size, err := strconv.Atoi(fields[2])
+				Expect(err).ToNot(HaveOccurred())
+				payload := make([]byte, size+2)
+				_, err = io.ReadFull(reader, payload)
+				Expect(err).ToNot(HaveOccurred())
+				publications <- natsPublication{subject: fields[1], payload: payload[:size]}
+				if publishReply != "" {
+					_, _ = fmt.Fprintf(conn, "%s\r\n", publishReply)
+				}
+			case "PING":
+				reply := "PONG"
+				if !connected {
+					reply = connectReply
+					connected = true
+				}
+				_, _ = fmt.Fprintf(conn, "%s\r\n", reply)
+			}
+		}
+	}()
+
+	return "nats://" + listener.Addr().String(), publications
+}
+
+var _ = Describe("NATS destination", func() {
+	event := Event{
+		Type:      apiv1.LifecycleEventTypeFailover,
+		Namespace: "default",
+		Cluster:   "cluster-example",
+		Instance:  "cluster-example-2",
+		Message:   "Failing over from cluster-example-1 to cluster-example-2",
+	}
+
+	It("publishes the event to the subject", func(ctx context.Context) {
+		serverURL, publications := startFakeNATSServer("PONG", "")
+
+		Expect(Deliver(ctx, &Configuration{
+			NATS: &NATSConfiguration{URL: serverURL, Subject: "cnpg.events"},
+		}, event)).To(Succeed())
+
+		var publication natsPublication
+		Eventually(publications).Should(Receive(&publication))
+		Expect(publication.subject).To(Equal("cnpg.events"))
+
+		var received Event
+		Expect(json.Unmarshal(publication.payload, &received)).To(Succeed())
+		Expect(received.Type).To(Equal(apiv1.LifecycleEventTypeFailover))
+		Expect(received.Instance).To(Equal("cluster-example-2"))
+	})
+
+	It("fails when the server rejects the event", func(ctx context.Context) {
+		serverURL, _ := startFakeNATSServer("PONG", `-ERR 'Permissions Violation for Publish to "cnpg.events"'`)
+
+		err := Deliver(ctx, &Configuration{
+			NATS: &NATSConfiguration{URL: serverURL, Subject: "cnpg.events"},
+		}, event)
+		Expect(err).To(MatchError(nats.ErrPermissionViolation))
+	})
+
+	It("fails when the server refuses the connection", func(ctx context.Context) {
+		serverURL, _ := startFakeNATSServer("-ERR 'Authorization Violation'", "")
+
+		err := Deliver(ctx, &Configuration{
+			NATS: &NATSConfiguration{URL: serverURL, Subject: "cnpg.events"},
+		}, event)
+		Expect(err).To(MatchError(nats.ErrAuthorization))
+	})
+})
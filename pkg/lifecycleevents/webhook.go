@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpTimeout is the timeout of the requests to the webhooks
+const httpTimeout = 10 * time.Second
+
+// WebhookConfiguration contains the parameters needed to send the
+// events to a webhook
+type WebhookConfiguration struct {
+	// URL is the URL of the webhook
+	URL string
+
+	// Authorization is the value of the `Authorization` header, if any
+	Authorization string
+}
+
+// webhookSender posts the events as JSON documents to a webhook
+type webhookSender struct {
+	configuration *WebhookConfiguration
+	httpClient    *http.Client
+}
+
+func newWebhookSender(configuration *WebhookConfiguration) *webhookSender {
+	return &webhookSender{
+		configuration: configuration,
+		httpClient:    &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// send implements the sender interface
+func (s *webhookSender) send(ctx context.Context, payload []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.configuration.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if s.configuration.Authorization != "" {
+		request.Header.Set("Authorization", s.configuration.Authorization)
+	}
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook destination", func() {
+	event := Event{
+		Type:      apiv1.LifecycleEventTypeCertificateRotated,
+		Namespace: "default",
+		Cluster:   "cluster-example",
+		Message:   "Completed the rotation of the TLS certificates",
+	}
+
+	It("posts the event to the webhook", func(ctx context.Context) {
+		var (
+			received      Event
+			contentType   string
+			authorization string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			authorization = r.Header.Get("Authorization")
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		Expect(Deliver(ctx, &Configuration{
+			Webhook: &WebhookConfiguration{URL: server.URL, Authorization: "Bearer token"},
+		}, event)).To(Succeed())
+
+		Expect(contentType).To(Equal("application/json"))
+		Expect(authorization).To(Equal("Bearer token"))
+		Expect(received.Type).To(Equal(apiv1.LifecycleEventTypeCertificateRotated))
+		Expect(received.Cluster).To(Equal("cluster-example"))
+	})
+
+	It("fails when the webhook rejects the event", func(ctx context.Context) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "not allowed", http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		err := Deliver(ctx, &Configuration{
+			Webhook: &WebhookConfiguration{URL: server.URL},
+		}, event)
+		Expect(err).To(MatchError(ContainSubstring("webhook")))
+		Expect(err).To(MatchError(ContainSubstring("unexpected status code 403: not allowed")))
+	})
+})
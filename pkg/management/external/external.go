@@ -117,3 +117,19 @@ func ConfigureConnectionToServer(
 
 	return configfile.CreateConnectionString(connectionParameters), nil
 }
+
+// GetServerPassword reads the password used to connect to the external
+// server from its secret. An empty string is returned when the external
+// server has no password
+func GetServerPassword(
+	ctx context.Context,
+	client ctrl.Client,
+	namespace string,
+	server *apiv1.ExternalCluster,
+) (string, error) {
+	if server.Password == nil {
+		return "", nil
+	}
+
+	return readSecretKeyRef(ctx, client, namespace, server.Password)
+}
@@ -0,0 +1,295 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+)
+
+const (
+	// httpTimeout is the timeout of the requests to the HTTP sinks
+	httpTimeout = 30 * time.Second
+
+	// defaultFlushInterval is the maximum time a log record is buffered
+	// when the flush interval is not specified
+	defaultFlushInterval = time.Second
+
+	// minRetryInterval is the time waited before sending again the log
+	// records after the sink failed for the first time
+	minRetryInterval = time.Second
+
+	// maxRetryInterval is the maximum time waited before sending again
+	// the log records to a failing sink
+	maxRetryInterval = 30 * time.Second
+
+	// stopTimeout is the time allowed to send the buffered log records
+	// when the forwarder is stopped
+	stopTimeout = 5 * time.Second
+)
+
+// LogEntry is a log record to be forwarded
+type LogEntry struct {
+	// Time is when the log record has been collected
+	Time time.Time
+
+	// Logger is the name of the logger of the record, i.e. `postgres`
+	Logger string
+
+	// Line is the JSON representation of the log record
+	Line []byte
+}
+
+// Source identifies the instance producing the log records
+type Source struct {
+	// Namespace is the namespace of the cluster
+	Namespace string
+
+	// Cluster is the name of the cluster
+	Cluster string
+
+	// Pod is the name of the instance
+	Pod string
+}
+
+// Configuration is the configuration of a log sink, including the
+// credentials retrieved from the Kubernetes secrets. Exactly one
+// destination is expected to be set
+type Configuration struct {
+	// Loki is the configuration of a Grafana Loki destination
+	Loki *LokiConfiguration
+
+	// Syslog is the configuration of a syslog destination
+	Syslog *SyslogConfiguration
+
+	// KafkaRestProxy is the configuration of a Kafka REST Proxy destination
+	KafkaRestProxy *KafkaRestProxyConfiguration
+
+	// BufferSize is the maximum number of buffered log records
+	BufferSize int
+
+	// BlockOnOverflow makes the forwarder wait for the sink when
+	// the buffer is full, instead of discarding the log records
+	BlockOnOverflow bool
+
+	// BatchSize is the maximum number of log records sent at once
+	BatchSize int
+
+	// FlushInterval is the maximum time a log record is buffered
+	FlushInterval time.Duration
+}
+
+// sender sends a batch of log records to a destination
+type sender interface {
+	send(ctx context.Context, entries []LogEntry) error
+	close() error
+}
+
+// newSender creates the sender described by the configuration
+func (c *Configuration) newSender(source Source) (sender, error) {
+	switch {
+	case c.Loki != nil:
+		return newLokiSender(c.Loki, source)
+	case c.Syslog != nil:
+		return newSyslogSender(c.Syslog), nil
+	case c.KafkaRestProxy != nil:
+		return newKafkaRestProxySender(c.KafkaRestProxy, source)
+	default:
+		return nil, errors.New("no log sink destination configured")
+	}
+}
+
+// Forwarder buffers the log records and sends them in batches to a
+// sink, retrying with an exponential backoff while the sink fails
+type Forwarder struct {
+	name          string
+	configuration *Configuration
+	sender        sender
+	buffer        chan LogEntry
+	dropped       atomic.Int64
+
+	cancel  context.CancelFunc
+	done    <-chan struct{}
+	stopped chan struct{}
+}
+
+// NewForwarder creates a forwarder sending the log records produced
+// by the passed source to the sink described by the configuration
+func NewForwarder(name string, configuration *Configuration, source Source) (*Forwarder, error) {
+	sender, err := configuration.newSender(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Forwarder{
+		name:          name,
+		configuration: configuration,
+		sender:        sender,
+		buffer:        make(chan LogEntry, max(configuration.BufferSize, 1)),
+		stopped:       make(chan struct{}),
+	}, nil
+}
+
+// Start starts sending the buffered log records to the sink,
+// until the forwarder is stopped or the context is cancelled
+func (f *Forwarder) Start(ctx context.Context) {
+	ctx, f.cancel = context.WithCancel(ctx)
+	f.done = ctx.Done()
+	go f.run(ctx)
+}
+
+// Stop stops the forwarder, trying to send the buffered log records
+func (f *Forwarder) Stop() {
+	f.cancel()
+	<-f.stopped
+}
+
+// Forward buffers a log record. When the buffer is full, the record is
+// discarded unless the forwarder is configured to block
+func (f *Forwarder) Forward(entry LogEntry) {
+	if f.configuration.BlockOnOverflow {
+		select {
+		case f.buffer <- entry:
+		case <-f.done:
+		}
+		return
+	}
+
+	select {
+	case f.buffer <- entry:
+	default:
+		f.dropped.Add(1)
+	}
+}
+
+// run collects the buffered log records in batches, sending them when
+// the batch is full or the flush interval expires
+func (f *Forwarder) run(ctx context.Context) {
+	defer close(f.stopped)
+	defer func() {
+		_ = f.sender.close()
+	}()
+
+	batchSize := max(f.configuration.BatchSize, 1)
+	batch := make([]LogEntry, 0, batchSize)
+	flushInterval := f.configuration.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			f.flushOnStop(batch)
+			return
+
+		case entry := <-f.buffer:
+			batch = append(batch, entry)
+			if len(batch) >= batchSize {
+				batch = f.flush(ctx, batch)
+			}
+
+		case <-ticker.C:
+			batch = f.flush(ctx, batch)
+		}
+	}
+}
+
+// flush sends a batch of log records, retrying until it succeeds or
+// the context is cancelled. It returns the batch emptied
+func (f *Forwarder) flush(ctx context.Context, batch []LogEntry) []LogEntry {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	contextLogger := log.FromContext(ctx).WithValues("sink", f.name)
+	retryInterval := minRetryInterval
+	for {
+		err := f.sender.send(ctx, batch)
+		if err == nil {
+			break
+		}
+
+		contextLogger.Warning("Cannot forward the log records, retrying",
+			"records", len(batch), "retryInterval", retryInterval, "err", err)
+		select {
+		case <-ctx.Done():
+			return batch[:0]
+		case <-time.After(retryInterval):
+		}
+		retryInterval = min(2*retryInterval, maxRetryInterval)
+	}
+
+	if dropped := f.dropped.Swap(0); dropped > 0 {
+		contextLogger.Warning("Discarded the log records not fitting in the buffer of the sink",
+			"records", dropped)
+	}
+
+	return batch[:0]
+}
+
+// flushOnStop tries to send the log records buffered when the
+// forwarder is stopped, without retrying
+func (f *Forwarder) flushOnStop(batch []LogEntry) {
+	for drained := false; !drained; {
+		select {
+		case entry := <-f.buffer:
+			batch = append(batch, entry)
+		default:
+			drained = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	for chunk := range slices.Chunk(batch, max(f.configuration.BatchSize, 1)) {
+		if err := f.sender.send(ctx, chunk); err != nil {
+			return
+		}
+	}
+}
+
+// newHTTPClient creates the client used to send the requests to
+// an HTTP sink, trusting the passed CA certificate if any
+func newHTTPClient(ca []byte) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(ca) > 0 {
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid CA certificate")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    caPool,
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   httpTimeout,
+	}, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSender collects the batches of log records, failing
+// the first sends when requested
+type fakeSender struct {
+	mu       sync.Mutex
+	batches  [][]LogEntry
+	failures int
+	closed   bool
+}
+
+func (s *fakeSender) send(_ context.Context, entries []LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.batches = append(s.batches, slices.Clone(entries))
+	return nil
+}
+
+func (s *fakeSender) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSender) getBatches() [][]LogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batches
+}
+
+func newTestForwarder(configuration *Configuration, sender sender) *Forwarder {
+	return &Forwarder{
+		name:          "test",
+		configuration: configuration,
+		sender:        sender,
+		buffer:        make(chan LogEntry, configuration.BufferSize),
+		stopped:       make(chan struct{}),
+	}
+}
+
+var _ = Describe("log forwarder", func() {
+	entry := func(line string) LogEntry {
+		return LogEntry{Time: time.Now(), Logger: "postgres", Line: []byte(line)}
+	}
+
+	It("sends the log records in batches", func(ctx context.Context) {
+		sender := &fakeSender{}
+		forwarder := newTestForwarder(&Configuration{
+			BufferSize:    10,
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+		}, sender)
+		forwarder.Start(ctx)
+
+		forwarder.Forward(entry("1"))
+		forwarder.Forward(entry("2"))
+		forwarder.Forward(entry("3"))
+		Eventually(sender.getBatches).Should(HaveLen(1))
+		Expect(sender.getBatches()[0]).To(HaveLen(2))
+
+		forwarder.Stop()
+		Expect(sender.getBatches()).To(HaveLen(2))
+		Expect(string(sender.getBatches()[1][0].Line)).To(Equal("3"))
+		Expect(sender.closed).To(BeTrue())
+	})
+
+	It("sends the log records when the flush interval expires", func(ctx context.Context) {
+		sender := &fakeSender{}
+		forwarder := newTestForwarder(&Configuration{
+			BufferSize:    10,
+			BatchSize:     100,
+			FlushInterval: 10 * time.Millisecond,
+		}, sender)
+		forwarder.Start(ctx)
+		defer forwarder.Stop()
+
+		forwarder.Forward(entry("1"))
+		Eventually(sender.getBatches).Should(HaveLen(1))
+	})
+
+	It("retries the batches the sink failed to receive", func(ctx context.Context) {
+		sender := &fakeSender{failures: 1}
+		forwarder := newTestForwarder(&Configuration{
+			BufferSize:    10,
+			BatchSize:     1,
+			FlushInterval: time.Hour,
+		}, sender)
+		forwarder.Start(ctx)
+		defer forwarder.Stop()
+
+		forwarder.Forward(entry("1"))
+		Eventually(sender.getBatches, 5*time.Second).Should(HaveLen(1))
+		Expect(string(sender.getBatches()[0][0].Line)).To(Equal("1"))
+	})
+
+	It("discards the log records not fitting in the buffer", func() {
+		forwarder := newTestForwarder(&Configuration{BufferSize: 1, BatchSize: 1}, &fakeSender{})
+
+		forwarder.Forward(entry("1"))
+		forwarder.Forward(entry("2"))
+		Expect(forwarder.buffer).To(HaveLen(1))
+		Expect(forwarder.dropped.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("requires a destination", func() {
+		_, err := NewForwarder("test", &Configuration{}, Source{})
+		Expect(err).To(HaveOccurred())
+	})
+})
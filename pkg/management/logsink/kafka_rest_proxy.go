@@ -0,0 +1,136 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// KafkaRestProxyConfiguration contains the parameters needed to produce
+// the log records to a Kafka topic through the Kafka REST Proxy
+type KafkaRestProxyConfiguration struct {
+	// URL is the URL of the Kafka REST Proxy
+	URL string
+
+	// Topic is the name of the topic
+	Topic string
+
+	// Username is the user authenticating to the REST Proxy, if any
+	Username string
+
+	// Password is the password of the user
+	Password string
+
+	// CA is the PEM encoded CA certificate of the REST Proxy
+	CA []byte
+}
+
+// kafkaRecord is a record produced with the v2 API of the REST Proxy
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaOffset is the result of producing a record
+type kafkaOffset struct {
+	Partition int    `json:"partition"`
+	ErrorCode *int   `json:"error_code"`
+	Error     string `json:"error"`
+}
+
+// kafkaRestProxySender produces the log records to a Kafka topic through
+// the REST Proxy, keyed by pod name to keep the records of every
+// instance in order
+type kafkaRestProxySender struct {
+	configuration *KafkaRestProxyConfiguration
+	key           string
+	endpoint      string
+	httpClient    *http.Client
+}
+
+func newKafkaRestProxySender(configuration *KafkaRestProxyConfiguration, source Source) (*kafkaRestProxySender, error) {
+	httpClient, err := newHTTPClient(configuration.CA)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.JoinPath(configuration.URL, "topics", configuration.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafkaRestProxySender{
+		configuration: configuration,
+		key:           source.Pod,
+		endpoint:      endpoint,
+		httpClient:    httpClient,
+	}, nil
+}
+
+// send implements the sender interface
+func (s *kafkaRestProxySender) send(ctx context.Context, entries []LogEntry) error {
+	request := struct {
+		Records []kafkaRecord `json:"records"`
+	}{
+		Records: make([]kafkaRecord, len(entries)),
+	}
+	for idx, entry := range entries {
+		request.Records[idx] = kafkaRecord{Key: s.key, Value: entry.Line}
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	httpRequest.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if s.configuration.Username != "" {
+		httpRequest.SetBasicAuth(s.configuration.Username, s.configuration.Password)
+	}
+
+	var response struct {
+		Offsets []kafkaOffset `json:"offsets"`
+	}
+	if err := doHTTPRequest(s.httpClient, httpRequest, &response); err != nil {
+		return err
+	}
+
+	for _, offset := range response.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("cannot produce the log records to partition %d: %s (error code %d)",
+				offset.Partition, offset.Error, *offset.ErrorCode)
+		}
+	}
+
+	return nil
+}
+
+// close implements the sender interface
+func (s *kafkaRestProxySender) close() error {
+	s.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kafka REST Proxy sink", func() {
+	source := Source{Namespace: "default", Cluster: "cluster-example", Pod: "cluster-example-1"}
+	entries := []LogEntry{
+		{Time: time.Now(), Logger: "postgres", Line: []byte(`{"a":1}`)},
+		{Time: time.Now(), Logger: "postgres", Line: []byte(`{"a":2}`)},
+	}
+
+	It("produces the log records to the topic", func(ctx context.Context) {
+		var (
+			request struct {
+				Records []kafkaRecord `json:"records"`
+			}
+			path        string
+			contentType string
+			username    string
+			password    string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			contentType = r.Header.Get("Content-Type")
+			username, password, _ = r.BasicAuth()
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1},{"partition":0,"offset":2}]}`))
+		}))
+		defer server.Close()
+
+		sender, err := newKafkaRestProxySender(&KafkaRestProxyConfiguration{
+			URL:      server.URL,
+			Topic:    "postgres-logs",
+			Username: "user",
+			Password: "secret",
+		}, source)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sender.send(ctx, entries)).To(Succeed())
+
+		Expect(path).To(Equal("/topics/postgres-logs"))
+		Expect(contentType).To(Equal("application/vnd.kafka.json.v2+json"))
+		Expect(username).To(Equal("user"))
+		Expect(password).To(Equal("secret"))
+		Expect(request.Records).To(HaveLen(2))
+		Expect(request.Records[0].Key).To(Equal("cluster-example-1"))
+		Expect(request.Records[1].Value).To(MatchJSON(`{"a":2}`))
+	})
+
+	It("fails when a record cannot be produced", func(ctx context.Context) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(
+				`{"offsets":[{"partition":0,"offset":1},{"partition":1,"error_code":50003,"error":"timeout"}]}`))
+		}))
+		defer server.Close()
+
+		sender, err := newKafkaRestProxySender(&KafkaRestProxyConfiguration{URL: server.URL, Topic: "postgres-logs"}, source)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sender.send(ctx, entries)).To(MatchError(ContainSubstring("timeout")))
+	})
+})
@@ -0,0 +1,157 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// LokiConfiguration contains the parameters needed to push the log
+// records to Grafana Loki
+type LokiConfiguration struct {
+	// URL is the URL of the Loki push API
+	URL string
+
+	// TenantID is the tenant of the log streams
+	TenantID string
+
+	// Labels are the additional labels of the log streams
+	Labels map[string]string
+
+	// BearerToken is the token used to authenticate the requests
+	BearerToken string
+
+	// CA is the PEM encoded CA certificate of the Loki server
+	CA []byte
+}
+
+// lokiStream is a stream of the Loki push API
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// lokiSender pushes the log records to Loki, with a stream per logger
+type lokiSender struct {
+	configuration *LokiConfiguration
+	labels        map[string]string
+	httpClient    *http.Client
+}
+
+func newLokiSender(configuration *LokiConfiguration, source Source) (*lokiSender, error) {
+	httpClient, err := newHTTPClient(configuration.CA)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]string, len(configuration.Labels)+3)
+	for key, value := range configuration.Labels {
+		labels[key] = value
+	}
+	labels["namespace"] = source.Namespace
+	labels["cluster"] = source.Cluster
+	labels["pod"] = source.Pod
+
+	return &lokiSender{
+		configuration: configuration,
+		labels:        labels,
+		httpClient:    httpClient,
+	}, nil
+}
+
+// send implements the sender interface
+func (s *lokiSender) send(ctx context.Context, entries []LogEntry) error {
+	streams := make(map[string]*lokiStream)
+	var streamOrder []string
+	for _, entry := range entries {
+		stream, ok := streams[entry.Logger]
+		if !ok {
+			labels := make(map[string]string, len(s.labels)+1)
+			for key, value := range s.labels {
+				labels[key] = value
+			}
+			labels["logger"] = entry.Logger
+			stream = &lokiStream{Stream: labels}
+			streams[entry.Logger] = stream
+			streamOrder = append(streamOrder, entry.Logger)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(entry.Time.UnixNano(), 10),
+			string(entry.Line),
+		})
+	}
+
+	request := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, logger := range streamOrder {
+		request.Streams = append(request.Streams, streams[logger])
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, s.configuration.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if s.configuration.TenantID != "" {
+		httpRequest.Header.Set("X-Scope-OrgID", s.configuration.TenantID)
+	}
+	if s.configuration.BearerToken != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+s.configuration.BearerToken)
+	}
+
+	return doHTTPRequest(s.httpClient, httpRequest, nil)
+}
+
+// close implements the sender interface
+func (s *lokiSender) close() error {
+	s.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// doHTTPRequest sends a request to an HTTP sink, decoding the
+// response body in the passed value, if any
+func doHTTPRequest(httpClient *http.Client, request *http.Request, response any) error {
+	httpResponse, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = httpResponse.Body.Close()
+	}()
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", httpResponse.StatusCode, bytes.TrimSpace(message))
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
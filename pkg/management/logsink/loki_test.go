@@ -0,0 +1,95 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Loki sink", func() {
+	source := Source{Namespace: "default", Cluster: "cluster-example", Pod: "cluster-example-1"}
+
+	It("pushes a stream for each logger", func(ctx context.Context) {
+		var (
+			request struct {
+				Streams []lokiStream `json:"streams"`
+			}
+			header http.Header
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		sender, err := newLokiSender(&LokiConfiguration{
+			URL:         server.URL,
+			TenantID:    "team-a",
+			Labels:      map[string]string{"env": "prod"},
+			BearerToken: "token",
+		}, source)
+		Expect(err).ToNot(HaveOccurred())
+
+		now := time.Unix(0, 1000)
+		Expect(sender.send(ctx, []LogEntry{
+			{Time: now, Logger: "postgres", Line: []byte(`{"a":1}`)},
+			{Time: now, Logger: "pgaudit", Line: []byte(`{"a":2}`)},
+			{Time: now, Logger: "postgres", Line: []byte(`{"a":3}`)},
+		})).To(Succeed())
+
+		Expect(header.Get("X-Scope-OrgID")).To(Equal("team-a"))
+		Expect(header.Get("Authorization")).To(Equal("Bearer token"))
+		Expect(request.Streams).To(HaveLen(2))
+		Expect(request.Streams[0].Stream).To(Equal(map[string]string{
+			"namespace": "default",
+			"cluster":   "cluster-example",
+			"pod":       "cluster-example-1",
+			"logger":    "postgres",
+			"env":       "prod",
+		}))
+		Expect(request.Streams[0].Values).To(Equal([][2]string{
+			{"1000", `{"a":1}`},
+			{"1000", `{"a":3}`},
+		}))
+		Expect(request.Streams[1].Stream["logger"]).To(Equal("pgaudit"))
+	})
+
+	It("fails when Loki rejects the log records", func(ctx context.Context) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "entry too far behind", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		sender, err := newLokiSender(&LokiConfiguration{URL: server.URL}, source)
+		Expect(err).ToNot(HaveOccurred())
+		err = sender.send(ctx, []LogEntry{{Time: time.Now(), Logger: "postgres", Line: []byte(`{}`)}})
+		Expect(err).To(MatchError(ContainSubstring("entry too far behind")))
+	})
+
+	It("rejects an invalid CA certificate", func() {
+		_, err := newLokiSender(&LokiConfiguration{URL: "https://loki", CA: []byte("invalid")}, source)
+		Expect(err).To(HaveOccurred())
+	})
+})
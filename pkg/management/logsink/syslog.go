@@ -0,0 +1,87 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logsink
+
+import (
+	"context"
+	"log/syslog"
+)
+
+// defaultSyslogTag is the tag of the syslog messages when
+// not specified
+const defaultSyslogTag = "postgres"
+
+// SyslogConfiguration contains the parameters needed to send the log
+// records to a syslog endpoint
+type SyslogConfiguration struct {
+	// Network is the transport protocol, `udp` or `tcp`
+	Network string
+
+	// Address is the address of the endpoint, in the `host:port` format
+	Address string
+
+	// Tag is the tag of the syslog messages
+	Tag string
+}
+
+// syslogSender sends the log records to a syslog endpoint, one
+// message per record, connecting to it when needed
+type syslogSender struct {
+	configuration *SyslogConfiguration
+	writer        *syslog.Writer
+}
+
+func newSyslogSender(configuration *SyslogConfiguration) *syslogSender {
+	return &syslogSender{configuration: configuration}
+}
+
+// send implements the sender interface. The records already sent are
+// sent again when the batch is retried, as syslog has no way to
+// acknowledge them
+func (s *syslogSender) send(_ context.Context, entries []LogEntry) error {
+	if s.writer == nil {
+		tag := s.configuration.Tag
+		if tag == "" {
+			tag = defaultSyslogTag
+		}
+		writer, err := syslog.Dial(s.configuration.Network, s.configuration.Address,
+			syslog.LOG_INFO|syslog.LOG_LOCAL0, tag)
+		if err != nil {
+			return err
+		}
+		s.writer = writer
+	}
+
+	for _, entry := range entries {
+		if _, err := s.writer.Write(entry.Line); err != nil {
+			_ = s.close()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// close implements the sender interface
+func (s *syslogSender) close() error {
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	s.writer = nil
+	return err
+}
@@ -0,0 +1,183 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// Catalog is the list of backups stored in a pgBackRest stanza,
+// ordered from the oldest to the newest one
+type Catalog struct {
+	Backups []BackupInfo
+}
+
+// BackupInfo is the information pgBackRest stores about a backup
+type BackupInfo struct {
+	// The backup label, which is used as backup ID
+	Label string `json:"label"`
+
+	// The backup type (full, diff, incr)
+	Type string `json:"type"`
+
+	// The label of the backup this one has been taken against. This
+	// is empty for full backups
+	Prior string `json:"prior"`
+
+	// The labels of every backup containing files this one depends on
+	Reference []string `json:"reference"`
+
+	// The first and the last WAL file needed by the backup
+	Archive struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	} `json:"archive"`
+
+	// The LSNs at the start and at the end of the backup
+	LSN struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	} `json:"lsn"`
+
+	// The Unix timestamps at the start and at the end of the backup
+	Timestamp struct {
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	} `json:"timestamp"`
+}
+
+// StartTime returns the time when the backup was started
+func (backup *BackupInfo) StartTime() time.Time {
+	return time.Unix(backup.Timestamp.Start, 0).UTC()
+}
+
+// StopTime returns the time when the backup was completed
+func (backup *BackupInfo) StopTime() time.Time {
+	return time.Unix(backup.Timestamp.Stop, 0).UTC()
+}
+
+// Level returns the level of the backup
+func (backup *BackupInfo) Level() apiv1.BackupLevel {
+	switch apiv1.PgBackRestBackupType(backup.Type) {
+	case apiv1.PgBackRestBackupTypeIncr:
+		return apiv1.BackupLevelIncremental
+	case apiv1.PgBackRestBackupTypeDiff:
+		return apiv1.BackupLevelDifferential
+	default:
+		return apiv1.BackupLevelFull
+	}
+}
+
+// stanzaInfo is the content of `pgbackrest info --output=json` for a stanza
+type stanzaInfo struct {
+	Name   string       `json:"name"`
+	Backup []BackupInfo `json:"backup"`
+}
+
+// ParseCatalog parses the output of `pgbackrest info --output=json`
+// for a single stanza
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var stanzas []stanzaInfo
+	if err := json.Unmarshal(data, &stanzas); err != nil {
+		return nil, fmt.Errorf("while parsing pgBackRest info output: %w", err)
+	}
+
+	catalog := &Catalog{}
+	for _, stanza := range stanzas {
+		catalog.Backups = append(catalog.Backups, stanza.Backup...)
+	}
+
+	return catalog, nil
+}
+
+// Latest returns the latest backup, or nil if the catalog is empty
+func (catalog *Catalog) Latest() *BackupInfo {
+	if len(catalog.Backups) == 0 {
+		return nil
+	}
+
+	return &catalog.Backups[len(catalog.Backups)-1]
+}
+
+// First returns the oldest backup, or nil if the catalog is empty
+func (catalog *Catalog) First() *BackupInfo {
+	if len(catalog.Backups) == 0 {
+		return nil
+	}
+
+	return &catalog.Backups[0]
+}
+
+// GetBackupIDs returns the labels of the backups in the catalog
+func (catalog *Catalog) GetBackupIDs() []string {
+	result := make([]string, len(catalog.Backups))
+	for idx := range catalog.Backups {
+		result[idx] = catalog.Backups[idx].Label
+	}
+
+	return result
+}
+
+// FindBackupInfo finds the backup to be restored to reach the passed
+// recovery target. The latest backup is selected when no target is passed
+func (catalog *Catalog) FindBackupInfo(recoveryTarget *apiv1.RecoveryTarget) (*BackupInfo, error) {
+	if recoveryTarget == nil {
+		return catalog.Latest(), nil
+	}
+
+	switch {
+	case recoveryTarget.BackupID != "":
+		for idx := range catalog.Backups {
+			if catalog.Backups[idx].Label == recoveryTarget.BackupID {
+				return &catalog.Backups[idx], nil
+			}
+		}
+		return nil, fmt.Errorf("no backup found with label %s", recoveryTarget.BackupID)
+
+	case recoveryTarget.TargetTime != "":
+		targetTime, err := types.ParseTargetTime(nil, recoveryTarget.TargetTime)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing recovery target targetTime: %w", err)
+		}
+		for idx := len(catalog.Backups) - 1; idx >= 0; idx-- {
+			if !catalog.Backups[idx].StopTime().After(targetTime) {
+				return &catalog.Backups[idx], nil
+			}
+		}
+		return nil, nil
+
+	case recoveryTarget.TargetLSN != "":
+		targetLSN := types.LSN(recoveryTarget.TargetLSN)
+		if _, err := targetLSN.Parse(); err != nil {
+			return nil, fmt.Errorf("while parsing recovery target targetLSN: %w", err)
+		}
+		for idx := len(catalog.Backups) - 1; idx >= 0; idx-- {
+			if types.LSN(catalog.Backups[idx].LSN.Stop).Less(targetLSN) {
+				return &catalog.Backups[idx], nil
+			}
+		}
+		return nil, nil
+	}
+
+	return catalog.Latest(), nil
+}
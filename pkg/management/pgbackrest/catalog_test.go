@@ -0,0 +1,107 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const infoOutput = `[
+  {
+    "name": "main",
+    "backup": [
+      {
+        "label": "20240101-120000F",
+        "type": "full",
+        "archive": {"start": "000000010000000000000003", "stop": "000000010000000000000003"},
+        "lsn": {"start": "0/3000028", "stop": "0/3000100"},
+        "timestamp": {"start": 1704110400, "stop": 1704110410}
+      },
+      {
+        "label": "20240102-120000F_20240102-180000D",
+        "type": "diff",
+        "prior": "20240101-120000F",
+        "reference": ["20240101-120000F"],
+        "archive": {"start": "000000010000000000000007", "stop": "000000010000000000000007"},
+        "lsn": {"start": "0/7000028", "stop": "0/7000100"},
+        "timestamp": {"start": 1704218400, "stop": 1704218410}
+      }
+    ]
+  }
+]`
+
+var _ = Describe("pgBackRest catalog", func() {
+	var catalog *Catalog
+
+	BeforeEach(func() {
+		var err error
+		catalog, err = ParseCatalog([]byte(infoOutput))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("parses the output of pgbackrest info", func() {
+		Expect(catalog.GetBackupIDs()).To(Equal([]string{
+			"20240101-120000F",
+			"20240102-120000F_20240102-180000D",
+		}))
+		Expect(catalog.First().Archive.Start).To(Equal("000000010000000000000003"))
+		Expect(catalog.Latest().LSN.Stop).To(Equal("0/7000100"))
+		Expect(catalog.Latest().StopTime().Unix()).To(BeEquivalentTo(1704218410))
+		Expect(catalog.First().Level()).To(Equal(apiv1.BackupLevelFull))
+		Expect(catalog.Latest().Level()).To(Equal(apiv1.BackupLevelDifferential))
+		Expect(catalog.Latest().Prior).To(Equal("20240101-120000F"))
+	})
+
+	It("selects the latest backup without a recovery target", func() {
+		backup, err := catalog.FindBackupInfo(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backup.Label).To(Equal("20240102-120000F_20240102-180000D"))
+	})
+
+	It("selects the backup by label", func() {
+		backup, err := catalog.FindBackupInfo(&apiv1.RecoveryTarget{BackupID: "20240101-120000F"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backup.Label).To(Equal("20240101-120000F"))
+
+		_, err = catalog.FindBackupInfo(&apiv1.RecoveryTarget{BackupID: "missing"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("selects the latest backup completed before the target time", func() {
+		backup, err := catalog.FindBackupInfo(&apiv1.RecoveryTarget{TargetTime: "2024-01-02 00:00:00+00"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backup.Label).To(Equal("20240101-120000F"))
+
+		backup, err = catalog.FindBackupInfo(&apiv1.RecoveryTarget{TargetTime: "2023-12-31 00:00:00+00"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backup).To(BeNil())
+	})
+
+	It("selects the latest backup completed before the target LSN", func() {
+		backup, err := catalog.FindBackupInfo(&apiv1.RecoveryTarget{TargetLSN: "0/5000000"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backup.Label).To(Equal("20240101-120000F"))
+	})
+
+	It("complains about malformed output", func() {
+		_, err := ParseCatalog([]byte("not json"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,253 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"path"
+	"slices"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"github.com/kballard/go-shellquote"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// Command is the name of the pgBackRest executable
+	Command = "pgbackrest"
+
+	// workingDirectory is the directory where pgBackRest stores its
+	// lock files and where we keep track of the created stanzas
+	workingDirectory = postgres.ScratchDataDirectory + "/pgbackrest"
+
+	// archiveGetNotFoundExitCode is the exit code of archive-get when
+	// the requested WAL file is not in the repository
+	archiveGetNotFoundExitCode = 1
+)
+
+// ErrWALNotFound is returned when the requested WAL file is not in the repository
+var ErrWALNotFound = errors.New("WAL not found")
+
+// GetStanza returns the name of the stanza to be used with the passed
+// configuration, defaulting to the passed name
+func GetStanza(config *apiv1.PgBackRestConfiguration, defaultName string) string {
+	if config.Stanza != "" {
+		return config.Stanza
+	}
+
+	return defaultName
+}
+
+// Options returns the pgBackRest options needed to reach the repository
+// of the passed stanza, backing up the passed data directory
+func Options(config *apiv1.PgBackRestConfiguration, stanza, pgData string) []string {
+	repositoryType := config.Repository.Type
+	if repositoryType == "" {
+		repositoryType = apiv1.PgBackRestRepositoryTypeS3
+	}
+
+	options := []string{
+		"--stanza=" + stanza,
+		"--pg1-path=" + pgData,
+		"--pg1-socket-path=" + postgres.SocketDirectory,
+		"--lock-path=" + workingDirectory,
+		"--log-level-console=info",
+		"--log-level-file=off",
+		"--repo1-type=" + string(repositoryType),
+		"--repo1-path=" + config.Repository.Path,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(config.Repository.Options)) {
+		options = append(options, fmt.Sprintf("--repo1-%s=%s", key, config.Repository.Options[key]))
+	}
+
+	return options
+}
+
+// RestoreCommand returns the `restore_command` PostgreSQL will use
+// to fetch WAL files from the repository. Every option is quoted, as
+// the command is run by the shell
+func RestoreCommand(options []string) string {
+	cmd := append([]string{Command}, options...)
+	cmd = append(cmd, "archive-get")
+	return shellquote.Join(cmd...) + " %f \"%p\""
+}
+
+// EnsureStanza creates the stanza in the repository, unless this has
+// already been done since this Pod has been started
+func EnsureStanza(ctx context.Context, env []string, options []string, stanza string) error {
+	markerFile := path.Join(workingDirectory, "stanza-"+stanza)
+	if exists, err := fileutils.FileExists(markerFile); err != nil || exists {
+		return err
+	}
+
+	if err := run(ctx, env, options, "stanza-create"); err != nil {
+		return fmt.Errorf("while creating the pgBackRest stanza: %w", err)
+	}
+
+	_, err := fileutils.WriteStringToFile(markerFile, stanza)
+	return err
+}
+
+// ArchivePush archives the passed WAL file in the repository
+func ArchivePush(ctx context.Context, env []string, options []string, walPath string) error {
+	return run(ctx, env, options, "archive-push", walPath)
+}
+
+// ArchiveGet restores the passed WAL file from the repository into
+// the destination path
+func ArchiveGet(ctx context.Context, env []string, options []string, walName, destinationPath string) error {
+	err := run(ctx, env, options, "archive-get", walName, destinationPath)
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) && exitError.ExitCode() == archiveGetNotFoundExitCode {
+		return ErrWALNotFound
+	}
+
+	return err
+}
+
+// Backup takes a new backup of the given type
+func Backup(ctx context.Context, env []string, options []string, backupType apiv1.PgBackRestBackupType) error {
+	if backupType == "" {
+		backupType = apiv1.PgBackRestBackupTypeFull
+	}
+
+	return run(ctx, env, options, "backup", "--type="+string(backupType))
+}
+
+// Expire removes the passed backup set, and every backup depending
+// on it, from the repository
+func Expire(ctx context.Context, env []string, options []string, backupSet string) error {
+	return run(ctx, env, options, "expire", "--set="+backupSet)
+}
+
+// BackupTypeForLevel returns the pgBackRest backup type to be used to take
+// a backup of the passed level, falling back to the passed default type
+// when the level is not specified
+func BackupTypeForLevel(
+	level apiv1.BackupLevel,
+	defaultType apiv1.PgBackRestBackupType,
+) apiv1.PgBackRestBackupType {
+	switch level {
+	case apiv1.BackupLevelFull:
+		return apiv1.PgBackRestBackupTypeFull
+	case apiv1.BackupLevelDifferential:
+		return apiv1.PgBackRestBackupTypeDiff
+	case apiv1.BackupLevelIncremental:
+		return apiv1.PgBackRestBackupTypeIncr
+	default:
+		return defaultType
+	}
+}
+
+// Restore restores the passed backup set, or the latest one when empty, in
+// the data directory, checking it can reach the passed recovery target.
+// The recovery settings written by pgBackRest are replaced by the ones
+// of the instance manager, which manages the recovery configuration
+func Restore(
+	ctx context.Context,
+	env []string,
+	options []string,
+	backupSet string,
+	recoveryTarget *apiv1.RecoveryTarget,
+) error {
+	restoreOptions := append([]string{"restore"}, RecoveryTargetOptions(recoveryTarget)...)
+	if backupSet != "" {
+		restoreOptions = append(restoreOptions, "--set="+backupSet)
+	}
+
+	return run(ctx, env, options, restoreOptions...)
+}
+
+// RecoveryTargetOptions returns the `--type` and `--target` options
+// of the pgBackRest restore command matching the passed recovery target
+func RecoveryTargetOptions(recoveryTarget *apiv1.RecoveryTarget) []string {
+	if recoveryTarget == nil {
+		return []string{"--type=none"}
+	}
+
+	targetType, target := "none", ""
+	switch {
+	case recoveryTarget.TargetImmediate != nil && *recoveryTarget.TargetImmediate:
+		targetType = "immediate"
+	case recoveryTarget.TargetTime != "":
+		targetType, target = "time", pgTime.ConvertToPostgresFormat(recoveryTarget.TargetTime)
+	case recoveryTarget.TargetLSN != "":
+		targetType, target = "lsn", recoveryTarget.TargetLSN
+	case recoveryTarget.TargetXID != "":
+		targetType, target = "xid", recoveryTarget.TargetXID
+	case recoveryTarget.TargetName != "":
+		targetType, target = "name", recoveryTarget.TargetName
+	case recoveryTarget.TargetTLI != "":
+		targetType = "default"
+	}
+
+	options := []string{"--type=" + targetType}
+	if target != "" {
+		options = append(options, "--target="+target)
+		if recoveryTarget.Exclusive != nil && *recoveryTarget.Exclusive {
+			options = append(options, "--target-exclusive")
+		}
+	}
+	if targetType != "none" && recoveryTarget.TargetTLI != "" {
+		options = append(options, "--target-timeline="+recoveryTarget.TargetTLI)
+	}
+	if targetType != "none" && targetType != "default" {
+		options = append(options, "--target-action=promote")
+	}
+
+	return options
+}
+
+// Info returns the list of backups available in the repository
+func Info(ctx context.Context, env []string, options []string) (*Catalog, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, Command, append(slices.Clone(options), "info", "--output=json")...) // #nosec G204
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		contextLogger.Error(err, "Can't get the pgBackRest repository information",
+			"stdout", stdout.String(),
+			"stderr", stderr.String())
+		return nil, err
+	}
+
+	return ParseCatalog(stdout.Bytes())
+}
+
+func run(ctx context.Context, env []string, options []string, args ...string) error {
+	if err := fileutils.EnsureDirectoryExists(workingDirectory); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, Command, append(slices.Clone(options), args...)...) // #nosec G204
+	cmd.Env = env
+	return execlog.RunStreaming(cmd, Command)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pgBackRest command options", func() {
+	config := &apiv1.PgBackRestConfiguration{
+		Repository: apiv1.PgBackRestRepository{
+			Path: "/cluster-example",
+			Options: map[string]string{
+				"s3-region": "eu-west-1",
+				"s3-bucket": "backups",
+			},
+		},
+	}
+
+	It("defaults the stanza to the passed name", func() {
+		Expect(GetStanza(config, "cluster-example")).To(Equal("cluster-example"))
+		Expect(GetStanza(&apiv1.PgBackRestConfiguration{Stanza: "main"}, "cluster-example")).To(Equal("main"))
+	})
+
+	It("generates the repository options in a stable order", func() {
+		options := Options(config, "main", "/var/lib/postgresql/data/pgdata")
+		Expect(options).To(ContainElements(
+			"--stanza=main",
+			"--pg1-path=/var/lib/postgresql/data/pgdata",
+			"--repo1-type=s3",
+			"--repo1-path=/cluster-example",
+		))
+		Expect(options[len(options)-2:]).To(Equal([]string{
+			"--repo1-s3-bucket=backups",
+			"--repo1-s3-region=eu-west-1",
+		}))
+	})
+
+	It("uses the configured repository type", func() {
+		posixConfig := config.DeepCopy()
+		posixConfig.Repository.Type = apiv1.PgBackRestRepositoryTypePosix
+		Expect(Options(posixConfig, "main", "/pgdata")).To(ContainElement("--repo1-type=posix"))
+	})
+
+	It("generates the restore command", func() {
+		Expect(RestoreCommand([]string{"--stanza=main"})).
+			To(Equal(`pgbackrest --stanza=main archive-get %f "%p"`))
+	})
+
+	It("quotes the options of the restore command", func() {
+		Expect(RestoreCommand([]string{"--stanza=main", "--repo1-path=/my backups/it's here"})).
+			To(Equal(`pgbackrest --stanza=main '--repo1-path=/my backups/it'\''s here' archive-get %f "%p"`))
+	})
+
+	It("doesn't write any recovery setting without a recovery target", func() {
+		Expect(RecoveryTargetOptions(nil)).To(Equal([]string{"--type=none"}))
+		Expect(RecoveryTargetOptions(&apiv1.RecoveryTarget{BackupID: "20240101-120000F"})).
+			To(Equal([]string{"--type=none"}))
+	})
+
+	It("passes the recovery target to pgBackRest", func() {
+		Expect(RecoveryTargetOptions(&apiv1.RecoveryTarget{
+			TargetLSN: "0/5000000",
+			Exclusive: ptr.To(true),
+			TargetTLI: "latest",
+		})).To(Equal([]string{
+			"--type=lsn",
+			"--target=0/5000000",
+			"--target-exclusive",
+			"--target-timeline=latest",
+			"--target-action=promote",
+		}))
+		Expect(RecoveryTargetOptions(&apiv1.RecoveryTarget{TargetName: "before-upgrade"})).
+			To(Equal([]string{"--type=name", "--target=before-upgrade", "--target-action=promote"}))
+		Expect(RecoveryTargetOptions(&apiv1.RecoveryTarget{TargetImmediate: ptr.To(true)})).
+			To(Equal([]string{"--type=immediate", "--target-action=promote"}))
+		Expect(RecoveryTargetOptions(&apiv1.RecoveryTarget{TargetTLI: "2"})).
+			To(Equal([]string{"--type=default", "--target-timeline=2"}))
+	})
+	It("maps the backup level to the pgBackRest backup type", func() {
+		Expect(BackupTypeForLevel(apiv1.BackupLevelIncremental, apiv1.PgBackRestBackupTypeFull)).
+			To(Equal(apiv1.PgBackRestBackupTypeIncr))
+		Expect(BackupTypeForLevel(apiv1.BackupLevelDifferential, apiv1.PgBackRestBackupTypeFull)).
+			To(Equal(apiv1.PgBackRestBackupTypeDiff))
+		Expect(BackupTypeForLevel(apiv1.BackupLevelFull, apiv1.PgBackRestBackupTypeIncr)).
+			To(Equal(apiv1.PgBackRestBackupTypeFull))
+		Expect(BackupTypeForLevel("", apiv1.PgBackRestBackupTypeIncr)).
+			To(Equal(apiv1.PgBackRestBackupTypeIncr))
+	})
+})
@@ -0,0 +1,58 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// EnvSetCredentials appends to the passed environment the content of the
+// credentials secret of the repository, one variable for every key
+func EnvSetCredentials(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	config *apiv1.PgBackRestConfiguration,
+	env []string,
+) ([]string, error) {
+	if config.Repository.CredentialsSecret == nil {
+		return env, nil
+	}
+
+	var secret corev1.Secret
+	if err := cli.Get(
+		ctx,
+		client.ObjectKey{Namespace: namespace, Name: config.Repository.CredentialsSecret.Name},
+		&secret,
+	); err != nil {
+		return nil, fmt.Errorf("while getting the pgBackRest credentials secret: %w", err)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+		env = append(env, fmt.Sprintf("%s=%s", key, secret.Data[key]))
+	}
+
+	return env, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/backup/retention"
+)
+
+// ExpiredBackups returns the labels of the backups that are not needed
+// anymore to recover to any point in the recovery window expressed by the
+// passed retention policy.
+// A backup is retained when it has been completed inside the recovery
+// window, when it is the most recent backup completed before the window
+// started, or when it is referenced by another retained backup. This
+// ensures that a full backup is never expired while incremental or
+// differential backups depending on it are still retained.
+// As expiring a backup also expires every backup depending on it, only
+// the first backup of each expired chain is returned.
+func (catalog *Catalog) ExpiredBackups(retentionPolicy string, now time.Time) ([]string, error) {
+	window, err := retention.ParseMaxAge(retentionPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing the retention policy: %w", err)
+	}
+	windowStart := now.Add(-window)
+
+	retained := stringset.New()
+	foundWindowStartBackup := false
+	for idx := len(catalog.Backups) - 1; idx >= 0; idx-- {
+		backup := &catalog.Backups[idx]
+		switch {
+		case backup.StopTime().After(windowStart):
+			// This backup has been completed inside the recovery window
+
+		case !foundWindowStartBackup:
+			// This backup is needed to recover to the start of the
+			// recovery window
+			foundWindowStartBackup = true
+
+		case retained.Has(backup.Label):
+			// This backup is referenced by a retained one
+
+		default:
+			continue
+		}
+
+		retained.Put(backup.Label)
+		if backup.Prior != "" {
+			retained.Put(backup.Prior)
+		}
+		for _, reference := range backup.Reference {
+			retained.Put(reference)
+		}
+	}
+
+	var result []string
+	expired := stringset.New()
+	for idx := range catalog.Backups {
+		backup := &catalog.Backups[idx]
+		if retained.Has(backup.Label) {
+			continue
+		}
+
+		expired.Put(backup.Label)
+		if backup.Prior == "" || !expired.Has(backup.Prior) {
+			result = append(result, backup.Label)
+		}
+	}
+
+	return result, nil
+}
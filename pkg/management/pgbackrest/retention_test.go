@@ -0,0 +1,80 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackrest
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pgBackRest retention policy", func() {
+	day := 24 * time.Hour
+	now := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	newBackup := func(label, prior string, age time.Duration, reference ...string) BackupInfo {
+		backup := BackupInfo{Label: label, Prior: prior, Reference: reference}
+		backup.Timestamp.Stop = now.Add(-age).Unix()
+		return backup
+	}
+
+	It("rejects an invalid retention policy", func() {
+		_, err := (&Catalog{}).ExpiredBackups("7y", now)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("retains the backup needed to recover to the start of the window", func() {
+		catalog := &Catalog{Backups: []BackupInfo{
+			newBackup("F1", "", 20*day),
+			newBackup("F2", "", 10*day),
+			newBackup("F3", "", 2*day),
+		}}
+		Expect(catalog.ExpiredBackups("7d", now)).To(Equal([]string{"F1"}))
+	})
+
+	It("never expires a full backup referenced by a retained incremental one", func() {
+		catalog := &Catalog{Backups: []BackupInfo{
+			newBackup("F1", "", 30*day),
+			newBackup("F2", "", 20*day),
+			newBackup("F2_I1", "F2", 15*day, "F2"),
+			newBackup("F2_I2", "F2_I1", 10*day, "F2", "F2_I1"),
+			newBackup("F2_I3", "F2_I2", 2*day, "F2", "F2_I1", "F2_I2"),
+		}}
+		Expect(catalog.ExpiredBackups("7d", now)).To(Equal([]string{"F1"}))
+	})
+
+	It("expires differential backups not referenced by retained ones", func() {
+		catalog := &Catalog{Backups: []BackupInfo{
+			newBackup("F1", "", 30*day),
+			newBackup("F1_D1", "F1", 20*day, "F1"),
+			newBackup("F1_D2", "F1", 10*day, "F1"),
+			newBackup("F1_D3", "F1", 2*day, "F1"),
+		}}
+		Expect(catalog.ExpiredBackups("7d", now)).To(Equal([]string{"F1_D1"}))
+	})
+
+	It("returns only the first backup of an expired chain", func() {
+		catalog := &Catalog{Backups: []BackupInfo{
+			newBackup("F1", "", 30*day),
+			newBackup("F1_I1", "F1", 25*day, "F1"),
+			newBackup("F2", "", 10*day),
+			newBackup("F2_I1", "F2", 2*day, "F2"),
+		}}
+		Expect(catalog.ExpiredBackups("7d", now)).To(Equal([]string{"F1"}))
+	})
+})
@@ -71,8 +71,16 @@ const (
 
 	pgBouncerIniTemplateString = `
 [databases]
-* = host={{.Pooler.Spec.Cluster.Name}}-{{.Pooler.Spec.Type}}
-
+{{ range $database := .Databases -}}
+{{ $database }}
+{{ end -}}
+* = host={{.Pooler.GetClusterServiceHost}}
+{{ if .Users }}
+[users]
+{{ range $user := .Users -}}
+{{ $user }}
+{{ end -}}
+{{ end }}
 [pgbouncer]
 pool_mode = {{ .Pooler.Spec.PgBouncer.PoolMode }}
 auth_user = {{ .AuthQueryUser }}
@@ -189,6 +197,8 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		AuthQueryPassword string
 		Parameters        string
 		PgHba             []string
+		Databases         []string
+		Users             []string
 	}{
 		Pooler:            pooler,
 		AuthQuery:         pooler.GetAuthQuery(),
@@ -203,6 +213,8 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		// to be stable.
 		Parameters: stringifyPgBouncerParameters(parameters),
 		PgHba:      pooler.Spec.PgBouncer.PgHBA,
+		Databases:  buildPgBouncerDatabases(pooler),
+		Users:      buildPgBouncerUsers(pooler.Spec.PgBouncer.Users),
 	}
 
 	err = pgBouncerIniTemplate.Execute(&pgbouncerIni, templateData)
@@ -226,8 +238,8 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 	files[filepath.Join(ConfigsDir, PgBouncerHBAConfFileName)] = pgbouncerHBA.Bytes()
 
 	// The required crypto-material
-	files[serverTLSCAPath] = secrets.ServerCA.Data[certs.CACertKey]
-	files[clientTLSCAPath] = secrets.ClientCA.Data[certs.CACertKey]
+	files[serverTLSCAPath] = certs.GetCABundle(secrets.ServerCA)
+	files[clientTLSCAPath] = certs.GetCABundle(secrets.ClientCA)
 	files[clientTLSCertPath] = secrets.Client.Data[certs.TLSCertKey]
 	files[clientTLSKeyPath] = secrets.Client.Data[certs.TLSPrivateKeyKey]
 
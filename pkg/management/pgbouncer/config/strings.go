@@ -21,6 +21,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
 
 // stringifyPgBouncerParameters will take map of PgBouncer parameters and emit
@@ -80,3 +82,54 @@ func cleanupPgBouncerValue(parameter string) (escaped string) {
 	// so we are just removing from the value
 	return newlineRegexp.ReplaceAllString(parameter, "")
 }
+
+// buildPgBouncerDatabases emits the entries of the `databases` section
+// for the pools declared in the Pooler, in the declaration order
+func buildPgBouncerDatabases(pooler *apiv1.Pooler) []string {
+	databases := make([]string, 0, len(pooler.Spec.PgBouncer.Databases))
+	for _, database := range pooler.Spec.PgBouncer.Databases {
+		options := []string{
+			"host=" + pooler.GetClusterServiceHost(),
+		}
+		if database.DBName != "" {
+			options = append(options, "dbname="+database.DBName)
+		}
+		if database.PoolMode != "" {
+			options = append(options, "pool_mode="+string(database.PoolMode))
+		}
+		if database.PoolSize != nil {
+			options = append(options, fmt.Sprintf("pool_size=%d", *database.PoolSize))
+		}
+		if database.MinPoolSize != nil {
+			options = append(options, fmt.Sprintf("min_pool_size=%d", *database.MinPoolSize))
+		}
+		if database.ReservePoolSize != nil {
+			options = append(options, fmt.Sprintf("reserve_pool=%d", *database.ReservePoolSize))
+		}
+		if database.MaxDBConnections != nil {
+			options = append(options, fmt.Sprintf("max_db_connections=%d", *database.MaxDBConnections))
+		}
+		databases = append(databases, fmt.Sprintf("%s = %s", database.Name, strings.Join(options, " ")))
+	}
+	return databases
+}
+
+// buildPgBouncerUsers emits the entries of the `users` section for the
+// user settings declared in the Pooler, in the declaration order
+func buildPgBouncerUsers(users []apiv1.PgBouncerUser) []string {
+	result := make([]string, 0, len(users))
+	for _, user := range users {
+		var options []string
+		if user.PoolMode != "" {
+			options = append(options, "pool_mode="+string(user.PoolMode))
+		}
+		if user.MaxUserConnections != nil {
+			options = append(options, fmt.Sprintf("max_user_connections=%d", *user.MaxUserConnections))
+		}
+		if len(options) == 0 {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s = %s", user.Name, strings.Join(options, " ")))
+	}
+	return result
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -44,4 +48,57 @@ var _ = Describe("PgBouncer configuration", func() {
 		Expect(params).NotTo(MatchRegexp("^pool_mode.*"))
 		Expect(params).NotTo(MatchRegexp("^pid_file.*"))
 	})
+
+	It("can build the entries of the declared databases and users", func() {
+		poolSize := int32(10)
+		maxConnections := int32(50)
+		pooler := &apiv1.Pooler{
+			Spec: apiv1.PoolerSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+				Type:    apiv1.PoolerTypeRW,
+				PgBouncer: &apiv1.PgBouncerSpec{
+					Databases: []apiv1.PgBouncerDatabase{
+						{Name: "app"},
+						{
+							Name:             "reports",
+							DBName:           "analytics",
+							PoolMode:         apiv1.PgBouncerPoolModeSession,
+							PoolSize:         &poolSize,
+							MaxDBConnections: &maxConnections,
+						},
+					},
+					Users: []apiv1.PgBouncerUser{
+						{Name: "app", PoolMode: apiv1.PgBouncerPoolModeTransaction},
+						{Name: "noop"},
+					},
+				},
+			},
+		}
+
+		Expect(buildPgBouncerDatabases(pooler)).To(Equal([]string{
+			"app = host=cluster-example-rw",
+			"reports = host=cluster-example-rw dbname=analytics pool_mode=session pool_size=10 max_db_connections=50",
+		}))
+		Expect(buildPgBouncerUsers(pooler.Spec.PgBouncer.Users)).To(Equal([]string{
+			"app = pool_mode=transaction",
+		}))
+	})
+
+	It("qualifies the host of a cluster living in another namespace", func() {
+		pooler := &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler-ro", Namespace: "apps"},
+			Spec: apiv1.PoolerSpec{
+				Cluster:          apiv1.LocalObjectReference{Name: "cluster-example"},
+				ClusterNamespace: "databases",
+				Type:             apiv1.PoolerTypeRO,
+				PgBouncer: &apiv1.PgBouncerSpec{
+					Databases: []apiv1.PgBouncerDatabase{{Name: "app"}},
+				},
+			},
+		}
+
+		Expect(buildPgBouncerDatabases(pooler)).To(Equal([]string{
+			"app = host=cluster-example-ro.databases",
+		}))
+	})
 })
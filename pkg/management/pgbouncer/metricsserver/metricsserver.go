@@ -62,6 +62,7 @@ func Setup(ctx context.Context) error {
 func ListenAndServe() error {
 	serveMux := http.NewServeMux()
 	serveMux.Handle(url.PathMetrics, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	serveMux.HandleFunc(url.PathPgBouncerStats, exporter.serveStats)
 
 	server = &http.Server{
 		Addr:              fmt.Sprintf(":%d", url.PgBouncerMetricsPort),
@@ -52,6 +52,7 @@ type metrics struct {
 	ShowLists          ShowListsMetrics
 	ShowPools          *ShowPoolsMetrics
 	ShowStats          *ShowStatsMetrics
+	ShowServers        *ShowServersMetrics
 }
 
 // NewExporter creates an exporter
@@ -96,9 +97,10 @@ func newMetrics() *metrics {
 			Name:      "collection_duration_seconds",
 			Help:      "Collection time duration in seconds",
 		}, []string{"collector"}),
-		ShowLists: NewShowListsMetrics(subsystem),
-		ShowPools: NewShowPoolsMetrics(subsystem),
-		ShowStats: NewShowStatsMetrics(subsystem),
+		ShowLists:   NewShowListsMetrics(subsystem),
+		ShowPools:   NewShowPoolsMetrics(subsystem),
+		ShowStats:   NewShowStatsMetrics(subsystem),
+		ShowServers: NewShowServersMetrics(subsystem),
 	}
 }
 
@@ -111,6 +113,7 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.ShowLists.Describe(ch)
 	e.Metrics.ShowPools.Describe(ch)
 	e.Metrics.ShowStats.Describe(ch)
+	e.Metrics.ShowServers.Describe(ch)
 }
 
 // Collect implements prometheus.Collector, collecting the Metrics values to
@@ -142,6 +145,7 @@ func (e *Exporter) collectPgBouncerMetrics(ch chan<- prometheus.Metric) {
 	e.collectShowLists(ch, db)
 	e.collectShowPools(ch, db)
 	e.collectShowStats(ch, db)
+	e.collectShowServers(ch, db)
 }
 
 // GetPgBouncerDB gets a connection to the admin user db "pgbouncer" on this instance
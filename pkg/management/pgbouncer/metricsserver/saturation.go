@@ -0,0 +1,93 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// serveStats serves the saturation indicators of this PgBouncer
+// instance, which the operator aggregates in the status of the Pooler
+func (e *Exporter) serveStats(w http.ResponseWriter, _ *http.Request) {
+	stats, err := e.getStats()
+	if err != nil {
+		log.FromContext(e.ctx).Error(err, "while collecting the PgBouncer stats")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		log.FromContext(e.ctx).Error(err, "while writing the PgBouncer stats")
+	}
+}
+
+// getStats sums the SHOW POOLS counters of this PgBouncer instance
+// across its pools, excluding the admin console
+func (e *Exporter) getStats() (*apiv1.PoolerStats, error) {
+	db, err := e.GetPgBouncerDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(e.ctx, "SHOW POOLS;")
+	if err != nil {
+		return nil, fmt.Errorf("while executing SHOW POOLS: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.FromContext(e.ctx).Error(err, "while closing rows for SHOW POOLS")
+		}
+	}()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &apiv1.PoolerStats{Instances: 1}
+	for rows.Next() {
+		row, err := scanRow(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("while reading SHOW POOLS: %w", err)
+		}
+
+		// The admin console, used by the exporter itself, is not a pool
+		if row["database"].String == "pgbouncer" {
+			continue
+		}
+
+		counter := func(name string) int32 {
+			value, _ := strconv.ParseInt(row[name].String, 10, 32)
+			return int32(value)
+		}
+		stats.ClientsActive += counter("cl_active")
+		stats.ClientsWaiting += counter("cl_waiting")
+		stats.ServersActive += counter("sv_active")
+		stats.ServersIdle += counter("sv_idle")
+		stats.MaxWaitSeconds = max(stats.MaxWaitSeconds, counter("maxwait"))
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PgBouncer stats", func() {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+		exp  *Exporter
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		exp = &Exporter{
+			Metrics: newMetrics(),
+			pool:    fakePooler{db: db},
+			ctx:     context.Background(),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("sums the counters of the pools, excluding the admin console", func() {
+		mock.ExpectQuery("SHOW POOLS;").
+			WillReturnRows(sqlmock.NewRows([]string{
+				"database", "user", "cl_active", "cl_waiting", "sv_active", "sv_idle", "maxwait", "pool_mode",
+			}).
+				AddRow("app", "app", 10, 3, 10, 0, 2, "session").
+				AddRow("pgbouncer", "pgbouncer", 1, 0, 0, 0, 0, "statement").
+				AddRow("reports", "app", 4, 1, 4, 2, 5, "transaction"))
+
+		recorder := httptest.NewRecorder()
+		exp.serveStats(recorder, httptest.NewRequest(http.MethodGet, "/pgbouncer/stats", nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var stats apiv1.PoolerStats
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &stats)).To(Succeed())
+		Expect(stats).To(Equal(apiv1.PoolerStats{
+			Instances:      1,
+			ClientsActive:  14,
+			ClientsWaiting: 4,
+			ServersActive:  14,
+			ServersIdle:    2,
+			MaxWaitSeconds: 5,
+		}))
+	})
+
+	It("fails when PgBouncer can't be queried", func() {
+		mock.ExpectQuery("SHOW POOLS;").WillReturnError(sql.ErrConnDone)
+
+		recorder := httptest.NewRecorder()
+		exp.serveStats(recorder, httptest.NewRequest(http.MethodGet, "/pgbouncer/stats", nil))
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,140 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsserver
+
+import (
+	"database/sql"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ShowServersMetrics contains all the SHOW SERVERS Metrics
+type ShowServersMetrics struct {
+	Connections,
+	CloseNeeded *prometheus.GaugeVec
+}
+
+// Describe produces the description for all the contained Metrics
+func (r *ShowServersMetrics) Describe(ch chan<- *prometheus.Desc) {
+	r.Connections.Describe(ch)
+	r.CloseNeeded.Describe(ch)
+}
+
+// Reset resets all the contained Metrics
+func (r *ShowServersMetrics) Reset() {
+	r.Connections.Reset()
+	r.CloseNeeded.Reset()
+}
+
+// NewShowServersMetrics builds the default ShowServersMetrics
+func NewShowServersMetrics(subsystem string) *ShowServersMetrics {
+	subsystem += "_servers"
+	return &ShowServersMetrics{
+		Connections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "connections",
+			Help:      "Server connections, by state (active, idle, used, tested, new).",
+		}, []string{"database", "user", "state"}),
+		CloseNeeded: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "close_needed",
+			Help: "Server connections that will be closed as soon as possible, because of a " +
+				"configuration reload or a DNS update.",
+		}, []string{"database", "user"}),
+	}
+}
+
+func (e *Exporter) collectShowServers(ch chan<- prometheus.Metric, db *sql.DB) {
+	contextLogger := log.FromContext(e.ctx)
+
+	e.Metrics.ShowServers.Reset()
+	rows, err := db.Query("SHOW SERVERS;")
+	if err != nil {
+		contextLogger.Error(err, "Error while executing SHOW SERVERS")
+		e.Metrics.PgbouncerUp.Set(0)
+		e.Metrics.Error.Set(1)
+		return
+	}
+
+	e.Metrics.PgbouncerUp.Set(1)
+	e.Metrics.Error.Set(0)
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			contextLogger.Error(err, "while closing rows for SHOW SERVERS")
+		}
+	}()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		contextLogger.Error(err, "Error while getting number of columns")
+		e.Metrics.PgbouncerUp.Set(0)
+		e.Metrics.Error.Set(1)
+		return
+	}
+
+	// The list of the servers is aggregated by pool, as a time series
+	// for each server connection would have an unbounded cardinality
+	for rows.Next() {
+		row, err := scanRow(rows, cols)
+		if err != nil {
+			contextLogger.Error(err, "Error while executing SHOW SERVERS")
+			e.Metrics.Error.Set(1)
+			e.Metrics.PgCollectionErrors.WithLabelValues(err.Error()).Inc()
+			continue
+		}
+
+		database, user := row["database"].String, row["user"].String
+		e.Metrics.ShowServers.Connections.WithLabelValues(database, user, row["state"].String).Inc()
+		closeNeeded := e.Metrics.ShowServers.CloseNeeded.WithLabelValues(database, user)
+		if row["close_needed"].String == "1" {
+			closeNeeded.Inc()
+		}
+	}
+
+	e.Metrics.ShowServers.Connections.Collect(ch)
+	e.Metrics.ShowServers.CloseNeeded.Collect(ch)
+
+	if err = rows.Err(); err != nil {
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues(err.Error()).Inc()
+	}
+}
+
+// scanRow reads the current row into a map indexed by the column
+// names, as the columns returned by the PgBouncer SHOW commands change
+// across PgBouncer versions
+func scanRow(rows *sql.Rows, cols []string) (map[string]sql.NullString, error) {
+	values := make([]sql.NullString, len(cols))
+	pointers := make([]interface{}, len(cols))
+	for idx := range values {
+		pointers[idx] = &values[idx]
+	}
+
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]sql.NullString, len(cols))
+	for idx, col := range cols {
+		result[col] = values[idx]
+	}
+	return result, nil
+}
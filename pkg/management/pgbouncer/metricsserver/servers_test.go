@@ -0,0 +1,119 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsserver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporter SHOW SERVERS", func() {
+	const (
+		serversConnectionsKey = "cnpg_pgbouncer_servers_connections"
+		serversCloseNeededKey = "cnpg_pgbouncer_servers_close_needed"
+	)
+
+	var (
+		registry *prometheus.Registry
+		db       *sql.DB
+		mock     sqlmock.Sqlmock
+		exp      *Exporter
+		ch       chan prometheus.Metric
+		columns  = []string{
+			"type", "user", "database", "state", "addr", "port", "local_addr", "local_port",
+			"connect_time", "request_time", "wait", "wait_us", "close_needed", "ptr", "link",
+			"remote_pid", "tls", "application_name",
+		}
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		exp = &Exporter{
+			Metrics: newMetrics(),
+			pool:    fakePooler{db: db},
+			ctx:     ctx,
+		}
+
+		registry = prometheus.NewRegistry()
+		registry.MustRegister(exp.Metrics.PgbouncerUp)
+		registry.MustRegister(exp.Metrics.Error)
+		registry.MustRegister(exp.Metrics.ShowServers.Connections)
+		registry.MustRegister(exp.Metrics.ShowServers.CloseNeeded)
+
+		ch = make(chan prometheus.Metric, 1000)
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should react properly if SQL shows no servers", func() {
+		mock.ExpectQuery("SHOW SERVERS;").WillReturnError(sql.ErrNoRows)
+		exp.collectShowServers(ch, db)
+
+		metrics, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		pgBouncerUpValue := getMetric(metrics, pgBouncerUpKey).GetMetric()[0].GetGauge().GetValue()
+		Expect(pgBouncerUpValue).Should(BeEquivalentTo(0))
+	})
+
+	It("should aggregate the server connections by pool and state", func() {
+		newRow := func(user, database, state, closeNeeded string) []driver.Value {
+			return []driver.Value{
+				"S", user, database, state, "10.0.0.1", 5432, "10.0.0.2", 40000,
+				"2024-01-01 00:00:00", "2024-01-01 00:00:00", 0, 0, closeNeeded, "0x1", "",
+				1234, "TLSv1.3", "app",
+			}
+		}
+		mock.ExpectQuery("SHOW SERVERS;").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(newRow("app", "app", "active", "0")...).
+				AddRow(newRow("app", "app", "active", "1")...).
+				AddRow(newRow("app", "app", "idle", "0")...))
+
+		exp.collectShowServers(ch, db)
+
+		metrics, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		connections := getMetric(metrics, serversConnectionsKey).GetMetric()
+		Expect(connections).To(HaveLen(2))
+		values := make(map[string]float64)
+		for _, connection := range connections {
+			for _, label := range connection.GetLabel() {
+				if label.GetName() == "state" {
+					values[label.GetValue()] = connection.GetGauge().GetValue()
+				}
+			}
+		}
+		Expect(values).To(Equal(map[string]float64{"active": 2, "idle": 1}))
+
+		closeNeeded := getMetric(metrics, serversCloseNeededKey).GetMetric()
+		Expect(closeNeeded).To(HaveLen(1))
+		Expect(closeNeeded[0].GetGauge().GetValue()).To(BeEquivalentTo(1))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
 	"path"
 	"path/filepath"
 	"time"
@@ -36,6 +37,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/repository"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbackrest"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/local"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
@@ -115,6 +117,21 @@ func Run(
 ) error {
 	contextLog := log.FromContext(ctx)
 
+	// When a standby has been elected to archive the WAL files, every other
+	// instance skips them. The primary retains them through the replication
+	// slot of the elected standby until they are streamed
+	if walArchiverInstance := cluster.Status.WALArchiverInstance; cluster.IsWALArchivedByStandby() &&
+		walArchiverInstance != "" {
+		if podName != walArchiverInstance {
+			contextLog.Debug("WAL archiving is performed by the elected standby, skipping WAL archiving",
+				"walName", walName,
+				"walArchiverInstance", walArchiverInstance,
+			)
+			return nil
+		}
+		return internalRun(ctx, pgData, cluster, walName)
+	}
+
 	if cluster.IsReplica() {
 		if podName != cluster.Status.CurrentPrimary && podName != cluster.Status.TargetPrimary {
 			contextLog.Debug("WAL archiving on a replica cluster, "+
@@ -153,6 +170,11 @@ func internalRun(
 		return err
 	}
 
+	// Request pgBackRest to archive this WAL
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.PgBackRest != nil {
+		return archiveWALViaPgBackRest(ctx, pgData, cluster, walName)
+	}
+
 	// Request Barman Cloud to archive this WAL
 	if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
 		// Backup not configured, skipping WAL
@@ -239,6 +261,16 @@ func internalRun(
 			"totalTime", time.Since(startTime))
 	}
 
+	// Step 6: spool the archived WAL files to be copied to the secondary
+	// object store, if any
+	if cluster.Spec.Backup.Mirror != nil {
+		if err := spoolWALsForMirror(
+			ctx, pgData, walArchiver, walStatus, cluster.GetBackupMirrorMaxSpoolSize(),
+		); err != nil {
+			return err
+		}
+	}
+
 	// We return only the first error to PostgreSQL, because the first error
 	// is the one raised by the file that PostgreSQL has requested to archive.
 	// The other errors are related to WAL files that were pre-archived as
@@ -246,6 +278,149 @@ func internalRun(
 	return walStatus[0].Err
 }
 
+// spoolWALsForMirror copies the successfully archived WAL files into the
+// mirror spool directory, where they wait to be copied to the secondary
+// object store by the instance manager. WAL files that cannot be spooled
+// are archived again: the failure of the WAL file requested by PostgreSQL
+// is returned, while the pre-archived ones are removed from the archiver
+// spool. When the spool is full, the WAL files are recorded as skipped
+// instead, and will never reach the secondary object store
+func spoolWALsForMirror(
+	ctx context.Context,
+	pgData string,
+	walArchiver *barmanArchiver.WALArchiver,
+	walStatus []barmanArchiver.WALArchiverResult,
+	maxSpoolSize int64,
+) error {
+	contextLog := log.FromContext(ctx)
+
+	spoolSize, err := getDirectorySize(postgres.MirrorSpoolDirectory)
+	if err != nil {
+		return fmt.Errorf("while reading the size of the mirror spool: %w", err)
+	}
+
+	for idx, result := range walStatus {
+		if result.Err != nil {
+			continue
+		}
+
+		walPath := path.Join(pgData, result.WalName)
+		walInfo, err := os.Stat(walPath)
+		if err != nil {
+			return err
+		}
+
+		if spoolSize+walInfo.Size() > maxSpoolSize {
+			contextLog.Warning("The mirror spool is full, the WAL file will not be copied "+
+				"to the secondary object store",
+				"walName", result.WalName,
+				"spoolSize", spoolSize,
+				"maxSpoolSize", maxSpoolSize)
+			if err := markWALAsSkipped(path.Base(result.WalName)); err != nil {
+				return fmt.Errorf("while recording a WAL file skipped by the mirror: %w", err)
+			}
+			continue
+		}
+
+		// The WAL file is copied with a temporary name and then renamed,
+		// to never expose a partial file to the instance manager
+		destination := path.Join(postgres.MirrorSpoolDirectory, path.Base(result.WalName))
+		err = fileutils.CopyFile(walPath, destination+".tmp")
+		if err == nil {
+			err = os.Rename(destination+".tmp", destination)
+		}
+		if err != nil {
+			contextLog.Error(err, "while spooling WAL file for the secondary object store",
+				"walName", result.WalName)
+			if idx == 0 {
+				return fmt.Errorf("while spooling WAL file for the secondary object store: %w", err)
+			}
+			if _, err := walArchiver.DeleteFromSpool(result.WalName); err != nil {
+				contextLog.Error(err, "while removing WAL file from the archiver spool",
+					"walName", result.WalName)
+			}
+			continue
+		}
+		spoolSize += walInfo.Size()
+	}
+
+	return nil
+}
+
+// markWALAsSkipped records that a WAL file has not been spooled to be
+// copied to the secondary object store
+func markWALAsSkipped(walName string) error {
+	if err := os.MkdirAll(postgres.MirrorSkippedDirectory, 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(postgres.MirrorSkippedDirectory, walName), nil, 0o600)
+}
+
+// getDirectorySize gets the total size of the regular files contained
+// in a directory, which is empty when it doesn't exist
+func getDirectorySize(directory string) (int64, error) {
+	entries, err := os.ReadDir(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var result int64
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			// The WAL file has just been copied to the secondary object store
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if info.Mode().IsRegular() {
+			result += info.Size()
+		}
+	}
+
+	return result, nil
+}
+
+// archiveWALViaPgBackRest archives the passed WAL file in the pgBackRest
+// repository of the cluster, creating the stanza if needed
+func archiveWALViaPgBackRest(
+	ctx context.Context,
+	pgData string,
+	cluster *apiv1.Cluster,
+	walName string,
+) error {
+	contextLog := log.FromContext(ctx)
+	startTime := time.Now()
+
+	// Get environment from cache
+	env, err := local.NewClient().Cache().GetEnv(cache.WALArchiveKey)
+	if err != nil {
+		return fmt.Errorf("failed to get envs: %w", err)
+	}
+
+	configuration := cluster.Spec.Backup.PgBackRest
+	stanza := pgbackrest.GetStanza(configuration, cluster.Name)
+	options := pgbackrest.Options(configuration, stanza, pgData)
+	if err := pgbackrest.EnsureStanza(ctx, env, options, stanza); err != nil {
+		return err
+	}
+
+	if err := pgbackrest.ArchivePush(ctx, env, options, path.Join(pgData, walName)); err != nil {
+		return fmt.Errorf("while archiving WAL file with pgBackRest: %w", err)
+	}
+
+	contextLog.Info("Archived WAL file",
+		"walName", walName,
+		"startTime", startTime,
+		"totalTime", time.Since(startTime))
+	return nil
+}
+
 // archiveWALViaPlugins requests every capable plugin to archive the passed
 // WAL file, and returns an error if a configured plugin fails to do so.
 // It will not return an error if there's no plugin capable of WAL archiving
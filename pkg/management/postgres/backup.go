@@ -22,6 +22,7 @@ import (
 	"os"
 	"reflect"
 	"slices"
+	"strings"
 	"time"
 
 	barmanBackup "github.com/cloudnative-pg/barman-cloud/pkg/backup"
@@ -32,8 +33,10 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,9 +45,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbackrest"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 
 	// this is needed to correctly open the sql connection with the pgx driver
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -93,10 +98,40 @@ func NewBarmanBackupCommand(
 		Instance:     instance,
 		Log:          log,
 		Capabilities: capabilities,
-		barmanBackup: barmanBackup.NewBackupCommand(cluster.Spec.Backup.BarmanObjectStore, capabilities),
+		barmanBackup: barmanBackup.NewBackupCommand(
+			withBandwidthLimit(cluster.Spec.Backup.BarmanObjectStore, cluster.Spec.Backup.BandwidthLimit),
+			capabilities,
+		),
 	}, nil
 }
 
+// withBandwidthLimit returns a copy of the passed object store configuration
+// limiting the bandwidth used by barman-cloud-backup. A limit already set in
+// the additional command arguments takes precedence
+func withBandwidthLimit(
+	configuration *apiv1.BarmanObjectStoreConfiguration,
+	bandwidthLimit *resource.Quantity,
+) *apiv1.BarmanObjectStoreConfiguration {
+	if configuration == nil || bandwidthLimit == nil {
+		return configuration
+	}
+
+	result := configuration.DeepCopy()
+	if result.Data == nil {
+		result.Data = &apiv1.DataBackupConfiguration{}
+	}
+	for _, arg := range result.Data.AdditionalCommandArgs {
+		if strings.HasPrefix(arg, "--max-bandwidth") {
+			return result
+		}
+	}
+
+	result.Data.AdditionalCommandArgs = append(
+		result.Data.AdditionalCommandArgs,
+		fmt.Sprintf("--max-bandwidth=%d", bandwidthLimit.Value()))
+	return result
+}
+
 // Start initiates a backup for this instance using
 // barman-cloud-backup
 func (b *BackupCommand) Start(ctx context.Context) error {
@@ -171,40 +206,174 @@ func (b *BackupCommand) run(ctx context.Context) {
 			),
 	)
 
+	ctx, span := StartBackupSpan(ctx, b.Backup)
+	defer span.End()
+
 	if err := b.takeBackup(ctx); err != nil {
-		backupStatus := b.Backup.GetStatus()
-
-		// record the failure
-		b.Log.Error(err, "Backup failed")
-		b.Recorder.Event(b.Backup, "Normal", "Failed", "Backup failed")
-
-		// update backup status as failed
-		backupStatus.SetAsFailed(err)
-		if err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
-			b.Log.Error(err, "Can't mark backup as failed")
-			// We do not terminate here because we still want to do the maintenance
-			// activity on the backups and to set the condition on the cluster.
-		}
+		markBackupAsFailed(ctx, b.Client, b.Recorder, b.Log, b.Cluster, b.Backup, err)
+	} else if b.Cluster.Spec.Backup.Mirror != nil {
+		b.mirrorBackup(ctx)
+	}
+
+	b.backupMaintenance(ctx)
+}
+
+// mirrorBackup takes a second, independent base backup in the secondary
+// object store, recording the result in the mirror status of the cluster.
+// Barman Cloud cannot copy a backup between two object stores: the second
+// base backup has the same name, but it is taken after the first one
+// completed, and needs a different range of WAL files
+func (b *BackupCommand) mirrorBackup(ctx context.Context) {
+	configuration := &b.Cluster.Spec.Backup.Mirror.BarmanObjectStore
+	serverName := configuration.ServerName
+	if serverName == "" {
+		serverName = b.Cluster.Name
+	}
+
+	b.Log.Info("Taking an independent base backup in the secondary object store")
+	mirroredBackup, err := b.takeMirrorBackup(ctx, configuration, serverName)
+	if err != nil {
+		b.Log.Error(err, "Error while taking the base backup in the secondary object store")
+		b.Recorder.Event(b.Backup, "Warning", "MirrorFailed",
+			"Independent base backup in the secondary object store failed")
+	} else {
+		b.Recorder.Event(b.Backup, "Normal", "MirrorCompleted",
+			"Independent base backup in the secondary object store completed")
+	}
+
+	if patchErr := b.retryWithRefreshedCluster(ctx, func() error {
+		return status.PatchWithOptimisticLock(
+			ctx,
+			b.Client,
+			b.Cluster,
+			func(cluster *apiv1.Cluster) {
+				if cluster.Status.BackupMirror == nil {
+					cluster.Status.BackupMirror = &apiv1.BackupMirrorStatus{}
+				}
+				if err != nil {
+					cluster.Status.BackupMirror.LastBackupError = err.Error()
+					return
+				}
+				cluster.Status.BackupMirror.LastBackupError = ""
+				cluster.Status.BackupMirror.LastMirroredBackupID = mirroredBackup.ID
+				cluster.Status.BackupMirror.LastMirroredBackupBeginWAL = mirroredBackup.BeginWal
+				cluster.Status.BackupMirror.LastMirroredBackupTime = pgTime.GetCurrentTimestampWithFormat(time.RFC3339)
+			},
+		)
+	}); patchErr != nil {
+		b.Log.Error(patchErr, "while setting the status of the backup in the secondary object store")
+	}
+}
+
+// takeMirrorBackup executes barman-cloud-backup against the secondary
+// object store, applying its retention policy, and returns the backup
+// that has been taken
+func (b *BackupCommand) takeMirrorBackup(
+	ctx context.Context,
+	configuration *apiv1.BarmanObjectStoreConfiguration,
+	serverName string,
+) (*barmanCatalog.BarmanBackup, error) {
+	env, err := barmanCredentials.EnvSetBackupCloudCredentials(
+		ctx,
+		b.Client,
+		b.Cluster.Namespace,
+		configuration,
+		os.Environ())
+	if err != nil {
+		return nil, fmt.Errorf("cannot recover the secondary object store credentials: %w", err)
+	}
+
+	mirrorCommand := barmanBackup.NewBackupCommand(
+		withBandwidthLimit(configuration, b.Cluster.Spec.Backup.BandwidthLimit),
+		b.Capabilities,
+	)
+	if err := mirrorCommand.Take(
+		ctx,
+		b.Backup.Status.BackupName,
+		serverName,
+		env,
+		b.Cluster,
+		postgres.BackupTemporaryDirectory,
+	); err != nil {
+		return nil, err
+	}
+
+	mirroredBackup, err := mirrorCommand.GetExecutedBackupInfo(
+		ctx, b.Backup.Status.BackupName, serverName, b.Cluster, env)
+	if err != nil {
+		return nil, err
+	}
+	if mirroredBackup == nil {
+		return nil, fmt.Errorf("backup %s not found in the secondary object store", b.Backup.Status.BackupName)
+	}
 
-		// add backup failed condition to the cluster
-		if failErr := b.retryWithRefreshedCluster(ctx, func() error {
-			return status.PatchWithOptimisticLock(
-				ctx,
-				b.Client,
-				b.Cluster,
-				func(cluster *apiv1.Cluster) {
-					meta.SetStatusCondition(&cluster.Status.Conditions, apiv1.BuildClusterBackupFailedCondition(err))
-					cluster.Status.LastFailedBackup = pgTime.GetCurrentTimestampWithFormat(time.RFC3339)
-				},
-			)
-		}); failErr != nil {
-			b.Log.Error(failErr, "while setting cluster condition for failed backup")
-			// We do not terminate here because it's more important to properly handle
-			// the backup maintenance activity than putting a condition in the cluster
+	if b.Cluster.Spec.Backup.RetentionPolicy != "" {
+		if err := barmanCommand.DeleteBackupsByPolicy(
+			ctx,
+			configuration,
+			serverName,
+			env,
+			b.Cluster.Spec.Backup.RetentionPolicy,
+		); err != nil {
+			// Proper logging already happened inside DeleteBackupsByPolicy
+			b.Recorder.Event(b.Cluster, "Warning", "RetentionPolicyFailed",
+				"Retention policy failed on the secondary object store")
 		}
 	}
 
-	b.backupMaintenance(ctx)
+	return mirroredBackup, nil
+}
+
+// StartBackupSpan starts the span tracing a backup taken
+// by the instance manager
+func StartBackupSpan(ctx context.Context, backup *apiv1.Backup) (context.Context, *telemetry.Span) {
+	return telemetry.Start(ctx, "instance.backup",
+		attribute.String("cnpg.backup.name", backup.Name),
+		attribute.String("cnpg.backup.method", string(backup.Spec.Method)))
+}
+
+// markBackupAsFailed records the failure of a backup in the Backup
+// object and in the conditions of the Cluster
+func markBackupAsFailed(
+	ctx context.Context,
+	cli client.Client,
+	recorder record.EventRecorder,
+	logger log.Logger,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	err error,
+) {
+	backupStatus := backup.GetStatus()
+
+	// record the failure
+	logger.Error(err, "Backup failed")
+	recorder.Event(backup, "Normal", "Failed", "Backup failed")
+	telemetry.SpanFromContext(ctx).RecordError(err)
+
+	// update backup status as failed
+	backupStatus.SetAsFailed(err)
+	if err := PatchBackupStatusAndRetry(ctx, cli, backup); err != nil {
+		logger.Error(err, "Can't mark backup as failed")
+		// We do not terminate here because we still want to do the maintenance
+		// activity on the backups and to set the condition on the cluster.
+	}
+
+	// add backup failed condition to the cluster
+	if failErr := resources.RetryWithRefreshedResource(ctx, cli, cluster, func() error {
+		return status.PatchWithOptimisticLock(
+			ctx,
+			cli,
+			cluster,
+			func(cluster *apiv1.Cluster) {
+				meta.SetStatusCondition(&cluster.Status.Conditions, apiv1.BuildClusterBackupFailedCondition(err))
+				cluster.Status.LastFailedBackup = pgTime.GetCurrentTimestampWithFormat(time.RFC3339)
+			},
+		)
+	}); failErr != nil {
+		logger.Error(failErr, "while setting cluster condition for failed backup")
+		// We do not terminate here because it's more important to properly handle
+		// the backup maintenance activity than putting a condition in the cluster
+	}
 }
 
 func (b *BackupCommand) takeBackup(ctx context.Context) error {
@@ -443,6 +612,15 @@ func deleteBackupsNotInCatalog(
 
 // useSameBackupLocation checks whether the given backup was taken using the same configuration as provided
 func useSameBackupLocation(backup *apiv1.BackupStatus, cluster *apiv1.Cluster) bool {
+	if backup.Method == apiv1.BackupMethodPgBackRest {
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.PgBackRest == nil {
+			return false
+		}
+		configuration := cluster.Spec.Backup.PgBackRest
+		return backup.DestinationPath == configuration.Repository.Path &&
+			backup.ServerName == pgbackrest.GetStanza(configuration, cluster.Name)
+	}
+
 	if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
 		return false
 	}
@@ -0,0 +1,294 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbackrest"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// PgBackRestBackupCommand represent a backup command taken using pgBackRest
+// that is being executed
+type PgBackRestBackupCommand struct {
+	Cluster  *apiv1.Cluster
+	Backup   *apiv1.Backup
+	Client   client.Client
+	Recorder record.EventRecorder
+	Env      []string
+	Log      log.Logger
+	Instance *Instance
+	options  []string
+}
+
+// NewPgBackRestBackupCommand initializes a PgBackRestBackupCommand object,
+// taking a physical backup using pgBackRest
+func NewPgBackRestBackupCommand(
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	client client.Client,
+	recorder record.EventRecorder,
+	instance *Instance,
+	log log.Logger,
+) *PgBackRestBackupCommand {
+	configuration := cluster.Spec.Backup.PgBackRest
+	return &PgBackRestBackupCommand{
+		Cluster:  cluster,
+		Backup:   backup,
+		Client:   client,
+		Recorder: recorder,
+		Env:      os.Environ(),
+		Instance: instance,
+		Log:      log,
+		options: pgbackrest.Options(
+			configuration,
+			pgbackrest.GetStanza(configuration, cluster.Name),
+			instance.PgData,
+		),
+	}
+}
+
+// Start initiates a backup for this instance using pgBackRest
+func (b *PgBackRestBackupCommand) Start(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	b.setupBackupStatus()
+
+	err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup)
+	if err != nil {
+		return fmt.Errorf("can't set backup as running: %v", err)
+	}
+
+	if err := ensureWalArchiveIsWorking(b.Instance); err != nil {
+		contextLogger.Warning("WAL archiving is not working", "err", err)
+		b.Backup.GetStatus().Phase = apiv1.BackupPhaseWalArchivingFailing
+		return PatchBackupStatusAndRetry(ctx, b.Client, b.Backup)
+	}
+
+	if b.Backup.GetStatus().Phase != apiv1.BackupPhaseRunning {
+		b.Backup.GetStatus().Phase = apiv1.BackupPhaseRunning
+		err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup)
+		if err != nil {
+			contextLogger.Error(err, "can't set backup as WAL archiving failing")
+		}
+	}
+
+	b.Env, err = pgbackrest.EnvSetCredentials(
+		ctx,
+		b.Client,
+		b.Cluster.Namespace,
+		b.Cluster.Spec.Backup.PgBackRest,
+		b.Env)
+	if err != nil {
+		return fmt.Errorf("cannot recover backup credentials: %w", err)
+	}
+
+	// Run the actual backup process
+	go b.run(ctx)
+
+	return nil
+}
+
+// setupBackupStatus configures the backup's status from the provided configuration
+func (b *PgBackRestBackupCommand) setupBackupStatus() {
+	configuration := b.Cluster.Spec.Backup.PgBackRest
+	backupStatus := b.Backup.GetStatus()
+
+	backupStatus.DestinationPath = configuration.Repository.Path
+	backupStatus.ServerName = pgbackrest.GetStanza(configuration, b.Cluster.Name)
+	backupStatus.Phase = apiv1.BackupPhaseRunning
+}
+
+// run executes pgBackRest and updates the status.
+// This method will take long time and is supposed to run inside a dedicated
+// goroutine.
+func (b *PgBackRestBackupCommand) run(ctx context.Context) {
+	ctx = log.IntoContext(
+		ctx,
+		log.FromContext(ctx).
+			WithValues(
+				"backupName", b.Backup.Name,
+				"backupNamespace", b.Backup.Namespace,
+			),
+	)
+
+	ctx, span := StartBackupSpan(ctx, b.Backup)
+	defer span.End()
+
+	if err := b.takeBackup(ctx); err != nil {
+		markBackupAsFailed(ctx, b.Client, b.Recorder, b.Log, b.Cluster, b.Backup, err)
+	}
+
+	b.backupMaintenance(ctx)
+}
+
+func (b *PgBackRestBackupCommand) takeBackup(ctx context.Context) error {
+	b.Recorder.Event(b.Backup, "Normal", "Starting", "Backup started")
+
+	// Update backup status in cluster conditions on startup
+	if err := resources.RetryWithRefreshedResource(ctx, b.Client, b.Cluster, func() error {
+		return status.PatchConditionsWithOptimisticLock(ctx, b.Client, b.Cluster, apiv1.BackupStartingCondition)
+	}); err != nil {
+		b.Log.Error(err, "Error changing backup condition (backup started)")
+		// We do not terminate here because we could still have a good backup
+		// even if we are unable to communicate with the Kubernetes API server
+	}
+
+	stanza := b.Backup.Status.ServerName
+	if err := pgbackrest.EnsureStanza(ctx, b.Env, b.options, stanza); err != nil {
+		return err
+	}
+
+	backupType := pgbackrest.BackupTypeForLevel(b.Backup.Spec.Level, b.Cluster.Spec.Backup.PgBackRest.BackupType)
+	if err := pgbackrest.Backup(ctx, b.Env, b.options, backupType); err != nil {
+		b.Log.Error(err, "Error while taking pgBackRest backup", "err", err)
+		return err
+	}
+
+	b.Log.Info("Backup completed")
+	b.Recorder.Event(b.Backup, "Normal", "Completed", "Backup completed")
+
+	// Set the status to completed
+	b.Backup.Status.SetAsCompleted()
+
+	catalog, err := pgbackrest.Info(ctx, b.Env, b.options)
+	if err != nil {
+		return err
+	}
+	backupInfo := catalog.Latest()
+	if backupInfo == nil {
+		return errors.New("the completed backup is missing from the pgBackRest repository")
+	}
+
+	b.Log.Debug("extracted pgBackRest backup", "backup", backupInfo)
+	assignPgBackRestBackupToBackup(b.Backup, backupInfo)
+
+	if err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		b.Log.Error(err, "Can't set backup status as completed")
+	}
+
+	// Update backup status in cluster conditions on backup completion
+	if err := resources.RetryWithRefreshedResource(ctx, b.Client, b.Cluster, func() error {
+		return status.PatchConditionsWithOptimisticLock(ctx, b.Client, b.Cluster, apiv1.BackupSucceededCondition)
+	}); err != nil {
+		b.Log.Error(err, "Can't update the cluster with the completed backup data")
+	}
+
+	return nil
+}
+
+// backupMaintenance applies the retention policy of the cluster, aligns
+// the Backup objects with the content of the repository and updates
+// the recoverability information of the cluster
+func (b *PgBackRestBackupCommand) backupMaintenance(ctx context.Context) {
+	catalog, err := pgbackrest.Info(ctx, b.Env, b.options)
+	if err != nil {
+		// Proper logging already happened inside Info
+		return
+	}
+
+	if b.Cluster.Spec.Backup.RetentionPolicy != "" {
+		b.Log.Info("Applying backup retention policy",
+			"retentionPolicy", b.Cluster.Spec.Backup.RetentionPolicy)
+		if err := b.applyRetentionPolicy(ctx, catalog); err != nil {
+			b.Log.Error(err, "while applying the backup retention policy")
+			b.Recorder.Event(b.Cluster, "Warning", "RetentionPolicyFailed", "Retention policy failed")
+		}
+
+		// Refresh the catalog, as some backups may have been expired
+		if catalog, err = pgbackrest.Info(ctx, b.Env, b.options); err != nil {
+			return
+		}
+	}
+
+	if err := deleteBackupsNotInCatalog(ctx, b.Client, b.Cluster, catalog.GetBackupIDs()); err != nil {
+		b.Log.Error(err, "while deleting Backups not present in the catalog")
+	}
+
+	firstBackup := catalog.First()
+	latestBackup := catalog.Latest()
+	if firstBackup == nil || latestBackup == nil {
+		return
+	}
+	firstRecoverabilityPoint := firstBackup.StopTime()
+	lastSuccessfulBackup := latestBackup.StopTime()
+
+	if err := resources.RetryWithRefreshedResource(ctx, b.Client, b.Cluster, func() error {
+		origCluster := b.Cluster.DeepCopy()
+
+		// Set the first recoverability point and the last successful backup
+		b.Cluster.UpdateBackupTimes(
+			apiv1.BackupMethodPgBackRest,
+			&firstRecoverabilityPoint,
+			&lastSuccessfulBackup,
+		)
+
+		if equality.Semantic.DeepEqual(origCluster.Status, b.Cluster.Status) {
+			return nil
+		}
+		return b.Client.Status().Patch(ctx, b.Cluster, client.MergeFrom(origCluster))
+	}); err != nil {
+		b.Log.Error(err, "while setting the firstRecoverabilityPoint and latestSuccessfulBackup")
+	}
+}
+
+// applyRetentionPolicy expires the backups that are not needed anymore
+// to recover to any point in the recovery window. Full backups referenced
+// by retained incremental or differential backups are never expired
+func (b *PgBackRestBackupCommand) applyRetentionPolicy(ctx context.Context, catalog *pgbackrest.Catalog) error {
+	expiredBackups, err := catalog.ExpiredBackups(b.Cluster.Spec.Backup.RetentionPolicy, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, backupSet := range expiredBackups {
+		b.Log.Info("Expiring backup set", "backupSet", backupSet)
+		if err := pgbackrest.Expire(ctx, b.Env, b.options, backupSet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func assignPgBackRestBackupToBackup(backup *apiv1.Backup, backupInfo *pgbackrest.BackupInfo) {
+	backupStatus := backup.GetStatus()
+
+	backupStatus.BackupName = backupInfo.Label
+	backupStatus.BackupID = backupInfo.Label
+	backupStatus.StartedAt = &metav1.Time{Time: backupInfo.StartTime()}
+	backupStatus.StoppedAt = &metav1.Time{Time: backupInfo.StopTime()}
+	backupStatus.BeginWal = backupInfo.Archive.Start
+	backupStatus.EndWal = backupInfo.Archive.Stop
+	backupStatus.BeginLSN = backupInfo.LSN.Start
+	backupStatus.EndLSN = backupInfo.LSN.Stop
+	backupStatus.Level = backupInfo.Level()
+	backupStatus.ParentBackupID = backupInfo.Prior
+}
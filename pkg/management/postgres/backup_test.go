@@ -25,6 +25,7 @@ import (
 	barmanCapabilities "github.com/cloudnative-pg/barman-cloud/pkg/capabilities"
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
@@ -214,4 +215,59 @@ var _ = Describe("generate backup options", func() {
 						"--min-chunk-size=5MB --read-timeout=60 -vv",
 				))
 	})
+
+	It("should limit the bandwidth", func() {
+		cluster.Spec.Backup.BarmanObjectStore.Data.AdditionalCommandArgs = nil
+		configuration := withBandwidthLimit(cluster.Spec.Backup.BarmanObjectStore, ptr.To(resource.MustParse("50Mi")))
+		cmd := barmanBackup.NewBackupCommand(configuration, &capabilities)
+		options, err := cmd.GetDataConfiguration([]string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(strings.Join(options, " ")).
+			To(Equal("--gzip --encryption aes256 --immediate-checkpoint --jobs 2 --max-bandwidth=52428800"))
+		Expect(cluster.Spec.Backup.BarmanObjectStore.Data.AdditionalCommandArgs).To(BeEmpty())
+	})
+
+	It("should not overwrite the bandwidth limit declared in the additional arguments", func() {
+		cluster.Spec.Backup.BarmanObjectStore.Data.AdditionalCommandArgs = []string{"--max-bandwidth=10MB"}
+		configuration := withBandwidthLimit(cluster.Spec.Backup.BarmanObjectStore, ptr.To(resource.MustParse("50Mi")))
+		Expect(configuration.Data.AdditionalCommandArgs).To(Equal([]string{"--max-bandwidth=10MB"}))
+	})
+})
+
+var _ = Describe("backup location of pgBackRest backups", func() {
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+		Spec: apiv1.ClusterSpec{
+			Backup: &apiv1.BackupConfiguration{
+				PgBackRest: &apiv1.PgBackRestConfiguration{
+					Repository: apiv1.PgBackRestRepository{Path: "/cluster-example"},
+				},
+			},
+		},
+	}
+
+	It("matches backups taken in the same repository and stanza", func() {
+		Expect(useSameBackupLocation(&apiv1.BackupStatus{
+			Method:          apiv1.BackupMethodPgBackRest,
+			DestinationPath: "/cluster-example",
+			ServerName:      "cluster-example",
+		}, cluster)).To(BeTrue())
+	})
+
+	It("doesn't match backups taken in another stanza", func() {
+		Expect(useSameBackupLocation(&apiv1.BackupStatus{
+			Method:          apiv1.BackupMethodPgBackRest,
+			DestinationPath: "/cluster-example",
+			ServerName:      "another",
+		}, cluster)).To(BeFalse())
+	})
+
+	It("doesn't match barman backups", func() {
+		Expect(useSameBackupLocation(&apiv1.BackupStatus{
+			Method:          apiv1.BackupMethodBarmanObjectStore,
+			DestinationPath: "/cluster-example",
+			ServerName:      "cluster-example",
+		}, cluster)).To(BeFalse())
+	})
 })
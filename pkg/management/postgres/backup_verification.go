@@ -0,0 +1,214 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+const (
+	pgVerifyBackupName = "pg_verifybackup"
+
+	// backupManifestFile is the manifest written by pg_basebackup, which
+	// is needed by pg_verifybackup
+	backupManifestFile = "backup_manifest"
+
+	// backupVerificationSourceName is the name of the external cluster
+	// used to restore pgBackRest backups in the throwaway instance
+	backupVerificationSourceName = "backup-verification-source"
+
+	// defaultBackupVerificationDatabase is the database where the
+	// smoke query is run, unless otherwise specified
+	defaultBackupVerificationDatabase = "postgres"
+)
+
+// VerifyBackup restores the passed backup in the data directory of a
+// throwaway instance and verifies it. The content of the backup is checked
+// with pg_verifybackup when a backup manifest is available, then PostgreSQL
+// is started and, once the recovery reaches a consistent state, the smoke
+// query of the cluster is run.
+// The archive of the cluster is never written, as WAL archiving is disabled
+// in the throwaway instance
+func (info InitInfo) VerifyBackup(ctx context.Context, cli client.Client, backup *apiv1.Backup) error {
+	contextLogger := log.FromContext(ctx)
+
+	cluster, err := info.loadCluster(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	verificationCluster, err := buildBackupVerificationCluster(cluster, backup)
+	if err != nil {
+		return err
+	}
+
+	envs, config, err := info.restoreBackupContent(ctx, cli, verificationCluster)
+	if err != nil {
+		return fmt.Errorf("while restoring the backup: %w", err)
+	}
+
+	if err := verifyBackupManifest(ctx, info.PgData); err != nil {
+		return err
+	}
+
+	if err := info.WriteInitialPostgresqlConf(ctx, verificationCluster); err != nil {
+		return err
+	}
+	if _, err := info.GetInstance().migratePostgresAutoConfFile(ctx); err != nil {
+		return err
+	}
+	if err := info.WriteRestoreHbaConf(ctx); err != nil {
+		return err
+	}
+	if err := info.writeCustomRestoreWalConfig(verificationCluster, config); err != nil {
+		return err
+	}
+
+	instance := info.GetInstance()
+	instance.Env = envs
+	if err := instance.VerifyPgDataCoherence(ctx); err != nil {
+		return err
+	}
+
+	return instance.WithActiveInstance(func() error {
+		db, err := instance.GetSuperUserDB()
+		if err != nil {
+			return err
+		}
+
+		if err := waitUntilRecoveryFinishes(db); err != nil {
+			return fmt.Errorf("while waiting for PostgreSQL to reach a consistent state: %w", err)
+		}
+		contextLogger.Info("The backup has been restored, the instance reached a consistent state")
+
+		var verification *apiv1.BackupVerificationConfiguration
+		if cluster.Spec.Backup != nil {
+			verification = cluster.Spec.Backup.Verification
+		}
+		return runBackupVerificationSmokeQuery(ctx, instance, verification)
+	})
+}
+
+// buildBackupVerificationCluster returns a copy of the passed cluster,
+// bootstrapped by recovering the passed backup up to the first consistent
+// point, without any backup or replica cluster configuration
+func buildBackupVerificationCluster(cluster *apiv1.Cluster, backup *apiv1.Backup) (*apiv1.Cluster, error) {
+	result := cluster.DeepCopy()
+	result.Spec.Backup = nil
+	result.Spec.ReplicaCluster = nil
+
+	recovery := &apiv1.BootstrapRecovery{
+		RecoveryTarget: &apiv1.RecoveryTarget{
+			TargetImmediate: ptr.To(true),
+		},
+	}
+
+	switch backup.Spec.Method {
+	case apiv1.BackupMethodBarmanObjectStore:
+		recovery.Backup = &apiv1.BackupSource{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: backup.Name},
+		}
+
+	case apiv1.BackupMethodPgBackRest:
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.PgBackRest == nil {
+			return nil, errors.New("missing pgBackRest configuration in the cluster")
+		}
+		configuration := cluster.Spec.Backup.PgBackRest.DeepCopy()
+		configuration.Stanza = backup.Status.ServerName
+		result.Spec.ExternalClusters = append(result.Spec.ExternalClusters, apiv1.ExternalCluster{
+			Name:       backupVerificationSourceName,
+			PgBackRest: configuration,
+		})
+		recovery.Source = backupVerificationSourceName
+		recovery.RecoveryTarget.BackupID = backup.Status.BackupID
+
+	default:
+		return nil, fmt.Errorf("backups taken with the %q method cannot be verified", backup.Spec.Method)
+	}
+
+	result.Spec.Bootstrap = &apiv1.BootstrapConfiguration{Recovery: recovery}
+	return result, nil
+}
+
+// verifyBackupManifest checks the restored data directory against the
+// backup manifest with pg_verifybackup. Backups not containing a manifest
+// are only verified by recovering them
+func verifyBackupManifest(ctx context.Context, pgData string) error {
+	contextLogger := log.FromContext(ctx)
+
+	manifestExists, err := fileutils.FileExists(path.Join(pgData, backupManifestFile))
+	if err != nil {
+		return err
+	}
+	if !manifestExists {
+		contextLogger.Info("The backup doesn't contain a backup manifest, skipping pg_verifybackup")
+		return nil
+	}
+
+	// WAL files are not part of the restored data directory, as they are
+	// fetched from the archive during the recovery
+	verifyBackupCmd := exec.Command(pgVerifyBackupName, "--no-parse-wal", pgData) // #nosec
+	verifyBackupCmd.Env = os.Environ()
+	if err := execlog.RunStreaming(verifyBackupCmd, pgVerifyBackupName); err != nil {
+		return fmt.Errorf("while verifying the backup manifest: %w", err)
+	}
+
+	contextLogger.Info("The backup has been checked against its manifest")
+	return nil
+}
+
+// runBackupVerificationSmokeQuery runs the smoke query, if configured,
+// in the throwaway instance
+func runBackupVerificationSmokeQuery(
+	ctx context.Context,
+	instance *Instance,
+	verification *apiv1.BackupVerificationConfiguration,
+) error {
+	if verification == nil || verification.SmokeQuery == "" {
+		return nil
+	}
+
+	database := verification.Database
+	if database == "" {
+		database = defaultBackupVerificationDatabase
+	}
+
+	db, err := instance.ConnectionPool().Connection(database)
+	if err != nil {
+		return fmt.Errorf("while connecting to the %q database: %w", database, err)
+	}
+
+	if _, err := db.ExecContext(ctx, verification.SmokeQuery); err != nil {
+		return fmt.Errorf("while running the smoke query: %w", err)
+	}
+
+	log.FromContext(ctx).Info("The smoke query completed successfully", "database", database)
+	return nil
+}
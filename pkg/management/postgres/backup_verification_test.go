@@ -0,0 +1,87 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup verification cluster", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					PgBackRest: &apiv1.PgBackRestConfiguration{
+						Repository: apiv1.PgBackRestRepository{Path: "/cluster-example"},
+					},
+				},
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{Source: "origin"},
+			},
+		}
+	})
+
+	It("recovers barman backups through the Backup object", func() {
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example"},
+			Spec:       apiv1.BackupSpec{Method: apiv1.BackupMethodBarmanObjectStore},
+		}
+
+		result, err := buildBackupVerificationCluster(cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Spec.Backup).To(BeNil())
+		Expect(result.Spec.ReplicaCluster).To(BeNil())
+		Expect(result.Spec.Bootstrap.Recovery.Backup.Name).To(Equal("backup-example"))
+		Expect(*result.Spec.Bootstrap.Recovery.RecoveryTarget.TargetImmediate).To(BeTrue())
+		Expect(cluster.Spec.Backup).ToNot(BeNil())
+	})
+
+	It("recovers pgBackRest backups from the repository of the cluster", func() {
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example"},
+			Spec:       apiv1.BackupSpec{Method: apiv1.BackupMethodPgBackRest},
+			Status: apiv1.BackupStatus{
+				ServerName: "main",
+				BackupID:   "20240101-120000F",
+			},
+		}
+
+		result, err := buildBackupVerificationCluster(cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		source := result.GetRecoverySourcePgBackRest()
+		Expect(source).ToNot(BeNil())
+		Expect(source.PgBackRest.Stanza).To(Equal("main"))
+		Expect(source.PgBackRest.Repository.Path).To(Equal("/cluster-example"))
+		Expect(result.Spec.Bootstrap.Recovery.RecoveryTarget.BackupID).To(Equal("20240101-120000F"))
+	})
+
+	It("refuses to verify volume snapshot backups", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{Method: apiv1.BackupMethodVolumeSnapshot},
+		}
+
+		_, err := buildBackupVerificationCluster(cluster, backup)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -27,6 +27,7 @@ import (
 
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/configfile"
@@ -89,7 +90,12 @@ func (instance *Instance) RefreshConfigurationFilesFromCluster(
 }
 
 // GeneratePostgresqlHBA generates the pg_hba.conf content with the LDAP configuration if configured.
-func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBindPassword string) (string, error) {
+// The address sets contain the client addresses referenced by the structured pg_hba rules
+func (instance *Instance) GeneratePostgresqlHBA(
+	cluster *apiv1.Cluster,
+	ldapBindPassword string,
+	addressSets HBAAddressSets,
+) (string, error) {
 	version, err := cluster.GetPostgresqlVersion()
 	if err != nil {
 		return "", err
@@ -107,19 +113,60 @@ func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBind
 		defaultAuthenticationMethod = "md5"
 	}
 
+	// The security profile only allows the authentication methods
+	// that can't be downgraded, requiring a client certificate
+	// in the verify-full mode
+	tlsMode := cluster.GetSecurityTLSMode()
+	defaultConnectionType := apiv1.PgHBAConnectionTypeHost
+	switch tlsMode {
+	case apiv1.SecurityTLSModeRequire:
+		defaultAuthenticationMethod = "scram-sha-256"
+		defaultConnectionType = apiv1.PgHBAConnectionTypeHostSSL
+	case apiv1.SecurityTLSModeVerifyFull:
+		defaultAuthenticationMethod = fmt.Sprintf("cert map=%s", postgres.CertificateIdentMap)
+		defaultConnectionType = apiv1.PgHBAConnectionTypeHostSSL
+	}
+
+	structuredRules, err := buildStructuredHBARules(
+		cluster.Spec.PostgresConfiguration.PgHBARules,
+		addressSets,
+		cluster.Spec.PostgresConfiguration.OAuth,
+		defaultConnectionType)
+	if err != nil {
+		return "", err
+	}
+
+	userRules := make([]string, 0, len(cluster.Spec.PostgresConfiguration.PgHBA)+len(structuredRules))
+	userRules = append(userRules, cluster.Spec.PostgresConfiguration.PgHBA...)
+	userRules = append(userRules, structuredRules...)
+	ldapConfigString := buildLDAPConfigString(cluster, ldapBindPassword)
+
+	if tlsMode == apiv1.SecurityTLSModeVerifyFull {
+		for idx := range userRules {
+			userRules[idx] = requireClientCertificate(userRules[idx])
+		}
+		ldapConfigString = requireClientCertificate(ldapConfigString)
+	}
+
 	return postgres.CreateHBARules(
-		cluster.Spec.PostgresConfiguration.PgHBA,
+		userRules,
 		defaultAuthenticationMethod,
-		buildLDAPConfigString(cluster, ldapBindPassword))
+		ldapConfigString,
+		tlsMode != "")
 }
 
 // RefreshPGHBA generates and writes down the pg_hba.conf file
-func (instance *Instance) RefreshPGHBA(ctx context.Context, cluster *apiv1.Cluster, ldapBindPassword string) (
+func (instance *Instance) RefreshPGHBA(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	ldapBindPassword string,
+	addressSets HBAAddressSets,
+) (
 	postgresHBAChanged bool,
 	err error,
 ) {
 	// Generate pg_hba.conf file
-	pgHBAContent, err := instance.GeneratePostgresqlHBA(cluster, ldapBindPassword)
+	pgHBAContent, err := instance.GeneratePostgresqlHBA(cluster, ldapBindPassword, addressSets)
 	if err != nil {
 		return false, nil
 	}
@@ -145,8 +192,13 @@ func buildLDAPConfigString(cluster *apiv1.Cluster, ldapBindPassword string) stri
 	}
 	ldapConfig := cluster.Spec.PostgresConfiguration.LDAP
 
-	ldapConfigString += fmt.Sprintf("host all all 0.0.0.0/0 ldap ldapserver=%s",
-		quoteHbaLiteral(ldapConfig.Server))
+	connectionType := apiv1.PgHBAConnectionTypeHost
+	if cluster.GetSecurityTLSMode() != "" {
+		connectionType = apiv1.PgHBAConnectionTypeHostSSL
+	}
+
+	ldapConfigString += fmt.Sprintf("%s all all 0.0.0.0/0 ldap ldapserver=%s",
+		connectionType, quoteHbaLiteral(ldapConfig.Server))
 
 	if ldapConfig.Port != 0 {
 		ldapConfigString += fmt.Sprintf(" ldapport=%d", ldapConfig.Port)
@@ -205,24 +257,36 @@ func quoteHbaLiteral(literal string) string {
 }
 
 // generatePostgresqlIdent generates the pg_ident.conf content given
-// a set of additional pg_ident lines that is usually taken from the
-// Cluster configuration
-func (instance *Instance) generatePostgresqlIdent(additionalLines []string) (string, error) {
+// the Cluster configuration. When the cluster is nil, only the
+// fixed rules are generated
+func (instance *Instance) generatePostgresqlIdent(cluster *apiv1.Cluster) (string, error) {
+	var (
+		additionalLines []string
+		certificateMap  string
+	)
+	if cluster != nil {
+		additionalLines = cluster.Spec.PostgresConfiguration.PgIdent
+		if cluster.GetSecurityTLSMode() == apiv1.SecurityTLSModeVerifyFull {
+			certificateMap = postgres.CertificateIdentMap
+		}
+	}
+
 	return postgres.CreateIdentRules(
 		additionalLines,
 		getCurrentUserOrDefaultToInsecureMapping(),
+		certificateMap,
 	)
 }
 
 // RefreshPGIdent generates and writes down the pg_ident.conf file given
-// a set of additional pg_ident lines that is usually taken from the
-// Cluster configuration
+// the Cluster configuration. When the cluster is nil, only the fixed
+// rules are written
 func (instance *Instance) RefreshPGIdent(
 	ctx context.Context,
-	additionalLines []string,
+	cluster *apiv1.Cluster,
 ) (postgresIdentChanged bool, err error) {
 	// Generate pg_ident.conf file
-	pgIdentContent, err := instance.generatePostgresqlIdent(additionalLines)
+	pgIdentContent, err := instance.generatePostgresqlIdent(cluster)
 	if err != nil {
 		return false, nil
 	}
@@ -386,11 +450,12 @@ func createPostgresqlConfiguration(cluster *apiv1.Cluster, preserveUserSettings
 	info := postgres.ConfigurationInfo{
 		Settings:                         postgres.CnpgConfigurationSettings,
 		Version:                          fromVersion,
-		UserSettings:                     cluster.Spec.PostgresConfiguration.Parameters,
+		UserSettings:                     cluster.GetPostgresParameters(),
 		IncludingSharedPreloadLibraries:  true,
 		AdditionalSharedPreloadLibraries: cluster.Spec.PostgresConfiguration.AdditionalLibraries,
 		IsReplicaCluster:                 cluster.IsReplica(),
 		IsWalArchivingDisabled:           utils.IsWalArchivingDisabled(&cluster.ObjectMeta),
+		IsWALArchivedByStandby:           cluster.IsWALArchivedByStandby(),
 		IsAlterSystemEnabled:             cluster.Spec.PostgresConfiguration.EnableAlterSystem,
 		SynchronousStandbyNames:          replication.GetSynchronousStandbyNames(cluster),
 	}
@@ -412,15 +477,78 @@ func createPostgresqlConfiguration(cluster *apiv1.Cluster, preserveUserSettings
 	}
 	sort.Strings(info.TemporaryTablespaces)
 
+	// Set the synchronization of the logical replication slots
+	if cluster.IsLogicalSlotsSynchronizationEnabled() {
+		info.SynchronizeLogicalSlots = true
+		info.SynchronizedLogicalSlotNames = cluster.Spec.ReplicationSlots.SynchronizeLogicalSlots.SlotNames
+		info.SynchronizedStandbySlots = replication.GetSynchronizedStandbySlots(cluster)
+	}
+
 	// Setup minimum replay delay if we're on a replica cluster
 	if cluster.IsReplica() && cluster.Spec.ReplicaCluster.MinApplyDelay != nil {
 		info.RecoveryMinApplyDelay = cluster.Spec.ReplicaCluster.MinApplyDelay.Duration
 	}
 
-	conf, sha256 := postgres.CreatePostgresqlConfFile(postgres.CreatePostgresqlConfiguration(info))
+	configuration := postgres.CreatePostgresqlConfiguration(info)
+	setOAuthConfiguration(cluster, configuration)
+	setReplicationSlotsInvalidation(cluster, fromVersion, configuration)
+
+	// The data encryption key is unwrapped by the instance manager
+	if cluster.Spec.PostgresConfiguration.TDE != nil {
+		configuration.OverwriteConfig("data_encryption_key_unwrap_command", postgres.TDEUnwrapCommand)
+	}
+
+	conf, sha256 := postgres.CreatePostgresqlConfFile(configuration)
 	return conf, sha256, nil
 }
 
+// setOAuthConfiguration loads the library validating the OAuth tokens,
+// passing it the expected audience
+func setOAuthConfiguration(cluster *apiv1.Cluster, configuration *postgres.PgConfiguration) {
+	oauthConfig := cluster.Spec.PostgresConfiguration.OAuth
+	if oauthConfig == nil {
+		return
+	}
+
+	library := oauthConfig.Validator.Library
+	if oauthConfig.Validator.Image != "" {
+		library = path.Join(postgres.OAuthValidatorDirectory, "lib", library)
+	}
+	configuration.OverwriteConfig("oauth_validator_libraries", library)
+
+	if oauthConfig.Audience != "" {
+		configuration.OverwriteConfig(oauthConfig.Validator.Library+".audience", oauthConfig.Audience)
+	}
+}
+
+// setReplicationSlotsInvalidation makes PostgreSQL invalidate the
+// replication slots exceeding the limits of the safeguard, when requested
+func setReplicationSlotsInvalidation(
+	cluster *apiv1.Cluster,
+	pgVersion version.Data,
+	configuration *postgres.PgConfiguration,
+) {
+	if cluster.Spec.ReplicationSlots == nil {
+		return
+	}
+
+	safeguard := cluster.Spec.ReplicationSlots.Safeguard
+	if !safeguard.GetEnabled() || safeguard.GetAction() != apiv1.ReplicationSlotsSafeguardActionInvalidate {
+		return
+	}
+
+	if maxRetainedWALBytes := safeguard.GetMaxRetainedWALBytes(); maxRetainedWALBytes > 0 {
+		configuration.OverwriteConfig("max_slot_wal_keep_size",
+			fmt.Sprintf("%dMB", max(1, maxRetainedWALBytes/(1024*1024))))
+	}
+
+	// The invalidation of the idle replication slots is available since PostgreSQL 18
+	if maxInactiveTime := safeguard.GetMaxInactiveTime(); maxInactiveTime > 0 && pgVersion.Major() >= 18 {
+		configuration.OverwriteConfig("idle_replication_slot_timeout",
+			fmt.Sprintf("%dmin", max(1, int64(maxInactiveTime.Minutes()))))
+	}
+}
+
 // configurePostgresForImport configures Postgres to be optimized for the firt import
 // process, by writing dedicated options the override.conf file just for this phase
 func configurePostgresForImport(ctx context.Context, pgData string) (changed bool, err error) {
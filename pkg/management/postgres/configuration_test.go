@@ -254,3 +254,85 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(config).ToNot(ContainSubstring("recovery_min_apply_delay"))
 	})
 })
+
+var _ = Describe("OAuth validator configuration", func() {
+	newCluster := func(image string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "configurationTest",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					OAuth: &apiv1.OAuthConfig{
+						Issuer:   "https://idp.example.com",
+						Scope:    "openid",
+						Audience: "postgres",
+						Validator: apiv1.OAuthValidator{
+							Library: "validator",
+							Image:   image,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	It("loads the validator library installed in the operand image", func() {
+		config, _, err := createPostgresqlConfiguration(newCluster(""), true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("oauth_validator_libraries = 'validator'"))
+		Expect(config).To(ContainSubstring("validator.audience = 'postgres'"))
+	})
+
+	It("loads the validator library from the mounted image", func() {
+		config, _, err := createPostgresqlConfiguration(newCluster("example.com/validator:1.0"), true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("oauth_validator_libraries = '/oauth-validator/lib/validator'"))
+	})
+})
+
+var _ = Describe("replication slots invalidation", func() {
+	newCluster := func(imageName string, action apiv1.ReplicationSlotsSafeguardAction) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "configurationTest",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: imageName,
+				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+					Safeguard: &apiv1.ReplicationSlotsSafeguardConfiguration{
+						Enabled:            true,
+						MaxRetainedWALSize: "10Gi",
+						MaxInactiveTime:    &metav1.Duration{Duration: 2 * time.Hour},
+						Action:             action,
+					},
+				},
+			},
+		}
+	}
+
+	It("configures PostgreSQL to invalidate the replication slots", func() {
+		config, _, err := createPostgresqlConfiguration(
+			newCluster("postgres:18", apiv1.ReplicationSlotsSafeguardActionInvalidate), true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("max_slot_wal_keep_size = '10240MB'"))
+		Expect(config).To(ContainSubstring("idle_replication_slot_timeout = '120min'"))
+	})
+
+	It("doesn't invalidate the idle replication slots before PostgreSQL 18", func() {
+		config, _, err := createPostgresqlConfiguration(
+			newCluster("postgres:17", apiv1.ReplicationSlotsSafeguardActionInvalidate), true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("max_slot_wal_keep_size = '10240MB'"))
+		Expect(config).ToNot(ContainSubstring("idle_replication_slot_timeout"))
+	})
+
+	It("doesn't change the configuration with the other actions", func() {
+		config, _, err := createPostgresqlConfiguration(
+			newCluster("postgres:18", apiv1.ReplicationSlotsSafeguardActionDrop), true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config).ToNot(ContainSubstring("max_slot_wal_keep_size"))
+	})
+})
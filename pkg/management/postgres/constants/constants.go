@@ -43,6 +43,10 @@ const (
 	// TablespaceMapFile holds the content of TablespaceMapFile. Used during a restore from a hot backup.
 	TablespaceMapFile = "tablespace_map"
 
+	// DataEncryptionKeyFile is the file, relative to the PGDATA, where
+	// initdb stores the wrapped data encryption key when TDE is enabled
+	DataEncryptionKeyFile = "pg_encryption/key.bin"
+
 	// InitdbName is the name of the command to initialize a PostgreSQL database
 	InitdbName = "initdb"
 
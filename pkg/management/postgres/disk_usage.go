@@ -0,0 +1,96 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"os"
+	"path"
+	"syscall"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// getVolumesUsage gets the disk usage of the volumes mounted by the
+// instance: the PGDATA one, the WAL one and the ones of the tablespaces,
+// when present
+func getVolumesUsage(pgData, walVolumePath, tablespacesVolumePath string) []postgres.VolumeUsage {
+	result := make([]postgres.VolumeUsage, 0, 2)
+
+	if usage, err := getVolumeUsage(pgData); err == nil {
+		usage.Role = utils.PVCRolePgData
+		result = append(result, usage)
+	} else {
+		log.Debug("cannot get the disk usage of the PGDATA volume", "error", err)
+	}
+
+	if _, err := os.Stat(walVolumePath); err == nil {
+		if usage, err := getVolumeUsage(walVolumePath); err == nil {
+			usage.Role = utils.PVCRolePgWal
+			result = append(result, usage)
+		} else {
+			log.Debug("cannot get the disk usage of the WAL volume", "error", err)
+		}
+	}
+
+	entries, err := os.ReadDir(tablespacesVolumePath)
+	if err != nil {
+		return result
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		usage, err := getVolumeUsage(path.Join(tablespacesVolumePath, entry.Name()))
+		if err != nil {
+			log.Debug("cannot get the disk usage of the tablespace volume",
+				"tablespace", entry.Name(), "error", err)
+			continue
+		}
+		usage.Role = utils.PVCRolePgTablespace
+		usage.TablespaceName = entry.Name()
+		result = append(result, usage)
+	}
+
+	return result
+}
+
+// GetWALVolumeUsage gets the disk usage of the volume containing the WAL
+// files, which is the PGDATA one when there is no dedicated WAL volume
+func (instance *Instance) GetWALVolumeUsage() (postgres.VolumeUsage, error) {
+	if _, err := os.Stat(specs.PgWalVolumePath); err == nil {
+		return getVolumeUsage(specs.PgWalVolumePath)
+	}
+	return getVolumeUsage(instance.PgData)
+}
+
+// getVolumeUsage gets the disk usage of the file system containing
+// the passed path
+func getVolumeUsage(volumePath string) (postgres.VolumeUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(volumePath, &stat); err != nil {
+		return postgres.VolumeUsage{}, err
+	}
+
+	return postgres.VolumeUsage{
+		TotalBytes:     stat.Blocks * uint64(stat.Bsize), // #nosec G115
+		AvailableBytes: stat.Bavail * uint64(stat.Bsize), // #nosec G115
+	}, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"os"
+	"path"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("volumes usage", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		Expect(os.MkdirAll(path.Join(tempDir, "data", "pgdata"), 0o700)).To(Succeed())
+	})
+
+	It("reports the PGDATA volume only, when the others are not mounted", func() {
+		usage := getVolumesUsage(
+			path.Join(tempDir, "data", "pgdata"),
+			path.Join(tempDir, "wal"),
+			path.Join(tempDir, "tablespaces"))
+		Expect(usage).To(HaveLen(1))
+		Expect(usage[0].Role).To(Equal(utils.PVCRolePgData))
+		Expect(usage[0].TotalBytes).To(BeNumerically(">", 0))
+		Expect(usage[0].AvailableBytes).To(BeNumerically("<=", usage[0].TotalBytes))
+	})
+
+	It("reports the WAL and the tablespaces volumes", func() {
+		Expect(os.MkdirAll(path.Join(tempDir, "wal"), 0o700)).To(Succeed())
+		Expect(os.MkdirAll(path.Join(tempDir, "tablespaces", "tbs1"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(path.Join(tempDir, "tablespaces", "file"), nil, 0o600)).To(Succeed())
+
+		usage := getVolumesUsage(
+			path.Join(tempDir, "data", "pgdata"),
+			path.Join(tempDir, "wal"),
+			path.Join(tempDir, "tablespaces"))
+		Expect(usage).To(HaveLen(3))
+		Expect(usage[1].Role).To(Equal(utils.PVCRolePgWal))
+		Expect(usage[2].Role).To(Equal(utils.PVCRolePgTablespace))
+		Expect(usage[2].TablespaceName).To(Equal("tbs1"))
+	})
+})
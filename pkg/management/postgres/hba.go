@@ -0,0 +1,184 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// HBAAddressSets contains the sets of client addresses referenced by the
+// structured pg_hba rules, indexed by ConfigMap name and key
+type HBAAddressSets map[string]map[string][]string
+
+// ParseHBAAddressSet parses the content of a ConfigMap key containing
+// a set of client addresses, one per line. Empty lines and the lines
+// starting with `#` are ignored. IP addresses are converted to the CIDR
+// matching only them. The whole set is rejected when any of the
+// addresses is not valid, as it would be written to pg_hba.conf
+func ParseHBAAddressSet(content string) ([]string, error) {
+	var result []string
+	for idx, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if address, err := netip.ParseAddr(line); err == nil {
+			line = netip.PrefixFrom(address, address.BitLen()).String()
+		}
+		if err := apiv1.ValidatePgHBAAddress(line); err != nil {
+			return nil, fmt.Errorf("invalid client address %q at line %d: %w", line, idx+1, err)
+		}
+		result = append(result, line)
+	}
+	return result, nil
+}
+
+// buildStructuredHBARules renders the structured pg_hba rules to
+// pg_hba.conf lines, generating one line for each client address.
+// The `oauth` rules default to the issuer and the scope of the
+// OAuth configuration of the cluster, and the rules without a type
+// match the passed default connection type
+func buildStructuredHBARules(
+	rules []apiv1.PgHBARule,
+	addressSets HBAAddressSets,
+	oauthConfig *apiv1.OAuthConfig,
+	defaultConnectionType apiv1.PgHBAConnectionType,
+) ([]string, error) {
+	var result []string
+	for idx, rule := range rules {
+		connectionType := rule.Type
+		if connectionType == "" {
+			connectionType = defaultConnectionType
+		}
+
+		options := rule.Options
+		if rule.Method == apiv1.PgHBAAuthMethodOAuth && oauthConfig != nil {
+			options = getOAuthHBAOptions(rule.Options, oauthConfig)
+		}
+
+		prefix := fmt.Sprintf("%s %s %s",
+			connectionType,
+			hbaKeywordList(rule.Databases),
+			hbaKeywordList(rule.Users))
+		suffix := string(rule.Method)
+		if renderedOptions := hbaOptions(options); renderedOptions != "" {
+			suffix += " " + renderedOptions
+		}
+
+		if connectionType == apiv1.PgHBAConnectionTypeLocal {
+			result = append(result, fmt.Sprintf("%s %s", prefix, suffix))
+			continue
+		}
+
+		addresses, err := getHBARuleAddresses(rule, addressSets)
+		if err != nil {
+			return nil, fmt.Errorf("while rendering pg_hba rule %d: %w", idx, err)
+		}
+		for _, address := range addresses {
+			result = append(result, fmt.Sprintf("%s %s %s", prefix, address, suffix))
+		}
+	}
+
+	return result, nil
+}
+
+// getOAuthHBAOptions adds the issuer and the scope of the OAuth
+// configuration to the options of an `oauth` rule, unless specified
+func getOAuthHBAOptions(options map[string]string, oauthConfig *apiv1.OAuthConfig) map[string]string {
+	result := make(map[string]string, len(options)+2)
+	result["issuer"] = oauthConfig.Issuer
+	result["scope"] = oauthConfig.Scope
+	for key, value := range options {
+		result[key] = value
+	}
+	return result
+}
+
+// getHBARuleAddresses gets the client addresses matched by a pg_hba
+// rule, including the ones referenced from ConfigMaps
+func getHBARuleAddresses(rule apiv1.PgHBARule, addressSets HBAAddressSets) ([]string, error) {
+	addresses := make([]string, 0, len(rule.Addresses))
+	addresses = append(addresses, rule.Addresses...)
+	for _, ref := range rule.AddressesFrom {
+		set, ok := addressSets[ref.Name][ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("missing address set %s/%s", ref.Name, ref.Key)
+		}
+		addresses = append(addresses, set...)
+	}
+
+	if len(rule.Addresses) == 0 && len(rule.AddressesFrom) == 0 {
+		addresses = append(addresses, "all")
+	}
+
+	return addresses, nil
+}
+
+// hbaKeywordList renders a list of databases or users as a pg_hba
+// field, defaulting to `all`
+func hbaKeywordList(values []string) string {
+	if len(values) == 0 {
+		return "all"
+	}
+
+	quoted := make([]string, len(values))
+	for idx, value := range values {
+		if strings.ContainsAny(value, " \t,\"#") {
+			quoted[idx] = quoteHbaLiteral(value)
+		} else {
+			quoted[idx] = value
+		}
+	}
+	return strings.Join(quoted, ",")
+}
+
+// hbaOptions renders the authentication options of a pg_hba rule,
+// sorted by name to keep the generated file stable
+func hbaOptions(options map[string]string) string {
+	keys := slices.Sorted(maps.Keys(options))
+	rendered := make([]string, len(keys))
+	for idx, key := range keys {
+		rendered[idx] = fmt.Sprintf("%s=%s", key, quoteHbaLiteral(options[key]))
+	}
+	return strings.Join(rendered, " ")
+}
+
+// requireClientCertificate adds the `clientcert=verify-full` option to a
+// pg_hba line matching the TLS connections, unless the line already
+// uses the certificate authentication or sets the `clientcert` option
+func requireClientCertificate(line string) string {
+	content, comment, hasComment := strings.Cut(line, "#")
+	fields := strings.Fields(content)
+	if len(fields) < 5 || fields[0] != string(apiv1.PgHBAConnectionTypeHostSSL) ||
+		strings.Contains(content, "clientcert=") ||
+		slices.Contains(fields[4:min(6, len(fields))], string(apiv1.PgHBAAuthMethodCert)) {
+		return line
+	}
+
+	result := strings.TrimRight(content, " \t") + " clientcert=verify-full"
+	if hasComment {
+		result += " #" + comment
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("structured pg_hba rules", func() {
+	officeNetworks := apiv1.ConfigMapKeySelector{
+		LocalObjectReference: apiv1.LocalObjectReference{Name: "office"},
+		Key:                  "cidrs",
+	}
+	officeCIDRs, err := ParseHBAAddressSet("# Milan\n10.1.0.0/16\n\n  10.2.0.0/16  \n")
+	Expect(err).ToNot(HaveOccurred())
+	addressSets := HBAAddressSets{
+		"office": {
+			"cidrs": officeCIDRs,
+		},
+	}
+
+	It("parses the address sets skipping comments and empty lines", func() {
+		Expect(addressSets["office"]["cidrs"]).To(Equal([]string{"10.1.0.0/16", "10.2.0.0/16"}))
+	})
+
+	It("accepts host names and converts the IP addresses to CIDRs", func() {
+		set, err := ParseHBAAddressSet("db.example.com\n.example.org\n10.0.0.1\nfd00::1\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(set).To(Equal([]string{"db.example.com", ".example.org", "10.0.0.1/32", "fd00::1/128"}))
+	})
+
+	It("rejects the whole address set when an entry is not valid", func() {
+		for _, content := range []string{
+			"10.1.0.0/16\n10.0.0.0/8 trust",
+			"10.1.0.0/16\nall all 0.0.0.0/0 trust",
+			"10.1.0.0/16\n10.2.0.0/16 # office",
+			"10.1.0.0/33",
+			"host_name",
+		} {
+			_, err := ParseHBAAddressSet(content)
+			Expect(err).To(HaveOccurred(), content)
+		}
+	})
+
+	It("defaults to every database, user and address", func() {
+		rules, err := buildStructuredHBARules(
+			[]apiv1.PgHBARule{{Method: "scram-sha-256"}}, nil, nil, apiv1.PgHBAConnectionTypeHost)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(Equal([]string{"host all all all scram-sha-256"}))
+	})
+
+	It("generates a line for each client address", func() {
+		rules, err := buildStructuredHBARules([]apiv1.PgHBARule{
+			{
+				Type:          apiv1.PgHBAConnectionTypeHostSSL,
+				Databases:     []string{"app", "reports"},
+				Users:         []string{"+readers", "app user"},
+				Addresses:     []string{"192.168.0.0/24"},
+				AddressesFrom: []apiv1.ConfigMapKeySelector{officeNetworks},
+				Method:        apiv1.PgHBAAuthMethodRADIUS,
+				Options: map[string]string{
+					"radiussecrets": "secret",
+					"radiusservers": "radius.example.com",
+				},
+			},
+		}, addressSets, nil, apiv1.PgHBAConnectionTypeHost)
+		Expect(err).ToNot(HaveOccurred())
+
+		suffix := `radius radiussecrets="secret" radiusservers="radius.example.com"`
+		Expect(rules).To(Equal([]string{
+			`hostssl app,reports +readers,"app user" 192.168.0.0/24 ` + suffix,
+			`hostssl app,reports +readers,"app user" 10.1.0.0/16 ` + suffix,
+			`hostssl app,reports +readers,"app user" 10.2.0.0/16 ` + suffix,
+		}))
+	})
+
+	It("doesn't add client addresses to local rules", func() {
+		rules, err := buildStructuredHBARules([]apiv1.PgHBARule{
+			{Type: apiv1.PgHBAConnectionTypeLocal, Users: []string{"postgres"}, Method: "peer"},
+		}, nil, nil, apiv1.PgHBAConnectionTypeHost)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(Equal([]string{"local all postgres peer"}))
+	})
+
+	It("defaults the issuer and the scope of the oauth rules", func() {
+		oauthConfig := &apiv1.OAuthConfig{
+			Issuer: "https://idp.example.com",
+			Scope:  "openid postgres",
+		}
+		rules, err := buildStructuredHBARules([]apiv1.PgHBARule{
+			{Method: apiv1.PgHBAAuthMethodOAuth, Options: map[string]string{"map": "oauth"}},
+			{Method: apiv1.PgHBAAuthMethodOAuth, Options: map[string]string{"scope": "reports"}},
+		}, nil, oauthConfig, apiv1.PgHBAConnectionTypeHost)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(Equal([]string{
+			`host all all all oauth issuer="https://idp.example.com" map="oauth" scope="openid postgres"`,
+			`host all all all oauth issuer="https://idp.example.com" scope="reports"`,
+		}))
+	})
+
+	It("defaults to the connection type required by the security profile", func() {
+		rules, err := buildStructuredHBARules(
+			[]apiv1.PgHBARule{{Method: "scram-sha-256"}}, nil, nil, apiv1.PgHBAConnectionTypeHostSSL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(Equal([]string{"hostssl all all all scram-sha-256"}))
+	})
+
+	It("requires a client certificate on the TLS connections", func() {
+		Expect(requireClientCertificate("hostssl all all 10.0.0.0/8 scram-sha-256")).To(
+			Equal("hostssl all all 10.0.0.0/8 scram-sha-256 clientcert=verify-full"))
+		Expect(requireClientCertificate("hostssl all all 10.0.0.0 255.0.0.0 ldap ldapserver=ldap # office")).To(
+			Equal("hostssl all all 10.0.0.0 255.0.0.0 ldap ldapserver=ldap clientcert=verify-full # office"))
+		Expect(requireClientCertificate("hostssl all all all cert")).To(Equal("hostssl all all all cert"))
+		Expect(requireClientCertificate("hostssl all all all md5 clientcert=verify-ca")).To(
+			Equal("hostssl all all all md5 clientcert=verify-ca"))
+		Expect(requireClientCertificate("local all all peer")).To(Equal("local all all peer"))
+	})
+
+	It("fails when an address set is missing", func() {
+		_, err := buildStructuredHBARules([]apiv1.PgHBARule{
+			{AddressesFrom: []apiv1.ConfigMapKeySelector{officeNetworks}, Method: "md5"},
+		}, nil, nil, apiv1.PgHBAConnectionTypeHost)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -44,6 +44,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logicalimport"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 )
 
@@ -92,6 +93,10 @@ type InitInfo struct {
 	// Whether it is a temporary instance that will never contain real data.
 	Temporary bool
 
+	// Whether the data directory is encrypted with TDE, using the
+	// instance manager to wrap the data encryption key
+	DataEncryption bool
+
 	// PostInitApplicationSQLRefsFolder is the folder which contains a bunch
 	// of SQL files to be executed inside the application database right after
 	// having configured a new instance
@@ -231,6 +236,9 @@ func (info InitInfo) CreateDataDirectory() error {
 	if info.PgWal != "" {
 		options = append(options, "--waldir", info.PgWal)
 	}
+	if info.DataEncryption {
+		options = append(options, "--data-encryption")
+	}
 	// Add custom initdb options from the user
 	options = append(options, info.InitDBOptions...)
 
@@ -244,6 +252,11 @@ func (info InitInfo) CreateDataDirectory() error {
 	_ = compatibility.Umask(0o077)
 
 	initdbCmd := exec.Command(constants.InitdbName, options...) // #nosec
+	if info.DataEncryption {
+		initdbCmd.Env = append(os.Environ(),
+			"PGDATAKEYWRAPCMD="+postgresSpec.TDEWrapCommand,
+			"PGDATAKEYUNWRAPCMD="+postgresSpec.TDEUnwrapCommand)
+	}
 	err := execlog.RunBuffering(initdbCmd, constants.InitdbName)
 	if err != nil {
 		return fmt.Errorf("error while creating the PostgreSQL instance: %w", err)
@@ -447,6 +460,13 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 		return err
 	}
 
+	if cluster.Spec.PostgresConfiguration.TDE != nil {
+		if err := ReconcileTDEKeyProvider(ctx, typedClient, cluster, info.PgData); err != nil {
+			return err
+		}
+		info.DataEncryption = true
+	}
+
 	err = info.CreateDataDirectory()
 	if err != nil {
 		return err
@@ -454,6 +474,15 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 
 	instance := info.GetInstance()
 
+	// A migration is bootstrapped importing the schema of
+	// the databases to be migrated
+	if cluster.IsBootstrappedWithMigration() {
+		cluster = cluster.DeepCopy()
+		cluster.Spec.Bootstrap = &apiv1.BootstrapConfiguration{
+			InitDB: cluster.Spec.Bootstrap.Migration.GetImportBootstrap(),
+		}
+	}
+
 	// Detect an initdb bootstrap with import
 	isImportBootstrap := cluster.Spec.Bootstrap != nil &&
 		cluster.Spec.Bootstrap.InitDB != nil &&
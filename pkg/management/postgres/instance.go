@@ -22,6 +22,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"math"
 	"os"
@@ -29,6 +30,7 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
@@ -806,8 +808,7 @@ func (instance *Instance) Demote(ctx context.Context, cluster *apiv1.Cluster) er
 	contextLogger := log.FromContext(ctx)
 
 	contextLogger.Info("Demoting instance", "pgpdata", instance.PgData)
-	slotName := cluster.GetSlotNameFromInstanceName(instance.GetPodName())
-	_, err := UpdateReplicaConfiguration(instance.PgData, instance.GetPrimaryConnInfo(), slotName)
+	_, err := instance.writeReplicaConfigurationForReplica(cluster)
 	return err
 }
 
@@ -1000,6 +1001,18 @@ func (instance *Instance) removePgControlFileBackup() error {
 // Rewind uses pg_rewind to align this data directory with the contents of the primary node.
 // If postgres major version is >= 13, add "--restore-target-wal" option
 func (instance *Instance) Rewind(ctx context.Context, postgresVersion version.Data) error {
+	primaryConnInfo := instance.GetPrimaryConnInfo()
+	return instance.RewindFromServer(ctx, postgresVersion, primaryConnInfo+" dbname=postgres")
+}
+
+// RewindFromServer uses pg_rewind to align this data directory with the contents
+// of the server reachable with the passed connection string.
+// If postgres major version is >= 13, add "--restore-target-wal" option
+func (instance *Instance) RewindFromServer(
+	ctx context.Context,
+	postgresVersion version.Data,
+	sourceServer string,
+) error {
 	contextLogger := log.FromContext(ctx)
 
 	// Signal the liveness probe that we are running pg_rewind before starting postgres
@@ -1010,10 +1023,9 @@ func (instance *Instance) Rewind(ctx context.Context, postgresVersion version.Da
 
 	instance.LogPgControldata(ctx, "before pg_rewind")
 
-	primaryConnInfo := instance.GetPrimaryConnInfo()
 	options := []string{
 		"-P",
-		"--source-server", primaryConnInfo + " dbname=postgres",
+		"--source-server", sourceServer,
 		"--target-pgdata", instance.PgData,
 	}
 
@@ -1035,9 +1047,24 @@ func (instance *Instance) Rewind(ctx context.Context, postgresVersion version.Da
 
 	pgRewindCmd := exec.Command(pgRewindName, options...) // #nosec
 	pgRewindCmd.Env = instance.Env
-	err = execlog.RunStreaming(pgRewindCmd, pgRewindName)
+	output := &pgRewindOutput{}
+	streamingCmd, err := execlog.RunStreamingNoWaitWithWriter(
+		pgRewindCmd,
+		pgRewindName,
+		&execlog.LogWriter{Logger: log.WithName(pgRewindName).WithValues(execlog.PipeKey, execlog.StdOut)},
+		io.MultiWriter(
+			&execlog.LogWriter{Logger: log.WithName(pgRewindName).WithValues(execlog.PipeKey, execlog.StdErr)},
+			output,
+		),
+	)
+	if err == nil {
+		err = streamingCmd.Wait()
+	}
 	if err != nil {
 		contextLogger.Error(err, "Failed to execute pg_rewind", "options", options)
+		if reason := output.rewindNotPossibleReason(); reason != "" {
+			return fmt.Errorf("error executing pg_rewind: %w (%w: %s)", err, ErrRewindNotPossible, reason)
+		}
 		return fmt.Errorf("error executing pg_rewind: %w", err)
 	}
 
@@ -1050,6 +1077,66 @@ func (instance *Instance) Rewind(ctx context.Context, postgresVersion version.Da
 	return nil
 }
 
+// ErrRewindNotPossible is returned by pg_rewind when the data directory
+// can't be realigned with the source server, and needs to be cloned again
+var ErrRewindNotPossible = errors.New("pg_rewind cannot realign the data directory")
+
+// pgRewindNotPossibleMessages are the pg_rewind error messages meaning that
+// the data directory can't be realigned, whatever the number of attempts
+var pgRewindNotPossibleMessages = []string{
+	// The WAL files needed to find the point of divergence are gone
+	"could not find previous WAL record",
+	"could not read WAL record",
+	// The timeline histories don't share any common ancestor
+	"could not find common ancestor",
+	// Neither data checksums nor wal_log_hints are enabled
+	"needs to use either data checksums or",
+}
+
+// pgRewindOutput collects the lines written by pg_rewind on its
+// standard error
+type pgRewindOutput struct {
+	lines []string
+}
+
+// Write implements the io.Writer interface. It's called once per line
+func (o *pgRewindOutput) Write(p []byte) (int, error) {
+	o.lines = append(o.lines, string(p))
+	return len(p), nil
+}
+
+// rewindNotPossibleReason returns the line of the output of pg_rewind
+// meaning that it can't realign the data directory, if any
+func (o *pgRewindOutput) rewindNotPossibleReason() string {
+	for _, line := range o.lines {
+		for _, message := range pgRewindNotPossibleMessages {
+			if strings.Contains(line, message) {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// CanRewind checks whether pg_rewind can be used on the data directory,
+// which requires either the data checksums or wal_log_hints to be enabled
+func (instance *Instance) CanRewind() (bool, error) {
+	pgControlDataString, err := instance.GetPgControldata()
+	if err != nil {
+		return false, err
+	}
+
+	return isRewindPermitted(utils.ParsePgControldataOutput(pgControlDataString)), nil
+}
+
+// isRewindPermitted checks, using the parsed pg_controldata output, whether
+// the hint bits updates were WAL-logged, as required by pg_rewind
+func isRewindPermitted(pgControlData map[string]string) bool {
+	checksumVersion := pgControlData[utils.PgControlDataKeyDataPageChecksumVersion]
+	return (checksumVersion != "" && checksumVersion != "0") ||
+		pgControlData[utils.PgControlDataKeyWalLogHintsSetting] == "on"
+}
+
 // PgIsReady gets the status from the pg_isready command
 func PgIsReady() error {
 	// We just use the environment variables we already have
@@ -18,8 +18,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 
+	"github.com/cloudnative-pg/machinery/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -61,7 +64,15 @@ func (instance *Instance) RefreshReplicaConfiguration(
 
 func (instance *Instance) writeReplicaConfigurationForReplica(cluster *apiv1.Cluster) (changed bool, err error) {
 	slotName := cluster.GetSlotNameFromInstanceName(instance.GetPodName())
-	return UpdateReplicaConfiguration(instance.PgData, instance.GetPrimaryConnInfo(), slotName)
+	primaryConnInfo := instance.GetPrimaryConnInfo()
+
+	// The workers synchronizing the logical replication slots
+	// need to connect to a database of the primary instance
+	if cluster.IsLogicalSlotsSynchronizationEnabled() {
+		primaryConnInfo += " dbname=postgres"
+	}
+
+	return UpdateReplicaConfiguration(instance.PgData, primaryConnInfo, slotName)
 }
 
 func (instance *Instance) writeReplicaConfigurationForDesignatedPrimary(
@@ -69,11 +80,21 @@ func (instance *Instance) writeReplicaConfigurationForDesignatedPrimary(
 	cli client.Client,
 	cluster *apiv1.Cluster,
 ) (changed bool, err error) {
-	server, ok := cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
+	server, ok := cluster.ExternalCluster(cluster.GetReplicaSource())
 	if !ok {
 		return false, fmt.Errorf("missing external cluster")
 	}
 
+	// A synchronous replica cluster is identified by the source cluster
+	// using its name as application name
+	if applicationName := cluster.GetSynchronousReplicaApplicationName(); applicationName != "" {
+		server.ConnectionParameters = maps.Clone(server.ConnectionParameters)
+		if server.ConnectionParameters == nil {
+			server.ConnectionParameters = make(map[string]string)
+		}
+		server.ConnectionParameters["application_name"] = applicationName
+	}
+
 	connectionString, err := external.ConfigureConnectionToServer(
 		ctx, cli, instance.GetNamespaceName(), &server)
 	if err != nil {
@@ -82,3 +103,62 @@ func (instance *Instance) writeReplicaConfigurationForDesignatedPrimary(
 
 	return UpdateReplicaConfiguration(instance.PgData, connectionString, "")
 }
+
+// RealignDesignatedPrimary realigns the data directory of the designated
+// primary of a replica cluster with the source cluster, as requested by
+// the replica cluster timeline divergence policy. pg_rewind is a no-op
+// when the timelines have not diverged.
+// This function must be called while PostgreSQL is not running.
+func (instance *Instance) RealignDesignatedPrimary(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	walDir string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	policy := cluster.Spec.ReplicaCluster.GetOnTimelineDivergence()
+	if policy == apiv1.TimelineDivergencePolicyManual {
+		return nil
+	}
+
+	server, ok := cluster.ExternalCluster(cluster.GetReplicaSource())
+	if !ok {
+		return fmt.Errorf("missing external cluster")
+	}
+	if len(server.ConnectionParameters) == 0 {
+		contextLogger.Info("The source cluster can't be reached by streaming, skipping realignment",
+			"source", server.Name)
+		return nil
+	}
+
+	connectionString, err := external.ConfigureConnectionToServer(
+		ctx, cli, instance.GetNamespaceName(), &server)
+	if err != nil {
+		return err
+	}
+
+	pgVersion, err := cluster.GetPostgresqlVersion()
+	if err != nil {
+		return err
+	}
+
+	rewindErr := instance.RewindFromServer(ctx, pgVersion, connectionString)
+	if rewindErr == nil {
+		return nil
+	}
+	if policy != apiv1.TimelineDivergencePolicyReclone || !errors.Is(rewindErr, ErrRewindNotPossible) {
+		return fmt.Errorf("while executing pg_rewind against the source cluster: %w", rewindErr)
+	}
+
+	contextLogger.Warning("pg_rewind can't realign the designated primary, re-cloning it from the source cluster",
+		"source", server.Name,
+		"err", rewindErr)
+
+	if err := instance.RecloneFromServer(ctx, connectionString, walDir); err != nil {
+		return fmt.Errorf("while re-cloning the designated primary: %w", err)
+	}
+
+	_, err = instance.writeReplicaConfigurationForDesignatedPrimary(ctx, cli, cluster)
+	return err
+}
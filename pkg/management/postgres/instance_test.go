@@ -26,6 +26,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -263,3 +264,40 @@ var _ = Describe("ALTER SYSTEM enable and disable in PostgreSQL <17", func() {
 		Expect(info.Mode()).To(BeEquivalentTo(0o400))
 	})
 })
+
+var _ = Describe("pg_rewind requirements", func() {
+	It("permits pg_rewind when the data checksums are enabled", func() {
+		Expect(isRewindPermitted(map[string]string{
+			utils.PgControlDataKeyDataPageChecksumVersion: "1",
+			utils.PgControlDataKeyWalLogHintsSetting:      "off",
+		})).To(BeTrue())
+	})
+
+	It("permits pg_rewind when wal_log_hints is enabled", func() {
+		Expect(isRewindPermitted(map[string]string{
+			utils.PgControlDataKeyDataPageChecksumVersion: "0",
+			utils.PgControlDataKeyWalLogHintsSetting:      "on",
+		})).To(BeTrue())
+	})
+
+	It("doesn't permit pg_rewind otherwise", func() {
+		Expect(isRewindPermitted(map[string]string{
+			utils.PgControlDataKeyDataPageChecksumVersion: "0",
+			utils.PgControlDataKeyWalLogHintsSetting:      "off",
+		})).To(BeFalse())
+		Expect(isRewindPermitted(map[string]string{})).To(BeFalse())
+	})
+
+	It("detects the pg_rewind failures which can't be solved retrying", func() {
+		output := &pgRewindOutput{}
+		_, _ = output.Write([]byte("pg_rewind: servers diverged at WAL location 0/3000000 on timeline 1"))
+		_, _ = output.Write([]byte("pg_rewind: error: could not find previous WAL record at 0/2FFFFD8"))
+		Expect(output.rewindNotPossibleReason()).To(ContainSubstring("could not find previous WAL record"))
+	})
+
+	It("doesn't consider the other pg_rewind failures as permanent", func() {
+		output := &pgRewindOutput{}
+		_, _ = output.Write([]byte("pg_rewind: error: connection to server failed: Connection refused"))
+		Expect(output.rewindNotPossibleReason()).To(BeEmpty())
+	})
+})
@@ -42,6 +42,9 @@ type LogPipe struct {
 	fileName        string
 	record          CSVRecordParser
 	fieldsValidator FieldsValidator
+	observers       []RecordObserver
+	auditSink       AuditSink
+	forwarders      []RecordForwarder
 
 	initialized *concurrency.Executed
 	exited      *concurrency.Executed
@@ -65,6 +68,27 @@ func NewLogPipe() *LogPipe {
 	}
 }
 
+// WithObserver adds an observer receiving every PostgreSQL log record
+// read by the pipe
+func (p *LogPipe) WithObserver(observer RecordObserver) *LogPipe {
+	p.observers = append(p.observers, observer)
+	return p
+}
+
+// WithAuditSink sets the sink where the pgaudit records are shipped,
+// separately from the other PostgreSQL log records
+func (p *LogPipe) WithAuditSink(sink AuditSink) *LogPipe {
+	p.auditSink = sink
+	return p
+}
+
+// WithForwarder adds a forwarder receiving the PostgreSQL log records
+// written by the pipe
+func (p *LogPipe) WithForwarder(forwarder RecordForwarder) *LogPipe {
+	p.forwarders = append(p.forwarders, forwarder)
+	return p
+}
+
 // GetInitializedCondition returns the condition that can be checked in order to
 // be sure initialization has been done
 func (p *LogPipe) GetInitializedCondition() *concurrency.Executed {
@@ -148,7 +172,12 @@ func (p *LogPipe) collectLogsFromFile(ctx context.Context) error {
 	// the cancellation signal happened
 	go func() {
 		defer close(errChan)
-		errChan <- p.streamLogFromCSVFile(ctx, f, &LogRecordWriter{})
+		errChan <- p.streamLogFromCSVFile(ctx, f, &observedRecordWriter{
+			RecordWriter: &LogRecordWriter{},
+			observers:    p.observers,
+			auditSink:    p.auditSink,
+			forwarders:   p.forwarders,
+		})
 	}()
 	select {
 	case <-ctx.Done():
@@ -36,6 +36,32 @@ func (writer *SpyRecordWriter) Write(record NamedRecord) {
 	writer.records = append(writer.records, record)
 }
 
+// SpyAuditSink is an implementation of the AuditSink interface
+// keeping track of the shipped pgaudit records
+type SpyAuditSink struct {
+	shipping bool
+	records  []PgAuditRecord
+}
+
+// ShipAuditRecord implements the AuditSink interface
+func (sink *SpyAuditSink) ShipAuditRecord(record *PgAuditLoggingDecorator) bool {
+	if sink.shipping {
+		sink.records = append(sink.records, *record.Audit)
+	}
+	return sink.shipping
+}
+
+// SpyRecordForwarder is an implementation of the RecordForwarder
+// interface keeping track of the names of the forwarded records
+type SpyRecordForwarder struct {
+	names []string
+}
+
+// Forward implements the RecordForwarder interface
+func (forwarder *SpyRecordForwarder) Forward(record NamedRecord) {
+	forwarder.names = append(forwarder.names, record.GetName())
+}
+
 var _ = Describe("CSV file reader", func() {
 	When("given CSV logs from logging_collector", func() {
 		It("can read multiple CSV lines", func(ctx SpecContext) {
@@ -190,4 +216,58 @@ var _ = Describe("CSV file reader", func() {
 			Expect(spy.records).To(BeEmpty())
 		})
 	})
+
+	When("given an audit sink", func() {
+		readInput := func() string {
+			logs, err := os.ReadFile("testdata/two_lines.csv")
+			Expect(err).ToNot(HaveOccurred())
+			auditLogs, err := os.ReadFile("testdata/pgaudit.csv")
+			Expect(err).ToNot(HaveOccurred())
+			return string(logs) + string(auditLogs)
+		}
+
+		It("splits the pgaudit records from the other ones", func(ctx SpecContext) {
+			spy := SpyRecordWriter{}
+			sink := SpyAuditSink{shipping: true}
+			p := LogPipe{
+				record:          NewPgAuditLoggingDecorator(),
+				fieldsValidator: LogFieldValidator,
+			}
+			writer := &observedRecordWriter{RecordWriter: &spy, auditSink: &sink}
+			Expect(p.streamLogFromCSVFile(ctx, strings.NewReader(readInput()), writer)).To(Succeed())
+			Expect(spy.records).To(HaveLen(2))
+			Expect(sink.records).To(HaveLen(2))
+			Expect(sink.records[1].Statement).To(Equal("SELECT NOT pg_is_in_recovery()"))
+		})
+
+		It("writes the pgaudit records the sink doesn't ship", func(ctx SpecContext) {
+			spy := SpyRecordWriter{}
+			sink := SpyAuditSink{}
+			p := LogPipe{
+				record:          NewPgAuditLoggingDecorator(),
+				fieldsValidator: LogFieldValidator,
+			}
+			writer := &observedRecordWriter{RecordWriter: &spy, auditSink: &sink}
+			Expect(p.streamLogFromCSVFile(ctx, strings.NewReader(readInput()), writer)).To(Succeed())
+			Expect(spy.records).To(HaveLen(4))
+			Expect(sink.records).To(BeEmpty())
+		})
+
+		It("forwards the records not shipped by the audit sink", func(ctx SpecContext) {
+			spy := SpyRecordWriter{}
+			sink := SpyAuditSink{shipping: true}
+			forwarder := SpyRecordForwarder{}
+			p := LogPipe{
+				record:          NewPgAuditLoggingDecorator(),
+				fieldsValidator: LogFieldValidator,
+			}
+			writer := &observedRecordWriter{
+				RecordWriter: &spy,
+				auditSink:    &sink,
+				forwarders:   []RecordForwarder{&forwarder},
+			}
+			Expect(p.streamLogFromCSVFile(ctx, strings.NewReader(readInput()), writer)).To(Succeed())
+			Expect(forwarder.names).To(Equal([]string{"postgres", "postgres"}))
+		})
+	})
 })
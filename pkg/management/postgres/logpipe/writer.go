@@ -37,3 +37,69 @@ type LogRecordWriter struct{}
 func (writer *LogRecordWriter) Write(record NamedRecord) {
 	log.WithName(record.GetName()).Info(logRecordKey, logRecordKey, record)
 }
+
+// RecordObserver is implemented by the components reacting to the
+// PostgreSQL log records. The record is reused by the pipe after the
+// call, so its content must be copied to be retained
+type RecordObserver interface {
+	Observe(record *LoggingRecord)
+}
+
+// AuditSink is implemented by the destinations where the pgaudit
+// records are shipped. The record is reused by the pipe after the
+// call, so its content must be copied to be retained
+type AuditSink interface {
+	// ShipAuditRecord ships a pgaudit record, returning false when
+	// the record needs to be written to the instance manager logger
+	// together with the other PostgreSQL log records
+	ShipAuditRecord(record *PgAuditLoggingDecorator) bool
+}
+
+// RecordForwarder is implemented by the components forwarding the
+// PostgreSQL log records to external sinks. The record is reused by
+// the pipe after the call, so its content must be copied to be retained
+type RecordForwarder interface {
+	Forward(record NamedRecord)
+}
+
+// observedRecordWriter implements the `RecordWriter` interface passing
+// the record to the observers before writing it. The pgaudit records
+// are passed to the audit sink, if any, and split from the other ones,
+// which are passed to the forwarders too
+type observedRecordWriter struct {
+	RecordWriter
+	observers  []RecordObserver
+	auditSink  AuditSink
+	forwarders []RecordForwarder
+}
+
+// Write passes the PostgreSQL log record to the observers and then
+// forwards and writes it with the inner writer, unless it is a pgaudit
+// record shipped by the audit sink
+func (writer *observedRecordWriter) Write(record NamedRecord) {
+	var loggingRecord *LoggingRecord
+	var auditRecord *PgAuditLoggingDecorator
+	switch r := record.(type) {
+	case *LoggingRecord:
+		loggingRecord = r
+	case *PgAuditLoggingDecorator:
+		loggingRecord = r.LoggingRecord
+		auditRecord = r
+	}
+
+	if loggingRecord != nil {
+		for _, observer := range writer.observers {
+			observer.Observe(loggingRecord)
+		}
+	}
+
+	if auditRecord != nil && writer.auditSink != nil && writer.auditSink.ShipAuditRecord(auditRecord) {
+		return
+	}
+
+	for _, forwarder := range writer.forwarders {
+		forwarder.Forward(record)
+	}
+
+	writer.RecordWriter.Write(record)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+// maskingHelpersSQL creates the helpers available to the data masking SQL.
+// They are removed once the data has been masked
+const maskingHelpersSQL = `
+CREATE SCHEMA cnpg_masking;
+
+CREATE FUNCTION cnpg_masking.hash(value text)
+RETURNS text LANGUAGE sql IMMUTABLE STRICT AS
+$$ SELECT encode(sha256(convert_to(value, 'UTF8')), 'hex') $$;
+
+CREATE FUNCTION cnpg_masking.email(value text)
+RETURNS text LANGUAGE sql IMMUTABLE STRICT AS
+$$ SELECT 'user-' || left(cnpg_masking.hash(value), 12) || '@example.com' $$;
+
+CREATE FUNCTION cnpg_masking.redact(value text)
+RETURNS text LANGUAGE sql IMMUTABLE STRICT AS
+$$ SELECT repeat('*', length(value)) $$;
+
+CREATE FUNCTION cnpg_masking.partial(value text, prefix integer, suffix integer)
+RETURNS text LANGUAGE sql IMMUTABLE STRICT AS
+$$ SELECT CASE
+    WHEN length(value) <= prefix + suffix THEN repeat('*', length(value))
+    ELSE left(value, prefix) || repeat('*', length(value) - prefix - suffix) || right(value, suffix)
+  END $$;
+
+CREATE FUNCTION cnpg_masking.digits(value text)
+RETURNS text LANGUAGE sql VOLATILE STRICT AS
+$$ SELECT coalesce(string_agg(
+    CASE WHEN c ~ '[0-9]' THEN floor(random() * 10)::integer::text ELSE c END, '' ORDER BY i), '')
+  FROM unnest(string_to_array(value, NULL)) WITH ORDINALITY AS t(c, i) $$;
+
+CREATE FUNCTION cnpg_masking.random_int(low integer, high integer)
+RETURNS integer LANGUAGE sql VOLATILE STRICT AS
+$$ SELECT low + floor(random() * (high - low + 1))::integer $$;
+`
+
+// dropMaskingHelpersSQL removes the data masking helpers
+const dropMaskingHelpersSQL = "DROP SCHEMA cnpg_masking CASCADE"
+
+// maskData runs the data masking SQL of the cluster on the restored
+// instance, before it accepts connections from the applications
+func (info InitInfo) maskData(ctx context.Context, instance *Instance, cluster *apiv1.Cluster) error {
+	masking := cluster.GetDataMasking()
+	database := cluster.GetDataMaskingDatabase()
+
+	contextLogger := log.FromContext(ctx).WithValues("database", database)
+	contextLogger.Info("Masking the data")
+
+	db, err := instance.ConnectionPool().Connection(database)
+	if err != nil {
+		return fmt.Errorf("while connecting to the database to be masked: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, maskingHelpersSQL); err != nil {
+		return fmt.Errorf("while creating the data masking helpers: %w", err)
+	}
+
+	if err := info.executeQueries(db, masking.SQL); err != nil {
+		return fmt.Errorf("could not execute the data masking queries: %w", err)
+	}
+
+	if cluster.ShouldRunDataMaskingSQLRefs() {
+		if err := info.executeSQLRefs(db, specs.DataMaskingSQLRefsFolder); err != nil {
+			return fmt.Errorf("could not execute the data masking SQL refs: %w", err)
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, dropMaskingHelpersSQL); err != nil {
+		return fmt.Errorf("while removing the data masking helpers: %w", err)
+	}
+
+	contextLogger.Info("Data masked")
+	return nil
+}
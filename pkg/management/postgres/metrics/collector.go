@@ -24,6 +24,9 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -47,6 +50,48 @@ type QueriesCollector struct {
 
 	errorUserQueries      *prometheus.CounterVec
 	errorUserQueriesGauge prometheus.Gauge
+
+	// cache contains the results of the queries having the
+	// cache_seconds option
+	cache *queryResultsCache
+}
+
+// queryResultsCache keeps the metrics generated by the queries
+// that don't need to run on every scrape
+type queryResultsCache struct {
+	mu      sync.Mutex
+	results map[string]cachedQueryResults
+}
+
+// cachedQueryResults are the metrics generated by a query
+// and the time they were collected at
+type cachedQueryResults struct {
+	metrics     []prometheus.Metric
+	collectedAt time.Time
+}
+
+// get gets the metrics generated by a query, if they have been
+// collected in the last ttl
+func (c *queryResultsCache) get(name string, ttl time.Duration) ([]prometheus.Metric, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	results, ok := c.results[name]
+	if !ok || time.Since(results.collectedAt) >= ttl {
+		return nil, false
+	}
+	return results.metrics, true
+}
+
+// set stores the metrics generated by a query
+func (c *queryResultsCache) set(name string, metrics []prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.results == nil {
+		c.results = make(map[string]cachedQueryResults)
+	}
+	c.results[name] = cachedQueryResults{metrics: metrics, collectedAt: time.Now()}
 }
 
 // Name returns the name of this collector, as supplied by the user in the configMap
@@ -96,54 +141,113 @@ func (q *QueriesCollector) collectUserQueries(ch chan<- prometheus.Metric) error
 			continue
 		}
 
+		cacheTTL := time.Duration(userQuery.CacheSeconds) * time.Second
+		if cachedMetrics, ok := q.cache.get(name, cacheTTL); ok {
+			queryLogger.Debug("Using the cached results")
+			for _, metric := range cachedMetrics {
+				ch <- metric
+			}
+			continue
+		}
+
 		queryLogger.Debug("Collecting data")
 
+		var targetDatabasesRegex *regexp.Regexp
+		if userQuery.TargetDatabasesRegex != "" {
+			targetDatabasesRegex, err = regexp.Compile(userQuery.TargetDatabasesRegex)
+			if err != nil {
+				q.reportUserQueryErrorMetric(name + ": " + err.Error())
+				continue
+			}
+		}
+
 		targetDatabases := userQuery.TargetDatabases
-		if len(targetDatabases) == 0 {
+		if len(targetDatabases) == 0 && targetDatabasesRegex == nil {
 			targetDatabases = append(targetDatabases, q.defaultDBName)
 		}
 
 		// Initialize the cache is one of the target contains a pattern
-		if allAccessibleDatabasesCache == nil {
-			for _, targetDatabase := range targetDatabases {
-				if isPathPattern.MatchString(targetDatabase) {
-					databases, err := q.getAllAccessibleDatabases()
-					if err != nil {
-						q.reportUserQueryErrorMetric(name + ": " + err.Error())
-						break
-					}
-					allAccessibleDatabasesCache = databases
-					break
-				}
+		// or the databases are matched by a regular expression
+		if allAccessibleDatabasesCache == nil &&
+			(targetDatabasesRegex != nil || slices.ContainsFunc(targetDatabases, isPathPattern.MatchString)) {
+			databases, err := q.getAllAccessibleDatabases()
+			if err != nil {
+				q.reportUserQueryErrorMetric(name + ": " + err.Error())
+			} else {
+				allAccessibleDatabasesCache = databases
 			}
 		}
 
-		allTargetDatabases := q.expandTargetDatabases(targetDatabases, allAccessibleDatabasesCache)
+		allTargetDatabases := q.expandTargetDatabases(
+			targetDatabases, targetDatabasesRegex, allAccessibleDatabasesCache)
+
+		// The metrics of the cached queries are kept while they are sent
+		queryCh := ch
+		var closeQueryCh func() []prometheus.Metric
+		if cacheTTL > 0 {
+			queryCh, closeQueryCh = teeMetrics(ch)
+		}
+
+		hasErrors := false
 		for targetDatabase := range allTargetDatabases {
 			conn, err := q.instance.ConnectionPool().Connection(targetDatabase)
 			if err != nil {
+				hasErrors = true
 				q.reportUserQueryErrorMetric(name + ": " + err.Error())
 				continue
 			}
 
-			err = collector.collect(conn, ch)
+			err = collector.collect(conn, queryCh)
 			if err != nil {
+				hasErrors = true
 				queryLogger.Error(err, "Error collecting user query",
 					"targetDatabase", targetDatabase)
 				// Increment metrics counters.
 				q.reportUserQueryErrorMetric(name + " on db " + targetDatabase + ": " + err.Error())
 			}
 		}
+
+		if closeQueryCh != nil {
+			collectedMetrics := closeQueryCh()
+			if !hasErrors {
+				q.cache.set(name, collectedMetrics)
+			}
+		}
 	}
 	return nil
 }
 
+// teeMetrics returns a channel whose metrics are forwarded to ch, and
+// a function closing it and returning the forwarded metrics
+func teeMetrics(ch chan<- prometheus.Metric) (chan<- prometheus.Metric, func() []prometheus.Metric) {
+	teeCh := make(chan prometheus.Metric)
+	done := make(chan []prometheus.Metric)
+	go func() {
+		var metrics []prometheus.Metric
+		for metric := range teeCh {
+			metrics = append(metrics, metric)
+			ch <- metric
+		}
+		done <- metrics
+	}()
+
+	return teeCh, func() []prometheus.Metric {
+		close(teeCh)
+		return <-done
+	}
+}
+
 func (q QueriesCollector) toBeChecked(name string, userQuery UserQuery, isPrimary bool, queryLogger log.Logger) bool {
 	if (userQuery.Primary || userQuery.Master) && !isPrimary { // wokeignore:rule=master
 		queryLogger.Debug("Skipping because runs only on primary")
 		return false
 	}
 
+	if userQuery.Replica && isPrimary {
+		queryLogger.Debug("Skipping because runs only on replicas")
+		return false
+	}
+
 	if runOnServer := userQuery.RunOnServer; runOnServer != "" {
 		matchesVersion, err := q.checkRunOnServerMatches(runOnServer, name)
 		// any error should result in the query not being executed
@@ -188,9 +292,17 @@ func (q QueriesCollector) checkRunOnServerMatches(runOnServer string, name strin
 
 func (q QueriesCollector) expandTargetDatabases(
 	targetDatabases []string,
+	targetDatabasesRegex *regexp.Regexp,
 	allAccessibleDatabasesCache []string,
 ) (allTargetDatabases map[string]bool) {
 	allTargetDatabases = make(map[string]bool)
+	if targetDatabasesRegex != nil {
+		for _, database := range allAccessibleDatabasesCache {
+			if targetDatabasesRegex.MatchString(database) {
+				allTargetDatabases[database] = true
+			}
+		}
+	}
 	for _, targetDatabase := range targetDatabases {
 		if !isPathPattern.MatchString(targetDatabase) {
 			allTargetDatabases[targetDatabase] = true
@@ -258,6 +370,7 @@ func NewQueriesCollector(
 		variableLabels: make(map[string]VariableSet),
 		userQueries:    make(UserQueries),
 		defaultDBName:  defaultDBName,
+		cache:          &queryResultsCache{},
 		errorUserQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: name,
 			Name:      "errors_total",
@@ -335,6 +448,14 @@ func (c QueryCollector) collect(conn *sql.DB, ch chan<- prometheus.Metric) error
 		}
 	}()
 
+	if c.userQuery.TimeoutSeconds > 0 {
+		if _, err := tx.Exec(
+			fmt.Sprintf("SET LOCAL statement_timeout TO '%ds'", c.userQuery.TimeoutSeconds),
+		); err != nil {
+			return err
+		}
+	}
+
 	shouldBeCollected, err := c.userQuery.isCollectable(tx)
 	if err != nil {
 		return err
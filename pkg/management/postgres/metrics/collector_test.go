@@ -17,6 +17,10 @@ limitations under the License.
 package metrics
 
 import (
+	"regexp"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/prometheus/client_golang/prometheus"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -147,3 +151,69 @@ var _ = Describe("QueryCollector tests", func() {
 		})
 	})
 })
+
+var _ = Describe("query target selection", func() {
+	q := NewQueriesCollector("test", nil, "db")
+	queryLogger := log.WithValues("query", "test")
+
+	It("runs the queries on the primary or on the replicas", func() {
+		Expect(q.toBeChecked("test", UserQuery{}, true, queryLogger)).To(BeTrue())
+		Expect(q.toBeChecked("test", UserQuery{}, false, queryLogger)).To(BeTrue())
+
+		Expect(q.toBeChecked("test", UserQuery{Primary: true}, true, queryLogger)).To(BeTrue())
+		Expect(q.toBeChecked("test", UserQuery{Primary: true}, false, queryLogger)).To(BeFalse())
+
+		Expect(q.toBeChecked("test", UserQuery{Replica: true}, true, queryLogger)).To(BeFalse())
+		Expect(q.toBeChecked("test", UserQuery{Replica: true}, false, queryLogger)).To(BeTrue())
+	})
+
+	It("expands the target databases", func() {
+		databases := []string{"app_one", "app_two", "postgres", "reports"}
+		Expect(q.expandTargetDatabases([]string{"reports", "app_*"}, nil, databases)).To(Equal(
+			map[string]bool{"reports": true, "app_one": true, "app_two": true}))
+		Expect(q.expandTargetDatabases(nil, regexp.MustCompile("^(app_two|postgres)$"), databases)).To(Equal(
+			map[string]bool{"app_two": true, "postgres": true}))
+		Expect(q.expandTargetDatabases([]string{"reports"}, regexp.MustCompile("one$"), databases)).To(Equal(
+			map[string]bool{"reports": true, "app_one": true}))
+	})
+})
+
+var _ = Describe("query results cache", func() {
+	newMetric := func() prometheus.Metric {
+		desc := prometheus.NewDesc("test_metric", "test metric", nil, nil)
+		return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1)
+	}
+
+	It("keeps the results of the queries for the requested time", func() {
+		cache := &queryResultsCache{}
+		_, ok := cache.get("test", time.Minute)
+		Expect(ok).To(BeFalse())
+
+		metric := newMetric()
+		cache.set("test", []prometheus.Metric{metric})
+		cachedMetrics, ok := cache.get("test", time.Minute)
+		Expect(ok).To(BeTrue())
+		Expect(cachedMetrics).To(ConsistOf(metric))
+
+		_, ok = cache.get("test", 0)
+		Expect(ok).To(BeFalse())
+
+		cache.results["test"] = cachedQueryResults{
+			metrics:     []prometheus.Metric{metric},
+			collectedAt: time.Now().Add(-2 * time.Minute),
+		}
+		_, ok = cache.get("test", time.Minute)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("forwards and keeps the collected metrics", func() {
+		ch := make(chan prometheus.Metric, 10)
+		teeCh, closeTeeCh := teeMetrics(ch)
+
+		metric := newMetric()
+		teeCh <- metric
+		teeCh <- metric
+		Expect(closeTeeCh()).To(HaveLen(2))
+		Expect(ch).To(HaveLen(2))
+	})
+})
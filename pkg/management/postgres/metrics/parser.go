@@ -21,6 +21,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
 
 	"gopkg.in/yaml.v3"
 )
@@ -35,11 +36,25 @@ type UserQuery struct {
 	Metrics         []Mapping `yaml:"metrics"`
 	Master          bool      `yaml:"master"` // wokeignore:rule=master
 	Primary         bool      `yaml:"primary"`
-	CacheSeconds    uint64    `yaml:"cache_seconds"`
 	RunOnServer     string    `yaml:"runonserver"`
 	TargetDatabases []string  `yaml:"target_databases"`
 	// Name allows overriding the key name in the metric namespace
 	Name string `yaml:"name"`
+
+	// Replica makes the query run only on the replicas
+	Replica bool `yaml:"replica"`
+
+	// CacheSeconds is the number of seconds the results of the query are
+	// reused for, instead of running the query on every scrape
+	CacheSeconds uint64 `yaml:"cache_seconds"`
+
+	// TimeoutSeconds is the statement timeout of the query
+	TimeoutSeconds uint64 `yaml:"timeout_seconds"`
+
+	// TargetDatabasesRegex is a regular expression matching the names
+	// of the databases where the query runs, in addition to the ones
+	// listed in TargetDatabases
+	TargetDatabasesRegex string `yaml:"target_databases_regex"`
 }
 
 // Mapping decide how a certain field, extracted from the query's result, should be used
@@ -94,9 +109,30 @@ func ParseQueries(content []byte) (UserQueries, error) {
 		return nil, fmt.Errorf("parsing user queries: %w", err)
 	}
 
+	for name, query := range result {
+		if err := query.validate(); err != nil {
+			return nil, fmt.Errorf("invalid user query %s: %w", name, err)
+		}
+	}
+
 	return result, nil
 }
 
+// validate checks the options selecting where the query runs
+func (userQuery UserQuery) validate() error {
+	if (userQuery.Primary || userQuery.Master) && userQuery.Replica { // wokeignore:rule=master
+		return errors.New("the query cannot run only on the primary and only on the replicas")
+	}
+
+	if userQuery.TargetDatabasesRegex != "" {
+		if _, err := regexp.Compile(userQuery.TargetDatabasesRegex); err != nil {
+			return fmt.Errorf("invalid target_databases_regex: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // isCollectable checks if a query to collect metrics should be executed.
 // The method tests the query provided in the PredicateQuery property within the same transaction
 // used to collect metrics.
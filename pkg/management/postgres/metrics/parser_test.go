@@ -86,6 +86,45 @@ some_query:
 		Expect(result["some_query"].Metrics[0]["datname"].SupportedVersions).To(Equal(">9.4 <11"))
 	})
 
+	It("parses the options selecting where and how often the query runs", func() {
+		result, err := ParseQueries([]byte(`
+slow_query:
+  query: SELECT count(*) AS total FROM big_table
+  replica: true
+  cache_seconds: 300
+  timeout_seconds: 10
+  target_databases_regex: "^app_.*"
+  metrics:
+  - total:
+      usage: "GAUGE"
+      description: "number of rows"
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result["slow_query"].Replica).To(BeTrue())
+		Expect(result["slow_query"].CacheSeconds).To(BeEquivalentTo(300))
+		Expect(result["slow_query"].TimeoutSeconds).To(BeEquivalentTo(10))
+		Expect(result["slow_query"].TargetDatabasesRegex).To(Equal("^app_.*"))
+	})
+
+	It("rejects the queries running only on the primary and only on the replicas", func() {
+		_, err := ParseQueries([]byte(`
+test:
+  query: SELECT 1 AS one
+  primary: true
+  replica: true
+`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects the invalid regular expressions matching the databases", func() {
+		_, err := ParseQueries([]byte(`
+test:
+  query: SELECT 1 AS one
+  target_databases_regex: "app_("
+`))
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("correctly handles YAML errors", func() {
 		result, err := ParseQueries([]byte(`
 test:
@@ -20,6 +20,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/executablehash"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -110,6 +112,11 @@ func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err er
 		return result, err
 	}
 
+	result.AlterSystemParameters, err = GetAlterSystemParameters(superUserDB)
+	if err != nil {
+		return result, err
+	}
+
 	result.InstanceArch = runtime.GOARCH
 
 	result.ExecutableHash, err = executablehash.Get()
@@ -118,6 +125,16 @@ func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err er
 	}
 
 	result.IsInstanceManagerUpgrading = instance.InstanceManagerIsUpgrading.Load()
+	result.TLSCertificatesFingerprint = getFilesFingerprint(
+		postgres.ServerCertificateLocation,
+		postgres.StreamingReplicaCertificateLocation)
+	result.TLSCAFingerprint = getFilesFingerprint(
+		postgres.ClientCACertificateLocation,
+		postgres.ServerCACertificateLocation)
+	result.VolumesUsage = getVolumesUsage(
+		instance.PgData,
+		specs.PgWalVolumePath,
+		specs.PgTablespaceVolumePath)
 
 	return result, nil
 }
@@ -157,6 +174,34 @@ func updateResultForDecrease(
 	return nil
 }
 
+// GetAlterSystemParameters gets the names of the parameters whose value
+// has been set with ALTER SYSTEM and is currently applied
+func GetAlterSystemParameters(superUserDB *sql.DB) ([]string, error) {
+	rows, err := superUserDB.Query(
+		`SELECT DISTINCT name
+		FROM pg_catalog.pg_file_settings
+		WHERE sourcefile = pg_catalog.current_setting('data_directory') || '/postgresql.auto.conf'
+			AND applied
+		ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var parameters []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, name)
+	}
+
+	return parameters, rows.Err()
+}
+
 func areAllParamsUpdated(decreasedValues map[string]int, pgControldataParams map[string]int) bool {
 	var readyParams int
 	for setting, newValue := range decreasedValues {
@@ -397,7 +442,8 @@ func (instance *Instance) fillReplicationSlotsStatus(result *postgres.Postgresql
 	coalesce(catalog_xmin::text, ''),	
 	coalesce(restart_lsn::text, ''),
 	coalesce(wal_status::text, ''),
-	safe_wal_size
+	safe_wal_size,
+	pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_current_wal_lsn(), restart_lsn)::bigint
     FROM pg_replication_slots`)
 	if err != nil {
 		return err
@@ -421,6 +467,7 @@ func (instance *Instance) fillReplicationSlotsStatus(result *postgres.Postgresql
 			&slot.RestartLsn,
 			&slot.WalStatus,
 			&slot.SafeWalSize,
+			&slot.LagBytes,
 		); err != nil {
 			return err
 		}
@@ -659,3 +706,17 @@ func GetReadyWALFiles() (fileNames []string, err error) {
 
 	return fileNames, nil
 }
+
+// getFilesFingerprint computes the fingerprint of the content of a set
+// of files, returning an empty string if any of them can't be read
+func getFilesFingerprint(fileNames ...string) string {
+	contents := make([][]byte, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		content, err := os.ReadFile(fileName) // #nosec
+		if err != nil {
+			return ""
+		}
+		contents = append(contents, content)
+	}
+	return certs.Fingerprint(contents...)
+}
@@ -89,6 +89,21 @@ var _ = Describe("probes", func() {
 		Expect(status.IsArchivingWAL).To(BeFalse())
 	})
 
+	It("gets the parameters set with ALTER SYSTEM", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*pg_file_settings.*`).
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).
+				AddRow("max_connections").
+				AddRow("work_mem"))
+
+		parameters, err := GetAlterSystemParameters(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(Equal([]string{"max_connections", "work_mem"}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
 	Context("Fill basebackup stats", func() {
 		It("does nothing in case of that major version is less than 13 ", func() {
 			instance := &Instance{
@@ -25,11 +25,19 @@ import (
 
 	"github.com/cloudnative-pg/machinery/pkg/execlog"
 	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 )
 
 // PromoteAndWait promotes this instance, and wait DefaultPgCtlTimeoutForPromotion
 // seconds for it to happen
-func (instance *Instance) PromoteAndWait(ctx context.Context) error {
+func (instance *Instance) PromoteAndWait(ctx context.Context) (err error) {
+	ctx, span := telemetry.Start(ctx, "instance.promote")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	contextLogger := log.FromContext(ctx)
 
 	instance.ShutdownConnections()
@@ -47,7 +55,7 @@ func (instance *Instance) PromoteAndWait(ctx context.Context) error {
 	contextLogger.Info("Promoting instance", "pgctl_options", options)
 
 	pgCtlCmd := exec.Command(pgCtlName, options...) // #nosec
-	err := execlog.RunStreaming(pgCtlCmd, pgCtlName)
+	err = execlog.RunStreaming(pgCtlCmd, pgCtlName)
 	if err != nil {
 		return fmt.Errorf("error promoting the PostgreSQL instance: %w", err)
 	}
@@ -0,0 +1,104 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querystats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// HistoryTableName is the name of the table where the snapshots of
+// the top statements are stored
+const HistoryTableName = "cnpg_query_history"
+
+var (
+	createHistoryTableQuery = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS public.%s (
+  snapshot_time timestamptz NOT NULL,
+  pod_name text NOT NULL,
+  datname name NOT NULL,
+  rolname name NOT NULL,
+  queryid bigint NOT NULL,
+  query text NOT NULL,
+  calls bigint NOT NULL,
+  total_exec_time double precision NOT NULL,
+  mean_exec_time double precision NOT NULL,
+  rows bigint NOT NULL
+)`, HistoryTableName)
+
+	createHistoryIndexQuery = fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %[1]s_snapshot_time_idx ON public.%[1]s (snapshot_time)",
+		HistoryTableName)
+
+	insertHistoryQuery = fmt.Sprintf(`INSERT INTO public.%s
+  (snapshot_time, pod_name, datname, rolname, queryid, query, calls, total_exec_time, mean_exec_time, rows)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, HistoryTableName)
+
+	pruneHistoryQuery = fmt.Sprintf("DELETE FROM public.%s WHERE snapshot_time < $1", HistoryTableName)
+)
+
+// WriteHistory stores a snapshot of the top statements in the history
+// table, creating it if needed, and removes the snapshots taken
+// before the retention period
+func WriteHistory(
+	ctx context.Context,
+	db *sql.DB,
+	podName string,
+	snapshotTime time.Time,
+	statements []Statement,
+	retention time.Duration,
+) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// This is a no-op when the transaction is committed
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, createHistoryTableQuery); err != nil {
+		return fmt.Errorf("while creating the query history table: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, createHistoryIndexQuery); err != nil {
+		return fmt.Errorf("while creating the query history index: %w", err)
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, insertHistoryQuery,
+			snapshotTime,
+			podName,
+			statement.Database,
+			statement.User,
+			statement.QueryID,
+			statement.Query,
+			statement.Calls,
+			statement.TotalExecTime,
+			statement.MeanExecTime,
+			statement.Rows,
+		); err != nil {
+			return fmt.Errorf("while storing the query history: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, pruneHistoryQuery, snapshotTime.Add(-retention)); err != nil {
+		return fmt.Errorf("while pruning the query history: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querystats
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("query history", func() {
+	var (
+		db           *sql.DB
+		mock         sqlmock.Sqlmock
+		snapshotTime time.Time
+		statements   []Statement
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+
+		snapshotTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		statements = []Statement{
+			{Database: "app", User: "app", QueryID: 42, Query: "SELECT 1", Calls: 10, TotalExecTime: 5, MeanExecTime: 0.5},
+		}
+	})
+
+	It("stores the snapshots and removes the expired ones", func(ctx context.Context) {
+		mock.ExpectBegin()
+		mock.ExpectExec(createHistoryTableQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createHistoryIndexQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insertHistoryQuery).
+			WithArgs(snapshotTime, "cluster-example-1", "app", "app", int64(42), "SELECT 1",
+				int64(10), 5.0, 0.5, int64(0)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(pruneHistoryQuery).
+			WithArgs(snapshotTime.Add(-24 * time.Hour)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		Expect(WriteHistory(ctx, db, "cluster-example-1", snapshotTime, statements, 24*time.Hour)).To(Succeed())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't store partial snapshots", func(ctx context.Context) {
+		mock.ExpectBegin()
+		mock.ExpectExec(createHistoryTableQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createHistoryIndexQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insertHistoryQuery).WillReturnError(errors.New("read-only transaction"))
+		mock.ExpectRollback()
+
+		Expect(WriteHistory(ctx, db, "cluster-example-1", snapshotTime, statements, time.Hour)).ToNot(Succeed())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querystats
+
+import (
+	"context"
+	"database/sql"
+)
+
+// topStatementsQuery gets the statements of every database with the
+// highest total and mean execution time
+const topStatementsQuery = `
+WITH statements AS (
+  SELECT d.datname, r.rolname, s.queryid, s.query, s.calls, s.total_exec_time, s.mean_exec_time, s.rows,
+    row_number() OVER (PARTITION BY s.dbid ORDER BY s.total_exec_time DESC) AS total_rank,
+    row_number() OVER (PARTITION BY s.dbid ORDER BY s.mean_exec_time DESC) AS mean_rank
+  FROM pg_catalog.pg_stat_statements s
+  JOIN pg_catalog.pg_database d ON d.oid = s.dbid
+  JOIN pg_catalog.pg_roles r ON r.oid = s.userid
+  WHERE s.queryid IS NOT NULL
+)
+SELECT datname, rolname, queryid, query, calls, total_exec_time, mean_exec_time, rows
+FROM statements
+WHERE total_rank <= $1 OR mean_rank <= $1
+ORDER BY datname, total_exec_time DESC`
+
+// Statement contains the statistics of a statement tracked
+// by `pg_stat_statements`
+type Statement struct {
+	// Database is the name of the database where the statement runs
+	Database string
+
+	// User is the name of the role running the statement
+	User string
+
+	// QueryID is the identifier of the normalized statement
+	QueryID int64
+
+	// Query is the text of the normalized statement
+	Query string
+
+	// Calls is the number of times the statement was executed
+	Calls int64
+
+	// TotalExecTime is the total time spent executing the
+	// statement, in milliseconds
+	TotalExecTime float64
+
+	// MeanExecTime is the mean time spent executing the
+	// statement, in milliseconds
+	MeanExecTime float64
+
+	// Rows is the total number of rows retrieved or affected
+	// by the statement
+	Rows int64
+}
+
+// GetTopStatements gets the topN statements of every database ranked by
+// total execution time together with the topN ones ranked by mean
+// execution time. The `pg_stat_statements` extension needs to be
+// installed in the database the passed connection points to
+func GetTopStatements(ctx context.Context, db *sql.DB, topN int) ([]Statement, error) {
+	rows, err := db.QueryContext(ctx, topStatementsQuery, topN)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Statement
+	for rows.Next() {
+		var statement Statement
+		if err := rows.Scan(
+			&statement.Database,
+			&statement.User,
+			&statement.QueryID,
+			&statement.Query,
+			&statement.Calls,
+			&statement.TotalExecTime,
+			&statement.MeanExecTime,
+			&statement.Rows,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, statement)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querystats
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var statementColumns = []string{
+	"datname", "rolname", "queryid", "query", "calls", "total_exec_time", "mean_exec_time", "rows",
+}
+
+var _ = Describe("top statements", func() {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+	})
+
+	It("gets the top statements of every database", func(ctx context.Context) {
+		mock.ExpectQuery(topStatementsQuery).WithArgs(5).WillReturnRows(
+			sqlmock.NewRows(statementColumns).
+				AddRow("app", "app", int64(42), "SELECT * FROM orders WHERE id = $1", 1000, 2500.0, 2.5, 1000).
+				AddRow("postgres", "postgres", int64(-7), "VACUUM", 1, 300.0, 300.0, 0))
+
+		statements, err := GetTopStatements(ctx, db, 5)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statements).To(Equal([]Statement{
+			{
+				Database:      "app",
+				User:          "app",
+				QueryID:       42,
+				Query:         "SELECT * FROM orders WHERE id = $1",
+				Calls:         1000,
+				TotalExecTime: 2500,
+				MeanExecTime:  2.5,
+				Rows:          1000,
+			},
+			{
+				Database:      "postgres",
+				User:          "postgres",
+				QueryID:       -7,
+				Query:         "VACUUM",
+				Calls:         1,
+				TotalExecTime: 300,
+				MeanExecTime:  300,
+			},
+		}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("fails when pg_stat_statements is not available", func(ctx context.Context) {
+		mock.ExpectQuery(topStatementsQuery).WithArgs(5).WillReturnError(sql.ErrConnDone)
+
+		_, err := GetTopStatements(ctx, db, 5)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,215 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// recloneSuffix is the suffix of the directories where the new copy
+	// of the data is taken while re-cloning an instance
+	recloneSuffix = ".reclone"
+
+	// recloneOldSuffix is the suffix of the directories being replaced
+	// by the new copy of the data, which are removed at the end
+	recloneOldSuffix = ".reclone-old"
+)
+
+// sourceSystem is the identity of a PostgreSQL server, as
+// returned by the IDENTIFY_SYSTEM replication command
+type sourceSystem struct {
+	systemID string
+	timeline int
+}
+
+// RecloneFromServer replaces the data directory, and the WAL directory
+// when it lives in a dedicated volume, with a new copy of the server
+// reachable with the passed connection string, taken with pg_basebackup.
+//
+// This is meant to be used only after pg_rewind failed: the server must
+// be reachable, belong to the same PostgreSQL system and be on a different
+// timeline. The copy is taken in a sibling directory, and replaces the
+// existing data only once pg_basebackup succeeded, so that nothing is lost
+// when the clone fails.
+// This function must be called while PostgreSQL is not running.
+func (instance *Instance) RecloneFromServer(ctx context.Context, connectionString, walDir string) error {
+	contextLogger := log.FromContext(ctx)
+
+	if err := instance.checkRecloneFromServer(ctx, connectionString); err != nil {
+		return err
+	}
+
+	// Cloning the data directory may take a long time, and the
+	// liveness probe must not fail in the meantime
+	mightBeUnavailable := instance.MightBeUnavailable()
+	instance.SetMightBeUnavailable(true)
+	defer instance.SetMightBeUnavailable(mightBeUnavailable)
+
+	newPgData := instance.PgData + recloneSuffix
+	var newWalDir string
+	if walDir != "" {
+		newWalDir = walDir + recloneSuffix
+	}
+
+	// Remove what's left from a previous attempt which didn't complete
+	if err := removeRecloneDirectories(newPgData, newWalDir); err != nil {
+		return err
+	}
+
+	contextLogger.Info("Cloning the source server beside the current data directory",
+		"pgdata", newPgData,
+		"walDir", newWalDir)
+	if err := ClonePgData(ctx, connectionString, newPgData, newWalDir); err != nil {
+		if cleanupErr := removeRecloneDirectories(newPgData, newWalDir); cleanupErr != nil {
+			contextLogger.Warning("Cannot remove the partial copy of the source server", "err", cleanupErr)
+		}
+		return fmt.Errorf("while cloning the source server: %w", err)
+	}
+
+	if newWalDir != "" {
+		// pg_basebackup linked pg_wal to the temporary WAL directory
+		pgWalLink := filepath.Join(newPgData, pgWalDirectory)
+		if err := os.Remove(pgWalLink); err != nil {
+			return err
+		}
+		if err := os.Symlink(walDir, pgWalLink); err != nil {
+			return err
+		}
+		if err := replaceDirectory(walDir, newWalDir); err != nil {
+			return fmt.Errorf("while replacing the WAL directory: %w", err)
+		}
+	}
+	if err := replaceDirectory(instance.PgData, newPgData); err != nil {
+		return fmt.Errorf("while replacing the data directory: %w", err)
+	}
+
+	contextLogger.Info("The data directory has been replaced by a new copy of the source server")
+	return nil
+}
+
+// checkRecloneFromServer checks that the source server is reachable, and
+// that its timeline really diverged from the one of the data directory
+func (instance *Instance) checkRecloneFromServer(ctx context.Context, connectionString string) error {
+	tablespaces, err := os.ReadDir(filepath.Join(instance.PgData, "pg_tblspc"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(tablespaces) > 0 {
+		return fmt.Errorf("cannot clone again a data directory containing tablespaces")
+	}
+
+	source, err := identifySourceSystem(ctx, connectionString)
+	if err != nil {
+		return fmt.Errorf("the source server is not reachable, refusing to clone it: %w", err)
+	}
+
+	pgControlData, err := instance.GetPgControldata()
+	if err != nil {
+		return err
+	}
+
+	return checkTimelineDivergence(utils.ParsePgControldataOutput(pgControlData), source)
+}
+
+// checkTimelineDivergence checks, using the parsed pg_controldata output,
+// that the data directory belongs to the same system as the source server,
+// and that their timelines are different
+func checkTimelineDivergence(pgControlData map[string]string, source *sourceSystem) error {
+	systemID := pgControlData[utils.PgControlDataKeyDatabaseSystemIdentifier]
+	if systemID != source.systemID {
+		return fmt.Errorf(
+			"the source server belongs to a different system (%s) than the data directory (%s), refusing to clone it",
+			source.systemID, systemID)
+	}
+
+	timeline, err := strconv.Atoi(pgControlData[utils.PgControlDataKeyLatestCheckpointTimelineID])
+	if err != nil {
+		return fmt.Errorf("while reading the timeline of the data directory: %w", err)
+	}
+	if timeline == source.timeline {
+		return fmt.Errorf(
+			"the data directory is on the same timeline (%d) of the source server, refusing to clone it",
+			timeline)
+	}
+
+	return nil
+}
+
+// identifySourceSystem gets the system identifier and the
+// current timeline of a PostgreSQL server
+func identifySourceSystem(ctx context.Context, connectionString string) (*sourceSystem, error) {
+	db, err := pool.NewDBConnection(connectionString, pool.ConnectionProfilePostgresqlPhysicalReplication)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	var (
+		result   sourceSystem
+		xLogPos  string
+		database sql.NullString
+	)
+	row := db.QueryRowContext(ctx, "IDENTIFY_SYSTEM")
+	if err := row.Scan(&result.systemID, &result.timeline, &xLogPos, &database); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// replaceDirectory replaces the target directory with the passed one,
+// which must live in the same volume
+func replaceDirectory(target, replacement string) error {
+	oldDirectory := target + recloneOldSuffix
+	if err := os.RemoveAll(oldDirectory); err != nil {
+		return err
+	}
+	if err := os.Rename(target, oldDirectory); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(replacement, target); err != nil {
+		return err
+	}
+	return os.RemoveAll(oldDirectory)
+}
+
+// removeRecloneDirectories removes the directories used to take a new
+// copy of the source server
+func removeRecloneDirectories(directories ...string) error {
+	for _, directory := range directories {
+		if directory == "" {
+			continue
+		}
+		if err := os.RemoveAll(directory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
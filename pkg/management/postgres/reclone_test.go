@@ -0,0 +1,73 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("re-cloning an instance", func() {
+	pgControlData := map[string]string{
+		utils.PgControlDataKeyDatabaseSystemIdentifier:   "7345678901234567890",
+		utils.PgControlDataKeyLatestCheckpointTimelineID: "2",
+	}
+
+	It("accepts a source server of the same system on another timeline", func() {
+		Expect(checkTimelineDivergence(pgControlData, &sourceSystem{
+			systemID: "7345678901234567890",
+			timeline: 3,
+		})).To(Succeed())
+	})
+
+	It("refuses a source server on the same timeline", func() {
+		Expect(checkTimelineDivergence(pgControlData, &sourceSystem{
+			systemID: "7345678901234567890",
+			timeline: 2,
+		})).ToNot(Succeed())
+	})
+
+	It("refuses a source server belonging to another system", func() {
+		Expect(checkTimelineDivergence(pgControlData, &sourceSystem{
+			systemID: "7000000000000000000",
+			timeline: 3,
+		})).ToNot(Succeed())
+	})
+
+	It("replaces a directory with a new copy", func() {
+		tempDir := GinkgoT().TempDir()
+		target := filepath.Join(tempDir, "pgdata")
+		replacement := target + recloneSuffix
+		Expect(os.MkdirAll(target, 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(target, "PG_VERSION"), []byte("16"), 0o600)).To(Succeed())
+		Expect(os.MkdirAll(replacement, 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(replacement, "PG_VERSION"), []byte("17"), 0o600)).To(Succeed())
+
+		Expect(replaceDirectory(target, replacement)).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(target, "PG_VERSION")) // #nosec
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("17"))
+		Expect(replacement).ToNot(BeADirectory())
+		Expect(target + recloneOldSuffix).ToNot(BeADirectory())
+	})
+})
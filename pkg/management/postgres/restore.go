@@ -54,6 +54,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/configfile"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbackrest"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
@@ -96,6 +97,10 @@ func (info InitInfo) RestoreSnapshot(ctx context.Context, cli client.Client, imm
 		return err
 	}
 
+	if err := ReconcileTDEKeyProvider(ctx, cli, cluster, info.PgData); err != nil {
+		return err
+	}
+
 	contextLogger.Info("Cleaning up PGDATA from stale files")
 	if err := fileutils.RemoveRestoreExcludedFiles(ctx, info.PgData); err != nil {
 		return fmt.Errorf("error while cleaning up the recovered PGDATA: %w", err)
@@ -155,9 +160,9 @@ func (info InitInfo) RestoreSnapshot(ctx context.Context, cli client.Client, imm
 	}
 
 	if cluster.IsReplica() {
-		server, ok := cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
+		server, ok := cluster.ExternalCluster(cluster.GetReplicaSource())
 		if !ok {
-			return fmt.Errorf("missing external cluster: %v", cluster.Spec.ReplicaCluster.Source)
+			return fmt.Errorf("missing external cluster: %v", cluster.GetReplicaSource())
 		}
 
 		connectionString, err := external.ConfigureConnectionToServer(
@@ -233,8 +238,6 @@ func (info InitInfo) createBackupObjectForSnapshotRestore(
 
 // Restore restores a PostgreSQL cluster from a backup into the object storage
 func (info InitInfo) Restore(ctx context.Context, cli client.Client) error {
-	contextLogger := log.FromContext(ctx)
-
 	cluster, err := info.loadCluster(ctx, cli)
 	if err != nil {
 		return err
@@ -245,11 +248,68 @@ func (info InitInfo) Restore(ctx context.Context, cli client.Client) error {
 		return err
 	}
 
+	if err := ReconcileTDEKeyProvider(ctx, cli, cluster, info.PgData); err != nil {
+		return err
+	}
+
 	if cluster.ShouldRecoveryCreateApplicationDatabase() {
 		info.ApplicationUser = cluster.GetApplicationDatabaseOwner()
 		info.ApplicationDatabase = cluster.GetApplicationDatabaseName()
 	}
 
+	envs, config, err := info.restoreBackupContent(ctx, cli, cluster)
+	if err != nil {
+		return err
+	}
+
+	if err := info.WriteInitialPostgresqlConf(ctx, cluster); err != nil {
+		return err
+	}
+	// we need a migration here, otherwise the server will not start up if
+	// we recover from a base which has postgresql.auto.conf
+	// the override.conf and include statement is present, what we need to do is to
+	// migrate the content
+	if _, err := info.GetInstance().migratePostgresAutoConfFile(ctx); err != nil {
+		return err
+	}
+	if cluster.IsReplica() {
+		server, ok := cluster.ExternalCluster(cluster.GetReplicaSource())
+		if !ok {
+			return fmt.Errorf("missing external cluster: %v", cluster.GetReplicaSource())
+		}
+
+		connectionString, err := external.ConfigureConnectionToServer(
+			ctx, cli, info.Namespace, &server)
+		if err != nil {
+			return err
+		}
+
+		// TODO: Using a replication slot on replica cluster is not supported (yet?)
+		_, err = UpdateReplicaConfiguration(info.PgData, connectionString, "")
+		return err
+	}
+
+	if err := info.WriteRestoreHbaConf(ctx); err != nil {
+		return err
+	}
+
+	if err := info.writeCustomRestoreWalConfig(cluster, config); err != nil {
+		return err
+	}
+
+	return info.ConfigureInstanceAfterRestore(ctx, cluster, envs)
+}
+
+// restoreBackupContent restores the content of the backup selected by the
+// recovery configuration of the passed cluster in PGDATA, returning the
+// environment and the configuration needed to complete the recovery
+func (info InitInfo) restoreBackupContent(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) ([]string, string, error) {
+	contextLogger := log.FromContext(ctx)
+
 	var envs []string
 	var config string
 
@@ -258,94 +318,71 @@ func (info InitInfo) Restore(ctx context.Context, cli client.Client) error {
 		contextLogger.Info("Restore through plugin detected, proceeding...")
 		res, err := restoreViaPlugin(ctx, cluster, pluginConfiguration)
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 		if res == nil {
-			return errors.New("empty response from restoreViaPlugin, programmatic error")
+			return nil, "", errors.New("empty response from restoreViaPlugin, programmatic error")
 		}
 
 		processEnvironment, err := envmap.ParseEnviron()
 		if err != nil {
-			return fmt.Errorf("error while parsing the process environment: %w", err)
+			return nil, "", fmt.Errorf("error while parsing the process environment: %w", err)
 		}
 
 		pluginEnvironment, err := envmap.Parse(res.Envs)
 		if err != nil {
-			return fmt.Errorf("error while parsing the plugin environment: %w", err)
+			return nil, "", fmt.Errorf("error while parsing the plugin environment: %w", err)
 		}
 
 		envs = envmap.Merge(processEnvironment, pluginEnvironment).StringSlice()
 		config = res.RestoreConfig
+	} else if source := cluster.GetRecoverySourcePgBackRest(); source != nil {
+		contextLogger.Info("Restore through pgBackRest detected, proceeding...")
+		env, restoreConfig, err := info.restoreFromPgBackRest(ctx, cli, cluster, source)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err := info.restoreCustomWalDir(ctx); err != nil {
+			return nil, "", err
+		}
+
+		config = restoreConfig
+		envs = env
 	} else {
 		// Before starting the restore we check if the archive destination is safe to use
 		// otherwise, we stop creating the cluster
-		err = info.checkBackupDestination(ctx, cli, cluster)
-		if err != nil {
-			return err
+		if err := info.checkBackupDestination(ctx, cli, cluster); err != nil {
+			return nil, "", err
 		}
 
 		// If we need to download data from a backup, we do it
 		backup, env, err := info.loadBackup(ctx, cli, cluster)
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 
 		if err := info.ensureArchiveContainsLastCheckpointRedoWAL(ctx, cluster, env, backup); err != nil {
-			return err
+			return nil, "", err
 		}
 
 		if err := info.restoreDataDir(ctx, backup, env); err != nil {
-			return err
+			return nil, "", err
 		}
 
 		if _, err := info.restoreCustomWalDir(ctx); err != nil {
-			return err
+			return nil, "", err
 		}
 
 		conf, err := getRestoreWalConfig(ctx, backup)
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 		config = conf
 		envs = env
 	}
 
-	if err := info.WriteInitialPostgresqlConf(ctx, cluster); err != nil {
-		return err
-	}
-	// we need a migration here, otherwise the server will not start up if
-	// we recover from a base which has postgresql.auto.conf
-	// the override.conf and include statement is present, what we need to do is to
-	// migrate the content
-	if _, err := info.GetInstance().migratePostgresAutoConfFile(ctx); err != nil {
-		return err
-	}
-	if cluster.IsReplica() {
-		server, ok := cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
-		if !ok {
-			return fmt.Errorf("missing external cluster: %v", cluster.Spec.ReplicaCluster.Source)
-		}
-
-		connectionString, err := external.ConfigureConnectionToServer(
-			ctx, cli, info.Namespace, &server)
-		if err != nil {
-			return err
-		}
-
-		// TODO: Using a replication slot on replica cluster is not supported (yet?)
-		_, err = UpdateReplicaConfiguration(info.PgData, connectionString, "")
-		return err
-	}
-
-	if err := info.WriteRestoreHbaConf(ctx); err != nil {
-		return err
-	}
-
-	if err := info.writeCustomRestoreWalConfig(cluster, config); err != nil {
-		return err
-	}
-
-	return info.ConfigureInstanceAfterRestore(ctx, cluster, envs)
+	return envs, config, nil
 }
 
 func (info InitInfo) ensureArchiveContainsLastCheckpointRedoWAL(
@@ -465,6 +502,58 @@ func (info InitInfo) restoreDataDir(ctx context.Context, backup *apiv1.Backup, e
 	return nil
 }
 
+// restoreFromPgBackRest restores PGDATA from the backup in the pgBackRest
+// repository of the passed external cluster that is needed to reach the
+// recovery target. It returns the environment and the configuration to
+// be used to complete the recovery
+func (info InitInfo) restoreFromPgBackRest(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	source *apiv1.ExternalCluster,
+) ([]string, string, error) {
+	contextLogger := log.FromContext(ctx)
+
+	env, err := pgbackrest.EnvSetCredentials(ctx, cli, info.Namespace, source.PgBackRest, os.Environ())
+	if err != nil {
+		return nil, "", err
+	}
+
+	options := pgbackrest.Options(
+		source.PgBackRest,
+		pgbackrest.GetStanza(source.PgBackRest, source.Name),
+		info.PgData,
+	)
+
+	catalog, err := pgbackrest.Info(ctx, env, options)
+	if err != nil {
+		return nil, "", fmt.Errorf("while getting the pgBackRest backup list: %w", err)
+	}
+
+	backupInfo, err := catalog.FindBackupInfo(cluster.Spec.Bootstrap.Recovery.RecoveryTarget)
+	if err != nil {
+		return nil, "", err
+	}
+	if backupInfo == nil {
+		return nil, "", fmt.Errorf("no target backup found in the pgBackRest repository")
+	}
+
+	contextLogger.Info("Starting pgBackRest restore", "backup", backupInfo.Label)
+	if err := pgbackrest.Restore(
+		ctx, env, options, backupInfo.Label, cluster.Spec.Bootstrap.Recovery.RecoveryTarget,
+	); err != nil {
+		contextLogger.Error(err, "Can't restore backup")
+		return nil, "", err
+	}
+	contextLogger.Info("Restore completed")
+
+	config := fmt.Sprintf(
+		"recovery_target_action = promote\n"+
+			"restore_command = '%s'\n",
+		strings.ReplaceAll(pgbackrest.RestoreCommand(options), "'", "''"))
+	return env, config, nil
+}
+
 // loadCluster loads the cluster definition from the API server
 func (info InitInfo) loadCluster(ctx context.Context, typedClient client.Client) (*apiv1.Cluster, error) {
 	var cluster apiv1.Cluster
@@ -587,18 +676,17 @@ func (info InitInfo) loadBackupFromReference(
 ) (*apiv1.Backup, []string, error) {
 	contextLogger := log.FromContext(ctx)
 	var backup apiv1.Backup
-	err := typedClient.Get(
-		ctx,
-		client.ObjectKey{Namespace: info.Namespace, Name: cluster.Spec.Bootstrap.Recovery.Backup.Name},
-		&backup)
+	err := typedClient.Get(ctx, *cluster.GetRecoveryBackupKey(), &backup)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// The credentials are stored in the namespace of the backup, which
+	// can be different from the one of the cluster
 	env, err := barmanCredentials.EnvSetRestoreCloudCredentials(
 		ctx,
 		typedClient,
-		cluster.Namespace,
+		backup.Namespace,
 		&apiv1.BarmanObjectStoreConfiguration{
 			BarmanCredentials: backup.Status.BarmanCredentials,
 			EndpointCA:        backup.Status.EndpointCA,
@@ -853,11 +941,11 @@ func (info InitInfo) WriteInitialPostgresqlConf(ctx context.Context, cluster *ap
 		WithNamespace(info.Namespace).
 		WithClusterName(info.ClusterName)
 
-	_, err = temporaryInstance.RefreshPGHBA(ctx, cluster, "")
+	_, err = temporaryInstance.RefreshPGHBA(ctx, cluster, "", nil)
 	if err != nil {
 		return fmt.Errorf("while generating pg_hba.conf: %w", err)
 	}
-	_, err = temporaryInstance.RefreshPGIdent(ctx, cluster.Spec.PostgresConfiguration.PgIdent)
+	_, err = temporaryInstance.RefreshPGIdent(ctx, cluster)
 	if err != nil {
 		return fmt.Errorf("while generating pg_ident.conf: %w", err)
 	}
@@ -955,15 +1043,27 @@ func (info InitInfo) ConfigureInstanceAfterRestore(ctx context.Context, cluster
 		return fmt.Errorf("while configuring replica: %w", err)
 	}
 
-	if info.ApplicationUser == "" || info.ApplicationDatabase == "" {
+	configureApplication := info.ApplicationUser != "" && info.ApplicationDatabase != ""
+	if !configureApplication {
 		log.Debug("configure new instance not ran, cluster is running in replica mode or missing user or database")
+	}
+
+	maskData := cluster.GetDataMasking() != nil && !cluster.IsReplica()
+	if !configureApplication && !maskData {
 		return nil
 	}
 
-	// Configure the application database information for restored instance
 	return instance.WithActiveInstance(func() error {
-		if err := info.ConfigureNewInstance(instance); err != nil {
-			return fmt.Errorf("while configuring restored instance: %w", err)
+		// Configure the application database information for restored instance
+		if configureApplication {
+			if err := info.ConfigureNewInstance(instance); err != nil {
+				return fmt.Errorf("while configuring restored instance: %w", err)
+			}
+		}
+
+		// Scrub the sensitive data before the applications can connect
+		if maskData {
+			return info.maskData(ctx, instance, cluster)
 		}
 
 		return nil
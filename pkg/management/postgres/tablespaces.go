@@ -0,0 +1,99 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+
+	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// EnsureTablespaceVersionDirectory recreates, inside the passed tablespace
+// location, the version-specific directory where PostgreSQL stores the
+// content of the tablespace. This is needed for the tablespaces backed by
+// an ephemeral volume, which is empty after every restart of the Pod.
+// Nothing is done when the location is not used by any tablespace, as
+// PostgreSQL refuses to create a tablespace in a directory already
+// containing a version-specific directory
+func (instance *Instance) EnsureTablespaceVersionDirectory(location string) error {
+	inUse, err := isTablespaceLocationInUse(instance.PgData, location)
+	if err != nil || !inUse {
+		return err
+	}
+
+	majorVersion, err := postgresutils.GetMajorVersion(instance.PgData)
+	if err != nil {
+		return fmt.Errorf("while reading the PostgreSQL major version: %w", err)
+	}
+
+	controlData, err := instance.GetPgControldata()
+	if err != nil {
+		return err
+	}
+
+	versionDirectory, err := getTablespaceVersionDirectory(majorVersion, controlData)
+	if err != nil {
+		return err
+	}
+
+	return fileutils.EnsureDirectoryExists(path.Join(location, versionDirectory))
+}
+
+// isTablespaceLocationInUse checks if a tablespace of the passed data
+// directory has been created in the passed location
+func isTablespaceLocationInUse(pgData, location string) (bool, error) {
+	tablespacesDirectory := path.Join(pgData, "pg_tblspc")
+	entries, err := os.ReadDir(tablespacesDirectory)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		target, err := os.Readlink(path.Join(tablespacesDirectory, entry.Name()))
+		if err != nil {
+			// Not a symbolic link, i.e. an in-place tablespace
+			continue
+		}
+		if filepath.Clean(target) == filepath.Clean(location) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getTablespaceVersionDirectory gets the name of the version-specific
+// directory PostgreSQL creates inside the location of a tablespace
+func getTablespaceVersionDirectory(majorVersion int, controlData string) (string, error) {
+	catalogVersion, ok := utils.ParsePgControldataOutput(controlData)[utils.PgControlDataKeyCatalogVersionNumber]
+	if !ok || catalogVersion == "" {
+		return "", fmt.Errorf("no catalog version number found in the pg_controldata output")
+	}
+
+	return fmt.Sprintf("PG_%d_%s", majorVersion, catalogVersion), nil
+}
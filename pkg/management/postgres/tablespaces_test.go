@@ -0,0 +1,75 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isTablespaceLocationInUse", func() {
+	var pgData, location string
+
+	BeforeEach(func() {
+		tempDir := GinkgoT().TempDir()
+		pgData = path.Join(tempDir, "pgdata")
+		location = path.Join(tempDir, "tablespaces", "scratch", "data")
+		Expect(os.MkdirAll(path.Join(pgData, "pg_tblspc"), 0o700)).To(Succeed())
+		Expect(os.MkdirAll(location, 0o700)).To(Succeed())
+	})
+
+	It("finds a tablespace created in the location", func() {
+		Expect(os.Symlink(location, path.Join(pgData, "pg_tblspc", "16385"))).To(Succeed())
+
+		inUse, err := isTablespaceLocationInUse(pgData, location)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inUse).To(BeTrue())
+	})
+
+	It("ignores the tablespaces created in other locations", func() {
+		Expect(os.Symlink(path.Join(location, "other"), path.Join(pgData, "pg_tblspc", "16385"))).To(Succeed())
+		Expect(os.MkdirAll(path.Join(pgData, "pg_tblspc", "16386"), 0o700)).To(Succeed())
+
+		inUse, err := isTablespaceLocationInUse(pgData, location)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inUse).To(BeFalse())
+	})
+
+	It("returns false when the data directory has not been created yet", func() {
+		inUse, err := isTablespaceLocationInUse(path.Join(pgData, "missing"), location)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inUse).To(BeFalse())
+	})
+})
+
+var _ = Describe("getTablespaceVersionDirectory", func() {
+	It("builds the directory name from the major and catalog versions", func() {
+		controlData := "pg_control version number:            1700\n" +
+			"Catalog version number:               202406281\n"
+		versionDirectory, err := getTablespaceVersionDirectory(17, controlData)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versionDirectory).To(Equal("PG_17_202406281"))
+	})
+
+	It("fails when the catalog version is missing", func() {
+		_, err := getTablespaceVersionDirectory(17, "pg_control version number:            1700\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,238 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablestats
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// The bloat of the tables is estimated comparing their pages with the ones
+// needed to store their live tuples. Every tuple needs a 24 bytes header and
+// a 4 bytes line pointer, and every page has a 24 bytes header. The tables
+// that were never analyzed have no estimate, as the width of their tuples
+// is unknown
+const tablesQuery = `
+WITH tables AS (
+  SELECT s.relid, s.schemaname, s.relname, s.n_live_tup, s.n_dead_tup,
+    s.last_autovacuum, s.last_autoanalyze, c.relpages, c.reltuples,
+    pg_catalog.pg_table_size(s.relid) AS table_bytes
+  FROM pg_catalog.pg_stat_user_tables s
+  JOIN pg_catalog.pg_class c ON c.oid = s.relid
+  ORDER BY table_bytes DESC
+  LIMIT $1
+), widths AS (
+  SELECT t.relid, COUNT(st.starelid) > 0 AS analyzed, COALESCE(SUM(st.stawidth), 0) AS tuple_width
+  FROM tables t
+  LEFT JOIN pg_catalog.pg_statistic st ON st.starelid = t.relid AND NOT st.stainherit
+  GROUP BY t.relid
+)
+SELECT pg_catalog.current_database(), t.schemaname, t.relname, t.table_bytes, t.n_live_tup, t.n_dead_tup,
+  CASE WHEN w.analyzed THEN
+    GREATEST(t.relpages - CEIL(GREATEST(t.reltuples, 0) * (w.tuple_width + 28) /
+      (pg_catalog.current_setting('block_size')::numeric - 24)), 0)::bigint *
+      pg_catalog.current_setting('block_size')::bigint
+  END AS bloat_bytes,
+  t.last_autovacuum, t.last_autoanalyze
+FROM tables t
+JOIN widths w ON w.relid = t.relid
+ORDER BY t.table_bytes DESC`
+
+// The bloat of the B-tree indexes is estimated comparing their pages with
+// the ones needed to store their entries, with the default fill factor of
+// 90%. Every entry needs an 8 bytes header and a 4 bytes line pointer, and
+// every page has a 24 bytes header and 16 bytes of special space. The
+// indexes on expressions and the ones whose columns were never analyzed
+// have no estimate
+const indexesQuery = `
+WITH tables AS (
+  SELECT s.relid, pg_catalog.pg_table_size(s.relid) AS table_bytes
+  FROM pg_catalog.pg_stat_user_tables s
+  ORDER BY table_bytes DESC
+  LIMIT $1
+), indexes AS (
+  SELECT i.indexrelid,
+    i.indexprs IS NULL AND COUNT(st.starelid) = i.indnatts AS analyzed,
+    COALESCE(SUM(st.stawidth), 0) AS entry_width
+  FROM tables t
+  JOIN pg_catalog.pg_index i ON i.indrelid = t.relid
+  LEFT JOIN pg_catalog.pg_statistic st
+    ON st.starelid = i.indrelid AND st.staattnum = ANY(i.indkey) AND NOT st.stainherit
+  GROUP BY i.indexrelid, i.indexprs, i.indnatts
+)
+SELECT pg_catalog.current_database(), s.schemaname, s.relname, s.indexrelname,
+  pg_catalog.pg_relation_size(s.indexrelid) AS index_bytes,
+  CASE WHEN i.analyzed AND am.amname = 'btree' THEN
+    GREATEST(c.relpages - CEIL(GREATEST(c.reltuples, 0) * (i.entry_width + 12) /
+      ((pg_catalog.current_setting('block_size')::numeric - 40) * 0.9)), 0)::bigint *
+      pg_catalog.current_setting('block_size')::bigint
+  END AS bloat_bytes
+FROM indexes i
+JOIN pg_catalog.pg_stat_user_indexes s ON s.indexrelid = i.indexrelid
+JOIN pg_catalog.pg_class c ON c.oid = i.indexrelid
+JOIN pg_catalog.pg_am am ON am.oid = c.relam
+ORDER BY s.schemaname, s.relname, s.indexrelname`
+
+// Table contains the statistics of a table
+type Table struct {
+	// Database is the name of the database containing the table
+	Database string
+
+	// Schema is the name of the schema containing the table
+	Schema string
+
+	// Name is the name of the table
+	Name string
+
+	// SizeBytes is the size of the table, including its TOAST
+	// table but not its indexes
+	SizeBytes int64
+
+	// LiveTuples is the estimated number of live tuples
+	LiveTuples int64
+
+	// DeadTuples is the estimated number of dead tuples
+	DeadTuples int64
+
+	// EstimatedBloatBytes is the estimated size of the free space
+	// of the table, nil when the table was never analyzed
+	EstimatedBloatBytes *int64
+
+	// LastAutovacuum is when the table was last vacuumed by
+	// autovacuum, nil if never
+	LastAutovacuum *time.Time
+
+	// LastAutoanalyze is when the table was last analyzed by
+	// autovacuum, nil if never
+	LastAutoanalyze *time.Time
+}
+
+// DeadTuplesRatio gets the ratio, between 0 and 1, of the dead tuples
+// of the table
+func (table Table) DeadTuplesRatio() float64 {
+	total := table.LiveTuples + table.DeadTuples
+	if total <= 0 {
+		return 0
+	}
+	return float64(table.DeadTuples) / float64(total)
+}
+
+// Index contains the statistics of an index
+type Index struct {
+	// Database is the name of the database containing the index
+	Database string
+
+	// Schema is the name of the schema containing the index
+	Schema string
+
+	// Table is the name of the indexed table
+	Table string
+
+	// Name is the name of the index
+	Name string
+
+	// SizeBytes is the size of the index
+	SizeBytes int64
+
+	// EstimatedBloatBytes is the estimated size of the free space
+	// of the index, nil when it cannot be estimated
+	EstimatedBloatBytes *int64
+}
+
+// GetTables gets the statistics of the topN largest tables of the
+// database the passed connection points to
+func GetTables(ctx context.Context, db *sql.DB, topN int) ([]Table, error) {
+	rows, err := db.QueryContext(ctx, tablesQuery, topN)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Table
+	for rows.Next() {
+		var (
+			table           Table
+			bloatBytes      sql.NullInt64
+			lastAutovacuum  sql.NullTime
+			lastAutoanalyze sql.NullTime
+		)
+		if err := rows.Scan(
+			&table.Database,
+			&table.Schema,
+			&table.Name,
+			&table.SizeBytes,
+			&table.LiveTuples,
+			&table.DeadTuples,
+			&bloatBytes,
+			&lastAutovacuum,
+			&lastAutoanalyze,
+		); err != nil {
+			return nil, err
+		}
+		if bloatBytes.Valid {
+			table.EstimatedBloatBytes = &bloatBytes.Int64
+		}
+		if lastAutovacuum.Valid {
+			table.LastAutovacuum = &lastAutovacuum.Time
+		}
+		if lastAutoanalyze.Valid {
+			table.LastAutoanalyze = &lastAutoanalyze.Time
+		}
+		result = append(result, table)
+	}
+
+	return result, rows.Err()
+}
+
+// GetIndexes gets the statistics of the indexes of the topN largest
+// tables of the database the passed connection points to
+func GetIndexes(ctx context.Context, db *sql.DB, topN int) ([]Index, error) {
+	rows, err := db.QueryContext(ctx, indexesQuery, topN)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Index
+	for rows.Next() {
+		var (
+			index      Index
+			bloatBytes sql.NullInt64
+		)
+		if err := rows.Scan(
+			&index.Database,
+			&index.Schema,
+			&index.Table,
+			&index.Name,
+			&index.SizeBytes,
+			&bloatBytes,
+		); err != nil {
+			return nil, err
+		}
+		if bloatBytes.Valid {
+			index.EstimatedBloatBytes = &bloatBytes.Int64
+		}
+		result = append(result, index)
+	}
+
+	return result, rows.Err()
+}
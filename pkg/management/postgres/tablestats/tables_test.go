@@ -0,0 +1,118 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablestats
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("table statistics", func() {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+	})
+
+	It("gets the statistics of the largest tables", func(ctx context.Context) {
+		lastAutovacuum := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(tablesQuery).WithArgs(2).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"current_database", "schemaname", "relname", "table_bytes", "n_live_tup", "n_dead_tup",
+				"bloat_bytes", "last_autovacuum", "last_autoanalyze",
+			}).
+				AddRow("app", "public", "orders", int64(8192000), int64(750), int64(250),
+					int64(81920), lastAutovacuum, nil).
+				AddRow("app", "public", "events", int64(16384), int64(0), int64(0), nil, nil, nil))
+
+		tables, err := GetTables(ctx, db, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tables).To(Equal([]Table{
+			{
+				Database:            "app",
+				Schema:              "public",
+				Name:                "orders",
+				SizeBytes:           8192000,
+				LiveTuples:          750,
+				DeadTuples:          250,
+				EstimatedBloatBytes: ptr.To(int64(81920)),
+				LastAutovacuum:      &lastAutovacuum,
+			},
+			{
+				Database:  "app",
+				Schema:    "public",
+				Name:      "events",
+				SizeBytes: 16384,
+			},
+		}))
+		Expect(tables[0].DeadTuplesRatio()).To(BeEquivalentTo(0.25))
+		Expect(tables[1].DeadTuplesRatio()).To(BeZero())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("gets the statistics of the indexes of the largest tables", func(ctx context.Context) {
+		mock.ExpectQuery(indexesQuery).WithArgs(2).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"current_database", "schemaname", "relname", "indexrelname", "index_bytes", "bloat_bytes",
+			}).
+				AddRow("app", "public", "orders", "orders_pkey", int64(40960), int64(8192)).
+				AddRow("app", "public", "orders", "orders_lower_idx", int64(16384), nil))
+
+		indexes, err := GetIndexes(ctx, db, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(indexes).To(Equal([]Index{
+			{
+				Database:            "app",
+				Schema:              "public",
+				Table:               "orders",
+				Name:                "orders_pkey",
+				SizeBytes:           40960,
+				EstimatedBloatBytes: ptr.To(int64(8192)),
+			},
+			{
+				Database:  "app",
+				Schema:    "public",
+				Table:     "orders",
+				Name:      "orders_lower_idx",
+				SizeBytes: 16384,
+			},
+		}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("fails when the statistics cannot be read", func(ctx context.Context) {
+		mock.ExpectQuery(tablesQuery).WithArgs(2).WillReturnError(sql.ErrConnDone)
+
+		_, err := GetTables(ctx, db, 2)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/tde"
+	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// defaultVaultTransitMountPath is the default path where the Vault
+	// transit secrets engine is mounted
+	defaultVaultTransitMountPath = "transit"
+)
+
+// ReconcileTDEKeyProvider writes the configuration of the TDE key
+// provider used by the wrap and unwrap commands. When the key provider
+// changed, the data encryption key stored in the PGDATA is rewrapped with
+// the new one before replacing the configuration
+func ReconcileTDEKeyProvider(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	pgData string,
+) error {
+	if cluster.Spec.PostgresConfiguration.TDE == nil {
+		return nil
+	}
+
+	configuration, err := GetTDEKeyProviderConfiguration(ctx, cli, cluster)
+	if err != nil {
+		return fmt.Errorf("while reading the TDE key provider configuration: %w", err)
+	}
+
+	return reconcileTDEKeyProviderFile(
+		ctx,
+		configuration,
+		postgresSpec.TDEKeyProviderFile,
+		path.Join(pgData, constants.DataEncryptionKeyFile))
+}
+
+// reconcileTDEKeyProviderFile replaces the key provider configuration
+// file, rewrapping the data encryption key if the key provider changed
+func reconcileTDEKeyProviderFile(
+	ctx context.Context,
+	configuration *tde.Configuration,
+	configurationFile string,
+	keyFile string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	previous, err := tde.LoadConfiguration(configurationFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return tde.WriteConfiguration(configurationFile, configuration)
+	case err != nil:
+		return err
+	case reflect.DeepEqual(previous, configuration):
+		return nil
+	}
+
+	_, err = os.Stat(keyFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// The data directory has not been created yet
+	case err != nil:
+		return err
+	default:
+		previousProvider, err := previous.NewKeyProvider()
+		if err != nil {
+			return err
+		}
+		currentProvider, err := configuration.NewKeyProvider()
+		if err != nil {
+			return err
+		}
+		if err := tde.Rewrap(ctx, keyFile, previousProvider, currentProvider); err != nil {
+			return fmt.Errorf("while rewrapping the data encryption key: %w", err)
+		}
+		contextLogger.Info("Rewrapped the data encryption key with the new TDE key provider")
+	}
+
+	return tde.WriteConfiguration(configurationFile, configuration)
+}
+
+// GetTDEKeyProviderConfiguration gets the configuration of the TDE key
+// provider of the cluster, including the credentials read from the secrets
+func GetTDEKeyProviderConfiguration(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) (*tde.Configuration, error) {
+	getSecretKey := func(selector *apiv1.SecretKeySelector) ([]byte, error) {
+		return getTDESecretKey(ctx, cli, cluster.Namespace, selector.Name, selector.Key)
+	}
+
+	keyProvider := cluster.Spec.PostgresConfiguration.TDE.KeyProvider
+	switch {
+	case keyProvider.Secret != nil:
+		key, err := getSecretKey(keyProvider.Secret)
+		if err != nil {
+			return nil, err
+		}
+		return &tde.Configuration{Secret: &tde.SecretConfiguration{Key: key}}, nil
+
+	case keyProvider.Vault != nil:
+		token, err := getSecretKey(&keyProvider.Vault.Token)
+		if err != nil {
+			return nil, err
+		}
+		result := &tde.VaultConfiguration{
+			Address:   keyProvider.Vault.Address,
+			MountPath: keyProvider.Vault.MountPath,
+			KeyName:   keyProvider.Vault.KeyName,
+			Token:     string(token),
+		}
+		if result.MountPath == "" {
+			result.MountPath = defaultVaultTransitMountPath
+		}
+		if keyProvider.Vault.CA != nil {
+			if result.CA, err = getSecretKey(keyProvider.Vault.CA); err != nil {
+				return nil, err
+			}
+		}
+		return &tde.Configuration{Vault: result}, nil
+
+	}
+
+	return nil, tde.ErrNoKeyProvider
+}
+
+// getTDESecretKey reads a key of a secret used by the TDE key provider
+func getTDESecretKey(
+	ctx context.Context,
+	cli client.Client,
+	namespace, name, key string,
+) ([]byte, error) {
+	var secret corev1.Secret
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("while getting secret %s: %w", name, err)
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("missing key %s in secret %s", key, name)
+	}
+	return value, nil
+}
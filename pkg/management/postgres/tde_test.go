@@ -0,0 +1,88 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/tde"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TDE key provider reconciliation", func() {
+	var (
+		configurationFile string
+		keyFile           string
+	)
+
+	newConfiguration := func(seed byte) *tde.Configuration {
+		return &tde.Configuration{Secret: &tde.SecretConfiguration{Key: bytes.Repeat([]byte{seed}, 32)}}
+	}
+
+	unwrap := func(ctx context.Context, configuration *tde.Configuration) ([]byte, error) {
+		provider, err := configuration.NewKeyProvider()
+		Expect(err).ToNot(HaveOccurred())
+		wrappedKey, err := os.ReadFile(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+		return provider.Unwrap(ctx, wrappedKey)
+	}
+
+	BeforeEach(func() {
+		tempDir := GinkgoT().TempDir()
+		configurationFile = filepath.Join(tempDir, "tde", "key-provider.json")
+		keyFile = filepath.Join(tempDir, "key.bin")
+	})
+
+	It("writes the configuration at startup", func(ctx context.Context) {
+		configuration := newConfiguration('a')
+		Expect(reconcileTDEKeyProviderFile(ctx, configuration, configurationFile, keyFile)).To(Succeed())
+
+		loaded, err := tde.LoadConfiguration(configurationFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded).To(Equal(configuration))
+	})
+
+	It("rewraps the data encryption key when the key provider changes", func(ctx context.Context) {
+		previous := newConfiguration('a')
+		Expect(reconcileTDEKeyProviderFile(ctx, previous, configurationFile, keyFile)).To(Succeed())
+
+		provider, err := previous.NewKeyProvider()
+		Expect(err).ToNot(HaveOccurred())
+		wrappedKey, err := provider.Wrap(ctx, []byte("data-encryption-key"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(keyFile, wrappedKey, 0o600)).To(Succeed())
+
+		current := newConfiguration('b')
+		Expect(reconcileTDEKeyProviderFile(ctx, current, configurationFile, keyFile)).To(Succeed())
+
+		key, err := unwrap(ctx, current)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(key).To(Equal([]byte("data-encryption-key")))
+
+		_, err = unwrap(ctx, previous)
+		Expect(err).To(HaveOccurred())
+
+		loaded, err := tde.LoadConfiguration(configurationFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded).To(Equal(current))
+	})
+})
@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+)
+
+// GetOldMajorDirectory returns the path where the passed directory, which
+// can be the data or the WAL one, is kept after having upgraded the instance
+// from the passed major version
+func GetOldMajorDirectory(directory string, major int) string {
+	return fmt.Sprintf("%s-%d", directory, major)
+}
+
+// getOldMajorDirectories returns the data directories that have been
+// kept after a major version upgrade
+func getOldMajorDirectories(pgData string) ([]string, error) {
+	return filepath.Glob(pgData + "-[0-9]*")
+}
+
+// HasOldMajorDirectories checks if the data directory of a previous
+// major version has been kept after an upgrade
+func HasOldMajorDirectories(pgData string) (bool, error) {
+	directories, err := getOldMajorDirectories(pgData)
+	return len(directories) > 0, err
+}
+
+// RemoveOldMajorDirectories removes the data directories kept after a
+// major version upgrade, together with the WAL directories they use
+func RemoveOldMajorDirectories(ctx context.Context, pgData string) error {
+	contextLogger := log.FromContext(ctx)
+
+	directories, err := getOldMajorDirectories(pgData)
+	if err != nil {
+		return fmt.Errorf("while looking for the data directories of previous major versions: %w", err)
+	}
+
+	for _, directory := range directories {
+		// The WAL directory is kept outside the data directory when
+		// a WAL storage is in use
+		walDirectory, err := os.Readlink(filepath.Join(directory, pgWalDirectory))
+		if err == nil {
+			contextLogger.Info("Removing the WAL directory of a previous major version",
+				"directory", walDirectory)
+			if err := fileutils.RemoveDirectory(walDirectory); err != nil {
+				return fmt.Errorf("while removing %s: %w", walDirectory, err)
+			}
+		}
+
+		contextLogger.Info("Removing the data directory of a previous major version",
+			"directory", directory)
+		if err := fileutils.RemoveDirectory(directory); err != nil {
+			return fmt.Errorf("while removing %s: %w", directory, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Directories of previous major versions", func() {
+	var (
+		pgData string
+		pgWal  string
+	)
+
+	BeforeEach(func() {
+		tempDir := GinkgoT().TempDir()
+		pgData = filepath.Join(tempDir, "pgdata")
+		pgWal = filepath.Join(tempDir, "pg_wal")
+
+		Expect(os.Mkdir(pgData, 0o700)).To(Succeed())
+		Expect(os.Mkdir(pgWal, 0o700)).To(Succeed())
+	})
+
+	It("names the old directories after the major version", func() {
+		Expect(GetOldMajorDirectory("/var/lib/postgresql/data/pgdata", 16)).
+			To(Equal("/var/lib/postgresql/data/pgdata-16"))
+	})
+
+	It("doesn't find anything when no upgrade happened", func(ctx SpecContext) {
+		Expect(HasOldMajorDirectories(pgData)).To(BeFalse())
+		Expect(RemoveOldMajorDirectories(ctx, pgData)).To(Succeed())
+		Expect(pgData).To(BeADirectory())
+	})
+
+	It("removes the old data and WAL directories", func(ctx SpecContext) {
+		oldPgData := GetOldMajorDirectory(pgData, 16)
+		oldPgWal := GetOldMajorDirectory(pgWal, 16)
+		Expect(os.Mkdir(oldPgData, 0o700)).To(Succeed())
+		Expect(os.Mkdir(oldPgWal, 0o700)).To(Succeed())
+		Expect(os.Symlink(oldPgWal, filepath.Join(oldPgData, pgWalDirectory))).To(Succeed())
+
+		Expect(HasOldMajorDirectories(pgData)).To(BeTrue())
+		Expect(RemoveOldMajorDirectories(ctx, pgData)).To(Succeed())
+
+		Expect(oldPgData).ToNot(BeAnExistingFile())
+		Expect(oldPgWal).ToNot(BeAnExistingFile())
+		Expect(pgData).To(BeADirectory())
+		Expect(pgWal).To(BeADirectory())
+	})
+})
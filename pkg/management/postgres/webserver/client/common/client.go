@@ -24,15 +24,16 @@ import (
 	"time"
 
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 )
 
 // NewHTTPClient returns a client capable of executing HTTP methods both in HTTPS and HTTP depending on the passed
-// context
+// context. The current span of the context is propagated to the server
 func NewHTTPClient(connectionTimeout, requestTimeout time.Duration) *http.Client {
 	dialer := &net.Dialer{Timeout: connectionTimeout}
 
 	return &http.Client{
-		Transport: &http.Transport{
+		Transport: telemetry.NewTransport(&http.Transport{
 			DialContext: dialer.DialContext,
 			DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
 				tlsConfig, err := certs.GetTLSConfigFromContext(ctx)
@@ -45,7 +46,7 @@ func NewHTTPClient(connectionTimeout, requestTimeout time.Duration) *http.Client
 				}
 				return tlsDialer.DialContext(ctx, network, addr)
 			},
-		},
+		}),
 		Timeout: requestTimeout,
 	}
 }
@@ -0,0 +1,89 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/common"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+)
+
+// PoolerClient a http client capable of querying the PgBouncer
+// instances of a pooler
+type PoolerClient interface {
+	// GetStats gets the saturation indicators of the PgBouncer
+	// instance running in the passed Pod
+	GetStats(ctx context.Context, pod *corev1.Pod) (*apiv1.PoolerStats, error)
+}
+
+type poolerClientImpl struct {
+	*http.Client
+}
+
+// newPoolerClient returns a client capable of querying the PgBouncer instances
+func newPoolerClient() PoolerClient {
+	const connectionTimeout = 2 * time.Second
+	const requestTimeout = 5 * time.Second
+
+	return &poolerClientImpl{Client: common.NewHTTPClient(connectionTimeout, requestTimeout)}
+}
+
+func (r *poolerClientImpl) GetStats(ctx context.Context, pod *corev1.Pod) (*apiv1.PoolerStats, error) {
+	contextLogger := log.FromContext(ctx)
+
+	statsURL := url.Build("http", pod.Status.PodIP, url.PathPgBouncerStats, url.PgBouncerMetricsPort)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			contextLogger.Error(err, "while closing body")
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result apiv1.PoolerStats
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
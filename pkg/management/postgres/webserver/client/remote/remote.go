@@ -19,19 +19,33 @@ package remote
 // Client is the interface to interact with the remote webserver
 type Client interface {
 	Instance() InstanceClient
+	Witness() WitnessClient
+	Pooler() PoolerClient
 }
 
 type remoteClientImpl struct {
 	instance InstanceClient
+	witness  WitnessClient
+	pooler   PoolerClient
 }
 
 func (r *remoteClientImpl) Instance() InstanceClient {
 	return r.instance
 }
 
+func (r *remoteClientImpl) Witness() WitnessClient {
+	return r.witness
+}
+
+func (r *remoteClientImpl) Pooler() PoolerClient {
+	return r.pooler
+}
+
 // NewClient creates a new remote client
 func NewClient() Client {
 	return &remoteClientImpl{
 		instance: newInstanceClient(),
+		witness:  newWitnessClient(),
+		pooler:   newPoolerClient(),
 	}
 }
@@ -0,0 +1,105 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/common"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/witness"
+)
+
+// WitnessClient a http client capable of querying the witness of a cluster
+type WitnessClient interface {
+	// IsInstanceReachable asks the witness of the cluster whether the
+	// PostgreSQL instance running in the passed Pod accepts connections
+	IsInstanceReachable(
+		ctx context.Context,
+		cluster *apiv1.Cluster,
+		pod *corev1.Pod,
+	) (bool, error)
+}
+
+type witnessClientImpl struct {
+	*http.Client
+}
+
+// newWitnessClient returns a client capable of querying the witness
+func newWitnessClient() WitnessClient {
+	const connectionTimeout = 2 * time.Second
+	const requestTimeout = 10 * time.Second
+
+	return &witnessClientImpl{Client: common.NewHTTPClient(connectionTimeout, requestTimeout)}
+}
+
+func (r *witnessClientImpl) IsInstanceReachable(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pod *corev1.Pod,
+) (bool, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if pod.Status.PodIP == "" {
+		return false, nil
+	}
+
+	hostname := fmt.Sprintf("%s.%s", cluster.GetWitnessName(), cluster.Namespace)
+	reachableURL := url.Build("http", hostname, url.PathWitnessReachable, url.StatusPort) +
+		"?ip=" + neturl.QueryEscape(pod.Status.PodIP)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reachableURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			contextLogger.Error(err, "while closing body")
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result witness.ReachabilityResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, err
+	}
+
+	return result.Reachable, nil
+}
@@ -174,6 +174,21 @@ func (ws *localWebserverEndpoints) requestBackup(w http.ResponseWriter, r *http.
 		}
 		_, _ = fmt.Fprint(w, "OK")
 
+	case apiv1.BackupMethodPgBackRest:
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.PgBackRest == nil {
+			http.Error(w, "pgBackRest backup not configured in the cluster", http.StatusConflict)
+			return
+		}
+
+		if err := ws.startPgBackRestBackup(ctx, cluster, &backup); err != nil {
+			http.Error(
+				w,
+				fmt.Sprintf("error while requesting backup: %v", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		_, _ = fmt.Fprint(w, "OK")
+
 	case apiv1.BackupMethodPlugin:
 		if backup.Spec.PluginConfiguration.IsEmpty() {
 			http.Error(w, "Plugin backup not configured in the cluster", http.StatusConflict)
@@ -230,6 +245,31 @@ func (ws *localWebserverEndpoints) startBarmanBackup(
 	return nil
 }
 
+func (ws *localWebserverEndpoints) startPgBackRestBackup(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) error {
+	backupLog := log.WithValues(
+		"backupName", backup.Name,
+		"backupNamespace", backup.Name)
+
+	backupCommand := postgres.NewPgBackRestBackupCommand(
+		cluster,
+		backup,
+		ws.typedClient,
+		ws.eventRecorder,
+		ws.instance,
+		backupLog,
+	)
+
+	if err := backupCommand.Start(ctx); err != nil {
+		return fmt.Errorf("while starting backup: %w", err)
+	}
+
+	return nil
+}
+
 func (ws *localWebserverEndpoints) startPluginBackup(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
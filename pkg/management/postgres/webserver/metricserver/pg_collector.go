@@ -51,6 +51,7 @@ type Exporter struct {
 	// - to ensure we are able to unit test
 	// - to make the struct adhere to the composition pattern instead of hardcoding dependencies inside the functions
 	getCluster func() (*apiv1.Cluster, error)
+	getDB      func(database string) (*sql.DB, error)
 }
 
 // metrics here are related to the exporter itself, which is instrumented to
@@ -73,6 +74,9 @@ type metrics struct {
 	FencingOn                    prometheus.Gauge
 	PgStatWalMetrics             PgStatWalMetrics
 	NodesUsed                    prometheus.Gauge
+	ReplicaClusterMetrics        ReplicaClusterMetrics
+	QueryInsightsMetrics         *QueryInsightsMetrics
+	TableStatisticsMetrics       *TableStatisticsMetrics
 }
 
 // PgStatWalMetrics is available from PG14+
@@ -93,6 +97,9 @@ func NewExporter(instance *postgres.Instance) *Exporter {
 		instance:   instance,
 		Metrics:    newMetrics(),
 		getCluster: local.NewClient().Cache().GetCluster,
+		getDB: func(database string) (*sql.DB, error) {
+			return instance.ConnectionPool().Connection(database)
+		},
 	}
 }
 
@@ -203,6 +210,9 @@ func newMetrics() *metrics {
 				"implying the absence of High Availability (HA). Ideally this value " +
 				"should match the number of instances in the cluster.",
 		}),
+		ReplicaClusterMetrics:  newReplicaClusterMetrics(subsystem),
+		QueryInsightsMetrics:   newQueryInsightsMetrics(subsystem),
+		TableStatisticsMetrics: newTableStatisticsMetrics(subsystem),
 		PgStatWalMetrics: PgStatWalMetrics{
 			WalRecords: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Namespace: PrometheusNamespace,
@@ -281,6 +291,9 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.LastFailedBackupTimestamp.Describe(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Describe(ch)
 	e.Metrics.NodesUsed.Describe(ch)
+	e.Metrics.ReplicaClusterMetrics.describe(ch)
+	e.Metrics.QueryInsightsMetrics.describe(ch)
+	e.Metrics.TableStatisticsMetrics.describe(ch)
 
 	if e.queries != nil {
 		e.queries.Describe(ch)
@@ -319,6 +332,9 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	e.Metrics.LastFailedBackupTimestamp.Collect(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Collect(ch)
 	e.Metrics.NodesUsed.Collect(ch)
+	e.Metrics.ReplicaClusterMetrics.collect(ch)
+	e.Metrics.QueryInsightsMetrics.collect(ch)
+	e.Metrics.TableStatisticsMetrics.collect(ch)
 
 	if version, _ := e.instance.GetPgVersion(); version.Major >= 14 {
 		e.Metrics.PgStatWalMetrics.WalSync.Collect(ch)
@@ -401,6 +417,27 @@ func (e *Exporter) collectPgMetrics(ch chan<- prometheus.Metric) {
 		e.collectFromPrimaryLastFailedBackupTimestamp()
 	}
 
+	if err := e.collectReplicaClusterLag(db); err != nil {
+		log.Error(err, "while collecting replica cluster lag metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.ReplicaClusterLag").Inc()
+		e.Metrics.ReplicaClusterMetrics.reset()
+	}
+
+	if err := e.collectQueryInsights(db); err != nil {
+		log.Error(err, "while collecting the top statements metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.QueryInsights").Inc()
+		e.Metrics.QueryInsightsMetrics.reset()
+	}
+
+	if err := e.collectTableStatistics(isPrimary); err != nil {
+		log.Error(err, "while collecting the table statistics metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.TableStatistics").Inc()
+		e.Metrics.TableStatisticsMetrics.reset()
+	}
+
 	if err := collectPGWalArchiveMetric(e); err != nil {
 		log.Error(err, "while collecting WAL archive metrics", "path", specs.PgWalArchiveStatusPath)
 		e.Metrics.Error.Set(1)
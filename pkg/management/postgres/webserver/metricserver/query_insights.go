@@ -0,0 +1,140 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/querystats"
+)
+
+// QueryInsightsMetrics are the metrics of the top statements tracked by
+// `pg_stat_statements`, refreshed at every snapshot interval
+type QueryInsightsMetrics struct {
+	Calls         *prometheus.GaugeVec
+	TotalExecTime *prometheus.GaugeVec
+	MeanExecTime  *prometheus.GaugeVec
+	Rows          *prometheus.GaugeVec
+
+	// lastSnapshot is when the metrics were last refreshed
+	lastSnapshot time.Time
+}
+
+func newQueryInsightsMetrics(subsystem string) *QueryInsightsMetrics {
+	labels := []string{"datname", "usename", "queryid"}
+	return &QueryInsightsMetrics{
+		Calls: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "top_statement_calls",
+			Help: "Number of times a top statement was executed. " +
+				"Only available when the query insights are enabled",
+		}, labels),
+		TotalExecTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "top_statement_total_exec_time_seconds",
+			Help: "Total time spent executing a top statement, in seconds. " +
+				"Only available when the query insights are enabled",
+		}, labels),
+		MeanExecTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "top_statement_mean_exec_time_seconds",
+			Help: "Mean time spent executing a top statement, in seconds. " +
+				"Only available when the query insights are enabled",
+		}, labels),
+		Rows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "top_statement_rows",
+			Help: "Total number of rows retrieved or affected by a top statement. " +
+				"Only available when the query insights are enabled",
+		}, labels),
+	}
+}
+
+func (m *QueryInsightsMetrics) describe(ch chan<- *prometheus.Desc) {
+	m.Calls.Describe(ch)
+	m.TotalExecTime.Describe(ch)
+	m.MeanExecTime.Describe(ch)
+	m.Rows.Describe(ch)
+}
+
+func (m *QueryInsightsMetrics) collect(ch chan<- prometheus.Metric) {
+	m.Calls.Collect(ch)
+	m.TotalExecTime.Collect(ch)
+	m.MeanExecTime.Collect(ch)
+	m.Rows.Collect(ch)
+}
+
+func (m *QueryInsightsMetrics) reset() {
+	m.Calls.Reset()
+	m.TotalExecTime.Reset()
+	m.MeanExecTime.Reset()
+	m.Rows.Reset()
+	m.lastSnapshot = time.Time{}
+}
+
+// collectQueryInsights refreshes the metrics of the top statements
+// when the snapshot interval of the query insights has elapsed
+func (e *Exporter) collectQueryInsights(db *sql.DB) error {
+	queryInsightsMetrics := e.Metrics.QueryInsightsMetrics
+
+	cluster, err := e.getCluster()
+	if errors.Is(err, cache.ErrCacheMiss) {
+		// there isn't a cached object yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	queryInsights := cluster.GetQueryInsights()
+	if queryInsights == nil {
+		queryInsightsMetrics.reset()
+		return nil
+	}
+
+	if time.Since(queryInsightsMetrics.lastSnapshot) < queryInsights.GetSnapshotInterval() {
+		return nil
+	}
+
+	statements, err := querystats.GetTopStatements(context.Background(), db, queryInsights.GetTopN())
+	if err != nil {
+		return err
+	}
+
+	queryInsightsMetrics.reset()
+	for _, statement := range statements {
+		labels := []string{statement.Database, statement.User, strconv.FormatInt(statement.QueryID, 10)}
+		queryInsightsMetrics.Calls.WithLabelValues(labels...).Set(float64(statement.Calls))
+		queryInsightsMetrics.TotalExecTime.WithLabelValues(labels...).Set(statement.TotalExecTime / 1000)
+		queryInsightsMetrics.MeanExecTime.WithLabelValues(labels...).Set(statement.MeanExecTime / 1000)
+		queryInsightsMetrics.Rows.WithLabelValues(labels...).Set(float64(statement.Rows))
+	}
+	queryInsightsMetrics.lastSnapshot = time.Now()
+
+	return nil
+}
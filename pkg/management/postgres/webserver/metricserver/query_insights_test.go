@@ -0,0 +1,99 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("query insights metrics", func() {
+	var (
+		db       *sql.DB
+		mock     sqlmock.Sqlmock
+		exporter *Exporter
+		cluster  *apiv1.Cluster
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					QueryInsights: &apiv1.QueryInsightsConfiguration{Enabled: true, TopN: 3},
+				},
+			},
+		}
+
+		exporter = NewExporter(postgres.NewInstance().WithPodName("cluster-example-1"))
+		exporter.getCluster = func() (*apiv1.Cluster, error) {
+			return cluster, nil
+		}
+	})
+
+	expectTopStatements := func() {
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_statements").WithArgs(3).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"datname", "rolname", "queryid", "query", "calls", "total_exec_time", "mean_exec_time", "rows",
+			}).AddRow("app", "app", 42, "SELECT 1", 1000, 2500.0, 2.5, 1000))
+	}
+
+	It("exposes the top statements", func() {
+		expectTopStatements()
+
+		Expect(exporter.collectQueryInsights(db)).To(Succeed())
+
+		metrics := exporter.Metrics.QueryInsightsMetrics
+		Expect(gatherGaugeValues(metrics.Calls)).To(Equal([]float64{1000}))
+		Expect(gatherGaugeValues(metrics.TotalExecTime)).To(Equal([]float64{2.5}))
+		Expect(gatherGaugeValues(metrics.MeanExecTime)).To(Equal([]float64{0.0025}))
+		Expect(gatherGaugeValues(metrics.Rows)).To(Equal([]float64{1000}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("takes a new snapshot only when the snapshot interval has elapsed", func() {
+		expectTopStatements()
+
+		Expect(exporter.collectQueryInsights(db)).To(Succeed())
+		Expect(exporter.collectQueryInsights(db)).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.QueryInsightsMetrics.Calls)).To(HaveLen(1))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't expose anything when the query insights are disabled", func() {
+		cluster.Spec.Monitoring.QueryInsights.Enabled = false
+
+		Expect(exporter.collectQueryInsights(db)).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.QueryInsightsMetrics.Calls)).To(BeEmpty())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,136 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+)
+
+// replicaClusterLagQuery gathers the replication progress of the designated
+// primary of a replica cluster
+const replicaClusterLagQuery = `
+SELECT
+  COALESCE(pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_last_wal_receive_lsn(), '0/0'), 0),
+  COALESCE(pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_last_wal_replay_lsn(), '0/0'), 0),
+  COALESCE(GREATEST(pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_last_wal_receive_lsn(),
+    pg_catalog.pg_last_wal_replay_lsn()), 0), 0),
+  CASE WHEN pg_catalog.pg_last_wal_receive_lsn() = pg_catalog.pg_last_wal_replay_lsn() THEN 0
+    ELSE COALESCE(EXTRACT(EPOCH FROM pg_catalog.now() - pg_catalog.pg_last_xact_replay_timestamp()), 0)
+  END`
+
+// ReplicaClusterMetrics are the metrics exposed by the designated primary
+// of a replica cluster, labelled with the name of the source cluster
+type ReplicaClusterMetrics struct {
+	ReceiveLSN *prometheus.GaugeVec
+	ReplayLSN  *prometheus.GaugeVec
+	LagBytes   *prometheus.GaugeVec
+	LagSeconds *prometheus.GaugeVec
+}
+
+func newReplicaClusterMetrics(subsystem string) ReplicaClusterMetrics {
+	return ReplicaClusterMetrics{
+		ReceiveLSN: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replica_cluster_receive_lsn",
+			Help: "Last WAL location received from the source cluster by the designated primary, " +
+				"expressed in bytes. Only available in replica clusters",
+		}, []string{"source"}),
+		ReplayLSN: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replica_cluster_replay_lsn",
+			Help: "Last WAL location replayed by the designated primary, expressed in bytes. " +
+				"Only available in replica clusters",
+		}, []string{"source"}),
+		LagBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replica_cluster_lag_bytes",
+			Help: "Amount of WAL received from the source cluster and not yet replayed by " +
+				"the designated primary. Only available in replica clusters",
+		}, []string{"source"}),
+		LagSeconds: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "replica_cluster_lag_seconds",
+			Help: "Seconds elapsed since the commit time of the last transaction replayed by " +
+				"the designated primary, or zero if every received WAL has been replayed. " +
+				"Only available in replica clusters",
+		}, []string{"source"}),
+	}
+}
+
+func (m ReplicaClusterMetrics) describe(ch chan<- *prometheus.Desc) {
+	m.ReceiveLSN.Describe(ch)
+	m.ReplayLSN.Describe(ch)
+	m.LagBytes.Describe(ch)
+	m.LagSeconds.Describe(ch)
+}
+
+func (m ReplicaClusterMetrics) collect(ch chan<- prometheus.Metric) {
+	m.ReceiveLSN.Collect(ch)
+	m.ReplayLSN.Collect(ch)
+	m.LagBytes.Collect(ch)
+	m.LagSeconds.Collect(ch)
+}
+
+func (m ReplicaClusterMetrics) reset() {
+	m.ReceiveLSN.Reset()
+	m.ReplayLSN.Reset()
+	m.LagBytes.Reset()
+	m.LagSeconds.Reset()
+}
+
+// collectReplicaClusterLag collects the replication lag of the designated
+// primary of a replica cluster towards its source cluster
+func (e *Exporter) collectReplicaClusterLag(db *sql.DB) error {
+	replicaMetrics := e.Metrics.ReplicaClusterMetrics
+	replicaMetrics.reset()
+
+	cluster, err := e.getCluster()
+	if errors.Is(err, cache.ErrCacheMiss) {
+		// there isn't a cached object yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !cluster.IsReplica() || cluster.Status.CurrentPrimary != e.instance.GetPodName() {
+		return nil
+	}
+
+	var receiveLSN, replayLSN, lagBytes, lagSeconds float64
+	row := db.QueryRow(replicaClusterLagQuery)
+	if err := row.Scan(&receiveLSN, &replayLSN, &lagBytes, &lagSeconds); err != nil {
+		return err
+	}
+
+	source := cluster.GetReplicaSource()
+	replicaMetrics.ReceiveLSN.WithLabelValues(source).Set(receiveLSN)
+	replicaMetrics.ReplayLSN.WithLabelValues(source).Set(replayLSN)
+	replicaMetrics.LagBytes.WithLabelValues(source).Set(lagBytes)
+	replicaMetrics.LagSeconds.WithLabelValues(source).Set(lagSeconds)
+
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func gatherGaugeValues(collector prometheus.Collector) []float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+	metrics, err := registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	var result []float64
+	for _, metric := range metrics {
+		for _, m := range metric.GetMetric() {
+			result = append(result, m.GetGauge().GetValue())
+		}
+	}
+	return result
+}
+
+var _ = Describe("replica cluster lag metrics", func() {
+	var (
+		db       *sql.DB
+		mock     sqlmock.Sqlmock
+		exporter *Exporter
+		cluster  *apiv1.Cluster
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+					Enabled: ptr.To(true),
+					Source:  "cluster-origin",
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+			},
+		}
+
+		exporter = NewExporter(postgres.NewInstance().WithPodName("cluster-example-1"))
+		exporter.getCluster = func() (*apiv1.Cluster, error) {
+			return cluster, nil
+		}
+	})
+
+	It("collects the lag on the designated primary", func() {
+		mock.ExpectQuery(replicaClusterLagQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"receive", "replay", "lag_bytes", "lag_seconds"}).
+				AddRow(2048, 1024, 1024, 12.5))
+
+		Expect(exporter.collectReplicaClusterLag(db)).To(Succeed())
+
+		metrics := exporter.Metrics.ReplicaClusterMetrics
+		Expect(gatherGaugeValues(metrics.ReceiveLSN)).To(Equal([]float64{2048}))
+		Expect(gatherGaugeValues(metrics.ReplayLSN)).To(Equal([]float64{1024}))
+		Expect(gatherGaugeValues(metrics.LagBytes)).To(Equal([]float64{1024}))
+		Expect(gatherGaugeValues(metrics.LagSeconds)).To(Equal([]float64{12.5}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't collect anything on the other instances", func() {
+		cluster.Status.CurrentPrimary = "cluster-example-2"
+
+		Expect(exporter.collectReplicaClusterLag(db)).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.ReplicaClusterMetrics.LagBytes)).To(BeEmpty())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't collect anything in a primary cluster", func() {
+		cluster.Spec.ReplicaCluster.Enabled = ptr.To(false)
+
+		Expect(exporter.collectReplicaClusterLag(db)).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.ReplicaClusterMetrics.LagBytes)).To(BeEmpty())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,223 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/cache"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/tablestats"
+)
+
+// TableStatisticsMetrics are the metrics about the bloat and the vacuuming
+// of the largest tables, sampled at every sampling interval
+type TableStatisticsMetrics struct {
+	TableSize          *prometheus.GaugeVec
+	TableBloat         *prometheus.GaugeVec
+	DeadTuplesRatio    *prometheus.GaugeVec
+	LastAutovacuumAge  *prometheus.GaugeVec
+	LastAutoanalyzeAge *prometheus.GaugeVec
+	IndexSize          *prometheus.GaugeVec
+	IndexBloat         *prometheus.GaugeVec
+
+	// tables and indexes are the last sampled statistics
+	tables  []tablestats.Table
+	indexes []tablestats.Index
+
+	// lastSample is when the statistics were last sampled
+	lastSample time.Time
+}
+
+func newTableStatisticsMetrics(subsystem string) *TableStatisticsMetrics {
+	tableLabels := []string{"datname", "schemaname", "relname"}
+	indexLabels := []string{"datname", "schemaname", "relname", "indexrelname"}
+	return &TableStatisticsMetrics{
+		TableSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "table_size_bytes",
+			Help: "Size of a table, including its TOAST table. " +
+				"Only available when the table statistics are enabled",
+		}, tableLabels),
+		TableBloat: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "table_bloat_bytes",
+			Help: "Estimated free space of a table, in bytes. " +
+				"Only available when the table statistics are enabled and the table was analyzed",
+		}, tableLabels),
+		DeadTuplesRatio: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "table_dead_tuples_ratio",
+			Help: "Ratio, between 0 and 1, of the dead tuples of a table. " +
+				"Only available when the table statistics are enabled",
+		}, tableLabels),
+		LastAutovacuumAge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "table_last_autovacuum_age_seconds",
+			Help: "Seconds elapsed since a table was last vacuumed by autovacuum. " +
+				"Only available when the table statistics are enabled and the table was autovacuumed",
+		}, tableLabels),
+		LastAutoanalyzeAge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "table_last_autoanalyze_age_seconds",
+			Help: "Seconds elapsed since a table was last analyzed by autovacuum. " +
+				"Only available when the table statistics are enabled and the table was autoanalyzed",
+		}, tableLabels),
+		IndexSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "index_size_bytes",
+			Help: "Size of an index of one of the largest tables. " +
+				"Only available when the table statistics are enabled",
+		}, indexLabels),
+		IndexBloat: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "index_bloat_bytes",
+			Help: "Estimated free space of a B-tree index of one of the largest tables, in bytes. " +
+				"Only available when the table statistics are enabled and the indexed columns were analyzed",
+		}, indexLabels),
+	}
+}
+
+func (m *TableStatisticsMetrics) describe(ch chan<- *prometheus.Desc) {
+	m.TableSize.Describe(ch)
+	m.TableBloat.Describe(ch)
+	m.DeadTuplesRatio.Describe(ch)
+	m.LastAutovacuumAge.Describe(ch)
+	m.LastAutoanalyzeAge.Describe(ch)
+	m.IndexSize.Describe(ch)
+	m.IndexBloat.Describe(ch)
+}
+
+func (m *TableStatisticsMetrics) collect(ch chan<- prometheus.Metric) {
+	m.TableSize.Collect(ch)
+	m.TableBloat.Collect(ch)
+	m.DeadTuplesRatio.Collect(ch)
+	m.LastAutovacuumAge.Collect(ch)
+	m.LastAutoanalyzeAge.Collect(ch)
+	m.IndexSize.Collect(ch)
+	m.IndexBloat.Collect(ch)
+}
+
+func (m *TableStatisticsMetrics) resetGauges() {
+	m.TableSize.Reset()
+	m.TableBloat.Reset()
+	m.DeadTuplesRatio.Reset()
+	m.LastAutovacuumAge.Reset()
+	m.LastAutoanalyzeAge.Reset()
+	m.IndexSize.Reset()
+	m.IndexBloat.Reset()
+}
+
+func (m *TableStatisticsMetrics) reset() {
+	m.resetGauges()
+	m.tables = nil
+	m.indexes = nil
+	m.lastSample = time.Time{}
+}
+
+// update sets the metrics from the last sampled statistics. The ages
+// of the last autovacuum and autoanalyze are computed at every scrape
+func (m *TableStatisticsMetrics) update(now time.Time) {
+	m.resetGauges()
+	for _, table := range m.tables {
+		labels := []string{table.Database, table.Schema, table.Name}
+		m.TableSize.WithLabelValues(labels...).Set(float64(table.SizeBytes))
+		m.DeadTuplesRatio.WithLabelValues(labels...).Set(table.DeadTuplesRatio())
+		if table.EstimatedBloatBytes != nil {
+			m.TableBloat.WithLabelValues(labels...).Set(float64(*table.EstimatedBloatBytes))
+		}
+		if table.LastAutovacuum != nil {
+			m.LastAutovacuumAge.WithLabelValues(labels...).Set(now.Sub(*table.LastAutovacuum).Seconds())
+		}
+		if table.LastAutoanalyze != nil {
+			m.LastAutoanalyzeAge.WithLabelValues(labels...).Set(now.Sub(*table.LastAutoanalyze).Seconds())
+		}
+	}
+
+	for _, index := range m.indexes {
+		labels := []string{index.Database, index.Schema, index.Table, index.Name}
+		m.IndexSize.WithLabelValues(labels...).Set(float64(index.SizeBytes))
+		if index.EstimatedBloatBytes != nil {
+			m.IndexBloat.WithLabelValues(labels...).Set(float64(*index.EstimatedBloatBytes))
+		}
+	}
+}
+
+// collectTableStatistics samples the statistics of the largest tables
+// when the sampling interval has elapsed. The statistics are sampled
+// by the primary instance only
+func (e *Exporter) collectTableStatistics(isPrimary bool) error {
+	tableStatisticsMetrics := e.Metrics.TableStatisticsMetrics
+
+	cluster, err := e.getCluster()
+	if errors.Is(err, cache.ErrCacheMiss) {
+		// there isn't a cached object yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tableStatistics := cluster.GetTableStatistics()
+	if tableStatistics == nil || !isPrimary {
+		tableStatisticsMetrics.reset()
+		return nil
+	}
+
+	if time.Since(tableStatisticsMetrics.lastSample) >= tableStatistics.GetSamplingInterval() {
+		var (
+			tables  []tablestats.Table
+			indexes []tablestats.Index
+		)
+		for _, database := range cluster.GetTableStatisticsDatabases() {
+			db, err := e.getDB(database)
+			if err != nil {
+				return fmt.Errorf("while connecting to database %s: %w", database, err)
+			}
+
+			databaseTables, err := tablestats.GetTables(context.Background(), db, tableStatistics.GetTopN())
+			if err != nil {
+				return fmt.Errorf("while sampling the tables of database %s: %w", database, err)
+			}
+			databaseIndexes, err := tablestats.GetIndexes(context.Background(), db, tableStatistics.GetTopN())
+			if err != nil {
+				return fmt.Errorf("while sampling the indexes of database %s: %w", database, err)
+			}
+
+			tables = append(tables, databaseTables...)
+			indexes = append(indexes, databaseIndexes...)
+		}
+
+		tableStatisticsMetrics.tables = tables
+		tableStatisticsMetrics.indexes = indexes
+		tableStatisticsMetrics.lastSample = time.Now()
+	}
+
+	tableStatisticsMetrics.update(time.Now())
+	return nil
+}
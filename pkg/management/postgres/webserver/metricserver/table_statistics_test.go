@@ -0,0 +1,127 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("table statistics metrics", func() {
+	var (
+		db        *sql.DB
+		mock      sqlmock.Sqlmock
+		exporter  *Exporter
+		cluster   *apiv1.Cluster
+		databases []string
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					TableStatistics: &apiv1.TableStatisticsConfiguration{
+						Enabled:   true,
+						Databases: []string{"app"},
+						TopN:      3,
+					},
+				},
+			},
+		}
+
+		databases = nil
+		exporter = NewExporter(postgres.NewInstance().WithPodName("cluster-example-1"))
+		exporter.getCluster = func() (*apiv1.Cluster, error) {
+			return cluster, nil
+		}
+		exporter.getDB = func(database string) (*sql.DB, error) {
+			databases = append(databases, database)
+			return db, nil
+		}
+	})
+
+	expectStatistics := func() {
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_user_tables").WithArgs(3).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"current_database", "schemaname", "relname", "table_bytes", "n_live_tup", "n_dead_tup",
+				"bloat_bytes", "last_autovacuum", "last_autoanalyze",
+			}).AddRow("app", "public", "orders", int64(8192000), int64(900), int64(100),
+				int64(81920), time.Now().Add(-time.Hour), nil))
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_user_tables").WithArgs(3).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"current_database", "schemaname", "relname", "indexrelname", "index_bytes", "bloat_bytes",
+			}).AddRow("app", "public", "orders", "orders_pkey", int64(40960), int64(8192)))
+	}
+
+	It("exposes the statistics of the largest tables", func() {
+		expectStatistics()
+
+		Expect(exporter.collectTableStatistics(true)).To(Succeed())
+
+		metrics := exporter.Metrics.TableStatisticsMetrics
+		Expect(databases).To(Equal([]string{"app"}))
+		Expect(gatherGaugeValues(metrics.TableSize)).To(Equal([]float64{8192000}))
+		Expect(gatherGaugeValues(metrics.TableBloat)).To(Equal([]float64{81920}))
+		Expect(gatherGaugeValues(metrics.DeadTuplesRatio)).To(Equal([]float64{0.1}))
+		Expect(gatherGaugeValues(metrics.LastAutovacuumAge)).To(ConsistOf(BeNumerically("~", 3600, 60)))
+		Expect(gatherGaugeValues(metrics.LastAutoanalyzeAge)).To(BeEmpty())
+		Expect(gatherGaugeValues(metrics.IndexSize)).To(Equal([]float64{40960}))
+		Expect(gatherGaugeValues(metrics.IndexBloat)).To(Equal([]float64{8192}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("samples the statistics only when the sampling interval has elapsed", func() {
+		expectStatistics()
+
+		Expect(exporter.collectTableStatistics(true)).To(Succeed())
+		Expect(exporter.collectTableStatistics(true)).To(Succeed())
+		Expect(databases).To(HaveLen(1))
+		Expect(gatherGaugeValues(exporter.Metrics.TableStatisticsMetrics.TableSize)).To(HaveLen(1))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't expose anything on the replicas", func() {
+		Expect(exporter.collectTableStatistics(false)).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.TableStatisticsMetrics.TableSize)).To(BeEmpty())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't expose anything when the table statistics are disabled", func() {
+		cluster.Spec.Monitoring.TableStatistics.Enabled = false
+
+		Expect(exporter.collectTableStatistics(true)).To(Succeed())
+		Expect(gatherGaugeValues(exporter.Metrics.TableStatisticsMetrics.TableSize)).To(BeEmpty())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
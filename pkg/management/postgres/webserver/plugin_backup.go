@@ -35,6 +35,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 )
 
 // PluginBackupCommand represent a backup command that is being executed
@@ -79,6 +80,9 @@ func (b *PluginBackupCommand) Start(ctx context.Context) {
 }
 
 func (b *PluginBackupCommand) invokeStart(ctx context.Context) {
+	ctx, span := postgres.StartBackupSpan(ctx, b.Backup)
+	defer span.End()
+
 	contextLogger := log.FromContext(ctx).WithValues(
 		"pluginConfiguration", b.Backup.Spec.PluginConfiguration,
 		"backupName", b.Backup.Name,
@@ -170,6 +174,7 @@ func (b *PluginBackupCommand) markBackupAsFailed(ctx context.Context, failure er
 	// record the failure
 	contextLogger.Error(failure, "Backup failed")
 	b.Recorder.Event(b.Backup, "Normal", "Failed", "Backup failed")
+	telemetry.SpanFromContext(ctx).RecordError(failure)
 
 	// update backup status as failed
 	backupStatus.SetAsFailed(failure)
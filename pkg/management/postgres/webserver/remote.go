@@ -41,6 +41,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/readiness"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/upgrade"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -87,12 +88,13 @@ func NewRemoteWebServer(
 	}
 
 	serveMux := http.NewServeMux()
-	serveMux.HandleFunc(url.PathPgModeBackup, endpoints.backup)
-	serveMux.HandleFunc(url.PathHealth, endpoints.isServerHealthy)
-	serveMux.HandleFunc(url.PathReady, endpoints.isServerReady)
-	serveMux.HandleFunc(url.PathPgStatus, endpoints.pgStatus)
-	serveMux.HandleFunc(url.PathPgArchivePartial, endpoints.pgArchivePartial)
-	serveMux.HandleFunc(url.PathPGControlData, endpoints.pgControlData)
+	serveMux.HandleFunc(url.PathPgModeBackup, telemetry.WrapHandler("instance.backup_mode", endpoints.backup))
+	serveMux.HandleFunc(url.PathHealth, telemetry.WrapHandler("instance.probe.liveness", endpoints.isServerHealthy))
+	serveMux.HandleFunc(url.PathReady, telemetry.WrapHandler("instance.probe.readiness", endpoints.isServerReady))
+	serveMux.HandleFunc(url.PathPgStatus, telemetry.WrapHandler("instance.status", endpoints.pgStatus))
+	serveMux.HandleFunc(url.PathPgArchivePartial,
+		telemetry.WrapHandler("instance.archive_partial", endpoints.pgArchivePartial))
+	serveMux.HandleFunc(url.PathPGControlData, telemetry.WrapHandler("instance.controldata", endpoints.pgControlData))
 	serveMux.HandleFunc(url.PathUpdate, endpoints.updateInstanceManager(cancelFunc, exitedConditions))
 
 	server := &http.Server{
@@ -0,0 +1,196 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tde
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// keyEncryptionKeySize is the size of the AES-256 key encryption key
+const keyEncryptionKeySize = 32
+
+// ErrNoKeyProvider is returned when the configuration doesn't
+// contain any key provider
+var ErrNoKeyProvider = errors.New("no TDE key provider configured")
+
+// KeyProvider wraps and unwraps the data encryption key of an instance
+// with a key encryption key
+type KeyProvider interface {
+	// Wrap encrypts the data encryption key
+	Wrap(ctx context.Context, key []byte) ([]byte, error)
+
+	// Unwrap decrypts a data encryption key encrypted by Wrap
+	Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+// Configuration is the configuration of the key provider of an instance,
+// including the credentials retrieved from the Kubernetes secrets.
+// Exactly one provider is expected to be set
+type Configuration struct {
+	// Secret is the configuration of a key encryption key stored
+	// in a Kubernetes secret
+	Secret *SecretConfiguration `json:"secret,omitempty"`
+
+	// Vault is the configuration of a key encryption key stored in
+	// the transit secrets engine of HashiCorp Vault
+	Vault *VaultConfiguration `json:"vault,omitempty"`
+}
+
+// SecretConfiguration contains the key encryption key stored in
+// a Kubernetes secret
+type SecretConfiguration struct {
+	// Key is the content of the secret key, which must be made of
+	// 32 random bytes, as it is directly used as an AES-256 key
+	Key []byte `json:"key"`
+}
+
+// NewKeyProvider creates the key provider described by the configuration
+func (c *Configuration) NewKeyProvider() (KeyProvider, error) {
+	switch {
+	case c.Secret != nil:
+		if len(c.Secret.Key) != keyEncryptionKeySize {
+			return nil, fmt.Errorf(
+				"the TDE key encryption key must be made of %d random bytes, got %d bytes",
+				keyEncryptionKeySize, len(c.Secret.Key))
+		}
+		key := c.Secret.Key
+		return &localKeyProvider{getKey: func(context.Context) ([]byte, error) {
+			return key, nil
+		}}, nil
+	case c.Vault != nil:
+		return newVaultKeyProvider(c.Vault)
+	default:
+		return nil, ErrNoKeyProvider
+	}
+}
+
+// LoadConfiguration reads the key provider configuration from a file
+// written by WriteConfiguration
+func LoadConfiguration(fileName string) (*Configuration, error) {
+	content, err := os.ReadFile(fileName) // #nosec
+	if err != nil {
+		return nil, err
+	}
+
+	var result Configuration
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("while decoding the TDE key provider configuration: %w", err)
+	}
+	return &result, nil
+}
+
+// WriteConfiguration atomically writes the key provider configuration
+// to a file only readable by the current user
+func WriteConfiguration(fileName string, configuration *Configuration) error {
+	content, err := json.Marshal(configuration)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(fileName, content)
+}
+
+// writeFileAtomically writes the content to a temporary file, syncing
+// it before renaming it to the target one
+func writeFileAtomically(fileName string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o700); err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tempFile.Name())
+	}()
+
+	if _, err := tempFile.Write(content); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), fileName)
+}
+
+// localKeyProvider wraps the data encryption key with AES-256-GCM,
+// using a key encryption key known to the instance manager
+type localKeyProvider struct {
+	getKey func(ctx context.Context) ([]byte, error)
+}
+
+// Wrap implements the KeyProvider interface
+func (p *localKeyProvider) Wrap(ctx context.Context, key []byte) ([]byte, error) {
+	aead, err := p.newAEAD(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+// Unwrap implements the KeyProvider interface
+func (p *localKeyProvider) Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	aead, err := p.newAEAD(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrappedKey) < aead.NonceSize() {
+		return nil, errors.New("wrapped key too short")
+	}
+	nonce, ciphertext := wrappedKey[:aead.NonceSize()], wrappedKey[aead.NonceSize():]
+	key, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("while unwrapping the data encryption key: %w", err)
+	}
+	return key, nil
+}
+
+func (p *localKeyProvider) newAEAD(ctx context.Context) (cipher.AEAD, error) {
+	key, err := p.getKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keyEncryptionKeySize {
+		return nil, fmt.Errorf("the key encryption key must be %d bytes long, got %d", keyEncryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
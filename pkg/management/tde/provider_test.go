@@ -0,0 +1,122 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tde
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("secret key provider", func() {
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	newProvider := func(seed byte) KeyProvider {
+		configuration := &Configuration{Secret: &SecretConfiguration{Key: bytes.Repeat([]byte{seed}, 32)}}
+		provider, err := configuration.NewKeyProvider()
+		Expect(err).ToNot(HaveOccurred())
+		return provider
+	}
+
+	It("wraps and unwraps the data encryption key", func(ctx context.Context) {
+		provider := newProvider('a')
+		wrappedKey, err := provider.Wrap(ctx, dataKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(wrappedKey).ToNot(ContainSubstring(string(dataKey)))
+
+		key, err := provider.Unwrap(ctx, wrappedKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(key).To(Equal(dataKey))
+	})
+
+	It("doesn't unwrap a key wrapped with another key", func(ctx context.Context) {
+		wrappedKey, err := newProvider('a').Wrap(ctx, dataKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = newProvider('b').Unwrap(ctx, wrappedKey)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("requires a 32 bytes key", func() {
+		configuration := &Configuration{Secret: &SecretConfiguration{Key: []byte("passphrase")}}
+		_, err := configuration.NewKeyProvider()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("requires a key provider", func() {
+		_, err := (&Configuration{}).NewKeyProvider()
+		Expect(err).To(MatchError(ErrNoKeyProvider))
+	})
+
+	It("rewraps the data encryption key with a new key provider", func(ctx context.Context) {
+		previous := newProvider('a')
+		current := newProvider('c')
+
+		keyFile := filepath.Join(GinkgoT().TempDir(), "key.bin")
+		wrappedKey, err := previous.Wrap(ctx, dataKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(keyFile, wrappedKey, 0o600)).To(Succeed())
+
+		Expect(Rewrap(ctx, keyFile, previous, current)).To(Succeed())
+
+		wrappedKey, err = os.ReadFile(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+		key, err := current.Unwrap(ctx, wrappedKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(key).To(Equal(dataKey))
+	})
+
+	It("doesn't touch the key file when the previous key provider can't unwrap it", func(ctx context.Context) {
+		keyFile := filepath.Join(GinkgoT().TempDir(), "key.bin")
+		wrappedKey, err := newProvider('a').Wrap(ctx, dataKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(keyFile, wrappedKey, 0o600)).To(Succeed())
+
+		Expect(Rewrap(ctx, keyFile, newProvider('d'), newProvider('c'))).ToNot(Succeed())
+
+		content, err := os.ReadFile(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).To(Equal(wrappedKey))
+	})
+})
+
+var _ = Describe("key provider configuration file", func() {
+	It("writes and reads the configuration", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "tde", "key-provider.json")
+		configuration := &Configuration{
+			Vault: &VaultConfiguration{
+				Address:   "https://vault.example.com:8200",
+				MountPath: "transit",
+				KeyName:   "postgres",
+				Token:     "token",
+			},
+		}
+		Expect(WriteConfiguration(fileName, configuration)).To(Succeed())
+
+		info, err := os.Stat(fileName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+		loaded, err := LoadConfiguration(fileName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded).To(Equal(configuration))
+	})
+})
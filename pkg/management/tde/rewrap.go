@@ -0,0 +1,56 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tde
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Rewrap decrypts the data encryption key stored in the passed file
+// with the previous key provider, and replaces it with the one wrapped
+// by the new key provider. The new wrapped key is verified before
+// replacing the file
+func Rewrap(ctx context.Context, keyFile string, previous, current KeyProvider) error {
+	wrappedKey, err := os.ReadFile(keyFile) // #nosec
+	if err != nil {
+		return err
+	}
+
+	key, err := previous.Unwrap(ctx, wrappedKey)
+	if err != nil {
+		return fmt.Errorf("while unwrapping with the previous key provider: %w", err)
+	}
+
+	newWrappedKey, err := current.Wrap(ctx, key)
+	if err != nil {
+		return fmt.Errorf("while wrapping with the new key provider: %w", err)
+	}
+
+	verifiedKey, err := current.Unwrap(ctx, newWrappedKey)
+	if err != nil {
+		return fmt.Errorf("while verifying the new wrapped key: %w", err)
+	}
+	if !bytes.Equal(verifiedKey, key) {
+		return errors.New("the new wrapped key doesn't match the data encryption key")
+	}
+
+	return writeFileAtomically(keyFile, newWrappedKey)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tde
+
+import (
+	"context"
+	"encoding/base64"
+	"path"
+	"strings"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/vault"
+)
+
+// VaultConfiguration contains the parameters needed to use a key of
+// the transit secrets engine of HashiCorp Vault
+type VaultConfiguration struct {
+	// Address is the URL of the Vault server
+	Address string `json:"address"`
+
+	// MountPath is the path where the transit secrets engine is mounted
+	MountPath string `json:"mountPath"`
+
+	// KeyName is the name of the transit key
+	KeyName string `json:"keyName"`
+
+	// Token is the Vault token used to authenticate the requests
+	Token string `json:"token"`
+
+	// CA is the PEM encoded CA certificate of the Vault server
+	CA []byte `json:"ca,omitempty"`
+}
+
+// vaultKeyProvider wraps the data encryption key using the
+// encrypt and decrypt endpoints of the Vault transit secrets engine,
+// without the key encryption key ever leaving Vault
+type vaultKeyProvider struct {
+	configuration *VaultConfiguration
+	client        *vault.Client
+}
+
+func newVaultKeyProvider(configuration *VaultConfiguration) (*vaultKeyProvider, error) {
+	client, err := vault.NewClient(configuration.Address, configuration.Token, configuration.CA)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vaultKeyProvider{
+		configuration: configuration,
+		client:        client,
+	}, nil
+}
+
+// Wrap implements the KeyProvider interface
+func (p *vaultKeyProvider) Wrap(ctx context.Context, key []byte) ([]byte, error) {
+	var response struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := p.post(ctx, "encrypt", map[string]any{
+		"plaintext": base64.StdEncoding.EncodeToString(key),
+	}, &response); err != nil {
+		return nil, err
+	}
+	return []byte(response.Ciphertext), nil
+}
+
+// Unwrap implements the KeyProvider interface
+func (p *vaultKeyProvider) Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	var response struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := p.post(ctx, "decrypt", map[string]any{
+		"ciphertext": string(wrappedKey),
+	}, &response); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Plaintext)
+}
+
+// post invokes an endpoint of the transit secrets engine, decoding
+// the data of the response
+func (p *vaultKeyProvider) post(ctx context.Context, operation string, request map[string]any, data any) error {
+	return p.client.Write(ctx,
+		path.Join(strings.Trim(p.configuration.MountPath, "/"), operation, p.configuration.KeyName),
+		request, data)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tde
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("vault key provider", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		// A fake transit secrets engine, "encrypting" the data
+		// by prefixing it
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+
+			var request map[string]string
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+
+			switch r.URL.Path {
+			case "/v1/transit/encrypt/postgres":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"data": map[string]string{"ciphertext": "vault:v1:" + request["plaintext"]},
+				})
+			case "/v1/transit/decrypt/postgres":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"data": map[string]string{"plaintext": strings.TrimPrefix(request["ciphertext"], "vault:v1:")},
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+			}
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	newProvider := func(token string) KeyProvider {
+		configuration := &Configuration{Vault: &VaultConfiguration{
+			Address:   server.URL,
+			MountPath: "/transit/",
+			KeyName:   "postgres",
+			Token:     token,
+		}}
+		provider, err := configuration.NewKeyProvider()
+		Expect(err).ToNot(HaveOccurred())
+		return provider
+	}
+
+	It("wraps and unwraps the data encryption key with the transit engine", func(ctx context.Context) {
+		provider := newProvider("token")
+		wrappedKey, err := provider.Wrap(ctx, []byte("data-key"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(wrappedKey)).To(HavePrefix("vault:v1:"))
+
+		key, err := provider.Unwrap(ctx, wrappedKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(key).To(Equal([]byte("data-key")))
+	})
+
+	It("reports the errors returned by Vault", func(ctx context.Context) {
+		_, err := newProvider("wrong").Wrap(ctx, []byte("data-key"))
+		Expect(err).To(MatchError(ContainSubstring("permission denied")))
+	})
+})
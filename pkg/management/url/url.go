@@ -58,12 +58,20 @@ const (
 	// PathMetrics is the URL path for Metrics
 	PathMetrics string = "/metrics"
 
+	// PathPgBouncerStats is the URL path for the saturation indicators
+	// of a PgBouncer instance
+	PathPgBouncerStats string = "/pgbouncer/stats"
+
 	// PathUpdate is the URL path for the instance manager update function
 	PathUpdate string = "/update"
 
 	// PathCache is the URL path for cached resources
 	PathCache string = "/cache/"
 
+	// PathWitnessReachable is the URL path used to ask the witness
+	// whether an instance is reachable
+	PathWitnessReachable string = "/witness/reachable"
+
 	// StatusPort is the port for status HTTP requests
 	StatusPort int32 = 8000
 )
@@ -0,0 +1,124 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package witness contains the web server of the witness, a lightweight
+// member of a PostgreSQL cluster storing no data, which the operator
+// consults before promoting a replica
+package witness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// dialTimeout is the time allowed to connect to an instance
+const dialTimeout = 3 * time.Second
+
+// ReachabilityResponse is the answer of the witness
+// about the reachability of an instance
+type ReachabilityResponse struct {
+	// Reachable is true when the instance accepts connections
+	Reachable bool `json:"reachable"`
+}
+
+// Server is the web server of the witness
+type Server struct {
+	server *http.Server
+
+	// postgresPort is the port the instances are accepting connections on
+	postgresPort int
+}
+
+// NewServer creates the web server of the witness
+func NewServer() *Server {
+	ws := &Server{
+		postgresPort: postgres.ServerPort,
+	}
+
+	serveMux := http.NewServeMux()
+	serveMux.HandleFunc(url.PathHealth, ws.isServerHealthy)
+	serveMux.HandleFunc(url.PathWitnessReachable, ws.isInstanceReachable)
+
+	ws.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", url.StatusPort),
+		Handler:           serveMux,
+		ReadTimeout:       webserver.DefaultReadTimeout,
+		ReadHeaderTimeout: webserver.DefaultReadHeaderTimeout,
+	}
+
+	return ws
+}
+
+// ListenAndServe starts the web server of the witness
+func (ws *Server) ListenAndServe() error {
+	err := ws.server.ListenAndServe()
+
+	// The server has been shut down
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops the web server of the witness
+func (ws *Server) Shutdown(ctx context.Context) error {
+	return ws.server.Shutdown(ctx)
+}
+
+func (ws *Server) isServerHealthy(w http.ResponseWriter, _ *http.Request) {
+	_, _ = fmt.Fprint(w, "OK")
+}
+
+// isInstanceReachable checks whether the PostgreSQL instance running
+// on the requested IP address accepts TCP connections. Only IP addresses
+// are accepted, to avoid the witness being used to probe arbitrary hosts
+func (ws *Server) isInstanceReachable(w http.ResponseWriter, r *http.Request) {
+	contextLogger := log.FromContext(r.Context())
+
+	ip := net.ParseIP(r.URL.Query().Get("ip"))
+	if ip == nil {
+		http.Error(w, "a valid IP address is required", http.StatusBadRequest)
+		return
+	}
+
+	var response ReachabilityResponse
+	address := net.JoinHostPort(ip.String(), strconv.Itoa(ws.postgresPort))
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err == nil {
+		response.Reachable = true
+		_ = conn.Close()
+	} else {
+		contextLogger.Info("Instance not reachable", "address", address, "error", err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		contextLogger.Error(err, "while writing the response")
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package witness
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("witness web server", func() {
+	var (
+		listener net.Listener
+		ws       *Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = listener.Close()
+		})
+
+		ws = NewServer()
+		ws.postgresPort = listener.Addr().(*net.TCPAddr).Port
+	})
+
+	checkReachability := func(ip string) (*httptest.ResponseRecorder, ReachabilityResponse) {
+		request := httptest.NewRequest(http.MethodGet, url.PathWitnessReachable+"?ip="+ip, nil)
+		recorder := httptest.NewRecorder()
+		ws.isInstanceReachable(recorder, request)
+
+		var response ReachabilityResponse
+		if recorder.Code == http.StatusOK {
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+		}
+		return recorder, response
+	}
+
+	It("reports an instance accepting connections as reachable", func() {
+		recorder, response := checkReachability("127.0.0.1")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(response.Reachable).To(BeTrue())
+	})
+
+	It("reports an instance not accepting connections as not reachable", func() {
+		Expect(listener.Close()).To(Succeed())
+
+		recorder, response := checkReachability("127.0.0.1")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(response.Reachable).To(BeFalse())
+	})
+
+	It("refuses host names", func() {
+		recorder, _ := checkReachability("example.com")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+})
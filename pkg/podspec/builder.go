@@ -19,6 +19,7 @@ package podspec
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
@@ -66,6 +67,28 @@ func (builder *Builder) WithLabel(name, value string) *Builder {
 	return builder
 }
 
+// WithTopologySpreadConstraintsSelector sets the passed label selector
+// in the topology spread constraints not specifying one, so that they
+// match the pods generated from this template
+func (builder *Builder) WithTopologySpreadConstraintsSelector(selector *metav1.LabelSelector) *Builder {
+	if len(builder.status.Spec.TopologySpreadConstraints) == 0 {
+		return builder
+	}
+
+	// The constraints are shared with the Pod template we started
+	// from, and must be copied before being changed
+	constraints := make([]corev1.TopologySpreadConstraint, len(builder.status.Spec.TopologySpreadConstraints))
+	for idx, constraint := range builder.status.Spec.TopologySpreadConstraints {
+		constraints[idx] = *constraint.DeepCopy()
+		if constraints[idx].LabelSelector == nil {
+			constraints[idx].LabelSelector = selector.DeepCopy()
+		}
+	}
+	builder.status.Spec.TopologySpreadConstraints = constraints
+
+	return builder
+}
+
 // WithVolume adds a volume to the current podTemplate, replacing the current
 // definition if present
 func (builder *Builder) WithVolume(volume *corev1.Volume) *Builder {
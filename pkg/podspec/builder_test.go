@@ -18,6 +18,7 @@ package podspec
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 
@@ -266,4 +267,22 @@ var _ = Describe("Pod template builder", func() {
 		Expect(template.Spec.Containers[0].Env[0].Name).To(Equal("one"))
 		Expect(template.Spec.Containers[0].Env[0].Value).To(Equal("two"))
 	})
+
+	It("sets the label selector of the topology spread constraints without one", func() {
+		userSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+		podTemplate := &apiv1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone"},
+					{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", LabelSelector: userSelector},
+				},
+			},
+		}
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"name": "pooler"}}
+
+		template := NewFrom(podTemplate).WithTopologySpreadConstraintsSelector(selector).Build()
+		Expect(template.Spec.TopologySpreadConstraints[0].LabelSelector).To(Equal(selector))
+		Expect(template.Spec.TopologySpreadConstraints[1].LabelSelector).To(Equal(userSelector))
+		Expect(podTemplate.Spec.TopologySpreadConstraints[0].LabelSelector).To(BeNil())
+	})
 })
@@ -92,6 +92,11 @@ local all all peer map=local
 hostssl postgres streaming_replica all cert
 hostssl replication streaming_replica all cert
 hostssl all cnpg_pooler_pgbouncer all cert
+{{- if .RequireTLS }}
+
+# Reject the connections not using TLS (security profile)
+hostnossl all all all reject
+{{- end }}
 
 #
 # USER-DEFINED RULES
@@ -111,7 +116,7 @@ hostssl all cnpg_pooler_pgbouncer all cert
 #
 # DEFAULT RULES
 #
-host all all all {{.DefaultAuthenticationMethod}}
+{{ if .RequireTLS }}hostssl{{ else }}host{{ end }} all all all {{.DefaultAuthenticationMethod}}
 `
 
 	// identTemplateString is the template used to generate the pg_ident.conf
@@ -123,6 +128,12 @@ host all all all {{.DefaultAuthenticationMethod}}
 
 # Grant local access ('local' user map)
 local {{.Username}} postgres
+{{- if .CertificateMap }}
+
+# Authenticate the client certificates whose common name
+# matches the user name ('{{.CertificateMap}}' user map)
+{{.CertificateMap}} /^(.*)$ \1
+{{- end }}
 
 #
 # USER-DEFINED RULES
@@ -133,6 +144,10 @@ local {{.Username}} postgres
 {{ end }}
 `
 
+	// CertificateIdentMap is the name of the pg_ident user map used to
+	// authenticate the client certificates when the cluster requires them
+	CertificateIdentMap = "cnpg_tls"
+
 	// fixedConfigurationParameter are the configuration parameters
 	// whose value is managed by the operator and should not be changed
 	// by the user
@@ -154,12 +169,48 @@ local {{.Username}} postgres
 	// were pre-archived in parallel
 	SpoolDirectory = ScratchDataDirectory + "/wal-archive-spool"
 
+	// MirrorSpoolDirectory is the directory where we spool the WAL files
+	// that still need to be copied to the secondary object store. It is
+	// kept in the PGDATA volume, next to the data directory, to survive
+	// restarts
+	MirrorSpoolDirectory = "/var/lib/postgresql/data/wal-mirror-spool"
+
+	// MirrorSkippedDirectory is the directory containing an empty file for
+	// every WAL file that has not been spooled to be copied to the secondary
+	// object store, because the spool was full
+	MirrorSkippedDirectory = "/var/lib/postgresql/data/wal-mirror-skipped"
+
+	// ScheduledDumpDirectory is the directory where the logical dumps are
+	// written before being copied to the object store
+	ScheduledDumpDirectory = "/var/lib/postgresql/data/scheduled-dumps"
+
+	// AuditLogDirectory is the directory where the audit log records are
+	// buffered before being uploaded to the object store
+	AuditLogDirectory = "/var/lib/postgresql/data/audit-logs"
+
 	// CertificatesDir location to store the certificates
 	CertificatesDir = ScratchDataDirectory + "/certificates/"
 
 	// ProjectedVolumeDirectory is the base directory to store ProjectedVolumeSource
 	ProjectedVolumeDirectory = "/projected"
 
+	// OAuthValidatorDirectory is the directory where the image containing
+	// the OAuth validator library is mounted
+	OAuthValidatorDirectory = "/oauth-validator"
+
+	// TDEKeyProviderFile is the file where the instance manager stores the
+	// configuration of the TDE key provider, including the credentials
+	// read from the secrets
+	TDEKeyProviderFile = ScratchDataDirectory + "/tde/key-provider.json"
+
+	// TDEWrapCommand is the command wrapping the data encryption key read
+	// from the standard input into the passed file, used by initdb
+	TDEWrapCommand = "/controller/manager instance tde wrap %p"
+
+	// TDEUnwrapCommand is the command writing to the standard output the
+	// data encryption key unwrapped from the passed file
+	TDEUnwrapCommand = "/controller/manager instance tde unwrap %p"
+
 	// ServerCertificateLocation is the location where the server certificate
 	// is stored
 	ServerCertificateLocation = CertificatesDir + "server.crt"
@@ -325,11 +376,28 @@ type ConfigurationInfo struct {
 	// IsWalArchivingDisabled is true when user requested to disable WAL archiving
 	IsWalArchivingDisabled bool
 
+	// IsWALArchivedByStandby is true when the WAL files are archived by
+	// a standby instance elected by the operator
+	IsWALArchivedByStandby bool
+
 	// IsAlterSystemEnabled is true when 'allow_alter_system' should be set to on
 	IsAlterSystemEnabled bool
 
 	// Minimum apply delay of transaction
 	RecoveryMinApplyDelay time.Duration
+
+	// SynchronizeLogicalSlots is true when the logical replication slots
+	// should be synchronized to the standby instances
+	SynchronizeLogicalSlots bool
+
+	// SynchronizedLogicalSlotNames is the list of the logical replication slots
+	// to be synchronized by pg_failover_slots. When empty, every slot is synchronized
+	SynchronizedLogicalSlotNames []string
+
+	// SynchronizedStandbySlots is the list of the physical replication slots
+	// of the standby instances that need to receive the WAL before it is sent
+	// to the logical decoding consumers
+	SynchronizedStandbySlots []string
 }
 
 // getAlterSystemEnabledValue returns a config compatible value for IsAlterSystemEnabled
@@ -400,39 +468,40 @@ var (
 	// changed by the user
 	FixedConfigurationParameters = map[string]string{
 		// The following parameters need a restart to be applied
-		"allow_system_table_mods":   blockedConfigurationParameter,
-		"archive_mode":              fixedConfigurationParameter,
-		"bonjour":                   blockedConfigurationParameter,
-		"bonjour_name":              blockedConfigurationParameter,
-		"cluster_name":              fixedConfigurationParameter,
-		"config_file":               blockedConfigurationParameter,
-		"data_directory":            blockedConfigurationParameter,
-		"data_sync_retry":           blockedConfigurationParameter,
-		"event_source":              blockedConfigurationParameter,
-		"external_pid_file":         blockedConfigurationParameter,
-		"hba_file":                  blockedConfigurationParameter,
-		"hot_standby":               blockedConfigurationParameter,
-		"ident_file":                blockedConfigurationParameter,
-		"jit_provider":              blockedConfigurationParameter,
-		"listen_addresses":          blockedConfigurationParameter,
-		"logging_collector":         blockedConfigurationParameter,
-		"port":                      fixedConfigurationParameter,
-		"primary_conninfo":          fixedConfigurationParameter,
-		"primary_slot_name":         fixedConfigurationParameter,
-		"recovery_target":           fixedConfigurationParameter,
-		"recovery_target_action":    fixedConfigurationParameter,
-		"recovery_target_inclusive": fixedConfigurationParameter,
-		"recovery_target_lsn":       fixedConfigurationParameter,
-		"recovery_target_name":      fixedConfigurationParameter,
-		"recovery_target_time":      fixedConfigurationParameter,
-		"recovery_target_timeline":  fixedConfigurationParameter,
-		"recovery_target_xid":       fixedConfigurationParameter,
-		"restore_command":           fixedConfigurationParameter,
-		"shared_preload_libraries":  fixedConfigurationParameter,
-		"temp_tablespaces":          fixedConfigurationParameter,
-		"unix_socket_directories":   blockedConfigurationParameter,
-		"unix_socket_group":         blockedConfigurationParameter,
-		"unix_socket_permissions":   blockedConfigurationParameter,
+		"allow_system_table_mods":            blockedConfigurationParameter,
+		"archive_mode":                       fixedConfigurationParameter,
+		"bonjour":                            blockedConfigurationParameter,
+		"bonjour_name":                       blockedConfigurationParameter,
+		"cluster_name":                       fixedConfigurationParameter,
+		"config_file":                        blockedConfigurationParameter,
+		"data_directory":                     blockedConfigurationParameter,
+		"data_encryption_key_unwrap_command": fixedConfigurationParameter,
+		"data_sync_retry":                    blockedConfigurationParameter,
+		"event_source":                       blockedConfigurationParameter,
+		"external_pid_file":                  blockedConfigurationParameter,
+		"hba_file":                           blockedConfigurationParameter,
+		"hot_standby":                        blockedConfigurationParameter,
+		"ident_file":                         blockedConfigurationParameter,
+		"jit_provider":                       blockedConfigurationParameter,
+		"listen_addresses":                   blockedConfigurationParameter,
+		"logging_collector":                  blockedConfigurationParameter,
+		"port":                               fixedConfigurationParameter,
+		"primary_conninfo":                   fixedConfigurationParameter,
+		"primary_slot_name":                  fixedConfigurationParameter,
+		"recovery_target":                    fixedConfigurationParameter,
+		"recovery_target_action":             fixedConfigurationParameter,
+		"recovery_target_inclusive":          fixedConfigurationParameter,
+		"recovery_target_lsn":                fixedConfigurationParameter,
+		"recovery_target_name":               fixedConfigurationParameter,
+		"recovery_target_time":               fixedConfigurationParameter,
+		"recovery_target_timeline":           fixedConfigurationParameter,
+		"recovery_target_xid":                fixedConfigurationParameter,
+		"restore_command":                    fixedConfigurationParameter,
+		"shared_preload_libraries":           fixedConfigurationParameter,
+		"temp_tablespaces":                   fixedConfigurationParameter,
+		"unix_socket_directories":            blockedConfigurationParameter,
+		"unix_socket_group":                  blockedConfigurationParameter,
+		"unix_socket_permissions":            blockedConfigurationParameter,
 
 		// The following parameters need a reload to be applied
 		"archive_cleanup_command":                blockedConfigurationParameter,
@@ -444,6 +513,7 @@ var (
 		"log_rotation_age":                       blockedConfigurationParameter,
 		"log_rotation_size":                      blockedConfigurationParameter,
 		"log_truncate_on_rotation":               blockedConfigurationParameter,
+		"oauth_validator_libraries":              fixedConfigurationParameter,
 		"pg_failover_slots.primary_dsn":          fixedConfigurationParameter,
 		"promote_trigger_file":                   blockedConfigurationParameter,
 		"recovery_end_command":                   blockedConfigurationParameter,
@@ -528,9 +598,11 @@ var (
 )
 
 // CreateHBARules will create the content of pg_hba.conf file given
-// the rules set by the cluster spec
+// the rules set by the cluster spec. When requireTLS is set, the
+// connections not using TLS are rejected
 func CreateHBARules(hba []string,
 	defaultAuthenticationMethod, ldapConfigString string,
+	requireTLS bool,
 ) (string, error) {
 	var hbaContent bytes.Buffer
 
@@ -538,10 +610,12 @@ func CreateHBARules(hba []string,
 		UserRules                   []string
 		LDAPConfiguration           string
 		DefaultAuthenticationMethod string
+		RequireTLS                  bool
 	}{
 		UserRules:                   hba,
 		LDAPConfiguration:           ldapConfigString,
 		DefaultAuthenticationMethod: defaultAuthenticationMethod,
+		RequireTLS:                  requireTLS,
 	}
 
 	if err := hbaTemplate.Execute(&hbaContent, templateData); err != nil {
@@ -552,16 +626,20 @@ func CreateHBARules(hba []string,
 }
 
 // CreateIdentRules will create the content of pg_ident.conf file given
-// the rules set by the cluster spec
-func CreateIdentRules(ident []string, username string) (string, error) {
+// the rules set by the cluster spec. When certificateMap is not empty,
+// a user map with that name authenticating the client certificates
+// whose common name matches the user name is added
+func CreateIdentRules(ident []string, username string, certificateMap string) (string, error) {
 	var identContent bytes.Buffer
 
 	templateData := struct {
-		Mappings []string
-		Username string
+		Mappings       []string
+		Username       string
+		CertificateMap string
 	}{
-		Mappings: ident,
-		Username: username,
+		Mappings:       ident,
+		Username:       username,
+		CertificateMap: certificateMap,
 	}
 
 	if err := identTemplate.Execute(&identContent, templateData); err != nil {
@@ -661,7 +739,7 @@ func CreatePostgresqlConfiguration(info ConfigurationInfo) *PgConfiguration {
 	case info.IsWalArchivingDisabled:
 		configuration.OverwriteConfig("archive_mode", "off")
 
-	case info.IsReplicaCluster:
+	case info.IsReplicaCluster, info.IsWALArchivedByStandby:
 		configuration.OverwriteConfig("archive_mode", "always")
 
 	default:
@@ -694,6 +772,11 @@ func CreatePostgresqlConfiguration(info ConfigurationInfo) *PgConfiguration {
 			fmt.Sprintf("%vs", math.Floor(info.RecoveryMinApplyDelay.Seconds())))
 	}
 
+	// Apply the logical replication slots synchronization settings
+	if info.SynchronizeLogicalSlots {
+		setLogicalSlotsSynchronization(info, configuration)
+	}
+
 	if info.IncludingSharedPreloadLibraries {
 		// Set all managed shared preload libraries
 		setManagedSharedPreloadLibraries(info, configuration)
@@ -742,6 +825,40 @@ func setManagedSharedPreloadLibraries(info ConfigurationInfo, configuration *PgC
 			}
 		}
 	}
+
+	// Before PostgreSQL 17, the logical replication slots
+	// are synchronized by pg_failover_slots
+	if info.SynchronizeLogicalSlots && info.Version.Major() < 17 {
+		configuration.AddSharedPreloadLibrary("pg_failover_slots")
+	}
+}
+
+// setLogicalSlotsSynchronization sets the parameters needed to synchronize
+// the logical replication slots to the standby instances, using the native
+// feature of PostgreSQL 17 or the pg_failover_slots extension
+func setLogicalSlotsSynchronization(info ConfigurationInfo, configuration *PgConfiguration) {
+	// The primary must not remove the rows needed by the
+	// synchronized slots while the standbys are using them
+	configuration.OverwriteConfig("hot_standby_feedback", "on")
+
+	standbySlots := strings.Join(info.SynchronizedStandbySlots, ",")
+	if info.Version.Major() >= 17 {
+		configuration.OverwriteConfig("sync_replication_slots", "on")
+		configuration.OverwriteConfig("synchronized_standby_slots", standbySlots)
+		return
+	}
+
+	configuration.OverwriteConfig("pg_failover_slots.standby_slot_names", standbySlots)
+	if len(info.SynchronizedLogicalSlotNames) == 0 {
+		configuration.OverwriteConfig("pg_failover_slots.synchronize_slot_names", "name_like:%")
+		return
+	}
+
+	slotNames := make([]string, len(info.SynchronizedLogicalSlotNames))
+	for idx, slotName := range info.SynchronizedLogicalSlotNames {
+		slotNames[idx] = "name:" + slotName
+	}
+	configuration.OverwriteConfig("pg_failover_slots.synchronize_slot_names", strings.Join(slotNames, ","))
 }
 
 // setUserSharedPreloadLibraries sets all additional preloaded libraries.
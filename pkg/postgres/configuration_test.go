@@ -136,6 +136,20 @@ var _ = Describe("PostgreSQL configuration creation", func() {
 		})
 	})
 
+	When("the WAL files are archived by a standby", func() {
+		It("will set archive_mode to always", func() {
+			info := ConfigurationInfo{
+				Settings:               CnpgConfigurationSettings,
+				Version:                version.New(13, 0),
+				UserSettings:           settings,
+				IncludingMandatory:     true,
+				IsWALArchivedByStandby: true,
+			}
+			config := CreatePostgresqlConfiguration(info)
+			Expect(config.GetConfig("archive_mode")).To(Equal("always"))
+		})
+	})
+
 	It("adds shared_preload_library correctly", func() {
 		info := ConfigurationInfo{
 			Settings:                         CnpgConfigurationSettings,
@@ -250,19 +264,27 @@ var _ = Describe("pg_hba.conf generation", func() {
 	}
 
 	It("insert the spec configuration between an header and a footer when the version can not be parsed", func() {
-		Expect(CreateHBARules(specRules, "md5", "")).To(
+		Expect(CreateHBARules(specRules, "md5", "", false)).To(
 			ContainSubstring("\ntwo\n"))
 	})
 
 	It("really use the passed default authentication method", func() {
-		Expect(CreateHBARules(specRules, "this-one", "")).To(
+		Expect(CreateHBARules(specRules, "this-one", "", false)).To(
 			ContainSubstring("\nhost all all all this-one\n"))
 	})
 
 	It("really uses the ldapConfigString", func() {
-		Expect(CreateHBARules(specRules, "defaultAuthenticationMethod", "ldapConfigString")).To(
+		Expect(CreateHBARules(specRules, "defaultAuthenticationMethod", "ldapConfigString", false)).To(
 			ContainSubstring("\nldapConfigString\n"))
 	})
+
+	It("rejects the connections not using TLS when required", func() {
+		rules, err := CreateHBARules(specRules, "scram-sha-256", "", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(ContainSubstring("\nhostnossl all all all reject\n"))
+		Expect(rules).To(ContainSubstring("\nhostssl all all all scram-sha-256\n"))
+		Expect(rules).ToNot(ContainSubstring("\nhost all all all"))
+	})
 })
 
 var _ = Describe("pg_ident.conf generation", func() {
@@ -271,15 +293,26 @@ var _ = Describe("pg_ident.conf generation", func() {
 	}
 
 	It("contains the default map when no mappings are added", func() {
-		Expect(CreateIdentRules(make([]string, 0), "someone")).To(
+		Expect(CreateIdentRules(make([]string, 0), "someone", "")).To(
 			ContainSubstring("\nlocal someone postgres\n"))
 	})
 
 	It("contains the default map and additional mappings when added", func() {
-		rules, _ := CreateIdentRules(specRules, "someone")
+		rules, _ := CreateIdentRules(specRules, "someone", "")
 		Expect(rules).To(ContainSubstring("\nlocal someone postgres\n"))
 		Expect(rules).To(ContainSubstring("\ntest someone else\n"))
 	})
+
+	It("contains the certificate map when requested", func() {
+		rules, err := CreateIdentRules(specRules, "someone", CertificateIdentMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(ContainSubstring("\ncnpg_tls /^(.*)$ \\1\n"))
+		Expect(rules).To(ContainSubstring("\ntest someone else\n"))
+	})
+
+	It("doesn't contain the certificate map by default", func() {
+		Expect(CreateIdentRules(specRules, "someone", "")).ToNot(ContainSubstring(CertificateIdentMap))
+	})
 })
 
 var _ = Describe("pgaudit", func() {
@@ -376,6 +409,51 @@ var _ = Describe("pg_failover_slots", func() {
 	})
 })
 
+var _ = Describe("logical replication slots synchronization", func() {
+	newInfo := func(major uint64) ConfigurationInfo {
+		return ConfigurationInfo{
+			Settings:                        CnpgConfigurationSettings,
+			Version:                         version.New(major, 0),
+			IncludingMandatory:              true,
+			IncludingSharedPreloadLibraries: true,
+			SynchronizeLogicalSlots:         true,
+			SynchronizedStandbySlots:        []string{"_cnpg_cluster_example_2", "_cnpg_cluster_example_3"},
+		}
+	}
+
+	It("uses the native slot synchronization with PostgreSQL 17", func() {
+		config := CreatePostgresqlConfiguration(newInfo(17))
+		Expect(config.GetConfig("hot_standby_feedback")).To(Equal("on"))
+		Expect(config.GetConfig("sync_replication_slots")).To(Equal("on"))
+		Expect(config.GetConfig("synchronized_standby_slots")).To(
+			Equal("_cnpg_cluster_example_2,_cnpg_cluster_example_3"))
+		Expect(config.GetConfig(SharedPreloadLibraries)).ToNot(ContainSubstring("pg_failover_slots"))
+	})
+
+	It("uses pg_failover_slots with older versions", func() {
+		info := newInfo(16)
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("hot_standby_feedback")).To(Equal("on"))
+		Expect(config.GetConfig("sync_replication_slots")).To(BeEmpty())
+		Expect(config.GetConfig("pg_failover_slots.standby_slot_names")).To(
+			Equal("_cnpg_cluster_example_2,_cnpg_cluster_example_3"))
+		Expect(config.GetConfig("pg_failover_slots.synchronize_slot_names")).To(Equal("name_like:%"))
+		Expect(strings.Split(config.GetConfig(SharedPreloadLibraries), ",")).To(ContainElement("pg_failover_slots"))
+
+		info.SynchronizedLogicalSlotNames = []string{"sub1", "sub2"}
+		config = CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("pg_failover_slots.synchronize_slot_names")).To(Equal("name:sub1,name:sub2"))
+	})
+
+	It("doesn't change the configuration when disabled", func() {
+		info := newInfo(17)
+		info.SynchronizeLogicalSlots = false
+		config := CreatePostgresqlConfiguration(info)
+		Expect(config.GetConfig("sync_replication_slots")).To(BeEmpty())
+		Expect(config.GetConfig("synchronized_standby_slots")).To(BeEmpty())
+	})
+})
+
 var _ = Describe("recovery_min_apply_delay", func() {
 	It("is not added when zero", func() {
 		info := ConfigurationInfo{
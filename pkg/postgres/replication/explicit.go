@@ -51,11 +51,16 @@ func explicitSynchronousStandbyNamesDataDurabilityRequired(cluster *apiv1.Cluste
 		clusterInstancesList = clusterInstancesList[:*config.MaxStandbyNamesFromCluster]
 	}
 
+	// The designated primaries of the synchronous replica clusters
+	// are listed after the local instances
+	replicaClustersList := cluster.GetSynchronousReplicaClusterNames()
+
 	// Add prefix and suffix
 	instancesList := make([]string, 0,
-		len(clusterInstancesList)+len(config.StandbyNamesPre)+len(config.StandbyNamesPost))
+		len(clusterInstancesList)+len(replicaClustersList)+len(config.StandbyNamesPre)+len(config.StandbyNamesPost))
 	instancesList = append(instancesList, config.StandbyNamesPre...)
 	instancesList = append(instancesList, clusterInstancesList...)
+	instancesList = append(instancesList, replicaClustersList...)
 	instancesList = append(instancesList, config.StandbyNamesPost...)
 
 	// An empty instances list would generate a PostgreSQL syntax error
@@ -77,7 +82,7 @@ func explicitSynchronousStandbyNamesDataDurabilityRequired(cluster *apiv1.Cluste
 	return fmt.Sprintf(
 		"%s %v (%v)",
 		config.Method.ToPostgreSQLConfigurationKeyword(),
-		config.Number,
+		getSynchronousReplicaNumber(cluster),
 		strings.Join(escapedReplicas, ","))
 }
 
@@ -100,7 +105,7 @@ func explicitSynchronousStandbyNamesDataDurabilityPreferred(cluster *apiv1.Clust
 
 	// If data durability is not enforced, we cap the number of synchronous
 	// replicas to be required to the number or available replicas.
-	syncReplicaNumber := config.Number
+	syncReplicaNumber := getSynchronousReplicaNumber(cluster)
 	if syncReplicaNumber > len(instancesList) {
 		syncReplicaNumber = len(instancesList)
 	}
@@ -117,6 +122,27 @@ func explicitSynchronousStandbyNamesDataDurabilityPreferred(cluster *apiv1.Clust
 		strings.Join(escapedReplicas, ","))
 }
 
+// getSynchronousReplicaNumber gets the number of synchronous standby servers
+// that transactions must wait for. When a quorum percentage is configured, it
+// is computed from the ready replicas of the cluster, never going below the
+// configured number
+func getSynchronousReplicaNumber(cluster *apiv1.Cluster) int {
+	config := cluster.Spec.PostgresConfiguration.Synchronous
+	if config.QuorumPercentage == nil {
+		return config.Number
+	}
+
+	readyReplicas := getSortedNonPrimaryHealthyInstanceNames(cluster)
+	if config.MaxStandbyNamesFromCluster != nil && len(readyReplicas) > *config.MaxStandbyNamesFromCluster {
+		readyReplicas = readyReplicas[:*config.MaxStandbyNamesFromCluster]
+	}
+
+	// Round up, so that the quorum is never smaller than the percentage
+	percentage := *config.QuorumPercentage
+	quorum := (len(readyReplicas)*percentage + 99) / 100
+	return max(quorum, config.Number)
+}
+
 // getSortedInstanceNames gets a list of all the known PostgreSQL instances in a
 // order that would be meaningful to be used by `synchronous_standby_names`.
 //
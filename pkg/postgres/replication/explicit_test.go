@@ -104,6 +104,30 @@ var _ = Describe("synchronous replica configuration with the new API", func() {
 				Equal("FIRST 2 (\"prefix\",\"here\",\"three\",\"suffix\",\"there\")"))
 		})
 
+		It("adds the synchronous replica clusters after the local instances", func() {
+			cluster := createFakeCluster("example")
+			cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
+				Method:                     apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number:                     1,
+				MaxStandbyNamesFromCluster: ptr.To(1),
+				StandbyNamesPost:           []string{"suffix"},
+			}
+			cluster.Spec.ExternalClusters = []apiv1.ExternalCluster{
+				{Name: "example", SynchronousReplica: true},
+				{Name: "example-dr", SynchronousReplica: true},
+				{Name: "example-async"},
+			}
+			cluster.Status = apiv1.ClusterStatus{
+				CurrentPrimary: "one",
+				InstancesStatus: map[apiv1.PodStatus][]string{
+					apiv1.PodHealthy: {"one", "two", "three"},
+				},
+			}
+
+			Expect(explicitSynchronousStandbyNames(cluster)).To(
+				Equal("ANY 1 (\"three\",\"example-dr\",\"suffix\")"))
+		})
+
 		It("enforce synchronous replication even if there are no healthy replicas", func() {
 			cluster := createFakeCluster("example")
 			cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
@@ -253,4 +277,50 @@ var _ = Describe("synchronous replica configuration with the new API", func() {
 			Expect(explicitSynchronousStandbyNames(cluster)).To(Equal("FIRST 1 (\"three\")"))
 		})
 	})
+
+	When("A quorum percentage is configured", func() {
+		var cluster *apiv1.Cluster
+
+		BeforeEach(func() {
+			cluster = createFakeCluster("example")
+			cluster.Spec.PostgresConfiguration.Synchronous = &apiv1.SynchronousReplicaConfiguration{
+				Method:           apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number:           1,
+				QuorumPercentage: ptr.To(50),
+			}
+			cluster.Status = apiv1.ClusterStatus{
+				CurrentPrimary: "one",
+				InstancesStatus: map[apiv1.PodStatus][]string{
+					apiv1.PodHealthy: {"one", "two", "three", "four", "five"},
+				},
+				InstanceNames: []string{"one", "two", "three", "four", "five"},
+			}
+		})
+
+		It("computes the quorum from the ready replicas", func() {
+			Expect(explicitSynchronousStandbyNames(cluster)).To(
+				Equal("ANY 2 (\"five\",\"four\",\"three\",\"two\",\"one\")"))
+		})
+
+		It("rounds the quorum up", func() {
+			cluster.Status.InstancesStatus[apiv1.PodHealthy] = []string{"one", "two", "three", "four"}
+			Expect(explicitSynchronousStandbyNames(cluster)).To(
+				Equal("ANY 2 (\"four\",\"three\",\"two\",\"five\",\"one\")"))
+		})
+
+		It("never goes below the configured number", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = 3
+			Expect(explicitSynchronousStandbyNames(cluster)).To(
+				Equal("ANY 3 (\"five\",\"four\",\"three\",\"two\",\"one\")"))
+		})
+
+		It("shrinks the quorum when replicas become unavailable with preferred data durability", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.DataDurability = apiv1.DataDurabilityLevelPreferred
+			Expect(explicitSynchronousStandbyNames(cluster)).To(
+				Equal("ANY 2 (\"five\",\"four\",\"three\",\"two\")"))
+
+			cluster.Status.InstancesStatus[apiv1.PodHealthy] = []string{"one", "two"}
+			Expect(explicitSynchronousStandbyNames(cluster)).To(Equal("ANY 1 (\"two\")"))
+		})
+	})
 })
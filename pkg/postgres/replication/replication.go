@@ -39,3 +39,20 @@ func GetSynchronousStandbyNames(cluster *apiv1.Cluster) string {
 
 	return legacySynchronousStandbyNames(cluster)
 }
+
+// GetSynchronizedStandbySlots gets the physical replication slots of the
+// healthy standby instances, which need to confirm the receipt of the WAL
+// before it is sent to the logical decoding consumers
+func GetSynchronizedStandbySlots(cluster *apiv1.Cluster) []string {
+	if !cluster.IsLogicalSlotsSynchronizationEnabled() {
+		return nil
+	}
+
+	instanceNames := getSortedNonPrimaryHealthyInstanceNames(cluster)
+	slotNames := make([]string, 0, len(instanceNames))
+	for _, instanceName := range instanceNames {
+		slotNames = append(slotNames, cluster.GetSlotNameFromInstanceName(instanceName))
+	}
+
+	return slotNames
+}
@@ -0,0 +1,43 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("synchronized standby slots", func() {
+	It("is empty when the logical replication slots are not synchronized", func() {
+		cluster := createFakeCluster("example")
+		Expect(GetSynchronizedStandbySlots(cluster)).To(BeEmpty())
+	})
+
+	It("lists the slots of the healthy standby instances", func() {
+		cluster := createFakeCluster("example")
+		cluster.Spec.ReplicationSlots.SynchronizeLogicalSlots = &apiv1.SynchronizeLogicalSlotsConfiguration{
+			Enabled: true,
+		}
+		Expect(GetSynchronizedStandbySlots(cluster)).To(Equal([]string{"_cnpg_example_2", "_cnpg_example_3"}))
+
+		cluster.Status.InstancesStatus[apiv1.PodHealthy] = []string{"example-1", "example-3"}
+		cluster.Status.InstancesStatus[apiv1.PodFailed] = []string{"example-2"}
+		Expect(GetSynchronizedStandbySlots(cluster)).To(Equal([]string{"_cnpg_example_3"}))
+	})
+})
@@ -81,6 +81,19 @@ type PostgresqlStatus struct {
 	InstanceArch               string `json:"instanceArch"`
 	IsInstanceManagerUpgrading bool   `json:"isInstanceManagerUpgrading"`
 
+	// The fingerprints of the TLS certificates and of the CA bundles
+	// currently deployed in the instance, used to coordinate the
+	// rotation of the certificates
+	TLSCertificatesFingerprint string `json:"tlsCertificatesFingerprint,omitempty"`
+	TLSCAFingerprint           string `json:"tlsCAFingerprint,omitempty"`
+
+	// The parameters whose value has been set with ALTER SYSTEM,
+	// read from the postgresql.auto.conf file
+	AlterSystemParameters []string `json:"alterSystemParameters,omitempty"`
+
+	// The disk usage of the volumes mounted by the instance
+	VolumesUsage []VolumeUsage `json:"volumesUsage,omitempty"`
+
 	// This field represents the Kubelet point-of-view of the readiness
 	// status of this instance and may be slightly stale when the Kubelet has
 	// not still invoked the readiness probe.
@@ -121,6 +134,40 @@ type PgStatBasebackup struct {
 	TablespacesStreamed  int64  `json:"tablespaces_streamed"`
 }
 
+// VolumeUsage contains the disk usage of a volume mounted by the instance
+type VolumeUsage struct {
+	Role           utils.PVCRole `json:"role"`
+	TablespaceName string        `json:"tablespaceName,omitempty"`
+	TotalBytes     uint64        `json:"totalBytes"`
+	AvailableBytes uint64        `json:"availableBytes"`
+}
+
+// GetUsedPercentage gets the percentage of the volume that is in use
+func (usage VolumeUsage) GetUsedPercentage() int {
+	if usage.TotalBytes == 0 {
+		return 0
+	}
+	return int((usage.TotalBytes - min(usage.AvailableBytes, usage.TotalBytes)) * 100 / usage.TotalBytes)
+}
+
+// GetWALVolumeUsage gets the disk usage of the volume containing the WAL
+// files, which is the PGDATA one when there is no dedicated WAL volume.
+// The second return value is false when the usage has not been reported
+func (status PostgresqlStatus) GetWALVolumeUsage() (VolumeUsage, bool) {
+	var result VolumeUsage
+	found := false
+	for _, usage := range status.VolumesUsage {
+		switch usage.Role {
+		case utils.PVCRolePgWal:
+			return usage, true
+		case utils.PVCRolePgData:
+			result = usage
+			found = true
+		}
+	}
+	return result, found
+}
+
 // AddPod store the Pod inside the status
 func (status *PostgresqlStatus) AddPod(pod corev1.Pod) {
 	status.Pod = &pod
@@ -162,6 +209,7 @@ type PgReplicationSlot struct {
 	RestartLsn  string `json:"restartLsn,omitempty"`
 	WalStatus   string `json:"walStatus,omitempty"`
 	SafeWalSize *int   `json:"safeWalSize,omitempty"`
+	LagBytes    *int64 `json:"lagBytes,omitempty"`
 	Active      bool   `json:"active,omitempty"`
 }
 
@@ -0,0 +1,126 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+var maxAgeRegex = regexp.MustCompile(`^([1-9][0-9]*)([dwm])$`)
+
+// ParseMaxAge returns the duration expressed by a maximum age, or by
+// a recovery window, in the `XXu` format used in the Cluster spec
+func ParseMaxAge(maxAge string) (time.Duration, error) {
+	matches := maxAgeRegex.FindStringSubmatch(maxAge)
+	if len(matches) < 3 {
+		return 0, fmt.Errorf("not a valid maximum age: %s", maxAge)
+	}
+
+	value, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, fmt.Errorf("not a valid maximum age: %s", maxAge)
+	}
+
+	day := 24 * time.Hour
+	switch matches[2] {
+	case "w":
+		return time.Duration(value) * 7 * day, nil
+	case "m":
+		return time.Duration(value) * 30 * day, nil
+	default:
+		return time.Duration(value) * day, nil
+	}
+}
+
+// BackupTime is the time used to compute the age of a backup
+func BackupTime(backup *apiv1.Backup) time.Time {
+	if backup.Status.StoppedAt != nil {
+		return backup.Status.StoppedAt.Time
+	}
+	return backup.CreationTimestamp.Time
+}
+
+// ExpiredBackups returns the completed backups accepted by the passed
+// filter which are out of the passed retention policy, and the time after
+// which the next retained backup will fall out of the policy, if any.
+// The most recent completed backup is never expired by the maximum age.
+func ExpiredBackups(
+	backups []apiv1.Backup,
+	policy *apiv1.VolumeSnapshotRetentionPolicy,
+	now time.Time,
+	filter func(backup *apiv1.Backup) bool,
+) ([]apiv1.Backup, *time.Time, error) {
+	if policy == nil {
+		return nil, nil, nil
+	}
+
+	var maxAge time.Duration
+	if policy.MaxAge != "" {
+		var err error
+		if maxAge, err = ParseMaxAge(policy.MaxAge); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	completedBackups := make([]apiv1.Backup, 0, len(backups))
+	for idx := range backups {
+		backup := &backups[idx]
+		if backup.Status.Phase == apiv1.BackupPhaseCompleted &&
+			backup.DeletionTimestamp == nil &&
+			filter(backup) {
+			completedBackups = append(completedBackups, *backup)
+		}
+	}
+
+	// Sort the backups starting from the most recent one
+	sort.Slice(completedBackups, func(i, j int) bool {
+		return BackupTime(&completedBackups[i]).After(BackupTime(&completedBackups[j]))
+	})
+
+	var expired []apiv1.Backup
+	var nextExpiration *time.Time
+	for idx := range completedBackups {
+		backup := completedBackups[idx]
+
+		if policy.KeepLast != nil && idx >= *policy.KeepLast {
+			expired = append(expired, backup)
+			continue
+		}
+
+		if maxAge == 0 || idx == 0 {
+			continue
+		}
+
+		expiration := BackupTime(&backup).Add(maxAge)
+		if !expiration.After(now) {
+			expired = append(expired, backup)
+			continue
+		}
+
+		if nextExpiration == nil || expiration.Before(*nextExpiration) {
+			nextExpiration = &expiration
+		}
+	}
+
+	return expired, nextExpiration, nil
+}
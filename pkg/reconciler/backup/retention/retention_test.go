@@ -0,0 +1,98 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup retention policy", func() {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	newBackup := func(name string, pluginName string, age time.Duration) apiv1.Backup {
+		return apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: apiv1.BackupSpec{
+				Method:              apiv1.BackupMethodPlugin,
+				PluginConfiguration: &apiv1.BackupPluginConfiguration{Name: pluginName},
+			},
+			Status: apiv1.BackupStatus{
+				Phase:     apiv1.BackupPhaseCompleted,
+				StoppedAt: ptr.To(metav1.NewTime(now.Add(-age))),
+			},
+		}
+	}
+
+	backupNames := func(backups []apiv1.Backup) []string {
+		result := make([]string, len(backups))
+		for idx := range backups {
+			result[idx] = backups[idx].Name
+		}
+		return result
+	}
+
+	isResticBackup := func(backup *apiv1.Backup) bool {
+		return backup.Spec.PluginConfiguration.Name == "restic.example.com"
+	}
+
+	It("parses the maximum age", func() {
+		Expect(ParseMaxAge("3d")).To(Equal(3 * day))
+		Expect(ParseMaxAge("2w")).To(Equal(14 * day))
+		Expect(ParseMaxAge("1m")).To(Equal(30 * day))
+		_, err := ParseMaxAge("1y")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("only considers the backups accepted by the filter", func() {
+		backups := []apiv1.Backup{
+			newBackup("restic-third", "restic.example.com", 1*day),
+			newBackup("kopia-first", "kopia.example.com", 10*day),
+			newBackup("restic-first", "restic.example.com", 10*day),
+			newBackup("restic-second", "restic.example.com", 5*day),
+		}
+
+		expired, next, err := ExpiredBackups(backups, &apiv1.VolumeSnapshotRetentionPolicy{
+			KeepLast: ptr.To(2),
+			MaxAge:   "3d",
+		}, now, isResticBackup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backupNames(expired)).To(ConsistOf("restic-first", "restic-second"))
+		Expect(next).To(BeNil())
+	})
+
+	It("doesn't consider the backups being deleted", func() {
+		deleted := newBackup("deleted", "restic.example.com", 10*day)
+		deleted.DeletionTimestamp = ptr.To(metav1.NewTime(now))
+
+		expired, _, err := ExpiredBackups(
+			[]apiv1.Backup{newBackup("latest", "restic.example.com", 0), deleted},
+			&apiv1.VolumeSnapshotRetentionPolicy{KeepLast: ptr.To(1)}, now, isResticBackup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expired).To(BeEmpty())
+	})
+})
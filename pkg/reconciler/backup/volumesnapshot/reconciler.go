@@ -27,6 +27,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
@@ -171,14 +172,22 @@ func (se *Reconciler) Reconcile(
 	// Step 1: backup preparation.
 	// This will set PostgreSQL in backup mode for hot snapshots, or fence the Pods for cold snapshots.
 	if len(volumeSnapshots) == 0 {
+		// Every volume of the instance, including the WAL and tablespace ones,
+		// must be part of the snapshot group or the backup won't be restorable
+		if missingPVCs := persistentvolumeclaim.GetMissingInstancePVCNames(
+			cluster, targetPod.Name, pvcs); len(missingPVCs) > 0 {
+			return nil, fmt.Errorf("cannot snapshot instance %s, missing PVCs: %v", targetPod.Name, missingPVCs)
+		}
+
 		if res, err := exec.prepare(ctx, cluster, backup, targetPod); res != nil || err != nil {
 			return res, err
 		}
 	}
 
 	// Step 2: create snapshot
-	if len(volumeSnapshots) == 0 {
-		// we execute the snapshots only if we don't find any
+	if len(volumeSnapshots) < len(pvcs) {
+		// we execute the snapshots only if we don't find them all, completing
+		// a group whose creation has been interrupted
 		if err := se.createSnapshotPVCGroupStep(ctx, cluster, pvcs, backup, targetPod); err != nil {
 			return nil, err
 		}
@@ -370,11 +379,23 @@ func (se *Reconciler) createSnapshotPVCGroupStep(
 	targetPod *corev1.Pod,
 ) error {
 	for i := range pvcs {
+		pvcCalculator, err := persistentvolumeclaim.GetExpectedObjectCalculator(pvcs[i].GetLabels())
+		if err != nil {
+			return err
+		}
+
+		exists, err := se.snapshotExists(ctx, pvcs[i].Namespace, pvcCalculator.GetSnapshotName(backup.Name))
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
 		se.recorder.Eventf(backup, "Normal", "CreateSnapshot",
 			"Creating VolumeSnapshot for PVC %v", pvcs[i].Name)
 
-		err := se.createSnapshot(ctx, cluster, backup, targetPod, &pvcs[i])
-		if err != nil {
+		if err := se.createSnapshot(ctx, cluster, backup, targetPod, &pvcs[i]); err != nil {
 			return err
 		}
 	}
@@ -382,6 +403,20 @@ func (se *Reconciler) createSnapshotPVCGroupStep(
 	return nil
 }
 
+// snapshotExists checks if a VolumeSnapshot with the given name exists
+func (se *Reconciler) snapshotExists(ctx context.Context, namespace, name string) (bool, error) {
+	var snapshot storagesnapshotv1.VolumeSnapshot
+	err := se.cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &snapshot)
+	if apierrs.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("while getting VolumeSnapshot %s: %w", name, err)
+	}
+
+	return true, nil
+}
+
 // waitSnapshotToBeProvisionedStep waits for every PVC snapshot to be claimed
 func (se *Reconciler) waitSnapshotToBeProvisionedStep(
 	ctx context.Context,
@@ -426,6 +461,10 @@ func (se *Reconciler) createSnapshot(
 
 	snapshotConfig := backup.GetVolumeSnapshotConfiguration(*cluster.Spec.Backup.VolumeSnapshot)
 	snapshotClassName := pvcCalculator.GetVolumeSnapshotClass(&snapshotConfig)
+	if backup.Status.Level == apiv1.BackupLevelIncremental &&
+		snapshotConfig.Incremental != nil && snapshotConfig.Incremental.ClassName != "" {
+		snapshotClassName = ptr.To(snapshotConfig.Incremental.ClassName)
+	}
 
 	if pvc.Annotations == nil {
 		pvc.Annotations = map[string]string{}
@@ -204,6 +204,70 @@ var _ = Describe("Volumesnapshot reconciler", func() {
 		Expect(data.Len()).To(Equal(0))
 	})
 
+	It("should fail when the PVC group doesn't contain every tablespace", func(ctx SpecContext) {
+		cluster.Spec.Tablespaces = []apiv1.TablespaceConfiguration{
+			{
+				Name: "tbs1",
+				Storage: apiv1.StorageConfiguration{
+					Size: "1Gi",
+				},
+			},
+		}
+
+		mockClient := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(backup, cluster, targetPod).
+			Build()
+
+		executor := NewReconcilerBuilder(mockClient, record.NewFakeRecorder(3)).
+			Build()
+
+		_, err := executor.Reconcile(ctx, cluster, backup, targetPod, pvcs)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(clusterName + "-2-tbs-tbs1"))
+
+		var snapshotList storagesnapshotv1.VolumeSnapshotList
+		err = mockClient.List(ctx, &snapshotList)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshotList.Items).To(BeEmpty())
+	})
+
+	It("should complete a partially created group of volumesnapshots", func(ctx SpecContext) {
+		snapshots := storagesnapshotv1.VolumeSnapshotList{
+			Items: []storagesnapshotv1.VolumeSnapshot{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   namespace,
+						Name:        backup.Name,
+						Annotations: map[string]string{},
+						Labels: map[string]string{
+							utils.BackupNameLabelName: backup.Name,
+						},
+					},
+				},
+			},
+		}
+
+		mockClient := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster, targetPod).
+			WithStatusSubresource(backup).
+			WithLists(&snapshots).
+			Build()
+
+		executor := NewReconcilerBuilder(mockClient, record.NewFakeRecorder(3)).
+			Build()
+
+		result, err := executor.Reconcile(ctx, cluster, backup, targetPod, pvcs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		var snapshotList storagesnapshotv1.VolumeSnapshotList
+		err = mockClient.List(ctx, &snapshotList)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshotList.Items).To(HaveLen(2))
+	})
+
 	It("should unfence the target pod when the snapshots have been provisioned", func(ctx SpecContext) {
 		snapshots := storagesnapshotv1.VolumeSnapshotList{
 			Items: []storagesnapshotv1.VolumeSnapshot{
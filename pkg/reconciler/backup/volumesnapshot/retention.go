@@ -0,0 +1,59 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumesnapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/backup/retention"
+)
+
+// ExpiredBackups returns the completed volume snapshot backups which are
+// out of the passed retention policy, and the time after which the next
+// retained backup will fall out of the policy, if any.
+// The most recent completed backup is never expired by the maximum age.
+func ExpiredBackups(
+	backups []apiv1.Backup,
+	policy *apiv1.VolumeSnapshotRetentionPolicy,
+	now time.Time,
+) ([]apiv1.Backup, *time.Time, error) {
+	return retention.ExpiredBackups(backups, policy, now, func(backup *apiv1.Backup) bool {
+		return backup.Spec.Method == apiv1.BackupMethodVolumeSnapshot
+	})
+}
+
+// DeleteBackup deletes a volume snapshot backup together with
+// its volume snapshots
+func DeleteBackup(ctx context.Context, cli client.Client, backup *apiv1.Backup) error {
+	snapshots, err := getBackupVolumeSnapshots(ctx, cli, backup.Namespace, backup.Name)
+	if err != nil {
+		return err
+	}
+
+	for idx := range snapshots {
+		if err := cli.Delete(ctx, &snapshots[idx]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("while deleting volume snapshot %s: %w", snapshots[idx].Name, err)
+		}
+	}
+
+	return client.IgnoreNotFound(cli.Delete(ctx, backup))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumesnapshot
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("volume snapshot retention policy", func() {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	newBackup := func(name string, age time.Duration) apiv1.Backup {
+		return apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodVolumeSnapshot,
+			},
+			Status: apiv1.BackupStatus{
+				Phase:     apiv1.BackupPhaseCompleted,
+				StoppedAt: ptr.To(metav1.NewTime(now.Add(-age))),
+			},
+		}
+	}
+
+	backupNames := func(backups []apiv1.Backup) []string {
+		result := make([]string, len(backups))
+		for idx := range backups {
+			result[idx] = backups[idx].Name
+		}
+		return result
+	}
+
+	backups := []apiv1.Backup{
+		newBackup("third", 1*day),
+		newBackup("first", 10*day),
+		newBackup("second", 5*day),
+	}
+
+	It("doesn't expire anything without a policy", func() {
+		expired, next, err := ExpiredBackups(backups, nil, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expired).To(BeEmpty())
+		Expect(next).To(BeNil())
+	})
+
+	It("keeps the most recent backups", func() {
+		expired, next, err := ExpiredBackups(backups, &apiv1.VolumeSnapshotRetentionPolicy{
+			KeepLast: ptr.To(2),
+		}, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backupNames(expired)).To(ConsistOf("first"))
+		Expect(next).To(BeNil())
+	})
+
+	It("expires the backups older than the maximum age", func() {
+		expired, next, err := ExpiredBackups(backups, &apiv1.VolumeSnapshotRetentionPolicy{
+			MaxAge: "7d",
+		}, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backupNames(expired)).To(ConsistOf("first"))
+		Expect(next).ToNot(BeNil())
+		Expect(*next).To(Equal(now.Add(2 * day)))
+	})
+
+	It("always keeps the most recent backup", func() {
+		expired, next, err := ExpiredBackups(backups, &apiv1.VolumeSnapshotRetentionPolicy{
+			MaxAge: "1d",
+		}, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backupNames(expired)).To(ConsistOf("first", "second"))
+		Expect(next).To(BeNil())
+	})
+
+	It("ignores backups which are not completed volume snapshot backups", func() {
+		running := newBackup("running", 20*day)
+		running.Status.Phase = apiv1.BackupPhaseRunning
+		barman := newBackup("barman", 20*day)
+		barman.Spec.Method = apiv1.BackupMethodBarmanObjectStore
+
+		expired, _, err := ExpiredBackups(append([]apiv1.Backup{running, barman}, backups...),
+			&apiv1.VolumeSnapshotRetentionPolicy{KeepLast: ptr.To(1)}, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backupNames(expired)).To(ConsistOf("first", "second"))
+	})
+
+})
@@ -45,12 +45,20 @@ func Reconcile(
 
 	switch hibernationCondition.Reason {
 	case HibernationConditionReasonDeletingPods:
+		if cluster.GetHibernationMode() == apiv1.HibernationModeMinimal {
+			return reconcileDeletePods(ctx, c, getReplicaPods(instances))
+		}
 		return reconcileDeletePods(ctx, c, instances)
 
 	case HibernationConditionReasonWaitingPodsDeletion:
 		return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 
 	default:
+		// The scheduled hibernation needs to be checked again
+		// when its window closes
+		if next, found := cluster.GetNextHibernationTransition(time.Now()); found {
+			return &ctrl.Result{RequeueAfter: time.Until(next)}, nil
+		}
 		return &ctrl.Result{}, nil
 	}
 }
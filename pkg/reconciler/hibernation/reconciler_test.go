@@ -18,6 +18,7 @@ package hibernation
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -179,6 +180,66 @@ var _ = Describe("Reconcile resources", func() {
 		Expect(Reconcile(ctx, mock, &cluster, pods)).ToNot(BeNil())
 		Expect(mock.deletedPods).To(ConsistOf("cluster-example-1"))
 	})
+
+	It("keeps the primary pod running in the minimal hibernation mode", func(ctx SpecContext) {
+		mock := &clientMock{}
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Spec: apiv1.ClusterSpec{
+				Hibernation: &apiv1.HibernationConfiguration{
+					Enabled: true,
+					Mode:    apiv1.HibernationModeMinimal,
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   HibernationConditionType,
+						Status: metav1.ConditionFalse,
+						Reason: HibernationConditionReasonDeletingPods,
+					},
+				},
+			},
+		}
+
+		pods := fakePodListWithPrimary()
+		Expect(Reconcile(ctx, mock, &cluster, pods)).ToNot(BeNil())
+		Expect(mock.deletedPods).To(ConsistOf("cluster-example-1"))
+	})
+
+	It("re-queues at the end of the hibernation window", func(ctx SpecContext) {
+		mock := &clientMock{}
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Spec: apiv1.ClusterSpec{
+				Hibernation: &apiv1.HibernationConfiguration{
+					Windows: []apiv1.MaintenanceWindow{
+						{Schedule: "0 0 * * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   HibernationConditionType,
+						Status: metav1.ConditionTrue,
+						Reason: HibernationConditionReasonHibernated,
+					},
+				},
+			},
+		}
+
+		result, err := Reconcile(ctx, mock, &cluster, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 2*time.Hour))
+		Expect(mock.deletedPods).To(BeEmpty())
+	})
 })
 
 func fakePod(name string, role string) corev1.Pod {
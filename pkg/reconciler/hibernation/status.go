@@ -19,12 +19,14 @@ package hibernation
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -90,12 +92,26 @@ func EnrichStatus(
 		return
 	}
 
+	message := "Cluster has been hibernated"
+	if cluster.GetHibernationMode() == apiv1.HibernationModeMinimal {
+		// The primary instance needs to be running. This happens when
+		// switching from the full to the minimal hibernation: the cluster
+		// is resumed, and hibernated again once healthy
+		if len(podList) == 0 {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, HibernationConditionType)
+			return
+		}
+
+		podList = getReplicaPods(podList)
+		message = "Cluster has been hibernated, keeping the primary instance running"
+	}
+
 	if len(podList) == 0 {
 		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
 			Type:    HibernationConditionType,
 			Status:  metav1.ConditionTrue,
 			Reason:  HibernationConditionReasonHibernated,
-			Message: "Cluster has been hibernated",
+			Message: message,
 		})
 		return
 	}
@@ -121,7 +137,18 @@ func EnrichStatus(
 }
 
 func isHibernationEnabled(cluster *apiv1.Cluster) bool {
-	return cluster.Annotations[utils.HibernationAnnotationName] == HibernationOn
+	return cluster.IsHibernationRequested(time.Now())
+}
+
+// getReplicaPods gets the Pods not running the primary instance
+func getReplicaPods(podList []corev1.Pod) []corev1.Pod {
+	result := make([]corev1.Pod, 0, len(podList))
+	for idx := range podList {
+		if !specs.IsPodPrimary(podList[idx]) {
+			result = append(result, podList[idx])
+		}
+	}
+	return result
 }
 
 // isHibernationOngoing check if the cluster is doing the hibernation process
@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -162,3 +163,54 @@ var _ = Describe("Status enrichment", func() {
 		Expect(hibernationCondition.Reason).To(Equal(HibernationConditionReasonWaitingPodsDeletion))
 	})
 })
+
+var _ = Describe("Minimal hibernation status enrichment", func() {
+	newCluster := func() apiv1.Cluster {
+		return apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Hibernation: &apiv1.HibernationConfiguration{
+					Enabled: true,
+					Mode:    apiv1.HibernationModeMinimal,
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Phase: apiv1.PhaseHealthy,
+			},
+		}
+	}
+
+	It("sets the cluster as hibernated when only the primary Pod is left", func(ctx SpecContext) {
+		cluster := newCluster()
+		EnrichStatus(ctx, &cluster, []corev1.Pod{fakePod("cluster-example-2", specs.ClusterRoleLabelPrimary)})
+
+		hibernationCondition := meta.FindStatusCondition(cluster.Status.Conditions, HibernationConditionType)
+		Expect(hibernationCondition).ToNot(BeNil())
+		Expect(hibernationCondition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(hibernationCondition.Reason).To(Equal(HibernationConditionReasonHibernated))
+	})
+
+	It("deletes the replica Pods", func(ctx SpecContext) {
+		cluster := newCluster()
+		EnrichStatus(ctx, &cluster, fakePodListWithPrimary())
+
+		hibernationCondition := meta.FindStatusCondition(cluster.Status.Conditions, HibernationConditionType)
+		Expect(hibernationCondition).ToNot(BeNil())
+		Expect(hibernationCondition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(hibernationCondition.Reason).To(Equal(HibernationConditionReasonDeletingPods))
+	})
+
+	It("resumes the cluster when the primary Pod is not running", func(ctx SpecContext) {
+		cluster := newCluster()
+		cluster.Status.Conditions = []metav1.Condition{
+			{
+				Type:   HibernationConditionType,
+				Status: metav1.ConditionTrue,
+				Reason: HibernationConditionReasonHibernated,
+			},
+		}
+		EnrichStatus(ctx, &cluster, nil)
+
+		hibernationCondition := meta.FindStatusCondition(cluster.Status.Conditions, HibernationConditionType)
+		Expect(hibernationCondition).To(BeNil())
+	})
+})
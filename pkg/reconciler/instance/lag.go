@@ -0,0 +1,117 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// ReconcileReplicaLagLabels marks the instances whose replication lag
+// exceeds the one allowed for the read and read-only services, using
+// the LSNs reported by the instance managers
+func ReconcileReplicaLagLabels(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) error {
+	maxReplicaLag := cluster.GetMaxReplicaLag()
+	if maxReplicaLag == nil {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	primaryLSN, ok := getPrimaryLSN(instancesStatus)
+	if !ok {
+		contextLogger.Debug("Cannot evaluate the replication lag without the primary LSN")
+		return nil
+	}
+
+	for idx := range instancesStatus.Items {
+		item := &instancesStatus.Items[idx]
+		if item.Pod == nil || item.Error != nil {
+			continue
+		}
+
+		lagging, err := isReplicaLagging(item, primaryLSN, maxReplicaLag.Value())
+		if err != nil {
+			contextLogger.Debug("Cannot evaluate the replication lag of the instance",
+				"pod", item.Pod.Name, "err", err)
+			continue
+		}
+
+		label := strconv.FormatBool(lagging)
+		if value, ok := item.Pod.Labels[utils.ReplicaLaggingLabelName]; ok && value == label {
+			continue
+		}
+
+		contextLogger.Info("Updating the replication lag label",
+			"pod", item.Pod.Name, "lagging", lagging)
+		origInstance := item.Pod.DeepCopy()
+		if item.Pod.Labels == nil {
+			item.Pod.Labels = make(map[string]string)
+		}
+		item.Pod.Labels[utils.ReplicaLaggingLabelName] = label
+		if err := cli.Patch(ctx, item.Pod, client.MergeFrom(origInstance)); err != nil {
+			return fmt.Errorf("cannot update the replication lag label on pod %s: %w", item.Pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getPrimaryLSN gets the current LSN of the primary instance
+func getPrimaryLSN(instancesStatus postgres.PostgresqlStatusList) (int64, bool) {
+	for _, item := range instancesStatus.Items {
+		if !item.IsPrimary || item.Error != nil {
+			continue
+		}
+
+		lsn, err := item.CurrentLsn.Parse()
+		if err != nil {
+			return 0, false
+		}
+		return lsn, true
+	}
+
+	return 0, false
+}
+
+// isReplicaLagging checks whether the replay LSN of a replica is
+// more than maxLag bytes behind the primary LSN
+func isReplicaLagging(item *postgres.PostgresqlStatus, primaryLSN int64, maxLag int64) (bool, error) {
+	if item.IsPrimary {
+		return false, nil
+	}
+
+	replayLSN, err := item.ReplayLsn.Parse()
+	if err != nil {
+		return false, err
+	}
+
+	return primaryLSN-replayLSN > maxLag, nil
+}
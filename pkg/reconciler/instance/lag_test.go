@@ -0,0 +1,100 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replica lag labels", func() {
+	var (
+		cluster         *apiv1.Cluster
+		pods            []corev1.Pod
+		instancesStatus postgres.PostgresqlStatusList
+		cli             client.Client
+	)
+
+	BeforeEach(func() {
+		maxReplicaLag := resource.MustParse("16Mi")
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Services: &apiv1.ManagedServices{MaxReplicaLag: &maxReplicaLag},
+				},
+			},
+		}
+
+		pods = []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-2", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-3", Namespace: "default"}},
+		}
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(&pods[0], &pods[1], &pods[2]).
+			Build()
+
+		instancesStatus = postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: &pods[0], IsPrimary: true, CurrentLsn: types.LSN("0/A000000")},
+				{Pod: &pods[1], ReplayLsn: types.LSN("0/9000000")},
+				{Pod: &pods[2], ReplayLsn: types.LSN("0/1000000")},
+			},
+		}
+	})
+
+	getLabel := func(ctx context.Context, pod *corev1.Pod) string {
+		var updatedPod corev1.Pod
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(pod), &updatedPod)).To(Succeed())
+		return updatedPod.Labels[utils.ReplicaLaggingLabelName]
+	}
+
+	It("marks the replicas exceeding the maximum lag", func(ctx context.Context) {
+		Expect(ReconcileReplicaLagLabels(ctx, cli, cluster, instancesStatus)).To(Succeed())
+		Expect(getLabel(ctx, &pods[0])).To(Equal("false"))
+		Expect(getLabel(ctx, &pods[1])).To(Equal("false"))
+		Expect(getLabel(ctx, &pods[2])).To(Equal("true"))
+	})
+
+	It("doesn't touch the instances when the maximum lag is not set", func(ctx context.Context) {
+		cluster.Spec.Managed.Services.MaxReplicaLag = nil
+		Expect(ReconcileReplicaLagLabels(ctx, cli, cluster, instancesStatus)).To(Succeed())
+		Expect(getLabel(ctx, &pods[2])).To(BeEmpty())
+	})
+
+	It("doesn't touch the instances when the primary LSN is unknown", func(ctx context.Context) {
+		instancesStatus.Items[0].IsPrimary = false
+		Expect(ReconcileReplicaLagLabels(ctx, cli, cluster, instancesStatus)).To(Succeed())
+		Expect(getLabel(ctx, &pods[2])).To(BeEmpty())
+	})
+})
@@ -0,0 +1,254 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package majorupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// logicalUpgradeRequeueDelay is the delay between two checks of the
+// progress of a logical major version upgrade
+const logicalUpgradeRequeueDelay = 10 * time.Second
+
+// reconcileLogicalUpgrade upgrades the cluster by replicating its data, via
+// logical replication, to a new cluster running the requested major version.
+// The reconciliation of the source cluster is suspended until the upgrade is
+// completed, as its instances need to keep running the old major version.
+func reconcileLogicalUpgrade(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	requestedMajor int,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if cluster.Status.LogicalMajorUpgrade == nil {
+		targetName := specs.GetLogicalUpgradeTargetClusterName(cluster.Name, requestedMajor)
+		contextLogger.Info("Starting the logical major version upgrade", "targetCluster", targetName)
+		return &ctrl.Result{Requeue: true}, status.PatchWithOptimisticLock(
+			ctx,
+			c,
+			cluster,
+			func(cluster *apiv1.Cluster) {
+				cluster.Status.LogicalMajorUpgrade = &apiv1.LogicalMajorUpgradeStatus{
+					TargetCluster: targetName,
+					Phase:         apiv1.LogicalMajorUpgradePhaseSynchronizing,
+				}
+				cluster.Status.Phase = apiv1.PhaseMajorUpgrade
+				cluster.Status.PhaseReason = fmt.Sprintf(
+					"Replicating the data to the cluster %s", targetName)
+			},
+		)
+	}
+
+	upgradeStatus := cluster.Status.LogicalMajorUpgrade
+	if upgradeStatus.Phase == apiv1.LogicalMajorUpgradePhaseCompleted {
+		// The services are routing the connections to the target cluster,
+		// the source cluster is kept as it is until the user deletes it
+		return &ctrl.Result{}, nil
+	}
+
+	var target apiv1.Cluster
+	err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: upgradeStatus.TargetCluster}, &target)
+	switch {
+	case apierrs.IsNotFound(err) && upgradeStatus.Phase == apiv1.LogicalMajorUpgradePhaseSynchronizing:
+		return createLogicalUpgradeTargetCluster(ctx, c, cluster, requestedMajor)
+
+	case apierrs.IsNotFound(err):
+		return &ctrl.Result{}, status.RegisterPhase(ctx, c, cluster, apiv1.PhaseMajorUpgrade,
+			fmt.Sprintf("The target cluster %s has been deleted during the cutover, "+
+				"manual intervention required", upgradeStatus.TargetCluster))
+
+	case err != nil:
+		return nil, err
+	}
+
+	condition := meta.FindStatusCondition(target.Status.Conditions,
+		string(apiv1.ConditionLogicalUpgradeSynchronized))
+	if condition != nil && condition.Reason == string(apiv1.ConditionReasonLogicalUpgradeFailed) {
+		reason := fmt.Sprintf("The logical replication to the cluster %s is failing: %s",
+			target.Name, condition.Message)
+		if cluster.Status.PhaseReason != reason {
+			if err := status.RegisterPhase(ctx, c, cluster, apiv1.PhaseMajorUpgrade, reason); err != nil {
+				return nil, err
+			}
+		}
+		return &ctrl.Result{RequeueAfter: logicalUpgradeRequeueDelay}, nil
+	}
+
+	switch upgradeStatus.Phase {
+	case apiv1.LogicalMajorUpgradePhaseSynchronizing:
+		if condition == nil || condition.Status != metav1.ConditionTrue ||
+			condition.Reason != string(apiv1.ConditionReasonLogicalUpgradeStreaming) {
+			contextLogger.Debug("Waiting for the target cluster to be synchronized",
+				"targetCluster", target.Name)
+			return &ctrl.Result{RequeueAfter: logicalUpgradeRequeueDelay}, nil
+		}
+
+		return requestLogicalUpgradeCutover(ctx, c, cluster, &target)
+
+	case apiv1.LogicalMajorUpgradePhaseCuttingOver:
+		if condition == nil || condition.Reason != string(apiv1.ConditionReasonLogicalUpgradeCompleted) {
+			contextLogger.Debug("Waiting for the target cluster to complete the cutover",
+				"targetCluster", target.Name)
+			return &ctrl.Result{RequeueAfter: logicalUpgradeRequeueDelay}, nil
+		}
+
+		return completeLogicalUpgrade(ctx, c, cluster, &target)
+	}
+
+	return nil, fmt.Errorf("unknown logical major version upgrade phase: %s", upgradeStatus.Phase)
+}
+
+func createLogicalUpgradeTargetCluster(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	requestedMajor int,
+) (*ctrl.Result, error) {
+	target := specs.CreateLogicalUpgradeTargetCluster(*cluster, requestedMajor)
+	if err := ctrl.SetControllerReference(cluster, target, c.Scheme()); err != nil {
+		return nil, err
+	}
+
+	log.FromContext(ctx).Info("Creating the target cluster of the logical major version upgrade",
+		"targetCluster", target.Name)
+	if err := c.Create(ctx, target); err != nil && !apierrs.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	return &ctrl.Result{RequeueAfter: logicalUpgradeRequeueDelay}, nil
+}
+
+// requestLogicalUpgradeCutover asks the target cluster to disconnect the
+// applications from the source cluster and to apply the last changes
+func requestLogicalUpgradeCutover(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	target *apiv1.Cluster,
+) (*ctrl.Result, error) {
+	log.FromContext(ctx).Info("Starting the cutover to the target cluster", "targetCluster", target.Name)
+
+	origTarget := target.DeepCopy()
+	if target.Annotations == nil {
+		target.Annotations = make(map[string]string)
+	}
+	target.Annotations[utils.LogicalUpgradeCutoverAnnotationName] = "true"
+	if err := c.Patch(ctx, target, client.MergeFrom(origTarget)); err != nil {
+		return nil, err
+	}
+
+	return &ctrl.Result{RequeueAfter: logicalUpgradeRequeueDelay}, status.PatchWithOptimisticLock(
+		ctx,
+		c,
+		cluster,
+		func(cluster *apiv1.Cluster) {
+			setLogicalUpgradePhase(cluster, target.Name, apiv1.LogicalMajorUpgradePhaseCuttingOver)
+			cluster.Status.PhaseReason = fmt.Sprintf("Cutting over to the cluster %s", target.Name)
+		},
+	)
+}
+
+// completeLogicalUpgrade routes the connections directed to the services of the
+// source cluster to the target cluster, which is detached from the source one
+func completeLogicalUpgrade(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	target *apiv1.Cluster,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	serviceNames := [][2]string{
+		{cluster.GetServiceReadWriteName(), target.GetServiceReadWriteName()},
+		{cluster.GetServiceReadOnlyName(), target.GetServiceReadOnlyName()},
+		{cluster.GetServiceReadName(), target.GetServiceReadName()},
+	}
+	for _, names := range serviceNames {
+		if err := routeServiceToCluster(ctx, c, cluster.Namespace, names[0], names[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	// The target cluster must survive the deletion of the source one
+	origTarget := target.DeepCopy()
+	target.OwnerReferences = nil
+	if err := c.Patch(ctx, target, client.MergeFrom(origTarget)); err != nil {
+		return nil, err
+	}
+
+	contextLogger.Info("Logical major version upgrade completed", "targetCluster", target.Name)
+	return &ctrl.Result{}, status.PatchWithOptimisticLock(
+		ctx,
+		c,
+		cluster,
+		func(cluster *apiv1.Cluster) {
+			setLogicalUpgradePhase(cluster, target.Name, apiv1.LogicalMajorUpgradePhaseCompleted)
+			cluster.Status.PhaseReason = fmt.Sprintf(
+				"Logical major version upgrade completed, the services are routed to the cluster %s",
+				target.Name)
+		},
+	)
+}
+
+// setLogicalUpgradePhase sets the phase of the logical major version
+// upgrade, recreating its status if it was cleared in the meantime
+func setLogicalUpgradePhase(cluster *apiv1.Cluster, targetName string, phase apiv1.LogicalMajorUpgradePhase) {
+	if cluster.Status.LogicalMajorUpgrade == nil {
+		cluster.Status.LogicalMajorUpgrade = &apiv1.LogicalMajorUpgradeStatus{TargetCluster: targetName}
+	}
+	cluster.Status.LogicalMajorUpgrade.Phase = phase
+}
+
+// routeServiceToCluster makes the passed service select the same
+// Pods as the passed service of the target cluster
+func routeServiceToCluster(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	serviceName string,
+	targetServiceName string,
+) error {
+	var service, targetService corev1.Service
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: serviceName}, &service); err != nil {
+		// The default services can be disabled
+		return client.IgnoreNotFound(err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: targetServiceName}, &targetService); err != nil {
+		return err
+	}
+
+	origService := service.DeepCopy()
+	service.Spec.Selector = targetService.Spec.Selector
+	return c.Patch(ctx, &service, client.MergeFrom(origService))
+}
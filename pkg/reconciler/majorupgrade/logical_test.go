@@ -0,0 +1,244 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package majorupgrade
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logical major version upgrade reconciler", func() {
+	const (
+		namespace  = "default"
+		oldImage   = "ghcr.io/cloudnative-pg/postgresql:16.4"
+		newImage   = "ghcr.io/cloudnative-pg/postgresql:17.2"
+		targetName = "cluster-example-pg17"
+	)
+
+	var (
+		cluster    *apiv1.Cluster
+		pvcs       []corev1.PersistentVolumeClaim
+		fakeClient client.Client
+	)
+
+	createTarget := func(ctx SpecContext, condition metav1.Condition) *apiv1.Cluster {
+		target := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      targetName,
+				Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: apiv1.GroupVersion.String(),
+						Kind:       apiv1.ClusterKind,
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+						Controller: ptr.To(true),
+					},
+				},
+			},
+		}
+		Expect(fakeClient.Create(ctx, target)).To(Succeed())
+
+		meta.SetStatusCondition(&target.Status.Conditions, condition)
+		Expect(fakeClient.Status().Update(ctx, target)).To(Succeed())
+		return target
+	}
+
+	setUpgradeStatus := func(ctx SpecContext, phase apiv1.LogicalMajorUpgradePhase) {
+		cluster.Status.LogicalMajorUpgrade = &apiv1.LogicalMajorUpgradeStatus{
+			TargetCluster: targetName,
+			Phase:         phase,
+		}
+		Expect(fakeClient.Status().Update(ctx, cluster)).To(Succeed())
+	}
+
+	getTarget := func(ctx SpecContext) (*apiv1.Cluster, error) {
+		var target apiv1.Cluster
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: targetName}, &target)
+		return &target, err
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: namespace,
+				UID:       "cluster-uid",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName:             newImage,
+				Instances:             2,
+				EnableSuperuserAccess: ptr.To(true),
+				MajorUpgrade: &apiv1.MajorUpgradeConfiguration{
+					Method: apiv1.MajorUpgradeMethodLogical,
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Image:          newImage,
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        oldImage,
+					MajorVersion: 16,
+				},
+			},
+		}
+		pvcs = []corev1.PersistentVolumeClaim{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1", Namespace: namespace}},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+	})
+
+	It("starts the upgrade by recording the target cluster", func(ctx SpecContext) {
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseMajorUpgrade))
+		Expect(cluster.Status.LogicalMajorUpgrade).To(Equal(&apiv1.LogicalMajorUpgradeStatus{
+			TargetCluster: targetName,
+			Phase:         apiv1.LogicalMajorUpgradePhaseSynchronizing,
+		}))
+	})
+
+	It("creates the target cluster owned by the source one", func(ctx SpecContext) {
+		setUpgradeStatus(ctx, apiv1.LogicalMajorUpgradePhaseSynchronizing)
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+
+		target, err := getTarget(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.Labels).To(HaveKeyWithValue(utils.LogicalUpgradeSourceLabelName, cluster.Name))
+		Expect(target.OwnerReferences).To(ConsistOf(HaveField("UID", cluster.UID)))
+	})
+
+	It("waits for the target cluster to be synchronized", func(ctx SpecContext) {
+		setUpgradeStatus(ctx, apiv1.LogicalMajorUpgradePhaseSynchronizing)
+		createTarget(ctx, metav1.Condition{
+			Type:   string(apiv1.ConditionLogicalUpgradeSynchronized),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonLogicalUpgradeCopying),
+		})
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+		Expect(cluster.Status.LogicalMajorUpgrade.Phase).To(Equal(apiv1.LogicalMajorUpgradePhaseSynchronizing))
+
+		target, err := getTarget(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.Annotations).ToNot(HaveKey(utils.LogicalUpgradeCutoverAnnotationName))
+	})
+
+	It("reports the replication failures in the phase reason", func(ctx SpecContext) {
+		setUpgradeStatus(ctx, apiv1.LogicalMajorUpgradePhaseSynchronizing)
+		createTarget(ctx, metav1.Condition{
+			Type:    string(apiv1.ConditionLogicalUpgradeSynchronized),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonLogicalUpgradeFailed),
+			Message: "connection refused",
+		})
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(cluster.Status.PhaseReason).To(ContainSubstring("connection refused"))
+	})
+
+	It("requests the cutover when the target cluster is streaming the changes", func(ctx SpecContext) {
+		setUpgradeStatus(ctx, apiv1.LogicalMajorUpgradePhaseSynchronizing)
+		createTarget(ctx, metav1.Condition{
+			Type:   string(apiv1.ConditionLogicalUpgradeSynchronized),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonLogicalUpgradeStreaming),
+		})
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(cluster.Status.LogicalMajorUpgrade.Phase).To(Equal(apiv1.LogicalMajorUpgradePhaseCuttingOver))
+
+		target, err := getTarget(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.Annotations).To(HaveKeyWithValue(utils.LogicalUpgradeCutoverAnnotationName, "true"))
+	})
+
+	It("routes the services to the target cluster when the cutover is completed", func(ctx SpecContext) {
+		setUpgradeStatus(ctx, apiv1.LogicalMajorUpgradePhaseCuttingOver)
+		target := createTarget(ctx, metav1.Condition{
+			Type:   string(apiv1.ConditionLogicalUpgradeSynchronized),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonLogicalUpgradeCompleted),
+		})
+
+		newService := func(name string, clusterName string) *corev1.Service {
+			return &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{utils.ClusterLabelName: clusterName},
+				},
+			}
+		}
+		Expect(fakeClient.Create(ctx, newService(cluster.GetServiceReadWriteName(), cluster.Name))).To(Succeed())
+		Expect(fakeClient.Create(ctx, newService(target.GetServiceReadWriteName(), targetName))).To(Succeed())
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(cluster.Status.LogicalMajorUpgrade.Phase).To(Equal(apiv1.LogicalMajorUpgradePhaseCompleted))
+
+		var service corev1.Service
+		Expect(fakeClient.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      cluster.GetServiceReadWriteName(),
+		}, &service)).To(Succeed())
+		Expect(service.Spec.Selector).To(HaveKeyWithValue(utils.ClusterLabelName, targetName))
+
+		target, err = getTarget(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.OwnerReferences).To(BeEmpty())
+	})
+
+	It("keeps the reconciliation of the source cluster suspended after the upgrade", func(ctx SpecContext) {
+		setUpgradeStatus(ctx, apiv1.LogicalMajorUpgradePhaseCompleted)
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.IsZero()).To(BeTrue())
+	})
+})
@@ -0,0 +1,254 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package majorupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// Reconcile upgrades the data directory of the cluster when the requested
+// PostgreSQL major version is newer than the one the data directory has been
+// created with. By default, the upgrade is run by a job on the volumes of
+// the primary, after all the instances have been shut down, and the replicas
+// are then recreated from the upgraded primary. With the logical method, the
+// data is replicated to a new cluster instead.
+func Reconcile(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	instances []corev1.Pod,
+	pvcs []corev1.PersistentVolumeClaim,
+	jobs []batchv1.Job,
+) (*ctrl.Result, error) {
+	if cluster.Status.PGDataImageInfo == nil {
+		return nil, nil
+	}
+
+	requestedVersion, err := cluster.GetPostgresqlVersion()
+	if err != nil {
+		// The version can't be detected, i.e. the image is referenced
+		// by digest: major version upgrades are not possible
+		return nil, nil
+	}
+	requestedMajor := int(requestedVersion.Major())
+	if requestedMajor <= cluster.Status.PGDataImageInfo.MajorVersion {
+		return nil, nil
+	}
+
+	contextLogger := log.FromContext(ctx).WithValues(
+		"fromMajor", cluster.Status.PGDataImageInfo.MajorVersion,
+		"toMajor", requestedMajor,
+	)
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	if job := getMajorUpgradeJob(jobs); job != nil {
+		return reconcileMajorUpgradeJob(ctx, c, cluster, job, pvcs, requestedMajor)
+	}
+
+	if cluster.Status.LogicalMajorUpgrade == nil && (len(pvcs) == 0 || cluster.Status.CurrentPrimary == "") {
+		// The cluster has not been bootstrapped yet, there's
+		// no data directory to be upgraded
+		return nil, status.PatchWithOptimisticLock(ctx, c, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.PGDataImageInfo = &apiv1.ImageInfo{
+				Image:        cluster.Status.Image,
+				MajorVersion: requestedMajor,
+			}
+		})
+	}
+
+	if cluster.GetMajorUpgradeMethod() == apiv1.MajorUpgradeMethodLogical || cluster.Status.LogicalMajorUpgrade != nil {
+		return reconcileLogicalUpgrade(ctx, c, cluster, requestedMajor)
+	}
+
+	primaryPVC := getPrimaryPVC(cluster, pvcs)
+	if primaryPVC == nil {
+		return nil, fmt.Errorf("cannot find the data volume of the primary instance %q",
+			cluster.Status.CurrentPrimary)
+	}
+
+	if cluster.Status.Phase != apiv1.PhaseMajorUpgrade {
+		contextLogger.Info("Starting the major version upgrade")
+		if err := status.RegisterPhase(ctx, c, cluster, apiv1.PhaseMajorUpgrade,
+			fmt.Sprintf("Upgrading from major version %d to %d",
+				cluster.Status.PGDataImageInfo.MajorVersion, requestedMajor)); err != nil {
+			return nil, err
+		}
+	}
+
+	// pg_upgrade requires every instance to be shut down
+	if len(instances) > 0 {
+		for idx := range instances {
+			if instances[idx].DeletionTimestamp != nil {
+				continue
+			}
+
+			contextLogger.Info("Deleting Pod as requested by the major version upgrade",
+				"podName", instances[idx].Name)
+			if err := c.Delete(ctx, &instances[idx]); err != nil && !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	return createMajorUpgradeJob(ctx, c, cluster, primaryPVC)
+}
+
+func createMajorUpgradeJob(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	primaryPVC *corev1.PersistentVolumeClaim,
+) (*ctrl.Result, error) {
+	nodeSerial, err := specs.GetNodeSerial(primaryPVC.ObjectMeta)
+	if err != nil {
+		return nil, fmt.Errorf("while detecting the serial of the primary instance: %w", err)
+	}
+
+	job := specs.CreateMajorUpgradeJob(*cluster, nodeSerial, cluster.Status.PGDataImageInfo.Image)
+	if err := ctrl.SetControllerReference(cluster, job, c.Scheme()); err != nil {
+		return nil, err
+	}
+
+	log.FromContext(ctx).Info("Creating the major version upgrade job", "jobName", job.Name)
+	if err := c.Create(ctx, job); err != nil && !apierrs.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+func reconcileMajorUpgradeJob(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	job *batchv1.Job,
+	pvcs []corev1.PersistentVolumeClaim,
+	requestedMajor int,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).WithValues("jobName", job.Name)
+
+	jobImage := job.Spec.Template.Spec.Containers[0].Image
+	if jobImage != cluster.Status.Image {
+		// The requested image changed while the upgrade was pending,
+		// the job will be recreated with the new one
+		contextLogger.Info("Deleting the major version upgrade job using an outdated image",
+			"jobImage", jobImage,
+			"requestedImage", cluster.Status.Image)
+		return &ctrl.Result{Requeue: true}, deleteJob(ctx, c, job)
+	}
+
+	if isJobFailed(job) {
+		// The old data directory is still there, the user needs
+		// to investigate the failure and delete the job to retry
+		contextLogger.Info("The major version upgrade job failed, manual intervention required")
+		if cluster.Status.PhaseReason != majorUpgradeFailedReason {
+			return &ctrl.Result{}, status.RegisterPhase(ctx, c, cluster, apiv1.PhaseMajorUpgrade,
+				majorUpgradeFailedReason)
+		}
+		return &ctrl.Result{}, nil
+	}
+
+	if !utils.JobHasOneCompletion(*job) {
+		contextLogger.Debug("Waiting for the major version upgrade job to complete")
+		return &ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// The replicas will be cloned again from the upgraded primary
+	upgradedInstance := job.Spec.Template.Labels[utils.InstanceNameLabelName]
+	for idx := range pvcs {
+		if pvcs[idx].Labels[utils.InstanceNameLabelName] == upgradedInstance {
+			continue
+		}
+
+		contextLogger.Info("Deleting the volume of a replica after the major version upgrade",
+			"pvcName", pvcs[idx].Name)
+		if err := c.Delete(ctx, &pvcs[idx]); err != nil && !apierrs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	contextLogger.Info("Major version upgrade completed")
+	if err := status.PatchWithOptimisticLock(ctx, c, cluster, func(cluster *apiv1.Cluster) {
+		cluster.Status.PGDataImageInfo = &apiv1.ImageInfo{
+			Image:        jobImage,
+			MajorVersion: requestedMajor,
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ctrl.Result{Requeue: true}, deleteJob(ctx, c, job)
+}
+
+// majorUpgradeFailedReason is the phase reason used when the
+// major version upgrade job failed
+const majorUpgradeFailedReason = "The major version upgrade job failed, " +
+	"delete it to retry once the problem has been fixed"
+
+func deleteJob(ctx context.Context, c client.Client, job *batchv1.Job) error {
+	err := c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func getMajorUpgradeJob(jobs []batchv1.Job) *batchv1.Job {
+	for idx := range jobs {
+		if specs.IsMajorUpgradeJob(&jobs[idx]) {
+			return &jobs[idx]
+		}
+	}
+
+	return nil
+}
+
+func getPrimaryPVC(cluster *apiv1.Cluster, pvcs []corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+	for idx := range pvcs {
+		if pvcs[idx].Name == cluster.Status.CurrentPrimary {
+			return &pvcs[idx]
+		}
+	}
+
+	return nil
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,215 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package majorupgrade
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Major version upgrade reconciler", func() {
+	const (
+		namespace = "default"
+		oldImage  = "ghcr.io/cloudnative-pg/postgresql:16.4"
+		newImage  = "ghcr.io/cloudnative-pg/postgresql:17.2"
+	)
+
+	var (
+		cluster    *apiv1.Cluster
+		pvcs       []corev1.PersistentVolumeClaim
+		fakeClient client.Client
+	)
+
+	newPVC := func(instanceName string, serial string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instanceName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+				},
+				Annotations: map[string]string{
+					utils.ClusterSerialAnnotationName: serial,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       apiv1.ClusterKind,
+				APIVersion: apiv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: namespace,
+				UID:       "cluster-uid",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: newImage,
+				Instances: 2,
+			},
+			Status: apiv1.ClusterStatus{
+				Image:          newImage,
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        oldImage,
+					MajorVersion: 16,
+				},
+			},
+		}
+		pvcs = []corev1.PersistentVolumeClaim{
+			newPVC("cluster-example-1", "1"),
+			newPVC("cluster-example-2", "2"),
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster, &pvcs[0], &pvcs[1]).
+			WithStatusSubresource(cluster).
+			Build()
+	})
+
+	getJob := func(ctx SpecContext) (*batchv1.Job, error) {
+		var job batchv1.Job
+		err := fakeClient.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      "cluster-example-1-major-upgrade",
+		}, &job)
+		return &job, err
+	}
+
+	It("lets the reconciliation loop proceed when the major version didn't change", func(ctx SpecContext) {
+		cluster.Status.PGDataImageInfo.MajorVersion = 17
+		Expect(Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)).To(BeNil())
+	})
+
+	It("lets the reconciliation loop proceed when the image of the data directory is unknown",
+		func(ctx SpecContext) {
+			cluster.Status.PGDataImageInfo = nil
+			Expect(Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)).To(BeNil())
+		})
+
+	It("shuts down the instances before starting the upgrade", func(ctx SpecContext) {
+		instances := []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1", Namespace: namespace}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-2", Namespace: namespace}},
+		}
+		for idx := range instances {
+			Expect(fakeClient.Create(ctx, &instances[idx])).To(Succeed())
+		}
+
+		result, err := Reconcile(ctx, fakeClient, cluster, instances, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseMajorUpgrade))
+
+		var pods corev1.PodList
+		Expect(fakeClient.List(ctx, &pods)).To(Succeed())
+		Expect(pods.Items).To(BeEmpty())
+
+		_, err = getJob(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("creates the upgrade job on the volumes of the primary", func(ctx SpecContext) {
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		job, err := getJob(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(specs.IsMajorUpgradeJob(job)).To(BeTrue())
+		Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal(newImage))
+		Expect(job.Spec.Template.Spec.InitContainers).To(ContainElement(
+			HaveField("Image", oldImage)))
+		Expect(job.OwnerReferences).To(HaveLen(1))
+	})
+
+	It("waits for the job to complete", func(ctx SpecContext) {
+		job := specs.CreateMajorUpgradeJob(*cluster, 1, oldImage)
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, []batchv1.Job{*job})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+		Expect(cluster.Status.PGDataImageInfo.MajorVersion).To(Equal(16))
+	})
+
+	It("stops when the job failed", func(ctx SpecContext) {
+		job := specs.CreateMajorUpgradeJob(*cluster, 1, oldImage)
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+		}
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, []batchv1.Job{*job})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseMajorUpgrade))
+		Expect(cluster.Status.PhaseReason).To(Equal(majorUpgradeFailedReason))
+		Expect(cluster.Status.PGDataImageInfo.MajorVersion).To(Equal(16))
+	})
+
+	It("recreates the replicas when the job succeeded", func(ctx SpecContext) {
+		job := specs.CreateMajorUpgradeJob(*cluster, 1, oldImage)
+		job.Status.Succeeded = 1
+		Expect(fakeClient.Create(ctx, job)).To(Succeed())
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, []batchv1.Job{*job})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(cluster.Status.PGDataImageInfo).To(Equal(&apiv1.ImageInfo{
+			Image:        newImage,
+			MajorVersion: 17,
+		}))
+
+		var pvcList corev1.PersistentVolumeClaimList
+		Expect(fakeClient.List(ctx, &pvcList)).To(Succeed())
+		Expect(pvcList.Items).To(ConsistOf(HaveField("Name", "cluster-example-1")))
+
+		_, err = getJob(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("replaces a job using an outdated image", func(ctx SpecContext) {
+		job := specs.CreateMajorUpgradeJob(*cluster, 1, oldImage)
+		job.Spec.Template.Spec.Containers[0].Image = "ghcr.io/cloudnative-pg/postgresql:17.1"
+		Expect(fakeClient.Create(ctx, job)).To(Succeed())
+
+		result, err := Reconcile(ctx, fakeClient, cluster, nil, pvcs, []batchv1.Job{*job})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		_, err = getJob(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+})
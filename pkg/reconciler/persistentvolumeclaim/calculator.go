@@ -133,7 +133,8 @@ func (r pgDataCalculator) GetName(instanceName string) string {
 // GetStorageConfiguration will return the storage configuration to be used
 // for this PVC role and this cluster
 func (r pgDataCalculator) GetStorageConfiguration(cluster *apiv1.Cluster) (apiv1.StorageConfiguration, error) {
-	return cluster.Spec.StorageConfiguration, nil
+	return cluster.Spec.StorageConfiguration.WithAutoResizedSize(
+		cluster.Status.AutoResizedStorage.GetPgDataSize()), nil
 }
 
 // GetSource gets the PVC source to be used when creating a new PVC
@@ -200,7 +201,8 @@ func (r pgWalCalculator) GetName(instanceName string) string {
 // GetStorageConfiguration will return the storage configuration to be used
 // for this PVC role and this cluster
 func (r pgWalCalculator) GetStorageConfiguration(cluster *apiv1.Cluster) (apiv1.StorageConfiguration, error) {
-	return *cluster.Spec.WalStorage, nil
+	return cluster.Spec.WalStorage.WithAutoResizedSize(
+		cluster.Status.AutoResizedStorage.GetPgWalSize()), nil
 }
 
 // GetSource gets the PVC source to be used when creating a new PVC
@@ -279,7 +281,8 @@ func (r pgTablespaceCalculator) GetStorageConfiguration(cluster *apiv1.Cluster)
 				r.tablespaceName,
 			)
 	}
-	return *storageConfiguration, nil
+	return storageConfiguration.WithAutoResizedSize(
+		cluster.Status.AutoResizedStorage.GetTablespaceSize(r.tablespaceName)), nil
 }
 
 // GetSource gets the PVC source to be used when creating a new PVC
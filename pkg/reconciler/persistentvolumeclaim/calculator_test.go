@@ -64,6 +64,26 @@ var _ = Describe("pvc role test", func() {
 		Expect(role.GetSource(&storageSource)).To(BeEquivalentTo(&dataSource))
 	})
 
+	It("uses the size the pgData volumes have been automatically expanded to", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size: "5Gi",
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				AutoResizedStorage: &apiv1.AutoResizedStorageStatus{
+					PgData: "6Gi",
+				},
+			},
+		}
+
+		Expect(NewPgDataCalculator().GetStorageConfiguration(&cluster)).To(BeEquivalentTo(
+			apiv1.StorageConfiguration{
+				Size: "6Gi",
+			}))
+	})
+
 	It("return expected value for pgWal", func() {
 		instanceName := "instance1"
 		backupName := "backup1"
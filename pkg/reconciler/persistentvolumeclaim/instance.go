@@ -96,6 +96,13 @@ func reconcileSingleInstanceMissingPVCs(
 			return ctrl.Result{}, err
 		}
 
+		// The cluster we are restoring into may have a different storage
+		// configuration than the one the snapshot has been taken from
+		conf, err = fitStorageConfigurationToSource(ctx, c, cluster.Namespace, conf, pvcSource)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
 		createConfiguration := expectedPVC.toCreateConfiguration(serial, conf, pvcSource)
 
 		if err := createIfNotExists(ctx, c, cluster, createConfiguration); err != nil {
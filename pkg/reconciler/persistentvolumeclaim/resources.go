@@ -73,6 +73,30 @@ func isResizing(pvc corev1.PersistentVolumeClaim) bool {
 	return false
 }
 
+// GetResizeStatus gets the progress of the expansion of the PVC, which
+// is empty when the PVC is not being expanded
+func GetResizeStatus(pvc corev1.PersistentVolumeClaim) apiv1.VolumeResizeStatus {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending {
+			return apiv1.VolumeResizeStatusFileSystemPending
+		}
+	}
+
+	if isResizing(pvc) {
+		return apiv1.VolumeResizeStatusInProgress
+	}
+
+	// The storage provider may not have started the expansion yet
+	requestedSize, hasRequestedSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity, hasCapacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if pvc.Status.Phase == corev1.ClaimBound && hasRequestedSize && hasCapacity &&
+		capacity.Cmp(requestedSize) < 0 {
+		return apiv1.VolumeResizeStatusInProgress
+	}
+
+	return ""
+}
+
 // BelongToInstance returns a boolean indicating if that given PVC belongs to an instance
 func BelongToInstance(cluster *apiv1.Cluster, instanceName, pvcName string) bool {
 	expectedPVCs := getExpectedInstancePVCNamesFromCluster(cluster, instanceName)
@@ -115,6 +139,23 @@ func InstanceHasMissingMounts(cluster *apiv1.Cluster, instance *corev1.Pod) bool
 	return false
 }
 
+// GetMissingInstancePVCNames returns the names of the PVCs expected for
+// the instance that are not included in the passed list
+func GetMissingInstancePVCNames(
+	cluster *apiv1.Cluster,
+	instanceName string,
+	pvcs []corev1.PersistentVolumeClaim,
+) []string {
+	var missing []string
+	pvcNames := getNamesFromPVCList(pvcs)
+	for _, pvcName := range getExpectedInstancePVCNamesFromCluster(cluster, instanceName) {
+		if !slices.Contains(pvcNames, pvcName) {
+			missing = append(missing, pvcName)
+		}
+	}
+	return missing
+}
+
 type expectedPVC struct {
 	calculator    ExpectedObjectCalculator
 	name          string
@@ -143,6 +184,10 @@ func getExpectedPVCsFromCluster(cluster *apiv1.Cluster, instanceName string) []e
 		roles = append(roles, NewPgWalCalculator())
 	}
 	for _, tbsConfig := range cluster.Spec.Tablespaces {
+		// Ephemeral tablespaces are not backed by a PVC
+		if tbsConfig.IsEphemeral() {
+			continue
+		}
 		roles = append(roles, NewPgTablespaceCalculator(tbsConfig.Name))
 	}
 	return buildExpectedPVCs(instanceName, roles)
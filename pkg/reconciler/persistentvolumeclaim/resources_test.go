@@ -19,6 +19,7 @@ package persistentvolumeclaim
 import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -148,3 +149,47 @@ var _ = Describe("instance with tablespace test", func() {
 		}
 	})
 })
+
+var _ = Describe("GetResizeStatus", func() {
+	newBoundPVC := func(requestedSize, capacity string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(requestedSize),
+					},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimBound,
+				Capacity: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(capacity),
+				},
+			},
+		}
+	}
+
+	It("is empty when the PVC has the requested size", func() {
+		Expect(GetResizeStatus(newBoundPVC("1Gi", "1Gi"))).To(BeEmpty())
+	})
+
+	It("detects the expansion not yet started by the storage provider", func() {
+		Expect(GetResizeStatus(newBoundPVC("2Gi", "1Gi"))).To(Equal(apiv1.VolumeResizeStatusInProgress))
+	})
+
+	It("detects the expansion in progress", func() {
+		pvc := newBoundPVC("2Gi", "1Gi")
+		pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue},
+		}
+		Expect(GetResizeStatus(pvc)).To(Equal(apiv1.VolumeResizeStatusInProgress))
+	})
+
+	It("detects the filesystem waiting to be grown", func() {
+		pvc := newBoundPVC("2Gi", "2Gi")
+		pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+		}
+		Expect(GetResizeStatus(pvc)).To(Equal(apiv1.VolumeResizeStatusFileSystemPending))
+	})
+})
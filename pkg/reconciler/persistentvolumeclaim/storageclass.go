@@ -0,0 +1,80 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolumeclaim
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// GetInstancesWithOutdatedStorageClass returns the sorted names of the
+// instances having at least a PVC whose storage class is different from
+// the one requested in the cluster. PVCs whose requested storage class
+// is not specified, and will use the default one, are never outdated
+func GetInstancesWithOutdatedStorageClass(
+	cluster *apiv1.Cluster,
+	pvcs []corev1.PersistentVolumeClaim,
+) []string {
+	var result []string
+	for idx := range pvcs {
+		pvc := &pvcs[idx]
+		instanceName := pvc.Labels[utils.InstanceNameLabelName]
+		if instanceName == "" || slices.Contains(result, instanceName) {
+			continue
+		}
+
+		if isStorageClassOutdated(cluster, pvc) {
+			result = append(result, instanceName)
+		}
+	}
+
+	slices.Sort(result)
+	return result
+}
+
+// isStorageClassOutdated checks if the PVC doesn't use the storage
+// class requested in the cluster for its role
+func isStorageClassOutdated(cluster *apiv1.Cluster, pvc *corev1.PersistentVolumeClaim) bool {
+	if utils.PVCRole(pvc.Labels[utils.PvcRoleLabelName]) == utils.PVCRolePgWal && cluster.Spec.WalStorage == nil {
+		return false
+	}
+
+	calculator, err := GetExpectedObjectCalculator(pvc.Labels)
+	if err != nil {
+		return false
+	}
+
+	storage, err := calculator.GetStorageConfiguration(cluster)
+	if err != nil {
+		return false
+	}
+
+	requestedStorageClass := storage.StorageClass
+	if requestedStorageClass == nil && storage.PersistentVolumeClaimTemplate != nil {
+		requestedStorageClass = storage.PersistentVolumeClaimTemplate.StorageClassName
+	}
+	if requestedStorageClass == nil {
+		return false
+	}
+
+	return ptr.Deref(pvc.Spec.StorageClassName, "") != *requestedStorageClass
+}
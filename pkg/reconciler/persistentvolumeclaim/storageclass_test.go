@@ -0,0 +1,94 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolumeclaim
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetInstancesWithOutdatedStorageClass", func() {
+	newPVC := func(name, instanceName string, role utils.PVCRole, storageClass string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+					utils.PvcRoleLabelName:      string(role),
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(storageClass),
+			},
+		}
+	}
+
+	pvcs := []corev1.PersistentVolumeClaim{
+		newPVC("cluster-example-1", "cluster-example-1", utils.PVCRolePgData, "standard"),
+		newPVC("cluster-example-1-wal", "cluster-example-1", utils.PVCRolePgWal, "standard"),
+		newPVC("cluster-example-2", "cluster-example-2", utils.PVCRolePgData, "fast"),
+		newPVC("cluster-example-2-wal", "cluster-example-2", utils.PVCRolePgWal, "standard"),
+		newPVC("cluster-example-3", "cluster-example-3", utils.PVCRolePgData, "fast"),
+		newPVC("cluster-example-3-wal", "cluster-example-3", utils.PVCRolePgWal, "fast"),
+	}
+
+	It("finds the instances with a volume using another storage class", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{
+					StorageClass: ptr.To("fast"),
+				},
+				WalStorage: &apiv1.StorageConfiguration{
+					StorageClass: ptr.To("fast"),
+				},
+			},
+		}
+		Expect(GetInstancesWithOutdatedStorageClass(cluster, pvcs)).To(
+			Equal([]string{"cluster-example-1", "cluster-example-2"}))
+	})
+
+	It("takes into account the storage class of the PVC template", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{
+					PersistentVolumeClaimTemplate: &corev1.PersistentVolumeClaimSpec{
+						StorageClassName: ptr.To("standard"),
+					},
+				},
+				WalStorage: &apiv1.StorageConfiguration{},
+			},
+		}
+		Expect(GetInstancesWithOutdatedStorageClass(cluster, pvcs)).To(
+			Equal([]string{"cluster-example-2", "cluster-example-3"}))
+	})
+
+	It("ignores the volumes using the default storage class", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				WalStorage: &apiv1.StorageConfiguration{},
+			},
+		}
+		Expect(GetInstancesWithOutdatedStorageClass(cluster, pvcs)).To(BeEmpty())
+	})
+})
@@ -18,12 +18,14 @@ package persistentvolumeclaim
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	volumesnapshot "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -49,7 +51,7 @@ func GetCandidateStorageSourceForPrimary(
 	backup *apiv1.Backup,
 ) *StorageSource {
 	if backup.IsCompletedVolumeSnapshot() {
-		return getCandidateSourceFromBackup(backup)
+		return GetCandidateSourceFromBackup(backup)
 	}
 	return getCandidateSourceFromClusterDefinition(cluster)
 }
@@ -89,7 +91,7 @@ func GetCandidateStorageSourceForReplica(
 
 	if result := getCandidateSourceFromBackupList(
 		ctx,
-		cluster.ObjectMeta.CreationTimestamp,
+		cluster,
 		backupList,
 	); result != nil {
 		return result
@@ -111,10 +113,11 @@ func GetCandidateStorageSourceForReplica(
 // given a backup list
 func getCandidateSourceFromBackupList(
 	ctx context.Context,
-	clusterCreationTime metav1.Time,
+	cluster *apiv1.Cluster,
 	backupList apiv1.BackupList,
 ) *StorageSource {
 	contextLogger := log.FromContext(ctx)
+	clusterCreationTime := cluster.ObjectMeta.CreationTimestamp
 
 	backupList.SortByReverseCreationTime()
 	for idx := range backupList.Items {
@@ -133,15 +136,47 @@ func getCandidateSourceFromBackupList(
 			continue
 		}
 
+		if missingTablespaces := getTablespacesMissingSnapshot(cluster, backup); len(missingTablespaces) > 0 {
+			contextLogger.Info(
+				"skipping backup as a potential recovery storage source candidate "+
+					"because it doesn't contain a snapshot for every tablespace",
+				"backupName", backup.Name,
+				"missingTablespaces", missingTablespaces)
+			continue
+		}
+
 		contextLogger.Debug("found a backup that is a valid storage source candidate")
 
-		return getCandidateSourceFromBackup(backup)
+		return GetCandidateSourceFromBackup(backup)
 	}
 
 	return nil
 }
 
-func getCandidateSourceFromBackup(backup *apiv1.Backup) *StorageSource {
+// getTablespacesMissingSnapshot returns the names of the non-ephemeral
+// tablespaces declared in the cluster that don't have a corresponding
+// snapshot in the passed backup
+func getTablespacesMissingSnapshot(cluster *apiv1.Cluster, backup *apiv1.Backup) []string {
+	snapshotted := make(map[string]bool, len(backup.Status.BackupSnapshotStatus.Elements))
+	for _, element := range backup.Status.BackupSnapshotStatus.Elements {
+		if utils.PVCRole(element.Type) == utils.PVCRolePgTablespace {
+			snapshotted[element.TablespaceName] = true
+		}
+	}
+
+	var result []string
+	for _, tablespace := range cluster.Spec.Tablespaces {
+		if !tablespace.IsEphemeral() && !snapshotted[tablespace.Name] {
+			result = append(result, tablespace.Name)
+		}
+	}
+
+	return result
+}
+
+// GetCandidateSourceFromBackup gets the storage source made of the
+// volume snapshots taken by a backup
+func GetCandidateSourceFromBackup(backup *apiv1.Backup) *StorageSource {
 	var result StorageSource
 	for _, element := range backup.Status.BackupSnapshotStatus.Elements {
 		reference := corev1.TypedLocalObjectReference{
@@ -182,3 +217,46 @@ func getCandidateSourceFromClusterDefinition(cluster *apiv1.Cluster) *StorageSou
 		TablespaceSource: volumeSnapshots.TablespaceStorage,
 	}
 }
+
+// fitStorageConfigurationToSource returns the passed storage configuration,
+// enlarging the requested size when the VolumeSnapshot used as a source
+// needs more space to be restored
+func fitStorageConfigurationToSource(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	conf apiv1.StorageConfiguration,
+	source *corev1.TypedLocalObjectReference,
+) (apiv1.StorageConfiguration, error) {
+	if source == nil || source.Kind != apiv1.VolumeSnapshotKind {
+		return conf, nil
+	}
+
+	var snapshot volumesnapshot.VolumeSnapshot
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.Name}, &snapshot)
+	if apierrs.IsNotFound(err) {
+		// Nothing to fit, the PVC will be pending until the snapshot
+		// is available
+		return conf, nil
+	}
+	if err != nil {
+		return conf, fmt.Errorf("while getting VolumeSnapshot %s: %w", source.Name, err)
+	}
+
+	if snapshot.Status == nil || snapshot.Status.RestoreSize == nil {
+		return conf, nil
+	}
+
+	restoreSize := *snapshot.Status.RestoreSize
+	if size := conf.GetSizeOrNil(); size != nil && size.Cmp(restoreSize) >= 0 {
+		return conf, nil
+	}
+
+	log.FromContext(ctx).Info(
+		"Enlarging the requested storage size to the restore size of the VolumeSnapshot",
+		"volumeSnapshotName", source.Name,
+		"requestedSize", conf.Size,
+		"restoreSize", restoreSize.String())
+	conf.Size = restoreSize.String()
+	return conf, nil
+}
@@ -20,11 +20,16 @@ import (
 	"context"
 	"time"
 
+	volumesnapshot "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -287,7 +292,13 @@ var _ = Describe("candidate backups", func() {
 		}
 		backupList.SortByReverseCreationTime()
 
-		source := getCandidateSourceFromBackupList(ctx, metav1.NewTime(time.Now().Add(-1*time.Hour)), backupList)
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+			},
+		}
+
+		source := getCandidateSourceFromBackupList(ctx, cluster, backupList)
 		Expect(source).ToNot(BeNil())
 		Expect(source.DataSource.Name).To(Equal("completed-backup"))
 	})
@@ -303,7 +314,108 @@ var _ = Describe("candidate backups", func() {
 		}
 		backupList.SortByReverseCreationTime()
 
-		source := getCandidateSourceFromBackupList(ctx, metav1.NewTime(time.Now().Add(1*time.Hour)), backupList)
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(time.Now().Add(1 * time.Hour)),
+			},
+		}
+
+		source := getCandidateSourceFromBackupList(ctx, cluster, backupList)
 		Expect(source).To(BeNil())
 	})
+
+	It("skips the backups not containing a snapshot for every tablespace", func(ctx context.Context) {
+		tablespaceBackup := completedBackup.DeepCopy()
+		tablespaceBackup.Name = "tablespace-backup"
+		tablespaceBackup.CreationTimestamp = metav1.NewTime(now.Add(-1 * time.Minute))
+		tablespaceBackup.Status.BackupSnapshotStatus.Elements = []apiv1.BackupSnapshotElementStatus{
+			{
+				Name: "tablespace-backup",
+				Type: string(utils.PVCRolePgData),
+			},
+			{
+				Name:           "tablespace-backup-tbs-tbs1",
+				Type:           string(utils.PVCRolePgTablespace),
+				TablespaceName: "tbs1",
+			},
+		}
+
+		backupList := apiv1.BackupList{
+			Items: []apiv1.Backup{
+				completedBackup,
+				*tablespaceBackup,
+			},
+		}
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(now.Add(-1 * time.Hour)),
+			},
+			Spec: apiv1.ClusterSpec{
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{Name: "tbs1"},
+				},
+			},
+		}
+
+		source := getCandidateSourceFromBackupList(ctx, cluster, backupList)
+		Expect(source).ToNot(BeNil())
+		Expect(source.DataSource.Name).To(Equal("tablespace-backup"))
+		Expect(source.TablespaceSource).To(HaveKey("tbs1"))
+		Expect(source.TablespaceSource["tbs1"].Name).To(Equal("tablespace-backup-tbs-tbs1"))
+	})
+})
+
+var _ = Describe("fitStorageConfigurationToSource", func() {
+	const namespace = "default"
+
+	snapshotSource := &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To(volumesnapshot.GroupName),
+		Kind:     apiv1.VolumeSnapshotKind,
+		Name:     "snapshot",
+	}
+
+	newClient := func(restoreSize string) client.Client {
+		snapshot := &volumesnapshot.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "snapshot",
+			},
+			Status: &volumesnapshot.VolumeSnapshotStatus{
+				RestoreSize: ptr.To(resource.MustParse(restoreSize)),
+			},
+		}
+		return fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(snapshot).
+			Build()
+	}
+
+	It("enlarges the size when the snapshot needs more space", func(ctx context.Context) {
+		conf, err := fitStorageConfigurationToSource(
+			ctx, newClient("2Gi"), namespace, apiv1.StorageConfiguration{Size: "1Gi"}, snapshotSource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conf.Size).To(Equal("2Gi"))
+	})
+
+	It("keeps the size when it is enough for the snapshot", func(ctx context.Context) {
+		conf, err := fitStorageConfigurationToSource(
+			ctx, newClient("1Gi"), namespace, apiv1.StorageConfiguration{Size: "5Gi"}, snapshotSource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conf.Size).To(Equal("5Gi"))
+	})
+
+	It("keeps the size when the snapshot doesn't exist", func(ctx context.Context) {
+		cli := fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).Build()
+		conf, err := fitStorageConfigurationToSource(
+			ctx, cli, namespace, apiv1.StorageConfiguration{Size: "1Gi"}, snapshotSource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conf.Size).To(Equal("1Gi"))
+	})
+
+	It("ignores sources that are not volume snapshots", func(ctx context.Context) {
+		conf, err := fitStorageConfigurationToSource(
+			ctx, newClient("2Gi"), namespace, apiv1.StorageConfiguration{Size: "1Gi"}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conf.Size).To(Equal("1Gi"))
+	})
 })
@@ -0,0 +1,78 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaclusterswitch
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// publishDemotionToken writes the demotion token of the demoted primary
+// cluster in the promotion token secret, where the designated primary of the
+// replica cluster being promoted is waiting for it
+func publishDemotionToken(ctx context.Context, cli client.Client, cluster *apiv1.Cluster) error {
+	if cluster.Spec.ReplicaCluster == nil ||
+		cluster.Spec.ReplicaCluster.PromotionTokenSecret == nil ||
+		len(cluster.Status.DemotionToken) == 0 {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx).WithName("replica_cluster_publish_token")
+
+	key := client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.ReplicaCluster.PromotionTokenSecret.Name,
+	}
+
+	var secret corev1.Secret
+	err := cli.Get(ctx, key, &secret)
+	if apierrs.IsNotFound(err) {
+		contextLogger.Info("creating the promotion token secret", "secretName", key.Name)
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Data: map[string][]byte{
+				apiv1.PromotionTokenSecretKey: []byte(cluster.Status.DemotionToken),
+			},
+		}
+		return cli.Create(ctx, &secret)
+	}
+	if err != nil {
+		return err
+	}
+
+	if string(secret.Data[apiv1.PromotionTokenSecretKey]) == cluster.Status.DemotionToken {
+		return nil
+	}
+
+	contextLogger.Info("publishing the demotion token", "secretName", key.Name)
+	origSecret := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[apiv1.PromotionTokenSecretKey] = []byte(cluster.Status.DemotionToken)
+	return cli.Patch(ctx, &secret, client.MergeFrom(origSecret))
+}
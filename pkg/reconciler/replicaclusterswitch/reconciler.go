@@ -43,6 +43,10 @@ func Reconcile(
 	instanceClient remote.InstanceClient,
 	instances postgres.PostgresqlStatusList,
 ) (*ctrl.Result, error) {
+	if err := reconcileUpstream(ctx, cli, cluster); err != nil {
+		return nil, err
+	}
+
 	if !cluster.IsReplica() {
 		return nil, nil
 	}
@@ -67,6 +71,35 @@ func Reconcile(
 	return startTransition(ctx, cli, cluster)
 }
 
+// reconcileUpstream keeps track of the cluster the designated primary is
+// streaming from. When a promotion changes the topology of the distributed
+// cluster, the instance manager will re-point the designated primary to the
+// new upstream cluster
+func reconcileUpstream(ctx context.Context, cli client.Client, cluster *apiv1.Cluster) error {
+	var upstream string
+	if cluster.IsReplica() {
+		upstream = cluster.GetReplicaSource()
+	}
+
+	if cluster.Status.SwitchReplicaClusterStatus.Upstream == upstream {
+		return nil
+	}
+
+	log.FromContext(ctx).WithName("replica_cluster").Info(
+		"replica cluster upstream changed",
+		"upstream", upstream,
+		"previousUpstream", cluster.Status.SwitchReplicaClusterStatus.Upstream)
+
+	return status.PatchWithOptimisticLock(
+		ctx,
+		cli,
+		cluster,
+		func(cluster *apiv1.Cluster) {
+			cluster.Status.SwitchReplicaClusterStatus.Upstream = upstream
+		},
+	)
+}
+
 func containsPrimaryInstance(instances postgres.PostgresqlStatusList) bool {
 	for _, item := range instances.Items {
 		if item.IsPrimary {
@@ -190,6 +223,10 @@ func reconcileDemotionToken(
 		}
 	}
 
+	if err := publishDemotionToken(ctx, cli, cluster); err != nil {
+		return nil, fmt.Errorf("while publishing the demotion token: %w", err)
+	}
+
 	if err := cleanupTransitionMetadata(ctx, cli, cluster); err != nil {
 		return nil, fmt.Errorf("while cleaning up demotion transition metadata: %w", err)
 	}
@@ -0,0 +1,337 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// dockerHubHost is the host used in the image names for Docker Hub
+	dockerHubHost = "docker.io"
+
+	// dockerHubRegistry is the host serving the registry API of Docker Hub
+	dockerHubRegistry = "registry-1.docker.io"
+
+	// defaultTimeout is the timeout of the requests to the registry
+	defaultTimeout = 30 * time.Second
+
+	// maxContentSize is the maximum size of the manifests and
+	// blobs downloaded from the registry
+	maxContentSize = 4 * 1024 * 1024
+)
+
+// manifestMediaTypes are the manifest formats accepted when resolving
+// a tag to its digest. Index formats come first, so that the digest of
+// multi-architecture images is not the one of a single platform
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var (
+	challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+	nextLinkRegex       = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+)
+
+var (
+	// ErrUnexpectedStatus is returned when the registry answers with an
+	// unexpected HTTP status code
+	ErrUnexpectedStatus = errors.New("unexpected status code from the registry")
+
+	// ErrNotFound is returned when the requested manifest or blob
+	// doesn't exist in the registry
+	ErrNotFound = errors.New("not found in the registry")
+)
+
+// Client queries the OCI distribution API of container registries,
+// using anonymous tokens when the registry requires authentication
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient creates a new registry client using the passed HTTP client,
+// or a default one when nil
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Client{httpClient: httpClient}
+}
+
+// ListTags gets the tags of the repository with the given name, i.e.
+// "ghcr.io/cloudnative-pg/postgresql"
+func (c *Client) ListTags(ctx context.Context, name string) ([]string, error) {
+	host, repository := splitName(name)
+	next := &url.URL{Scheme: "https", Host: host, Path: fmt.Sprintf("/v2/%s/tags/list", repository)}
+
+	var tags []string
+	for next != nil {
+		resp, err := c.do(ctx, http.MethodGet, next, repository, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("while decoding the tags of %s: %w", name, err)
+		}
+		tags = append(tags, page.Tags...)
+
+		next, err = nextPage(next, resp.Header.Get("Link"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+// GetDigest resolves a tag of the repository with the given name to
+// the digest of its manifest, i.e. "sha256:..."
+func (c *Client) GetDigest(ctx context.Context, name, tag string) (string, error) {
+	host, repository := splitName(name)
+	manifestURL := &url.URL{Scheme: "https", Host: host, Path: fmt.Sprintf("/v2/%s/manifests/%s", repository, tag)}
+
+	header := http.Header{}
+	header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	resp, err := c.do(ctx, http.MethodHead, manifestURL, repository, header)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("missing digest for %s:%s", name, tag)
+	}
+
+	return digest, nil
+}
+
+// GetManifest gets the OCI image manifest of the repository with
+// the given name, referenced by tag or digest
+func (c *Client) GetManifest(ctx context.Context, name, reference string) ([]byte, error) {
+	host, repository := splitName(name)
+	manifestURL := &url.URL{Scheme: "https", Host: host, Path: fmt.Sprintf("/v2/%s/manifests/%s", repository, reference)}
+
+	header := http.Header{}
+	header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	return c.getContent(ctx, manifestURL, repository, header)
+}
+
+// GetBlob gets the content of a blob of the repository with the given
+// name, verifying it matches its digest
+func (c *Client) GetBlob(ctx context.Context, name, digest string) ([]byte, error) {
+	host, repository := splitName(name)
+	blobURL := &url.URL{Scheme: "https", Host: host, Path: fmt.Sprintf("/v2/%s/blobs/%s", repository, digest)}
+
+	content, err := c.getContent(ctx, blobURL, repository, nil)
+	if err != nil {
+		return nil, err
+	}
+	if computeDigest(content) != digest {
+		return nil, fmt.Errorf("the content of blob %s doesn't match its digest", digest)
+	}
+
+	return content, nil
+}
+
+func (c *Client) getContent(
+	ctx context.Context,
+	target *url.URL,
+	repository string,
+	header http.Header,
+) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, target, repository, header)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxContentSize))
+}
+
+// do executes a request to the registry, retrying it with an anonymous
+// bearer token when the registry requires authentication
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	target *url.URL,
+	repository string,
+	header http.Header,
+) (*http.Response, error) {
+	resp, err := c.send(ctx, method, target, header, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+
+		token, err := c.getToken(ctx, challenge, repository)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp, err = c.send(ctx, method, target, header, token); err != nil {
+			return nil, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, target)
+	default:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s %s: %d", ErrUnexpectedStatus, method, target, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) send(
+	ctx context.Context,
+	method string,
+	target *url.URL,
+	header http.Header,
+	token string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// getToken requests an anonymous token to the authorization service
+// described by the WWW-Authenticate challenge of the registry
+func (c *Client) getToken(ctx context.Context, challenge, repository string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge from the registry: %q", challenge)
+	}
+
+	values := map[string]string{}
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid authentication realm from the registry: %q", challenge)
+	}
+
+	query := realm.Query()
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	resp, err := c.send(ctx, http.MethodGet, realm, nil, "")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("%w: GET %s: %d", ErrUnexpectedStatus, realm, resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("while decoding the registry token: %w", err)
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	return tokenResponse.AccessToken, nil
+}
+
+// splitName splits an image name into the host serving the
+// registry API and the name of the repository
+func splitName(name string) (string, string) {
+	host, repository, found := strings.Cut(name, "/")
+	if !found {
+		return dockerHubRegistry, "library/" + name
+	}
+
+	if host == dockerHubHost {
+		host = dockerHubRegistry
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+
+	return host, repository
+}
+
+// nextPage gets the URL of the next page of results from the
+// Link header of the response, if any
+func nextPage(current *url.URL, link string) (*url.URL, error) {
+	match := nextLinkRegex.FindStringSubmatch(link)
+	if match == nil {
+		return nil, nil
+	}
+
+	next, err := url.Parse(match[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid pagination link from the registry: %q", link)
+	}
+
+	return current.ResolveReference(next), nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testToken = "anonymous-token"
+
+var _ = Describe("registry client", func() {
+	var (
+		server *httptest.Server
+		client *Client
+		name   string
+	)
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("scope") != "repository:cloudnative-pg/postgresql:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"token":%q}`, testToken)
+		})
+		mux.HandleFunc("/v2/cloudnative-pg/postgresql/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+testToken {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+					`Bearer realm="%s/token",service="test",scope="repository:cloudnative-pg/postgresql:pull"`,
+					server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			switch {
+			case r.URL.Path == "/v2/cloudnative-pg/postgresql/tags/list" && r.URL.Query().Get("last") == "":
+				w.Header().Set("Link", `</v2/cloudnative-pg/postgresql/tags/list?last=16.3&n=2>; rel="next"`)
+				_, _ = w.Write([]byte(`{"name":"cloudnative-pg/postgresql","tags":["16.2","16.3"]}`))
+			case r.URL.Path == "/v2/cloudnative-pg/postgresql/tags/list":
+				_, _ = w.Write([]byte(`{"name":"cloudnative-pg/postgresql","tags":["16.4"]}`))
+			case r.URL.Path == "/v2/cloudnative-pg/postgresql/manifests/16.4" && r.Method == http.MethodHead:
+				if !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+					w.WriteHeader(http.StatusNotAcceptable)
+					return
+				}
+				w.Header().Set("Docker-Content-Digest", "sha256:0123")
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+
+		server = httptest.NewTLSServer(mux)
+		client = NewClient(server.Client())
+		name = strings.TrimPrefix(server.URL, "https://") + "/cloudnative-pg/postgresql"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the tags following the pagination links", func(ctx context.Context) {
+		tags, err := client.ListTags(ctx, name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(Equal([]string{"16.2", "16.3", "16.4"}))
+	})
+
+	It("resolves a tag to its digest", func(ctx context.Context) {
+		digest, err := client.GetDigest(ctx, name, "16.4")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal("sha256:0123"))
+	})
+
+	It("complains about missing tags", func(ctx context.Context) {
+		_, err := client.GetDigest(ctx, name, "17.0")
+		Expect(err).To(MatchError(ErrNotFound))
+	})
+})
+
+var _ = Describe("splitName", func() {
+	It("uses the Docker Hub registry for docker.io images", func() {
+		host, repository := splitName("docker.io/library/postgres")
+		Expect(host).To(Equal("registry-1.docker.io"))
+		Expect(repository).To(Equal("library/postgres"))
+
+		host, repository = splitName("docker.io/postgres")
+		Expect(host).To(Equal("registry-1.docker.io"))
+		Expect(repository).To(Equal("library/postgres"))
+	})
+
+	It("splits the host from the repository", func() {
+		host, repository := splitName("ghcr.io/cloudnative-pg/postgresql")
+		Expect(host).To(Equal("ghcr.io"))
+		Expect(repository).To(Equal("cloudnative-pg/postgresql"))
+	})
+})
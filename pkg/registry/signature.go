@@ -0,0 +1,197 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The annotations used by cosign on the layers of a signature manifest
+const (
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+)
+
+// ErrNoValidSignature is returned when an image has no signature
+// matching the verification policy
+var ErrNoValidSignature = errors.New("no valid signature found")
+
+// Signature is a cosign signature of an image
+type Signature struct {
+	// Payload is the signed simple signing document
+	Payload []byte
+
+	// Signature is the signature of the payload
+	Signature []byte
+
+	// Keyless is true when the signature has been produced with
+	// keyless signing, and is bound to a Fulcio certificate
+	Keyless bool
+}
+
+// Verifier verifies the cosign signatures of an image against a policy
+type Verifier struct {
+	// PublicKeys are the trusted signing keys
+	PublicKeys []crypto.PublicKey
+}
+
+// simpleSigning is the payload signed by cosign
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// ociManifest is the subset of an OCI image manifest used to
+// read cosign signatures
+type ociManifest struct {
+	Layers []struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// GetCosignSignatures gets the cosign signatures attached to the image
+// with the given name and digest. An image without signatures has an
+// empty list
+func (c *Client) GetCosignSignatures(ctx context.Context, name, digest string) ([]Signature, error) {
+	tag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	content, err := c.GetManifest(ctx, name, tag)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("while decoding the signature manifest of %s: %w", name, err)
+	}
+
+	signatures := make([]Signature, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		encodedSignature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		_, keyless := layer.Annotations[cosignCertificateAnnotation]
+		signature := Signature{Keyless: keyless}
+		if signature.Signature, err = base64.StdEncoding.DecodeString(encodedSignature); err != nil {
+			return nil, fmt.Errorf("while decoding a signature of %s: %w", name, err)
+		}
+		if signature.Payload, err = c.GetBlob(ctx, name, layer.Digest); err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, signature)
+	}
+
+	return signatures, nil
+}
+
+// ParsePublicKey parses a PEM encoded public key, as generated by cosign
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// Verify checks that at least one of the signatures is valid for the
+// image with the given digest, returning the reasons of the rejection
+// otherwise
+func (v *Verifier) Verify(signatures []Signature, digest string) error {
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w: the image is not signed", ErrNoValidSignature)
+	}
+
+	reasons := make([]string, 0, len(signatures))
+	for _, signature := range signatures {
+		err := v.verifySignature(signature, digest)
+		if err == nil {
+			return nil
+		}
+		reasons = append(reasons, err.Error())
+	}
+
+	return fmt.Errorf("%w: %s", ErrNoValidSignature, strings.Join(reasons, "; "))
+}
+
+func (v *Verifier) verifySignature(signature Signature, digest string) error {
+	var payload simpleSigning
+	if err := json.Unmarshal(signature.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid signature payload: %w", err)
+	}
+	if payload.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature of a different image (%s)", payload.Critical.Image.DockerManifestDigest)
+	}
+
+	// Trusting a keyless signature requires verifying its Fulcio certificate
+	// chain and its Rekor transparency log entry, which is not supported
+	if signature.Keyless {
+		return errors.New("keyless signatures are not supported")
+	}
+
+	for _, key := range v.PublicKeys {
+		if verifyWithKey(key, signature.Payload, signature.Signature) == nil {
+			return nil
+		}
+	}
+
+	return errors.New("signature not matching any trusted public key")
+}
+
+// verifyWithKey verifies a signature of the SHA-256 digest of the content
+func verifyWithKey(key crypto.PublicKey, content, signature []byte) error {
+	hash := sha256.Sum256(content)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// computeDigest computes the digest of a content, in the format
+// used by the registries
+func computeDigest(content []byte) string {
+	hash := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,117 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testDigest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+
+func newTestPayload(digest string) []byte {
+	return []byte(fmt.Sprintf(
+		`{"critical":{"identity":{"docker-reference":"ghcr.io/cloudnative-pg/postgresql"},`+
+			`"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+		digest))
+}
+
+func sign(key *ecdsa.PrivateKey, content []byte) []byte {
+	hash := sha256.Sum256(content)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	Expect(err).ToNot(HaveOccurred())
+	return signature
+}
+
+func newTestKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	return key
+}
+
+var _ = Describe("cosign signature verification", func() {
+	It("parses PEM encoded public keys", func() {
+		key := newTestKey()
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		publicKey, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(publicKey).To(Equal(&key.PublicKey))
+
+		_, err = ParsePublicKey([]byte("not a key"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("with public keys", func() {
+		var (
+			key      *ecdsa.PrivateKey
+			verifier *Verifier
+		)
+
+		BeforeEach(func() {
+			key = newTestKey()
+			verifier = &Verifier{PublicKeys: []crypto.PublicKey{&newTestKey().PublicKey, &key.PublicKey}}
+		})
+
+		It("accepts images signed with a trusted key", func() {
+			payload := newTestPayload(testDigest)
+			Expect(verifier.Verify([]Signature{{Payload: payload, Signature: sign(key, payload)}}, testDigest)).
+				To(Succeed())
+		})
+
+		It("rejects unsigned images", func() {
+			Expect(verifier.Verify(nil, testDigest)).To(MatchError(ErrNoValidSignature))
+		})
+
+		It("rejects signatures of other images", func() {
+			payload := newTestPayload("sha256:0000")
+			err := verifier.Verify([]Signature{{Payload: payload, Signature: sign(key, payload)}}, testDigest)
+			Expect(err).To(MatchError(ErrNoValidSignature))
+			Expect(err.Error()).To(ContainSubstring("signature of a different image"))
+		})
+
+		It("rejects tampered payloads", func() {
+			payload := newTestPayload(testDigest)
+			signature := sign(key, newTestPayload("sha256:0000"))
+			Expect(verifier.Verify([]Signature{{Payload: payload, Signature: signature}}, testDigest)).
+				To(MatchError(ErrNoValidSignature))
+		})
+
+		It("rejects signatures made with untrusted keys", func() {
+			payload := newTestPayload(testDigest)
+			Expect(verifier.Verify([]Signature{{Payload: payload, Signature: sign(newTestKey(), payload)}}, testDigest)).
+				To(MatchError(ErrNoValidSignature))
+		})
+
+		It("rejects keyless signatures", func() {
+			payload := newTestPayload(testDigest)
+			err := verifier.Verify([]Signature{{Payload: payload, Signature: sign(key, payload), Keyless: true}}, testDigest)
+			Expect(err).To(MatchError(ErrNoValidSignature))
+			Expect(err.Error()).To(ContainSubstring("keyless signatures are not supported"))
+		})
+	})
+})
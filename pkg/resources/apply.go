@@ -0,0 +1,46 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager is the field manager of the operator, owning the
+// fields of the objects it applies
+const FieldManager = "cloudnative-pg"
+
+// Apply creates or updates an object through server-side apply. The fields
+// previously applied by the operator and missing in the passed object are
+// removed, while the ones set by other field managers are preserved
+func Apply(ctx context.Context, cli client.Client, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, cli.Scheme())
+	if err != nil {
+		return err
+	}
+
+	// An apply request needs the type of the object and can't carry
+	// its resource version or the managed fields
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	return cli.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
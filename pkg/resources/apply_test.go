@@ -0,0 +1,76 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Apply", func() {
+	It("sends an apply patch owned by the operator", func(ctx SpecContext) {
+		var (
+			patchType    types.PatchType
+			patchOptions client.PatchOptions
+			appliedKind  string
+		)
+
+		cli := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(
+					_ context.Context,
+					_ client.WithWatch,
+					obj client.Object,
+					patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					patchType = patch.Type()
+					patchOptions.ApplyOptions(opts)
+					appliedKind = obj.GetObjectKind().GroupVersionKind().Kind
+					return nil
+				},
+			}).
+			Build()
+
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "cluster-example",
+				Namespace:       "default",
+				ResourceVersion: "42",
+			},
+		}
+		Expect(Apply(ctx, cli, pdb)).To(Succeed())
+
+		Expect(patchType).To(Equal(types.ApplyPatchType))
+		Expect(patchOptions.FieldManager).To(Equal(FieldManager))
+		Expect(patchOptions.Force).To(HaveValue(BeTrue()))
+		Expect(appliedKind).To(Equal("PodDisruptionBudget"))
+		Expect(pdb.ResourceVersion).To(BeEmpty())
+	})
+})
@@ -46,7 +46,10 @@ func PatchConditionsWithOptimisticLock(
 	applyConditions := func(cluster *apiv1.Cluster) bool {
 		changed := false
 		for _, c := range conditions {
-			changed = changed || meta.SetStatusCondition(&cluster.Status.Conditions, c)
+			// every condition needs to be set, even after the first change
+			if meta.SetStatusCondition(&cluster.Status.Conditions, c) {
+				changed = true
+			}
 		}
 		return changed
 	}
@@ -21,11 +21,13 @@ import (
 	"fmt"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 )
 
 // RegisterPhase update phase in the status cluster with the
@@ -90,6 +92,11 @@ func RegisterPhaseWithOrigCluster(
 	modifiedPhase := modifiedCluster.Status.Phase
 	origPhase := origCluster.Status.Phase
 
+	if modifiedPhase != origPhase {
+		telemetry.AddEvent(ctx, "cluster.phase",
+			attribute.String("cnpg.phase", modifiedPhase),
+			attribute.String("cnpg.phase_reason", reason))
+	}
 	if modifiedPhase != apiv1.PhaseHealthy && origPhase == apiv1.PhaseHealthy {
 		contextLogger.Info("Cluster is not healthy")
 	}
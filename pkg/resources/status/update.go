@@ -71,3 +71,24 @@ func PatchWithOptimisticLock(
 
 	return nil
 }
+
+// PatchIfChanged patches the status of the cluster with the changes made
+// since its original version, skipping the request when the status didn't
+// change. Like an update, the patch fails with a conflict when the cluster
+// has been changed in the meantime, but it only carries the changed fields
+func PatchIfChanged(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	origCluster *apiv1.Cluster,
+) error {
+	if equality.Semantic.DeepEqual(origCluster.Status, cluster.Status) {
+		return nil
+	}
+
+	return c.Status().Patch(
+		ctx,
+		cluster,
+		client.MergeFromWithOptions(origCluster, client.MergeFromWithOptimisticLock{}),
+	)
+}
@@ -39,7 +39,7 @@ func createBootstrapContainer(cluster apiv1.Cluster) corev1.Container {
 			"/controller/manager",
 		},
 		VolumeMounts:    createPostgresVolumeMounts(cluster),
-		Resources:       cluster.Spec.Resources,
+		Resources:       cluster.GetResources(),
 		SecurityContext: CreateContainerSecurityContext(cluster.GetSeccompProfile()),
 	}
 
@@ -24,6 +24,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -40,6 +41,14 @@ const (
 	postInitSQLRefsFolder            postInitFolder = "/etc/post-init-sql"
 )
 
+// DataMaskingSQLRefsFolder is the folder containing the data masking
+// SQL files, in the primary job restoring or cloning the data
+const DataMaskingSQLRefsFolder = "/etc/data-masking-sql"
+
+// majorUpgradeOldBinariesPath is the directory where the binaries of
+// the previous major version are copied during a major version upgrade
+const majorUpgradeOldBinariesPath = "/controller/old"
+
 func (p postInitFolder) toString() string {
 	return string(p)
 }
@@ -107,6 +116,16 @@ func CreatePrimaryJobViaInitdb(cluster apiv1.Cluster, nodeSerial int) *batchv1.J
 	return createPrimaryJob(cluster, nodeSerial, jobRoleInitDB, initCommand)
 }
 
+// CreatePrimaryJobViaMigration creates a new primary instance in a Pod,
+// importing the schema of the databases migrated from the external cluster
+func CreatePrimaryJobViaMigration(cluster apiv1.Cluster, nodeSerial int) *batchv1.Job {
+	cluster.Spec.Bootstrap = &apiv1.BootstrapConfiguration{
+		InitDB: cluster.Spec.Bootstrap.Migration.GetImportBootstrap(),
+	}
+
+	return CreatePrimaryJobViaInitdb(cluster, nodeSerial)
+}
+
 func buildInitDBFlags(cluster apiv1.Cluster) (initCommand []string) {
 	config := cluster.Spec.Bootstrap.InitDB
 	var options []string
@@ -283,6 +302,104 @@ func RestoreReplicaInstance(cluster apiv1.Cluster, nodeSerial int) *batchv1.Job
 	return job
 }
 
+// GetBackupVerificationJobName returns the name of the Job verifying
+// the passed backup
+func GetBackupVerificationJobName(backupName string) string {
+	return fmt.Sprintf("%s-%s", backupName, jobRoleBackupVerification)
+}
+
+// CreateBackupVerificationJob creates a Job restoring the passed backup in a
+// throwaway instance to verify it. The instance uses ephemeral volumes in
+// place of the persistent volume claims of the cluster, and the Job is not
+// controlled by the cluster, so that it doesn't interfere with its
+// reconciliation loop
+func CreateBackupVerificationJob(cluster apiv1.Cluster, backup *apiv1.Backup) *batchv1.Job {
+	initCommand := []string{
+		"/controller/manager",
+		"instance",
+		"verifybackup",
+		"--backup-name", backup.Name,
+	}
+
+	job := createPrimaryJob(cluster, 0, jobRoleBackupVerification, initCommand)
+
+	jobName := GetBackupVerificationJobName(backup.Name)
+	job.Name = jobName
+	job.OwnerReferences = nil
+	job.Spec.BackoffLimit = ptr.To[int32](0)
+	job.Spec.Template.Spec.Hostname = jobName
+	job.Spec.Template.Spec.Subdomain = ""
+	job.Spec.Template.Spec.Containers[0].Env = CreatePodEnvConfig(cluster, jobName).EnvVars
+
+	for _, labels := range []map[string]string{job.Labels, job.Spec.Template.Labels} {
+		delete(labels, utils.InstanceNameLabelName)
+		labels[utils.BackupNameLabelName] = backup.Name
+	}
+
+	for idx := range job.Spec.Template.Spec.Volumes {
+		volume := &job.Spec.Template.Spec.Volumes[idx]
+		if volume.PersistentVolumeClaim != nil {
+			volume.VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		}
+	}
+
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.Verification != nil &&
+		cluster.Spec.Backup.Verification.Resources != nil {
+		job.Spec.Template.Spec.Containers[0].Resources = *cluster.Spec.Backup.Verification.Resources
+	}
+
+	AddBarmanEndpointCAToPodSpec(&job.Spec.Template.Spec, backup.Status.EndpointCA, backup.Status.BarmanCredentials)
+
+	return job
+}
+
+// CreateMajorUpgradeJob creates a Job running pg_upgrade on the volumes of
+// the passed instance. The binaries of the previous major version, which
+// pg_upgrade needs, are copied from the passed image by an init container
+func CreateMajorUpgradeJob(cluster apiv1.Cluster, nodeSerial int, oldImage string) *batchv1.Job {
+	upgradeCommand := []string{
+		"/controller/manager",
+		"instance",
+		"upgrade",
+		"execute",
+		"--old-binaries", majorUpgradeOldBinariesPath,
+	}
+	if cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.InitDB != nil {
+		upgradeCommand = append(upgradeCommand, buildInitDBFlags(cluster)...)
+	}
+	upgradeCommand = append(upgradeCommand, buildCommonInitJobFlags(cluster)...)
+
+	job := createPrimaryJob(cluster, nodeSerial, jobRoleMajorUpgrade, upgradeCommand)
+
+	// pg_upgrade in link mode cannot be safely retried
+	job.Spec.BackoffLimit = ptr.To[int32](0)
+
+	oldBinariesContainer := corev1.Container{
+		Name:            MajorUpgradeOldBinariesContainerName,
+		Image:           oldImage,
+		ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+		Command: []string{
+			"/controller/manager",
+			"instance",
+			"upgrade",
+			"prepare",
+			majorUpgradeOldBinariesPath,
+		},
+		VolumeMounts:    createPostgresVolumeMounts(cluster),
+		Resources:       cluster.Spec.Resources,
+		SecurityContext: CreateContainerSecurityContext(cluster.GetSeccompProfile()),
+	}
+	addManagerLoggingOptions(cluster, &oldBinariesContainer)
+	job.Spec.Template.Spec.InitContainers = append(job.Spec.Template.Spec.InitContainers, oldBinariesContainer)
+
+	return job
+}
+
+// IsMajorUpgradeJob checks if the passed Job is running a major version upgrade
+func IsMajorUpgradeJob(job *batchv1.Job) bool {
+	return job.Spec.Template.Labels[utils.JobRoleLabelName] == string(jobRoleMajorUpgrade)
+}
+
 func buildCommonInitJobFlags(cluster apiv1.Cluster) []string {
 	var flags []string
 
@@ -303,15 +420,31 @@ const (
 	jobRoleFullRecovery     jobRole = "full-recovery"
 	jobRoleJoin             jobRole = "join"
 	jobRoleSnapshotRecovery jobRole = "snapshot-recovery"
+	jobRoleMajorUpgrade     jobRole = "major-upgrade"
+
+	jobRoleBackupVerification jobRole = "verification"
 )
 
-var jobRoleList = []jobRole{jobRoleImport, jobRoleInitDB, jobRolePGBaseBackup, jobRoleFullRecovery, jobRoleJoin}
+var jobRoleList = []jobRole{
+	jobRoleImport,
+	jobRoleInitDB,
+	jobRolePGBaseBackup,
+	jobRoleFullRecovery,
+	jobRoleJoin,
+	jobRoleMajorUpgrade,
+}
 
 // getJobName returns a string indicating the job name
 func (role jobRole) getJobName(instanceName string) string {
 	return fmt.Sprintf("%s-%s", instanceName, role)
 }
 
+// copiesData checks if the job bootstraps the cluster from the data
+// of another one
+func (role jobRole) copiesData() bool {
+	return role == jobRoleFullRecovery || role == jobRoleSnapshotRecovery || role == jobRolePGBaseBackup
+}
+
 // GetPossibleJobNames get all the possible job names for a given instance
 func GetPossibleJobNames(instanceName string) []string {
 	res := make([]string, len(jobRoleList))
@@ -371,7 +504,7 @@ func createPrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initC
 						cluster.GetSeccompProfile(),
 						cluster.GetPostgresUID(),
 						cluster.GetPostgresGID()),
-					Affinity:                  CreateAffinitySection(cluster.Name, cluster.Spec.Affinity),
+					Affinity:                  createInstanceAffinity(cluster),
 					Tolerations:               cluster.Spec.Affinity.Tolerations,
 					ServiceAccountName:        cluster.Name,
 					RestartPolicy:             corev1.RestartPolicyNever,
@@ -422,6 +555,16 @@ func createPrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initC
 			job.Spec.Template.Spec.Containers[0].VolumeMounts, volumeMounts...)
 	}
 
+	if cluster.ShouldRunDataMaskingSQLRefs() && role.copiesData() {
+		volumes, volumeMounts := createVolumesAndVolumeMountsForSQLRefs(
+			DataMaskingSQLRefsFolder,
+			cluster.GetDataMasking().SQLRefs,
+		)
+		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, volumes...)
+		job.Spec.Template.Spec.Containers[0].VolumeMounts = append(
+			job.Spec.Template.Spec.Containers[0].VolumeMounts, volumeMounts...)
+	}
+
 	if cluster.Spec.PriorityClassName != "" {
 		job.Spec.Template.Spec.PriorityClassName = cluster.Spec.PriorityClassName
 	}
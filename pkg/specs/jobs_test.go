@@ -21,9 +21,12 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -193,3 +196,150 @@ var _ = Describe("Job created via InitDB", func() {
 		Expect(initdbFlags).Should(ContainSubstring("'--icu-rules=&A < z <<< Z'"))
 	})
 })
+
+var _ = Describe("Job created via migration", func() {
+	It("imports the schema of the migrated databases", func() {
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Migration: &apiv1.BootstrapMigration{
+						Source:    "source",
+						Databases: []string{"app"},
+					},
+				},
+			},
+		}
+		job := CreatePrimaryJobViaMigration(cluster, 1)
+
+		Expect(job.Name).To(Equal(jobRoleImport.getJobName("cluster-example-1")))
+		Expect(job.Spec.Template.Spec.Containers[0].Command).Should(ContainElement("init"))
+		Expect(cluster.Spec.Bootstrap.InitDB).To(BeNil())
+	})
+})
+
+var _ = Describe("Data masking SQL references", func() {
+	cluster := apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-example",
+		},
+		Spec: apiv1.ClusterSpec{
+			Bootstrap: &apiv1.BootstrapConfiguration{
+				Clone: &apiv1.BootstrapClone{
+					Cluster: "cluster-prod",
+					Masking: &apiv1.DataMaskingConfiguration{
+						SQLRefs: &apiv1.SQLRefs{
+							ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+								{
+									Key:                  "masking.sql",
+									LocalObjectReference: apiv1.LocalObjectReference{Name: "masking"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	It("are mounted in the job copying the data", func() {
+		job := CreatePrimaryJobViaPgBaseBackup(cluster, 1)
+		Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "0-data-masking-sql")))
+		Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(
+			ContainElement(HaveField("MountPath", DataMaskingSQLRefsFolder+"/0.sql")))
+	})
+
+	It("are not mounted in the jobs joining the cluster", func() {
+		job := JoinReplicaInstance(cluster, 2)
+		Expect(job.Spec.Template.Spec.Volumes).ToNot(ContainElement(HaveField("Name", "0-data-masking-sql")))
+	})
+})
+
+var _ = Describe("Backup verification job", func() {
+	cluster := apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example",
+			Namespace: "default",
+		},
+		Spec: apiv1.ClusterSpec{
+			Backup: &apiv1.BackupConfiguration{
+				Verification: &apiv1.BackupVerificationConfiguration{
+					Schedule: "0 0 6 * * *",
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	backup := &apiv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup-example",
+			Namespace: "default",
+		},
+	}
+
+	It("restores the backup in ephemeral volumes", func() {
+		job := CreateBackupVerificationJob(cluster, backup)
+		Expect(job.Name).To(Equal("backup-example-verification"))
+		Expect(job.OwnerReferences).To(BeEmpty())
+		Expect(job.Labels).ToNot(HaveKey(utils.InstanceNameLabelName))
+		Expect(job.Spec.Template.Labels[utils.BackupNameLabelName]).To(Equal("backup-example"))
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(ContainElements("verifybackup", "backup-example"))
+		for _, volume := range job.Spec.Template.Spec.Volumes {
+			Expect(volume.PersistentVolumeClaim).To(BeNil())
+		}
+	})
+
+	It("uses the resources of the verification configuration", func() {
+		job := CreateBackupVerificationJob(cluster, backup)
+		Expect(job.Spec.Template.Spec.Containers[0].Resources.Limits).
+			To(HaveKeyWithValue(corev1.ResourceMemory, resource.MustParse("1Gi")))
+	})
+})
+
+var _ = Describe("Major upgrade job", func() {
+	cluster := apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example",
+			Namespace: "default",
+		},
+		Spec: apiv1.ClusterSpec{
+			ImageName: "postgres:17.2",
+			Bootstrap: &apiv1.BootstrapConfiguration{
+				InitDB: &apiv1.BootstrapInitDB{
+					DataChecksums: ptr.To(true),
+				},
+			},
+		},
+	}
+
+	It("runs pg_upgrade on the volumes of the instance", func() {
+		job := CreateMajorUpgradeJob(cluster, 1, "postgres:16.6")
+		Expect(job.Name).To(Equal("cluster-example-1-major-upgrade"))
+		Expect(IsMajorUpgradeJob(job)).To(BeTrue())
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+		Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("postgres:17.2"))
+		Expect(job.Spec.Template.Spec.Containers[0].Command).
+			To(ContainElements("upgrade", "execute", "--initdb-flags", "-k"))
+		Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "pgdata")))
+	})
+
+	It("copies the binaries of the previous major version from the old image", func() {
+		job := CreateMajorUpgradeJob(cluster, 1, "postgres:16.6")
+		initContainers := job.Spec.Template.Spec.InitContainers
+		Expect(initContainers).To(HaveLen(2))
+		Expect(initContainers[0].Name).To(Equal(BootstrapControllerContainerName))
+		Expect(initContainers[1].Name).To(Equal(MajorUpgradeOldBinariesContainerName))
+		Expect(initContainers[1].Image).To(Equal("postgres:16.6"))
+		Expect(initContainers[1].Command).To(ContainElements("upgrade", "prepare", majorUpgradeOldBinariesPath))
+	})
+
+	It("is not recognized as an upgrade job when created for another purpose", func() {
+		Expect(IsMajorUpgradeJob(CreatePrimaryJobViaInitdb(cluster, 1))).To(BeFalse())
+	})
+})
@@ -0,0 +1,148 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"fmt"
+	"maps"
+	"math"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// lifecycleHookJobTTL is how long the completed lifecycle hook jobs
+// are kept, so that their logs can be inspected
+const lifecycleHookJobTTL = 24 * 60 * 60
+
+// CreateLifecycleHookEnv creates the environment variables describing
+// the event which triggered a lifecycle hook
+func CreateLifecycleHookEnv(hook apiv1.LifecycleHook, instance string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "HOOK_NAME",
+			Value: hook.Name,
+		},
+		{
+			Name:  "HOOK_EVENT",
+			Value: string(hook.Event),
+		},
+		{
+			Name:  "HOOK_INSTANCE",
+			Value: instance,
+		},
+	}
+}
+
+// CreateLifecycleHookJob creates the Job running a lifecycle hook for
+// the event referring to the given instance. The Job is not controlled
+// by the cluster, so that it doesn't interfere with its reconciliation
+// loop, but it is garbage collected together with it
+func CreateLifecycleHookJob(cluster apiv1.Cluster, hook apiv1.LifecycleHook, instance string) *batchv1.Job {
+	image := hook.Job.Image
+	if image == "" {
+		image = cluster.GetImageName()
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "NAMESPACE",
+			Value: cluster.Namespace,
+		},
+		{
+			Name:  "CLUSTER_NAME",
+			Value: cluster.Name,
+		},
+		{
+			Name:  "PGHOST",
+			Value: cluster.GetServiceReadWriteName(),
+		},
+		{
+			Name:  "PGPORT",
+			Value: fmt.Sprint(postgres.ServerPort),
+		},
+	}
+	env = append(env, CreateLifecycleHookEnv(hook, instance)...)
+	env = append(env, hook.Job.Env...)
+
+	labels := map[string]string{
+		utils.ClusterLabelName:       cluster.Name,
+		utils.LifecycleHookLabelName: hook.Name,
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-hook-%s-", cluster.Name, hook.Name),
+			Namespace:    cluster.Namespace,
+			Labels:       labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: cluster.APIVersion,
+					Kind:       cluster.Kind,
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				},
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To[int32](0),
+			ActiveDeadlineSeconds:   ptr.To(int64(math.Ceil(hook.GetTimeout().Seconds()))),
+			TTLSecondsAfterFinished: ptr.To[int32](lifecycleHookJobTTL),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: maps.Clone(labels),
+				},
+				Spec: corev1.PodSpec{
+					SchedulerName: cluster.Spec.SchedulerName,
+					Containers: []corev1.Container{
+						{
+							Name:            "hook",
+							Image:           image,
+							ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+							Command:         hook.Job.Command,
+							Args:            hook.Job.Args,
+							Env:             env,
+							SecurityContext: CreateContainerSecurityContext(cluster.GetSeccompProfile()),
+						},
+					},
+					SecurityContext: CreatePodSecurityContext(
+						cluster.GetSeccompProfile(),
+						cluster.GetPostgresUID(),
+						cluster.GetPostgresGID()),
+					// The service account of the cluster provides the image pull
+					// secrets, but its token, granting the permissions of the
+					// instance manager, is not mounted
+					ServiceAccountName:           cluster.Name,
+					AutomountServiceAccountToken: ptr.To(false),
+					RestartPolicy:                corev1.RestartPolicyNever,
+					NodeSelector:                 cluster.Spec.Affinity.NodeSelector,
+					Tolerations:                  cluster.Spec.Affinity.Tolerations,
+				},
+			},
+		},
+	}
+
+	cluster.SetInheritedData(&job.ObjectMeta)
+
+	return job
+}
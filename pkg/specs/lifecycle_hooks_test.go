@@ -0,0 +1,96 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lifecycle hook jobs", func() {
+	cluster := apiv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       apiv1.ClusterKind,
+			APIVersion: apiv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example",
+			Namespace: "default",
+			UID:       "cluster-uid",
+		},
+		Spec: apiv1.ClusterSpec{
+			ImageName: "ghcr.io/cloudnative-pg/postgresql:17",
+		},
+	}
+
+	hook := apiv1.LifecycleHook{
+		Name:  "migrate",
+		Event: apiv1.LifecycleHookEventPostInit,
+		Job: &apiv1.LifecycleHookJob{
+			Command: []string{"/migrate"},
+			Args:    []string{"up"},
+			Env: []corev1.EnvVar{
+				{Name: "PGDATABASE", Value: "app"},
+			},
+		},
+		Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	It("runs the hook once, within its timeout", func() {
+		job := CreateLifecycleHookJob(cluster, hook, "cluster-example-1")
+		Expect(job.GenerateName).To(Equal("cluster-example-hook-migrate-"))
+		Expect(job.Labels).To(HaveKeyWithValue(utils.ClusterLabelName, "cluster-example"))
+		Expect(job.Labels).To(HaveKeyWithValue(utils.LifecycleHookLabelName, "migrate"))
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+		Expect(*job.Spec.ActiveDeadlineSeconds).To(BeEquivalentTo(600))
+		Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(*job.Spec.Template.Spec.AutomountServiceAccountToken).To(BeFalse())
+	})
+
+	It("is not controlled by the cluster", func() {
+		job := CreateLifecycleHookJob(cluster, hook, "cluster-example-1")
+		Expect(job.OwnerReferences).To(HaveLen(1))
+		Expect(job.OwnerReferences[0].Name).To(Equal("cluster-example"))
+		Expect(job.OwnerReferences[0].Controller).To(BeNil())
+		Expect(metav1.GetControllerOf(job)).To(BeNil())
+	})
+
+	It("describes the event to the container", func() {
+		job := CreateLifecycleHookJob(cluster, hook, "cluster-example-1")
+		container := job.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal("ghcr.io/cloudnative-pg/postgresql:17"))
+		Expect(container.Command).To(Equal([]string{"/migrate"}))
+		Expect(container.Args).To(Equal([]string{"up"}))
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: "CLUSTER_NAME", Value: "cluster-example"},
+			corev1.EnvVar{Name: "PGHOST", Value: "cluster-example-rw"},
+			corev1.EnvVar{Name: "PGPORT", Value: "5432"},
+			corev1.EnvVar{Name: "HOOK_NAME", Value: "migrate"},
+			corev1.EnvVar{Name: "HOOK_EVENT", Value: "postInit"},
+			corev1.EnvVar{Name: "HOOK_INSTANCE", Value: "cluster-example-1"},
+			corev1.EnvVar{Name: "PGDATABASE", Value: "app"},
+		))
+	})
+})
@@ -0,0 +1,124 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// LogicalUpgradeSourceExternalClusterName is the name of the external
+// cluster, declared in the target cluster of a logical major version
+// upgrade, pointing to the cluster being upgraded
+const LogicalUpgradeSourceExternalClusterName = "logical-upgrade-source"
+
+// GetLogicalUpgradeTargetClusterName gets the name of the cluster created
+// to upgrade the passed cluster to the passed major version
+func GetLogicalUpgradeTargetClusterName(clusterName string, major int) string {
+	return fmt.Sprintf("%s-pg%d", clusterName, major)
+}
+
+// CreateLogicalUpgradeTargetCluster creates the cluster which will receive,
+// via logical replication, the data of the passed cluster. The target cluster
+// runs the requested PostgreSQL major version and is bootstrapped with the
+// schema of every database of the source cluster
+func CreateLogicalUpgradeTargetCluster(cluster apiv1.Cluster, major int) *apiv1.Cluster {
+	spec := cluster.Spec.DeepCopy()
+	spec.MajorUpgrade = nil
+	if spec.ImageCatalogRef == nil {
+		spec.ImageName = cluster.GetImageName()
+	}
+
+	// The target cluster must not interfere with the WAL archive
+	// and with the services of the source cluster
+	spec.Backup = nil
+	spec.Plugins = nil
+	spec.ReplicaCluster = nil
+	if spec.Managed != nil {
+		spec.Managed.Services = nil
+	}
+
+	initDB := &apiv1.BootstrapInitDB{}
+	if cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.InitDB != nil {
+		// Keep the settings of the source data directory
+		sourceInitDB := cluster.Spec.Bootstrap.InitDB
+		initDB.Options = slices.Clone(sourceInitDB.Options)
+		initDB.DataChecksums = sourceInitDB.DataChecksums
+		initDB.Encoding = sourceInitDB.Encoding
+		initDB.LocaleCollate = sourceInitDB.LocaleCollate
+		initDB.LocaleCType = sourceInitDB.LocaleCType
+		initDB.Locale = sourceInitDB.Locale
+		initDB.LocaleProvider = sourceInitDB.LocaleProvider
+		initDB.IcuLocale = sourceInitDB.IcuLocale
+		initDB.IcuRules = sourceInitDB.IcuRules
+		initDB.BuiltinLocale = sourceInitDB.BuiltinLocale
+		initDB.WalSegmentSize = sourceInitDB.WalSegmentSize
+	}
+	initDB.Import = &apiv1.Import{
+		Source: apiv1.ImportSource{
+			ExternalCluster: LogicalUpgradeSourceExternalClusterName,
+		},
+		Type:       apiv1.MonolithSnapshotType,
+		Databases:  []string{"*"},
+		Roles:      []string{"*"},
+		SchemaOnly: true,
+	}
+	spec.Bootstrap = &apiv1.BootstrapConfiguration{InitDB: initDB}
+
+	spec.ExternalClusters = slices.DeleteFunc(spec.ExternalClusters, func(server apiv1.ExternalCluster) bool {
+		return server.Name == LogicalUpgradeSourceExternalClusterName
+	})
+	spec.ExternalClusters = append(spec.ExternalClusters, apiv1.ExternalCluster{
+		Name: LogicalUpgradeSourceExternalClusterName,
+		ConnectionParameters: map[string]string{
+			"host":    cluster.GetServiceReadWriteName(),
+			"user":    "postgres",
+			"dbname":  "postgres",
+			"sslmode": "verify-full",
+		},
+		SSLRootCert: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: cluster.GetServerCASecretName(),
+			},
+			Key: certs.CACertKey,
+		},
+		Password: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: cluster.GetSuperuserSecretName(),
+			},
+			Key: corev1.BasicAuthPasswordKey,
+		},
+	})
+
+	return &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetLogicalUpgradeTargetClusterName(cluster.Name, major),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.LogicalUpgradeSourceLabelName: cluster.Name,
+			},
+		},
+		Spec: *spec,
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logical upgrade target cluster", func() {
+	cluster := apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example",
+			Namespace: "default",
+		},
+		Spec: apiv1.ClusterSpec{
+			ImageName:             "ghcr.io/cloudnative-pg/postgresql:17.2",
+			Instances:             3,
+			EnableSuperuserAccess: ptr.To(true),
+			MajorUpgrade: &apiv1.MajorUpgradeConfiguration{
+				Method: apiv1.MajorUpgradeMethodLogical,
+			},
+			Backup: &apiv1.BackupConfiguration{},
+			Bootstrap: &apiv1.BootstrapConfiguration{
+				InitDB: &apiv1.BootstrapInitDB{
+					Database:       "app",
+					Owner:          "app",
+					WalSegmentSize: 32,
+					PostInitSQL:    []string{"CREATE ROLE test"},
+				},
+			},
+		},
+		Status: apiv1.ClusterStatus{
+			Image: "ghcr.io/cloudnative-pg/postgresql:17.2",
+		},
+	}
+
+	It("names the target cluster after the major version", func() {
+		Expect(GetLogicalUpgradeTargetClusterName("cluster-example", 17)).To(Equal("cluster-example-pg17"))
+	})
+
+	It("creates the target cluster importing the schema of the source cluster", func() {
+		target := CreateLogicalUpgradeTargetCluster(cluster, 17)
+		Expect(target.Name).To(Equal("cluster-example-pg17"))
+		Expect(target.Namespace).To(Equal("default"))
+		Expect(target.Labels).To(HaveKeyWithValue(utils.LogicalUpgradeSourceLabelName, "cluster-example"))
+		Expect(target.Spec.ImageName).To(Equal("ghcr.io/cloudnative-pg/postgresql:17.2"))
+		Expect(target.Spec.Instances).To(Equal(3))
+		Expect(target.Spec.MajorUpgrade).To(BeNil())
+		Expect(target.Spec.Backup).To(BeNil())
+
+		initDB := target.Spec.Bootstrap.InitDB
+		Expect(initDB.WalSegmentSize).To(Equal(32))
+		Expect(initDB.PostInitSQL).To(BeEmpty())
+		Expect(initDB.Import).ToNot(BeNil())
+		Expect(initDB.Import.Type).To(Equal(apiv1.MonolithSnapshotType))
+		Expect(initDB.Import.SchemaOnly).To(BeTrue())
+		Expect(initDB.Import.Source.ExternalCluster).To(Equal(LogicalUpgradeSourceExternalClusterName))
+	})
+
+	It("connects the target cluster to the source one", func() {
+		target := CreateLogicalUpgradeTargetCluster(cluster, 17)
+		source, ok := target.ExternalCluster(LogicalUpgradeSourceExternalClusterName)
+		Expect(ok).To(BeTrue())
+		Expect(source.ConnectionParameters).To(HaveKeyWithValue("host", "cluster-example-rw"))
+		Expect(source.ConnectionParameters).To(HaveKeyWithValue("user", "postgres"))
+		Expect(source.Password.Name).To(Equal("cluster-example-superuser"))
+		Expect(source.SSLRootCert.Name).To(Equal("cluster-example-ca"))
+	})
+
+	It("doesn't change the source cluster", func() {
+		_ = CreateLogicalUpgradeTargetCluster(cluster, 17)
+		Expect(cluster.Spec.Backup).ToNot(BeNil())
+		Expect(cluster.Spec.ExternalClusters).To(BeEmpty())
+		Expect(cluster.Spec.Bootstrap.InitDB.Import).To(BeNil())
+	})
+})
@@ -52,6 +52,13 @@ func Deployment(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*appsv1.Deploymen
 		return nil, err
 	}
 
+	serverCASecretName := cluster.GetServerCASecretName()
+	serverTLSSecretName := cluster.GetServerTLSSecretName()
+	if pooler.IsCrossNamespace() {
+		serverCASecretName = pooler.GetReplicatedSecretName(serverCASecretName)
+		serverTLSSecretName = pooler.GetReplicatedSecretName(serverTLSSecretName)
+	}
+
 	podTemplate := podspec.NewFrom(pooler.Spec.Template).
 		WithLabel(utils.PgbouncerNameLabel, pooler.Name).
 		WithLabel(utils.ClusterLabelName, cluster.Name).
@@ -60,7 +67,7 @@ func Deployment(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*appsv1.Deploymen
 			Name: "ca",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: cluster.GetServerCASecretName(),
+					SecretName: serverCASecretName,
 				},
 			},
 		}).
@@ -68,12 +75,12 @@ func Deployment(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*appsv1.Deploymen
 			Name: "server-tls",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: cluster.GetServerTLSSecretName(),
+					SecretName: serverTLSSecretName,
 				},
 			},
 		}).
 		WithSecurityContext(specs.CreatePodSecurityContext(cluster.GetSeccompProfile(), 998, 996), true).
-		WithContainerImage("pgbouncer", DefaultPgbouncerImage, false).
+		WithContainerImage("pgbouncer", getPgbouncerImage(pooler), false).
 		WithContainerCommand("pgbouncer", []string{
 			"/controller/manager",
 			"pgbouncer",
@@ -119,6 +126,11 @@ func Deployment(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*appsv1.Deploymen
 		}, false).
 		WithContainerSecurityContext("pgbouncer", specs.CreateContainerSecurityContext(cluster.GetSeccompProfile()), true).
 		WithServiceAccountName(pooler.Name, true).
+		WithTopologySpreadConstraintsSelector(&metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				utils.PgbouncerNameLabel: pooler.Name,
+			},
+		}).
 		WithReadinessProbe("pgbouncer", &corev1.Probe{
 			TimeoutSeconds: 5,
 			ProbeHandler: corev1.ProbeHandler{
@@ -161,16 +173,28 @@ func Deployment(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*appsv1.Deploymen
 	}, nil
 }
 
+// getPgbouncerImage gets the PgBouncer image found in the image
+// catalog referenced by the pooler, or the default one
+func getPgbouncerImage(pooler *apiv1.Pooler) string {
+	if pooler.Status.Image != "" {
+		return pooler.Status.Image
+	}
+
+	return DefaultPgbouncerImage
+}
+
 func computeTemplateHash(pooler *apiv1.Pooler, operatorImageName string) (string, error) {
 	type deploymentHash struct {
 		poolerSpec                      apiv1.PoolerSpec
 		operatorImageName               string
+		pgbouncerImage                  string
 		isPodSpecReconciliationDisabled bool
 	}
 
 	return hash.ComputeHash(deploymentHash{
 		poolerSpec:                      pooler.Spec,
 		operatorImageName:               operatorImageName,
+		pgbouncerImage:                  pooler.Status.Image,
 		isPodSpecReconciliationDisabled: utils.IsPodSpecReconciliationDisabled(&pooler.ObjectMeta),
 	})
 }
@@ -18,6 +18,7 @@ package pgbouncer
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -123,6 +124,25 @@ var _ = Describe("Deployment", func() {
 		Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
 	})
 
+	It("spreads the pods of the pooler", func() {
+		pooler.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			},
+		}
+		deployment, err := Deployment(pooler, cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		constraints := deployment.Spec.Template.Spec.TopologySpreadConstraints
+		Expect(constraints).To(HaveLen(1))
+		Expect(constraints[0].LabelSelector.MatchLabels).To(Equal(map[string]string{
+			utils.PgbouncerNameLabel: pooler.Name,
+		}))
+		Expect(pooler.Spec.Template.Spec.TopologySpreadConstraints[0].LabelSelector).To(BeNil())
+	})
+
 	It("creates correct volume mounts", func() {
 		deployment, err := Deployment(pooler, cluster)
 		Expect(err).ShouldNot(HaveOccurred())
@@ -156,4 +176,17 @@ var _ = Describe("Deployment", func() {
 		Expect(deployment.Spec.Template.Spec.Containers[0].ReadinessProbe.TCPSocket.Port).
 			To(Equal(intstr.FromInt32(pgBouncerConfig.PgBouncerPort)))
 	})
+
+	It("uses the PgBouncer image found in the image catalog", func() {
+		deployment, err := Deployment(pooler, cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(DefaultPgbouncerImage))
+		defaultHash := deployment.Annotations[utils.PoolerSpecHashAnnotationName]
+
+		pooler.Status.Image = "ghcr.io/cloudnative-pg/pgbouncer:1.24.0"
+		deployment, err = Deployment(pooler, cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(pooler.Status.Image))
+		Expect(deployment.Annotations[utils.PoolerSpecHashAnnotationName]).ToNot(Equal(defaultHash))
+	})
 })
@@ -0,0 +1,57 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbouncer
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// PodDisruptionBudget creates the pod disruption budget of the pooler,
+// telling K8s to avoid evicting more than one pooler pod at a time.
+// No budget is needed when the pooler has a single instance, as it would
+// only block the node drains
+func PodDisruptionBudget(pooler *apiv1.Pooler) *policyv1.PodDisruptionBudget {
+	if !pooler.GetEnablePDB() || pooler.Spec.Instances == nil || *pooler.Spec.Instances < 2 {
+		return nil
+	}
+
+	one := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pooler.Name,
+			Namespace: pooler.Namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName:   pooler.Spec.Cluster.Name,
+				utils.PgbouncerNameLabel: pooler.Name,
+				utils.PodRoleLabelName:   string(utils.PodRolePooler),
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					utils.PgbouncerNameLabel: pooler.Name,
+				},
+			},
+			MaxUnavailable: &one,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbouncer
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pooler PodDisruptionBudget", func() {
+	var pooler *apiv1.Pooler
+
+	BeforeEach(func() {
+		pooler = &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pooler",
+				Namespace: "test-namespace",
+			},
+			Spec: apiv1.PoolerSpec{
+				Cluster:   apiv1.LocalObjectReference{Name: "test-cluster"},
+				Instances: ptr.To(int32(3)),
+			},
+		}
+	})
+
+	It("allows the eviction of one pod at a time", func() {
+		pdb := PodDisruptionBudget(pooler)
+		Expect(pdb).ToNot(BeNil())
+		Expect(pdb.Name).To(Equal(pooler.Name))
+		Expect(pdb.Namespace).To(Equal(pooler.Namespace))
+		Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+			utils.PgbouncerNameLabel: pooler.Name,
+		}))
+		Expect(pdb.Spec.MaxUnavailable).To(Equal(ptr.To(intstr.FromInt32(1))))
+		Expect(pdb.Spec.MinAvailable).To(BeNil())
+	})
+
+	It("is not needed for a single instance", func() {
+		pooler.Spec.Instances = ptr.To(int32(1))
+		Expect(PodDisruptionBudget(pooler)).To(BeNil())
+	})
+
+	It("is not created when disabled", func() {
+		pooler.Spec.EnablePDB = ptr.To(false)
+		Expect(PodDisruptionBudget(pooler)).To(BeNil())
+	})
+})
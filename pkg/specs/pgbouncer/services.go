@@ -42,12 +42,7 @@ func Service(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*corev1.Service, err
 		WithLabel(utils.PodRoleLabelName, string(utils.PodRolePooler)).
 		WithAnnotation(utils.PoolerSpecHashAnnotationName, poolerHash).
 		WithServiceType(corev1.ServiceTypeClusterIP, false).
-		WithServicePortNoOverwrite(&corev1.ServicePort{
-			Name:       pgBouncerConfig.PgBouncerPortName,
-			Port:       pgBouncerConfig.PgBouncerPort,
-			TargetPort: intstr.FromString(pgBouncerConfig.PgBouncerPortName),
-			Protocol:   corev1.ProtocolTCP,
-		}).
+		WithServicePortNoOverwrite(servicePort()).
 		SetPGBouncerSelector(pooler.Name).
 		Build()
 
@@ -61,3 +56,57 @@ func Service(pooler *apiv1.Pooler, cluster *apiv1.Cluster) (*corev1.Service, err
 		Spec: serviceTemplate.Spec,
 	}, nil
 }
+
+// AdditionalServices creates the specification for the additional
+// services of pgbouncer
+func AdditionalServices(pooler *apiv1.Pooler, cluster *apiv1.Cluster) ([]corev1.Service, error) {
+	services := make([]corev1.Service, 0, len(pooler.Spec.AdditionalServices))
+	for idx := range pooler.Spec.AdditionalServices {
+		additionalService := &pooler.Spec.AdditionalServices[idx]
+		serviceHash, err := hash.ComputeHash(additionalService)
+		if err != nil {
+			return nil, err
+		}
+
+		updateStrategy := additionalService.UpdateStrategy
+		if updateStrategy == "" {
+			updateStrategy = apiv1.ServiceUpdateStrategyPatch
+		}
+
+		builder := servicespec.NewFrom(additionalService.ServiceTemplate.DeepCopy()).
+			WithLabel(utils.PgbouncerNameLabel, pooler.Name).
+			WithLabel(utils.ClusterLabelName, cluster.Name).
+			WithLabel(utils.PodRoleLabelName, string(utils.PodRolePooler)).
+			WithAnnotation(utils.PoolerSpecHashAnnotationName, serviceHash).
+			WithAnnotation(utils.UpdateStrategyAnnotation, string(updateStrategy)).
+			WithServiceType(corev1.ServiceTypeClusterIP, false).
+			WithServicePortNoOverwrite(servicePort()).
+			SetPGBouncerSelector(pooler.Name)
+		if additionalService.DNSHostname != "" {
+			builder = builder.WithAnnotation(utils.ExternalDNSHostnameAnnotationName, additionalService.DNSHostname)
+		}
+
+		serviceTemplate := builder.Build()
+		services = append(services, corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        serviceTemplate.ObjectMeta.Name,
+				Namespace:   pooler.Namespace,
+				Labels:      serviceTemplate.ObjectMeta.Labels,
+				Annotations: serviceTemplate.ObjectMeta.Annotations,
+			},
+			Spec: serviceTemplate.Spec,
+		})
+	}
+
+	return services, nil
+}
+
+// servicePort is the port exposing pgbouncer in its services
+func servicePort() *corev1.ServicePort {
+	return &corev1.ServicePort{
+		Name:       pgBouncerConfig.PgBouncerPortName,
+		Port:       pgBouncerConfig.PgBouncerPort,
+		TargetPort: intstr.FromString(pgBouncerConfig.PgBouncerPortName),
+		Protocol:   corev1.ProtocolTCP,
+	}
+}
@@ -88,4 +88,77 @@ var _ = Describe("Pooler Service", func() {
 			}))
 		})
 	})
+
+	Context("when creating the additional services", func() {
+		BeforeEach(func() {
+			loadBalancerClass := "service.k8s.aws/nlb"
+			pooler.Spec.AdditionalServices = []apiv1.PoolerAdditionalService{
+				{
+					ServiceTemplate: apiv1.ServiceTemplateSpec{
+						ObjectMeta: apiv1.Metadata{Name: "test-pooler-internal"},
+					},
+				},
+				{
+					UpdateStrategy: apiv1.ServiceUpdateStrategyReplace,
+					DNSHostname:    "pooler.example.com",
+					ServiceTemplate: apiv1.ServiceTemplateSpec{
+						ObjectMeta: apiv1.Metadata{
+							Name:        "test-pooler-external",
+							Annotations: map[string]string{"custom": "annotation"},
+						},
+						Spec: corev1.ServiceSpec{
+							Type:              corev1.ServiceTypeLoadBalancer,
+							LoadBalancerClass: &loadBalancerClass,
+						},
+					},
+				},
+			}
+		})
+
+		It("returns no service when none is requested", func() {
+			pooler.Spec.AdditionalServices = nil
+			services, err := AdditionalServices(pooler, cluster)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(services).To(BeEmpty())
+		})
+
+		It("returns the managed services selecting the pooler pods", func() {
+			services, err := AdditionalServices(pooler, cluster)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(services).To(HaveLen(2))
+
+			for _, service := range services {
+				Expect(service.Namespace).To(Equal(pooler.Namespace))
+				Expect(service.Labels).To(HaveKeyWithValue(utils.PgbouncerNameLabel, pooler.Name))
+				Expect(service.Annotations).To(HaveKey(utils.PoolerSpecHashAnnotationName))
+				Expect(service.Spec.Selector).To(Equal(map[string]string{
+					utils.PgbouncerNameLabel: pooler.Name,
+				}))
+				Expect(service.Spec.Ports).To(HaveLen(1))
+				Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(pgBouncerConfig.PgBouncerPort))
+			}
+
+			internal := services[0]
+			Expect(internal.Name).To(Equal("test-pooler-internal"))
+			Expect(internal.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			Expect(internal.Annotations).To(HaveKeyWithValue(utils.UpdateStrategyAnnotation, "patch"))
+			Expect(internal.Annotations).ToNot(HaveKey(utils.ExternalDNSHostnameAnnotationName))
+
+			external := services[1]
+			Expect(external.Name).To(Equal("test-pooler-external"))
+			Expect(external.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(external.Spec.LoadBalancerClass).To(HaveValue(Equal("service.k8s.aws/nlb")))
+			Expect(external.Annotations).To(HaveKeyWithValue(utils.UpdateStrategyAnnotation, "replace"))
+			Expect(external.Annotations).To(HaveKeyWithValue(utils.ExternalDNSHostnameAnnotationName,
+				"pooler.example.com"))
+			Expect(external.Annotations).To(HaveKeyWithValue("custom", "annotation"))
+		})
+
+		It("doesn't change the pooler specification", func() {
+			_, err := AdditionalServices(pooler, cluster)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pooler.Spec.AdditionalServices[1].ServiceTemplate.ObjectMeta.Annotations).To(Equal(
+				map[string]string{"custom": "annotation"}))
+		})
+	})
 })
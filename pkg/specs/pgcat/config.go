@@ -0,0 +1,271 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// ConfigFileName is the name of the PgCat configuration file
+	ConfigFileName = "pgcat.toml"
+
+	// AdminPasswordKey is the key of the configuration secret containing
+	// the password of the PgCat admin user
+	AdminPasswordKey = "adminPassword"
+
+	// AdminUser is the name of the PgCat admin user
+	AdminUser = "pgcat"
+
+	// Port is the port where PgCat will be listening
+	Port = 5432
+
+	// MetricsPort is the port where PgCat exposes its Prometheus metrics
+	MetricsPort = 9930
+
+	// ConfigDir is the directory where the configuration secret is mounted
+	ConfigDir = "/etc/pgcat"
+
+	// TLSDir is the directory where the server TLS secret is mounted
+	TLSDir = "/etc/pgcat/tls"
+
+	configTemplateString = `[general]
+host = "0.0.0.0"
+port = {{ .Port }}
+enable_prometheus_exporter = true
+prometheus_exporter_port = {{ .MetricsPort }}
+admin_username = {{ toml .AdminUser }}
+admin_password = {{ toml .AdminPassword }}
+tls_certificate = {{ toml .TLSCertificate }}
+tls_private_key = {{ toml .TLSPrivateKey }}
+server_tls = true
+verify_server_certificate = false
+{{ range $param := .Parameters }}{{ $param }}
+{{ end }}
+{{- range $database := .Databases }}
+[pools.{{ toml $database }}]
+pool_mode = {{ toml $.PoolMode }}
+default_role = {{ toml $.DefaultRole }}
+query_parser_enabled = {{ $.ReadWriteSplitting }}
+query_parser_read_write_splitting = {{ $.ReadWriteSplitting }}
+primary_reads_enabled = {{ $.PrimaryReadsEnabled }}
+auth_query = {{ toml $.AuthQuery }}
+auth_query_user = {{ toml $.AuthQueryUser }}
+auth_query_password = {{ toml $.AuthQueryPassword }}
+
+[pools.{{ toml $database }}.shards.0]
+database = {{ toml $database }}
+servers = [{{ range $idx, $server := $.Servers }}{{ if $idx }}, {{ end }}[{{ toml $server.Host }}, {{ $server.Port }}, {{ toml $server.Role }}]{{ end }}]
+{{ range $idx, $user := $.Users }}
+[pools.{{ toml $database }}.users.{{ $idx }}]
+username = {{ toml $user }}
+pool_size = {{ $.PoolSize }}
+{{ end }}
+{{- end }}`
+)
+
+var (
+	configTemplate = template.Must(
+		template.New(ConfigFileName).Funcs(template.FuncMap{"toml": tomlString}).Parse(configTemplateString))
+
+	// ErrInvalidAuthQuerySecret is raised when the auth query secret
+	// doesn't contain the credentials of the auth query user
+	ErrInvalidAuthQuerySecret = errors.New("the auth query secret must contain a username and a password")
+)
+
+// server is a PostgreSQL server PgCat connects to
+type server struct {
+	Host string
+	Port int
+	Role string
+}
+
+type configurationData struct {
+	Port                int
+	MetricsPort         int
+	AdminUser           string
+	AdminPassword       string
+	TLSCertificate      string
+	TLSPrivateKey       string
+	Parameters          []string
+	Databases           []string
+	Users               []string
+	PoolMode            string
+	PoolSize            int32
+	DefaultRole         string
+	ReadWriteSplitting  bool
+	PrimaryReadsEnabled bool
+	AuthQuery           string
+	AuthQueryUser       string
+	AuthQueryPassword   string
+	Servers             []server
+}
+
+// GetConfigSecretName returns the name of the secret containing the
+// PgCat configuration of a Pooler
+func GetConfigSecretName(pooler *apiv1.Pooler) string {
+	return pooler.Name + "-pgcat"
+}
+
+// ConfigSecret creates the secret containing the PgCat configuration
+// of a Pooler. The admin password is preserved across reconciliations
+// by the caller, which reads it from the current secret
+func ConfigSecret(
+	pooler *apiv1.Pooler,
+	cluster *apiv1.Cluster,
+	authQuerySecret *corev1.Secret,
+	adminPassword string,
+) (*corev1.Secret, error) {
+	configuration, err := BuildConfiguration(pooler, cluster, authQuerySecret, adminPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetConfigSecretName(pooler),
+			Namespace: pooler.Namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName:   cluster.Name,
+				utils.PgbouncerNameLabel: pooler.Name,
+				utils.PodRoleLabelName:   string(utils.PodRolePooler),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			ConfigFileName:   configuration,
+			AdminPasswordKey: []byte(adminPassword),
+		},
+	}, nil
+}
+
+// BuildConfiguration renders the PgCat configuration file for a Pooler
+func BuildConfiguration(
+	pooler *apiv1.Pooler,
+	cluster *apiv1.Cluster,
+	authQuerySecret *corev1.Secret,
+	adminPassword string,
+) ([]byte, error) {
+	spec := pooler.Spec.PgCat
+	if spec == nil {
+		return nil, fmt.Errorf("missing pgcat configuration in pooler %s", pooler.Name)
+	}
+
+	authQueryUser := string(authQuerySecret.Data[corev1.BasicAuthUsernameKey])
+	authQueryPassword := string(authQuerySecret.Data[corev1.BasicAuthPasswordKey])
+	if authQueryUser == "" || authQueryPassword == "" {
+		return nil, ErrInvalidAuthQuerySecret
+	}
+
+	data := configurationData{
+		Port:              Port,
+		MetricsPort:       MetricsPort,
+		AdminUser:         AdminUser,
+		AdminPassword:     adminPassword,
+		TLSCertificate:    TLSDir + "/" + corev1.TLSCertKey,
+		TLSPrivateKey:     TLSDir + "/" + corev1.TLSPrivateKeyKey,
+		Parameters:        buildParameters(spec.Parameters),
+		Databases:         spec.Databases,
+		Users:             spec.Users,
+		PoolMode:          string(apiv1.PgBouncerPoolModeTransaction),
+		PoolSize:          20,
+		DefaultRole:       string(apiv1.PgCatDefaultRoleAny),
+		AuthQuery:         spec.GetAuthQuery(),
+		AuthQueryUser:     authQueryUser,
+		AuthQueryPassword: authQueryPassword,
+		Servers:           buildServers(pooler, cluster),
+	}
+
+	if spec.PoolMode != "" {
+		data.PoolMode = string(spec.PoolMode)
+	}
+	if spec.PoolSize > 0 {
+		data.PoolSize = spec.PoolSize
+	}
+	if routing := spec.QueryRouting; routing != nil {
+		data.ReadWriteSplitting = routing.ReadWriteSplitting
+		data.PrimaryReadsEnabled = routing.PrimaryReadsEnabled
+		if routing.DefaultRole != "" {
+			data.DefaultRole = string(routing.DefaultRole)
+		}
+	}
+	if pooler.Spec.Type == apiv1.PoolerTypeRO && !data.ReadWriteSplitting {
+		// A read-only pooler without query routing only reaches the replicas
+		data.DefaultRole = string(apiv1.PgCatDefaultRoleReplica)
+	}
+
+	var buffer bytes.Buffer
+	if err := configTemplate.Execute(&buffer, data); err != nil {
+		return nil, fmt.Errorf("while rendering the pgcat configuration: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// buildServers returns the servers PgCat connects to. The primary is
+// reached via the `-rw` service and the replicas via the `-ro` one.
+func buildServers(pooler *apiv1.Pooler, cluster *apiv1.Cluster) []server {
+	primary := server{
+		Host: cluster.GetServiceReadWriteName(),
+		Port: postgres.ServerPort,
+		Role: string(apiv1.PgCatDefaultRolePrimary),
+	}
+	replicas := server{
+		Host: cluster.GetServiceReadOnlyName(),
+		Port: postgres.ServerPort,
+		Role: string(apiv1.PgCatDefaultRoleReplica),
+	}
+
+	routing := pooler.Spec.PgCat.QueryRouting
+	switch {
+	case routing != nil && routing.ReadWriteSplitting:
+		return []server{primary, replicas}
+	case pooler.Spec.Type == apiv1.PoolerTypeRO:
+		return []server{replicas}
+	default:
+		return []server{primary}
+	}
+}
+
+// buildParameters returns the user-specified parameters of the general
+// section, sorted to keep the configuration stable
+func buildParameters(parameters map[string]string) []string {
+	result := make([]string, 0, len(parameters))
+	for key, value := range parameters {
+		result = append(result, fmt.Sprintf("%s = %s", key, value))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// tomlString quotes a value as a TOML basic string
+func tomlString(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,107 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcat
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PgCat configuration", func() {
+	var (
+		pooler     *apiv1.Pooler
+		cluster    *apiv1.Cluster
+		authSecret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		pooler = &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler", Namespace: "default"},
+			Spec: apiv1.PoolerSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster"},
+				Type:    apiv1.PoolerTypeRW,
+				Backend: apiv1.PoolerBackendPgCat,
+				PgCat: &apiv1.PgCatSpec{
+					AuthQuerySecret: apiv1.LocalObjectReference{Name: "auth"},
+					Databases:       []string{"app", "reports"},
+					Users:           []string{"app"},
+					Parameters:      map[string]string{"idle_timeout": "30000", "ban_time": "60"},
+				},
+			},
+		}
+		cluster = &apiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+		authSecret = &corev1.Secret{
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("auth_user"),
+				corev1.BasicAuthPasswordKey: []byte(`pass"word`),
+			},
+		}
+	})
+
+	It("creates a pool for each database", func() {
+		configuration, err := BuildConfiguration(pooler, cluster, authSecret, "admin")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(configuration)).To(SatisfyAll(
+			ContainSubstring(`[pools."app"]`),
+			ContainSubstring(`[pools."reports"]`),
+			ContainSubstring(`[pools."reports".users.0]`),
+			ContainSubstring(`pool_mode = "transaction"`),
+			ContainSubstring(`auth_query_password = "pass\"word"`),
+			ContainSubstring(`servers = [["cluster-rw", 5432, "primary"]]`),
+			ContainSubstring("ban_time = 60\nidle_timeout = 30000\n"),
+		))
+	})
+
+	It("routes the read queries to the replicas when requested", func() {
+		pooler.Spec.PgCat.QueryRouting = &apiv1.PgCatQueryRoutingSpec{ReadWriteSplitting: true}
+		configuration, err := BuildConfiguration(pooler, cluster, authSecret, "admin")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(configuration)).To(SatisfyAll(
+			ContainSubstring("query_parser_read_write_splitting = true"),
+			ContainSubstring(`servers = [["cluster-rw", 5432, "primary"], ["cluster-ro", 5432, "replica"]]`),
+		))
+	})
+
+	It("only reaches the replicas with a read-only pooler", func() {
+		pooler.Spec.Type = apiv1.PoolerTypeRO
+		configuration, err := BuildConfiguration(pooler, cluster, authSecret, "admin")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(configuration)).To(SatisfyAll(
+			ContainSubstring(`default_role = "replica"`),
+			ContainSubstring(`servers = [["cluster-ro", 5432, "replica"]]`),
+		))
+	})
+
+	It("requires the credentials of the auth query user", func() {
+		authSecret.Data = nil
+		_, err := BuildConfiguration(pooler, cluster, authSecret, "admin")
+		Expect(err).To(MatchError(ErrInvalidAuthQuerySecret))
+	})
+
+	It("stores the configuration and the admin password in a secret", func() {
+		secret, err := ConfigSecret(pooler, cluster, authSecret, "admin")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Name).To(Equal("pooler-pgcat"))
+		Expect(secret.Data).To(HaveKeyWithValue(AdminPasswordKey, []byte("admin")))
+		Expect(secret.Data).To(HaveKey(ConfigFileName))
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pgcat contains the specification of the K8s resources
+// generated by the CloudNativePG operator related to PgCat poolers
+package pgcat
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pgBouncerConfig "github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbouncer/config"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/podspec"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
+)
+
+const (
+	// DefaultPgCatImage is the name of the PgCat image used by default
+	DefaultPgCatImage = "ghcr.io/postgresml/pgcat:v1.2.0"
+
+	// ContainerName is the name of the PgCat container
+	ContainerName = "pgcat"
+
+	// ConfigHashAnnotationName is the annotation containing the hash of
+	// the PgCat configuration, used to roll out the pods when it changes
+	ConfigHashAnnotationName = utils.MetadataNamespace + "/pgcatConfigHash"
+)
+
+// Deployment creates the deployment of PgCat, given the configuration
+// we have in the pooler specification and the hash of the generated
+// configuration file
+func Deployment(pooler *apiv1.Pooler, cluster *apiv1.Cluster, configHash string) (*appsv1.Deployment, error) {
+	poolerHash, err := hash.ComputeHash(struct {
+		poolerSpec                      apiv1.PoolerSpec
+		configHash                      string
+		isPodSpecReconciliationDisabled bool
+	}{
+		poolerSpec:                      pooler.Spec,
+		configHash:                      configHash,
+		isPodSpecReconciliationDisabled: utils.IsPodSpecReconciliationDisabled(&pooler.ObjectMeta),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	podTemplate := podspec.NewFrom(pooler.Spec.Template).
+		WithLabel(utils.PgbouncerNameLabel, pooler.Name).
+		WithLabel(utils.ClusterLabelName, cluster.Name).
+		WithLabel(utils.PodRoleLabelName, string(utils.PodRolePooler)).
+		WithAnnotation(ConfigHashAnnotationName, configHash).
+		WithVolume(&corev1.Volume{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: GetConfigSecretName(pooler),
+					Items: []corev1.KeyToPath{
+						{Key: ConfigFileName, Path: ConfigFileName},
+					},
+				},
+			},
+		}).
+		WithVolume(&corev1.Volume{
+			Name: "server-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cluster.GetServerTLSSecretName(),
+				},
+			},
+		}).
+		WithSecurityContext(specs.CreatePodSecurityContext(cluster.GetSeccompProfile(), 1000, 1000), true).
+		WithContainerImage(ContainerName, DefaultPgCatImage, false).
+		WithContainerCommand(ContainerName, []string{
+			"pgcat",
+			ConfigDir + "/" + ConfigFileName,
+		}, false).
+		WithContainerPort(ContainerName, &corev1.ContainerPort{
+			// We keep the port name used by PgBouncer, so that the
+			// Pooler service works with any backend
+			Name:          pgBouncerConfig.PgBouncerPortName,
+			ContainerPort: Port,
+		}).
+		WithContainerPort(ContainerName, &corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: MetricsPort,
+		}).
+		WithContainerVolumeMount(ContainerName, &corev1.VolumeMount{
+			Name:      "config",
+			MountPath: ConfigDir,
+			ReadOnly:  true,
+		}, true).
+		WithContainerVolumeMount(ContainerName, &corev1.VolumeMount{
+			Name:      "server-tls",
+			MountPath: TLSDir,
+			ReadOnly:  true,
+		}, true).
+		WithContainerSecurityContext(ContainerName,
+			specs.CreateContainerSecurityContext(cluster.GetSeccompProfile()), true).
+		WithServiceAccountName(pooler.Name, true).
+		WithTopologySpreadConstraintsSelector(&metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				utils.PgbouncerNameLabel: pooler.Name,
+			},
+		}).
+		WithReadinessProbe(ContainerName, &corev1.Probe{
+			TimeoutSeconds: 5,
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt32(Port),
+				},
+			},
+		}, false).
+		Build()
+
+	var strategy appsv1.DeploymentStrategy
+	if pooler.Spec.DeploymentStrategy != nil {
+		strategy = *pooler.Spec.DeploymentStrategy.DeepCopy()
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pooler.Name,
+			Namespace: pooler.Namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName:   cluster.Name,
+				utils.PgbouncerNameLabel: pooler.Name,
+				utils.PodRoleLabelName:   string(utils.PodRolePooler),
+			},
+			Annotations: map[string]string{
+				utils.PoolerSpecHashAnnotationName: poolerHash,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pooler.Spec.Instances,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					utils.PgbouncerNameLabel: pooler.Name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: podTemplate.ObjectMeta.Annotations,
+					Labels:      podTemplate.ObjectMeta.Labels,
+				},
+				Spec: podTemplate.Spec,
+			},
+			Strategy: strategy,
+		},
+	}, nil
+}
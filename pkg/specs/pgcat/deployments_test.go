@@ -0,0 +1,65 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcat
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PgCat deployment", func() {
+	pooler := &apiv1.Pooler{
+		ObjectMeta: metav1.ObjectMeta{Name: "pooler", Namespace: "default"},
+		Spec: apiv1.PoolerSpec{
+			Cluster:   apiv1.LocalObjectReference{Name: "cluster"},
+			Instances: ptr.To(int32(2)),
+			Backend:   apiv1.PoolerBackendPgCat,
+			PgCat:     &apiv1.PgCatSpec{},
+		},
+	}
+	cluster := &apiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+
+	It("runs PgCat with the generated configuration", func() {
+		deployment, err := Deployment(pooler, cluster, "abc")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deployment.Spec.Replicas).To(Equal(ptr.To(int32(2))))
+		Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(ConfigHashAnnotationName, "abc"))
+		Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(utils.PgbouncerNameLabel, "pooler"))
+
+		Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(1))
+		container := deployment.Spec.Template.Spec.Containers[0]
+		Expect(container.Name).To(Equal(ContainerName))
+		Expect(container.Image).To(Equal(DefaultPgCatImage))
+		Expect(container.Command).To(Equal([]string{"pgcat", "/etc/pgcat/pgcat.toml"}))
+		Expect(deployment.Spec.Template.Spec.Volumes).To(HaveLen(2))
+	})
+
+	It("rolls out the pods when the configuration changes", func() {
+		first, err := Deployment(pooler, cluster, "abc")
+		Expect(err).ToNot(HaveOccurred())
+		second, err := Deployment(pooler, cluster, "def")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first.Annotations[utils.PoolerSpecHashAnnotationName]).
+			ToNot(Equal(second.Annotations[utils.PoolerSpecHashAnnotationName]))
+	})
+})
@@ -0,0 +1,74 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// PodSpecPreviewKey is the key of the preview ConfigMap containing
+	// the instance pod, as it would be created by the operator
+	PodSpecPreviewKey = "pod.yaml"
+
+	// PodSpecPreviewErrorKey is the key of the preview ConfigMap containing
+	// the error raised while rendering the instance pod
+	PodSpecPreviewErrorKey = "error"
+)
+
+// CreatePodSpecPreview creates the ConfigMap containing the preview of the
+// instance pod requested at the passed time, or the error raised while
+// rendering it
+func CreatePodSpecPreview(
+	cluster *apiv1.Cluster,
+	requestedAt string,
+	pod *corev1.Pod,
+	renderErr error,
+) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.GetPodSpecPreviewName(),
+			Namespace: cluster.Namespace,
+		},
+		Data: make(map[string]string, 1),
+	}
+	cluster.SetInheritedDataAndOwnership(&configMap.ObjectMeta)
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string, 1)
+	}
+	configMap.Annotations[utils.PodSpecPreviewAnnotationName] = requestedAt
+
+	if renderErr != nil {
+		configMap.Data[PodSpecPreviewErrorKey] = renderErr.Error()
+		return configMap, nil
+	}
+
+	content, err := yaml.Marshal(pod)
+	if err != nil {
+		return nil, fmt.Errorf("while serializing the instance pod: %w", err)
+	}
+	configMap.Data[PodSpecPreviewKey] = string(content)
+
+	return configMap, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pod spec preview", func() {
+	const requestedAt = "2024-03-01 10:00:00.000000+00"
+
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example",
+			Namespace: "default",
+		},
+	}
+
+	It("contains the rendered pod", func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "postgres"}, {Name: "log-shipper"}},
+			},
+		}
+
+		configMap, err := CreatePodSpecPreview(cluster, requestedAt, pod, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Name).To(Equal("cluster-example-pod-spec-preview"))
+		Expect(configMap.Namespace).To(Equal("default"))
+		Expect(configMap.Annotations).To(HaveKeyWithValue(utils.PodSpecPreviewAnnotationName, requestedAt))
+		Expect(configMap.Data).To(HaveLen(1))
+		Expect(configMap.Data[PodSpecPreviewKey]).To(ContainSubstring("name: log-shipper"))
+	})
+
+	It("contains the error raised while rendering the pod", func() {
+		configMap, err := CreatePodSpecPreview(cluster, requestedAt, nil, errors.New("conflicting plugin mutations"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Data).To(HaveLen(1))
+		Expect(configMap.Data).To(HaveKeyWithValue(PodSpecPreviewErrorKey, "conflicting plugin mutations"))
+	})
+})
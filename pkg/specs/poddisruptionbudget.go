@@ -26,15 +26,20 @@ import (
 )
 
 // BuildReplicasPodDisruptionBudget creates a pod disruption budget telling
-// K8s to avoid removing more than one replica at a time
+// K8s to avoid removing more than the allowed number of replicas at a time
+// (one by default)
 func BuildReplicasPodDisruptionBudget(cluster *apiv1.Cluster) *policyv1.PodDisruptionBudget {
-	// We should ensure that in a cluster of n instances,
-	// with n-1 replicas, at least n-2 are always available
-	if cluster == nil || cluster.Spec.Instances < 3 {
+	if cluster == nil {
 		return nil
 	}
-	minAvailableReplicas := int32(cluster.Spec.Instances - 2) //nolint:gosec
-	allReplicasButOne := intstr.FromInt32(minAvailableReplicas)
+
+	// We should ensure that in a cluster of n instances, with n-1
+	// replicas, at least n-1-maxUnavailable are always available
+	minAvailableReplicas := int32(cluster.Spec.Instances-1) - cluster.GetReplicasMaxUnavailable() //nolint:gosec
+	if minAvailableReplicas < 1 {
+		return nil
+	}
+	allowedReplicas := intstr.FromInt32(minAvailableReplicas)
 
 	pdb := &policyv1.PodDisruptionBudget{
 		ObjectMeta: metav1.ObjectMeta{
@@ -48,7 +53,7 @@ func BuildReplicasPodDisruptionBudget(cluster *apiv1.Cluster) *policyv1.PodDisru
 					utils.ClusterInstanceRoleLabelName: ClusterRoleLabelReplica,
 				},
 			},
-			MinAvailable: &allReplicasButOne,
+			MinAvailable: &allowedReplicas,
 		},
 	}
 
@@ -58,9 +63,10 @@ func BuildReplicasPodDisruptionBudget(cluster *apiv1.Cluster) *policyv1.PodDisru
 }
 
 // BuildPrimaryPodDisruptionBudget creates a pod disruption budget, telling
-// K8s to avoid removing more than one primary instance at a time
+// K8s to avoid removing more than one primary instance at a time, unless
+// the user disabled it
 func BuildPrimaryPodDisruptionBudget(cluster *apiv1.Cluster) *policyv1.PodDisruptionBudget {
-	if cluster == nil {
+	if cluster == nil || !cluster.IsPrimaryPDBEnabled() {
 		return nil
 	}
 	one := intstr.FromInt32(1)
@@ -18,6 +18,7 @@ package specs
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 
@@ -53,4 +54,26 @@ var _ = Describe("POD Disruption Budget specifications", func() {
 		result := BuildPrimaryPodDisruptionBudget(cluster)
 		Expect(result.Spec.MinAvailable.IntVal).To(Equal(int32(minAvailablePrimary)))
 	})
+
+	It("allows more replicas to be unavailable when requested", func() {
+		maxUnavailable := int32(2)
+		customCluster := cluster.DeepCopy()
+		customCluster.Spec.Instances = 5
+		customCluster.Spec.PodDisruptionBudget = &apiv1.PodDisruptionBudgetConfiguration{
+			MaxUnavailableReplicas: &maxUnavailable,
+		}
+		result := BuildReplicasPodDisruptionBudget(customCluster)
+		Expect(result.Spec.MinAvailable.IntVal).To(Equal(int32(2)))
+
+		customCluster.Spec.Instances = 3
+		Expect(BuildReplicasPodDisruptionBudget(customCluster)).To(BeNil())
+	})
+
+	It("doesn't protect the primary instance when its budget is disabled", func() {
+		customCluster := cluster.DeepCopy()
+		customCluster.Spec.PodDisruptionBudget = &apiv1.PodDisruptionBudgetConfiguration{
+			EnablePrimaryPDB: ptr.To(false),
+		}
+		Expect(BuildPrimaryPodDisruptionBudget(customCluster)).To(BeNil())
+	})
 })
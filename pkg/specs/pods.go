@@ -21,11 +21,14 @@ package specs
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
+	neturl "net/url"
 	"path"
 	"reflect"
 	"slices"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +38,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/telemetry"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
 )
@@ -69,6 +73,10 @@ const (
 	// controller inside the Pod file system
 	BootstrapControllerContainerName = "bootstrap-controller"
 
+	// MajorUpgradeOldBinariesContainerName is the name of the container copying
+	// the binaries of the previous PostgreSQL major version during an upgrade
+	MajorUpgradeOldBinariesContainerName = "copy-old-binaries"
+
 	// PgDataPath is the path to PGDATA variable
 	PgDataPath = "/var/lib/postgresql/data/pgdata"
 
@@ -151,6 +159,7 @@ func CreatePodEnvConfig(cluster apiv1.Cluster, podName string) EnvConfig {
 		},
 		EnvFrom: cluster.Spec.EnvFrom,
 	}
+	config.EnvVars = append(config.EnvVars, createOpenTelemetryEnvVars(cluster, podName)...)
 	config.EnvVars = append(config.EnvVars, cluster.Spec.Env...)
 
 	hashValue, _ := hash.ComputeHash(config)
@@ -158,6 +167,68 @@ func CreatePodEnvConfig(cluster apiv1.Cluster, podName string) EnvConfig {
 	return config
 }
 
+// createOpenTelemetryEnvVars creates the standard OpenTelemetry
+// environment variables configuring the export of the instance
+// manager telemetry. The variables set by the user in the cluster
+// spec come later, and can be used to tune the exporter further
+func createOpenTelemetryEnvVars(cluster apiv1.Cluster, podName string) []corev1.EnvVar {
+	openTelemetry := cluster.GetOpenTelemetry()
+	if openTelemetry == nil {
+		return nil
+	}
+
+	resourceAttributes := map[string]string{
+		"k8s.namespace.name": cluster.Namespace,
+		"k8s.pod.name":       podName,
+		"cnpg.cluster.name":  cluster.Name,
+	}
+	for key, value := range openTelemetry.ResourceAttributes {
+		resourceAttributes[key] = value
+	}
+	renderedAttributes := make([]string, 0, len(resourceAttributes))
+	for _, key := range slices.Sorted(maps.Keys(resourceAttributes)) {
+		renderedAttributes = append(renderedAttributes, key+"="+neturl.PathEscape(resourceAttributes[key]))
+	}
+
+	result := []corev1.EnvVar{
+		{
+			Name:  "OTEL_EXPORTER_OTLP_ENDPOINT",
+			Value: openTelemetry.Endpoint,
+		},
+		{
+			Name:  "OTEL_SERVICE_NAME",
+			Value: telemetry.InstanceManagerServiceName,
+		},
+		{
+			Name:  "OTEL_RESOURCE_ATTRIBUTES",
+			Value: strings.Join(renderedAttributes, ","),
+		},
+		{
+			Name:  "OTEL_TRACES_SAMPLER",
+			Value: "parentbased_traceidratio",
+		},
+		{
+			Name:  "OTEL_TRACES_SAMPLER_ARG",
+			Value: strconv.FormatFloat(openTelemetry.GetSampleRatio(), 'f', -1, 64),
+		},
+	}
+	if openTelemetry.HeadersSecret != nil {
+		result = append(result, corev1.EnvVar{
+			Name: "OTEL_EXPORTER_OTLP_HEADERS",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: openTelemetry.HeadersSecret.Name,
+					},
+					Key: openTelemetry.HeadersSecret.Key,
+				},
+			},
+		})
+	}
+
+	return result
+}
+
 // CreateClusterPodSpec computes the PodSpec corresponding to a cluster
 func CreateClusterPodSpec(
 	podName string,
@@ -178,7 +249,7 @@ func CreateClusterPodSpec(
 			cluster.GetSeccompProfile(),
 			cluster.GetPostgresUID(),
 			cluster.GetPostgresGID()),
-		Affinity:                      CreateAffinitySection(cluster.Name, cluster.Spec.Affinity),
+		Affinity:                      createInstanceAffinity(cluster),
 		Tolerations:                   cluster.Spec.Affinity.Tolerations,
 		ServiceAccountName:            cluster.Name,
 		NodeSelector:                  cluster.Spec.Affinity.NodeSelector,
@@ -240,7 +311,7 @@ func createPostgresContainers(cluster apiv1.Cluster, envConfig EnvConfig, enable
 				"instance",
 				"run",
 			},
-			Resources: cluster.Spec.Resources,
+			Resources: cluster.GetResources(),
 			Ports: []corev1.ContainerPort{
 				{
 					Name:          "postgresql",
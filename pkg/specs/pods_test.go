@@ -934,3 +934,67 @@ var _ = Describe("Compute liveness probe failure threshold", func() {
 		Expect(getLivenessProbeFailureThreshold(31)).To(BeNumerically("==", 4))
 	})
 })
+
+var _ = Describe("OpenTelemetry environment variables", func() {
+	It("doesn't add any variable when the export is not configured", func() {
+		cluster := v1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"}}
+		Expect(createOpenTelemetryEnvVars(cluster, "test-1")).To(BeEmpty())
+	})
+
+	It("configures the OTLP exporter of the instance manager", func() {
+		samplingPercentage := int32(10)
+		cluster := v1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+			Spec: v1.ClusterSpec{
+				Monitoring: &v1.MonitoringConfiguration{
+					OpenTelemetry: &v1.OpenTelemetryConfiguration{
+						Endpoint: "http://otel-collector.monitoring:4318",
+						HeadersSecret: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "otel"},
+							Key:                  "headers",
+						},
+						SamplingPercentage: &samplingPercentage,
+						ResourceAttributes: map[string]string{"deployment.environment": "production eu"},
+					},
+				},
+			},
+		}
+
+		Expect(createOpenTelemetryEnvVars(cluster, "test-1")).To(Equal([]corev1.EnvVar{
+			{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://otel-collector.monitoring:4318"},
+			{Name: "OTEL_SERVICE_NAME", Value: "cnpg-instance-manager"},
+			{
+				Name: "OTEL_RESOURCE_ATTRIBUTES",
+				Value: "cnpg.cluster.name=test,deployment.environment=production%20eu," +
+					"k8s.namespace.name=test-ns,k8s.pod.name=test-1",
+			},
+			{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+			{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.1"},
+			{
+				Name: "OTEL_EXPORTER_OTLP_HEADERS",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "otel"},
+						Key:                  "headers",
+					},
+				},
+			},
+		}))
+	})
+
+	It("adds the variables before the ones of the user", func() {
+		cluster := v1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+			Spec: v1.ClusterSpec{
+				Monitoring: &v1.MonitoringConfiguration{
+					OpenTelemetry: &v1.OpenTelemetryConfiguration{Endpoint: "http://otel-collector:4318"},
+				},
+				Env: []corev1.EnvVar{{Name: "OTEL_BSP_SCHEDULE_DELAY", Value: "1000"}},
+			},
+		}
+
+		envVars := CreatePodEnvConfig(cluster, "test-1").EnvVars
+		Expect(envVars[len(envVars)-1].Name).To(Equal("OTEL_BSP_SCHEDULE_DELAY"))
+		Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "1"}))
+	})
+})
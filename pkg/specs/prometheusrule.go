@@ -0,0 +1,157 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// ClusterPrometheusRuleManager builds the PrometheusRule containing
+// the alerts of the cluster resource
+type ClusterPrometheusRuleManager struct {
+	cluster *apiv1.Cluster
+}
+
+// IsPrometheusRuleEnabled returns a boolean indicating if the PrometheusRule should exists or not
+func (c ClusterPrometheusRuleManager) IsPrometheusRuleEnabled() bool {
+	return c.cluster.IsPrometheusRuleEnabled()
+}
+
+// BuildPrometheusRule builds a new PrometheusRule object
+func (c ClusterPrometheusRuleManager) BuildPrometheusRule() *monitoringv1.PrometheusRule {
+	meta := metav1.ObjectMeta{
+		Namespace: c.cluster.Namespace,
+		Name:      c.cluster.Name,
+	}
+	c.cluster.SetInheritedDataAndOwnership(&meta)
+
+	alerts := c.cluster.GetAlerts()
+	if alerts == nil {
+		alerts = &apiv1.AlertsConfiguration{}
+	}
+
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: meta,
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  fmt.Sprintf("cnpg-%s", c.cluster.Name),
+					Rules: c.buildAlertingRules(alerts),
+				},
+			},
+		},
+	}
+}
+
+// buildAlertingRules builds the alerting rules whose threshold is set
+func (c ClusterPrometheusRuleManager) buildAlertingRules(alerts *apiv1.AlertsConfiguration) []monitoringv1.Rule {
+	// The metrics of the instances are matched by the name of their pods,
+	// given that the labels of the cluster may be dropped while scraping
+	selector := fmt.Sprintf(`namespace=%q, pod=~"%s-[0-9]+"`, c.cluster.Namespace, c.cluster.Name)
+
+	var rules []monitoringv1.Rule
+	addRule := func(name, summary, description, expr string) {
+		labels := map[string]string{"severity": "warning"}
+		maps.Copy(labels, alerts.Labels)
+		rules = append(rules, monitoringv1.Rule{
+			Alert:  name,
+			Expr:   intstr.FromString(expr),
+			For:    ptrToPrometheusDuration(alerts.GetFor()),
+			Labels: labels,
+			Annotations: map[string]string{
+				"summary":     summary,
+				"description": description,
+			},
+		})
+	}
+
+	if maxLag := alerts.GetMaxReplicationLag(); maxLag > 0 {
+		addRule(
+			"CNPGClusterReplicationLag",
+			"A replica is lagging behind the primary",
+			fmt.Sprintf("Instance {{ $labels.pod }} of cluster %s is lagging behind the primary "+
+				"by more than %s", c.cluster.Name, maxLag),
+			fmt.Sprintf(`max by (namespace, pod) (cnpg_pg_replication_lag{%s}) > %d`,
+				selector, int64(maxLag.Seconds())),
+		)
+	}
+
+	if alerts.IsWALArchiveFailureEnabled() {
+		addRule(
+			"CNPGClusterWALArchiveFailing",
+			"The WAL archiving is failing",
+			fmt.Sprintf("Instance {{ $labels.pod }} of cluster %s failed to archive the last WAL file",
+				c.cluster.Name),
+			fmt.Sprintf(`(cnpg_pg_stat_archiver_last_failed_time{%[1]s} - `+
+				`cnpg_pg_stat_archiver_last_archived_time{%[1]s}) > 0`, selector),
+		)
+	}
+
+	if maxAge := alerts.GetMaxBackupAge(); maxAge > 0 && c.cluster.Spec.Backup != nil {
+		addRule(
+			"CNPGClusterBackupTooOld",
+			"The last available backup is too old",
+			fmt.Sprintf("The last available backup of cluster %s is older than %s", c.cluster.Name, maxAge),
+			fmt.Sprintf(`time() - max by (namespace) (cnpg_collector_last_available_backup_timestamp{%s}) > %d`,
+				selector, int64(maxAge.Seconds())),
+		)
+	}
+
+	if maxUsage := alerts.GetMaxConnectionsUsage(); maxUsage > 0 {
+		addRule(
+			"CNPGClusterConnectionsSaturation",
+			"The instance is running out of connections",
+			fmt.Sprintf("Instance {{ $labels.pod }} of cluster %s is using more than %d%% "+
+				"of max_connections", c.cluster.Name, maxUsage),
+			fmt.Sprintf(`100 * sum by (namespace, pod) (cnpg_backends_total{%[1]s}) / on (namespace, pod) `+
+				`max by (namespace, pod) (cnpg_pg_settings_setting{%[1]s, name="max_connections"}) > %[2]d`,
+				selector, maxUsage),
+		)
+	}
+
+	if maxDeadlocks := alerts.GetMaxDeadlocks(); maxDeadlocks > 0 {
+		addRule(
+			"CNPGClusterDeadlocks",
+			"Deadlocks were detected",
+			fmt.Sprintf("More than %d deadlocks were detected in database {{ $labels.datname }} "+
+				"of instance {{ $labels.pod }} of cluster %s in the last 5 minutes", maxDeadlocks, c.cluster.Name),
+			fmt.Sprintf(`max by (namespace, pod, datname) (increase(cnpg_pg_stat_database_deadlocks{%s}[5m])) > %d`,
+				selector, maxDeadlocks),
+		)
+	}
+
+	return rules
+}
+
+// ptrToPrometheusDuration formats a duration as a Prometheus one
+func ptrToPrometheusDuration(duration time.Duration) *monitoringv1.Duration {
+	result := monitoringv1.Duration(fmt.Sprintf("%ds", int64(duration.Seconds())))
+	return &result
+}
+
+// NewClusterPrometheusRuleManager returns a new instance of ClusterPrometheusRuleManager
+func NewClusterPrometheusRuleManager(cluster *apiv1.Cluster) *ClusterPrometheusRuleManager {
+	return &ClusterPrometheusRuleManager{cluster: cluster}
+}
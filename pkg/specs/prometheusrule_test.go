@@ -0,0 +1,107 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusRule test", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-namespace",
+			},
+			Spec: apiv1.ClusterSpec{
+				Monitoring: &apiv1.MonitoringConfiguration{
+					Alerts: &apiv1.AlertsConfiguration{Enabled: true},
+				},
+			},
+		}
+	})
+
+	getAlertNames := func(rule *monitoringv1.PrometheusRule) []string {
+		Expect(rule.Spec.Groups).To(HaveLen(1))
+		names := make([]string, 0, len(rule.Spec.Groups[0].Rules))
+		for _, item := range rule.Spec.Groups[0].Rules {
+			names = append(names, item.Alert)
+		}
+		return names
+	}
+
+	It("is enabled when the alerts are", func() {
+		Expect(NewClusterPrometheusRuleManager(cluster).IsPrometheusRuleEnabled()).To(BeTrue())
+
+		cluster.Spec.Monitoring.Alerts.Enabled = false
+		Expect(NewClusterPrometheusRuleManager(cluster).IsPrometheusRuleEnabled()).To(BeFalse())
+	})
+
+	It("creates the default alerts of the cluster", func() {
+		rule := NewClusterPrometheusRuleManager(cluster).BuildPrometheusRule()
+		Expect(rule.Name).To(Equal(cluster.Name))
+		Expect(rule.Namespace).To(Equal(cluster.Namespace))
+		Expect(rule.Labels[utils.ClusterLabelName]).To(Equal(cluster.Name))
+		Expect(getAlertNames(rule)).To(Equal([]string{
+			"CNPGClusterReplicationLag",
+			"CNPGClusterWALArchiveFailing",
+			"CNPGClusterConnectionsSaturation",
+			"CNPGClusterDeadlocks",
+		}))
+
+		lagRule := rule.Spec.Groups[0].Rules[0]
+		Expect(lagRule.Expr.String()).To(Equal(
+			`max by (namespace, pod) (cnpg_pg_replication_lag{namespace="test-namespace", pod=~"test-[0-9]+"}) > 300`))
+		Expect(lagRule.For).To(HaveValue(BeEquivalentTo("300s")))
+		Expect(lagRule.Labels).To(HaveKeyWithValue("severity", "warning"))
+	})
+
+	It("creates the backup alert when the backups are configured", func() {
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{}
+		rule := NewClusterPrometheusRuleManager(cluster).BuildPrometheusRule()
+		Expect(getAlertNames(rule)).To(ContainElement("CNPGClusterBackupTooOld"))
+	})
+
+	It("skips the alerts whose threshold is zero and uses the configured labels", func() {
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{}
+		cluster.Spec.Monitoring.Alerts = &apiv1.AlertsConfiguration{
+			Enabled:             true,
+			Labels:              map[string]string{"severity": "critical", "team": "dba"},
+			MaxReplicationLag:   &metav1.Duration{},
+			WALArchiveFailure:   ptr.To(false),
+			MaxBackupAge:        &metav1.Duration{},
+			MaxConnectionsUsage: ptr.To(int32(90)),
+			MaxDeadlocks:        ptr.To(int32(0)),
+		}
+		rule := NewClusterPrometheusRuleManager(cluster).BuildPrometheusRule()
+		Expect(getAlertNames(rule)).To(Equal([]string{"CNPGClusterConnectionsSaturation"}))
+
+		connectionsRule := rule.Spec.Groups[0].Rules[0]
+		Expect(connectionsRule.Expr.String()).To(HaveSuffix("> 90"))
+		Expect(connectionsRule.Labels).To(Equal(map[string]string{"severity": "critical", "team": "dba"}))
+	})
+})
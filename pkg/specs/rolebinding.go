@@ -19,6 +19,8 @@ package specs
 import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
 
 // CreateRoleBinding is the binding between the permissions that the instance manager can use
@@ -44,3 +46,27 @@ func CreateRoleBinding(objectMeta metav1.ObjectMeta) rbacv1.RoleBinding {
 		},
 	}
 }
+
+// CreateRecoveryBackupRoleBinding binds the recovery role, living in the
+// passed namespace, to the ServiceAccount used by the Pods of the cluster
+func CreateRecoveryBackupRoleBinding(cluster apiv1.Cluster, namespace string) rbacv1.RoleBinding {
+	return rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      GetRecoveryBackupRoleName(cluster),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				APIGroup:  "",
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     GetRecoveryBackupRoleName(cluster),
+		},
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package specs
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
@@ -210,6 +211,65 @@ func CreateRole(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) rbacv1.Role {
 				"update",
 			},
 		},
+		{
+			APIGroups: []string{
+				"postgresql.cnpg.io",
+			},
+			Resources: []string{
+				"scheduleddumps",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+			ResourceNames: []string{},
+		},
+		{
+			APIGroups: []string{
+				"postgresql.cnpg.io",
+			},
+			Resources: []string{
+				"scheduleddumps/status",
+			},
+			Verbs: []string{
+				"get",
+				"patch",
+				"update",
+			},
+		},
+	}
+
+	// The instance manager reports on its own Pod how a former primary
+	// rejoined the cluster. The rules are restricted to the instances
+	// Pods, as an empty list of names would allow every Pod
+	if len(cluster.Status.InstanceNames) > 0 {
+		rules = append(rules,
+			rbacv1.PolicyRule{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"pods",
+				},
+				Verbs: []string{
+					"get",
+				},
+				ResourceNames: cluster.Status.InstanceNames,
+			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"pods/status",
+				},
+				Verbs: []string{
+					"patch",
+				},
+				ResourceNames: cluster.Status.InstanceNames,
+			},
+		)
 	}
 
 	return rbacv1.Role{
@@ -221,6 +281,66 @@ func CreateRole(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) rbacv1.Role {
 	}
 }
 
+// GetRecoveryBackupRoleName returns the name of the role allowing the instance
+// manager to read the backup the cluster is bootstrapped from, when the backup
+// lives in another namespace
+func GetRecoveryBackupRoleName(cluster apiv1.Cluster) string {
+	return fmt.Sprintf("%s-%s-recovery", cluster.Namespace, cluster.Name)
+}
+
+// CreateRecoveryBackupRole creates a role, in the namespace of the passed backup,
+// allowing the instance manager to read the backup and the secrets needed to
+// access the object store where it is stored
+func CreateRecoveryBackupRole(cluster apiv1.Cluster, backup *apiv1.Backup) rbacv1.Role {
+	secretNames := s3CredentialsSecrets(backup.Status.BarmanCredentials.AWS)
+	secretNames = append(secretNames, azureCredentialsSecrets(backup.Status.BarmanCredentials.Azure)...)
+	secretNames = append(secretNames, googleCredentialsSecrets(backup.Status.BarmanCredentials.Google)...)
+	if backup.Status.EndpointCA != nil {
+		secretNames = append(secretNames, backup.Status.EndpointCA.Name)
+	}
+
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				"postgresql.cnpg.io",
+			},
+			Resources: []string{
+				"backups",
+			},
+			Verbs: []string{
+				"get",
+			},
+			ResourceNames: []string{
+				backup.Name,
+			},
+		},
+	}
+
+	// An empty list of resource names would grant access to every secret
+	if secretNames = cleanupResourceList(secretNames); len(secretNames) > 0 {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"secrets",
+			},
+			Verbs: []string{
+				"get",
+			},
+			ResourceNames: secretNames,
+		})
+	}
+
+	return rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: backup.Namespace,
+			Name:      GetRecoveryBackupRoleName(cluster),
+		},
+		Rules: rules,
+	}
+}
+
 func getInvolvedSecretNames(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) []string {
 	involvedSecretNames := []string{
 		cluster.GetReplicationSecretName(),
@@ -238,9 +358,19 @@ func getInvolvedSecretNames(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) [
 		}
 	}
 
+	if cluster.IsBootstrappedWithClone() {
+		involvedSecretNames = append(involvedSecretNames, cluster.GetCloneSecretName())
+	}
+
+	if cluster.Spec.ReplicaCluster != nil && cluster.Spec.ReplicaCluster.PromotionTokenSecret != nil {
+		involvedSecretNames = append(involvedSecretNames, cluster.Spec.ReplicaCluster.PromotionTokenSecret.Name)
+	}
+
 	involvedSecretNames = append(involvedSecretNames, backupSecrets(cluster, backupOrigin)...)
 	involvedSecretNames = append(involvedSecretNames, externalClusterSecrets(cluster)...)
 	involvedSecretNames = append(involvedSecretNames, managedRolesSecrets(cluster)...)
+	involvedSecretNames = append(involvedSecretNames, tdeKeyProviderSecrets(cluster)...)
+	involvedSecretNames = append(involvedSecretNames, logSinkSecrets(cluster)...)
 
 	return cleanupResourceList(involvedSecretNames)
 }
@@ -258,6 +388,14 @@ func getInvolvedConfigMapNames(cluster apiv1.Cluster) []string {
 		}
 	}
 
+	// The instance manager reads the client addresses of the
+	// structured pg_hba rules from these ConfigMaps
+	for _, rule := range cluster.Spec.PostgresConfiguration.PgHBARules {
+		for _, ref := range rule.AddressesFrom {
+			involvedConfigMapNames = append(involvedConfigMapNames, ref.Name)
+		}
+	}
+
 	return cleanupResourceList(involvedConfigMapNames)
 }
 
@@ -304,6 +442,9 @@ func externalClusterSecrets(cluster apiv1.Cluster) []string {
 				result = append(result, barmanObjStore.EndpointCA.Name)
 			}
 		}
+		if pgBackRest := server.PgBackRest; pgBackRest != nil && pgBackRest.Repository.CredentialsSecret != nil {
+			result = append(result, pgBackRest.Repository.CredentialsSecret.Name)
+		}
 	}
 
 	return result
@@ -325,6 +466,14 @@ func backupSecrets(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) []string {
 			googleCredentialsSecrets(cluster.Spec.Backup.BarmanObjectStore.BarmanCredentials.Google)...)
 	}
 
+	// Secrets needed by pgBackRest, if set
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.PgBackRest != nil &&
+		cluster.Spec.Backup.PgBackRest.Repository.CredentialsSecret != nil {
+		result = append(
+			result,
+			cluster.Spec.Backup.PgBackRest.Repository.CredentialsSecret.Name)
+	}
+
 	// Secrets needed by Barman, if set
 	if cluster.Spec.Backup.IsBarmanEndpointCASet() {
 		result = append(
@@ -332,7 +481,26 @@ func backupSecrets(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) []string {
 			cluster.Spec.Backup.BarmanObjectStore.EndpointCA.Name)
 	}
 
-	if backupOrigin != nil {
+	// Secrets needed to access the secondary object store, if set
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.Mirror != nil {
+		mirrorObjectStore := cluster.Spec.Backup.Mirror.BarmanObjectStore
+		result = append(
+			result,
+			s3CredentialsSecrets(mirrorObjectStore.BarmanCredentials.AWS)...)
+		result = append(
+			result,
+			azureCredentialsSecrets(mirrorObjectStore.BarmanCredentials.Azure)...)
+		result = append(
+			result,
+			googleCredentialsSecrets(mirrorObjectStore.BarmanCredentials.Google)...)
+		if mirrorObjectStore.EndpointCA != nil {
+			result = append(result, mirrorObjectStore.EndpointCA.Name)
+		}
+	}
+
+	// The secrets of a backup living in another namespace are
+	// granted by the recovery role in that namespace
+	if backupOrigin != nil && !cluster.IsRecoveringFromAnotherNamespace() {
 		result = append(
 			result,
 			s3CredentialsSecrets(backupOrigin.Status.BarmanCredentials.AWS)...)
@@ -434,3 +602,51 @@ func managedRolesSecrets(cluster apiv1.Cluster) []string {
 
 	return secretNames
 }
+
+// tdeKeyProviderSecrets gets the secrets containing the credentials of
+// the TDE key provider
+func tdeKeyProviderSecrets(cluster apiv1.Cluster) []string {
+	if cluster.Spec.PostgresConfiguration.TDE == nil {
+		return nil
+	}
+
+	keyProvider := cluster.Spec.PostgresConfiguration.TDE.KeyProvider
+	var secretNames []string
+	if keyProvider.Secret != nil {
+		secretNames = append(secretNames, keyProvider.Secret.Name)
+	}
+	if keyProvider.Vault != nil {
+		secretNames = append(secretNames, keyProvider.Vault.Token.Name)
+		if keyProvider.Vault.CA != nil {
+			secretNames = append(secretNames, keyProvider.Vault.CA.Name)
+		}
+	}
+
+	return secretNames
+}
+
+// logSinkSecrets gets the secrets containing the credentials of
+// the log sinks
+func logSinkSecrets(cluster apiv1.Cluster) []string {
+	var secretNames []string
+	for _, sink := range cluster.GetLogSinks() {
+		if sink.Loki != nil {
+			if sink.Loki.BearerToken != nil {
+				secretNames = append(secretNames, sink.Loki.BearerToken.Name)
+			}
+			if sink.Loki.CA != nil {
+				secretNames = append(secretNames, sink.Loki.CA.Name)
+			}
+		}
+		if sink.KafkaRestProxy != nil {
+			if sink.KafkaRestProxy.BasicAuthSecret != nil {
+				secretNames = append(secretNames, sink.KafkaRestProxy.BasicAuthSecret.Name)
+			}
+			if sink.KafkaRestProxy.CA != nil {
+				secretNames = append(secretNames, sink.KafkaRestProxy.CA.Name)
+			}
+		}
+	}
+
+	return secretNames
+}
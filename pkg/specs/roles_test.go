@@ -165,7 +165,35 @@ var _ = Describe("Roles", func() {
 		serviceAccount := CreateRole(cluster, nil)
 		Expect(serviceAccount.Name).To(Equal(cluster.Name))
 		Expect(serviceAccount.Namespace).To(Equal(cluster.Namespace))
-		Expect(serviceAccount.Rules).To(HaveLen(13))
+		Expect(serviceAccount.Rules).To(HaveLen(15))
+	})
+
+	It("allows patching the status of the instances Pods only", func() {
+		clusterWithInstances := cluster.DeepCopy()
+		clusterWithInstances.Status.InstanceNames = []string{"thistest-1", "thistest-2"}
+		role := CreateRole(*clusterWithInstances, nil)
+		Expect(role.Rules).To(HaveLen(17))
+		Expect(role.Rules[16].Resources).To(ConsistOf("pods/status"))
+		Expect(role.Rules[16].Verbs).To(ConsistOf("patch"))
+		Expect(role.Rules[16].ResourceNames).To(ConsistOf("thistest-1", "thistest-2"))
+	})
+
+	It("allows reading the ConfigMaps referenced by the pg_hba rules", func() {
+		clusterWithRules := cluster.DeepCopy()
+		clusterWithRules.Spec.PostgresConfiguration.PgHBARules = []apiv1.PgHBARule{
+			{
+				Method: "scram-sha-256",
+				AddressesFrom: []apiv1.ConfigMapKeySelector{
+					{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: "office-networks"},
+						Key:                  "cidrs",
+					},
+				},
+			},
+		}
+		role := CreateRole(*clusterWithRules, nil)
+		Expect(role.Rules[0].ResourceNames).To(ConsistOf(
+			"thisTest", "testConfigMapKeySelector", "office-networks"))
 	})
 
 	It("should contain every secret of the origin backup and backup configuration of every external cluster", func() {
@@ -299,6 +327,141 @@ var _ = Describe("Secrets", func() {
 			"thisTest-superuser",
 		}))
 	})
+
+	It("should not contain the secrets of a backup living in another namespace", func() {
+		cluster.Spec.Bootstrap = &apiv1.BootstrapConfiguration{
+			Recovery: &apiv1.BootstrapRecovery{
+				Backup: &apiv1.BackupSource{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: backup.Name},
+					Namespace:            "production",
+				},
+			},
+		}
+		backup.Namespace = "production"
+		Expect(getInvolvedSecretNames(cluster, &backup)).ToNot(ContainElement("aws-status-secret-test"))
+
+		role := CreateRecoveryBackupRole(cluster, &backup)
+		Expect(role.Namespace).To(Equal("production"))
+		Expect(role.Name).To(Equal("default-thisTest-recovery"))
+		Expect(role.Rules).To(HaveLen(2))
+		Expect(role.Rules[0].ResourceNames).To(ConsistOf("testBackup"))
+		Expect(role.Rules[1].ResourceNames).To(ConsistOf(
+			"aws-status-secret-test",
+			"azure-storage-key-secret-test",
+			"google-application-secret-test",
+		))
+
+		roleBinding := CreateRecoveryBackupRoleBinding(cluster, "production")
+		Expect(roleBinding.Namespace).To(Equal("production"))
+		Expect(roleBinding.RoleRef.Name).To(Equal(role.Name))
+		Expect(roleBinding.Subjects[0].Namespace).To(Equal("default"))
+		Expect(roleBinding.Subjects[0].Name).To(Equal("thisTest"))
+	})
+
+	It("should contain the promotion token secret", func() {
+		replicaCluster := cluster.DeepCopy()
+		replicaCluster.Spec.ReplicaCluster = &apiv1.ReplicaClusterConfiguration{
+			Source:               "origin",
+			PromotionTokenSecret: &apiv1.LocalObjectReference{Name: "switchover-token"},
+		}
+		Expect(getInvolvedSecretNames(*replicaCluster, nil)).To(ContainElement("switchover-token"))
+	})
+
+	It("should contain the secrets of the TDE key provider", func() {
+		tdeCluster := cluster.DeepCopy()
+		tdeCluster.Spec.PostgresConfiguration.TDE = &apiv1.TDEConfiguration{
+			KeyProvider: apiv1.TDEKeyProvider{
+				Vault: &apiv1.TDEVaultKeyProvider{
+					Address: "https://vault.example.com:8200",
+					KeyName: "postgres",
+					Token: apiv1.SecretKeySelector{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: "vault-token"},
+						Key:                  "token",
+					},
+					CA: &apiv1.SecretKeySelector{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: "vault-ca"},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+		}
+		Expect(getInvolvedSecretNames(*tdeCluster, nil)).To(ContainElements("vault-token", "vault-ca"))
+	})
+
+	It("should contain the secrets of the log sinks", func() {
+		loggingCluster := cluster.DeepCopy()
+		loggingCluster.Spec.Logging = &apiv1.LoggingConfiguration{
+			Sinks: []apiv1.LogSink{
+				{
+					Name: "loki",
+					Loki: &apiv1.LokiLogSink{
+						URL: "https://loki.example.com/loki/api/v1/push",
+						BearerToken: &apiv1.SecretKeySelector{
+							LocalObjectReference: apiv1.LocalObjectReference{Name: "loki-token"},
+							Key:                  "token",
+						},
+					},
+				},
+				{
+					Name: "kafka",
+					KafkaRestProxy: &apiv1.KafkaRestProxyLogSink{
+						URL:             "https://kafka-rest.example.com",
+						Topic:           "postgres-logs",
+						BasicAuthSecret: &apiv1.LocalObjectReference{Name: "kafka-credentials"},
+					},
+				},
+			},
+		}
+		Expect(getInvolvedSecretNames(*loggingCluster, nil)).To(ContainElements("loki-token", "kafka-credentials"))
+	})
+
+	It("should contain the pgBackRest credentials secrets", func() {
+		pgBackRestCluster := cluster.DeepCopy()
+		pgBackRestCluster.Spec.Backup = &apiv1.BackupConfiguration{
+			PgBackRest: &apiv1.PgBackRestConfiguration{
+				Repository: apiv1.PgBackRestRepository{
+					Path:              "/backups",
+					CredentialsSecret: &apiv1.LocalObjectReference{Name: "backup-credentials"},
+				},
+			},
+		}
+		pgBackRestCluster.Spec.ExternalClusters = append(pgBackRestCluster.Spec.ExternalClusters,
+			apiv1.ExternalCluster{
+				Name: "pgbackrest-origin",
+				PgBackRest: &apiv1.PgBackRestConfiguration{
+					Repository: apiv1.PgBackRestRepository{
+						Path:              "/origin",
+						CredentialsSecret: &apiv1.LocalObjectReference{Name: "origin-credentials"},
+					},
+				},
+			})
+		Expect(getInvolvedSecretNames(*pgBackRestCluster, nil)).To(ContainElements(
+			"backup-credentials",
+			"origin-credentials",
+		))
+	})
+
+	It("should contain the secondary object store credentials secrets", func() {
+		mirrorCluster := cluster.DeepCopy()
+		mirrorCluster.Spec.Backup = &apiv1.BackupConfiguration{
+			Mirror: &apiv1.BackupMirrorConfiguration{
+				BarmanObjectStore: apiv1.BarmanObjectStoreConfiguration{
+					BarmanCredentials: apiv1.BarmanCredentials{
+						Azure: &apiv1.AzureCredentials{
+							ConnectionString: &apiv1.SecretKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{Name: "mirror-connection"},
+							},
+						},
+					},
+					EndpointCA: &apiv1.SecretKeySelector{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: "mirror-endpoint-ca"},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+		}
+		Expect(backupSecrets(*mirrorCluster, nil)).To(ConsistOf("mirror-connection", "mirror-endpoint-ca"))
+	})
 })
 
 var _ = Describe("Managed Roles", func() {
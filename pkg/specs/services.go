@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
@@ -61,7 +62,7 @@ func CreateClusterAnyService(cluster apiv1.Cluster) *corev1.Service {
 
 // CreateClusterReadService create a service insisting on all the ready pods
 func CreateClusterReadService(cluster apiv1.Cluster) *corev1.Service {
-	return &corev1.Service{
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cluster.GetServiceReadName(),
 			Namespace: cluster.Namespace,
@@ -75,11 +76,14 @@ func CreateClusterReadService(cluster apiv1.Cluster) *corev1.Service {
 			},
 		},
 	}
+	excludeLaggingReplicas(cluster, service)
+	applyServiceRouting(cluster, service, apiv1.ServiceSelectorTypeR)
+	return service
 }
 
 // CreateClusterReadOnlyService create a service insisting on all the ready pods
 func CreateClusterReadOnlyService(cluster apiv1.Cluster) *corev1.Service {
-	return &corev1.Service{
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cluster.GetServiceReadOnlyName(),
 			Namespace: cluster.Namespace,
@@ -93,11 +97,14 @@ func CreateClusterReadOnlyService(cluster apiv1.Cluster) *corev1.Service {
 			},
 		},
 	}
+	excludeLaggingReplicas(cluster, service)
+	applyServiceRouting(cluster, service, apiv1.ServiceSelectorTypeRO)
+	return service
 }
 
 // CreateClusterReadWriteService create a service insisting on the primary pod
 func CreateClusterReadWriteService(cluster apiv1.Cluster) *corev1.Service {
-	return &corev1.Service{
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cluster.GetServiceReadWriteName(),
 			Namespace: cluster.Namespace,
@@ -111,6 +118,42 @@ func CreateClusterReadWriteService(cluster apiv1.Cluster) *corev1.Service {
 			},
 		},
 	}
+	applyServiceRouting(cluster, service, apiv1.ServiceSelectorTypeRW)
+	return service
+}
+
+// excludeLaggingReplicas restricts the service to the instances not
+// exceeding the maximum replication lag, when configured
+func excludeLaggingReplicas(cluster apiv1.Cluster, service *corev1.Service) {
+	if cluster.GetMaxReplicaLag() == nil {
+		return
+	}
+
+	service.Spec.Selector[utils.ReplicaLaggingLabelName] = "false"
+}
+
+// applyServiceRouting sets the load balancing options of the default
+// service with the given selector type
+func applyServiceRouting(cluster apiv1.Cluster, service *corev1.Service, selectorType apiv1.ServiceSelectorType) {
+	routing := cluster.GetServiceRouting(selectorType)
+	if routing == nil {
+		return
+	}
+
+	if routing.SessionAffinity == corev1.ServiceAffinityClientIP {
+		service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		if routing.SessionAffinityTimeoutSeconds != nil {
+			service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+				ClientIP: &corev1.ClientIPConfig{
+					TimeoutSeconds: ptr.To(*routing.SessionAffinityTimeoutSeconds),
+				},
+			}
+		}
+	}
+
+	if routing.TopologyAwareRouting {
+		service.Spec.TrafficDistribution = ptr.To(corev1.ServiceTrafficDistributionPreferClose)
+	}
 }
 
 // BuildManagedServices creates a list of Kubernetes Services based on the
@@ -182,6 +225,59 @@ func BuildManagedServices(cluster apiv1.Cluster) ([]corev1.Service, error) {
 	return services, nil
 }
 
+// BuildInstanceServices creates the externally reachable services pointing
+// to each instance of the cluster, when enabled in the ManagedServices
+// configuration
+func BuildInstanceServices(cluster apiv1.Cluster) []corev1.Service {
+	perInstance := cluster.GetPerInstanceServices()
+	if perInstance == nil {
+		return nil
+	}
+
+	serviceType := perInstance.Type
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeLoadBalancer
+	}
+
+	services := make([]corev1.Service, len(cluster.Status.InstanceNames))
+	for i, instanceName := range cluster.Status.InstanceNames {
+		services[i] = corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        cluster.GetInstanceServiceName(instanceName),
+				Namespace:   cluster.Namespace,
+				Labels:      make(map[string]string, len(perInstance.Labels)+2),
+				Annotations: make(map[string]string, len(perInstance.Annotations)+1),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                  serviceType,
+				Ports:                 buildInstanceServicePorts(),
+				ExternalTrafficPolicy: perInstance.ExternalTrafficPolicy,
+				Selector: map[string]string{
+					utils.ClusterLabelName:      cluster.Name,
+					utils.InstanceNameLabelName: instanceName,
+				},
+			},
+		}
+		if serviceType == corev1.ServiceTypeLoadBalancer {
+			services[i].Spec.LoadBalancerSourceRanges = perInstance.LoadBalancerSourceRanges
+		}
+
+		utils.MergeMap(services[i].Labels, perInstance.Labels)
+		services[i].Labels[utils.IsManagedLabelName] = "true"
+		services[i].Labels[utils.InstanceNameLabelName] = instanceName
+
+		utils.MergeMap(services[i].Annotations, perInstance.Annotations)
+		if perInstance.DNSDomain != "" {
+			services[i].Annotations[utils.ExternalDNSHostnameAnnotationName] = fmt.Sprintf(
+				"%s.%s", instanceName, perInstance.DNSDomain)
+		}
+
+		cluster.SetInheritedDataAndOwnership(&services[i].ObjectMeta)
+	}
+
+	return services
+}
+
 func buildDefaultService(cluster apiv1.Cluster, serviceConf apiv1.ManagedService) (*corev1.Service, error) {
 	switch serviceConf.SelectorType {
 	case apiv1.ServiceSelectorTypeRO:
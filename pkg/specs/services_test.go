@@ -18,8 +18,10 @@ package specs
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
@@ -81,6 +83,104 @@ var _ = Describe("Services specification", func() {
 		Expect(service.Spec.Ports).To(HaveLen(1))
 		Expect(service.Spec.Ports).To(ContainElement(expectedPort))
 	})
+
+	It("excludes the lagging replicas from the -r and -ro services", func() {
+		maxReplicaLag := resource.MustParse("16Mi")
+		cluster := postgresql.DeepCopy()
+		cluster.Spec.Managed = &apiv1.ManagedConfiguration{
+			Services: &apiv1.ManagedServices{MaxReplicaLag: &maxReplicaLag},
+		}
+
+		Expect(CreateClusterReadService(*cluster).Spec.Selector).To(
+			HaveKeyWithValue(utils.ReplicaLaggingLabelName, "false"))
+		Expect(CreateClusterReadOnlyService(*cluster).Spec.Selector).To(
+			HaveKeyWithValue(utils.ReplicaLaggingLabelName, "false"))
+		Expect(CreateClusterReadWriteService(*cluster).Spec.Selector).ToNot(
+			HaveKey(utils.ReplicaLaggingLabelName))
+	})
+
+	It("applies the load balancing options to the default services", func() {
+		cluster := postgresql.DeepCopy()
+		cluster.Spec.Managed = &apiv1.ManagedConfiguration{
+			Services: &apiv1.ManagedServices{
+				Routing: []apiv1.ServiceRoutingConfiguration{
+					{
+						SelectorType:                  apiv1.ServiceSelectorTypeRO,
+						SessionAffinity:               corev1.ServiceAffinityClientIP,
+						SessionAffinityTimeoutSeconds: ptr.To(int32(600)),
+						TopologyAwareRouting:          true,
+					},
+				},
+			},
+		}
+
+		service := CreateClusterReadOnlyService(*cluster)
+		Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+		Expect(service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(HaveValue(Equal(int32(600))))
+		Expect(service.Spec.TrafficDistribution).To(HaveValue(Equal(corev1.ServiceTrafficDistributionPreferClose)))
+
+		service = CreateClusterReadService(*cluster)
+		Expect(service.Spec.SessionAffinity).To(BeEmpty())
+		Expect(service.Spec.TrafficDistribution).To(BeNil())
+	})
+})
+
+var _ = Describe("BuildInstanceServices", func() {
+	var cluster apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "clustername",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Services: &apiv1.ManagedServices{
+						PerInstance: &apiv1.PerInstanceServicesConfiguration{
+							DNSDomain:   "db.example.com",
+							Annotations: map[string]string{"test-annotation": "value"},
+						},
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				InstanceNames: []string{"clustername-1", "clustername-2"},
+			},
+		}
+	})
+
+	It("should not create any service when not enabled", func() {
+		cluster.Spec.Managed.Services.PerInstance = nil
+		Expect(BuildInstanceServices(cluster)).To(BeEmpty())
+	})
+
+	It("should create a service for each instance", func() {
+		services := BuildInstanceServices(cluster)
+		Expect(services).To(HaveLen(2))
+
+		service := services[1]
+		Expect(service.Name).To(Equal("clustername-2-external"))
+		Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+		Expect(service.Spec.Selector).To(Equal(map[string]string{
+			utils.ClusterLabelName:      "clustername",
+			utils.InstanceNameLabelName: "clustername-2",
+		}))
+		Expect(service.Labels).To(HaveKeyWithValue(utils.IsManagedLabelName, "true"))
+		Expect(service.Annotations).To(HaveKeyWithValue("test-annotation", "value"))
+		Expect(service.Annotations).To(HaveKeyWithValue(
+			utils.ExternalDNSHostnameAnnotationName, "clustername-2.db.example.com"))
+	})
+
+	It("should create NodePort services without source ranges", func() {
+		cluster.Spec.Managed.Services.PerInstance.Type = corev1.ServiceTypeNodePort
+		cluster.Spec.Managed.Services.PerInstance.LoadBalancerSourceRanges = []string{"10.0.0.0/8"}
+
+		services := BuildInstanceServices(cluster)
+		Expect(services).To(HaveLen(2))
+		Expect(services[0].Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+		Expect(services[0].Spec.LoadBalancerSourceRanges).To(BeEmpty())
+	})
 })
 
 var _ = Describe("BuildManagedServices", func() {
@@ -0,0 +1,95 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// createInstanceAffinity creates the affinity section of the pods running
+// the instances and the jobs preparing their storage
+func createInstanceAffinity(cluster apiv1.Cluster) *corev1.Affinity {
+	return createTopologyAffinity(cluster, CreateAffinitySection(cluster.Name, cluster.Spec.Affinity))
+}
+
+// createTopologyAffinity adds to the given affinity section the scheduling
+// constraints generated from the zone constraints of the cluster
+func createTopologyAffinity(cluster apiv1.Cluster, affinity *corev1.Affinity) *corev1.Affinity {
+	topology := cluster.Spec.Topology
+	if topology == nil || (!topology.DistinctZones && len(topology.PrimaryZones) == 0) {
+		return affinity
+	}
+
+	// The affinity section may be shared with the cluster specification
+	result := affinity.DeepCopy()
+	if result == nil {
+		result = &corev1.Affinity{}
+	}
+
+	zoneLabel := cluster.GetZoneLabel()
+
+	if topology.DistinctZones {
+		if result.PodAntiAffinity == nil {
+			result.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		result.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			result.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      utils.ClusterLabelName,
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{cluster.Name},
+						},
+						{
+							Key:      utils.PodRoleLabelName,
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{string(utils.PodRoleInstance)},
+						},
+					},
+				},
+				TopologyKey: zoneLabel,
+			})
+	}
+
+	if len(topology.PrimaryZones) > 0 {
+		if result.NodeAffinity == nil {
+			result.NodeAffinity = &corev1.NodeAffinity{}
+		}
+		result.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			result.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			corev1.PreferredSchedulingTerm{
+				Weight: 100,
+				Preference: corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      zoneLabel,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   topology.PrimaryZones,
+						},
+					},
+				},
+			})
+	}
+
+	return result
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Topology affinity", func() {
+	var cluster apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec:       apiv1.ClusterSpec{Instances: 3},
+		}
+	})
+
+	It("doesn't change the affinity when no topology is configured", func() {
+		affinity := &corev1.Affinity{}
+		Expect(createTopologyAffinity(cluster, affinity)).To(BeIdenticalTo(affinity))
+	})
+
+	It("forbids two instances in the same zone", func() {
+		cluster.Spec.Topology = &apiv1.TopologyConfiguration{DistinctZones: true}
+		affinity := createTopologyAffinity(cluster, nil)
+		Expect(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+		Expect(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey).
+			To(Equal(corev1.LabelTopologyZone))
+		Expect(affinity.NodeAffinity).To(BeNil())
+	})
+
+	It("prefers the primary zones without changing the cluster specification", func() {
+		nodeAffinity := &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{Weight: 10}},
+		}
+		cluster.Spec.Affinity.NodeAffinity = nodeAffinity
+		cluster.Spec.Topology = &apiv1.TopologyConfiguration{
+			ZoneLabel:    "example.com/zone",
+			PrimaryZones: []string{"zone-a", "zone-b"},
+		}
+
+		affinity := createInstanceAffinity(cluster)
+		Expect(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(2))
+		term := affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[1]
+		Expect(term.Preference.MatchExpressions[0].Key).To(Equal("example.com/zone"))
+		Expect(term.Preference.MatchExpressions[0].Values).To(ConsistOf("zone-a", "zone-b"))
+		Expect(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+	})
+})
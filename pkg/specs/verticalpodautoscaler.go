@@ -0,0 +1,231 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// VerticalPodAutoscalerGVK is the GroupVersionKind of the
+// VerticalPodAutoscaler objects
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{
+	Group:   "autoscaling.k8s.io",
+	Version: "v1",
+	Kind:    "VerticalPodAutoscaler",
+}
+
+// ClusterVerticalPodAutoscalerManager builds the VerticalPodAutoscaler
+// recommending the resources of the instances of the cluster resource
+type ClusterVerticalPodAutoscalerManager struct {
+	cluster *apiv1.Cluster
+}
+
+// NewClusterVerticalPodAutoscalerManager creates a new
+// ClusterVerticalPodAutoscalerManager
+func NewClusterVerticalPodAutoscalerManager(cluster *apiv1.Cluster) *ClusterVerticalPodAutoscalerManager {
+	return &ClusterVerticalPodAutoscalerManager{cluster: cluster}
+}
+
+// IsVerticalPodAutoscalerEnabled returns a boolean indicating if the
+// VerticalPodAutoscaler should exist or not
+func (c ClusterVerticalPodAutoscalerManager) IsVerticalPodAutoscalerEnabled() bool {
+	return c.cluster.IsVerticalPodAutoscalerEnabled()
+}
+
+// BuildVerticalPodAutoscaler builds a new VerticalPodAutoscaler object.
+// The update mode is `Off`, as the recommended resources are applied by
+// the operator through a rolling update of the instances
+func (c ClusterVerticalPodAutoscalerManager) BuildVerticalPodAutoscaler() *unstructured.Unstructured {
+	meta := metav1.ObjectMeta{
+		Namespace: c.cluster.Namespace,
+		Name:      c.cluster.Name,
+	}
+	c.cluster.SetInheritedDataAndOwnership(&meta)
+
+	configuration := c.cluster.Spec.VerticalPodAutoscaler
+	containerPolicy := map[string]interface{}{
+		"containerName":       PostgresContainerName,
+		"controlledResources": []interface{}{string(corev1.ResourceCPU), string(corev1.ResourceMemory)},
+		"controlledValues":    string(configuration.GetControlledValues()),
+	}
+	if configuration != nil && len(configuration.MinAllowed) > 0 {
+		containerPolicy["minAllowed"] = resourceListToUnstructured(configuration.MinAllowed)
+	}
+	if configuration != nil && len(configuration.MaxAllowed) > 0 {
+		containerPolicy["maxAllowed"] = resourceListToUnstructured(configuration.MaxAllowed)
+	}
+
+	vpa := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"targetRef": map[string]interface{}{
+					"apiVersion": apiv1.GroupVersion.String(),
+					"kind":       apiv1.ClusterKind,
+					"name":       c.cluster.Name,
+				},
+				"updatePolicy": map[string]interface{}{
+					"updateMode": "Off",
+				},
+				"resourcePolicy": map[string]interface{}{
+					"containerPolicies": []interface{}{
+						containerPolicy,
+						map[string]interface{}{
+							"containerName": "*",
+							"mode":          "Off",
+						},
+					},
+				},
+			},
+		},
+	}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetNamespace(meta.Namespace)
+	vpa.SetName(meta.Name)
+	vpa.SetLabels(meta.Labels)
+	vpa.SetAnnotations(meta.Annotations)
+	vpa.SetOwnerReferences(meta.OwnerReferences)
+
+	return vpa
+}
+
+// resourceListToUnstructured converts a list of resources to the
+// representation used by the unstructured objects
+func resourceListToUnstructured(resources corev1.ResourceList) map[string]interface{} {
+	result := make(map[string]interface{}, len(resources))
+	for name, quantity := range resources {
+		result[string(name)] = quantity.String()
+	}
+	return result
+}
+
+// GetRecommendedResources computes the resources of the instances from
+// the target recommended by the VerticalPodAutoscaler for the postgres
+// container. The limits specified in the cluster are scaled together with
+// the requests, keeping their ratio, unless only the requests are
+// controlled. A request never exceeds the corresponding limit. Returns
+// nil when the VerticalPodAutoscaler has no recommendation
+func GetRecommendedResources(
+	cluster *apiv1.Cluster,
+	vpa *unstructured.Unstructured,
+) (*corev1.ResourceRequirements, error) {
+	target, err := getRecommendationTarget(vpa)
+	if err != nil || len(target) == 0 {
+		return nil, err
+	}
+
+	scaleLimits := cluster.Spec.VerticalPodAutoscaler.GetControlledValues() ==
+		apiv1.VerticalPodAutoscalerControlledValuesRequestsAndLimits
+
+	result := cluster.Spec.Resources.DeepCopy()
+	if result.Requests == nil {
+		result.Requests = make(corev1.ResourceList, len(target))
+	}
+	for name, request := range target {
+		original, hasOriginal := cluster.Spec.Resources.Requests[name]
+		limit, hasLimit := cluster.Spec.Resources.Limits[name]
+		if hasLimit && scaleLimits && hasOriginal && !original.IsZero() {
+			limit = scaleQuantity(name, limit, request, original)
+			result.Limits[name] = limit
+		}
+		if hasLimit && request.Cmp(limit) > 0 {
+			request = limit
+		}
+		result.Requests[name] = request
+	}
+
+	return result, nil
+}
+
+// getRecommendationTarget gets the target resources recommended by the
+// VerticalPodAutoscaler for the postgres container
+func getRecommendationTarget(vpa *unstructured.Unstructured) (corev1.ResourceList, error) {
+	recommendations, _, err := unstructured.NestedSlice(
+		vpa.Object, "status", "recommendation", "containerRecommendations")
+	if err != nil {
+		return nil, fmt.Errorf("while reading the recommendation: %w", err)
+	}
+
+	for _, item := range recommendations {
+		recommendation, ok := item.(map[string]interface{})
+		if !ok || recommendation["containerName"] != PostgresContainerName {
+			continue
+		}
+
+		target, _, err := unstructured.NestedStringMap(recommendation, "target")
+		if err != nil {
+			return nil, fmt.Errorf("while reading the recommended target: %w", err)
+		}
+
+		result := make(corev1.ResourceList, len(target))
+		for name, value := range target {
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("while parsing the recommended %s: %w", name, err)
+			}
+			result[corev1.ResourceName(name)] = quantity
+		}
+		return result, nil
+	}
+
+	return nil, nil
+}
+
+// scaleQuantity scales a limit by the ratio between the recommended
+// request and the original one
+func scaleQuantity(name corev1.ResourceName, limit, request, original resource.Quantity) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		ratio := float64(limit.MilliValue()) / float64(original.MilliValue())
+		return *resource.NewMilliQuantity(int64(math.Ceil(float64(request.MilliValue())*ratio)), limit.Format)
+	}
+
+	ratio := float64(limit.Value()) / float64(original.Value())
+	return *resource.NewQuantity(int64(math.Ceil(float64(request.Value())*ratio)), limit.Format)
+}
+
+// IsResourcesChangeSignificant checks whether the recommended resources
+// differ from the applied ones by more than the tolerance, in percent
+func IsResourcesChangeSignificant(applied, recommended corev1.ResourceRequirements, tolerance int32) bool {
+	isListChangeSignificant := func(applied, recommended corev1.ResourceList) bool {
+		if len(applied) != len(recommended) {
+			return true
+		}
+		for name, recommendedQuantity := range recommended {
+			appliedQuantity, ok := applied[name]
+			if !ok || appliedQuantity.IsZero() {
+				return true
+			}
+			appliedValue := float64(appliedQuantity.MilliValue())
+			difference := math.Abs(float64(recommendedQuantity.MilliValue()) - appliedValue)
+			if difference*100 > appliedValue*float64(tolerance) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return isListChangeSignificant(applied.Requests, recommended.Requests) ||
+		isListChangeSignificant(applied.Limits, recommended.Limits)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VerticalPodAutoscaler", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+				VerticalPodAutoscaler: &apiv1.VerticalPodAutoscalerConfiguration{
+					Enabled:    true,
+					MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+			},
+		}
+	})
+
+	withRecommendation := func(target map[string]interface{}) *unstructured.Unstructured {
+		vpa := NewClusterVerticalPodAutoscalerManager(cluster).BuildVerticalPodAutoscaler()
+		vpa.Object["status"] = map[string]interface{}{
+			"recommendation": map[string]interface{}{
+				"containerRecommendations": []interface{}{
+					map[string]interface{}{"containerName": "bootstrap-controller", "target": map[string]interface{}{}},
+					map[string]interface{}{"containerName": PostgresContainerName, "target": target},
+				},
+			},
+		}
+		return vpa
+	}
+
+	It("builds a recommendation-only autoscaler targeting the cluster", func() {
+		vpa := NewClusterVerticalPodAutoscalerManager(cluster).BuildVerticalPodAutoscaler()
+		Expect(vpa.GroupVersionKind()).To(Equal(VerticalPodAutoscalerGVK))
+		Expect(vpa.GetName()).To(Equal("cluster-example"))
+		Expect(vpa.GetOwnerReferences()).To(HaveLen(1))
+
+		updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		Expect(updateMode).To(Equal("Off"))
+		targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		Expect(targetKind).To(Equal(apiv1.ClusterKind))
+
+		policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+		Expect(policies).To(HaveLen(2))
+		Expect(policies[0]).To(HaveKeyWithValue("maxAllowed", map[string]interface{}{"memory": "8Gi"}))
+		Expect(policies[0]).To(HaveKeyWithValue("controlledValues", "RequestsAndLimits"))
+	})
+
+	It("doesn't recommend anything without a recommendation", func() {
+		vpa := NewClusterVerticalPodAutoscalerManager(cluster).BuildVerticalPodAutoscaler()
+		resources, err := GetRecommendedResources(cluster, vpa)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources).To(BeNil())
+	})
+
+	It("scales the limits together with the requests", func() {
+		resources, err := GetRecommendedResources(cluster, withRecommendation(map[string]interface{}{
+			"cpu":    "500m",
+			"memory": "2Gi",
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources.Requests.Cpu().String()).To(Equal("500m"))
+		Expect(resources.Limits.Cpu().String()).To(Equal("1"))
+		Expect(resources.Requests.Memory().String()).To(Equal("2Gi"))
+		Expect(resources.Limits.Memory().String()).To(Equal("2Gi"))
+	})
+
+	It("caps the requests to the limits when only the requests are controlled", func() {
+		cluster.Spec.VerticalPodAutoscaler.ControlledValues = apiv1.VerticalPodAutoscalerControlledValuesRequestsOnly
+		resources, err := GetRecommendedResources(cluster, withRecommendation(map[string]interface{}{
+			"cpu":    "500m",
+			"memory": "2Gi",
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources.Requests.Cpu().String()).To(Equal("500m"))
+		Expect(resources.Limits.Cpu().String()).To(Equal("2"))
+		Expect(resources.Requests.Memory().String()).To(Equal("1Gi"))
+		Expect(resources.Limits.Memory().String()).To(Equal("1Gi"))
+	})
+
+	It("detects the significant changes according to the tolerance", func() {
+		applied := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}
+		slightlyChanged := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1050m")},
+		}
+		Expect(IsResourcesChangeSignificant(applied, slightlyChanged, 10)).To(BeFalse())
+		Expect(IsResourcesChangeSignificant(applied, slightlyChanged, 0)).To(BeTrue())
+		Expect(IsResourcesChangeSignificant(applied, corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		}, 10)).To(BeTrue())
+		Expect(cluster.Spec.VerticalPodAutoscaler.GetTolerance()).To(Equal(int32(10)))
+	})
+})
@@ -26,6 +26,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
 )
 
 // PgWalVolumePath is the path used by the WAL volume when present
@@ -131,11 +132,10 @@ func createPostgresVolumes(cluster *apiv1.Cluster, podName string) []corev1.Volu
 			result = append(result,
 				corev1.Volume{
 					Name: VolumeMountNameForTablespace(tbsNames[i]),
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: PvcNameForTablespace(podName, tbsNames[i]),
-						},
-					},
+					VolumeSource: createTablespaceVolumeSource(
+						podName,
+						cluster.GetTablespaceConfiguration(tbsNames[i]),
+					),
 				},
 			)
 		}
@@ -144,9 +144,31 @@ func createPostgresVolumes(cluster *apiv1.Cluster, podName string) []corev1.Volu
 	if cluster.ShouldCreateProjectedVolume() {
 		result = append(result, createProjectedVolume(cluster))
 	}
+
+	if image := getOAuthValidatorImage(cluster); image != "" {
+		result = append(result,
+			corev1.Volume{
+				Name: "oauth-validator",
+				VolumeSource: corev1.VolumeSource{
+					Image: &corev1.ImageVolumeSource{
+						Reference:  image,
+						PullPolicy: cluster.Spec.ImagePullPolicy,
+					},
+				},
+			})
+	}
 	return result
 }
 
+// getOAuthValidatorImage gets the image containing the OAuth
+// validator library, if any
+func getOAuthValidatorImage(cluster *apiv1.Cluster) string {
+	if cluster.Spec.PostgresConfiguration.OAuth == nil {
+		return ""
+	}
+	return cluster.Spec.PostgresConfiguration.OAuth.Validator.Image
+}
+
 func createVolumesAndVolumeMountsForSQLRefs(
 	folder postInitFolder,
 	refs *apiv1.SQLRefs,
@@ -159,6 +181,8 @@ func createVolumesAndVolumeMountsForSQLRefs(
 		suffix = "post-init-template"
 	case postInitSQLRefsFolder:
 		suffix = "post-init"
+	case DataMaskingSQLRefsFolder:
+		suffix = "data-masking"
 	}
 
 	length := len(refs.ConfigMapRefs) + len(refs.SecretRefs)
@@ -270,6 +294,16 @@ func createPostgresVolumeMounts(cluster apiv1.Cluster) []corev1.VolumeMount {
 			)
 		}
 	}
+
+	if getOAuthValidatorImage(&cluster) != "" {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{
+				Name:      "oauth-validator",
+				MountPath: postgres.OAuthValidatorDirectory,
+				ReadOnly:  true,
+			},
+		)
+	}
 	return volumeMounts
 }
 
@@ -285,6 +319,50 @@ func getSortedTablespaceList(cluster *apiv1.Cluster) []string {
 	return tbsNames
 }
 
+// createTablespaceVolumeSource creates the source of the volume of a
+// tablespace, which is the tablespace PVC unless the tablespace is ephemeral
+func createTablespaceVolumeSource(
+	podName string,
+	tbsConfig *apiv1.TablespaceConfiguration,
+) corev1.VolumeSource {
+	if !tbsConfig.IsEphemeral() {
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: PvcNameForTablespace(podName, tbsConfig.Name),
+			},
+		}
+	}
+
+	size := tbsConfig.Storage.GetSizeOrNil()
+	if tbsConfig.Ephemeral.GetVolumeType() == apiv1.EphemeralTablespaceVolumeTypeEmptyDir {
+		return corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				SizeLimit: size,
+			},
+		}
+	}
+
+	builder := resources.NewPersistentVolumeClaimBuilder().
+		WithSpec(tbsConfig.Storage.PersistentVolumeClaimTemplate.DeepCopy()).
+		WithDefaultAccessMode(corev1.ReadWriteOnce)
+	if tbsConfig.Storage.StorageClass != nil {
+		builder = builder.WithStorageClass(tbsConfig.Storage.StorageClass)
+	}
+	if size != nil {
+		builder = builder.WithRequests(corev1.ResourceList{
+			corev1.ResourceStorage: *size,
+		})
+	}
+
+	return corev1.VolumeSource{
+		Ephemeral: &corev1.EphemeralVolumeSource{
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+				Spec: builder.Build().Spec,
+			},
+		},
+	}
+}
+
 func createEphemeralVolume(cluster *apiv1.Cluster) corev1.Volume {
 	scratchVolumeSource := corev1.VolumeSource{}
 	if cluster.Spec.EphemeralVolumeSource != nil {
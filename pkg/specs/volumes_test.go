@@ -473,6 +473,89 @@ var _ = DescribeTable("test creation of volumes",
 				},
 			},
 		}),
+	Entry("should create an ephemeral volume for each ephemeral tablespace",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{
+						Name: "scratch",
+						Storage: apiv1.StorageConfiguration{
+							Size:         "10Gi",
+							StorageClass: ptr.To("local-nvme"),
+						},
+						Temporary: true,
+						Ephemeral: &apiv1.EphemeralTablespaceConfiguration{},
+					},
+					{
+						Name: "spill",
+						Storage: apiv1.StorageConfiguration{
+							Size: "1Gi",
+						},
+						Temporary: true,
+						Ephemeral: &apiv1.EphemeralTablespaceConfiguration{
+							VolumeType: apiv1.EphemeralTablespaceVolumeTypeEmptyDir,
+						},
+					},
+				},
+			},
+		},
+		[]corev1.Volume{
+			{
+				Name: "scratch",
+				VolumeSource: corev1.VolumeSource{
+					Ephemeral: &corev1.EphemeralVolumeSource{
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+								StorageClassName: ptr.To("local-nvme"),
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("10Gi"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "spill",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						SizeLimit: ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			},
+		}),
+	Entry("should create an image volume for the OAuth validator",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances:       1,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					OAuth: &apiv1.OAuthConfig{
+						Issuer: "https://idp.example.com",
+						Scope:  "openid",
+						Validator: apiv1.OAuthValidator{
+							Library: "validator",
+							Image:   "example.com/validator:1.0",
+						},
+					},
+				},
+			},
+		},
+		[]corev1.Volume{
+			{
+				Name: "oauth-validator",
+				VolumeSource: corev1.VolumeSource{
+					Image: &corev1.ImageVolumeSource{
+						Reference:  "example.com/validator:1.0",
+						PullPolicy: corev1.PullIfNotPresent,
+					},
+				},
+			},
+		}),
 )
 
 var _ = Describe("createEphemeralVolume", func() {
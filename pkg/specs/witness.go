@@ -0,0 +1,170 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/hash"
+)
+
+// WitnessContainerName is the name of the container running the witness
+const WitnessContainerName = "witness"
+
+// getWitnessSelector gets the labels selecting the witness Pod of a cluster
+func getWitnessSelector(cluster *apiv1.Cluster) map[string]string {
+	return map[string]string{
+		utils.ClusterLabelName: cluster.Name,
+		utils.PodRoleLabelName: string(utils.PodRoleWitness),
+	}
+}
+
+// BuildWitnessDeployment creates the Deployment running the witness of
+// the cluster, which stores no data and is consulted by the operator
+// before promoting a replica
+func BuildWitnessDeployment(cluster *apiv1.Cluster) (*appsv1.Deployment, error) {
+	config := cluster.Spec.Witness
+
+	witnessHash, err := computeWitnessHash(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	container := corev1.Container{
+		Name:            WitnessContainerName,
+		Image:           configuration.Current.OperatorImageName,
+		ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+		Command:         []string{"/manager", "witness"},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "status",
+				ContainerPort: url.StatusPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			TimeoutSeconds: 5,
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: url.PathHealth,
+					Port: intstr.FromInt32(url.StatusPort),
+				},
+			},
+		},
+		Resources:       config.Resources,
+		SecurityContext: CreateContainerSecurityContext(cluster.GetSeccompProfile()),
+	}
+	addManagerLoggingOptions(*cluster, &container)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.GetWitnessName(),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.PodRoleLabelName: string(utils.PodRoleWitness),
+			},
+			Annotations: map[string]string{
+				utils.CNPGHashAnnotationName: witnessHash,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: getWitnessSelector(cluster),
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: getWitnessSelector(cluster),
+				},
+				Spec: corev1.PodSpec{
+					Containers:                   []corev1.Container{container},
+					NodeSelector:                 config.NodeSelector,
+					Tolerations:                  config.Tolerations,
+					ServiceAccountName:           cluster.Name,
+					AutomountServiceAccountToken: ptr.To(false),
+					SecurityContext: CreatePodSecurityContext(
+						cluster.GetSeccompProfile(),
+						cluster.GetPostgresUID(),
+						cluster.GetPostgresGID(),
+					),
+				},
+			},
+		},
+	}
+
+	cluster.SetInheritedDataAndOwnership(&deployment.ObjectMeta)
+
+	return deployment, nil
+}
+
+// computeWitnessHash computes the hash of the configuration
+// the witness Deployment is generated from
+func computeWitnessHash(cluster *apiv1.Cluster) (string, error) {
+	type witnessHash struct {
+		witness           apiv1.WitnessConfiguration
+		operatorImageName string
+		imagePullPolicy   corev1.PullPolicy
+		logLevel          string
+		seccompProfile    *corev1.SeccompProfile
+	}
+
+	return hash.ComputeHash(witnessHash{
+		witness:           *cluster.Spec.Witness,
+		operatorImageName: configuration.Current.OperatorImageName,
+		imagePullPolicy:   cluster.Spec.ImagePullPolicy,
+		logLevel:          cluster.Spec.LogLevel,
+		seccompProfile:    cluster.GetSeccompProfile(),
+	})
+}
+
+// BuildWitnessService creates the Service the operator
+// uses to reach the witness of the cluster
+func BuildWitnessService(cluster *apiv1.Cluster) *corev1.Service {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.GetWitnessName(),
+			Namespace: cluster.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "status",
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt32(url.StatusPort),
+					Port:       url.StatusPort,
+				},
+			},
+			Selector: getWitnessSelector(cluster),
+		},
+	}
+
+	cluster.SetInheritedDataAndOwnership(&service.ObjectMeta)
+
+	return service
+}
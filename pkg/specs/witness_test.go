@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Witness", func() {
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example",
+			Namespace: "default",
+		},
+		Spec: apiv1.ClusterSpec{
+			Instances: 2,
+			Witness: &apiv1.WitnessConfiguration{
+				Enabled:      true,
+				NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-c"},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+			},
+		},
+	}
+
+	It("runs the witness command in a Deployment", func() {
+		deployment, err := BuildWitnessDeployment(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deployment.Annotations).To(HaveKey(utils.CNPGHashAnnotationName))
+		Expect(deployment.Name).To(Equal("cluster-example-witness"))
+		Expect(deployment.Namespace).To(Equal("default"))
+		Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(1))
+		Expect(deployment.OwnerReferences).To(HaveLen(1))
+
+		podSpec := deployment.Spec.Template.Spec
+		Expect(podSpec.Containers).To(HaveLen(1))
+		Expect(podSpec.Containers[0].Name).To(Equal(WitnessContainerName))
+		Expect(podSpec.Containers[0].Command).To(Equal([]string{"/manager", "witness"}))
+		Expect(podSpec.NodeSelector).To(HaveKeyWithValue("topology.kubernetes.io/zone", "zone-c"))
+		Expect(podSpec.Tolerations).To(HaveLen(1))
+		Expect(podSpec.Volumes).To(BeEmpty())
+		Expect(deployment.Spec.Template.Labels).To(
+			HaveKeyWithValue(utils.PodRoleLabelName, string(utils.PodRoleWitness)))
+	})
+
+	It("exposes the witness with a Service", func() {
+		service := BuildWitnessService(cluster)
+		Expect(service.Name).To(Equal("cluster-example-witness"))
+		Expect(service.Spec.Selector).To(Equal(map[string]string{
+			utils.ClusterLabelName: "cluster-example",
+			utils.PodRoleLabelName: string(utils.PodRoleWitness),
+		}))
+	})
+})
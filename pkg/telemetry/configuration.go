@@ -0,0 +1,74 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	// OperatorServiceName is the default service name of the operator
+	OperatorServiceName = "cnpg-operator"
+
+	// InstanceManagerServiceName is the default service name of
+	// the instance manager
+	InstanceManagerServiceName = "cnpg-instance-manager"
+)
+
+// Configuration contains the signals exported by the process. The
+// exporters, the sampler and the resource attributes are configured by
+// the OpenTelemetry SDK from the standard environment variables
+type Configuration struct {
+	// ExportTraces is true when the spans are exported
+	ExportTraces bool
+
+	// ExportMetrics is true when the duration of the traced
+	// operations is exported
+	ExportMetrics bool
+
+	// ServiceName is the value of the `service.name` resource attribute
+	// when not set with `OTEL_SERVICE_NAME` or `OTEL_RESOURCE_ATTRIBUTES`
+	ServiceName string
+}
+
+// NewConfigurationFromEnv creates the exporter configuration from the
+// standard OpenTelemetry environment variables. Nil is returned when
+// no OTLP endpoint has been set or the SDK has been disabled
+func NewConfigurationFromEnv(defaultServiceName string) *Configuration {
+	return newConfigurationFromEnv(os.Getenv, defaultServiceName)
+}
+
+func newConfigurationFromEnv(getenv func(string) string, defaultServiceName string) *Configuration {
+	if disabled, _ := strconv.ParseBool(getenv("OTEL_SDK_DISABLED")); disabled {
+		return nil
+	}
+
+	endpoint := getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	configuration := &Configuration{
+		ExportTraces: getenv("OTEL_TRACES_EXPORTER") != "none" &&
+			(endpoint != "" || getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""),
+		ExportMetrics: getenv("OTEL_METRICS_EXPORTER") != "none" &&
+			(endpoint != "" || getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT") != ""),
+		ServiceName: defaultServiceName,
+	}
+	if !configuration.ExportTraces && !configuration.ExportMetrics {
+		return nil
+	}
+
+	return configuration
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configuration from the environment", func() {
+	getenvFrom := func(env map[string]string) func(string) string {
+		return func(key string) string {
+			return env[key]
+		}
+	}
+
+	It("is disabled without an endpoint", func() {
+		Expect(newConfigurationFromEnv(getenvFrom(nil), OperatorServiceName)).To(BeNil())
+	})
+
+	It("is disabled when the SDK is disabled", func() {
+		Expect(newConfigurationFromEnv(getenvFrom(map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_SDK_DISABLED":           "true",
+		}), OperatorServiceName)).To(BeNil())
+	})
+
+	It("exports every signal to the base endpoint", func() {
+		Expect(newConfigurationFromEnv(getenvFrom(map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+		}), InstanceManagerServiceName)).To(Equal(&Configuration{
+			ExportTraces:  true,
+			ExportMetrics: true,
+			ServiceName:   InstanceManagerServiceName,
+		}))
+	})
+
+	It("exports only the signals having an endpoint", func() {
+		Expect(newConfigurationFromEnv(getenvFrom(map[string]string{
+			"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4318/v1/traces",
+		}), OperatorServiceName)).To(Equal(&Configuration{
+			ExportTraces: true,
+			ServiceName:  OperatorServiceName,
+		}))
+	})
+
+	It("does not export the signals whose exporter is disabled", func() {
+		Expect(newConfigurationFromEnv(getenvFrom(map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_METRICS_EXPORTER":       "none",
+		}), OperatorServiceName)).To(Equal(&Configuration{
+			ExportTraces: true,
+			ServiceName:  OperatorServiceName,
+		}))
+
+		Expect(newConfigurationFromEnv(getenvFrom(map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_METRICS_EXPORTER":       "none",
+			"OTEL_TRACES_EXPORTER":        "none",
+		}), OperatorServiceName)).To(BeNil())
+	})
+})
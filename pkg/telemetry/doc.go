@@ -0,0 +1,23 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package telemetry integrates the OpenTelemetry SDK in the operator and
+// the instance manager, to trace the reconciliation loops, the promotions,
+// the backups and the probes. The spans and the duration of the traced
+// operations are exported with the OTLP/HTTP exporters, configured through
+// the standard OpenTelemetry environment variables, and the trace context
+// is propagated across HTTP requests with the W3C `traceparent` header
+package telemetry
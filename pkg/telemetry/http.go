@@ -0,0 +1,98 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTransport wraps an HTTP transport, propagating the current span of
+// the context of the outgoing requests to the servers
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &propagatingTransport{base: base}
+}
+
+// propagatingTransport is an HTTP transport adding the `traceparent`
+// header to the outgoing requests
+type propagatingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *propagatingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(request.Context()).IsValid() {
+		return t.base.RoundTrip(request)
+	}
+
+	// A RoundTripper must not modify the passed request
+	request = request.Clone(request.Context())
+	otel.GetTextMapPropagator().Inject(request.Context(), propagation.HeaderCarrier(request.Header))
+	return t.base.RoundTrip(request)
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped
+// transport, if supported
+func (t *propagatingTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// WrapHandler traces the requests served by an HTTP handler with a
+// server span, child of the span of the client, if propagated. The
+// responses with a 5xx status code mark the span as failed
+func WrapHandler(name string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if GetProvider() == nil {
+			handler(w, r)
+			return
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := StartWithKind(ctx, trace.SpanKindServer, name,
+			attribute.String("http.request.method", r.Method),
+			attribute.String("url.path", r.URL.Path),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		handler(recorder, r.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.response.status_code", recorder.statusCode))
+		if recorder.statusCode >= http.StatusInternalServerError {
+			span.RecordError(fmt.Errorf("%d %s", recorder.statusCode, http.StatusText(recorder.statusCode)))
+		}
+	}
+}
+
+// statusRecorder records the status code of an HTTP response
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+// WriteHeader implements the http.ResponseWriter interface
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
+)
+
+const (
+	// instrumentationScope is the name of the instrumentation
+	// producing the telemetry
+	instrumentationScope = "github.com/cloudnative-pg/cloudnative-pg"
+
+	// operationDurationMetric is the name of the histogram of the
+	// duration of the traced operations
+	operationDurationMetric = "cnpg.operation.duration"
+
+	// shutdownTimeout is the time allowed to export the pending
+	// telemetry when the provider is stopped
+	shutdownTimeout = 10 * time.Second
+)
+
+// durationBuckets are the upper bounds, in seconds, of the buckets of
+// the histogram of the duration of the traced operations
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// Provider contains the OpenTelemetry tracer and meter providers
+// exporting the telemetry of the process
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	durations      metric.Float64Histogram
+}
+
+var globalProvider atomic.Pointer[Provider]
+
+// GetProvider gets the provider used to create the spans, which is nil
+// when the telemetry is disabled
+func GetProvider() *Provider {
+	return globalProvider.Load()
+}
+
+// SetProvider sets the provider used to create the spans, registering
+// it as the global OpenTelemetry tracer provider
+func SetProvider(provider *Provider) {
+	globalProvider.Store(provider)
+	if provider == nil {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return
+	}
+
+	otel.SetTracerProvider(provider.tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warning("Cannot export the telemetry", "err", err.Error())
+	}))
+}
+
+// NewProvider creates a provider exporting the signals enabled by
+// the configuration with the OTLP/HTTP exporters
+func NewProvider(ctx context.Context, configuration *Configuration) (*Provider, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", configuration.ServiceName),
+			attribute.String("service.version", versions.Version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerOptions := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if configuration.ExportTraces {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tracerOptions = append(tracerOptions, sdktrace.WithBatcher(exporter))
+	}
+
+	meterOptions := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	if configuration.ExportMetrics {
+		exporter, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		meterOptions = append(meterOptions, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)))
+	}
+
+	return newProvider(sdktrace.NewTracerProvider(tracerOptions...), sdkmetric.NewMeterProvider(meterOptions...))
+}
+
+// newProvider creates a provider given the SDK tracer and meter providers
+func newProvider(tracerProvider *sdktrace.TracerProvider, meterProvider *sdkmetric.MeterProvider) (*Provider, error) {
+	durations, err := meterProvider.
+		Meter(instrumentationScope, metric.WithInstrumentationVersion(versions.Version)).
+		Float64Histogram(
+			operationDurationMetric,
+			metric.WithDescription("Duration of the operations traced by CloudNativePG"),
+			metric.WithUnit("s"),
+			metric.WithExplicitBucketBoundaries(durationBuckets...),
+		)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		tracerProvider: tracerProvider,
+		meterProvider:  meterProvider,
+		durations:      durations,
+	}, nil
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable
+// interface: the telemetry is exported by every operator replica
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// Start waits for the context to be cancelled, then exports the
+// pending telemetry and stops the exporters
+func (p *Provider) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+
+	return errors.Join(
+		p.tracerProvider.Shutdown(shutdownCtx),
+		p.meterProvider.Shutdown(shutdownCtx),
+	)
+}
+
+// recordDuration adds the duration of a traced operation to the histogram
+func (p *Provider) recordDuration(operation string, failed bool, duration time.Duration) {
+	status := "ok"
+	if failed {
+		status = "error"
+	}
+
+	p.durations.Record(context.Background(), duration.Seconds(), metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("status", status),
+	))
+}
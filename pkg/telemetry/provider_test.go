@@ -0,0 +1,132 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// newTestProvider creates a provider collecting the ended spans and
+// the durations in memory, and sets it as the current one
+func newTestProvider() (*Provider, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	metricReader := sdkmetric.NewManualReader()
+
+	provider, err := newProvider(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader)),
+	)
+	Expect(err).ToNot(HaveOccurred())
+
+	SetProvider(provider)
+	DeferCleanup(func() {
+		SetProvider(nil)
+	})
+
+	return provider, spanRecorder, metricReader
+}
+
+// collectDurations gets the data points of the operation duration histogram
+func collectDurations(ctx context.Context, reader *sdkmetric.ManualReader) []metricdata.HistogramDataPoint[float64] {
+	var resourceMetrics metricdata.ResourceMetrics
+	Expect(reader.Collect(ctx, &resourceMetrics)).To(Succeed())
+	Expect(resourceMetrics.ScopeMetrics).To(HaveLen(1))
+
+	metrics := resourceMetrics.ScopeMetrics[0].Metrics
+	Expect(metrics).To(HaveLen(1))
+	Expect(metrics[0].Name).To(Equal(operationDurationMetric))
+	Expect(metrics[0].Unit).To(Equal("s"))
+
+	histogram, ok := metrics[0].Data.(metricdata.Histogram[float64])
+	Expect(ok).To(BeTrue())
+	return histogram.DataPoints
+}
+
+var _ = Describe("provider", func() {
+	It("records the duration of the ended spans", func(ctx context.Context) {
+		_, _, metricReader := newTestProvider()
+
+		ctx, parent := Start(ctx, "cluster.reconcile")
+		_, child := Start(ctx, "cluster.failover")
+		child.RecordError(errors.New("failure"))
+		child.End()
+		child.End()
+		parent.End()
+
+		dataPoints := collectDurations(ctx, metricReader)
+		Expect(dataPoints).To(HaveLen(2))
+		for _, dataPoint := range dataPoints {
+			operation, _ := dataPoint.Attributes.Value("operation")
+			status, _ := dataPoint.Attributes.Value("status")
+			Expect(dataPoint.Count).To(BeEquivalentTo(1))
+			Expect(dataPoint.Bounds).To(Equal(durationBuckets))
+			switch operation.AsString() {
+			case "cluster.failover":
+				Expect(status.AsString()).To(Equal("error"))
+			case "cluster.reconcile":
+				Expect(status.AsString()).To(Equal("ok"))
+			default:
+				Fail("unexpected operation " + operation.AsString())
+			}
+		}
+	})
+
+	It("counts the durations in the right buckets", func(ctx context.Context) {
+		provider, _, metricReader := newTestProvider()
+		provider.recordDuration("instance.probe", false, 5*time.Millisecond)
+		provider.recordDuration("instance.probe", false, 7*time.Millisecond)
+		provider.recordDuration("instance.probe", false, 10*time.Minute)
+
+		dataPoints := collectDurations(ctx, metricReader)
+		Expect(dataPoints).To(HaveLen(1))
+		Expect(dataPoints[0].Attributes).To(Equal(attribute.NewSet(
+			attribute.String("operation", "instance.probe"),
+			attribute.String("status", "ok"),
+		)))
+		Expect(dataPoints[0].Count).To(BeEquivalentTo(3))
+		Expect(dataPoints[0].BucketCounts[0]).To(BeEquivalentTo(1))
+		Expect(dataPoints[0].BucketCounts[1]).To(BeEquivalentTo(1))
+		Expect(dataPoints[0].BucketCounts[len(durationBuckets)]).To(BeEquivalentTo(1))
+	})
+
+	It("shuts down the exporters when stopped", func(ctx context.Context) {
+		provider, spanRecorder, _ := newTestProvider()
+
+		providerCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() {
+			done <- provider.Start(providerCtx)
+		}()
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+
+		_, span := Start(ctx, "cluster.reconcile")
+		span.End()
+		Expect(spanRecorder.Ended()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,110 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
+)
+
+// Span is a traced operation, whose duration is recorded in the
+// operation duration histogram when it ends
+type Span struct {
+	trace.Span
+
+	provider  *Provider
+	name      string
+	startTime time.Time
+	failed    atomic.Bool
+	endOnce   sync.Once
+}
+
+type spanKey struct{}
+
+// SpanFromContext gets the current span of the context. A span not
+// recording anything is returned when there is none
+func SpanFromContext(ctx context.Context) *Span {
+	otelSpan := trace.SpanFromContext(ctx)
+	if span, ok := ctx.Value(spanKey{}).(*Span); ok && span.SpanContext().Equal(otelSpan.SpanContext()) {
+		return span
+	}
+	return &Span{Span: otelSpan}
+}
+
+// Start starts an internal span, child of the current span of the
+// context, if any. The returned context has the new span as the
+// current one
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, *Span) {
+	return StartWithKind(ctx, trace.SpanKindInternal, name, attributes...)
+}
+
+// StartWithKind starts a span of the passed kind, child of the current
+// span of the context, if any
+func StartWithKind(
+	ctx context.Context,
+	kind trace.SpanKind,
+	name string,
+	attributes ...attribute.KeyValue,
+) (context.Context, *Span) {
+	ctx, otelSpan := otel.Tracer(instrumentationScope, trace.WithInstrumentationVersion(versions.Version)).
+		Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+
+	span := &Span{
+		Span:      otelSpan,
+		provider:  GetProvider(),
+		name:      name,
+		startTime: time.Now(),
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// AddEvent records an event on the current span of the context,
+// i.e. a phase change happened during a reconciliation loop
+func AddEvent(ctx context.Context, name string, attributes ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).AddEvent(name, trace.WithAttributes(attributes...))
+}
+
+// RecordError marks the span as failed when the passed error is not nil
+func (s *Span) RecordError(err error, options ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+
+	s.failed.Store(true)
+	s.Span.RecordError(err, options...)
+	s.Span.SetStatus(codes.Error, err.Error())
+}
+
+// End ends the span, recording its duration. Ending a span more
+// than once has no effect
+func (s *Span) End(options ...trace.SpanEndOption) {
+	s.endOnce.Do(func() {
+		if s.provider != nil {
+			s.provider.recordDuration(s.name, s.failed.Load(), time.Since(s.startTime))
+		}
+		s.Span.End(options...)
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("spans", func() {
+	It("does nothing when the telemetry is disabled", func(ctx context.Context) {
+		SetProvider(nil)
+
+		ctx, span := Start(ctx, "cluster.reconcile")
+		Expect(span.IsRecording()).To(BeFalse())
+		Expect(SpanFromContext(ctx)).To(BeIdenticalTo(span))
+		span.SetAttributes(attribute.String("cluster", "cluster-example"))
+		span.RecordError(errors.New("failure"))
+		span.End()
+	})
+
+	It("creates child spans in the same trace", func(ctx context.Context) {
+		_, spanRecorder, _ := newTestProvider()
+
+		ctx, parent := Start(ctx, "cluster.reconcile", attribute.String("cnpg.cluster.name", "cluster-example"))
+		childCtx, child := Start(ctx, "cluster.failover")
+		AddEvent(ctx, "cluster.phase", attribute.String("cnpg.phase", "Failing over"))
+		SpanFromContext(childCtx).RecordError(errors.New("failure"))
+		child.End()
+		parent.End()
+
+		ended := spanRecorder.Ended()
+		Expect(ended).To(HaveLen(2))
+		endedChild, endedParent := ended[0], ended[1]
+
+		Expect(endedChild.Name()).To(Equal("cluster.failover"))
+		Expect(endedChild.Parent().SpanID()).To(Equal(endedParent.SpanContext().SpanID()))
+		Expect(endedChild.SpanContext().TraceID()).To(Equal(endedParent.SpanContext().TraceID()))
+		Expect(endedChild.Status().Code).To(Equal(codes.Error))
+		Expect(endedChild.Status().Description).To(Equal("failure"))
+
+		Expect(endedParent.Parent().IsValid()).To(BeFalse())
+		Expect(endedParent.Status().Code).To(Equal(codes.Unset))
+		Expect(endedParent.Attributes()).To(ConsistOf(attribute.String("cnpg.cluster.name", "cluster-example")))
+		Expect(endedParent.Events()).To(HaveLen(1))
+		Expect(endedParent.Events()[0].Name).To(Equal("cluster.phase"))
+	})
+
+	It("follows the sampling decision of the parent", func(ctx context.Context) {
+		_, spanRecorder, _ := newTestProvider()
+
+		remote := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+			Remote:  true,
+		})
+		_, span := Start(trace.ContextWithRemoteSpanContext(ctx, remote), "instance.probe")
+		span.End()
+
+		Expect(span.SpanContext().TraceID()).To(Equal(remote.TraceID()))
+		Expect(span.SpanContext().IsSampled()).To(BeFalse())
+		Expect(spanRecorder.Ended()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("trace context propagation", func() {
+	It("propagates the current span to the server", func(ctx context.Context) {
+		_, spanRecorder, _ := newTestProvider()
+
+		serverSpans := make(chan trace.SpanContext, 1)
+		server := httptest.NewServer(WrapHandler("instance.status", func(w http.ResponseWriter, r *http.Request) {
+			serverSpans <- trace.SpanContextFromContext(r.Context())
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		ctx, clientSpan := Start(ctx, "cluster.reconcile")
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		httpClient := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+		response, err := httpClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Body.Close()).To(Succeed())
+		Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(request.Header.Get("traceparent")).To(BeEmpty())
+
+		var serverSpan trace.SpanContext
+		Expect(serverSpans).To(Receive(&serverSpan))
+		Expect(serverSpan.TraceID()).To(Equal(clientSpan.SpanContext().TraceID()))
+		Expect(serverSpan.SpanID()).ToNot(Equal(clientSpan.SpanContext().SpanID()))
+
+		Eventually(spanRecorder.Ended).Should(HaveLen(1))
+		endedServer := spanRecorder.Ended()[0]
+		Expect(endedServer.SpanKind()).To(Equal(trace.SpanKindServer))
+		Expect(endedServer.Parent().SpanID()).To(Equal(clientSpan.SpanContext().SpanID()))
+		Expect(endedServer.Status().Code).To(Equal(codes.Error))
+		Expect(endedServer.Attributes()).To(ContainElement(
+			attribute.Int("http.response.status_code", http.StatusInternalServerError)))
+	})
+})
@@ -27,3 +27,7 @@ const PluginClientKey contextKey = "pluginClient"
 
 // GRPCTimeoutKey is the context key holding the gRPC timeout
 const GRPCTimeoutKey contextKey = "grpcTimeout"
+
+// DriftReportKey is the context key holding the manual changes detected
+// during a reconciliation loop
+const DriftReportKey contextKey = "driftReport"
@@ -182,6 +182,16 @@ func PodMonitorExist(client discovery.DiscoveryInterface) (bool, error) {
 	return exist, nil
 }
 
+// PrometheusRuleExist tries to find the PrometheusRule resource in the current cluster
+func PrometheusRuleExist(client discovery.DiscoveryInterface) (bool, error) {
+	return resourceExist(client, "monitoring.coreos.com/v1", "prometheusrules")
+}
+
+// VerticalPodAutoscalerExist tries to find the VerticalPodAutoscaler resource in the current cluster
+func VerticalPodAutoscalerExist(client discovery.DiscoveryInterface) (bool, error) {
+	return resourceExist(client, "autoscaling.k8s.io/v1", "verticalpodautoscalers")
+}
+
 // extractK8sMinorVersion extracts and parses the Kubernetes minor version from
 // the version info that's been  detected by discovery client
 func extractK8sMinorVersion(info *version.Info) (int, error) {
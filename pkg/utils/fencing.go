@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
 	corev1 "k8s.io/api/core/v1"
@@ -136,12 +137,141 @@ func removeFencedInstance(instanceName string, object metav1.Object) (bool, erro
 	return true, setFencedInstances(object, fencedInstances)
 }
 
+// GetFencingExpirations gets the time after which the fencing of each
+// instance is automatically lifted
+func GetFencingExpirations(annotations map[string]string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	fencingExpirations, ok := annotations[FencingExpirationAnnotation]
+	if !ok {
+		return result, nil
+	}
+
+	if err := json.Unmarshal([]byte(fencingExpirations), &result); err != nil {
+		return nil, fmt.Errorf("fencingExpiration annotation has invalid syntax: %w", err)
+	}
+
+	return result, nil
+}
+
+// setFencingExpirations sets the fencing expiration times inside the annotations
+func setFencingExpirations(object metav1.Object, expirations map[string]time.Time) error {
+	annotations := object.GetAnnotations()
+	defer func() {
+		object.SetAnnotations(annotations)
+	}()
+	if len(expirations) == 0 {
+		delete(annotations, FencingExpirationAnnotation)
+		return nil
+	}
+
+	annotationValue, err := json.Marshal(expirations)
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[FencingExpirationAnnotation] = string(annotationValue)
+
+	return nil
+}
+
+// updateFencingExpiration sets the fencing expiration time of an instance,
+// removing it when the expiration time is zero
+func updateFencingExpiration(instanceName string, expiration time.Time, object metav1.Object) (bool, error) {
+	expirations, err := GetFencingExpirations(object.GetAnnotations())
+	if err != nil {
+		return false, err
+	}
+
+	current, found := expirations[instanceName]
+	switch {
+	case instanceName == FenceAllInstances && expiration.IsZero():
+		// Lifting the fence from the whole cluster clears every expiration
+		if len(expirations) == 0 {
+			return false, nil
+		}
+		expirations = nil
+	case instanceName == FenceAllInstances:
+		// Fencing the whole cluster supersedes the expiration of every instance
+		expiration = expiration.UTC().Truncate(time.Second)
+		if len(expirations) == 1 && found && current.Equal(expiration) {
+			return false, nil
+		}
+		expirations = map[string]time.Time{FenceAllInstances: expiration}
+	case expiration.IsZero():
+		if !found {
+			return false, nil
+		}
+		delete(expirations, instanceName)
+	default:
+		expiration = expiration.UTC().Truncate(time.Second)
+		if found && current.Equal(expiration) {
+			return false, nil
+		}
+		expirations[instanceName] = expiration
+	}
+
+	return true, setFencingExpirations(object, expirations)
+}
+
+// RemoveExpiredFencing lifts the fencing of the instances whose fencing
+// expiration time has passed, returning their names
+func RemoveExpiredFencing(object metav1.Object, now time.Time) ([]string, error) {
+	expirations, err := GetFencingExpirations(object.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []string
+	for name, expiration := range expirations {
+		if !expiration.After(now) {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired)
+
+	for _, name := range expired {
+		// An instance cannot be unfenced while the whole cluster is
+		// fenced, so only its expiration time is dropped
+		if _, err := removeFencedInstance(name, object); err != nil &&
+			!errors.Is(err, ErrorSingleInstanceUnfencing) {
+			return nil, err
+		}
+		if _, err := updateFencingExpiration(name, time.Time{}, object); err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
+
+// GetNextFencingExpiration gets the first time when the fencing of
+// an instance is going to be automatically lifted
+func GetNextFencingExpiration(annotations map[string]string) (time.Time, bool) {
+	expirations, err := GetFencingExpirations(annotations)
+	if err != nil || len(expirations) == 0 {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	for _, expiration := range expirations {
+		if next.IsZero() || expiration.Before(next) {
+			next = expiration
+		}
+	}
+
+	return next, true
+}
+
 // FencingMetadataExecutor executes the logic regarding adding and removing the fencing annotation for a kubernetes
 // object
 type FencingMetadataExecutor struct {
 	fenceFunc     func(string, metav1.Object) (appliedChange bool, err error)
 	cli           client.Client
 	instanceNames []string
+	addingFencing bool
+	expiration    time.Time
 }
 
 // NewFencingMetadataExecutor creates a fluent client for FencingMetadataExecutor
@@ -154,12 +284,21 @@ func NewFencingMetadataExecutor(cli client.Client) *FencingMetadataExecutor {
 // AddFencing instructs the client to execute the logic of adding a instance
 func (fb *FencingMetadataExecutor) AddFencing() *FencingMetadataExecutor {
 	fb.fenceFunc = AddFencedInstance
+	fb.addingFencing = true
 	return fb
 }
 
 // RemoveFencing instructs the client to execute the logic of removing an instance
 func (fb *FencingMetadataExecutor) RemoveFencing() *FencingMetadataExecutor {
 	fb.fenceFunc = removeFencedInstance
+	fb.addingFencing = false
+	return fb
+}
+
+// WithExpiration sets the time after which the fencing is automatically
+// lifted by the operator. It only applies when adding the fencing
+func (fb *FencingMetadataExecutor) WithExpiration(expiration time.Time) *FencingMetadataExecutor {
+	fb.expiration = expiration
 	return fb
 }
 
@@ -197,6 +336,13 @@ func (fb *FencingMetadataExecutor) Execute(ctx context.Context, key types.Namesp
 		}
 	}
 
+	// Fencing an instance without an expiration time, or lifting its
+	// fencing, removes any previously set expiration time
+	expiration := fb.expiration
+	if !fb.addingFencing {
+		expiration = time.Time{}
+	}
+
 	var appliedChange bool
 	fencedObject := obj.DeepCopyObject().(client.Object)
 	for _, name := range fb.instanceNames {
@@ -204,7 +350,11 @@ func (fb *FencingMetadataExecutor) Execute(ctx context.Context, key types.Namesp
 		if err != nil {
 			return err
 		}
-		appliedChange = appliedChange || changed
+		expirationChanged, err := updateFencingExpiration(name, expiration, fencedObject)
+		if err != nil {
+			return err
+		}
+		appliedChange = appliedChange || changed || expirationChanged
 	}
 	if !appliedChange {
 		return nil
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"encoding/json"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -119,3 +120,61 @@ var _ = Describe("Fencing annotation handling", func() {
 		})
 	})
 })
+
+var _ = Describe("Fencing expiration handling", func() {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	It("should record and clear the expiration of an instance", func() {
+		clusterMeta := metav1.ObjectMeta{}
+		modified, err := updateFencingExpiration("cluster-example-1", now.Add(time.Hour), &clusterMeta)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modified).To(BeTrue())
+		Expect(clusterMeta.Annotations).To(HaveKeyWithValue(FencingExpirationAnnotation,
+			`{"cluster-example-1":"2024-01-01T11:00:00Z"}`))
+
+		modified, err = updateFencingExpiration("cluster-example-1", time.Time{}, &clusterMeta)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modified).To(BeTrue())
+		Expect(clusterMeta.Annotations).NotTo(HaveKey(FencingExpirationAnnotation))
+	})
+
+	It("should lift only the expired fencing", func() {
+		clusterMeta := metav1.ObjectMeta{
+			Annotations: map[string]string{
+				FencedInstanceAnnotation: `["cluster-example-1","cluster-example-2","cluster-example-3"]`,
+				FencingExpirationAnnotation: `{"cluster-example-1":"2024-01-01T09:00:00Z",` +
+					`"cluster-example-2":"2024-01-01T11:00:00Z"}`,
+			},
+		}
+		expired, err := RemoveExpiredFencing(&clusterMeta, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(ConsistOf("cluster-example-1"))
+		Expect(clusterMeta.Annotations).To(HaveKeyWithValue(FencedInstanceAnnotation,
+			`["cluster-example-2","cluster-example-3"]`))
+		Expect(clusterMeta.Annotations).To(HaveKeyWithValue(FencingExpirationAnnotation,
+			`{"cluster-example-2":"2024-01-01T11:00:00Z"}`))
+
+		next, found := GetNextFencingExpiration(clusterMeta.Annotations)
+		Expect(found).To(BeTrue())
+		Expect(next).To(BeTemporally("==", now.Add(time.Hour)))
+	})
+
+	It("should drop the expiration of an instance while the whole cluster is fenced", func() {
+		clusterMeta := metav1.ObjectMeta{
+			Annotations: map[string]string{
+				FencedInstanceAnnotation:    `["*"]`,
+				FencingExpirationAnnotation: `{"cluster-example-1":"2024-01-01T09:00:00Z"}`,
+			},
+		}
+		expired, err := RemoveExpiredFencing(&clusterMeta, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(ConsistOf("cluster-example-1"))
+		Expect(clusterMeta.Annotations).To(HaveKeyWithValue(FencedInstanceAnnotation, `["*"]`))
+		Expect(clusterMeta.Annotations).NotTo(HaveKey(FencingExpirationAnnotation))
+	})
+
+	It("should not find any expiration when the annotation is missing", func() {
+		_, found := GetNextFencingExpiration(nil)
+		Expect(found).To(BeFalse())
+	})
+})
@@ -38,6 +38,10 @@ const (
 	// the value could be import, initdb, join
 	JobRoleLabelName = MetadataNamespace + "/jobRole"
 
+	// LifecycleHookLabelName is the name of the label containing the name
+	// of the lifecycle hook run by a job
+	LifecycleHookLabelName = MetadataNamespace + "/lifecycleHook"
+
 	// PvcRoleLabelName is the name of the label containing the purpose of the pvc
 	PvcRoleLabelName = MetadataNamespace + "/pvcRole"
 
@@ -63,6 +67,11 @@ const (
 	// ClusterInstanceRoleLabelName is the name of label applied to instances to mark primary/replica
 	ClusterInstanceRoleLabelName = MetadataNamespace + "/instanceRole"
 
+	// ReplicaLaggingLabelName is the name of the label applied to instances
+	// to mark the replicas exceeding the maximum replication lag of the
+	// read and read-only services
+	ReplicaLaggingLabelName = MetadataNamespace + "/replicaLagging"
+
 	// ImmediateBackupLabelName is the name of the label applied to backups to tell if the first scheduled backup is
 	// taken immediately or not
 	ImmediateBackupLabelName = MetadataNamespace + "/immediateBackup"
@@ -100,6 +109,15 @@ const (
 	// PluginNameLabelName is the name of the label to be applied to services
 	// to have them detected as CNPG-i plugins
 	PluginNameLabelName = MetadataNamespace + "/pluginName"
+
+	// LogicalUpgradeSourceLabelName is the name of the label applied to the
+	// target cluster of a logical major version upgrade, containing the name
+	// of the cluster being upgraded
+	LogicalUpgradeSourceLabelName = MetadataNamespace + "/logicalUpgradeSource"
+
+	// BenchmarkLabelName is the name of the label applied to the ConfigMaps
+	// containing the results of a benchmark, containing the benchmarking tool
+	BenchmarkLabelName = MetadataNamespace + "/benchmark"
 )
 
 const (
@@ -118,6 +136,14 @@ const (
 	// ReconcilePodSpecAnnotationName is the name of the annotation that prevents the pod spec to be reconciled
 	ReconcilePodSpecAnnotationName = MetadataNamespace + "/reconcilePodSpec"
 
+	// ReconcileMinBackoffAnnotationName is the name of the annotation overriding,
+	// for a cluster, the delay before retrying its first failed reconciliation
+	ReconcileMinBackoffAnnotationName = MetadataNamespace + "/reconcileMinBackoff"
+
+	// ReconcileMaxBackoffAnnotationName is the name of the annotation overriding,
+	// for a cluster, the maximum delay before retrying its failed reconciliations
+	ReconcileMaxBackoffAnnotationName = MetadataNamespace + "/reconcileMaxBackoff"
+
 	// HibernateClusterManifestAnnotationName contains the hibernated cluster manifest
 	// Deprecated. Replaced by: ClusterManifestAnnotationName. This annotation is
 	// kept for backward compatibility
@@ -160,6 +186,16 @@ const (
 	// latest reload time trigger by external
 	ClusterReloadAnnotationName = MetadataNamespace + "/reloadedAt"
 
+	// PodSpecPreviewAnnotationName is the name of the annotation requesting
+	// the operator to preview the instance pod spec, after the mutations of
+	// the plugins. It contains the time of the request
+	PodSpecPreviewAnnotationName = MetadataNamespace + "/podSpecPreview"
+
+	// LifecycleEventAnnotationName is the name of the annotation containing
+	// the JSON representation of a lifecycle event, set on the Kubernetes
+	// events notified to the plugins
+	LifecycleEventAnnotationName = MetadataNamespace + "/lifecycleEvent"
+
 	// PVCStatusAnnotationName is the name of the annotation that shows the current status of the PVC.
 	// The status can be "initializing", "ready" or "detached"
 	PVCStatusAnnotationName = MetadataNamespace + "/pvcStatus"
@@ -172,10 +208,50 @@ const (
 	// PostgreSQL cluster
 	HibernationAnnotationName = MetadataNamespace + "/hibernation"
 
+	// ConfirmMajorUpgradeAnnotationName is the name of the annotation used to confirm
+	// a major version upgrade of a PostgreSQL cluster, allowing the instance manager
+	// to remove the data directory of the previous major version
+	ConfirmMajorUpgradeAnnotationName = MetadataNamespace + "/confirmMajorUpgrade"
+
+	// LogicalUpgradeCutoverAnnotationName is the name of the annotation set by
+	// the operator on the target cluster of a logical major version upgrade
+	// to request the cutover from the source cluster
+	LogicalUpgradeCutoverAnnotationName = MetadataNamespace + "/logicalUpgradeCutover"
+
+	// MigrationCutoverAnnotationName is the name of the annotation to be set
+	// on a cluster bootstrapped with a migration to request the cutover
+	// from the external cluster
+	MigrationCutoverAnnotationName = MetadataNamespace + "/migrationCutover"
+
+	// FailoverApprovalAnnotationName is the name of the annotation containing
+	// the name of the primary instance the user approved the replacement of,
+	// when the failover policy requires an approval
+	FailoverApprovalAnnotationName = MetadataNamespace + "/approveFailover"
+
+	// ExternalDNSHostnameAnnotationName is the name of the annotation used
+	// by ExternalDNS to publish the hostname of a service
+	ExternalDNSHostnameAnnotationName = "external-dns.alpha.kubernetes.io/hostname"
+
+	// PoolerSwitchoverPauseAnnotationName is the name of the annotation set by
+	// the operator on the poolers that need to be paused before a switchover,
+	// containing the name of the instance that will be promoted
+	PoolerSwitchoverPauseAnnotationName = MetadataNamespace + "/switchoverPause"
+
+	// PoolerCertificateReloadAnnotationName is the name of the annotation set by
+	// the operator on the poolers that need to reload the TLS certificates
+	// during a certificate rotation, containing the fingerprint of the
+	// certificates being rotated
+	PoolerCertificateReloadAnnotationName = MetadataNamespace + "/certificateReload"
+
 	// PoolerSpecHashAnnotationName is the name of the annotation added to the deployment to tell
 	// the hash of the Pooler Specification
 	PoolerSpecHashAnnotationName = MetadataNamespace + "/poolerSpecHash"
 
+	// CertificateProviderHashAnnotationName is the name of the annotation set on
+	// the certificates issued by an external provider, containing the hash of the
+	// provider configuration. It is used to reissue them when the provider changes
+	CertificateProviderHashAnnotationName = MetadataNamespace + "/certificateProviderHash"
+
 	// OperatorManagedSecretsAnnotationName is the name of the annotation containing
 	// the secrets managed by the operator inside the generated service account
 	OperatorManagedSecretsAnnotationName = MetadataNamespace + "/managedSecrets"
@@ -185,6 +261,11 @@ const (
 	// If the list contain the "*" element, every node is fenced.
 	FencedInstanceAnnotation = MetadataNamespace + "/fencedInstances"
 
+	// FencingExpirationAnnotation is the annotation containing the time after which the fencing of
+	// an instance is automatically lifted. The value is a JSON object mapping the fenced instances
+	// to an RFC 3339 timestamp, e.g. `{"cluster-example-1":"2024-01-01T10:00:00Z"}`
+	FencingExpirationAnnotation = MetadataNamespace + "/fencingExpiration"
+
 	// CNPGHashAnnotationName is the name of the annotation containing the hash of the resource used by operator
 	// expect the pooler that uses PoolerSpecHashAnnotationName
 	CNPGHashAnnotationName = MetadataNamespace + "/hash"
@@ -233,6 +314,10 @@ const (
 	// PluginPortAnnotationName is the name of the annotation containing the
 	// port the plugin is listening to
 	PluginPortAnnotationName = MetadataNamespace + "/pluginPort"
+
+	// PromotionCheckAnnotationName is the name of the annotation where the
+	// WAL position verified before promoting a replica cluster is kept
+	PromotionCheckAnnotationName = MetadataNamespace + "/promotionCheck"
 )
 
 type annotationStatus string
@@ -250,6 +335,8 @@ const (
 	PodRoleInstance PodRole = "instance"
 	// PodRolePooler the label value indicating a pooler instance
 	PodRolePooler PodRole = "pooler"
+	// PodRoleWitness the label value indicating the witness of a cluster
+	PodRoleWitness PodRole = "witness"
 )
 
 // PVCRole describes the role of a PVC
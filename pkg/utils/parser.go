@@ -54,6 +54,22 @@ const (
 	// PgControlDataDatabaseClusterStateKey is the status
 	// of the latest primary that run on this data directory.
 	PgControlDataDatabaseClusterStateKey pgControlDataKey = "Database cluster state"
+
+	// PgControlDataKeyDataPageChecksumVersion is the data page
+	// checksum version pg_controldata entry, zero when disabled
+	PgControlDataKeyDataPageChecksumVersion pgControlDataKey = "Data page checksum version"
+
+	// PgControlDataKeyWalLogHintsSetting is the wal_log_hints
+	// setting pg_controldata entry
+	PgControlDataKeyWalLogHintsSetting pgControlDataKey = "wal_log_hints setting"
+
+	// PgControlDataKeyCatalogVersionNumber is the catalog
+	// version number pg_controldata entry
+	PgControlDataKeyCatalogVersionNumber pgControlDataKey = "Catalog version number"
+
+	// PgControlDataKeyBytesPerWALSegment is the WAL segment
+	// size pg_controldata entry
+	PgControlDataKeyBytesPerWALSegment pgControlDataKey = "Bytes per WAL segment"
 )
 
 // PgDataState represents the "Database cluster state" field of pg_controldata
@@ -0,0 +1,91 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// requestTimeout is the timeout of the requests to the Vault server
+const requestTimeout = 30 * time.Second
+
+// ErrInvalidCA is returned when the CA certificate of the
+// Vault server cannot be parsed
+var ErrInvalidCA = errors.New("invalid Vault CA certificate")
+
+// Client invokes the HTTP API of a Vault server, authenticating
+// the requests with a token
+type Client struct {
+	client *api.Client
+}
+
+// NewClient creates a new Vault client given the URL of the server, the
+// token used to authenticate the requests and the PEM encoded CA
+// certificate of the server, which is optional
+func NewClient(address, token string, ca []byte) (*Client, error) {
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, config.Error
+	}
+	config.Address = address
+	config.Timeout = requestTimeout
+
+	if len(ca) > 0 {
+		if !x509.NewCertPool().AppendCertsFromPEM(ca) {
+			return nil, ErrInvalidCA
+		}
+		if err := config.ConfigureTLS(&api.TLSConfig{CACertBytes: ca}); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCA, err)
+		}
+	}
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(token)
+
+	return &Client{client: client}, nil
+}
+
+// Write sends the request to the passed path of the API, relative to
+// "/v1", decoding the data of the response into the passed value.
+// The errors reported by Vault are returned as *api.ResponseError
+func (c *Client) Write(ctx context.Context, path string, request map[string]any, data any) error {
+	path = strings.Trim(path, "/")
+	secret, err := c.client.Logical().WriteWithContext(ctx, path, request)
+	if err != nil {
+		return err
+	}
+	if secret == nil || len(secret.Data) == 0 {
+		return fmt.Errorf("the Vault response of %s contains no data", path)
+	}
+
+	content, err := json.Marshal(secret.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, data)
+}
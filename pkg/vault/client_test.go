@@ -0,0 +1,93 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hashicorp/vault/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Vault client", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+
+			switch r.URL.Path {
+			case "/v1/secret/echo":
+				var request map[string]any
+				Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": request})
+			case "/v1/secret/empty":
+				_, _ = w.Write([]byte(`{}`))
+			default:
+				_, _ = w.Write([]byte(`not json`))
+			}
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	newClient := func(token string) *Client {
+		client, err := NewClient(server.URL, token, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return client
+	}
+
+	It("sends the requests and decodes the data of the responses", func(ctx context.Context) {
+		var data map[string]string
+		Expect(newClient("token").Write(ctx, "/secret/echo/", map[string]any{"key": "value"}, &data)).
+			To(Succeed())
+		Expect(data).To(HaveKeyWithValue("key", "value"))
+	})
+
+	It("reports the errors returned by Vault", func(ctx context.Context) {
+		var data map[string]string
+		err := newClient("wrong").Write(ctx, "secret/echo", nil, &data)
+
+		var responseError *api.ResponseError
+		Expect(errors.As(err, &responseError)).To(BeTrue())
+		Expect(responseError.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(err).To(MatchError(ContainSubstring("permission denied")))
+	})
+
+	It("rejects the responses without data", func(ctx context.Context) {
+		var data map[string]string
+		Expect(newClient("token").Write(ctx, "secret/empty", nil, &data)).ToNot(Succeed())
+	})
+
+	It("rejects the responses that cannot be decoded", func(ctx context.Context) {
+		var data map[string]string
+		Expect(newClient("token").Write(ctx, "secret/other", nil, &data)).ToNot(Succeed())
+	})
+
+	It("rejects an invalid CA certificate", func() {
+		_, err := NewClient(server.URL, "token", []byte("invalid"))
+		Expect(err).To(MatchError(ErrInvalidCA))
+	})
+})